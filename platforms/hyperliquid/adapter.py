@@ -95,6 +95,22 @@ except ImportError:
     class _HLClientError(Exception):
         pass
 
+try:
+    from hyperliquid.utils.types import Cloid as _HLCloid
+except ImportError:
+    _HLCloid = None  # client order IDs (#4916) are skipped when unavailable
+
+
+def _to_cloid(cloid: str):
+    """Convert a 0x-prefixed 16-byte hex client order ID to the SDK type.
+
+    Returns None for an empty ID or when the SDK lacks Cloid, so the order is
+    placed without one rather than failing.
+    """
+    if not cloid or _HLCloid is None:
+        return None
+    return _HLCloid.from_str(cloid)
+
 
 def _safe_float(v) -> float:
     if v is None:
@@ -797,10 +813,12 @@ class HyperliquidExchangeAdapter:
     # Order execution (live mode only)
     # ─────────────────────────────────────────────
 
-    def market_open(self, symbol: str, is_buy: bool, size: float) -> dict:
+    def market_open(self, symbol: str, is_buy: bool, size: float, cloid: str = "") -> dict:
         """
         Place a market order to open/add to a position.
         Only available in live mode; raises RuntimeError in paper mode.
+        ``cloid`` tags the order with the scheduler's order-intent key so it
+        can be looked up after a crash (#4916).
         Returns raw SDK response dict.
         """
         exchange = self._require_exchange("market_open")
@@ -809,6 +827,9 @@ class HyperliquidExchangeAdapter:
         size = round(size, sz_decimals)
         if size <= 0:
             raise ValueError(f"Size rounded to zero for {symbol} (sz_decimals={sz_decimals})")
+        hl_cloid = _to_cloid(cloid)
+        if hl_cloid is not None:
+            return exchange.market_open(symbol, is_buy, size, None, 0.01, cloid=hl_cloid)
         return exchange.market_open(symbol, is_buy, size, None, 0.01)
 
     def limit_open(
@@ -849,7 +870,7 @@ class HyperliquidExchangeAdapter:
             symbol, is_buy, size, limit_px, order_type, reduce_only=False
         )
 
-    def market_close(self, symbol: str, sz: float | None = None) -> dict:
+    def market_close(self, symbol: str, sz: float | None = None, cloid: str = "") -> dict:
         """
         Close an open perp position for a symbol (reduce-only).
 
@@ -858,6 +879,9 @@ class HyperliquidExchangeAdapter:
         quantity only — used for shared-wallet per-strategy circuit breakers
         (#356).
 
+        ``cloid`` is the scheduler's order-intent key (#4916), as in
+        ``market_open``.
+
         Only available in live mode; raises RuntimeError in paper mode.
         Returns raw SDK response dict.
         """
@@ -868,8 +892,31 @@ class HyperliquidExchangeAdapter:
             sz = round(sz, sz_decimals)
             if sz <= 0:
                 raise ValueError(f"Size rounded to zero for {symbol} (sz_decimals={sz_decimals})")
+        hl_cloid = _to_cloid(cloid)
+        if hl_cloid is not None:
+            return exchange.market_close(symbol, sz, cloid=hl_cloid)
         return exchange.market_close(symbol, sz)
 
+    def query_order_by_cloid(self, cloid: str) -> dict:
+        """Look up an order by the client order ID it was placed with (#4916).
+
+        Returns ``{"status": "not_found"}`` when HL has no such order, else
+        ``{"status": <HL order status, e.g. "filled"/"open"/"canceled">,
+        "oid": int}``. Raises when the lookup itself fails so the caller can
+        keep the intent unresolved rather than treat it as never placed.
+        """
+        if not self._account_address:
+            raise RuntimeError("query_order_by_cloid requires an account address")
+        hl_cloid = _to_cloid(cloid)
+        if hl_cloid is None:
+            raise RuntimeError("client order IDs unsupported by the installed hyperliquid SDK")
+        resp = self._info.query_order_by_cloid(self._account_address, hl_cloid)
+        if not isinstance(resp, dict) or resp.get("status") != "order":
+            return {"status": "not_found"}
+        wrapper = resp.get("order") or {}
+        order = wrapper.get("order") or {}
+        return {"status": str(wrapper.get("status", "")), "oid": _safe_int(order.get("oid"))}
+
     def lookup_fill_fee_by_oid(
         self,
         oid: int,
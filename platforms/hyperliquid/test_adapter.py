@@ -393,6 +393,41 @@ class TestOrderExecution:
         assert result == {"status": "ok"}
         mock_exchange.market_open.assert_called_once_with("BTC", True, 0.5, None, 0.01)
 
+    def test_market_open_with_cloid(self):
+        mock_info = MagicMock()
+        mock_info.asset_to_sz_decimals = {"BTC": 4}
+        mock_info_cls = MagicMock(return_value=mock_info)
+        mock_exchange = MagicMock()
+        mock_exchange.market_open.return_value = {"status": "ok"}
+        mod = _load_hl_adapter(mock_info_cls=mock_info_cls)
+        mod._HLCloid = MagicMock()
+        mod._HLCloid.from_str.side_effect = lambda raw: ("cloid", raw)
+        adapter = mod.HyperliquidExchangeAdapter()
+        adapter._wallet = MagicMock()
+        adapter._exchange = mock_exchange
+        adapter._info = mock_info
+
+        adapter.market_open("BTC", True, 0.5, cloid="0x" + "ab" * 16)
+        mock_exchange.market_open.assert_called_once_with(
+            "BTC", True, 0.5, None, 0.01, cloid=("cloid", "0x" + "ab" * 16))
+
+    def test_query_order_by_cloid(self):
+        mock_info = MagicMock()
+        mock_info_cls = MagicMock(return_value=mock_info)
+        mod = _load_hl_adapter(mock_info_cls=mock_info_cls)
+        mod._HLCloid = MagicMock()
+        adapter = mod.HyperliquidExchangeAdapter()
+        adapter._info = mock_info
+        adapter._account_address = "0xabc"
+
+        mock_info.query_order_by_cloid.return_value = {
+            "status": "order",
+            "order": {"order": {"oid": 77}, "status": "filled"},
+        }
+        assert adapter.query_order_by_cloid("0x" + "ab" * 16) == {"status": "filled", "oid": 77}
+        mock_info.query_order_by_cloid.return_value = {"status": "unknownOid"}
+        assert adapter.query_order_by_cloid("0x" + "ab" * 16) == {"status": "not_found"}
+
     def test_market_open_size_rounded_to_zero_raises(self):
         mock_info = MagicMock()
         mock_info.asset_to_sz_decimals = {"BTC": 0}
@@ -223,11 +223,13 @@ class OKXExchangeAdapter:
             )
         return self._exchange.fetch_positions() or []
 
-    def market_open(self, symbol: str, is_buy: bool, size: float, inst_type: str = "spot") -> dict:
+    def market_open(self, symbol: str, is_buy: bool, size: float, inst_type: str = "spot", client_order_id: str = "") -> dict:
         """
         Place a market order.
 
         inst_type: "spot" for spot trading, "swap" for perpetual swap.
+        client_order_id: sent as OKX clOrdId — the scheduler's order-intent
+        key, so the order can be looked up after a crash (#4916).
         Only available in live mode; raises RuntimeError in paper mode.
         """
         if not self._is_live:
@@ -241,8 +243,34 @@ class OKXExchangeAdapter:
         else:
             pair = f"{symbol}/USDT"
             params = {"tdMode": "cash"}
+        if client_order_id:
+            params["clOrdId"] = client_order_id
         return self._exchange.create_market_order(pair, side, size, params=params)
 
+    def fetch_order_by_client_id(self, symbol: str, client_order_id: str, inst_type: str = "spot") -> dict:
+        """Look up an order by the clOrdId it was placed with (#4916).
+
+        Returns ``{"status": "not_found"}`` when OKX has no such order, else
+        the ccxt order status ("closed"/"open"/"canceled") with ``oid``,
+        ``filled`` and ``average``. Other failures raise so the caller keeps
+        the intent unresolved rather than treat it as never placed.
+        """
+        if not self._is_live:
+            raise RuntimeError(
+                "fetch_order_by_client_id requires live mode (set OKX_API_KEY, OKX_API_SECRET, OKX_PASSPHRASE)"
+            )
+        pair = f"{symbol}/USDT:USDT" if inst_type == "swap" else f"{symbol}/USDT"
+        try:
+            order = self._exchange.fetch_order(None, pair, params={"clOrdId": client_order_id})
+        except ccxt.OrderNotFound:
+            return {"status": "not_found"}
+        return {
+            "status": str(order.get("status") or ""),
+            "oid": str(order.get("id") or ""),
+            "filled": float(order.get("filled", 0) or 0),
+            "average": float(order.get("average", 0) or 0),
+        }
+
     def market_close(self, symbol: str, sz: float | None = None) -> dict:
         """
         Close an open perpetual swap position for a symbol (reduce-only).
@@ -186,6 +186,29 @@ class TestOrderExecution:
             "BTC/USDT:USDT", "sell", 1.0, params={"tdMode": "cross"}
         )
 
+    def test_market_open_sends_client_order_id(self, adapter):
+        a, mock_ex = adapter
+        a._is_live = True
+        mock_ex.create_market_order.return_value = {"id": "457"}
+        a.market_open("BTC", True, 1.0, inst_type="swap", client_order_id="abc123")
+        mock_ex.create_market_order.assert_called_once_with(
+            "BTC/USDT:USDT", "buy", 1.0, params={"tdMode": "cross", "clOrdId": "abc123"}
+        )
+
+    def test_fetch_order_by_client_id(self, adapter):
+        a, mock_ex = adapter
+        a._is_live = True
+        mock_ex.fetch_order.return_value = {"id": "9", "status": "closed", "filled": 1.0, "average": 100.5}
+        got = a.fetch_order_by_client_id("BTC", "abc123", inst_type="swap")
+        assert got == {"status": "closed", "oid": "9", "filled": 1.0, "average": 100.5}
+        mock_ex.fetch_order.assert_called_once_with(None, "BTC/USDT:USDT", params={"clOrdId": "abc123"})
+
+    def test_fetch_order_by_client_id_not_found(self, adapter):
+        a, mock_ex = adapter
+        a._is_live = True
+        mock_ex.fetch_order.side_effect = _mod.ccxt.OrderNotFound("51603")
+        assert a.fetch_order_by_client_id("BTC", "abc123") == {"status": "not_found"}
+
     def test_market_close_with_position(self, adapter):
         a, mock_ex = adapter
         a._is_live = True
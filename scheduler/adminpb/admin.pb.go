@@ -0,0 +1,856 @@
+// gRPC admin/status API for the go-trader scheduler (#4935).
+//
+// Regenerate after editing (from scheduler/):
+//   go generate ./adminpb
+//
+// Every call requires "authorization: Bearer <STATUS_AUTH_TOKEN>" metadata.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: admin.proto
+
+package adminpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type StrategySummary struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                    string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Platform                string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Cash                    float64                `protobuf:"fixed64,4,opt,name=cash,proto3" json:"cash,omitempty"`
+	InitialCapital          float64                `protobuf:"fixed64,5,opt,name=initial_capital,json=initialCapital,proto3" json:"initial_capital,omitempty"`
+	PortfolioValue          float64                `protobuf:"fixed64,6,opt,name=portfolio_value,json=portfolioValue,proto3" json:"portfolio_value,omitempty"`
+	Pnl                     float64                `protobuf:"fixed64,7,opt,name=pnl,proto3" json:"pnl,omitempty"`
+	PnlPct                  float64                `protobuf:"fixed64,8,opt,name=pnl_pct,json=pnlPct,proto3" json:"pnl_pct,omitempty"`
+	OpenPositions           int32                  `protobuf:"varint,9,opt,name=open_positions,json=openPositions,proto3" json:"open_positions,omitempty"`
+	Paused                  bool                   `protobuf:"varint,10,opt,name=paused,proto3" json:"paused,omitempty"`
+	CircuitBreaker          bool                   `protobuf:"varint,11,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	CircuitBreakerUntilUnix int64                  `protobuf:"varint,12,opt,name=circuit_breaker_until_unix,json=circuitBreakerUntilUnix,proto3" json:"circuit_breaker_until_unix,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StrategySummary) Reset() {
+	*x = StrategySummary{}
+	mi := &file_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategySummary) ProtoMessage() {}
+
+func (x *StrategySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategySummary.ProtoReflect.Descriptor instead.
+func (*StrategySummary) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StrategySummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StrategySummary) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StrategySummary) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *StrategySummary) GetCash() float64 {
+	if x != nil {
+		return x.Cash
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetInitialCapital() float64 {
+	if x != nil {
+		return x.InitialCapital
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetPortfolioValue() float64 {
+	if x != nil {
+		return x.PortfolioValue
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetPnl() float64 {
+	if x != nil {
+		return x.Pnl
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetPnlPct() float64 {
+	if x != nil {
+		return x.PnlPct
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetOpenPositions() int32 {
+	if x != nil {
+		return x.OpenPositions
+	}
+	return 0
+}
+
+func (x *StrategySummary) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *StrategySummary) GetCircuitBreaker() bool {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return false
+}
+
+func (x *StrategySummary) GetCircuitBreakerUntilUnix() int64 {
+	if x != nil {
+		return x.CircuitBreakerUntilUnix
+	}
+	return 0
+}
+
+type StatusReply struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CycleCount         int64                  `protobuf:"varint,1,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
+	LastCycleUnix      int64                  `protobuf:"varint,2,opt,name=last_cycle_unix,json=lastCycleUnix,proto3" json:"last_cycle_unix,omitempty"`
+	TotalValue         float64                `protobuf:"fixed64,3,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	TotalNotional      float64                `protobuf:"fixed64,4,opt,name=total_notional,json=totalNotional,proto3" json:"total_notional,omitempty"`
+	PeakValue          float64                `protobuf:"fixed64,5,opt,name=peak_value,json=peakValue,proto3" json:"peak_value,omitempty"`
+	CurrentDrawdownPct float64                `protobuf:"fixed64,6,opt,name=current_drawdown_pct,json=currentDrawdownPct,proto3" json:"current_drawdown_pct,omitempty"`
+	KillSwitchActive   bool                   `protobuf:"varint,7,opt,name=kill_switch_active,json=killSwitchActive,proto3" json:"kill_switch_active,omitempty"`
+	KillSwitchReason   string                 `protobuf:"bytes,8,opt,name=kill_switch_reason,json=killSwitchReason,proto3" json:"kill_switch_reason,omitempty"`
+	Strategies         []*StrategySummary     `protobuf:"bytes,9,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	Version            string                 `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StatusReply) Reset() {
+	*x = StatusReply{}
+	mi := &file_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusReply) ProtoMessage() {}
+
+func (x *StatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusReply.ProtoReflect.Descriptor instead.
+func (*StatusReply) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StatusReply) GetCycleCount() int64 {
+	if x != nil {
+		return x.CycleCount
+	}
+	return 0
+}
+
+func (x *StatusReply) GetLastCycleUnix() int64 {
+	if x != nil {
+		return x.LastCycleUnix
+	}
+	return 0
+}
+
+func (x *StatusReply) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *StatusReply) GetTotalNotional() float64 {
+	if x != nil {
+		return x.TotalNotional
+	}
+	return 0
+}
+
+func (x *StatusReply) GetPeakValue() float64 {
+	if x != nil {
+		return x.PeakValue
+	}
+	return 0
+}
+
+func (x *StatusReply) GetCurrentDrawdownPct() float64 {
+	if x != nil {
+		return x.CurrentDrawdownPct
+	}
+	return 0
+}
+
+func (x *StatusReply) GetKillSwitchActive() bool {
+	if x != nil {
+		return x.KillSwitchActive
+	}
+	return false
+}
+
+func (x *StatusReply) GetKillSwitchReason() string {
+	if x != nil {
+		return x.KillSwitchReason
+	}
+	return ""
+}
+
+func (x *StatusReply) GetStrategies() []*StrategySummary {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+func (x *StatusReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ListPositionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty lists every strategy.
+	StrategyId    string `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPositionsRequest) Reset() {
+	*x = ListPositionsRequest{}
+	mi := &file_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsRequest) ProtoMessage() {}
+
+func (x *ListPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPositionsRequest) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StrategyId    string                 `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AvgCost       float64                `protobuf:"fixed64,5,opt,name=avg_cost,json=avgCost,proto3" json:"avg_cost,omitempty"`
+	Mark          float64                `protobuf:"fixed64,6,opt,name=mark,proto3" json:"mark,omitempty"`
+	UnrealizedPnl float64                `protobuf:"fixed64,7,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
+	Leverage      float64                `protobuf:"fixed64,8,opt,name=leverage,proto3" json:"leverage,omitempty"`
+	OpenedAtUnix  int64                  `protobuf:"varint,9,opt,name=opened_at_unix,json=openedAtUnix,proto3" json:"opened_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Position) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *Position) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Position) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Position) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Position) GetAvgCost() float64 {
+	if x != nil {
+		return x.AvgCost
+	}
+	return 0
+}
+
+func (x *Position) GetMark() float64 {
+	if x != nil {
+		return x.Mark
+	}
+	return 0
+}
+
+func (x *Position) GetUnrealizedPnl() float64 {
+	if x != nil {
+		return x.UnrealizedPnl
+	}
+	return 0
+}
+
+func (x *Position) GetLeverage() float64 {
+	if x != nil {
+		return x.Leverage
+	}
+	return 0
+}
+
+func (x *Position) GetOpenedAtUnix() int64 {
+	if x != nil {
+		return x.OpenedAtUnix
+	}
+	return 0
+}
+
+type ListPositionsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positions     []*Position            `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPositionsReply) Reset() {
+	*x = ListPositionsReply{}
+	mi := &file_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPositionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsReply) ProtoMessage() {}
+
+func (x *ListPositionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsReply.ProtoReflect.Descriptor instead.
+func (*ListPositionsReply) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPositionsReply) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type PauseStrategyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StrategyId    string                 `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	Paused        bool                   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseStrategyRequest) Reset() {
+	*x = PauseStrategyRequest{}
+	mi := &file_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseStrategyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseStrategyRequest) ProtoMessage() {}
+
+func (x *PauseStrategyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseStrategyRequest.ProtoReflect.Descriptor instead.
+func (*PauseStrategyRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PauseStrategyRequest) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *PauseStrategyRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type CloseStrategyRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StrategyId string                 `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	// Partial close quantity; 0 closes the whole position.
+	Qty           float64 `protobuf:"fixed64,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseStrategyRequest) Reset() {
+	*x = CloseStrategyRequest{}
+	mi := &file_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseStrategyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseStrategyRequest) ProtoMessage() {}
+
+func (x *CloseStrategyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseStrategyRequest.ProtoReflect.Descriptor instead.
+func (*CloseStrategyRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CloseStrategyRequest) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *CloseStrategyRequest) GetQty() float64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+type ResetKillSwitchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free-text note recorded on the kill-switch event log.
+	Note          string `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetKillSwitchRequest) Reset() {
+	*x = ResetKillSwitchRequest{}
+	mi := &file_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetKillSwitchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetKillSwitchRequest) ProtoMessage() {}
+
+func (x *ResetKillSwitchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetKillSwitchRequest.ProtoReflect.Descriptor instead.
+func (*ResetKillSwitchRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResetKillSwitchRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type AdminReply struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// True when the action was submitted and is applied on the next cycle.
+	Queued        bool `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminReply) Reset() {
+	*x = AdminReply{}
+	mi := &file_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminReply) ProtoMessage() {}
+
+func (x *AdminReply) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminReply.ProtoReflect.Descriptor instead.
+func (*AdminReply) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AdminReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AdminReply) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+var File_admin_proto protoreflect.FileDescriptor
+
+const file_admin_proto_rawDesc = "" +
+	"\n" +
+	"\vadmin.proto\x12\x11gotrader.admin.v1\"\x12\n" +
+	"\x10GetStatusRequest\"\x87\x03\n" +
+	"\x0fStrategySummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x12\n" +
+	"\x04cash\x18\x04 \x01(\x01R\x04cash\x12'\n" +
+	"\x0finitial_capital\x18\x05 \x01(\x01R\x0einitialCapital\x12'\n" +
+	"\x0fportfolio_value\x18\x06 \x01(\x01R\x0eportfolioValue\x12\x10\n" +
+	"\x03pnl\x18\a \x01(\x01R\x03pnl\x12\x17\n" +
+	"\apnl_pct\x18\b \x01(\x01R\x06pnlPct\x12%\n" +
+	"\x0eopen_positions\x18\t \x01(\x05R\ropenPositions\x12\x16\n" +
+	"\x06paused\x18\n" +
+	" \x01(\bR\x06paused\x12'\n" +
+	"\x0fcircuit_breaker\x18\v \x01(\bR\x0ecircuitBreaker\x12;\n" +
+	"\x1acircuit_breaker_until_unix\x18\f \x01(\x03R\x17circuitBreakerUntilUnix\"\xa9\x03\n" +
+	"\vStatusReply\x12\x1f\n" +
+	"\vcycle_count\x18\x01 \x01(\x03R\n" +
+	"cycleCount\x12&\n" +
+	"\x0flast_cycle_unix\x18\x02 \x01(\x03R\rlastCycleUnix\x12\x1f\n" +
+	"\vtotal_value\x18\x03 \x01(\x01R\n" +
+	"totalValue\x12%\n" +
+	"\x0etotal_notional\x18\x04 \x01(\x01R\rtotalNotional\x12\x1d\n" +
+	"\n" +
+	"peak_value\x18\x05 \x01(\x01R\tpeakValue\x120\n" +
+	"\x14current_drawdown_pct\x18\x06 \x01(\x01R\x12currentDrawdownPct\x12,\n" +
+	"\x12kill_switch_active\x18\a \x01(\bR\x10killSwitchActive\x12,\n" +
+	"\x12kill_switch_reason\x18\b \x01(\tR\x10killSwitchReason\x12B\n" +
+	"\n" +
+	"strategies\x18\t \x03(\v2\".gotrader.admin.v1.StrategySummaryR\n" +
+	"strategies\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\tR\aversion\"7\n" +
+	"\x14ListPositionsRequest\x12\x1f\n" +
+	"\vstrategy_id\x18\x01 \x01(\tR\n" +
+	"strategyId\"\x8b\x02\n" +
+	"\bPosition\x12\x1f\n" +
+	"\vstrategy_id\x18\x01 \x01(\tR\n" +
+	"strategyId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x03 \x01(\tR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x19\n" +
+	"\bavg_cost\x18\x05 \x01(\x01R\aavgCost\x12\x12\n" +
+	"\x04mark\x18\x06 \x01(\x01R\x04mark\x12%\n" +
+	"\x0eunrealized_pnl\x18\a \x01(\x01R\runrealizedPnl\x12\x1a\n" +
+	"\bleverage\x18\b \x01(\x01R\bleverage\x12$\n" +
+	"\x0eopened_at_unix\x18\t \x01(\x03R\fopenedAtUnix\"O\n" +
+	"\x12ListPositionsReply\x129\n" +
+	"\tpositions\x18\x01 \x03(\v2\x1b.gotrader.admin.v1.PositionR\tpositions\"O\n" +
+	"\x14PauseStrategyRequest\x12\x1f\n" +
+	"\vstrategy_id\x18\x01 \x01(\tR\n" +
+	"strategyId\x12\x16\n" +
+	"\x06paused\x18\x02 \x01(\bR\x06paused\"I\n" +
+	"\x14CloseStrategyRequest\x12\x1f\n" +
+	"\vstrategy_id\x18\x01 \x01(\tR\n" +
+	"strategyId\x12\x10\n" +
+	"\x03qty\x18\x02 \x01(\x01R\x03qty\",\n" +
+	"\x16ResetKillSwitchRequest\x12\x12\n" +
+	"\x04note\x18\x01 \x01(\tR\x04note\">\n" +
+	"\n" +
+	"AdminReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x16\n" +
+	"\x06queued\x18\x02 \x01(\bR\x06queued2\xc9\x03\n" +
+	"\x05Admin\x12P\n" +
+	"\tGetStatus\x12#.gotrader.admin.v1.GetStatusRequest\x1a\x1e.gotrader.admin.v1.StatusReply\x12_\n" +
+	"\rListPositions\x12'.gotrader.admin.v1.ListPositionsRequest\x1a%.gotrader.admin.v1.ListPositionsReply\x12W\n" +
+	"\rPauseStrategy\x12'.gotrader.admin.v1.PauseStrategyRequest\x1a\x1d.gotrader.admin.v1.AdminReply\x12W\n" +
+	"\rCloseStrategy\x12'.gotrader.admin.v1.CloseStrategyRequest\x1a\x1d.gotrader.admin.v1.AdminReply\x12[\n" +
+	"\x0fResetKillSwitch\x12).gotrader.admin.v1.ResetKillSwitchRequest\x1a\x1d.gotrader.admin.v1.AdminReplyB\x1bZ\x19trading-scheduler/adminpbb\x06proto3"
+
+var (
+	file_admin_proto_rawDescOnce sync.Once
+	file_admin_proto_rawDescData []byte
+)
+
+func file_admin_proto_rawDescGZIP() []byte {
+	file_admin_proto_rawDescOnce.Do(func() {
+		file_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)))
+	})
+	return file_admin_proto_rawDescData
+}
+
+var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_admin_proto_goTypes = []any{
+	(*GetStatusRequest)(nil),       // 0: gotrader.admin.v1.GetStatusRequest
+	(*StrategySummary)(nil),        // 1: gotrader.admin.v1.StrategySummary
+	(*StatusReply)(nil),            // 2: gotrader.admin.v1.StatusReply
+	(*ListPositionsRequest)(nil),   // 3: gotrader.admin.v1.ListPositionsRequest
+	(*Position)(nil),               // 4: gotrader.admin.v1.Position
+	(*ListPositionsReply)(nil),     // 5: gotrader.admin.v1.ListPositionsReply
+	(*PauseStrategyRequest)(nil),   // 6: gotrader.admin.v1.PauseStrategyRequest
+	(*CloseStrategyRequest)(nil),   // 7: gotrader.admin.v1.CloseStrategyRequest
+	(*ResetKillSwitchRequest)(nil), // 8: gotrader.admin.v1.ResetKillSwitchRequest
+	(*AdminReply)(nil),             // 9: gotrader.admin.v1.AdminReply
+}
+var file_admin_proto_depIdxs = []int32{
+	1, // 0: gotrader.admin.v1.StatusReply.strategies:type_name -> gotrader.admin.v1.StrategySummary
+	4, // 1: gotrader.admin.v1.ListPositionsReply.positions:type_name -> gotrader.admin.v1.Position
+	0, // 2: gotrader.admin.v1.Admin.GetStatus:input_type -> gotrader.admin.v1.GetStatusRequest
+	3, // 3: gotrader.admin.v1.Admin.ListPositions:input_type -> gotrader.admin.v1.ListPositionsRequest
+	6, // 4: gotrader.admin.v1.Admin.PauseStrategy:input_type -> gotrader.admin.v1.PauseStrategyRequest
+	7, // 5: gotrader.admin.v1.Admin.CloseStrategy:input_type -> gotrader.admin.v1.CloseStrategyRequest
+	8, // 6: gotrader.admin.v1.Admin.ResetKillSwitch:input_type -> gotrader.admin.v1.ResetKillSwitchRequest
+	2, // 7: gotrader.admin.v1.Admin.GetStatus:output_type -> gotrader.admin.v1.StatusReply
+	5, // 8: gotrader.admin.v1.Admin.ListPositions:output_type -> gotrader.admin.v1.ListPositionsReply
+	9, // 9: gotrader.admin.v1.Admin.PauseStrategy:output_type -> gotrader.admin.v1.AdminReply
+	9, // 10: gotrader.admin.v1.Admin.CloseStrategy:output_type -> gotrader.admin.v1.AdminReply
+	9, // 11: gotrader.admin.v1.Admin.ResetKillSwitch:output_type -> gotrader.admin.v1.AdminReply
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_admin_proto_init() }
+func file_admin_proto_init() {
+	if File_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_proto_goTypes,
+		DependencyIndexes: file_admin_proto_depIdxs,
+		MessageInfos:      file_admin_proto_msgTypes,
+	}.Build()
+	File_admin_proto = out.File
+	file_admin_proto_goTypes = nil
+	file_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,298 @@
+// gRPC admin/status API for the go-trader scheduler (#4935).
+//
+// Regenerate after editing (from scheduler/):
+//   go generate ./adminpb
+//
+// Every call requires "authorization: Bearer <STATUS_AUTH_TOKEN>" metadata.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Admin_GetStatus_FullMethodName       = "/gotrader.admin.v1.Admin/GetStatus"
+	Admin_ListPositions_FullMethodName   = "/gotrader.admin.v1.Admin/ListPositions"
+	Admin_PauseStrategy_FullMethodName   = "/gotrader.admin.v1.Admin/PauseStrategy"
+	Admin_CloseStrategy_FullMethodName   = "/gotrader.admin.v1.Admin/CloseStrategy"
+	Admin_ResetKillSwitch_FullMethodName = "/gotrader.admin.v1.Admin/ResetKillSwitch"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	// GetStatus returns portfolio totals, kill-switch state, and one summary
+	// row per configured strategy.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	// ListPositions returns open positions with live marks, optionally for one
+	// strategy.
+	ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsReply, error)
+	// PauseStrategy pauses or resumes a strategy through the same config
+	// write + hot-reload path as the dashboard.
+	PauseStrategy(ctx context.Context, in *PauseStrategyRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	// CloseStrategy closes a strategy's position: manual strategies via the
+	// manual close core, live Hyperliquid perps via force-close.
+	CloseStrategy(ctx context.Context, in *CloseStrategyRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	// ResetKillSwitch clears a latched portfolio kill switch.
+	ResetKillSwitch(ctx context.Context, in *ResetKillSwitchRequest, opts ...grpc.CallOption) (*AdminReply, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusReply)
+	err := c.cc.Invoke(ctx, Admin_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPositionsReply)
+	err := c.cc.Invoke(ctx, Admin_ListPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) PauseStrategy(ctx context.Context, in *PauseStrategyRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, Admin_PauseStrategy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CloseStrategy(ctx context.Context, in *CloseStrategyRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, Admin_CloseStrategy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResetKillSwitch(ctx context.Context, in *ResetKillSwitchRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, Admin_ResetKillSwitch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+type AdminServer interface {
+	// GetStatus returns portfolio totals, kill-switch state, and one summary
+	// row per configured strategy.
+	GetStatus(context.Context, *GetStatusRequest) (*StatusReply, error)
+	// ListPositions returns open positions with live marks, optionally for one
+	// strategy.
+	ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsReply, error)
+	// PauseStrategy pauses or resumes a strategy through the same config
+	// write + hot-reload path as the dashboard.
+	PauseStrategy(context.Context, *PauseStrategyRequest) (*AdminReply, error)
+	// CloseStrategy closes a strategy's position: manual strategies via the
+	// manual close core, live Hyperliquid perps via force-close.
+	CloseStrategy(context.Context, *CloseStrategyRequest) (*AdminReply, error)
+	// ResetKillSwitch clears a latched portfolio kill switch.
+	ResetKillSwitch(context.Context, *ResetKillSwitchRequest) (*AdminReply, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) GetStatus(context.Context, *GetStatusRequest) (*StatusReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedAdminServer) ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPositions not implemented")
+}
+func (UnimplementedAdminServer) PauseStrategy(context.Context, *PauseStrategyRequest) (*AdminReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseStrategy not implemented")
+}
+func (UnimplementedAdminServer) CloseStrategy(context.Context, *CloseStrategyRequest) (*AdminReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloseStrategy not implemented")
+}
+func (UnimplementedAdminServer) ResetKillSwitch(context.Context, *ResetKillSwitchRequest) (*AdminReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetKillSwitch not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call panics, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListPositions(ctx, req.(*ListPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_PauseStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PauseStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_PauseStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PauseStrategy(ctx, req.(*PauseStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CloseStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CloseStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CloseStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CloseStrategy(ctx, req.(*CloseStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResetKillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetKillSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResetKillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResetKillSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResetKillSwitch(ctx, req.(*ResetKillSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gotrader.admin.v1.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _Admin_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListPositions",
+			Handler:    _Admin_ListPositions_Handler,
+		},
+		{
+			MethodName: "PauseStrategy",
+			Handler:    _Admin_PauseStrategy_Handler,
+		},
+		{
+			MethodName: "CloseStrategy",
+			Handler:    _Admin_CloseStrategy_Handler,
+		},
+		{
+			MethodName: "ResetKillSwitch",
+			Handler:    _Admin_ResetKillSwitch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
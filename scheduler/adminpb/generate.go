@@ -0,0 +1,5 @@
+// Package adminpb holds the generated protobuf/gRPC code for the scheduler's
+// admin API (admin.proto, #4935).
+package adminpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative admin.proto
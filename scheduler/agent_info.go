@@ -67,10 +67,17 @@ var agentInfoCommands = []agentCommand{
 	{Name: "manual-cancel", Summary: "Cancel a resting manual limit order.", Usage: "go-trader manual-cancel [...]"},
 	{Name: "manual-update-sl", Summary: "Move the stop-loss trigger on a manual position.", Usage: "go-trader manual-update-sl <strategy-id> --trigger N [--symbol Y] [--dry-run]"},
 	{Name: "manual-cancel-sl", Summary: "Cancel the resting stop-loss on a manual position.", Usage: "go-trader manual-cancel-sl <strategy-id> [--symbol Y] [--dry-run]"},
+	{Name: "harvest-override", Summary: "Override theta harvest thresholds for one open sold option (applied next cycle).", Usage: "go-trader harvest-override [--config <path>] <strategy-id> <position-id> <field> <value>|clear", Flags: []string{"--config"}},
+	{Name: "adjust-position", Summary: "Register or correct a position in state (qty, avg cost, side) with an audit trade; 0 qty removes it (applied next cycle).", Usage: "go-trader adjust-position [--config <path>] <strategy-id> --qty N [--avg-cost P] [--side long|short] [--symbol S]", Flags: []string{"--config", "--qty", "--avg-cost", "--side", "--symbol"}},
+	{Name: "order-intents", Summary: "List live orders left in the order journal after a crash, or clear one once the venue and state agree (#4916).", Usage: "go-trader order-intents [--config <path>] [--resolve <key>]", Flags: []string{"--config", "--resolve"}},
+	{Name: "promote", Summary: "Flip a paper strategy to live after checks pass (paper days, closed trades, Sharpe, credentials, flat), write the config and announce it on Discord.", Usage: "go-trader promote [--config <path>] <strategy-id> [--min-days N] [--min-trades N] [--min-sharpe X] [--dry-run]", Flags: []string{"--config", "--min-days", "--min-trades", "--min-sharpe", "--dry-run"}},
+	{Name: "compare", Summary: "Side-by-side A/B report for two strategies: equity sparklines, returns, max drawdown, trade stats, Sharpe and return correlation.", Usage: "go-trader compare [--config <path>] <strategy-a> <strategy-b> [--days N]", Flags: []string{"--config", "--days"}},
 	{Name: "backfill", Summary: "Backfill derived data (trade-ledger fees/PnL, HL fees).", Usage: "go-trader backfill <trade-ledger|hl-fees> [...]"},
 	{Name: "probe", Summary: "Run startup probes against the configured check scripts.", Usage: "go-trader probe [--config <path>]"},
 	{Name: "inspect", Summary: "Print a strategy's effective (post-migration, post-default) config.", Usage: "go-trader inspect [--config <path>] [--json] <strategy-id>|--all"},
 	{Name: "diagnostics", Summary: "Read-only per-strategy trade-quality report (MFE/MAE/capture ratio) with backtestable tuning hypotheses (#1147).", Usage: "go-trader diagnostics [--config <path>] [--db <path>] [--strategy <id>] [--min-trades N] [--min-bucket N]", Flags: []string{"--config", "--db", "--strategy", "--min-trades", "--min-bucket"}},
+	{Name: "stress", Summary: "Read-only stress test: revalue open positions under spot/vol shocks and report projected loss and which breakers would trip.", Usage: "go-trader stress [--config <path>] [--shock -20%[,-10%...]] [--vol-shock +50%] [--top N] [--post]", Flags: []string{"--config", "--shock", "--vol-shock", "--top", "--post"}},
+	{Name: "ledger", Summary: "Standalone trade ledger: sync it from the state DB or print PnL aggregates by month, symbol or strategy (#4938).", Usage: "go-trader ledger <sync|pnl> [--config <path>] [--by month|symbol|strategy] [--strategy <id>] [--symbol <sym>] [--since <date>] [--until <date>] [--json]", Flags: []string{"--config", "--by", "--strategy", "--symbol", "--since", "--until", "--json"}},
 	{Name: "version", Summary: "Print the binary version.", Usage: "go-trader version"},
 }
 
@@ -93,7 +100,12 @@ var agentInfoEnvVars = []agentEnvVar{
 	{Name: "GO_TRADER_CASHFLOW_JOURNAL_ALARM", Purpose: "Set to 0/off/false/no to force the legacy trade-ledger drift basis for HL shared wallets instead of the #1100 exchange-sourced cash-flow journal (default on).", Secret: false},
 	{Name: "GO_TRADER_DIRECTIONAL_CERT_PATH", Purpose: "Override path to the regime directional-certification artifact (#1085); default backtest/research/regime_directional_certifications.json.", Secret: false},
 	{Name: "GO_TRADER_GITHUB_TOKEN", Purpose: "GitHub token for the self-updater (preferred over GITHUB_TOKEN).", Secret: true},
+	{Name: "GO_TRADER_AWS_SECRET_ID", Purpose: "AWS Secrets Manager secret name/ARN read by the aws secrets provider (JSON object of ENV_VAR_NAME → value).", Secret: false},
+	{Name: "GO_TRADER_SECRETS_PROVIDER", Purpose: "External secrets store loaded into the environment at daemon startup: vault or aws (unset = raw env vars only).", Secret: false},
 	{Name: "GO_TRADER_SERVICE", Purpose: "systemd unit name used by the updater's restart path.", Secret: false},
+	{Name: "GO_TRADER_SMTP_PASSWORD", Purpose: "SMTP password for alert_escalation.email (#4945); only used when email.username is set.", Secret: true},
+	{Name: "GO_TRADER_VAULT_SECRET_PATH", Purpose: "Vault API path below /v1/ read by the vault secrets provider, e.g. secret/data/go-trader (KV v2).", Secret: false},
+	{Name: "GOOGLE_APPLICATION_CREDENTIALS", Purpose: "Path to the Google service-account JSON key used by the #4936 google_sheets export when credentials_file is unset.", Secret: false},
 	{Name: "HYPERLIQUID_ACCOUNT_ADDRESS", Purpose: "Hyperliquid account address for live perps.", Secret: false},
 	{Name: "HYPERLIQUID_SECRET_KEY", Purpose: "Hyperliquid signing key for live perps execution.", Secret: true},
 	{Name: "OKX_API_KEY", Purpose: "OKX API key for live OKX spot.", Secret: true},
@@ -108,6 +120,9 @@ var agentInfoEnvVars = []agentEnvVar{
 	{Name: "TOPSTEP_ACCOUNT_ID", Purpose: "TopStep account ID for live futures.", Secret: false},
 	{Name: "TOPSTEP_API_KEY", Purpose: "TopStep API key for live futures.", Secret: true},
 	{Name: "TOPSTEP_API_SECRET", Purpose: "TopStep API secret for live futures.", Secret: true},
+	{Name: "VAULT_ADDR", Purpose: "Vault server address for the vault secrets provider.", Secret: false},
+	{Name: "VAULT_NAMESPACE", Purpose: "Optional Vault Enterprise namespace for the vault secrets provider.", Secret: false},
+	{Name: "VAULT_TOKEN", Purpose: "Vault token for the vault secrets provider.", Secret: true},
 }
 
 // agentConfigField documents one top-level config.json key.
@@ -247,6 +262,12 @@ func loadConfigSnapshot(path string) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read config: %w", err)
 	}
+	// Relative include paths resolve against the config's own directory, not
+	// the temp dir — merge them into the snapshot before copying.
+	data, _, err = resolveConfigIncludes(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("config include: %w", err)
+	}
 	tmp, err := os.CreateTemp("", "agent-info-cfg-*.json")
 	if err != nil {
 		return nil, err
@@ -368,8 +389,9 @@ func readStateDBReadOnly(path string, statusPort int) ([]agentTable, agentLiveSt
 		live.Note = fmt.Sprintf("state DB %s not present yet; %s", path, live.Note)
 		return nil, live
 	}
-	// Read-only DSN: no schema creation, no migration, no WAL writes.
-	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	// Read-only open: no schema creation, no migration, no WAL writes (an
+	// encrypted DB is decrypted into memory).
+	db, err := openStateDBForRead(path)
 	if err != nil {
 		live.DBPresent = false
 		return nil, live
@@ -0,0 +1,333 @@
+package main
+
+// alert_escalation: unacknowledged critical alert escalation (#4945).
+//
+// Kill-switch and live-execution-failure alerts are "critical": when the
+// top-level alert_escalation block is enabled, raising one arms a timer for
+// ack_window. An acknowledgment is any Discord DM reply or reaction from an
+// owner (discord.owner_id or alert_escalation.extra_owner_ids); one ack
+// covers every alert pending at that moment. If the window lapses with no
+// ack, the alert escalates once: DMs to every owner on every backend plus the
+// extra owners, a POST to webhook_url, and an email when the email block is
+// set. An alert stays registered (acked or escalated) until its condition
+// clears — the kill switch un-latches or the live order succeeds — so the
+// every-cycle kill-switch re-raise and hourly live-failure re-alerts never
+// re-arm an episode the owner already saw. Telegram replies are not observed
+// (the bot only polls while a prompt is waiting), so a Telegram-only setup
+// escalates every critical alert after the window. State is in-memory.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAlertEscalationAckWindow = 15 * time.Minute
+	defaultEscalationSMTPPort       = 587
+	escalationSendTimeout           = 10 * time.Second
+
+	// killSwitchEscalationKey is the single critical-alert slot for the
+	// portfolio kill switch.
+	killSwitchEscalationKey = "kill_switch"
+)
+
+// AlertEscalationConfig is the top-level "alert_escalation" block.
+type AlertEscalationConfig struct {
+	Enabled       bool                   `json:"enabled"`
+	AckWindow     string                 `json:"ack_window,omitempty"`      // Go duration; "" → 15m
+	ExtraOwnerIDs []string               `json:"extra_owner_ids,omitempty"` // more Discord user IDs that are DM'd on escalation and whose replies/reactions ack
+	WebhookURL    string                 `json:"webhook_url,omitempty"`     // POSTed {"content","text"} JSON on escalation (Discord/Slack-compatible)
+	Email         *EscalationEmailConfig `json:"email,omitempty"`           // SMTP email on escalation; password from GO_TRADER_SMTP_PASSWORD
+}
+
+// EscalationEmailConfig is alert_escalation.email.
+type EscalationEmailConfig struct {
+	SMTPHost string   `json:"smtp_host"`
+	SMTPPort int      `json:"smtp_port,omitempty"` // 0/omitted → 587
+	Username string   `json:"username,omitempty"`  // SMTP auth user; empty = no auth
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+func (c *AlertEscalationConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *AlertEscalationConfig) ackWindow() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(c.AckWindow)); err == nil && d > 0 {
+		return d
+	}
+	return defaultAlertEscalationAckWindow
+}
+
+// validateAlertEscalationConfig checks the block. Nil is disabled.
+func validateAlertEscalationConfig(c *AlertEscalationConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	if s := strings.TrimSpace(c.AckWindow); s != "" {
+		if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("alert_escalation.ack_window must be a positive Go duration (e.g. \"15m\"), got %q", c.AckWindow))
+		}
+	}
+	for _, id := range c.ExtraOwnerIDs {
+		if strings.TrimSpace(id) == "" {
+			errs = append(errs, "alert_escalation.extra_owner_ids must not contain empty IDs")
+			break
+		}
+	}
+	if c.WebhookURL != "" {
+		if err := validateHealthcheckURL(c.WebhookURL); err != nil {
+			errs = append(errs, fmt.Sprintf("alert_escalation.webhook_url: %v", err))
+		}
+	}
+	if e := c.Email; e != nil {
+		if strings.TrimSpace(e.SMTPHost) == "" {
+			errs = append(errs, "alert_escalation.email.smtp_host is required")
+		}
+		if e.SMTPPort < 0 || e.SMTPPort > 65535 {
+			errs = append(errs, fmt.Sprintf("alert_escalation.email.smtp_port must be in [0, 65535], got %d", e.SMTPPort))
+		}
+		if strings.TrimSpace(e.From) == "" {
+			errs = append(errs, "alert_escalation.email.from is required")
+		}
+		if len(e.To) == 0 {
+			errs = append(errs, "alert_escalation.email.to must list at least one address")
+		}
+	}
+	return errs
+}
+
+func cloneAlertEscalationConfig(c *AlertEscalationConfig) *AlertEscalationConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	cp.ExtraOwnerIDs = append([]string(nil), c.ExtraOwnerIDs...)
+	if c.Email != nil {
+		email := *c.Email
+		email.To = append([]string(nil), c.Email.To...)
+		cp.Email = &email
+	}
+	return &cp
+}
+
+// formatAlertEscalationConfig renders the block for reload change logs. The
+// webhook URL can embed a token, so only whether it is set is shown.
+func formatAlertEscalationConfig(c *AlertEscalationConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled(ack_window=%s, extra_owners=%d, webhook=%t, email=%t)", c.ackWindow(), len(c.ExtraOwnerIDs), c.WebhookURL != "", c.Email != nil)
+}
+
+// criticalAlert is one registered critical alert.
+type criticalAlert struct {
+	msg       string
+	raisedAt  time.Time
+	seq       uint64 // guards against a stale timer firing for a re-raised key
+	acked     bool
+	escalated bool
+}
+
+// criticalAlertTracker holds the registered critical alerts and the adopted
+// escalation config. escalate is swapped in tests.
+type criticalAlertTracker struct {
+	mu       sync.Mutex
+	cfg      *AlertEscalationConfig
+	owners   map[string]bool
+	notifier *MultiNotifier
+	alerts   map[string]*criticalAlert
+	seq      uint64
+	escalate func(notifier *MultiNotifier, cfg *AlertEscalationConfig, msg string)
+}
+
+// criticalAlerts is the package-level tracker; resets on restart.
+var criticalAlerts = &criticalAlertTracker{escalate: escalateCriticalAlert}
+
+// Configure adopts cfg's alert_escalation block and the owner IDs whose DM
+// replies/reactions acknowledge. Call at startup and on accepted reloads.
+func (t *criticalAlertTracker) Configure(cfg *Config) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cfg = cloneAlertEscalationConfig(cfg.AlertEscalation)
+	t.owners = make(map[string]bool)
+	if id := strings.TrimSpace(cfg.Discord.OwnerID); id != "" {
+		t.owners[id] = true
+	}
+	if t.cfg != nil {
+		for _, id := range t.cfg.ExtraOwnerIDs {
+			t.owners[strings.TrimSpace(id)] = true
+		}
+	}
+}
+
+// SetNotifier sets the notifier escalation DMs go through.
+func (t *criticalAlertTracker) SetNotifier(n *MultiNotifier) {
+	t.mu.Lock()
+	t.notifier = n
+	t.mu.Unlock()
+}
+
+// Raise registers a critical alert under key and arms the ack timer. It
+// reports whether a new alert was armed; a key that is already registered
+// (pending, acked or escalated) is left alone, as is everything while
+// escalation is disabled.
+func (t *criticalAlertTracker) Raise(key, msg string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.cfg.enabled() {
+		return false
+	}
+	if _, ok := t.alerts[key]; ok {
+		return false
+	}
+	if t.alerts == nil {
+		t.alerts = make(map[string]*criticalAlert)
+	}
+	t.seq++
+	seq := t.seq
+	t.alerts[key] = &criticalAlert{msg: msg, raisedAt: time.Now().UTC(), seq: seq}
+	time.AfterFunc(t.cfg.ackWindow(), func() { t.fire(key, seq) })
+	return true
+}
+
+// Ack acknowledges every pending alert when userID is an owner and returns
+// how many were pending.
+func (t *criticalAlertTracker) Ack(userID string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.owners[userID] {
+		return 0
+	}
+	n := 0
+	for _, a := range t.alerts {
+		if !a.acked && !a.escalated {
+			a.acked = true
+			n++
+		}
+	}
+	if n > 0 {
+		fmt.Printf("[escalation] %d critical alert(s) acknowledged by %s\n", n, userID)
+	}
+	return n
+}
+
+// Resolve drops key once its condition has cleared, so the next occurrence
+// arms a fresh ack window.
+func (t *criticalAlertTracker) Resolve(key string) {
+	t.mu.Lock()
+	delete(t.alerts, key)
+	t.mu.Unlock()
+}
+
+// AckHint is the line appended to a critical owner DM while escalation is on.
+func (t *criticalAlertTracker) AckHint() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.cfg.enabled() {
+		return ""
+	}
+	return fmt.Sprintf("\nReact or reply to this DM within %s to acknowledge, or it escalates.", t.cfg.ackWindow())
+}
+
+// fire is the ack-window timer. It escalates when the alert raised as seq is
+// still registered, unacknowledged, and escalation is still enabled.
+func (t *criticalAlertTracker) fire(key string, seq uint64) {
+	t.mu.Lock()
+	a := t.alerts[key]
+	if a == nil || a.seq != seq || a.acked || a.escalated || !t.cfg.enabled() {
+		t.mu.Unlock()
+		return
+	}
+	a.escalated = true
+	cfg := cloneAlertEscalationConfig(t.cfg)
+	notifier := t.notifier
+	msg := formatCriticalAlertEscalation(a, cfg.ackWindow())
+	t.mu.Unlock()
+	fmt.Printf("[escalation] %s unacknowledged after %s — escalating\n", key, cfg.ackWindow())
+	t.escalate(notifier, cfg, msg)
+}
+
+func formatCriticalAlertEscalation(a *criticalAlert, window time.Duration) string {
+	return fmt.Sprintf("**UNACKNOWLEDGED CRITICAL ALERT** (raised %s, no owner ack within %s):\n%s",
+		a.raisedAt.Format("2006-01-02 15:04 UTC"), window, a.msg)
+}
+
+// escalateCriticalAlert fans msg out to every escalation channel. Failures
+// are logged per channel and never stop the others.
+func escalateCriticalAlert(notifier *MultiNotifier, cfg *AlertEscalationConfig, msg string) {
+	if notifier.HasBackends() {
+		notifier.SendOwnerDM(msg)
+		if d := notifier.DiscordBackend(); d != nil {
+			for _, id := range cfg.ExtraOwnerIDs {
+				if err := d.SendDM(strings.TrimSpace(id), msg); err != nil {
+					fmt.Printf("[WARN] escalation DM to %s failed: %v\n", id, err)
+				}
+			}
+		}
+	}
+	if cfg.WebhookURL != "" {
+		if err := postEscalationWebhook(cfg.WebhookURL, msg); err != nil {
+			fmt.Printf("[WARN] escalation webhook failed: %v\n", err)
+		}
+	}
+	if cfg.Email != nil {
+		if err := sendEscalationEmail(cfg.Email, msg); err != nil {
+			fmt.Printf("[WARN] escalation email failed: %v\n", err)
+		}
+	}
+}
+
+func postEscalationWebhook(url, msg string) error {
+	body, err := json.Marshal(map[string]string{"content": msg, "text": msg})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: escalationSendTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sendEscalationEmail(e *EscalationEmailConfig, msg string) error {
+	port := e.SMTPPort
+	if port == 0 {
+		port = defaultEscalationSMTPPort
+	}
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, os.Getenv("GO_TRADER_SMTP_PASSWORD"), e.SMTPHost)
+	}
+	to := append([]string(nil), e.To...)
+	sort.Strings(to)
+	return smtp.SendMail(fmt.Sprintf("%s:%d", e.SMTPHost, port), auth, e.From, to, buildEscalationEmail(e.From, to, msg))
+}
+
+// buildEscalationEmail renders a minimal RFC 5322 plain-text message.
+func buildEscalationEmail(from string, to []string, msg string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	b.WriteString("Subject: go-trader: unacknowledged critical alert\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg, "**", ""), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestCriticalAlertTracker(esc *AlertEscalationConfig) (*criticalAlertTracker, *[]string) {
+	var sent []string
+	tr := &criticalAlertTracker{escalate: func(_ *MultiNotifier, _ *AlertEscalationConfig, msg string) {
+		sent = append(sent, msg)
+	}}
+	cfg := &Config{AlertEscalation: esc}
+	cfg.Discord.OwnerID = "owner-1"
+	tr.Configure(cfg)
+	return tr, &sent
+}
+
+func TestCriticalAlertTracker_EscalatesWhenUnacked(t *testing.T) {
+	tr, sent := newTestCriticalAlertTracker(&AlertEscalationConfig{Enabled: true, AckWindow: "1h"})
+	if !tr.Raise("kill_switch", "**PORTFOLIO KILL SWITCH** drawdown 26%") {
+		t.Fatal("first raise should arm")
+	}
+	if tr.Raise("kill_switch", "repeat") {
+		t.Fatal("re-raise of a registered key should not re-arm")
+	}
+	tr.fire("kill_switch", tr.alerts["kill_switch"].seq)
+	if len(*sent) != 1 || !strings.Contains((*sent)[0], "UNACKNOWLEDGED CRITICAL ALERT") || !strings.Contains((*sent)[0], "drawdown 26%") {
+		t.Fatalf("escalations = %q", *sent)
+	}
+	tr.fire("kill_switch", tr.alerts["kill_switch"].seq)
+	if len(*sent) != 1 {
+		t.Fatalf("escalated twice: %q", *sent)
+	}
+}
+
+func TestCriticalAlertTracker_OwnerAckCancels(t *testing.T) {
+	tr, sent := newTestCriticalAlertTracker(&AlertEscalationConfig{Enabled: true, AckWindow: "1h", ExtraOwnerIDs: []string{"owner-2"}})
+	tr.Raise("live_exec a", "fail a")
+	tr.Raise("live_exec b", "fail b")
+	if n := tr.Ack("stranger"); n != 0 {
+		t.Fatalf("non-owner acked %d alerts", n)
+	}
+	if n := tr.Ack("owner-2"); n != 2 {
+		t.Fatalf("extra owner acked %d alerts, want 2", n)
+	}
+	tr.fire("live_exec a", tr.alerts["live_exec a"].seq)
+	if len(*sent) != 0 {
+		t.Fatalf("acked alert escalated: %q", *sent)
+	}
+}
+
+func TestCriticalAlertTracker_ResolveRearmsAndStaleTimerIgnored(t *testing.T) {
+	tr, sent := newTestCriticalAlertTracker(&AlertEscalationConfig{Enabled: true, AckWindow: "1h"})
+	tr.Raise("kill_switch", "first")
+	staleSeq := tr.alerts["kill_switch"].seq
+	tr.Resolve("kill_switch")
+	if !tr.Raise("kill_switch", "second") {
+		t.Fatal("raise after resolve should arm a fresh window")
+	}
+	tr.fire("kill_switch", staleSeq)
+	if len(*sent) != 0 {
+		t.Fatalf("stale timer escalated: %q", *sent)
+	}
+}
+
+func TestCriticalAlertTracker_DisabledIsNoop(t *testing.T) {
+	tr, _ := newTestCriticalAlertTracker(nil)
+	if tr.Raise("kill_switch", "x") {
+		t.Fatal("raise armed with escalation disabled")
+	}
+	if hint := tr.AckHint(); hint != "" {
+		t.Fatalf("AckHint = %q, want empty when disabled", hint)
+	}
+}
+
+func TestValidateAlertEscalationConfig(t *testing.T) {
+	ok := &AlertEscalationConfig{Enabled: true, AckWindow: "10m", WebhookURL: "https://hooks.example.com/x",
+		Email: &EscalationEmailConfig{SMTPHost: "smtp.example.com", From: "bot@example.com", To: []string{"me@example.com"}}}
+	if errs := validateAlertEscalationConfig(ok); len(errs) != 0 {
+		t.Fatalf("valid block rejected: %v", errs)
+	}
+	bad := &AlertEscalationConfig{Enabled: true, AckWindow: "soon", WebhookURL: "ftp://x", ExtraOwnerIDs: []string{" "},
+		Email: &EscalationEmailConfig{SMTPPort: 70000}}
+	if errs := validateAlertEscalationConfig(bad); len(errs) != 7 {
+		t.Fatalf("errs = %v, want 7", errs)
+	}
+}
+
+func TestPostEscalationWebhook(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	if err := postEscalationWebhook(srv.URL, "alert"); err != nil {
+		t.Fatalf("postEscalationWebhook: %v", err)
+	}
+	if got["content"] != "alert" || got["text"] != "alert" {
+		t.Fatalf("payload = %v", got)
+	}
+}
+
+func TestBuildEscalationEmail(t *testing.T) {
+	body := string(buildEscalationEmail("bot@example.com", []string{"a@example.com", "b@example.com"}, "**KILL**\nline"))
+	for _, want := range []string{"To: a@example.com, b@example.com\r\n", "Subject: go-trader: unacknowledged critical alert\r\n", "\r\n\r\nKILL\r\nline\r\n"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("email missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -753,7 +753,7 @@ func runFetchHLUserFillsWithTimeout(since time.Time, timeout time.Duration) (*HL
 	}
 	var result HLUserFillsResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	if runErr != nil && result.Error == "" {
 		return &result, fmt.Errorf("script error: %w", runErr)
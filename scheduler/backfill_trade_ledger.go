@@ -425,6 +425,9 @@ func (sdb *StateDB) ApplyTradeLedgerPlan(plan TradeLedgerPlan) error {
 	if _, err := tx.Exec("UPDATE strategies SET cash = ? WHERE id = ?", plan.NewCash, plan.StrategyID); err != nil {
 		return fmt.Errorf("update strategy cash: %w", err)
 	}
+	if err := stampStateChecksum(tx); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
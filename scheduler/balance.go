@@ -32,12 +32,12 @@ func fetchPythonBalance(platform string) (float64, error) {
 	args := []string{fmt.Sprintf("--platform=%s", platform)}
 	stdout, stderr, err := RunPythonScript("shared_scripts/check_balance.py", args)
 	if err != nil {
-		return 0, fmt.Errorf("check_balance.py %s: %w (stderr: %s)", platform, err, string(stderr))
+		return 0, fmt.Errorf("check_balance.py %s: %w (stderr: %s)", platform, err, outputSnippet(string(stderr)))
 	}
 
 	var result balanceResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return 0, fmt.Errorf("parse balance output for %s: %w (stdout: %s)", platform, err, string(stdout))
+		return 0, fmt.Errorf("parse balance output for %s: %w (stdout: %s)", platform, err, outputSnippet(string(stdout)))
 	}
 	if result.Error != "" {
 		return 0, fmt.Errorf("balance check %s: %s", platform, result.Error)
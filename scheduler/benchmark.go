@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// #4927: per-strategy benchmark tracking. Each cycle records one point per
+// UTC day — the strategy's portfolio value, its baseline, and the benchmark
+// price — so alpha/beta against buy-and-hold (or a configured pair) is
+// persisted data rather than something recomputed ad hoc from trade logs.
+
+// benchmarkHistoryMaxDays caps the stored daily points per strategy.
+const benchmarkHistoryMaxDays = 120
+
+// benchmarkWindowDays is the rolling window alpha/beta are computed over.
+const benchmarkWindowDays = 30
+
+// minBenchmarkReturns is the minimum number of daily return pairs required
+// before alpha/beta are reported; fewer and the regression is noise.
+const minBenchmarkReturns = 10
+
+// BenchmarkPoint is one UTC day's end-of-day (or latest intraday) sample.
+// Capital is the strategy's baseline at the time, so deposits and allocator
+// transfers are not mistaken for returns.
+type BenchmarkPoint struct {
+	Date    string  `json:"d"`
+	Value   float64 `json:"v"`
+	Capital float64 `json:"c"`
+	Bench   float64 `json:"b"`
+}
+
+// BenchmarkTrack is the persisted series for one strategy. Symbol records
+// which price the Bench column tracks; a config change to a different
+// benchmark restarts the series rather than splicing two instruments.
+type BenchmarkTrack struct {
+	Symbol string           `json:"symbol"`
+	Points []BenchmarkPoint `json:"points"`
+}
+
+// BenchmarkStats is the rolling comparison surfaced on /status and Discord.
+// Alpha is Jensen's alpha annualized in percent; the return fields cover the
+// same window and are in percent.
+type BenchmarkStats struct {
+	Symbol             string  `json:"symbol"`
+	Days               int     `json:"days"`
+	Alpha              float64 `json:"alpha_pct"`
+	Beta               float64 `json:"beta"`
+	StrategyReturnPct  float64 `json:"strategy_return_pct"`
+	BenchmarkReturnPct float64 `json:"benchmark_return_pct"`
+}
+
+// benchmarkErrors validates a strategy's benchmark field. An explicit value
+// must be a spot pair the BinanceUS price rail can fetch.
+func benchmarkErrors(sc StrategyConfig) []string {
+	if sc.Benchmark == "" {
+		return nil
+	}
+	base, quote, ok := strings.Cut(sc.Benchmark, "/")
+	if !ok || base == "" || quote == "" || strings.Contains(quote, "/") {
+		return []string{fmt.Sprintf("strategy %s: benchmark %q must be a spot pair like \"BTC/USDT\" (omit to benchmark against the strategy's own underlying)", sc.ID, sc.Benchmark)}
+	}
+	return nil
+}
+
+// benchmarkSymbol returns the price key a strategy is benchmarked against:
+// the configured pair, or the strategy's own underlying.
+func benchmarkSymbol(sc StrategyConfig) string {
+	if sc.Benchmark != "" {
+		return sc.Benchmark
+	}
+	if len(sc.Args) < 2 {
+		return ""
+	}
+	return sc.Args[1]
+}
+
+// benchmarkPrice resolves the benchmark price from the cycle's price map.
+// Options underlyings ("BTC") are only priced as spot pairs, so a bare coin
+// falls back to its USDT pair.
+func benchmarkPrice(sym string, prices map[string]float64) float64 {
+	if p := prices[sym]; p > 0 {
+		return p
+	}
+	if !strings.Contains(sym, "/") {
+		return prices[sym+"/USDT"]
+	}
+	return 0
+}
+
+// recordBenchmarkPoints appends (or updates, intraday) today's point for
+// every configured strategy with both a valuation and a benchmark price.
+// Caller holds mu.Lock.
+func recordBenchmarkPoints(strategies []StrategyConfig, state *AppState, prices map[string]float64, now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	for _, sc := range strategies {
+		ss := state.Strategies[sc.ID]
+		if ss == nil {
+			continue
+		}
+		sym := benchmarkSymbol(sc)
+		bench := benchmarkPrice(sym, prices)
+		value := displayStrategyValue(ss, prices)
+		if sym == "" || bench <= 0 || value <= 0 {
+			continue
+		}
+		if ss.Benchmark == nil || ss.Benchmark.Symbol != sym {
+			ss.Benchmark = &BenchmarkTrack{Symbol: sym}
+		}
+		pt := BenchmarkPoint{Date: day, Value: value, Capital: EffectiveInitialCapital(sc, ss), Bench: bench}
+		pts := ss.Benchmark.Points
+		if n := len(pts); n > 0 && pts[n-1].Date == day {
+			pts[n-1] = pt
+		} else {
+			pts = append(pts, pt)
+		}
+		if len(pts) > benchmarkHistoryMaxDays {
+			pts = pts[len(pts)-benchmarkHistoryMaxDays:]
+		}
+		ss.Benchmark.Points = pts
+	}
+}
+
+// benchmarkStats computes rolling alpha/beta over the last window daily
+// returns. Strategy returns net out baseline changes so capital flows do not
+// register as performance. Returns ok=false below minBenchmarkReturns or when
+// the benchmark did not move (beta undefined).
+func benchmarkStats(track *BenchmarkTrack, window int) (BenchmarkStats, bool) {
+	if track == nil || len(track.Points) < 2 {
+		return BenchmarkStats{}, false
+	}
+	pts := track.Points
+	if len(pts) > window+1 {
+		pts = pts[len(pts)-window-1:]
+	}
+	var rs, rb []float64
+	stratGrowth, benchGrowth := 1.0, 1.0
+	for i := 1; i < len(pts); i++ {
+		prev, cur := pts[i-1], pts[i]
+		if prev.Value <= 0 || prev.Bench <= 0 {
+			continue
+		}
+		s := ((cur.Value - prev.Value) - (cur.Capital - prev.Capital)) / prev.Value
+		b := cur.Bench/prev.Bench - 1
+		rs = append(rs, s)
+		rb = append(rb, b)
+		stratGrowth *= 1 + s
+		benchGrowth *= 1 + b
+	}
+	n := len(rs)
+	if n < minBenchmarkReturns {
+		return BenchmarkStats{}, false
+	}
+	var meanS, meanB float64
+	for i := range rs {
+		meanS += rs[i]
+		meanB += rb[i]
+	}
+	meanS /= float64(n)
+	meanB /= float64(n)
+	var cov, varB float64
+	for i := range rs {
+		cov += (rs[i] - meanS) * (rb[i] - meanB)
+		varB += (rb[i] - meanB) * (rb[i] - meanB)
+	}
+	if varB <= 0 {
+		return BenchmarkStats{}, false
+	}
+	beta := cov / varB
+	alpha := (meanS - beta*meanB) * TradingDaysPerYear * 100
+	if math.IsNaN(alpha) || math.IsInf(alpha, 0) {
+		return BenchmarkStats{}, false
+	}
+	return BenchmarkStats{
+		Symbol:             track.Symbol,
+		Days:               n,
+		Alpha:              alpha,
+		Beta:               beta,
+		StrategyReturnPct:  (stratGrowth - 1) * 100,
+		BenchmarkReturnPct: (benchGrowth - 1) * 100,
+	}, true
+}
+
+func marshalBenchmarkJSON(t *BenchmarkTrack) string {
+	if t == nil || len(t.Points) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func parseBenchmarkJSON(raw string) *BenchmarkTrack {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	var out BenchmarkTrack
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil
+	}
+	return &out
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchmarkTestTrack builds daily points where the strategy returns
+// alpha + beta*benchmark each day.
+func benchmarkTestTrack(days int, alpha, beta float64) *BenchmarkTrack {
+	t := &BenchmarkTrack{Symbol: "BTC/USDT"}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	value, bench := 1000.0, 100.0
+	for d := 0; d < days; d++ {
+		t.Points = append(t.Points, BenchmarkPoint{Date: start.AddDate(0, 0, d).Format("2006-01-02"), Value: value, Capital: 1000, Bench: bench})
+		rb := 0.02 * math.Sin(float64(d))
+		bench *= 1 + rb
+		value *= 1 + alpha + beta*rb
+	}
+	return t
+}
+
+func TestBenchmarkStatsRecoversAlphaBeta(t *testing.T) {
+	st, ok := benchmarkStats(benchmarkTestTrack(40, 0.001, 0.5), benchmarkWindowDays)
+	if !ok {
+		t.Fatal("expected stats")
+	}
+	if st.Days != benchmarkWindowDays {
+		t.Fatalf("Days = %d, want %d", st.Days, benchmarkWindowDays)
+	}
+	if math.Abs(st.Beta-0.5) > 1e-9 {
+		t.Fatalf("Beta = %.6f, want 0.5", st.Beta)
+	}
+	if want := 0.001 * TradingDaysPerYear * 100; math.Abs(st.Alpha-want) > 1e-6 {
+		t.Fatalf("Alpha = %.6f, want %.6f", st.Alpha, want)
+	}
+
+	if _, ok := benchmarkStats(benchmarkTestTrack(minBenchmarkReturns, 0, 1), benchmarkWindowDays); ok {
+		t.Fatal("too few returns should not report")
+	}
+	if _, ok := benchmarkStats(nil, benchmarkWindowDays); ok {
+		t.Fatal("nil track should not report")
+	}
+}
+
+func TestBenchmarkStatsIgnoresCapitalFlows(t *testing.T) {
+	track := benchmarkTestTrack(20, 0, 1)
+	// A $500 deposit on day 10 raises value and baseline together.
+	for i := 10; i < len(track.Points); i++ {
+		track.Points[i].Value += 500
+		track.Points[i].Capital += 500
+	}
+	st, ok := benchmarkStats(track, benchmarkWindowDays)
+	if !ok {
+		t.Fatal("expected stats")
+	}
+	if st.Alpha > 50 {
+		t.Fatalf("deposit leaked into alpha: %+v", st)
+	}
+}
+
+func TestRecordBenchmarkPoints(t *testing.T) {
+	state := NewAppState()
+	state.Strategies["s"] = &StrategyState{ID: "s", Type: "spot", Cash: 1000, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	strategies := []StrategyConfig{{ID: "s", Type: "spot", Args: []string{"sma", "BTC/USDT", "1h"}}}
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	recordBenchmarkPoints(strategies, state, map[string]float64{"BTC/USDT": 100}, now)
+	recordBenchmarkPoints(strategies, state, map[string]float64{"BTC/USDT": 110}, now.Add(time.Hour))
+	track := state.Strategies["s"].Benchmark
+	if track == nil || len(track.Points) != 1 || track.Points[0].Bench != 110 {
+		t.Fatalf("same-day sample should update in place, got %+v", track)
+	}
+	recordBenchmarkPoints(strategies, state, map[string]float64{"BTC/USDT": 120}, now.Add(24*time.Hour))
+	if len(track.Points) != 2 {
+		t.Fatalf("new day should append, got %+v", track.Points)
+	}
+
+	strategies[0].Benchmark = "ETH/USDT"
+	recordBenchmarkPoints(strategies, state, map[string]float64{"BTC/USDT": 120}, now.Add(48*time.Hour))
+	if got := state.Strategies["s"].Benchmark; got.Symbol != "BTC/USDT" || len(got.Points) != 2 {
+		t.Fatalf("unpriced benchmark should leave the series alone, got %+v", got)
+	}
+	recordBenchmarkPoints(strategies, state, map[string]float64{"ETH/USDT": 3000}, now.Add(48*time.Hour))
+	if got := state.Strategies["s"].Benchmark; got.Symbol != "ETH/USDT" || len(got.Points) != 1 {
+		t.Fatalf("changed benchmark should restart the series, got %+v", got)
+	}
+}
+
+func TestBenchmarkPriceFallsBackToUSDTPair(t *testing.T) {
+	prices := map[string]float64{"BTC/USDT": 100}
+	if got := benchmarkPrice("BTC", prices); got != 100 {
+		t.Fatalf("bare coin = %v, want 100", got)
+	}
+	if got := benchmarkPrice("ETH/USDT", prices); got != 0 {
+		t.Fatalf("missing pair = %v, want 0", got)
+	}
+}
+
+func TestBenchmarkErrors(t *testing.T) {
+	if errs := benchmarkErrors(StrategyConfig{ID: "s", Benchmark: "ETH/USDT"}); len(errs) != 0 {
+		t.Fatalf("valid pair rejected: %v", errs)
+	}
+	for _, bad := range []string{"ETH", "/USDT", "ETH/", "A/B/C"} {
+		if errs := benchmarkErrors(StrategyConfig{ID: "s", Benchmark: bad}); len(errs) == 0 {
+			t.Fatalf("benchmark %q accepted", bad)
+		}
+	}
+}
+
+func TestBenchmarkTrackPersists(t *testing.T) {
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	state := NewAppState()
+	state.Strategies["s"] = &StrategyState{ID: "s", Type: "spot", Cash: 1000, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}, Benchmark: benchmarkTestTrack(3, 0, 1)}
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Strategies["s"].Benchmark
+	if got == nil || got.Symbol != "BTC/USDT" || len(got.Points) != 3 || got.Points[2] != state.Strategies["s"].Benchmark.Points[2] {
+		t.Fatalf("loaded track = %+v", got)
+	}
+}
+
+func TestCatTableBenchmarkColumns(t *testing.T) {
+	bots := []botInfo{{id: "a", value: 1000}, {id: "b", value: 1000}}
+	var sb strings.Builder
+	writeCatTablePartial(&sb, bots, false, true, 2000, 0, 0, 0, 0, 0)
+	if strings.Contains(sb.String(), "Alpha") {
+		t.Fatalf("no history should hide the columns:\n%s", sb.String())
+	}
+	bots[1].benchmark = &BenchmarkStats{Alpha: 12.4, Beta: 0.8}
+	sb.Reset()
+	writeCatTablePartial(&sb, bots, false, true, 2000, 0, 0, 0, 0, 0)
+	out := sb.String()
+	if !strings.Contains(out, "Alpha  Beta") || !strings.Contains(out, "+12%  0.80") {
+		t.Fatalf("missing benchmark columns:\n%s", out)
+	}
+}
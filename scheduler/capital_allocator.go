@@ -0,0 +1,441 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Capital allocator defaults (#4925).
+const (
+	defaultAllocatorInterval       = 24 * time.Hour
+	defaultAllocatorLookbackDays   = 30
+	defaultAllocatorMinWeightPct   = 5.0
+	defaultAllocatorMaxWeightPct   = 100.0
+	defaultAllocatorMaxTurnoverPct = 10.0
+	// allocatorMinScoredDays is how many distinct close days a strategy
+	// needs inside the lookback before its Sharpe moves capital. Below it the
+	// strategy keeps its current weight.
+	allocatorMinScoredDays = 5
+	// allocatorMinTransferUSD suppresses dust rebalances.
+	allocatorMinTransferUSD = 1.0
+)
+
+// CapitalAllocatorConfig periodically shifts capital between paper
+// strategies toward better rolling risk-adjusted performance (#4925).
+type CapitalAllocatorConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Strategies     []string `json:"strategies"`                 // participating strategy IDs (paper, fixed capital)
+	Interval       string   `json:"interval,omitempty"`         // Go duration between rebalances (default "24h")
+	LookbackDays   int      `json:"lookback_days,omitempty"`    // rolling Sharpe window (default 30)
+	MinWeightPct   float64  `json:"min_weight_pct,omitempty"`   // floor per strategy, % of the pool (default 5)
+	MaxWeightPct   float64  `json:"max_weight_pct,omitempty"`   // cap per strategy, % of the pool (default 100)
+	MaxTurnoverPct float64  `json:"max_turnover_pct,omitempty"` // max % of the pool moved per rebalance (default 10)
+}
+
+func (c *CapitalAllocatorConfig) interval() time.Duration {
+	if c.Interval == "" {
+		return defaultAllocatorInterval
+	}
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return defaultAllocatorInterval
+	}
+	return d
+}
+
+func (c *CapitalAllocatorConfig) lookbackDays() int {
+	if c.LookbackDays <= 0 {
+		return defaultAllocatorLookbackDays
+	}
+	return c.LookbackDays
+}
+
+func (c *CapitalAllocatorConfig) minWeight() float64 {
+	if c.MinWeightPct <= 0 {
+		return defaultAllocatorMinWeightPct / 100
+	}
+	return c.MinWeightPct / 100
+}
+
+func (c *CapitalAllocatorConfig) maxWeight() float64 {
+	if c.MaxWeightPct <= 0 {
+		return defaultAllocatorMaxWeightPct / 100
+	}
+	return c.MaxWeightPct / 100
+}
+
+func (c *CapitalAllocatorConfig) maxTurnover() float64 {
+	if c.MaxTurnoverPct <= 0 {
+		return defaultAllocatorMaxTurnoverPct / 100
+	}
+	return c.MaxTurnoverPct / 100
+}
+
+// capitalAllocatorErrors validates the top-level capital_allocator block.
+func capitalAllocatorErrors(c *CapitalAllocatorConfig, strategies []StrategyConfig) []string {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	var errs []string
+	if c.Interval != "" {
+		if d, err := time.ParseDuration(c.Interval); err != nil || d < time.Hour {
+			errs = append(errs, fmt.Sprintf("capital_allocator.interval must be a Go duration >= 1h, got %q", c.Interval))
+		}
+	}
+	if c.LookbackDays < 0 || c.LookbackDays > 365 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.lookback_days must be in [0, 365] (0 = default %d), got %d", defaultAllocatorLookbackDays, c.LookbackDays))
+	}
+	if c.MinWeightPct < 0 || c.MinWeightPct >= 100 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.min_weight_pct must be in [0, 100) (0 = default %g), got %g", defaultAllocatorMinWeightPct, c.MinWeightPct))
+	}
+	if c.MaxWeightPct < 0 || c.MaxWeightPct > 100 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.max_weight_pct must be in [0, 100] (0 = default %g), got %g", defaultAllocatorMaxWeightPct, c.MaxWeightPct))
+	}
+	if c.MaxTurnoverPct < 0 || c.MaxTurnoverPct > 100 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.max_turnover_pct must be in [0, 100] (0 = default %g), got %g", defaultAllocatorMaxTurnoverPct, c.MaxTurnoverPct))
+	}
+	if len(c.Strategies) < 2 {
+		errs = append(errs, "capital_allocator.strategies must list at least 2 strategies")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	n := float64(len(c.Strategies))
+	if c.minWeight() > c.maxWeight() {
+		errs = append(errs, fmt.Sprintf("capital_allocator.min_weight_pct (%g) exceeds max_weight_pct (%g)", c.minWeight()*100, c.maxWeight()*100))
+	}
+	if c.minWeight()*n > 1 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.min_weight_pct %g%% x %d strategies exceeds 100%%", c.minWeight()*100, len(c.Strategies)))
+	}
+	if c.maxWeight()*n < 1 {
+		errs = append(errs, fmt.Sprintf("capital_allocator.max_weight_pct %g%% x %d strategies is below 100%%", c.maxWeight()*100, len(c.Strategies)))
+	}
+	byID := make(map[string]StrategyConfig, len(strategies))
+	for _, sc := range strategies {
+		byID[sc.ID] = sc
+	}
+	seen := make(map[string]bool, len(c.Strategies))
+	for _, id := range c.Strategies {
+		sc, ok := byID[id]
+		switch {
+		case seen[id]:
+			errs = append(errs, fmt.Sprintf("capital_allocator.strategies lists %q more than once", id))
+		case !ok:
+			errs = append(errs, fmt.Sprintf("capital_allocator.strategies references unknown strategy %q", id))
+		case isLiveArgs(sc.Args):
+			errs = append(errs, fmt.Sprintf("capital_allocator.strategies: %q is live; only paper strategies can be reallocated", id))
+		case sc.CapitalPct > 0:
+			errs = append(errs, fmt.Sprintf("capital_allocator.strategies: %q uses capital_pct; the allocator needs fixed capital", id))
+		case sc.InitialCapital > 0:
+			errs = append(errs, fmt.Sprintf("capital_allocator.strategies: %q pins initial_capital in config; the allocator owns that baseline", id))
+		}
+		seen[id] = true
+	}
+	return errs
+}
+
+// CapitalTransfer is one pairwise capital move recorded in capital_transfers.
+type CapitalTransfer struct {
+	At        time.Time
+	From      string
+	To        string
+	AmountUSD float64
+	Reason    string
+}
+
+// allocatorSlot is one participant's allocator input.
+type allocatorSlot struct {
+	ID      string
+	Capital float64 // current initial_capital baseline
+	Cash    float64 // donor limit: only idle cash can move
+	Sharpe  float64
+	Scored  bool // enough closes in the lookback for Sharpe to count
+}
+
+// planCapitalTransfers returns the pairwise transfers that move the pool
+// toward Sharpe-proportional weights. Unscored strategies keep their weight;
+// scored ones share the rest in proportion to max(Sharpe, 0), clamped to
+// [min, max] weight. The plan is scaled down to the turnover limit and to
+// what donors hold in cash. Returns nil when there is nothing to do.
+func planCapitalTransfers(slots []allocatorSlot, c *CapitalAllocatorConfig) []CapitalTransfer {
+	var pool, scoredPool, positive float64
+	var scored []int
+	for i, s := range slots {
+		pool += s.Capital
+		if s.Scored {
+			scored = append(scored, i)
+			scoredPool += s.Capital
+			positive += math.Max(s.Sharpe, 0)
+		}
+	}
+	if pool <= 0 || len(scored) < 2 || positive <= 0 {
+		return nil
+	}
+	target := make(map[int]float64, len(scored))
+	for _, i := range scored {
+		target[i] = scoredPool * math.Max(slots[i].Sharpe, 0) / positive
+	}
+	clampAllocatorTargets(target, slots, scoredPool, c.minWeight()*pool, c.maxWeight()*pool)
+
+	delta := make(map[int]float64, len(scored))
+	var moved float64
+	for _, i := range scored {
+		delta[i] = target[i] - slots[i].Capital
+		if delta[i] > 0 {
+			moved += delta[i]
+		}
+	}
+	if moved < allocatorMinTransferUSD {
+		return nil
+	}
+	scale := 1.0
+	if limit := c.maxTurnover() * pool; moved > limit {
+		scale = limit / moved
+	}
+	for _, i := range scored {
+		if d := delta[i] * scale; d < 0 && -d > slots[i].Cash {
+			scale *= math.Max(slots[i].Cash, 0) / -d
+		}
+	}
+	if moved*scale < allocatorMinTransferUSD {
+		return nil
+	}
+
+	type leg struct {
+		id     string
+		amount float64
+	}
+	var donors, receivers []leg
+	for _, i := range scored {
+		switch d := delta[i] * scale; {
+		case d < 0:
+			donors = append(donors, leg{slots[i].ID, -d})
+		case d > 0:
+			receivers = append(receivers, leg{slots[i].ID, d})
+		}
+	}
+	sort.Slice(donors, func(a, b int) bool { return donors[a].id < donors[b].id })
+	sort.Slice(receivers, func(a, b int) bool { return receivers[a].id < receivers[b].id })
+	var out []CapitalTransfer
+	for di, ri := 0, 0; di < len(donors) && ri < len(receivers); {
+		amt := math.Min(donors[di].amount, receivers[ri].amount)
+		if amt >= 0.005 {
+			out = append(out, CapitalTransfer{From: donors[di].id, To: receivers[ri].id, AmountUSD: math.Round(amt*100) / 100})
+		}
+		donors[di].amount -= amt
+		receivers[ri].amount -= amt
+		if donors[di].amount < 0.005 {
+			di++
+		}
+		if receivers[ri].amount < 0.005 {
+			ri++
+		}
+	}
+	return out
+}
+
+// clampAllocatorTargets pins targets outside [lo, hi] to the bound and
+// re-spreads the remainder over the free targets in proportion, until every
+// target is in range or all are pinned.
+func clampAllocatorTargets(target map[int]float64, slots []allocatorSlot, total, lo, hi float64) {
+	pinned := make(map[int]bool, len(target))
+	for range target {
+		var pinnedSum, freeSum float64
+		for i, t := range target {
+			if pinned[i] {
+				pinnedSum += t
+			} else {
+				freeSum += t
+			}
+		}
+		remaining := total - pinnedSum
+		changed := false
+		for i, t := range target {
+			if pinned[i] {
+				continue
+			}
+			v := remaining / float64(countFree(target, pinned))
+			if freeSum > 0 {
+				v = t * remaining / freeSum
+			}
+			target[i] = v
+		}
+		for i, t := range target {
+			if pinned[i] {
+				continue
+			}
+			if t < lo {
+				target[i], pinned[i], changed = lo, true, true
+			} else if t > hi {
+				target[i], pinned[i], changed = hi, true, true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+func countFree(target map[int]float64, pinned map[int]bool) int {
+	n := 0
+	for i := range target {
+		if !pinned[i] {
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// allocatorSlots builds allocator inputs from state and the cycle's
+// pre-loaded closed positions. Strategies without state are skipped.
+func allocatorSlots(c *CapitalAllocatorConfig, state *AppState, closedByStrategy map[string][]ClosedPosition, rfr float64, now time.Time) []allocatorSlot {
+	since := now.Add(-time.Duration(c.lookbackDays()) * 24 * time.Hour)
+	var slots []allocatorSlot
+	for _, id := range c.Strategies {
+		ss := state.Strategies[id]
+		if ss == nil || ss.InitialCapital <= 0 {
+			continue
+		}
+		var window []ClosedPosition
+		for _, cp := range closedByStrategy[id] {
+			if !cp.ClosedAt.Before(since) {
+				window = append(window, cp)
+			}
+		}
+		returns, days := dailyReturnsContinuous(window, ss.InitialCapital)
+		slots = append(slots, allocatorSlot{
+			ID:      id,
+			Capital: ss.InitialCapital,
+			Cash:    ss.Cash,
+			Sharpe:  annualizedSharpeFromDaily(returns, rfr),
+			Scored:  days >= allocatorMinScoredDays,
+		})
+	}
+	return slots
+}
+
+// capitalAllocator tracks when the last rebalance ran.
+type capitalAllocator struct {
+	lastRun time.Time
+}
+
+// newCapitalAllocator seeds the schedule from the newest recorded transfer
+// so a restart does not rebalance early.
+func newCapitalAllocator(sdb *StateDB) *capitalAllocator {
+	a := &capitalAllocator{}
+	if last, err := sdb.LastCapitalTransferAt(); err == nil {
+		a.lastRun = last
+	}
+	return a
+}
+
+// MaybeRun rebalances when the allocator is enabled and its interval has
+// elapsed. Caller holds mu (write). Transfers are applied to state only
+// after they are persisted, so a failed write leaves state untouched.
+// Returns the log line for a rebalance, or "".
+func (a *capitalAllocator) MaybeRun(cfg *Config, state *AppState, sdb *StateDB, closedByStrategy map[string][]ClosedPosition, now time.Time) (string, error) {
+	c := cfg.CapitalAllocator
+	if c == nil || !c.Enabled || sdb == nil {
+		return "", nil
+	}
+	if !a.lastRun.IsZero() && now.Sub(a.lastRun) < c.interval() {
+		return "", nil
+	}
+	a.lastRun = now
+	slots := allocatorSlots(c, state, closedByStrategy, RiskFreeRateOrDefault(cfg), now)
+	transfers := planCapitalTransfers(slots, c)
+	if len(transfers) == 0 {
+		return "", nil
+	}
+	parts := make([]string, 0, len(slots))
+	for _, s := range slots {
+		if s.Scored {
+			parts = append(parts, fmt.Sprintf("%s=%.2f", s.ID, s.Sharpe))
+		} else {
+			parts = append(parts, s.ID+"=n/a")
+		}
+	}
+	reason := fmt.Sprintf("rolling %dd Sharpe: %s", c.lookbackDays(), strings.Join(parts, " "))
+	for i := range transfers {
+		transfers[i].At = now
+		transfers[i].Reason = reason
+	}
+	balances := make(map[string][2]float64)
+	for _, t := range transfers {
+		from, to := state.Strategies[t.From], state.Strategies[t.To]
+		fb, ok := balances[t.From]
+		if !ok {
+			fb = [2]float64{from.Cash, from.InitialCapital}
+		}
+		tb, ok := balances[t.To]
+		if !ok {
+			tb = [2]float64{to.Cash, to.InitialCapital}
+		}
+		balances[t.From] = [2]float64{fb[0] - t.AmountUSD, fb[1] - t.AmountUSD}
+		balances[t.To] = [2]float64{tb[0] + t.AmountUSD, tb[1] + t.AmountUSD}
+	}
+	if err := sdb.RecordCapitalTransfers(transfers, balances); err != nil {
+		return "", err
+	}
+	lines := make([]string, 0, len(transfers))
+	for _, t := range transfers {
+		lines = append(lines, fmt.Sprintf("%s -> %s $%.2f", t.From, t.To, t.AmountUSD))
+	}
+	for id, b := range balances {
+		state.Strategies[id].Cash = b[0]
+		state.Strategies[id].InitialCapital = b[1]
+	}
+	return fmt.Sprintf("capital rebalance (%s): %s", reason, strings.Join(lines, "; ")), nil
+}
+
+// RecordCapitalTransfers appends transfers and writes each touched
+// strategy's new cash and initial_capital ({cash, initial_capital}) in one
+// transaction, so the ledger and the baselines never disagree.
+func (sdb *StateDB) RecordCapitalTransfers(transfers []CapitalTransfer, balances map[string][2]float64) error {
+	if sdb == nil || sdb.db == nil {
+		return fmt.Errorf("state db unavailable")
+	}
+	tx, err := sdb.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	for _, t := range transfers {
+		if _, err := tx.Exec(`INSERT INTO capital_transfers (transferred_at, from_strategy, to_strategy, amount_usd, reason)
+			VALUES (?, ?, ?, ?, ?)`, formatTime(t.At), t.From, t.To, t.AmountUSD, t.Reason); err != nil {
+			return fmt.Errorf("insert capital transfer: %w", err)
+		}
+	}
+	for id, b := range balances {
+		if b[1] <= 0 {
+			return fmt.Errorf("capital transfer would leave %s with initial_capital $%.2f", id, b[1])
+		}
+		if _, err := tx.Exec("UPDATE strategies SET cash = ?, initial_capital = ? WHERE id = ?", b[0], b[1], id); err != nil {
+			return fmt.Errorf("update capital for %s: %w", id, err)
+		}
+	}
+	if err := stampStateChecksum(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LastCapitalTransferAt returns the newest capital_transfers timestamp, or
+// the zero time when none are recorded.
+func (sdb *StateDB) LastCapitalTransferAt() (time.Time, error) {
+	if sdb == nil || sdb.db == nil {
+		return time.Time{}, fmt.Errorf("state db unavailable")
+	}
+	var last sql.NullString
+	if err := sdb.db.QueryRow("SELECT MAX(transferred_at) FROM capital_transfers").Scan(&last); err != nil {
+		return time.Time{}, fmt.Errorf("query last capital transfer: %w", err)
+	}
+	return parseTime(last.String), nil
+}
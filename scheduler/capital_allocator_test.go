@@ -0,0 +1,149 @@
+package main
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCapitalAllocatorErrors(t *testing.T) {
+	strategies := []StrategyConfig{
+		{ID: "a", Args: []string{"sma", "BTC/USDT", "1h"}},
+		{ID: "b", Args: []string{"rsi", "BTC/USDT", "1h"}},
+		{ID: "live", Args: []string{"sma", "BTC", "1h", "--mode=live"}},
+		{ID: "pct", CapitalPct: 0.5, Args: []string{"sma", "BTC/USDT", "1h"}},
+	}
+	cases := []struct {
+		name string
+		c    CapitalAllocatorConfig
+		want string
+	}{
+		{"valid", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "b"}}, ""},
+		{"too few", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a"}}, "at least 2"},
+		{"unknown", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "zz"}}, "unknown strategy"},
+		{"live", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "live"}}, "is live"},
+		{"capital_pct", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "pct"}}, "capital_pct"},
+		{"short interval", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "b"}, Interval: "10m"}, "interval"},
+		{"min too high", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "b"}, MinWeightPct: 60}, "exceeds 100%"},
+		{"max too low", CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "b"}, MaxWeightPct: 40}, "below 100%"},
+		{"disabled ignored", CapitalAllocatorConfig{Strategies: []string{"zz"}}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.c
+			errs := capitalAllocatorErrors(&c, strategies)
+			if tc.want == "" {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(errs, "\n"), tc.want) {
+				t.Fatalf("errors %v missing %q", errs, tc.want)
+			}
+		})
+	}
+}
+
+func sumTransfers(ts []CapitalTransfer) map[string]float64 {
+	net := make(map[string]float64)
+	for _, tr := range ts {
+		net[tr.From] -= tr.AmountUSD
+		net[tr.To] += tr.AmountUSD
+	}
+	return net
+}
+
+func TestPlanCapitalTransfersShiftsTowardSharpe(t *testing.T) {
+	c := &CapitalAllocatorConfig{MaxTurnoverPct: 100, MinWeightPct: 10, MaxWeightPct: 100}
+	slots := []allocatorSlot{
+		{ID: "a", Capital: 1000, Cash: 1000, Sharpe: 3, Scored: true},
+		{ID: "b", Capital: 1000, Cash: 1000, Sharpe: 1, Scored: true},
+		{ID: "c", Capital: 1000, Cash: 1000, Sharpe: -2, Scored: true},
+		{ID: "d", Capital: 1000, Cash: 1000, Scored: false},
+	}
+	net := sumTransfers(planCapitalTransfers(slots, c))
+	// Scored pool $3000: c is floored at 10% of $4000, the other $2600 splits 3:1.
+	want := map[string]float64{"a": 950, "b": -350, "c": -600}
+	for id, w := range want {
+		if math.Abs(net[id]-w) > 0.02 {
+			t.Fatalf("net[%s] = %.2f, want %.2f (all: %v)", id, net[id], w, net)
+		}
+	}
+	if net["d"] != 0 {
+		t.Fatalf("unscored strategy moved: %v", net)
+	}
+}
+
+func TestPlanCapitalTransfersTurnoverAndCashLimits(t *testing.T) {
+	slots := []allocatorSlot{
+		{ID: "a", Capital: 1000, Cash: 1000, Sharpe: 1, Scored: true},
+		{ID: "b", Capital: 1000, Cash: 1000, Sharpe: 0, Scored: true},
+	}
+	c := &CapitalAllocatorConfig{MaxTurnoverPct: 10}
+	net := sumTransfers(planCapitalTransfers(slots, c))
+	if math.Abs(net["a"]-200) > 0.02 {
+		t.Fatalf("turnover-limited move = %.2f, want 200", net["a"])
+	}
+
+	slots[1].Cash = 50 // b is mostly in a position
+	net = sumTransfers(planCapitalTransfers(slots, c))
+	if math.Abs(net["a"]-50) > 0.02 {
+		t.Fatalf("cash-limited move = %.2f, want 50", net["a"])
+	}
+
+	slots[0].Sharpe = -1
+	if got := planCapitalTransfers(slots, c); got != nil {
+		t.Fatalf("no positive Sharpe should be a no-op, got %v", got)
+	}
+}
+
+func TestCapitalAllocatorMaybeRunPersistsTransfers(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	state := NewAppState()
+	state.Strategies["a"] = &StrategyState{ID: "a", Type: "spot", Cash: 1000, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	state.Strategies["b"] = &StrategyState{ID: "b", Type: "spot", Cash: 1000, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var aClosed, bClosed []ClosedPosition
+	for d := 1; d <= 10; d++ {
+		at := now.Add(-time.Duration(d) * 24 * time.Hour)
+		aClosed = append(aClosed, ClosedPosition{ClosedAt: at, RealizedPnL: float64(10 + d%3)})
+		bClosed = append(bClosed, ClosedPosition{ClosedAt: at, RealizedPnL: float64(d%3 - 1)})
+	}
+	cfg := &Config{CapitalAllocator: &CapitalAllocatorConfig{Enabled: true, Strategies: []string{"a", "b"}}}
+	a := newCapitalAllocator(db)
+	msg, err := a.MaybeRun(cfg, state, db, map[string][]ClosedPosition{"a": aClosed, "b": bClosed}, now)
+	if err != nil || msg == "" {
+		t.Fatalf("MaybeRun = %q, %v; want a rebalance", msg, err)
+	}
+	if got := state.Strategies["a"].InitialCapital; math.Abs(got-1200) > 0.02 {
+		t.Fatalf("a baseline = %.2f, want 1200", got)
+	}
+	if got := state.Strategies["b"].Cash; math.Abs(got-800) > 0.02 {
+		t.Fatalf("b cash = %.2f, want 800", got)
+	}
+	if err := db.VerifyIntegrity(); err != nil {
+		t.Fatalf("transfer tx left state unverifiable: %v", err)
+	}
+	if last, err := db.LastCapitalTransferAt(); err != nil || !last.Equal(now) {
+		t.Fatalf("LastCapitalTransferAt = %v, %v; want %v", last, err, now)
+	}
+	if msg, _ := a.MaybeRun(cfg, state, db, nil, now.Add(time.Hour)); msg != "" {
+		t.Fatalf("rebalance before interval elapsed: %q", msg)
+	}
+	if restarted := newCapitalAllocator(db); !restarted.lastRun.Equal(now) {
+		t.Fatalf("restarted allocator lastRun = %v, want %v", restarted.lastRun, now)
+	}
+}
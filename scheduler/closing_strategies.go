@@ -41,7 +41,7 @@ func fetchCloseRegistryCatalog() ([]closeRegistryEntry, error) {
 	}
 	stdout, stderr, err := runPythonReadOnly("shared_tools/close_registry_loader.py", []string{"--list-json"})
 	if err != nil {
-		return nil, fmt.Errorf("close registry dump failed: %w (stderr: %s)", err, strings.TrimSpace(string(stderr)))
+		return nil, fmt.Errorf("close registry dump failed: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 	}
 	var entries []closeRegistryEntry
 	if jsonErr := json.Unmarshal(stdout, &entries); jsonErr != nil {
@@ -0,0 +1,377 @@
+package main
+
+// compare: side-by-side A/B report for two strategies (#4976).
+//
+// `go-trader compare <id-a> <id-b> [--days N]` and each weekly_digest.compare
+// pair render the same report over the last N days (default 30):
+//   - equity: a sparkline and the return of each strategy's daily value
+//     series (the benchmark track, #4927), with baseline changes netted out
+//     so deposits and allocator transfers are not performance;
+//   - max drawdown of that capital-adjusted curve;
+//   - trade stats from the trades table's close legs: count, win rate,
+//     profit factor and net PnL;
+//   - realized Sharpe (ComputeSharpeRatio over closes in the window);
+//   - Pearson correlation of the two strategies' daily returns, on the days
+//     both have a point.
+// A closing "Leader" line names the strategy ahead on more of return,
+// drawdown, Sharpe and net PnL — a prompt for the kill/keep call, not a
+// verdict.
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultCompareDays is the A/B lookback when --days is not given.
+const defaultCompareDays = 30
+
+// sparklineMaxPoints caps the equity sparkline width.
+const sparklineMaxPoints = 30
+
+// abSide is one strategy's half of an A/B comparison.
+type abSide struct {
+	id        string
+	curve     []float64 // capital-adjusted growth index, 1 at the window start
+	returnPct float64
+	maxDDPct  float64
+	trades    int
+	wins      int
+	grossWin  float64
+	grossLoss float64 // positive
+	netPnL    float64
+	sharpe    float64
+}
+
+// abComparison is a rendered-ready A/B report.
+type abComparison struct {
+	days        int
+	a, b        abSide
+	correlation float64
+	corrDays    int
+}
+
+// compareEquityCurve returns the capital-adjusted growth index and the
+// daily returns keyed by date, for track points on or after since (a
+// "2006-01-02" date).
+func compareEquityCurve(track *BenchmarkTrack, since string) ([]float64, map[string]float64) {
+	returns := make(map[string]float64)
+	if track == nil {
+		return nil, returns
+	}
+	var pts []BenchmarkPoint
+	for _, p := range track.Points {
+		if p.Date >= since {
+			pts = append(pts, p)
+		}
+	}
+	if len(pts) == 0 {
+		return nil, returns
+	}
+	curve := []float64{1}
+	for i := 1; i < len(pts); i++ {
+		prev, cur := pts[i-1], pts[i]
+		r := 0.0
+		if prev.Value > 0 {
+			r = ((cur.Value - prev.Value) - (cur.Capital - prev.Capital)) / prev.Value
+		}
+		returns[cur.Date] = r
+		curve = append(curve, curve[len(curve)-1]*(1+r))
+	}
+	return curve, returns
+}
+
+// curveMaxDrawdownPct is the largest peak-to-trough fall of curve, in percent.
+func curveMaxDrawdownPct(curve []float64) float64 {
+	peak, worst := 0.0, 0.0
+	for _, v := range curve {
+		peak = math.Max(peak, v)
+		if peak > 0 {
+			worst = math.Max(worst, (peak-v)/peak*100)
+		}
+	}
+	return worst
+}
+
+// returnCorrelation is the Pearson correlation of a and b over their common
+// dates. n < 3 or a flat series yields 0.
+func returnCorrelation(a, b map[string]float64) (float64, int) {
+	var xs, ys []float64
+	for d, x := range a {
+		if y, ok := b[d]; ok {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	n := len(xs)
+	if n < 3 {
+		return 0, n
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(n)
+	my /= float64(n)
+	var cov, vx, vy float64
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+		vx += (xs[i] - mx) * (xs[i] - mx)
+		vy += (ys[i] - my) * (ys[i] - my)
+	}
+	// A constant series leaves only rounding noise in the variance.
+	if vx <= 1e-18 || vy <= 1e-18 {
+		return 0, n
+	}
+	return cov / math.Sqrt(vx*vy), n
+}
+
+// sparkline renders vals as block characters, downsampled to
+// sparklineMaxPoints.
+func sparkline(vals []float64) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	if len(vals) > sparklineMaxPoints {
+		sampled := make([]float64, sparklineMaxPoints)
+		for i := range sampled {
+			sampled[i] = vals[i*(len(vals)-1)/(sparklineMaxPoints-1)]
+		}
+		vals = sampled
+	}
+	const bars = "▁▂▃▄▅▆▇█"
+	runes := []rune(bars)
+	lo, hi := vals[0], vals[0]
+	for _, v := range vals {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range vals {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(runes)-1))
+		}
+		sb.WriteRune(runes[idx])
+	}
+	return sb.String()
+}
+
+// buildABSide fills one side from its state, close-leg trades and closed
+// positions inside the window.
+func buildABSide(sc StrategyConfig, s *StrategyState, trades []Trade, closed []ClosedPosition, since time.Time, rf float64) (abSide, map[string]float64) {
+	side := abSide{id: sc.ID}
+	var returns map[string]float64
+	if s != nil {
+		side.curve, returns = compareEquityCurve(s.Benchmark, since.UTC().Format("2006-01-02"))
+		side.sharpe = ComputeSharpeRatio(closed, EffectiveInitialCapital(sc, s), rf)
+	}
+	if n := len(side.curve); n > 0 {
+		side.returnPct = (side.curve[n-1] - 1) * 100
+		side.maxDDPct = curveMaxDrawdownPct(side.curve)
+	}
+	for _, t := range trades {
+		if !t.IsClose || t.TradeType == "funding" {
+			continue
+		}
+		pnl := tradeNetPnL(t)
+		side.trades++
+		side.netPnL += pnl
+		if pnl > 0 {
+			side.wins++
+			side.grossWin += pnl
+		} else {
+			side.grossLoss -= pnl
+		}
+	}
+	return side, returns
+}
+
+// loadABComparison reads both strategies' window from state and db.
+func loadABComparison(cfg *Config, state *AppState, db *StateDB, idA, idB string, days int, now time.Time) (abComparison, error) {
+	since := now.AddDate(0, 0, -days)
+	c := abComparison{days: days}
+	var returns [2]map[string]float64
+	for i, id := range []string{idA, idB} {
+		sc, ok := findStrategyConfig(cfg, id)
+		if !ok {
+			return c, fmt.Errorf("strategy %q not found in config", id)
+		}
+		trades, _, err := db.QueryTradeHistory(id, "", since, now, 500, 0)
+		if err != nil {
+			return c, fmt.Errorf("%s trades: %w", id, err)
+		}
+		closed, _, err := db.QueryClosedPositions(id, "", since, now, sharpeLookbackLimit, 0)
+		if err != nil {
+			return c, fmt.Errorf("%s closed positions: %w", id, err)
+		}
+		side, r := buildABSide(sc, state.Strategies[id], trades, closed, since, RiskFreeRateOrDefault(cfg))
+		returns[i] = r
+		if i == 0 {
+			c.a = side
+		} else {
+			c.b = side
+		}
+	}
+	c.correlation, c.corrDays = returnCorrelation(returns[0], returns[1])
+	return c, nil
+}
+
+func (s abSide) winRate() float64 {
+	if s.trades == 0 {
+		return 0
+	}
+	return float64(s.wins) / float64(s.trades) * 100
+}
+
+func (s abSide) profitFactor() string {
+	if s.grossLoss <= 0 {
+		if s.grossWin > 0 {
+			return "∞"
+		}
+		return "—"
+	}
+	return fmt.Sprintf("%.2f", s.grossWin/s.grossLoss)
+}
+
+// abLeader names the side ahead on more of return, drawdown, Sharpe and net
+// PnL, with the metrics it leads on; "" on a tie.
+func abLeader(c abComparison) (string, []string) {
+	var aWins, bWins []string
+	score := func(name string, aBetter, bBetter bool) {
+		if aBetter {
+			aWins = append(aWins, name)
+		} else if bBetter {
+			bWins = append(bWins, name)
+		}
+	}
+	score("return", c.a.returnPct > c.b.returnPct, c.b.returnPct > c.a.returnPct)
+	score("drawdown", c.a.maxDDPct < c.b.maxDDPct, c.b.maxDDPct < c.a.maxDDPct)
+	score("Sharpe", c.a.sharpe > c.b.sharpe, c.b.sharpe > c.a.sharpe)
+	score("net PnL", c.a.netPnL > c.b.netPnL, c.b.netPnL > c.a.netPnL)
+	switch {
+	case len(aWins) > len(bWins):
+		return c.a.id, aWins
+	case len(bWins) > len(aWins):
+		return c.b.id, bWins
+	}
+	return "", nil
+}
+
+// formatABComparison renders the report.
+func formatABComparison(c abComparison) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "⚖️ **A/B: `%s` vs `%s`** (%dd)\n", c.a.id, c.b.id, c.days)
+	equity := func(s abSide) string {
+		if len(s.curve) < 2 {
+			return fmt.Sprintf("`%s` no daily values yet", s.id)
+		}
+		return fmt.Sprintf("`%s` %s %+.2f%%", s.id, sparkline(s.curve), s.returnPct)
+	}
+	fmt.Fprintf(&sb, "Equity: %s · %s\n", equity(c.a), equity(c.b))
+	fmt.Fprintf(&sb, "Max drawdown: `%s` %.2f%% · `%s` %.2f%%\n", c.a.id, c.a.maxDDPct, c.b.id, c.b.maxDDPct)
+	stats := func(s abSide) string {
+		return fmt.Sprintf("`%s` %d closes, %.0f%% win, PF %s, net $%+.2f", s.id, s.trades, s.winRate(), s.profitFactor(), s.netPnL)
+	}
+	fmt.Fprintf(&sb, "Trades: %s · %s\n", stats(c.a), stats(c.b))
+	fmt.Fprintf(&sb, "Sharpe: `%s` %s · `%s` %s\n", c.a.id, fmtSharpe(c.a.sharpe), c.b.id, fmtSharpe(c.b.sharpe))
+	if c.corrDays < 3 {
+		fmt.Fprintf(&sb, "Return correlation: not enough overlapping days (%d)\n", c.corrDays)
+	} else {
+		fmt.Fprintf(&sb, "Return correlation: %+.2f over %d days\n", c.correlation, c.corrDays)
+	}
+	if leader, on := abLeader(c); leader != "" {
+		fmt.Fprintf(&sb, "Leader: `%s` (%s)", leader, strings.Join(on, ", "))
+	} else {
+		sb.WriteString("Leader: none — the two are level")
+	}
+	return sb.String()
+}
+
+// weeklyDigestCompareErrors checks weekly_digest.compare: each entry is two
+// distinct configured strategy IDs.
+func weeklyDigestCompareErrors(c *WeeklyDigestConfig, strategies []StrategyConfig) []string {
+	if c == nil {
+		return nil
+	}
+	known := make(map[string]bool, len(strategies))
+	for _, sc := range strategies {
+		known[sc.ID] = true
+	}
+	var errs []string
+	for i, pair := range c.Compare {
+		if len(pair) != 2 || pair[0] == pair[1] {
+			errs = append(errs, fmt.Sprintf("weekly_digest.compare[%d] must be two different strategy IDs, got %v", i, pair))
+			continue
+		}
+		for _, id := range pair {
+			if !known[id] {
+				errs = append(errs, fmt.Sprintf("weekly_digest.compare[%d]: unknown strategy %q", i, id))
+			}
+		}
+	}
+	return errs
+}
+
+// digestABComparisons renders one A/B report per weekly_digest.compare pair.
+func digestABComparisons(in weeklyDigestInput) string {
+	if in.cfg == nil || in.cfg.WeeklyDigest == nil || in.db == nil || in.state == nil {
+		return ""
+	}
+	var parts []string
+	for _, pair := range in.cfg.WeeklyDigest.Compare {
+		if len(pair) != 2 {
+			continue
+		}
+		c, err := loadABComparison(in.cfg, in.state, in.db, pair[0], pair[1], defaultCompareDays, in.now)
+		if err != nil {
+			fmt.Printf("[WARN] weekly digest: A/B %s vs %s: %v\n", pair[0], pair[1], err)
+			continue
+		}
+		parts = append(parts, formatABComparison(c))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// runCompare implements `go-trader compare <id-a> <id-b> [--days N]`.
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	days := fs.Int("days", defaultCompareDays, "Lookback in days")
+
+	args = reorderArgsForPositional(args, collectBoolFlagNames(fs))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 || *days <= 0 {
+		fmt.Fprintln(os.Stderr, "Usage: go-trader compare <strategy-a> <strategy-b> [--days N]")
+		return 2
+	}
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+	state, err := stateDB.LoadState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load state: %v\n", err)
+		return 1
+	}
+	c, err := loadABComparison(cfg, state, stateDB, fs.Arg(0), fs.Arg(1), *days, time.Now().UTC())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	fmt.Println(formatABComparison(c))
+	return 0
+}
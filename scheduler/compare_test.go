@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareEquityCurve(t *testing.T) {
+	track := &BenchmarkTrack{Points: []BenchmarkPoint{
+		{Date: "2026-05-30", Value: 900, Capital: 1000}, // before the window
+		{Date: "2026-06-01", Value: 1000, Capital: 1000},
+		{Date: "2026-06-02", Value: 1100, Capital: 1000},
+		{Date: "2026-06-03", Value: 1590, Capital: 1500}, // +$500 deposit, -$10 trading
+		{Date: "2026-06-04", Value: 1192, Capital: 1500},
+	}}
+	curve, returns := compareEquityCurve(track, "2026-06-01")
+	if len(curve) != 4 || len(returns) != 3 {
+		t.Fatalf("curve=%v returns=%v", curve, returns)
+	}
+	if r := returns["2026-06-03"]; r > -0.0090 || r < -0.0092 {
+		t.Errorf("deposit day return = %v, want about -0.91%%", r)
+	}
+	if dd := curveMaxDrawdownPct(curve); dd < 25.6 || dd > 25.8 {
+		t.Errorf("max drawdown = %v", dd)
+	}
+
+	corr, n := returnCorrelation(
+		map[string]float64{"a": 0.01, "b": 0.02, "c": -0.01, "d": 0.03},
+		map[string]float64{"a": 0.02, "b": 0.04, "c": -0.02, "e": 0.5},
+	)
+	if n != 3 || corr < 0.999 {
+		t.Errorf("correlation = %v over %d", corr, n)
+	}
+	if got := sparkline([]float64{1, 2, 3}); got != "▁▄█" {
+		t.Errorf("sparkline = %q", got)
+	}
+}
+
+func TestLoadABComparison(t *testing.T) {
+	db := openTestDB(t)
+	now := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	cfg := &Config{Strategies: []StrategyConfig{
+		{ID: "sma-btc", Type: "spot", Capital: 1000},
+		{ID: "rsi-btc", Type: "spot", Capital: 1000},
+	}}
+	state := &AppState{Strategies: map[string]*StrategyState{}}
+	for i, id := range []string{"sma-btc", "rsi-btc"} {
+		track := &BenchmarkTrack{Symbol: "BTC/USDT"}
+		v := 1000.0
+		for d, r := range []float64{0.02, -0.01, 0.03, 0.01, 0.02, 0} {
+			track.Points = append(track.Points, BenchmarkPoint{Date: now.AddDate(0, 0, d-6).Format("2006-01-02"), Value: v, Capital: 1000})
+			if i == 1 {
+				r = -r
+			}
+			v *= 1 + r
+		}
+		state.Strategies[id] = &StrategyState{ID: id, Benchmark: track}
+	}
+	for _, tr := range []struct {
+		id  string
+		pnl float64
+	}{{"sma-btc", 30}, {"sma-btc", -10}, {"rsi-btc", -20}} {
+		if err := db.InsertTrade(tr.id, Trade{Timestamp: now.AddDate(0, 0, -2), StrategyID: tr.id, Symbol: "BTC/USDT", Side: "sell", IsClose: true, RealizedPnL: tr.pnl, TradeType: "spot"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := loadABComparison(cfg, state, db, "sma-btc", "rsi-btc", 30, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.a.trades != 2 || c.a.wins != 1 || c.b.trades != 1 || c.a.netPnL != 20 {
+		t.Fatalf("trade stats a=%+v b=%+v", c.a, c.b)
+	}
+	out := formatABComparison(c)
+	for _, want := range []string{
+		"**A/B: `sma-btc` vs `rsi-btc`** (30d)",
+		"`sma-btc` ▁▂▁▄▅█ +7.15%",
+		"Return correlation: -1.00 over 5 days",
+		"`rsi-btc` 1 closes, 0% win, PF 0.00, net $-20.00",
+		"PF 3.00",
+		"Leader: `sma-btc` (return, drawdown, net PnL)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if _, err := loadABComparison(cfg, state, db, "sma-btc", "nope", 30, now); err == nil {
+		t.Error("unknown strategy accepted")
+	}
+
+	wd := &WeeklyDigestConfig{Enabled: true, Compare: [][]string{{"sma-btc", "rsi-btc"}, {"sma-btc", "sma-btc"}, {"sma-btc", "gone"}}}
+	if errs := weeklyDigestCompareErrors(wd, cfg.Strategies); len(errs) != 2 {
+		t.Errorf("compare errors = %v", errs)
+	}
+}
@@ -62,7 +62,7 @@ type PortfolioRiskConfig struct {
 	MaxDrawdownPct   float64 `json:"max_drawdown_pct"`             // kill switch threshold (default 25)
 	MaxNotionalUSD   float64 `json:"max_notional_usd"`             // 0 = disabled. #42/#1344 — when total gross notional exceeds the cap, position-INCREASING opens are held (per-signal via pausedBlocksSignal; options opens dropped; manual open/add/limit-open refuse). Closes, reductions, and SL/TP maintenance keep running; nothing is force-closed. Restart-required (not SIGHUP-hot-reloadable).
 	WarnThresholdPct float64 `json:"warn_threshold_pct,omitempty"` // % of MaxDrawdownPct to warn (default 60)
-	DailyMaxLossUSD  float64 `json:"daily_max_loss_usd,omitempty"` // #1269 — hard daily loss limit in USD (0 = disabled). When the day's aggregate PRE-FEE realized loss across all strategies reaches this, position-increasing actions (fresh opens, adds, flips, manual-open/add) are held until the UTC rollover; closes and SL/TP management keep running and nothing is force-closed. Hot-reloadable, including while tripped. Portfolio-level only — ignored inside platforms.<name>.risk overrides.
+	DailyMaxLossUSD  float64 `json:"daily_max_loss_usd,omitempty"` // #1269 — hard daily loss limit in USD (0 = disabled). When the day's aggregate PRE-FEE realized loss across all strategies reaches this, position-increasing actions (fresh opens, adds, flips, manual-open/add) are held until the daily reset (daily_reset_hour); closes and SL/TP management keep running and nothing is force-closed. Hot-reloadable, including while tripped. Portfolio-level only — ignored inside platforms.<name>.risk overrides.
 	DailyMaxLossPct  float64 `json:"daily_max_loss_pct,omitempty"` // #1269 — same limit as a percent of the sum of per-strategy initial_capital (0 = disabled). Both arms may be set: the lower resolved USD threshold wins. The pct arm cannot evaluate when no strategy has initial_capital > 0 (surfaced in /status). Portfolio-level only.
 	// MaxSameDirectionNotionalUSD (#1270, 0 = disabled) caps aggregate SAME-DIRECTION
 	// signed exposure in the crypto bucket (all spot/perps/manual positions plus
@@ -79,11 +79,63 @@ type PortfolioRiskConfig struct {
 	// over-concentrated asset are held, and only in its net direction. Blocking-only;
 	// hot-reloadable via SIGHUP. Portfolio-level only.
 	MaxAssetConcentrationPct float64 `json:"max_asset_concentration_pct,omitempty"`
+	// AssetConcentrationPct overrides max_asset_concentration_pct per underlying
+	// (keys are assets as strategies trade them, e.g. "BTC", matched
+	// case-insensitively). A positive value replaces the default cap for that
+	// asset — and arms the concentration gate on its own when the default is 0;
+	// an explicit 0 exempts the asset. Same net-delta basis (spot + perps +
+	// option deltas combined). Hot-reloadable; portfolio-level only.
+	AssetConcentrationPct map[string]float64 `json:"asset_concentration_pct,omitempty"`
+	// CorrelationGroups declares named sets of assets treated as one exposure
+	// (e.g. majors = BTC,ETH,SOL) with their own same-direction and net
+	// limits, enforced by the same gate as the #1270 arms. Hot-reloadable;
+	// portfolio-level only.
+	CorrelationGroups []CorrelationGroupConfig `json:"correlation_groups,omitempty"`
+	// DrawdownWindowDays (0 = all-time peak) measures the kill-switch equity
+	// drawdown from the highest daily portfolio value of the last N days
+	// instead of the all-time high-water mark. The all-time peak holds until
+	// the persisted daily history covers the whole window. Hot-reloadable.
+	DrawdownWindowDays int `json:"drawdown_window_days,omitempty"`
+	// Historical VaR (portfolio_var.go) over the persisted daily history.
+	// VaRConfidencePct (default 95) and VaRLookbackDays (default 90) shape the
+	// estimate shown in /status and the summaries; MaxVaRPct (0 = disabled)
+	// holds position-increasing actions while the 1-day VaR exceeds that
+	// percent of portfolio value. Hot-reloadable; portfolio-level only.
+	VaRConfidencePct float64 `json:"var_confidence_pct,omitempty"`
+	VaRLookbackDays  int     `json:"var_lookback_days,omitempty"`
+	MaxVaRPct        float64 `json:"max_var_pct,omitempty"`
+	// DailyResetHour (0-23, default 0) is the hour, in DailyResetTimezone
+	// (IANA name, empty = UTC), at which the per-day risk counters roll over:
+	// per-strategy DailyPnL/DailyTrades (max_daily_trades) and the daily loss
+	// limit built on them. E.g. 17 + "America/New_York" for a 17:00 ET day.
+	// Hot-reloadable; a change starts a new day for the counters at once.
+	DailyResetHour     int    `json:"daily_reset_hour,omitempty"`
+	DailyResetTimezone string `json:"daily_reset_timezone,omitempty"`
+}
+
+// CorrelationGroupConfig is one portfolio_risk.correlation_groups entry. Assets
+// match strategies' assets (extractAsset, e.g. "BTC") case-insensitively.
+type CorrelationGroupConfig struct {
+	Name   string   `json:"name"`
+	Assets []string `json:"assets"`
+	// MaxSameDirectionNotionalUSD caps the sum of the members' positive
+	// (long) or negative (short) net deltas; new opens in a capped direction
+	// on any member are held. 0 = disabled.
+	MaxSameDirectionNotionalUSD float64 `json:"max_same_direction_notional_usd,omitempty"`
+	// MaxNetExposurePct caps |sum of member net deltas| as a percent of
+	// portfolio value; new opens in the group's net direction are held.
+	// 0 = disabled.
+	MaxNetExposurePct float64 `json:"max_net_exposure_pct,omitempty"`
 }
 
 // PlatformConfig holds per-platform optional risk overrides.
 type PlatformConfig struct {
-	Risk *PortfolioRiskConfig `json:"risk,omitempty"` // overrides portfolio-level defaults
+	Risk    *PortfolioRiskConfig   `json:"risk,omitempty"`    // overrides portfolio-level defaults
+	Symbols map[string]*SymbolSpec `json:"symbols,omitempty"` // per-symbol exchange metadata (tick/lot size, min notional) keyed by the strategy's args[1] symbol; see symbol_spec.go
+	// MinOrderNotionalUSD is the venue's minimum live order notional for
+	// symbols without their own symbols.<symbol>.min_notional_usd. Live
+	// opens below it are skipped before the executor is called.
+	MinOrderNotionalUSD float64 `json:"min_order_notional_usd,omitempty"`
 }
 
 // RegimeConfig controls the market regime detector run once per (symbol, timeframe) cycle.
@@ -196,24 +248,52 @@ type Config struct {
 	Discord                  DiscordConfig              `json:"discord"`
 	Telegram                 TelegramConfig             `json:"telegram,omitempty"`
 	AutoUpdate               string                     `json:"auto_update,omitempty"`           // "off", "daily", "heartbeat" (default: "off")
+	AutoUpdateWindow         string                     `json:"auto_update_window,omitempty"`    // UTC maintenance window for owner-approved upgrades, "[days] HH:MM-HH:MM [UTC]" (e.g. "sat 02:00-04:00 UTC"); empty = apply as soon as approved. Live positions must be flat (or owner override) either way.
+	UpdateChannel            string                     `json:"update_channel,omitempty"`        // "git" (default: fetch + scripts/update.sh) or "releases" (download the go-trader_<os>_<arch> asset from the latest GitHub release; for deployments without git/Go)
+	UpdateRepo               string                     `json:"update_repo,omitempty"`           // GitHub "owner/name" polled by update_channel "releases" (default: "richkuo/go-trader")
+	UpdateSigningKey         string                     `json:"update_signing_key,omitempty"`    // Ed25519 public key (hex/base64); when set, update_channel "releases" requires a valid checksums.txt.sig before swapping in a download
 	LeaderboardPostTime      string                     `json:"leaderboard_post_time,omitempty"` // "HH:MM" in UTC; auto-post daily leaderboard at this time (empty = disabled)
 	Strategies               []StrategyConfig           `json:"strategies"`
 	PortfolioRisk            *PortfolioRiskConfig       `json:"portfolio_risk,omitempty"`
 	Correlation              *CorrelationConfig         `json:"correlation,omitempty"`
 	Regime                   *RegimeConfig              `json:"regime,omitempty"`
+	Ensembles                []EnsembleConfig           `json:"ensembles,omitempty"`          // #4924 — signal ensembles: members vote, one executor per asset trades the consolidated signal (majority/weighted). Restart required to change.
+	CapitalAllocator         *CapitalAllocatorConfig    `json:"capital_allocator,omitempty"`  // #4925 — periodic Sharpe-weighted capital rebalancing across paper strategies, recorded in capital_transfers. Restart required to change.
+	MarketRegime             *MarketRegimeConfig        `json:"market_regime,omitempty"`      // #4926 — Go-side per-asset trend/vol label from cached OHLCV, forwarded as --market-regime and shown in summaries. Restart required to change.
+	GRPC                     *GRPCConfig                `json:"grpc,omitempty"`               // #4935 — authenticated gRPC admin/status API (adminpb/admin.proto); requires STATUS_AUTH_TOKEN. Restart required to change.
+	CORS                     *CORSConfig                `json:"cors,omitempty"`               // #4932 — origins/headers allowed to read the status API cross-origin (GET/HEAD only). Nil = no CORS headers. Hot-reloadable.
+	PriceHistoryDays         *int                       `json:"price_history_days,omitempty"` // #4929 — days of per-cycle price snapshots kept under <db_file>.prices/ and served at /prices/history. Nil → 30; 0 disables recording. Restart required to change. Read via PriceHistoryRetentionDays().
+	IndicatorLogDays         *int                       `json:"indicator_log_days,omitempty"` // #4953 — days of per-cycle check-script indicator lines kept under <db_file>.indicators/<strategy_id>/. Nil or 0 disables (default). Restart required to change. Read via IndicatorLogRetentionDays().
 	Platforms                map[string]*PlatformConfig `json:"platforms,omitempty"`
 	LeaderboardSummaries     []LeaderboardSummaryConfig `json:"leaderboard_summaries,omitempty"`        // #308 — configurable per-channel leaderboards
-	SummaryFrequency         map[string]string          `json:"summary_frequency,omitempty"`            // #30 — per-channel summary cadence; keys match Discord/Telegram channel keys (e.g. "spot", "options", "hyperliquid"). Values: Go duration ("30m", "2h"), alias ("hourly", "every"/"per_check"/"always"), or empty for legacy default (continuous: every channel run; spot: hourly)
+	SummaryFrequency         map[string]string          `json:"summary_frequency,omitempty"`            // #30 — per-channel summary cadence; keys match Discord/Telegram channel keys (e.g. "spot", "options", "hyperliquid"). Values: Go duration ("30m", "2h"), alias ("hourly", "daily", "every"/"per_check"/"always", "on_trade_only"/"on_trade" #4946), or empty for legacy default (continuous: every channel run; spot: hourly)
+	SummaryLayout            SummaryLayouts             `json:"summary_layout,omitempty"`               // #4947 — per-channel summary sections, table columns and row order; keys match summary_frequency ("*" = fallback)
 	RiskFreeRate             *float64                   `json:"risk_free_rate,omitempty"`               // #397 — annualized risk-free rate used in Sharpe-ratio calculations (e.g. 0.02 for 2%). Nil/missing falls back to DefaultAnnualRiskFreeRate; an explicit 0 is respected so backtest comparisons can pin to a 0% benchmark.
 	DefaultStopLossATRMult   *float64                   `json:"default_stop_loss_atr_mult,omitempty"`   // #605 — top-level default applied to HL perps/manual strategies that omit all stop_loss_* / trailing_stop_* fields. Nil/missing falls back to 1.0; explicit values let operators tune the ATR stop without recompiling.
 	ATRMethod                string                     `json:"atr_method,omitempty"`                   // #1277 — global default ATR smoothing method for the standard_atr surface (EntryATR stamping, live market_ctx["atr"], manual fetch-atr, tuner simulate): "simple" (default; frozen legacy rolling mean with the #887 >=100 integer rounding) or "wilder" (published Wilder RMA, never rounded). Per-strategy atr_method overrides. Strategy-internal indicator math is NOT config-driven (see docs/research/1277-wilder-atr-cutover.md). Read via resolveATRMethod(sc, cfg), never directly. Hot-reload: blocked while the affected strategy has open positions (EntryATR/frozen stop geometry must not be re-based mid-position); applies when flat.
 	NotifyTPSLFills          *bool                      `json:"notify_tp_sl_fills,omitempty"`           // #661 — owner DM when HL on-chain TP/SL fills are detected by the reconciler. Nil/missing → enabled; explicit false disables.
 	NotifyRatchetTriggers    *bool                      `json:"notify_ratchet_triggers,omitempty"`      // #1110 — owner DM when a trailing_tp_ratchet* tier clears and tightens the trail. Nil/missing → enabled; explicit false disables.
 	AlertThrottleInterval    string                     `json:"alert_throttle_interval,omitempty"`      // #1266 — fleet-wide re-alert back-off for throttled operator alerts. Go duration ("6h", "30m"); empty → 6h.
+	ScriptFailureAlertAfter  int                        `json:"script_failure_alert_after,omitempty"`   // #4943 — consecutive check-script failures (crash, timeout, unparseable output, script error) per strategy before the owner alert. 0/omitted → 3. Hot-reloadable.
+	ScriptOutputMaxBytes     int                        `json:"script_output_max_bytes,omitempty"`      // #4979 — per-stream cap on captured Python stdout/stderr (stdout keeps its head, stderr its tail, with a truncation marker). 0/omitted → 1 MiB. Hot-reloadable.
+	SignalHistoryDays        *int                       `json:"signal_history_days,omitempty"`          // #4954 — days of per-check signal records (HOLDs and gate-blocked signals included) kept in the state DB and served at /signals. Nil → 14; 0 disables. Restart required to change. Read via SignalHistoryRetentionDays().
 	KillSwitchResetDMTimeout string                     `json:"kill_switch_reset_dm_timeout,omitempty"` // #1368 — AskOwnerDM wait for the portfolio kill-switch reset prompt. Go duration ("6h", "30m"); empty → 6h. Independent of alert_throttle_interval (re-alert back-off ≠ interactive reply wait).
 	TradingViewExport        TradingViewExportConfig    `json:"tradingview_export,omitempty"`           // #3 — optional symbol overrides for TradingView portfolio CSV exports
 	UserDefaults             *UserDefaultsConfig        `json:"user_defaults,omitempty"`                // #1135 — canonical operator override layer for defaults. close → close-evaluator tier ladders; regime_atr → standalone use_defaults-only *_atr_regime owners; manual → manual-open/type=manual defaults. Legacy user_close_defaults/manual_defaults are migrated to this tree at load.
 	Tuning                   *TuningConfig              `json:"tuning,omitempty"`                       // #1382 — retention for #1339 status-server tuning-run artifacts. Nil/omitted ≡ keep-all.
+	Healthcheck              *HealthcheckConfig         `json:"healthcheck,omitempty"`                  // external dead-man's-switch ping after each cycle (healthchecks.io / Uptime Kuma). Nil/empty url ≡ disabled. SIGHUP-adoptable.
+	GoogleSheets             *GoogleSheetsConfig        `json:"google_sheets,omitempty"`                // #4936 — append closed trades + a daily equity row to a Google Sheet (service account). Nil/disabled ≡ off. SIGHUP-adoptable.
+	Pushgateway              *PushgatewayConfig         `json:"pushgateway,omitempty"`                  // #4939 — push /metrics to a Prometheus Pushgateway at the end of a --once run. Nil/empty url ≡ disabled; ignored by the daemon.
+	StaleData                *StaleDataConfig           `json:"stale_data,omitempty"`                   // #4944 — alert (and optionally hold entries) when a strategy's price stops moving or its candles fall behind the timeframe. Nil/disabled ≡ off. SIGHUP-adoptable.
+	PriceSanity              *PriceSanityConfig         `json:"price_sanity,omitempty"`                 // #4960 — refuse a cycle's signal (and alert) when the fetched price jumped more than max_move_pct from the previous cycle. Nil/disabled ≡ off. SIGHUP-adoptable.
+	PriceValidation          *PriceValidationConfig     `json:"price_validation,omitempty"`             // #4961 — cross-check crypto prices across BinanceUS, the HL mid and the Deribit index; median valuation when outvoted, divergence alerts, optional signal refusal. Nil/disabled ≡ off. SIGHUP-adoptable.
+	AlertEscalation          *AlertEscalationConfig     `json:"alert_escalation,omitempty"`             // #4945 — kill-switch / live-execution-failure alerts need an owner DM reply or reaction within ack_window, else escalate to every owner + webhook/email. Nil/disabled ≡ off. SIGHUP-adoptable.
+	QuietHours               *QuietHoursConfig          `json:"quiet_hours,omitempty"`                  // #4950 — hold trade-free channel summaries inside a local-time window and post a catch-up after it; nil/disabled = no quiet hours
+	WeeklyDigest             *WeeklyDigestConfig        `json:"weekly_digest,omitempty"`                // #4955 — once-a-week operator digest (signal-to-execution conversion, position aging, …) posted at weekday+time UTC; nil/disabled = no digest
+	TradeLedger              *TradeLedgerConfig         `json:"trade_ledger,omitempty"`                 // #4938 — stream every trade into a standalone, never-pruned SQLite ledger (<db_file>.ledger.db) queried by `go-trader ledger`. Restart required.
+	PythonConcurrency        *PythonConcurrencyConfig   `json:"python_concurrency,omitempty"`           // #4978 — cap on concurrent trading-path Python subprocesses (max, default 4), optionally adaptive: steps down on script timeouts / high load, back up after clean runs. SIGHUP-adoptable.
+	StartupSync              *StartupSyncConfig         `json:"startup_sync,omitempty"`                 // #4973 — at startup, compare live venue positions (and flat-wallet cash) with state; mode "report" DMs discrepancies, "adopt" also rewrites state with audit trades. Nil/disabled ≡ off.
+	IncludedFiles            []string                   `json:"-"`                                      // resolved fragment paths merged from the root config's top-level "include" array (load order); named in startup/reload logs and strategy-not-found errors (includedFilesNote). Never marshaled
 }
 
 // TuningConfig bounds #1339 persistent tuning-run artifacts (#1382).
@@ -492,6 +572,15 @@ func (sc *StrategyConfig) CircuitBreakerEnabled() bool {
 	return *sc.CircuitBreaker
 }
 
+// ShortBorrowAPR returns the annual borrow rate (percent) charged on
+// allow_short paper spot shorts, defaulting to defaultShortBorrowAPRPct.
+func (sc *StrategyConfig) ShortBorrowAPR() float64 {
+	if sc == nil || sc.ShortBorrowAPRPct == nil {
+		return defaultShortBorrowAPRPct
+	}
+	return *sc.ShortBorrowAPRPct
+}
+
 // AllowDeprecatedEffective reports whether the M5-deprecated-edge warning /
 // owner-DM surface should treat this strategy as acknowledged (#1275/#1402).
 // Explicit true/false always win. When unset (nil): paper strategies (no
@@ -566,10 +655,14 @@ func (sc *StrategyConfig) CircuitBreakerLossStreakCooldown() time.Duration {
 	return time.Duration(*sc.CBLossStreakCooldownMinutes) * time.Minute
 }
 
+// summaryOnTradeOnly is the ParseSummaryFrequency result for
+// "on_trade_only": the channel posts only on runs that executed trades.
+const summaryOnTradeOnly time.Duration = -2
+
 // ParseSummaryFrequency converts a summary_frequency value to a duration.
-// Returns -1 to mean "use legacy default", 0 to mean "every channel run", or a
-// positive duration when caller should post every duration. An unrecognized
-// value returns a non-nil error.
+// Returns -1 to mean "use legacy default", 0 to mean "every channel run",
+// summaryOnTradeOnly for "on_trade_only", or a positive duration when caller
+// should post every duration. An unrecognized value returns a non-nil error.
 func ParseSummaryFrequency(s string) (time.Duration, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -578,6 +671,8 @@ func ParseSummaryFrequency(s string) (time.Duration, error) {
 	switch strings.ToLower(s) {
 	case "every", "per_check", "always":
 		return 0, nil
+	case "on_trade_only", "on_trade":
+		return summaryOnTradeOnly, nil
 	case "hourly":
 		return time.Hour, nil
 	case "daily":
@@ -599,6 +694,7 @@ func ParseSummaryFrequency(s string) (time.Duration, error) {
 //   - freq empty or invalid → legacy default: continuous channels post every
 //     channel run; non-continuous channels post hourly.
 //   - freq "every"/"per_check"/"always" → every channel run.
+//   - freq "on_trade_only"/"on_trade" → never without trades (#4946).
 //   - freq parseable as Go duration or alias → post when that wall-clock
 //     duration has elapsed since lastPost.
 //
@@ -613,6 +709,8 @@ func ShouldPostSummary(freq string, continuous, hasTrades bool, lastPost, now ti
 		dur = -1
 	}
 	switch {
+	case dur == summaryOnTradeOnly:
+		return false
 	case dur < 0: // legacy default
 		if continuous {
 			return true
@@ -633,6 +731,27 @@ type ThetaHarvestConfig struct {
 	ProfitTargetPct float64 `json:"profit_target_pct"` // Close sold options when this % of premium captured (e.g. 60)
 	StopLossPct     float64 `json:"stop_loss_pct"`     // Close if loss exceeds this % of premium (e.g. 200 = 2x premium)
 	MinDTEClose     float64 `json:"min_dte_close"`     // Force-close positions with fewer than N days to expiry
+	// Trailing mode (#4969): once capture reaches TrailingTriggerPct, track the
+	// best capture and close when it gives back TrailingRetracePct points.
+	// The fixed ProfitTargetPct still closes when set; leave it 0 to let
+	// steadily decaying positions run on the trail alone.
+	TrailingTriggerPct float64 `json:"trailing_trigger_pct,omitempty"`
+	TrailingRetracePct float64 `json:"trailing_retrace_pct,omitempty"`
+}
+
+// LongOptionExitConfig controls early exit on bought options (#4968).
+// Opt-in: without it a long call/put is held until a close action or expiry.
+type LongOptionExitConfig struct {
+	Enabled         bool    `json:"enabled"`
+	ProfitTargetPct float64 `json:"profit_target_pct"` // Close bought options once their value is up this % over the premium paid (e.g. 100 = doubled)
+	StopLossPct     float64 `json:"stop_loss_pct"`     // Close once this % of the premium paid is lost (e.g. 50)
+	MinDTEClose     float64 `json:"min_dte_close"`     // Close with fewer than N days to expiry, before time decay takes the rest
+}
+
+// defaultThetaHarvestConfig is the #56 exit applied to options strategies
+// that omit theta_harvest.
+func defaultThetaHarvestConfig() ThetaHarvestConfig {
+	return ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 60, StopLossPct: 200, MinDTEClose: 3}
 }
 
 // FuturesConfig holds per-contract futures trading parameters.
@@ -668,10 +787,20 @@ type StrategyConfig struct {
 	RegimeGateWindow            string                   `json:"regime_gate_window,omitempty"`        // window key for allowed_regimes gate; "" or "default" = legacy single lookback (#792)
 	RegimeATRWindow             string                   `json:"regime_atr_window,omitempty"`         // window key for *_atr_regime resolution (#792)
 	RegimeDirectionalWindow     string                   `json:"regime_directional_window,omitempty"` // window key for regime_directional_policy (#792)
+	Symbols                     []string                 `json:"symbols,omitempty"`                   // run one instance of this strategy per listed symbol: expanded at load into "<id>-<symbol>" entries with args[1] replaced and capital split across them (see expandStrategySymbols). Empty = the single args[1] symbol.
+	SymbolWeights               map[string]float64       `json:"symbol_weights,omitempty"`            // optional per-symbol capital weights for symbols (normalized; must cover every listed symbol). Nil = equal split.
 	Capital                     float64                  `json:"capital"`
 	CapitalPct                  float64                  `json:"capital_pct,omitempty"`     // 0-1; dynamic capital = wallet_balance * capital_pct (overrides capital)
 	InitialCapital              float64                  `json:"initial_capital,omitempty"` // fixed starting balance for PnL display (never overwritten by capital_pct)
 	MaxDrawdownPct              float64                  `json:"max_drawdown_pct"`
+	MaxNotionalUSD              float64                  `json:"max_notional_usd,omitempty"`                // per-strategy gross notional cap (0 = disabled). Once the strategy's open notional reaches it, position-increasing signals are held (pausedBlocksSignal semantics; options opens dropped); exits keep running, nothing is force-closed. Rejected on type=manual. Hot-reloadable via SIGHUP.
+	MaxPositions                int                      `json:"max_positions,omitempty"`                   // per-strategy cap on open positions incl. option legs (0 = disabled). At the cap, fresh opens are held (adds to an existing position pass the count check). Rejected on type=manual. Hot-reloadable via SIGHUP.
+	ExitAt                      *ExitScheduleConfig      `json:"exit_at,omitempty"`                         // spot only: close positions opened before a fixed UTC slot ({weekday, time}; weekday empty = daily), whatever the strategy signals, as scheduled-exit trades. Paper spot only. Hot-reloadable via SIGHUP.
+	MaxHoldingHours             float64                  `json:"max_holding_hours,omitempty"`               // force-close a position (or option leg) held longer than this many hours; the cycle's signal becomes a full close and a note posts to the strategy's channel (0 = disabled). Rejected on type=manual. Hot-reloadable via SIGHUP.
+	Shadow                      bool                     `json:"shadow,omitempty"`                          // live HL perps, OKX perps/spot and Robinhood spot only: keep a paper twin fed the same signals and report the live-vs-paper divergence (fees, slippage, missed fills) in the weekly digest. Hot-reloadable via SIGHUP.
+	SignalDedupMinutes          int                      `json:"signal_dedup_minutes,omitempty"`            // suppress a position-increasing signal identical to the last executed one (per symbol; per leg for options) within this many minutes (0 = disabled). Exits always pass. Rejected on type=manual. Hot-reloadable via SIGHUP.
+	MaxDailyTrades              int                      `json:"max_daily_trades,omitempty"`                // per-strategy cap on trades recorded per risk day (RiskState.DailyTrades, reset with the daily PnL rollover; 0 = disabled). At the cap, position-increasing signals are held for the rest of the day; exits keep running. Rejected on type=manual. Hot-reloadable via SIGHUP.
+	DrawdownWindowDays          int                      `json:"drawdown_window_days,omitempty"`            // rolling lookback (days) for the max_drawdown_pct peak; 0 = all-time high-water mark. On enable the current peak seeds today's sample and ages out after N days. Rejected on type=manual (exempt from CheckRisk). Hot-reloadable via SIGHUP including while open.
 	CircuitBreaker              *bool                    `json:"circuit_breaker,omitempty"`                 // #1048 — per-strategy circuit-breaker opt-out. Nil/missing → enabled (the safe default); explicit false disables BOTH firing arms in CheckRisk (drawdown > max_drawdown_pct AND the consecutive-loss streak), uniformly for live and paper (no platform/live gating). Hot-reloadable via SIGHUP including while a position is open: disabling only suppresses NEW fires — an already-latched CB and any pending circuit close still drain. No effect on type=manual (exempt from CheckRisk). Read via CircuitBreakerEnabled(), never directly.
 	CBDrawdownCooldownMinutes   *int                     `json:"cb_drawdown_cooldown_minutes,omitempty"`    // #1273 — how long a drawdown-triggered circuit breaker latches, in minutes. Nil/missing → 24h (the historical hardcoded value). Must be positive and ≤ 30 days; rejected on type=manual (exempt from CheckRisk). Hot-reloadable via SIGHUP including while open — affects only NEW fires; an already-latched CircuitBreakerUntil is never rewritten. Read via CircuitBreakerDrawdownCooldown(), never directly.
 	CBLossStreakThreshold       *int                     `json:"cb_loss_streak_threshold,omitempty"`        // #1273 — consecutive losses that fire the loss-streak circuit-breaker arm. Nil/missing → 5 (the historical hardcoded value). Must be positive and ≤ 100; rejected on type=manual. Drives both the firing arm and the #1048 suppression warning through the same accessor. Hot-reloadable via SIGHUP including while open (new fires only). Read via CircuitBreakerLossStreakThreshold(), never directly.
@@ -680,12 +809,19 @@ type StrategyConfig struct {
 	LLMEntryAnalysis            *LLMEntryAnalysisConfig  `json:"llm_entry_analysis,omitempty"`              // #1137 — optional post-open LLM multi-agent entry analysis (advisory-only commentary; never gates/sizes/closes anything). Default off. Runs async on a dedicated lane after a FRESH position-open (not adds/flips/manual), posts a digest to the strategy's trade-alert DM by default (notify_dm on / notify_channel off; both per-strategy overridable), and stamps the verdict for trade_diagnostics.llm_verdict. Notification-only, so SIGHUP hot-reloads it unconditionally even while a position is open. Read via LLMEntryAnalysisEnabled()/resolveLLMEntryAnalysisParams().
 	AllowDeprecated             *bool                    `json:"allow_deprecated,omitempty"`                // #1275/#1402 — operator acknowledgment that this strategy's open leg carries the M5 fee-audit deprecate verdict (documented gross edge <= 0; docs/research/fee-audit-m5.md). Pointer so unset (nil) is distinguishable from explicit false: live strategies with nil/false warn + DM; paper strategies (!isLiveArgs) with nil auto-suppress the warning/DM (#1402) while an explicit false opts a paper strategy back into the warning. Explicit true always suppresses. The [config] summary line still tags edge=deprecated_m5 with (ack) or (paper) so the risk state is never hidden. Advisory only — never gates loading, probing, or trading. Read via AllowDeprecatedEffective()/AllowDeprecatedAcknowledged(), never directly for the warning surface.
 	Paused                      bool                     `json:"paused,omitempty"`                          // #1150 — per-strategy pause. The strategy stays in dueStrategies and runs its full cycle (manage-only, mirroring the #1046 latched-CB shape), but position-INCREASING signals are forced to hold via pausedBlocksSignal: fresh opens, scale-in adds, and bidirectional flips. Position-REDUCING actions pass through — close-registry actions (closeFraction>0) and pure-close directional exits — so an open position rides its natural exit; trailing SL, ratchet, protection sync, and paper SL/TP simulation all keep running on the Signal==0 manage path. Hot-reloadable via SIGHUP unconditionally, including while a position is open (pausing never strands protection). No effect on type=manual (no open signal to suppress; the manual dispatch is pure management).
+	MuteTradeAlerts             bool                     `json:"mute_trade_alerts,omitempty"`               // #4951 — suppress this strategy's per-trade DM/channel alerts. It still appears in summary tables and risk alerts. Also settable at runtime with /go-trader-mute, which persists in app_state.muted_strategies. Hot-reloadable.
 	IntervalSeconds             int                      `json:"interval_seconds,omitempty"`                // per-strategy override (0 = use global)
+	DependsOn                   []string                 `json:"depends_on,omitempty"`                      // #4923 — strategy IDs that must run before this one whenever both are due in the same cycle (e.g. a hedger after its directional bots). Ordering only: a dependency that is not due imposes nothing. Validated (known IDs, no self/duplicate, acyclic); hot-reloadable.
+	Benchmark                   string                   `json:"benchmark,omitempty"`                       // #4927 — spot pair (e.g. "BTC/USDT") this strategy's rolling alpha/beta is measured against. Empty benchmarks against the strategy's own underlying (buy-and-hold). A configured pair is added to the cycle price fetch. Display-only; hot-reloadable (a changed benchmark restarts the stored series).
+	Env                         map[string]string        `json:"env,omitempty"`                             // #4981 — variables set on this strategy's check-script subprocess (exchange keys, proxy settings, feature flags), overriding the scheduler's own. Check script only; execute/close/fetch helpers keep the scheduler environment. Values are redacted from /go-trader-config and the dashboard. Hot-reloadable.
+	EnvPassthrough              []string                 `json:"env_passthrough,omitempty"`                 // #4981 — when non-empty, the check script no longer inherits the scheduler's full environment: only these host variables (trailing "*" = prefix match) plus PATH/HOME/LANG/LC_ALL/TZ/TMPDIR pass through, then env is applied. Empty/omitted = inherit everything. Hot-reloadable.
 	HTFFilter                   bool                     `json:"htf_filter,omitempty"`                      // higher-timeframe trend filter
 	ATRMethod                   string                   `json:"atr_method,omitempty"`                      // #1277 — per-strategy override of the global atr_method ("simple"|"wilder"; empty inherits). Governs the standard_atr surface only (EntryATR stamping when the open strategy emits no atr column, live market_ctx["atr"], manual fetch-atr); strategy-emitted atr columns and regime classification (pinned simple) are untouched. Rejected on type=options (no ATR surface). Read via resolveATRMethod(sc, cfg), never directly. Hot-reload blocked while open.
 	InvertSignal                bool                     `json:"invert_signal,omitempty"`                   // HL perps/manual only: flip BUY<->SELL on a non-zero signal before execution (HOLD/0 is never flipped). Lets inverse variants reuse the same open/close refs. Composes with Direction — invert runs in the Go layer before direction interprets the resulting sign (e.g. direction="short" + invert_signal=true opens short on raw-BUY triggers, distinct from plain direction="short" which opens on raw-SELL). Rejected outside HL perps/manual.
 	AllowShorts                 bool                     `json:"allow_shorts,omitempty"`                    // DEPRECATED — use Direction. Perps only; legacy boolean retained on the struct so pre-v14 JSON unmarshals cleanly. Read via EffectiveDirection / PerpsAllowsShort / PerpsAllowsLong, never directly. Migrated to Direction in v14 (#656).
 	Direction                   string                   `json:"direction,omitempty"`                       // perps only: "long" (default; signal=1 opens, signal=-1 closes long), "short" (signal=-1 opens, signal=1 closes short), "both" (bidirectional). Empty falls back to AllowShorts (legacy). v14 migration converts allow_shorts→direction. (#656)
+	AllowShort                  bool                     `json:"allow_short,omitempty"`                     // paper spot only: a SELL (-1) signal with no position opens a paper short (collateral = cash, valued like PortfolioValue's short branch); a BUY closes it. Borrow fees accrue per cycle at short_borrow_apr_pct. Distinct from the deprecated perps allow_shorts. Rejected on live, okx and robinhood spot. Hot-reloadable via SIGHUP; disabling while short only stops NEW shorts.
+	ShortBorrowAPRPct           *float64                 `json:"short_borrow_apr_pct,omitempty"`            // annual borrow rate (percent of short notional) charged on allow_short paper shorts. Nil/missing → 10. Must be in [0, 1000]; requires allow_short. Hot-reloadable via SIGHUP. Read via ShortBorrowAPR(), never directly.
 	Leverage                    float64                  `json:"leverage,omitempty"`                        // perps exchange leverage (default 1 = no leverage); used for exchange margin/risk and HL update_leverage (#254/#497)
 	SizingLeverage              float64                  `json:"sizing_leverage,omitempty"`                 // perps notional multiplier; defaults to Leverage for backwards compatibility (#497). Notional formula: notional = cash * sizing_leverage; size = notional / price. For margin-based sizing, prefer MarginPerTradeUSD (#518).
 	MarginPerTradeUSD           *float64                 `json:"margin_per_trade_usd,omitempty"`            // perps only: USD margin to deploy per open. When set (positive), overrides SizingLeverage: notional = min(MarginPerTradeUSD, cash) * exchange_leverage; size = notional / price. Lets operators size in margin-space directly so high exchange_leverage doesn't decouple intent from outcome (#518).
@@ -698,14 +834,67 @@ type StrategyConfig struct {
 	StopLossATRRegime           *RegimeATRBlock          `json:"stop_loss_atr_regime,omitempty"`            // HL perps only: regime-aware sibling of stop_loss_atr_mult — resolves the ATR multiplier from pos.Regime stamped at open. Mutually exclusive with the four scalar siblings AND stop_loss_atr_mult. Requires regime detection enabled at the top-level cfg.Regime. (#733)
 	TrailingStopATRRegime       *RegimeATRBlock          `json:"trailing_stop_atr_regime,omitempty"`        // HL perps only: regime-aware sibling of trailing_stop_atr_mult — trailing distance frozen at open via pos.Regime. Mutually exclusive with the scalar siblings. Requires regime detection. (#733)
 	TrailingStopMinMovePct      *float64                 `json:"trailing_stop_min_move_pct,omitempty"`      // HL perps trailing SL only: minimum trigger-price move before cancel/replace; nil defaults to 0.5% (#501)
+	MaxSlippagePct              *float64                 `json:"max_slippage_pct,omitempty"`                // live only: alert when a fill's avg price is worse than the signal price by more than this percent. Nil = no guard. Bounds (0, 100]. Hot-reloadable via SIGHUP.
+	FlattenOnSlippage           bool                     `json:"flatten_on_slippage,omitempty"`             // HL live perps only: also market-close a fresh open whose fill breached max_slippage_pct (required). Hot-reloadable via SIGHUP.
 	MarginMode                  string                   `json:"margin_mode,omitempty"`                     // HL perps only: "isolated" (default) or "cross"; sent via update_leverage on fresh opens to enforce per-position liq isolation (#486)
 	ThetaHarvest                *ThetaHarvestConfig      `json:"theta_harvest,omitempty"`
+	LongOptionExits             *LongOptionExitConfig    `json:"long_option_exits,omitempty"` // #4968 take-profit / stop / DTE exit for bought options; options only; nil = hold to close action or expiry. Hot-reloadable via SIGHUP.
 	FuturesConfig               *FuturesConfig           `json:"futures,omitempty"`
 	RegimeDirectionalPolicy     *RegimeDirectionalPolicy `json:"regime_directional_policy,omitempty"` // HL perps only: regime-aware override for Direction + InvertSignal. When set, runHyperliquidCheck resolves the effective pair per-cycle from the current regime (when flat) or pos.Regime (when an open position is held — "hold until natural exit" semantics). Static Direction/InvertSignal are the base; the policy overrides per regime. Requires regime detection enabled at top-level cfg.Regime. (#779)
 	RegimeWindowDivergence      *RegimeWindowDivergence  `json:"regime_window_divergence,omitempty"`  // HL perps live only: detect divergence between two regime windows (short vs medium) and optionally override effective direction when they hard-diverge. Builds on regime_directional_policy surface (#907).
 	RegimeProfileAllocation     *RegimeProfileAllocation `json:"regime_profile_allocation,omitempty"` // HL perps only: slow regime switch between two validated open_strategy param profiles. A long-window regime label (from the #879 store) selects the active profile; switching is hysteretic (confirm_bars closed bars) and flat-only. Requires regime.enabled=true. Backtester replays the switch. (#998)
 	AllowScaleIn                bool                     `json:"allow_scale_in,omitempty"`            // HL perps/manual only: opt in to scale-in / pyramiding — a same-direction signal on an open position ADDS size (blends price+size, freezes EntryATR/regime/TP geometry) instead of being skipped. Default false preserves the legacy skip-on-same-direction behavior for every strategy that does not opt in. Gated by ScaleIn caps + spacing. (#873)
 	ScaleIn                     *ScaleInConfig           `json:"scale_in,omitempty"`                  // scale-in tuning; only consulted when AllowScaleIn is true. Nil = defaults (unlimited adds/notional, no spacing, per-add size = standard open notional). (#873)
+	DCA                         *DCAConfig               `json:"dca,omitempty"`                       // paper generic spot only: accumulation mode — each BUY buys a fixed dca.tranche_usd tranche (adding to an open long and re-blending AvgCost instead of skipping "already long") up to dca.max_position_usd of cost basis. Nil = legacy all-in/all-out. Hot-reloadable via SIGHUP including while open (only the next BUY reads it).
+	PaperLimitEntries           *PaperLimitEntriesConfig `json:"paper_limit_entries,omitempty"`       // paper generic spot only: fresh opens rest as limit orders at the signal price (less offset_pct) and fill only when a later cycle's bar range crosses them, expiring after expiry_cycles. Nil = market fills at the cycle price. Hot-reloadable via SIGHUP (disabling cancels resting orders on the next cycle).
+	PaperStopOrder              *PaperStopOrderConfig    `json:"paper_stop_order,omitempty"`          // HL paper perps only: evaluate the paper fixed-ATR / trailing stop against the check script's bar_high/bar_low as a "stop" or "stop_limit" order instead of the cycle snapshot price (paper_stops.go). Nil = legacy snapshot check. Hot-reloadable via SIGHUP including while open.
+	SizeFraction                *SizeFractionConfig      `json:"size_fraction,omitempty"`             // opt-in conviction sizing: scale fresh opens by the check script's size_fraction (or confidence) output, clamped to [min, max]; missing = full size. Nil = the fields are ignored. Perps (HL/OKX, live + paper) and paper generic spot only. Hot-reloadable via SIGHUP (only the next open reads it).
+}
+
+// SizeFractionConfig opts a strategy into conviction sizing from the check
+// script's size_fraction / confidence output (size_fraction.go). The emitted
+// fraction is clamped to [Min, Max].
+type SizeFractionConfig struct {
+	// Min floors the fraction so a low-conviction signal still opens a
+	// meaningful size. 0 = no floor.
+	Min float64 `json:"min,omitempty"`
+	// Max caps the fraction (0 = 1, full size).
+	Max float64 `json:"max,omitempty"`
+}
+
+// DCAConfig enables the accumulation execution mode for paper generic spot
+// strategies (dca.go). Every BUY buys one TrancheUSD tranche — from flat it
+// opens the position, while long it adds to it and re-blends AvgCost — until
+// the position's cost basis reaches MaxPositionUSD. SELL still closes the
+// whole position.
+type DCAConfig struct {
+	// TrancheUSD is the USD notional bought per BUY signal. Required (> 0).
+	TrancheUSD float64 `json:"tranche_usd"`
+	// MaxPositionUSD caps the position's cost basis (quantity * avg cost);
+	// the last tranche is trimmed to fit and further BUYs are skipped once
+	// it is reached. 0 = bounded by cash only.
+	MaxPositionUSD float64 `json:"max_position_usd,omitempty"`
+}
+
+// PaperLimitEntriesConfig makes paper generic spot entries rest as limit
+// orders instead of filling at the cycle price (paper_orders.go).
+type PaperLimitEntriesConfig struct {
+	// OffsetPct places the limit this percent better than the signal price
+	// (below for a BUY, above for an allow_short SELL). 0 = at the signal price.
+	OffsetPct float64 `json:"offset_pct,omitempty"`
+	// ExpiryCycles is how many later cycles the order may rest before it is
+	// cancelled unfilled. 0 = 1.
+	ExpiryCycles int `json:"expiry_cycles,omitempty"`
+}
+
+// PaperStopOrderConfig selects the order model for paper protective stops
+// (paper_stops.go).
+type PaperStopOrderConfig struct {
+	// Type is "stop" (stop-market, the default) or "stop_limit".
+	Type string `json:"type,omitempty"`
+	// LimitOffsetPct is how far past the trigger a stop_limit may still fill
+	// (percent of the trigger). 0 = only at the trigger or better.
+	LimitOffsetPct float64 `json:"limit_offset_pct,omitempty"`
 }
 
 // ScaleInConfig tunes the opt-in scale-in / pyramiding path (#873). All fields
@@ -728,6 +917,11 @@ type ScaleInConfig struct {
 	// AddNotionalUSD is the USD notional to add per leg (0 = default to the
 	// strategy's standard open notional, i.e. the same sizing a fresh open uses).
 	AddNotionalUSD float64 `json:"add_notional_usd,omitempty"`
+	// MinGainPct is the pyramiding gate in price terms: the next add requires
+	// price to have moved at least this percent in the position's favor from
+	// the last entry leg's fill price (0 = no gain gate). Composes with
+	// AddSpacingATR (both must pass); needs no EntryATR.
+	MinGainPct float64 `json:"min_gain_pct,omitempty"`
 }
 
 // UnmarshalJSON parses a StrategyConfig while accepting both the canonical
@@ -1084,10 +1278,18 @@ func loadConfig(path string, skipLiveCredentialChecks bool) (*Config, error) {
 			return nil, fmt.Errorf("read config after v16 user-defaults migration: %w", err)
 		}
 	}
+	// Merge "include" fragments after the root-file migrations (which rewrite
+	// only the root on disk) and before parse, so the unknown-key guard below
+	// also covers strategies defined in fragments.
+	data, included, err := resolveConfigIncludes(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("config include: %w", err)
+	}
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
+	cfg.IncludedFiles = included
 	// #704: flag unknown per-strategy fields (typos like `take_profit_atr_mult`)
 	// before applying defaults; json.Unmarshal silently drops them and would
 	// otherwise produce a struct indistinguishable from "no protection configured".
@@ -1096,6 +1298,12 @@ func loadConfig(path string, skipLiveCredentialChecks bool) (*Config, error) {
 	if len(unknownErrs) > 0 {
 		return nil, fmt.Errorf("config validation errors:\n  %s", strings.Join(unknownErrs, "\n  "))
 	}
+	// Expand multi-symbol strategies into per-symbol instances before any
+	// per-strategy defaults so every instance is defaulted and validated like
+	// a hand-written entry.
+	if symErrs := expandStrategySymbols(&cfg); len(symErrs) > 0 {
+		return nil, fmt.Errorf("config validation errors:\n  %s", strings.Join(symErrs, "\n  "))
+	}
 	if cfg.IntervalSeconds <= 0 {
 		cfg.IntervalSeconds = 600
 	}
@@ -1267,12 +1475,8 @@ func loadConfig(path string, skipLiveCredentialChecks bool) (*Config, error) {
 		// #56: Default theta harvest for options strategies — sold options
 		// must always have an automatic exit to prevent unbounded losses.
 		if cfg.Strategies[i].Type == "options" && cfg.Strategies[i].ThetaHarvest == nil {
-			cfg.Strategies[i].ThetaHarvest = &ThetaHarvestConfig{
-				Enabled:         true,
-				ProfitTargetPct: 60,
-				StopLossPct:     200,
-				MinDTEClose:     3,
-			}
+			th := defaultThetaHarvestConfig()
+			cfg.Strategies[i].ThetaHarvest = &th
 			fmt.Printf("[INFO] %s: no theta_harvest config, applying defaults (profit=60%%, stop=200%%, dte=3)\n", cfg.Strategies[i].ID)
 		}
 	}
@@ -1567,41 +1771,6 @@ func ParseLeaderboardPostTime(s string) (int, int, bool) {
 	return h, m, true
 }
 
-// strategyIntervalExceedsGlobalWarning returns a [WARN] message when the
-// per-strategy interval exceeds the top-level interval (#409), or "" otherwise.
-func strategyIntervalExceedsGlobalWarning(sc StrategyConfig, globalInterval int) string {
-	if sc.IntervalSeconds <= 0 || globalInterval <= 0 || sc.IntervalSeconds <= globalInterval {
-		return ""
-	}
-	ratio := sc.IntervalSeconds / globalInterval
-	if sc.IntervalSeconds%globalInterval != 0 {
-		ratio++
-	}
-	return fmt.Sprintf("[WARN] strategy %q interval_seconds=%d exceeds top-level interval_seconds=%d. Strategy will only run every %s portfolio cycle.",
-		sc.ID, sc.IntervalSeconds, globalInterval, ordinal(ratio))
-}
-
-// ordinal returns the English ordinal suffix form of n (e.g. 1 → "1st", 3 → "3rd", 11 → "11th").
-func ordinal(n int) string {
-	if n < 0 {
-		n = -n
-	}
-	mod100 := n % 100
-	if mod100 >= 11 && mod100 <= 13 {
-		return fmt.Sprintf("%dth", n)
-	}
-	switch n % 10 {
-	case 1:
-		return fmt.Sprintf("%dst", n)
-	case 2:
-		return fmt.Sprintf("%dnd", n)
-	case 3:
-		return fmt.Sprintf("%drd", n)
-	default:
-		return fmt.Sprintf("%dth", n)
-	}
-}
-
 // regimeDirectionalPolicyWarnings returns one operator warning per strategy that selects
 // trade side from the regime label (regime_directional_policy, #779). #1076 validated that
 // premise — regime -> forward DIRECTION — and found it empirically false across BTC/ETH/SOL/
@@ -1875,17 +2044,136 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 			}
 		}
 
+		if sc.MaxNotionalUSD != 0 || sc.MaxPositions != 0 || sc.MaxDailyTrades != 0 {
+			if sc.Type == "manual" {
+				errs = append(errs, fmt.Sprintf("%s: max_notional_usd/max_positions/max_daily_trades are not supported for manual strategies (no dispatch signals to hold)", prefix))
+			}
+			if sc.MaxNotionalUSD < 0 {
+				errs = append(errs, fmt.Sprintf("%s: max_notional_usd must be >= 0 (0 = disabled), got %g", prefix, sc.MaxNotionalUSD))
+			}
+			if sc.MaxPositions < 0 {
+				errs = append(errs, fmt.Sprintf("%s: max_positions must be >= 0 (0 = disabled), got %d", prefix, sc.MaxPositions))
+			}
+			if sc.MaxDailyTrades < 0 {
+				errs = append(errs, fmt.Sprintf("%s: max_daily_trades must be >= 0 (0 = disabled), got %d", prefix, sc.MaxDailyTrades))
+			}
+		}
+		errs = append(errs, validateExitSchedule(sc, prefix)...)
+		if lx := sc.LongOptionExits; lx != nil {
+			if sc.Type != "options" {
+				errs = append(errs, fmt.Sprintf("%s: long_option_exits is only supported for options strategies, got type %q", prefix, sc.Type))
+			}
+			if lx.ProfitTargetPct < 0 || lx.MinDTEClose < 0 {
+				errs = append(errs, fmt.Sprintf("%s: long_option_exits.profit_target_pct and min_dte_close must be >= 0 (0 = off)", prefix))
+			}
+			if lx.StopLossPct < 0 || lx.StopLossPct > 100 {
+				errs = append(errs, fmt.Sprintf("%s: long_option_exits.stop_loss_pct must be in [0, 100] (0 = off), got %g", prefix, lx.StopLossPct))
+			}
+		}
+		if sc.MaxHoldingHours < 0 {
+			errs = append(errs, fmt.Sprintf("%s: max_holding_hours must be >= 0 (0 = disabled), got %g", prefix, sc.MaxHoldingHours))
+		} else if sc.MaxHoldingHours > 0 && sc.Type == "manual" {
+			errs = append(errs, fmt.Sprintf("%s: max_holding_hours is not supported for manual strategies (no dispatch signals)", prefix))
+		}
+		if sc.Shadow && (!isLiveArgs(sc.Args) || !shadowSupported(sc)) {
+			errs = append(errs, fmt.Sprintf("%s: shadow is only supported for live HL perps, OKX perps/spot and Robinhood spot strategies (got platform=%q type=%q)", prefix, sc.Platform, sc.Type))
+		}
+		errs = append(errs, validateStrategyScriptEnv(prefix, sc)...)
+		if sc.SignalDedupMinutes < 0 {
+			errs = append(errs, fmt.Sprintf("%s: signal_dedup_minutes must be >= 0 (0 = disabled), got %d", prefix, sc.SignalDedupMinutes))
+		} else if sc.SignalDedupMinutes > 0 && sc.Type == "manual" {
+			errs = append(errs, fmt.Sprintf("%s: signal_dedup_minutes is not supported for manual strategies (no dispatch signals)", prefix))
+		}
+		if sc.SizeFraction != nil {
+			genericSpot := sc.Type == "spot" && sc.Platform != "okx" && sc.Platform != "robinhood"
+			if sc.Type != "perps" && !genericSpot {
+				errs = append(errs, fmt.Sprintf("%s: size_fraction is only supported for perps and generic spot strategies (got type %q, platform %q)", prefix, sc.Type, sc.Platform))
+			}
+			if sc.SizeFraction.Min < 0 || sc.SizeFraction.Min > 1 {
+				errs = append(errs, fmt.Sprintf("%s: size_fraction.min must be in [0, 1], got %g", prefix, sc.SizeFraction.Min))
+			}
+			if sc.SizeFraction.Max < 0 || sc.SizeFraction.Max > 1 {
+				errs = append(errs, fmt.Sprintf("%s: size_fraction.max must be in [0, 1] (0 = 1), got %g", prefix, sc.SizeFraction.Max))
+			} else if lo, hi := sc.SizeFraction.bounds(); lo > hi {
+				errs = append(errs, fmt.Sprintf("%s: size_fraction.min (%g) must be <= max (%g)", prefix, lo, hi))
+			}
+		}
+		if sc.DCA != nil {
+			if sc.Type != "spot" || sc.Platform == "okx" || sc.Platform == "robinhood" || isLiveArgs(sc.Args) {
+				errs = append(errs, fmt.Sprintf("%s: dca is only supported for paper generic spot strategies (got type %q, platform %q)", prefix, sc.Type, sc.Platform))
+			}
+			if sc.DCA.TrancheUSD <= 0 {
+				errs = append(errs, fmt.Sprintf("%s: dca.tranche_usd must be positive, got %g", prefix, sc.DCA.TrancheUSD))
+			}
+			if sc.DCA.MaxPositionUSD < 0 {
+				errs = append(errs, fmt.Sprintf("%s: dca.max_position_usd must be >= 0 (0 = bounded by cash), got %g", prefix, sc.DCA.MaxPositionUSD))
+			} else if sc.DCA.MaxPositionUSD > 0 && sc.DCA.MaxPositionUSD < sc.DCA.TrancheUSD {
+				errs = append(errs, fmt.Sprintf("%s: dca.max_position_usd ($%g) must be >= dca.tranche_usd ($%g)", prefix, sc.DCA.MaxPositionUSD, sc.DCA.TrancheUSD))
+			}
+		}
+		if sc.PaperLimitEntries != nil {
+			if sc.Type != "spot" || sc.Platform == "okx" || sc.Platform == "robinhood" || isLiveArgs(sc.Args) {
+				errs = append(errs, fmt.Sprintf("%s: paper_limit_entries is only supported for paper generic spot strategies (got type %q, platform %q)", prefix, sc.Type, sc.Platform))
+			}
+			if sc.PaperLimitEntries.OffsetPct < 0 || sc.PaperLimitEntries.OffsetPct >= 50 {
+				errs = append(errs, fmt.Sprintf("%s: paper_limit_entries.offset_pct must be in [0, 50), got %g", prefix, sc.PaperLimitEntries.OffsetPct))
+			}
+			if sc.PaperLimitEntries.ExpiryCycles < 0 || sc.PaperLimitEntries.ExpiryCycles > 1000 {
+				errs = append(errs, fmt.Sprintf("%s: paper_limit_entries.expiry_cycles must be in [0, 1000] (0 = 1), got %d", prefix, sc.PaperLimitEntries.ExpiryCycles))
+			}
+			if sc.DCA != nil {
+				errs = append(errs, fmt.Sprintf("%s: paper_limit_entries and dca both own the BUY entry path — pick one", prefix))
+			}
+		}
+		if sc.PaperStopOrder != nil {
+			if sc.Type != "perps" || sc.Platform != "hyperliquid" || hyperliquidIsLive(sc.Args) {
+				errs = append(errs, fmt.Sprintf("%s: paper_stop_order is only supported for paper hyperliquid perps strategies (got type %q, platform %q)", prefix, sc.Type, sc.Platform))
+			}
+			switch sc.PaperStopOrder.Type {
+			case "", paperStopTypeStop:
+				if sc.PaperStopOrder.LimitOffsetPct != 0 {
+					errs = append(errs, fmt.Sprintf("%s: paper_stop_order.limit_offset_pct requires type %q", prefix, paperStopTypeStopLimit))
+				}
+			case paperStopTypeStopLimit:
+				if sc.PaperStopOrder.LimitOffsetPct < 0 || sc.PaperStopOrder.LimitOffsetPct >= 50 {
+					errs = append(errs, fmt.Sprintf("%s: paper_stop_order.limit_offset_pct must be in [0, 50), got %g", prefix, sc.PaperStopOrder.LimitOffsetPct))
+				}
+			default:
+				errs = append(errs, fmt.Sprintf("%s: paper_stop_order.type must be %q or %q, got %q", prefix, paperStopTypeStop, paperStopTypeStopLimit, sc.PaperStopOrder.Type))
+			}
+		}
+		if sc.AllowShort {
+			if sc.Type != "spot" {
+				errs = append(errs, fmt.Sprintf("%s: allow_short is only supported for spot strategies (got type %q; perps use direction)", prefix, sc.Type))
+			} else if sc.Platform == "okx" || sc.Platform == "robinhood" {
+				errs = append(errs, fmt.Sprintf("%s: allow_short is not supported on platform %q (paper spot shorts run on the generic spot executor only)", prefix, sc.Platform))
+			}
+			if isLiveArgs(sc.Args) {
+				errs = append(errs, fmt.Sprintf("%s: allow_short is paper-only (no venue borrow); remove --mode=live or allow_short", prefix))
+			}
+		}
+		if sc.ShortBorrowAPRPct != nil {
+			if !sc.AllowShort {
+				errs = append(errs, fmt.Sprintf("%s: short_borrow_apr_pct requires allow_short", prefix))
+			}
+			if v := *sc.ShortBorrowAPRPct; v < 0 || v > 1000 {
+				errs = append(errs, fmt.Sprintf("%s: short_borrow_apr_pct must be in [0, 1000], got %g", prefix, v))
+			}
+		}
+		if sc.DrawdownWindowDays != 0 {
+			if sc.Type == "manual" {
+				errs = append(errs, fmt.Sprintf("%s: drawdown_window_days is not supported for manual strategies (exempt from CheckRisk)", prefix))
+			}
+			if sc.DrawdownWindowDays < 0 || sc.DrawdownWindowDays > maxDrawdownWindowDays {
+				errs = append(errs, fmt.Sprintf("%s: drawdown_window_days must be in [0, %d] (0 = all-time peak), got %d", prefix, maxDrawdownWindowDays, sc.DrawdownWindowDays))
+			}
+		}
+
 		// #36: IntervalSeconds must be >= 0 (0 means use global).
 		if sc.IntervalSeconds < 0 {
 			errs = append(errs, fmt.Sprintf("%s: interval_seconds must be >= 0, got %d", prefix, sc.IntervalSeconds))
 		}
 
-		// #409: warn when per-strategy interval exceeds the top-level interval;
-		// the strategy will only run every Nth portfolio cycle.
-		if msg := strategyIntervalExceedsGlobalWarning(sc, cfg.IntervalSeconds); msg != "" {
-			fmt.Println(msg)
-		}
-
 		// #254/#497: Leverage is exchange leverage and must be >= 1 when set.
 		// Only applicable to perps and manual (#569: manual uses leverage for sizing).
 		if sc.Leverage != 0 {
@@ -1932,17 +2220,21 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 		errs = append(errs, validateRiskPerTradePct(sc, prefix)...)
 
 		// #873: scale-in / pyramiding is opt-in and scoped to HL perps + manual
-		// (live + paper). The blend math is platform-agnostic, but the on-chain
-		// protection re-size is HL-specific and the dispatch wiring only covers
-		// these two types — reject the flag elsewhere so an operator can't
-		// silently enable a no-op.
+		// (live + paper), plus paper generic spot pyramiding. The blend math is
+		// platform-agnostic, but the on-chain protection re-size is HL-specific
+		// and the dispatch wiring only covers these types — reject the flag
+		// elsewhere so an operator can't silently enable a no-op.
 		if sc.AllowScaleIn {
-			if sc.Type != "perps" && sc.Type != "manual" {
-				errs = append(errs, fmt.Sprintf("%s: allow_scale_in is only supported for perps/manual strategies (got type %q)", prefix, sc.Type))
+			genericPaperSpot := sc.Type == "spot" && sc.Platform != "okx" && sc.Platform != "robinhood" && !isLiveArgs(sc.Args)
+			if sc.Type != "perps" && sc.Type != "manual" && !genericPaperSpot {
+				errs = append(errs, fmt.Sprintf("%s: allow_scale_in is only supported for perps/manual strategies and paper generic spot (got type %q)", prefix, sc.Type))
 			}
-			if sc.Platform != "hyperliquid" {
+			if sc.Type != "spot" && sc.Platform != "hyperliquid" {
 				errs = append(errs, fmt.Sprintf("%s: allow_scale_in is only supported on hyperliquid (got platform %q)", prefix, sc.Platform))
 			}
+			if sc.DCA != nil {
+				errs = append(errs, fmt.Sprintf("%s: allow_scale_in and dca both size BUYs while long — pick one", prefix))
+			}
 			// #873 (from #875): on HL LIVE perps the on-chain SL must be one the
 			// scale-in resize path can grow — an ATR/regime fixed SL (sync
 			// force-replace) or a trailing SL (walker forceResize). A static
@@ -1968,6 +2260,11 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 			if sc.ScaleIn.AddNotionalUSD < 0 {
 				errs = append(errs, fmt.Sprintf("%s: scale_in.add_notional_usd must be >= 0, got %g", prefix, sc.ScaleIn.AddNotionalUSD))
 			}
+			if sc.ScaleIn.MinGainPct < 0 || sc.ScaleIn.MinGainPct > 1000 {
+				errs = append(errs, fmt.Sprintf("%s: scale_in.min_gain_pct must be in [0, 1000], got %g", prefix, sc.ScaleIn.MinGainPct))
+			} else if sc.ScaleIn.MinGainPct > 0 && sc.ScaleIn.AddSpacingATR < 0 {
+				errs = append(errs, fmt.Sprintf("%s: scale_in.min_gain_pct (add-to-winners) conflicts with negative add_spacing_atr (average-down)", prefix))
+			}
 		}
 
 		// #656: validate direction (perps only). Empty is allowed and falls
@@ -2215,6 +2512,18 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 			errs = append(errs, fmt.Sprintf("%s: %s", prefix, msg))
 		}
 
+		if sc.MaxSlippagePct != nil && (*sc.MaxSlippagePct <= 0 || *sc.MaxSlippagePct > 100) {
+			errs = append(errs, fmt.Sprintf("%s: max_slippage_pct must be in (0, 100], got %g", prefix, *sc.MaxSlippagePct))
+		}
+		if sc.FlattenOnSlippage {
+			if sc.MaxSlippagePct == nil {
+				errs = append(errs, fmt.Sprintf("%s: flatten_on_slippage requires max_slippage_pct", prefix))
+			}
+			if sc.Platform != "hyperliquid" || sc.Type != "perps" {
+				errs = append(errs, fmt.Sprintf("%s: flatten_on_slippage is only supported for HL perps strategies (got platform=%q type=%q)", prefix, sc.Platform, sc.Type))
+			}
+		}
+
 		if sc.TrailingStopMinMovePct != nil {
 			pct := *sc.TrailingStopMinMovePct
 			if pct < 0 || pct > 100 {
@@ -2256,6 +2565,11 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 			if th.MinDTEClose < 0 {
 				errs = append(errs, fmt.Sprintf("%s: theta_harvest.min_dte_close must be >= 0", prefix))
 			}
+			if th.TrailingTriggerPct < 0 || th.TrailingTriggerPct > 100 || th.TrailingRetracePct < 0 {
+				errs = append(errs, fmt.Sprintf("%s: theta_harvest.trailing_trigger_pct must be in [0, 100] and trailing_retrace_pct >= 0", prefix))
+			} else if (th.TrailingTriggerPct > 0) != (th.TrailingRetracePct > 0) {
+				errs = append(errs, fmt.Sprintf("%s: theta_harvest.trailing_trigger_pct and trailing_retrace_pct must be set together", prefix))
+			}
 		}
 	}
 
@@ -2294,6 +2608,123 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 		if cfg.PortfolioRisk.MaxAssetConcentrationPct < 0 || cfg.PortfolioRisk.MaxAssetConcentrationPct > 100 {
 			errs = append(errs, fmt.Sprintf("portfolio_risk.max_asset_concentration_pct must be in [0, 100] (0 = disabled), got %g", cfg.PortfolioRisk.MaxAssetConcentrationPct))
 		}
+		concAssets := make([]string, 0, len(cfg.PortfolioRisk.AssetConcentrationPct))
+		for asset := range cfg.PortfolioRisk.AssetConcentrationPct {
+			concAssets = append(concAssets, asset)
+		}
+		sort.Strings(concAssets)
+		seenConcAssets := make(map[string]string)
+		for _, asset := range concAssets {
+			pct := cfg.PortfolioRisk.AssetConcentrationPct[asset]
+			if strings.TrimSpace(asset) == "" {
+				errs = append(errs, "portfolio_risk.asset_concentration_pct: asset key must not be empty")
+				continue
+			}
+			if prev, ok := seenConcAssets[strings.ToUpper(asset)]; ok {
+				errs = append(errs, fmt.Sprintf("portfolio_risk.asset_concentration_pct: %q and %q name the same asset", prev, asset))
+			}
+			seenConcAssets[strings.ToUpper(asset)] = asset
+			if pct < 0 || pct > 100 {
+				errs = append(errs, fmt.Sprintf("portfolio_risk.asset_concentration_pct[%s] must be in [0, 100] (0 = exempt), got %g", asset, pct))
+			}
+		}
+		if cfg.PortfolioRisk.DrawdownWindowDays < 0 || cfg.PortfolioRisk.DrawdownWindowDays > maxDrawdownWindowDays {
+			errs = append(errs, fmt.Sprintf("portfolio_risk.drawdown_window_days must be in [0, %d] (0 = all-time peak), got %d", maxDrawdownWindowDays, cfg.PortfolioRisk.DrawdownWindowDays))
+		}
+		if c := cfg.PortfolioRisk.VaRConfidencePct; c != 0 && (c < 50 || c >= 100) {
+			errs = append(errs, fmt.Sprintf("portfolio_risk.var_confidence_pct must be in [50, 100) (0 = default %d), got %g", defaultVaRConfidencePct, c))
+		}
+		seenGroups := make(map[string]bool)
+		for i, g := range cfg.PortfolioRisk.CorrelationGroups {
+			label := fmt.Sprintf("portfolio_risk.correlation_groups[%d]", i)
+			if strings.TrimSpace(g.Name) == "" {
+				errs = append(errs, label+": name is required")
+			} else {
+				label = fmt.Sprintf("portfolio_risk.correlation_groups[%s]", g.Name)
+				if seenGroups[strings.ToLower(g.Name)] {
+					errs = append(errs, fmt.Sprintf("%s: duplicate group name", label))
+				}
+				seenGroups[strings.ToLower(g.Name)] = true
+			}
+			if len(g.Assets) < 2 {
+				errs = append(errs, fmt.Sprintf("%s: assets must list at least 2 assets, got %d", label, len(g.Assets)))
+			}
+			for _, a := range g.Assets {
+				if strings.TrimSpace(a) == "" {
+					errs = append(errs, fmt.Sprintf("%s: asset names must not be empty", label))
+					break
+				}
+			}
+			if g.MaxSameDirectionNotionalUSD < 0 {
+				errs = append(errs, fmt.Sprintf("%s: max_same_direction_notional_usd must be >= 0 (0 = disabled), got %g", label, g.MaxSameDirectionNotionalUSD))
+			}
+			if g.MaxNetExposurePct < 0 || g.MaxNetExposurePct > 1000 {
+				errs = append(errs, fmt.Sprintf("%s: max_net_exposure_pct must be in [0, 1000] (0 = disabled), got %g", label, g.MaxNetExposurePct))
+			}
+			if g.MaxSameDirectionNotionalUSD == 0 && g.MaxNetExposurePct == 0 {
+				errs = append(errs, fmt.Sprintf("%s: set max_same_direction_notional_usd and/or max_net_exposure_pct", label))
+			}
+		}
+		if d := cfg.PortfolioRisk.VaRLookbackDays; d != 0 && (d <= minVaRSamples || d > maxPortfolioRiskHistory) {
+			errs = append(errs, fmt.Sprintf("portfolio_risk.var_lookback_days must be in (%d, %d] (0 = default %d), got %d", minVaRSamples, maxPortfolioRiskHistory, defaultVaRLookbackDays, d))
+		}
+		if cfg.PortfolioRisk.MaxVaRPct < 0 || cfg.PortfolioRisk.MaxVaRPct > 100 {
+			errs = append(errs, fmt.Sprintf("portfolio_risk.max_var_pct must be in [0, 100] (0 = disabled), got %g", cfg.PortfolioRisk.MaxVaRPct))
+		}
+		if h := cfg.PortfolioRisk.DailyResetHour; h < 0 || h > 23 {
+			errs = append(errs, fmt.Sprintf("portfolio_risk.daily_reset_hour must be in [0, 23], got %d", h))
+		}
+		if tz := cfg.PortfolioRisk.DailyResetTimezone; tz != "" {
+			if _, err := time.LoadLocation(tz); err != nil {
+				errs = append(errs, fmt.Sprintf("portfolio_risk.daily_reset_timezone: unknown zone %q", tz))
+			}
+		}
+	}
+	// #4923: depends_on must name known strategies and stay acyclic.
+	errs = append(errs, strategyDependencyErrors(cfg.Strategies)...)
+	// #4927: an explicit benchmark must be a fetchable spot pair.
+	for _, sc := range cfg.Strategies {
+		errs = append(errs, benchmarkErrors(sc)...)
+	}
+	// #4924: ensembles group same-asset strategies under one executor.
+	errs = append(errs, ensembleErrors(cfg.Ensembles, cfg.Strategies)...)
+	// #4925: the allocator only moves fixed paper capital.
+	errs = append(errs, capitalAllocatorErrors(cfg.CapitalAllocator, cfg.Strategies)...)
+	errs = append(errs, marketRegimeErrors(cfg.MarketRegime)...)
+	errs = append(errs, corsErrors(cfg.CORS)...)
+	errs = append(errs, grpcErrors(cfg.GRPC, cfg.StatusToken)...)
+	if d := cfg.PriceHistoryDays; d != nil && (*d < 0 || *d > maxPriceHistoryDays) {
+		errs = append(errs, fmt.Sprintf("price_history_days must be in [0, %d] (0 = disabled), got %d", maxPriceHistoryDays, *d))
+	}
+	if d := cfg.SignalHistoryDays; d != nil && (*d < 0 || *d > maxPriceHistoryDays) {
+		errs = append(errs, fmt.Sprintf("signal_history_days must be in [0, %d] (0 = disabled), got %d", maxPriceHistoryDays, *d))
+	}
+	if d := cfg.IndicatorLogDays; d != nil && (*d < 0 || *d > maxPriceHistoryDays) {
+		errs = append(errs, fmt.Sprintf("indicator_log_days must be in [0, %d] (0 = disabled), got %d", maxPriceHistoryDays, *d))
+	}
+	platformNames := make([]string, 0, len(cfg.Platforms))
+	for name := range cfg.Platforms {
+		platformNames = append(platformNames, name)
+	}
+	sort.Strings(platformNames)
+	for _, name := range platformNames {
+		pc := cfg.Platforms[name]
+		if pc == nil {
+			continue
+		}
+		errs = append(errs, symbolSpecErrors(name, pc.Symbols)...)
+		if pc.MinOrderNotionalUSD < 0 {
+			errs = append(errs, fmt.Sprintf("platforms.%s.min_order_notional_usd must be >= 0, got %g", name, pc.MinOrderNotionalUSD))
+		}
+		if pc.Risk == nil {
+			continue
+		}
+		if pc.Risk.MaxDrawdownPct < 0 || pc.Risk.MaxDrawdownPct > 100 {
+			errs = append(errs, fmt.Sprintf("platforms.%s.risk.max_drawdown_pct must be in [0, 100] (0 = no platform drawdown limit), got %g", name, pc.Risk.MaxDrawdownPct))
+		}
+		if pc.Risk.MaxNotionalUSD < 0 {
+			errs = append(errs, fmt.Sprintf("platforms.%s.risk.max_notional_usd must be >= 0 (0 = disabled), got %g", name, pc.Risk.MaxNotionalUSD))
+		}
 	}
 
 	// Validate leaderboard_summaries (#308).
@@ -2411,13 +2842,33 @@ func validateConfig(cfg *Config, skipLiveCredentialChecks bool) error {
 			errs = append(errs, fmt.Sprintf("summary_frequency[%q]: %v", k, err))
 		}
 	}
+	errs = append(errs, validateSummaryLayouts(cfg.SummaryLayout)...)
+	errs = append(errs, validateQuietHoursConfig(cfg.QuietHours)...)
+	errs = append(errs, validateWeeklyDigestConfig(cfg.WeeklyDigest)...)
+	errs = append(errs, weeklyDigestCompareErrors(cfg.WeeklyDigest, cfg.Strategies)...)
 
 	if _, err := ParseAlertThrottleInterval(cfg.AlertThrottleInterval); err != nil {
 		errs = append(errs, err.Error())
 	}
+	errs = append(errs, validateScriptOutputMaxBytes(cfg.ScriptOutputMaxBytes)...)
+	if cfg.ScriptFailureAlertAfter < 0 {
+		errs = append(errs, fmt.Sprintf("script_failure_alert_after must be >= 0 (0 = default %d), got %d", scriptFailureAlertThreshold, cfg.ScriptFailureAlertAfter))
+	}
 	if _, err := ParseKillSwitchResetDMTimeout(cfg.KillSwitchResetDMTimeout); err != nil {
 		errs = append(errs, err.Error())
 	}
+	errs = append(errs, validateHealthcheckConfig(cfg.Healthcheck)...)
+	errs = append(errs, validateGoogleSheetsConfig(cfg.GoogleSheets)...)
+	errs = append(errs, tradeLedgerErrors(cfg.TradeLedger, cfg.DBFile)...)
+	errs = append(errs, startupSyncErrors(cfg.StartupSync)...)
+	errs = append(errs, validatePushgatewayConfig(cfg.Pushgateway)...)
+	errs = append(errs, validateStaleDataConfig(cfg.StaleData)...)
+	errs = append(errs, validatePriceSanityConfig(cfg.PriceSanity)...)
+	errs = append(errs, validatePythonConcurrencyConfig(cfg.PythonConcurrency)...)
+	errs = append(errs, validatePriceValidationConfig(cfg.PriceValidation)...)
+	errs = append(errs, validateAlertEscalationConfig(cfg.AlertEscalation)...)
+	errs = append(errs, validateUpdateChannel(cfg)...)
+	errs = append(errs, validateAutoUpdateWindow(cfg.AutoUpdateWindow)...)
 	if cfg.Tuning != nil && cfg.Tuning.MaxRetainedRuns < 0 {
 		errs = append(errs, fmt.Sprintf("tuning.max_retained_runs must be >= 0 (0 = keep-all), got %d", cfg.Tuning.MaxRetainedRuns))
 	}
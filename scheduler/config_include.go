@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// configIncludeKey is the top-level root-config key listing fragment files
+// merged at load time, e.g. "include": ["spot.json", "perps.json"].
+const configIncludeKey = "include"
+
+// configIncludeForbiddenKeys may only appear in the root config: the version
+// stamp drives on-disk migrations of the root file, and nested includes would
+// make merge order and cycle detection non-obvious.
+var configIncludeForbiddenKeys = map[string]bool{
+	configIncludeKey: true,
+	"config_version": true,
+}
+
+// resolveConfigIncludes merges the fragment files named by the root config's
+// top-level "include" array into one config document.
+//
+// Merge rules (deliberately strict — a silently shadowed block is worse than a
+// load error):
+//   - "strategies" arrays concatenate: root first, then fragments in include order.
+//   - Any other top-level key may be defined by exactly one file (the root or
+//     one fragment); a second definition is a load error naming both files.
+//   - Fragments may not carry "include" or "config_version".
+//
+// Relative include paths resolve against the directory of the root config
+// path as given. Returns the merged JSON with the "include" key removed, plus
+// the resolved fragment paths in include order. A root without "include" is
+// returned unchanged with a nil path list.
+func resolveConfigIncludes(data []byte, configPath string) ([]byte, []string, error) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		// Leave the parse error to the caller's json.Unmarshal into Config so
+		// the message is the familiar "parse config: ..." one.
+		return data, nil, nil
+	}
+	rawInclude, ok := root[configIncludeKey]
+	if !ok {
+		return data, nil, nil
+	}
+	var includes []string
+	if err := json.Unmarshal(rawInclude, &includes); err != nil {
+		return nil, nil, fmt.Errorf("include: must be an array of file paths: %w", err)
+	}
+	delete(root, configIncludeKey)
+
+	baseDir := filepath.Dir(configPath)
+	owner := make(map[string]string, len(root))
+	for k := range root {
+		owner[k] = filepath.Base(configPath)
+	}
+	var strategies []json.RawMessage
+	if raw, ok := root["strategies"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &strategies); err != nil {
+			return nil, nil, fmt.Errorf("parse strategies: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(includes))
+	resolved := make([]string, 0, len(includes))
+	for i, inc := range includes {
+		inc = strings.TrimSpace(inc)
+		if inc == "" {
+			return nil, nil, fmt.Errorf("include[%d]: empty path", i)
+		}
+		p := inc
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(baseDir, p)
+		}
+		p = filepath.Clean(p)
+		if seen[p] {
+			return nil, nil, fmt.Errorf("include[%d]: %s listed more than once", i, inc)
+		}
+		seen[p] = true
+		fragData, err := os.ReadFile(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("include[%d]: read %s: %w", i, inc, err)
+		}
+		var frag map[string]json.RawMessage
+		if err := json.Unmarshal(fragData, &frag); err != nil {
+			return nil, nil, fmt.Errorf("include[%d]: parse %s: %w", i, inc, err)
+		}
+		keys := make([]string, 0, len(frag))
+		for k := range frag {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			raw := frag[k]
+			if configIncludeForbiddenKeys[k] {
+				return nil, nil, fmt.Errorf("include[%d]: %s: %q is only allowed in the root config", i, inc, k)
+			}
+			if k == "strategies" {
+				var fragStrategies []json.RawMessage
+				if err := json.Unmarshal(raw, &fragStrategies); err != nil {
+					return nil, nil, fmt.Errorf("include[%d]: %s: parse strategies: %w", i, inc, err)
+				}
+				strategies = append(strategies, fragStrategies...)
+				continue
+			}
+			if prev, dup := owner[k]; dup {
+				return nil, nil, fmt.Errorf("include[%d]: %s: top-level key %q is already defined in %s (only strategies may be split across files)", i, inc, k, prev)
+			}
+			owner[k] = inc
+			root[k] = raw
+		}
+		resolved = append(resolved, p)
+	}
+
+	if strategies != nil {
+		raw, err := json.Marshal(strategies)
+		if err != nil {
+			return nil, nil, fmt.Errorf("merge strategies: %w", err)
+		}
+		root["strategies"] = raw
+	}
+	merged, err := json.Marshal(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("merge config: %w", err)
+	}
+	return merged, resolved, nil
+}
+
+// includedFilesNote describes where cfg's strategies came from for operator
+// messages: "" for a single-file config, otherwise the merged fragment paths.
+func includedFilesNote(cfg *Config) string {
+	if cfg == nil || len(cfg.IncludedFiles) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (root config + include fragments: %s)", strings.Join(cfg.IncludedFiles, ", "))
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return strings.TrimSpace(string(raw)) == "null"
+}
+
+// configStrategyNotFound is the config writers' (dashboard, Discord) error for
+// a strategy ID missing from root["strategies"]. Writers edit the root file
+// only, so when the root has an include list the message points the operator
+// at the fragment files instead of implying the strategy does not exist.
+func configStrategyNotFound(root map[string]json.RawMessage, id string) error {
+	_, hasInclude := root[configIncludeKey]
+	return strategyNotFoundError(id, hasInclude)
+}
+
+// strategyNotFoundError is configStrategyNotFound for callers holding a
+// generically decoded root (#4942 /adjust).
+func strategyNotFoundError(id string, hasInclude bool) error {
+	if hasInclude {
+		return fmt.Errorf("strategy %q not found in the root config — strategies defined in included fragments must be edited in the fragment file", id)
+	}
+	return fmt.Errorf("strategy %q not found", id)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const includeTestSpotStrategy = `{
+	"id": "%s",
+	"type": "spot",
+	"script": "shared_scripts/check_strategy.py",
+	"args": ["sma_crossover", "BTC/USDT", "1h"],
+	"capital": 1000
+}`
+
+func includeTestStrategy(id string) string {
+	return strings.Replace(includeTestSpotStrategy, "%s", id, 1)
+}
+
+func writeIncludeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigMergesIncludedStrategies(t *testing.T) {
+	dir := t.TempDir()
+	writeIncludeFile(t, dir, "conf.d/spot.json", `{"strategies": [`+includeTestStrategy("spot-eth")+`]}`)
+	writeIncludeFile(t, dir, "conf.d/shared.json", `{"strategies": [`+includeTestStrategy("spot-sol")+`], "log_dir": "fragment-logs"}`)
+	path := writeTestConfig(t, dir, `{
+		"config_version": 17,
+		"include": ["conf.d/spot.json", "conf.d/shared.json"],
+		"strategies": [`+includeTestStrategy("spot-btc")+`]
+	}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	var ids []string
+	for _, sc := range cfg.Strategies {
+		ids = append(ids, sc.ID)
+	}
+	if got := strings.Join(ids, ","); got != "spot-btc,spot-eth,spot-sol" {
+		t.Fatalf("strategy order = %s, want root first then fragments in include order", got)
+	}
+	if cfg.LogDir != "fragment-logs" {
+		t.Errorf("LogDir = %q, want block from fragment", cfg.LogDir)
+	}
+	want := []string{filepath.Join(dir, "conf.d", "spot.json"), filepath.Join(dir, "conf.d", "shared.json")}
+	if len(cfg.IncludedFiles) != 2 || cfg.IncludedFiles[0] != want[0] || cfg.IncludedFiles[1] != want[1] {
+		t.Errorf("IncludedFiles = %v, want %v", cfg.IncludedFiles, want)
+	}
+}
+
+func TestLoadConfigIncludeOnlyStrategies(t *testing.T) {
+	dir := t.TempDir()
+	writeIncludeFile(t, dir, "perps.json", `{"strategies": [`+includeTestStrategy("spot-btc")+`]}`)
+	path := writeTestConfig(t, dir, `{"config_version": 17, "include": ["perps.json"]}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Strategies) != 1 || cfg.Strategies[0].ID != "spot-btc" {
+		t.Fatalf("Strategies = %+v, want the fragment's strategy", cfg.Strategies)
+	}
+}
+
+func TestLoadConfigIncludeRejects(t *testing.T) {
+	cases := []struct {
+		name     string
+		fragment string
+		include  string
+		wantErr  string
+	}{
+		{"duplicate top-level key", `{"log_dir": "other"}`, `["frag.json"]`, `"log_dir" is already defined in config.json`},
+		{"nested include", `{"include": ["x.json"]}`, `["frag.json"]`, `"include" is only allowed in the root config`},
+		{"fragment version stamp", `{"config_version": 17}`, `["frag.json"]`, `"config_version" is only allowed in the root config`},
+		{"missing file", `{}`, `["nope.json"]`, "read nope.json"},
+		{"listed twice", `{}`, `["frag.json", "./frag.json"]`, "listed more than once"},
+		{"not an array", `{}`, `"frag.json"`, "must be an array"},
+		{"unknown strategy key in fragment", `{"strategies": [{"id": "spot-x", "type": "spot", "script": "s.py", "capital": 1, "take_profit_atr_mult": 2}]}`, `["frag.json"]`, "take_profit_atr_mult"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeIncludeFile(t, dir, "frag.json", tc.fragment)
+			path := writeTestConfig(t, dir, `{
+				"config_version": 17,
+				"log_dir": "logs",
+				"include": `+tc.include+`,
+				"strategies": [`+includeTestStrategy("spot-btc")+`]
+			}`)
+			_, err := LoadConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveConfigIncludesNoIncludeUnchanged(t *testing.T) {
+	data := []byte(`{"strategies": []}`)
+	got, included, err := resolveConfigIncludes(data, "/nonexistent/config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) || included != nil {
+		t.Fatalf("got %s %v, want input unchanged", got, included)
+	}
+}
+
+func TestLoadConfigSnapshotResolvesIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeIncludeFile(t, dir, "spot.json", `{"strategies": [`+includeTestStrategy("spot-eth")+`]}`)
+	path := writeTestConfig(t, dir, `{"config_version": 17, "include": ["spot.json"], "strategies": [`+includeTestStrategy("spot-btc")+`]}`)
+
+	cfg, err := loadConfigSnapshot(path)
+	if err != nil {
+		t.Fatalf("loadConfigSnapshot: %v", err)
+	}
+	if len(cfg.Strategies) != 2 {
+		t.Fatalf("Strategies = %d, want 2 (snapshot must resolve includes against the config dir)", len(cfg.Strategies))
+	}
+}
+
+func TestConfigWritersPointAtIncludedFragments(t *testing.T) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(`{"include": ["perps.json"]}`), &root); err != nil {
+		t.Fatal(err)
+	}
+	err := removeStrategyFromRoot(root, "hl-btc")
+	if err == nil || !strings.Contains(err.Error(), "included fragments") {
+		t.Fatalf("remove err = %v, want fragment hint", err)
+	}
+	if _, err := addStrategyToRoot(root, "sma_crossover", "binanceus", "BTC"); err != nil {
+		t.Fatalf("add to include-only root: %v", err)
+	}
+	list, err := configStrategies(root)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("root strategies after add = %d (%v), want 1", len(list), err)
+	}
+}
+
+func TestIncludedFilesNoteInStrategyNotFound(t *testing.T) {
+	if got := includedFilesNote(&Config{}); got != "" {
+		t.Errorf("single-file note = %q, want empty", got)
+	}
+	cfg := &Config{IncludedFiles: []string{"/etc/go-trader/conf.d/perps.json"}}
+	_, err := lookupManualStrategy(cfg, "missing")
+	if err == nil || !strings.Contains(err.Error(), "conf.d/perps.json") {
+		t.Errorf("not-found error = %v, want the fragment path", err)
+	}
+}
@@ -184,10 +184,25 @@ func MigrateConfig(configPath string, fieldValues map[string]string, cfg *Config
 	if err != nil {
 		return fmt.Errorf("read config: %w", err)
 	}
+	newData, err := migrateConfigData(data, fieldValues)
+	if err != nil {
+		return err
+	}
 
+	tmpPath := configPath + ".tmp"
+	if err := os.WriteFile(tmpPath, newData, 0600); err != nil {
+		return fmt.Errorf("write tmp: %w", err)
+	}
+	return os.Rename(tmpPath, configPath)
+}
+
+// migrateConfigData is MigrateConfig without the file I/O: it returns the
+// bytes MigrateConfig would write. The dry-run preview
+// (config_migration_preview.go) diffs against exactly this output.
+func migrateConfigData(data []byte, fieldValues map[string]string) ([]byte, error) {
 	var raw map[string]interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
-		return fmt.Errorf("parse config: %w", err)
+		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
 	oldVer := 0
@@ -199,7 +214,7 @@ func MigrateConfig(configPath string, fieldValues map[string]string, cfg *Config
 	// migration floor — the sub-floor handlers are gone, so falling through
 	// would silently drop their translations and mis-load the config.
 	if oldVer != 0 && oldVer < MinSupportedConfigVersion {
-		return errUnsupportedConfigVersion(oldVer)
+		return nil, errUnsupportedConfigVersion(oldVer)
 	}
 	// #1285: likewise reject a version-less config that still carries a v7
 	// DM-routing key — that translation was deleted, so a rewrite would preserve
@@ -208,7 +223,7 @@ func MigrateConfig(configPath string, fieldValues map[string]string, cfg *Config
 	// checkRawConfigVersionSupported; reject before any rewrite.
 	if oldVer == 0 {
 		if key, found := versionlessConfigRemovedTranslationKey(data); found {
-			return errVersionlessRemovedTranslationKey(key)
+			return nil, errVersionlessRemovedTranslationKey(key)
 		}
 	}
 
@@ -249,7 +264,7 @@ func MigrateConfig(configPath string, fieldValues map[string]string, cfg *Config
 	// with the canonical section they map to.
 	if oldVer < 16 || hasLegacyUserDefaultAliases(raw) {
 		if err := migrateV16UserDefaults(raw); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -257,18 +272,19 @@ func MigrateConfig(configPath string, fieldValues map[string]string, cfg *Config
 
 	newData, err := json.MarshalIndent(raw, "", "  ")
 	if err != nil {
-		return fmt.Errorf("marshal: %w", err)
-	}
-
-	tmpPath := configPath + ".tmp"
-	if err := os.WriteFile(tmpPath, newData, 0600); err != nil {
-		return fmt.Errorf("write tmp: %w", err)
+		return nil, fmt.Errorf("marshal: %w", err)
 	}
-	return os.Rename(tmpPath, configPath)
+	return newData, nil
 }
 
 // setNestedField sets a value at a dot-path in a nested map[string]interface{}.
 func setNestedField(obj map[string]interface{}, path string, value string) {
+	setNestedValue(obj, path, value)
+}
+
+// setNestedValue is setNestedField for typed JSON values (numbers, bools) —
+// the #4942 strategy adjustments write floats, not strings.
+func setNestedValue(obj map[string]interface{}, path string, value interface{}) {
 	parts := strings.SplitN(path, ".", 2)
 	if len(parts) == 1 {
 		obj[parts[0]] = value
@@ -279,7 +295,7 @@ func setNestedField(obj map[string]interface{}, path string, value string) {
 		nested = make(map[string]interface{})
 		obj[parts[0]] = nested
 	}
-	setNestedField(nested, parts[1], value)
+	setNestedValue(nested, parts[1], value)
 }
 
 func jsonBoolish(v interface{}) bool {
@@ -369,6 +385,11 @@ func runConfigMigrationDM(cfg *Config, notifier *MultiNotifier, configPath strin
 				values[f.JSONPath] = f.Default
 			}
 		}
+		if preview, err := PreviewConfigMigration(configPath, values); err == nil {
+			for _, line := range preview {
+				fmt.Printf("[migration]   %s\n", line)
+			}
+		}
 		if err := MigrateConfig(configPath, values, cfg); err != nil {
 			fmt.Printf("[migration] Failed to migrate config: %v\n", err)
 		}
@@ -403,6 +424,21 @@ func runConfigMigrationDM(cfg *Config, notifier *MultiNotifier, configPath strin
 		}
 	}
 
+	// Show the exact change list and require an explicit yes before writing.
+	// Anything else (including a timeout) leaves the file untouched; the
+	// version stays behind, so the prompt repeats on the next restart.
+	preview, err := PreviewConfigMigration(configPath, values)
+	if err != nil {
+		notifier.SendOwnerDM(fmt.Sprintf("**Migration failed**: %v", err))
+		return
+	}
+	notifier.SendOwnerDM(fmt.Sprintf("**Pending config changes** (%d) to `%s`:\n%s", len(preview), configPath, formatConfigMigrationPreview(preview, 1800)))
+	resp, err := notifier.AskOwnerDM("Reply `yes` to write these changes, anything else to skip:", 10*time.Minute)
+	if err != nil || !strings.EqualFold(strings.TrimSpace(resp), "yes") {
+		notifier.SendOwnerDM("Migration skipped — config unchanged. You'll be asked again on the next restart; `go-trader --migrate-dry-run` previews it offline.")
+		return
+	}
+
 	if err := MigrateConfig(configPath, values, cfg); err != nil {
 		notifier.SendOwnerDM(fmt.Sprintf("**Migration failed**: %v", err))
 		return
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// configDiffValueMaxRunes caps one rendered value in a migration diff line so
+// a whole rewritten strategy block doesn't blow the Discord 2000-char limit.
+const configDiffValueMaxRunes = 160
+
+// PreviewConfigMigration returns the change list MigrateConfig would write for
+// fieldValues, without touching the file. Empty when the migration is a no-op.
+func PreviewConfigMigration(configPath string, fieldValues map[string]string) ([]string, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	newData, err := migrateConfigData(data, fieldValues)
+	if err != nil {
+		return nil, err
+	}
+	return diffConfigBytes(data, newData)
+}
+
+// diffConfigBytes is the sorted diffJSONValues change list between two
+// config documents.
+func diffConfigBytes(data, newData []byte) ([]string, error) {
+	var before, after interface{}
+	if err := json.Unmarshal(data, &before); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if err := json.Unmarshal(newData, &after); err != nil {
+		return nil, fmt.Errorf("parse migrated config: %w", err)
+	}
+	var lines []string
+	diffJSONValues("", before, after, &lines)
+	sort.Slice(lines, func(i, j int) bool {
+		pi, pj := configDiffPath(lines[i]), configDiffPath(lines[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return lines[i] < lines[j]
+	})
+	return lines, nil
+}
+
+// diffJSONValues appends one line per changed leaf: "+ path: v" (added),
+// "- path: v" (removed), "~ path: old → new" (changed). Objects recurse by
+// key; arrays recurse by index when both sides are arrays of equal length,
+// labelling elements by their "id" when they carry one (strategies).
+func diffJSONValues(path string, before, after interface{}, out *[]string) {
+	bm, bIsMap := before.(map[string]interface{})
+	am, aIsMap := after.(map[string]interface{})
+	if bIsMap && aIsMap {
+		keys := make(map[string]bool, len(bm)+len(am))
+		for k := range bm {
+			keys[k] = true
+		}
+		for k := range am {
+			keys[k] = true
+		}
+		for k := range keys {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			bv, inB := bm[k]
+			av, inA := am[k]
+			switch {
+			case !inB:
+				*out = append(*out, "+ "+child+": "+renderConfigDiffValue(av))
+			case !inA:
+				*out = append(*out, "- "+child+": "+renderConfigDiffValue(bv))
+			default:
+				diffJSONValues(child, bv, av, out)
+			}
+		}
+		return
+	}
+	ba, bIsArr := before.([]interface{})
+	aa, aIsArr := after.([]interface{})
+	if bIsArr && aIsArr && len(ba) == len(aa) {
+		for i := range ba {
+			diffJSONValues(fmt.Sprintf("%s[%s]", path, configDiffElemLabel(ba[i], i)), ba[i], aa[i], out)
+		}
+		return
+	}
+	if renderConfigDiffValue(before) != renderConfigDiffValue(after) {
+		*out = append(*out, "~ "+path+": "+renderConfigDiffValue(before)+" → "+renderConfigDiffValue(after))
+	}
+}
+
+func configDiffElemLabel(v interface{}, i int) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		if id, ok := m["id"].(string); ok && strings.TrimSpace(id) != "" {
+			return id
+		}
+	}
+	return fmt.Sprint(i)
+}
+
+func renderConfigDiffValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(b)
+	if clipped := truncateRunes(s, configDiffValueMaxRunes); clipped != s {
+		return clipped + "…"
+	}
+	return s
+}
+
+// configDiffPath strips the "+ "/"- "/"~ " marker for sorting by path.
+func configDiffPath(line string) string {
+	if len(line) > 2 {
+		line = line[2:]
+	}
+	if i := strings.Index(line, ": "); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// formatConfigMigrationPreview renders the diff as a fenced block for the
+// owner DM, clipped to maxChars with a count of the omitted lines.
+func formatConfigMigrationPreview(lines []string, maxChars int) string {
+	if len(lines) == 0 {
+		return "No changes to write."
+	}
+	var b strings.Builder
+	b.WriteString("```diff\n")
+	shown := 0
+	for _, line := range lines {
+		if b.Len()+len(line)+120 > maxChars { // leave room for the overflow note and fence
+			break
+		}
+		b.WriteString(line + "\n")
+		shown++
+	}
+	if shown < len(lines) {
+		fmt.Fprintf(&b, "… %d more change(s) — run `go-trader --migrate-dry-run` for the full list\n", len(lines)-shown)
+	}
+	b.WriteString("```")
+	return b.String()
+}
+
+// runMigrateDryRun implements --migrate-dry-run: list the fields the upgrade
+// DM would prompt for and print the diff MigrateConfig would write with their
+// defaults applied. Reads the config only — never LoadConfig, whose
+// synchronous schema migrations rewrite the file.
+func runMigrateDryRun(configPath string) int {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate-dry-run: read config: %v\n", err)
+		return 1
+	}
+	var probe struct {
+		ConfigVersion int `json:"config_version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate-dry-run: parse config: %v\n", err)
+		return 1
+	}
+
+	fields := NewFieldsSince(probe.ConfigVersion)
+	values := make(map[string]string)
+	fmt.Printf("Config %s: version %d → %d\n", configPath, probe.ConfigVersion, CurrentConfigVersion)
+	if len(fields) == 0 {
+		fmt.Println("No new fields to prompt for.")
+	} else {
+		fmt.Printf("%d pending field(s) (the upgrade DM asks for each; defaults shown):\n", len(fields))
+		for _, f := range fields {
+			def := f.Default
+			if def == "" {
+				def = "(none — left unset)"
+			} else {
+				values[f.JSONPath] = f.Default
+			}
+			fmt.Printf("  %s — %s [default: %s]\n", f.JSONPath, f.Description, def)
+		}
+	}
+
+	lines, err := PreviewConfigMigration(configPath, values)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate-dry-run: %v\n", err)
+		return 1
+	}
+	if len(lines) == 0 {
+		fmt.Println("Migration would not change the file.")
+		return 0
+	}
+	fmt.Printf("Migration would write %d change(s) (file not modified):\n", len(lines))
+	for _, line := range lines {
+		fmt.Println("  " + line)
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreviewConfigMigrationDoesNotWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	original := []byte(`{"config_version": 16, "interval_seconds": 300, "discord": {"enabled": true}, "strategies": [{"id": "hl-a", "type": "perps"}]}`)
+	if err := os.WriteFile(path, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := PreviewConfigMigration(path, map[string]string{"discord.owner_id": "12345"})
+	if err != nil {
+		t.Fatalf("PreviewConfigMigration: %v", err)
+	}
+	want := []string{
+		fmt.Sprintf("~ config_version: 16 → %d", CurrentConfigVersion),
+		`+ discord.owner_id: "12345"`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("preview =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(after, original) {
+		t.Error("preview modified the config file")
+	}
+}
+
+func TestPreviewConfigMigrationLabelsStrategiesByID(t *testing.T) {
+	before := map[string]interface{}{"strategies": []interface{}{
+		map[string]interface{}{"id": "hl-a", "allow_shorts": true},
+	}}
+	after := map[string]interface{}{"strategies": []interface{}{
+		map[string]interface{}{"id": "hl-a", "direction": "both"},
+	}}
+	var lines []string
+	diffJSONValues("", before, after, &lines)
+	joined := strings.Join(lines, "\n")
+	for _, want := range []string{`- strategies[hl-a].allow_shorts: true`, `+ strategies[hl-a].direction: "both"`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("diff missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestFormatConfigMigrationPreviewClips(t *testing.T) {
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("+ strategies[s-%03d].note: %q", i, strings.Repeat("x", 40)))
+	}
+	got := formatConfigMigrationPreview(lines, 500)
+	if len(got) > 500 {
+		t.Errorf("preview length %d exceeds cap 500", len(got))
+	}
+	if !strings.Contains(got, "more change(s)") || !strings.HasSuffix(got, "```") {
+		t.Errorf("clipped preview missing overflow note or fence:\n%s", got)
+	}
+	if formatConfigMigrationPreview(nil, 500) != "No changes to write." {
+		t.Error("empty preview should say there is nothing to write")
+	}
+}
+
+func TestRunMigrateDryRunLeavesFileUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	original := []byte(`{"config_version": 16, "strategies": []}`)
+	if err := os.WriteFile(path, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if code := runMigrateDryRun(path); code != 0 {
+		t.Fatalf("runMigrateDryRun exit = %d", code)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(after, original) {
+		t.Error("--migrate-dry-run modified the config file")
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("--migrate-dry-run left a .tmp file")
+	}
+}
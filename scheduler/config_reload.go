@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
 	"sort"
 	"strings"
@@ -32,6 +33,12 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 		addChange("interval_seconds: %d -> %d", cfg.IntervalSeconds, next.IntervalSeconds)
 		cfg.IntervalSeconds = next.IntervalSeconds
 	}
+	// #4882: the fragment list is informational (its strategies and blocks are
+	// already merged into next), but keep it current for operator messages.
+	if !reflect.DeepEqual(cfg.IncludedFiles, next.IncludedFiles) {
+		addChange("include: [%s] -> [%s]", strings.Join(cfg.IncludedFiles, ", "), strings.Join(next.IncludedFiles, ", "))
+		cfg.IncludedFiles = append([]string(nil), next.IncludedFiles...)
+	}
 	// #1256: the GLOBAL notify_ratchet_triggers default (#1110) hot-reloads —
 	// notification-only, never touches position/order state, mirroring the
 	// per-strategy #1118 override handled below. Without this copy a dashboard
@@ -59,6 +66,17 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 			return nil, fmt.Errorf("alert_throttle_interval: %w", err)
 		}
 	}
+	if cfg.ScriptFailureAlertAfter != next.ScriptFailureAlertAfter {
+		addChange("script_failure_alert_after: %d -> %d", cfg.ScriptFailureAlertAfter, next.ScriptFailureAlertAfter)
+		cfg.ScriptFailureAlertAfter = next.ScriptFailureAlertAfter
+		applyScriptFailureAlertThreshold(cfg.ScriptFailureAlertAfter)
+	}
+	// #4979: applies to subprocesses spawned after the reload.
+	if cfg.ScriptOutputMaxBytes != next.ScriptOutputMaxBytes {
+		addChange("script_output_max_bytes: %d -> %d", cfg.ScriptOutputMaxBytes, next.ScriptOutputMaxBytes)
+		cfg.ScriptOutputMaxBytes = next.ScriptOutputMaxBytes
+		applyScriptOutputMaxBytes(cfg.ScriptOutputMaxBytes)
+	}
 	if cfg.KillSwitchResetDMTimeout != next.KillSwitchResetDMTimeout {
 		addChange("kill_switch_reset_dm_timeout: %q -> %q", cfg.KillSwitchResetDMTimeout, next.KillSwitchResetDMTimeout)
 		cfg.KillSwitchResetDMTimeout = next.KillSwitchResetDMTimeout
@@ -66,6 +84,12 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 			return nil, fmt.Errorf("kill_switch_reset_dm_timeout: %w", err)
 		}
 	}
+	// #4932: CORS only changes response headers; the server picks up the
+	// new block via SetConfigContext below.
+	if !reflect.DeepEqual(cfg.CORS, next.CORS) {
+		addChange("cors: %s -> %s", corsLabel(cfg.CORS), corsLabel(next.CORS))
+		cfg.CORS = cloneCORSConfig(next.CORS)
+	}
 	// #1382: tuning retention is research-artifact housekeeping only — never
 	// touches positions/orders — so SIGHUP can adopt a new cap immediately and
 	// prune on the spot (queued/running runs are still never deleted).
@@ -76,6 +100,47 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 			server.tuning.setMaxRetainedRuns(cfg.tuningMaxRetainedRuns())
 		}
 	}
+	// The healthcheck ping is monitoring-only; the main loop reads
+	// cfg.Healthcheck at ping time, so a new URL takes effect next cycle.
+	if !reflect.DeepEqual(cfg.Healthcheck, next.Healthcheck) {
+		addChange("healthcheck: %s -> %s", formatHealthcheckConfig(cfg.Healthcheck), formatHealthcheckConfig(next.Healthcheck))
+		cfg.Healthcheck = cloneHealthcheckConfig(next.Healthcheck)
+	}
+	// #4936: the Sheets export is reporting-only and reads cfg.GoogleSheets
+	// per cycle; a new spreadsheet_id restarts the cursor at the ledger end.
+	if !reflect.DeepEqual(cfg.GoogleSheets, next.GoogleSheets) {
+		addChange("google_sheets: %s -> %s", formatGoogleSheetsConfig(cfg.GoogleSheets), formatGoogleSheetsConfig(next.GoogleSheets))
+		cfg.GoogleSheets = cloneGoogleSheetsConfig(next.GoogleSheets)
+	}
+	// #4944: stale-data checks only alert and hold entries; the dispatch
+	// sites read cfg.StaleData per cycle. Tracker streaks are kept.
+	if !reflect.DeepEqual(cfg.StaleData, next.StaleData) {
+		addChange("stale_data: %s -> %s", formatStaleDataConfig(cfg.StaleData), formatStaleDataConfig(next.StaleData))
+		cfg.StaleData = cloneStaleDataConfig(next.StaleData)
+	}
+	// #4960: price sanity only refuses signals; reference prices are kept.
+	if !reflect.DeepEqual(cfg.PriceSanity, next.PriceSanity) {
+		addChange("price_sanity: %s -> %s", formatPriceSanityConfig(cfg.PriceSanity), formatPriceSanityConfig(next.PriceSanity))
+		cfg.PriceSanity = clonePriceSanityConfig(next.PriceSanity)
+	}
+	// #4978: new acquires see the new limit at once; running scripts keep
+	// their slots.
+	if !reflect.DeepEqual(cfg.PythonConcurrency, next.PythonConcurrency) {
+		addChange("python_concurrency: %s -> %s", formatPythonConcurrencyConfig(cfg.PythonConcurrency), formatPythonConcurrencyConfig(next.PythonConcurrency))
+		cfg.PythonConcurrency = clonePythonConcurrencyConfig(next.PythonConcurrency)
+		pythonSemaphore.Configure(cfg.PythonConcurrency)
+	}
+	// #4961: the next cycle re-quotes every source under the new block.
+	if !reflect.DeepEqual(cfg.PriceValidation, next.PriceValidation) {
+		addChange("price_validation: %s -> %s", formatPriceValidationConfig(cfg.PriceValidation), formatPriceValidationConfig(next.PriceValidation))
+		cfg.PriceValidation = clonePriceValidationConfig(next.PriceValidation)
+	}
+	// #4945: escalation only changes who is told about unacknowledged
+	// critical alerts; alerts already armed keep their original window.
+	if !reflect.DeepEqual(cfg.AlertEscalation, next.AlertEscalation) {
+		addChange("alert_escalation: %s -> %s", formatAlertEscalationConfig(cfg.AlertEscalation), formatAlertEscalationConfig(next.AlertEscalation))
+		cfg.AlertEscalation = cloneAlertEscalationConfig(next.AlertEscalation)
+	}
 	// #1135: user_defaults flows through hot-reload so SIGHUP edits to the
 	// operator-default layer shape subsequent manual-open invocations, new
 	// type=manual defaults, and close-default injection. The CLI loads fresh
@@ -125,6 +190,125 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 				ss.RiskState.MaxDrawdownPct = ns.MaxDrawdownPct
 			}
 		}
+		// Per-strategy caps are re-read at the next dispatch; no state to touch.
+		if sc.MaxNotionalUSD != ns.MaxNotionalUSD {
+			addChange("strategy[%s].max_notional_usd: $%.2f -> $%.2f", sc.ID, sc.MaxNotionalUSD, ns.MaxNotionalUSD)
+			sc.MaxNotionalUSD = ns.MaxNotionalUSD
+		}
+		if sc.MaxPositions != ns.MaxPositions {
+			addChange("strategy[%s].max_positions: %d -> %d", sc.ID, sc.MaxPositions, ns.MaxPositions)
+			sc.MaxPositions = ns.MaxPositions
+		}
+		if !reflect.DeepEqual(sc.ExitAt, ns.ExitAt) {
+			addChange("strategy[%s].exit_at: %s -> %s", sc.ID, formatExitSchedule(sc.ExitAt), formatExitSchedule(ns.ExitAt))
+			sc.ExitAt = ns.ExitAt
+		}
+		if sc.MaxHoldingHours != ns.MaxHoldingHours {
+			addChange("strategy[%s].max_holding_hours: %g -> %g", sc.ID, sc.MaxHoldingHours, ns.MaxHoldingHours)
+			sc.MaxHoldingHours = ns.MaxHoldingHours
+		}
+		if sc.Shadow != ns.Shadow {
+			addChange("strategy[%s].shadow: %v -> %v", sc.ID, sc.Shadow, ns.Shadow)
+			sc.Shadow = ns.Shadow
+		}
+		if sc.SignalDedupMinutes != ns.SignalDedupMinutes {
+			addChange("strategy[%s].signal_dedup_minutes: %d -> %d", sc.ID, sc.SignalDedupMinutes, ns.SignalDedupMinutes)
+			sc.SignalDedupMinutes = ns.SignalDedupMinutes
+		}
+		if sc.MaxDailyTrades != ns.MaxDailyTrades {
+			addChange("strategy[%s].max_daily_trades: %d -> %d", sc.ID, sc.MaxDailyTrades, ns.MaxDailyTrades)
+			sc.MaxDailyTrades = ns.MaxDailyTrades
+		}
+		// allow_short only gates NEW paper shorts; an open short still closes
+		// on the next BUY and keeps accruing borrow at the current rate.
+		if sc.AllowShort != ns.AllowShort {
+			addChange("strategy[%s].allow_short: %v -> %v", sc.ID, sc.AllowShort, ns.AllowShort)
+			sc.AllowShort = ns.AllowShort
+		}
+		if sc.ShortBorrowAPR() != ns.ShortBorrowAPR() {
+			addChange("strategy[%s].short_borrow_apr_pct: %.2f%% -> %.2f%%", sc.ID, sc.ShortBorrowAPR(), ns.ShortBorrowAPR())
+		}
+		sc.ShortBorrowAPRPct = ns.ShortBorrowAPRPct
+		if !sizeFractionConfigEqual(sc.SizeFraction, ns.SizeFraction) {
+			addChange("strategy[%s].size_fraction: %s -> %s", sc.ID, sizeFractionLabel(sc.SizeFraction), sizeFractionLabel(ns.SizeFraction))
+			if ns.SizeFraction != nil {
+				clone := *ns.SizeFraction
+				sc.SizeFraction = &clone
+			} else {
+				sc.SizeFraction = nil
+			}
+		}
+		// The slippage guard only vets the next live fill.
+		if !floatPtrEqual(sc.MaxSlippagePct, ns.MaxSlippagePct) {
+			addChange("strategy[%s].max_slippage_pct: %s -> %s", sc.ID, formatFloatPtrPct(sc.MaxSlippagePct), formatFloatPtrPct(ns.MaxSlippagePct))
+			sc.MaxSlippagePct = ns.MaxSlippagePct
+		}
+		if sc.FlattenOnSlippage != ns.FlattenOnSlippage {
+			addChange("strategy[%s].flatten_on_slippage: %v -> %v", sc.ID, sc.FlattenOnSlippage, ns.FlattenOnSlippage)
+			sc.FlattenOnSlippage = ns.FlattenOnSlippage
+		}
+		// A resting order keeps its price; disabling cancels it on the next
+		// cycle (expireSpotPaperOrders).
+		if !paperLimitEntriesConfigEqual(sc.PaperLimitEntries, ns.PaperLimitEntries) {
+			addChange("strategy[%s].paper_limit_entries: %s -> %s", sc.ID, paperLimitEntriesLabel(sc.PaperLimitEntries), paperLimitEntriesLabel(ns.PaperLimitEntries))
+			if ns.PaperLimitEntries != nil {
+				clone := *ns.PaperLimitEntries
+				sc.PaperLimitEntries = &clone
+			} else {
+				sc.PaperLimitEntries = nil
+			}
+		}
+		// paper_stop_order only changes how the next paper stop check reads
+		// the bar; the trigger itself is untouched.
+		if !paperStopOrderConfigEqual(sc.PaperStopOrder, ns.PaperStopOrder) {
+			addChange("strategy[%s].paper_stop_order: %s -> %s", sc.ID, paperStopOrderLabel(sc.PaperStopOrder), paperStopOrderLabel(ns.PaperStopOrder))
+			if ns.PaperStopOrder != nil {
+				clone := *ns.PaperStopOrder
+				sc.PaperStopOrder = &clone
+			} else {
+				sc.PaperStopOrder = nil
+			}
+		}
+		// dca only sizes the next BUY; lowering the cap below an open
+		// position's basis just stops further tranches.
+		if !dcaConfigEqual(sc.DCA, ns.DCA) {
+			addChange("strategy[%s].dca: %s -> %s", sc.ID, dcaLabel(sc.DCA), dcaLabel(ns.DCA))
+			if ns.DCA != nil {
+				clone := *ns.DCA
+				sc.DCA = &clone
+			} else {
+				sc.DCA = nil
+			}
+		}
+		// #4942: theta_harvest is read from config on every options cycle
+		// (CheckThetaHarvest), so new thresholds apply to open sold options
+		// from the next cycle with no state to touch.
+		if !thetaHarvestConfigEqual(sc.ThetaHarvest, ns.ThetaHarvest) {
+			addChange("strategy[%s].theta_harvest: %s -> %s", sc.ID, thetaHarvestLabel(sc.ThetaHarvest), thetaHarvestLabel(ns.ThetaHarvest))
+			if ns.ThetaHarvest != nil {
+				clone := *ns.ThetaHarvest
+				sc.ThetaHarvest = &clone
+			} else {
+				sc.ThetaHarvest = nil
+			}
+		}
+		// #4968: long_option_exits is read every options cycle like theta_harvest.
+		if !reflect.DeepEqual(sc.LongOptionExits, ns.LongOptionExits) {
+			addChange("strategy[%s].long_option_exits: %s -> %s", sc.ID, longOptionExitsLabel(sc.LongOptionExits), longOptionExitsLabel(ns.LongOptionExits))
+			if ns.LongOptionExits != nil {
+				clone := *ns.LongOptionExits
+				sc.LongOptionExits = &clone
+			} else {
+				sc.LongOptionExits = nil
+			}
+		}
+		// Rolling drawdown window: the next CheckRisk re-derives PeakValue from
+		// PeakHistory (seeding it from the current peak on enable, dropping it
+		// on disable), so no state mutation is needed here.
+		if sc.DrawdownWindowDays != ns.DrawdownWindowDays {
+			addChange("strategy[%s].drawdown_window_days: %d -> %d", sc.ID, sc.DrawdownWindowDays, ns.DrawdownWindowDays)
+			sc.DrawdownWindowDays = ns.DrawdownWindowDays
+		}
 		// #1048: per-strategy circuit-breaker toggle is hot-reloadable always,
 		// including while a position is open (no state-compat guard). Disabling
 		// only suppresses NEW fires from the next cycle; an already-latched CB
@@ -179,6 +363,11 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 			addChange("strategy[%s].paused: %t -> %t", sc.ID, sc.Paused, ns.Paused)
 			sc.Paused = ns.Paused
 		}
+		// #4951: trade-alert mute is a notification preference only.
+		if sc.MuteTradeAlerts != ns.MuteTradeAlerts {
+			addChange("strategy[%s].mute_trade_alerts: %t -> %t", sc.ID, sc.MuteTradeAlerts, ns.MuteTradeAlerts)
+			sc.MuteTradeAlerts = ns.MuteTradeAlerts
+		}
 		// #1275/#1402: allow_deprecated is hot-reloadable always, including while a
 		// position is open — an acknowledgment flag only, never gates loading,
 		// probing, or trading. Pointer so unset/true/false are distinct (paper
@@ -226,6 +415,21 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 			addChange("strategy[%s].interval_seconds: %d -> %d", sc.ID, sc.IntervalSeconds, ns.IntervalSeconds)
 			sc.IntervalSeconds = ns.IntervalSeconds
 		}
+		if sc.Benchmark != ns.Benchmark {
+			addChange("strategy[%s].benchmark: %q -> %q", sc.ID, sc.Benchmark, ns.Benchmark)
+			sc.Benchmark = ns.Benchmark
+		}
+		if !reflect.DeepEqual(sc.DependsOn, ns.DependsOn) {
+			addChange("strategy[%s].depends_on: %v -> %v", sc.ID, sc.DependsOn, ns.DependsOn)
+			sc.DependsOn = append([]string(nil), ns.DependsOn...)
+		}
+		// #4981: the next check-script spawn picks the new environment up.
+		// Values are secrets, so only names and the passthrough mode are logged.
+		if !reflect.DeepEqual(sc.Env, ns.Env) || !reflect.DeepEqual(sc.EnvPassthrough, ns.EnvPassthrough) {
+			addChange("strategy[%s].env: %s -> %s", sc.ID, formatScriptEnv(sc.Env, sc.EnvPassthrough), formatScriptEnv(ns.Env, ns.EnvPassthrough))
+			sc.Env = maps.Clone(ns.Env)
+			sc.EnvPassthrough = append([]string(nil), ns.EnvPassthrough...)
+		}
 		if sc.InvertSignal != ns.InvertSignal {
 			addChange("strategy[%s].invert_signal: %t -> %t", sc.ID, sc.InvertSignal, ns.InvertSignal)
 			sc.InvertSignal = ns.InvertSignal
@@ -401,7 +605,29 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 		addChange("portfolio_risk.max_asset_concentration_pct: %.2f%% -> %.2f%%",
 			portfolioRiskMaxAssetConcentration(cfg.PortfolioRisk), portfolioRiskMaxAssetConcentration(next.PortfolioRisk))
 	}
+	if prev, nxt := correlationGroupsLabel(cfg.PortfolioRisk), correlationGroupsLabel(next.PortfolioRisk); prev != nxt {
+		addChange("portfolio_risk.correlation_groups: %s -> %s", prev, nxt)
+	}
+	if prev, nxt := assetConcentrationOverridesLabel(cfg.PortfolioRisk), assetConcentrationOverridesLabel(next.PortfolioRisk); prev != nxt {
+		addChange("portfolio_risk.asset_concentration_pct: %s -> %s", prev, nxt)
+	}
+	if portfolioRiskDrawdownWindowDays(cfg.PortfolioRisk) != portfolioRiskDrawdownWindowDays(next.PortfolioRisk) {
+		addChange("portfolio_risk.drawdown_window_days: %d -> %d",
+			portfolioRiskDrawdownWindowDays(cfg.PortfolioRisk), portfolioRiskDrawdownWindowDays(next.PortfolioRisk))
+	}
+	if varConfidencePct(cfg.PortfolioRisk) != varConfidencePct(next.PortfolioRisk) || varLookbackDays(cfg.PortfolioRisk) != varLookbackDays(next.PortfolioRisk) {
+		addChange("portfolio_risk VaR: %.0f%%/%dd -> %.0f%%/%dd",
+			varConfidencePct(cfg.PortfolioRisk), varLookbackDays(cfg.PortfolioRisk), varConfidencePct(next.PortfolioRisk), varLookbackDays(next.PortfolioRisk))
+	}
+	if maxVaRPct(cfg.PortfolioRisk) != maxVaRPct(next.PortfolioRisk) {
+		addChange("portfolio_risk.max_var_pct: %.2f%% -> %.2f%%", maxVaRPct(cfg.PortfolioRisk), maxVaRPct(next.PortfolioRisk))
+	}
+	// #4986: the daily reset boundary moves for the next rollover check.
+	if prev, nxt := portfolioRiskDailyResetLabel(cfg.PortfolioRisk), portfolioRiskDailyResetLabel(next.PortfolioRisk); prev != nxt {
+		addChange("portfolio_risk daily reset: %s -> %s", prev, nxt)
+	}
 	cfg.PortfolioRisk = clonePortfolioRiskConfig(next.PortfolioRisk)
+	applyDailyResetFromConfig(cfg)
 
 	if !reflect.DeepEqual(cfg.Discord.Channels, next.Discord.Channels) {
 		addChange("discord.channels: %s -> %s", formatStringMap(cfg.Discord.Channels), formatStringMap(next.Discord.Channels))
@@ -441,13 +667,36 @@ func applyHotReloadConfig(cfg, next *Config, state *AppState, notifier *MultiNot
 		addChange("summary_frequency: %s -> %s", formatStringMap(cfg.SummaryFrequency), formatStringMap(next.SummaryFrequency))
 	}
 	cfg.SummaryFrequency = cloneStringMap(next.SummaryFrequency)
+	if !reflect.DeepEqual(cfg.SummaryLayout, next.SummaryLayout) {
+		addChange("summary_layout: %s -> %s", formatSummaryLayouts(cfg.SummaryLayout), formatSummaryLayouts(next.SummaryLayout))
+	}
+	cfg.SummaryLayout = cloneSummaryLayouts(next.SummaryLayout)
+	if !reflect.DeepEqual(cfg.QuietHours, next.QuietHours) {
+		addChange("quiet_hours: %s -> %s", formatQuietHoursConfig(cfg.QuietHours), formatQuietHoursConfig(next.QuietHours))
+	}
+	cfg.QuietHours = cloneQuietHoursConfig(next.QuietHours)
+	if !reflect.DeepEqual(cfg.WeeklyDigest, next.WeeklyDigest) {
+		addChange("weekly_digest: %s -> %s", formatWeeklyDigestConfig(cfg.WeeklyDigest), formatWeeklyDigestConfig(next.WeeklyDigest))
+	}
+	cfg.WeeklyDigest = cloneWeeklyDigestConfig(next.WeeklyDigest)
 
 	cfg.ConfigVersion = next.ConfigVersion
+	if line, nextLine := platformRiskStartupSummaryLine(cfg), platformRiskStartupSummaryLine(next); line != nextLine {
+		addChange("platforms.*.risk limits: %q -> %q", strings.TrimPrefix(line, platformRiskSummaryPrefix), strings.TrimPrefix(nextLine, platformRiskSummaryPrefix))
+	}
+	if label, nextLabel := symbolSpecsLabel(cfg.Platforms), symbolSpecsLabel(next.Platforms); label != nextLabel {
+		addChange("platforms.*.symbols: %s -> %s", label, nextLabel)
+	}
+	if label, nextLabel := platformMinNotionalsLabel(cfg.Platforms), platformMinNotionalsLabel(next.Platforms); label != nextLabel {
+		addChange("platforms.*.min_order_notional_usd: %s -> %s", label, nextLabel)
+	}
 	cfg.Platforms = next.Platforms
+	setSymbolSpecs(cfg.Platforms)
 
 	if notifier != nil {
 		notifier.ReloadConfig(cfg)
 	}
+	criticalAlerts.Configure(cfg)
 	if server != nil {
 		server.UpdateStrategies(cfg.Strategies)
 		server.SetConfigContext(server.configPath, cfg)
@@ -498,12 +747,45 @@ func validateHotReloadCompatible(cfg, next *Config) error {
 	if cfg.AutoUpdate != next.AutoUpdate {
 		errs = append(errs, fmt.Sprintf("auto_update changed (%q -> %q; restart required)", cfg.AutoUpdate, next.AutoUpdate))
 	}
+	if cfg.AutoUpdateWindow != next.AutoUpdateWindow {
+		errs = append(errs, fmt.Sprintf("auto_update_window changed (%q -> %q; restart required)", cfg.AutoUpdateWindow, next.AutoUpdateWindow))
+	}
+	if cfg.UpdateChannel != next.UpdateChannel || cfg.UpdateRepo != next.UpdateRepo {
+		errs = append(errs, fmt.Sprintf("update_channel/update_repo changed (%q %q -> %q %q; restart required)", cfg.UpdateChannel, cfg.UpdateRepo, next.UpdateChannel, next.UpdateRepo))
+	}
+	if cfg.UpdateSigningKey != next.UpdateSigningKey {
+		errs = append(errs, "update_signing_key changed (restart required)")
+	}
 	if cfg.LeaderboardPostTime != next.LeaderboardPostTime {
 		errs = append(errs, fmt.Sprintf("leaderboard_post_time changed (%q -> %q; restart required)", cfg.LeaderboardPostTime, next.LeaderboardPostTime))
 	}
 	if !reflect.DeepEqual(cfg.Correlation, next.Correlation) {
 		errs = append(errs, "correlation changed (restart required)")
 	}
+	if !reflect.DeepEqual(cfg.Ensembles, next.Ensembles) {
+		errs = append(errs, "ensembles changed (restart required)")
+	}
+	if !reflect.DeepEqual(cfg.CapitalAllocator, next.CapitalAllocator) {
+		errs = append(errs, "capital_allocator changed (restart required)")
+	}
+	if !reflect.DeepEqual(cfg.MarketRegime, next.MarketRegime) {
+		errs = append(errs, "market_regime changed (restart required)")
+	}
+	if !reflect.DeepEqual(cfg.GRPC, next.GRPC) {
+		errs = append(errs, "grpc changed (restart required)")
+	}
+	if !reflect.DeepEqual(cfg.TradeLedger, next.TradeLedger) {
+		errs = append(errs, "trade_ledger changed (restart required)")
+	}
+	if PriceHistoryRetentionDays(cfg) != PriceHistoryRetentionDays(next) {
+		errs = append(errs, "price_history_days changed (restart required)")
+	}
+	if SignalHistoryRetentionDays(cfg) != SignalHistoryRetentionDays(next) {
+		errs = append(errs, "signal_history_days changed (restart required)")
+	}
+	if IndicatorLogRetentionDays(cfg) != IndicatorLogRetentionDays(next) {
+		errs = append(errs, "indicator_log_days changed (restart required)")
+	}
 	// #1062/#1139: mask top-level regime fields with explicit apply paths.
 	// Any OTHER regime field change still rejects.
 	if !regimeConfigEqualIgnoringReloadableFields(cfg.Regime, next.Regime) {
@@ -627,8 +909,9 @@ func validateHotReloadStateCompatible(cfg, next *Config, state *AppState) error
 		// it mid-position is surprising (e.g. flipping add_spacing_atr sign, or
 		// lowering a cap below the current count). Block toggle/shape changes
 		// while open; edits when flat take effect on the next cycle. Applies to
-		// both perps (strategy-flag adds) and manual (manual-add).
-		if (sc.Type == "perps" || sc.Type == "manual") && strategyHasOpenPositions(stateStrategy(state, sc.ID)) {
+		// perps (strategy-flag adds), manual (manual-add) and paper spot
+		// pyramiding.
+		if (sc.Type == "perps" || sc.Type == "manual" || sc.Type == "spot") && strategyHasOpenPositions(stateStrategy(state, sc.ID)) {
 			if sc.AllowScaleIn != ns.AllowScaleIn {
 				errs = append(errs, fmt.Sprintf("strategy[%s] allow_scale_in changed with open positions (%t -> %t; flatten first or restart after close)",
 					sc.ID, sc.AllowScaleIn, ns.AllowScaleIn))
@@ -821,12 +1104,31 @@ func validateHotReloadStateCompatible(cfg, next *Config, state *AppState) error
 
 func strategyRestartShape(sc StrategyConfig) StrategyConfig {
 	sc.MaxDrawdownPct = 0
+	sc.DrawdownWindowDays = 0
+	sc.MaxNotionalUSD = 0
+	sc.MaxPositions = 0
+	sc.MaxDailyTrades = 0
+	sc.SignalDedupMinutes = 0
+	sc.Shadow = false
+	sc.MaxHoldingHours = 0
+	sc.ExitAt = nil
+	sc.AllowShort = false
+	sc.ShortBorrowAPRPct = nil
+	sc.DCA = nil
+	sc.LongOptionExits = nil
+	sc.ThetaHarvest = nil // #4942: hot-reloadable always; re-read each options cycle. Applied in applyHotReloadConfig.
+	sc.PaperLimitEntries = nil
+	sc.PaperStopOrder = nil
+	sc.SizeFraction = nil
+	sc.MaxSlippagePct = nil
+	sc.FlattenOnSlippage = false
 	sc.CircuitBreaker = nil              // #1048: hot-reloadable always, including while open. No state-compat guard — disabling only suppresses new fires; an already-latched CB and pending close still drain, and re-enabling just resumes evaluation on the next cycle.
 	sc.CBDrawdownCooldownMinutes = nil   // #1273: hot-reloadable always, including while open — parameterizes only FUTURE fires; a latched CircuitBreakerUntil is never rewritten. Applied in applyHotReloadConfig.
 	sc.CBLossStreakThreshold = nil       // #1273: same stance — the next CheckRisk cycle reads the new threshold via the accessor.
 	sc.CBLossStreakCooldownMinutes = nil // #1273: same stance as the drawdown cooldown.
 	sc.NotifyRatchetTriggers = nil       // #1118: hot-reloadable always, including while open — notification preference only, never touches position/order state. Masked here so a pure notify_ratchet_triggers toggle isn't flagged "restart required"; applied in applyHotReloadConfig.
 	sc.Paused = false                    // #1150: hot-reloadable always, including while open. Pausing only holds position-increasing signals from the next cycle — closes, trailing SL, ratchet, and protection sync keep running — so toggling mid-position never strands protection. Applied in applyHotReloadConfig.
+	sc.MuteTradeAlerts = false           // #4951: hot-reloadable always — notification preference only. Applied in applyHotReloadConfig.
 	sc.LLMEntryAnalysis = nil            // #1137: hot-reloadable always, including while open — advisory-only entry commentary, never touches position/order state. Applied in applyHotReloadConfig.
 	sc.AllowDeprecated = nil             // #1275/#1402: hot-reloadable always, including while open — acknowledgment flag only, never gates loading, probing, or trading. Pointer (*bool) so unset/true/false are distinct. Applied in applyHotReloadConfig; reloadConfig re-evaluates the deprecated-edge warning after apply, so flipping the ack off re-warns.
 	sc.Capital = 0
@@ -835,6 +1137,10 @@ func strategyRestartShape(sc StrategyConfig) StrategyConfig {
 	sc.MarginPerTradeUSD = nil // #518: hot-reloadable; nil/positive switching is purely additive
 	sc.RiskPerTradePct = nil   // #1268: hot-reloadable; state-compat blocks risk↔notional mode switches while open
 	sc.IntervalSeconds = 0
+	sc.DependsOn = nil      // #4923: execution ordering only; applied in applyHotReloadConfig.
+	sc.Benchmark = ""       // #4927: display-only; applied in applyHotReloadConfig.
+	sc.Env = nil            // #4981: read at each check-script spawn; applied in applyHotReloadConfig.
+	sc.EnvPassthrough = nil // #4981: same.
 	sc.OpenStrategy = StrategyRef{}
 	sc.CloseStrategy = nil
 	sc.closeStrategiesLegacy = nil
@@ -872,6 +1178,59 @@ func scaleInConfigEqual(a, b *ScaleInConfig) bool {
 	return *a == *b
 }
 
+func sizeFractionConfigEqual(a, b *SizeFractionConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func dcaConfigEqual(a, b *DCAConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func thetaHarvestConfigEqual(a, b *ThetaHarvestConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func thetaHarvestLabel(th *ThetaHarvestConfig) string {
+	if th == nil {
+		return "off"
+	}
+	label := fmt.Sprintf("enabled=%v profit=%g%% stop=%g%% min_dte=%g", th.Enabled, th.ProfitTargetPct, th.StopLossPct, th.MinDTEClose)
+	if th.TrailingTriggerPct > 0 {
+		label += fmt.Sprintf(" trail=%g%%/-%g", th.TrailingTriggerPct, th.TrailingRetracePct)
+	}
+	return label
+}
+
+func longOptionExitsLabel(lx *LongOptionExitConfig) string {
+	if lx == nil {
+		return "off"
+	}
+	return fmt.Sprintf("enabled=%v profit=%g%% stop=%g%% min_dte=%g", lx.Enabled, lx.ProfitTargetPct, lx.StopLossPct, lx.MinDTEClose)
+}
+
+func paperLimitEntriesConfigEqual(a, b *PaperLimitEntriesConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func paperStopOrderConfigEqual(a, b *PaperStopOrderConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func floatPtrEqual(a, b *float64) bool {
 	if a == nil || b == nil {
 		return a == b
@@ -1073,11 +1432,42 @@ func portfolioRiskMaxAssetConcentration(pr *PortfolioRiskConfig) float64 {
 	return pr.MaxAssetConcentrationPct
 }
 
+func portfolioRiskDrawdownWindowDays(pr *PortfolioRiskConfig) int {
+	if pr == nil {
+		return 0
+	}
+	return pr.DrawdownWindowDays
+}
+
+func portfolioRiskDailyResetLabel(pr *PortfolioRiskConfig) string {
+	hour, tz := 0, "UTC"
+	if pr != nil {
+		hour = pr.DailyResetHour
+		if pr.DailyResetTimezone != "" {
+			tz = pr.DailyResetTimezone
+		}
+	}
+	return fmt.Sprintf("%02d:00 %s", hour, tz)
+}
+
 func clonePortfolioRiskConfig(pr *PortfolioRiskConfig) *PortfolioRiskConfig {
 	if pr == nil {
 		return nil
 	}
 	cp := *pr
+	if pr.AssetConcentrationPct != nil {
+		cp.AssetConcentrationPct = make(map[string]float64, len(pr.AssetConcentrationPct))
+		for asset, pct := range pr.AssetConcentrationPct {
+			cp.AssetConcentrationPct[asset] = pct
+		}
+	}
+	if pr.CorrelationGroups != nil {
+		cp.CorrelationGroups = make([]CorrelationGroupConfig, len(pr.CorrelationGroups))
+		for i, g := range pr.CorrelationGroups {
+			g.Assets = append([]string(nil), g.Assets...)
+			cp.CorrelationGroups[i] = g
+		}
+	}
 	return &cp
 }
 
@@ -1209,23 +1599,3 @@ func formatStringMap(m map[string]string) string {
 	}
 	return string(b)
 }
-
-func schedulerTickSeconds(cfg *Config) int {
-	if cfg == nil {
-		return 60
-	}
-	tickSeconds := cfg.IntervalSeconds
-	for _, sc := range cfg.Strategies {
-		si := sc.IntervalSeconds
-		if si <= 0 {
-			si = cfg.IntervalSeconds
-		}
-		if si < tickSeconds {
-			tickSeconds = si
-		}
-	}
-	if tickSeconds < 60 {
-		tickSeconds = 60
-	}
-	return tickSeconds
-}
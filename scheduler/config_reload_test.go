@@ -463,6 +463,33 @@ func TestApplyHotReloadConfigRejectsFixedToTrailingWithOpenPosition(t *testing.T
 	}
 }
 
+// #4942 — theta_harvest hot-reloads (also while sold options are open) and
+// is not a restart-required shape change.
+func TestApplyHotReloadConfigAppliesThetaHarvest(t *testing.T) {
+	sc := StrategyConfig{
+		ID: "opt", Type: "options", Platform: "deribit", Script: "x.py", Capital: 1000, MaxDrawdownPct: 20,
+		ThetaHarvest: &ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 60, StopLossPct: 200, MinDTEClose: 3},
+	}
+	cfg := minimalReloadConfig([]StrategyConfig{sc})
+	sc.ThetaHarvest = &ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 75, StopLossPct: 150, MinDTEClose: 3}
+	next := minimalReloadConfig([]StrategyConfig{sc})
+
+	changes, err := applyHotReloadConfig(cfg, next, NewAppState(), nil, nil)
+	if err != nil {
+		t.Fatalf("theta_harvest reload rejected: %v", err)
+	}
+	if got := cfg.Strategies[0].ThetaHarvest; got == nil || got.ProfitTargetPct != 75 || got.StopLossPct != 150 {
+		t.Fatalf("theta_harvest not applied: %+v", got)
+	}
+	if got := strings.Join(changes, "\n"); !strings.Contains(got, "strategy[opt].theta_harvest:") {
+		t.Fatalf("changes missing theta_harvest: %q", changes)
+	}
+	next.Strategies[0].ThetaHarvest.ProfitTargetPct = 90
+	if cfg.Strategies[0].ThetaHarvest.ProfitTargetPct != 75 {
+		t.Fatal("applied theta_harvest aliases the reloaded config")
+	}
+}
+
 func minimalReloadConfig(strategies []StrategyConfig) *Config {
 	return &Config{
 		IntervalSeconds: 600,
@@ -1217,6 +1244,9 @@ func TestValidateHotReloadCompatible(t *testing.T) {
 		{"status_port changed", func(c *Config) { c.StatusPort = 9090 }, "status_port"},
 		{"status_token changed", func(c *Config) { c.StatusToken = "tok" }, "status token"},
 		{"auto_update changed", func(c *Config) { c.AutoUpdate = "daily" }, "auto_update"},
+		{"auto_update_window changed", func(c *Config) { c.AutoUpdateWindow = "sat 02:00-04:00" }, "auto_update_window"},
+		{"update_channel changed", func(c *Config) { c.UpdateChannel = "releases" }, "update_channel"},
+		{"update_signing_key changed", func(c *Config) { c.UpdateSigningKey = "k" }, "update_signing_key"},
 		{"leaderboard_post_time changed", func(c *Config) { c.LeaderboardPostTime = "09:00" }, "leaderboard_post_time"},
 		{"correlation changed", func(c *Config) {
 			c.Correlation = &CorrelationConfig{Enabled: true}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// expandStrategySymbols replaces every strategy carrying a "symbols" list with
+// one instance per symbol. Each instance is a copy of the template with:
+//   - ID "<id>-<symbol slug>" (e.g. "sma-majors-btc"), so state, trades and
+//     logs stay per-symbol exactly as if the entries were written by hand;
+//   - args[1] (the canonical symbol slot for every strategy type) set to the
+//     symbol — one check-script call per symbol per cycle;
+//   - capital, capital_pct and initial_capital split across the instances by
+//     symbol_weights (normalized) or equally when no weights are given.
+//
+// Expansion happens in memory only; the config file keeps the compact form,
+// so config-editing commands must target the template entry. Returns load
+// errors in validateConfig's "strategy[id]: ..." shape.
+func expandStrategySymbols(cfg *Config) []string {
+	var errs []string
+	expanded := make([]StrategyConfig, 0, len(cfg.Strategies))
+	for _, sc := range cfg.Strategies {
+		if len(sc.Symbols) == 0 {
+			if sc.SymbolWeights != nil {
+				errs = append(errs, fmt.Sprintf("strategy[%s]: symbol_weights requires symbols", sc.ID))
+			}
+			expanded = append(expanded, sc)
+			continue
+		}
+		instances, err := expandStrategySymbolsOne(sc)
+		if err != "" {
+			errs = append(errs, fmt.Sprintf("strategy[%s]: %s", sc.ID, err))
+			continue
+		}
+		expanded = append(expanded, instances...)
+	}
+	cfg.Strategies = expanded
+	return errs
+}
+
+func expandStrategySymbolsOne(sc StrategyConfig) ([]StrategyConfig, string) {
+	if sc.Type == "manual" {
+		return nil, "symbols is not supported for manual strategies (use one entry per symbol)"
+	}
+	if len(sc.Args) < 2 {
+		return nil, "symbols requires args with a symbol slot at args[1]"
+	}
+	seen := make(map[string]bool, len(sc.Symbols))
+	slugs := make(map[string]string, len(sc.Symbols))
+	for _, sym := range sc.Symbols {
+		sym = strings.TrimSpace(sym)
+		if sym == "" {
+			return nil, "symbols contains an empty entry"
+		}
+		if seen[sym] {
+			return nil, fmt.Sprintf("symbols lists %q more than once", sym)
+		}
+		seen[sym] = true
+		slug := symbolIDSlug(sym)
+		if slug == "" {
+			return nil, fmt.Sprintf("symbol %q does not yield an id suffix", sym)
+		}
+		if prev, ok := slugs[slug]; ok {
+			return nil, fmt.Sprintf("symbols %q and %q expand to the same id %q", prev, sym, sc.ID+"-"+slug)
+		}
+		slugs[slug] = sym
+	}
+
+	weights := make(map[string]float64, len(sc.Symbols))
+	total := 0.0
+	if sc.SymbolWeights != nil {
+		for sym, w := range sc.SymbolWeights {
+			if !seen[sym] {
+				return nil, fmt.Sprintf("symbol_weights has %q which is not in symbols", sym)
+			}
+			if w <= 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return nil, fmt.Sprintf("symbol_weights[%s] must be positive, got %g", sym, w)
+			}
+		}
+		for _, sym := range sc.Symbols {
+			w, ok := sc.SymbolWeights[strings.TrimSpace(sym)]
+			if !ok {
+				return nil, fmt.Sprintf("symbol_weights is missing %q (weights must cover every symbol)", sym)
+			}
+			weights[strings.TrimSpace(sym)] = w
+			total += w
+		}
+	} else {
+		for _, sym := range sc.Symbols {
+			weights[strings.TrimSpace(sym)] = 1
+			total++
+		}
+	}
+
+	out := make([]StrategyConfig, 0, len(sc.Symbols))
+	for _, sym := range sc.Symbols {
+		sym = strings.TrimSpace(sym)
+		share := weights[sym] / total
+		inst := sc
+		inst.ID = sc.ID + "-" + symbolIDSlug(sym)
+		inst.Args = append([]string(nil), sc.Args...)
+		inst.Args[1] = sym
+		inst.Capital = sc.Capital * share
+		inst.CapitalPct = sc.CapitalPct * share
+		inst.InitialCapital = sc.InitialCapital * share
+		inst.Symbols = nil
+		inst.SymbolWeights = nil
+		out = append(out, inst)
+	}
+	return out, ""
+}
+
+// symbolIDSlug derives the strategy-ID suffix for a symbol: the lowercased
+// base asset ("BTC/USDT" → "btc", "ETH" → "eth", "BTC-PERP" → "btc") with
+// anything outside [a-z0-9] dropped.
+func symbolIDSlug(sym string) string {
+	base := sym
+	if i := strings.IndexAny(base, "/-:"); i > 0 {
+		base = base[:i]
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(base) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
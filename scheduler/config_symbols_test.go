@@ -0,0 +1,68 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigExpandsStrategySymbols(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestConfig(t, dir, `{
+		"config_version": 17,
+		"strategies": [{
+			"id": "sma-majors",
+			"type": "spot",
+			"script": "shared_scripts/check_strategy.py",
+			"args": ["sma_crossover", "*", "1h"],
+			"capital": 1000,
+			"symbols": ["BTC/USDT", "ETH/USDT", "SOL/USDT"],
+			"symbol_weights": {"BTC/USDT": 2, "ETH/USDT": 1, "SOL/USDT": 1}
+		}]
+	}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	want := map[string]struct {
+		sym     string
+		capital float64
+	}{
+		"sma-majors-btc": {"BTC/USDT", 500},
+		"sma-majors-eth": {"ETH/USDT", 250},
+		"sma-majors-sol": {"SOL/USDT", 250},
+	}
+	if len(cfg.Strategies) != len(want) {
+		t.Fatalf("got %d strategies, want %d", len(cfg.Strategies), len(want))
+	}
+	for _, sc := range cfg.Strategies {
+		w, ok := want[sc.ID]
+		if !ok {
+			t.Fatalf("unexpected id %q", sc.ID)
+		}
+		if sc.Args[1] != w.sym || math.Abs(sc.Capital-w.capital) > 1e-9 || sc.Symbols != nil || sc.MaxDrawdownPct != 60 {
+			t.Errorf("%s: args=%v capital=%v symbols=%v dd=%v", sc.ID, sc.Args, sc.Capital, sc.Symbols, sc.MaxDrawdownPct)
+		}
+	}
+}
+
+func TestExpandStrategySymbolsRejects(t *testing.T) {
+	cases := []struct {
+		sc   StrategyConfig
+		want string
+	}{
+		{StrategyConfig{ID: "m", Type: "manual", Symbols: []string{"BTC"}}, "not supported for manual"},
+		{StrategyConfig{ID: "a", Type: "spot", Args: []string{"sma"}, Symbols: []string{"BTC"}}, "args[1]"},
+		{StrategyConfig{ID: "d", Type: "spot", Args: []string{"sma", "x"}, Symbols: []string{"BTC/USDT", "BTC/USD"}}, "same id"},
+		{StrategyConfig{ID: "w", Type: "spot", Args: []string{"sma", "x"}, Symbols: []string{"BTC", "ETH"}, SymbolWeights: map[string]float64{"BTC": 1}}, "missing \"ETH\""},
+		{StrategyConfig{ID: "o", Type: "spot", Args: []string{"sma", "x"}, SymbolWeights: map[string]float64{"BTC": 1}}, "requires symbols"},
+	}
+	for _, tc := range cases {
+		cfg := &Config{Strategies: []StrategyConfig{tc.sc}}
+		errs := expandStrategySymbols(cfg)
+		if len(errs) != 1 || !strings.Contains(errs[0], tc.want) {
+			t.Errorf("%s: errs = %v, want %q", tc.sc.ID, errs, tc.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+// portfolio_risk.correlation_groups: declared asset groups for the exposure
+// cap (exposure_cap.go).
+//
+// A lightweight precursor to estimated correlations: the operator names the
+// assets that move together (majors = BTC,ETH,SOL) and the gate treats the
+// group like one asset. Member net deltas come from the same computeAssetDeltas
+// pass the #1270 arms use (spot + perps + manual + delta-weighted options).
+//
+//   - max_same_direction_notional_usd: the sum of the members' positive
+//     (negative) nets is the group's long (short) bucket; over the cap, new
+//     opens in that direction on ANY member are held.
+//   - max_net_exposure_pct: |sum of member nets| as a percent of portfolio
+//     value; over the cap, new opens in the group's net direction are held.
+//
+// Same enforcement contract as the other arms: position-increasing actions
+// only (pausedBlocksSignal, option open legs by delta direction, manual
+// entries), unlatched, nothing force-closed.
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ExposureCapGroupStat is one correlation group's evaluation for the cycle.
+type ExposureCapGroupStat struct {
+	Name         string
+	Assets       []string // upper-cased members
+	LongUSD      float64
+	ShortUSD     float64
+	NetUSD       float64
+	NetPct       float64 // |NetUSD| / portfolio value * 100 (0 when no basis)
+	LongBlocked  bool    // same-direction arm: long bucket over the cap
+	ShortBlocked bool
+	NetBlocked   string // "long"/"short" when the net arm holds that direction
+	CapUSD       float64
+	NetCapPct    float64
+}
+
+// correlationGroupsArmed reports whether any group carries a limit.
+func correlationGroupsArmed(pr *PortfolioRiskConfig) bool {
+	if pr == nil {
+		return false
+	}
+	for _, g := range pr.CorrelationGroups {
+		if g.MaxSameDirectionNotionalUSD > 0 || g.MaxNetExposurePct > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// correlationGroupsNeedPV reports whether any group's net arm needs the
+// portfolio value basis.
+func correlationGroupsNeedPV(pr *PortfolioRiskConfig) bool {
+	if pr == nil {
+		return false
+	}
+	for _, g := range pr.CorrelationGroups {
+		if g.MaxNetExposurePct > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateCorrelationGroups buckets per-asset net deltas (asset -> signed USD)
+// into the configured groups.
+func evaluateCorrelationGroups(pr *PortfolioRiskConfig, nets map[string]float64, portfolioValue float64) []ExposureCapGroupStat {
+	if pr == nil {
+		return nil
+	}
+	var out []ExposureCapGroupStat
+	for _, g := range pr.CorrelationGroups {
+		if g.MaxSameDirectionNotionalUSD <= 0 && g.MaxNetExposurePct <= 0 {
+			continue
+		}
+		gs := ExposureCapGroupStat{Name: g.Name, CapUSD: g.MaxSameDirectionNotionalUSD, NetCapPct: g.MaxNetExposurePct}
+		for _, a := range g.Assets {
+			a = strings.ToUpper(strings.TrimSpace(a))
+			gs.Assets = append(gs.Assets, a)
+			net := nets[a]
+			if net > 0 {
+				gs.LongUSD += net
+			} else {
+				gs.ShortUSD += -net
+			}
+			gs.NetUSD += net
+		}
+		gs.LongBlocked = gs.CapUSD > 0 && gs.LongUSD > gs.CapUSD
+		gs.ShortBlocked = gs.CapUSD > 0 && gs.ShortUSD > gs.CapUSD
+		if portfolioValue > 0 {
+			gs.NetPct = math.Abs(gs.NetUSD) / portfolioValue * 100
+			if gs.NetCapPct > 0 && gs.NetPct > gs.NetCapPct {
+				gs.NetBlocked = "long"
+				if gs.NetUSD < 0 {
+					gs.NetBlocked = "short"
+				}
+			}
+		}
+		out = append(out, gs)
+	}
+	return out
+}
+
+// hasMember reports whether asset belongs to the group.
+func (gs ExposureCapGroupStat) hasMember(asset string) bool {
+	for _, a := range gs.Assets {
+		if strings.EqualFold(a, asset) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockReason returns why a new dir ("long"/"short") open on a member must
+// be held, or "".
+func (gs ExposureCapGroupStat) blockReason(dir string) string {
+	if (dir == "long" && gs.LongBlocked) || (dir == "short" && gs.ShortBlocked) {
+		bucket := gs.LongUSD
+		if dir == "short" {
+			bucket = gs.ShortUSD
+		}
+		return fmt.Sprintf("group %s %s exposure $%.2f exceeds cap $%.2f", gs.Name, dir, bucket, gs.CapUSD)
+	}
+	if gs.NetBlocked == dir {
+		return fmt.Sprintf("group %s net %s exposure %.1f%% of portfolio value exceeds cap %.1f%%", gs.Name, dir, gs.NetPct, gs.NetCapPct)
+	}
+	return ""
+}
+
+// exposureCapGroupBlock returns the first group hold for a new dir open on
+// asset, or "".
+func exposureCapGroupBlock(st ExposureCapStatus, asset, dir string) string {
+	for _, gs := range st.Groups {
+		if !gs.hasMember(asset) {
+			continue
+		}
+		if why := gs.blockReason(dir); why != "" {
+			return why
+		}
+	}
+	return ""
+}
+
+// correlationGroupsLabel renders the configured groups for the startup line
+// and the hot-reload diff, or "none".
+func correlationGroupsLabel(pr *PortfolioRiskConfig) string {
+	if pr == nil || len(pr.CorrelationGroups) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(pr.CorrelationGroups))
+	for _, g := range pr.CorrelationGroups {
+		p := fmt.Sprintf("%s(%s)", g.Name, strings.ToUpper(strings.Join(g.Assets, ",")))
+		if g.MaxSameDirectionNotionalUSD > 0 {
+			p += fmt.Sprintf(" same_dir<=$%.0f", g.MaxSameDirectionNotionalUSD)
+		}
+		if g.MaxNetExposurePct > 0 {
+			p += fmt.Sprintf(" net<=%.1f%%", g.MaxNetExposurePct)
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// groupBlockLines renders one operator line per held group direction, in
+// config order.
+func groupBlockLines(st ExposureCapStatus) []string {
+	var lines []string
+	for _, gs := range st.Groups {
+		for _, dir := range []string{"long", "short"} {
+			if why := gs.blockReason(dir); why != "" {
+				lines = append(lines, fmt.Sprintf("%s — new %s opens on %s blocked", why, dir, strings.Join(gs.Assets, "/")))
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateExposureCap_CorrelationGroups(t *testing.T) {
+	// BTC long $10000, ETH $6000, SOL $3000; PV 20000.
+	pr := &PortfolioRiskConfig{MaxDrawdownPct: 25, CorrelationGroups: []CorrelationGroupConfig{
+		{Name: "majors", Assets: []string{"btc", "ETH"}, MaxSameDirectionNotionalUSD: 15000},
+		{Name: "alts", Assets: []string{"ETH", "SOL"}, MaxNetExposurePct: 40},
+	}}
+	if !exposureCapConfigured(pr) {
+		t.Fatal("groups alone must arm the exposure cap")
+	}
+	st := evaluateExposureCap(pr, exposureTestStates(), exposureTestConfigs(), exposureTestPrices(), 20000)
+	if len(st.Groups) != 2 || !st.Groups[0].LongBlocked || st.Groups[0].LongUSD != 16000 {
+		t.Fatalf("groups = %+v", st.Groups)
+	}
+	if st.Groups[1].NetBlocked != "long" || st.Groups[1].NetPct != 45 {
+		t.Fatalf("alts = %+v", st.Groups[1])
+	}
+
+	if blocked, why := exposureCapBlocksSignal(st, "BTC", 1, 0, 0, "", true, true); !blocked || !strings.Contains(why, "group majors long exposure $16000.00 exceeds cap $15000.00") {
+		t.Errorf("BTC long: blocked=%v why=%q", blocked, why)
+	}
+	if blocked, _ := exposureCapBlocksSignal(st, "BTC", -1, 0, 0, "", true, true); blocked {
+		t.Error("BTC short must pass — it reduces the group's long exposure")
+	}
+	if blocked, why := exposureCapBlocksSignal(st, "SOL", 1, 0, 0, "", true, true); !blocked || !strings.Contains(why, "group alts net long") {
+		t.Errorf("SOL long: blocked=%v why=%q", blocked, why)
+	}
+	if blocked, _ := exposureCapManualEntryBlock(st, "DOGE", "long"); blocked {
+		t.Error("non-member asset must pass")
+	}
+
+	_, dropped, reason := exposureCapOptionsActions(st, "ETH", []OptionsAction{{Action: "buy", OptionType: "call"}, {Action: "buy", OptionType: "put"}})
+	if dropped != 1 || !strings.Contains(reason, "group majors") {
+		t.Errorf("options: dropped=%d reason=%q", dropped, reason)
+	}
+
+	msg, next := exposureCapAlertMessage(st, exposureCapAlertState{}, time.Now())
+	if !strings.Contains(msg, "group majors long") || !next.GroupAlerted["majors/long"] || !next.GroupAlerted["alts/long"] {
+		t.Errorf("alert: %q next=%+v", msg, next)
+	}
+	if again, _ := exposureCapAlertMessage(st, next, time.Now()); again != "" {
+		t.Errorf("second cycle must not re-alert: %q", again)
+	}
+}
+
+func TestValidateConfig_CorrelationGroups(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		PortfolioRisk: &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60, CorrelationGroups: []CorrelationGroupConfig{
+			{Name: "majors", Assets: []string{"BTC", "ETH"}, MaxSameDirectionNotionalUSD: 1000},
+			{Name: "Majors", Assets: []string{"BTC"}},
+		}},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"duplicate group name", "at least 2 assets", "set max_same_direction_notional_usd and/or max_net_exposure_pct"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
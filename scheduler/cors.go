@@ -0,0 +1,154 @@
+package main
+
+// cors: cross-origin access to the status HTTP API (#4932).
+//
+// A browser dashboard served from another host can read /status, /history,
+// /risk, /api/* etc. once its origin is listed in cors.allowed_origins. Only
+// read methods (GET/HEAD) are granted: the mutation endpoints keep their
+// same-origin/loopback posture, and a preflight for POST/PUT/DELETE gets no
+// CORS headers so the browser refuses it. Authorization is always an allowed
+// request header so the status_token bearer works cross-origin; credentials
+// mode (cookies) is never enabled.
+//
+// Unset cors leaves the server exactly as before — no CORS headers at all.
+// Hot-reloadable: SetConfigContext copies the block on startup and SIGHUP.
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// CORSConfig is the top-level "cors" block.
+type CORSConfig struct {
+	AllowedOrigins []string `json:"allowed_origins"`           // exact origins ("https://dash.example.com") or "*"
+	AllowedHeaders []string `json:"allowed_headers,omitempty"` // extra request headers beyond Authorization and Content-Type
+}
+
+// corsDefaultHeaders are always allowed on a granted preflight.
+var corsDefaultHeaders = []string{"Authorization", "Content-Type"}
+
+const (
+	corsAllowedMethods = "GET, HEAD, OPTIONS"
+	corsMaxAgeSeconds  = "600"
+)
+
+// cloneCORSConfig deep-copies c (nil-safe).
+func cloneCORSConfig(c *CORSConfig) *CORSConfig {
+	if c == nil {
+		return nil
+	}
+	return &CORSConfig{
+		AllowedOrigins: slices.Clone(c.AllowedOrigins),
+		AllowedHeaders: slices.Clone(c.AllowedHeaders),
+	}
+}
+
+// corsLabel is the one-line reload/startup description of c.
+func corsLabel(c *CORSConfig) string {
+	if c == nil || len(c.AllowedOrigins) == 0 {
+		return "off"
+	}
+	label := "origins=" + strings.Join(c.AllowedOrigins, ",")
+	if len(c.AllowedHeaders) > 0 {
+		label += " headers=" + strings.Join(c.AllowedHeaders, ",")
+	}
+	return label
+}
+
+// corsErrors validates the cors block.
+func corsErrors(c *CORSConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			continue
+		}
+		u, err := url.Parse(o)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			errs = append(errs, fmt.Sprintf("cors.allowed_origins entry %q must be \"*\" or scheme://host[:port] with no path", o))
+		}
+	}
+	for _, h := range c.AllowedHeaders {
+		if !validHTTPHeaderName(h) {
+			errs = append(errs, fmt.Sprintf("cors.allowed_headers entry %q is not a valid header name", h))
+		}
+	}
+	return errs
+}
+
+// validHTTPHeaderName reports whether h is a non-empty RFC 7230 token.
+func validHTTPHeaderName(h string) bool {
+	if h == "" {
+		return false
+	}
+	for _, r := range h {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// corsAllowOrigin returns the Access-Control-Allow-Origin value for origin,
+// or "" when it is not allowed.
+func corsAllowOrigin(c *CORSConfig, origin string) string {
+	if c == nil || origin == "" {
+		return ""
+	}
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			return "*"
+		}
+		if strings.EqualFold(o, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// currentCORS returns the CORS block in force (guarded by strategiesMu).
+func (ss *StatusServer) currentCORS() *CORSConfig {
+	ss.strategiesMu.RLock()
+	defer ss.strategiesMu.RUnlock()
+	return ss.cors
+}
+
+// corsHandler wraps next with the configured CORS policy. Preflights are
+// answered here; they never reach the route handlers.
+func (ss *StatusServer) corsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := ss.currentCORS()
+		if c == nil || len(c.AllowedOrigins) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		allow := corsAllowOrigin(c, origin)
+		preflight := r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != ""
+		if preflight {
+			method := r.Header.Get("Access-Control-Request-Method")
+			if allow != "" && (method == http.MethodGet || method == http.MethodHead) {
+				h := w.Header()
+				h.Set("Access-Control-Allow-Origin", allow)
+				h.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+				h.Set("Access-Control-Allow-Headers", strings.Join(append(slices.Clone(corsDefaultHeaders), c.AllowedHeaders...), ", "))
+				h.Set("Access-Control-Max-Age", corsMaxAgeSeconds)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if allow != "" && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			w.Header().Set("Access-Control-Allow-Origin", allow)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCORSErrors(t *testing.T) {
+	ok := &CORSConfig{AllowedOrigins: []string{"*", "https://dash.example.com", "http://10.0.0.2:3000"}, AllowedHeaders: []string{"X-Request-Id"}}
+	if errs := corsErrors(ok); len(errs) != 0 {
+		t.Fatalf("valid config rejected: %v", errs)
+	}
+	bad := &CORSConfig{AllowedOrigins: []string{"dash.example.com", "https://dash.example.com/", "ftp://x"}, AllowedHeaders: []string{"", "X Bad"}}
+	if errs := corsErrors(bad); len(errs) != 5 {
+		t.Fatalf("errs = %v, want 5", errs)
+	}
+}
+
+func TestCORSHandler(t *testing.T) {
+	var mu sync.RWMutex
+	ss := NewStatusServer(NewAppState(), &mu, "", nil, nil)
+	h := ss.corsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) }))
+	do := func(method, origin, reqMethod string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/status", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if reqMethod != "" {
+			req.Header.Set("Access-Control-Request-Method", reqMethod)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Unset: pass-through, no headers.
+	if rec := do(http.MethodGet, "https://dash.example.com", ""); rec.Code != http.StatusTeapot || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("unset cors: %d %v", rec.Code, rec.Header())
+	}
+
+	ss.SetConfigContext("", &Config{CORS: &CORSConfig{AllowedOrigins: []string{"https://dash.example.com"}, AllowedHeaders: []string{"X-Request-Id"}}})
+	rec := do(http.MethodGet, "https://dash.example.com", "")
+	if rec.Code != http.StatusTeapot || rec.Header().Get("Access-Control-Allow-Origin") != "https://dash.example.com" || rec.Header().Get("Vary") != "Origin" {
+		t.Fatalf("allowed GET: %d %v", rec.Code, rec.Header())
+	}
+	if rec := do(http.MethodGet, "https://evil.example.com", ""); rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("foreign origin granted: %v", rec.Header())
+	}
+	if rec := do(http.MethodPost, "https://dash.example.com", ""); rec.Code != http.StatusTeapot || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("POST granted: %v", rec.Header())
+	}
+
+	rec = do(http.MethodOptions, "https://dash.example.com", http.MethodGet)
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Headers") != "Authorization, Content-Type, X-Request-Id" {
+		t.Fatalf("preflight: %d %v", rec.Code, rec.Header())
+	}
+	if rec := do(http.MethodOptions, "https://dash.example.com", http.MethodPost); rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("POST preflight granted: %v", rec.Header())
+	}
+}
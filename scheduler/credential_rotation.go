@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// credentialEnvFileVar names an env file (systemd EnvironmentFile= / dotenv
+// format) re-read on credential rotation. A process's environment is fixed at
+// exec, so editing the unit's EnvironmentFile alone never reaches a running
+// daemon — pointing GO_TRADER_ENV_FILE at the same file closes that gap.
+const credentialEnvFileVar = "GO_TRADER_ENV_FILE"
+
+// requestCredentialRotation asks the main loop to rotate credentials, the
+// same self-signal pattern requestSIGHUPReload uses for config reloads.
+func requestCredentialRotation() error {
+	return raiseSelfSignal(credentialRotateSignal)
+}
+
+// parseEnvFile reads KEY=VALUE lines. Blank lines and #/; comments are
+// skipped, an optional "export " prefix is accepted, and one layer of
+// matching single or double quotes is stripped from the value.
+func parseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	out := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		out[key] = value
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// refreshCredentialEnv re-reads the credential sources and exports changed
+// values. Precedence matches startup: the env file (an explicit operator
+// edit) wins; provider values only overwrite keys the provider itself
+// exported at startup or that are still unset, so a box-level override set
+// before launch keeps winning. Returns the names whose values changed
+// (sorted, never values). Errors when no rotation source is configured.
+func refreshCredentialEnv() ([]string, error) {
+	updates := make(map[string]string)
+	sources := 0
+
+	name, secrets, err := fetchProviderSecrets()
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		sources++
+		if _, err := validateSecretEnvNames(secrets); err != nil {
+			return nil, fmt.Errorf("%s secrets provider: %w", name, err)
+		}
+		for k, v := range secrets {
+			if secretsProviderOwned[k] || strings.TrimSpace(os.Getenv(k)) == "" {
+				updates[k] = v
+			}
+		}
+	}
+
+	if path := strings.TrimSpace(os.Getenv(credentialEnvFileVar)); path != "" {
+		sources++
+		fileVals, err := parseEnvFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", credentialEnvFileVar, err)
+		}
+		if _, err := validateSecretEnvNames(fileVals); err != nil {
+			return nil, fmt.Errorf("%s: %w", credentialEnvFileVar, err)
+		}
+		for k, v := range fileVals {
+			updates[k] = v
+		}
+	}
+
+	if sources == 0 {
+		return nil, fmt.Errorf("no credential source to reload from — set GO_TRADER_SECRETS_PROVIDER or %s (the process environment is fixed at startup)", credentialEnvFileVar)
+	}
+
+	var changed []string
+	for k, v := range updates {
+		if os.Getenv(k) == v {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return nil, fmt.Errorf("set %s: %w", k, err)
+		}
+		if name != "" && secrets[k] == v {
+			secretsProviderOwned[k] = true
+		}
+		changed = append(changed, k)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// credentialRotationResult summarizes one rotation for logs and the owner DM.
+type credentialRotationResult struct {
+	ChangedEnv []string // env var names whose values changed
+	Rotated    []string // live clients re-established (discord, telegram, status token)
+	Errors     []string
+}
+
+// rotateCredentials re-reads credentials and swaps them into the clients
+// that hold them in-process. Python check/execute subprocesses read exchange
+// keys from the environment at spawn, so refreshed env vars reach them on the
+// next cycle with no further action. Runs on the main goroutine between
+// cycles (like the SIGHUP reload); takes mu only to update cfg.
+//
+// A failed client swap restores the previous env value so the next SIGHUP
+// reload (which re-reads env) does not see a token change it must reject.
+func rotateCredentials(cfg *Config, mu *sync.RWMutex, notifier *MultiNotifier, server *StatusServer) credentialRotationResult {
+	var res credentialRotationResult
+	changed, err := refreshCredentialEnv()
+	if err != nil {
+		res.Errors = append(res.Errors, err.Error())
+		return res
+	}
+	res.ChangedEnv = changed
+
+	if tok := os.Getenv("DISCORD_BOT_TOKEN"); tok != "" && tok != cfg.Discord.Token {
+		if d := notifier.DiscordBackend(); d == nil {
+			res.Errors = append(res.Errors, "DISCORD_BOT_TOKEN changed but Discord is not connected (restart to enable)")
+			os.Setenv("DISCORD_BOT_TOKEN", cfg.Discord.Token)
+		} else if err := d.RotateToken(tok); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("discord: %v — keeping the current session", err))
+			os.Setenv("DISCORD_BOT_TOKEN", cfg.Discord.Token)
+		} else {
+			mu.Lock()
+			cfg.Discord.Token = tok
+			mu.Unlock()
+			res.Rotated = append(res.Rotated, "discord gateway")
+		}
+	}
+
+	if tok := os.Getenv("TELEGRAM_BOT_TOKEN"); tok != "" && tok != cfg.Telegram.BotToken {
+		if t := notifier.telegramBackend(); t == nil {
+			res.Errors = append(res.Errors, "TELEGRAM_BOT_TOKEN changed but Telegram is not connected (restart to enable)")
+			os.Setenv("TELEGRAM_BOT_TOKEN", cfg.Telegram.BotToken)
+		} else if err := t.RotateToken(tok); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("telegram: %v — keeping the current token", err))
+			os.Setenv("TELEGRAM_BOT_TOKEN", cfg.Telegram.BotToken)
+		} else {
+			mu.Lock()
+			cfg.Telegram.BotToken = tok
+			mu.Unlock()
+			res.Rotated = append(res.Rotated, "telegram bot")
+		}
+	}
+
+	// Never rotate the status token to empty: that would silently open the
+	// mutating API to every loopback client.
+	if tok := os.Getenv("STATUS_AUTH_TOKEN"); tok != "" && tok != cfg.StatusToken {
+		server.SetStatusToken(tok)
+		mu.Lock()
+		cfg.StatusToken = tok
+		mu.Unlock()
+		res.Rotated = append(res.Rotated, "status API token")
+	}
+	return res
+}
+
+// formatCredentialRotation renders the result for the log and owner DM.
+// Names only — never values.
+func formatCredentialRotation(res credentialRotationResult) string {
+	var b strings.Builder
+	switch {
+	case len(res.ChangedEnv) == 0 && len(res.Errors) == 0:
+		b.WriteString("Credential rotation: no changes")
+	case len(res.ChangedEnv) == 0:
+		b.WriteString("Credential rotation failed")
+	default:
+		fmt.Fprintf(&b, "Credential rotation: %d env var(s) updated (%s)", len(res.ChangedEnv), strings.Join(res.ChangedEnv, ", "))
+	}
+	if len(res.Rotated) > 0 {
+		fmt.Fprintf(&b, "; re-established %s", strings.Join(res.Rotated, ", "))
+	}
+	for _, e := range res.Errors {
+		b.WriteString("\n  error: " + e)
+	}
+	return b.String()
+}
+
+// handleAPICredentialsRotate serves POST /api/credentials/rotate. It only
+// signals the main loop; the rotation itself runs between cycles and reports
+// via the log and owner DM.
+func (ss *StatusServer) handleAPICredentialsRotate(w http.ResponseWriter, r *http.Request) {
+	if ss.rejectIfDraining(w) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !ss.requireMutatingAPIAuth(w, r) || !requireSameOrigin(w, r) {
+		return
+	}
+	if ss.rotateCreds == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "credential rotation not wired")
+		return
+	}
+	if err := ss.rotateCreds(); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "signal credential rotation: "+err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, uiMutationResponse{OK: true, Message: "Credential rotation requested; it runs before the next cycle — see the log or owner DM for the result."})
+}
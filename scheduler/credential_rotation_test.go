@@ -0,0 +1,154 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go-trader.env")
+	body := "# comment\n\nexport OKX_API_KEY=abc\nHYPERLIQUID_SECRET_KEY=\"0x123\"\n; other comment\nSTATUS_AUTH_TOKEN='tok en'\n"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseEnvFile(path)
+	if err != nil {
+		t.Fatalf("parseEnvFile: %v", err)
+	}
+	want := map[string]string{
+		"OKX_API_KEY":            "abc",
+		"HYPERLIQUID_SECRET_KEY": "0x123",
+		"STATUS_AUTH_TOKEN":      "tok en",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseEnvFile = %v, want %v", got, want)
+	}
+}
+
+func TestParseEnvFileRejectsMalformedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.env")
+	if err := os.WriteFile(path, []byte("OKX_API_KEY\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseEnvFile(path); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Fatalf("err = %v, want line-numbered error", err)
+	}
+}
+
+func TestRefreshCredentialEnvNoSource(t *testing.T) {
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "")
+	t.Setenv(credentialEnvFileVar, "")
+	if _, err := refreshCredentialEnv(); err == nil || !strings.Contains(err.Error(), credentialEnvFileVar) {
+		t.Fatalf("err = %v, want no-source error naming %s", err, credentialEnvFileVar)
+	}
+}
+
+func TestRefreshCredentialEnvFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go-trader.env")
+	if err := os.WriteFile(path, []byte("OKX_API_KEY=new-key\nOKX_API_SECRET=same\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "")
+	t.Setenv(credentialEnvFileVar, path)
+	t.Setenv("OKX_API_KEY", "old-key")
+	t.Setenv("OKX_API_SECRET", "same")
+
+	changed, err := refreshCredentialEnv()
+	if err != nil {
+		t.Fatalf("refreshCredentialEnv: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"OKX_API_KEY"}) {
+		t.Errorf("changed = %v, want [OKX_API_KEY]", changed)
+	}
+	if got := os.Getenv("OKX_API_KEY"); got != "new-key" {
+		t.Errorf("OKX_API_KEY = %q, want new-key", got)
+	}
+}
+
+func TestRefreshCredentialEnvProviderKeepsBoxOverride(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"data":{"DISCORD_BOT_TOKEN":"rotated","OKX_API_KEY":"rotated-okx"},"metadata":{"version":2}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "vault")
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "root-token")
+	t.Setenv("GO_TRADER_VAULT_SECRET_PATH", "secret/data/go-trader")
+	t.Setenv(credentialEnvFileVar, "")
+	t.Setenv("DISCORD_BOT_TOKEN", "explicit-on-box")
+	t.Setenv("OKX_API_KEY", "from-provider-at-startup")
+	prevOwned := secretsProviderOwned
+	secretsProviderOwned = map[string]bool{"OKX_API_KEY": true}
+	defer func() { secretsProviderOwned = prevOwned }()
+
+	changed, err := refreshCredentialEnv()
+	if err != nil {
+		t.Fatalf("refreshCredentialEnv: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"OKX_API_KEY"}) {
+		t.Errorf("changed = %v, want [OKX_API_KEY]", changed)
+	}
+	if got := os.Getenv("DISCORD_BOT_TOKEN"); got != "explicit-on-box" {
+		t.Errorf("DISCORD_BOT_TOKEN = %q, box override must win", got)
+	}
+}
+
+func TestRotateCredentialsStatusTokenNeverEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go-trader.env")
+	if err := os.WriteFile(path, []byte("STATUS_AUTH_TOKEN=\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "")
+	t.Setenv(credentialEnvFileVar, path)
+	t.Setenv("STATUS_AUTH_TOKEN", "current")
+	t.Setenv("DISCORD_BOT_TOKEN", "")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+
+	cfg := &Config{StatusToken: "current"}
+	ss := &StatusServer{statusToken: "current"}
+	res := rotateCredentials(cfg, &sync.RWMutex{}, NewMultiNotifier(), ss)
+	if len(res.Errors) != 0 {
+		t.Fatalf("errors = %v", res.Errors)
+	}
+	if ss.currentStatusToken() != "current" || cfg.StatusToken != "current" {
+		t.Errorf("status token rotated to empty: server=%q cfg=%q", ss.currentStatusToken(), cfg.StatusToken)
+	}
+}
+
+func TestHandleAPICredentialsRotate(t *testing.T) {
+	called := 0
+	ss := &StatusServer{rotateCreds: func() error { called++; return nil }}
+
+	rec := httptest.NewRecorder()
+	ss.handleAPICredentialsRotate(rec, httptest.NewRequest(http.MethodGet, "/api/credentials/rotate", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET status = %d, want 405", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	ss.handleAPICredentialsRotate(rec, httptest.NewRequest(http.MethodPost, "/api/credentials/rotate", nil))
+	if rec.Code != http.StatusAccepted || called != 1 {
+		t.Fatalf("POST status = %d called = %d, want 202 and one signal", rec.Code, called)
+	}
+}
+
+func TestFormatCredentialRotation(t *testing.T) {
+	got := formatCredentialRotation(credentialRotationResult{
+		ChangedEnv: []string{"DISCORD_BOT_TOKEN", "OKX_API_KEY"},
+		Rotated:    []string{"discord gateway"},
+	})
+	want := "Credential rotation: 2 env var(s) updated (DISCORD_BOT_TOKEN, OKX_API_KEY); re-established discord gateway"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := formatCredentialRotation(credentialRotationResult{}); got != "Credential rotation: no changes" {
+		t.Errorf("empty result = %q", got)
+	}
+}
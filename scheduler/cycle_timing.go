@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// cycleTimingWindow caps the rolling per-cycle timing history kept in
+// AppState.CycleTimings (and persisted to app_state.cycle_timings). Large
+// enough to cover several hours at the default tick, small enough that the
+// JSON blob rewritten by every SaveState stays a few tens of KB.
+const cycleTimingWindow = 60
+
+// StrategyCycleTiming is one strategy's elapsed time inside a cycle.
+// SubprocessMs covers the check-script + execute dispatch (Phase 3/4),
+// MarkingMs the option mark fetch (Phase 5), TotalMs the whole per-strategy
+// iteration including risk checks and status logging.
+type StrategyCycleTiming struct {
+	SubprocessMs int64 `json:"subprocess_ms"`
+	MarkingMs    int64 `json:"marking_ms"`
+	TotalMs      int64 `json:"total_ms"`
+}
+
+// CycleTiming is one completed scheduler cycle. PriceFetchMs is the
+// cycle-level spot/perps/futures mark fetch; SubprocessMs / MarkingMs are
+// summed across strategies; SaveMs is the end-of-cycle SaveState. Slow is set
+// when TotalMs exceeded the tick interval in force for that cycle — since
+// #4921 the shortest effective interval among the cycle's due strategies.
+type CycleTiming struct {
+	Cycle        int                            `json:"cycle"`
+	StartedAt    time.Time                      `json:"started_at"`
+	TotalMs      int64                          `json:"total_ms"`
+	PriceFetchMs int64                          `json:"price_fetch_ms"`
+	SubprocessMs int64                          `json:"subprocess_ms"`
+	MarkingMs    int64                          `json:"marking_ms"`
+	SaveMs       int64                          `json:"save_ms"`
+	TickSeconds  int                            `json:"tick_seconds"`
+	DueCount     int                            `json:"due_count"`
+	Slow         bool                           `json:"slow,omitempty"`
+	Strategies   map[string]StrategyCycleTiming `json:"strategies,omitempty"`
+}
+
+// cycleTimingRecorder accumulates timings for the cycle in progress. It is
+// owned by the main loop goroutine (same single-writer invariant as lastRun),
+// so it needs no locking; only the finished CycleTiming is published into
+// AppState under mu.
+type cycleTimingRecorder struct {
+	cycle      int
+	start      time.Time
+	dueCount   int
+	priceFetch time.Duration
+	strategies map[string]StrategyCycleTiming
+}
+
+func newCycleTimingRecorder(cycle int, start time.Time, dueCount int) *cycleTimingRecorder {
+	return &cycleTimingRecorder{
+		cycle:      cycle,
+		start:      start,
+		dueCount:   dueCount,
+		strategies: make(map[string]StrategyCycleTiming),
+	}
+}
+
+func (r *cycleTimingRecorder) recordPriceFetch(d time.Duration) {
+	r.priceFetch += d
+}
+
+func (r *cycleTimingRecorder) recordSubprocess(id string, d time.Duration) {
+	t := r.strategies[id]
+	t.SubprocessMs += d.Milliseconds()
+	r.strategies[id] = t
+}
+
+func (r *cycleTimingRecorder) recordMarking(id string, d time.Duration) {
+	t := r.strategies[id]
+	t.MarkingMs += d.Milliseconds()
+	r.strategies[id] = t
+}
+
+func (r *cycleTimingRecorder) recordStrategyTotal(id string, d time.Duration) {
+	t := r.strategies[id]
+	t.TotalMs = d.Milliseconds()
+	r.strategies[id] = t
+}
+
+// finish builds the CycleTiming for the cycle. total is the wall-clock cycle
+// duration measured by the caller (after the save), save the SaveState
+// duration, tickSeconds the interval the slow-cycle check compares to
+// (shortestStrategyInterval of the due strategies).
+func (r *cycleTimingRecorder) finish(total, save time.Duration, tickSeconds int) CycleTiming {
+	ct := CycleTiming{
+		Cycle:        r.cycle,
+		StartedAt:    r.start.UTC(),
+		TotalMs:      total.Milliseconds(),
+		PriceFetchMs: r.priceFetch.Milliseconds(),
+		SaveMs:       save.Milliseconds(),
+		TickSeconds:  tickSeconds,
+		DueCount:     r.dueCount,
+	}
+	if len(r.strategies) > 0 {
+		ct.Strategies = make(map[string]StrategyCycleTiming, len(r.strategies))
+		for id, t := range r.strategies {
+			ct.Strategies[id] = t
+			ct.SubprocessMs += t.SubprocessMs
+			ct.MarkingMs += t.MarkingMs
+		}
+	}
+	ct.Slow = cycleExceedsTick(total, tickSeconds)
+	return ct
+}
+
+// cycleExceedsTick reports whether a cycle ran longer than the tick interval.
+// A non-positive tick disables the check.
+func cycleExceedsTick(total time.Duration, tickSeconds int) bool {
+	return tickSeconds > 0 && total > time.Duration(tickSeconds)*time.Second
+}
+
+// appendCycleTiming appends ct to the rolling window, dropping the oldest
+// entries beyond cycleTimingWindow. Caller holds mu.Lock.
+func appendCycleTiming(state *AppState, ct CycleTiming) {
+	state.CycleTimings = append(state.CycleTimings, ct)
+	if over := len(state.CycleTimings) - cycleTimingWindow; over > 0 {
+		state.CycleTimings = append([]CycleTiming(nil), state.CycleTimings[over:]...)
+	}
+}
+
+// formatSlowCycleWarning is the log line emitted when a cycle overran the
+// tick interval. Names the slowest strategy so the operator knows where to look.
+func formatSlowCycleWarning(ct CycleTiming) string {
+	msg := fmt.Sprintf("Cycle %d took %s, exceeding the %ds tick interval (prices=%s subprocess=%s marking=%s save=%s, %d due)",
+		ct.Cycle, formatTimingMs(ct.TotalMs), ct.TickSeconds,
+		formatTimingMs(ct.PriceFetchMs), formatTimingMs(ct.SubprocessMs), formatTimingMs(ct.MarkingMs), formatTimingMs(ct.SaveMs), ct.DueCount)
+	if id, t, ok := slowestStrategyTiming(ct); ok {
+		msg += fmt.Sprintf("; slowest strategy %s=%s", id, formatTimingMs(t.TotalMs))
+	}
+	return msg
+}
+
+// slowestStrategyTiming returns the strategy with the largest TotalMs, ties
+// broken by ID so the output is deterministic.
+func slowestStrategyTiming(ct CycleTiming) (string, StrategyCycleTiming, bool) {
+	var bestID string
+	var best StrategyCycleTiming
+	found := false
+	for id, t := range ct.Strategies {
+		if !found || t.TotalMs > best.TotalMs || (t.TotalMs == best.TotalMs && id < bestID) {
+			bestID, best, found = id, t, true
+		}
+	}
+	return bestID, best, found
+}
+
+func formatTimingMs(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+// CycleTimingSummary aggregates the rolling window for /status and /metrics.
+type CycleTimingSummary struct {
+	Samples    int   `json:"samples"`
+	SlowCycles int   `json:"slow_cycles"`
+	AvgTotalMs int64 `json:"avg_total_ms"`
+	MaxTotalMs int64 `json:"max_total_ms"`
+	MaxCycle   int   `json:"max_cycle,omitempty"`
+}
+
+func summarizeCycleTimings(timings []CycleTiming) CycleTimingSummary {
+	s := CycleTimingSummary{Samples: len(timings)}
+	if len(timings) == 0 {
+		return s
+	}
+	var sum int64
+	for i, ct := range timings {
+		sum += ct.TotalMs
+		if ct.Slow {
+			s.SlowCycles++
+		}
+		if i == 0 || ct.TotalMs > s.MaxTotalMs {
+			s.MaxTotalMs = ct.TotalMs
+			s.MaxCycle = ct.Cycle
+		}
+	}
+	s.AvgTotalMs = sum / int64(len(timings))
+	return s
+}
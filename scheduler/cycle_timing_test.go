@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCycleTimingRecorderFinish(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := newCycleTimingRecorder(7, start, 2)
+	rec.recordPriceFetch(300 * time.Millisecond)
+	rec.recordSubprocess("a", 2*time.Second)
+	rec.recordSubprocess("a", 500*time.Millisecond)
+	rec.recordMarking("a", 100*time.Millisecond)
+	rec.recordStrategyTotal("a", 3*time.Second)
+	rec.recordSubprocess("b", time.Second)
+	rec.recordStrategyTotal("b", 1200*time.Millisecond)
+
+	ct := rec.finish(5*time.Second, 250*time.Millisecond, 60)
+	if ct.Cycle != 7 || !ct.StartedAt.Equal(start) || ct.DueCount != 2 {
+		t.Fatalf("identity = %+v", ct)
+	}
+	if ct.TotalMs != 5000 || ct.SaveMs != 250 || ct.PriceFetchMs != 300 {
+		t.Errorf("total/save/price = %d/%d/%d", ct.TotalMs, ct.SaveMs, ct.PriceFetchMs)
+	}
+	if ct.SubprocessMs != 3500 || ct.MarkingMs != 100 {
+		t.Errorf("subprocess/marking sums = %d/%d, want 3500/100", ct.SubprocessMs, ct.MarkingMs)
+	}
+	if got := ct.Strategies["a"]; got.SubprocessMs != 2500 || got.TotalMs != 3000 {
+		t.Errorf("strategy a = %+v", got)
+	}
+	if ct.Slow {
+		t.Error("5s cycle on a 60s tick must not be slow")
+	}
+}
+
+func TestCycleExceedsTick(t *testing.T) {
+	cases := []struct {
+		total time.Duration
+		tick  int
+		want  bool
+	}{
+		{59 * time.Second, 60, false},
+		{60 * time.Second, 60, false},
+		{61 * time.Second, 60, true},
+		{time.Hour, 0, false},
+	}
+	for _, c := range cases {
+		if got := cycleExceedsTick(c.total, c.tick); got != c.want {
+			t.Errorf("cycleExceedsTick(%s, %d) = %v, want %v", c.total, c.tick, got, c.want)
+		}
+	}
+}
+
+func TestAppendCycleTimingCapsWindow(t *testing.T) {
+	state := NewAppState()
+	for i := 1; i <= cycleTimingWindow+5; i++ {
+		appendCycleTiming(state, CycleTiming{Cycle: i})
+	}
+	if len(state.CycleTimings) != cycleTimingWindow {
+		t.Fatalf("len = %d, want %d", len(state.CycleTimings), cycleTimingWindow)
+	}
+	if state.CycleTimings[0].Cycle != 6 || state.CycleTimings[cycleTimingWindow-1].Cycle != cycleTimingWindow+5 {
+		t.Errorf("window = [%d..%d], want [6..%d]", state.CycleTimings[0].Cycle, state.CycleTimings[cycleTimingWindow-1].Cycle, cycleTimingWindow+5)
+	}
+}
+
+func TestFormatSlowCycleWarningNamesSlowestStrategy(t *testing.T) {
+	ct := CycleTiming{
+		Cycle:       3,
+		TotalMs:     90000,
+		TickSeconds: 60,
+		DueCount:    2,
+		Strategies: map[string]StrategyCycleTiming{
+			"fast": {TotalMs: 1000},
+			"slow": {TotalMs: 85000},
+		},
+	}
+	msg := formatSlowCycleWarning(ct)
+	if !strings.Contains(msg, "Cycle 3 took 1m30s") || !strings.Contains(msg, "60s tick interval") {
+		t.Errorf("msg = %q", msg)
+	}
+	if !strings.Contains(msg, "slowest strategy slow=1m25s") {
+		t.Errorf("msg missing slowest strategy: %q", msg)
+	}
+}
+
+func TestSummarizeCycleTimings(t *testing.T) {
+	sum := summarizeCycleTimings([]CycleTiming{
+		{Cycle: 1, TotalMs: 1000},
+		{Cycle: 2, TotalMs: 5000, Slow: true},
+		{Cycle: 3, TotalMs: 3000},
+	})
+	if sum.Samples != 3 || sum.SlowCycles != 1 || sum.AvgTotalMs != 3000 || sum.MaxTotalMs != 5000 || sum.MaxCycle != 2 {
+		t.Errorf("summary = %+v", sum)
+	}
+	if empty := summarizeCycleTimings(nil); empty.Samples != 0 || empty.MaxTotalMs != 0 {
+		t.Errorf("empty summary = %+v", empty)
+	}
+}
+
+func TestCycleTimingsPersistAcrossSaveLoad(t *testing.T) {
+	db := openTestDB(t)
+	state := NewAppState()
+	state.CycleCount = 2
+	appendCycleTiming(state, CycleTiming{Cycle: 1, TotalMs: 1500, Strategies: map[string]StrategyCycleTiming{"s1": {SubprocessMs: 1200, TotalMs: 1400}}})
+	appendCycleTiming(state, CycleTiming{Cycle: 2, TotalMs: 70000, TickSeconds: 60, Slow: true})
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if len(loaded.CycleTimings) != 2 {
+		t.Fatalf("CycleTimings len = %d, want 2", len(loaded.CycleTimings))
+	}
+	if got := loaded.CycleTimings[0].Strategies["s1"]; got.SubprocessMs != 1200 {
+		t.Errorf("strategy timing lost: %+v", got)
+	}
+	if !loaded.CycleTimings[1].Slow {
+		t.Error("slow flag lost on round trip")
+	}
+}
+
+func TestHandleMetricsCycleTimings(t *testing.T) {
+	state := NewAppState()
+	state.CycleCount = 4
+	appendCycleTiming(state, CycleTiming{
+		Cycle:        4,
+		TotalMs:      2500,
+		SubprocessMs: 2000,
+		TickSeconds:  60,
+		Strategies:   map[string]StrategyCycleTiming{"hl-b": {SubprocessMs: 1500, TotalMs: 1600}, "hl-a": {SubprocessMs: 500, TotalMs: 600}},
+	})
+	var mu sync.RWMutex
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+
+	w := httptest.NewRecorder()
+	ss.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"go_trader_cycle_count 4\n",
+		`go_trader_cycle_duration_seconds{phase="total"} 2.500`,
+		`go_trader_cycle_duration_seconds{phase="subprocess"} 2.000`,
+		"go_trader_tick_interval_seconds 60\n",
+		`go_trader_strategy_duration_seconds{strategy="hl-b",phase="subprocess"} 1.500`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+	if strings.Index(body, `strategy="hl-a"`) > strings.Index(body, `strategy="hl-b"`) {
+		t.Error("strategy series must be sorted by id")
+	}
+}
+
+func TestHandleMetricsRequiresToken(t *testing.T) {
+	var mu sync.RWMutex
+	ss := NewStatusServer(NewAppState(), &mu, "secret", nil, nil)
+	w := httptest.NewRecorder()
+	ss.handleMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+}
+
+func TestHandleStatusIncludesCycleTimings(t *testing.T) {
+	state := NewAppState()
+	appendCycleTiming(state, CycleTiming{Cycle: 1, TotalMs: 1000})
+	appendCycleTiming(state, CycleTiming{Cycle: 2, TotalMs: 3000, Slow: true})
+	var mu sync.RWMutex
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+
+	w := httptest.NewRecorder()
+	ss.handleStatus(w, httptest.NewRequest("GET", "/status", nil))
+	var resp struct {
+		CycleTimings       []CycleTiming       `json:"cycle_timings"`
+		CycleTimingSummary *CycleTimingSummary `json:"cycle_timing_summary"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.CycleTimings) != 2 {
+		t.Fatalf("cycle_timings len = %d, want 2", len(resp.CycleTimings))
+	}
+	if resp.CycleTimingSummary == nil || resp.CycleTimingSummary.SlowCycles != 1 || resp.CycleTimingSummary.MaxTotalMs != 3000 {
+		t.Errorf("summary = %+v", resp.CycleTimingSummary)
+	}
+}
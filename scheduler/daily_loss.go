@@ -5,7 +5,8 @@ package main
 // When the day's aggregate realized PnL across ALL strategies falls past a
 // configured loss threshold (portfolio_risk.daily_max_loss_usd and/or
 // daily_max_loss_pct), every position-INCREASING action is held for the rest
-// of the UTC day: the six regime-gated dispatch sites reuse the #1150
+// of the risk day (00:00 UTC unless portfolio_risk.daily_reset_hour /
+// daily_reset_timezone move it, #4986): the six regime-gated dispatch sites reuse the #1150
 // pausedBlocksSignal predicate (fresh opens, scale-in adds, and flips are
 // forced to hold; close-registry / pure-close exits pass through), options
 // open actions are dropped via pausedOptionsActions, and the manual CLI
@@ -16,7 +17,7 @@ package main
 //
 // The gate is UNLATCHED by design: it is recomputed each evaluation from the
 // per-strategy RiskState.DailyPnL values (persisted in SQLite), so it
-// survives restarts for free and clears automatically at the UTC rollover
+// survives restarts for free and clears automatically at the daily rollover
 // already implemented by rolloverDailyPnL — no separate latch state exists.
 // DailyPnL is fed by RecordTradeResult with the same PRE-FEE realized PnL the
 // trades ledger stores (#918: fees are stamped separately and read via
@@ -54,11 +55,11 @@ func dailyLossLimitConfigured(pr *PortfolioRiskConfig) bool {
 // evaluateDailyLossLimit aggregates today's realized PnL across every
 // strategy state and compares the loss against the configured thresholds.
 // Pure read — never mutates state (see the stale-day note in the file
-// comment); safe under mu.RLock. now must be UTC-meaningful (callers pass
-// time.Now().UTC()); the day key matches rolloverDailyPnL's format.
+// comment); safe under mu.RLock. The day key is riskDay(now), the same one
+// rolloverDailyPnL stamps.
 func evaluateDailyLossLimit(pr *PortfolioRiskConfig, states map[string]*StrategyState, now time.Time) DailyLossLimitStatus {
 	st := DailyLossLimitStatus{Configured: dailyLossLimitConfigured(pr)}
-	today := now.UTC().Format("2006-01-02")
+	today := riskDay(now)
 	for _, ss := range states {
 		if ss == nil {
 			continue
@@ -111,13 +112,13 @@ func formatDailyLossTripDM(st DailyLossLimitStatus, now time.Time) string {
 		"🛑 **Daily loss limit tripped** (%s UTC)\n"+
 			"Today's aggregate realized PnL: $%.2f (pre-fee, across all strategies)\n"+
 			"Threshold: $%.2f (capital basis $%.2f)\n"+
-			"All fresh opens, scale-in adds, and flips are held for the rest of the UTC day — including manual-open/manual-add. "+
+			"All fresh opens, scale-in adds, and flips are held for the rest of the day — including manual-open/manual-add. "+
 			"Open positions keep being managed (closes, trailing SL, ratchet, protection sync) and nothing is force-closed. "+
-			"Entries resume automatically at the next UTC rollover.",
-		now.UTC().Format("2006-01-02 15:04"), st.DailyPnL, st.ThresholdUSD, st.CapitalBasis)
+			"Entries resume automatically at the next daily reset (%s).",
+		now.UTC().Format("2006-01-02 15:04"), st.DailyPnL, st.ThresholdUSD, st.CapitalBasis, dailyResetLabel())
 }
 
-// dailyLossAlertDue reports whether the trip DM should fire: once per UTC day
+// dailyLossAlertDue reports whether the trip DM should fire: once per risk day
 // while tripped. lastAlertDate is the day key of the last DM sent ("" =
 // never); a process restart re-arms it, which re-DMs at most once — acceptable
 // (and arguably useful) for an auto-protective halt.
@@ -125,7 +126,7 @@ func dailyLossAlertDue(tripped bool, lastAlertDate, today string) bool {
 	return tripped && lastAlertDate != today
 }
 
-// dailyLossLastAlertDate throttles the trip DM to once per UTC day. Written
+// dailyLossLastAlertDate throttles the trip DM to once per risk day. Written
 // only from the main trading loop (single goroutine).
 var dailyLossLastAlertDate string
 
@@ -145,7 +146,7 @@ func dailyLossStartupSummaryLine(pr *PortfolioRiskConfig) string {
 		}
 		parts += fmt.Sprintf("pct=%.2f%% of initial capital", pr.DailyMaxLossPct)
 	}
-	return fmt.Sprintf("[config] portfolio: daily_max_loss %s (pre-fee realized; blocks new entries for the rest of the UTC day when tripped)", parts)
+	return fmt.Sprintf("[config] portfolio: daily_max_loss %s (pre-fee realized; blocks new entries until the %s daily reset when tripped)", parts, dailyResetLabel())
 }
 
 // dailyLossStatusNote renders the /status line for the daily loss limit.
@@ -159,7 +160,7 @@ func dailyLossStatusNote(pr *PortfolioRiskConfig, states map[string]*StrategySta
 	var note string
 	switch {
 	case st.Tripped:
-		note = fmt.Sprintf("\n🛑 daily loss limit TRIPPED: loss $%.2f >= $%.2f — entries held until UTC rollover", st.LossUSD, st.ThresholdUSD)
+		note = fmt.Sprintf("\n🛑 daily loss limit TRIPPED: loss $%.2f >= $%.2f — entries held until the %s daily reset", st.LossUSD, st.ThresholdUSD, dailyResetLabel())
 	case st.ThresholdUSD > 0:
 		note = fmt.Sprintf("\n🟢 daily loss limit armed: today $%.2f / threshold $%.2f", st.DailyPnL, st.ThresholdUSD)
 	}
@@ -178,7 +179,7 @@ func dailyLossStatusNote(pr *PortfolioRiskConfig, states map[string]*StrategySta
 const dailyLossPctBasisMissWarning = "⚠️ daily loss limit: daily_max_loss_pct is configured but no strategy has initial_capital > 0 — the pct arm CANNOT evaluate and enforces nothing (set initial_capital or use daily_max_loss_usd)"
 
 // dailyLossPctBasisMissAlertDate throttles the inert-pct-arm owner DM to once
-// per UTC day, mirroring dailyLossLastAlertDate. Written only from the main
+// per risk day, mirroring dailyLossLastAlertDate. Written only from the main
 // trading loop (single goroutine).
 var dailyLossPctBasisMissAlertDate string
 
@@ -191,6 +192,6 @@ func formatDailyLossPctBasisMissDM(st DailyLossLimitStatus, now time.Time) strin
 		usdNote = fmt.Sprintf("The USD arm still enforces at $%.2f.", st.ThresholdUSD)
 	}
 	return fmt.Sprintf(
-		"%s\n%s\nToday's aggregate realized PnL: $%.2f. This DM repeats once per UTC day while the gap persists. (%s UTC)",
+		"%s\n%s\nToday's aggregate realized PnL: $%.2f. This DM repeats once per day while the gap persists. (%s UTC)",
 		dailyLossPctBasisMissWarning, usdNote, st.DailyPnL, now.UTC().Format("2006-01-02 15:04"))
 }
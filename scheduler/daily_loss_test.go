@@ -317,7 +317,7 @@ func TestManualOpenCoreRefusesDailyLossHold(t *testing.T) {
 			return manualStateView{HasStrategy: true, DailyLossHold: true,
 				DailyLossNote: "daily loss limit tripped: today's realized loss $600.00 >= threshold $500.00 (pre-fee; basis=$0.00 initial capital)"}, nil
 		},
-		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 			t.Error("execute must not be called while the daily loss limit is tripped")
 			return nil, "", nil
 		},
@@ -340,7 +340,7 @@ func TestManualAddCoreRefusesDailyLossHold(t *testing.T) {
 			return manualStateView{HasStrategy: true, Pos: pos, DailyLossHold: true,
 				DailyLossNote: "daily loss limit tripped: today's realized loss $600.00 >= threshold $500.00 (pre-fee; basis=$0.00 initial capital)"}, nil
 		},
-		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 			t.Error("execute must not be called while the daily loss limit is tripped")
 			return nil, "", nil
 		},
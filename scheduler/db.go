@@ -34,7 +34,11 @@ CREATE TABLE IF NOT EXISTS app_state (
     last_cycle TEXT NOT NULL DEFAULT '',
     last_leaderboard_post_date TEXT NOT NULL DEFAULT '',
     last_leaderboard_summaries TEXT NOT NULL DEFAULT '',
-    last_summary_post TEXT NOT NULL DEFAULT ''
+    last_summary_post TEXT NOT NULL DEFAULT '',
+    cycle_timings TEXT NOT NULL DEFAULT '',
+    muted_strategies TEXT NOT NULL DEFAULT '',
+    last_weekly_digest_date TEXT NOT NULL DEFAULT '',
+    state_checksum TEXT NOT NULL DEFAULT ''
 );
 
 CREATE TABLE IF NOT EXISTS strategies (
@@ -48,6 +52,9 @@ CREATE TABLE IF NOT EXISTS strategies (
     risk_current_drawdown_pct REAL NOT NULL DEFAULT 0,
     risk_daily_pnl REAL NOT NULL DEFAULT 0,
     risk_daily_pnl_date TEXT NOT NULL DEFAULT '',
+    risk_daily_trades INTEGER NOT NULL DEFAULT 0,
+    total_fees REAL NOT NULL DEFAULT 0,
+    total_slippage REAL NOT NULL DEFAULT 0,
     risk_consecutive_losses INTEGER NOT NULL DEFAULT 0,
     risk_circuit_breaker INTEGER NOT NULL DEFAULT 0,
     risk_circuit_breaker_until TEXT NOT NULL DEFAULT '',
@@ -59,7 +66,13 @@ CREATE TABLE IF NOT EXISTS strategies (
     -- #998: regime-profile allocation active profile (flat-switch persistence).
     active_profile TEXT NOT NULL DEFAULT '',
     -- #1394: live spot over-budget books still need operator reconciliation.
-    cash_reconcile_required INTEGER NOT NULL DEFAULT 0
+    cash_reconcile_required INTEGER NOT NULL DEFAULT 0,
+    -- Daily peaks for drawdown_window_days (JSON array, '' when unset).
+    risk_peak_history_json TEXT NOT NULL DEFAULT '',
+    -- Resting paper orders (JSON object keyed by symbol, '' when none).
+    paper_orders_json TEXT NOT NULL DEFAULT '',
+    -- #4927: daily value/benchmark series for alpha/beta ('' when none).
+    benchmark_json TEXT NOT NULL DEFAULT ''
 );
 
 CREATE TABLE IF NOT EXISTS positions (
@@ -99,6 +112,9 @@ CREATE TABLE IF NOT EXISTS positions (
     llm_analysis_requested INTEGER NOT NULL DEFAULT 0,
     llm_verdict TEXT NOT NULL DEFAULT '',
     atr_method_at_open TEXT NOT NULL DEFAULT '',
+    borrow_fees_usd REAL NOT NULL DEFAULT 0,
+    borrow_accrued_at TEXT NOT NULL DEFAULT '',
+    metadata_json TEXT NOT NULL DEFAULT '',
     PRIMARY KEY (strategy_id, symbol)
 );
 
@@ -164,6 +180,9 @@ CREATE TABLE IF NOT EXISTS option_positions (
     theta REAL NOT NULL DEFAULT 0,
     vega REAL NOT NULL DEFAULT 0,
     opened_at TEXT NOT NULL DEFAULT '',
+    harvest_peak_pct REAL NOT NULL DEFAULT 0,
+    harvest_override_json TEXT NOT NULL DEFAULT '',
+    metadata_json TEXT NOT NULL DEFAULT '',
     PRIMARY KEY (strategy_id, id)
 );
 
@@ -188,7 +207,13 @@ CREATE TABLE IF NOT EXISTS trades (
     stop_loss_oid INTEGER NOT NULL DEFAULT 0,
     tp_oids_json TEXT NOT NULL DEFAULT '',
     pnl_gross INTEGER NOT NULL DEFAULT 0,
-    fee_source TEXT NOT NULL DEFAULT ''
+    fee_source TEXT NOT NULL DEFAULT '',
+    -- #4928: attribution tags (JSON object, '' when untagged).
+    tags_json TEXT NOT NULL DEFAULT '',
+    -- #4952: indicator snapshot behind the trade (JSON object, '' when none).
+    indicators_json TEXT NOT NULL DEFAULT '',
+    -- #4971: free-form operator/executor notes (JSON object, '' when none).
+    metadata_json TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_trades_strategy ON trades(strategy_id);
@@ -210,7 +235,27 @@ CREATE TABLE IF NOT EXISTS portfolio_risk (
     last_warning_equity_dd_pct REAL NOT NULL DEFAULT 0,
     last_warning_margin_dd_pct REAL NOT NULL DEFAULT 0,
     warning_equity_delta_pct REAL NOT NULL DEFAULT 0,
-    warning_margin_delta_pct REAL NOT NULL DEFAULT 0
+    warning_margin_delta_pct REAL NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 0,
+    peak_at TEXT NOT NULL DEFAULT '',
+    peak_source TEXT NOT NULL DEFAULT '',
+    kill_switch_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_risk_history (
+    date TEXT PRIMARY KEY,
+    high_value REAL NOT NULL DEFAULT 0,
+    last_value REAL NOT NULL DEFAULT 0,
+    max_drawdown_pct REAL NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS platform_risk (
+    platform TEXT PRIMARY KEY,
+    peak_value REAL NOT NULL DEFAULT 0,
+    current_drawdown_pct REAL NOT NULL DEFAULT 0,
+    breached INTEGER NOT NULL DEFAULT 0,
+    breached_at TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT ''
 );
 
 CREATE TABLE IF NOT EXISTS kill_switch_events (
@@ -247,6 +292,9 @@ CREATE TABLE IF NOT EXISTS pending_manual_actions (
     is_full_close INTEGER NOT NULL DEFAULT 0,
     tp_oids_json TEXT NOT NULL DEFAULT '',
     ratchet_fallback_normalize_pending INTEGER NOT NULL DEFAULT 0,
+    override_field TEXT NOT NULL DEFAULT '',
+    override_value TEXT NOT NULL DEFAULT '',
+    metadata_json TEXT NOT NULL DEFAULT '',
     created_at TEXT NOT NULL
 );
 
@@ -282,6 +330,18 @@ CREATE TABLE IF NOT EXISTS wallet_transfers (
     dedup_id TEXT NOT NULL UNIQUE
 );
 
+-- #4925: capital moved between strategies by the capital_allocator. Each row
+-- is one pairwise transfer; the donor's cash and initial_capital baseline drop
+-- by amount_usd and the receiver's rise by it, in the same transaction.
+CREATE TABLE IF NOT EXISTS capital_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transferred_at TEXT NOT NULL,
+    from_strategy TEXT NOT NULL,
+    to_strategy TEXT NOT NULL,
+    amount_usd REAL NOT NULL,
+    reason TEXT NOT NULL DEFAULT ''
+);
+
 -- #1100: exchange-sourced equity journal for shared-wallet TOTAL reconciliation.
 -- Where wallet_ledger_state / wallet_transfers feed the per-strategy ATTRIBUTION
 -- split (#954), this journal reconstructs the wallet's settled-cash balance from
@@ -346,6 +406,41 @@ CREATE TABLE IF NOT EXISTS pending_limit_orders (
     created_at TEXT NOT NULL
 );
 
+-- #4956: live HL orders that were partially filled or left resting at
+-- execute time; polled each cycle so late fills reach the position.
+CREATE TABLE IF NOT EXISTS hl_tracked_orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    strategy_id TEXT NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    order_oid INTEGER NOT NULL,
+    order_size REAL NOT NULL,
+    filled_size REAL NOT NULL DEFAULT 0,
+    avg_fill_price REAL NOT NULL DEFAULT 0,
+    fill_fee REAL NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL
+);
+
+-- #4916: live order intents, journaled before the executor call and deleted
+-- once the resulting fill is applied to state. A row that survives a restart
+-- marks a live order whose outcome never reached state (crash between Phase 3
+-- and Phase 4); reconcileOrderIntents reports it at startup.
+CREATE TABLE IF NOT EXISTS order_intents (
+    intent_key TEXT PRIMARY KEY,
+    strategy_id TEXT NOT NULL,
+    platform TEXT NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    size REAL NOT NULL,
+    status TEXT NOT NULL,
+    fill_qty REAL NOT NULL DEFAULT 0,
+    fill_price REAL NOT NULL DEFAULT 0,
+    exchange_order_id TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
 -- #1147 per-trade trade-quality diagnostics: one row per closed position,
 -- inserted eagerly at close; nullable quality metrics filled asynchronously.
 CREATE TABLE IF NOT EXISTS trade_diagnostics (
@@ -431,24 +526,84 @@ CREATE TABLE IF NOT EXISTS regime_reversal_alerts (
     alerted_at TEXT NOT NULL,
     PRIMARY KEY (platform, symbol, timeframe, spec_json)
 );
+
+-- #4954 every evaluated check-script signal, traded or not. Pruned by
+-- signal_history_days; served at GET /signals.
+CREATE TABLE IF NOT EXISTS signal_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    ts TEXT NOT NULL,
+    strategy_id TEXT NOT NULL,
+    symbol TEXT NOT NULL DEFAULT '',
+    signal INTEGER NOT NULL DEFAULT 0,
+    effective INTEGER NOT NULL DEFAULT 0,
+    outcome TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    price REAL NOT NULL DEFAULT 0,
+    trades INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_signal_history_strategy ON signal_history(strategy_id, id);
+CREATE INDEX IF NOT EXISTS idx_signal_history_ts ON signal_history(ts);
+
+-- #4975 paper twins of shadowed live strategies (shadow.go), one JSON
+-- StrategyState per strategy.
+CREATE TABLE IF NOT EXISTS shadow_strategies (
+    strategy_id TEXT PRIMARY KEY,
+    state_json TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- #4936 Google Sheets export cursor (single row).
+CREATE TABLE IF NOT EXISTS sheets_export_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    spreadsheet_id TEXT NOT NULL,
+    last_trade_rowid INTEGER NOT NULL DEFAULT 0,
+    last_equity_date TEXT NOT NULL DEFAULT ''
+);
 `
 
 // StateDB wraps a SQLite database for persistent state storage.
 type StateDB struct {
 	db *sql.DB
+
+	// At-rest encryption (state_encryption.go). When encKey is set, db is an
+	// in-memory copy and persistEncrypted seals it back to encPath.
+	encPath string
+	encKey  []byte
+	encLock *stateDBLock
+	encMu   sync.Mutex
 }
 
-// OpenStateDB opens (or creates) the SQLite database at the given path.
+// OpenStateDB opens (or creates) the SQLite database at the given path. With
+// GO_TRADER_STATE_KEY set the file is kept AES-GCM encrypted at rest.
 func OpenStateDB(path string) (*StateDB, error) {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("create db dir: %w", err)
 	}
-	db, err := sql.Open("sqlite", path)
+	key, err := stateEncryptionKey()
 	if err != nil {
-		return nil, fmt.Errorf("open db: %w", err)
+		return nil, err
+	}
+	var sdb *StateDB
+	if key != nil {
+		if sdb, err = openEncryptedStateDB(path, key); err != nil {
+			return nil, err
+		}
+	} else {
+		if encrypted, err := isEncryptedStateFile(path); err != nil {
+			return nil, fmt.Errorf("open db: %w", err)
+		} else if encrypted {
+			return nil, fmt.Errorf("%s is encrypted; set %s to open it", path, stateKeyEnvVar)
+		}
+		db, err := sql.Open("sqlite", path)
+		if err != nil {
+			return nil, fmt.Errorf("open db: %w", err)
+		}
+		db.SetMaxOpenConns(1)
+		sdb = &StateDB{db: db}
 	}
-	db.SetMaxOpenConns(1)
+	db := sdb.db
 
 	for _, pragma := range []string{
 		"PRAGMA journal_mode=WAL",
@@ -456,24 +611,29 @@ func OpenStateDB(path string) (*StateDB, error) {
 		"PRAGMA foreign_keys=ON",
 	} {
 		if _, err := db.Exec(pragma); err != nil {
-			db.Close()
+			sdb.closeDB()
 			return nil, fmt.Errorf("pragma %q: %w", pragma, err)
 		}
 	}
 
 	if _, err := db.Exec(schemaDDL); err != nil {
-		db.Close()
+		sdb.closeDB()
 		return nil, fmt.Errorf("create schema: %w", err)
 	}
 
-	sdb := &StateDB{db: db}
 	if err := sdb.migrateSchema(); err != nil {
-		db.Close()
+		sdb.closeDB()
 		return nil, fmt.Errorf("migrate schema: %w", err)
 	}
 	return sdb, nil
 }
 
+// closeDB releases the handle without persisting (open-failure paths).
+func (sdb *StateDB) closeDB() {
+	sdb.db.Close()
+	sdb.encLock.Release()
+}
+
 // migrateSchema adds columns that may be missing from older databases.
 func (sdb *StateDB) migrateSchema() error {
 	// Add exchange_order_id and exchange_fee to trades table (added in #219).
@@ -491,10 +651,21 @@ func (sdb *StateDB) migrateSchema() error {
 		"ALTER TABLE portfolio_risk ADD COLUMN last_warning_margin_dd_pct REAL NOT NULL DEFAULT 0",
 		"ALTER TABLE portfolio_risk ADD COLUMN warning_equity_delta_pct REAL NOT NULL DEFAULT 0",
 		"ALTER TABLE portfolio_risk ADD COLUMN warning_margin_delta_pct REAL NOT NULL DEFAULT 0",
+		// Versioned portfolio high-water-mark metadata.
+		"ALTER TABLE portfolio_risk ADD COLUMN version INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE portfolio_risk ADD COLUMN peak_at TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE portfolio_risk ADD COLUMN peak_source TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE portfolio_risk ADD COLUMN kill_switch_reason TEXT NOT NULL DEFAULT ''",
 		// Per-leaderboard-summary last-post timestamps stored as JSON (#308).
 		"ALTER TABLE app_state ADD COLUMN last_leaderboard_summaries TEXT NOT NULL DEFAULT ''",
 		// Per-channel regular summary last-post timestamps stored as JSON (#474).
 		"ALTER TABLE app_state ADD COLUMN last_summary_post TEXT NOT NULL DEFAULT ''",
+		// Rolling per-cycle timing history stored as JSON.
+		"ALTER TABLE app_state ADD COLUMN cycle_timings TEXT NOT NULL DEFAULT ''",
+		// Runtime trade-alert mutes stored as JSON (#4951).
+		"ALTER TABLE app_state ADD COLUMN muted_strategies TEXT NOT NULL DEFAULT ''",
+		// Weekly digest last-post date (#4955).
+		"ALTER TABLE app_state ADD COLUMN last_weekly_digest_date TEXT NOT NULL DEFAULT ''",
 		// Per-trade HL stop-loss trigger OID (#412).
 		"ALTER TABLE positions ADD COLUMN stop_loss_oid INTEGER NOT NULL DEFAULT 0",
 		// Per-trade HL stop-loss trigger price for later-fill reconciliation (#421).
@@ -619,6 +790,36 @@ func (sdb *StateDB) migrateSchema() error {
 		// second restart after ValidateState clamped cash to 0) cannot silently
 		// clear the "books still need reconciliation" guard.
 		"ALTER TABLE strategies ADD COLUMN cash_reconcile_required INTEGER NOT NULL DEFAULT 0",
+		// Rolling drawdown window daily peaks.
+		"ALTER TABLE strategies ADD COLUMN risk_peak_history_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE strategies ADD COLUMN paper_orders_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE strategies ADD COLUMN benchmark_json TEXT NOT NULL DEFAULT ''",
+		// max_daily_trades: trades recorded on risk_daily_pnl_date.
+		"ALTER TABLE strategies ADD COLUMN risk_daily_trades INTEGER NOT NULL DEFAULT 0",
+		// Gross vs net PnL: cumulative fees and modeled slippage.
+		"ALTER TABLE strategies ADD COLUMN total_fees REAL NOT NULL DEFAULT 0",
+		"ALTER TABLE strategies ADD COLUMN total_slippage REAL NOT NULL DEFAULT 0",
+		// Theta harvest trailing mode: best premium capture per sold option.
+		"ALTER TABLE option_positions ADD COLUMN harvest_peak_pct REAL NOT NULL DEFAULT 0",
+		// Per-position theta harvest overrides, and the harvest-override
+		// queue row's field/value.
+		"ALTER TABLE option_positions ADD COLUMN harvest_override_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE pending_manual_actions ADD COLUMN override_field TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE pending_manual_actions ADD COLUMN override_value TEXT NOT NULL DEFAULT ''",
+		// #4971: free-form metadata on positions, option legs and trades.
+		"ALTER TABLE positions ADD COLUMN metadata_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE option_positions ADD COLUMN metadata_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE trades ADD COLUMN metadata_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE pending_manual_actions ADD COLUMN metadata_json TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE trades ADD COLUMN tags_json TEXT NOT NULL DEFAULT ''",
+		// Indicator snapshot that triggered the trade (#4952).
+		"ALTER TABLE trades ADD COLUMN indicators_json TEXT NOT NULL DEFAULT ''",
+		// #4918: content hash of the core state rows, restamped by every writer.
+		"ALTER TABLE app_state ADD COLUMN state_checksum TEXT NOT NULL DEFAULT ''",
+		// allow_short paper spot shorts: cumulative borrow fees and the
+		// accrual watermark, so a restart neither re-charges nor skips time.
+		"ALTER TABLE positions ADD COLUMN borrow_fees_usd REAL NOT NULL DEFAULT 0",
+		"ALTER TABLE positions ADD COLUMN borrow_accrued_at TEXT NOT NULL DEFAULT ''",
 		// #1395: composite index so LoadState's per-strategy trade query can
 		// satisfy filter (strategy_id) + order (timestamp DESC, rowid DESC) from
 		// one index instead of the single-column strategy/timestamp indexes.
@@ -691,6 +892,52 @@ func marshalStringMapJSON(m map[string]string) string {
 	return string(b)
 }
 
+func marshalPeakHistoryJSON(h []DailyPeak) string {
+	if len(h) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(h)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func parsePeakHistoryJSON(raw string) []DailyPeak {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	var out []DailyPeak
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func marshalPaperOrdersJSON(m map[string]*PaperOrder) string {
+	if len(m) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func parsePaperOrdersJSON(raw string) map[string]*PaperOrder {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	var out map[string]*PaperOrder
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil
+	}
+	return out
+}
+
 func parseStringMapJSON(raw string) map[string]string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -944,9 +1191,33 @@ func (sdb *StateDB) strategiesColumnPresence() (hasLegacy, hasNew bool, err erro
 	return hasLegacy, hasNew, rows.Err()
 }
 
-// Close closes the database connection.
+// Close closes the database connection, first sealing an encrypted DB back
+// to disk.
 func (sdb *StateDB) Close() error {
-	return sdb.db.Close()
+	persistErr := sdb.persistEncrypted()
+	err := sdb.db.Close()
+	sdb.encLock.Release()
+	if persistErr != nil {
+		return persistErr
+	}
+	return err
+}
+
+// BackupTo writes a consistent snapshot of the DB to path (the updater's
+// pre-upgrade rollback point). An encrypted DB is sealed first and its file
+// copied, so the snapshot stays encrypted at rest too.
+func (sdb *StateDB) BackupTo(path string) error {
+	os.Remove(path) // VACUUM INTO refuses an existing file
+	if sdb.encKey != nil {
+		if err := sdb.persistEncrypted(); err != nil {
+			return err
+		}
+		return copyFileSync(sdb.encPath, path)
+	}
+	if _, err := sdb.db.Exec("VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("vacuum into %s: %w", path, err)
+	}
+	return nil
 }
 
 // InsertTrade persists a single trade row immediately (#289). This is invoked
@@ -965,17 +1236,17 @@ func (sdb *StateDB) InsertTrade(strategyID string, trade Trade) error {
 		isManual = 1
 	}
 	_, err := sdb.db.Exec(`INSERT INTO trades
-			(strategy_id, timestamp, symbol, position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, regime, entry_atr, stop_loss_oid, stop_loss_trigger_px, tp_oids_json, manual, stop_loss_atr_mult, tp_tiers_json, pnl_gross, fee_source)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			(strategy_id, timestamp, symbol, position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, regime, entry_atr, stop_loss_oid, stop_loss_trigger_px, tp_oids_json, manual, stop_loss_atr_mult, tp_tiers_json, pnl_gross, fee_source, tags_json, indicators_json, metadata_json)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		strategyID, formatTime(trade.Timestamp), trade.Symbol, trade.PositionID, trade.Side,
 		trade.Quantity, trade.Price, trade.Value, trade.TradeType, trade.Details,
 		trade.ExchangeOrderID, trade.ExchangeFee, isClose, trade.RealizedPnL, trade.Regime,
 		trade.EntryATR, trade.StopLossOID, trade.StopLossTriggerPx, marshalTPOIDsJSON(trade.TPOIDs), isManual,
-		nullableFloat64(trade.StopLossATRMult), trade.TPTiersJSON, boolToInt(trade.PnLGross), trade.FeeSource)
+		nullableFloat64(trade.StopLossATRMult), trade.TPTiersJSON, boolToInt(trade.PnLGross), trade.FeeSource, marshalStringMapJSON(trade.Tags), marshalIndicatorsJSON(trade.Indicators), marshalStringMapJSON(trade.Metadata))
 	if err != nil {
 		return fmt.Errorf("insert trade for %s: %w", strategyID, err)
 	}
-	return nil
+	return sdb.persistEncrypted()
 }
 
 // RecentTrades returns the newest trade rows since a cutoff, newest first.
@@ -1136,6 +1407,9 @@ func (sdb *StateDB) SetInitialCapital(strategyID string, value float64) error {
 	if n == 0 {
 		return fmt.Errorf("no strategy row for id=%q", strategyID)
 	}
+	if err := stampStateChecksum(tx); err != nil {
+		return err
+	}
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit: %w", err)
 	}
@@ -1179,13 +1453,32 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 		}
 		summaryPostJSON = string(raw)
 	}
-	if _, err := tx.Exec(`INSERT OR REPLACE INTO app_state (id, cycle_count, last_cycle, last_leaderboard_post_date, last_leaderboard_summaries, last_summary_post)
-		VALUES (1, ?, ?, ?, ?, ?)`,
+	cycleTimingsJSON := ""
+	if len(state.CycleTimings) > 0 {
+		raw, err := json.Marshal(state.CycleTimings)
+		if err != nil {
+			return fmt.Errorf("marshal cycle_timings: %w", err)
+		}
+		cycleTimingsJSON = string(raw)
+	}
+	mutedJSON := ""
+	if len(state.MutedStrategies) > 0 {
+		raw, err := json.Marshal(state.MutedStrategies)
+		if err != nil {
+			return fmt.Errorf("marshal muted_strategies: %w", err)
+		}
+		mutedJSON = string(raw)
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO app_state (id, cycle_count, last_cycle, last_leaderboard_post_date, last_leaderboard_summaries, last_summary_post, cycle_timings, muted_strategies, last_weekly_digest_date)
+		VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		state.CycleCount,
 		formatTime(state.LastCycle),
 		state.LastLeaderboardPostDate,
 		lbSummariesJSON,
 		summaryPostJSON,
+		cycleTimingsJSON,
+		mutedJSON,
+		state.LastWeeklyDigestDate,
 	); err != nil {
 		return fmt.Errorf("upsert app_state: %w", err)
 	}
@@ -1227,15 +1520,16 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 		risk_peak_value, risk_max_drawdown_pct, risk_current_drawdown_pct,
 		risk_daily_pnl, risk_daily_pnl_date, risk_consecutive_losses,
 		risk_circuit_breaker, risk_circuit_breaker_until, risk_pending_circuit_closes_json, active_profile,
-		cash_reconcile_required)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		cash_reconcile_required, risk_peak_history_json, paper_orders_json, benchmark_json, risk_daily_trades,
+		total_fees, total_slippage)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("prepare strategy insert: %w", err)
 	}
 	defer stmtStrat.Close()
 
-	stmtPos, err := tx.Prepare(`INSERT INTO positions (strategy_id, symbol, position_id, quantity, initial_quantity, avg_cost, entry_atr, side, multiplier, owner_strategy_id, opened_at, stop_loss_oid, stop_loss_trigger_px, stop_loss_high_water_px, tp1_oid, tp2_oid, tp_oids_json, tp_armed_tiers_json, stop_loss_atr_mult, tp_tiers_json, sl_adjusted_tiers_processed, post_tp_trailing_atr_mult, regime, regime_windows_json, regime_pending_label, regime_pending_count, regime_applied_label, scale_in_count, last_add_price, added_notional_usd, risk_anchor_price, scale_in_resize_pending, ratchet_fallback_normalize_pending, open_profile, direction_certified_at_open, direction_certified_states_json, llm_analysis_requested, llm_verdict, atr_method_at_open)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	stmtPos, err := tx.Prepare(`INSERT INTO positions (strategy_id, symbol, position_id, quantity, initial_quantity, avg_cost, entry_atr, side, multiplier, owner_strategy_id, opened_at, stop_loss_oid, stop_loss_trigger_px, stop_loss_high_water_px, tp1_oid, tp2_oid, tp_oids_json, tp_armed_tiers_json, stop_loss_atr_mult, tp_tiers_json, sl_adjusted_tiers_processed, post_tp_trailing_atr_mult, regime, regime_windows_json, regime_pending_label, regime_pending_count, regime_applied_label, scale_in_count, last_add_price, added_notional_usd, risk_anchor_price, scale_in_resize_pending, ratchet_fallback_normalize_pending, open_profile, direction_certified_at_open, direction_certified_states_json, llm_analysis_requested, llm_verdict, atr_method_at_open, borrow_fees_usd, borrow_accrued_at, metadata_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("prepare position insert: %w", err)
 	}
@@ -1243,8 +1537,8 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 
 	stmtOpt, err := tx.Prepare(`INSERT INTO option_positions (strategy_id, id, position_id, underlying, option_type, strike, expiry, dte,
 		action, quantity, entry_premium, entry_premium_usd, current_value_usd,
-		delta, gamma, theta, vega, opened_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		delta, gamma, theta, vega, opened_at, harvest_peak_pct, harvest_override_json, metadata_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("prepare option_position insert: %w", err)
 	}
@@ -1285,6 +1579,11 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 			s.RiskState.MarshalPendingCircuitClosesJSON(),
 			strategyActiveProfile(s),
 			cashReconcileInt,
+			marshalPeakHistoryJSON(s.RiskState.PeakHistory),
+			marshalPaperOrdersJSON(s.PaperOrders),
+			marshalBenchmarkJSON(s.Benchmark),
+			s.RiskState.DailyTrades,
+			s.TotalFees, s.TotalSlippage,
 		); err != nil {
 			return fmt.Errorf("insert strategy %s: %w", s.ID, err)
 		}
@@ -1308,7 +1607,7 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 			if pos.LLMAnalysisRequested {
 				llmAnalysisRequested = 1
 			}
-			if _, err := stmtPos.Exec(s.ID, pos.Symbol, positionID, pos.Quantity, pos.InitialQuantity, pos.AvgCost, pos.EntryATR, pos.Side, pos.Multiplier, pos.OwnerStrategyID, formatTime(pos.OpenedAt), pos.StopLossOID, pos.StopLossTriggerPx, pos.StopLossHighWaterPx, tp1OID, tp2OID, marshalTPOIDsJSON(pos.TPOIDs), marshalTPArmedTiersJSON(pos.TPArmedTiers), nullableFloat64(pos.StopLossATRMult), pos.TPTiersJSON, pos.SLAdjustedTiersProcessed, nullableFloat64(pos.PostTPTrailingATRMult), pos.Regime, marshalRegimeWindowsJSON(pos.RegimeWindows), pos.RegimePendingLabel, pos.RegimePendingCount, pos.RegimeAppliedLabel, pos.ScaleInCount, pos.LastAddPrice, pos.AddedNotionalUSD, pos.RiskAnchorPrice, scaleInResizePending, ratchetFallbackNormalizePending, pos.OpenProfile, directionCertifiedAtOpen, marshalStringMapJSON(pos.DirectionCertifiedStatesAtOpen), llmAnalysisRequested, pos.LLMVerdict, pos.ATRMethodAtOpen); err != nil {
+			if _, err := stmtPos.Exec(s.ID, pos.Symbol, positionID, pos.Quantity, pos.InitialQuantity, pos.AvgCost, pos.EntryATR, pos.Side, pos.Multiplier, pos.OwnerStrategyID, formatTime(pos.OpenedAt), pos.StopLossOID, pos.StopLossTriggerPx, pos.StopLossHighWaterPx, tp1OID, tp2OID, marshalTPOIDsJSON(pos.TPOIDs), marshalTPArmedTiersJSON(pos.TPArmedTiers), nullableFloat64(pos.StopLossATRMult), pos.TPTiersJSON, pos.SLAdjustedTiersProcessed, nullableFloat64(pos.PostTPTrailingATRMult), pos.Regime, marshalRegimeWindowsJSON(pos.RegimeWindows), pos.RegimePendingLabel, pos.RegimePendingCount, pos.RegimeAppliedLabel, pos.ScaleInCount, pos.LastAddPrice, pos.AddedNotionalUSD, pos.RiskAnchorPrice, scaleInResizePending, ratchetFallbackNormalizePending, pos.OpenProfile, directionCertifiedAtOpen, marshalStringMapJSON(pos.DirectionCertifiedStatesAtOpen), llmAnalysisRequested, pos.LLMVerdict, pos.ATRMethodAtOpen, pos.BorrowFeesUSD, formatTime(pos.BorrowAccruedAt), marshalStringMapJSON(pos.Metadata)); err != nil {
 				return fmt.Errorf("insert position %s/%s: %w", s.ID, pos.Symbol, err)
 			}
 		}
@@ -1319,7 +1618,7 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 				s.ID, key, positionID, opt.Underlying, opt.OptionType, opt.Strike, opt.Expiry, opt.DTE,
 				opt.Action, opt.Quantity, opt.EntryPremium, opt.EntryPremiumUSD, opt.CurrentValueUSD,
 				opt.Greeks.Delta, opt.Greeks.Gamma, opt.Greeks.Theta, opt.Greeks.Vega,
-				formatTime(opt.OpenedAt),
+				formatTime(opt.OpenedAt), opt.HarvestPeakPct, marshalHarvestOverrideJSON(opt.HarvestOverride), marshalStringMapJSON(opt.Metadata),
 			); err != nil {
 				return fmt.Errorf("insert option_position %s/%s: %w", s.ID, key, err)
 			}
@@ -1333,8 +1632,8 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 	//    failed, even if later-timestamped rows were persisted successfully
 	//    (fixes the MAX(timestamp) dedup gap that would silently drop
 	//    out-of-order retries).
-	stmtTrade, err := tx.Prepare(`INSERT INTO trades (strategy_id, timestamp, symbol, position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, regime, entry_atr, stop_loss_oid, stop_loss_trigger_px, tp_oids_json, manual, stop_loss_atr_mult, tp_tiers_json, pnl_gross, fee_source)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	stmtTrade, err := tx.Prepare(`INSERT INTO trades (strategy_id, timestamp, symbol, position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, regime, entry_atr, stop_loss_oid, stop_loss_trigger_px, tp_oids_json, manual, stop_loss_atr_mult, tp_tiers_json, pnl_gross, fee_source, tags_json, indicators_json, metadata_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("prepare trade insert: %w", err)
 	}
@@ -1362,7 +1661,7 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 			if t.Manual {
 				isManual = 1
 			}
-			if _, err := stmtTrade.Exec(s.ID, formatTime(t.Timestamp), t.Symbol, t.PositionID, t.Side, t.Quantity, t.Price, t.Value, t.TradeType, t.Details, t.ExchangeOrderID, t.ExchangeFee, isClose, t.RealizedPnL, t.Regime, t.EntryATR, t.StopLossOID, t.StopLossTriggerPx, marshalTPOIDsJSON(t.TPOIDs), isManual, nullableFloat64(t.StopLossATRMult), t.TPTiersJSON, boolToInt(t.PnLGross), t.FeeSource); err != nil {
+			if _, err := stmtTrade.Exec(s.ID, formatTime(t.Timestamp), t.Symbol, t.PositionID, t.Side, t.Quantity, t.Price, t.Value, t.TradeType, t.Details, t.ExchangeOrderID, t.ExchangeFee, isClose, t.RealizedPnL, t.Regime, t.EntryATR, t.StopLossOID, t.StopLossTriggerPx, marshalTPOIDsJSON(t.TPOIDs), isManual, nullableFloat64(t.StopLossATRMult), t.TPTiersJSON, boolToInt(t.PnLGross), t.FeeSource, marshalStringMapJSON(t.Tags), marshalIndicatorsJSON(t.Indicators), marshalStringMapJSON(t.Metadata)); err != nil {
 				return fmt.Errorf("insert trade for %s: %w", s.ID, err)
 			}
 			flushed = append(flushed, trackedFlush{strat: s, index: i})
@@ -1442,15 +1741,59 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 	if state.PortfolioRisk.WarningSent {
 		warnSent = 1
 	}
-	if _, err := tx.Exec(`INSERT OR REPLACE INTO portfolio_risk (id, peak_value, current_drawdown_pct, current_margin_drawdown_pct, kill_switch_active, kill_switch_at, warning_sent, warn_band_entered_at, last_warning_equity_dd_pct, last_warning_margin_dd_pct, warning_equity_delta_pct, warning_margin_delta_pct)
-		VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO portfolio_risk (id, peak_value, current_drawdown_pct, current_margin_drawdown_pct, kill_switch_active, kill_switch_at, warning_sent, warn_band_entered_at, last_warning_equity_dd_pct, last_warning_margin_dd_pct, warning_equity_delta_pct, warning_margin_delta_pct, version, peak_at, peak_source, kill_switch_reason)
+		VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		state.PortfolioRisk.PeakValue, state.PortfolioRisk.CurrentDrawdownPct, state.PortfolioRisk.CurrentMarginDrawdownPct,
 		ksActive, formatTime(state.PortfolioRisk.KillSwitchAt), warnSent, formatTime(state.PortfolioRisk.WarnBandEnteredAt),
 		state.PortfolioRisk.LastWarningEquityDDPct, state.PortfolioRisk.LastWarningMarginDDPct,
 		state.PortfolioRisk.WarningEquityDeltaPct, state.PortfolioRisk.WarningMarginDeltaPct,
+		portfolioRiskStateVersion, formatTime(state.PortfolioRisk.PeakAt), state.PortfolioRisk.PeakSource,
+		state.PortfolioRisk.KillSwitchReason,
 	); err != nil {
 		return fmt.Errorf("upsert portfolio_risk: %w", err)
 	}
+	state.PortfolioRisk.Version = portfolioRiskStateVersion
+
+	// 6b. Portfolio value history: replace all (capped at maxPortfolioRiskHistory).
+	if _, err := tx.Exec("DELETE FROM portfolio_risk_history"); err != nil {
+		return fmt.Errorf("delete portfolio_risk_history: %w", err)
+	}
+	if len(state.PortfolioRisk.History) > 0 {
+		stmtHist, err := tx.Prepare(`INSERT OR REPLACE INTO portfolio_risk_history (date, high_value, last_value, max_drawdown_pct) VALUES (?, ?, ?, ?)`)
+		if err != nil {
+			return fmt.Errorf("prepare portfolio_risk_history insert: %w", err)
+		}
+		defer stmtHist.Close()
+		for _, h := range state.PortfolioRisk.History {
+			if _, err := stmtHist.Exec(h.Date, h.HighValue, h.LastValue, h.MaxDrawdownPct); err != nil {
+				return fmt.Errorf("insert portfolio_risk_history %s: %w", h.Date, err)
+			}
+		}
+	}
+
+	// 6c. Per-platform risk state: replace all.
+	if _, err := tx.Exec("DELETE FROM platform_risk"); err != nil {
+		return fmt.Errorf("delete platform_risk: %w", err)
+	}
+	if len(state.PlatformRisk) > 0 {
+		stmtPlat, err := tx.Prepare(`INSERT INTO platform_risk (platform, peak_value, current_drawdown_pct, breached, breached_at, reason) VALUES (?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return fmt.Errorf("prepare platform_risk insert: %w", err)
+		}
+		defer stmtPlat.Close()
+		for name, prs := range state.PlatformRisk {
+			if prs == nil {
+				continue
+			}
+			breached := 0
+			if prs.Breached {
+				breached = 1
+			}
+			if _, err := stmtPlat.Exec(name, prs.PeakValue, prs.CurrentDrawdownPct, breached, formatTime(prs.BreachedAt), prs.Reason); err != nil {
+				return fmt.Errorf("insert platform_risk %s: %w", name, err)
+			}
+		}
+	}
 
 	// 7. Kill switch events: replace all (capped at maxKillSwitchEvents).
 	if _, err := tx.Exec("DELETE FROM kill_switch_events"); err != nil {
@@ -1482,6 +1825,9 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 	if _, err := tx.Exec(`INSERT OR REPLACE INTO correlation_snapshot (id, snapshot_json) VALUES (1, ?)`, snapJSON); err != nil {
 		return fmt.Errorf("upsert correlation_snapshot: %w", err)
 	}
+	if err := stampStateChecksum(tx); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return err
@@ -1501,7 +1847,9 @@ func (sdb *StateDB) SaveState(state *AppState) error {
 	for _, f := range flushed {
 		f.strat.TradeHistory[f.index].persisted = true
 	}
-	return nil
+	// The commit above landed in memory for an encrypted DB; a failed seal
+	// still leaves the rows there for the next save to carry to disk.
+	return sdb.persistEncrypted()
 }
 
 // QueryClosedPositions returns closed-position history ordered by closed_at desc,
@@ -1653,9 +2001,9 @@ func (sdb *StateDB) QueryClosedOptionPositions(strategyID, underlying string, si
 func (sdb *StateDB) LoadState() (*AppState, error) {
 	// 1. Load app_state singleton.
 	var cycleCount int
-	var lastCycleStr, lastLeaderboardDate, lastLBSummariesJSON, lastSummaryPostJSON string
-	err := sdb.db.QueryRow("SELECT cycle_count, last_cycle, last_leaderboard_post_date, last_leaderboard_summaries, last_summary_post FROM app_state WHERE id = 1").
-		Scan(&cycleCount, &lastCycleStr, &lastLeaderboardDate, &lastLBSummariesJSON, &lastSummaryPostJSON)
+	var lastCycleStr, lastLeaderboardDate, lastLBSummariesJSON, lastSummaryPostJSON, cycleTimingsJSON, mutedJSON, lastWeeklyDigestDate string
+	err := sdb.db.QueryRow("SELECT cycle_count, last_cycle, last_leaderboard_post_date, last_leaderboard_summaries, last_summary_post, cycle_timings, muted_strategies, last_weekly_digest_date FROM app_state WHERE id = 1").
+		Scan(&cycleCount, &lastCycleStr, &lastLeaderboardDate, &lastLBSummariesJSON, &lastSummaryPostJSON, &cycleTimingsJSON, &mutedJSON, &lastWeeklyDigestDate)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -1675,6 +2023,22 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 			return nil, fmt.Errorf("parse last_summary_post: %w", err)
 		}
 	}
+	// Timing history is diagnostics-only: a corrupt blob is dropped with a
+	// warning rather than failing the state load.
+	var cycleTimings []CycleTiming
+	if cycleTimingsJSON != "" {
+		if err := json.Unmarshal([]byte(cycleTimingsJSON), &cycleTimings); err != nil {
+			fmt.Printf("[WARN] state: discarding unparseable cycle_timings: %v\n", err)
+			cycleTimings = nil
+		}
+	}
+
+	var muted map[string]time.Time
+	if mutedJSON != "" {
+		if err := json.Unmarshal([]byte(mutedJSON), &muted); err != nil {
+			return nil, fmt.Errorf("parse muted_strategies: %w", err)
+		}
+	}
 
 	state := &AppState{
 		CycleCount:               cycleCount,
@@ -1682,6 +2046,9 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		LastLeaderboardPostDate:  lastLeaderboardDate,
 		LastLeaderboardSummaries: lbSummaries,
 		LastSummaryPost:          summaryPosts,
+		LastWeeklyDigestDate:     lastWeeklyDigestDate,
+		MutedStrategies:          muted,
+		CycleTimings:             cycleTimings,
 		Strategies:               make(map[string]*StrategyState),
 	}
 
@@ -1691,7 +2058,13 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		risk_daily_pnl, risk_daily_pnl_date, risk_consecutive_losses,
 		risk_circuit_breaker, risk_circuit_breaker_until, risk_pending_circuit_closes_json,
 		COALESCE(active_profile, '') AS active_profile,
-		COALESCE(cash_reconcile_required, 0) AS cash_reconcile_required
+		COALESCE(cash_reconcile_required, 0) AS cash_reconcile_required,
+		COALESCE(risk_peak_history_json, '') AS risk_peak_history_json,
+		COALESCE(paper_orders_json, '') AS paper_orders_json,
+		COALESCE(benchmark_json, '') AS benchmark_json,
+		COALESCE(risk_daily_trades, 0) AS risk_daily_trades,
+		COALESCE(total_fees, 0) AS total_fees,
+		COALESCE(total_slippage, 0) AS total_slippage
 		FROM strategies`)
 	if err != nil {
 		return nil, fmt.Errorf("load strategies: %w", err)
@@ -1702,13 +2075,15 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		var s StrategyState
 		var cbInt int
 		var cashReconcileInt int
-		var cbUntilStr, pendingCircuitClosesJSON, activeProfile string
+		var cbUntilStr, pendingCircuitClosesJSON, activeProfile, peakHistoryJSON, paperOrdersJSON, benchmarkJSON string
 		if err := rows.Scan(
 			&s.ID, &s.Type, &s.Platform, &s.Cash, &s.InitialCapital,
 			&s.RiskState.PeakValue, &s.RiskState.MaxDrawdownPct, &s.RiskState.CurrentDrawdownPct,
 			&s.RiskState.DailyPnL, &s.RiskState.DailyPnLDate, &s.RiskState.ConsecutiveLosses,
 			&cbInt, &cbUntilStr, &pendingCircuitClosesJSON, &activeProfile,
-			&cashReconcileInt,
+			&cashReconcileInt, &peakHistoryJSON, &paperOrdersJSON, &benchmarkJSON,
+			&s.RiskState.DailyTrades,
+			&s.TotalFees, &s.TotalSlippage,
 		); err != nil {
 			return nil, fmt.Errorf("scan strategy: %w", err)
 		}
@@ -1716,6 +2091,9 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		s.RiskState.CircuitBreakerUntil = parseTime(cbUntilStr)
 		s.RiskState.UnmarshalPendingCircuitClosesJSON(pendingCircuitClosesJSON)
 		s.CashReconcileRequired = cashReconcileInt != 0
+		s.RiskState.PeakHistory = parsePeakHistoryJSON(peakHistoryJSON)
+		s.PaperOrders = parsePaperOrdersJSON(paperOrdersJSON)
+		s.Benchmark = parseBenchmarkJSON(benchmarkJSON)
 		// #998: restore the flat-switch active profile; the pending counter
 		// re-arms from zero on restart (a restart can only delay a switch).
 		if activeProfile != "" {
@@ -1731,7 +2109,7 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 	}
 
 	// 3. Load positions for each strategy.
-	posRows, err := sdb.db.Query("SELECT strategy_id, symbol, COALESCE(position_id, '') AS position_id, quantity, initial_quantity, avg_cost, entry_atr, side, multiplier, owner_strategy_id, opened_at, stop_loss_oid, stop_loss_trigger_px, stop_loss_high_water_px, COALESCE(tp1_oid, 0) AS tp1_oid, COALESCE(tp2_oid, 0) AS tp2_oid, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(tp_armed_tiers_json, '') AS tp_armed_tiers_json, stop_loss_atr_mult, COALESCE(tp_tiers_json, '') AS tp_tiers_json, COALESCE(sl_adjusted_tiers_processed, 0) AS sl_adjusted_tiers_processed, post_tp_trailing_atr_mult, COALESCE(regime, '') AS regime, COALESCE(regime_windows_json, '') AS regime_windows_json, COALESCE(regime_pending_label, '') AS regime_pending_label, COALESCE(regime_pending_count, 0) AS regime_pending_count, COALESCE(regime_applied_label, '') AS regime_applied_label, COALESCE(scale_in_count, 0) AS scale_in_count, COALESCE(last_add_price, 0) AS last_add_price, COALESCE(added_notional_usd, 0) AS added_notional_usd, COALESCE(risk_anchor_price, 0) AS risk_anchor_price, COALESCE(scale_in_resize_pending, 0) AS scale_in_resize_pending, COALESCE(ratchet_fallback_normalize_pending, 0) AS ratchet_fallback_normalize_pending, COALESCE(open_profile, '') AS open_profile, COALESCE(direction_certified_at_open, 0) AS direction_certified_at_open, COALESCE(direction_certified_states_json, '') AS direction_certified_states_json, COALESCE(llm_analysis_requested, 0) AS llm_analysis_requested, COALESCE(llm_verdict, '') AS llm_verdict, COALESCE(atr_method_at_open, '') AS atr_method_at_open FROM positions")
+	posRows, err := sdb.db.Query("SELECT strategy_id, symbol, COALESCE(position_id, '') AS position_id, quantity, initial_quantity, avg_cost, entry_atr, side, multiplier, owner_strategy_id, opened_at, stop_loss_oid, stop_loss_trigger_px, stop_loss_high_water_px, COALESCE(tp1_oid, 0) AS tp1_oid, COALESCE(tp2_oid, 0) AS tp2_oid, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(tp_armed_tiers_json, '') AS tp_armed_tiers_json, stop_loss_atr_mult, COALESCE(tp_tiers_json, '') AS tp_tiers_json, COALESCE(sl_adjusted_tiers_processed, 0) AS sl_adjusted_tiers_processed, post_tp_trailing_atr_mult, COALESCE(regime, '') AS regime, COALESCE(regime_windows_json, '') AS regime_windows_json, COALESCE(regime_pending_label, '') AS regime_pending_label, COALESCE(regime_pending_count, 0) AS regime_pending_count, COALESCE(regime_applied_label, '') AS regime_applied_label, COALESCE(scale_in_count, 0) AS scale_in_count, COALESCE(last_add_price, 0) AS last_add_price, COALESCE(added_notional_usd, 0) AS added_notional_usd, COALESCE(risk_anchor_price, 0) AS risk_anchor_price, COALESCE(scale_in_resize_pending, 0) AS scale_in_resize_pending, COALESCE(ratchet_fallback_normalize_pending, 0) AS ratchet_fallback_normalize_pending, COALESCE(open_profile, '') AS open_profile, COALESCE(direction_certified_at_open, 0) AS direction_certified_at_open, COALESCE(direction_certified_states_json, '') AS direction_certified_states_json, COALESCE(llm_analysis_requested, 0) AS llm_analysis_requested, COALESCE(llm_verdict, '') AS llm_verdict, COALESCE(atr_method_at_open, '') AS atr_method_at_open, COALESCE(borrow_fees_usd, 0) AS borrow_fees_usd, COALESCE(borrow_accrued_at, '') AS borrow_accrued_at, COALESCE(metadata_json, '') AS metadata_json FROM positions")
 	if err != nil {
 		return nil, fmt.Errorf("load positions: %w", err)
 	}
@@ -1751,7 +2129,8 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		var directionCertifiedAtOpen int
 		var directionCertifiedStatesJSON string
 		var llmAnalysisRequested int
-		if err := posRows.Scan(&stratID, &pos.Symbol, &pos.TradePositionID, &pos.Quantity, &pos.InitialQuantity, &pos.AvgCost, &pos.EntryATR, &pos.Side, &pos.Multiplier, &pos.OwnerStrategyID, &openedAtStr, &pos.StopLossOID, &pos.StopLossTriggerPx, &pos.StopLossHighWaterPx, &tp1OID, &tp2OID, &tpOIDsJSON, &tpArmedTiersJSON, &slATRMult, &pos.TPTiersJSON, &pos.SLAdjustedTiersProcessed, &postTPTrailingMult, &pos.Regime, &regimeWindowsJSON, &pos.RegimePendingLabel, &pos.RegimePendingCount, &pos.RegimeAppliedLabel, &pos.ScaleInCount, &pos.LastAddPrice, &pos.AddedNotionalUSD, &pos.RiskAnchorPrice, &scaleInResizePending, &ratchetFallbackNormalizePending, &pos.OpenProfile, &directionCertifiedAtOpen, &directionCertifiedStatesJSON, &llmAnalysisRequested, &pos.LLMVerdict, &pos.ATRMethodAtOpen); err != nil {
+		var borrowAccruedAtStr, metadataJSON string
+		if err := posRows.Scan(&stratID, &pos.Symbol, &pos.TradePositionID, &pos.Quantity, &pos.InitialQuantity, &pos.AvgCost, &pos.EntryATR, &pos.Side, &pos.Multiplier, &pos.OwnerStrategyID, &openedAtStr, &pos.StopLossOID, &pos.StopLossTriggerPx, &pos.StopLossHighWaterPx, &tp1OID, &tp2OID, &tpOIDsJSON, &tpArmedTiersJSON, &slATRMult, &pos.TPTiersJSON, &pos.SLAdjustedTiersProcessed, &postTPTrailingMult, &pos.Regime, &regimeWindowsJSON, &pos.RegimePendingLabel, &pos.RegimePendingCount, &pos.RegimeAppliedLabel, &pos.ScaleInCount, &pos.LastAddPrice, &pos.AddedNotionalUSD, &pos.RiskAnchorPrice, &scaleInResizePending, &ratchetFallbackNormalizePending, &pos.OpenProfile, &directionCertifiedAtOpen, &directionCertifiedStatesJSON, &llmAnalysisRequested, &pos.LLMVerdict, &pos.ATRMethodAtOpen, &pos.BorrowFeesUSD, &borrowAccruedAtStr, &metadataJSON); err != nil {
 			return nil, fmt.Errorf("scan position: %w", err)
 		}
 		pos.ScaleInResizePending = scaleInResizePending != 0
@@ -1760,6 +2139,8 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 		pos.DirectionCertifiedAtOpen = directionCertifiedAtOpen != 0
 		pos.DirectionCertifiedStatesAtOpen = parseStringMapJSON(directionCertifiedStatesJSON)
 		pos.OpenedAt = parseTime(openedAtStr)
+		pos.BorrowAccruedAt = parseTime(borrowAccruedAtStr)
+		pos.Metadata = parseStringMapJSON(metadataJSON)
 		pos.TPOIDs = parseTPOIDsJSON(tpOIDsJSON, tp1OID, tp2OID)
 		pos.TPArmedTiers = parseTPArmedTiersJSON(tpArmedTiersJSON)
 		pos.RegimeWindows = parseRegimeWindowsJSON(regimeWindowsJSON)
@@ -1783,7 +2164,8 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 	// 4. Load option positions for each strategy.
 	optRows, err := sdb.db.Query(`SELECT strategy_id, id, COALESCE(position_id, '') AS position_id, underlying, option_type, strike, expiry, dte,
 		action, quantity, entry_premium, entry_premium_usd, current_value_usd,
-		delta, gamma, theta, vega, opened_at FROM option_positions`)
+		delta, gamma, theta, vega, opened_at, COALESCE(harvest_peak_pct, 0) AS harvest_peak_pct,
+		COALESCE(harvest_override_json, '') AS harvest_override_json, COALESCE(metadata_json, '') AS metadata_json FROM option_positions`)
 	if err != nil {
 		return nil, fmt.Errorf("load option_positions: %w", err)
 	}
@@ -1791,16 +2173,18 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 	for optRows.Next() {
 		var stratID string
 		var opt OptionPosition
-		var openedAtStr string
+		var openedAtStr, overrideJSON, metadataJSON string
 		if err := optRows.Scan(
 			&stratID, &opt.ID, &opt.TradePositionID, &opt.Underlying, &opt.OptionType, &opt.Strike, &opt.Expiry, &opt.DTE,
 			&opt.Action, &opt.Quantity, &opt.EntryPremium, &opt.EntryPremiumUSD, &opt.CurrentValueUSD,
 			&opt.Greeks.Delta, &opt.Greeks.Gamma, &opt.Greeks.Theta, &opt.Greeks.Vega,
-			&openedAtStr,
+			&openedAtStr, &opt.HarvestPeakPct, &overrideJSON, &metadataJSON,
 		); err != nil {
 			return nil, fmt.Errorf("scan option_position: %w", err)
 		}
 		opt.OpenedAt = parseTime(openedAtStr)
+		opt.HarvestOverride = parseHarvestOverrideJSON(overrideJSON)
+		opt.Metadata = parseStringMapJSON(metadataJSON)
 		if s, ok := state.Strategies[stratID]; ok {
 			s.OptionPositions[opt.ID] = &opt
 		}
@@ -1812,7 +2196,7 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 	// 5. Load most recent maxTradeHistory trades per strategy, bounded in SQL
 	// (full history stays in SQLite; see idx_trades_strategy_timestamp).
 	for id, s := range state.Strategies {
-		tradeRows, err := sdb.db.Query(`SELECT timestamp, strategy_id, symbol, COALESCE(position_id, '') AS position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, COALESCE(regime, '') AS regime, COALESCE(entry_atr, 0) AS entry_atr, COALESCE(stop_loss_oid, 0) AS stop_loss_oid, COALESCE(stop_loss_trigger_px, 0) AS stop_loss_trigger_px, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(manual, 0) AS manual, stop_loss_atr_mult, COALESCE(tp_tiers_json, '') AS tp_tiers_json, COALESCE(pnl_gross, 0) AS pnl_gross, COALESCE(fee_source, '') AS fee_source
+		tradeRows, err := sdb.db.Query(`SELECT timestamp, strategy_id, symbol, COALESCE(position_id, '') AS position_id, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, COALESCE(regime, '') AS regime, COALESCE(entry_atr, 0) AS entry_atr, COALESCE(stop_loss_oid, 0) AS stop_loss_oid, COALESCE(stop_loss_trigger_px, 0) AS stop_loss_trigger_px, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(manual, 0) AS manual, stop_loss_atr_mult, COALESCE(tp_tiers_json, '') AS tp_tiers_json, COALESCE(pnl_gross, 0) AS pnl_gross, COALESCE(fee_source, '') AS fee_source, COALESCE(tags_json, '') AS tags_json, COALESCE(indicators_json, '') AS indicators_json, COALESCE(metadata_json, '') AS metadata_json
 			FROM trades WHERE strategy_id = ? ORDER BY timestamp DESC, rowid DESC LIMIT ?`, id, maxTradeHistory)
 		if err != nil {
 			return nil, fmt.Errorf("load trades for %s: %w", id, err)
@@ -1822,9 +2206,9 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 			var t Trade
 			var tsStr string
 			var isCloseInt, isManualInt, pnlGrossInt int
-			var tpOIDsJSON string
+			var tpOIDsJSON, tagsJSON, indicatorsJSON, metadataJSON string
 			var slATRMult sql.NullFloat64
-			if err := tradeRows.Scan(&tsStr, &t.StrategyID, &t.Symbol, &t.PositionID, &t.Side, &t.Quantity, &t.Price, &t.Value, &t.TradeType, &t.Details, &t.ExchangeOrderID, &t.ExchangeFee, &isCloseInt, &t.RealizedPnL, &t.Regime, &t.EntryATR, &t.StopLossOID, &t.StopLossTriggerPx, &tpOIDsJSON, &isManualInt, &slATRMult, &t.TPTiersJSON, &pnlGrossInt, &t.FeeSource); err != nil {
+			if err := tradeRows.Scan(&tsStr, &t.StrategyID, &t.Symbol, &t.PositionID, &t.Side, &t.Quantity, &t.Price, &t.Value, &t.TradeType, &t.Details, &t.ExchangeOrderID, &t.ExchangeFee, &isCloseInt, &t.RealizedPnL, &t.Regime, &t.EntryATR, &t.StopLossOID, &t.StopLossTriggerPx, &tpOIDsJSON, &isManualInt, &slATRMult, &t.TPTiersJSON, &pnlGrossInt, &t.FeeSource, &tagsJSON, &indicatorsJSON, &metadataJSON); err != nil {
 				tradeRows.Close()
 				return nil, fmt.Errorf("scan trade: %w", err)
 			}
@@ -1833,6 +2217,9 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 			t.Manual = isManualInt != 0
 			t.PnLGross = pnlGrossInt != 0
 			t.TPOIDs = parseTPOIDsJSON(tpOIDsJSON, 0, 0)
+			t.Tags = parseStringMapJSON(tagsJSON)
+			t.Indicators = parseIndicatorsJSON(indicatorsJSON)
+			t.Metadata = parseStringMapJSON(metadataJSON)
 			if slATRMult.Valid {
 				v := slATRMult.Float64
 				t.StopLossATRMult = &v
@@ -1857,18 +2244,63 @@ func (sdb *StateDB) LoadState() (*AppState, error) {
 
 	// 6. Load portfolio_risk.
 	var ksActiveInt, warnSentInt int
-	var ksAtStr, warnBandEnteredAtStr string
-	err = sdb.db.QueryRow("SELECT peak_value, current_drawdown_pct, current_margin_drawdown_pct, kill_switch_active, kill_switch_at, warning_sent, COALESCE(warn_band_entered_at, '') AS warn_band_entered_at, COALESCE(last_warning_equity_dd_pct, 0) AS last_warning_equity_dd_pct, COALESCE(last_warning_margin_dd_pct, 0) AS last_warning_margin_dd_pct, COALESCE(warning_equity_delta_pct, 0) AS warning_equity_delta_pct, COALESCE(warning_margin_delta_pct, 0) AS warning_margin_delta_pct FROM portfolio_risk WHERE id = 1").
+	var ksAtStr, warnBandEnteredAtStr, peakAtStr string
+	err = sdb.db.QueryRow("SELECT peak_value, current_drawdown_pct, current_margin_drawdown_pct, kill_switch_active, kill_switch_at, warning_sent, COALESCE(warn_band_entered_at, '') AS warn_band_entered_at, COALESCE(last_warning_equity_dd_pct, 0) AS last_warning_equity_dd_pct, COALESCE(last_warning_margin_dd_pct, 0) AS last_warning_margin_dd_pct, COALESCE(warning_equity_delta_pct, 0) AS warning_equity_delta_pct, COALESCE(warning_margin_delta_pct, 0) AS warning_margin_delta_pct, COALESCE(version, 0), COALESCE(peak_at, ''), COALESCE(peak_source, ''), COALESCE(kill_switch_reason, '') FROM portfolio_risk WHERE id = 1").
 		Scan(&state.PortfolioRisk.PeakValue, &state.PortfolioRisk.CurrentDrawdownPct, &state.PortfolioRisk.CurrentMarginDrawdownPct,
 			&ksActiveInt, &ksAtStr, &warnSentInt, &warnBandEnteredAtStr, &state.PortfolioRisk.LastWarningEquityDDPct,
-			&state.PortfolioRisk.LastWarningMarginDDPct, &state.PortfolioRisk.WarningEquityDeltaPct, &state.PortfolioRisk.WarningMarginDeltaPct)
+			&state.PortfolioRisk.LastWarningMarginDDPct, &state.PortfolioRisk.WarningEquityDeltaPct, &state.PortfolioRisk.WarningMarginDeltaPct,
+			&state.PortfolioRisk.Version, &peakAtStr, &state.PortfolioRisk.PeakSource, &state.PortfolioRisk.KillSwitchReason)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("load portfolio_risk: %w", err)
 	}
+	if state.PortfolioRisk.Version > portfolioRiskStateVersion {
+		return nil, fmt.Errorf("load portfolio_risk: state version %d is newer than supported version %d (refusing to downgrade the portfolio high-water mark)",
+			state.PortfolioRisk.Version, portfolioRiskStateVersion)
+	}
 	state.PortfolioRisk.KillSwitchActive = ksActiveInt != 0
 	state.PortfolioRisk.KillSwitchAt = parseTime(ksAtStr)
 	state.PortfolioRisk.WarningSent = warnSentInt != 0
 	state.PortfolioRisk.WarnBandEnteredAt = parseTime(warnBandEnteredAtStr)
+	state.PortfolioRisk.PeakAt = parseTime(peakAtStr)
+
+	histRows, err := sdb.db.Query("SELECT date, high_value, last_value, max_drawdown_pct FROM portfolio_risk_history ORDER BY date ASC")
+	if err != nil {
+		return nil, fmt.Errorf("load portfolio_risk_history: %w", err)
+	}
+	defer histRows.Close()
+	for histRows.Next() {
+		var h PortfolioRiskSample
+		if err := histRows.Scan(&h.Date, &h.HighValue, &h.LastValue, &h.MaxDrawdownPct); err != nil {
+			return nil, fmt.Errorf("scan portfolio_risk_history: %w", err)
+		}
+		state.PortfolioRisk.History = append(state.PortfolioRisk.History, h)
+	}
+	if err := histRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate portfolio_risk_history: %w", err)
+	}
+
+	platRows, err := sdb.db.Query("SELECT platform, peak_value, current_drawdown_pct, breached, breached_at, reason FROM platform_risk")
+	if err != nil {
+		return nil, fmt.Errorf("load platform_risk: %w", err)
+	}
+	defer platRows.Close()
+	for platRows.Next() {
+		var name, breachedAtStr string
+		var breached int
+		prs := &PlatformRiskState{}
+		if err := platRows.Scan(&name, &prs.PeakValue, &prs.CurrentDrawdownPct, &breached, &breachedAtStr, &prs.Reason); err != nil {
+			return nil, fmt.Errorf("scan platform_risk: %w", err)
+		}
+		prs.Breached = breached != 0
+		prs.BreachedAt = parseTime(breachedAtStr)
+		if state.PlatformRisk == nil {
+			state.PlatformRisk = make(map[string]*PlatformRiskState)
+		}
+		state.PlatformRisk[name] = prs
+	}
+	if err := platRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate platform_risk: %w", err)
+	}
 
 	// 7. Load kill switch events.
 	evtRows, err := sdb.db.Query("SELECT timestamp, type, source, drawdown_pct, portfolio_value, peak_value, details FROM kill_switch_events ORDER BY rowid ASC")
@@ -2170,7 +2602,7 @@ func (sdb *StateDB) QueryTradingViewExportTrades(strategyIDs []string) ([]Trade,
 type PendingManualAction struct {
 	ID                              int64
 	StrategyID                      string
-	Action                          string // "open" | "close" | "add" | "update-sl" | "cancel-sl"
+	Action                          string // "open" | "close" | "add" | "update-sl" | "cancel-sl" | "harvest-override"
 	Symbol                          string
 	Side                            string
 	Quantity                        float64
@@ -2182,9 +2614,12 @@ type PendingManualAction struct {
 	EntryATR                        float64
 	ATRMethod                       string // open-only: atr_method resolved at queue time, next to the EntryATR fetch (#1277)
 	RealizedPnL                     float64
-	IsFullClose                     bool    // close-only: operator/scheduler intent flag (avoids tolerance heuristics on the drain side)
-	TPOIDs                          []int64 // open: placed TP OIDs; close: canceled TP OIDs that must be cleared for re-arm
-	RatchetFallbackNormalizePending bool    // open-only: one-shot normalize marker for fallback ratchet SL (#1121)
+	IsFullClose                     bool              // close-only: operator/scheduler intent flag (avoids tolerance heuristics on the drain side)
+	TPOIDs                          []int64           // open: placed TP OIDs; close: canceled TP OIDs that must be cleared for re-arm
+	RatchetFallbackNormalizePending bool              // open-only: one-shot normalize marker for fallback ratchet SL (#1121)
+	OverrideField                   string            // harvest-override only: theta harvest field (or "clear"); Symbol is the option position ID
+	OverrideValue                   string            // harvest-override only: raw value, parsed at drain
+	Metadata                        map[string]string // open-only: operator notes copied onto the position and its trade (#4971)
 	CreatedAt                       time.Time
 }
 
@@ -2203,11 +2638,11 @@ func (sdb *StateDB) InsertPendingManualAction(a PendingManualAction) error {
 		ratchetFallbackNormalizePending = 1
 	}
 	_, err := sdb.db.Exec(`INSERT INTO pending_manual_actions
-		(strategy_id, action, symbol, side, quantity, fill_price, fill_fee, exchange_order_id, stop_loss_oid, stop_loss_trigger_px, entry_atr, atr_method, realized_pnl, is_full_close, tp_oids_json, ratchet_fallback_normalize_pending, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		(strategy_id, action, symbol, side, quantity, fill_price, fill_fee, exchange_order_id, stop_loss_oid, stop_loss_trigger_px, entry_atr, atr_method, realized_pnl, is_full_close, tp_oids_json, ratchet_fallback_normalize_pending, override_field, override_value, metadata_json, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		a.StrategyID, a.Action, a.Symbol, a.Side, a.Quantity, a.FillPrice, a.FillFee,
 		a.ExchangeOrderID, a.StopLossOID, a.StopLossTriggerPx, a.EntryATR, a.ATRMethod, a.RealizedPnL,
-		isFullClose, marshalTPOIDsJSON(a.TPOIDs), ratchetFallbackNormalizePending, formatTime(a.CreatedAt))
+		isFullClose, marshalTPOIDsJSON(a.TPOIDs), ratchetFallbackNormalizePending, a.OverrideField, a.OverrideValue, marshalStringMapJSON(a.Metadata), formatTime(a.CreatedAt))
 	return err
 }
 
@@ -2216,7 +2651,7 @@ func (sdb *StateDB) LoadPendingManualActions() ([]PendingManualAction, error) {
 	if sdb == nil || sdb.db == nil {
 		return nil, nil
 	}
-	rows, err := sdb.db.Query(`SELECT id, strategy_id, action, symbol, side, quantity, fill_price, fill_fee, exchange_order_id, stop_loss_oid, stop_loss_trigger_px, entry_atr, COALESCE(atr_method, '') AS atr_method, realized_pnl, COALESCE(is_full_close, 0) AS is_full_close, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(ratchet_fallback_normalize_pending, 0) AS ratchet_fallback_normalize_pending, created_at FROM pending_manual_actions ORDER BY id`)
+	rows, err := sdb.db.Query(`SELECT id, strategy_id, action, symbol, side, quantity, fill_price, fill_fee, exchange_order_id, stop_loss_oid, stop_loss_trigger_px, entry_atr, COALESCE(atr_method, '') AS atr_method, realized_pnl, COALESCE(is_full_close, 0) AS is_full_close, COALESCE(tp_oids_json, '') AS tp_oids_json, COALESCE(ratchet_fallback_normalize_pending, 0) AS ratchet_fallback_normalize_pending, COALESCE(override_field, '') AS override_field, COALESCE(override_value, '') AS override_value, COALESCE(metadata_json, '') AS metadata_json, created_at FROM pending_manual_actions ORDER BY id`)
 	if err != nil {
 		return nil, fmt.Errorf("load pending manual actions: %w", err)
 	}
@@ -2228,12 +2663,14 @@ func (sdb *StateDB) LoadPendingManualActions() ([]PendingManualAction, error) {
 		var isFullCloseInt int
 		var tpOIDsJSON string
 		var ratchetFallbackNormalizePending int
-		if err := rows.Scan(&a.ID, &a.StrategyID, &a.Action, &a.Symbol, &a.Side, &a.Quantity, &a.FillPrice, &a.FillFee, &a.ExchangeOrderID, &a.StopLossOID, &a.StopLossTriggerPx, &a.EntryATR, &a.ATRMethod, &a.RealizedPnL, &isFullCloseInt, &tpOIDsJSON, &ratchetFallbackNormalizePending, &createdStr); err != nil {
+		var metadataJSON string
+		if err := rows.Scan(&a.ID, &a.StrategyID, &a.Action, &a.Symbol, &a.Side, &a.Quantity, &a.FillPrice, &a.FillFee, &a.ExchangeOrderID, &a.StopLossOID, &a.StopLossTriggerPx, &a.EntryATR, &a.ATRMethod, &a.RealizedPnL, &isFullCloseInt, &tpOIDsJSON, &ratchetFallbackNormalizePending, &a.OverrideField, &a.OverrideValue, &metadataJSON, &createdStr); err != nil {
 			return nil, fmt.Errorf("scan pending manual action: %w", err)
 		}
 		a.IsFullClose = isFullCloseInt != 0
 		a.TPOIDs = parseTPOIDsJSON(tpOIDsJSON, 0, 0)
 		a.RatchetFallbackNormalizePending = ratchetFallbackNormalizePending != 0
+		a.Metadata = parseStringMapJSON(metadataJSON)
 		a.CreatedAt = parseTime(createdStr)
 		actions = append(actions, a)
 	}
@@ -2538,6 +2975,9 @@ func (sdb *StateDB) ApplyBackfillPlan(plan BackfillPlan) error {
 	if _, err := tx.Exec("UPDATE strategies SET cash = ? WHERE id = ?", plan.NewCash, plan.StrategyID); err != nil {
 		return fmt.Errorf("update strategy cash: %w", err)
 	}
+	if err := stampStateChecksum(tx); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -0,0 +1,131 @@
+package main
+
+// dca: accumulation execution mode for paper generic spot strategies.
+//
+// The default spot executor is all-in/all-out: a BUY from flat deploys all
+// cash and a BUY while long is skipped ("already long"). With a dca block,
+// executeSpotResult routes BUYs here instead: each one buys a single
+// tranche_usd tranche — opening the position from flat, otherwise adding to
+// it via applySpotScaleIn (the same blend the #873 scale-in path uses, so
+// AvgCost, InitialQuantity and the add counters stay consistent) — until the
+// position's cost basis reaches max_position_usd. SELL is untouched and
+// closes the whole accumulated position.
+//
+// Tranche legs after the first are tagged scaleInTradeType so lifetime stats
+// count one round trip per accumulated position.
+
+import (
+	"fmt"
+	"time"
+)
+
+// dcaTrancheUSD returns the USD to spend on the next tranche given the open
+// position (nil when flat), available cash and the resolved size_fraction,
+// or 0 with the skip reason.
+func dcaTrancheUSD(cfg *DCAConfig, pos *Position, cash, sizeFraction float64) (float64, string) {
+	usd := cfg.TrancheUSD
+	if sizeFraction > 0 && sizeFraction < 1 {
+		usd *= sizeFraction
+	}
+	if cfg.MaxPositionUSD > 0 {
+		basis := 0.0
+		if pos != nil {
+			basis = pos.Quantity * pos.AvgCost
+		}
+		room := cfg.MaxPositionUSD - basis
+		if room < 1 {
+			return 0, fmt.Sprintf("position basis $%.2f at dca.max_position_usd $%.2f", basis, cfg.MaxPositionUSD)
+		}
+		if room < usd {
+			usd = room
+		}
+	}
+	if cash < usd {
+		usd = cash
+	}
+	if usd < 1 {
+		return 0, fmt.Sprintf("insufficient cash ($%.2f) for a tranche", cash)
+	}
+	return usd, ""
+}
+
+// dcaBuyApplies reports whether a spot signal goes through the dca tranche
+// path: a plain BUY (not a registry close action) while flat or long.
+func dcaBuyApplies(sc StrategyConfig, s *StrategyState, signal int, symbol string, closeFraction float64) bool {
+	if sc.DCA == nil || signal != 1 || closeFraction > 0 {
+		return false
+	}
+	pos, exists := s.Positions[symbol]
+	return !exists || pos.Side == "long"
+}
+
+// dcaLabel renders a dca block for the hot-reload diff.
+func dcaLabel(cfg *DCAConfig) string {
+	if cfg == nil {
+		return "off"
+	}
+	if cfg.MaxPositionUSD > 0 {
+		return fmt.Sprintf("$%.2f/tranche up to $%.2f", cfg.TrancheUSD, cfg.MaxPositionUSD)
+	}
+	return fmt.Sprintf("$%.2f/tranche", cfg.TrancheUSD)
+}
+
+// ExecuteSpotDCABuyDeferredOpen buys one dca tranche of symbol at price. A
+// fresh open is returned in OpenTrade for the caller to record (mirrors the
+// DeferredOpen executors); an add leg is recorded directly.
+func ExecuteSpotDCABuyDeferredOpen(s *StrategyState, cfg *DCAConfig, symbol string, price, sizeFraction float64, logger *StrategyLogger) (SignalExecutionResult, error) {
+	var result SignalExecutionResult
+	pos := s.Positions[symbol]
+	usd, why := dcaTrancheUSD(cfg, pos, s.Cash, sizeFraction)
+	if usd <= 0 {
+		logger.Info("DCA: BUY %s skipped — %s", symbol, why)
+		return result, nil
+	}
+	execPrice := ApplySlippage(price)
+	if execPrice <= 0 {
+		return result, nil
+	}
+	if pos != nil {
+		applySpotScaleIn(s, pos, symbol, usd, execPrice, fmt.Sprintf("DCA tranche %d", pos.ScaleInCount+2), logger)
+		result.TradesExecuted = 1
+		return result, nil
+	}
+
+	qty := usd / execPrice
+	fee := CalculatePlatformSpotFee(s.Platform, usd)
+	totalDebit := usd + fee
+	s.Cash -= totalDebit
+	now := time.Now().UTC()
+	positionID := newTradePositionID(s.ID, symbol, now)
+	s.Positions[symbol] = &Position{
+		Symbol:          symbol,
+		TradePositionID: positionID,
+		Quantity:        qty,
+		InitialQuantity: qty,
+		AvgCost:         execPrice,
+		Side:            "long",
+		OwnerStrategyID: s.ID,
+		OpenedAt:        now,
+	}
+	trade := Trade{
+		Timestamp:   now,
+		StrategyID:  s.ID,
+		Symbol:      symbol,
+		PositionID:  positionID,
+		Side:        "buy",
+		Quantity:    qty,
+		Price:       execPrice,
+		Value:       totalDebit,
+		TradeType:   "spot",
+		Details:     fmt.Sprintf("Open long %.6f @ $%.2f (fee $%.2f) [dca tranche 1]", qty, execPrice, fee),
+		ExchangeFee: fee,
+		FeeSource:   FeeSourceModeled,
+		PnLGross:    true,
+	}
+	trade.SlippageCost = slippageCost("buy", qty, price, execPrice)
+	trade.Regime = s.Regime
+	result.OpenTrade = &trade
+	result.TradesExecuted = 1
+	logger.Info("DCA BUY %s: %.6f @ $%.2f (tranche $%.2f, fee $%.2f) [open]", symbol, qty, execPrice, usd, fee)
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDCATrancheUSD(t *testing.T) {
+	cfg := &DCAConfig{TrancheUSD: 100, MaxPositionUSD: 250}
+	if usd, _ := dcaTrancheUSD(cfg, nil, 1000, 1); usd != 100 {
+		t.Errorf("flat: %v, want 100", usd)
+	}
+	pos := &Position{Quantity: 2, AvgCost: 100, Side: "long"}
+	if usd, _ := dcaTrancheUSD(cfg, pos, 1000, 1); usd != 50 {
+		t.Errorf("last tranche must be trimmed to the cap: %v, want 50", usd)
+	}
+	pos.Quantity = 2.5
+	if usd, why := dcaTrancheUSD(cfg, pos, 1000, 1); usd != 0 || !strings.Contains(why, "max_position_usd") {
+		t.Errorf("at cap: %v %q", usd, why)
+	}
+	if usd, _ := dcaTrancheUSD(&DCAConfig{TrancheUSD: 100}, nil, 40, 1); usd != 40 {
+		t.Errorf("cash-bounded: %v, want 40", usd)
+	}
+}
+
+func TestExecuteSpotDCABuy_AccumulatesAndCloses(t *testing.T) {
+	sc := StrategyConfig{ID: "dca-btc", Type: "spot", Platform: "binanceus", Capital: 1000, DCA: &DCAConfig{TrancheUSD: 200, MaxPositionUSD: 500}}
+	s := NewStrategyState(sc)
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("dca-btc")
+
+	exec, err := ExecuteSpotDCABuyDeferredOpen(s, sc.DCA, "BTC/USDT", 100, 1, logger)
+	if err != nil || exec.OpenTrade == nil {
+		t.Fatalf("first tranche must be a fresh open: %+v, %v", exec, err)
+	}
+	RecordTrade(s, *exec.OpenTrade)
+	if !dcaBuyApplies(sc, s, 1, "BTC/USDT", 0) || dcaBuyApplies(sc, s, -1, "BTC/USDT", 0) {
+		t.Fatal("BUY while long must route to dca; SELL must not")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := ExecuteSpotDCABuyDeferredOpen(s, sc.DCA, "BTC/USDT", 50, 1, logger); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pos := s.Positions["BTC/USDT"]
+	// Tranches: $200 @ ~100, $200 @ ~50, $100 @ ~50 (trimmed), then capped.
+	if pos.ScaleInCount != 2 || math.Abs(pos.Quantity*pos.AvgCost-500) > 1e-6 {
+		t.Fatalf("position = %+v", pos)
+	}
+	if wantAvg := 500 / (2 + 6.0); math.Abs(pos.AvgCost-wantAvg) > 0.1 {
+		t.Errorf("avg cost = %v, want ~%v", pos.AvgCost, wantAvg)
+	}
+	if got := s.TradeHistory[len(s.TradeHistory)-1]; got.TradeType != scaleInTradeType {
+		t.Errorf("add leg trade type = %q", got.TradeType)
+	}
+	if math.Abs(s.Cash-(500-totalFees(s))) > 1e-6 {
+		t.Errorf("cash = %v", s.Cash)
+	}
+
+	// SELL closes the whole accumulated position.
+	if _, err := ExecuteSpotSignalWithFillFee(s, -1, "BTC/USDT", 80, 0, 0, "", 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	if _, open := s.Positions["BTC/USDT"]; open {
+		t.Error("SELL must close the accumulated position")
+	}
+}
+
+func totalFees(s *StrategyState) float64 {
+	var f float64
+	for _, tr := range s.TradeHistory {
+		f += tr.ExchangeFee
+	}
+	return f
+}
+
+func TestValidateConfig_DCA(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{
+				ID: "dca-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+				Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				DCA: &DCAConfig{TrancheUSD: 200, MaxPositionUSD: 100},
+			},
+			{
+				ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Script: "shared_scripts/check_hyperliquid.py",
+				Args: []string{"sma_crossover", "BTC", "1h"}, Capital: 1000, MaxDrawdownPct: 10, DCA: &DCAConfig{},
+			},
+		},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"must be >= dca.tranche_usd", "dca is only supported for paper generic spot", "dca.tranche_usd must be positive"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -36,7 +36,7 @@ type DeribitPricer struct {
 
 func NewDeribitPricer() *DeribitPricer {
 	return &DeribitPricer{
-		client: &http.Client{Timeout: 10 * time.Second},
+		client: newLatencyClient("deribit", "", 10*time.Second), // op = endpoint (#4940)
 	}
 }
 
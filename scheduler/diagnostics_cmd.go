@@ -1,7 +1,6 @@
 package main
 
 import (
-	"database/sql"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -67,7 +66,7 @@ func runDiagnostics(args []string) int {
 
 	// Read-only open (agent-info pattern): never migrates, never writes, safe
 	// to run next to the live daemon.
-	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	db, err := openStateDBForRead(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "diagnostics: open %s: %v\n", path, err)
 		return 1
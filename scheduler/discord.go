@@ -29,6 +29,10 @@ type DiscordNotifier struct {
 	dmHandlers []dmHandler
 	mu         sync.Mutex
 
+	// sessionMu guards the session pointer, which RotateToken swaps while
+	// senders on other goroutines may be using it.
+	sessionMu sync.RWMutex
+
 	// Slash-command context, set by RegisterSlashCommands; nil until then.
 	ss  *StatusServer
 	cfg *Config
@@ -36,28 +40,71 @@ type DiscordNotifier struct {
 
 // NewDiscordNotifier creates a discordgo session, registers the DM message handler, and opens the gateway.
 func NewDiscordNotifier(token, ownerID string) (*DiscordNotifier, error) {
-	session, err := discordgo.New("Bot " + token)
+	d := &DiscordNotifier{ownerID: ownerID}
+	session, err := d.openSession(token, false)
 	if err != nil {
-		return nil, fmt.Errorf("create session: %w", err)
+		return nil, err
 	}
+	d.session = session
+	return d, nil
+}
 
-	d := &DiscordNotifier{
-		session: session,
-		ownerID: ownerID,
+// openSession creates and opens a gateway session wired to this notifier's
+// handlers. withSlash also attaches the slash-command interaction handler
+// (set once RegisterSlashCommands has run).
+func (d *DiscordNotifier) openSession(token string, withSlash bool) (*discordgo.Session, error) {
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
 	}
-	session.Identify.Intents = discordgo.IntentsDirectMessages
+	session.Identify.Intents = discordgo.IntentsDirectMessages | discordgo.IntentsDirectMessageReactions
 	session.AddHandler(d.messageCreate)
-
+	session.AddHandler(d.messageReactionAdd)
+	if withSlash {
+		session.AddHandler(d.interactionCreate)
+	}
 	if err := session.Open(); err != nil {
 		return nil, fmt.Errorf("open gateway: %w", err)
 	}
+	return session, nil
+}
 
-	return d, nil
+func (d *DiscordNotifier) currentSession() *discordgo.Session {
+	d.sessionMu.RLock()
+	defer d.sessionMu.RUnlock()
+	return d.session
+}
+
+// RotateToken re-establishes the gateway under a new bot token without a
+// restart. The new session is opened (and slash commands re-registered when
+// the token belongs to a different application) before the old one is
+// closed, so a bad token leaves the current connection untouched.
+func (d *DiscordNotifier) RotateToken(token string) error {
+	old := d.currentSession()
+	withSlash := d.ss != nil
+	next, err := d.openSession(token, withSlash)
+	if err != nil {
+		return err
+	}
+	if withSlash && next.State != nil && next.State.User != nil &&
+		(old == nil || old.State == nil || old.State.User == nil || old.State.User.ID != next.State.User.ID) {
+		if _, err := next.ApplicationCommandBulkOverwrite(next.State.User.ID, "", slashCommands()); err != nil {
+			next.Close()
+			return fmt.Errorf("register slash commands for new application: %w", err)
+		}
+	}
+	d.sessionMu.Lock()
+	d.session = next
+	d.sessionMu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	return nil
 }
 
 // Close shuts down the gateway connection.
 func (d *DiscordNotifier) Close() {
-	d.session.Close()
+	d.currentSession().Close()
 }
 
 // SendMessage posts content to a channel. Truncates to 2000 chars.
@@ -65,20 +112,21 @@ func (d *DiscordNotifier) SendMessage(channelID string, content string) error {
 	if len(content) > 2000 {
 		content = content[:1997] + "..."
 	}
-	_, err := d.session.ChannelMessageSend(channelID, content)
+	_, err := d.currentSession().ChannelMessageSend(channelID, content)
 	return err
 }
 
 // SendDM opens a DM channel with userID and sends content.
 func (d *DiscordNotifier) SendDM(userID, content string) error {
-	ch, err := d.session.UserChannelCreate(userID)
+	session := d.currentSession()
+	ch, err := session.UserChannelCreate(userID)
 	if err != nil {
 		return fmt.Errorf("create DM channel: %w", err)
 	}
 	if len(content) > 2000 {
 		content = content[:1997] + "..."
 	}
-	_, err = d.session.ChannelMessageSend(ch.ID, content)
+	_, err = session.ChannelMessageSend(ch.ID, content)
 	return err
 }
 
@@ -127,6 +175,9 @@ func (d *DiscordNotifier) messageCreate(s *discordgo.Session, m *discordgo.Messa
 	if m.GuildID != "" {
 		return // only handle DMs
 	}
+	// Any owner DM — including a reply to a waiting prompt — acknowledges
+	// pending critical alerts (#4945).
+	criticalAlerts.Ack(m.Author.ID)
 
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -152,6 +203,15 @@ func (d *DiscordNotifier) messageCreate(s *discordgo.Session, m *discordgo.Messa
 	d.dmHandlers = remaining
 }
 
+// messageReactionAdd treats an owner's reaction in the bot DM as an
+// acknowledgment of pending critical alerts (#4945).
+func (d *DiscordNotifier) messageReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	if r.GuildID != "" || s.State == nil || s.State.User == nil || r.UserID == s.State.User.ID {
+		return
+	}
+	criticalAlerts.Ack(r.UserID)
+}
+
 // resolveChannel returns the Discord channel ID for a strategy.
 // Lookup order: channels[platform] -> channels[stratType] -> "" (no channel).
 func resolveChannel(channels map[string]string, platform, stratType string) string {
@@ -269,7 +329,8 @@ const discordSplitThreshold = 1980
 // the table is continued in a follow-up message. Sized so the rendered table
 // (including header/sep/totals) plus the base summary header and the per-channel
 // position section stays under the 2000-char limit (#381 added #T, #434 added
-// W/L, and #436 added DD; 15 rows remains within the Discord limit).
+// W/L, and #436 added DD; 15 rows remains within the Discord limit). The
+// optional #4927 Alpha/Beta columns add 13 chars per row, still under it.
 const (
 	catTableMaxRows       = 15
 	catTableStrategyWidth = 18
@@ -345,6 +406,8 @@ func priceForAsset(prices map[string]float64, asset string) (float64, string, bo
 // round-trips because SQLite trades are authoritative (#472).
 // regime is the top-level cfg.regime pointer; when enabled and state has labels,
 // each symbol's price segment gains " | <regime>" (#741).
+// layout is the channel's summary_layout entry (#4947); nil renders every
+// section with the standard table in ID order.
 func FormatCategorySummary(
 	cycle int,
 	elapsed time.Duration,
@@ -361,6 +424,7 @@ func FormatCategorySummary(
 	categorySharpe float64,
 	lifetimeStats map[string]LifetimeTradeStats,
 	regime *RegimeConfig,
+	layout *SummaryLayoutConfig,
 ) []string {
 	var sb strings.Builder
 
@@ -425,13 +489,18 @@ func FormatCategorySummary(
 			cbActive = append(cbActive, fmt.Sprintf("%s (resumes in %s)", sc.ID, remaining))
 		}
 	}
-	if len(cbActive) > 0 {
-		sb.WriteString("🚫 **Circuit breaker active — trading disabled**\n")
-		for _, cb := range cbActive {
-			sb.WriteString(fmt.Sprintf("  • %s\n", cb))
+	if layout.showSection("risk") {
+		if len(cbActive) > 0 {
+			sb.WriteString("🚫 **Circuit breaker active — trading disabled**\n")
+			for _, cb := range cbActive {
+				sb.WriteString(fmt.Sprintf("  • %s\n", cb))
+			}
+		} else {
+			sb.WriteString("✅ **Trading active**\n")
+		}
+		if v := state.PortfolioRisk.VaR; v != nil && !v.Insufficient {
+			sb.WriteString("📉 Portfolio " + portfolioVaRLine(v) + "\n")
 		}
-	} else {
-		sb.WriteString("✅ **Trading active**\n")
 	}
 
 	// Prices inline — filter to just this asset when asset is specified.
@@ -445,7 +514,7 @@ func FormatCategorySummary(
 			}
 		}
 	}
-	if len(displayPrices) > 0 {
+	if len(displayPrices) > 0 && layout.showSection("prices") {
 		syms := make([]string, 0, len(displayPrices))
 		for s := range displayPrices {
 			syms = append(syms, s)
@@ -466,12 +535,16 @@ func FormatCategorySummary(
 			} else {
 				part = fmt.Sprintf("%s: $%s", short, priceStr)
 			}
+			base := strings.ToUpper(short)
 			if regimeByBase != nil {
-				base := strings.ToUpper(short)
 				if rl := regimeByBase[base]; rl != "" {
 					part += " | " + rl
 				}
 			}
+			// #4926: Go-side market regime, when market_regime is enabled.
+			if ml := globalMarketRegimes.LabelForAsset(base); ml != "" {
+				part += " | mkt " + ml
+			}
 			parts = append(parts, part)
 		}
 		sb.WriteString(strings.Join(parts, " | "))
@@ -537,6 +610,10 @@ func FormatCategorySummary(
 			winT = lt.Wins
 			lossT = lt.Losses
 		}
+		var bench *BenchmarkStats
+		if st, ok := benchmarkStats(ss.Benchmark, benchmarkWindowDays); ok {
+			bench = &st
+		}
 		tableBots = append(tableBots, botInfo{
 			id:             sc.ID,
 			strategy:       stratName,
@@ -554,6 +631,7 @@ func FormatCategorySummary(
 			winningTrades:  winT,
 			losingTrades:   lossT,
 			tradeHistory:   ss.TradeHistory,
+			benchmark:      bench,
 		})
 	}
 
@@ -566,6 +644,19 @@ func FormatCategorySummary(
 	// to the naive sum). A portfolio value is never negative, so this lets a
 	// legitimately drained shared wallet display $0 instead of being mistaken
 	// for "unset" and falling back to the inflated naive sum (#917 review item 3).
+	// summary_layout sort_by (#4947): reorder rows, and the strategies the
+	// position bullets iterate, to match. The default stays A→Z by ID.
+	if by := layout.sortBy(); by != "id" {
+		sortSummaryBots(tableBots, by)
+		rank := make(map[string]int, len(tableBots))
+		for i, b := range tableBots {
+			rank[b.id] = i
+		}
+		sort.SliceStable(strategies, func(i, j int) bool {
+			return rank[strategies[i].ID] < rank[strategies[j].ID]
+		})
+	}
+
 	totalRowValue := filteredValue
 	if totalValue >= 0 {
 		totalRowValue = totalValue
@@ -576,12 +667,23 @@ func FormatCategorySummary(
 		totalPnlPct = (totalPnl / totalInitCap) * 100
 	}
 
-	sb.WriteString(fmt.Sprintf("Cycle #%d | %.1fs | Initial capital: $%s\n", cycle, elapsed.Seconds(), fmtComma(totalInitCap)))
+	if layout.showSection("stats") {
+		sb.WriteString(fmt.Sprintf("Cycle #%d | %.1fs | Initial capital: $%s\n", cycle, elapsed.Seconds(), fmtComma(totalInitCap)))
+	}
 
 	// Render the strategy table in chunks of catTableMaxRows. The first chunk
 	// is appended to the in-message header; any extra chunks become standalone
 	// continuation messages so the table never overflows the 2000-char limit.
-	tableChunks := writeCatTableChunks(tableBots, totalRowValue, totalPnl, totalPnlPct, hasSharedWallet)
+	var tableChunks []string
+	switch {
+	case !layout.showSection("table"):
+	case layout != nil && len(layout.Columns) > 0:
+		tableChunks = writeSummaryLayoutTableChunks(tableBots, layout.Columns, summaryTotals{
+			value: totalRowValue, pnl: totalPnl, pnlPct: totalPnlPct, sharedWallet: hasSharedWallet,
+		})
+	default:
+		tableChunks = writeCatTableChunks(tableBots, totalRowValue, totalPnl, totalPnlPct, hasSharedWallet)
+	}
 	if len(tableChunks) > 0 {
 		sb.WriteString(tableChunks[0])
 	}
@@ -590,10 +692,15 @@ func FormatCategorySummary(
 	// strategy in this channel/asset, not any one strategy's figure — per-strategy
 	// Sharpes are rendered in the leaderboard column. Computed from realized
 	// daily returns with zero-fill on flat days (see sharpe.go).
-	if categorySharpe != 0 {
+	if categorySharpe != 0 && layout.showSection("stats") {
 		sb.WriteString(fmt.Sprintf("📐 Book Sharpe (realized, annualized): %s\n", fmtSharpe(categorySharpe)))
 	}
 
+	// Per-underlying PnL and net exposure across the channel's bots (#4948).
+	if layout.showSection("assets") {
+		sb.WriteString(formatAssetBreakdown(assetBreakdown(strategies, tableBots, state, prices)))
+	}
+
 	header := sb.String()
 
 	var continuationTables []string
@@ -613,7 +720,7 @@ func FormatCategorySummary(
 		totalOpenPos += bot.openPositions
 	}
 	var posLines []string
-	if totalOpenPos > 0 {
+	if totalOpenPos > 0 && layout.showSection("positions") {
 		for _, sc := range strategies {
 			ss := state.Strategies[sc.ID]
 			if ss == nil {
@@ -625,6 +732,9 @@ func FormatCategorySummary(
 
 	// Collect trade detail lines.
 	var tradeLines []string
+	if !layout.showSection("trades") {
+		tradeDetails = nil
+	}
 	for _, td := range tradeDetails {
 		tradeLines = append(tradeLines, fmt.Sprintf("• %s", td))
 	}
@@ -759,7 +869,8 @@ type botInfo struct {
 	pnl            float64
 	pnlPct         float64
 	maxDrawdownPct float64
-	walletPct      float64 // 0 = not a shared wallet; >0 = strategy's share of the wallet
+	walletPct      float64         // 0 = not a shared wallet; >0 = strategy's share of the wallet
+	benchmark      *BenchmarkStats // #4927: rolling alpha/beta; nil until enough history
 	trades         int
 	openPositions  int
 	closedTrades   int
@@ -919,9 +1030,18 @@ func writeCatTablePartial(sb *strings.Builder, bots []botInfo, showWalletPct, in
 	if len(bots) == 0 {
 		return
 	}
+	// #4927: the Alpha/Beta columns only appear once some row has enough
+	// benchmark history, so young tables keep their familiar width.
+	showBench := false
+	for _, bot := range bots {
+		if bot.benchmark != nil {
+			showBench = true
+			break
+		}
+	}
 	sb.WriteString("\n```\n")
 	if showWalletPct {
-		header := fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4s %5s", catTableStrategyWidth, "Strategy", "Value", "PnL", "PnL%", "DD", "Wallet%", "Tf", "Int", "#T", "W/L")
+		header := fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4s %5s", catTableStrategyWidth, "Strategy", "Value", "PnL", "PnL%", "DD", "Wallet%", "Tf", "Int", "#T", "W/L") + catBenchmarkHeader(showBench)
 		sep := strings.Repeat("-", len(header))
 		sb.WriteString(header + "\n")
 		sb.WriteString(sep + "\n")
@@ -936,7 +1056,7 @@ func writeCatTablePartial(sb *strings.Builder, bots []botInfo, showWalletPct, in
 				wpStr = fmt.Sprintf("%.1f%%", bot.walletPct)
 			}
 			wlStr := fmtWinLossRatio(bot.winningTrades, bot.losingTrades)
-			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4d %5s\n", catTableStrategyWidth, label, valStr, pnlStr, pctStr, maxDDStr, wpStr, bot.timeframe, bot.interval, bot.closedTrades, wlStr))
+			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4d %5s", catTableStrategyWidth, label, valStr, pnlStr, pctStr, maxDDStr, wpStr, bot.timeframe, bot.interval, bot.closedTrades, wlStr) + catBenchmarkCols(showBench, bot.benchmark) + "\n")
 		}
 		if includeTotals {
 			sb.WriteString(sep + "\n")
@@ -944,10 +1064,10 @@ func writeCatTablePartial(sb *strings.Builder, bots []botInfo, showWalletPct, in
 			totPnlStr := fmtPnl(totalPnl)
 			totPctStr := fmtPnlPct(totalPnlPct)
 			totWlStr := fmtWinLossRatio(totalWins, totalLosses)
-			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4d %5s\n", catTableStrategyWidth, "TOTAL", totValStr, totPnlStr, totPctStr, "", "100.0%", "", "", totalClosed, totWlStr))
+			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %8s%5s %4s %4d %5s", catTableStrategyWidth, "TOTAL", totValStr, totPnlStr, totPctStr, "", "100.0%", "", "", totalClosed, totWlStr) + catBenchmarkCols(showBench, nil) + "\n")
 		}
 	} else {
-		header := fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4s %5s", catTableStrategyWidth, "Strategy", "Value", "PnL", "PnL%", "DD", "Tf", "Int", "#T", "W/L")
+		header := fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4s %5s", catTableStrategyWidth, "Strategy", "Value", "PnL", "PnL%", "DD", "Tf", "Int", "#T", "W/L") + catBenchmarkHeader(showBench)
 		sep := strings.Repeat("-", len(header))
 		sb.WriteString(header + "\n")
 		sb.WriteString(sep + "\n")
@@ -958,7 +1078,7 @@ func writeCatTablePartial(sb *strings.Builder, bots []botInfo, showWalletPct, in
 			pctStr := fmtPnlPct(bot.pnlPct)
 			wlStr := fmtWinLossRatio(bot.winningTrades, bot.losingTrades)
 			maxDDStr := fmtDrawdownPct(bot.maxDrawdownPct)
-			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4d %5s\n", catTableStrategyWidth, label, valStr, pnlStr, pctStr, maxDDStr, bot.timeframe, bot.interval, bot.closedTrades, wlStr))
+			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4d %5s", catTableStrategyWidth, label, valStr, pnlStr, pctStr, maxDDStr, bot.timeframe, bot.interval, bot.closedTrades, wlStr) + catBenchmarkCols(showBench, bot.benchmark) + "\n")
 		}
 		if includeTotals {
 			sb.WriteString(sep + "\n")
@@ -966,12 +1086,31 @@ func writeCatTablePartial(sb *strings.Builder, bots []botInfo, showWalletPct, in
 			totPnlStr := fmtPnl(totalPnl)
 			totPctStr := fmtPnlPct(totalPnlPct)
 			totWlStr := fmtWinLossRatio(totalWins, totalLosses)
-			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4d %5s\n", catTableStrategyWidth, "TOTAL", totValStr, totPnlStr, totPctStr, "", "", "", totalClosed, totWlStr))
+			sb.WriteString(fmt.Sprintf("%-*s %6s %6s %8s%5s %5s %4s %4d %5s", catTableStrategyWidth, "TOTAL", totValStr, totPnlStr, totPctStr, "", "", "", totalClosed, totWlStr) + catBenchmarkCols(showBench, nil) + "\n")
 		}
 	}
 	sb.WriteString("```\n")
 }
 
+// catBenchmarkHeader / catBenchmarkCols render the optional #4927 Alpha
+// (annualized %) and Beta columns; both are empty when showBench is false.
+func catBenchmarkHeader(showBench bool) string {
+	if !showBench {
+		return ""
+	}
+	return fmt.Sprintf(" %6s %5s", "Alpha", "Beta")
+}
+
+func catBenchmarkCols(showBench bool, st *BenchmarkStats) string {
+	if !showBench {
+		return ""
+	}
+	if st == nil {
+		return fmt.Sprintf(" %6s %5s", "", "")
+	}
+	return fmt.Sprintf(" %6s %5.2f", fmt.Sprintf("%+.0f%%", st.Alpha), st.Beta)
+}
+
 // writeCatTableChunks splits bots into catTableMaxRows-sized chunks and returns
 // one rendered code-block table per chunk. The TOTAL row appears only in the
 // final chunk so totals always show against the same numbers regardless of how
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// This file implements the owner-DM /adjust command (#4942): live changes to
+// a strategy's capital, max_drawdown_pct, or theta_harvest thresholds.
+//
+// The edit goes through the config-migration machinery: the strategy entry
+// is patched in the raw JSON with setNestedValue and the document is run
+// through migrateConfigData (version floor, any pending passes, stamp), so
+// the change list the owner confirms is the same diffJSONValues preview the
+// upgrade DM shows. The confirmed write rides mutateConfigRoot
+// (configWriteMu, LoadConfigForProbe validation, atomic rename) and then a
+// SIGHUP hot reload syncs the running state: capital moves the strategy's
+// cash by the delta, max_drawdown_pct updates RiskState.MaxDrawdownPct, and
+// theta_harvest applies from the next options cycle.
+
+// strategyAdjustFields are the fields /adjust accepts, in help order.
+var strategyAdjustFields = []string{
+	"capital",
+	"max_drawdown_pct",
+	"theta_harvest.enabled",
+	"theta_harvest.profit_target_pct",
+	"theta_harvest.stop_loss_pct",
+	"theta_harvest.min_dte_close",
+}
+
+// parseStrategyAdjustment validates one field/value pair and returns the
+// typed JSON value to write.
+func parseStrategyAdjustment(field, value string) (interface{}, error) {
+	field = strings.TrimSpace(field)
+	v := strings.TrimSpace(value)
+	if field == "theta_harvest.enabled" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("theta_harvest.enabled must be true or false")
+		}
+		return b, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		if isStrategyAdjustField(field) {
+			return nil, fmt.Errorf("%s must be a number, got %q", field, v)
+		}
+		return nil, fmt.Errorf("unsupported field %q (supported: %s)", field, strings.Join(strategyAdjustFields, ", "))
+	}
+	switch field {
+	case "capital":
+		if f <= 0 {
+			return nil, fmt.Errorf("capital must be > 0, got %g", f)
+		}
+	case "max_drawdown_pct":
+		if f <= 0 || f > 100 {
+			return nil, fmt.Errorf("max_drawdown_pct must be in (0, 100], got %g", f)
+		}
+	case "theta_harvest.profit_target_pct", "theta_harvest.stop_loss_pct", "theta_harvest.min_dte_close":
+		if f < 0 {
+			return nil, fmt.Errorf("%s must be >= 0, got %g", field, f)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported field %q (supported: %s)", field, strings.Join(strategyAdjustFields, ", "))
+	}
+	return f, nil
+}
+
+func isStrategyAdjustField(field string) bool {
+	for _, f := range strategyAdjustFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// adjustStrategyConfigData returns data with field set to value on strategy
+// id, passed through migrateConfigData like any MigrateConfig rewrite.
+// Refuses capital on a capital_pct strategy (capital is derived there) and
+// theta_harvest on non-options strategies. A theta_harvest block the file
+// omits starts from the #56 defaults LoadConfig would have applied, so
+// setting one threshold does not silently zero the others.
+func adjustStrategyConfigData(data []byte, id, field string, value interface{}) ([]byte, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	list, _ := raw["strategies"].([]interface{})
+	var entry map[string]interface{}
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok && m["id"] == id {
+			entry = m
+			break
+		}
+	}
+	if entry == nil {
+		_, hasInclude := raw[configIncludeKey]
+		return nil, strategyNotFoundError(id, hasInclude)
+	}
+	switch {
+	case field == "capital":
+		if pct, _ := entry["capital_pct"].(float64); pct > 0 {
+			return nil, fmt.Errorf("%s sizes from capital_pct (%g) — capital is derived from the wallet balance; change capital_pct instead", id, pct)
+		}
+	case strings.HasPrefix(field, "theta_harvest."):
+		if entry["type"] != "options" {
+			return nil, fmt.Errorf("theta_harvest applies to options strategies only; %s is type %v", id, entry["type"])
+		}
+		if _, ok := entry["theta_harvest"].(map[string]interface{}); !ok {
+			th := defaultThetaHarvestConfig()
+			entry["theta_harvest"] = map[string]interface{}{
+				"enabled":           th.Enabled,
+				"profit_target_pct": th.ProfitTargetPct,
+				"stop_loss_pct":     th.StopLossPct,
+				"min_dte_close":     th.MinDTEClose,
+			}
+		}
+	}
+	setNestedValue(entry, field, value)
+	patched, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return migrateConfigData(patched, nil)
+}
+
+// handleAdjust is the /adjust handler: validate, DM the change list, wait for
+// an explicit confirm, then write and hot-reload.
+func (d *DiscordNotifier) handleAdjust(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	deferAck(s, i)
+	path, err := d.configOpsReady()
+	if err != nil {
+		followupText(s, i, err.Error())
+		return
+	}
+	id := optionString(opts, "strategy", "")
+	field := optionString(opts, "field", "")
+	if id == "" || field == "" {
+		followupText(s, i, "usage: /go-trader-adjust <strategy> <field> <value> — fields: "+strings.Join(strategyAdjustFields, ", "))
+		return
+	}
+	value, err := parseStrategyAdjustment(field, optionString(opts, "value", ""))
+	if err != nil {
+		followupText(s, i, err.Error())
+		return
+	}
+
+	// Preview against the file as it is now; the write below re-applies the
+	// edit to whatever is on disk under configWriteMu.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		followupText(s, i, "read config failed: "+err.Error())
+		return
+	}
+	newData, err := adjustStrategyConfigData(data, id, field, value)
+	if err != nil {
+		followupText(s, i, "adjust failed: "+err.Error())
+		return
+	}
+	preview, err := diffConfigBytes(data, newData)
+	if err != nil {
+		followupText(s, i, "adjust failed: "+err.Error())
+		return
+	}
+	if len(preview) == 0 {
+		followupText(s, i, fmt.Sprintf("`%s` %s is already %v — nothing to change.", id, field, value))
+		return
+	}
+	prompt := fmt.Sprintf("Adjust `%s` in `%s`:\n%s", id, path, formatConfigMigrationPreview(preview, 1500))
+	if field == "capital" {
+		prompt += "\nThe strategy's cash moves by the capital delta on reload; the P&L baseline (initial_capital) is unchanged."
+	}
+	if !d.confirmDestructive(interactionUserID(i), prompt) {
+		followupText(s, i, "Cancelled — no confirmation received.")
+		return
+	}
+
+	err = d.mutateConfig(path, func(root map[string]json.RawMessage) error {
+		data, err := json.Marshal(root)
+		if err != nil {
+			return err
+		}
+		newData, err := adjustStrategyConfigData(data, id, field, value)
+		if err != nil {
+			return err
+		}
+		var next map[string]json.RawMessage
+		if err := json.Unmarshal(newData, &next); err != nil {
+			return err
+		}
+		for k := range root {
+			delete(root, k)
+		}
+		for k, v := range next {
+			root[k] = v
+		}
+		return nil
+	})
+	if err != nil {
+		followupText(s, i, "adjust failed: "+err.Error())
+		return
+	}
+	fmt.Printf("[discord] /adjust %s %s=%v written to %s\n", id, field, value, path)
+	d.applyConfigChange(s, i, false, fmt.Sprintf("Set `%s` %s = %v.", id, field, value))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const adjustTestConfig = `{
+  "config_version": 17,
+  "strategies": [
+    {"id": "spot-btc", "type": "spot", "capital": 1000, "max_drawdown_pct": 10},
+    {"id": "pct-eth", "type": "perps", "capital_pct": 0.5, "max_drawdown_pct": 10},
+    {"id": "deribit-strangle", "type": "options", "capital": 2000, "max_drawdown_pct": 20}
+  ]
+}`
+
+func adjustedStrategy(t *testing.T, data []byte, id string) map[string]interface{} {
+	t.Helper()
+	var raw struct {
+		ConfigVersion int                      `json:"config_version"`
+		Strategies    []map[string]interface{} `json:"strategies"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.ConfigVersion != CurrentConfigVersion {
+		t.Fatalf("config_version = %d, want %d", raw.ConfigVersion, CurrentConfigVersion)
+	}
+	for _, s := range raw.Strategies {
+		if s["id"] == id {
+			return s
+		}
+	}
+	t.Fatalf("strategy %s missing", id)
+	return nil
+}
+
+func TestParseStrategyAdjustment(t *testing.T) {
+	cases := []struct {
+		field, value string
+		want         interface{}
+		wantErr      string
+	}{
+		{"capital", "1500", 1500.0, ""},
+		{"capital", "0", nil, "capital must be > 0"},
+		{"max_drawdown_pct", "12.5", 12.5, ""},
+		{"max_drawdown_pct", "150", nil, "(0, 100]"},
+		{"theta_harvest.enabled", "false", false, ""},
+		{"theta_harvest.stop_loss_pct", "-1", nil, ">= 0"},
+		{"theta_harvest.min_dte_close", "abc", nil, "must be a number"},
+		{"leverage", "2", nil, "unsupported field"},
+	}
+	for _, c := range cases {
+		got, err := parseStrategyAdjustment(c.field, c.value)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("%s=%s: err = %v, want %q", c.field, c.value, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != c.want {
+			t.Errorf("%s=%s: got (%v, %v), want %v", c.field, c.value, got, err, c.want)
+		}
+	}
+}
+
+func TestAdjustStrategyConfigDataCapitalAndDrawdown(t *testing.T) {
+	out, err := adjustStrategyConfigData([]byte(adjustTestConfig), "spot-btc", "capital", 1500.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = adjustStrategyConfigData(out, "spot-btc", "max_drawdown_pct", 15.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := adjustedStrategy(t, out, "spot-btc")
+	if s["capital"] != 1500.0 || s["max_drawdown_pct"] != 15.0 {
+		t.Fatalf("spot-btc = %v", s)
+	}
+	if other := adjustedStrategy(t, out, "deribit-strangle"); other["capital"] != 2000.0 {
+		t.Fatalf("untouched strategy changed: %v", other)
+	}
+	lines, err := diffConfigBytes([]byte(adjustTestConfig), out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"~ strategies[spot-btc].capital: 1000 → 1500",
+		"~ strategies[spot-btc].max_drawdown_pct: 10 → 15",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("preview = %q, want %q", lines, want)
+	}
+}
+
+func TestAdjustStrategyConfigDataThetaHarvestSeedsDefaults(t *testing.T) {
+	out, err := adjustStrategyConfigData([]byte(adjustTestConfig), "deribit-strangle", "theta_harvest.profit_target_pct", 70.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th, _ := adjustedStrategy(t, out, "deribit-strangle")["theta_harvest"].(map[string]interface{})
+	want := map[string]interface{}{"enabled": true, "profit_target_pct": 70.0, "stop_loss_pct": 200.0, "min_dte_close": 3.0}
+	for k, v := range want {
+		if th[k] != v {
+			t.Errorf("theta_harvest.%s = %v, want %v", k, th[k], v)
+		}
+	}
+}
+
+func TestAdjustStrategyConfigDataRefusals(t *testing.T) {
+	cases := []struct {
+		id, field string
+		value     interface{}
+		wantErr   string
+	}{
+		{"pct-eth", "capital", 100.0, "capital_pct"},
+		{"spot-btc", "theta_harvest.stop_loss_pct", 150.0, "options strategies only"},
+		{"missing", "capital", 100.0, `strategy "missing" not found`},
+	}
+	for _, c := range cases {
+		_, err := adjustStrategyConfigData([]byte(adjustTestConfig), c.id, c.field, c.value)
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("%s %s: err = %v, want %q", c.id, c.field, err, c.wantErr)
+		}
+	}
+}
@@ -51,6 +51,13 @@ var opsCommandNames = map[string]bool{
 	"paper-to-live":        true,
 	"apply-regime-gate":    true,
 	"clear-cash-reconcile": true,
+	"run":                  true,
+	"adjust":               true,
+	"mute":                 true,
+	"unmute":               true,
+	"harvest":              true,
+	"note":                 true,
+	"position":             true,
 }
 
 // authorizeCommand decides whether invokerID may run command `name`. Read-only
@@ -441,6 +448,39 @@ func slashCommands() []*discordgo.ApplicationCommand {
 		{Name: commandPrefix + "clear-cash-reconcile", Description: "Clear CashReconcileRequired after books match the venue (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
 			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID whose cash-reconcile latch to clear", Required: true},
 		}},
+		{Name: commandPrefix + "adjust", Description: "Change a strategy's capital, max drawdown, or theta harvest (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "field", Description: "capital, max_drawdown_pct, or theta_harvest.<enabled|profit_target_pct|stop_loss_pct|min_dte_close>", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "value", Description: "New value", Required: true},
+		}},
+		{Name: commandPrefix + "mute", Description: "Mute a strategy's trade alerts; it stays in summaries and risk alerts (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID to mute", Required: true},
+		}},
+		{Name: commandPrefix + "unmute", Description: "Unmute a strategy's trade alerts (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID to unmute", Required: true},
+		}},
+		{Name: commandPrefix + "harvest", Description: "Override theta harvest thresholds for one open option position (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Options strategy ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "position", Description: "Option position ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "field", Description: "enabled, profit_target_pct, stop_loss_pct, min_dte_close, trailing_trigger_pct, trailing_retrace_pct, or clear", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "value", Description: "New value (ignored for clear)"},
+		}},
+		{Name: commandPrefix + "note", Description: "Attach a key=value note to an open position (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "position", Description: "Position symbol, or option position ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "key", Description: "Metadata key (e.g. thesis, hedge_for)", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "value", Description: "Value; omit to remove the key"},
+		}},
+		{Name: commandPrefix + "position", Description: "Register or correct a position in state with an audit trade (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "symbol", Description: "Position symbol", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "qty", Description: "Target quantity; 0 removes the position", Required: true},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "avg_cost", Description: "Target average cost (required unless qty is 0)"},
+			{Type: discordgo.ApplicationCommandOptionString, Name: "side", Description: "long or short (default: keep, or long when new)"},
+		}},
+		{Name: commandPrefix + "run", Description: "Run a strategy on the next tick, ignoring its interval (owner DM only)", Contexts: dmContext(), Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "strategy", Description: "Strategy ID to run", Required: true},
+		}},
 	}
 }
 
@@ -448,17 +488,18 @@ func slashCommands() []*discordgo.ApplicationCommand {
 // interaction handler, and registers commands globally. Non-fatal on failure: the
 // caller logs/DMs and the daemon keeps running.
 func (d *DiscordNotifier) RegisterSlashCommands(ss *StatusServer, cfg *Config) error {
-	if d == nil || d.session == nil {
+	if d == nil || d.currentSession() == nil {
 		return fmt.Errorf("discord session not initialized")
 	}
-	if d.session.State == nil || d.session.State.User == nil {
+	session := d.currentSession()
+	if session.State == nil || session.State.User == nil {
 		return fmt.Errorf("discord gateway not ready (no application identity)")
 	}
 	d.ss = ss
 	d.cfg = cfg
-	d.session.AddHandler(d.interactionCreate)
-	appID := d.session.State.User.ID
-	if _, err := d.session.ApplicationCommandBulkOverwrite(appID, "", slashCommands()); err != nil {
+	session.AddHandler(d.interactionCreate)
+	appID := session.State.User.ID
+	if _, err := session.ApplicationCommandBulkOverwrite(appID, "", slashCommands()); err != nil {
 		return fmt.Errorf("bulk overwrite commands: %w", err)
 	}
 	return nil
@@ -534,6 +575,20 @@ func (d *DiscordNotifier) interactionCreate(s *discordgo.Session, i *discordgo.I
 		d.handleApplyRegimeGate(s, i, data.Options)
 	case "clear-cash-reconcile":
 		d.handleClearCashReconcile(s, i, data.Options)
+	case "adjust":
+		d.handleAdjust(s, i, data.Options)
+	case "run":
+		d.handleRunStrategy(s, i, data.Options)
+	case "mute":
+		d.handleMute(s, i, data.Options, true)
+	case "unmute":
+		d.handleMute(s, i, data.Options, false)
+	case "harvest":
+		d.handleHarvestOverride(s, i, data.Options)
+	case "note":
+		d.handleNote(s, i, data.Options)
+	case "position":
+		d.handlePositionAdjust(s, i, data.Options)
 	default:
 		respondEphemeral(s, i, "unknown command")
 	}
@@ -642,15 +697,14 @@ func (d *DiscordNotifier) respondReadOnlyDeferred(s *discordgo.Session, i *disco
 	})
 }
 
-// buildReadOnly runs a (state, prices) builder under RLock with live prices.
+// buildReadOnly runs a (state, prices) builder on the readState snapshot
+// (#4919) with live prices.
 func (d *DiscordNotifier) buildReadOnly(fn func(*AppState, map[string]float64) string) string {
 	if d.ss == nil {
 		return "status server not wired"
 	}
 	prices := d.ss.fetchLiveMarkPrices() // must run without holding mu
-	d.ss.mu.RLock()
-	defer d.ss.mu.RUnlock()
-	return fn(d.ss.state, prices)
+	return fn(d.ss.readState(), prices)
 }
 
 // buildDiscordStatus is the /status slash-command builder: portfolio summary plus
@@ -664,8 +718,12 @@ func (d *DiscordNotifier) buildDiscordStatus() string {
 	defer d.ss.mu.RUnlock()
 	base := formatStatusResponse(d.ss.state, prices)
 	base += pausedStrategiesNote(d.cfg.Strategies)
+	base += mutedStrategiesNote(d.cfg.Strategies, d.ss.state)
 	base += dailyLossStatusNote(d.cfg.PortfolioRisk, d.ss.state.Strategies, time.Now())
 	base += exposureCapStatusNote(d.cfg.PortfolioRisk, d.ss.state, d.cfg.Strategies, prices)
+	if line := portfolioVaRLine(d.ss.state.PortfolioRisk.VaR); line != "" {
+		base += "\n📉 " + line
+	}
 	base += recentRegimeTransitionsNote(d.ss.stateDB, d.cfg.Regime, time.Now())
 	if note := directionalCertOperatorNotes(d.cfg.Strategies, d.cfg.Regime); note != "" {
 		return base + note
@@ -693,11 +751,8 @@ func (d *DiscordNotifier) buildHealth() string {
 	if d.ss == nil {
 		return "status server not wired"
 	}
-	d.ss.mu.RLock()
-	lastCycle := d.ss.state.LastCycle
-	cycles := d.ss.state.CycleCount
-	d.ss.mu.RUnlock()
-	return formatHealthResponse(lastCycle, cycles, Version, time.Now())
+	state := d.ss.readState()
+	return formatHealthResponse(state.LastCycle, state.CycleCount, Version, time.Now())
 }
 
 func (d *DiscordNotifier) buildPnL() string {
@@ -705,9 +760,7 @@ func (d *DiscordNotifier) buildPnL() string {
 		return "status server not wired"
 	}
 	prices := d.ss.fetchLiveMarkPrices()
-	d.ss.mu.RLock()
-	defer d.ss.mu.RUnlock()
-	return formatPnLResponse(d.ss.state, prices)
+	return formatPnLResponse(d.ss.readState(), prices)
 }
 
 func (d *DiscordNotifier) buildLeaderboard(topN int) string {
@@ -725,9 +778,7 @@ func (d *DiscordNotifier) buildCircuitBreakers() string {
 	if d.ss == nil {
 		return "status server not wired"
 	}
-	d.ss.mu.RLock()
-	defer d.ss.mu.RUnlock()
-	return formatCircuitBreakersResponse(d.ss.state, time.Now())
+	return formatCircuitBreakersResponse(d.ss.readState(), time.Now())
 }
 
 func (d *DiscordNotifier) buildDeadStrategies() string {
@@ -735,18 +786,14 @@ func (d *DiscordNotifier) buildDeadStrategies() string {
 		return "status server not wired"
 	}
 	lifetime := d.lifetimeStats()
-	d.ss.mu.RLock()
-	defer d.ss.mu.RUnlock()
-	return formatDeadStrategiesResponse(d.ss.state, lifetime)
+	return formatDeadStrategiesResponse(d.ss.readState(), lifetime)
 }
 
 func (d *DiscordNotifier) buildCorrelation() string {
 	if d.ss == nil {
 		return "status server not wired"
 	}
-	d.ss.mu.RLock()
-	defer d.ss.mu.RUnlock()
-	return formatCorrelationResponse(d.ss.state.CorrelationSnapshot)
+	return formatCorrelationResponse(d.ss.readState().CorrelationSnapshot)
 }
 
 // handleClosingStrategies answers /closing-strategies (#1203) with the full
@@ -832,6 +879,28 @@ func (d *DiscordNotifier) handleRestart(s *discordgo.Session, i *discordgo.Inter
 	}()
 }
 
+// handleRunStrategy schedules one strategy for the next loop iteration
+// regardless of its interval (#4941), e.g. after fixing its script. The run
+// goes through the normal cycle — kill switch, risk gate and circuit breakers
+// still apply — and the strategy's regular cadence is unchanged.
+func (d *DiscordNotifier) handleRunStrategy(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	id := optionString(opts, "strategy", "")
+	if id == "" {
+		respondText(s, i, "usage: /go-trader-run <strategy>")
+		return
+	}
+	if d.ss == nil {
+		respondText(s, i, "status server not ready")
+		return
+	}
+	if err := d.ss.requestRunNow(id); err != nil {
+		respondText(s, i, "run failed: "+err.Error())
+		return
+	}
+	fmt.Printf("[discord] /run %s: scheduled for the next tick\n", id)
+	respondText(s, i, fmt.Sprintf("Scheduled `%s` for the next tick. Risk gates still apply; its regular interval is unchanged.", id))
+}
+
 // handleBacktest runs run_backtest.py and replies with a summary plus the full report file.
 func (d *DiscordNotifier) handleBacktest(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
 	strategy := optionString(data.Options, "strategy", "")
@@ -840,8 +909,8 @@ func (d *DiscordNotifier) handleBacktest(s *discordgo.Session, i *discordgo.Inte
 	deferAck(s, i)
 
 	args := []string{"--strategy", strategy, "--symbol", symbol, "--timeframe", timeframe, "--mode", "single"}
-	// Holds one of the 4 pythonSemaphore slots (executor.go) for up to 5 min —
-	// i.e. 25% of the Python concurrency the trading loop shares. Acceptable
+	// Holds one pythonSemaphore slot (python_pool.go) for up to 5 min — 25% of
+	// the default Python concurrency the trading loop shares. Acceptable
 	// because /backtest is owner-gated and can't be spammed by guild members.
 	stdout, stderr, err := runPythonWithTimeout(shutdownReadOnlyCtx, "backtest/run_backtest.py", args, nil, 5*time.Minute)
 	report := string(stdout)
@@ -44,6 +44,14 @@ func TestAuthorizeCommand(t *testing.T) {
 		{"clear-cash-reconcile", owner, "", true},
 		{"clear-cash-reconcile", owner, "guild1", false},
 		{"clear-cash-reconcile", "intruder", "", false},
+		// #4941 run: owner-DM only (off-schedule strategy run).
+		{"run", owner, "", true},
+		{"run", owner, "guild1", false},
+		{"run", "intruder", "", false},
+		// #4942 adjust: owner-DM only (capital/drawdown/theta config writes).
+		{"adjust", owner, "", true},
+		{"adjust", owner, "guild1", false},
+		{"adjust", "intruder", "", false},
 		{"unknown", owner, "", false}, // unknown command rejected
 	}
 	for _, c := range cases {
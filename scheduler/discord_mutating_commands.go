@@ -8,7 +8,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -45,10 +44,11 @@ const configSecretReplacement = "***redacted***"
 // ---------------------------------------------------------------------------
 
 // redactConfigForDisplay parses a config file's JSON and replaces secret-bearing
-// fields with a placeholder so the result is safe to post in a DM. Only the
-// Discord/Telegram token fields are persisted in the config file; platform API
-// keys and the status token live in the environment (StatusToken is json:"-"),
-// never on disk. Returns indented JSON.
+// fields with a placeholder so the result is safe to post in a DM. The
+// Discord/Telegram token fields and per-strategy env values (#4981) are the
+// secrets persisted in the config file; platform API keys and the status
+// token live in the environment (StatusToken is json:"-"), never on disk.
+// Returns indented JSON.
 func redactConfigForDisplay(raw []byte) (string, error) {
 	var root map[string]json.RawMessage
 	if err := json.Unmarshal(raw, &root); err != nil {
@@ -56,6 +56,7 @@ func redactConfigForDisplay(raw []byte) (string, error) {
 	}
 	redactSectionKeys(root, "discord", "token", "report_github_token")
 	redactSectionKeys(root, "telegram", "bot_token")
+	redactStrategyEnvJSON(root)
 	out, err := json.MarshalIndent(root, "", "  ")
 	if err != nil {
 		return "", err
@@ -104,6 +105,12 @@ func redactSectionKeys(root map[string]json.RawMessage, section string, keys ...
 func configStrategies(root map[string]json.RawMessage) ([]json.RawMessage, error) {
 	raw, ok := root["strategies"]
 	if !ok {
+		// All strategies may live in include fragments; writers then see an
+		// empty root list (lookups fail via configStrategyNotFound, adds
+		// create the root array).
+		if _, inc := root[configIncludeKey]; inc {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("config has no strategies array")
 	}
 	var list []json.RawMessage
@@ -265,7 +272,7 @@ func removeStrategyFromRoot(root map[string]json.RawMessage, id string) error {
 		}
 	}
 	if idx < 0 {
-		return fmt.Errorf("strategy %q not found", id)
+		return configStrategyNotFound(root, id)
 	}
 	if len(list) == 1 {
 		return fmt.Errorf("refusing to remove the only strategy %q — a config must keep at least one strategy", id)
@@ -300,7 +307,7 @@ func flipStrategyToLive(root map[string]json.RawMessage, id string) (before, aft
 		}
 	}
 	if idx < 0 {
-		return nil, nil, fmt.Errorf("strategy %q not found", id)
+		return nil, nil, configStrategyNotFound(root, id)
 	}
 	var item map[string]json.RawMessage
 	if err := json.Unmarshal(list[idx], &item); err != nil {
@@ -593,7 +600,7 @@ func writeValidatedConfigRoot(configPath string, root map[string]json.RawMessage
 // asynchronously on the main loop; an incompatible change is rejected there and
 // the running config is kept (the file change then applies on the next restart).
 func requestSIGHUPReload() error {
-	return syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	return raiseSelfSignal(reloadSignal)
 }
 
 // ---------------------------------------------------------------------------
@@ -884,6 +891,7 @@ func (d *DiscordNotifier) handleClearCashReconcile(s *discordgo.Session, i *disc
 		saveErr = SaveStateWithDB(d.ss.state, d.cfg, d.ss.stateDB)
 	}
 	d.ss.mu.Unlock()
+	d.ss.snapshot.expire()
 
 	if err != nil {
 		followupText(s, i, err.Error())
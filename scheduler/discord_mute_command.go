@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// #4951: per-strategy trade-alert mute.
+//
+// A muted strategy's per-trade DM/channel alerts (sendTradeAlerts) are
+// skipped. Everything else is unchanged: it keeps its summary table row and
+// trade lines, and risk alerts (circuit breaker, kill switch, stale data,
+// execution failures) still fire. A strategy is muted when its config sets
+// mute_trade_alerts, or when the owner muted it with /go-trader-mute. The
+// runtime set lives in AppState.MutedStrategies and is saved immediately, so
+// a restart doesn't unmute it. /go-trader-unmute clears only the runtime
+// mute; a config mute stays until the config changes.
+
+// tradeAlertsMuted reports whether sc's trade alerts are muted. Caller holds
+// mu (read is enough).
+func tradeAlertsMuted(sc StrategyConfig, state *AppState) bool {
+	if sc.MuteTradeAlerts {
+		return true
+	}
+	if state == nil {
+		return false
+	}
+	_, ok := state.MutedStrategies[sc.ID]
+	return ok
+}
+
+// sendTradeAlertsUnlessMuted is sendTradeAlerts gated on tradeAlertsMuted.
+// Called without mu held, like sendTradeAlerts.
+func sendTradeAlertsUnlessMuted(sc StrategyConfig, stratState *StrategyState, trades int, state *AppState, mu *sync.RWMutex, notifier *MultiNotifier) {
+	mu.RLock()
+	muted := tradeAlertsMuted(sc, state)
+	mu.RUnlock()
+	if muted {
+		return
+	}
+	sendTradeAlerts(sc, stratState, trades, mu, notifier)
+}
+
+// setStrategyMuted sets or clears the runtime mute for id. It returns whether
+// anything changed. Unknown strategies are an error. Caller holds mu.
+func setStrategyMuted(state *AppState, id string, muted bool, now time.Time) (bool, error) {
+	if _, ok := state.Strategies[id]; !ok {
+		return false, fmt.Errorf("unknown strategy %q", id)
+	}
+	_, was := state.MutedStrategies[id]
+	if muted == was {
+		return false, nil
+	}
+	if muted {
+		if state.MutedStrategies == nil {
+			state.MutedStrategies = make(map[string]time.Time)
+		}
+		state.MutedStrategies[id] = now.UTC()
+	} else {
+		delete(state.MutedStrategies, id)
+	}
+	return true, nil
+}
+
+// mutedStrategiesNote lists strategies with muted trade alerts for /status.
+// Empty string when none are muted. IDs are sorted for stable output.
+func mutedStrategiesNote(strategies []StrategyConfig, state *AppState) string {
+	var muted []string
+	for _, sc := range strategies {
+		if tradeAlertsMuted(sc, state) {
+			muted = append(muted, sc.ID)
+		}
+	}
+	if len(muted) == 0 {
+		return ""
+	}
+	sort.Strings(muted)
+	return fmt.Sprintf("\n🔇 trade alerts muted: %s", strings.Join(muted, ", "))
+}
+
+// handleMute serves /go-trader-mute and /go-trader-unmute. The change is
+// saved immediately so a restart before the next cycle keeps it.
+func (d *DiscordNotifier) handleMute(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption, muted bool) {
+	verb := "mute"
+	if !muted {
+		verb = "unmute"
+	}
+	id := optionString(opts, "strategy", "")
+	if id == "" {
+		respondText(s, i, fmt.Sprintf("usage: /go-trader-%s <strategy>", verb))
+		return
+	}
+	if d.ss == nil || d.ss.state == nil || d.ss.mu == nil {
+		respondText(s, i, "status server not ready")
+		return
+	}
+
+	var configMuted bool
+	if d.cfg != nil {
+		for _, sc := range d.cfg.Strategies {
+			if sc.ID == id {
+				configMuted = sc.MuteTradeAlerts
+				break
+			}
+		}
+	}
+
+	d.ss.mu.Lock()
+	changed, err := setStrategyMuted(d.ss.state, id, muted, time.Now())
+	var saveErr error
+	if err == nil && changed && d.ss.stateDB != nil {
+		saveErr = SaveStateWithDB(d.ss.state, d.cfg, d.ss.stateDB)
+	}
+	d.ss.mu.Unlock()
+	d.ss.snapshot.expire()
+
+	if err != nil {
+		respondText(s, i, verb+" failed: "+err.Error())
+		return
+	}
+	var msg string
+	switch {
+	case muted && changed:
+		msg = fmt.Sprintf("🔇 Muted trade alerts for `%s`. It stays in summaries and risk alerts.", id)
+	case muted:
+		msg = fmt.Sprintf("Trade alerts for `%s` are already muted.", id)
+	case changed:
+		msg = fmt.Sprintf("🔔 Unmuted trade alerts for `%s`.", id)
+	default:
+		msg = fmt.Sprintf("`%s` has no runtime mute to clear.", id)
+	}
+	if !muted && configMuted {
+		msg += " Note: its config sets `mute_trade_alerts`, so alerts stay muted until that is removed."
+	}
+	if saveErr != nil {
+		msg += " WARNING: SaveState failed (" + saveErr.Error() + "); the change may not survive a restart before the next successful save."
+	}
+	fmt.Printf("[discord] /%s %s: changed=%t\n", verb, id, changed)
+	respondText(s, i, msg)
+}
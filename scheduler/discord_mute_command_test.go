@@ -0,0 +1,87 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetStrategyMuted(t *testing.T) {
+	state := &AppState{Strategies: map[string]*StrategyState{"hl-a-btc": {ID: "hl-a-btc"}}}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := setStrategyMuted(state, "nope", true, now); err == nil {
+		t.Fatal("unknown strategy should error")
+	}
+	changed, err := setStrategyMuted(state, "hl-a-btc", true, now)
+	if err != nil || !changed {
+		t.Fatalf("mute: changed=%v err=%v", changed, err)
+	}
+	if got := state.MutedStrategies["hl-a-btc"]; !got.Equal(now) {
+		t.Errorf("muted at = %v, want %v", got, now)
+	}
+	if changed, _ := setStrategyMuted(state, "hl-a-btc", true, now); changed {
+		t.Error("re-muting should be a no-op")
+	}
+	if changed, _ := setStrategyMuted(state, "hl-a-btc", false, now); !changed {
+		t.Error("unmute should report a change")
+	}
+	if _, ok := state.MutedStrategies["hl-a-btc"]; ok {
+		t.Error("unmute should clear the entry")
+	}
+}
+
+func TestTradeAlertsMuted_ConfigOrRuntime(t *testing.T) {
+	state := &AppState{MutedStrategies: map[string]time.Time{"b": time.Now()}}
+	strategies := []StrategyConfig{{ID: "a", MuteTradeAlerts: true}, {ID: "b"}, {ID: "c"}}
+	if !tradeAlertsMuted(strategies[0], state) || !tradeAlertsMuted(strategies[1], state) || tradeAlertsMuted(strategies[2], state) {
+		t.Error("want a (config) and b (runtime) muted, c not")
+	}
+	if tradeAlertsMuted(strategies[2], nil) {
+		t.Error("nil state must not mute")
+	}
+	if note := mutedStrategiesNote(strategies, state); note != "\n🔇 trade alerts muted: a, b" {
+		t.Errorf("note = %q", note)
+	}
+	if note := mutedStrategiesNote(strategies[2:], state); note != "" {
+		t.Errorf("no mutes should render nothing, got %q", note)
+	}
+}
+
+func TestMutedStrategies_PersistAcrossReload(t *testing.T) {
+	db := openTestDB(t)
+	mutedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &AppState{
+		CycleCount:      1,
+		MutedStrategies: map[string]time.Time{"hl-a-btc": mutedAt},
+		Strategies: map[string]*StrategyState{
+			"hl-a-btc": {ID: "hl-a-btc", Type: "perps", Cash: 1000, InitialCapital: 1000,
+				Positions: make(map[string]*Position), OptionPositions: make(map[string]*OptionPosition), TradeHistory: []Trade{}},
+		},
+	}
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := loaded.MutedStrategies["hl-a-btc"]; !ok || !got.Equal(mutedAt) {
+		t.Errorf("MutedStrategies after reload = %v, want hl-a-btc at %v", loaded.MutedStrategies, mutedAt)
+	}
+}
+
+func TestSendTradeAlertsUnlessMuted_SkipsMuted(t *testing.T) {
+	sc := StrategyConfig{ID: "hl-a-btc", Platform: "hyperliquid", Type: "perps", MuteTradeAlerts: true}
+	ss := &StrategyState{TradeHistory: []Trade{{Symbol: "BTC", Side: "buy", Quantity: 1, Price: 50000}}}
+	state := &AppState{Strategies: map[string]*StrategyState{sc.ID: ss}}
+	// A nil notifier would panic inside sendTradeAlerts; a muted strategy must
+	// return before reaching it.
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("muted strategy reached sendTradeAlerts: %v", r)
+		}
+	}()
+	var mu sync.RWMutex
+	sendTradeAlertsUnlessMuted(sc, ss, 1, state, &mu, nil)
+}
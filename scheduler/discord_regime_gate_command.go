@@ -401,7 +401,7 @@ func applyRegimeGateToRoot(root map[string]json.RawMessage, strategyID string, p
 		}
 	}
 	if idx < 0 {
-		return fmt.Errorf("strategy %q not found", strategyID)
+		return configStrategyNotFound(root, strategyID)
 	}
 
 	var item map[string]json.RawMessage
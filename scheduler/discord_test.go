@@ -182,7 +182,7 @@ func TestFormatCategorySummary_WithAsset(t *testing.T) {
 	prices := map[string]float64{"BTC/USDT": 50000, "ETH/USDT": 3000}
 
 	// With asset — title should contain " — BTC" and only BTC price shown
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 	if !strings.Contains(msg, "— BTC") {
 		t.Errorf("expected '— BTC' in title, got:\n%s", msg)
@@ -192,7 +192,7 @@ func TestFormatCategorySummary_WithAsset(t *testing.T) {
 	}
 
 	// Without asset — no suffix in title
-	msgs2 := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil)
+	msgs2 := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, nil)
 	msg2 := strings.Join(msgs2, "\n")
 	if strings.Contains(msg2, "— ") {
 		t.Errorf("expected no asset suffix when asset='', got:\n%s", msg2)
@@ -218,20 +218,20 @@ func TestFormatCategorySummary_VersionSuffix(t *testing.T) {
 	defer func() { Version = orig }()
 
 	Version = "v9.9.9-test"
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	summary := strings.Join(msgs, "\n")
 	if !strings.Contains(summary, Version) {
 		t.Errorf("expected version %q in summary title, got:\n%s", Version, summary)
 	}
 
-	msgs = FormatCategorySummary(1, 0, 1, 3, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs = FormatCategorySummary(1, 0, 1, 3, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	trades := strings.Join(msgs, "\n")
 	if !strings.Contains(trades, Version) {
 		t.Errorf("expected version %q in trades title, got:\n%s", Version, trades)
 	}
 
 	Version = ""
-	msgs = FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs = FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	empty := strings.Join(msgs, "\n")
 	if strings.Contains(empty, "()") {
 		t.Errorf("empty Version should omit the suffix, got:\n%s", empty)
@@ -257,7 +257,7 @@ func TestFormatCategorySummary_CircuitBreakerActive(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, "Circuit breaker active") {
@@ -291,7 +291,7 @@ func TestFormatCategorySummary_StrategiesSortedByID(t *testing.T) {
 		},
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
-	msgs := FormatCategorySummary(1, 0, 1, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 	idxAdx := strings.Index(msg, "hl-adx-btc")
 	idxZebra := strings.Index(msg, "hl-zebra-btc")
@@ -314,7 +314,7 @@ func TestFormatCategorySummary_NoCircuitBreaker(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if strings.Contains(msg, "Circuit breaker") {
@@ -631,7 +631,7 @@ func TestFormatCategorySummary_TfIntColumn(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 3600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 3600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	// Separate Tf and Int column headers should be present (at end of table).
@@ -656,7 +656,7 @@ func TestFormatCategorySummary_TfIntGlobalFallback(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "spot", "", 3600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "spot", "", 3600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	// No timeframe for spot → "—"; global interval 3600s → "1h". Separate columns now.
@@ -680,7 +680,7 @@ func TestFormatCategorySummary_StrategyLabelWidthAndTieredAliases(t *testing.T)
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, "hl-123456789012345") {
@@ -709,7 +709,7 @@ func TestFormatCategorySummary_MaxDrawdownColumn(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 50000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, " DD ") {
@@ -741,7 +741,7 @@ func TestFormatCategorySummary_MaxDrawdownColumn_SharedWallet(t *testing.T) {
 	}
 	prices := map[string]float64{"ETH/USDT": 3000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 	lines := strings.Split(msg, "\n")
 	var headerLine, totalLine string
@@ -792,7 +792,7 @@ func TestFormatCategorySummary_ClosedTradesColumn(t *testing.T) {
 		"hl-mom-btc": {PositionsOpened: 0},
 	}
 
-	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, lifetime, nil)
+	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, lifetime, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	// Header should include #T column.
@@ -850,7 +850,7 @@ func TestFormatCategorySummary_ClosedTradesColumn_SharedWallet(t *testing.T) {
 		"hl-tema-eth": {PositionsOpened: 9},
 	}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, lifetime, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, lifetime, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, "#T") {
@@ -928,7 +928,7 @@ func TestFormatCategorySummary_WinLossColumn(t *testing.T) {
 		"hl-mom-btc": {PositionsOpened: 0, Wins: 0, Losses: 0},
 	}
 
-	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, lifetime, nil)
+	msgs := FormatCategorySummary(1, 0, 3, 0, 3000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, lifetime, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, "W/L") {
@@ -982,7 +982,7 @@ func TestFormatCategorySummary_SharedWallet(t *testing.T) {
 	}
 	prices := map[string]float64{"ETH/USDT": 3000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	// Should contain Wallet% column
@@ -1035,7 +1035,7 @@ func TestFormatCategorySummary_WalletPctFromConfig(t *testing.T) {
 	}
 	prices := map[string]float64{"ETH/USDT": 3000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if !strings.Contains(msg, "30.0%") {
@@ -1060,7 +1060,7 @@ func TestFormatCategorySummary_NoSharedWallet(t *testing.T) {
 	}
 	prices := map[string]float64{"ETH/USDT": 3000}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	if strings.Contains(msg, "Wallet%") {
@@ -1091,7 +1091,7 @@ func TestFormatCategorySummary_MessageSplitting(t *testing.T) {
 	state := &AppState{Strategies: strategies}
 	prices := map[string]float64{"BTC/USDT": 51000}
 
-	msgs := FormatCategorySummary(1, 0, 20, 0, 10000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 20, 0, 10000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 
 	// Should produce multiple messages.
 	if len(msgs) < 2 {
@@ -1160,7 +1160,7 @@ func TestFormatCategorySummary_NoSplitWhenShort(t *testing.T) {
 	}
 	prices := map[string]float64{"BTC/USDT": 51000}
 
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 
 	if len(msgs) != 1 {
 		t.Errorf("expected single message for 1 position, got %d", len(msgs))
@@ -1847,7 +1847,7 @@ func TestFormatCategorySummary_HeaderPriceFormat(t *testing.T) {
 	}
 	prices := map[string]float64{"ETH/USDT": 2240.5}
 
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 	if !strings.Contains(msg, "ETH: $2,240.50") {
 		t.Errorf("expected header price 'ETH: $2,240.50', got:\n%s", msg)
@@ -1875,7 +1875,7 @@ func TestFormatCategorySummary_RegimePriceLine741(t *testing.T) {
 	prices := map[string]float64{"ETH/USDT": 2277.25}
 	regimeOn := &RegimeConfig{Enabled: true, Period: 14, ADXThreshold: 20}
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, regimeOn)
+	msgs := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, regimeOn, nil)
 	msg := strings.Join(msgs, "\n")
 	if !strings.Contains(msg, "ETH: $2,277.25 | trending_down") {
 		t.Errorf("expected regime suffix on single ETH price segment, got:\n%s", msg)
@@ -1884,7 +1884,7 @@ func TestFormatCategorySummary_RegimePriceLine741(t *testing.T) {
 		t.Errorf("expected exactly one trending_down on price line, got:\n%s", msg)
 	}
 
-	msgsOff := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgsOff := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	msgOff := strings.Join(msgsOff, "\n")
 	if !strings.Contains(msgOff, "ETH: $2,277.25") || strings.Contains(msgOff, "trending_down") {
 		t.Errorf("expected price line without regime when cfg.regime nil, got:\n%s", msgOff)
@@ -1892,7 +1892,7 @@ func TestFormatCategorySummary_RegimePriceLine741(t *testing.T) {
 
 	state.Strategies["hl-a-eth"].Regime = ""
 	state.Strategies["hl-b-eth"].Regime = ""
-	msgsEmpty := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, regimeOn)
+	msgsEmpty := FormatCategorySummary(1, 0, 2, 0, 2000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, regimeOn, nil)
 	msgEmpty := strings.Join(msgsEmpty, "\n")
 	if !strings.Contains(msgEmpty, "ETH: $2,277.25") || strings.Contains(msgEmpty, "ETH: $2,277.25 |") {
 		t.Errorf("expected price line without regime suffix when labels empty, got:\n%s", msgEmpty)
@@ -1956,7 +1956,7 @@ func TestFormatCategorySummary_LargeTableChunked(t *testing.T) {
 	state := &AppState{Strategies: strategies}
 	prices := map[string]float64{"BTC/USDT": 51000}
 
-	msgs := FormatCategorySummary(1, 0, stratCount, 0, 14000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, stratCount, 0, 14000, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 
 	if len(msgs) < 2 {
 		t.Fatalf("expected at least 2 messages for %d strategies, got %d", stratCount, len(msgs))
@@ -2307,7 +2307,7 @@ func TestFormatCategorySummary_LifetimeStatsOverride(t *testing.T) {
 	lifetime := map[string]LifetimeTradeStats{
 		"hl-rmc-eth-live": {PositionsOpened: 17, Wins: 10, Losses: 7},
 	}
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, lifetime, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, lifetime, nil, nil)
 	if len(msgs) == 0 {
 		t.Fatal("expected at least one message")
 	}
@@ -2337,12 +2337,12 @@ func TestFormatCategorySummary_LifetimeStatsNoFallback(t *testing.T) {
 		},
 	}
 	// Nil map, such as a DB query failure, renders zero lifetime stats.
-	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, nil, nil, nil)
 	if !strings.Contains(msgs[0], " 0     —") {
 		t.Errorf("expected zero #T/W-L without lifetime stats, got:\n%s", msgs[0])
 	}
 	// Empty map (DB returned no rows for this strategy) also renders zero.
-	msgs2 := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, map[string]LifetimeTradeStats{}, nil)
+	msgs2 := FormatCategorySummary(1, 0, 1, 0, 1000, prices, nil, strats, state, "hyperliquid", "ETH", 600, 0, map[string]LifetimeTradeStats{}, nil, nil)
 	if !strings.Contains(msgs2[0], " 0     —") {
 		t.Errorf("expected zero #T/W-L from empty lifetime stats map, got:\n%s", msgs2[0])
 	}
@@ -2896,7 +2896,7 @@ func TestFormatCategorySummary_AdjustedTotalOverridesNaiveSum(t *testing.T) {
 
 	adjustedTotal := 8000.0 // real wallet balance < naive sum
 
-	msgs := FormatCategorySummary(1, 0, 2, 0, adjustedTotal, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil)
+	msgs := FormatCategorySummary(1, 0, 2, 0, adjustedTotal, prices, nil, strats, state, "hyperliquid", "BTC", 600, 0, nil, nil, nil)
 	msg := strings.Join(msgs, "\n")
 
 	// Find the TOTAL row.
@@ -2962,7 +2962,7 @@ func TestFormatCategorySummary_NegativeAdjustedTotalFallsBackToNaiveSum(t *testi
 	}
 
 	// Negative sentinel → fall back to filteredValue (3000+2000=5000).
-	fallbackLine := totalLineOf(FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "spot", "", 600, 0, nil, nil))
+	fallbackLine := totalLineOf(FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "spot", "", 600, 0, nil, nil, nil))
 	if fallbackLine == "" {
 		t.Fatal("no TOTAL row found for negative-sentinel case")
 	}
@@ -2973,7 +2973,7 @@ func TestFormatCategorySummary_NegativeAdjustedTotalFallsBackToNaiveSum(t *testi
 	// Explicit $0 adjustment (drained shared wallet) → TOTAL Value column shows
 	// $0, NOT the inflated naive sum. Header shows aggregate initial capital
 	// $5,000; PnL% -100.0% distinguishes drained value=0 from naive fallback 0.0%.
-	drainedLine := totalLineOf(FormatCategorySummary(1, 0, 2, 0, 0, prices, nil, strats, state, "spot", "", 600, 0, nil, nil))
+	drainedLine := totalLineOf(FormatCategorySummary(1, 0, 2, 0, 0, prices, nil, strats, state, "spot", "", 600, 0, nil, nil, nil))
 	if drainedLine == "" {
 		t.Fatal("no TOTAL row found for $0-adjustment case")
 	}
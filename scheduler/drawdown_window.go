@@ -0,0 +1,96 @@
+package main
+
+import (
+	"time"
+)
+
+// maxDrawdownWindowDays bounds drawdown_window_days for strategies and the
+// portfolio. The portfolio window must fit inside maxPortfolioRiskHistory.
+const maxDrawdownWindowDays = 365
+
+// peakSourceRollingWindow marks a portfolio peak that aged down to the
+// highest daily value inside portfolio_risk.drawdown_window_days.
+const peakSourceRollingWindow = "rolling_window"
+
+// DailyPeak is one UTC day's highest observed strategy value. CheckRisk keeps
+// these in RiskState.PeakHistory only while drawdown_window_days is set.
+type DailyPeak struct {
+	Date  string  `json:"date"` // YYYY-MM-DD (UTC)
+	Value float64 `json:"value"`
+}
+
+// drawdownWindowCutoff returns the last UTC date that falls outside a
+// windowDays lookback ending today; samples dated after it are in the window.
+func drawdownWindowCutoff(now time.Time, windowDays int) string {
+	return now.UTC().AddDate(0, 0, -windowDays).Format("2006-01-02")
+}
+
+// updateStrategyPeak advances RiskState.PeakValue for one CheckRisk cycle.
+// With no window the peak is the all-time high-water mark. With a window the
+// peak is the highest daily value of the last windowDays days. When a
+// window is first enabled, the existing all-time peak seeds today's sample,
+// so it ages out after windowDays instead of vanishing at once.
+func updateStrategyPeak(r *RiskState, windowDays int, value float64, now time.Time) {
+	if windowDays <= 0 {
+		r.PeakHistory = nil
+		if value > r.PeakValue {
+			r.PeakValue = value
+		}
+		return
+	}
+	today := now.UTC().Format("2006-01-02")
+	if len(r.PeakHistory) == 0 && r.PeakValue > value {
+		r.PeakHistory = []DailyPeak{{Date: today, Value: r.PeakValue}}
+	}
+	if n := len(r.PeakHistory); n > 0 && r.PeakHistory[n-1].Date == today {
+		if value > r.PeakHistory[n-1].Value {
+			r.PeakHistory[n-1].Value = value
+		}
+	} else {
+		r.PeakHistory = append(r.PeakHistory, DailyPeak{Date: today, Value: value})
+	}
+	cutoff := drawdownWindowCutoff(now, windowDays)
+	kept := r.PeakHistory[:0]
+	peak := 0.0
+	for _, d := range r.PeakHistory {
+		if d.Date <= cutoff {
+			continue
+		}
+		kept = append(kept, d)
+		if d.Value > peak {
+			peak = d.Value
+		}
+	}
+	r.PeakHistory = kept
+	r.PeakValue = peak
+}
+
+// applyPortfolioDrawdownWindow lowers the portfolio peak to the highest daily
+// value recorded inside the last windowDays days. The all-time peak holds
+// until the recorded history covers the whole window, so enabling the option
+// (or a fresh install) never drops the high-water mark early. Returns true
+// when the peak changed.
+func applyPortfolioDrawdownWindow(prs *PortfolioRiskState, windowDays int, now time.Time) bool {
+	if windowDays <= 0 || len(prs.History) == 0 {
+		return false
+	}
+	cutoff := drawdownWindowCutoff(now, windowDays)
+	if prs.History[0].Date > cutoff {
+		return false
+	}
+	var best PortfolioRiskSample
+	for _, s := range prs.History {
+		if s.Date > cutoff && s.HighValue > best.HighValue {
+			best = s
+		}
+	}
+	if best.HighValue <= 0 || best.HighValue >= prs.PeakValue {
+		return false
+	}
+	prs.PeakValue = best.HighValue
+	prs.PeakSource = peakSourceRollingWindow
+	if t, err := time.Parse("2006-01-02", best.Date); err == nil {
+		prs.PeakAt = t
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateStrategyPeak_AllTime(t *testing.T) {
+	r := RiskState{PeakValue: 1000, PeakHistory: []DailyPeak{{Date: "2026-01-01", Value: 1000}}}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	updateStrategyPeak(&r, 0, 900, now)
+	if r.PeakValue != 1000 || r.PeakHistory != nil {
+		t.Errorf("all-time: %+v", r)
+	}
+	updateStrategyPeak(&r, 0, 1200, now)
+	if r.PeakValue != 1200 {
+		t.Errorf("ratchet: peak = %v", r.PeakValue)
+	}
+}
+
+func TestUpdateStrategyPeak_RollingWindowAgesOut(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// A lucky all-time peak from before the window was enabled.
+	r := RiskState{PeakValue: 2000}
+	updateStrategyPeak(&r, 30, 1000, start)
+	if r.PeakValue != 2000 || len(r.PeakHistory) != 1 {
+		t.Fatalf("enable must seed the existing peak: %+v", r)
+	}
+	for d := 1; d <= 30; d++ {
+		updateStrategyPeak(&r, 30, 1000+float64(d), start.AddDate(0, 0, d))
+	}
+	if r.PeakValue != 1030 {
+		t.Errorf("after 30 days the seeded peak should age out: peak = %v", r.PeakValue)
+	}
+	if len(r.PeakHistory) != 30 {
+		t.Errorf("history len = %d, want 30", len(r.PeakHistory))
+	}
+}
+
+func TestApplyPortfolioDrawdownWindow(t *testing.T) {
+	now := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+	prs := PortfolioRiskState{PeakValue: 50000, History: []PortfolioRiskSample{
+		{Date: "2026-04-01", HighValue: 30000},
+		{Date: "2026-04-08", HighValue: 28000},
+	}}
+	// History does not reach back 30 days yet: keep the all-time peak.
+	if applyPortfolioDrawdownWindow(&prs, 30, now) || prs.PeakValue != 50000 {
+		t.Fatalf("partial history lowered peak: %+v", prs)
+	}
+	// Covered 7-day window: cutoff 2026-04-03, only the 04-08 sample counts.
+	if !applyPortfolioDrawdownWindow(&prs, 7, now) || prs.PeakValue != 28000 || prs.PeakSource != peakSourceRollingWindow {
+		t.Errorf("rolling peak = %+v", prs)
+	}
+	if applyPortfolioDrawdownWindow(&prs, 0, now) {
+		t.Error("window 0 must be a no-op")
+	}
+}
+
+func TestValidateConfig_DrawdownWindowDays(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		PortfolioRisk:   &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60, DrawdownWindowDays: 400},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10, DrawdownWindowDays: -1,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"portfolio_risk.drawdown_window_days", "sma-btc", "drawdown_window_days must be in [0, 365]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
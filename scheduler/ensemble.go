@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ensemble consolidation rules (#4924).
+const (
+	ensembleRuleMajority = "majority"
+	ensembleRuleWeighted = "weighted"
+)
+
+// ensembleStaleIntervals is how many of a voter's own check intervals its
+// last vote stays valid for. Older votes count as abstentions.
+const ensembleStaleIntervals = 2
+
+// EnsembleConfig groups strategies trading the same asset (#4924). Members
+// only vote; the executor votes too and is the single strategy that trades
+// the consolidated signal, so conflicting bots stop trading against each
+// other.
+type EnsembleConfig struct {
+	ID        string             `json:"id"`
+	Executor  string             `json:"executor"`            // strategy ID that trades the consolidated signal
+	Members   []string           `json:"members"`             // voting-only strategy IDs (same platform, type and symbol as executor)
+	Rule      string             `json:"rule,omitempty"`      // "majority" (default) or "weighted"
+	Weights   map[string]float64 `json:"weights,omitempty"`   // weighted rule: per-strategy vote weight (default 1)
+	Threshold *float64           `json:"threshold,omitempty"` // weighted rule: |score| needed to act, in [0, 1) (default 0)
+}
+
+// ensembleRule returns the normalized consolidation rule.
+func (e EnsembleConfig) ensembleRule() string {
+	if e.Rule == "" {
+		return ensembleRuleMajority
+	}
+	return e.Rule
+}
+
+// voters returns the executor followed by the members.
+func (e EnsembleConfig) voters() []string {
+	return append([]string{e.Executor}, e.Members...)
+}
+
+// ensembleSymbol is the asset a strategy trades for ensemble grouping.
+func ensembleSymbol(sc StrategyConfig) string {
+	if len(sc.Args) < 2 {
+		return ""
+	}
+	return sc.Args[1]
+}
+
+// ensembleErrors validates top-level ensembles (#4924).
+func ensembleErrors(ensembles []EnsembleConfig, strategies []StrategyConfig) []string {
+	if len(ensembles) == 0 {
+		return nil
+	}
+	byID := make(map[string]StrategyConfig, len(strategies))
+	for _, sc := range strategies {
+		byID[sc.ID] = sc
+	}
+	var errs []string
+	seenEnsemble := make(map[string]bool, len(ensembles))
+	owner := make(map[string]string)
+	for i, e := range ensembles {
+		label := e.ID
+		if label == "" {
+			label = fmt.Sprintf("#%d", i)
+			errs = append(errs, fmt.Sprintf("ensembles[%d]: id is required", i))
+		} else if seenEnsemble[e.ID] {
+			errs = append(errs, fmt.Sprintf("ensemble[%s]: duplicate id", e.ID))
+		}
+		seenEnsemble[e.ID] = true
+
+		switch e.ensembleRule() {
+		case ensembleRuleMajority:
+			if len(e.Weights) > 0 || e.Threshold != nil {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: weights/threshold require rule %q", label, ensembleRuleWeighted))
+			}
+		case ensembleRuleWeighted:
+			if e.Threshold != nil && (*e.Threshold < 0 || *e.Threshold >= 1) {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: threshold must be in [0, 1), got %g", label, *e.Threshold))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("ensemble[%s]: rule must be %q or %q, got %q", label, ensembleRuleMajority, ensembleRuleWeighted, e.Rule))
+		}
+
+		if len(e.Members) == 0 {
+			errs = append(errs, fmt.Sprintf("ensemble[%s]: members must list at least one strategy", label))
+		}
+		exec, ok := byID[e.Executor]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("ensemble[%s]: executor references unknown strategy %q", label, e.Executor))
+		} else if exec.Type == "options" {
+			errs = append(errs, fmt.Sprintf("ensemble[%s]: options strategies cannot join an ensemble", label))
+		}
+		inEnsemble := make(map[string]bool)
+		for _, id := range e.voters() {
+			if inEnsemble[id] {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: strategy %q listed more than once", label, id))
+				continue
+			}
+			inEnsemble[id] = true
+			if prev, taken := owner[id]; taken {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: strategy %q already belongs to ensemble %q", label, id, prev))
+			} else {
+				owner[id] = label
+			}
+			if id == e.Executor {
+				continue
+			}
+			sc, ok := byID[id]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: members references unknown strategy %q", label, id))
+				continue
+			}
+			if exec.ID != "" && (sc.Platform != exec.Platform || sc.Type != exec.Type || ensembleSymbol(sc) != ensembleSymbol(exec)) {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: member %q trades %s/%s %s but executor %q trades %s/%s %s",
+					label, id, sc.Platform, sc.Type, ensembleSymbol(sc), exec.ID, exec.Platform, exec.Type, ensembleSymbol(exec)))
+			}
+		}
+		weightIDs := make([]string, 0, len(e.Weights))
+		for id := range e.Weights {
+			weightIDs = append(weightIDs, id)
+		}
+		sort.Strings(weightIDs)
+		for _, id := range weightIDs {
+			if !inEnsemble[id] {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: weights references %q, which is not in the ensemble", label, id))
+			} else if w := e.Weights[id]; w <= 0 {
+				errs = append(errs, fmt.Sprintf("ensemble[%s]: weights[%s] must be > 0, got %g", label, id, w))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if cycle := strategyDependencyCycle(withEnsembleDependencies(strategies, ensembles)); len(cycle) > 0 {
+		errs = append(errs, fmt.Sprintf("ensembles: executor ordering conflicts with depends_on cycle %s", strings.Join(cycle, " -> ")))
+	}
+	return errs
+}
+
+// withEnsembleDependencies returns copies of strategies where each ensemble
+// executor additionally depends on its members, so a cycle's votes are in
+// before the executor consolidates them. The input slice is not modified.
+func withEnsembleDependencies(strategies []StrategyConfig, ensembles []EnsembleConfig) []StrategyConfig {
+	if len(ensembles) == 0 {
+		return strategies
+	}
+	members := make(map[string][]string, len(ensembles))
+	for _, e := range ensembles {
+		members[e.Executor] = append(members[e.Executor], e.Members...)
+	}
+	out := make([]StrategyConfig, len(strategies))
+	copy(out, strategies)
+	for i := range out {
+		if extra := members[out[i].ID]; len(extra) > 0 {
+			out[i].DependsOn = append(append([]string(nil), out[i].DependsOn...), extra...)
+		}
+	}
+	return out
+}
+
+// ensembleVote is one strategy's most recent raw signal.
+type ensembleVote struct {
+	Signal int
+	At     time.Time
+}
+
+// ensembleBook holds live ensemble votes. Votes are in-memory only: after a
+// restart each member votes again on its next check.
+type ensembleBook struct {
+	mu      sync.Mutex
+	byID    map[string]EnsembleConfig // strategy ID (executor or member) -> ensemble
+	maxAge  map[string]time.Duration  // strategy ID -> vote validity window
+	votes   map[string]ensembleVote
+	nowFunc func() time.Time
+}
+
+// globalEnsembles is configured once at startup from cfg.Ensembles.
+var globalEnsembles = &ensembleBook{}
+
+func (b *ensembleBook) now() time.Time {
+	if b.nowFunc != nil {
+		return b.nowFunc()
+	}
+	return time.Now()
+}
+
+// Configure indexes cfg.Ensembles. Votes from strategies that remain in an
+// ensemble are kept.
+func (b *ensembleBook) Configure(cfg *Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	intervals := make(map[string]int, len(cfg.Strategies))
+	for _, sc := range cfg.Strategies {
+		intervals[sc.ID] = configuredStrategyIntervalSeconds(sc, cfg.IntervalSeconds)
+	}
+	b.byID = make(map[string]EnsembleConfig)
+	b.maxAge = make(map[string]time.Duration)
+	for _, e := range cfg.Ensembles {
+		for _, id := range e.voters() {
+			b.byID[id] = e
+			b.maxAge[id] = time.Duration(ensembleStaleIntervals*intervals[id]) * time.Second
+		}
+	}
+	for id := range b.votes {
+		if _, ok := b.byID[id]; !ok {
+			delete(b.votes, id)
+		}
+	}
+	if b.votes == nil {
+		b.votes = make(map[string]ensembleVote)
+	}
+}
+
+// Apply records sc's raw signal and returns the signal sc should act on.
+// Members always return 0 (the executor trades for them); the executor
+// returns the consolidated signal. Strategies outside any ensemble pass
+// through unchanged.
+func (b *ensembleBook) Apply(sc StrategyConfig, signal int, logger *StrategyLogger) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e, ok := b.byID[sc.ID]
+	if !ok {
+		return signal
+	}
+	now := b.now()
+	b.votes[sc.ID] = ensembleVote{Signal: signal, At: now}
+	if sc.ID != e.Executor {
+		if signal != 0 && logger != nil {
+			logger.Info("Ensemble %s: vote %+d recorded; execution delegated to %s", e.ID, signal, e.Executor)
+		}
+		return 0
+	}
+	consolidated, detail := b.consolidate(e, now)
+	if logger != nil && (consolidated != signal || signal != 0) {
+		logger.Info("Ensemble %s: %s -> %+d (own vote %+d)", e.ID, detail, consolidated, signal)
+	}
+	return consolidated
+}
+
+// consolidate scores the ensemble's fresh votes. Caller holds b.mu.
+// majority: a direction wins when more than half of the fresh votes back it.
+// weighted: score = Σ(weight·sign)/Σweight must exceed ±threshold.
+func (b *ensembleBook) consolidate(e EnsembleConfig, now time.Time) (int, string) {
+	weighted := e.ensembleRule() == ensembleRuleWeighted
+	var buy, sell, total float64
+	parts := make([]string, 0, len(e.Members)+1)
+	for _, id := range e.voters() {
+		v, ok := b.votes[id]
+		if !ok || (b.maxAge[id] > 0 && now.Sub(v.At) > b.maxAge[id]) {
+			parts = append(parts, id+"=stale")
+			continue
+		}
+		w := 1.0
+		if cw, ok := e.Weights[id]; ok && weighted {
+			w = cw
+		}
+		switch sign(v.Signal) {
+		case 1:
+			buy += w
+		case -1:
+			sell += w
+		}
+		total += w
+		parts = append(parts, fmt.Sprintf("%s=%+d", id, v.Signal))
+	}
+	out := 0
+	detail := fmt.Sprintf("%s votes [%s]", e.ensembleRule(), strings.Join(parts, " "))
+	if total == 0 {
+		return 0, detail
+	}
+	if weighted {
+		threshold := 0.0
+		if e.Threshold != nil {
+			threshold = *e.Threshold
+		}
+		score := (buy - sell) / total
+		switch {
+		case score > threshold:
+			out = 1
+		case score < -threshold:
+			out = -1
+		}
+		return out, fmt.Sprintf("%s score=%.2f", detail, score)
+	}
+	switch {
+	case 2*buy > total:
+		out = 1
+	case 2*sell > total:
+		out = -1
+	}
+	return out, detail
+}
+
+// sign maps a raw strategy signal onto -1/0/+1.
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func ensembleTestConfig(e ...EnsembleConfig) *Config {
+	return &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{ID: "hl-a-btc", Type: "perps", Platform: "hyperliquid", Args: []string{"a", "BTC", "1h"}},
+			{ID: "hl-b-btc", Type: "perps", Platform: "hyperliquid", Args: []string{"b", "BTC", "1h"}},
+			{ID: "hl-c-btc", Type: "perps", Platform: "hyperliquid", Args: []string{"c", "BTC", "1h"}},
+			{ID: "hl-a-eth", Type: "perps", Platform: "hyperliquid", Args: []string{"a", "ETH", "1h"}},
+		},
+		Ensembles: e,
+	}
+}
+
+func TestEnsembleErrors(t *testing.T) {
+	half := 0.5
+	tooHigh := 1.0
+	cases := []struct {
+		name string
+		e    []EnsembleConfig
+		want string
+	}{
+		{"valid majority", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc", "hl-c-btc"}}}, ""},
+		{"valid weighted", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}, Rule: "weighted", Weights: map[string]float64{"hl-b-btc": 2}, Threshold: &half}}, ""},
+		{"missing id", []EnsembleConfig{{Executor: "hl-a-btc", Members: []string{"hl-b-btc"}}}, "id is required"},
+		{"unknown executor", []EnsembleConfig{{ID: "btc", Executor: "nope", Members: []string{"hl-b-btc"}}}, "executor references unknown strategy"},
+		{"no members", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc"}}, "at least one strategy"},
+		{"symbol mismatch", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-a-eth"}}}, "but executor"},
+		{"executor as member", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-a-btc"}}}, "listed more than once"},
+		{"two ensembles", []EnsembleConfig{
+			{ID: "x", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}},
+			{ID: "y", Executor: "hl-c-btc", Members: []string{"hl-b-btc"}},
+		}, "already belongs to ensemble"},
+		{"bad rule", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}, Rule: "unanimous"}}, "rule must be"},
+		{"weights need weighted", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}, Weights: map[string]float64{"hl-b-btc": 2}}}, "require rule"},
+		{"threshold range", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}, Rule: "weighted", Threshold: &tooHigh}}, "threshold must be in"},
+		{"foreign weight", []EnsembleConfig{{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}, Rule: "weighted", Weights: map[string]float64{"hl-c-btc": 1}}}, "not in the ensemble"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := ensembleTestConfig(tc.e...)
+			errs := ensembleErrors(cfg.Ensembles, cfg.Strategies)
+			if tc.want == "" {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(errs, "\n"), tc.want) {
+				t.Fatalf("errors %v missing %q", errs, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnsembleErrorsRejectsDependsOnCycle(t *testing.T) {
+	cfg := ensembleTestConfig(EnsembleConfig{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc"}})
+	cfg.Strategies[1].DependsOn = []string{"hl-a-btc"}
+	errs := ensembleErrors(cfg.Ensembles, cfg.Strategies)
+	if !strings.Contains(strings.Join(errs, "\n"), "cycle") {
+		t.Fatalf("expected cycle error, got %v", errs)
+	}
+}
+
+func TestWithEnsembleDependenciesOrdersExecutorLast(t *testing.T) {
+	cfg := ensembleTestConfig(EnsembleConfig{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc", "hl-c-btc"}})
+	ordered := orderByDependencies(withEnsembleDependencies(cfg.Strategies[:3], cfg.Ensembles))
+	if got := ordered[len(ordered)-1].ID; got != "hl-a-btc" {
+		t.Fatalf("executor should run last, got order ending in %s", got)
+	}
+	if len(cfg.Strategies[0].DependsOn) != 0 {
+		t.Fatalf("input strategies mutated: %v", cfg.Strategies[0].DependsOn)
+	}
+}
+
+func TestEnsembleBookMajority(t *testing.T) {
+	cfg := ensembleTestConfig(EnsembleConfig{ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc", "hl-c-btc"}})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &ensembleBook{nowFunc: func() time.Time { return now }}
+	b.Configure(cfg)
+
+	if got := b.Apply(cfg.Strategies[1], 1, nil); got != 0 {
+		t.Fatalf("member should delegate, got %d", got)
+	}
+	b.Apply(cfg.Strategies[2], -1, nil)
+	if got := b.Apply(cfg.Strategies[0], 1, nil); got != 1 {
+		t.Fatalf("2 of 3 buy should buy, got %d", got)
+	}
+	b.Apply(cfg.Strategies[1], 0, nil)
+	if got := b.Apply(cfg.Strategies[0], 1, nil); got != 0 {
+		t.Fatalf("split vote should hold, got %d", got)
+	}
+	if got := b.Apply(cfg.Strategies[3], -1, nil); got != -1 {
+		t.Fatalf("non-member should pass through, got %d", got)
+	}
+}
+
+func TestEnsembleBookWeightedAndStale(t *testing.T) {
+	threshold := 0.2
+	cfg := ensembleTestConfig(EnsembleConfig{
+		ID: "btc", Executor: "hl-a-btc", Members: []string{"hl-b-btc", "hl-c-btc"},
+		Rule: "weighted", Weights: map[string]float64{"hl-b-btc": 3}, Threshold: &threshold,
+	})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &ensembleBook{nowFunc: func() time.Time { return now }}
+	b.Configure(cfg)
+
+	b.Apply(cfg.Strategies[1], -1, nil)
+	b.Apply(cfg.Strategies[2], 1, nil)
+	// score = (1 + 1 - 3) / 5 = -0.2, not beyond the 0.2 threshold.
+	if got := b.Apply(cfg.Strategies[0], 1, nil); got != 0 {
+		t.Fatalf("score at threshold should hold, got %d", got)
+	}
+
+	// The heavy member's vote goes stale after 2 intervals; the rest agree.
+	now = now.Add(3 * time.Minute)
+	b.Apply(cfg.Strategies[2], 1, nil)
+	if got := b.Apply(cfg.Strategies[0], 1, nil); got != 1 {
+		t.Fatalf("stale vote should abstain, got %d", got)
+	}
+}
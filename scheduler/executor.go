@@ -10,7 +10,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -24,9 +23,6 @@ func (e *pythonScriptTimeoutError) Error() string {
 	return fmt.Sprintf("script timed out after %s", e.d)
 }
 
-// pythonSemaphore limits concurrent Python subprocess executions.
-var pythonSemaphore = make(chan struct{}, 4)
-
 const scriptTimeout = 30 * time.Second
 
 // SpotResult is the JSON output from check_strategy.py.
@@ -69,6 +65,7 @@ type HyperliquidFill struct {
 	Fee               float64 `json:"fee,omitempty"`                  // exchange fee (if available)
 	StopLossOID       int64   `json:"stop_loss_oid,omitempty"`        // resting trigger OID for the per-trade SL placed alongside the fill (#412)
 	StopLossTriggerPx float64 `json:"stop_loss_trigger_px,omitempty"` // SL trigger price (for logs/audit) (#412)
+	RestingOID        int64   `json:"resting_oid,omitempty"`          // order left resting on the book instead of filling (#4956)
 }
 
 // HyperliquidExecution is the execution block from check_hyperliquid.py --execute output.
@@ -89,6 +86,12 @@ type HyperliquidExecuteResult struct {
 	CancelStopLossSucceeded   bool                  `json:"cancel_stop_loss_succeeded,omitempty"`   // SL cancel went through (set even if subsequent open failed) so caller can clear stale pos.StopLossOID (#421)
 	StopLossError             string                `json:"stop_loss_error,omitempty"`              // non-fatal: SL placement after fill failed (#412)
 	StopLossFilledImmediately bool                  `json:"stop_loss_filled_immediately,omitempty"` // SL trigger filled at submit (price already through the level) — position is flat on-chain (#421)
+	// PartialFill is set Go-side by vetHyperliquidFill when the fill is short
+	// of the requested size (#4957). Not from the Python script.
+	PartialFill bool `json:"-"`
+	// SlippageBreached is set Go-side when the fill breached the strategy's
+	// max_slippage_pct (#4959).
+	SlippageBreached bool `json:"-"`
 }
 
 // HyperliquidStopLossUpdateResult is the JSON output from check_hyperliquid.py
@@ -156,26 +159,33 @@ func runPython(parentCtx context.Context, script string, args []string, stdinDat
 // long-running fetch scripts like fetch_hl_user_fills.py). Semaphore, Setpgid,
 // stdin, and SIGKILL-on-deadline behavior match runPython.
 func runPythonWithTimeout(parentCtx context.Context, script string, args []string, stdinData []byte, timeout time.Duration) ([]byte, []byte, error) {
-	pythonSemaphore <- struct{}{}
-	defer func() { <-pythonSemaphore }()
-	return spawnPythonProcess(parentCtx, script, args, stdinData, timeout)
+	return runPythonWithEnv(parentCtx, script, args, stdinData, timeout, nil)
+}
+
+// runPythonWithEnv is runPythonWithTimeout with a per-strategy environment
+// (#4981); nil inherits the scheduler's.
+func runPythonWithEnv(parentCtx context.Context, script string, args []string, stdinData []byte, timeout time.Duration, env *ScriptEnv) ([]byte, []byte, error) {
+	pythonSemaphore.Acquire()
+	stdout, stderr, err := spawnPythonProcessWithEnv(parentCtx, script, args, stdinData, timeout, env)
+	pythonSemaphore.Release(err)
+	return stdout, stderr, err
 }
 
 // spawnPythonProcess is the semaphore-free spawn core shared by
 // runPythonWithTimeout and the dedicated #1137 LLM / #1339 tuning lanes. Those
 // long-running lanes deliberately bypass pythonSemaphore so they cannot starve
-// the 4 trading-path slots, and each supplies its own worker-level concurrency
+// the trading-path slots (python_pool.go), and each supplies its own worker-level concurrency
 // cap. Other callers must go through runPython*/runPythonWithTimeout so
 // trading-path subprocesses stay capped.
 func spawnPythonProcess(parentCtx context.Context, script string, args []string, stdinData []byte, timeout time.Duration) ([]byte, []byte, error) {
 	return spawnPythonProcessWithEnv(parentCtx, script, args, stdinData, timeout, nil)
 }
 
-// spawnPythonProcessWithEnv is the dedicated-lane variant for subprocesses
-// that need a small, explicit environment overlay. A non-positive timeout
+// spawnPythonProcessWithEnv is the variant for subprocesses that need an
+// explicit environment (script_env.go); nil inherits the scheduler's. A non-positive timeout
 // means the parent context is the only deadline; the tuning lane uses that so
 // research runs are cancelled on shutdown without an arbitrary wall-clock cap.
-func spawnPythonProcessWithEnv(parentCtx context.Context, script string, args []string, stdinData []byte, timeout time.Duration, envOverrides map[string]string) ([]byte, []byte, error) {
+func spawnPythonProcessWithEnv(parentCtx context.Context, script string, args []string, stdinData []byte, timeout time.Duration, env *ScriptEnv) ([]byte, []byte, error) {
 	var ctx context.Context
 	var cancel context.CancelFunc
 	if timeout > 0 {
@@ -186,23 +196,26 @@ func spawnPythonProcessWithEnv(parentCtx context.Context, script string, args []
 	defer cancel()
 
 	cmdArgs := append([]string{script}, args...)
-	cmd := exec.CommandContext(ctx, ".venv/bin/python3", cmdArgs...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
-	if len(envOverrides) > 0 {
-		cmd.Env = processEnvironment(envOverrides)
-	}
+	cmd := exec.CommandContext(ctx, pythonInterpreter, cmdArgs...)
+	setScriptProcessGroup(cmd)
+	// #4980: every script carries the owner marker the orphan reaper keys on.
+	cmd.Env = buildScriptEnvironment(env)
 	if stdinData != nil {
 		cmd.Stdin = bytes.NewReader(stdinData)
 	}
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	// #4979: bounded capture — stdout keeps its head, stderr its tail.
+	stdout := newCappedOutput(activeScriptOutputMaxBytes, false)
+	stderr := newCappedOutput(activeScriptOutputMaxBytes, true)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
+	start := time.Now()
 	err := cmd.Run()
+	observeCallLatency("subprocess", subprocessLatencyOp(script), start, err)
 	if ctx.Err() != nil {
 		if cmd.Process != nil {
-			syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			killProcessGroup(cmd.Process.Pid)
 		}
 	}
 	if ctx.Err() == context.DeadlineExceeded {
@@ -250,11 +263,17 @@ func runPythonReadOnly(script string, args []string) ([]byte, []byte, error) {
 }
 
 // runPythonReadOnlyWithStdin mirrors runPythonReadOnly for scripts that
-// receive their input over stdin (currently RunOptionsCheckWithStdin).
+// receive their input over stdin (currently the tuner simulation).
 func runPythonReadOnlyWithStdin(script string, args []string, stdinData []byte) ([]byte, []byte, error) {
 	return runPython(shutdownReadOnlyCtx, script, args, stdinData)
 }
 
+// runPythonReadOnlyWithEnv is runPythonReadOnlyWithStdin for a strategy's
+// check script, run under that strategy's environment (#4981).
+func runPythonReadOnlyWithEnv(script string, args []string, stdinData []byte, env *ScriptEnv) ([]byte, []byte, error) {
+	return runPythonWithEnv(shutdownReadOnlyCtx, script, args, stdinData, scriptTimeout, env)
+}
+
 // runPythonSideEffect is for scripts that place live orders, mutate local
 // state via on-chain operations, or send external messages (--execute,
 // close_*.py, --sync-protection, trigger updates). Registers with
@@ -275,8 +294,8 @@ func RunPythonScript(script string, args []string) ([]byte, []byte, error) {
 }
 
 // RunSpotCheck runs check_strategy.py and parses the result.
-func RunSpotCheck(script string, args []string) (*SpotResult, string, error) {
-	stdout, stderr, err := RunPythonScript(script, args)
+func RunSpotCheck(script string, args []string, env *ScriptEnv) (*SpotResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, nil, env)
 	stderrStr := string(stderr)
 	if err != nil {
 		// Try to parse JSON even on non-zero exit (script may exit(1) with JSON error output)
@@ -284,56 +303,50 @@ func RunSpotCheck(script string, args []string) (*SpotResult, string, error) {
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result SpotResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
 
-// RunPythonScriptWithStdin is the public stdin-piped entry, currently used
-// only via RunOptionsCheckWithStdin (read-only).
-func RunPythonScriptWithStdin(script string, args []string, stdinData []byte) ([]byte, []byte, error) {
-	return runPythonReadOnlyWithStdin(script, args, stdinData)
-}
-
 // RunOptionsCheckWithStdin runs check_options.py, passing positionsJSON via stdin.
-func RunOptionsCheckWithStdin(script string, args []string, positionsJSON string) (*OptionsResult, string, error) {
-	stdout, stderr, err := RunPythonScriptWithStdin(script, args, []byte(positionsJSON))
+func RunOptionsCheckWithStdin(script string, args []string, positionsJSON string, env *ScriptEnv) (*OptionsResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, []byte(positionsJSON), env)
 	stderrStr := string(stderr)
 	if err != nil {
 		var result OptionsResult
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result OptionsResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
 
 // RunHyperliquidCheck runs check_hyperliquid.py in signal check mode and parses the result.
-func RunHyperliquidCheck(script string, args []string) (*HyperliquidResult, string, error) {
-	stdout, stderr, err := RunPythonScript(script, args)
+func RunHyperliquidCheck(script string, args []string, env *ScriptEnv) (*HyperliquidResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, nil, env)
 	stderrStr := string(stderr)
 	if err != nil {
 		var result HyperliquidResult
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result HyperliquidResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -367,7 +380,7 @@ type hlExecuteSnapshot struct {
 	AccountMarginMode string // "isolated" | "cross" (empty == unknown)
 }
 
-func buildHyperliquidExecuteArgs(symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) []string {
+func buildHyperliquidExecuteArgs(symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) []string {
 	args := []string{
 		"--execute",
 		fmt.Sprintf("--symbol=%s", symbol),
@@ -393,6 +406,11 @@ func buildHyperliquidExecuteArgs(symbol, side string, size, stopLossPct float64,
 	if prevPosQty > 0 {
 		args = append(args, fmt.Sprintf("--prev-pos-qty=%g", prevPosQty))
 	}
+	// #4916: the order-intent key as HL's client order ID, so the startup
+	// reconcile can ask the venue what became of an unsettled order.
+	if cloid != "" {
+		args = append(args, fmt.Sprintf("--cloid=%s", cloid))
+	}
 	if marginMode != "" {
 		args = append(args, fmt.Sprintf("--margin-mode=%s", marginMode))
 		if leverage > 0 {
@@ -411,10 +429,13 @@ func buildHyperliquidExecuteArgs(symbol, side string, size, stopLossPct float64,
 
 // RunHyperliquidExecute runs check_hyperliquid.py in execute mode (live orders).
 // See buildHyperliquidExecuteArgs for argv-contract details.
-func RunHyperliquidExecute(script, symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
-	args := buildHyperliquidExecuteArgs(symbol, side, size, stopLossPct, cancelStopLossOID, prevPosQty, marginMode, leverage, closeFullPosition, snapshot, extraCancelOIDs...)
+func RunHyperliquidExecute(script, symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	args := buildHyperliquidExecuteArgs(symbol, side, size, stopLossPct, cancelStopLossOID, prevPosQty, marginMode, leverage, closeFullPosition, snapshot, cloid, extraCancelOIDs...)
+	start := time.Now()
 	stdout, stderr, err := runPythonSideEffect(script, args)
-	return parseHyperliquidExecuteOutput(stdout, string(stderr), err)
+	result, stderrStr, err := parseHyperliquidExecuteOutput(stdout, string(stderr), err)
+	observeCallLatency("hyperliquid", "execute", start, err)
+	return result, stderrStr, err
 }
 
 // RunHyperliquidUpdateStopLoss cancels the existing resting SL trigger and
@@ -503,12 +524,12 @@ func RunHyperliquidSyncProtection(script, symbol, side string, size, avgCost, en
 	var result HyperliquidProtectionSyncResult
 	if jsonErr := json.Unmarshal(stdout, &result); jsonErr != nil {
 		if err != nil {
-			return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s; stdout: %s)", err, stderrStr, string(stdout))
+			return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s; stdout: %s)", err, outputSnippet(stderrStr), outputSnippet(string(stdout)))
 		}
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, outputSnippet(string(stdout)))
 	}
 	if err != nil && result.Error == "" {
-		return &result, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return &result, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 	return &result, stderrStr, nil
 }
@@ -519,12 +540,12 @@ func parseHyperliquidUpdateStopLossOutput(stdout []byte, stderrStr string, runEr
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("update stop-loss error: %w (stderr: %s)", runErr, stderrStr)
+		return nil, stderrStr, fmt.Errorf("update stop-loss error: %w (stderr: %s)", runErr, outputSnippet(stderrStr))
 	}
 
 	var result HyperliquidStopLossUpdateResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -539,12 +560,12 @@ func parseHyperliquidExecuteOutput(stdout []byte, stderrStr string, runErr error
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", runErr, stderrStr)
+		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", runErr, outputSnippet(stderrStr))
 	}
 
 	var result HyperliquidExecuteResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -612,8 +633,11 @@ func RunHyperliquidCloseCancelAfterFill(script, symbol string, partialSz *float6
 
 func runHyperliquidClose(script, symbol string, partialSz *float64, cancelStopLossOIDs []int64, cancelProtectionAfterClose bool) (*HyperliquidCloseResult, string, error) {
 	args := buildHyperliquidCloseArgs(symbol, partialSz, cancelStopLossOIDs, cancelProtectionAfterClose)
+	start := time.Now()
 	stdout, stderr, runErr := runPythonSideEffect(script, args)
-	return parseHyperliquidCloseOutput(stdout, string(stderr), runErr)
+	result, stderrStr, err := parseHyperliquidCloseOutput(stdout, string(stderr), runErr)
+	observeCallLatency("hyperliquid", "close", start, err)
+	return result, stderrStr, err
 }
 
 func buildHyperliquidCloseArgs(symbol string, partialSz *float64, cancelStopLossOIDs []int64, cancelProtectionAfterClose bool) []string {
@@ -663,11 +687,11 @@ func parseHyperliquidCloseOutput(stdout []byte, stderrStr string, runErr error)
 	case parseErr == nil && runErr != nil:
 		// Exit non-zero with valid JSON but no error field — unexpected. Treat
 		// as failure to avoid silently reporting success on a non-zero exit.
-		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
 		// Malformed JSON. Always a failure regardless of exit code.
-		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -721,20 +745,20 @@ type TopStepExecuteResult struct {
 }
 
 // RunTopStepCheck runs check_topstep.py in signal check mode and parses the result.
-func RunTopStepCheck(script string, args []string) (*TopStepResult, string, error) {
-	stdout, stderr, err := RunPythonScript(script, args)
+func RunTopStepCheck(script string, args []string, env *ScriptEnv) (*TopStepResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, nil, env)
 	stderrStr := string(stderr)
 	if err != nil {
 		var result TopStepResult
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result TopStepResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -755,12 +779,12 @@ func RunTopStepExecute(script, symbol, side string, contracts int) (*TopStepExec
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result TopStepExecuteResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -824,10 +848,10 @@ func parseTopStepCloseOutput(stdout []byte, stderrStr string, runErr error) (*To
 		return &result, stderrStr, fmt.Errorf("close failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -880,10 +904,10 @@ func parseTopStepPositionsOutput(stdout []byte, stderrStr string, runErr error)
 		return &result, stderrStr, fmt.Errorf("fetch positions failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -928,10 +952,10 @@ func parseTopStepBalanceOutput(stdout []byte, stderrStr string, runErr error) (*
 		return &result, stderrStr, fmt.Errorf("fetch balance failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch balance subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch balance subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse balance output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse balance output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -978,10 +1002,10 @@ func parseTopStepFillsOutput(stdout []byte, stderrStr string, runErr error) (*To
 		return &result, stderrStr, fmt.Errorf("fetch fills failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch fills subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch fills subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse fills output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse fills output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1026,20 +1050,20 @@ type RobinhoodExecuteResult struct {
 }
 
 // RunRobinhoodCheck runs check_robinhood.py in signal check mode and parses the result.
-func RunRobinhoodCheck(script string, args []string) (*RobinhoodResult, string, error) {
-	stdout, stderr, err := RunPythonScript(script, args)
+func RunRobinhoodCheck(script string, args []string, env *ScriptEnv) (*RobinhoodResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, nil, env)
 	stderrStr := string(stderr)
 	if err != nil {
 		var result RobinhoodResult
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result RobinhoodResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -1064,12 +1088,12 @@ func RunRobinhoodExecute(script, symbol, side string, amountUSD, quantity float6
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result RobinhoodExecuteResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -1114,26 +1138,27 @@ type OKXExecuteResult struct {
 }
 
 // RunOKXCheck runs check_okx.py in signal check mode and parses the result.
-func RunOKXCheck(script string, args []string) (*OKXResult, string, error) {
-	stdout, stderr, err := RunPythonScript(script, args)
+func RunOKXCheck(script string, args []string, env *ScriptEnv) (*OKXResult, string, error) {
+	stdout, stderr, err := runPythonReadOnlyWithEnv(script, args, nil, env)
 	stderrStr := string(stderr)
 	if err != nil {
 		var result OKXResult
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("script error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result OKXResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
 
 // RunOKXExecute runs check_okx.py in execute mode (live orders).
-func RunOKXExecute(script, symbol, side string, size float64, instType string) (*OKXExecuteResult, string, error) {
+// clientOrderID (the order-intent key, #4916) is sent as OKX clOrdId.
+func RunOKXExecute(script, symbol, side string, size float64, instType, clientOrderID string) (*OKXExecuteResult, string, error) {
 	args := []string{
 		"--execute",
 		fmt.Sprintf("--symbol=%s", symbol),
@@ -1142,6 +1167,9 @@ func RunOKXExecute(script, symbol, side string, size float64, instType string) (
 		"--mode=live",
 		fmt.Sprintf("--inst-type=%s", instType),
 	}
+	if clientOrderID != "" {
+		args = append(args, fmt.Sprintf("--client-order-id=%s", clientOrderID))
+	}
 	stdout, stderr, err := runPythonSideEffect(script, args)
 	stderrStr := string(stderr)
 	if err != nil {
@@ -1149,12 +1177,12 @@ func RunOKXExecute(script, symbol, side string, size float64, instType string) (
 		if jsonErr := json.Unmarshal(stdout, &result); jsonErr == nil && result.Error != "" {
 			return &result, stderrStr, nil
 		}
-		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, stderrStr)
+		return nil, stderrStr, fmt.Errorf("execute error: %w (stderr: %s)", err, outputSnippet(stderrStr))
 	}
 
 	var result OKXExecuteResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse execute output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -1233,10 +1261,10 @@ func parseOKXCloseOutput(stdout []byte, stderrStr string, runErr error) (*OKXClo
 		return &result, stderrStr, fmt.Errorf("close failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1299,10 +1327,10 @@ func parseOKXPositionsOutput(stdout []byte, stderrStr string, runErr error) (*OK
 		// Treat as failure to avoid silently reporting "no positions" on a
 		// non-zero exit (kill switch would clear virtual state while
 		// on-chain exposure remained — the #345 bug class).
-		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1348,10 +1376,10 @@ func parseOKXBalanceOutput(stdout []byte, stderrStr string, runErr error) (*OKXB
 		return &result, stderrStr, fmt.Errorf("fetch balance failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch balance subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch balance subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse balance output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse balance output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1398,10 +1426,10 @@ func parseOKXBillsOutput(stdout []byte, stderrStr string, runErr error) (*OKXBil
 		return &result, stderrStr, fmt.Errorf("fetch bills failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch bills subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch bills subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse bills output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse bills output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1468,10 +1496,10 @@ func parseRobinhoodCloseOutput(stdout []byte, stderrStr string, runErr error) (*
 		return &result, stderrStr, fmt.Errorf("close failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("close subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse close output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1518,10 +1546,10 @@ func parseRobinhoodPositionsOutput(stdout []byte, stderrStr string, runErr error
 		return &result, stderrStr, fmt.Errorf("fetch positions failed: %s", result.Error)
 
 	case parseErr == nil && runErr != nil:
-		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, stderrStr)
+		return &result, stderrStr, fmt.Errorf("fetch positions subprocess exit %v with no error field (stderr: %s)", runErr, outputSnippet(stderrStr))
 
 	default:
-		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse positions output: %v (run err: %v, stdout: %s)", parseErr, runErr, outputSnippet(string(stdout)))
 	}
 }
 
@@ -1529,12 +1557,12 @@ func parseRobinhoodPositionsOutput(stdout []byte, stderrStr string, runErr error
 func FetchPrices(symbols []string) (map[string]float64, error) {
 	stdout, stderr, err := RunPythonScript("shared_scripts/check_price.py", symbols)
 	if err != nil {
-		return nil, fmt.Errorf("price fetch error: %w (stderr: %s)", err, string(stderr))
+		return nil, fmt.Errorf("price fetch error: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 	}
 
 	var prices map[string]float64
 	if err := json.Unmarshal(stdout, &prices); err != nil {
-		return nil, fmt.Errorf("parse prices: %w (stdout: %s)", err, string(stdout))
+		return nil, fmt.Errorf("parse prices: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return prices, nil
 }
@@ -1569,7 +1597,7 @@ func FetchFuturesMarks(symbols []string) (map[string]float64, string, error) {
 	}
 	stdout, stderr, err := RunPythonScript("shared_scripts/fetch_futures_marks.py", symbols)
 	if err != nil {
-		return nil, "", fmt.Errorf("futures marks fetch error: %w (stderr: %s)", err, string(stderr))
+		return nil, "", fmt.Errorf("futures marks fetch error: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 	}
 
 	// The script mixes float prices with a string "_mode" metadata key,
@@ -1581,7 +1609,7 @@ func FetchFuturesMarks(symbols []string) (map[string]float64, string, error) {
 	// changes this return type, the filter must move with it.
 	var raw map[string]interface{}
 	if err := json.Unmarshal(stdout, &raw); err != nil {
-		return nil, "", fmt.Errorf("parse futures marks: %w (stdout: %s)", err, string(stdout))
+		return nil, "", fmt.Errorf("parse futures marks: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 
 	marks := make(map[string]float64, len(raw))
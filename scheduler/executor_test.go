@@ -630,7 +630,7 @@ func argsHasPrefix(args []string, prefix string) bool {
 // the Python script calls adapter.market_close(sz=None) instead of
 // market_open(size). This is the load-bearing #592 contract.
 func TestBuildHyperliquidExecuteArgs_CloseFullPosition(t *testing.T) {
-	args := buildHyperliquidExecuteArgs("ETH", "sell", 0, 0, 0, 0, "", 0, true, hlExecuteSnapshot{})
+	args := buildHyperliquidExecuteArgs("ETH", "sell", 0, 0, 0, 0, "", 0, true, hlExecuteSnapshot{}, "")
 
 	if !argsContains(args, "--close-full-position") {
 		t.Errorf("expected --close-full-position flag in argv, got %v", args)
@@ -650,7 +650,7 @@ func TestBuildHyperliquidExecuteArgs_CloseFullPosition(t *testing.T) {
 // --close-full-position. This is the path used for shared-coin peers and for
 // partial closes.
 func TestBuildHyperliquidExecuteArgs_SizedClose(t *testing.T) {
-	args := buildHyperliquidExecuteArgs("ETH", "sell", 0.42, 0, 0, 0, "", 0, false, hlExecuteSnapshot{})
+	args := buildHyperliquidExecuteArgs("ETH", "sell", 0.42, 0, 0, 0, "", 0, false, hlExecuteSnapshot{}, "")
 
 	if argsContains(args, "--close-full-position") {
 		t.Errorf("--close-full-position must be omitted when closeFullPosition=false, got %v", args)
@@ -667,7 +667,7 @@ func TestBuildHyperliquidExecuteArgs_SizedClose(t *testing.T) {
 // --cancel-stop-loss-oid flags (mirrors the posQty>0 && !partialClose gate in
 // main.go that cancels every tier TP OID on a full or flip close).
 func TestBuildHyperliquidExecuteArgs_ExtraCancelOIDsFullClose(t *testing.T) {
-	args := buildHyperliquidExecuteArgs("ETH", "sell", 0, 0, 0, 0, "", 0, true, hlExecuteSnapshot{}, 111, 222, 333)
+	args := buildHyperliquidExecuteArgs("ETH", "sell", 0, 0, 0, 0, "", 0, true, hlExecuteSnapshot{}, "", 111, 222, 333)
 
 	for _, want := range []string{"--cancel-stop-loss-oid=111", "--cancel-stop-loss-oid=222", "--cancel-stop-loss-oid=333"} {
 		if !argsContains(args, want) {
@@ -681,7 +681,7 @@ func TestBuildHyperliquidExecuteArgs_ExtraCancelOIDsFullClose(t *testing.T) {
 func TestBuildHyperliquidExecuteArgs_ExtraCancelOIDsPartialClose(t *testing.T) {
 	// No extraCancelOIDs passed — matches what runHyperliquidExecuteOrder does on
 	// a partial close.
-	args := buildHyperliquidExecuteArgs("ETH", "sell", 0.5, 0, 0, 0, "", 0, false, hlExecuteSnapshot{})
+	args := buildHyperliquidExecuteArgs("ETH", "sell", 0.5, 0, 0, 0, "", 0, false, hlExecuteSnapshot{}, "")
 
 	for _, notWant := range []string{"--cancel-stop-loss-oid=111", "--cancel-stop-loss-oid=222"} {
 		if argsContains(args, notWant) {
@@ -693,16 +693,16 @@ func TestBuildHyperliquidExecuteArgs_ExtraCancelOIDsPartialClose(t *testing.T) {
 // Optional flags should be conditionally present.
 func TestBuildHyperliquidExecuteArgs_OptionalFlags(t *testing.T) {
 	t.Run("no optional flags", func(t *testing.T) {
-		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "", 0, false, hlExecuteSnapshot{})
-		for _, prefix := range []string{"--stop-loss-pct=", "--cancel-stop-loss-oid=", "--prev-pos-qty=", "--margin-mode=", "--leverage="} {
+		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "", 0, false, hlExecuteSnapshot{}, "")
+		for _, prefix := range []string{"--stop-loss-pct=", "--cancel-stop-loss-oid=", "--prev-pos-qty=", "--margin-mode=", "--leverage=", "--cloid="} {
 			if argsHasPrefix(args, prefix) {
 				t.Errorf("expected %s to be omitted, got %v", prefix, args)
 			}
 		}
 	})
 	t.Run("all optional flags", func(t *testing.T) {
-		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 2.5, 12345, 0.0005, "isolated", 5, false, hlExecuteSnapshot{})
-		for _, want := range []string{"--stop-loss-pct=2.5", "--cancel-stop-loss-oid=12345", "--prev-pos-qty=0.0005", "--margin-mode=isolated", "--leverage=5"} {
+		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 2.5, 12345, 0.0005, "isolated", 5, false, hlExecuteSnapshot{}, "0x0123456789abcdef0123456789abcdef")
+		for _, want := range []string{"--stop-loss-pct=2.5", "--cancel-stop-loss-oid=12345", "--prev-pos-qty=0.0005", "--margin-mode=isolated", "--leverage=5", "--cloid=0x0123456789abcdef0123456789abcdef"} {
 			if !argsContains(args, want) {
 				t.Errorf("expected %q in argv, got %v", want, args)
 			}
@@ -711,7 +711,7 @@ func TestBuildHyperliquidExecuteArgs_OptionalFlags(t *testing.T) {
 	t.Run("margin mode without leverage", func(t *testing.T) {
 		// leverage=0 with non-empty margin_mode: --leverage must not appear (would
 		// confuse the Python validator) but --margin-mode is still emitted.
-		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "cross", 0, false, hlExecuteSnapshot{})
+		args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "cross", 0, false, hlExecuteSnapshot{}, "")
 		if !argsContains(args, "--margin-mode=cross") {
 			t.Errorf("expected --margin-mode=cross, got %v", args)
 		}
@@ -726,7 +726,7 @@ func TestBuildHyperliquidExecuteArgs_OptionalFlags(t *testing.T) {
 // Python side only consults them inside the `if margin_mode:` branch.
 func TestBuildHyperliquidExecuteArgs_AccountSnapshotForwarded(t *testing.T) {
 	snap := hlExecuteSnapshot{AccountLeverage: 10, AccountMarginMode: "isolated"}
-	args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "isolated", 10, false, snap)
+	args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "isolated", 10, false, snap, "")
 	for _, want := range []string{"--account-leverage=10", "--account-margin-mode=isolated"} {
 		if !argsContains(args, want) {
 			t.Errorf("expected %q in argv when snapshot is known, got %v", want, args)
@@ -745,7 +745,7 @@ func TestBuildHyperliquidExecuteArgs_AccountSnapshotOmittedWhenIncomplete(t *tes
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "isolated", 10, false, tc.snap)
+			args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "isolated", 10, false, tc.snap, "")
 			for _, prefix := range []string{"--account-leverage=", "--account-margin-mode="} {
 				if argsHasPrefix(args, prefix) {
 					t.Errorf("expected %s to be omitted on incomplete snapshot (%s), got %v", prefix, tc.name, args)
@@ -760,7 +760,7 @@ func TestBuildHyperliquidExecuteArgs_AccountSnapshotOmittedWithoutMarginMode(t *
 	// branch; forwarding it when margin_mode is empty would be wasted argv
 	// noise. Verify the omission so we don't drift from that contract.
 	snap := hlExecuteSnapshot{AccountLeverage: 10, AccountMarginMode: "isolated"}
-	args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "", 0, false, snap)
+	args := buildHyperliquidExecuteArgs("BTC", "buy", 0.001, 0, 0, 0, "", 0, false, snap, "")
 	for _, prefix := range []string{"--account-leverage=", "--account-margin-mode="} {
 		if argsHasPrefix(args, prefix) {
 			t.Errorf("expected %s to be omitted when margin-mode is empty, got %v", prefix, args)
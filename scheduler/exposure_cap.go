@@ -17,7 +17,10 @@ package main
 // reduce-only exits) pass through untouched. The optional
 // max_asset_concentration_pct arm blocks per-asset: an asset whose |net delta|
 // exceeds the configured percent of portfolio value holds new opens in its net
-// direction for strategies trading that asset only.
+// direction for strategies trading that asset only. The concentration cap is
+// per-underlying overridable via portfolio_risk.asset_concentration_pct (a
+// positive value replaces the default for that asset, 0 exempts it), so BTC can
+// be allowed a bigger slice of the book than a small-cap alt.
 //
 // Blocking-only, mirroring the #42 notional cap contract: nothing is ever
 // force-closed, reduced, or mutated. Manual open/add/limit-open refuse next to
@@ -47,6 +50,7 @@ type ExposureCapAssetStat struct {
 	Direction string  // "long" / "short" (sign of the asset's net delta)
 	Pct       float64 // |net| / portfolio value * 100
 	NetUSD    float64 // signed net delta USD
+	CapPct    float64 // the asset's resolved concentration cap (override or default)
 }
 
 // ExposureCapStatus is the once-per-cycle evaluation of the #1270 exposure
@@ -59,16 +63,77 @@ type ExposureCapStatus struct {
 	ShortUSD         float64                         // sum of |negative| per-asset net deltas
 	LongBlocked      bool                            // long bucket exceeds CapUSD
 	ShortBlocked     bool                            // short bucket exceeds CapUSD
-	ConcentrationPct float64                         // max_asset_concentration_pct (0 = arm disabled)
+	ConcentrationPct float64                         // max_asset_concentration_pct default (0 = no default; overrides may still arm it)
 	PortfolioValue   float64                         // concentration basis (live portfolio value)
 	PVBasisMiss      bool                            // concentration arm configured but basis <= 0 — it cannot evaluate
 	OverConcentrated map[string]ExposureCapAssetStat // asset -> stat for assets over the concentration arm
 	SkippedPositions []string                        // sorted "strategy/symbol: why" entries excluded from the sums
+	Groups           []ExposureCapGroupStat          // portfolio_risk.correlation_groups (correlation_groups.go)
 }
 
 // exposureCapConfigured reports whether either exposure-cap arm is set.
 func exposureCapConfigured(pr *PortfolioRiskConfig) bool {
-	return pr != nil && (pr.MaxSameDirectionNotionalUSD > 0 || pr.MaxAssetConcentrationPct > 0)
+	return pr != nil && (pr.MaxSameDirectionNotionalUSD > 0 || assetConcentrationArmed(pr) || correlationGroupsArmed(pr))
+}
+
+// assetConcentrationArmed reports whether the concentration arm caps at least
+// one asset: a default max_asset_concentration_pct or any positive override.
+func assetConcentrationArmed(pr *PortfolioRiskConfig) bool {
+	if pr == nil {
+		return false
+	}
+	if pr.MaxAssetConcentrationPct > 0 {
+		return true
+	}
+	for _, pct := range pr.AssetConcentrationPct {
+		if pct > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// assetConcentrationCap resolves the concentration cap for one asset: the
+// asset_concentration_pct override when present (case-insensitive key match;
+// 0 exempts the asset), else max_asset_concentration_pct. 0 = uncapped.
+func assetConcentrationCap(pr *PortfolioRiskConfig, asset string) float64 {
+	if pr == nil {
+		return 0
+	}
+	for key, pct := range pr.AssetConcentrationPct {
+		if strings.EqualFold(key, asset) {
+			return pct
+		}
+	}
+	return pr.MaxAssetConcentrationPct
+}
+
+// assetConcentrationOverridesLabel renders the per-asset overrides sorted by
+// asset ("BTC=60.0%, DOGE=0.0%"), or "none". Used by the startup summary and
+// the hot-reload diff.
+func assetConcentrationOverridesLabel(pr *PortfolioRiskConfig) string {
+	if pr == nil || len(pr.AssetConcentrationPct) == 0 {
+		return "none"
+	}
+	keys := make([]string, 0, len(pr.AssetConcentrationPct))
+	for k := range pr.AssetConcentrationPct {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%.1f%%", strings.ToUpper(k), pr.AssetConcentrationPct[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// assetCapPct is the cap an over-concentrated asset was measured against.
+// Falls back to the default for stats built without a resolved CapPct.
+func (st ExposureCapStatus) assetCapPct(stat ExposureCapAssetStat) float64 {
+	if stat.CapPct > 0 {
+		return stat.CapPct
+	}
+	return st.ConcentrationPct
 }
 
 // evaluateExposureCap aggregates signed per-asset exposure across every
@@ -86,7 +151,7 @@ func evaluateExposureCap(pr *PortfolioRiskConfig, states map[string]*StrategySta
 	st.CapUSD = pr.MaxSameDirectionNotionalUSD
 	st.ConcentrationPct = pr.MaxAssetConcentrationPct
 	st.PortfolioValue = portfolioValue
-	st.PVBasisMiss = st.ConcentrationPct > 0 && portfolioValue <= 0
+	st.PVBasisMiss = (assetConcentrationArmed(pr) || correlationGroupsNeedPV(pr)) && portfolioValue <= 0
 
 	assets, skipped := computeAssetDeltas(states, cfgStrategies, prices)
 	st.SkippedPositions = skipped
@@ -103,23 +168,30 @@ func evaluateExposureCap(pr *PortfolioRiskConfig, states map[string]*StrategySta
 		} else {
 			st.ShortUSD += -net
 		}
-		if st.ConcentrationPct > 0 && portfolioValue > 0 {
+		if capPct := assetConcentrationCap(pr, a); capPct > 0 && portfolioValue > 0 {
 			pct := net / portfolioValue * 100
 			dir := "long"
 			if net < 0 {
 				pct = -pct
 				dir = "short"
 			}
-			if pct > st.ConcentrationPct {
+			if pct > capPct {
 				if st.OverConcentrated == nil {
 					st.OverConcentrated = make(map[string]ExposureCapAssetStat)
 				}
-				st.OverConcentrated[a] = ExposureCapAssetStat{Direction: dir, Pct: pct, NetUSD: net}
+				st.OverConcentrated[a] = ExposureCapAssetStat{Direction: dir, Pct: pct, NetUSD: net, CapPct: capPct}
 			}
 		}
 	}
 	st.LongBlocked = st.CapUSD > 0 && st.LongUSD > st.CapUSD
 	st.ShortBlocked = st.CapUSD > 0 && st.ShortUSD > st.CapUSD
+	if correlationGroupsArmed(pr) {
+		nets := make(map[string]float64, len(assets))
+		for a, ae := range assets {
+			nets[a] = ae.NetDeltaUSD
+		}
+		st.Groups = evaluateCorrelationGroups(pr, nets, portfolioValue)
+	}
 	return st
 }
 
@@ -164,7 +236,10 @@ func exposureCapManualEntryBlock(st ExposureCapStatus, asset, dir string) (bool,
 	}
 	if stat, ok := st.OverConcentrated[asset]; ok && stat.Direction == dir {
 		return true, fmt.Sprintf("%s net %s exposure $%.2f is %.1f%% of portfolio value $%.2f (cap %.1f%%)",
-			asset, dir, stat.NetUSD, stat.Pct, st.PortfolioValue, st.ConcentrationPct)
+			asset, dir, stat.NetUSD, stat.Pct, st.PortfolioValue, st.assetCapPct(stat))
+	}
+	if why := exposureCapGroupBlock(st, asset, dir); why != "" {
+		return true, why
 	}
 	return false, ""
 }
@@ -199,7 +274,10 @@ func exposureCapBlocksSignal(st ExposureCapStatus, asset string, signal int, clo
 	}
 	if stat, ok := st.OverConcentrated[asset]; ok && stat.Direction == dir {
 		return true, fmt.Sprintf("%s net %s exposure $%.2f is %.1f%% of portfolio value $%.2f (cap %.1f%%) — new %s %ss blocked",
-			asset, dir, stat.NetUSD, stat.Pct, st.PortfolioValue, st.ConcentrationPct, asset, dir)
+			asset, dir, stat.NetUSD, stat.Pct, st.PortfolioValue, st.assetCapPct(stat), asset, dir)
+	}
+	if why := exposureCapGroupBlock(st, asset, dir); why != "" {
+		return true, why + " — new " + dir + " opens blocked"
 	}
 	return false, ""
 }
@@ -254,6 +332,15 @@ func exposureCapOptionsActions(st ExposureCapStatus, asset string, actions []Opt
 				blocked = true
 			}
 		}
+		if !blocked && dir != "" {
+			if why := exposureCapGroupBlock(st, asset, dir); why != "" {
+				dropped++
+				if reason == "" {
+					reason = why + " — new " + dir + "-delta option opens blocked"
+				}
+				continue
+			}
+		}
 		if blocked {
 			dropped++
 			if reason == "" {
@@ -265,7 +352,7 @@ func exposureCapOptionsActions(st ExposureCapStatus, asset string, actions []Opt
 					reason = fmt.Sprintf("same-direction crypto exposure $%.2f exceeds cap $%.2f — new %s-delta option opens blocked", bucketUSD, st.CapUSD, dir)
 				} else {
 					stat := st.OverConcentrated[asset]
-					reason = fmt.Sprintf("%s net %s exposure is %.1f%% of portfolio value (cap %.1f%%) — new %s-delta option opens blocked", asset, dir, stat.Pct, st.ConcentrationPct, dir)
+					reason = fmt.Sprintf("%s net %s exposure is %.1f%% of portfolio value (cap %.1f%%) — new %s-delta option opens blocked", asset, dir, stat.Pct, st.assetCapPct(stat), dir)
 				}
 			}
 			continue
@@ -318,8 +405,9 @@ func exposureCapCycleWarning(st ExposureCapStatus) string {
 	for _, a := range sortedOverConcentrated(st) {
 		stat := st.OverConcentrated[a]
 		parts = append(parts, fmt.Sprintf("%s net %s %.1f%% of portfolio value $%.2f > cap %.1f%% — new %s %ss blocked",
-			a, stat.Direction, stat.Pct, st.PortfolioValue, st.ConcentrationPct, a, stat.Direction))
+			a, stat.Direction, stat.Pct, st.PortfolioValue, st.assetCapPct(stat), a, stat.Direction))
 	}
+	parts = append(parts, groupBlockLines(st)...)
 	if len(parts) == 0 {
 		return ""
 	}
@@ -350,6 +438,7 @@ type exposureCapAlertState struct {
 	LongAlerted        bool
 	ShortAlerted       bool
 	ConcAlerted        map[string]string // asset -> direction already alerted
+	GroupAlerted       map[string]bool   // group block line already alerted
 	PVBasisMissAlerted bool
 }
 
@@ -380,7 +469,23 @@ func exposureCapAlertMessage(st ExposureCapStatus, prev exposureCapAlertState, n
 		next.ConcAlerted[a] = stat.Direction
 		if prev.ConcAlerted[a] != stat.Direction {
 			lines = append(lines, fmt.Sprintf("🛑 %s net %s $%.2f is %.1f%% of portfolio value $%.2f (cap %.1f%%) — new %s %ss blocked",
-				a, stat.Direction, stat.NetUSD, stat.Pct, st.PortfolioValue, st.ConcentrationPct, a, stat.Direction))
+				a, stat.Direction, stat.NetUSD, stat.Pct, st.PortfolioValue, st.assetCapPct(stat), a, stat.Direction))
+		}
+	}
+	for _, gs := range st.Groups {
+		for _, dir := range []string{"long", "short"} {
+			why := gs.blockReason(dir)
+			if why == "" {
+				continue
+			}
+			if next.GroupAlerted == nil {
+				next.GroupAlerted = make(map[string]bool)
+			}
+			key := gs.Name + "/" + dir
+			next.GroupAlerted[key] = true
+			if !prev.GroupAlerted[key] {
+				lines = append(lines, fmt.Sprintf("🛑 %s — new %s opens on %s blocked", why, dir, strings.Join(gs.Assets, "/")))
+			}
 		}
 	}
 	if st.PVBasisMiss && !prev.PVBasisMissAlerted {
@@ -409,6 +514,12 @@ func exposureCapStartupSummaryLine(pr *PortfolioRiskConfig) string {
 	if pr.MaxAssetConcentrationPct > 0 {
 		parts = append(parts, fmt.Sprintf("asset_concentration=%.1f%% of portfolio value", pr.MaxAssetConcentrationPct))
 	}
+	if len(pr.AssetConcentrationPct) > 0 {
+		parts = append(parts, fmt.Sprintf("asset_overrides=[%s]", assetConcentrationOverridesLabel(pr)))
+	}
+	if len(pr.CorrelationGroups) > 0 {
+		parts = append(parts, fmt.Sprintf("groups=[%s]", correlationGroupsLabel(pr)))
+	}
 	return fmt.Sprintf("[config] portfolio: exposure cap %s (blocks capped-direction opens only; closes and SL/TP management unaffected)", strings.Join(parts, " "))
 }
 
@@ -438,7 +549,10 @@ func exposureCapStatusNote(pr *PortfolioRiskConfig, state *AppState, cfgStrategi
 	for _, a := range sortedOverConcentrated(st) {
 		stat := st.OverConcentrated[a]
 		note += fmt.Sprintf("\n🛑 exposure cap: %s net %s %.1f%% of portfolio value (cap %.1f%%) — new %s %ss blocked",
-			a, stat.Direction, stat.Pct, st.ConcentrationPct, a, stat.Direction)
+			a, stat.Direction, stat.Pct, st.assetCapPct(stat), a, stat.Direction)
+	}
+	for _, line := range groupBlockLines(st) {
+		note += "\n🛑 exposure cap: " + line
 	}
 	if st.PVBasisMiss {
 		note += "\n" + exposureCapPVBasisMissWarning
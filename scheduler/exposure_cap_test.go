@@ -314,6 +314,62 @@ func TestEvaluateExposureCap_Concentration(t *testing.T) {
 	}
 }
 
+func TestEvaluateExposureCap_PerAssetOverride(t *testing.T) {
+	// Default 40%; BTC allowed 60%, ETH tightened to 25%, no default for the rest.
+	pr := &PortfolioRiskConfig{MaxDrawdownPct: 25, MaxAssetConcentrationPct: 40,
+		AssetConcentrationPct: map[string]float64{"btc": 60, "ETH": 25}}
+	// PV 20000: BTC 50% (under its 60% override), ETH 30% (over its 25%).
+	st := evaluateExposureCap(pr, exposureTestStates(), exposureTestConfigs(), exposureTestPrices(), 20000)
+	if _, ok := st.OverConcentrated["BTC"]; ok {
+		t.Error("BTC at 50% must pass its 60% override")
+	}
+	eth, ok := st.OverConcentrated["ETH"]
+	if !ok || eth.CapPct != 25 {
+		t.Fatalf("ETH stat = %+v (ok=%v), want over its 25%% override", eth, ok)
+	}
+	if _, why := exposureCapBlocksSignal(st, "ETH", 1, 0, 0, "", true, true); !strings.Contains(why, "(cap 25.0%)") {
+		t.Errorf("reason must name the override cap: %q", why)
+	}
+
+	// Overrides alone arm the concentration arm.
+	only := &PortfolioRiskConfig{MaxDrawdownPct: 25, AssetConcentrationPct: map[string]float64{"ETH": 25}}
+	if !exposureCapConfigured(only) {
+		t.Fatal("an override with no default must arm the gate")
+	}
+	st = evaluateExposureCap(only, exposureTestStates(), exposureTestConfigs(), exposureTestPrices(), 20000)
+	if _, ok := st.OverConcentrated["BTC"]; ok || len(st.OverConcentrated) != 1 {
+		t.Errorf("only ETH is capped: %v", st.OverConcentrated)
+	}
+
+	// An explicit 0 exempts the asset from the default.
+	exempt := &PortfolioRiskConfig{MaxDrawdownPct: 25, MaxAssetConcentrationPct: 40, AssetConcentrationPct: map[string]float64{"BTC": 0}}
+	st = evaluateExposureCap(exempt, exposureTestStates(), exposureTestConfigs(), exposureTestPrices(), 20000)
+	if _, ok := st.OverConcentrated["BTC"]; ok {
+		t.Error("BTC override 0 must exempt it")
+	}
+}
+
+func TestValidateConfig_AssetConcentrationOverrides(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		PortfolioRisk: &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60,
+			AssetConcentrationPct: map[string]float64{"BTC": 120, "eth": 30, "ETH": 20}},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"asset_concentration_pct[BTC] must be in [0, 100]", "name the same asset"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
 func TestEvaluateExposureCap_PVBasisMiss(t *testing.T) {
 	pr := &PortfolioRiskConfig{MaxDrawdownPct: 25, MaxAssetConcentrationPct: 40}
 	st := evaluateExposureCap(pr, exposureTestStates(), exposureTestConfigs(), exposureTestPrices(), 0)
@@ -702,7 +758,7 @@ func exposureCapE2EDeps(t *testing.T, view manualStateView, executed *bool) manu
 		loadState: func(strategyID, symbol string) (manualStateView, error) {
 			return view, nil
 		},
-		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 			*executed = true
 			return nil, "", errSentinelStopAfterGuards
 		},
@@ -13,6 +13,7 @@ import (
 const (
 	directionOpen  = "open"
 	directionClose = "close"
+	directionSkip  = "skip"
 )
 
 // shouldNotifyDrainFailure decides whether a circuit-breaker close drain
@@ -158,6 +159,39 @@ func notifyLiveExecFailure(notifier *MultiNotifier, sc StrategyConfig, direction
 	}
 	msg := formatLiveExecFailureAlert(sc.ID, sc.Platform, direction, symbol, errMsg, count)
 	notifier.SendToAllChannels(msg)
+	dm := msg
+	if criticalAlerts.Raise(liveExecEscalationKey(key), msg) {
+		dm += criticalAlerts.AckHint()
+	}
+	notifier.SendOwnerDM(dm)
+}
+
+// liveExecEscalationKey is the critical-alert slot for one live-order
+// failure key (#4945).
+func liveExecEscalationKey(key string) string {
+	return "live_exec " + key
+}
+
+// notifyLiveOrderSkipped fires a throttled Discord+DM alert when a live open is
+// skipped before reaching the executor (e.g. below the venue's minimum order
+// notional). Shares liveExecThrottle under the directionSkip label, so a
+// wallet stuck below the minimum re-alerts on the drain-failure cadence
+// rather than every cycle.
+func notifyLiveOrderSkipped(notifier *MultiNotifier, sc StrategyConfig, symbol, reason string) {
+	if notifier == nil || !notifier.HasBackends() {
+		return
+	}
+	key := liveExecKey(sc.ID, sc.Platform, symbol, directionSkip)
+	shouldNotify, count := liveExecThrottle.Record(key, reason, time.Now().UTC())
+	if !shouldNotify {
+		return
+	}
+	countNote := ""
+	if count > 1 {
+		countNote = fmt.Sprintf(" (skip #%d)", count)
+	}
+	msg := fmt.Sprintf("**LIVE ORDER SKIPPED** [%s] %s open %s: %s%s", sc.ID, sc.Platform, symbol, reason, countNote)
+	notifier.SendToAllChannels(msg)
 	notifier.SendOwnerDM(msg)
 }
 
@@ -166,4 +200,5 @@ func notifyLiveExecFailure(notifier *MultiNotifier, sc StrategyConfig, direction
 func clearLiveExecThrottle(sc StrategyConfig, direction, symbol string) {
 	key := liveExecKey(sc.ID, sc.Platform, symbol, direction)
 	liveExecThrottle.Clear(key)
+	criticalAlerts.Resolve(liveExecEscalationKey(key))
 }
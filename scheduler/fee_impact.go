@@ -0,0 +1,92 @@
+package main
+
+// fee_impact: gross vs net PnL (#4977).
+//
+// RecordTrade accumulates every booked fee and every modeled paper slippage
+// cost into StrategyState.TotalFees / TotalSlippage. Net PnL is the usual
+// portfolio value minus initial capital; gross PnL adds the two totals back,
+// i.e. what the strategy would have made with free, perfect fills. The
+// weekly digest lists strategies by how much of their gross the costs ate
+// and flags the ones that are profitable gross but negative net — churners
+// that the 0.1% spot fee turns into losers.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// feeImpact is one strategy's lifetime gross-vs-net breakdown.
+type feeImpact struct {
+	strategyID string
+	netPnL     float64
+	fees       float64
+	slippage   float64 // positive = cost
+}
+
+func (f feeImpact) costs() float64    { return f.fees + f.slippage }
+func (f feeImpact) grossPnL() float64 { return f.netPnL + f.costs() }
+
+// feesFlipSign reports a strategy that made money before costs and lost it
+// after.
+func (f feeImpact) feesFlipSign() bool { return f.grossPnL() > 0 && f.netPnL < 0 }
+
+// strategyFeeImpact builds s's breakdown against prices.
+func strategyFeeImpact(s *StrategyState, prices map[string]float64) feeImpact {
+	return feeImpact{
+		strategyID: s.ID,
+		netPnL:     PortfolioValue(s, prices) - s.InitialCapital,
+		fees:       s.TotalFees,
+		slippage:   s.TotalSlippage,
+	}
+}
+
+// digestFeeImpact is the gross-vs-net section: every configured strategy
+// that has paid costs, sign flips first, then by total cost.
+func digestFeeImpact(in weeklyDigestInput) string {
+	if in.cfg == nil || in.state == nil {
+		return ""
+	}
+	var rows []feeImpact
+	for _, sc := range in.cfg.Strategies {
+		s := in.state.Strategies[sc.ID]
+		if s == nil || (s.TotalFees == 0 && s.TotalSlippage == 0) {
+			continue
+		}
+		rows = append(rows, strategyFeeImpact(s, in.prices))
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if fi, fj := rows[i].feesFlipSign(), rows[j].feesFlipSign(); fi != fj {
+			return fi
+		}
+		if ci, cj := rows[i].costs(), rows[j].costs(); ci != cj {
+			return ci > cj
+		}
+		return rows[i].strategyID < rows[j].strategyID
+	})
+	return formatFeeImpact(rows)
+}
+
+func formatFeeImpact(rows []feeImpact) string {
+	var sb strings.Builder
+	sb.WriteString("💸 **Gross vs net PnL (lifetime)**\n")
+	for i, f := range rows {
+		if i == weeklyDigestMaxRows {
+			fmt.Fprintf(&sb, "… +%d more\n", len(rows)-weeklyDigestMaxRows)
+			break
+		}
+		fmt.Fprintf(&sb, "`%s` gross $%+.2f − fees $%.2f − slippage $%.2f = net $%+.2f",
+			f.strategyID, f.grossPnL(), f.fees, f.slippage, f.netPnL)
+		if gross := f.grossPnL(); gross > 0 {
+			fmt.Fprintf(&sb, " (costs %.0f%% of gross)", f.costs()/gross*100)
+		}
+		if f.feesFlipSign() {
+			sb.WriteString(" ⚠️ profitable only before costs")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
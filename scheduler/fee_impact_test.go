@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordTradeAccumulatesCosts(t *testing.T) {
+	sc := StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000}
+	s := NewStrategyState(sc)
+	logger := &StrategyLogger{stratID: sc.ID, writer: io.Discard}
+	if _, err := ExecuteSpotSignalWithFillFee(s, 1, "BTC/USDT", 100, 0, 0, "", 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExecuteSpotSignalWithFillFee(s, -1, "BTC/USDT", 100, 0, 0, "", 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.TradeHistory) != 2 {
+		t.Fatalf("trades = %d", len(s.TradeHistory))
+	}
+	var fees, slip float64
+	for _, tr := range s.TradeHistory {
+		if want := slippageCost(tr.Side, tr.Quantity, 100, tr.Price); tr.SlippageCost != want {
+			t.Errorf("%s slippage = %v, want %v", tr.Side, tr.SlippageCost, want)
+		}
+		fees += tr.ExchangeFee
+		slip += tr.SlippageCost
+	}
+	if fees <= 0 || s.TotalFees != fees || s.TotalSlippage != slip {
+		t.Errorf("totals fees=%v slippage=%v, want %v / %v", s.TotalFees, s.TotalSlippage, fees, slip)
+	}
+	// Round trip at a flat signal price: gross is zero up to float noise.
+	if f := strategyFeeImpact(s, nil); f.grossPnL() > 1e-9 || f.grossPnL() < -1e-9 {
+		t.Errorf("gross = %v, net %v", f.grossPnL(), f.netPnL)
+	}
+
+	if got := slippageCost("buy", 2, 100, 100.5); got != 1 {
+		t.Errorf("buy slippage = %v", got)
+	}
+	if got := slippageCost("sell", 2, 100, 100.5); got != -1 {
+		t.Errorf("sell slippage = %v", got)
+	}
+}
+
+func TestFeeImpactPersistsAndDigest(t *testing.T) {
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	state := NewAppState()
+	state.Strategies["churn"] = &StrategyState{ID: "churn", Type: "spot", Cash: 990, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}, TotalFees: 12, TotalSlippage: 3}
+	state.Strategies["steady"] = &StrategyState{ID: "steady", Type: "spot", Cash: 1100, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}, TotalFees: 20}
+	state.Strategies["idle"] = &StrategyState{ID: "idle", Type: "spot", Cash: 1000, InitialCapital: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Strategies["churn"]; got.TotalFees != 12 || got.TotalSlippage != 3 {
+		t.Fatalf("loaded totals = %v / %v", got.TotalFees, got.TotalSlippage)
+	}
+
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "steady"}, {ID: "churn"}, {ID: "idle"}}}
+	out := digestFeeImpact(weeklyDigestInput{cfg: cfg, state: loaded})
+	want := "💸 **Gross vs net PnL (lifetime)**\n" +
+		"`churn` gross $+5.00 − fees $12.00 − slippage $3.00 = net $-10.00 (costs 300% of gross) ⚠️ profitable only before costs\n" +
+		"`steady` gross $+120.00 − fees $20.00 − slippage $0.00 = net $+100.00 (costs 17% of gross)"
+	if out != want {
+		t.Errorf("digest =\n%s\nwant\n%s", out, want)
+	}
+	if strings.Contains(out, "idle") {
+		t.Error("cost-free strategy listed")
+	}
+}
@@ -45,6 +45,17 @@ func ApplySlippage(price float64) float64 {
 	return price * (1 + slippage)
 }
 
+// slippageCost is the dollar cost of filling units at execPrice instead of
+// the signal price: positive when the fill was worse for side, negative when
+// better. Zero for live fills, which book at the venue price.
+func slippageCost(side string, units, signalPrice, execPrice float64) float64 {
+	cost := (execPrice - signalPrice) * units
+	if side == "sell" {
+		cost = -cost
+	}
+	return cost
+}
+
 // CalculateSpotFee calculates trading fee for spot trade (BinanceUS default).
 func CalculateSpotFee(value float64) float64 {
 	return value * BinanceSpotFeePct
@@ -0,0 +1,327 @@
+package main
+
+// grpc_admin: gRPC admin/status API alongside the HTTP server (#4935).
+//
+// The service (adminpb/admin.proto) exposes status, positions, and three
+// admin operations — pause/resume, close, and kill-switch reset. Every
+// operation reuses the core the dashboard and owner DM already run
+// (setStrategyPaused, runTradeAction, ManualResetKillSwitch), so gRPC adds a
+// transport, not a new set of guards.
+//
+// Authentication is mandatory: every call must carry
+// "authorization: Bearer <STATUS_AUTH_TOKEN>" metadata, and config
+// validation refuses grpc.enabled without the token. The listener defaults to
+// loopback; a non-loopback listen address requires TLS. The rotated token
+// (credential_rotation.go) applies to the next call. Restart required.
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"trading-scheduler/adminpb"
+)
+
+// defaultGRPCListen is the listen address when grpc.listen is unset.
+const defaultGRPCListen = "localhost:9098"
+
+// GRPCConfig is the top-level "grpc" block.
+type GRPCConfig struct {
+	Enabled     bool   `json:"enabled"`
+	Listen      string `json:"listen,omitempty"`        // host:port; default localhost:9098
+	TLSCertFile string `json:"tls_cert_file,omitempty"` // PEM cert; required with tls_key_file for a non-loopback listen
+	TLSKeyFile  string `json:"tls_key_file,omitempty"`
+}
+
+// grpcListenAddr returns the configured or default listen address.
+func grpcListenAddr(c *GRPCConfig) string {
+	if c == nil || c.Listen == "" {
+		return defaultGRPCListen
+	}
+	return c.Listen
+}
+
+// isLoopbackHost reports whether host only accepts local connections.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// grpcErrors validates the grpc block against the loaded status token.
+func grpcErrors(c *GRPCConfig, statusToken string) []string {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	var errs []string
+	if statusToken == "" {
+		errs = append(errs, "grpc.enabled requires STATUS_AUTH_TOKEN (every gRPC call is authenticated)")
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, "grpc.tls_cert_file and grpc.tls_key_file must be set together")
+	}
+	host, port, err := net.SplitHostPort(grpcListenAddr(c))
+	if err != nil {
+		return append(errs, fmt.Sprintf("grpc.listen %q must be host:port: %v", c.Listen, err))
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		errs = append(errs, fmt.Sprintf("grpc.listen %q has an invalid port", c.Listen))
+	}
+	if !isLoopbackHost(host) && c.TLSCertFile == "" {
+		errs = append(errs, fmt.Sprintf("grpc.listen %q is not loopback; set grpc.tls_cert_file/tls_key_file", c.Listen))
+	}
+	return errs
+}
+
+// grpcAdminServer implements adminpb.AdminServer on top of StatusServer.
+type grpcAdminServer struct {
+	adminpb.UnimplementedAdminServer
+	ss *StatusServer
+}
+
+// authUnaryInterceptor enforces the bearer token and refuses calls while
+// the daemon drains.
+func (ss *StatusServer) authUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isDraining() {
+		return nil, status.Error(codes.Unavailable, "scheduler is shutting down")
+	}
+	token := ss.currentStatusToken()
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "status token not configured")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var got string
+	if v := md.Get("authorization"); len(v) > 0 {
+		got = strings.TrimPrefix(v[0], "Bearer ")
+	}
+	if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return handler(ctx, req)
+}
+
+// StartGRPCAdmin listens and serves the admin API in the background. A
+// listen failure is logged and leaves gRPC unavailable, like the HTTP server.
+func (ss *StatusServer) StartGRPCAdmin(c *GRPCConfig) *grpc.Server {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(ss.authUnaryInterceptor)}
+	if c.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(c.TLSCertFile, c.TLSKeyFile)
+		if err != nil {
+			fmt.Printf("[grpc] WARNING: TLS setup failed: %v. gRPC admin API unavailable.\n", err)
+			return nil
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	addr := grpcListenAddr(c)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Printf("[grpc] WARNING: listen %s failed: %v. gRPC admin API unavailable.\n", addr, err)
+		return nil
+	}
+	srv := grpc.NewServer(opts...)
+	adminpb.RegisterAdminServer(srv, &grpcAdminServer{ss: ss})
+	fmt.Printf("[grpc] Admin API at %s (TLS=%v)\n", addr, c.TLSCertFile != "")
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			fmt.Printf("[grpc] server error: %v\n", err)
+		}
+	}()
+	return srv
+}
+
+// grpcActionError maps a *uiActionError's HTTP status to a gRPC status.
+func grpcActionError(err error) error {
+	ae, ok := err.(*uiActionError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.Internal
+	switch ae.status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict, http.StatusForbidden:
+		code = codes.FailedPrecondition
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.Error(code, ae.msg)
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func (g *grpcAdminServer) GetStatus(ctx context.Context, _ *adminpb.GetStatusRequest) (*adminpb.StatusReply, error) {
+	ss := g.ss
+	prices := ss.fetchLiveMarkPrices()
+	state := ss.readState()
+	ss.strategiesMu.RLock()
+	strategies := append([]StrategyConfig(nil), ss.strategies...)
+	ss.strategiesMu.RUnlock()
+
+	reply := &adminpb.StatusReply{
+		CycleCount:         int64(state.CycleCount),
+		LastCycleUnix:      unixOrZero(state.LastCycle),
+		TotalNotional:      PortfolioNotional(state.Strategies, prices),
+		PeakValue:          state.PortfolioRisk.PeakValue,
+		CurrentDrawdownPct: state.PortfolioRisk.CurrentDrawdownPct,
+		KillSwitchActive:   state.PortfolioRisk.KillSwitchActive,
+		KillSwitchReason:   state.PortfolioRisk.KillSwitchReason,
+		Version:            Version,
+	}
+	for _, s := range state.Strategies {
+		reply.TotalValue += displayStrategyValue(s, prices)
+	}
+	for _, sc := range strategies {
+		s := state.Strategies[sc.ID]
+		if s == nil {
+			continue
+		}
+		pv := displayStrategyValue(s, prices)
+		initCap := EffectiveInitialCapital(sc, s)
+		row := &adminpb.StrategySummary{
+			Id:             sc.ID,
+			Type:           sc.Type,
+			Platform:       sc.Platform,
+			Cash:           s.Cash,
+			InitialCapital: initCap,
+			PortfolioValue: pv,
+			Pnl:            pv - initCap,
+			OpenPositions:  int32(len(s.Positions) + len(s.OptionPositions)),
+			Paused:         sc.Paused,
+			CircuitBreaker: s.RiskState.CircuitBreaker,
+		}
+		if initCap > 0 {
+			row.PnlPct = row.Pnl / initCap * 100
+		}
+		if s.RiskState.CircuitBreaker {
+			row.CircuitBreakerUntilUnix = unixOrZero(s.RiskState.CircuitBreakerUntil)
+		}
+		reply.Strategies = append(reply.Strategies, row)
+	}
+	sort.Slice(reply.Strategies, func(i, j int) bool { return reply.Strategies[i].Id < reply.Strategies[j].Id })
+	return reply, nil
+}
+
+func (g *grpcAdminServer) ListPositions(ctx context.Context, req *adminpb.ListPositionsRequest) (*adminpb.ListPositionsReply, error) {
+	ss := g.ss
+	if id := req.GetStrategyId(); id != "" {
+		if _, ok := ss.strategyConfig(id); !ok {
+			return nil, status.Error(codes.NotFound, "strategy not found")
+		}
+	}
+	prices := ss.fetchLiveMarkPrices()
+	state := ss.readState()
+	reply := &adminpb.ListPositionsReply{}
+	for id, s := range state.Strategies {
+		if req.GetStrategyId() != "" && id != req.GetStrategyId() {
+			continue
+		}
+		for _, pos := range s.Positions {
+			p := &adminpb.Position{
+				StrategyId:   id,
+				Symbol:       pos.Symbol,
+				Side:         pos.Side,
+				Quantity:     pos.Quantity,
+				AvgCost:      pos.AvgCost,
+				Leverage:     pos.Leverage,
+				OpenedAtUnix: unixOrZero(pos.OpenedAt),
+			}
+			if mark := prices[pos.Symbol]; mark > 0 {
+				p.Mark = mark
+				p.UnrealizedPnl = positionUnrealizedPnL(pos, mark)
+			}
+			reply.Positions = append(reply.Positions, p)
+		}
+	}
+	sort.Slice(reply.Positions, func(i, j int) bool {
+		a, b := reply.Positions[i], reply.Positions[j]
+		if a.StrategyId != b.StrategyId {
+			return a.StrategyId < b.StrategyId
+		}
+		return a.Symbol < b.Symbol
+	})
+	return reply, nil
+}
+
+func (g *grpcAdminServer) PauseStrategy(ctx context.Context, req *adminpb.PauseStrategyRequest) (*adminpb.AdminReply, error) {
+	msg, err := g.ss.setStrategyPaused(req.GetStrategyId(), req.GetPaused())
+	if err != nil {
+		return nil, grpcActionError(err)
+	}
+	return &adminpb.AdminReply{Message: msg}, nil
+}
+
+// CloseStrategy picks the close core by strategy kind: type=manual uses the
+// manual close, everything else goes through force-close (live HL perps
+// only; the core refuses other venues with a clear message).
+func (g *grpcAdminServer) CloseStrategy(ctx context.Context, req *adminpb.CloseStrategyRequest) (*adminpb.AdminReply, error) {
+	sc, ok := g.ss.strategyConfig(req.GetStrategyId())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "strategy not found")
+	}
+	if req.GetQty() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "qty must be non-negative")
+	}
+	action := "force-close"
+	if sc.Type == "manual" {
+		action = "close"
+	}
+	var params map[string]json.RawMessage
+	if req.GetQty() > 0 {
+		params = map[string]json.RawMessage{"qty": json.RawMessage(strconv.FormatFloat(req.GetQty(), 'f', -1, 64))}
+	}
+	res, err := g.ss.runTradeAction(sc.ID, action, params)
+	if err != nil {
+		return nil, grpcActionError(err)
+	}
+	return &adminpb.AdminReply{Message: res.uiMessage(), Queued: res.queued}, nil
+}
+
+func (g *grpcAdminServer) ResetKillSwitch(ctx context.Context, req *adminpb.ResetKillSwitchRequest) (*adminpb.AdminReply, error) {
+	ss := g.ss
+	details := "manual reset via gRPC"
+	if note := strings.TrimSpace(req.GetNote()); note != "" {
+		details += ": " + note
+	}
+	defer ss.snapshot.expire()
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if !ManualResetKillSwitch(&ss.state.PortfolioRisk, details) {
+		return nil, status.Error(codes.FailedPrecondition, "kill switch is not latched")
+	}
+	if ss.stateDB != nil {
+		if err := SaveStateWithDB(ss.state, ss.uiTradeConfig(), ss.stateDB); err != nil {
+			fmt.Printf("[CRITICAL] Failed to save state after kill switch reset: %v\n", err)
+			return nil, status.Errorf(codes.Internal, "kill switch cleared in memory but save failed: %v", err)
+		}
+	}
+	fmt.Println("[grpc] Kill switch reset via admin API")
+	if ss.uiNotifier != nil {
+		ss.uiNotifier.SendOwnerDM("Kill switch reset via gRPC admin API. Trading will resume next cycle.")
+	}
+	return &adminpb.AdminReply{Message: "Kill switch reset. Trading will resume next cycle."}, nil
+}
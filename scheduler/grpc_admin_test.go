@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"trading-scheduler/adminpb"
+)
+
+func TestGRPCErrors(t *testing.T) {
+	if errs := grpcErrors(&GRPCConfig{Enabled: true}, "tok"); len(errs) != 0 {
+		t.Fatalf("default config rejected: %v", errs)
+	}
+	if errs := grpcErrors(&GRPCConfig{Enabled: false, Listen: "bogus"}, ""); len(errs) != 0 {
+		t.Fatalf("disabled block validated: %v", errs)
+	}
+	errs := grpcErrors(&GRPCConfig{Enabled: true, Listen: "0.0.0.0:99999", TLSKeyFile: "k.pem"}, "")
+	if len(errs) != 4 {
+		t.Fatalf("errs = %v, want token, tls pair, port, non-loopback", errs)
+	}
+}
+
+func dialTestAdmin(t *testing.T, ss *StatusServer) adminpb.AdminClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(ss.authUnaryInterceptor))
+	adminpb.RegisterAdminServer(srv, &grpcAdminServer{ss: ss})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return adminpb.NewAdminClient(conn)
+}
+
+func TestGRPCAdminStatusPositionsAndReset(t *testing.T) {
+	state := NewAppState()
+	state.CycleCount = 7
+	state.PortfolioRisk = PortfolioRiskState{PeakValue: 1000, KillSwitchActive: true, KillSwitchReason: "drawdown"}
+	state.Strategies["hl-a"] = &StrategyState{
+		ID: "hl-a", Cash: 500,
+		Positions:       map[string]*Position{"ETH": {Symbol: "ETH", Quantity: 1, AvgCost: 2000, Side: "long"}},
+		OptionPositions: map[string]*OptionPosition{},
+	}
+	var mu sync.RWMutex
+	ss := NewStatusServer(state, &mu, "secret", []StrategyConfig{{ID: "hl-a", Type: "perps", Platform: "hyperliquid", Capital: 2500, Paused: true}}, nil)
+	client := dialTestAdmin(t, ss)
+
+	if _, err := client.GetStatus(context.Background(), &adminpb.GetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no token: err = %v", err)
+	}
+	bad := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer nope")
+	if _, err := client.GetStatus(bad, &adminpb.GetStatusRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("wrong token: err = %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	st, err := client.GetStatus(ctx, &adminpb.GetStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.CycleCount != 7 || !st.KillSwitchActive || len(st.Strategies) != 1 || !st.Strategies[0].Paused || st.Strategies[0].OpenPositions != 1 {
+		t.Fatalf("status = %+v", st)
+	}
+
+	pos, err := client.ListPositions(ctx, &adminpb.ListPositionsRequest{StrategyId: "hl-a"})
+	if err != nil || len(pos.Positions) != 1 || pos.Positions[0].Symbol != "ETH" || pos.Positions[0].AvgCost != 2000 {
+		t.Fatalf("positions = %+v, %v", pos, err)
+	}
+	if _, err := client.ListPositions(ctx, &adminpb.ListPositionsRequest{StrategyId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown strategy: err = %v", err)
+	}
+
+	if _, err := client.ResetKillSwitch(ctx, &adminpb.ResetKillSwitchRequest{Note: "checked positions"}); err != nil {
+		t.Fatal(err)
+	}
+	if state.PortfolioRisk.KillSwitchActive || len(state.PortfolioRisk.Events) != 1 || state.PortfolioRisk.Events[0].Details != "manual reset via gRPC: checked positions" {
+		t.Fatalf("after reset: %+v", state.PortfolioRisk)
+	}
+	if _, err := client.ResetKillSwitch(ctx, &adminpb.ResetKillSwitchRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("second reset: err = %v", err)
+	}
+
+	// No config path wired: pause is refused, not silently dropped.
+	if _, err := client.PauseStrategy(ctx, &adminpb.PauseStrategyRequest{StrategyId: "hl-a", Paused: false}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("pause without config path: err = %v", err)
+	}
+	if _, err := client.CloseStrategy(ctx, &adminpb.CloseStrategyRequest{StrategyId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("close unknown: err = %v", err)
+	}
+}
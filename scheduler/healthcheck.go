@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// defaultHealthcheckTimeout bounds one ping. The ping runs off the main loop,
+// so this only caps how long a wedged endpoint can hold the in-flight slot.
+const defaultHealthcheckTimeout = 10 * time.Second
+
+// HealthcheckConfig configures the external dead-man's-switch ping: a GET to
+// URL after every cycle that completed and saved state, and a GET to the
+// failure URL when a cycle was skipped or could not persist. A hung scheduler
+// stops pinging entirely, so the external service (healthchecks.io, Uptime
+// Kuma push monitor, …) raises the alarm even when /health is unreachable.
+type HealthcheckConfig struct {
+	URL            string `json:"url"`                       // success ping URL (healthchecks.io check URL, Uptime Kuma push URL, …)
+	FailURL        string `json:"fail_url,omitempty"`        // failure ping URL; empty → URL + "/fail" (healthchecks.io convention)
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // per-ping HTTP timeout; 0/omitted → 10
+}
+
+func (h *HealthcheckConfig) enabled() bool {
+	return h != nil && strings.TrimSpace(h.URL) != ""
+}
+
+// resolvedFailURL returns the failure ping URL. healthchecks.io signals
+// failure on "<check-url>/fail"; services with a different convention (e.g.
+// Uptime Kuma's "?status=down") set fail_url explicitly.
+func (h *HealthcheckConfig) resolvedFailURL() string {
+	if f := strings.TrimSpace(h.FailURL); f != "" {
+		return f
+	}
+	u, err := url.Parse(strings.TrimSpace(h.URL))
+	if err != nil {
+		return strings.TrimRight(strings.TrimSpace(h.URL), "/") + "/fail"
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/fail"
+	return u.String()
+}
+
+func (h *HealthcheckConfig) timeout() time.Duration {
+	if h.TimeoutSeconds > 0 {
+		return time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	return defaultHealthcheckTimeout
+}
+
+// validateHealthcheckConfig checks the optional healthcheck block. Nil or an
+// empty url is "disabled" and always valid.
+func validateHealthcheckConfig(h *HealthcheckConfig) []string {
+	if h == nil {
+		return nil
+	}
+	var errs []string
+	if strings.TrimSpace(h.URL) == "" {
+		if strings.TrimSpace(h.FailURL) != "" {
+			errs = append(errs, "healthcheck.fail_url requires healthcheck.url")
+		}
+	} else if err := validateHealthcheckURL(h.URL); err != nil {
+		errs = append(errs, fmt.Sprintf("healthcheck.url: %v", err))
+	}
+	if strings.TrimSpace(h.FailURL) != "" {
+		if err := validateHealthcheckURL(h.FailURL); err != nil {
+			errs = append(errs, fmt.Sprintf("healthcheck.fail_url: %v", err))
+		}
+	}
+	if h.TimeoutSeconds < 0 {
+		errs = append(errs, fmt.Sprintf("healthcheck.timeout_seconds must be >= 0 (0 = default %s), got %d", defaultHealthcheckTimeout, h.TimeoutSeconds))
+	}
+	return errs
+}
+
+func validateHealthcheckURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must be an http(s) URL, got %q", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host in %q", raw)
+	}
+	return nil
+}
+
+// healthcheckPinger sends cycle pings. At most one ping is in flight: if the
+// endpoint is slow enough that the previous ping has not returned by the next
+// cycle, the new ping is dropped rather than stacking goroutines.
+type healthcheckPinger struct {
+	inflight atomic.Bool
+	client   func(timeout time.Duration) *http.Client
+}
+
+func newHealthcheckPinger() *healthcheckPinger {
+	return &healthcheckPinger{client: func(timeout time.Duration) *http.Client {
+		return &http.Client{Timeout: timeout}
+	}}
+}
+
+// Ping sends the success ping when failure is empty, else the failure ping.
+// Blocks for the HTTP round-trip — the main loop calls it via `go` except on
+// --once, where the process would exit before an async ping landed. Errors
+// are logged, never returned: an unreachable monitor must not affect trading.
+func (p *healthcheckPinger) Ping(hc *HealthcheckConfig, failure string) {
+	if !hc.enabled() {
+		return
+	}
+	if !p.inflight.CompareAndSwap(false, true) {
+		fmt.Println("[WARN] healthcheck: previous ping still in flight, skipping this cycle's ping")
+		return
+	}
+	defer p.inflight.Store(false)
+
+	target := strings.TrimSpace(hc.URL)
+	kind := "success"
+	if failure != "" {
+		target = hc.resolvedFailURL()
+		kind = "failure"
+	}
+	resp, err := p.client(hc.timeout()).Get(target)
+	if err != nil {
+		// *url.Error embeds the full ping URL (the check's secret); log only
+		// the underlying cause.
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		fmt.Printf("[WARN] healthcheck: %s ping failed: %v\n", kind, err)
+		return
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		fmt.Printf("[WARN] healthcheck: %s ping returned HTTP %d\n", kind, resp.StatusCode)
+		return
+	}
+	if failure != "" {
+		fmt.Printf("[healthcheck] Sent failure ping: %s\n", failure)
+	}
+}
+
+func cloneHealthcheckConfig(h *HealthcheckConfig) *HealthcheckConfig {
+	if h == nil {
+		return nil
+	}
+	cp := *h
+	return &cp
+}
+
+// formatHealthcheckConfig renders the block for reload change logs. Ping URLs
+// embed the check's secret UUID/token, so only the host is shown.
+func formatHealthcheckConfig(h *HealthcheckConfig) string {
+	if !h.enabled() {
+		return "disabled"
+	}
+	host := "?"
+	if u, err := url.Parse(strings.TrimSpace(h.URL)); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return fmt.Sprintf("enabled(host=%s, timeout=%s)", host, h.timeout())
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newHealthcheckTestServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.RequestURI())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func TestHealthcheckPingSuccessAndDefaultFailURL(t *testing.T) {
+	srv, paths := newHealthcheckTestServer(t)
+	hc := &HealthcheckConfig{URL: srv.URL + "/ping/abc-123"}
+	p := newHealthcheckPinger()
+
+	p.Ping(hc, "")
+	p.Ping(hc, "state save failed")
+
+	got := paths()
+	want := []string{"/ping/abc-123", "/ping/abc-123/fail"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("pinged %v, want %v", got, want)
+	}
+}
+
+func TestHealthcheckPingExplicitFailURL(t *testing.T) {
+	srv, paths := newHealthcheckTestServer(t)
+	hc := &HealthcheckConfig{
+		URL:     srv.URL + "/api/push/tok?status=up",
+		FailURL: srv.URL + "/api/push/tok?status=down",
+	}
+	newHealthcheckPinger().Ping(hc, "price fetch failed")
+	if got := paths(); len(got) != 1 || got[0] != "/api/push/tok?status=down" {
+		t.Errorf("pinged %v, want explicit fail_url", got)
+	}
+}
+
+func TestHealthcheckPingDisabledAndInflightSkip(t *testing.T) {
+	srv, paths := newHealthcheckTestServer(t)
+	p := newHealthcheckPinger()
+	p.Ping(nil, "")
+	p.Ping(&HealthcheckConfig{}, "")
+
+	p.inflight.Store(true)
+	p.Ping(&HealthcheckConfig{URL: srv.URL + "/x"}, "")
+	if got := paths(); len(got) != 0 {
+		t.Errorf("pinged %v, want nothing (disabled / in flight)", got)
+	}
+}
+
+func TestHealthcheckResolvedFailURLKeepsQuery(t *testing.T) {
+	hc := &HealthcheckConfig{URL: "https://hc-ping.com/uuid/?rid=1"}
+	if got := hc.resolvedFailURL(); got != "https://hc-ping.com/uuid/fail?rid=1" {
+		t.Errorf("resolvedFailURL = %q", got)
+	}
+}
+
+func TestValidateHealthcheckConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		hc   *HealthcheckConfig
+		want string
+	}{
+		{"nil", nil, ""},
+		{"disabled", &HealthcheckConfig{}, ""},
+		{"valid", &HealthcheckConfig{URL: "https://hc-ping.com/uuid", TimeoutSeconds: 5}, ""},
+		{"bad scheme", &HealthcheckConfig{URL: "ftp://example.com/x"}, "healthcheck.url"},
+		{"no host", &HealthcheckConfig{URL: "https:///x"}, "missing host"},
+		{"fail without url", &HealthcheckConfig{FailURL: "https://example.com/f"}, "requires healthcheck.url"},
+		{"negative timeout", &HealthcheckConfig{URL: "https://example.com", TimeoutSeconds: -1}, "timeout_seconds"},
+	}
+	for _, c := range cases {
+		errs := validateHealthcheckConfig(c.hc)
+		joined := strings.Join(errs, "; ")
+		if c.want == "" && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", c.name, errs)
+		}
+		if c.want != "" && !strings.Contains(joined, c.want) {
+			t.Errorf("%s: errors %q, want substring %q", c.name, joined, c.want)
+		}
+	}
+}
+
+func TestApplyHotReloadConfigAdoptsHealthcheck(t *testing.T) {
+	cfg := &Config{IntervalSeconds: 300}
+	next := &Config{IntervalSeconds: 300, Healthcheck: &HealthcheckConfig{URL: "https://hc-ping.com/secret-uuid"}}
+	changes, err := applyHotReloadConfig(cfg, next, NewAppState(), nil, nil)
+	if err != nil {
+		t.Fatalf("applyHotReloadConfig: %v", err)
+	}
+	if !cfg.Healthcheck.enabled() || cfg.Healthcheck == next.Healthcheck {
+		t.Fatalf("healthcheck not adopted as a copy: %+v", cfg.Healthcheck)
+	}
+	joined := strings.Join(changes, "\n")
+	if !strings.Contains(joined, "healthcheck: disabled -> enabled(host=hc-ping.com") {
+		t.Errorf("changes = %q", joined)
+	}
+	if strings.Contains(joined, "secret-uuid") {
+		t.Errorf("reload log leaked the ping token: %q", joined)
+	}
+}
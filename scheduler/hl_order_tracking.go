@@ -0,0 +1,317 @@
+package main
+
+// hl_order_tracking: post-execute order tracking for live HL strategies (#4956).
+//
+// --execute returns one synchronous snapshot of the order. Anything that
+// happened after it — a resting remainder filling later, or userFills
+// reporting more size than the placeOrder response did — used to be invisible
+// until the on-chain position reconcile noticed a quantity drift. When the
+// execute result shows a partial fill or a resting OID on a position-
+// increasing order, the order is persisted to hl_tracked_orders. Each cycle
+// reconcileTrackedHyperliquidOrders polls it via --limit-status (the #883
+// machinery), books any fill beyond the recorded watermark into the owning
+// position as a scale_in leg, re-syncs protection, and drops the row once the
+// order is off the book. A remainder still resting after
+// hlTrackedOrderMaxAge is cancelled.
+//
+// Closes are not tracked: a short close leaves the virtual position larger
+// than on-chain, which the existing position reconcile already books.
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// hlTrackedOrderMaxAge bounds how long a resting remainder is left on the
+// book before the scheduler cancels it.
+const hlTrackedOrderMaxAge = 24 * time.Hour
+
+// HLTrackedOrder is a row from hl_tracked_orders.
+type HLTrackedOrder struct {
+	ID           int64
+	StrategyID   string
+	Symbol       string
+	Side         string // "long" | "short" — the position side the order grows
+	OrderOID     int64
+	OrderSize    float64
+	FilledSize   float64 // cumulative qty already booked into the position
+	AvgFillPrice float64 // size-weighted avg of FilledSize
+	FillFee      float64 // cumulative fee already booked
+	CreatedAt    time.Time
+}
+
+// trackedOrderFromExecution returns the row to track for a live execute
+// result, or ok=false when the order needs no follow-up (fully filled,
+// rejected, or not an open/add). posSide is the strategy's position side
+// after the fill was applied ("" when flat).
+func trackedOrderFromExecution(strategyID, symbol string, execResult *HyperliquidExecuteResult, posSide string, now time.Time) (HLTrackedOrder, bool) {
+	if execResult == nil || execResult.Execution == nil || execResult.Execution.Fill == nil {
+		return HLTrackedOrder{}, false
+	}
+	exec := execResult.Execution
+	fill := exec.Fill
+	side := "long"
+	if exec.Action == "sell" {
+		side = "short"
+	}
+	if posSide != side {
+		return HLTrackedOrder{}, false
+	}
+	oid := fill.OID
+	if oid == 0 {
+		oid = fill.RestingOID
+	}
+	if oid == 0 {
+		return HLTrackedOrder{}, false
+	}
+	if fill.RestingOID == 0 && limitOrderFullyFilled(fill.TotalSz, exec.Size) {
+		return HLTrackedOrder{}, false
+	}
+	return HLTrackedOrder{
+		StrategyID:   strategyID,
+		Symbol:       symbol,
+		Side:         side,
+		OrderOID:     oid,
+		OrderSize:    exec.Size,
+		FilledSize:   fill.TotalSz,
+		AvgFillPrice: fill.AvgPx,
+		FillFee:      fill.Fee,
+		CreatedAt:    now.UTC(),
+	}, true
+}
+
+// trackHyperliquidExecution persists execResult's order for follow-up polling
+// when it was partially filled or left resting. Call after the fill has been
+// applied to stratState, outside mu.
+func trackHyperliquidExecution(stateDB *StateDB, sc StrategyConfig, stratState *StrategyState, symbol string, execResult *HyperliquidExecuteResult, mu *sync.RWMutex, logger *StrategyLogger) {
+	if stateDB == nil {
+		return
+	}
+	mu.RLock()
+	posSide := ""
+	if pos := stratState.Positions[symbol]; pos != nil {
+		posSide = pos.Side
+	}
+	mu.RUnlock()
+	o, ok := trackedOrderFromExecution(sc.ID, symbol, execResult, posSide, time.Now())
+	if !ok {
+		return
+	}
+	if _, err := stateDB.InsertHLTrackedOrder(o); err != nil {
+		logger.Warn("Order tracking: failed to persist oid=%d: %v", o.OrderOID, err)
+		return
+	}
+	logger.Info("Order tracking: oid=%d filled %.6f of %.6f %s — polling for further fills", o.OrderOID, o.FilledSize, o.OrderSize, symbol)
+}
+
+// applyTrackedOrderFill grows the tracked order's position by the fill beyond
+// the watermark and books it as a scale_in leg. MUST be called with the state
+// write lock held. Returns the number of trades booked (0 or 1).
+func applyTrackedOrderFill(ss *StrategyState, o HLTrackedOrder, cumFilled, avgPx, cumFee float64, now time.Time) (int, error) {
+	pos := ss.Positions[o.Symbol]
+	if pos == nil || pos.Side != o.Side {
+		return 0, fmt.Errorf("late fill for oid=%d but %s %s position is gone — leaving it to the position reconcile", o.OrderOID, o.Side, o.Symbol)
+	}
+	deltaQty := cumFilled - o.FilledSize
+	// Price of just the new fills, backed out of the cumulative VWAP.
+	deltaPx := (cumFilled*avgPx - o.FilledSize*o.AvgFillPrice) / deltaQty
+	if deltaPx <= 0 {
+		deltaPx = avgPx
+	}
+	deltaFee := cumFee - o.FillFee
+	if deltaFee < 0 {
+		deltaFee = 0
+	}
+	newQty := pos.Quantity + deltaQty
+	pos.AvgCost = (pos.Quantity*pos.AvgCost + deltaQty*deltaPx) / newQty
+	pos.Quantity = newQty
+	pos.InitialQuantity += deltaQty
+
+	trade := Trade{
+		Timestamp:       now,
+		StrategyID:      o.StrategyID,
+		Symbol:          o.Symbol,
+		Side:            openTradeSide(o.Side),
+		Quantity:        deltaQty,
+		Price:           deltaPx,
+		Value:           deltaQty * deltaPx,
+		TradeType:       scaleInTradeType,
+		Details:         fmt.Sprintf("late fill %s %s +%.6f @ $%.4f (oid=%d, %.6f of %.6f)", o.Side, o.Symbol, deltaQty, deltaPx, o.OrderOID, cumFilled, o.OrderSize),
+		PositionID:      ensurePositionTradeID(o.StrategyID, o.Symbol, pos),
+		ExchangeOrderID: fmt.Sprintf("%d", o.OrderOID),
+		ExchangeFee:     deltaFee,
+		FeeSource:       FeeSourceUserFills,
+		PnLGross:        true,
+		EntryATR:        pos.EntryATR,
+	}
+	RecordTrade(ss, trade)
+	ss.Cash -= deltaFee
+	return 1, nil
+}
+
+// reconcileTrackedHyperliquidOrders polls every tracked order, books late
+// fills into the owning position, and drops orders that are off the book.
+// Runs at the top of each cycle next to the #883 limit reconcile and follows
+// its locking: network calls outside mu, position mutation under mu.Lock.
+// Returns one manualAlert per strategy that booked a fill.
+func reconcileTrackedHyperliquidOrders(state *AppState, cfg *Config, stateDB *StateDB, mu *sync.RWMutex, notifier *MultiNotifier, logMgr *LogManager) []manualAlert {
+	if stateDB == nil {
+		return nil
+	}
+	orders, err := stateDB.LoadHLTrackedOrders()
+	if err != nil {
+		fmt.Printf("[order-track] failed to load tracked orders: %v\n", err)
+		return nil
+	}
+	if len(orders) == 0 {
+		return nil
+	}
+	scByID := make(map[string]StrategyConfig, len(cfg.Strategies))
+	for _, sc := range cfg.Strategies {
+		scByID[sc.ID] = sc
+	}
+
+	now := time.Now().UTC()
+	applied := make(map[string]*manualAlert)
+	var order []string
+	for _, o := range orders {
+		sc, ok := scByID[o.StrategyID]
+		if !ok || !hyperliquidIsLive(sc.Args) {
+			// Strategy removed or no longer live: nothing can book a fill.
+			fmt.Printf("[order-track] dropping oid=%d: strategy %q missing or not HL-live\n", o.OrderOID, o.StrategyID)
+			stateDB.DeleteHLTrackedOrder(o.ID)
+			continue
+		}
+		var logger *StrategyLogger
+		if logMgr != nil {
+			logger, _ = logMgr.GetStrategyLogger(o.StrategyID)
+		}
+
+		statusRes, stderr, perr := runHyperliquidLimitStatusFn(sc.Script, o.Symbol, []int64{o.OrderOID}, limitStatusSinceMs(o.CreatedAt))
+		if stderr != "" {
+			fmt.Fprintf(os.Stderr, "[order-track] %s status stderr: %s\n", o.StrategyID, stderr)
+		}
+		if perr != nil || statusRes == nil || statusRes.Error != "" || len(statusRes.Orders) == 0 {
+			msg := ""
+			if statusRes != nil {
+				msg = statusRes.Error
+			}
+			fmt.Printf("[order-track] status poll failed for %s oid=%d: %v %s\n", o.StrategyID, o.OrderOID, perr, msg)
+			continue
+		}
+		st := statusRes.Orders[0]
+
+		if st.FilledSize > o.FilledSize+limitFillEpsilon {
+			avgPx := st.AvgPx
+			if avgPx <= 0 {
+				avgPx = o.AvgFillPrice
+			}
+			mu.Lock()
+			ss := state.Strategies[o.StrategyID]
+			var booked int
+			var applyErr error
+			if ss == nil {
+				applyErr = fmt.Errorf("strategy state for %q not found", o.StrategyID)
+			} else {
+				booked, applyErr = applyTrackedOrderFill(ss, o, st.FilledSize, avgPx, st.Fee, now)
+			}
+			mu.Unlock()
+			if applyErr != nil {
+				warnNotifier(notifier, fmt.Sprintf("[order-track] %s %s: %v", o.StrategyID, o.Symbol, applyErr))
+				stateDB.DeleteHLTrackedOrder(o.ID)
+				continue
+			}
+			if err := stateDB.UpdateHLTrackedOrderFill(o.ID, st.FilledSize, avgPx, st.Fee); err != nil {
+				fmt.Printf("[order-track] failed to persist fill watermark for oid=%d: %v\n", o.OrderOID, err)
+			}
+			fmt.Printf("[order-track] %s oid=%d late fill: %.6f → %.6f %s\n", o.StrategyID, o.OrderOID, o.FilledSize, st.FilledSize, o.Symbol)
+			o.FilledSize = st.FilledSize
+			runHyperliquidProtectionSync(sc, ss, stateDB, o.Symbol, mu, notifier, logger, "HL protection synced after late fill", nil)
+			if ma := applied[o.StrategyID]; ma == nil {
+				applied[o.StrategyID] = &manualAlert{sc: sc, ss: ss, trades: booked}
+				order = append(order, o.StrategyID)
+			} else {
+				ma.trades += booked
+			}
+		}
+
+		if st.Resting != nil && !*st.Resting {
+			if !limitOrderFullyFilled(o.FilledSize, o.OrderSize) {
+				fmt.Printf("[order-track] %s oid=%d off the book at %.6f of %.6f %s (remainder cancelled)\n",
+					o.StrategyID, o.OrderOID, o.FilledSize, o.OrderSize, o.Symbol)
+			}
+			if err := stateDB.DeleteHLTrackedOrder(o.ID); err != nil {
+				fmt.Printf("[order-track] failed to delete oid=%d: %v\n", o.OrderOID, err)
+			}
+			continue
+		}
+
+		// Still resting (or book state unknown). Cancel a stale remainder; the
+		// next poll observes resting=false, books any last fill, and drops it.
+		if st.Resting != nil && now.Sub(o.CreatedAt) > hlTrackedOrderMaxAge {
+			cancelRes, cstderr, cerr := runHyperliquidCancelOrderFn(sc.Script, o.Symbol, o.OrderOID)
+			if cstderr != "" {
+				fmt.Fprintf(os.Stderr, "[order-track] %s cancel stderr: %s\n", o.StrategyID, cstderr)
+			}
+			if cerr != nil || cancelRes == nil || cancelRes.Error != "" {
+				fmt.Printf("[order-track] cancel failed for %s oid=%d: %v — will retry\n", o.StrategyID, o.OrderOID, cerr)
+				continue
+			}
+			fmt.Printf("[order-track] %s oid=%d resting > %s, cancel issued\n", o.StrategyID, o.OrderOID, hlTrackedOrderMaxAge)
+		}
+	}
+
+	alerts := make([]manualAlert, 0, len(order))
+	for _, id := range order {
+		alerts = append(alerts, *applied[id])
+	}
+	return alerts
+}
+
+// InsertHLTrackedOrder persists an order for follow-up polling.
+func (sdb *StateDB) InsertHLTrackedOrder(o HLTrackedOrder) (int64, error) {
+	res, err := sdb.db.Exec(`INSERT INTO hl_tracked_orders
+		(strategy_id, symbol, side, order_oid, order_size, filled_size, avg_fill_price, fill_fee, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		o.StrategyID, o.Symbol, o.Side, o.OrderOID, o.OrderSize, o.FilledSize, o.AvgFillPrice, o.FillFee,
+		formatTime(o.CreatedAt.UTC()))
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// LoadHLTrackedOrders returns all tracked orders ordered by id.
+func (sdb *StateDB) LoadHLTrackedOrders() ([]HLTrackedOrder, error) {
+	rows, err := sdb.db.Query(`SELECT id, strategy_id, symbol, side, order_oid, order_size, filled_size, avg_fill_price, fill_fee, created_at FROM hl_tracked_orders ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("load tracked orders: %w", err)
+	}
+	defer rows.Close()
+	var orders []HLTrackedOrder
+	for rows.Next() {
+		var o HLTrackedOrder
+		var createdStr string
+		if err := rows.Scan(&o.ID, &o.StrategyID, &o.Symbol, &o.Side, &o.OrderOID, &o.OrderSize, &o.FilledSize, &o.AvgFillPrice, &o.FillFee, &createdStr); err != nil {
+			return nil, fmt.Errorf("scan tracked order: %w", err)
+		}
+		o.CreatedAt = parseTime(createdStr)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+// UpdateHLTrackedOrderFill advances a tracked order's fill watermark.
+func (sdb *StateDB) UpdateHLTrackedOrderFill(id int64, filledSize, avgFillPrice, fillFee float64) error {
+	_, err := sdb.db.Exec("UPDATE hl_tracked_orders SET filled_size = ?, avg_fill_price = ?, fill_fee = ? WHERE id = ?",
+		filledSize, avgFillPrice, fillFee, id)
+	return err
+}
+
+// DeleteHLTrackedOrder removes a finished tracked order.
+func (sdb *StateDB) DeleteHLTrackedOrder(id int64) error {
+	_, err := sdb.db.Exec("DELETE FROM hl_tracked_orders WHERE id = ?", id)
+	return err
+}
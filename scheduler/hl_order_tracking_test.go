@@ -0,0 +1,140 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func hlTrackExecResult(action string, size, filled float64, oid, restingOID int64) *HyperliquidExecuteResult {
+	return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{
+		Action: action, Symbol: "ETH", Size: size,
+		Fill: &HyperliquidFill{AvgPx: 2000, TotalSz: filled, OID: oid, RestingOID: restingOID},
+	}}
+}
+
+func TestTrackedOrderFromExecution(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name    string
+		res     *HyperliquidExecuteResult
+		posSide string
+		want    bool
+	}{
+		{"full fill", hlTrackExecResult("buy", 1, 1, 7, 0), "long", false},
+		{"partial open", hlTrackExecResult("buy", 1, 0.4, 7, 0), "long", true},
+		{"resting, nothing filled", hlTrackExecResult("sell", 1, 0, 0, 8), "short", true},
+		{"partial close leaves opposite side", hlTrackExecResult("sell", 1, 0.4, 7, 0), "long", false},
+		{"partial close to flat", hlTrackExecResult("sell", 1, 0.4, 7, 0), "", false},
+		{"no oid", hlTrackExecResult("buy", 1, 0.4, 0, 0), "long", false},
+		{"no execution", &HyperliquidExecuteResult{}, "long", false},
+	}
+	for _, c := range cases {
+		o, ok := trackedOrderFromExecution("s", "ETH", c.res, c.posSide, now)
+		if ok != c.want {
+			t.Errorf("%s: tracked = %v, want %v", c.name, ok, c.want)
+		}
+		if ok && c.res.Execution.Fill.RestingOID > 0 && o.OrderOID != c.res.Execution.Fill.RestingOID {
+			t.Errorf("%s: oid = %d, want resting oid", c.name, o.OrderOID)
+		}
+	}
+}
+
+func TestReconcileTrackedHyperliquidOrders_LateFillThenDone(t *testing.T) {
+	sc, state := newLimitTestStrategy()
+	sc.Type = "perps"
+	cfg := &Config{Strategies: []StrategyConfig{sc}}
+	db := newLimitTestStateDB(t)
+	var mu sync.RWMutex
+	ss := state.Strategies[sc.ID]
+	ss.Positions["ETH"] = &Position{Symbol: "ETH", Side: "long", Quantity: 0.4, InitialQuantity: 0.4, AvgCost: 2000, Multiplier: 1, OwnerStrategyID: sc.ID}
+	db.InsertHLTrackedOrder(HLTrackedOrder{
+		StrategyID: sc.ID, Symbol: "ETH", Side: "long", OrderOID: 7,
+		OrderSize: 1, FilledSize: 0.4, AvgFillPrice: 2000, FillFee: 0.1, CreatedAt: time.Now(),
+	})
+
+	// Cycle 1: cumulative 1.0 @ 2006 → the new 0.6 filled @ 2010.
+	withStubbedLimitDeps(t,
+		func(string, string, []int64, int64) (*HyperliquidLimitStatusResult, string, error) {
+			return &HyperliquidLimitStatusResult{Orders: []HyperliquidLimitOrderStatus{
+				{OID: 7, Resting: limitTestBoolPtr(true), FilledSize: 1.0, AvgPx: 2006, Fee: 0.4, Count: 2},
+			}}, "", nil
+		},
+		func(string, string, int64) (*HyperliquidCancelOrderResult, string, error) {
+			t.Error("fresh order should not be cancelled")
+			return &HyperliquidCancelOrderResult{}, "", nil
+		},
+	)
+	alerts := reconcileTrackedHyperliquidOrders(state, cfg, db, &mu, nil, nil)
+	if len(alerts) != 1 || alerts[0].trades != 1 {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+	pos := ss.Positions["ETH"]
+	if math.Abs(pos.Quantity-1.0) > 1e-9 || math.Abs(pos.AvgCost-2006) > 1e-6 {
+		t.Fatalf("position = %+v", pos)
+	}
+	last := ss.TradeHistory[len(ss.TradeHistory)-1]
+	if last.TradeType != scaleInTradeType || math.Abs(last.Quantity-0.6) > 1e-9 || math.Abs(last.Price-2010) > 1e-6 ||
+		math.Abs(last.ExchangeFee-0.3) > 1e-9 || last.ExchangeOrderID != "7" {
+		t.Errorf("late-fill trade = %+v", last)
+	}
+	if orders, _ := db.LoadHLTrackedOrders(); len(orders) != 1 || orders[0].FilledSize != 1.0 {
+		t.Fatalf("watermark not persisted: %+v", orders)
+	}
+
+	// Cycle 2: off the book, nothing new → row dropped, no trade.
+	withStubbedLimitDeps(t,
+		func(string, string, []int64, int64) (*HyperliquidLimitStatusResult, string, error) {
+			return &HyperliquidLimitStatusResult{Orders: []HyperliquidLimitOrderStatus{
+				{OID: 7, Resting: limitTestBoolPtr(false), FilledSize: 1.0, AvgPx: 2006, Fee: 0.4, Count: 2},
+			}}, "", nil
+		},
+		func(string, string, int64) (*HyperliquidCancelOrderResult, string, error) {
+			return &HyperliquidCancelOrderResult{}, "", nil
+		},
+	)
+	if alerts := reconcileTrackedHyperliquidOrders(state, cfg, db, &mu, nil, nil); len(alerts) != 0 {
+		t.Errorf("alerts after terminal poll = %+v", alerts)
+	}
+	if orders, _ := db.LoadHLTrackedOrders(); len(orders) != 0 {
+		t.Errorf("expected row dropped, got %+v", orders)
+	}
+}
+
+func TestReconcileTrackedHyperliquidOrders_PositionGoneAndStale(t *testing.T) {
+	sc, state := newLimitTestStrategy()
+	cfg := &Config{Strategies: []StrategyConfig{sc}}
+	db := newLimitTestStateDB(t)
+	var mu sync.RWMutex
+	db.InsertHLTrackedOrder(HLTrackedOrder{StrategyID: sc.ID, Symbol: "ETH", Side: "long", OrderOID: 7, OrderSize: 1, CreatedAt: time.Now()})
+	db.InsertHLTrackedOrder(HLTrackedOrder{StrategyID: sc.ID, Symbol: "ETH", Side: "long", OrderOID: 8, OrderSize: 1, CreatedAt: time.Now().Add(-2 * hlTrackedOrderMaxAge)})
+
+	var cancelled []int64
+	withStubbedLimitDeps(t,
+		func(_ string, _ string, oids []int64, _ int64) (*HyperliquidLimitStatusResult, string, error) {
+			filled := 0.5
+			if oids[0] == 8 {
+				filled = 0
+			}
+			return &HyperliquidLimitStatusResult{Orders: []HyperliquidLimitOrderStatus{
+				{OID: oids[0], Resting: limitTestBoolPtr(true), FilledSize: filled, AvgPx: 2000},
+			}}, "", nil
+		},
+		func(_ string, _ string, oid int64) (*HyperliquidCancelOrderResult, string, error) {
+			cancelled = append(cancelled, oid)
+			return &HyperliquidCancelOrderResult{}, "", nil
+		},
+	)
+	reconcileTrackedHyperliquidOrders(state, cfg, db, &mu, nil, nil)
+	if len(state.Strategies[sc.ID].Positions) != 0 {
+		t.Error("a late fill must not re-create a closed position")
+	}
+	orders, _ := db.LoadHLTrackedOrders()
+	if len(orders) != 1 || orders[0].OrderOID != 8 {
+		t.Errorf("want only the stale order left (awaiting cancel confirmation), got %+v", orders)
+	}
+	if len(cancelled) != 1 || cancelled[0] != 8 {
+		t.Errorf("cancelled = %v, want [8]", cancelled)
+	}
+}
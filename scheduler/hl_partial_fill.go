@@ -0,0 +1,91 @@
+package main
+
+// hl_partial_fill: HL execute results that filled less than requested (#4957).
+//
+// The appliers book Fill.TotalSz, but a fill of zero used to fall through to
+// the paper path and book the full modeled size, and a close that only partly
+// filled still deleted the whole virtual position. vetHyperliquidFill runs on
+// every successful live execute: a zero fill is treated as a failed order
+// (nothing reaches state), a short fill is flagged PartialFill and the owner
+// is told about the unfilled remainder, and partialFillCloseFraction turns a
+// short close into a partial close of exactly the filled quantity. A resting
+// remainder is followed by hl_order_tracking (#4956); an IOC remainder is
+// gone, and the strategy's next signal decides whether to retry.
+
+import "fmt"
+
+// vetHyperliquidFill checks a successful execute's fill against the requested
+// size. Returns false when the order filled nothing, in which case the caller
+// must not apply state mutations. fullClose marks market_close(sz=None),
+// whose fill is the on-chain residual rather than requested.
+func vetHyperliquidFill(sc StrategyConfig, execResult *HyperliquidExecuteResult, symbol, side string, requested float64, fullClose bool, notifier *MultiNotifier, logger *StrategyLogger) bool {
+	if fullClose || execResult == nil || execResult.Execution == nil {
+		return true
+	}
+	var filled float64
+	var restingOID int64
+	if fill := execResult.Execution.Fill; fill != nil {
+		filled = fill.TotalSz
+		restingOID = fill.RestingOID
+	}
+	direction := directionOpen
+	if side == "sell" {
+		direction = directionClose
+	}
+	if filled <= 0 {
+		msg := fmt.Sprintf("order for %.6f did not fill", requested)
+		if restingOID > 0 {
+			msg += fmt.Sprintf(" (resting oid=%d — cancel it or let the next signal retry)", restingOID)
+		}
+		logger.Error("Live execute %s %s: %s — state unchanged", side, symbol, msg)
+		notifyLiveExecFailure(notifier, sc, direction, symbol, msg)
+		return false
+	}
+	if limitOrderFullyFilled(filled, requested) {
+		return true
+	}
+	execResult.PartialFill = true
+	logger.Warn("Live %s %s partially filled: %.6f of %.6f — booking the filled size", side, symbol, filled, requested)
+	notifyLivePartialFill(notifier, sc, symbol, side, filled, requested, restingOID)
+	return true
+}
+
+// partialFillCloseFraction returns the close fraction to apply for a live
+// result. When a partially filled order closes (or flips through) an open
+// position and the fill does not cover it, the close becomes a partial close
+// of exactly the filled quantity with no open leg; otherwise closeFraction is
+// returned unchanged.
+func partialFillCloseFraction(pos *Position, signal int, closeFraction float64, execResult *HyperliquidExecuteResult) float64 {
+	if execResult == nil || !execResult.PartialFill || pos == nil || pos.Quantity <= 0 {
+		return closeFraction
+	}
+	closing := (signal == 1 && pos.Side == "short") || (signal == -1 && pos.Side == "long")
+	if !closing {
+		return closeFraction
+	}
+	filled := execResult.Execution.Fill.TotalSz
+	if limitOrderFullyFilled(filled, pos.Quantity) {
+		return closeFraction
+	}
+	return filled / pos.Quantity
+}
+
+// notifyLivePartialFill tells the operator that a live order filled short of
+// its requested size. Not throttled: each partial fill is a distinct event.
+func notifyLivePartialFill(notifier *MultiNotifier, sc StrategyConfig, symbol, side string, filled, requested float64, restingOID int64) {
+	if notifier == nil || !notifier.HasBackends() {
+		return
+	}
+	msg := formatLivePartialFillAlert(sc.ID, sc.Platform, symbol, side, filled, requested, restingOID)
+	notifier.SendToAllChannels(msg)
+	notifier.SendOwnerDM(msg)
+}
+
+func formatLivePartialFillAlert(strategyID, platform, symbol, side string, filled, requested float64, restingOID int64) string {
+	remainder := "not filled — the next signal decides whether to retry"
+	if restingOID > 0 {
+		remainder = fmt.Sprintf("resting (oid=%d) — later fills are tracked", restingOID)
+	}
+	return fmt.Sprintf("**LIVE PARTIAL FILL** [%s] %s %s %s: filled %.6f of %.6f; remainder %.6f %s. State booked at the filled size.",
+		strategyID, platform, side, symbol, filled, requested, requested-filled, remainder)
+}
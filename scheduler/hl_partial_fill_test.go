@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVetHyperliquidFill(t *testing.T) {
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	sc := StrategyConfig{ID: "hl-eth", Platform: "hyperliquid"}
+
+	full := hlTrackExecResult("buy", 1, 1, 7, 0)
+	if !vetHyperliquidFill(sc, full, "ETH", "buy", 1, false, nil, logger) || full.PartialFill {
+		t.Error("full fill should pass unflagged")
+	}
+	partial := hlTrackExecResult("buy", 1, 0.4, 7, 0)
+	if !vetHyperliquidFill(sc, partial, "ETH", "buy", 1, false, nil, logger) || !partial.PartialFill {
+		t.Error("partial fill should pass flagged")
+	}
+	if vetHyperliquidFill(sc, hlTrackExecResult("buy", 1, 0, 0, 8), "ETH", "buy", 1, false, nil, logger) {
+		t.Error("zero fill must not reach state")
+	}
+	if vetHyperliquidFill(sc, &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Size: 1}}, "ETH", "buy", 1, false, nil, logger) {
+		t.Error("missing fill block must not reach state")
+	}
+	residual := hlTrackExecResult("sell", 1, 0.4, 7, 0)
+	if !vetHyperliquidFill(sc, residual, "ETH", "sell", 1, true, nil, logger) || residual.PartialFill {
+		t.Error("market_close(sz=None) fills the on-chain residual and is not vetted")
+	}
+}
+
+func TestFormatLivePartialFillAlert(t *testing.T) {
+	msg := formatLivePartialFillAlert("hl-eth", "hyperliquid", "ETH", "buy", 0.4, 1, 0)
+	if !strings.Contains(msg, "filled 0.400000 of 1.000000; remainder 0.600000 not filled") {
+		t.Errorf("alert = %q", msg)
+	}
+	if msg := formatLivePartialFillAlert("hl-eth", "hyperliquid", "ETH", "buy", 0.4, 1, 9); !strings.Contains(msg, "resting (oid=9)") {
+		t.Errorf("resting alert = %q", msg)
+	}
+}
+
+func TestExecuteHyperliquidResult_PartialFillScalesState(t *testing.T) {
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	newState := func() *StrategyState {
+		return &StrategyState{
+			ID: "hl-eth", Type: "perps", Platform: "hyperliquid", Cash: 1000, InitialCapital: 1000,
+			Positions: map[string]*Position{
+				"ETH": {Symbol: "ETH", Side: "long", Quantity: 1, InitialQuantity: 1, AvgCost: 2000, Multiplier: 1, OwnerStrategyID: "hl-eth"},
+			},
+			OptionPositions: make(map[string]*OptionPosition),
+			RiskState:       RiskState{PeakValue: 1000},
+		}
+	}
+	sc := StrategyConfig{ID: "hl-eth", Type: "perps", Platform: "hyperliquid", Direction: DirectionBoth}
+
+	// Close of a 1.0 long filled only 0.4: 0.6 stays open.
+	s := newState()
+	exec := hlTrackExecResult("sell", 1, 0.4, 7, 0)
+	exec.PartialFill = true
+	executeHyperliquidResult(sc, s, &HyperliquidResult{Signal: -1, Symbol: "ETH", StrategyDecisionFields: StrategyDecisionFields{CloseFraction: 1}}, exec, "SELL", 2100, nil, nil, logger)
+	pos := s.Positions["ETH"]
+	if pos == nil || pos.Side != "long" || math.Abs(pos.Quantity-0.6) > 1e-9 {
+		t.Fatalf("after partial close: pos = %+v", pos)
+	}
+	if last := s.TradeHistory[len(s.TradeHistory)-1]; !last.IsClose || math.Abs(last.Quantity-0.4) > 1e-9 {
+		t.Errorf("close trade = %+v", last)
+	}
+
+	// Flip (close 1.0 long + open 1.0 short) filled 0.4: partial close, no short.
+	s = newState()
+	exec = hlTrackExecResult("sell", 2, 0.4, 7, 0)
+	exec.PartialFill = true
+	executeHyperliquidResult(sc, s, &HyperliquidResult{Signal: -1, Symbol: "ETH"}, exec, "SELL", 2100, nil, nil, logger)
+	if pos := s.Positions["ETH"]; pos == nil || pos.Side != "long" || math.Abs(pos.Quantity-0.6) > 1e-9 {
+		t.Fatalf("after partial flip: pos = %+v", pos)
+	}
+
+	// Flip filled 1.5 of 2.0: long closed, short opened at the 0.5 that filled.
+	s = newState()
+	exec = hlTrackExecResult("sell", 2, 1.5, 7, 0)
+	exec.PartialFill = true
+	executeHyperliquidResult(sc, s, &HyperliquidResult{Signal: -1, Symbol: "ETH"}, exec, "SELL", 2100, nil, nil, logger)
+	if pos := s.Positions["ETH"]; pos == nil || pos.Side != "short" || math.Abs(pos.Quantity-0.5) > 1e-9 {
+		t.Fatalf("after short flip: pos = %+v", pos)
+	}
+}
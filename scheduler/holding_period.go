@@ -0,0 +1,218 @@
+package main
+
+// holding_period: maximum holding period exits and the digest aging report
+// (#4966).
+//
+// A position only closes when its strategy emits an exit, so a strategy that
+// stops signalling (a stuck script, a regime that never flips) leaves its
+// position open forever. With max_holding_hours set, a directional position
+// older than the limit has the cycle's signal overridden with a full close
+// (close_fraction 1) — the same path a close evaluator's exit takes, so live
+// venues close on-chain and paper books at the cycle price. Option legs past
+// the limit are closed at their current value alongside the theta-harvest
+// walker. Each forced exit posts a note to the strategy's channel.
+//
+// The weekly digest lists every open position held longer than
+// weekly_digest.aging_days, whether or not max_holding_hours is set.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultDigestAgingDays is the weekly digest's aging threshold when
+// weekly_digest.aging_days is unset.
+const defaultDigestAgingDays = 7
+
+// agedPositionExit is a directional position past max_holding_hours,
+// evaluated in the Phase 1 RLock. Zero value = nothing to close.
+type agedPositionExit struct {
+	Symbol string
+	Signal int // closing direction: -1 closes a long, 1 closes a short
+	Reason string
+}
+
+// evaluateAgedPosition returns the oldest position in s held longer than
+// sc.MaxHoldingHours. Positions without an OpenedAt are skipped.
+func evaluateAgedPosition(sc *StrategyConfig, s *StrategyState, now time.Time) agedPositionExit {
+	if sc.MaxHoldingHours <= 0 || s == nil {
+		return agedPositionExit{}
+	}
+	limit := time.Duration(sc.MaxHoldingHours * float64(time.Hour))
+	var out agedPositionExit
+	var oldest time.Time
+	for sym, pos := range s.Positions {
+		if pos == nil || pos.Quantity <= 0 || pos.OpenedAt.IsZero() {
+			continue
+		}
+		held := now.Sub(pos.OpenedAt)
+		if held <= limit || (!oldest.IsZero() && !pos.OpenedAt.Before(oldest)) {
+			continue
+		}
+		oldest = pos.OpenedAt
+		out = agedPositionExit{Symbol: sym, Signal: -1}
+		if pos.Side == "short" {
+			out.Signal = 1
+		}
+		out.Reason = fmt.Sprintf("%s %s held %s, past max_holding_hours %g", pos.Side, sym, formatHoldingAge(held), sc.MaxHoldingHours)
+	}
+	return out
+}
+
+// apply overrides the cycle's signal with a full close when symbol is the
+// aged position. It returns the reason, or "" when nothing changed.
+func (x agedPositionExit) apply(symbol string, signal *int, closeFraction *float64) string {
+	if x.Reason == "" || x.Symbol != symbol {
+		return ""
+	}
+	*signal = x.Signal
+	*closeFraction = 1
+	return x.Reason
+}
+
+// notifyAgedPositionExit logs a forced max-holding exit and posts a note to
+// the strategy's channel.
+func notifyAgedPositionExit(sc StrategyConfig, reason string, notifier *MultiNotifier, logger *StrategyLogger) {
+	logger.Warn("Max holding period: %s — forcing a full close", reason)
+	if notifier != nil && notifier.HasBackends() {
+		notifier.SendToChannel(sc.Platform, sc.Type, fmt.Sprintf("⏳ **MAX HOLDING EXIT** [%s] %s — closing", sc.ID, reason))
+	}
+}
+
+// closeAgedOptionPositions closes option legs held longer than maxHours at
+// their current value, mirroring the theta-harvest close. Returns trades and
+// channel details.
+func closeAgedOptionPositions(s *StrategyState, maxHours float64, now time.Time, logger *StrategyLogger) (int, []string) {
+	if maxHours <= 0 {
+		return 0, nil
+	}
+	limit := time.Duration(maxHours * float64(time.Hour))
+	var ids []string
+	for id, pos := range s.OptionPositions {
+		if !pos.OpenedAt.IsZero() && now.Sub(pos.OpenedAt) > limit {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	trades := 0
+	var details []string
+	for _, id := range ids {
+		pos := s.OptionPositions[id]
+		held := formatHoldingAge(now.Sub(pos.OpenedAt))
+		var pnl, closeUSD float64
+		if pos.Action == "buy" {
+			closeUSD = pos.CurrentValueUSD
+			pnl = closeUSD - pos.EntryPremiumUSD
+			s.Cash += closeUSD
+		} else {
+			closeUSD = -pos.CurrentValueUSD // CurrentValueUSD is negative for sold options
+			if closeUSD < 0 {
+				closeUSD = 0
+			}
+			pnl = pos.EntryPremiumUSD - closeUSD
+			s.Cash -= closeUSD
+		}
+		trade := Trade{
+			Timestamp:   now,
+			StrategyID:  s.ID,
+			Symbol:      pos.ID,
+			PositionID:  ensureOptionTradeID(s.ID, pos),
+			Side:        optionCloseTradeSide(pos.Action),
+			Quantity:    pos.Quantity,
+			Price:       closeUSD,
+			Value:       closeUSD,
+			TradeType:   "options",
+			Details:     fmt.Sprintf("Max holding close %s PnL=$%.2f", pos.ID, pnl),
+			IsClose:     true,
+			RealizedPnL: pnl,
+			PnLGross:    true, // no fee modeled on option closes: gross == net
+		}
+		trade.Regime = s.Regime
+		RecordTrade(s, trade)
+		RecordTradeResult(&s.RiskState, pnl)
+		recordClosedOptionPosition(s, pos, closeUSD, pnl, "max_holding", now)
+
+		logger.Info("⏳ Max holding exit: %s held %s (limit %gh) | PnL: $%.2f", pos.ID, held, maxHours, pnl)
+		details = append(details, fmt.Sprintf("[%s] CLOSE %s — ⏳ max holding exit: held %s (PnL: $%.2f)", s.ID, pos.ID, held, pnl))
+		delete(s.OptionPositions, id)
+		trades++
+	}
+	return trades, details
+}
+
+// formatHoldingAge renders a holding duration as "3d 4h" or "5h".
+func formatHoldingAge(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours >= 24 {
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
+
+// agedHolding is one open position in the digest aging report.
+type agedHolding struct {
+	strategyID string
+	symbol     string
+	side       string
+	held       time.Duration
+}
+
+// agedHoldings returns every open position and option leg held longer than
+// minAge, oldest first.
+func agedHoldings(state *AppState, minAge time.Duration, now time.Time) []agedHolding {
+	if state == nil {
+		return nil
+	}
+	var out []agedHolding
+	for id, s := range state.Strategies {
+		if s == nil {
+			continue
+		}
+		for sym, pos := range s.Positions {
+			if pos != nil && pos.Quantity > 0 && !pos.OpenedAt.IsZero() && now.Sub(pos.OpenedAt) > minAge {
+				out = append(out, agedHolding{strategyID: id, symbol: sym, side: pos.Side, held: now.Sub(pos.OpenedAt)})
+			}
+		}
+		for _, pos := range s.OptionPositions {
+			if pos != nil && !pos.OpenedAt.IsZero() && now.Sub(pos.OpenedAt) > minAge {
+				out = append(out, agedHolding{strategyID: id, symbol: pos.ID, side: pos.Action, held: now.Sub(pos.OpenedAt)})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].held != out[j].held {
+			return out[i].held > out[j].held
+		}
+		if out[i].strategyID != out[j].strategyID {
+			return out[i].strategyID < out[j].strategyID
+		}
+		return out[i].symbol < out[j].symbol
+	})
+	return out
+}
+
+// digestPositionAging is the aging section: open positions held longer than
+// weekly_digest.aging_days, oldest first.
+func digestPositionAging(in weeklyDigestInput) string {
+	days := defaultDigestAgingDays
+	if in.cfg != nil && in.cfg.WeeklyDigest != nil && in.cfg.WeeklyDigest.AgingDays > 0 {
+		days = in.cfg.WeeklyDigest.AgingDays
+	}
+	aged := agedHoldings(in.state, time.Duration(days)*24*time.Hour, in.now)
+	if len(aged) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "⏳ **Positions held > %dd**\n", days)
+	for i, a := range aged {
+		if i == weeklyDigestMaxRows {
+			fmt.Fprintf(&sb, "… +%d more\n", len(aged)-weeklyDigestMaxRows)
+			break
+		}
+		fmt.Fprintf(&sb, "`%s` %s %s — %s\n", a.strategyID, a.side, a.symbol, formatHoldingAge(a.held))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateAgedPosition(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	sc := StrategyConfig{ID: "hl-btc", Type: "perps", Platform: "hyperliquid"}
+	s := &StrategyState{ID: sc.ID, Positions: map[string]*Position{
+		"BTC": {Symbol: "BTC", Side: "short", Quantity: 0.1, AvgCost: 60000, OpenedAt: now.Add(-50 * time.Hour)},
+	}}
+	if x := evaluateAgedPosition(&sc, s, now); x.Reason != "" {
+		t.Fatalf("disabled limit flagged: %+v", x)
+	}
+	sc.MaxHoldingHours = 72
+	if x := evaluateAgedPosition(&sc, s, now); x.Reason != "" {
+		t.Fatalf("50h position flagged under a 72h limit: %+v", x)
+	}
+	sc.MaxHoldingHours = 48
+	x := evaluateAgedPosition(&sc, s, now)
+	if x.Symbol != "BTC" || x.Signal != 1 || !strings.Contains(x.Reason, "held 2d 2h") {
+		t.Fatalf("aged short = %+v", x)
+	}
+
+	signal, frac := 0, 0.0
+	if why := x.apply("ETH", &signal, &frac); why != "" || signal != 0 {
+		t.Errorf("other symbol overridden: %q", why)
+	}
+	if why := x.apply("BTC", &signal, &frac); why == "" || signal != 1 || frac != 1 {
+		t.Errorf("override = %q signal=%d frac=%v, want a full buy-to-close", why, signal, frac)
+	}
+}
+
+func TestCloseAgedOptionPositions(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	s := &StrategyState{
+		ID: "deribit-vol-btc", Cash: 1000,
+		Positions: map[string]*Position{},
+		OptionPositions: map[string]*OptionPosition{
+			"old": {ID: "old", Underlying: "BTC", OptionType: "put", Action: "sell", Quantity: 1, EntryPremiumUSD: 300, CurrentValueUSD: -100, OpenedAt: now.Add(-100 * time.Hour)},
+			"new": {ID: "new", Underlying: "BTC", OptionType: "call", Action: "buy", Quantity: 1, EntryPremiumUSD: 200, CurrentValueUSD: 250, OpenedAt: now.Add(-time.Hour)},
+		},
+	}
+	trades, details := closeAgedOptionPositions(s, 72, now, silentStrategyLogger(s.ID))
+	if trades != 1 || len(details) != 1 || !strings.Contains(details[0], "max holding exit") {
+		t.Fatalf("trades=%d details=%v", trades, details)
+	}
+	if _, open := s.OptionPositions["old"]; open {
+		t.Error("aged leg still open")
+	}
+	if _, open := s.OptionPositions["new"]; !open {
+		t.Error("fresh leg closed")
+	}
+	if s.Cash != 900 || s.TradeHistory[0].RealizedPnL != 200 {
+		t.Errorf("cash=%v pnl=%v, want buyback at $100 for +$200", s.Cash, s.TradeHistory[0].RealizedPnL)
+	}
+}
+
+func TestDigestPositionAging(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	state := &AppState{Strategies: map[string]*StrategyState{
+		"sma-btc": {ID: "sma-btc", Positions: map[string]*Position{
+			"BTC/USDT": {Symbol: "BTC/USDT", Side: "long", Quantity: 0.1, OpenedAt: now.Add(-20 * 24 * time.Hour)},
+		}},
+		"hl-eth": {ID: "hl-eth", Positions: map[string]*Position{
+			"ETH": {Symbol: "ETH", Side: "short", Quantity: 1, OpenedAt: now.Add(-2 * 24 * time.Hour)},
+		}},
+	}}
+	in := weeklyDigestInput{cfg: &Config{}, state: state, now: now}
+	got := digestPositionAging(in)
+	if !strings.Contains(got, "held > 7d") || !strings.Contains(got, "`sma-btc` long BTC/USDT — 20d 0h") || strings.Contains(got, "hl-eth") {
+		t.Fatalf("aging section = %q", got)
+	}
+	in.cfg.WeeklyDigest = &WeeklyDigestConfig{AgingDays: 30}
+	if got := digestPositionAging(in); got != "" {
+		t.Fatalf("nothing older than 30d, got %q", got)
+	}
+}
@@ -35,7 +35,7 @@ func fetchHyperliquidMids(coins []string) (map[string]float64, error) {
 		return nil, fmt.Errorf("marshal allMids request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newLatencyClient("hyperliquid", "allMids", 10*time.Second)
 	resp, err := client.Post(hlMainnetURL+"/info", "application/json", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("http request: %w", err)
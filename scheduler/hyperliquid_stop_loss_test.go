@@ -1849,7 +1849,7 @@ func TestRunHyperliquidTrailingStopPaper(t *testing.T) {
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			gotHW, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(c.sc, c.side, c.pos, c.mark, c.highWater, c.currentTrigger)
+			gotHW, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(c.sc, c.side, c.pos, c.mark, c.highWater, c.currentTrigger, paperBar{})
 			if floatDiff(gotHW, c.want.newHighWater) > 1e-9 ||
 				floatDiff(gotTrig, c.want.newTrigger) > 1e-9 ||
 				gotBreach != c.want.breach ||
@@ -1883,13 +1883,13 @@ func TestRunHyperliquidTrailingStopPaper_RegimeSnapshotArms(t *testing.T) {
 	}
 
 	incomplete := &Position{AvgCost: pos.AvgCost, EntryATR: pos.EntryATR}
-	_, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(sc, "long", incomplete, 2000, 0, 0)
+	_, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(sc, "long", incomplete, 2000, 0, 0, paperBar{})
 	if gotTrig != 0 || gotBreach || gotPx != 0 {
 		t.Fatalf("incomplete snapshot unexpectedly armed: trig=%v breach=%v px=%v", gotTrig, gotBreach, gotPx)
 	}
 
 	snapshot := hyperliquidProtectionPositionSnapshot(pos)
-	gotHW, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(sc, "long", snapshot, 2000, 0, 0)
+	gotHW, gotTrig, gotBreach, gotPx := runHyperliquidTrailingStopPaper(sc, "long", snapshot, 2000, 0, 0, paperBar{})
 	if !approxEq(gotHW, 2000) || !approxEq(gotTrig, 1900) || gotBreach || gotPx != 0 {
 		t.Fatalf("regime snapshot paper trail = (hw=%v trig=%v breach=%v px=%v), want (2000, 1900, false, 0)",
 			gotHW, gotTrig, gotBreach, gotPx)
@@ -2426,7 +2426,7 @@ func TestRunHyperliquidFixedATRStopLossPaper(t *testing.T) {
 	wantTrigger := 1940.0
 
 	// Cycle 1: not yet armed — return trigger px, no breach.
-	newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2010, 0)
+	newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2010, 0, paperBar{})
 	if breach {
 		t.Errorf("cycle1 breach=true, want false")
 	}
@@ -2438,7 +2438,7 @@ func TestRunHyperliquidFixedATRStopLossPaper(t *testing.T) {
 	}
 
 	// Cycle 2 above trigger: trigger already armed; no new trigger; no breach.
-	newTrigger, breach, _ = runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2050, wantTrigger)
+	newTrigger, breach, _ = runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2050, wantTrigger, paperBar{})
 	if breach {
 		t.Errorf("cycle2 breach=true, want false (mark above trigger)")
 	}
@@ -2447,7 +2447,7 @@ func TestRunHyperliquidFixedATRStopLossPaper(t *testing.T) {
 	}
 
 	// Cycle 3 mark crosses trigger: breach.
-	newTrigger, breach, breachPx = runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 1939, wantTrigger)
+	newTrigger, breach, breachPx = runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 1939, wantTrigger, paperBar{})
 	if !breach {
 		t.Error("cycle3 breach=false, want true")
 	}
@@ -2460,14 +2460,14 @@ func TestRunHyperliquidFixedATRStopLossPaper(t *testing.T) {
 
 	// short side — mark above trigger triggers breach.
 	shortTrigger := 2060.0 // 2000 * 1.03
-	newTrigger, breach, _ = runHyperliquidFixedATRStopLossPaper(sc, "short", pos, 1990, 0)
+	newTrigger, breach, _ = runHyperliquidFixedATRStopLossPaper(sc, "short", pos, 1990, 0, paperBar{})
 	if breach {
 		t.Errorf("short cycle1 breach=true, want false")
 	}
 	if newTrigger != shortTrigger {
 		t.Errorf("short cycle1 newTrigger = %g, want %g", newTrigger, shortTrigger)
 	}
-	newTrigger, breach, breachPx = runHyperliquidFixedATRStopLossPaper(sc, "short", pos, 2061, shortTrigger)
+	newTrigger, breach, breachPx = runHyperliquidFixedATRStopLossPaper(sc, "short", pos, 2061, shortTrigger, paperBar{})
 	if !breach {
 		t.Error("short cycle3 breach=false, want true")
 	}
@@ -2480,7 +2480,7 @@ func TestRunHyperliquidFixedATRStopLossPaper(t *testing.T) {
 func TestRunHyperliquidFixedATRStopLossPaper_Unset(t *testing.T) {
 	sc := StrategyConfig{Platform: "hyperliquid", Type: "perps"}
 	pos := &Position{AvgCost: 2000, EntryATR: 40}
-	newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2010, 0)
+	newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 2010, 0, paperBar{})
 	if newTrigger != 0 || breach || breachPx != 0 {
 		t.Errorf("unset short-circuit: trigger=%g breach=%v breachPx=%g, want 0,false,0", newTrigger, breach, breachPx)
 	}
@@ -289,7 +289,7 @@ func fixedStopLossATRTriggerPx(sc StrategyConfig, side string, pos *Position) fl
 // is isolated, so a single strategy's breach closes only that strategy's
 // virtual quantity. Peer strategies on the same coin retain their independent
 // virtual exposure.
-func runHyperliquidFixedATRStopLossPaper(sc StrategyConfig, side string, pos *Position, mark, currentTrigger float64) (newTrigger float64, breach bool, breachPx float64) {
+func runHyperliquidFixedATRStopLossPaper(sc StrategyConfig, side string, pos *Position, mark, currentTrigger float64, bar paperBar) (newTrigger float64, breach bool, breachPx float64) {
 	if sc.StopLossATRMult == nil || *sc.StopLossATRMult <= 0 {
 		return 0, false, 0
 	}
@@ -297,8 +297,8 @@ func runHyperliquidFixedATRStopLossPaper(sc StrategyConfig, side string, pos *Po
 		return 0, false, 0
 	}
 	if currentTrigger > 0 {
-		if trailingStopBreached(side, mark, currentTrigger) {
-			return 0, true, currentTrigger
+		if fill, px, _ := paperStopFill(sc.PaperStopOrder, side, currentTrigger, mark, bar); fill {
+			return 0, true, px
 		}
 		return 0, false, 0
 	}
@@ -584,13 +584,19 @@ func trailingStopBreached(side string, mark, currentTrigger float64) bool {
 // is isolated in scheduler state, so a single strategy's breach closes only
 // that strategy's virtual quantity. Peer strategies on the same coin retain
 // their independent virtual exposure and run their own trailing loops.
-func runHyperliquidTrailingStopPaper(sc StrategyConfig, side string, pos *Position, mark, highWater, currentTrigger float64) (newHighWater, newTrigger float64, breach bool, breachPx float64) {
+func runHyperliquidTrailingStopPaper(sc StrategyConfig, side string, pos *Position, mark, highWater, currentTrigger float64, bar paperBar) (newHighWater, newTrigger float64, breach bool, breachPx float64) {
 	trailingPct := effectiveTrailingStopPct(sc, pos)
 	if trailingPct <= 0 || mark <= 0 {
 		return highWater, 0, false, 0
 	}
-	if trailingStopBreached(side, mark, currentTrigger) {
-		return highWater, 0, true, currentTrigger
+	fill, px, triggeredUnfilled := paperStopFill(sc.PaperStopOrder, side, currentTrigger, mark, bar)
+	if fill {
+		return highWater, 0, true, px
+	}
+	if triggeredUnfilled {
+		// paper_stop_order stop_limit: the trigger fired but the limit was
+		// gapped through — hold the trail where it is until a bar fills it.
+		return highWater, 0, false, 0
 	}
 	avgCost := 0.0
 	if pos != nil {
@@ -0,0 +1,134 @@
+package main
+
+// indicator_log: per-cycle indicator time series on disk (#4953).
+//
+// With indicator_log_days set, every successful check script run appends one
+// JSON line — raw signal, price and the numeric indicators the script
+// returned — to <db_file>.indicators/<strategy_id>/YYYY-MM-DD.jsonl (UTC
+// day). The signal is the script's own, before regime/pause/risk gates, so
+// the files answer "why did (or didn't) this fire" offline without touching
+// the Python scripts. Like price history this is analysis data, not state:
+// writes are best-effort and not fsync'd.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// indicatorLogLine is one line of a strategy's day file.
+type indicatorLogLine struct {
+	T      int64              `json:"t"`
+	Symbol string             `json:"sym"`
+	Signal int                `json:"sig"`
+	Price  float64            `json:"px"`
+	Ind    map[string]float64 `json:"i,omitempty"`
+}
+
+// IndicatorLogStore appends per-strategy indicator lines.
+type IndicatorLogStore struct {
+	mu            sync.Mutex
+	dir           string
+	retentionDays int
+	lastPruneDay  map[string]string // strategy dir → last UTC day pruned
+}
+
+// indicatorLog is the package-level store, wired in main. nil disables
+// logging (the default, tests, CLI subcommands).
+var indicatorLog *IndicatorLogStore
+
+// IndicatorLogRetentionDays returns cfg.IndicatorLogDays; unset or 0
+// disables logging.
+func IndicatorLogRetentionDays(cfg *Config) int {
+	if cfg == nil || cfg.IndicatorLogDays == nil {
+		return 0
+	}
+	return *cfg.IndicatorLogDays
+}
+
+// indicatorLogDir returns the log root for a state DB file ("" when the DB
+// has no file to sit beside).
+func indicatorLogDir(dbFile string) string {
+	if dbFile == "" || dbFile == ":memory:" {
+		return ""
+	}
+	return dbFile + ".indicators"
+}
+
+// NewIndicatorLogStore returns a store under dir, or nil when dir is empty
+// or retention is disabled.
+func NewIndicatorLogStore(dir string, retentionDays int) *IndicatorLogStore {
+	if dir == "" || retentionDays <= 0 {
+		return nil
+	}
+	return &IndicatorLogStore{dir: dir, retentionDays: retentionDays, lastPruneDay: make(map[string]string)}
+}
+
+// indicatorLogStrategyDir maps a strategy ID to a single safe path element.
+func indicatorLogStrategyDir(id string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, id)
+	if safe == "" {
+		return "_"
+	}
+	return safe
+}
+
+// Record appends one line for strategyID and, once per UTC day per strategy,
+// deletes its day files older than the retention window.
+func (l *IndicatorLogStore) Record(strategyID, symbol string, signal int, price float64, indicators map[string]interface{}, at time.Time) error {
+	if l == nil {
+		return nil
+	}
+	line, err := json.Marshal(indicatorLogLine{
+		T:      at.Unix(),
+		Symbol: symbol,
+		Signal: signal,
+		Price:  price,
+		Ind:    tradeIndicatorSnapshot(indicators),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal indicator line: %w", err)
+	}
+	day := at.UTC().Format(priceHistoryDayLayout)
+	sdir := filepath.Join(l.dir, indicatorLogStrategyDir(strategyID))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(sdir, 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(sdir, day+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if l.lastPruneDay[sdir] != day {
+		l.lastPruneDay[sdir] = day
+		return pruneDayFiles(sdir, at.UTC().AddDate(0, 0, -l.retentionDays).Format(priceHistoryDayLayout))
+	}
+	return nil
+}
+
+// logCycleIndicators records a check result to indicatorLog, warning on
+// failure. Never blocks the cycle.
+func logCycleIndicators(strategyID, symbol string, signal int, price float64, indicators map[string]interface{}, logger *StrategyLogger) {
+	if err := indicatorLog.Record(strategyID, symbol, signal, price, indicators, time.Now().UTC()); err != nil {
+		logger.Warn("indicator log write failed: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIndicatorLogStore_AppendsPerStrategyDay(t *testing.T) {
+	dir := t.TempDir()
+	l := NewIndicatorLogStore(dir, 7)
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if err := l.Record("hl-a-btc", "BTC", 0, 50000, map[string]interface{}{"rsi": 41.2, "trend": "up"}, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record("hl-a-btc", "BTC", 1, 50100, map[string]interface{}{"rsi": 28.9}, at.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "hl-a-btc", "2026-03-10.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), raw)
+	}
+	var first indicatorLogLine
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.T != at.Unix() || first.Symbol != "BTC" || first.Signal != 0 || first.Price != 50000 || first.Ind["rsi"] != 41.2 || len(first.Ind) != 1 {
+		t.Errorf("first line = %+v", first)
+	}
+}
+
+func TestIndicatorLogStore_PrunesOldDays(t *testing.T) {
+	dir := t.TempDir()
+	l := NewIndicatorLogStore(dir, 2)
+	sdir := filepath.Join(dir, "spot-sma")
+	if err := os.MkdirAll(sdir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	old := filepath.Join(sdir, "2026-03-01.jsonl")
+	if err := os.WriteFile(old, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record("spot-sma", "BTC/USDT", 0, 1, nil, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("day file past retention should be pruned, stat err = %v", err)
+	}
+}
+
+func TestIndicatorLog_DisabledByDefault(t *testing.T) {
+	if NewIndicatorLogStore("/tmp/x.indicators", IndicatorLogRetentionDays(&Config{})) != nil {
+		t.Error("unset indicator_log_days should disable logging")
+	}
+	var l *IndicatorLogStore
+	if err := l.Record("a", "BTC", 1, 1, nil, time.Now()); err != nil {
+		t.Errorf("nil store Record = %v", err)
+	}
+	if got := indicatorLogStrategyDir("../evil/id"); strings.ContainsAny(got, "/.") {
+		t.Errorf("strategy dir not sanitized: %q", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -350,7 +351,8 @@ func selectionDefaults(options []string, preferred []string, fallbackFirst bool)
 // InitOptions captures all user choices from the interactive wizard.
 type InitOptions struct {
 	OutputPath              string
-	Assets                  []string // selected asset names, e.g. ["BTC", "ETH"]
+	Assets                  []string // selected asset names, e.g. ["BTC", "ETH"]; any ticker is accepted (normalized to upper case), non-builtin ones get "<NAME>/USDT" spot symbols
+	ValidateAssets          bool     `json:"validateAssets,omitempty"` // --json only: look up non-builtin assets on the enabled platforms and fail on any that aren't listed (the wizard always checks)
 	EnableSpot              bool
 	EnableOptions           bool
 	EnablePerps             bool
@@ -396,9 +398,17 @@ type InitOptions struct {
 	OKXCapital              float64
 	OKXDrawdown             float64
 	CapitalPct              float64 `json:"capitalPct,omitempty"` // 0-1; global capital_pct applied to all strategies
-	HTFFilter               bool    // higher-timeframe trend filter for all strategies
-	DisableCircuitBreaker   bool    `json:"disableCircuitBreaker,omitempty"` // #1048 — when true, stamp circuit_breaker:false on every generated non-manual strategy (fleet-wide opt-out of the per-strategy circuit breaker). Default false keeps the safe default (CB on). Exposed for the JSON-driven `init --json` path; the interactive wizard leaves it false (disabling an auto-protective halt at setup is a footgun — operators opt out per-strategy via config edit + SIGHUP instead).
-	ATRMethod               string  `json:"atrMethod,omitempty"`             // #1277 — top-level atr_method emitted into the generated config ("simple"|"wilder"; empty omits the field = simple). Exposed for the JSON-driven `init --json` path only; the interactive wizard leaves it unset (switching live stop-geometry math at setup is a footgun — operators opt in via config edit + restart/SIGHUP after reading the cutover notes).
+	// Per-asset / per-strategy capital overrides. Resolution per generated
+	// strategy: StrategyCapital[template ID] > AssetCapital[asset or futures
+	// symbol] > the type's *Capital default (allocCapital). TotalBudget > 0
+	// caps the sum across all generated strategies — --json fails over it,
+	// the wizard offers to scale down proportionally.
+	AssetCapital          map[string]float64 `json:"assetCapital,omitempty"`
+	StrategyCapital       map[string]float64 `json:"strategyCapital,omitempty"`
+	TotalBudget           float64            `json:"totalBudget,omitempty"`
+	HTFFilter             bool               // higher-timeframe trend filter for all strategies
+	DisableCircuitBreaker bool               `json:"disableCircuitBreaker,omitempty"` // #1048 — when true, stamp circuit_breaker:false on every generated non-manual strategy (fleet-wide opt-out of the per-strategy circuit breaker). Default false keeps the safe default (CB on). Exposed for the JSON-driven `init --json` path; the interactive wizard leaves it false (disabling an auto-protective halt at setup is a footgun — operators opt out per-strategy via config edit + SIGHUP instead).
+	ATRMethod             string             `json:"atrMethod,omitempty"`             // #1277 — top-level atr_method emitted into the generated config ("simple"|"wilder"; empty omits the field = simple). Exposed for the JSON-driven `init --json` path only; the interactive wizard leaves it unset (switching live stop-geometry math at setup is a footgun — operators opt in via config edit + restart/SIGHUP after reading the cutover notes).
 	// #1273 — optional circuit-breaker timing/threshold overrides stamped on
 	// every generated non-manual strategy. 0/omitted leaves the field nil so the
 	// historical hardcoded defaults apply (24h drawdown cooldown, 5-loss streak,
@@ -428,6 +438,18 @@ type InitOptions struct {
 	ManualCapital   float64
 	ManualDrawdown  float64
 	ManualLeverage  float64
+
+	// Preset names an initPreset ("conservative", "balanced", "aggressive")
+	// whose selections fill every field the payload leaves unset.
+	Preset string `json:"preset,omitempty"`
+	// Cadence / theta-harvest overrides (presets set them; 0/nil keeps the
+	// historical 1h for hourly strategies, 4h for options, 60/200/3 theta).
+	IntervalSeconds        int                 `json:"intervalSeconds,omitempty"`
+	OptionsIntervalSeconds int                 `json:"optionsIntervalSeconds,omitempty"`
+	ThetaHarvest           *ThetaHarvestConfig `json:"thetaHarvest,omitempty"`
+	// StrategyParams overrides the timeframe and open_strategy params per
+	// strategy template ID (applyStrategyOverrides).
+	StrategyParams map[string]InitStrategyOverride `json:"strategyParams,omitempty"`
 }
 
 // generateConfig builds a Config from InitOptions. Pure function, no I/O.
@@ -440,10 +462,22 @@ func generateConfig(opts InitOptions) *Config {
 	if portfolioWarn <= 0 {
 		portfolioWarn = 60
 	}
+	hourly := 3600
+	if opts.IntervalSeconds > 0 {
+		hourly = opts.IntervalSeconds
+	}
+	optionsInterval := 14400
+	if opts.OptionsIntervalSeconds > 0 {
+		optionsInterval = opts.OptionsIntervalSeconds
+	}
+	thetaHarvest := ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 60, StopLossPct: 200, MinDTEClose: 3}
+	if opts.ThetaHarvest != nil {
+		thetaHarvest = *opts.ThetaHarvest
+	}
 	defaultStopLossATRMult := DefaultStopLossATRMult
 	cfg := &Config{
 		ConfigVersion:          CurrentConfigVersion,
-		IntervalSeconds:        3600,
+		IntervalSeconds:        min(3600, hourly, optionsInterval), // tick no slower than the fastest strategy
 		LogDir:                 "logs",
 		DBFile:                 "scheduler/state.db",
 		DefaultStopLossATRMult: &defaultStopLossATRMult,
@@ -466,21 +500,12 @@ func generateConfig(opts InitOptions) *Config {
 		ATRMethod:  normalizeATRMethod(opts.ATRMethod), // #1277: "" omitted from JSON (= simple)
 	}
 
-	// Build asset name → exchange symbol map.
-	assetSymbol := make(map[string]string)
-	for _, a := range supportedAssets {
-		assetSymbol[a.Name] = a.Symbol
-	}
-
 	// Spot strategies.
 	if opts.EnableSpot {
 		for _, stratID := range opts.SpotStrategies {
 			shortName := deriveShortName(stratID)
 			for _, assetName := range opts.Assets {
-				sym := assetSymbol[assetName]
-				if sym == "" {
-					continue
-				}
+				sym := assetSpotSymbol(assetName)
 				id := shortName + "-" + strings.ToLower(assetName)
 				cfg.Strategies = append(cfg.Strategies, StrategyConfig{
 					ID:              id,
@@ -488,9 +513,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "binanceus",
 					Script:          "shared_scripts/check_strategy.py",
 					Args:            []string{stratID, sym, "1h"},
-					Capital:         opts.SpotCapital,
+					Capital:         allocCapital(opts, opts.SpotCapital, stratID, assetName),
 					MaxDrawdownPct:  opts.SpotDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 				})
 			}
 		}
@@ -505,8 +530,8 @@ func generateConfig(opts InitOptions) *Config {
 					Type:            "spot",
 					Platform:        "binanceus",
 					Script:          "shared_scripts/check_strategy.py",
-					Args:            []string{"pairs_spread", assetSymbol[a1], "1d", assetSymbol[a2]},
-					Capital:         opts.SpotCapital,
+					Args:            []string{"pairs_spread", assetSpotSymbol(a1), "1d", assetSpotSymbol(a2)},
+					Capital:         allocCapital(opts, opts.SpotCapital, "pairs_spread", ""),
 					MaxDrawdownPct:  opts.SpotDrawdown,
 					IntervalSeconds: 86400,
 				})
@@ -528,12 +553,13 @@ func generateConfig(opts InitOptions) *Config {
 					}
 				} else {
 					for _, a := range opts.Assets {
-						if a != "SOL" { // options don't support SOL
+						if optionsCurrencySupported(platform, a) {
 							symbols = append(symbols, a)
 						}
 					}
 				}
 				for _, assetName := range symbols {
+					th := thetaHarvest
 					prefix := platform
 					if platform == "robinhood" {
 						prefix = "rh"
@@ -545,15 +571,10 @@ func generateConfig(opts InitOptions) *Config {
 						Platform:        platform,
 						Script:          "shared_scripts/check_options.py",
 						Args:            []string{stratID, assetName, fmt.Sprintf("--platform=%s", platform)},
-						Capital:         opts.OptionsCapital,
+						Capital:         allocCapital(opts, opts.OptionsCapital, stratID, assetName),
 						MaxDrawdownPct:  opts.OptionsDrawdown,
-						IntervalSeconds: 14400,
-						ThetaHarvest: &ThetaHarvestConfig{
-							Enabled:         true,
-							ProfitTargetPct: 60,
-							StopLossPct:     200,
-							MinDTEClose:     3,
-						},
+						IntervalSeconds: optionsInterval,
+						ThetaHarvest:    &th,
 					})
 				}
 			}
@@ -604,9 +625,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:          "hyperliquid",
 					Script:            "shared_scripts/check_hyperliquid.py",
 					Args:              []string{stratID, assetName, "1h", fmt.Sprintf("--mode=%s", opts.PerpsMode)},
-					Capital:           opts.PerpsCapital,
+					Capital:           allocCapital(opts, opts.PerpsCapital, stratID, assetName),
 					MaxDrawdownPct:    opts.PerpsDrawdown,
-					IntervalSeconds:   3600,
+					IntervalSeconds:   hourly,
 					Leverage:          perpsLeverage,
 					SizingLeverage:    perpsSizingLeverage,
 					RiskPerTradePct:   perpsRiskPerTradePct, // *float64 — nil keeps notional sizing; >0 opts into risk-per-trade (#1268)
@@ -633,9 +654,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "topstep",
 					Script:          "shared_scripts/check_topstep.py",
 					Args:            []string{stratID, symbol, "1h", fmt.Sprintf("--mode=%s", opts.FuturesMode)},
-					Capital:         opts.FuturesCapital,
+					Capital:         allocCapital(opts, opts.FuturesCapital, stratID, symbol),
 					MaxDrawdownPct:  opts.FuturesDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 				}
 				if feePerContract > 0 {
 					sc.FuturesConfig = &FuturesConfig{FeePerContract: feePerContract}
@@ -650,10 +671,7 @@ func generateConfig(opts InitOptions) *Config {
 		for _, stratID := range opts.LunoStrategies {
 			shortName := deriveShortName(stratID)
 			for _, assetName := range opts.Assets {
-				sym := assetSymbol[assetName]
-				if sym == "" {
-					continue
-				}
+				sym := assetSpotSymbol(assetName)
 				id := fmt.Sprintf("luno-%s-%s", shortName, strings.ToLower(assetName))
 				cfg.Strategies = append(cfg.Strategies, StrategyConfig{
 					ID:              id,
@@ -661,9 +679,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "luno",
 					Script:          "shared_scripts/check_strategy.py",
 					Args:            []string{stratID, sym, "1h"},
-					Capital:         opts.LunoCapital,
+					Capital:         allocCapital(opts, opts.LunoCapital, stratID, assetName),
 					MaxDrawdownPct:  opts.LunoDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 				})
 			}
 		}
@@ -681,9 +699,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "robinhood",
 					Script:          "shared_scripts/check_robinhood.py",
 					Args:            []string{stratID, assetName, "1h", fmt.Sprintf("--mode=%s", opts.RobinhoodMode)},
-					Capital:         opts.RobinhoodCapital,
+					Capital:         allocCapital(opts, opts.RobinhoodCapital, stratID, assetName),
 					MaxDrawdownPct:  opts.RobinhoodDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 				})
 			}
 		}
@@ -705,9 +723,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "okx",
 					Script:          "shared_scripts/check_okx.py",
 					Args:            []string{stratID, assetName, "1h", fmt.Sprintf("--mode=%s", okxMode), "--inst-type=spot"},
-					Capital:         opts.OKXCapital,
+					Capital:         allocCapital(opts, opts.OKXCapital, stratID, assetName),
 					MaxDrawdownPct:  opts.OKXDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 				})
 			}
 		}
@@ -730,9 +748,9 @@ func generateConfig(opts InitOptions) *Config {
 					Platform:        "okx",
 					Script:          "shared_scripts/check_okx.py",
 					Args:            []string{stratID, assetName, "1h", fmt.Sprintf("--mode=%s", okxMode), "--inst-type=swap"},
-					Capital:         opts.OKXCapital,
+					Capital:         allocCapital(opts, opts.OKXCapital, stratID, assetName),
 					MaxDrawdownPct:  opts.OKXDrawdown,
-					IntervalSeconds: 3600,
+					IntervalSeconds: hourly,
 					Leverage:        okxPerpsLeverage,
 					SizingLeverage:  okxPerpsSizingLeverage,
 				})
@@ -812,6 +830,9 @@ func generateConfig(opts InitOptions) *Config {
 	stampCBOverride(opts.CBLossStreakThreshold, func(sc *StrategyConfig, p *int) { sc.CBLossStreakThreshold = p })
 	stampCBOverride(opts.CBLossStreakCooldownMinutes, func(sc *StrategyConfig, p *int) { sc.CBLossStreakCooldownMinutes = p })
 
+	// Per-template timeframe / open_strategy params overrides.
+	applyStrategyOverrides(cfg, opts.StrategyParams)
+
 	// #87: Apply capital_pct to all strategies if set globally.
 	if opts.CapitalPct > 0 {
 		for i := range cfg.Strategies {
@@ -877,7 +898,7 @@ func makePairs(assets []string) [][2]string {
 }
 
 // runInitFromJSON generates a config from a JSON blob of InitOptions. Returns exit code.
-func runInitFromJSON(jsonStr string, outputPath string) int {
+func runInitFromJSON(jsonStr string, outputPath string, merge bool) int {
 	discoverStrategies()
 
 	var opts InitOptions
@@ -894,12 +915,24 @@ func runInitFromJSON(jsonStr string, outputPath string) int {
 		}
 	}
 
+	if err := applyInitPreset(&opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: preset: %v\n", err)
+		return 1
+	}
 	applyMinimalStarterDefaults(&opts)
 
 	if len(opts.Assets) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: at least one asset required")
 		return 1
 	}
+	assets, assetErrs := parseAssetTickers(strings.Join(opts.Assets, ","))
+	if len(assetErrs) > 0 {
+		for _, err := range assetErrs {
+			fmt.Fprintf(os.Stderr, "Error: assets: %v\n", err)
+		}
+		return 1
+	}
+	opts.Assets = assets
 	if !hasAnyEnabledStrategyType(opts) {
 		fmt.Fprintln(os.Stderr, "Error: at least one strategy type must be enabled")
 		return 1
@@ -1050,7 +1083,32 @@ func runInitFromJSON(jsonStr string, outputPath string) int {
 		}
 	}
 
+	// Listing lookups run after defaults so OKX spot/swap checks see the
+	// auto-populated strategy lists.
+	if opts.ValidateAssets {
+		if missing := unlistedAssets(opts); len(missing) > 0 {
+			for _, m := range missing {
+				fmt.Fprintf(os.Stderr, "Error: asset %s\n", m)
+			}
+			return 1
+		}
+	}
+
+	if errs := append(validateCapitalAllocation(opts), validateStrategyOverrides(opts)...); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", e)
+		}
+		return 1
+	}
+
 	cfg := generateConfig(opts)
+	if merge {
+		return writeMergedInitConfig(opts.OutputPath, cfg, opts.TotalBudget)
+	}
+	if err := checkCapitalBudget(cfg, opts.TotalBudget); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
 
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -1071,13 +1129,14 @@ func runInit(args []string) int {
 	fs := flag.NewFlagSet("init", flag.ContinueOnError)
 	jsonFlag := fs.String("json", "", "JSON blob of InitOptions for non-interactive config generation")
 	outputFlag := fs.String("output", "scheduler/config.json", "output config file path")
+	mergeFlag := fs.Bool("merge", false, "add strategies to the existing config at the output path instead of overwriting it; existing strategy IDs and capital are kept")
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
 		return 1
 	}
 
 	if *jsonFlag != "" {
-		return runInitFromJSON(*jsonFlag, *outputFlag)
+		return runInitFromJSON(*jsonFlag, *outputFlag, *mergeFlag)
 	}
 
 	discoverStrategies()
@@ -1089,29 +1148,67 @@ func runInit(args []string) int {
 	fmt.Println("Interactive config setup. Press Enter to accept defaults.")
 	fmt.Println()
 
-	// Step 1: Output path.
-	outputPath := p.String("Output config path", "scheduler/config.json")
-	if _, err := os.Stat(outputPath); err == nil {
-		if !p.YesNo(fmt.Sprintf("  %s already exists. Overwrite?", outputPath), false) {
+	// Step 1: Output path. In merge mode it's the existing config to extend.
+	outputPath := p.String("Output config path", *outputFlag)
+	var mergeBase *initMergeBase
+	if *mergeFlag {
+		base, err := loadInitMergeBase(outputPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --merge: %v\n", err)
+			return 1
+		}
+		mergeBase = base
+		fmt.Printf("\nMerging into %s (%d existing strategies, $%.0f allocated). Existing strategy IDs and capital are kept; only new ones are added.\n",
+			outputPath, len(base.ids), base.existingCapital())
+		for _, line := range base.stateSummary() {
+			fmt.Printf("  %s\n", line)
+		}
+	} else if _, err := os.Stat(outputPath); err == nil {
+		if !p.YesNo(fmt.Sprintf("  %s already exists. Overwrite? (or re-run with --merge to add to it)", outputPath), false) {
 			fmt.Println("Aborted.")
 			return 0
 		}
 	}
 
+	// Step 1b: Preset — output path, preset, confirm is a complete config;
+	// "custom" walks every step below.
+	presetOptions := []string{"custom (choose everything yourself)"}
+	for _, pr := range initPresets {
+		presetOptions = append(presetOptions, fmt.Sprintf("%s — %s", pr.Name, pr.Description))
+	}
+	if idx := p.Choice("\nStart from a preset?", presetOptions, 0); idx > 0 {
+		opts := InitOptions{OutputPath: outputPath, Preset: initPresets[idx-1].Name, HTFFilter: true, AutoUpdate: "off"}
+		if err := applyInitPreset(&opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return finishInit(p, opts, mergeBase)
+	}
+
 	// Step 2: Asset selection.
 	assetNames := make([]string, len(supportedAssets))
 	for i, a := range supportedAssets {
 		assetNames[i] = a.Name
 	}
 	assetIdxs := p.MultiSelectWithDefaults("\nSelect assets to trade:", assetNames, selectionDefaults(assetNames, []string{starterAssetName}, true))
-	if len(assetIdxs) == 0 {
+	selectedAssets := make([]string, 0, len(assetIdxs))
+	for _, idx := range assetIdxs {
+		selectedAssets = append(selectedAssets, supportedAssets[idx].Name)
+	}
+	// Step 2b: Custom tickers, validated against the chosen platforms in 7e.
+	customAssets, customErrs := parseAssetTickers(p.String("Other tickers to trade (comma-separated, blank for none)", ""))
+	for _, err := range customErrs {
+		fmt.Printf("  Skipping: %v\n", err)
+	}
+	for _, t := range customAssets {
+		if !slices.Contains(selectedAssets, t) {
+			selectedAssets = append(selectedAssets, t)
+		}
+	}
+	if len(selectedAssets) == 0 {
 		fmt.Println("No assets selected. Aborted.")
 		return 1
 	}
-	selectedAssets := make([]string, len(assetIdxs))
-	for i, idx := range assetIdxs {
-		selectedAssets[i] = supportedAssets[idx].Name
-	}
 
 	// Step 3: Strategy types.
 	stratTypeNames := []string{"spot", "options", "perps", "futures", "robinhood", "luno", "okx"}
@@ -1309,6 +1406,47 @@ func runInit(args []string) int {
 		return 1
 	}
 
+	// Step 7e: Confirm custom tickers are listed where they'll trade.
+	listingOpts := InitOptions{
+		EnableSpot:         enableSpot && (len(selectedSpotStrats) > 0 || includePairs),
+		EnablePerps:        enablePerps,
+		EnableOptions:      enableOptions,
+		OptionPlatforms:    optionPlatforms,
+		EnableOKX:          enableOKX,
+		OKXSpotStrategies:  selectedOKXSpotStrats,
+		OKXPerpsStrategies: selectedOKXPerpsStrats,
+	}
+	kept := selectedAssets[:0:0]
+	for _, a := range selectedAssets {
+		if isBuiltinAsset(a) {
+			kept = append(kept, a)
+			continue
+		}
+		fmt.Printf("\nChecking %s listings...\n", a)
+		problems, notes := checkAssetListings(a, listingOpts)
+		for _, n := range notes {
+			fmt.Printf("  Note: %s\n", n)
+		}
+		if len(problems) == 0 {
+			fmt.Printf("  %s OK\n", a)
+			kept = append(kept, a)
+			continue
+		}
+		unverifiedOnly := true
+		for _, pr := range problems {
+			fmt.Printf("  %s\n", pr)
+			unverifiedOnly = unverifiedOnly && pr.Unverified
+		}
+		if p.YesNo(fmt.Sprintf("  Keep %s anyway? (its strategies will fail the startup probe where it isn't listed)", a), unverifiedOnly) {
+			kept = append(kept, a)
+		}
+	}
+	selectedAssets = kept
+	if len(selectedAssets) == 0 {
+		fmt.Println("No assets left. Aborted.")
+		return 1
+	}
+
 	// Use sensible defaults for optional fields (capital, risk, notifications, etc.).
 	// Users can customize these post-setup by editing the config or asking OpenClaw.
 	spotCapital := 1000.0
@@ -1475,6 +1613,88 @@ func runInit(args []string) int {
 		}
 	}
 
+	// Strategy templates the generated config will use, for the per-strategy
+	// capital and parameter steps. perpsStratIDs is the full roster even when
+	// perps is off.
+	lists := [][]string{selectedSpotStrats, selectedOptStrats, futuresStratIDs, robinhoodStratIDs, lunoStratIDs, okxSpotStratIDs, okxPerpsStratIDs}
+	if enablePerps {
+		lists = append(lists, perpsStratIDs)
+	}
+	if includePairs && len(selectedAssets) >= 2 {
+		lists = append(lists, []string{"pairs_spread"})
+	}
+	var templates []string
+	for _, ids := range lists {
+		for _, id := range ids {
+			if !slices.Contains(templates, id) {
+				templates = append(templates, id)
+			}
+		}
+	}
+
+	// Capital allocation: optional per-asset / per-strategy overrides of the
+	// type defaults above, plus a total budget the generated fleet must fit.
+	var assetCapital, strategyCapital map[string]float64
+	totalBudget := 0.0
+	if p.YesNo("\nCustomize capital per asset or per strategy?", false) {
+		fmt.Println("Enter 0 to keep the strategy type's default.")
+		assetCapital = make(map[string]float64)
+		for _, a := range selectedAssets {
+			if v := p.FloatRange(fmt.Sprintf("  Capital per strategy on %s (USD)", a), 0, 0, 1e9); v > 0 {
+				assetCapital[a] = v
+			}
+		}
+		strategyCapital = make(map[string]float64)
+		for _, idx := range p.MultiSelect("\nStrategies to give their own capital (overrides the asset amount):", templates, false) {
+			if v := p.FloatRange(fmt.Sprintf("  Capital per %s strategy (USD)", templates[idx]), 0, 0, 1e9); v > 0 {
+				strategyCapital[templates[idx]] = v
+			}
+		}
+		totalBudget = p.FloatRange("Total budget across all strategies (USD, 0 = no limit)", 0, 0, 1e12)
+	}
+
+	// Strategy parameters: timeframe and open_strategy params (lookback
+	// windows etc.) per template. Options templates take neither.
+	var strategyParams map[string]InitStrategyOverride
+	var paramTemplates []string
+	for _, id := range templates {
+		if !slices.Contains(selectedOptStrats, id) {
+			paramTemplates = append(paramTemplates, id)
+		}
+	}
+	if len(paramTemplates) > 0 && p.YesNo("\nOverride timeframe or parameters for any strategy?", false) {
+		strategyParams = make(map[string]InitStrategyOverride)
+		for _, idx := range p.MultiSelect("\nStrategies to customize:", paramTemplates, false) {
+			id := paramTemplates[idx]
+			defaultTF := "1h"
+			if id == "pairs_spread" {
+				defaultTF = "1d"
+			}
+			var o InitStrategyOverride
+			for {
+				tf := p.String(fmt.Sprintf("  %s timeframe", id), defaultTF)
+				if validRegimeTimeframe(tf) {
+					if normalizeRegimeTimeframe(tf) != defaultTF {
+						o.Timeframe = normalizeRegimeTimeframe(tf)
+					}
+					break
+				}
+				fmt.Printf("  Unknown timeframe %q (want one of %s)\n", tf, strings.Join(validRegimeTimeframes(), ", "))
+			}
+			for {
+				params, err := parseParamOverrides(p.String(fmt.Sprintf("  %s params (key=value, comma-separated, blank for registry defaults)", id), ""))
+				if err == nil {
+					o.Params = params
+					break
+				}
+				fmt.Printf("  %v\n", err)
+			}
+			if o.Timeframe != "" || len(o.Params) > 0 {
+				strategyParams[id] = o
+			}
+		}
+	}
+
 	opts := InitOptions{
 		OutputPath:                outputPath,
 		Assets:                    selectedAssets,
@@ -1536,37 +1756,83 @@ func runInit(args []string) int {
 		TelegramOwnerChatID:       telegramOwnerChatID,
 		TelegramChannelMap:        telegramChannelMap,
 		AutoUpdate:                autoUpdate,
+		AssetCapital:              assetCapital,
+		StrategyCapital:           strategyCapital,
+		TotalBudget:               totalBudget,
+		StrategyParams:            strategyParams,
 	}
 
+	return finishInit(p, opts, mergeBase)
+}
+
+// finishInit is the shared wizard tail (custom flow and presets): generate,
+// merge against the existing config when --merge, apply the budget, show the
+// summary and write on confirmation.
+func finishInit(p *Prompter, opts InitOptions, mergeBase *initMergeBase) int {
+	budget := opts.TotalBudget
 	cfg := generateConfig(opts)
+	var keptIDs []string
+	if mergeBase != nil {
+		// Only new strategies are written (and scaled); existing capital is
+		// fixed and counts against the budget.
+		cfg.Strategies, keptIDs = mergeBase.split(cfg)
+		if budget > 0 {
+			budget -= mergeBase.existingCapital()
+			if budget <= 0 {
+				fmt.Printf("\nExisting strategies already allocate $%.2f, at or over the total budget. Aborted.\n", mergeBase.existingCapital())
+				return 1
+			}
+		}
+	}
+	if err := checkCapitalBudget(cfg, budget); err != nil {
+		fmt.Printf("\n%v\n", err)
+		if !p.YesNo("Scale every new strategy's capital down proportionally to fit?", true) {
+			fmt.Println("Aborted.")
+			return 1
+		}
+		scaleCapitalToBudget(cfg, budget)
+	}
 
 	// Summary + confirm.
 	fmt.Println("\n--- Summary ---")
-	fmt.Printf("Output:     %s\n", outputPath)
-	fmt.Printf("Assets:     %s\n", strings.Join(selectedAssets, ", "))
-	fmt.Printf("Strategies: %d\n", len(cfg.Strategies))
+	fmt.Printf("Output:     %s\n", opts.OutputPath)
+	fmt.Printf("Assets:     %s\n", strings.Join(opts.Assets, ", "))
+	fmt.Printf("Strategies: %d ($%.0f total)\n", len(cfg.Strategies), totalStrategyCapital(cfg))
 	for _, s := range cfg.Strategies {
 		fmt.Printf("  - %-35s (%s, $%.0f)\n", s.ID, s.Type, s.Capital)
 	}
+	for _, id := range keptIDs {
+		fmt.Printf("  = %-35s (already configured, unchanged)\n", id)
+	}
 
 	if !p.YesNo("\nWrite config?", true) {
 		fmt.Println("Aborted.")
 		return 0
 	}
 
+	if mergeBase != nil {
+		if err := mergeBase.write(cfg.Strategies); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", opts.OutputPath, err)
+			return 1
+		}
+		fmt.Printf("\nAdded %d strategies to %s\n", len(cfg.Strategies), opts.OutputPath)
+		fmt.Println("  Restart the daemon to pick them up (a changed strategy set is restart-only).")
+		return 0
+	}
+
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error marshaling config: %v\n", err)
 		return 1
 	}
-	if err := os.WriteFile(outputPath, data, 0600); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outputPath, err)
+	if err := os.WriteFile(opts.OutputPath, data, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", opts.OutputPath, err)
 		return 1
 	}
 
-	fmt.Printf("\nConfig written to %s\n", outputPath)
+	fmt.Printf("\nConfig written to %s\n", opts.OutputPath)
 	fmt.Println("Next steps:")
 	fmt.Println("  To enable Discord/Telegram notifications, edit the config or ask OpenClaw.")
-	fmt.Printf("  ./go-trader --config %s --once\n", outputPath)
+	fmt.Printf("  ./go-trader --config %s --once\n", opts.OutputPath)
 	return 0
 }
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// binanceUSAPIURL and okxPublicAPIURL are the public (unauthenticated) REST
+// roots the init wizard queries to confirm a custom ticker is listed. Vars so
+// tests can redirect to a stub server (same pattern as hlMainnetURL).
+var (
+	binanceUSAPIURL = "https://api.binance.us"
+	okxPublicAPIURL = "https://www.okx.com"
+)
+
+// maxAssetTickerLen bounds a free-form ticker. Exchange base currencies top
+// out well below this (e.g. "1000PEPE", "FARTCOIN").
+const maxAssetTickerLen = 12
+
+// optionsCurrencies lists the underlyings each crypto options platform
+// quotes. Robinhood options trade stock symbols and are configured
+// separately (RobinhoodOptionsSymbols).
+var optionsCurrencies = map[string][]string{
+	"deribit": {"BTC", "ETH"},
+	"ibkr":    {"BTC", "ETH"},
+	"okx":     {"BTC", "ETH"},
+}
+
+// normalizeAssetTicker canonicalizes a user-entered ticker: trims, upper-
+// cases, and accepts a quote-suffixed form ("sol/usdt", "SOL-USDT") by
+// keeping the base. Rejects anything that isn't 1..maxAssetTickerLen
+// letters/digits.
+func normalizeAssetTicker(s string) (string, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	for _, suffix := range []string{"/USDT", "-USDT", "/USD", "-USD"} {
+		t = strings.TrimSuffix(t, suffix)
+	}
+	if t == "" {
+		return "", fmt.Errorf("empty ticker")
+	}
+	if len(t) > maxAssetTickerLen {
+		return "", fmt.Errorf("ticker %q is longer than %d characters", s, maxAssetTickerLen)
+	}
+	for _, r := range t {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return "", fmt.Errorf("ticker %q may only contain letters and digits", s)
+		}
+	}
+	return t, nil
+}
+
+// parseAssetTickers splits a comma/space separated ticker list, normalizing
+// each entry and dropping duplicates. Returns the valid tickers in input
+// order plus one error per rejected entry.
+func parseAssetTickers(s string) ([]string, []error) {
+	var out []string
+	var errs []error
+	seen := make(map[string]bool)
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		t, err := normalizeAssetTicker(f)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, errs
+}
+
+// isBuiltinAsset reports whether name is one of supportedAssets — those are
+// known-listed everywhere the wizard offers them and skip the lookups.
+func isBuiltinAsset(name string) bool {
+	for _, a := range supportedAssets {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// assetSpotSymbol returns the CCXT spot symbol for an asset name: the
+// supportedAssets entry when there is one, else "<NAME>/USDT".
+func assetSpotSymbol(name string) string {
+	for _, a := range supportedAssets {
+		if a.Name == name {
+			return a.Symbol
+		}
+	}
+	return name + "/USDT"
+}
+
+// optionsCurrencySupported reports whether a crypto options platform quotes
+// options on asset.
+func optionsCurrencySupported(platform, asset string) bool {
+	for _, c := range optionsCurrencies[platform] {
+		if c == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// assetListingProblem is one platform on which a custom ticker could not be
+// confirmed. Unverified (lookup failed) is softer than not listed: the wizard
+// lets the operator keep the ticker, --json validation only fails on !Listed.
+type assetListingProblem struct {
+	Platform   string
+	Detail     string
+	Unverified bool
+}
+
+func (p assetListingProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Platform, p.Detail)
+}
+
+// checkAssetListings verifies a custom ticker against every platform opts
+// would generate strategies for: BinanceUS spot pair, Hyperliquid perp, OKX
+// spot/swap instrument, and the static options currency list. Luno and
+// Robinhood crypto have no public listing endpoint wired here and are left to
+// the startup probe. Options platforms that don't quote the asset are not a
+// problem — generateConfig simply skips them — so they're reported as notes.
+func checkAssetListings(asset string, opts InitOptions) (problems []assetListingProblem, notes []string) {
+	check := func(platform string, listed bool, err error) {
+		switch {
+		case err != nil:
+			problems = append(problems, assetListingProblem{Platform: platform, Detail: fmt.Sprintf("could not verify (%v)", err), Unverified: true})
+		case !listed:
+			problems = append(problems, assetListingProblem{Platform: platform, Detail: "not listed"})
+		}
+	}
+	if opts.EnableSpot {
+		sym := assetSpotSymbol(asset)
+		listed, err := binanceUSSpotListed(sym)
+		check("binanceus "+sym, listed, err)
+	}
+	if opts.EnablePerps {
+		listed, err := hyperliquidPerpListed(asset)
+		check("hyperliquid "+asset, listed, err)
+	}
+	if opts.EnableOKX {
+		if len(opts.OKXSpotStrategies) > 0 {
+			listed, err := okxInstrumentListed("SPOT", asset+"-USDT")
+			check("okx "+asset+"-USDT", listed, err)
+		}
+		if len(opts.OKXPerpsStrategies) > 0 {
+			listed, err := okxInstrumentListed("SWAP", asset+"-USDT-SWAP")
+			check("okx "+asset+"-USDT-SWAP", listed, err)
+		}
+	}
+	if opts.EnableOptions {
+		for _, platform := range opts.OptionPlatforms {
+			if platform == "robinhood" || optionsCurrencySupported(platform, asset) {
+				continue
+			}
+			notes = append(notes, fmt.Sprintf("%s options don't support %s (supported: %s) — skipped", platform, asset, strings.Join(optionsCurrencies[platform], ", ")))
+		}
+	}
+	return problems, notes
+}
+
+// unlistedAssets runs checkAssetListings for every non-builtin asset in opts
+// and returns "<asset> — <platform>: not listed" lines for confirmed misses,
+// sorted. Used by `init --json` with validateAssets.
+func unlistedAssets(opts InitOptions) []string {
+	var out []string
+	for _, a := range opts.Assets {
+		if isBuiltinAsset(a) {
+			continue
+		}
+		problems, _ := checkAssetListings(a, opts)
+		for _, p := range problems {
+			if !p.Unverified {
+				out = append(out, fmt.Sprintf("%s — %s", a, p))
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+var assetLookupClient = &http.Client{Timeout: 10 * time.Second}
+
+// binanceUSSpotListed asks BinanceUS exchangeInfo for a single symbol. The
+// endpoint answers 400 "Invalid symbol" for pairs that don't exist.
+func binanceUSSpotListed(symbol string) (bool, error) {
+	pair := strings.ReplaceAll(symbol, "/", "")
+	resp, err := assetLookupClient.Get(binanceUSAPIURL + "/api/v3/exchangeInfo?symbol=" + url.QueryEscape(pair))
+	if err != nil {
+		return false, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest:
+		return false, nil
+	default:
+		return false, fmt.Errorf("http %d from %s exchangeInfo", resp.StatusCode, binanceUSAPIURL)
+	}
+}
+
+// hyperliquidPerpListed checks the /info meta universe for coin. Delisted
+// coins stay in the universe flagged isDelisted and count as not listed.
+func hyperliquidPerpListed(coin string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"type": "meta"})
+	if err != nil {
+		return false, fmt.Errorf("marshal meta request: %w", err)
+	}
+	resp, err := assetLookupClient.Post(hlMainnetURL+"/info", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("http %d from %s/info meta", resp.StatusCode, hlMainnetURL)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("read meta response: %w", err)
+	}
+	var meta struct {
+		Universe []struct {
+			Name       string `json:"name"`
+			IsDelisted bool   `json:"isDelisted"`
+		} `json:"universe"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return false, fmt.Errorf("parse meta response: %w", err)
+	}
+	for _, u := range meta.Universe {
+		if u.Name == coin {
+			return !u.IsDelisted, nil
+		}
+	}
+	return false, nil
+}
+
+// okxInstrumentListed queries OKX public instruments for one instId. Unknown
+// instruments come back with a non-"0" code or an empty data array.
+func okxInstrumentListed(instType, instID string) (bool, error) {
+	q := url.Values{"instType": {instType}, "instId": {instID}}
+	resp, err := assetLookupClient.Get(okxPublicAPIURL + "/api/v5/public/instruments?" + q.Encode())
+	if err != nil {
+		return false, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return false, fmt.Errorf("http %d from %s instruments", resp.StatusCode, okxPublicAPIURL)
+	}
+	var out struct {
+		Code string            `json:"code"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("parse instruments response: %w", err)
+	}
+	return out.Code == "0" && len(out.Data) > 0, nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAssetTickers(t *testing.T) {
+	got, errs := parseAssetTickers(" avax, sol/usdt  LINK-USDT,avax,bad$,")
+	if want := []string{"AVAX", "SOL", "LINK"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("tickers = %v, want %v", got, want)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "letters and digits") {
+		t.Errorf("errs = %v, want one charset error", errs)
+	}
+	if _, err := normalizeAssetTicker("TOOLONGTICKERNAME"); err == nil {
+		t.Error("expected over-long ticker to be rejected")
+	}
+}
+
+func TestGenerateConfig_CustomAssetSymbols(t *testing.T) {
+	cfg := generateConfig(InitOptions{
+		Assets:          []string{"BTC", "AVAX"},
+		EnableSpot:      true,
+		SpotStrategies:  []string{"sma_crossover"},
+		IncludePairs:    true,
+		EnableOptions:   true,
+		OptStrategies:   []string{"vol_mean_reversion"},
+		OptionPlatforms: []string{"deribit"},
+		SpotCapital:     1000,
+		OptionsCapital:  5000,
+	})
+	byID := make(map[string]StrategyConfig)
+	for _, sc := range cfg.Strategies {
+		byID[sc.ID] = sc
+	}
+	if sc, ok := byID["sma-avax"]; !ok || sc.Args[1] != "AVAX/USDT" {
+		t.Errorf("sma-avax = %+v, want spot strategy on AVAX/USDT", sc)
+	}
+	if sc, ok := byID["pairs-btc-avax"]; !ok || sc.Args[3] != "AVAX/USDT" {
+		t.Errorf("pairs-btc-avax = %+v, want AVAX/USDT leg", sc)
+	}
+	for id := range byID {
+		if strings.HasPrefix(id, "deribit-") && strings.HasSuffix(id, "-avax") {
+			t.Errorf("unexpected deribit options strategy %s for unsupported AVAX", id)
+		}
+	}
+	if _, ok := byID["deribit-vol-btc"]; !ok {
+		t.Errorf("expected deribit-vol-btc, got %v", cfg.Strategies)
+	}
+}
+
+// stubListingServers points the BinanceUS/HL/OKX lookups at one stub that
+// lists BTC everywhere and AVAX only on BinanceUS spot.
+func stubListingServers(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3/exchangeInfo":
+			if sym := r.URL.Query().Get("symbol"); sym != "BTCUSDT" && sym != "AVAXUSDT" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+				return
+			}
+			w.Write([]byte(`{"symbols":[{}]}`))
+		case r.URL.Path == "/info":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"meta"`) {
+				http.Error(w, "unexpected", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"universe":[{"name":"BTC"},{"name":"LUNA","isDelisted":true}]}`))
+		case r.URL.Path == "/api/v5/public/instruments":
+			if strings.HasPrefix(r.URL.Query().Get("instId"), "BTC-") {
+				w.Write([]byte(`{"code":"0","data":[{}]}`))
+				return
+			}
+			w.Write([]byte(`{"code":"51001","data":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	prevBinance, prevOKX, prevHL := binanceUSAPIURL, okxPublicAPIURL, hlMainnetURL
+	binanceUSAPIURL, okxPublicAPIURL, hlMainnetURL = srv.URL, srv.URL, srv.URL
+	t.Cleanup(func() { binanceUSAPIURL, okxPublicAPIURL, hlMainnetURL = prevBinance, prevOKX, prevHL })
+}
+
+func TestCheckAssetListings(t *testing.T) {
+	stubListingServers(t)
+	opts := InitOptions{
+		EnableSpot:         true,
+		EnablePerps:        true,
+		EnableOKX:          true,
+		OKXSpotStrategies:  []string{"sma_crossover"},
+		EnableOptions:      true,
+		OptionPlatforms:    []string{"deribit", "robinhood"},
+		OKXPerpsStrategies: nil,
+	}
+	problems, notes := checkAssetListings("BTC", opts)
+	if len(problems) != 0 || len(notes) != 0 {
+		t.Errorf("BTC problems=%v notes=%v, want none", problems, notes)
+	}
+	problems, notes = checkAssetListings("AVAX", opts)
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	if want := "hyperliquid AVAX: not listed|okx AVAX-USDT: not listed"; strings.Join(got, "|") != want {
+		t.Errorf("AVAX problems = %q, want %q", got, want)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "deribit options don't support AVAX") {
+		t.Errorf("AVAX notes = %v", notes)
+	}
+	if listed, err := hyperliquidPerpListed("LUNA"); err != nil || listed {
+		t.Errorf("delisted LUNA listed=%v err=%v, want false", listed, err)
+	}
+}
+
+func TestCheckAssetListings_LookupFailureIsUnverified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	prev := binanceUSAPIURL
+	binanceUSAPIURL = srv.URL
+	t.Cleanup(func() { binanceUSAPIURL = prev })
+
+	problems, _ := checkAssetListings("AVAX", InitOptions{EnableSpot: true})
+	if len(problems) != 1 || !problems[0].Unverified {
+		t.Fatalf("problems = %+v, want one unverified", problems)
+	}
+	if missing := unlistedAssets(InitOptions{Assets: []string{"AVAX"}, EnableSpot: true}); len(missing) != 0 {
+		t.Errorf("unlistedAssets = %v, want unverified lookups not to fail validation", missing)
+	}
+}
+
+func TestRunInitFromJSON_CustomAssets(t *testing.T) {
+	stubListingServers(t)
+	dir := t.TempDir()
+
+	out := filepath.Join(dir, "ok.json")
+	if code := runInitFromJSON(`{"assets":["avax"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"spotDrawdown":10,"validateAssets":true}`, out, false); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Strategies) != 1 || cfg.Strategies[0].ID != "sma-avax" || cfg.Strategies[0].Args[1] != "AVAX/USDT" {
+		t.Errorf("strategies = %+v, want sma-avax on AVAX/USDT", cfg.Strategies)
+	}
+
+	if code := runInitFromJSON(`{"assets":["AVAX"],"enablePerps":true,"validateAssets":true}`, filepath.Join(dir, "perps.json"), false); code != 1 {
+		t.Errorf("unlisted HL coin: expected exit 1, got %d", code)
+	}
+	if code := runInitFromJSON(`{"assets":["AV AX!"],"enableSpot":true,"spotStrategies":["sma_crossover"]}`, filepath.Join(dir, "bad.json"), false); code != 1 {
+		t.Errorf("malformed ticker: expected exit 1, got %d", code)
+	}
+}
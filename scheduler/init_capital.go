@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// allocCapital resolves the capital for one generated strategy: a
+// per-strategy-template override (StrategyCapital, keyed by strategy ID such
+// as "sma_crossover") beats a per-asset override (AssetCapital, keyed by asset
+// name or futures symbol), which beats the type-wide default. assetName is ""
+// for strategies that span several assets (pairs).
+func allocCapital(opts InitOptions, typeCapital float64, stratID, assetName string) float64 {
+	if v, ok := opts.StrategyCapital[stratID]; ok {
+		return v
+	}
+	if assetName != "" {
+		if v, ok := opts.AssetCapital[assetName]; ok {
+			return v
+		}
+	}
+	return typeCapital
+}
+
+// validateCapitalAllocation checks the per-asset/per-strategy overrides and
+// budget before generateConfig: every value positive, every asset key one of
+// the selected assets, futures symbols or Robinhood options symbols. Strategy
+// keys aren't checked against the selection — a template that generates
+// nothing is harmless.
+func validateCapitalAllocation(opts InitOptions) []string {
+	var errs []string
+	known := make(map[string]bool)
+	for _, a := range opts.Assets {
+		known[a] = true
+	}
+	for _, s := range opts.FuturesSymbols {
+		known[s] = true
+	}
+	for _, s := range opts.RobinhoodOptionsSymbols {
+		known[s] = true
+	}
+	for _, k := range sortedCapitalKeys(opts.AssetCapital) {
+		if !known[k] {
+			errs = append(errs, fmt.Sprintf("assetCapital: %q is not a selected asset", k))
+		}
+		if v := opts.AssetCapital[k]; v <= 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			errs = append(errs, fmt.Sprintf("assetCapital[%s] must be > 0, got %g", k, v))
+		}
+	}
+	for _, k := range sortedCapitalKeys(opts.StrategyCapital) {
+		if v := opts.StrategyCapital[k]; v <= 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			errs = append(errs, fmt.Sprintf("strategyCapital[%s] must be > 0, got %g", k, v))
+		}
+	}
+	if opts.TotalBudget < 0 || math.IsNaN(opts.TotalBudget) || math.IsInf(opts.TotalBudget, 0) {
+		errs = append(errs, fmt.Sprintf("totalBudget must be >= 0, got %g", opts.TotalBudget))
+	}
+	return errs
+}
+
+func sortedCapitalKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// totalStrategyCapital sums capital across every generated strategy,
+// including the manual tracking strategy.
+func totalStrategyCapital(cfg *Config) float64 {
+	total := 0.0
+	for _, sc := range cfg.Strategies {
+		total += sc.Capital
+	}
+	return total
+}
+
+// checkCapitalBudget is the total-budget sanity check: nil when budget is 0
+// (unchecked) or the generated strategies fit inside it.
+func checkCapitalBudget(cfg *Config, budget float64) error {
+	if budget <= 0 {
+		return nil
+	}
+	if total := totalStrategyCapital(cfg); total > budget {
+		return fmt.Errorf("generated strategies allocate $%.2f across %d strategies, over the $%.2f total budget", total, len(cfg.Strategies), budget)
+	}
+	return nil
+}
+
+// scaleCapitalToBudget shrinks every strategy's capital by the same factor so
+// the total fits budget. No-op when already within budget. Amounts round down
+// to the cent so rounding can't push the total back over.
+func scaleCapitalToBudget(cfg *Config, budget float64) {
+	total := totalStrategyCapital(cfg)
+	if budget <= 0 || total <= budget {
+		return
+	}
+	f := budget / total
+	for i := range cfg.Strategies {
+		cfg.Strategies[i].Capital = math.Floor(cfg.Strategies[i].Capital*f*100) / 100
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateConfig_CapitalAllocation(t *testing.T) {
+	cfg := generateConfig(InitOptions{
+		Assets:          []string{"BTC", "ETH"},
+		EnableSpot:      true,
+		SpotStrategies:  []string{"sma_crossover", "rsi"},
+		IncludePairs:    true,
+		SpotCapital:     1000,
+		AssetCapital:    map[string]float64{"ETH": 400},
+		StrategyCapital: map[string]float64{"rsi": 250, "pairs_spread": 300},
+	})
+	want := map[string]float64{
+		"sma-btc":       1000, // type default
+		"sma-eth":       400,  // asset override
+		"rsi-btc":       250,  // strategy override beats asset
+		"rsi-eth":       250,
+		"pairs-btc-eth": 300,
+	}
+	for _, sc := range cfg.Strategies {
+		if w, ok := want[sc.ID]; !ok || sc.Capital != w {
+			t.Errorf("%s capital = %v, want %v (listed=%v)", sc.ID, sc.Capital, w, ok)
+		}
+	}
+	if len(cfg.Strategies) != len(want) {
+		t.Errorf("got %d strategies, want %d", len(cfg.Strategies), len(want))
+	}
+	if got := totalStrategyCapital(cfg); got != 2200 {
+		t.Errorf("total = %v, want 2200", got)
+	}
+}
+
+func TestCapitalBudget(t *testing.T) {
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "a", Capital: 1000}, {ID: "b", Capital: 500}}}
+	if err := checkCapitalBudget(cfg, 0); err != nil {
+		t.Errorf("no budget: %v", err)
+	}
+	if err := checkCapitalBudget(cfg, 1500); err != nil {
+		t.Errorf("exact budget: %v", err)
+	}
+	err := checkCapitalBudget(cfg, 1000)
+	if err == nil || !strings.Contains(err.Error(), "over the $1000.00 total budget") {
+		t.Fatalf("over budget err = %v", err)
+	}
+	scaleCapitalToBudget(cfg, 1000)
+	if cfg.Strategies[0].Capital != 666.66 || cfg.Strategies[1].Capital != 333.33 {
+		t.Errorf("scaled = %v / %v, want 666.66 / 333.33", cfg.Strategies[0].Capital, cfg.Strategies[1].Capital)
+	}
+	if err := checkCapitalBudget(cfg, 1000); err != nil {
+		t.Errorf("after scaling: %v", err)
+	}
+}
+
+func TestValidateCapitalAllocation(t *testing.T) {
+	errs := validateCapitalAllocation(InitOptions{
+		Assets:          []string{"BTC"},
+		FuturesSymbols:  []string{"ES"},
+		AssetCapital:    map[string]float64{"BTC": 100, "ES": 2000, "DOGE": 50},
+		StrategyCapital: map[string]float64{"rsi": -1},
+		TotalBudget:     -5,
+	})
+	got := strings.Join(errs, "|")
+	for _, want := range []string{`"DOGE" is not a selected asset`, "strategyCapital[rsi] must be > 0", "totalBudget must be >= 0"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("errs %q missing %q", got, want)
+		}
+	}
+	if len(errs) != 3 {
+		t.Errorf("errs = %v, want 3", errs)
+	}
+}
+
+func TestRunInitFromJSON_TotalBudget(t *testing.T) {
+	dir := t.TempDir()
+	over := `{"assets":["BTC","ETH"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"totalBudget":1500}`
+	if code := runInitFromJSON(over, filepath.Join(dir, "over.json"), false); code != 1 {
+		t.Errorf("over budget: expected exit 1, got %d", code)
+	}
+	fits := `{"assets":["BTC","ETH"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"assetCapital":{"ETH":500},"totalBudget":1500}`
+	if code := runInitFromJSON(fits, filepath.Join(dir, "fits.json"), false); code != 0 {
+		t.Errorf("within budget: expected exit 0, got %d", code)
+	}
+	unknown := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["sma_crossover"],"assetCapital":{"ETH":500}}`
+	if code := runInitFromJSON(unknown, filepath.Join(dir, "unknown.json"), false); code != 1 {
+		t.Errorf("unknown asset key: expected exit 1, got %d", code)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// initMergeBase is an existing config opened by `init --merge`. The root file
+// is kept as raw JSON (same approach as the dashboard/Discord config writers)
+// so every existing key and strategy entry is written back byte-for-byte in
+// meaning — only new strategies are appended.
+type initMergeBase struct {
+	path       string
+	root       map[string]json.RawMessage
+	strategies []json.RawMessage         // the root file's own strategies, in order
+	existing   map[string]StrategyConfig // every configured strategy by ID, incl. include fragments
+	ids        []string                  // existing IDs in config order
+	dbFile     string
+}
+
+// loadInitMergeBase reads the config at path for merging. Strategies pulled in
+// through "include" fragments count as existing (never duplicated) but stay in
+// their fragment; new strategies are appended to the root file.
+func loadInitMergeBase(path string) (*initMergeBase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	b := &initMergeBase{path: path, existing: make(map[string]StrategyConfig)}
+	if err := json.Unmarshal(data, &b.root); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if raw, ok := b.root["strategies"]; ok && !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &b.strategies); err != nil {
+			return nil, fmt.Errorf("parse %s strategies: %w", path, err)
+		}
+	}
+	merged, _, err := resolveConfigIncludes(data, path)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct {
+		DBFile     string           `json:"db_file"`
+		Strategies []StrategyConfig `json:"strategies"`
+	}
+	if err := json.Unmarshal(merged, &parsed); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for _, sc := range parsed.Strategies {
+		if _, dup := b.existing[sc.ID]; dup {
+			continue
+		}
+		b.existing[sc.ID] = sc
+		b.ids = append(b.ids, sc.ID)
+	}
+	b.dbFile = parsed.DBFile
+	if b.dbFile == "" {
+		b.dbFile = "scheduler/state.db"
+	}
+	return b, nil
+}
+
+// split partitions a freshly generated config into strategies the existing
+// config lacks (added) and IDs it already has (kept — the existing entry, its
+// capital and its persisted state win untouched).
+func (b *initMergeBase) split(cfg *Config) (added []StrategyConfig, kept []string) {
+	for _, sc := range cfg.Strategies {
+		if _, ok := b.existing[sc.ID]; ok {
+			kept = append(kept, sc.ID)
+			continue
+		}
+		added = append(added, sc)
+	}
+	return added, kept
+}
+
+// existingCapital sums capital across the configured strategies.
+func (b *initMergeBase) existingCapital() float64 {
+	total := 0.0
+	for _, sc := range b.existing {
+		total += sc.Capital
+	}
+	return total
+}
+
+// render returns the root config with added appended to its strategies.
+func (b *initMergeBase) render(added []StrategyConfig) ([]byte, error) {
+	strategies := append([]json.RawMessage(nil), b.strategies...)
+	for _, sc := range added {
+		raw, err := json.Marshal(sc)
+		if err != nil {
+			return nil, fmt.Errorf("marshal strategy %s: %w", sc.ID, err)
+		}
+		strategies = append(strategies, raw)
+	}
+	raw, err := json.Marshal(strategies)
+	if err != nil {
+		return nil, fmt.Errorf("marshal strategies: %w", err)
+	}
+	root := make(map[string]json.RawMessage, len(b.root)+1)
+	for k, v := range b.root {
+		root[k] = v
+	}
+	root["strategies"] = raw
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// write atomically replaces the config with the merged document.
+func (b *initMergeBase) write(added []StrategyConfig) error {
+	data, err := b.render(added)
+	if err != nil {
+		return err
+	}
+	tmpPath := b.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("write tmp: %w", err)
+	}
+	return os.Rename(tmpPath, b.path)
+}
+
+// stateSummary describes the persisted state for the existing config: one
+// line per configured strategy that has state, plus a warning for strategies
+// in the DB that no configured ID covers (those are pruned on the next start
+// whether or not this merge runs). Missing/unreadable DB → a single note.
+func (b *initMergeBase) stateSummary() []string {
+	if _, err := os.Stat(b.dbFile); err != nil {
+		return []string{fmt.Sprintf("no state DB at %s", b.dbFile)}
+	}
+	// Read-only: `init --merge` may run next to the live daemon, so it must
+	// neither migrate the file nor contend for the singleton lock.
+	db, err := openStateDBForRead(b.dbFile)
+	if err != nil {
+		return []string{fmt.Sprintf("state DB %s unavailable: %v", b.dbFile, err)}
+	}
+	sdb := &StateDB{db: db}
+	defer sdb.Close()
+	state, err := sdb.LoadState()
+	if err != nil {
+		return []string{fmt.Sprintf("state DB %s unavailable: %v", b.dbFile, err)}
+	}
+	if state == nil {
+		return []string{fmt.Sprintf("state DB %s is empty", b.dbFile)}
+	}
+	var lines, orphans []string
+	for _, id := range b.ids {
+		ss := state.Strategies[id]
+		if ss == nil {
+			continue
+		}
+		open := 0
+		for _, pos := range ss.Positions {
+			if pos != nil && pos.Quantity != 0 {
+				open++
+			}
+		}
+		for _, op := range ss.OptionPositions {
+			if op != nil && op.Quantity != 0 {
+				open++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s: cash $%.2f, %d open position(s), %d trade(s)", id, ss.Cash, open, len(ss.TradeHistory)))
+	}
+	for id := range state.Strategies {
+		if _, ok := b.existing[id]; !ok {
+			orphans = append(orphans, id)
+		}
+	}
+	sort.Strings(orphans)
+	if len(orphans) > 0 {
+		lines = append(lines, fmt.Sprintf("WARNING: state for %s has no config entry and will be pruned on next start", strings.Join(orphans, ", ")))
+	}
+	return lines
+}
+
+// writeMergedInitConfig is the `init --merge --json` tail: merge the
+// generated strategies into the config at path. With a budget, the existing
+// capital counts against it and nothing is scaled — the existing allocations
+// are exactly what merge mode promises not to touch.
+func writeMergedInitConfig(path string, cfg *Config, budget float64) int {
+	base, err := loadInitMergeBase(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --merge: %v\n", err)
+		return 1
+	}
+	added, kept := base.split(cfg)
+	if budget > 0 {
+		newCfg := &Config{Strategies: added}
+		if total := base.existingCapital() + totalStrategyCapital(newCfg); total > budget {
+			fmt.Fprintf(os.Stderr, "Error: merged config allocates $%.2f ($%.2f existing + $%.2f new), over the $%.2f total budget\n",
+				total, base.existingCapital(), totalStrategyCapital(newCfg), budget)
+			return 1
+		}
+	}
+	if err := base.write(added); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+		return 1
+	}
+	for _, id := range kept {
+		fmt.Fprintf(os.Stderr, "kept existing %s\n", id)
+	}
+	for _, sc := range added {
+		fmt.Fprintf(os.Stderr, "added %s\n", sc.ID)
+	}
+	fmt.Println(path)
+	return 0
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeMergeFixture writes a root config with one spot strategy (custom
+// capital), an unrelated top-level key, and an include fragment holding a
+// second strategy.
+func writeMergeFixture(t *testing.T, dir string) string {
+	t.Helper()
+	frag := `{"strategies":[{"id":"sma-eth","type":"spot","platform":"binanceus","script":"shared_scripts/check_strategy.py","args":["sma_crossover","ETH/USDT","1h"],"capital":333}]}`
+	if err := os.WriteFile(filepath.Join(dir, "frag.json"), []byte(frag), 0600); err != nil {
+		t.Fatal(err)
+	}
+	root := `{
+  "interval_seconds": 600,
+  "db_file": "` + filepath.Join(dir, "state.db") + `",
+  "include": ["frag.json"],
+  "operator_note": "keep me",
+  "strategies": [
+    {"id":"sma-btc","type":"spot","platform":"binanceus","script":"shared_scripts/check_strategy.py","args":["sma_crossover","BTC/USDT","1h"],"capital":777,"max_drawdown_pct":7}
+  ]
+}`
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(root), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunInitFromJSON_MergePreservesExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := writeMergeFixture(t, dir)
+
+	jsonStr := `{"assets":["BTC","ETH","SOL"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"spotDrawdown":10}`
+	if code := runInitFromJSON(jsonStr, path, true); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		t.Fatal(err)
+	}
+	if string(root["operator_note"]) != `"keep me"` || string(root["interval_seconds"]) != "600" {
+		t.Errorf("top-level keys not preserved: %s", data)
+	}
+	var got struct {
+		Strategies []StrategyConfig `json:"strategies"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, sc := range got.Strategies {
+		ids = append(ids, sc.ID)
+	}
+	// sma-eth lives in the fragment: counted as existing, not duplicated.
+	if strings.Join(ids, ",") != "sma-btc,sma-sol" {
+		t.Fatalf("root strategies = %v, want [sma-btc sma-sol]", ids)
+	}
+	if got.Strategies[0].Capital != 777 || got.Strategies[0].MaxDrawdownPct != 7 {
+		t.Errorf("existing sma-btc changed: %+v", got.Strategies[0])
+	}
+	if got.Strategies[1].Capital != 1000 {
+		t.Errorf("new sma-sol capital = %v, want 1000", got.Strategies[1].Capital)
+	}
+}
+
+func TestRunInitFromJSON_MergeBudgetCountsExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := writeMergeFixture(t, dir)
+	before, _ := os.ReadFile(path)
+
+	// 777 + 333 existing + 1000 new > 2000.
+	jsonStr := `{"assets":["SOL"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"totalBudget":2000}`
+	if code := runInitFromJSON(jsonStr, path, true); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("config rewritten despite budget failure")
+	}
+	if code := runInitFromJSON(jsonStr, filepath.Join(dir, "missing.json"), true); code != 1 {
+		t.Errorf("merge into missing config: expected exit 1, got %d", code)
+	}
+}
+
+func TestInitMergeStateSummary(t *testing.T) {
+	dir := t.TempDir()
+	path := writeMergeFixture(t, dir)
+	base, err := loadInitMergeBase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := base.stateSummary(); len(lines) != 1 || !strings.Contains(lines[0], "no state DB") {
+		t.Errorf("summary without DB = %v", lines)
+	}
+
+	sdb, err := OpenStateDB(base.dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := NewAppState()
+	btc := NewStrategyState(base.existing["sma-btc"])
+	btc.Positions["BTC/USDT"] = &Position{Symbol: "BTC/USDT", Quantity: 0.01, Side: "long"}
+	state.Strategies["sma-btc"] = btc
+	state.Strategies["old-gone"] = NewStrategyState(StrategyConfig{ID: "old-gone", Capital: 100})
+	if err := sdb.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	sdb.Close()
+
+	got := strings.Join(base.stateSummary(), "\n")
+	if !strings.Contains(got, "sma-btc: cash $777.00, 1 open position(s)") {
+		t.Errorf("summary missing sma-btc state:\n%s", got)
+	}
+	if !strings.Contains(got, "old-gone has no config entry") {
+		t.Errorf("summary missing orphan warning:\n%s", got)
+	}
+}
+
+func TestInitMergeStateSummaryNextToLiveDaemon(t *testing.T) {
+	dir := t.TempDir()
+	path := writeMergeFixture(t, dir)
+	base, err := loadInitMergeBase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An encrypted DB held open by the "daemon" keeps the singleton lock; the
+	// summary must still read it instead of failing on the lock.
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	sdb, err := OpenStateDB(base.dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sdb.Close()
+	state := NewAppState()
+	state.Strategies["sma-btc"] = NewStrategyState(base.existing["sma-btc"])
+	if err := sdb.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(base.stateSummary(), "\n")
+	if !strings.Contains(got, "sma-btc: cash $777.00") {
+		t.Errorf("summary while the DB is held:\n%s", got)
+	}
+}
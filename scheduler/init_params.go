@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InitStrategyOverride customizes one strategy template for every strategy
+// init generates from it: Timeframe replaces the positional timeframe arg
+// (args[2], "1h" by default, "1d" for pairs) and Params become the
+// open_strategy params the check script merges over the registry defaults
+// (e.g. lookback windows: {"fast_period": 10, "slow_period": 30}).
+type InitStrategyOverride struct {
+	Timeframe string                 `json:"timeframe,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+}
+
+// validateStrategyOverrides checks InitOptions.StrategyParams: known
+// timeframes, non-empty param names, and no overrides on options templates
+// (check_options.py takes neither a timeframe nor open_strategy params).
+func validateStrategyOverrides(opts InitOptions) []string {
+	var errs []string
+	ids := make([]string, 0, len(opts.StrategyParams))
+	for id := range opts.StrategyParams {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	optionsOnly := make(map[string]bool)
+	for _, id := range opts.OptStrategies {
+		optionsOnly[id] = true
+	}
+	for _, list := range [][]string{opts.SpotStrategies, opts.PerpsStrategies, opts.FuturesStrategies, opts.LunoStrategies, opts.RobinhoodStrategies, opts.OKXSpotStrategies, opts.OKXPerpsStrategies} {
+		for _, id := range list {
+			delete(optionsOnly, id)
+		}
+	}
+	for _, id := range ids {
+		o := opts.StrategyParams[id]
+		if optionsOnly[id] {
+			errs = append(errs, fmt.Sprintf("strategyParams[%s]: options strategies take no timeframe/params overrides", id))
+			continue
+		}
+		if o.Timeframe != "" && !validRegimeTimeframe(o.Timeframe) {
+			errs = append(errs, fmt.Sprintf("strategyParams[%s]: unknown timeframe %q (want one of %s)", id, o.Timeframe, strings.Join(validRegimeTimeframes(), ", ")))
+		}
+		for k := range o.Params {
+			if strings.TrimSpace(k) == "" {
+				errs = append(errs, fmt.Sprintf("strategyParams[%s]: empty param name", id))
+			}
+		}
+	}
+	return errs
+}
+
+// applyStrategyOverrides stamps StrategyParams onto the generated strategies.
+// Options and manual strategies are left alone; every other generated type
+// carries [strategy, symbol, timeframe, ...] args.
+func applyStrategyOverrides(cfg *Config, overrides map[string]InitStrategyOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+	for i := range cfg.Strategies {
+		sc := &cfg.Strategies[i]
+		if sc.Type == "options" || sc.Type == "manual" || len(sc.Args) < 3 {
+			continue
+		}
+		o, ok := overrides[sc.Args[0]]
+		if !ok {
+			continue
+		}
+		if o.Timeframe != "" {
+			sc.Args[2] = normalizeRegimeTimeframe(o.Timeframe)
+		}
+		if len(o.Params) > 0 {
+			params := make(map[string]interface{}, len(o.Params))
+			for k, v := range o.Params {
+				params[k] = v
+			}
+			sc.OpenStrategy = StrategyRef{Name: sc.Args[0], Params: params}
+		}
+	}
+}
+
+// parseParamOverrides parses the wizard's "key=value, key=value" params
+// entry. Values that parse as a number or bool are typed; anything else stays
+// a string.
+func parseParamOverrides(s string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("want key=value, got %q", part)
+		}
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			out[k] = n
+		} else if b, err := strconv.ParseBool(v); err == nil {
+			out[k] = b
+		} else {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
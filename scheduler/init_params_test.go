@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseParamOverrides(t *testing.T) {
+	got, err := parseParamOverrides(" fast_period=10, use_htf = true ,mode=ema,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["fast_period"] != 10.0 || got["use_htf"] != true || got["mode"] != "ema" || len(got) != 3 {
+		t.Errorf("params = %#v", got)
+	}
+	if _, err := parseParamOverrides("fast_period"); err == nil {
+		t.Error("expected error for missing '='")
+	}
+}
+
+func TestGenerateConfig_StrategyOverrides(t *testing.T) {
+	cfg := generateConfig(InitOptions{
+		Assets:          []string{"BTC", "ETH"},
+		EnableSpot:      true,
+		SpotStrategies:  []string{"chart_pattern", "momentum_pro"},
+		EnablePerps:     true,
+		PerpsMode:       "paper",
+		PerpsStrategies: []string{"chart_pattern"},
+		SpotCapital:     1000,
+		PerpsCapital:    1000,
+		StrategyParams: map[string]InitStrategyOverride{
+			"chart_pattern": {Timeframe: "4H", Params: map[string]interface{}{"lookback": 50.0}},
+			"momentum_pro":  {Timeframe: "15m"},
+		},
+	})
+	seen := 0
+	for _, sc := range cfg.Strategies {
+		switch sc.Args[0] {
+		case "chart_pattern":
+			seen++
+			if sc.Args[2] != "4h" || sc.OpenStrategy.Name != "chart_pattern" || sc.OpenStrategy.Params["lookback"] != 50.0 {
+				t.Errorf("%s args=%v open=%+v", sc.ID, sc.Args, sc.OpenStrategy)
+			}
+		case "momentum_pro":
+			seen++
+			if sc.Args[2] != "15m" || sc.OpenStrategy.Name != "" {
+				t.Errorf("%s args=%v open=%+v, want timeframe only", sc.ID, sc.Args, sc.OpenStrategy)
+			}
+		}
+	}
+	if seen != 6 {
+		t.Errorf("saw %d overridden strategies, want 6", seen)
+	}
+}
+
+func TestValidateStrategyOverrides(t *testing.T) {
+	errs := validateStrategyOverrides(InitOptions{
+		SpotStrategies: []string{"chart_pattern"},
+		OptStrategies:  []string{"covered_calls"},
+		StrategyParams: map[string]InitStrategyOverride{
+			"chart_pattern": {Timeframe: "7h", Params: map[string]interface{}{" ": 1}},
+			"covered_calls": {Timeframe: "1h"},
+		},
+	})
+	got := strings.Join(errs, "|")
+	for _, want := range []string{`unknown timeframe "7h"`, "empty param name", "strategyParams[covered_calls]: options strategies"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("errs %q missing %q", got, want)
+		}
+	}
+}
+
+func TestRunInitFromJSON_StrategyParams(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "config.json")
+	jsonStr := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["chart_pattern"],"spotCapital":1000,"spotDrawdown":10,
+		"strategyParams":{"chart_pattern":{"timeframe":"4h","params":{"lookback":60}}}}`
+	if code := runInitFromJSON(jsonStr, out, false); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateConfig(&cfg, false); err != nil {
+		t.Fatalf("generated config invalid: %v", err)
+	}
+	sc := cfg.Strategies[0]
+	if sc.Args[2] != "4h" || sc.OpenStrategy.Params["lookback"] != 60.0 {
+		t.Errorf("strategy = %+v", sc)
+	}
+
+	bad := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["chart_pattern"],"strategyParams":{"chart_pattern":{"timeframe":"7h"}}}`
+	if code := runInitFromJSON(bad, filepath.Join(dir, "bad.json"), false); code != 1 {
+		t.Errorf("bad timeframe: expected exit 1, got %d", code)
+	}
+}
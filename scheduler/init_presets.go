@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// initPreset is a named starting point for `init`: a full set of strategy
+// selections, capitals, drawdowns, cadences and theta-harvest settings. Every
+// preset is paper-only — going live stays an explicit config edit. Strategy
+// names must stay discovery-visible (never in m5DeprecatedEdgeStrategies);
+// pinned by TestInitPresetsUseVisibleStrategies.
+type initPreset struct {
+	Name        string
+	Description string
+
+	Assets          []string
+	SpotStrategies  []string
+	PerpsStrategies []string
+	OptStrategies   []string
+	OptionPlatforms []string
+
+	SpotCapital, PerpsCapital, OptionsCapital    float64
+	SpotDrawdown, PerpsDrawdown, OptionsDrawdown float64
+	PerpsLeverage                                float64
+
+	IntervalSeconds        int // hourly-cadence strategies (spot/perps)
+	OptionsIntervalSeconds int
+	ThetaHarvest           ThetaHarvestConfig
+}
+
+var initPresets = []initPreset{
+	{
+		Name:                   "conservative",
+		Description:            "BTC spot only, one strategy, $500, 5% drawdown, checked every 4h",
+		Assets:                 []string{"BTC"},
+		SpotStrategies:         []string{starterSpotStrategyID},
+		SpotCapital:            500,
+		SpotDrawdown:           5,
+		IntervalSeconds:        14400,
+		OptionsIntervalSeconds: 14400,
+		ThetaHarvest:           ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 50, StopLossPct: 150, MinDTEClose: 5},
+	},
+	{
+		Name:                   "balanced",
+		Description:            "BTC+ETH spot and 1x paper perps, Deribit covered calls, 8-10% drawdowns, hourly",
+		Assets:                 []string{"BTC", "ETH"},
+		SpotStrategies:         []string{"chart_pattern", "mean_reversion_pro"},
+		PerpsStrategies:        []string{"chart_pattern", "atr_band_revert"},
+		OptStrategies:          []string{"covered_calls"},
+		OptionPlatforms:        []string{"deribit"},
+		SpotCapital:            1000,
+		PerpsCapital:           1000,
+		OptionsCapital:         3000,
+		SpotDrawdown:           8,
+		PerpsDrawdown:          8,
+		OptionsDrawdown:        10,
+		PerpsLeverage:          1,
+		IntervalSeconds:        3600,
+		OptionsIntervalSeconds: 14400,
+		ThetaHarvest:           ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 60, StopLossPct: 200, MinDTEClose: 3},
+	},
+	{
+		Name:                   "aggressive",
+		Description:            "BTC+ETH+SOL spot and 3x paper perps, Deribit vol/momentum options, 15-20% drawdowns, every 30m",
+		Assets:                 []string{"BTC", "ETH", "SOL"},
+		SpotStrategies:         []string{"chart_pattern", "momentum_pro", "liquidity_sweeps"},
+		PerpsStrategies:        []string{"chart_pattern", "momentum_pro", "liquidity_sweeps"},
+		OptStrategies:          []string{"vol_mean_reversion", "momentum_options"},
+		OptionPlatforms:        []string{"deribit"},
+		SpotCapital:            1000,
+		PerpsCapital:           2000,
+		OptionsCapital:         5000,
+		SpotDrawdown:           15,
+		PerpsDrawdown:          15,
+		OptionsDrawdown:        20,
+		PerpsLeverage:          3,
+		IntervalSeconds:        1800,
+		OptionsIntervalSeconds: 7200,
+		ThetaHarvest:           ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 75, StopLossPct: 300, MinDTEClose: 1},
+	},
+}
+
+func findInitPreset(name string) (*initPreset, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := make([]string, len(initPresets))
+	for i := range initPresets {
+		if initPresets[i].Name == name {
+			return &initPresets[i], nil
+		}
+		names[i] = initPresets[i].Name
+	}
+	return nil, fmt.Errorf("unknown preset %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// applyInitPreset fills opts from the named preset (opts.Preset) without
+// overriding anything already set, so `--json` can start from a preset and
+// adjust single fields. Strategy types only come from the preset when the
+// payload enables none itself. No-op when opts.Preset is empty.
+func applyInitPreset(opts *InitOptions) error {
+	if opts.Preset == "" {
+		return nil
+	}
+	pr, err := findInitPreset(opts.Preset)
+	if err != nil {
+		return err
+	}
+	if len(opts.Assets) == 0 {
+		opts.Assets = append([]string(nil), pr.Assets...)
+	}
+	if !hasAnyEnabledStrategyType(*opts) {
+		if len(pr.SpotStrategies) > 0 {
+			opts.EnableSpot = true
+			opts.SpotStrategies = append([]string(nil), pr.SpotStrategies...)
+		}
+		if len(pr.PerpsStrategies) > 0 {
+			opts.EnablePerps = true
+			opts.PerpsMode = "paper"
+			opts.PerpsStrategies = append([]string(nil), pr.PerpsStrategies...)
+		}
+		if len(pr.OptStrategies) > 0 {
+			opts.EnableOptions = true
+			opts.OptStrategies = append([]string(nil), pr.OptStrategies...)
+			opts.OptionPlatforms = append([]string(nil), pr.OptionPlatforms...)
+		}
+	}
+	setFloat := func(dst *float64, v float64) {
+		if *dst == 0 {
+			*dst = v
+		}
+	}
+	setFloat(&opts.SpotCapital, pr.SpotCapital)
+	setFloat(&opts.PerpsCapital, pr.PerpsCapital)
+	setFloat(&opts.OptionsCapital, pr.OptionsCapital)
+	setFloat(&opts.SpotDrawdown, pr.SpotDrawdown)
+	setFloat(&opts.PerpsDrawdown, pr.PerpsDrawdown)
+	setFloat(&opts.OptionsDrawdown, pr.OptionsDrawdown)
+	setFloat(&opts.PerpsLeverage, pr.PerpsLeverage)
+	if opts.IntervalSeconds == 0 {
+		opts.IntervalSeconds = pr.IntervalSeconds
+	}
+	if opts.OptionsIntervalSeconds == 0 {
+		opts.OptionsIntervalSeconds = pr.OptionsIntervalSeconds
+	}
+	if opts.ThetaHarvest == nil {
+		th := pr.ThetaHarvest
+		opts.ThetaHarvest = &th
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitPresetsUseVisibleStrategies(t *testing.T) {
+	for _, pr := range initPresets {
+		for _, list := range [][]string{pr.SpotStrategies, pr.PerpsStrategies} {
+			for _, id := range list {
+				if _, bad := m5DeprecatedEdgeStrategies[id]; bad {
+					t.Errorf("preset %s offers %q, which is M5-quarantined", pr.Name, id)
+				}
+			}
+		}
+	}
+}
+
+func TestInitPresetsGenerateValidConfigs(t *testing.T) {
+	for _, pr := range initPresets {
+		t.Run(pr.Name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "config.json")
+			if code := runInitFromJSON(`{"preset":"`+pr.Name+`"}`, out, false); code != 0 {
+				t.Fatalf("expected exit 0, got %d", code)
+			}
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cfg Config
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				t.Fatal(err)
+			}
+			if err := validateConfig(&cfg, false); err != nil {
+				t.Fatalf("preset config invalid: %v", err)
+			}
+			want := len(pr.Assets) * (len(pr.SpotStrategies) + len(pr.PerpsStrategies))
+			for _, a := range pr.Assets {
+				if optionsCurrencySupported("deribit", a) {
+					want += len(pr.OptStrategies)
+				}
+			}
+			if len(cfg.Strategies) != want {
+				t.Errorf("got %d strategies, want %d", len(cfg.Strategies), want)
+			}
+			for _, sc := range cfg.Strategies {
+				switch sc.Type {
+				case "options":
+					if sc.IntervalSeconds != pr.OptionsIntervalSeconds || sc.ThetaHarvest == nil || *sc.ThetaHarvest != pr.ThetaHarvest {
+						t.Errorf("%s interval=%d theta=%+v", sc.ID, sc.IntervalSeconds, sc.ThetaHarvest)
+					}
+				default:
+					if sc.IntervalSeconds != pr.IntervalSeconds {
+						t.Errorf("%s interval = %d, want %d", sc.ID, sc.IntervalSeconds, pr.IntervalSeconds)
+					}
+				}
+				if isLiveArgs(sc.Args) {
+					t.Errorf("%s is live; presets must be paper-only", sc.ID)
+				}
+			}
+			if cfg.IntervalSeconds > pr.IntervalSeconds {
+				t.Errorf("scheduler tick %d slower than strategy cadence %d", cfg.IntervalSeconds, pr.IntervalSeconds)
+			}
+		})
+	}
+}
+
+func TestApplyInitPresetKeepsExplicitFields(t *testing.T) {
+	opts := InitOptions{Preset: "Balanced", Assets: []string{"SOL"}, SpotCapital: 42, EnableSpot: true, SpotStrategies: []string{"momentum_pro"}}
+	if err := applyInitPreset(&opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(opts.Assets) != 1 || opts.Assets[0] != "SOL" || opts.SpotCapital != 42 {
+		t.Errorf("explicit fields overridden: %+v", opts)
+	}
+	if opts.EnablePerps || opts.EnableOptions || len(opts.SpotStrategies) != 1 {
+		t.Errorf("preset strategy types applied over an explicit selection: %+v", opts)
+	}
+	if opts.SpotDrawdown != 8 || opts.IntervalSeconds != 3600 || opts.ThetaHarvest == nil {
+		t.Errorf("unset fields not filled from preset: %+v", opts)
+	}
+	if err := applyInitPreset(&InitOptions{Preset: "yolo"}); err == nil {
+		t.Error("expected unknown preset error")
+	}
+}
@@ -558,7 +558,7 @@ func TestStratShortName(t *testing.T) {
 func TestRunInitFromJSON_Valid(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"spotDrawdown":10}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -578,7 +578,7 @@ func TestRunInitFromJSON_Valid(t *testing.T) {
 func TestRunInitFromJSON_EmptyUsesStarterSpotDefaults(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0 for starter defaults, got %d", code)
 	}
@@ -608,7 +608,7 @@ func TestRunInitFromJSON_EmptyUsesStarterSpotDefaults(t *testing.T) {
 func TestRunInitFromJSON_AssetsOnlyDefaultsToStarterSpot(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"]}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0 for starter defaults, got %d", code)
 	}
@@ -628,7 +628,7 @@ func TestRunInitFromJSON_AssetsOnlyDefaultsToStarterSpot(t *testing.T) {
 func TestRunInitFromJSON_SpotEnabledNoStrategiesUsesStarterStrategy(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableSpot":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0 for starter defaults, got %d", code)
 	}
@@ -706,7 +706,7 @@ func TestStarterConstants_PinnedToOptionLists(t *testing.T) {
 func TestRunInitFromJSON_PerpsNoModeDefaultsPaper(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enablePerps":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0 with perps default paper mode, got %d", code)
 	}
@@ -939,7 +939,7 @@ func TestGenerateConfig_FuturesNoFeeConfig(t *testing.T) {
 func TestRunInitFromJSON_FuturesEnabled(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableFutures":true,"futuresSymbols":["ES","MES"],"futuresStrategies":["momentum"],"futuresCapital":5000,"futuresDrawdown":5,"futuresFeePerContract":1.50}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1125,7 +1125,7 @@ func TestRunInitFromJSON_DefaultCapitalAndNotifications(t *testing.T) {
 	// Verify that JSON mode with minimal input produces correct config with defaults.
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["sma_crossover"],"spotCapital":1000,"spotDrawdown":5}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1454,7 +1454,7 @@ func TestRunInitFromJSON_FuturesAutoPopulate(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	// Only enable futures; omit strategies/symbols/capital/drawdown — all should be auto-populated.
 	jsonStr := `{"assets":["BTC"],"enableFutures":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1496,7 +1496,7 @@ func TestRunInitFromJSON_FuturesAutoPopulate(t *testing.T) {
 func TestRunInitFromJSON_RobinhoodAutoPopulate(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableRobinhood":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1519,7 +1519,7 @@ func TestRunInitFromJSON_RobinhoodAutoPopulate(t *testing.T) {
 func TestRunInitFromJSON_LunoAutoPopulate(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableLuno":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1542,7 +1542,7 @@ func TestRunInitFromJSON_LunoAutoPopulate(t *testing.T) {
 func TestRunInitFromJSON_OKXAutoPopulate(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableOKX":true}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1565,7 +1565,7 @@ func TestRunInitFromJSON_OKXAutoPopulate(t *testing.T) {
 func TestRunInitFromJSON_DeprecatedChannelMigration(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	jsonStr := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["momentum"],"spotCapital":1000,"spotDrawdown":5,"SpotChannelID":"ch-spot","OptionsChannelID":"ch-opts"}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1589,7 +1589,7 @@ func TestRunInitFromJSON_PerpsSizingLeverageInherits(t *testing.T) {
 	out := filepath.Join(t.TempDir(), "config.json")
 	// PerpsLeverage=5, no PerpsSizingLeverage → should inherit 5
 	jsonStr := `{"assets":["BTC"],"enablePerps":true,"perpsLeverage":5,"perpsStrategies":["momentum"],"perpsCapital":1000,"perpsDrawdown":5}`
-	code := runInitFromJSON(jsonStr, out)
+	code := runInitFromJSON(jsonStr, out, false)
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -1615,7 +1615,7 @@ func TestRunInitFromJSON_WriteError(t *testing.T) {
 	// Pass a directory as output path → os.WriteFile should fail → exit 1
 	dir := t.TempDir()
 	jsonStr := `{"assets":["BTC"],"enableSpot":true,"spotStrategies":["momentum"],"spotCapital":1000,"spotDrawdown":5}`
-	code := runInitFromJSON(jsonStr, dir)
+	code := runInitFromJSON(jsonStr, dir, false)
 	if code != 1 {
 		t.Errorf("expected exit code 1 when writing to a directory, got %d", code)
 	}
@@ -551,6 +551,10 @@ func formatStrategyInspection(sc StrategyConfig, explicit map[string]bool, cfg *
 			sc.ThetaHarvest.Enabled, sc.ThetaHarvest.ProfitTargetPct, sc.ThetaHarvest.StopLossPct, sc.ThetaHarvest.MinDTEClose,
 			markIfDefault(explicit, "theta_harvest"))
 	}
+	if lx := sc.LongOptionExits; lx != nil {
+		fmt.Fprintf(&b, "  long_option_exits:   enabled=%v profit=%g%% stop=%g%% min_dte=%g\n",
+			lx.Enabled, lx.ProfitTargetPct, lx.StopLossPct, lx.MinDTEClose)
+	}
 	return b.String()
 }
 
@@ -0,0 +1,186 @@
+package main
+
+// latency: duration histograms for subprocess and exchange calls (#4940).
+//
+// Every Python subprocess (labelled by script), Deribit REST request, and
+// Hyperliquid /info request or live execute/close is observed into a
+// fixed-bucket histogram keyed by (kind, op). /metrics and the --once
+// Pushgateway push expose them as go_trader_call_duration_seconds plus an
+// error counter, and the main loop logs a one-line per-cycle summary (calls,
+// average, max per series) so a venue slowing down shows up well before
+// calls start hitting their timeouts.
+//
+// Buckets span 50ms..120s: exchange REST calls land at the low end, strategy
+// scripts (scriptTimeout) at the high end. Registry state is process-local
+// and resets on restart, like any Prometheus client counter.
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the histogram upper bounds in seconds (+Inf implicit).
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+type latencyKey struct {
+	Kind, Op string
+}
+
+// latencySeries is one histogram plus the current cycle's window.
+type latencySeries struct {
+	buckets []uint64 // non-cumulative per bucket; last is +Inf
+	count   uint64
+	sum     float64
+	errors  uint64
+
+	cycleCount uint64
+	cycleSum   float64
+	cycleMax   float64
+}
+
+// LatencyRegistry holds all call-duration series.
+type LatencyRegistry struct {
+	mu     sync.Mutex
+	series map[latencyKey]*latencySeries
+}
+
+// callLatency is the process-wide registry.
+var callLatency = &LatencyRegistry{series: map[latencyKey]*latencySeries{}}
+
+// Observe records one call of duration d; failed marks it as an error.
+func (r *LatencyRegistry) Observe(kind, op string, d time.Duration, failed bool) {
+	secs := d.Seconds()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := latencyKey{kind, op}
+	s := r.series[k]
+	if s == nil {
+		s = &latencySeries{buckets: make([]uint64, len(latencyBuckets)+1)}
+		r.series[k] = s
+	}
+	i := sort.SearchFloat64s(latencyBuckets, secs)
+	s.buckets[i]++
+	s.count++
+	s.sum += secs
+	if failed {
+		s.errors++
+	}
+	s.cycleCount++
+	s.cycleSum += secs
+	if secs > s.cycleMax {
+		s.cycleMax = secs
+	}
+}
+
+// observeCallLatency records a call that started at start.
+func observeCallLatency(kind, op string, start time.Time, err error) {
+	callLatency.Observe(kind, op, time.Since(start), err != nil)
+}
+
+func (r *LatencyRegistry) sortedKeys() []latencyKey {
+	keys := make([]latencyKey, 0, len(r.series))
+	for k := range r.series {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Kind != keys[j].Kind {
+			return keys[i].Kind < keys[j].Kind
+		}
+		return keys[i].Op < keys[j].Op
+	})
+	return keys
+}
+
+// CycleSummary returns the per-cycle log line ("" when nothing was called)
+// and starts a new cycle window.
+func (r *LatencyRegistry) CycleSummary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var parts []string
+	for _, k := range r.sortedKeys() {
+		s := r.series[k]
+		if s.cycleCount == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s/%s n=%d avg=%s max=%s", k.Kind, k.Op, s.cycleCount,
+			formatLatencySeconds(s.cycleSum/float64(s.cycleCount)), formatLatencySeconds(s.cycleMax)))
+		s.cycleCount, s.cycleSum, s.cycleMax = 0, 0, 0
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatLatencySeconds(secs float64) string {
+	return time.Duration(secs * float64(time.Second)).Round(time.Millisecond).String()
+}
+
+// WritePrometheus appends the histogram and error-counter families.
+func (r *LatencyRegistry) WritePrometheus(b *strings.Builder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.series) == 0 {
+		return
+	}
+	keys := r.sortedKeys()
+	writePromHeader(b, "go_trader_call_duration_seconds", "histogram", "Duration of subprocess and exchange calls by kind and operation.")
+	for _, k := range keys {
+		s := r.series[k]
+		labels := fmt.Sprintf("kind=\"%s\",op=\"%s\"", promLabelValue(k.Kind), promLabelValue(k.Op))
+		var cum uint64
+		for i, le := range latencyBuckets {
+			cum += s.buckets[i]
+			fmt.Fprintf(b, "go_trader_call_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels, le, cum)
+		}
+		fmt.Fprintf(b, "go_trader_call_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.count)
+		fmt.Fprintf(b, "go_trader_call_duration_seconds_sum{%s} %g\n", labels, s.sum)
+		fmt.Fprintf(b, "go_trader_call_duration_seconds_count{%s} %d\n", labels, s.count)
+	}
+	writePromHeader(b, "go_trader_call_errors_total", "counter", "Subprocess and exchange calls that failed, by kind and operation.")
+	for _, k := range keys {
+		fmt.Fprintf(b, "go_trader_call_errors_total{kind=\"%s\",op=\"%s\"} %d\n", promLabelValue(k.Kind), promLabelValue(k.Op), r.series[k].errors)
+	}
+}
+
+// renderLatencyMetrics renders the call-latency families on their own.
+func renderLatencyMetrics() string {
+	var b strings.Builder
+	callLatency.WritePrometheus(&b)
+	return b.String()
+}
+
+// subprocessLatencyOp labels a subprocess series by script file name.
+func subprocessLatencyOp(script string) string {
+	return filepath.Base(script)
+}
+
+// latencyTransport observes each HTTP round trip (time to response headers).
+// op "" labels by the last URL path segment (Deribit's /public/ticker →
+// "ticker"). 5xx responses count as errors.
+type latencyTransport struct {
+	kind, op string
+	base     http.RoundTripper
+}
+
+func newLatencyClient(kind, op string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: &latencyTransport{kind: kind, op: op}}
+}
+
+func (t *latencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	op := t.op
+	if op == "" {
+		op = path.Base(req.URL.Path)
+	}
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	callLatency.Observe(t.kind, op, time.Since(start), err != nil || (resp != nil && resp.StatusCode >= 500))
+	return resp, err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyRegistryHistogramAndCycleSummary(t *testing.T) {
+	r := &LatencyRegistry{series: map[latencyKey]*latencySeries{}}
+	r.Observe("subprocess", "check_strategy.py", 300*time.Millisecond, false)
+	r.Observe("subprocess", "check_strategy.py", 1500*time.Millisecond, true)
+	r.Observe("deribit", "ticker", 40*time.Millisecond, false)
+
+	var b strings.Builder
+	r.WritePrometheus(&b)
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE go_trader_call_duration_seconds histogram\n",
+		`go_trader_call_duration_seconds_bucket{kind="deribit",op="ticker",le="0.05"} 1` + "\n",
+		`go_trader_call_duration_seconds_bucket{kind="subprocess",op="check_strategy.py",le="0.25"} 0` + "\n",
+		`go_trader_call_duration_seconds_bucket{kind="subprocess",op="check_strategy.py",le="0.5"} 1` + "\n",
+		`go_trader_call_duration_seconds_bucket{kind="subprocess",op="check_strategy.py",le="2.5"} 2` + "\n",
+		`go_trader_call_duration_seconds_bucket{kind="subprocess",op="check_strategy.py",le="+Inf"} 2` + "\n",
+		`go_trader_call_duration_seconds_sum{kind="subprocess",op="check_strategy.py"} 1.8` + "\n",
+		`go_trader_call_errors_total{kind="subprocess",op="check_strategy.py"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	want := "deribit/ticker n=1 avg=40ms max=40ms; subprocess/check_strategy.py n=2 avg=900ms max=1.5s"
+	if got := r.CycleSummary(); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if got := r.CycleSummary(); got != "" {
+		t.Errorf("window not reset: %q", got)
+	}
+	// Totals survive the window reset.
+	b.Reset()
+	r.WritePrometheus(&b)
+	if !strings.Contains(b.String(), `go_trader_call_duration_seconds_count{kind="subprocess",op="check_strategy.py"} 2`) {
+		t.Errorf("histogram reset with the cycle window:\n%s", b.String())
+	}
+}
+
+func TestLatencyTransportLabelsByEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "get_instruments") {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	prev := callLatency
+	callLatency = &LatencyRegistry{series: map[latencyKey]*latencySeries{}}
+	t.Cleanup(func() { callLatency = prev })
+
+	c := newLatencyClient("deribit", "", time.Second)
+	for _, p := range []string{"/api/v2/public/ticker?instrument_name=X", "/api/v2/public/get_instruments"} {
+		resp, err := c.Get(srv.URL + p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if s := callLatency.series[latencyKey{"deribit", "ticker"}]; s == nil || s.count != 1 || s.errors != 0 {
+		t.Errorf("ticker series = %+v", s)
+	}
+	if s := callLatency.series[latencyKey{"deribit", "get_instruments"}]; s == nil || s.errors != 1 {
+		t.Errorf("get_instruments 5xx should count as an error: %+v", s)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+// live_exec_errors: classify live execute failures and recover where safe
+// (#4958).
+//
+// Every failed HL execute used to surface as the same "Live execute failed"
+// line and alert. classifyLiveExecError buckets the error text, and
+// retryHyperliquidExecute applies the handling each class allows:
+//
+//   - insufficient_margin: skip and alert (retrying cannot help).
+//   - min_size: an open below the venue minimum is resized once to the
+//     minimum (never more than liveExecMaxResizeFactor × the request).
+//   - rate_limit: retried after each liveExecRateLimitBackoff delay.
+//   - network: not retried — the order may have reached the venue, so the
+//     alert asks the operator to verify (the on-chain reconcile adopts any
+//     fill, and the #4916 intent journal marks it unknown).
+//
+// Anything else is "other" and handled as before.
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Live execute error classes.
+const (
+	liveErrMargin    = "insufficient_margin"
+	liveErrMinSize   = "min_size"
+	liveErrRateLimit = "rate_limit"
+	liveErrNetwork   = "network"
+	liveErrOther     = "other"
+)
+
+// liveExecRateLimitBackoff is the wait before each rate-limit retry. Vars so
+// tests can shrink them.
+var (
+	liveExecRateLimitBackoff = []time.Duration{2 * time.Second, 5 * time.Second, 10 * time.Second}
+	liveExecSleep            = time.Sleep
+)
+
+// liveExecMaxResizeFactor caps the min-size resize: an order that would need
+// more than this multiple of the requested size is skipped instead.
+const liveExecMaxResizeFactor = 2.0
+
+// liveMinValueRe extracts HL's "minimum value of $10" threshold.
+var liveMinValueRe = regexp.MustCompile(`minimum value of \$?([0-9]+(?:\.[0-9]+)?)`)
+
+// classifyLiveExecError buckets a live execute error message.
+func classifyLiveExecError(msg string) string {
+	lower := strings.ToLower(msg)
+	has := func(subs ...string) bool {
+		for _, s := range subs {
+			if strings.Contains(lower, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("insufficient margin", "insufficient balance", "not enough margin", "insufficient funds"):
+		return liveErrMargin
+	case has("minimum value", "min order", "minimum order", "below minimum", "order size too small", "invalid size"):
+		return liveErrMinSize
+	case has("429", "rate limit", "rate-limit", "ratelimit", "too many requests"):
+		return liveErrRateLimit
+	case has("timed out", "timeout", "connection", "max retries exceeded", "name resolution", "network", "eof", "deadline exceeded", "502", "503", "504"):
+		return liveErrNetwork
+	default:
+		return liveErrOther
+	}
+}
+
+// liveExecRejected reports whether msg is a venue verdict that nothing was
+// placed: margin, min-size and rate-limit rejections. Network and other
+// errors leave the outcome unknown.
+func liveExecRejected(msg string) bool {
+	switch classifyLiveExecError(msg) {
+	case liveErrMargin, liveErrMinSize, liveErrRateLimit:
+		return true
+	}
+	return false
+}
+
+// liveExecFailureMessage prefixes msg with its class and what the scheduler
+// did about it, for logs and operator alerts.
+func liveExecFailureMessage(msg string) string {
+	switch classifyLiveExecError(msg) {
+	case liveErrMargin:
+		return "insufficient margin — order skipped, free margin or reduce size: " + msg
+	case liveErrMinSize:
+		return "below the venue minimum order size — order skipped: " + msg
+	case liveErrRateLimit:
+		return "rate limited — retries exhausted: " + msg
+	case liveErrNetwork:
+		return "network error — outcome unknown, verify the position on the venue: " + msg
+	default:
+		return msg
+	}
+}
+
+// liveExecErrorText returns the error text of a failed attempt ("" on
+// success).
+func liveExecErrorText(res *HyperliquidExecuteResult, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if res != nil {
+		return res.Error
+	}
+	return ""
+}
+
+// minSizeResize returns the open size that clears the minimum named in msg
+// at price, rounded up to the lot. ok=false when the minimum is unknown or
+// the resize would exceed liveExecMaxResizeFactor × size.
+func minSizeResize(msg string, size, price float64, spec SymbolSpec) (float64, bool) {
+	minUSD := spec.MinNotionalUSD
+	if m := liveMinValueRe.FindStringSubmatch(msg); m != nil {
+		if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+			minUSD = v
+		}
+	}
+	if minUSD <= 0 || price <= 0 || size <= 0 {
+		return 0, false
+	}
+	// 1% headroom so a tick of adverse movement doesn't re-trip the minimum.
+	qty := minUSD * 1.01 / price
+	if spec.LotSize > 0 {
+		qty = cleanStepValue(math.Ceil(qty/spec.LotSize-1e-9)*spec.LotSize, spec.LotSize)
+	}
+	if qty <= size || qty > size*liveExecMaxResizeFactor {
+		return 0, false
+	}
+	return qty, true
+}
+
+// retryHyperliquidExecute runs attempt(size) and recovers from rate-limit and
+// (for opens) min-size rejections. Returns the last result, the size it was
+// placed at, and the error.
+func retryHyperliquidExecute(attempt func(size float64) (*HyperliquidExecuteResult, error), size, price float64, spec SymbolSpec, opening bool, logger *StrategyLogger) (*HyperliquidExecuteResult, float64, error) {
+	res, err := attempt(size)
+	resized := false
+	for retry := 0; ; {
+		msg := liveExecErrorText(res, err)
+		if msg == "" {
+			return res, size, err
+		}
+		switch classifyLiveExecError(msg) {
+		case liveErrRateLimit:
+			if retry >= len(liveExecRateLimitBackoff) {
+				return res, size, err
+			}
+			delay := liveExecRateLimitBackoff[retry]
+			retry++
+			logger.Warn("Live execute rate limited (attempt %d) — retrying in %s", retry, delay)
+			liveExecSleep(delay)
+		case liveErrMinSize:
+			if !opening || resized {
+				return res, size, err
+			}
+			next, ok := minSizeResize(msg, size, price, spec)
+			if !ok {
+				return res, size, err
+			}
+			logger.Warn("Live execute below venue minimum at size=%.6f — resizing to %.6f", size, next)
+			size, resized = next, true
+		default:
+			return res, size, err
+		}
+		res, err = attempt(size)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifyLiveExecError(t *testing.T) {
+	cases := map[string]string{
+		"order rejected: Insufficient margin to place order. asset=0":       liveErrMargin,
+		"order rejected: Order must have minimum value of $10. asset=4":     liveErrMinSize,
+		"(429, None, 'null', None, {})":                                     liveErrRateLimit,
+		"Too Many Requests":                                                 liveErrRateLimit,
+		"HTTPSConnectionPool: Max retries exceeded (Read timed out)":        liveErrNetwork,
+		"execute error: signal: killed (stderr: context deadline exceeded)": liveErrNetwork,
+		"update_leverage failed (margin_mode=cross, leverage=5): bad asset": liveErrOther,
+	}
+	for msg, want := range cases {
+		if got := classifyLiveExecError(msg); got != want {
+			t.Errorf("classify(%q) = %s, want %s", msg, got, want)
+		}
+	}
+	if got := liveExecFailureMessage("Insufficient margin to place order."); !strings.HasPrefix(got, "insufficient margin — order skipped") {
+		t.Errorf("margin message = %q", got)
+	}
+	if got := liveExecFailureMessage("boom"); got != "boom" {
+		t.Errorf("other message = %q, want unchanged", got)
+	}
+}
+
+func TestMinSizeResize(t *testing.T) {
+	spec := SymbolSpec{LotSize: 0.001}
+	got, ok := minSizeResize("Order must have minimum value of $10. asset=1", 0.004, 2000, spec)
+	if !ok || got != 0.006 { // 10*1.01/2000 = 0.00505 → 0.006
+		t.Errorf("resize = %v, %v; want 0.006", got, ok)
+	}
+	if _, ok := minSizeResize("Order must have minimum value of $10.", 0.001, 2000, spec); ok {
+		t.Error("a resize beyond 2x the request must be refused")
+	}
+	if got, ok := minSizeResize("order size too small", 0.004, 2000, SymbolSpec{LotSize: 0.001, MinNotionalUSD: 10}); !ok || got != 0.006 {
+		t.Errorf("spec minimum fallback = %v, %v", got, ok)
+	}
+	if _, ok := minSizeResize("order size too small", 0.004, 2000, spec); ok {
+		t.Error("unknown minimum must not resize")
+	}
+}
+
+func TestRetryHyperliquidExecute(t *testing.T) {
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	origBackoff, origSleep := liveExecRateLimitBackoff, liveExecSleep
+	t.Cleanup(func() { liveExecRateLimitBackoff, liveExecSleep = origBackoff, origSleep })
+	var slept []time.Duration
+	liveExecRateLimitBackoff = []time.Duration{time.Second, 2 * time.Second}
+	liveExecSleep = func(d time.Duration) { slept = append(slept, d) }
+	ok := &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{TotalSz: 1}}}
+	spec := SymbolSpec{LotSize: 0.001}
+
+	// Rate limited twice, then filled.
+	var sizes []float64
+	res, _, err := retryHyperliquidExecute(func(size float64) (*HyperliquidExecuteResult, error) {
+		sizes = append(sizes, size)
+		if len(sizes) < 3 {
+			return &HyperliquidExecuteResult{Error: "429 Too Many Requests"}, nil
+		}
+		return ok, nil
+	}, 1, 2000, spec, true, logger)
+	if err != nil || res != ok || len(sizes) != 3 || len(slept) != 2 {
+		t.Errorf("rate limit: res=%v err=%v attempts=%d slept=%v", res, err, len(sizes), slept)
+	}
+
+	// Rate limited past the backoff schedule: gives up with the last error.
+	slept, sizes = nil, nil
+	res, _, _ = retryHyperliquidExecute(func(size float64) (*HyperliquidExecuteResult, error) {
+		sizes = append(sizes, size)
+		return &HyperliquidExecuteResult{Error: "rate limited"}, nil
+	}, 1, 2000, spec, true, logger)
+	if len(sizes) != 3 || res.Error != "rate limited" {
+		t.Errorf("exhausted: attempts=%d res=%+v", len(sizes), res)
+	}
+
+	// Min size on an open: resized once.
+	sizes = nil
+	_, placed, err := retryHyperliquidExecute(func(size float64) (*HyperliquidExecuteResult, error) {
+		sizes = append(sizes, size)
+		if size < 0.005 {
+			return &HyperliquidExecuteResult{Error: "order rejected: Order must have minimum value of $10."}, nil
+		}
+		return ok, nil
+	}, 0.004, 2000, spec, true, logger)
+	if err != nil || placed != 0.006 || len(sizes) != 2 {
+		t.Errorf("min size: placed=%v attempts=%v err=%v", placed, sizes, err)
+	}
+
+	// Min size on a close, margin, and network errors: one attempt only.
+	for _, c := range []struct {
+		msg     string
+		opening bool
+	}{
+		{"Order must have minimum value of $10.", false},
+		{"Insufficient margin to place order.", true},
+	} {
+		sizes = nil
+		retryHyperliquidExecute(func(size float64) (*HyperliquidExecuteResult, error) {
+			sizes = append(sizes, size)
+			return &HyperliquidExecuteResult{Error: c.msg}, nil
+		}, 0.004, 2000, spec, c.opening, logger)
+		if len(sizes) != 1 {
+			t.Errorf("%q: attempts = %d, want 1", c.msg, len(sizes))
+		}
+	}
+	sizes = nil
+	_, _, err = retryHyperliquidExecute(func(size float64) (*HyperliquidExecuteResult, error) {
+		sizes = append(sizes, size)
+		return nil, errors.New("execute error: Read timed out")
+	}, 1, 2000, spec, true, logger)
+	if err == nil || len(sizes) != 1 {
+		t.Errorf("network: attempts=%d err=%v", len(sizes), err)
+	}
+}
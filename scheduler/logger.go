@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
@@ -70,12 +71,69 @@ func (sl *StrategyLogger) Info(format string, args ...interface{}) {
 
 func (sl *StrategyLogger) Error(format string, args ...interface{}) {
 	sl.log("ERROR", format, args...)
+	strategyActivity.recordError(sl.stratID, fmt.Sprintf(format, args...))
+}
+
+// Output logs the one-line summary of a check script's result at INFO and
+// keeps it as the strategy's last script output (#4930).
+func (sl *StrategyLogger) Output(format string, args ...interface{}) {
+	sl.log("INFO", format, args...)
+	strategyActivity.recordOutput(sl.stratID, fmt.Sprintf(format, args...))
 }
 
 func (sl *StrategyLogger) Warn(format string, args ...interface{}) {
 	sl.log("WARN", format, args...)
 }
 
+// StrategyActivity is the most recent script output and error logged for a
+// strategy, surfaced by /strategies/{id} (#4930). In memory only.
+type StrategyActivity struct {
+	LastOutput   string    `json:"last_output,omitempty"`
+	LastOutputAt time.Time `json:"last_output_at,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastErrorAt  time.Time `json:"last_error_at,omitempty"`
+}
+
+type strategyActivityLog struct {
+	mu   sync.Mutex
+	byID map[string]StrategyActivity
+}
+
+var strategyActivity = &strategyActivityLog{}
+
+func (a *strategyActivityLog) update(id string, fn func(*StrategyActivity)) {
+	if id == "" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.byID == nil {
+		a.byID = make(map[string]StrategyActivity)
+	}
+	act := a.byID[id]
+	fn(&act)
+	a.byID[id] = act
+}
+
+func (a *strategyActivityLog) recordOutput(id, msg string) {
+	a.update(id, func(act *StrategyActivity) {
+		act.LastOutput, act.LastOutputAt = msg, time.Now().UTC()
+	})
+}
+
+func (a *strategyActivityLog) recordError(id, msg string) {
+	a.update(id, func(act *StrategyActivity) {
+		act.LastError, act.LastErrorAt = msg, time.Now().UTC()
+	})
+}
+
+// Get returns id's activity (zero value when nothing was logged yet).
+func (a *strategyActivityLog) Get(id string) StrategyActivity {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.byID[id]
+}
+
 // LogSummary writes a cycle summary to stdout.
 func (lm *LogManager) LogSummary(cycle int, elapsed time.Duration, stratCount int, trades int, totalValue float64) {
 	now := time.Now().UTC().Format("2006-01-02 15:04 UTC")
@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -28,11 +27,18 @@ var knownSubcommands = []string{
 	"manual-cancel",
 	"manual-update-sl",
 	"manual-cancel-sl",
+	"harvest-override",
+	"adjust-position",
+	"order-intents",
+	"promote",
+	"compare",
 	"backfill",
 	"probe",
 	"inspect",
 	"agent-info",
 	"diagnostics",
+	"stress",
+	"ledger",
 	"version",
 }
 
@@ -77,6 +83,16 @@ func main() {
 			os.Exit(runManualUpdateSL(os.Args[2:]))
 		case "manual-cancel-sl":
 			os.Exit(runManualCancelSL(os.Args[2:]))
+		case "harvest-override":
+			os.Exit(runHarvestOverride(os.Args[2:]))
+		case "adjust-position":
+			os.Exit(runAdjustPosition(os.Args[2:]))
+		case "order-intents":
+			os.Exit(runOrderIntents(os.Args[2:]))
+		case "promote":
+			os.Exit(runPromote(os.Args[2:]))
+		case "compare":
+			os.Exit(runCompare(os.Args[2:]))
 		case "backfill":
 			os.Exit(runBackfill(os.Args[2:]))
 		case "probe":
@@ -87,6 +103,10 @@ func main() {
 			os.Exit(runAgentInfo(os.Args[2:]))
 		case "diagnostics":
 			os.Exit(runDiagnostics(os.Args[2:]))
+		case "stress":
+			os.Exit(runStress(os.Args[2:]))
+		case "ledger":
+			os.Exit(runLedger(os.Args[2:]))
 		case "version", "--version", "-version":
 			fmt.Println(Version)
 			os.Exit(0)
@@ -98,6 +118,7 @@ func main() {
 	summary := flag.String("summary", "", "Post snapshot summary for the specified channel (e.g., hyperliquid, spot, options) and exit")
 	leaderboard := flag.Bool("leaderboard", false, "Post pre-computed daily leaderboard and exit")
 	statusPortFlag := flag.Int("status-port", 0, fmt.Sprintf("HTTP status server port (overrides config, default: %d)", DefaultStatusPort))
+	migrateDryRun := flag.Bool("migrate-dry-run", false, "Print pending config-migration fields and the diff MigrateConfig would write, then exit without touching the file")
 	flag.Parse()
 
 	if err := validateDaemonInvocation(flag.Args()); err != nil {
@@ -105,6 +126,26 @@ func main() {
 		os.Exit(2)
 	}
 
+	if *migrateDryRun {
+		os.Exit(runMigrateDryRun(*configPath))
+	}
+
+	// A previous in-process upgrade may still be unconfirmed: count this start
+	// and roll back to the previous binary if the new build keeps dying
+	// (upgrade_guard.go). Runs before anything the new build could fail at.
+	var upgradeNotice string
+	if !*once && *summary == "" && !*leaderboard {
+		upgradeNotice = upgradeGuardOnStartup()
+	}
+
+	// Export secrets from an external store (Vault / AWS Secrets Manager)
+	// before LoadConfig reads credential env vars, so Go and the Python
+	// subprocesses see them exactly as if they were set on the box.
+	if err := loadSecretsFromProvider(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load secrets: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Load config
 	cfg, err := LoadConfig(*configPath)
 	if err != nil {
@@ -115,11 +156,16 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to apply alert throttle interval: %v\n", err)
 		os.Exit(1)
 	}
+	applyScriptFailureAlertThreshold(cfg.ScriptFailureAlertAfter)
+	applyScriptOutputMaxBytes(cfg.ScriptOutputMaxBytes)
+	applyDailyResetFromConfig(cfg)
+	criticalAlerts.Configure(cfg)
+	pythonSemaphore.Configure(cfg.PythonConcurrency)
 	if err := applyKillSwitchResetDMTimeoutFromConfig(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to apply kill-switch reset DM timeout: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Loaded config: %d strategies, interval=%ds\n", len(cfg.Strategies), cfg.IntervalSeconds)
+	fmt.Printf("Loaded config: %d strategies, interval=%ds%s\n", len(cfg.Strategies), cfg.IntervalSeconds, includedFilesNote(cfg))
 
 	// #1085: load the directional-certification artifact (SSoT for the
 	// regime->direction edge gate). Fail-closed — a missing/malformed artifact
@@ -128,6 +174,7 @@ func main() {
 	setDirectionalCertStore(LoadDirectionalCertSetFailClosed(directionalCertPath(), func(f string, a ...interface{}) {
 		fmt.Fprintf(os.Stderr, f+"\n", a...)
 	}))
+	setSymbolSpecs(cfg.Platforms)
 	directionalCertSummaryLines := directionalCertStartupSummary(cfg)
 	for _, line := range directionalCertSummaryLines {
 		fmt.Println(line)
@@ -158,6 +205,37 @@ func main() {
 	if line := exposureCapStartupSummaryLine(cfg.PortfolioRisk); line != "" {
 		fmt.Println(line)
 	}
+	// Same for platforms.<name>.risk aggregate limits.
+	if line := platformRiskStartupSummaryLine(cfg); line != "" {
+		fmt.Println(line)
+	}
+
+	// #849/#4984: Singleton guard — claim an exclusive lock on the resolved
+	// state-DB path BEFORE anything opens, repairs, replays or saves the DB,
+	// so a second scheduler (an operator launching the binary alongside the
+	// systemd-managed instance, a cron --once against a live daemon, or an
+	// out-of-cgroup process from a signal-mode update) refuses to start
+	// instead of overwriting the running instance's saves and double-trading
+	// against the same exchange account. --summary / --leaderboard are
+	// read-side companions meant to run next to the daemon and stay
+	// unguarded. The lock is a kernel flock the OS releases on exit/crash, so
+	// a SIGKILLed daemon leaves nothing stale to block the next start (see
+	// singleton_lock.go).
+	if *summary == "" && !*leaderboard {
+		lock, lockErr := acquireStateDBLock(cfg.DBFile)
+		if lockErr != nil {
+			refuseSecondScheduler(cfg, lockErr)
+		}
+		// Hold for the entire process lifetime. We deliberately do NOT
+		// release on the happy path: the OS drops the flock when the process
+		// exits, which is strictly after the deferred final SaveState +
+		// stateDB.Close have run. Releasing earlier (e.g. via defer) would run
+		// BEFORE those in LIFO order and open a window where a duplicate could
+		// grab the lock and start trading while we're still flushing state.
+		// Stashing it in a package var also keeps the fd reachable so its
+		// os.File finalizer can't close (and release) it mid-run.
+		heldStateDBLock = lock
+	}
 
 	// #339: Detect a missing state DB on a live deployment *before* OpenStateDB
 	// creates it — a wiped directory (vs. an in-place `git pull`) would otherwise
@@ -169,12 +247,16 @@ func main() {
 		missingStateWarning = msg
 	}
 
-	// Open SQLite state database.
-	stateDB, err := OpenStateDB(cfg.DBFile)
+	// Open SQLite state database. #4918: a DB that fails its integrity
+	// check is moved aside and the newest valid backup restored.
+	stateDB, stateRecoveryNotice, err := openStateDBWithRecovery(cfg.DBFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
 		os.Exit(1)
 	}
+	if stateRecoveryNotice != "" {
+		fmt.Fprintf(os.Stderr, "[CRITICAL] %s\n", stateRecoveryNotice)
+	}
 	defer stateDB.Close()
 
 	// Wire the immediate trade-persistence hook (#289) so every trade is
@@ -182,13 +264,77 @@ func main() {
 	// survives mid-cycle crashes that would otherwise lose the in-memory batch.
 	tradeRecorder = stateDB.InsertTrade
 
+	// #4916: report live orders journaled by a previous run whose fill never
+	// reached state (crash between execution and the state mutation), after
+	// asking HL/OKX about unsettled ones by client order ID, then journal this
+	// run's orders. Unresolved rows are kept and reported again each start
+	// until `go-trader order-intents --resolve`. Replayed to the owner DM once
+	// the notifier is wired.
+	orderIntentWarnings, err := reconcileOrderIntents(stateDB, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] order intent reconcile failed: %v\n", err)
+	}
+	for _, msg := range orderIntentWarnings {
+		fmt.Fprintln(os.Stderr, "[CRITICAL] "+msg)
+	}
+	orderIntentJournal = stateDB
+
+	// #4938: standalone trade ledger. Catch up from the state DB (history
+	// predating the ledger, backfill corrections), then stream via RecordTrade.
+	if cfg.TradeLedger != nil && cfg.TradeLedger.Enabled {
+		ledgerPath := tradeLedgerPath(cfg)
+		if err := tradeLedgerPlaintextErr(cfg.TradeLedger); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] trade ledger disabled: %v\n", err)
+		} else if ledger, err := OpenTradeLedger(ledgerPath); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] trade ledger disabled: %v\n", err)
+		} else {
+			if n, err := ledger.SyncFromStateDB(stateDB); err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] trade ledger sync failed: %v\n", err)
+			} else if n > 0 {
+				fmt.Printf("[ledger] Synced %d trades into %s\n", n, ledgerPath)
+			}
+			if os.Getenv(stateKeyEnvVar) != "" {
+				fmt.Fprintf(os.Stderr, "[WARN] trade ledger %s is unencrypted while %s is set (trade_ledger.allow_plaintext)\n", ledgerPath, stateKeyEnvVar)
+			}
+			tradeLedger = ledger
+			defer ledger.Close()
+		}
+	}
+
 	// Load state: SQLite primary, JSON fallback with auto-migration.
 	state, err := LoadStateWithDB(cfg, stateDB)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load state: %v\n", err)
 		os.Exit(1)
 	}
+	// #4917: the WAL is non-empty only when mutations happened after the last
+	// completed save (crash or failed saves). Replay them and persist at once.
+	walPath := stateWALPath(cfg.DBFile)
+	var stateWALWarnings []string
+	if walPath != "" {
+		// Sealed with the state key when encryption is on (#4885), so the
+		// journal never holds positions/cash in plaintext next to the
+		// encrypted DB.
+		stateWALWarnings, err = replayStateWAL(state, walPath, stateDB.encKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[CRITICAL] %v\n", err)
+			os.Exit(1)
+		}
+		stateWAL = newStateWAL(walPath, stateDB.encKey)
+		for _, msg := range stateWALWarnings {
+			fmt.Fprintln(os.Stderr, "[state] "+msg)
+		}
+		if len(stateWALWarnings) > 0 {
+			if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
+				fmt.Fprintf(os.Stderr, "[CRITICAL] Failed to save WAL-replayed state (WAL kept for the next start): %v\n", err)
+			}
+		}
+	}
 	ValidateState(state)
+	priceHistory = NewPriceHistoryStore(priceHistoryDir(cfg.DBFile), PriceHistoryRetentionDays(cfg))
+	indicatorLog = NewIndicatorLogStore(indicatorLogDir(cfg.DBFile), IndicatorLogRetentionDays(cfg))
+	signalHistory = newSignalHistoryRecorder(stateDB, SignalHistoryRetentionDays(cfg))
+	shadowTwins = newShadowBook(stateDB)
 
 	// #87: Resolve capital_pct at startup so initial state gets the right capital.
 	resolveCapitalPct(cfg.Strategies)
@@ -261,7 +407,7 @@ func main() {
 		oldPeak := state.PortfolioRisk.PeakValue
 		newPeak := rebaselinePortfolioPeakAfterPrune(state, cfg, nil)
 		if newPeak != oldPeak {
-			state.PortfolioRisk.PeakValue = newPeak
+			rebaselinePortfolioPeak(&state.PortfolioRisk, newPeak, peakSourcePruneRebaseline, "strategies pruned from config", time.Now())
 			fmt.Printf("  Portfolio peak rebaselined after prune: $%.0f -> $%.0f\n", oldPeak, newPeak)
 		}
 	}
@@ -281,6 +427,19 @@ func main() {
 	// Collect here, forward to owner DM once the notifier is wired below.
 	atrMethodDriftWarnings := checkATRMethodDriftAtStartup(state, cfg)
 
+	// #4973: compare live venue positions (and flat-wallet cash) with the
+	// state just loaded; adopt mode rewrites state to match before the first
+	// cycle. Forwarded to the owner DM once the notifier is wired below.
+	startupSyncLines, startupSyncChanged := syncLiveStateAtStartup(state, cfg, nil, time.Now().UTC())
+	for _, msg := range startupSyncLines {
+		fmt.Println("[startup-sync] " + msg)
+	}
+	if startupSyncChanged {
+		if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
+			fmt.Fprintf(os.Stderr, "[CRITICAL] Failed to save startup-synced state: %v\n", err)
+		}
+	}
+
 	// #42 / #243: Initialize portfolio peak from sum of capitals on first run.
 	// For strategies that share an exchange wallet (e.g. multiple Hyperliquid
 	// perps strategies on the same account), use the real on-exchange balance
@@ -288,7 +447,7 @@ func main() {
 	// inflated and the kill switch can fire prematurely.
 	if state.PortfolioRisk.PeakValue == 0 {
 		total := computeInitialPortfolioPeak(cfg.Strategies, nil)
-		state.PortfolioRisk.PeakValue = total
+		rebaselinePortfolioPeak(&state.PortfolioRisk, total, peakSourceInit, "initial peak", time.Now())
 		fmt.Printf("  Portfolio peak initialized: $%.0f\n", total)
 	}
 
@@ -314,6 +473,13 @@ func main() {
 	// read-only context and must be killable from its first accepted request.
 	initShutdownContexts()
 
+	// #4980: kill scripts a crashed previous run left behind, then keep
+	// sweeping for any that escape a later crash of another instance.
+	if reaped := reapOrphanedScripts(orphanReaperProcDir); len(reaped) > 0 {
+		fmt.Printf("[reaper] startup sweep killed %d orphaned script(s)\n", len(reaped))
+	}
+	go runOrphanReaper(shutdownReadOnlyCtx, orphanReapInterval)
+
 	// Start HTTP status server. Priority: CLI flag > config > default.
 	statusPort := resolveStatusPort(*statusPortFlag, cfg.StatusPort)
 	server := NewStatusServer(state, &mu, cfg.StatusToken, cfg.Strategies, stateDB)
@@ -332,6 +498,7 @@ func main() {
 		go tuningManager.run(shutdownReadOnlyCtx)
 	}
 	server.Start(statusPort)
+	server.StartGRPCAdmin(cfg.GRPC)
 
 	// Graceful shutdown — two-phase drain (see scheduler/shutdown.go).
 	//
@@ -361,6 +528,7 @@ func main() {
 	go func() {
 		sig := <-sigCh
 		fmt.Printf("\nReceived %s, draining...\n", sig)
+		sdNotifyLogged("STOPPING=1")
 		beginDrain()
 		close(stopCh)
 	}()
@@ -368,6 +536,11 @@ func main() {
 	// Initialize notification backends (Discord and/or Telegram).
 	notifier, cleanupNotifier := buildNotifierFromConfig(cfg)
 	defer cleanupNotifier()
+	// #4920: cycle summaries are queued and delivered off the hot path;
+	// drain the queue before the backends close (defers run LIFO).
+	notifier.StartOutbox()
+	defer notifier.FlushOutbox(30 * time.Second)
+	criticalAlerts.SetNotifier(notifier)
 	fmt.Printf("Notification backends: %d active\n", notifier.BackendCount())
 	// #1257: the dashboard trade-action cores share the daemon notifier so
 	// their protection warnings reach the operator like the manual CLI's do.
@@ -516,6 +689,13 @@ func main() {
 		}
 	}
 
+	// #4973: forward startup venue-sync findings.
+	if len(startupSyncLines) > 0 && notifier.HasOwner() {
+		for _, msg := range startupSyncLines {
+			notifier.SendOwnerDM("[startup-sync] " + msg)
+		}
+	}
+
 	// #1157: surface uncertified/expired directional policy to owner DM at startup.
 	notifyDirectionalCertStartupSummary(notifier, directionalCertSummaryLines)
 
@@ -529,12 +709,32 @@ func main() {
 		}
 	}
 
+	if len(stateWALWarnings) > 0 && notifier.HasOwner() {
+		for _, msg := range stateWALWarnings {
+			notifier.SendOwnerDM("[state] " + msg)
+		}
+	}
+	if stateRecoveryNotice != "" && notifier.HasOwner() {
+		notifier.SendOwnerDM("[state] " + stateRecoveryNotice)
+	}
+	if len(orderIntentWarnings) > 0 && notifier.HasOwner() {
+		for _, msg := range orderIntentWarnings {
+			notifier.SendOwnerDM("[state] CRITICAL: " + msg)
+		}
+	}
+
 	// #339: Forward the missing-state-DB warning to the owner. Captured before
 	// OpenStateDB ran (which would have created an empty DB), surfaced here
 	// once the notifier is available.
 	if missingStateWarning != "" && notifier.HasOwner() {
 		notifier.SendOwnerDM("[state] " + missingStateWarning)
 	}
+	if upgradeNotice != "" {
+		fmt.Println("[upgrade] " + upgradeNotice)
+		if notifier.HasOwner() {
+			notifier.SendOwnerDM(upgradeNotice)
+		}
+	}
 
 	// -summary mode: post snapshot summary for the specified channel and exit.
 	// Checked early since it only needs config, state, and notifier — avoids
@@ -570,7 +770,7 @@ func main() {
 
 	reloadCh := make(chan struct{}, 1)
 	hupCh := make(chan os.Signal, 1)
-	signal.Notify(hupCh, syscall.SIGHUP)
+	notifySelfSignal(hupCh, reloadSignal)
 	defer signal.Stop(hupCh)
 	go func() {
 		for range hupCh {
@@ -582,6 +782,23 @@ func main() {
 		}
 	}()
 
+	// SIGUSR1 (or POST /api/credentials/rotate) re-reads credentials from the
+	// secrets provider / GO_TRADER_ENV_FILE and re-establishes the clients
+	// that hold them, without a restart. See credential_rotation.go.
+	rotateCh := make(chan struct{}, 1)
+	usr1Ch := make(chan os.Signal, 1)
+	notifySelfSignal(usr1Ch, credentialRotateSignal)
+	defer signal.Stop(usr1Ch)
+	go func() {
+		for range usr1Ch {
+			select {
+			case rotateCh <- struct{}{}:
+			default:
+				fmt.Println("[credentials] SIGUSR1 received while rotation is pending; coalescing")
+			}
+		}
+	}()
+
 	// Config migration: DM owner about new fields if config is behind current version.
 	if cfg.ConfigVersion < CurrentConfigVersion {
 		go runConfigMigrationDM(cfg, notifier, *configPath)
@@ -595,50 +812,16 @@ func main() {
 		if notifier != nil && notifier.HasOwner() {
 			notifier.SendOwnerDM(fmt.Sprintf("**Startup probe failed** — refusing to start (exit %d; fix deploy, then restart):\n```\n%v\n```", ExitProbeFailure, err))
 		}
+		rollbackPendingUpgrade(fmt.Sprintf("upgraded binary failed the startup probe: %v", err))
 		os.Exit(ExitProbeFailure)
 	}
 
-	// #849: Singleton guard — claim an exclusive lock on the resolved state-DB
-	// path so a second daemon (e.g. an operator manually launching the binary
-	// alongside the systemd-managed instance, or an out-of-cgroup process from a
-	// signal-mode update) refuses to start instead of silently double-trading
-	// against the same state.db / exchange account.
-	//
-	// Scoped to the persistent daemon loop only: CLI subcommands and --summary /
-	// --leaderboard have already exited above, and --once is intentionally left
-	// unguarded (it's update.sh's post-deploy smoke and the operator's
-	// responsibility). The probe ran first because update.sh invokes the
-	// `probe` subcommand against the still-live old daemon during a deploy;
-	// that path never reaches here. The lock is a kernel flock the OS releases
-	// on exit/crash, so a SIGKILLed daemon leaves nothing stale to block the
-	// next start (see singleton_lock.go).
+	// The singleton lock (#849/#4984) was taken before the state DB opened.
 	if !*once {
-		lock, lockErr := acquireStateDBLock(cfg.DBFile)
-		if lockErr != nil {
-			var locked *stateDBLockedError
-			if errors.As(lockErr, &locked) {
-				msg := fmt.Sprintf("CRITICAL: %s — refusing to start so this process can't double-trade against the same state DB. If no other go-trader is actually running, the lock auto-releases on exit; check `pgrep -af go-trader`.", locked.Error())
-				fmt.Fprintln(os.Stderr, "[singleton] "+msg)
-				if notifier != nil && notifier.HasOwner() {
-					notifier.SendOwnerDM("**Singleton guard** — " + msg)
-				}
-			} else {
-				fmt.Fprintf(os.Stderr, "[singleton] CRITICAL: could not acquire state DB lock: %v — refusing to start.\n", lockErr)
-				if notifier != nil && notifier.HasOwner() {
-					notifier.SendOwnerDM(fmt.Sprintf("**Singleton guard** — could not acquire state DB lock, refusing to start: %v", lockErr))
-				}
-			}
-			os.Exit(ExitSingletonLock)
-		}
-		// Hold for the entire process lifetime. We deliberately do NOT release
-		// on the happy path: the OS drops the flock when the process exits,
-		// which is strictly after the deferred final SaveState + stateDB.Close
-		// have run. Releasing earlier (e.g. via defer) would run BEFORE those
-		// in LIFO order and open a window where a duplicate could grab the lock
-		// and start trading while we're still flushing state. Stashing it in a
-		// package var also keeps the fd reachable so its os.File finalizer
-		// can't close (and release) it mid-run.
-		heldStateDBLock = lock
+		// Post-upgrade self-check (config, state, dry cycle) before the first
+		// real cycle; a failure rolls back and never returns.
+		validateUpgradeHealth(cfg, stateDB)
+		confirmUpgradeAfter(upgradeHealthyAfter, notifier)
 	}
 
 	// Track the last remote hash we notified about to avoid re-notifying on every cycle.
@@ -653,28 +836,29 @@ func main() {
 	deribitPricer := NewDeribitPricer()
 	fmt.Println("Option pricers ready (deribit: live API, ibkr: Black-Scholes)")
 
-	// Track last-run time per strategy for per-strategy intervals.
-	// Single-writer invariant: lastRun is only mutated from the scheduler
-	// goroutine (this for-loop and the per-strategy continuations below).
-	// Reads from the same goroutine (the dueStrategies loop and the
-	// schedulerDelay calls) are safe without additional synchronization.
-	// If you ever split writes across goroutines, add explicit locking —
-	// the existing `mu` lock guards `state`, not `lastRun`.
-	lastRun := make(map[string]time.Time)
-	// Same single-writer invariant as lastRun; copied into AppState only during
+	// #4921: one ticker goroutine per strategy marks it due on its own
+	// cadence; the loop below runs whatever is due. The scheduler has its own
+	// lock — `mu` guards `state`, not the schedule.
+	sched := newStrategyScheduler()
+	defer sched.Stop()
+	server.SetRunNow(func(id string) bool { return sched.RunNow(id, time.Now()) })
+	// #4924: index ensemble membership; votes are in-memory only.
+	globalEnsembles.Configure(cfg)
+	globalTradeTagger.Configure(cfg)
+	// #4925: resumes the rebalance cadence from the last recorded transfer.
+	allocator := newCapitalAllocator(stateDB)
+	// Only mutated by this loop's goroutine; copied into AppState only during
 	// the save phase so restart throttling survives without widening state locks.
 	lastSummaryPost := cloneTimeMap(state.LastSummaryPost)
 
-	// Determine tick interval from configured strategy intervals, min 60s.
-	tickSeconds := schedulerTickSeconds(cfg)
-	fmt.Printf("Tick interval: %ds (strategies have individual intervals)\n", tickSeconds)
+	fmt.Printf("Scheduler: per-strategy cadence for %d strategies (default interval %ds)\n", len(cfg.Strategies), cfg.IntervalSeconds)
 	drawdownWarnThresholdPct := configuredDrawdownWarnThresholdPct(cfg)
 
 	reloadConfig := func() {
 		fmt.Printf("[reload] SIGHUP received; reloading config from %s\n", *configPath)
 		nextCfg, err := LoadConfig(*configPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "[reload] ERROR: reload failed; keeping previous config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "[reload] ERROR: reload failed; keeping previous config%s: %v\n", includedFilesNote(cfg), err)
 			return
 		}
 		mu.Lock()
@@ -685,11 +869,14 @@ func main() {
 		changes, err := applyHotReloadConfig(cfg, nextCfg, state, notifier, server)
 		if err != nil {
 			mu.Unlock()
-			fmt.Fprintf(os.Stderr, "[reload] ERROR: reload rejected; keeping previous config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "[reload] ERROR: reload rejected; keeping previous config%s: %v\n", includedFilesNote(cfg), err)
 			return
 		}
-		tickSeconds = schedulerTickSeconds(cfg)
 		drawdownWarnThresholdPct = configuredDrawdownWarnThresholdPct(cfg)
+		// #4924: ensembles are restart-only, but reloaded intervals move
+		// each voter's staleness window.
+		globalEnsembles.Configure(cfg)
+		globalTradeTagger.Configure(cfg)
 		mu.Unlock()
 
 		// #1147: refresh the diagnostics worker's strategy-ID → config
@@ -730,13 +917,21 @@ func main() {
 				fmt.Printf("[reload]   %s\n", change)
 			}
 		}
-		fmt.Printf("[reload] Tick interval now %ds\n", tickSeconds)
+	}
+	rotateCreds := func() {
+		msg := formatCredentialRotation(rotateCredentials(cfg, &mu, notifier, server))
+		fmt.Println("[credentials] " + msg)
+		if notifier.HasOwner() {
+			notifier.SendOwnerDM(msg)
+		}
 	}
 	processConfigReloads := func() {
 		for {
 			select {
 			case <-reloadCh:
 				reloadConfig()
+			case <-rotateCh:
+				rotateCreds()
 			default:
 				return
 			}
@@ -746,14 +941,21 @@ func main() {
 	// Wall-clock tracker for cfg.AutoUpdate == "daily". Initialized to now so
 	// the first daily check fires after 24h (matching the previous
 	// cycle-based behavior). We can't use cycle counts anymore because the
-	// scheduler no longer sleeps a fixed tick — schedulerDelay returns a
-	// variable delay (1s when due, up to the longest strategy interval
-	// otherwise), so cycle increments no longer correspond to wall-clock
-	// time.
+	// scheduler no longer sleeps a fixed tick — the loop wakes whenever any
+	// strategy's own ticker fires (#4921), so cycle increments no longer
+	// correspond to wall-clock time.
 	lastAutoUpdateCheck := time.Now()
 
 	saveFailures := 0
 	var resetGoroutineRunning atomic.Bool
+	healthPinger := newHealthcheckPinger()
+	sheetsExport := newSheetsExporter()
+
+	// #4983: tell systemd (Type=notify) startup is done and feed its
+	// watchdog from the loop below. No-ops outside systemd.
+	watchdog := newSDWatchdog()
+	go watchdog.Run(shutdownReadOnlyCtx)
+	sdNotifyLogged("READY=1")
 
 	// Main loop
 	for {
@@ -767,6 +969,7 @@ func main() {
 			return
 		}
 
+		watchdog.CycleStarted(time.Now())
 		processConfigReloads()
 
 		cycleStart := time.Now()
@@ -788,7 +991,15 @@ func main() {
 		// (which runs under mu.Lock) would self-deadlock. This gives manual fills
 		// the same DM/channel routing as normal live trades.
 		for _, ma := range manualAlerts {
-			sendTradeAlerts(ma.sc, ma.ss, ma.trades, &mu, notifier)
+			for _, msg := range ma.notices {
+				notifier.SendToChannel(ma.sc.Platform, ma.sc.Type, msg)
+				if notifier.HasOwner() {
+					notifier.SendOwnerDM(msg)
+				}
+			}
+			if ma.trades > 0 {
+				sendTradeAlertsUnlessMuted(ma.sc, ma.ss, ma.trades, state, &mu, notifier)
+			}
 		}
 
 		// #883: poll resting limit orders, adopt fills into tracked positions
@@ -798,7 +1009,12 @@ func main() {
 		// outside mu); alerts fire after, like the drain (#880).
 		limitAlerts := reconcilePendingLimitOrders(state, cfg, stateDB, &mu, notifier, logMgr)
 		for _, ma := range limitAlerts {
-			sendTradeAlerts(ma.sc, ma.ss, ma.trades, &mu, notifier)
+			sendTradeAlertsUnlessMuted(ma.sc, ma.ss, ma.trades, state, &mu, notifier)
+		}
+		// #4956: same for live HL orders that were partial or resting at
+		// execute time — book late fills into the owning position.
+		for _, ma := range reconcileTrackedHyperliquidOrders(state, cfg, stateDB, &mu, notifier, logMgr) {
+			sendTradeAlertsUnlessMuted(ma.sc, ma.ss, ma.trades, state, &mu, notifier)
 		}
 
 		// #87: Resolve capital_pct → capital for strategies with dynamic sizing.
@@ -807,42 +1023,52 @@ func main() {
 		resolveCapitalPct(cfg.Strategies)
 
 		// Compute effective per-strategy intervals once per cycle under
-		// RLock; reuse the same map for due-detection and schedulerDelay
-		// below to avoid re-entering the lock and recomputing per strategy.
+		// RLock and hand them to the scheduler (#4921), which starts/stops/
+		// re-anchors tickers for reloaded strategies and drawdown cadence.
 		mu.RLock()
 		intervals := effectiveStrategyIntervals(cfg.Strategies, state.Strategies, cfg.IntervalSeconds, drawdownWarnThresholdPct)
 		mu.RUnlock()
+		sched.Sync(cfg.Strategies, intervals, cycleStart)
 
-		// Determine which strategies are due this tick
+		// Determine which strategies are due this cycle
+		dueIDs := sched.DueIDs()
 		dueStrategies := make([]StrategyConfig, 0)
 		for _, sc := range cfg.Strategies {
+			if !dueIDs[sc.ID] {
+				continue
+			}
 			// #100: Skip strategies where capital_pct is set but capital resolved to $0
 			// (balance fetch failed and no fallback capital configured).
 			if shouldSkipZeroCapital(sc) {
 				fmt.Printf("[ERROR] %s: capital_pct set but capital resolved to $0 — skipping\n", sc.ID)
+				sched.MarkRan(sc.ID, cycleStart)
 				continue
 			}
-			interval := intervals[sc.ID]
-			last, exists := lastRun[sc.ID]
-			if !exists || cycleStart.Sub(last) >= time.Duration(interval)*time.Second {
-				dueStrategies = append(dueStrategies, sc)
-			}
+			dueStrategies = append(dueStrategies, sc)
 		}
+		// #4923: run each strategy after the due strategies it depends_on;
+		// #4924: ensemble executors also run after their due members.
+		dueStrategies = orderByDependencies(withEnsembleDependencies(dueStrategies, cfg.Ensembles))
 
 		if len(dueStrategies) == 0 {
-			// Nothing due, wait for next tick
-			delay := schedulerDelay(cfg.Strategies, intervals, lastRun, cfg.IntervalSeconds, time.Now(), tickSeconds)
-			timer := time.NewTimer(delay)
+			// Nothing due, wait for the next strategy tick
+			watchdog.Idle()
+			waitCh, stopWait := sched.waitChannel()
 			select {
-			case <-timer.C:
+			case <-waitCh:
 				continue
 			case <-reloadCh:
-				timer.Stop()
+				stopWait()
 				reloadConfig()
 				processConfigReloads()
 				continue
+			case <-rotateCh:
+				stopWait()
+				rotateCreds()
+				processConfigReloads()
+				continue
 			case <-stopCh:
-				timer.Stop()
+				stopWait()
 				fmt.Println("[shutdown] exiting trading loop.")
 				return
 			}
@@ -851,6 +1077,8 @@ func main() {
 		fmt.Printf("\n=== Cycle %d starting at %s (%d/%d strategies due) ===\n",
 			cycle, cycleStart.UTC().Format("2006-01-02 15:04:05 UTC"),
 			len(dueStrategies), len(cfg.Strategies))
+		cycleTimer := newCycleTimingRecorder(cycle, cycleStart, len(dueStrategies))
+		markUpgradeStateTouched()
 
 		// Collect symbols that need prices. Spot strategies use the
 		// BinanceUS-formatted symbol directly (e.g. "BTC/USDT").
@@ -865,11 +1093,13 @@ func main() {
 		hlPerpsCoins, okxPerpsCoins := collectPerpsMarkSymbols(cfg.Strategies)
 
 		// Fetch current prices for portfolio valuation
+		priceFetchStart := time.Now()
 		prices := make(map[string]float64)
 		if len(symbols) > 0 {
 			p, err := FetchPrices(symbols)
 			if err != nil {
 				fmt.Printf("[CRITICAL] Price fetch failed: %v — skipping cycle\n", err)
+				go healthPinger.Ping(cfg.Healthcheck, "price fetch failed")
 				continue
 			}
 			// Filter out any zero prices returned by the script
@@ -882,6 +1112,7 @@ func main() {
 			}
 			if len(prices) == 0 {
 				fmt.Printf("[CRITICAL] All prices are zero/missing — skipping cycle\n")
+				go healthPinger.Ping(cfg.Healthcheck, "all prices zero/missing")
 				continue
 			}
 		}
@@ -936,6 +1167,10 @@ func main() {
 				}
 			}
 		}
+		// #4961: cross-check each asset across BinanceUS / HL mid / Deribit
+		// index; an outvoted valuation price is replaced by the median.
+		runCrossSourcePriceValidation(cfg.PriceValidation, cfg.Strategies, prices, notifier)
+		cycleTimer.recordPriceFetch(time.Since(priceFetchStart))
 		if len(prices) > 0 {
 			fmt.Printf("Prices: ")
 			for sym, price := range prices {
@@ -943,6 +1178,10 @@ func main() {
 			}
 			fmt.Println()
 		}
+		// #4929: best-effort on-disk price history for /prices/history.
+		if err := priceHistory.Record(prices, cycleStart); err != nil {
+			fmt.Printf("[WARN] price history record failed: %v\n", err)
+		}
 
 		// totalPV holds the shared-wallet-adjusted portfolio value computed during
 		// the portfolio risk check; it is reused in the cycle summary log below
@@ -956,8 +1195,13 @@ func main() {
 		// paths fall back to virtual-sum as before.
 		walletBalances := make(map[SharedWalletKey]float64)
 
+		// cycleFailure, when set, turns this cycle's healthcheck ping into a
+		// failure ping (the cycle ran but did not do its job).
+		cycleFailure := ""
+
 		// Process only due strategies
 		if saveFailures >= 3 {
+			cycleFailure = "trading suspended after repeated state save failures"
 			fmt.Println("[CRITICAL] State save failed 3x, skipping trades this cycle")
 			// #879: the fan-out below is skipped, so clear the regime store —
 			// /api/regime must not keep serving the prior cycle's labels as
@@ -988,6 +1232,9 @@ func main() {
 			// manual, options, dashboard) reads this map; check scripts no
 			// longer compute regime inline.
 			regimeStoreReady := startRegimeStorePopulation(globalRegimeStore, dueStrategies, cfg.Regime, notifier)
+			// #4926: refresh the Go-side market regime's OHLCV cache (once per
+			// bar per asset) alongside the regime store.
+			marketRegimeReady := globalMarketRegimes.StartRefresh(cfg.MarketRegime, dueStrategies, time.Now().UTC())
 			// #42 / #243: Portfolio-level risk check before running any strategy.
 			//
 			// Fetch live Hyperliquid clearinghouseState ONCE per cycle (outside
@@ -1000,6 +1247,8 @@ func main() {
 			killSwitchFired := false
 			notionalBlocked := false
 			dailyLossEntriesHeld := false
+			var platformRiskStatus map[string]PlatformRiskStatus
+			varHoldReason := ""
 			exposureCapStatus := ExposureCapStatus{}
 			usedPVFallback := false
 
@@ -1240,7 +1489,11 @@ func main() {
 			// CheckPortfolioRisk auto-ratchets PeakValue when totalValue > peak;
 			// we snapshot before the call and restore if we're on a fallback
 			// cycle. Drawdown detection still runs against the frozen peak.
-			origPeak := state.PortfolioRisk.PeakValue
+			if cfg.PortfolioRisk != nil && applyPortfolioDrawdownWindow(&state.PortfolioRisk, cfg.PortfolioRisk.DrawdownWindowDays, time.Now()) {
+				fmt.Printf("[risk] Portfolio peak aged to $%.2f (%s, %d-day drawdown window)\n",
+					state.PortfolioRisk.PeakValue, state.PortfolioRisk.PeakAt.Format("2006-01-02"), cfg.PortfolioRisk.DrawdownWindowDays)
+			}
+			origPeak, origPeakAt, origPeakSource := state.PortfolioRisk.PeakValue, state.PortfolioRisk.PeakAt, state.PortfolioRisk.PeakSource
 			prevWarningSent := state.PortfolioRisk.WarningSent
 			portfolioAllowed, nb, portfolioWarning, portfolioReason := CheckPortfolioRisk(&state.PortfolioRisk, cfg.PortfolioRisk, totalPV, totalNotional, perpsLoss, perpsMargin)
 			// True only on the cycle that first enters the warn band; false on
@@ -1249,6 +1502,11 @@ func main() {
 			portfolioWarnBandEntered := portfolioWarning && !prevWarningSent
 			if usedPVFallback && state.PortfolioRisk.PeakValue > origPeak {
 				state.PortfolioRisk.PeakValue = origPeak
+				state.PortfolioRisk.PeakAt = origPeakAt
+				state.PortfolioRisk.PeakSource = origPeakSource
+			}
+			if !usedPVFallback {
+				recordPortfolioRiskSample(&state.PortfolioRisk, totalPV, state.PortfolioRisk.CurrentDrawdownPct, time.Now())
 			}
 			if !portfolioAllowed {
 				killSwitchFired = true
@@ -1280,16 +1538,16 @@ func main() {
 			if notionalBlocked {
 				fmt.Printf("[WARN] %s\n", portfolioReason)
 			}
-			// #1269: hold position-increasing signals for the rest of the UTC
+			// #1269: hold position-increasing signals for the rest of the risk
 			// day once the aggregate daily realized loss reaches the limit.
 			// Entry-suppression only — the manage-only paths below keep
 			// running, nothing is force-closed, and the gate clears itself at
-			// the UTC rollover (DailyPnL date keys roll per strategy). The
+			// the daily reset (DailyPnL date keys roll per strategy, #4986). The
 			// once-per-day owner DM fires after mu is released below (#880
 			// convention: no notifier I/O under the state lock).
 			dailyLossEntriesHeld = dailyLossStatus.Tripped
 			if dailyLossEntriesHeld {
-				fmt.Printf("[WARN] %s — entries held until UTC rollover\n", dailyLossHoldDetail(dailyLossStatus))
+				fmt.Printf("[WARN] %s — entries held until the %s daily reset\n", dailyLossHoldDetail(dailyLossStatus), dailyResetLabel())
 			}
 			// #1291 review: a configured pct arm that cannot evaluate is an
 			// auto-protective gap — surface it every cycle, not only in the
@@ -1297,6 +1555,25 @@ func main() {
 			if dailyLossStatus.PctBasisMiss {
 				fmt.Printf("[WARN] %s\n", dailyLossPctBasisMissWarning)
 			}
+			// platforms.<name>.risk: per-platform aggregate drawdown/notional
+			// limits. Holds position-increasing actions on a breached platform
+			// (same semantics as the daily loss hold); never force-closes.
+			platformRiskStatus = evaluatePlatformRisk(cfg, state, prices, walletBalances, sharedWallets, usedPVFallback, time.Now())
+			for _, name := range sortedPlatformRiskNames(platformRiskStatus) {
+				if st := platformRiskStatus[name]; st.Held() {
+					fmt.Printf("[WARN] %s — %s entries held\n", st.Reason, name)
+				}
+			}
+			// Historical VaR from the daily history; max_var_pct holds entries
+			// while the estimate is over the limit (same semantics as #1269).
+			portfolioVaR := evaluatePortfolioVaR(cfg.PortfolioRisk, &state.PortfolioRisk, totalPV, time.Now())
+			state.PortfolioRisk.VaR = &portfolioVaR
+			varNewlyBreached := portfolioVaR.Breached && !portfolioVaRAlerted
+			portfolioVaRAlerted = portfolioVaR.Breached
+			if portfolioVaR.Breached {
+				varHoldReason = portfolioVaRHoldDetail(portfolioVaR)
+				fmt.Printf("[WARN] %s — entries held\n", varHoldReason)
+			}
 			// #954: book this cycle's funding payments + non-trade flows into
 			// the ledger BEFORE the display reconcile reads the ledger sums —
 			// the wallet balance being reconciled already includes them.
@@ -1310,20 +1587,30 @@ func main() {
 			driftResults := reconcileSharedWalletDisplayValues(cfg.Strategies, state, stateDB, sharedWallets, walletBalances, hlPositions, okxPositions, okxStateFetched)
 			mu.Unlock()
 
-			// #1269: once-per-UTC-day owner DM on a tripped daily loss limit.
+			// #1269: once-per-risk-day owner DM on a tripped daily loss limit.
 			// Outside mu (notifier I/O never runs under the state lock, #880).
 			if dailyLossEntriesHeld {
-				today := time.Now().UTC().Format("2006-01-02")
+				today := riskDay(time.Now())
 				if dailyLossAlertDue(true, dailyLossLastAlertDate, today) {
 					dailyLossLastAlertDate = today
 					notifier.SendOwnerDM(formatDailyLossTripDM(dailyLossStatus, time.Now().UTC()))
 				}
 			}
-			// #1291 review: once-per-UTC-day owner DM while a configured pct
+			// Platform risk: owner DM on the cycle a platform enters the held
+			// state (re-arms once it clears). Outside mu (#880).
+			for _, name := range sortedPlatformRiskNames(platformRiskStatus) {
+				if st := platformRiskStatus[name]; st.NewlyBreached {
+					notifier.SendOwnerDM(fmt.Sprintf("Platform risk limit breached: %s. New %s entries are held until it clears; open positions keep their exits.", st.Reason, name))
+				}
+			}
+			if varNewlyBreached {
+				notifier.SendOwnerDM(fmt.Sprintf("🛑 %s. New entries are held until it falls back under the limit; open positions keep their exits.", varHoldReason))
+			}
+			// #1291 review: once-per-risk-day owner DM while a configured pct
 			// arm cannot evaluate (initial_capital basis is 0) — a silently
 			// inert protection must reach an active operator channel.
 			if dailyLossStatus.PctBasisMiss {
-				today := time.Now().UTC().Format("2006-01-02")
+				today := riskDay(time.Now())
 				if dailyLossAlertDue(true, dailyLossPctBasisMissAlertDate, today) {
 					dailyLossPctBasisMissAlertDate = today
 					notifier.SendOwnerDM(formatDailyLossPctBasisMissDM(dailyLossStatus, time.Now().UTC()))
@@ -1511,6 +1798,14 @@ func main() {
 				}
 				notifier.SendToAllChannels(killSwitchMsg)
 			}
+			// #4945: a latched kill switch needs an owner ack within
+			// alert_escalation.ack_window or it escalates; the slot clears
+			// with the latch so the next trip arms a fresh window.
+			if killSwitchFired {
+				criticalAlerts.Raise(killSwitchEscalationKey, "**PORTFOLIO KILL SWITCH** "+portfolioReason)
+			} else {
+				criticalAlerts.Resolve(killSwitchEscalationKey)
+			}
 
 			// Warning alert: drawdown approaching kill switch threshold.
 			if portfolioWarning && notifier.HasBackends() {
@@ -1595,9 +1890,7 @@ func main() {
 						return
 					}
 					mu.Lock()
-					state.PortfolioRisk.KillSwitchActive = false
-					state.PortfolioRisk.KillSwitchAt = time.Time{}
-					addKillSwitchEvent(&state.PortfolioRisk, "reset", "", state.PortfolioRisk.CurrentDrawdownPct, 0, state.PortfolioRisk.PeakValue, "manual reset via DM")
+					ManualResetKillSwitch(&state.PortfolioRisk, "manual reset via DM")
 					if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
 						fmt.Printf("[CRITICAL] Failed to save state after kill switch reset: %v\n", err)
 					}
@@ -1741,6 +2034,7 @@ func main() {
 				// consumer — wait (bounded by regimeStorePhaseBudget) for the
 				// population kicked off before the risk phase.
 				regimeStoreReady()
+				marketRegimeReady()
 				// #1224: persist per-window labels, detect transitions, and
 				// alert on cross-window reversals. Sequential main loop,
 				// outside mu; fail-open — never blocks the dispatch below.
@@ -1750,6 +2044,7 @@ func main() {
 					if stratState == nil {
 						continue
 					}
+					strategyStart := time.Now()
 
 					logger, err := logMgr.GetStrategyLogger(sc.ID)
 					if err != nil {
@@ -1769,6 +2064,8 @@ func main() {
 					// Phase 1: RLock — read inputs needed for subprocess
 					mu.RLock()
 					pv := PortfolioValue(stratState, prices)
+					strategyLimits := evaluateStrategyLimits(&sc, stratState, prices)
+					agedExit := evaluateAgedPosition(&sc, stratState, time.Now().UTC())
 					var posJSON string
 					if sc.Type == "options" {
 						posJSON = EncodeAllPositionsJSON(stratState.OptionPositions, stratState.Positions)
@@ -1933,7 +2230,8 @@ func main() {
 							logger.Info("Circuit breaker latched — suppressing new entries but continuing trailing-SL/TP management for open position (#1046)")
 						} else {
 							logger.Close()
-							lastRun[sc.ID] = time.Now()
+							sched.MarkRan(sc.ID, cycleStart)
+							cycleTimer.recordStrategyTotal(sc.ID, time.Since(strategyStart))
 							continue
 						}
 					}
@@ -1952,13 +2250,15 @@ func main() {
 					if notionalCapSkipsStrategyCycle(notionalBlocked) {
 						logger.Warn("Notional cap exceeded — skipping strategy cycle")
 						logger.Close()
-						lastRun[sc.ID] = time.Now()
+						sched.MarkRan(sc.ID, cycleStart)
+						cycleTimer.recordStrategyTotal(sc.ID, time.Since(strategyStart))
 						continue
 					}
 
 					// Phase 3 (no lock) + Phase 4 (Lock): subprocess then state mutation
 					trades := 0
 					var detail string
+					dispatchStart := time.Now()
 					switch sc.Type {
 					case "spot":
 						if sc.Platform == "okx" {
@@ -1969,9 +2269,18 @@ func main() {
 								// result.Regime at it so stamp-at-open inside execute* shares it.
 								storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 								result.Regime = &storeRegime
+								// #4924: ensemble members only vote; the executor trades the
+								// consolidated signal. Applied before the gates below.
+								result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+								// #4966: a position held past max_holding_hours is closed whatever
+								// the script says; the close passes the entry holds below.
+								if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+									notifyAgedPositionExit(sc, why, notifier, logger)
+								}
 								if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, okxPosQty); regimeBlocked {
 									logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "regime_gate")
 								}
 								// #1150: paused — hold position-increasing signals (fresh open, add,
 								// flip); position-reducing actions pass so open positions ride their
@@ -1979,18 +2288,67 @@ func main() {
 								if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
 									logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "paused")
 								}
 								// #1269: daily loss limit tripped — identical hold semantics to pause:
 								// position-increasing signals held, position-reducing actions pass.
 								if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
-									logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+									logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "daily_loss_limit")
 								}
 								// #1344: portfolio notional cap — hold position-increasing signals
 								// only; closes/reductions and Signal==0 manage keep running.
 								if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
 									logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "notional_cap")
+								}
+								// platforms.<name>.risk: platform drawdown/notional limit breached —
+								// same hold semantics as the notional cap.
+								if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
+									logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "platform_risk")
+								}
+								// Per-strategy max_notional_usd / max_positions caps.
+								if why := strategyLimits.holdReason(okxPosQty); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
+									logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "strategy_cap")
+								}
+								// portfolio_risk.max_var_pct: historical VaR over the limit.
+								if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
+									logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "var_limit")
+								}
+								// #4944: frozen price / stale candles — alert, and with
+								// stale_data.block_entries hold position-increasing signals only.
+								if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
+									logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_data")
+								}
+								// #4960: a price that jumped past price_sanity.max_move_pct since the
+								// previous cycle is treated as a bad print — refuse any trade on it.
+								if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_sanity")
+								}
+								// #4961: the check price must agree with the cross-source reference.
+								if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_divergence")
+								}
+								// #4962: a signal computed from candles that closed more than a
+								// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+								if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+									logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_candle")
 								}
 								// #1270: same-direction exposure cap — only the capped direction's
 								// position-increasing signals are held; the other direction and all
@@ -1998,6 +2356,14 @@ func main() {
 								if capBlocked, capWhy := exposureCapBlocksSignal(exposureCapStatus, extractAsset(sc), result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false); capBlocked {
 									logger.Warn("Exposure cap: %s signal suppressed — %s (#1270)", signalStr, capWhy)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "exposure_cap")
+								}
+								// #4965: an identical position-increasing signal within
+								// signal_dedup_minutes of the last executed one is churn.
+								if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, true, false) {
+									logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "duplicate_signal")
 								}
 								mu.Lock()
 								syncStrategyRegimeState(stratState, storeRegime, cfg.Regime)
@@ -2015,7 +2381,12 @@ func main() {
 									var cashAlert string
 									mu.Lock()
 									trades, detail, cashAlert = executeOKXResult(sc, stratState, stateDB, result, execResult, signalStr, price, cfg.Regime, cfg, logger)
+									signalHistory.Finish(sc.ID, result.Signal, trades)
+									recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 									mu.Unlock()
+									if execResult != nil {
+										ackOrderIntents(sc.ID, result.Symbol, logger)
+									}
 									if cashAlert != "" {
 										notifySpotLiveCashOverBudget(notifier, cashAlert)
 										// Seed the cycle reminder so the founding
@@ -2035,9 +2406,18 @@ func main() {
 								// result.Regime at it so stamp-at-open inside execute* shares it.
 								storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 								result.Regime = &storeRegime
+								// #4924: ensemble members only vote; the executor trades the
+								// consolidated signal. Applied before the gates below.
+								result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+								// #4966: a position held past max_holding_hours is closed whatever
+								// the script says; the close passes the entry holds below.
+								if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+									notifyAgedPositionExit(sc, why, notifier, logger)
+								}
 								if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, rhPosQty); regimeBlocked {
 									logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "regime_gate")
 								}
 								// #1150: paused — hold position-increasing signals (fresh open, add,
 								// flip); position-reducing actions pass so open positions ride their
@@ -2045,18 +2425,67 @@ func main() {
 								if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
 									logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "paused")
 								}
 								// #1269: daily loss limit tripped — identical hold semantics to pause:
 								// position-increasing signals held, position-reducing actions pass.
 								if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
-									logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+									logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "daily_loss_limit")
 								}
 								// #1344: portfolio notional cap — hold position-increasing signals
 								// only; closes/reductions and Signal==0 manage keep running.
 								if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
 									logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "notional_cap")
+								}
+								// platforms.<name>.risk: platform drawdown/notional limit breached —
+								// same hold semantics as the notional cap.
+								if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
+									logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "platform_risk")
+								}
+								// Per-strategy max_notional_usd / max_positions caps.
+								if why := strategyLimits.holdReason(rhPosQty); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
+									logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "strategy_cap")
+								}
+								// portfolio_risk.max_var_pct: historical VaR over the limit.
+								if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
+									logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "var_limit")
+								}
+								// #4944: frozen price / stale candles — alert, and with
+								// stale_data.block_entries hold position-increasing signals only.
+								if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
+									logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_data")
+								}
+								// #4960: a price that jumped past price_sanity.max_move_pct since the
+								// previous cycle is treated as a bad print — refuse any trade on it.
+								if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_sanity")
+								}
+								// #4961: the check price must agree with the cross-source reference.
+								if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_divergence")
+								}
+								// #4962: a signal computed from candles that closed more than a
+								// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+								if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+									logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_candle")
 								}
 								// #1270: same-direction exposure cap — only the capped direction's
 								// position-increasing signals are held; the other direction and all
@@ -2064,6 +2493,14 @@ func main() {
 								if capBlocked, capWhy := exposureCapBlocksSignal(exposureCapStatus, extractAsset(sc), result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false); capBlocked {
 									logger.Warn("Exposure cap: %s signal suppressed — %s (#1270)", signalStr, capWhy)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "exposure_cap")
+								}
+								// #4965: an identical position-increasing signal within
+								// signal_dedup_minutes of the last executed one is churn.
+								if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, rhPosQty, rhPosSide, true, false) {
+									logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "duplicate_signal")
 								}
 								mu.Lock()
 								syncStrategyRegimeState(stratState, storeRegime, cfg.Regime)
@@ -2081,7 +2518,12 @@ func main() {
 									var cashAlert string
 									mu.Lock()
 									trades, detail, cashAlert = executeRobinhoodResult(sc, stratState, stateDB, result, execResult, signalStr, price, cfg.Regime, cfg, logger)
+									signalHistory.Finish(sc.ID, result.Signal, trades)
+									recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 									mu.Unlock()
+									if execResult != nil {
+										ackOrderIntents(sc.ID, result.Symbol, logger)
+									}
 									if cashAlert != "" {
 										notifySpotLiveCashOverBudget(notifier, cashAlert)
 										// Seed the cycle reminder so the founding
@@ -2099,9 +2541,18 @@ func main() {
 							// result.Regime at it so stamp-at-open inside execute* shares it.
 							storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 							result.Regime = &storeRegime
+							// #4924: ensemble members only vote; the executor trades the
+							// consolidated signal. Applied before the gates below.
+							result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+							// #4966: a position held past max_holding_hours is closed whatever
+							// the script says; the close passes the entry holds below.
+							if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+								notifyAgedPositionExit(sc, why, notifier, logger)
+							}
 							if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, spotPosCtx.Quantity); regimeBlocked {
 								logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "regime_gate")
 							}
 							// #1150: paused — hold position-increasing signals (fresh open, add,
 							// flip); position-reducing actions pass so open positions ride their
@@ -2109,18 +2560,67 @@ func main() {
 							if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
 								logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "paused")
 							}
 							// #1269: daily loss limit tripped — identical hold semantics to pause:
 							// position-increasing signals held, position-reducing actions pass.
 							if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
-								logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+								logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "daily_loss_limit")
 							}
 							// #1344: portfolio notional cap — hold position-increasing signals
 							// only; closes/reductions and Signal==0 manage keep running.
 							if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
 								logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "notional_cap")
+							}
+							// platforms.<name>.risk: platform drawdown/notional limit breached —
+							// same hold semantics as the notional cap.
+							if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
+								logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "platform_risk")
+							}
+							// Per-strategy max_notional_usd / max_positions caps.
+							if why := strategyLimits.holdReason(spotPosCtx.Quantity); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
+								logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "strategy_cap")
+							}
+							// portfolio_risk.max_var_pct: historical VaR over the limit.
+							if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
+								logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "var_limit")
+							}
+							// #4944: frozen price / stale candles — alert, and with
+							// stale_data.block_entries hold position-increasing signals only.
+							if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
+								logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_data")
+							}
+							// #4960: a price that jumped past price_sanity.max_move_pct since the
+							// previous cycle is treated as a bad print — refuse any trade on it.
+							if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_sanity")
+							}
+							// #4961: the check price must agree with the cross-source reference.
+							if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_divergence")
+							}
+							// #4962: a signal computed from candles that closed more than a
+							// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+							if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+								logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_candle")
 							}
 							// #1270: same-direction exposure cap — only the capped direction's
 							// position-increasing signals are held; the other direction and all
@@ -2128,10 +2628,20 @@ func main() {
 							if capBlocked, capWhy := exposureCapBlocksSignal(exposureCapStatus, extractAsset(sc), result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false); capBlocked {
 								logger.Warn("Exposure cap: %s signal suppressed — %s (#1270)", signalStr, capWhy)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "exposure_cap")
+							}
+							// #4965: an identical position-increasing signal within
+							// signal_dedup_minutes of the last executed one is churn.
+							if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, spotPosCtx.Quantity, spotPosCtx.Side, true, false) {
+								logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "duplicate_signal")
 							}
 							mu.Lock()
 							syncStrategyRegimeState(stratState, storeRegime, cfg.Regime)
 							trades, detail = executeSpotResult(sc, stratState, stateDB, result, signalStr, price, cfg.Regime, cfg, logger)
+							signalHistory.Finish(sc.ID, result.Signal, trades)
+							recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 							mu.Unlock()
 						}
 					case "options":
@@ -2143,6 +2653,7 @@ func main() {
 								kept, dropped := pausedOptionsActions(result.Actions)
 								if dropped > 0 {
 									logger.Info("Paused: %d option open action(s) dropped — close actions still execute (#1150)", dropped)
+									signalHistory.Block(sc.ID, "paused")
 								}
 								result.Actions = kept
 							}
@@ -2152,7 +2663,8 @@ func main() {
 							if dailyLossEntriesHeld {
 								kept, dropped := pausedOptionsActions(result.Actions)
 								if dropped > 0 {
-									logger.Info("Daily loss limit: %d option open action(s) dropped — entries held until UTC rollover (#1269)", dropped)
+									logger.Info("Daily loss limit: %d option open action(s) dropped — entries held until the daily reset (#1269)", dropped)
+									signalHistory.Block(sc.ID, "daily_loss_limit")
 								}
 								result.Actions = kept
 							}
@@ -2162,6 +2674,34 @@ func main() {
 								kept, dropped := pausedOptionsActions(result.Actions)
 								if dropped > 0 {
 									logger.Warn("Notional cap: %d option open action(s) dropped — new opens blocked, exits continue (#1344)", dropped)
+									signalHistory.Block(sc.ID, "notional_cap")
+								}
+								result.Actions = kept
+							}
+							// platforms.<name>.risk: drop option open actions on a breached
+							// platform; closes and the theta-harvest walker still run.
+							if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" {
+								kept, dropped := pausedOptionsActions(result.Actions)
+								if dropped > 0 {
+									logger.Warn("Platform risk: %d option open action(s) dropped — %s", dropped, why)
+									signalHistory.Block(sc.ID, "platform_risk")
+								}
+								result.Actions = kept
+							}
+							// Per-strategy caps: every option open adds a leg.
+							if why := strategyLimits.holdReason(0); why != "" {
+								kept, dropped := pausedOptionsActions(result.Actions)
+								if dropped > 0 {
+									logger.Warn("Strategy cap: %d option open action(s) dropped — %s", dropped, why)
+									signalHistory.Block(sc.ID, "strategy_cap")
+								}
+								result.Actions = kept
+							}
+							if varHoldReason != "" {
+								kept, dropped := pausedOptionsActions(result.Actions)
+								if dropped > 0 {
+									logger.Warn("VaR limit: %d option open action(s) dropped — %s", dropped, varHoldReason)
+									signalHistory.Block(sc.ID, "var_limit")
 								}
 								result.Actions = kept
 							}
@@ -2171,6 +2711,15 @@ func main() {
 							// theta-harvest walker still manage existing positions.
 							if kept, dropped, capWhy := exposureCapOptionsActions(exposureCapStatus, extractAsset(sc), result.Actions); dropped > 0 {
 								logger.Warn("Exposure cap: %d option open action(s) dropped — %s (#1270)", dropped, capWhy)
+								signalHistory.Block(sc.ID, "exposure_cap")
+								result.Actions = kept
+							}
+							// #4965: drop open legs identical to ones executed within
+							// signal_dedup_minutes — scripts that re-emit the same opens
+							// every run would otherwise keep stacking positions.
+							if kept, dropped := duplicateOptionsActions(sc, result.Underlying, result.Actions); dropped > 0 {
+								logger.Info("Duplicate signal: %d option open action(s) dropped — identical leg executed within signal_dedup_minutes %d", dropped, sc.SignalDedupMinutes)
+								signalHistory.Block(sc.ID, "duplicate_signal")
 								result.Actions = kept
 							}
 							// #879: options regime now comes from the global store's
@@ -2182,6 +2731,8 @@ func main() {
 							stratState.Regime = optionsRegime.PrimaryLabel(nil)
 							var harvestDetails []string
 							trades, detail, harvestDetails = executeOptionsResult(sc, stratState, result, signalStr, logger)
+							signalHistory.Finish(sc.ID, result.Signal, trades)
+							recordExecutedOptionsActions(sc, result.Underlying, result.Actions, trades)
 							mu.Unlock()
 							if chKey := notifier.resolveChannelKey(sc.Platform, sc.Type); chKey != "" {
 								key := chKey + "|" + extractAsset(sc)
@@ -2216,9 +2767,18 @@ func main() {
 								// result.Regime at it so stamp-at-open inside execute* shares it.
 								storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 								result.Regime = &storeRegime
+								// #4924: ensemble members only vote; the executor trades the
+								// consolidated signal. Applied before the gates below.
+								result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+								// #4966: a position held past max_holding_hours is closed whatever
+								// the script says; the close passes the entry holds below.
+								if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+									notifyAgedPositionExit(sc, why, notifier, logger)
+								}
 								if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, okxPosQty); regimeBlocked {
 									logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "regime_gate")
 								}
 								// #1150: paused — hold position-increasing signals (fresh open, add,
 								// flip); position-reducing actions pass so open positions ride their
@@ -2226,18 +2786,67 @@ func main() {
 								if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
 									logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "paused")
 								}
 								// #1269: daily loss limit tripped — identical hold semantics to pause:
 								// position-increasing signals held, position-reducing actions pass.
 								if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
-									logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+									logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "daily_loss_limit")
 								}
 								// #1344: portfolio notional cap — hold position-increasing signals
 								// only; closes/reductions and Signal==0 manage keep running.
 								if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
 									logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "notional_cap")
+								}
+								// platforms.<name>.risk: platform drawdown/notional limit breached —
+								// same hold semantics as the notional cap.
+								if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+									logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "platform_risk")
+								}
+								// Per-strategy max_notional_usd / max_positions caps.
+								if why := strategyLimits.holdReason(okxPosQty); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+									logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "strategy_cap")
+								}
+								// portfolio_risk.max_var_pct: historical VaR over the limit.
+								if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+									logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "var_limit")
+								}
+								// #4944: frozen price / stale candles — alert, and with
+								// stale_data.block_entries hold position-increasing signals only.
+								if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+									logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_data")
+								}
+								// #4960: a price that jumped past price_sanity.max_move_pct since the
+								// previous cycle is treated as a bad print — refuse any trade on it.
+								if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_sanity")
+								}
+								// #4961: the check price must agree with the cross-source reference.
+								if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+									logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "price_divergence")
+								}
+								// #4962: a signal computed from candles that closed more than a
+								// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+								if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+									logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "stale_candle")
 								}
 								// #1270: same-direction exposure cap — only the capped direction's
 								// position-increasing signals are held; the other direction and all
@@ -2245,6 +2854,14 @@ func main() {
 								if capBlocked, capWhy := exposureCapBlocksSignal(exposureCapStatus, extractAsset(sc), result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)); capBlocked {
 									logger.Warn("Exposure cap: %s signal suppressed — %s (#1270)", signalStr, capWhy)
 									result.Signal = 0
+									signalHistory.Block(sc.ID, "exposure_cap")
+								}
+								// #4965: an identical position-increasing signal within
+								// signal_dedup_minutes of the last executed one is churn.
+								if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, okxPosQty, okxPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+									logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+									result.Signal = 0
+									signalHistory.Block(sc.ID, "duplicate_signal")
 								}
 								mu.Lock()
 								syncStrategyRegimeState(stratState, storeRegime, cfg.Regime)
@@ -2262,7 +2879,12 @@ func main() {
 									var cashAlert string
 									mu.Lock()
 									trades, detail, cashAlert = executeOKXResult(sc, stratState, stateDB, result, execResult, signalStr, price, cfg.Regime, cfg, logger)
+									signalHistory.Finish(sc.ID, result.Signal, trades)
+									recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 									mu.Unlock()
+									if execResult != nil {
+										ackOrderIntents(sc.ID, result.Symbol, logger)
+									}
 									if cashAlert != "" {
 										notifySpotLiveCashOverBudget(notifier, cashAlert)
 										// Seed the cycle reminder so the founding
@@ -2284,15 +2906,25 @@ func main() {
 							// position's stop-loss ratcheting through the latch window.
 							if cbManageOnly {
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "circuit_breaker")
 							}
 							// #879: single-source regime — read the global store for this
 							// strategy's signature instead of the check output, and point
 							// result.Regime at it so stamp-at-open inside execute* shares it.
 							storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 							result.Regime = &storeRegime
+							// #4924: ensemble members only vote; the executor trades the
+							// consolidated signal. Applied before the gates below.
+							result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+							// #4966: a position held past max_holding_hours is closed whatever
+							// the script says; the close passes the entry holds below.
+							if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+								notifyAgedPositionExit(sc, why, notifier, logger)
+							}
 							if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, hlPosQty); regimeBlocked {
 								logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "regime_gate")
 							}
 							// #1150: paused — hold position-increasing signals (fresh open, add,
 							// flip); position-reducing actions pass so open positions ride their
@@ -2300,12 +2932,14 @@ func main() {
 							if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
 								logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "paused")
 							}
 							// #1269: daily loss limit tripped — identical hold semantics to pause:
 							// position-increasing signals held, position-reducing actions pass.
 							if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
-								logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+								logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "daily_loss_limit")
 							}
 							// #1344: portfolio notional cap — hold position-increasing signals
 							// only; closes/reductions and Signal==0 manage (trailing SL /
@@ -2313,6 +2947,53 @@ func main() {
 							if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
 								logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "notional_cap")
+							}
+							// platforms.<name>.risk: platform drawdown/notional limit breached —
+							// same hold semantics as the notional cap.
+							if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+								logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "platform_risk")
+							}
+							// Per-strategy max_notional_usd / max_positions caps.
+							if why := strategyLimits.holdReason(hlPosQty); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+								logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "strategy_cap")
+							}
+							// portfolio_risk.max_var_pct: historical VaR over the limit.
+							if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+								logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "var_limit")
+							}
+							// #4944: frozen price / stale candles — alert, and with
+							// stale_data.block_entries hold position-increasing signals only.
+							if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+								logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_data")
+							}
+							// #4960: a price that jumped past price_sanity.max_move_pct since the
+							// previous cycle is treated as a bad print — refuse any trade on it.
+							if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_sanity")
+							}
+							// #4961: the check price must agree with the cross-source reference.
+							if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_divergence")
+							}
+							// #4962: a signal computed from candles that closed more than a
+							// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+							if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+								logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_candle")
 							}
 							// #1270: same-direction exposure cap — only the capped direction's
 							// position-increasing signals are held; the other direction and all
@@ -2321,6 +3002,14 @@ func main() {
 							if capBlocked, capWhy := exposureCapBlocksSignal(exposureCapStatus, extractAsset(sc), result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)); capBlocked {
 								logger.Warn("Exposure cap: %s signal suppressed — %s (#1270)", signalStr, capWhy)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "exposure_cap")
+							}
+							// #4965: an identical position-increasing signal within
+							// signal_dedup_minutes of the last executed one is churn.
+							if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, hlPosQty, hlPosSide, PerpsAllowsLong(sc), PerpsAllowsShort(sc)) {
+								logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "duplicate_signal")
 							}
 							mu.Lock()
 							syncStrategyRegimeState(stratState, storeRegime, cfg.Regime)
@@ -2358,7 +3047,7 @@ func main() {
 								// synthetic close when mark crosses the trigger. Each strategy's
 								// virtual position is isolated in stratState.Positions, so peers
 								// on the same coin are unaffected by this strategy's breach.
-								newHighWater, newTrigger, breach, breachPx := runHyperliquidTrailingStopPaper(sc, hlPosSide, hlPosSnapshot, price, hlStopLossHighWaterPx, hlStopLossTriggerPx)
+								newHighWater, newTrigger, breach, breachPx := runHyperliquidTrailingStopPaper(sc, hlPosSide, hlPosSnapshot, price, hlStopLossHighWaterPx, hlStopLossTriggerPx, paperBarOf(result.StrategyDecisionFields))
 								mu.Lock()
 								if pos, ok3 := stratState.Positions[result.Symbol]; ok3 && pos.Quantity > 0 && pos.Side == hlPosSide {
 									if breach {
@@ -2417,7 +3106,7 @@ func main() {
 							// OID (live) or TriggerPx (paper) — the trigger is fixed for the
 							// life of the position and never re-armed.
 							if !hyperliquidIsLive(sc.Args) && result.Signal == 0 && hlPosQty > 0 && sc.StopLossATRMult != nil && *sc.StopLossATRMult > 0 {
-								newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, hlPosSide, hlPosSnapshot, price, hlStopLossTriggerPx)
+								newTrigger, breach, breachPx := runHyperliquidFixedATRStopLossPaper(sc, hlPosSide, hlPosSnapshot, price, hlStopLossTriggerPx, paperBarOf(result.StrategyDecisionFields))
 								mu.Lock()
 								if pos, ok3 := stratState.Positions[result.Symbol]; ok3 && pos.Quantity > 0 && pos.Side == hlPosSide {
 									if breach {
@@ -2534,6 +3223,8 @@ func main() {
 								} else {
 									trades, detail, openTrade, ratchetAlert = executeHyperliquidResultDeferredOpen(sc, stratState, result, execResult, signalStr, price, cfg.Regime, cfg, logger)
 								}
+								signalHistory.Finish(sc.ID, result.Signal, trades)
+								recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 								mu.Unlock()
 								// #1110: deliver any ratchet-tighten DM after releasing the lock
 								// (Discord/Telegram HTTP must not run under mu). Nil-safe no-op
@@ -2586,6 +3277,22 @@ func main() {
 									recordPositionOpen(stratState, sc, openTrade, pos)
 									mu.Unlock()
 								}
+								// #4916: the fill is now applied (open trades were
+								// persisted by recordPositionOpen above) — drop its intent.
+								if execResult != nil {
+									ackOrderIntents(sc.ID, result.Symbol, logger)
+									// #4956: a partial or resting order keeps filling
+									// after --execute returns; poll it until off the book.
+									trackHyperliquidExecution(stateDB, sc, stratState, result.Symbol, execResult, &mu, logger)
+									// #4959: a fresh open that filled through the
+									// slippage limit is closed again right away.
+									if execResult.SlippageBreached && sc.FlattenOnSlippage && hlPosQty == 0 && scaleInAddQty == 0 {
+										if n, flatDetail := flattenSlippedHyperliquidEntry(sc, stratState, result.Symbol, &mu, notifier, logger); n > 0 {
+											trades += n
+											detail = flatDetail
+										}
+									}
+								}
 							}
 							// #998: stamp the active profile on a freshly opened
 							// position (freezes it for the position's life) and commit
@@ -2607,9 +3314,18 @@ func main() {
 							// result.Regime at it so stamp-at-open inside execute* shares it.
 							storeRegime := globalRegimeStore.PayloadForStrategy(sc, cfg.Regime)
 							result.Regime = &storeRegime
+							// #4924: ensemble members only vote; the executor trades the
+							// consolidated signal. Applied before the gates below.
+							result.Signal = globalEnsembles.Apply(sc, result.Signal, logger)
+							// #4966: a position held past max_holding_hours is closed whatever
+							// the script says; the close passes the entry holds below.
+							if why := agedExit.apply(result.Symbol, &result.Signal, &result.CloseFraction); why != "" {
+								notifyAgedPositionExit(sc, why, notifier, logger)
+							}
 							if gateRegime, regimeBlocked := applyRegimeGate(sc, storeRegime, cfg.Regime, tsContracts); regimeBlocked {
 								logger.Info("Regime gate: open signal blocked (%s)", regimeGateBlockDetail(gateRegime))
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "regime_gate")
 							}
 							// #1150: paused — hold position-increasing signals (fresh open, add,
 							// flip); position-reducing actions pass so open positions ride their
@@ -2623,12 +3339,14 @@ func main() {
 							if sc.Paused && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
 								logger.Info("Paused: %s signal suppressed — position-increasing actions held while paused (#1150)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "paused")
 							}
 							// #1269: daily loss limit tripped — identical hold semantics to pause:
 							// position-increasing signals held, position-reducing actions pass.
 							if dailyLossEntriesHeld && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
-								logger.Info("Daily loss limit: %s signal suppressed — entries held until UTC rollover (#1269)", signalStr)
+								logger.Info("Daily loss limit: %s signal suppressed — entries held until the daily reset (#1269)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "daily_loss_limit")
 							}
 							// #1344: portfolio notional cap — gross notional includes futures
 							// (unlike #1270's crypto-only bucket), so TopStep is gated too.
@@ -2636,6 +3354,60 @@ func main() {
 							if notionalBlocked && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
 								logger.Warn("Notional cap: %s signal suppressed — new opens blocked, exits continue (#1344)", signalStr)
 								result.Signal = 0
+								signalHistory.Block(sc.ID, "notional_cap")
+							}
+							// platforms.<name>.risk: platform drawdown/notional limit breached —
+							// same hold semantics as the notional cap.
+							if why := platformRiskHoldReason(platformRiskStatus, sc.Platform); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
+								logger.Warn("Platform risk: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "platform_risk")
+							}
+							// Per-strategy max_notional_usd / max_positions caps.
+							if why := strategyLimits.holdReason(tsContracts); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
+								logger.Warn("Strategy cap: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "strategy_cap")
+							}
+							// portfolio_risk.max_var_pct: historical VaR over the limit.
+							if varHoldReason != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
+								logger.Warn("VaR limit: %s signal suppressed — %s", signalStr, varHoldReason)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "var_limit")
+							}
+							// #4944: frozen price / stale candles — alert, and with
+							// stale_data.block_entries hold position-increasing signals only.
+							if why := observeStaleData(cfg.StaleData, sc, price, result.BarTime, result.Timeframe, notifier, logger); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
+								logger.Warn("Stale data: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_data")
+							}
+							// #4960: a price that jumped past price_sanity.max_move_pct since the
+							// previous cycle is treated as a bad print — refuse any trade on it.
+							if why := observePriceSanity(cfg.PriceSanity, sc, price, notifier, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price sanity: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_sanity")
+							}
+							// #4961: the check price must agree with the cross-source reference.
+							if why := crossSourceHoldReason(cfg.PriceValidation, sc, price, logger); why != "" && result.Signal != 0 {
+								logger.Warn("Price validation: %s signal refused — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "price_divergence")
+							}
+							// #4962: a signal computed from candles that closed more than a
+							// timeframe ago (exchange outage, ccxt cache) is rejected outright.
+							if why := staleCandleReason(result.BarTime, result.Timeframe, time.Now().UTC()); why != "" && result.Signal != 0 {
+								logger.Warn("Stale data: %s signal rejected — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "stale_candle")
+							}
+							// #4965: an identical position-increasing signal within
+							// signal_dedup_minutes of the last executed one is churn.
+							if why := duplicateSignalReason(sc, result.Symbol, result.Signal); why != "" && pausedBlocksSignal(result.Signal, result.CloseFraction, tsContracts, tsPosSide, true, true) {
+								logger.Info("Duplicate signal: %s signal suppressed — %s", signalStr, why)
+								result.Signal = 0
+								signalHistory.Block(sc.ID, "duplicate_signal")
 							}
 							// #1270: deliberately NOT gated by the same-direction exposure
 							// cap — CME futures are outside the phase-1 crypto bucket
@@ -2656,7 +3428,12 @@ func main() {
 							if !liveExecFailed {
 								mu.Lock()
 								trades, detail = executeTopStepResult(sc, stratState, stateDB, result, execResult, signalStr, price, cfg.Regime, cfg, logger)
+								signalHistory.Finish(sc.ID, result.Signal, trades)
+								recordExecutedSignal(sc, result.Symbol, result.Signal, trades)
 								mu.Unlock()
+								if execResult != nil {
+									ackOrderIntents(sc.ID, result.Symbol, logger)
+								}
 							}
 						}
 					case "manual":
@@ -2819,7 +3596,7 @@ func main() {
 								}
 								execResult, execStderr, execErr := RunHyperliquidExecute(
 									sc.Script, sc.Symbol, closeSide, closeQty,
-									0, cancelOID, 0, "", 0, closeFullPosition, hlExecuteSnapshot{}, extraCancelOIDs...,
+									0, cancelOID, 0, "", 0, closeFullPosition, hlExecuteSnapshot{}, "", extraCancelOIDs...,
 								)
 								if execStderr != "" {
 									logger.Info("HL manual close stderr: %s", execStderr)
@@ -2879,19 +3656,36 @@ func main() {
 					default:
 						logger.Error("Unknown strategy type: %s", sc.Type)
 					}
+					cycleTimer.recordSubprocess(sc.ID, time.Since(dispatchStart))
+					// #4967: exit_at closes spot positions at the scheduled slot,
+					// whatever the strategy signalled (or if its check failed).
+					if n, exitDetail := runScheduledExit(sc, stratState, prices, &mu, time.Now().UTC(), logger); n > 0 {
+						trades += n
+						if detail != "" {
+							exitDetail = detail + "\n" + exitDetail
+						}
+						detail = exitDetail
+					}
 					if trades > 0 && detail != "" {
 						if chKey := notifier.resolveChannelKey(sc.Platform, sc.Type); chKey != "" {
 							channelTrades[chKey] += trades
 							key := chKey + "|" + extractAsset(sc)
 							channelTradeDetails[key] = append(channelTradeDetails[key], detail)
 						}
-						// DM trade alerts (Discord + Telegram)
-						sendTradeAlerts(sc, stratState, trades, &mu, notifier)
+						// DM trade alerts (Discord + Telegram); skipped when muted (#4951).
+						sendTradeAlertsUnlessMuted(sc, stratState, trades, state, &mu, notifier)
 					}
 
 					totalTrades += trades
 
+					// #4917: this strategy's Phase 4 mutations are complete —
+					// journal them before moving on.
+					mu.RLock()
+					flushStateWAL()
+					mu.RUnlock()
+
 					// Phase 5: mark option positions with live prices (platform-aware).
+					markStart := time.Now()
 					mu.RLock()
 					markReqs := collectMarkRequests(stratState)
 					mu.RUnlock()
@@ -2907,6 +3701,7 @@ func main() {
 						applyMarkResults(stratState, markResults, logger)
 						mu.Unlock()
 					}
+					cycleTimer.recordMarking(sc.ID, time.Since(markStart))
 
 					// Phase 6: RLock — status log
 					mu.RLock()
@@ -2932,7 +3727,8 @@ func main() {
 					logger.Info("%s", statusLine)
 
 					logger.Close()
-					lastRun[sc.ID] = time.Now()
+					sched.MarkRan(sc.ID, cycleStart)
+					cycleTimer.recordStrategyTotal(sc.ID, time.Since(strategyStart))
 				}
 			} // end if !killSwitchFired
 		}
@@ -2994,8 +3790,11 @@ func main() {
 		lifetimeStats := loadLifetimeStatsBestEffort(stateDB, "[summary]")
 
 		// Notification — one message per channel per asset, sent to all backends.
+		// #4920: messages are formatted under RLock, then queued for delivery
+		// after release so a slow Discord/Telegram call never holds mu.
 		if notifier.HasBackends() {
 			summaryNow := time.Now().UTC()
+			var summaryMsgs []pendingChannelSummary
 			mu.RLock()
 			for chKey, chStrats := range channelStrats {
 				// Only post if at least one due strategy maps to this channel key.
@@ -3016,9 +3815,22 @@ func main() {
 				// Trades always force a post so operators see executions
 				// immediately regardless of cadence.
 				continuous := isOptionsType(chStrats) || isFuturesType(chStrats) || isPerpsType(chStrats)
-				if !ShouldPostSummary(cfg.SummaryFrequency[chKey], continuous, chTrades > 0, lastSummaryPost[chKey], summaryNow) {
+				// Quiet hours (#4950): trade-free summaries are held inside the
+				// window; the channel's first run after it posts a catch-up
+				// regardless of cadence.
+				inQuiet := cfg.QuietHours.active(summaryNow)
+				catchUp := !inQuiet && quietHours.Pending(chKey)
+				if !catchUp && !ShouldPostSummary(cfg.SummaryFrequency[chKey], continuous, chTrades > 0, lastSummaryPost[chKey], summaryNow) {
+					continue
+				}
+				if inQuiet && chTrades == 0 {
+					quietHours.Hold(chKey, summaryNow)
+					lastSummaryPost[chKey] = summaryNow
 					continue
 				}
+				if catchUp {
+					summaryMsgs = append(summaryMsgs, pendingChannelSummary{chKey: chKey, content: quietHours.Release(chKey, cfg.QuietHours)})
+				}
 				assetGroups, assetKeys := groupByAsset(chStrats)
 				if len(assetKeys) <= 1 {
 					// Single asset (or none) → backwards-compatible single message without asset label.
@@ -3032,9 +3844,9 @@ func main() {
 					// reconciles with the per-strategy rows (#918).
 					chAdj, _ := computeSubsetDisplayValue(chStrats, state, prices, walletBalances, sharedWallets)
 					chSharpe := aggregateSharpe(closedByStrategy, chStrats, state, rfr)
-					msgs := FormatCategorySummary(cycle, elapsed, len(dueStrategies), chTrades, chAdj, prices, chDetails, chStrats, state, chKey, "", cfg.IntervalSeconds, chSharpe, lifetimeStats, cfg.Regime)
+					msgs := FormatCategorySummary(cycle, elapsed, len(dueStrategies), chTrades, chAdj, prices, chDetails, chStrats, state, chKey, "", cfg.IntervalSeconds, chSharpe, lifetimeStats, cfg.Regime, resolveSummaryLayout(cfg.SummaryLayout, chKey))
 					for _, msg := range msgs {
-						notifier.SendToChannel(chKey, chKey, msg)
+						summaryMsgs = append(summaryMsgs, pendingChannelSummary{chKey: chKey, content: msg})
 					}
 				} else {
 					// Multiple assets → one message per asset.
@@ -3048,15 +3860,18 @@ func main() {
 						assetAdj, _ := computeSubsetDisplayValue(assetStrats, state, prices, walletBalances, sharedWallets)
 						assetTrades := len(assetDetails)
 						assetSharpe := aggregateSharpe(closedByStrategy, assetStrats, state, rfr)
-						msgs := FormatCategorySummary(cycle, elapsed, len(dueStrategies), assetTrades, assetAdj, prices, assetDetails, assetStrats, state, chKey, asset, cfg.IntervalSeconds, assetSharpe, lifetimeStats, cfg.Regime)
+						msgs := FormatCategorySummary(cycle, elapsed, len(dueStrategies), assetTrades, assetAdj, prices, assetDetails, assetStrats, state, chKey, asset, cfg.IntervalSeconds, assetSharpe, lifetimeStats, cfg.Regime, resolveSummaryLayout(cfg.SummaryLayout, chKey))
 						for _, msg := range msgs {
-							notifier.SendToChannel(chKey, chKey, msg)
+							summaryMsgs = append(summaryMsgs, pendingChannelSummary{chKey: chKey, content: msg})
 						}
 					}
 				}
 				lastSummaryPost[chKey] = summaryNow
 			}
 			mu.RUnlock()
+			for _, m := range summaryMsgs {
+				notifier.SendToChannelAsync(m.chKey, m.chKey, m.content)
+			}
 		}
 
 		// Save state after each cycle
@@ -3072,11 +3887,36 @@ func main() {
 			duePending = collectDueLeaderboardSummaries(cfg, state, prices, ComputeSharpeByStrategy(closedByStrategy, cfg, state), lifetimeStats, walletBalances, sharedWallets)
 		}
 
+		// #4927: roll today's benchmark point before the save persists it.
+		recordBenchmarkPoints(cfg.Strategies, state, prices, state.LastCycle)
+
+		// #4925: periodic capital rebalance. Persists its own transfer rows
+		// and baselines first; the save below then writes the new cash.
+		if msg, err := allocator.MaybeRun(cfg, state, stateDB, closedByStrategy, time.Now().UTC()); err != nil {
+			fmt.Printf("[ERROR] capital allocator: %v\n", err)
+		} else if msg != "" {
+			fmt.Printf("[allocator] %s\n", msg)
+		}
+
+		saveStart := time.Now()
 		if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
 			saveFailures++
 			fmt.Printf("[CRITICAL] Save state failed (%d/3): %v\n", saveFailures, err)
+			cycleFailure = "state save failed"
 		} else {
 			saveFailures = 0
+			maybeRotateStateBackups(stateDB, cfg.DBFile, time.Now())
+		}
+		// The finished timing lands in memory now (visible on /status and
+		// /metrics immediately) and is persisted by the next cycle's save.
+		cycleTiming := cycleTimer.finish(time.Since(cycleStart), time.Since(saveStart), shortestStrategyInterval(dueStrategies, intervals))
+		appendCycleTiming(state, cycleTiming)
+		if cycleTiming.Slow {
+			fmt.Printf("[WARN] %s\n", formatSlowCycleWarning(cycleTiming))
+		}
+		// #4940: per-cycle call latency (the histograms keep the totals).
+		if line := callLatency.CycleSummary(); line != "" {
+			fmt.Printf("[latency] %s\n", line)
 		}
 
 		// #175: Decide whether to auto-post daily leaderboard (check inside lock).
@@ -3090,7 +3930,38 @@ func main() {
 				postLeaderboard = true
 			}
 		}
+		// #4955: weekly digest due check, same stamp-after-post discipline.
+		postDigest := cfg.WeeklyDigest.due(time.Now(), state.LastWeeklyDigestDate) && notifier.HasBackends()
+		sheetsCfg := cloneGoogleSheetsConfig(cfg.GoogleSheets)
+		var sheetsEquity SheetsEquityRow
+		if sheetsCfg.enabled() {
+			sheetsEquity = buildSheetsEquityRow(cfg.Strategies, state, prices, time.Now().UTC())
+		}
 		mu.Unlock()
+		// #4919: refresh the readers' fallback copy with the saved cycle.
+		server.publishStateSnapshot()
+
+		// External dead-man's-switch ping, off the main loop so a slow monitor
+		// never delays trading; synchronous on --once so it lands before exit.
+		if *once {
+			healthPinger.Ping(cfg.Healthcheck, cycleFailure)
+		} else {
+			go healthPinger.Ping(cfg.Healthcheck, cycleFailure)
+		}
+		// #4983: a completed cycle feeds the systemd watchdog; the status line
+		// shows in `systemctl status`.
+		watchdog.CycleFinished(cycleFailure)
+		if cycleFailure == "" {
+			sdNotifyLogged(fmt.Sprintf("STATUS=cycle %d ok at %s", cycle, time.Now().UTC().Format("15:04:05 UTC")))
+		} else {
+			sdNotifyLogged(fmt.Sprintf("STATUS=cycle %d failed: %s", cycle, cycleFailure))
+		}
+		// #4936: Google Sheets export, same off-loop/--once discipline.
+		if *once {
+			sheetsExport.Export(sheetsCfg, stateDB, sheetsEquity)
+		} else {
+			go sheetsExport.Export(sheetsCfg, stateDB, sheetsEquity)
+		}
 
 		// Post any configurable leaderboard summaries (#308) outside the lock.
 		for _, p := range duePending {
@@ -3143,6 +4014,28 @@ func main() {
 			}
 		}
 
+		if postDigest {
+			mu.RLock()
+			digest := buildWeeklyDigest(weeklyDigestInput{cfg: cfg, state: state, db: stateDB, shadow: shadowTwins, prices: prices, now: time.Now().UTC()})
+			mu.RUnlock()
+			var postErr error
+			if digest == "" {
+				fmt.Println("[digest] Weekly digest skipped: nothing to report")
+			} else if postErr = postWeeklyDigest(cfg.WeeklyDigest, digest, notifier); postErr != nil {
+				fmt.Printf("[WARN] Weekly digest post failed: %v\n", postErr)
+			} else {
+				fmt.Println("[digest] Posted weekly digest")
+			}
+			if postErr == nil {
+				mu.Lock()
+				state.LastWeeklyDigestDate = time.Now().UTC().Format("2006-01-02")
+				if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
+					fmt.Printf("[WARN] Weekly digest post-date save failed: %v\n", err)
+				}
+				mu.Unlock()
+			}
+		}
+
 		// Periodic update check (heartbeat: every cycle; daily: once per
 		// 24h wall-clock — was cycle-based, broke when schedulerDelay
 		// became variable, see lastAutoUpdateCheck above).
@@ -3154,28 +4047,44 @@ func main() {
 		}
 
 		if *once {
+			// #4939: nothing scrapes a --once run; push its metrics instead.
+			if cfg.Pushgateway.enabled() {
+				mu.RLock()
+				body := renderPushgatewayMetrics(state, cycleFailure)
+				mu.RUnlock()
+				if err := pushMetrics(cfg.Pushgateway, body); err != nil {
+					fmt.Printf("[WARN] %v\n", err)
+				} else {
+					fmt.Printf("[pushgateway] Pushed metrics (job=%s)\n", cfg.Pushgateway.job())
+				}
+			}
 			fmt.Println("--once flag set, exiting after single cycle.")
 			return
 		}
 
-		// Wait for next tick or shutdown. Recompute intervals here under
-		// RLock — drawdown state may have changed during the cycle, so
-		// re-evaluating ensures a strategy that just entered (or exited)
-		// the warning band gets the fast (or slow) cadence immediately.
+		// Wait for the next strategy tick or shutdown. Re-sync intervals here
+		// under RLock — drawdown state may have changed during the cycle, so
+		// a strategy that just entered (or exited) the warning band gets the
+		// fast (or slow) cadence immediately.
 		mu.RLock()
 		endIntervals := effectiveStrategyIntervals(cfg.Strategies, state.Strategies, cfg.IntervalSeconds, drawdownWarnThresholdPct)
 		mu.RUnlock()
-		delay := schedulerDelay(cfg.Strategies, endIntervals, lastRun, cfg.IntervalSeconds, time.Now(), tickSeconds)
-		timer := time.NewTimer(delay)
+		sched.Sync(cfg.Strategies, endIntervals, time.Now())
+		watchdog.Idle()
+		waitCh, stopWait := sched.waitChannel()
 		select {
-		case <-timer.C:
-			// Next tick
+		case <-waitCh:
+			// Next strategy due
 		case <-reloadCh:
-			timer.Stop()
+			stopWait()
 			reloadConfig()
 			processConfigReloads()
+		case <-rotateCh:
+			stopWait()
+			rotateCreds()
+			processConfigReloads()
 		case <-stopCh:
-			timer.Stop()
+			stopWait()
 			fmt.Println("[shutdown] exiting trading loop.")
 			return
 		}
@@ -3254,7 +4163,7 @@ func runSummaryAndExit(channelKey string, cfg *Config, state *AppState, sdb *Sta
 	if len(assetKeys) <= 1 {
 		chAdj, _ := computeSubsetDisplayValue(chStrats, state, prices, summaryWalletBalances, summaryAccountShared)
 		chSharpe := aggregateSharpe(closedByStrategy, chStrats, state, rfr)
-		msgs := FormatCategorySummary(state.CycleCount, 0, 0, 0, chAdj, prices, nil, chStrats, state, channelKey, "", cfg.IntervalSeconds, chSharpe, lifetimeStats, cfg.Regime)
+		msgs := FormatCategorySummary(state.CycleCount, 0, 0, 0, chAdj, prices, nil, chStrats, state, channelKey, "", cfg.IntervalSeconds, chSharpe, lifetimeStats, cfg.Regime, resolveSummaryLayout(cfg.SummaryLayout, channelKey))
 		for _, msg := range msgs {
 			notifier.SendToChannel(channelKey, channelKey, msg)
 			fmt.Println(msg)
@@ -3264,7 +4173,7 @@ func runSummaryAndExit(channelKey string, cfg *Config, state *AppState, sdb *Sta
 			assetStrats := assetGroups[asset]
 			assetAdj, _ := computeSubsetDisplayValue(assetStrats, state, prices, summaryWalletBalances, summaryAccountShared)
 			assetSharpe := aggregateSharpe(closedByStrategy, assetStrats, state, rfr)
-			msgs := FormatCategorySummary(state.CycleCount, 0, 0, 0, assetAdj, prices, nil, assetStrats, state, channelKey, asset, cfg.IntervalSeconds, assetSharpe, lifetimeStats, cfg.Regime)
+			msgs := FormatCategorySummary(state.CycleCount, 0, 0, 0, assetAdj, prices, nil, assetStrats, state, channelKey, asset, cfg.IntervalSeconds, assetSharpe, lifetimeStats, cfg.Regime, resolveSummaryLayout(cfg.SummaryLayout, channelKey))
 			for _, msg := range msgs {
 				notifier.SendToChannel(channelKey, channelKey, msg)
 				fmt.Println(msg)
@@ -3295,6 +4204,7 @@ func runSpotCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionC
 	args = appendRegimeArgs(args, regime)
 	args = appendStrategyRegimeWindowArgs(args, sc, regime)
 	args = appendRegimePayloadArg(args, sc, regime)
+	args = appendMarketRegimeArg(args, sc)
 	args = appendATRMethodArg(args, atrMethod)
 	if refsArgs, err := buildStrategyRefsArg(sc); err != nil {
 		logger.Warn("Failed to marshal strategy refs: %v", err)
@@ -3303,13 +4213,13 @@ func runSpotCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionC
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunSpotCheck(sc.Script, args)
+	result, stderr, err := RunSpotCheck(sc.Script, args, strategyScriptEnv(sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, sc, scriptCrashMode(err), err.Error())
 		return nil, "", 0, false
 	}
 	if stderr != "" {
@@ -3329,7 +4239,7 @@ func runSpotCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionC
 	} else if result.Signal == -1 {
 		signalStr = "SELL"
 	}
-	logger.Info("Signal: %s | %s @ $%.2f", signalStr, result.Symbol, result.Price)
+	logger.Output("Signal: %s | %s @ $%.2f", signalStr, result.Symbol, result.Price)
 
 	// Use script price, fallback to fetched price
 	price := result.Price
@@ -3344,12 +4254,41 @@ func runSpotCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionC
 		return nil, "", 0, false
 	}
 
+	logCycleIndicators(sc.ID, result.Symbol, result.Signal, price, result.Indicators, logger)
+	signalHistory.Begin(sc.ID, result.Symbol, result.Signal, price)
 	return result, signalStr, price, true
 }
 
 // executeSpotResult applies a spot signal to state. Must be called under Lock.
 func executeSpotResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *SpotResult, signalStr string, price float64, regime *RegimeConfig, cfg *Config, logger *StrategyLogger) (int, string) {
-	exec, err := ExecuteSpotSignalWithFillFeeDeferredOpen(s, result.Signal, result.Symbol, price, 0, 0, "", result.CloseFraction, logger)
+	// #4952: trades booked from this signal carry its indicator snapshot.
+	defer stageTradeIndicators(s, result.Indicators)()
+	// allow_short: charge borrow on an open paper short before any close, and
+	// route a SELL from flat to the paper-short opener.
+	accrueSpotShortBorrow(sc, s, result.Symbol, price, time.Now().UTC())
+	var exec SignalExecutionResult
+	var err error
+	sizeFraction := resolveSizeFraction(sc, result.StrategyDecisionFields)
+	if limitExec, handled := routeSpotPaperLimitEntry(sc, s, result, price, sizeFraction, logger); handled {
+		// paper_limit_entries: the entry rests (or just filled) on the
+		// simulated book instead of filling at the cycle price.
+		exec = limitExec
+	} else if sc.AllowShort && spotPaperShortOpens(s, result.Signal, result.Symbol, result.CloseFraction) {
+		exec, err = ExecuteSpotPaperShortDeferredOpen(s, result.Symbol, price, sizeFraction, logger)
+	} else if dcaBuyApplies(sc, s, result.Signal, result.Symbol, result.CloseFraction) {
+		exec, err = ExecuteSpotDCABuyDeferredOpen(s, sc.DCA, result.Symbol, price, sizeFraction, logger)
+	} else if pos := s.Positions[result.Symbol]; sc.AllowScaleIn && result.Signal == 1 && result.CloseFraction == 0 && pos != nil && pos.Side == "long" {
+		// allow_scale_in: pyramid into the open long instead of skipping
+		// "already long" when the scale_in gates pass.
+		if usd, why := spotScaleInUSD(sc, pos, s.Cash, price, sizeFraction); usd > 0 {
+			applySpotScaleIn(s, pos, result.Symbol, usd, ApplySlippage(price), "Scale-in", logger)
+			exec.TradesExecuted = 1
+		} else {
+			logger.Info("Scale-in: BUY %s skipped — %s", result.Symbol, why)
+		}
+	} else {
+		exec, err = ExecuteSpotPaperSignalSizedDeferredOpen(s, result.Signal, result.Symbol, price, sizeFraction, result.CloseFraction, logger)
+	}
 	if err != nil {
 		logger.Error("Trade execution failed: %v", err)
 		return 0, ""
@@ -3366,7 +4305,7 @@ func executeSpotResult(sc StrategyConfig, s *StrategyState, db *StateDB, result
 
 	detail := ""
 	if trades > 0 {
-		detail = fmt.Sprintf("[%s] %s %s @ $%.2f", sc.ID, signalStr, result.Symbol, price)
+		detail = fmt.Sprintf("[%s] %s %s @ $%.2f", sc.ID, signalStr, result.Symbol, price) + formatTradeIndicators(s.pendingIndicators)
 	}
 	return trades, detail
 }
@@ -3446,13 +4385,13 @@ func runOptionsCheck(sc StrategyConfig, posJSON string, notifier *MultiNotifier,
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunOptionsCheckWithStdin(sc.Script, args, posJSON)
+	result, stderr, err := RunOptionsCheckWithStdin(sc.Script, args, posJSON, strategyScriptEnv(sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, sc, scriptCrashMode(err), err.Error())
 		return nil, "", false
 	}
 	if stderr != "" {
@@ -3472,8 +4411,9 @@ func runOptionsCheck(sc StrategyConfig, posJSON string, notifier *MultiNotifier,
 	} else if result.Signal == -1 {
 		signalStr = "BEARISH"
 	}
-	logger.Info("Signal: %s | %s spot=$%.2f | IV rank=%.1f | %d actions",
+	logger.Output("Signal: %s | %s spot=$%.2f | IV rank=%.1f | %d actions",
 		signalStr, result.Underlying, result.SpotPrice, result.IVRank, len(result.Actions))
+	signalHistory.Begin(sc.ID, result.Underlying, result.Signal, result.SpotPrice)
 
 	return result, signalStr, true
 }
@@ -3492,11 +4432,20 @@ func executeOptionsResult(sc StrategyConfig, s *StrategyState, result *OptionsRe
 		detail = fmt.Sprintf("[%s] %s %s spot=$%.2f IV=%.1f", sc.ID, signalStr, result.Underlying, result.SpotPrice, result.IVRank)
 	}
 
-	var harvestDetails []string
-	if sc.ThetaHarvest != nil {
-		harvestTrades, hDetails := CheckThetaHarvest(s, sc.ThetaHarvest, logger)
-		trades += harvestTrades
-		harvestDetails = hDetails
+	// Runs even without a theta_harvest block: a per-position override
+	// (#4970) can enable harvesting for a single leg.
+	harvestTrades, harvestDetails := CheckThetaHarvest(s, sc.ThetaHarvest, logger)
+	trades += harvestTrades
+	if sc.LongOptionExits != nil {
+		longTrades, lDetails := CheckLongOptionExits(s, sc.LongOptionExits, logger)
+		trades += longTrades
+		harvestDetails = append(harvestDetails, lDetails...)
+	}
+	// #4966: option legs held past max_holding_hours close at current value.
+	if sc.MaxHoldingHours > 0 {
+		agedTrades, agedDetails := closeAgedOptionPositions(s, sc.MaxHoldingHours, time.Now().UTC(), logger)
+		trades += agedTrades
+		harvestDetails = append(harvestDetails, agedDetails...)
 	}
 
 	return trades, detail, harvestDetails
@@ -3640,6 +4589,7 @@ func runHyperliquidCheck(sc *StrategyConfig, prices map[string]float64, posCtx P
 	args = appendRegimeArgs(args, regime)
 	args = appendStrategyRegimeWindowArgs(args, *sc, regime)
 	args = appendRegimePayloadArg(args, *sc, regime)
+	args = appendMarketRegimeArg(args, *sc)
 	args = appendATRMethodArg(args, atrMethod)
 	if refsArgs, err := buildStrategyRefsArg(scForCheck); err != nil {
 		logger.Warn("Failed to marshal strategy refs: %v", err)
@@ -3656,13 +4606,13 @@ func runHyperliquidCheck(sc *StrategyConfig, prices map[string]float64, posCtx P
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunHyperliquidCheck(sc.Script, args)
+	result, stderr, err := RunHyperliquidCheck(sc.Script, args, strategyScriptEnv(*sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, *sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, *sc, scriptCrashMode(err), err.Error())
 		return nil, "", 0, false
 	}
 	if stderr != "" {
@@ -3733,7 +4683,7 @@ func runHyperliquidCheck(sc *StrategyConfig, prices map[string]float64, posCtx P
 	applySignalInversion(*sc, result, logger)
 
 	signalStr := signalLabel(result.Signal)
-	logger.Info("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
+	logger.Output("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
 
 	price := result.Price
 	if price <= 0 {
@@ -3745,6 +4695,9 @@ func runHyperliquidCheck(sc *StrategyConfig, prices map[string]float64, posCtx P
 		logger.Error("No price available for %s", result.Symbol)
 		return nil, "", 0, false
 	}
+	logCycleIndicators(sc.ID, result.Symbol, result.Signal, price, result.Indicators, logger)
+	signalHistory.Begin(sc.ID, result.Symbol, result.Signal, price)
+	shadowTwins.Observe(*sc, result.Symbol, result.Signal, price, result.StrategyDecisionFields, result.Indicators)
 	return result, signalStr, price, true
 }
 
@@ -3821,7 +4774,7 @@ func runHyperliquidExecuteOrder(sc StrategyConfig, result *HyperliquidResult, pr
 	// distance (ATR owners read the check payload's indicators.atr — the same
 	// value stampEntryATRIfOpened later freezes, so sizing and SL geometry
 	// agree) and fails closed on fresh opens when the distance is unresolvable.
-	sizing := PerpsSizingFor(sc, price, indicatorsATRValue(result.Indicators))
+	sizing := PerpsSizingFor(sc, price, indicatorsATRValue(result.Indicators)).withSizeFraction(resolveSizeFraction(sc, result.StrategyDecisionFields))
 	size, ok, reason := perpsLiveOrderSize(result.Signal, price, cash, posQty, avgCost, sizing, posSide, directionEnum, result.CloseFraction)
 	if !ok {
 		logger.Info("%s for %s", reason, result.Symbol)
@@ -3866,6 +4819,25 @@ func runHyperliquidExecuteOrder(sc StrategyConfig, result *HyperliquidResult, pr
 	// is close-only, never a flip; the sizer's flip branch carries the same
 	// guard, so this mirror must too or prevPosQty diverges from the order size.
 	flipping := EffectiveDirection(sc) == DirectionBoth && posQty > 0 && result.CloseFraction == 0 && ((result.Signal == 1 && posSide == "short") || (result.Signal == -1 && posSide == "long"))
+	// #4914/#4915: round the opening leg to the symbol's lot size and skip it
+	// below the venue's minimum order notional, so HL never sees raw floats
+	// like size=0.0123456789 or a $3 order it will reject. The close leg of
+	// a flip and pure closes keep the exact position quantity — a skipped
+	// close would strand the position.
+	closing := posQty > 0 && ((result.Signal == 1 && posSide == "short") || (result.Signal == -1 && posSide == "long"))
+	if spec := liveSymbolSpecFor(sc.Platform, result.Symbol); !closing || flipping {
+		openQty := size
+		if flipping {
+			openQty = size - posQty
+		}
+		rounded, why := spec.openQty(openQty, price)
+		if why != "" {
+			logger.Warn("Skipping live %s %s: %s", side, result.Symbol, why)
+			notifyLiveOrderSkipped(notifier, sc, result.Symbol, why)
+			return nil, false
+		}
+		size += rounded - openQty
+	}
 	var cancelOID int64
 	if existingStopLossOID > 0 && posQty > 0 && !partialClose {
 		cancelOID = existingStopLossOID
@@ -3918,10 +4890,20 @@ func runHyperliquidExecuteOrder(sc StrategyConfig, result *HyperliquidResult, pr
 	} else if result.CloseFraction == 1.0 {
 		logger.Info("Final-tier close %s shares coin with HL perps peers — using sized close to preserve peer exposure", result.Symbol)
 	}
-	execResult, stderr, err := RunHyperliquidExecute(sc.Script, result.Symbol, side, size, slPct, cancelOID, prevPosQty, marginMode, leverageForOpen, closeFullPosition, walletSnapshot, extraCancelOIDs...)
-	if stderr != "" {
-		logger.Info("execute stderr: %s", stderr)
+	// #4916: journal the intent before the order so a crash between the fill
+	// and the Phase 4 state mutation is detected at the next startup.
+	attempt := func(size float64) (*HyperliquidExecuteResult, error) {
+		intentKey := beginOrderIntent(sc, result.Symbol, side, size, logger)
+		execResult, stderr, err := RunHyperliquidExecute(sc.Script, result.Symbol, side, size, slPct, cancelOID, prevPosQty, marginMode, leverageForOpen, closeFullPosition, walletSnapshot, orderIntentClientID(sc.Platform, intentKey), extraCancelOIDs...)
+		settleOrderIntent(intentKey, err, execResult, logger)
+		if stderr != "" {
+			logger.Info("execute stderr: %s", stderr)
+		}
+		return execResult, err
 	}
+	// #4958: rate-limit rejections retry with backoff; a pure open below the
+	// venue minimum is resized once. Flip and close legs are never resized.
+	execResult, size, err := retryHyperliquidExecute(attempt, size, price, liveSymbolSpecFor(sc.Platform, result.Symbol), !closing, logger)
 	// On failure, the Python script may still report cancel_stop_loss_succeeded
 	// — propagate execResult to the caller so the stale OID can be cleared
 	// even when the open leg fails (#421). Caller treats ok=false as "do not
@@ -3932,13 +4914,15 @@ func runHyperliquidExecuteOrder(sc StrategyConfig, result *HyperliquidResult, pr
 		direction = directionClose
 	}
 	if err != nil {
-		logger.Error("Live execute failed: %v", err)
-		notifyLiveExecFailure(notifier, sc, direction, result.Symbol, err.Error())
+		msg := liveExecFailureMessage(err.Error())
+		logger.Error("Live execute failed: %s", msg)
+		notifyLiveExecFailure(notifier, sc, direction, result.Symbol, msg)
 		return execResult, false
 	}
 	if execResult.Error != "" {
-		logger.Error("Live execute returned error: %s", execResult.Error)
-		notifyLiveExecFailure(notifier, sc, direction, result.Symbol, execResult.Error)
+		msg := liveExecFailureMessage(execResult.Error)
+		logger.Error("Live execute returned error: %s", msg)
+		notifyLiveExecFailure(notifier, sc, direction, result.Symbol, msg)
 		return execResult, false
 	}
 	clearLiveExecThrottle(sc, direction, result.Symbol)
@@ -3968,6 +4952,12 @@ func runHyperliquidExecuteOrder(sc StrategyConfig, result *HyperliquidResult, pr
 	if execResult.StopLossFilledImmediately {
 		logger.Warn("SL trigger filled at submit (price was already through the level) for %s — position is flat on-chain", result.Symbol)
 	}
+	if !vetHyperliquidFill(sc, execResult, result.Symbol, side, size, closeFullPosition, notifier, logger) {
+		return execResult, false
+	}
+	if execResult.Execution != nil && execResult.Execution.Fill != nil {
+		execResult.SlippageBreached = checkLiveFillSlippage(sc, result.Symbol, side, price, execResult.Execution.Fill.AvgPx, notifier, logger)
+	}
 	return execResult, true
 }
 
@@ -4006,6 +4996,8 @@ func executeHyperliquidResult(sc StrategyConfig, s *StrategyState, result *Hyper
 // nil for paper mode. Live open trades are returned so the caller can run
 // same-cycle protection sync before the single INSERT.
 func executeHyperliquidResultDeferredOpen(sc StrategyConfig, s *StrategyState, result *HyperliquidResult, execResult *HyperliquidExecuteResult, signalStr string, price float64, regime *RegimeConfig, cfg *Config, logger *StrategyLogger) (int, string, *Trade, *RatchetTriggerAlert) {
+	// #4952: trades booked from this signal carry its indicator snapshot.
+	defer stageTradeIndicators(s, result.Indicators)()
 	fillPrice := price
 	var fillQty float64
 	if execResult != nil && execResult.Execution != nil && execResult.Execution.Fill != nil && execResult.Execution.Fill.AvgPx > 0 {
@@ -4017,7 +5009,7 @@ func executeHyperliquidResultDeferredOpen(sc StrategyConfig, s *StrategyState, r
 	// #1268: sizing bundle resolved at the apply price; only consulted when
 	// this is a paper open (fillQty==0) — live orders were already sized in
 	// runHyperliquidExecuteOrder from the same config surface.
-	sizing := PerpsSizingFor(sc, fillPrice, indicatorsATRValue(result.Indicators))
+	sizing := PerpsSizingFor(sc, fillPrice, indicatorsATRValue(result.Indicators)).withSizeFraction(resolveSizeFraction(sc, result.StrategyDecisionFields))
 
 	// Thread exchange metadata into ExecutePerpsSignalWithLeverage so each Trade is built
 	// with the OID and fee before RecordTrade persists it (#289). Stamping the
@@ -4034,13 +5026,22 @@ func executeHyperliquidResultDeferredOpen(sc StrategyConfig, s *StrategyState, r
 		fillFee = fill.Fee
 	}
 
-	exec, err := ExecutePerpsSignalWithLeverageDeferredOpen(s, result.Signal, result.Symbol, fillPrice, sizing, fillQty, fillOID, fillFee, EffectiveDirection(sc), result.CloseFraction, logger)
+	// #4957: a close that only partly filled closes just the filled quantity.
+	closeFraction := partialFillCloseFraction(s.Positions[result.Symbol], result.Signal, result.CloseFraction, execResult)
+	if closeFraction != result.CloseFraction {
+		logger.Warn("Partial fill %.6f does not cover the %s position — booking a partial close", fillQty, result.Symbol)
+	}
+	exec, err := ExecutePerpsSignalWithLeverageDeferredOpen(s, result.Signal, result.Symbol, fillPrice, sizing, fillQty, fillOID, fillFee, EffectiveDirection(sc), closeFraction, logger)
 	if err != nil {
 		logger.Error("Trade execution failed: %v", err)
 		return 0, "", nil, nil
 	}
 	trades := exec.TradesExecuted
 	openTrade := exec.OpenTrade
+	if openTrade != nil && openTrade.Indicators == nil {
+		// The deferred open is recorded after the staged snapshot is cleared.
+		openTrade.Indicators = s.pendingIndicators
+	}
 	stampEntryATRIfOpened(s, result.Symbol, result.Indicators)
 	stampPositionRegimeIfOpened(s, result.Symbol, regimePayloadValue(result.Regime), sc, regime)
 	stampDirectionCertifiedAtOpenIfOpened(s, result.Symbol, openTrade != nil, sc, regime)
@@ -4118,7 +5119,7 @@ func executeHyperliquidResultDeferredOpen(sc StrategyConfig, s *StrategyState, r
 		if execResult != nil {
 			prefix = "LIVE "
 		}
-		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice)
+		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice) + formatTradeIndicators(s.pendingIndicators)
 	}
 	if execResult == nil {
 		var pos *Position
@@ -4157,6 +5158,7 @@ func runTopStepCheck(sc StrategyConfig, prices map[string]float64, posCtx Positi
 	args = appendRegimeArgs(args, regime)
 	args = appendStrategyRegimeWindowArgs(args, sc, regime)
 	args = appendRegimePayloadArg(args, sc, regime)
+	args = appendMarketRegimeArg(args, sc)
 	args = appendATRMethodArg(args, atrMethod)
 	if refsArgs, err := buildStrategyRefsArg(sc); err != nil {
 		logger.Warn("Failed to marshal strategy refs: %v", err)
@@ -4165,13 +5167,13 @@ func runTopStepCheck(sc StrategyConfig, prices map[string]float64, posCtx Positi
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunTopStepCheck(sc.Script, args)
+	result, stderr, err := RunTopStepCheck(sc.Script, args, strategyScriptEnv(sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, sc, scriptCrashMode(err), err.Error())
 		return nil, "", 0, false
 	}
 	if stderr != "" {
@@ -4195,7 +5197,7 @@ func runTopStepCheck(sc StrategyConfig, prices map[string]float64, posCtx Positi
 	} else if result.Signal == -1 {
 		signalStr = "SELL"
 	}
-	logger.Info("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
+	logger.Output("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
 
 	price := result.Price
 	if price <= 0 {
@@ -4207,6 +5209,8 @@ func runTopStepCheck(sc StrategyConfig, prices map[string]float64, posCtx Positi
 		logger.Error("No price available for %s", result.Symbol)
 		return nil, "", 0, false
 	}
+	logCycleIndicators(sc.ID, result.Symbol, result.Signal, price, result.Indicators, logger)
+	signalHistory.Begin(sc.ID, result.Symbol, result.Signal, price)
 	return result, signalStr, price, true
 }
 
@@ -4271,7 +5275,9 @@ func runTopStepExecuteOrder(sc StrategyConfig, result *TopStepResult, price, cas
 	}
 	logger.Info("Placing live %s %s contracts=%d", side, result.Symbol, contracts)
 
+	intentKey := beginOrderIntent(sc, result.Symbol, side, float64(contracts), logger)
 	execResult, stderr, err := RunTopStepExecute(sc.Script, result.Symbol, side, contracts)
+	settleOrderIntent(intentKey, err, execResult, logger)
 	if stderr != "" {
 		logger.Info("execute stderr: %s", stderr)
 	}
@@ -4290,11 +5296,16 @@ func runTopStepExecuteOrder(sc StrategyConfig, result *TopStepResult, price, cas
 		return nil, false
 	}
 	clearLiveExecThrottle(sc, direction, result.Symbol)
+	if execResult.Execution != nil && execResult.Execution.Fill != nil {
+		checkLiveFillSlippage(sc, result.Symbol, side, price, execResult.Execution.Fill.AvgPx, notifier, logger)
+	}
 	return execResult, true
 }
 
 // executeTopStepResult applies a TopStep futures result to state. Must be called under Lock.
 func executeTopStepResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *TopStepResult, execResult *TopStepExecuteResult, signalStr string, price float64, regime *RegimeConfig, cfg *Config, logger *StrategyLogger) (int, string) {
+	// #4952: trades booked from this signal carry its indicator snapshot.
+	defer stageTradeIndicators(s, result.Indicators)()
 	fillPrice := price
 	var fillContracts int
 	var fillFee float64
@@ -4335,7 +5346,7 @@ func executeTopStepResult(sc StrategyConfig, s *StrategyState, db *StateDB, resu
 		if execResult != nil {
 			prefix = "LIVE "
 		}
-		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice)
+		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice) + formatTradeIndicators(s.pendingIndicators)
 	}
 	return trades, detail
 }
@@ -4363,6 +5374,7 @@ func runRobinhoodCheck(sc StrategyConfig, prices map[string]float64, posCtx Posi
 	args = appendRegimeArgs(args, regime)
 	args = appendStrategyRegimeWindowArgs(args, sc, regime)
 	args = appendRegimePayloadArg(args, sc, regime)
+	args = appendMarketRegimeArg(args, sc)
 	args = appendATRMethodArg(args, atrMethod)
 	if refsArgs, err := buildStrategyRefsArg(sc); err != nil {
 		logger.Warn("Failed to marshal strategy refs: %v", err)
@@ -4371,13 +5383,13 @@ func runRobinhoodCheck(sc StrategyConfig, prices map[string]float64, posCtx Posi
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunRobinhoodCheck(sc.Script, args)
+	result, stderr, err := RunRobinhoodCheck(sc.Script, args, strategyScriptEnv(sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, sc, scriptCrashMode(err), err.Error())
 		return nil, "", 0, false
 	}
 	if stderr != "" {
@@ -4396,7 +5408,7 @@ func runRobinhoodCheck(sc StrategyConfig, prices map[string]float64, posCtx Posi
 	} else if result.Signal == -1 {
 		signalStr = "SELL"
 	}
-	logger.Info("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
+	logger.Output("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
 
 	price := result.Price
 	if price <= 0 {
@@ -4408,6 +5420,9 @@ func runRobinhoodCheck(sc StrategyConfig, prices map[string]float64, posCtx Posi
 		logger.Error("No price available for %s", result.Symbol)
 		return nil, "", 0, false
 	}
+	logCycleIndicators(sc.ID, result.Symbol, result.Signal, price, result.Indicators, logger)
+	signalHistory.Begin(sc.ID, result.Symbol, result.Signal, price)
+	shadowTwins.Observe(sc, result.Symbol, result.Signal, price, result.StrategyDecisionFields, result.Indicators)
 	return result, signalStr, price, true
 }
 
@@ -4464,7 +5479,13 @@ func runRobinhoodExecuteOrder(sc StrategyConfig, result *RobinhoodResult, price,
 
 	logger.Info("Placing live %s %s amount_usd=%.2f qty=%.6f", side, result.Symbol, amountUSD, quantity)
 
+	intentSize := quantity
+	if intentSize <= 0 && price > 0 {
+		intentSize = amountUSD / price
+	}
+	intentKey := beginOrderIntent(sc, result.Symbol, side, intentSize, logger)
 	execResult, stderr, err := robinhoodExecuteFn(sc.Script, result.Symbol, side, amountUSD, quantity)
+	settleOrderIntent(intentKey, err, execResult, logger)
 	if stderr != "" {
 		logger.Info("execute stderr: %s", stderr)
 	}
@@ -4483,6 +5504,9 @@ func runRobinhoodExecuteOrder(sc StrategyConfig, result *RobinhoodResult, price,
 		return nil, false
 	}
 	clearLiveExecThrottle(sc, direction, result.Symbol)
+	if execResult.Execution != nil && execResult.Execution.Fill != nil {
+		checkLiveFillSlippage(sc, result.Symbol, side, price, execResult.Execution.Fill.AvgPx, notifier, logger)
+	}
 	return execResult, true
 }
 
@@ -4490,6 +5514,8 @@ func runRobinhoodExecuteOrder(sc StrategyConfig, result *RobinhoodResult, price,
 // cashOverBudgetAlert is non-empty when a live spot buy was booked past virtual
 // cash (#1394); callers must notify AFTER releasing mu.
 func executeRobinhoodResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *RobinhoodResult, execResult *RobinhoodExecuteResult, signalStr string, price float64, regime *RegimeConfig, cfg *Config, logger *StrategyLogger) (int, string, string) {
+	// #4952: trades booked from this signal carry its indicator snapshot.
+	defer stageTradeIndicators(s, result.Indicators)()
 	fillPrice := price
 	var fillQty float64
 	var fillFee float64
@@ -4523,7 +5549,7 @@ func executeRobinhoodResult(sc StrategyConfig, s *StrategyState, db *StateDB, re
 		if execResult != nil {
 			prefix = "LIVE "
 		}
-		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice)
+		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice) + formatTradeIndicators(s.pendingIndicators)
 	}
 	cashAlert := ""
 	if exec.CashReconcileRequired {
@@ -4565,6 +5591,7 @@ func runOKXCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionCt
 	args = appendRegimeArgs(args, regime)
 	args = appendStrategyRegimeWindowArgs(args, sc, regime)
 	args = appendRegimePayloadArg(args, sc, regime)
+	args = appendMarketRegimeArg(args, sc)
 	args = appendATRMethodArg(args, atrMethod)
 	if refsArgs, err := buildStrategyRefsArg(sc); err != nil {
 		logger.Warn("Failed to marshal strategy refs: %v", err)
@@ -4573,13 +5600,13 @@ func runOKXCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionCt
 	}
 	logger.Info("Running: python3 %s %v", sc.Script, args)
 
-	result, stderr, err := RunOKXCheck(sc.Script, args)
+	result, stderr, err := RunOKXCheck(sc.Script, args, strategyScriptEnv(sc))
 	if err != nil {
 		logger.Error("Script failed: %v", err)
 		if stderr != "" {
 			logger.Error("stderr: %s", stderr)
 		}
-		notifyScriptFailure(notifier, sc, scriptFailureCrash, err.Error())
+		notifyScriptFailure(notifier, sc, scriptCrashMode(err), err.Error())
 		return nil, "", 0, false
 	}
 	if stderr != "" {
@@ -4598,7 +5625,7 @@ func runOKXCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionCt
 	} else if result.Signal == -1 {
 		signalStr = "SELL"
 	}
-	logger.Info("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
+	logger.Output("Signal: %s | %s @ $%.2f [%s]", signalStr, result.Symbol, result.Price, result.Mode)
 
 	price := result.Price
 	if price <= 0 {
@@ -4610,6 +5637,9 @@ func runOKXCheck(sc StrategyConfig, prices map[string]float64, posCtx PositionCt
 		logger.Error("No price available for %s", result.Symbol)
 		return nil, "", 0, false
 	}
+	logCycleIndicators(sc.ID, result.Symbol, result.Signal, price, result.Indicators, logger)
+	signalHistory.Begin(sc.ID, result.Symbol, result.Signal, price)
+	shadowTwins.Observe(sc, result.Symbol, result.Signal, price, result.StrategyDecisionFields, result.Indicators)
 	return result, signalStr, price, true
 }
 
@@ -4646,7 +5676,7 @@ func runOKXExecuteOrder(sc StrategyConfig, result *OKXResult, price, cash float6
 	// hardcoded 0.95 safety buffer. risk_per_trade_pct (#1268) is HL-only, so
 	// PerpsSizingFor resolves zero risk fields here (validation rejects it on
 	// OKX at load).
-	sizing := PerpsSizingFor(sc, price, indicatorsATRValue(result.Indicators))
+	sizing := PerpsSizingFor(sc, price, indicatorsATRValue(result.Indicators)).withSizeFraction(resolveSizeFraction(sc, result.StrategyDecisionFields))
 	var size float64
 	if sc.Type == "perps" {
 		var ok bool
@@ -4684,10 +5714,23 @@ func runOKXExecuteOrder(sc StrategyConfig, result *OKXResult, price, cash float6
 	if !isBuy {
 		side = "sell"
 	}
+	// #4915: fresh opens below the venue's minimum order notional are skipped
+	// before the executor call. Closes and flips keep the exact size.
+	if (sc.Type != "perps" && isBuy) || (sc.Type == "perps" && posQty == 0) {
+		rounded, why := liveSymbolSpecFor(sc.Platform, result.Symbol).openQty(size, price)
+		if why != "" {
+			logger.Warn("Skipping live %s %s: %s", side, result.Symbol, why)
+			notifyLiveOrderSkipped(notifier, sc, result.Symbol, why)
+			return nil, false
+		}
+		size = rounded
+	}
 	instType := okxInstType(sc.Args)
 	logger.Info("Placing live %s %s size=%.6f inst_type=%s", side, result.Symbol, size, instType)
 
-	execResult, stderr, err := okxExecuteFn(sc.Script, result.Symbol, side, size, instType)
+	intentKey := beginOrderIntent(sc, result.Symbol, side, size, logger)
+	execResult, stderr, err := okxExecuteFn(sc.Script, result.Symbol, side, size, instType, orderIntentClientID(sc.Platform, intentKey))
+	settleOrderIntent(intentKey, err, execResult, logger)
 	if stderr != "" {
 		logger.Info("execute stderr: %s", stderr)
 	}
@@ -4706,6 +5749,9 @@ func runOKXExecuteOrder(sc StrategyConfig, result *OKXResult, price, cash float6
 		return nil, false
 	}
 	clearLiveExecThrottle(sc, direction, result.Symbol)
+	if execResult.Execution != nil && execResult.Execution.Fill != nil {
+		checkLiveFillSlippage(sc, result.Symbol, side, price, execResult.Execution.Fill.AvgPx, notifier, logger)
+	}
 	return execResult, true
 }
 
@@ -4713,6 +5759,8 @@ func runOKXExecuteOrder(sc StrategyConfig, result *OKXResult, price, cash float6
 // cashOverBudgetAlert is non-empty when a live spot buy was booked past virtual
 // cash (#1394); callers must notify AFTER releasing mu. Perps never set it.
 func executeOKXResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *OKXResult, execResult *OKXExecuteResult, signalStr string, price float64, regime *RegimeConfig, cfg *Config, logger *StrategyLogger) (int, string, string) {
+	// #4952: trades booked from this signal carry its indicator snapshot.
+	defer stageTradeIndicators(s, result.Indicators)()
 	fillPrice := price
 	var fillQty float64
 	var fillFee float64
@@ -4733,7 +5781,7 @@ func executeOKXResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *
 	var exec SignalExecutionResult
 	var err error
 	if sc.Type == "perps" {
-		exec, err = ExecutePerpsSignalWithLeverageDeferredOpen(s, result.Signal, result.Symbol, fillPrice, PerpsSizingFor(sc, fillPrice, indicatorsATRValue(result.Indicators)), fillQty, fillOID, fillFee, EffectiveDirection(sc), result.CloseFraction, logger)
+		exec, err = ExecutePerpsSignalWithLeverageDeferredOpen(s, result.Signal, result.Symbol, fillPrice, PerpsSizingFor(sc, fillPrice, indicatorsATRValue(result.Indicators)).withSizeFraction(resolveSizeFraction(sc, result.StrategyDecisionFields)), fillQty, fillOID, fillFee, EffectiveDirection(sc), result.CloseFraction, logger)
 	} else {
 		exec, err = ExecuteSpotSignalWithFillFeeDeferredOpen(s, result.Signal, result.Symbol, fillPrice, fillQty, fillFee, fillOID, result.CloseFraction, logger)
 	}
@@ -4757,7 +5805,7 @@ func executeOKXResult(sc StrategyConfig, s *StrategyState, db *StateDB, result *
 		if execResult != nil {
 			prefix = "LIVE "
 		}
-		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice)
+		detail = fmt.Sprintf("[%s] %s%s %s @ $%.2f", sc.ID, prefix, signalStr, result.Symbol, fillPrice) + formatTradeIndicators(s.pendingIndicators)
 	}
 	cashAlert := ""
 	if exec.CashReconcileRequired {
@@ -4897,6 +5945,13 @@ func cloneTimeMap(in map[string]time.Time) map[string]time.Time {
 	return out
 }
 
+// pendingChannelSummary is one formatted cycle-summary page, built under
+// mu.RLock and delivered through the notifier outbox after release (#4920).
+type pendingChannelSummary struct {
+	chKey   string
+	content string
+}
+
 // pendingLeaderboardSummary carries a computed summary from under-lock
 // computation to post-unlock I/O. (#308)
 type pendingLeaderboardSummary struct {
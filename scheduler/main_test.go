@@ -1331,7 +1331,7 @@ func TestValidateDaemonInvocation(t *testing.T) {
 }
 
 func TestKnownSubcommandsMatchDispatch(t *testing.T) {
-	expected := []string{"init", "export", "manual-open", "manual-add", "manual-close", "force-close", "manual-cancel", "manual-update-sl", "manual-cancel-sl", "backfill", "probe", "inspect", "agent-info", "diagnostics", "version"}
+	expected := []string{"init", "export", "manual-open", "manual-add", "manual-close", "force-close", "manual-cancel", "manual-update-sl", "manual-cancel-sl", "harvest-override", "adjust-position", "order-intents", "promote", "compare", "backfill", "probe", "inspect", "agent-info", "diagnostics", "stress", "ledger", "version"}
 	if len(knownSubcommands) != len(expected) {
 		t.Fatalf("knownSubcommands length = %d, want %d (update validateDaemonInvocation when adding/removing a subcommand in main())", len(knownSubcommands), len(expected))
 	}
@@ -37,6 +37,8 @@ func runManualOpen(args []string) int {
 	expireAfter := fs.Duration("expire-after", 0, "Auto-cancel a resting --limit-price order after this duration (e.g. 2h, 30m); 0 = GTC, no expiry")
 	recordOnly := fs.Bool("record-only", false, "Register an existing fill without placing a new on-chain order")
 	dryRun := fs.Bool("dry-run", false, "Print planned action without placing order or mutating state")
+	meta := metadataFlag{}
+	fs.Var(meta, "meta", "Attach key=value metadata to the position and its open trade (repeatable, e.g. --meta thesis=breakout)")
 
 	// #711: stdlib flag.Parse stops at the first positional arg, so the
 	// documented `manual-open <strategy-id> --flag value` form fails to parse
@@ -58,6 +60,7 @@ func runManualOpen(args []string) int {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		return 1
 	}
+	applyDailyResetFromConfig(cfg)
 
 	sc, ok := findManualStrategy(cfg, strategyID)
 	if !ok {
@@ -126,7 +129,7 @@ func runManualOpen(args []string) int {
 				// entry — refuse while the daily loss limit is tripped, same
 				// as the market-order core path.
 				if st := evaluateDailyLossLimit(cfg.PortfolioRisk, state.Strategies, time.Now().UTC()); st.Tripped {
-					fmt.Fprintf(os.Stderr, "error: %s — manual-open blocked until UTC rollover (closes and SL edits are unaffected)\n", dailyLossHoldDetail(st))
+					fmt.Fprintf(os.Stderr, "error: %s — manual-open blocked until the daily reset (closes and SL edits are unaffected)\n", dailyLossHoldDetail(st))
 					return 1
 				}
 				// #1344: a resting limit open still grows gross notional once it
@@ -194,6 +197,7 @@ func runManualOpen(args []string) int {
 		RecordOnly: *recordOnly,
 		FillPrice:  *fillPrice,
 		DryRun:     *dryRun,
+		Metadata:   meta,
 	})
 	return printManualCoreOutcome(res, coreErr)
 }
@@ -380,9 +384,10 @@ func printManualCoreOutcome(res *manualCoreResult, err error) int {
 // under mu.Lock and sendTradeAlerts re-acquires mu.RLock; since sync.RWMutex is
 // not reentrant, alerting inside the drain would self-deadlock (#880).
 type manualAlert struct {
-	sc     StrategyConfig
-	ss     *StrategyState
-	trades int // count of trades appended this drain for this strategy
+	sc      StrategyConfig
+	ss      *StrategyState
+	trades  int      // count of trades appended this drain for this strategy
+	notices []string // non-trade announcements for the strategy channel + owner DM (#4974 promote)
 }
 
 // drainPendingManualActions reads all rows from pending_manual_actions, applies
@@ -425,7 +430,11 @@ func drainPendingManualActions(state *AppState, cfg *Config, stateDB *StateDB) [
 		// per strategy so sendTradeAlerts alerts the correct tail slice of
 		// TradeHistory. SL-only actions (#1050 update-sl/cancel-sl) record no
 		// trade — skip alert bookkeeping so the tail slice isn't misaligned.
-		if !manualActionRecordsTrade(a.Action) {
+		notice := ""
+		if a.Action == "promote" {
+			notice = promotionAnnouncement(scByID[a.StrategyID], a)
+		}
+		if !manualActionRecordsTrade(a.Action) && notice == "" {
 			continue
 		}
 		ma := applied[a.StrategyID]
@@ -434,6 +443,10 @@ func drainPendingManualActions(state *AppState, cfg *Config, stateDB *StateDB) [
 			applied[a.StrategyID] = ma
 			order = append(order, a.StrategyID)
 		}
+		if notice != "" {
+			ma.notices = append(ma.notices, notice)
+			continue
+		}
 		ma.trades++
 	}
 
@@ -492,6 +505,7 @@ func applyManualAction(state *AppState, cfg *Config, scByID map[string]StrategyC
 			StopLossTriggerPx:               a.StopLossTriggerPx,
 			TPOIDs:                          a.TPOIDs,
 			RatchetFallbackNormalizePending: a.RatchetFallbackNormalizePending,
+			Metadata:                        cloneMetadata(a.Metadata),
 		}
 		// #1277: freeze the atr_method the EntryATR was computed under, so
 		// checkATRMethodDriftAtStartup sees manual positions too. Prefer the
@@ -526,6 +540,7 @@ func applyManualAction(state *AppState, cfg *Config, scByID map[string]StrategyC
 			StopLossTriggerPx: a.StopLossTriggerPx,
 			TPOIDs:            cloneInt64s(a.TPOIDs),
 			Manual:            true,
+			Metadata:          cloneMetadata(a.Metadata),
 		}
 		recordPositionOpen(ss, sc, &trade, pos)
 		// Fix #1: perps open deducts only the fee; notional stays virtual.
@@ -671,6 +686,29 @@ func applyManualAction(state *AppState, cfg *Config, scByID map[string]StrategyC
 		fmt.Printf("[manual] applied cancel-sl: %s %s (stop-loss removed)\n",
 			a.StrategyID, a.Symbol)
 
+	case "adjust-position":
+		// #4972: register/correct a position queued by `go-trader
+		// adjust-position`; books one audit trade, no cash or PnL.
+		summary, err := applyPositionAdjustment(ss, sc, positionAdjustment{Symbol: a.Symbol, Quantity: a.Quantity, AvgCost: a.FillPrice, Side: a.Side}, now)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[manual] applied adjust-position: %s %s\n", a.StrategyID, summary)
+
+	case "harvest-override":
+		// #4970: per-position theta harvest override queued by
+		// `go-trader harvest-override`. Symbol carries the option position ID.
+		summary, err := setOptionHarvestOverride(ss, a.Symbol, a.OverrideField, a.OverrideValue)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[manual] applied harvest-override: %s %s -> %s\n", a.StrategyID, a.Symbol, summary)
+
+	case "promote":
+		// #4974: `go-trader promote` already rewrote the config; the row only
+		// carries the announcement, emitted by the caller after the drain.
+		fmt.Printf("[manual] applied promote: %s\n", a.StrategyID)
+
 	default:
 		return fmt.Errorf("unknown action %q", a.Action)
 	}
@@ -786,6 +824,18 @@ func clearForceCloseCanceledProtectionOIDs(pos *Position, canceledSLOID int64, c
 }
 
 func validatePendingManualActionStrategy(sc StrategyConfig, a PendingManualAction) error {
+	if a.Action == "adjust-position" {
+		return validatePositionAdjustment(sc, positionAdjustment{Symbol: a.Symbol, Quantity: a.Quantity, AvgCost: a.FillPrice, Side: a.Side})
+	}
+	if a.Action == "promote" {
+		return nil
+	}
+	if a.Action == "harvest-override" {
+		if sc.Type != "options" {
+			return fmt.Errorf("strategy %q harvest-override requires type=options (got %q)", a.StrategyID, sc.Type)
+		}
+		return nil
+	}
 	if sc.Type == "manual" {
 		return nil
 	}
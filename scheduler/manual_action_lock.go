@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -70,13 +69,13 @@ func acquireManualActionFileLock(dbPath string) (release func(), err error) {
 	}
 	deadline := time.Now().Add(manualActionLockMaxWait)
 	for {
-		flockErr := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		flockErr := tryLockFile(f)
 		if flockErr == nil {
 			return func() { f.Close() }, nil
 		}
-		// LOCK_NB returns EWOULDBLOCK (== EAGAIN on Linux) while another holder
-		// has it. Any other errno is a genuine failure — fail closed.
-		if !errors.Is(flockErr, syscall.EWOULDBLOCK) {
+		// errFileLocked while another holder has it. Any other error is a
+		// genuine failure — fail closed.
+		if !errors.Is(flockErr, errFileLocked) {
 			f.Close()
 			return nil, fmt.Errorf("flock manual-action lock %s: %w", lockPath, flockErr)
 		}
@@ -42,11 +42,11 @@ func parseHyperliquidFetchATROutput(stdout []byte, stderrStr string, runErr erro
 		// fetch-atr emits structured JSON even on its own internal failures (it
 		// catches and reports), so a process-level error is real (e.g. Python
 		// missing). Surface it without trying to parse stdout.
-		return nil, stderrStr, fmt.Errorf("fetch-atr error: %w (stderr: %s)", runErr, stderrStr)
+		return nil, stderrStr, fmt.Errorf("fetch-atr error: %w (stderr: %s)", runErr, outputSnippet(stderrStr))
 	}
 	var result HyperliquidFetchATRResult
 	if err := json.Unmarshal(stdout, &result); err != nil {
-		return nil, stderrStr, fmt.Errorf("parse fetch-atr output: %w (stdout: %s)", err, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse fetch-atr output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -90,7 +90,7 @@ func fetchManualEntryATR(sc StrategyConfig, cfg *Config) (float64, string, bool)
 	if err != nil {
 		msg := err.Error()
 		if stderr != "" {
-			msg = fmt.Sprintf("%s; stderr=%s", msg, stderr)
+			msg = fmt.Sprintf("%s; stderr=%s", msg, outputSnippet(stderr))
 		}
 		return 0, msg, false
 	}
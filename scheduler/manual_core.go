@@ -24,6 +24,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 )
@@ -166,7 +167,7 @@ type manualCoreDeps struct {
 	// loadState returns the current state view for strategyID+symbol.
 	loadState func(strategyID, symbol string) (manualStateView, error)
 
-	execute     func(script, symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error)
+	execute     func(script, symbol, side string, size, stopLossPct float64, cancelStopLossOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error)
 	updateSL    func(script, symbol, side string, size, triggerPx float64, cancelStopLossOID int64) (*HyperliquidStopLossUpdateResult, string, error)
 	cancelOrder func(script, symbol string, oid int64) (*HyperliquidCancelOrderResult, string, error)
 	fetchMids   manualMarkFetcher
@@ -193,9 +194,28 @@ func (d manualCoreDeps) acquireManualActionLock() (func(), error) {
 	return d.lockManualActions()
 }
 
+// executeWithIntent places a manual HL order through d.execute under an order
+// intent (#4916) whose key goes out as the cloid, so a crash between the fill
+// and the pending-action insert surfaces in the startup reconcile. The caller
+// drops the key once the action is queued.
+func (d manualCoreDeps) executeWithIntent(sc StrategyConfig, side string, size float64, run func(cloid string) (*HyperliquidExecuteResult, string, error)) (string, *HyperliquidExecuteResult, string, error) {
+	logger := manualIntentLogger(sc)
+	key := d.stateDB.beginIntent(sc, sc.Symbol, side, size, logger)
+	res, stderr, err := run(orderIntentClientID(sc.Platform, key))
+	d.stateDB.settleIntent(key, err, res, logger)
+	return key, res, stderr, err
+}
+
+func manualIntentLogger(sc StrategyConfig) *StrategyLogger {
+	return &StrategyLogger{stratID: sc.ID, writer: os.Stderr}
+}
+
 // newCLIManualCoreDeps builds deps for the standalone CLI process: state is
 // read from the shared SQLite DB.
 func newCLIManualCoreDeps(cfg *Config, stateDB *StateDB, notifier *MultiNotifier) manualCoreDeps {
+	// The CLI never runs main's startup, so adopt the daily reset boundary
+	// here for the daily loss guard (#4986).
+	applyDailyResetFromConfig(cfg)
 	d := newManualCoreDeps(cfg, stateDB, notifier)
 	d.loadState = func(strategyID, symbol string) (manualStateView, error) {
 		state, err := LoadStateWithDB(cfg, stateDB)
@@ -233,7 +253,7 @@ func lookupManualStrategy(cfg *Config, id string) (StrategyConfig, error) {
 			return sc, nil
 		}
 	}
-	return StrategyConfig{}, manualFailf("error: strategy %q not found in config", id)
+	return StrategyConfig{}, manualFailf("error: strategy %q not found in config%s", id, includedFilesNote(cfg))
 }
 
 // lookupForceCloseStrategy is the silent core behind findForceCloseStrategy:
@@ -442,6 +462,7 @@ type manualOpenInputs struct {
 	RecordOnly bool
 	FillPrice  float64
 	DryRun     bool
+	Metadata   map[string]string // #4971: copied onto the position and its open trade
 }
 
 // resolveManualOpenSide applies the config-default side and validates it
@@ -533,7 +554,7 @@ func manualOpenCore(d manualCoreDeps, sc StrategyConfig, in manualOpenInputs) (*
 				return res, manualFailf("error: strategy has a pending circuit-breaker close — manual-open blocked")
 			}
 			if view.DailyLossHold {
-				return res, manualFailf("error: %s — manual-open blocked until UTC rollover (closes and SL edits are unaffected)", view.DailyLossNote)
+				return res, manualFailf("error: %s — manual-open blocked until the daily reset (closes and SL edits are unaffected)", view.DailyLossNote)
 			}
 			// #1344: a manual open grows gross notional — refuse while over cap
 			// (closes and SL edits are unaffected).
@@ -603,7 +624,7 @@ func manualOpenCore(d manualCoreDeps, sc StrategyConfig, in manualOpenInputs) (*
 	}
 
 	var resolvedFillPrice, fillQty, fillFee float64
-	var exchangeOID string
+	var exchangeOID, intentKey string
 
 	if in.DryRun {
 		prefix := "[dry-run]"
@@ -627,12 +648,15 @@ func manualOpenCore(d manualCoreDeps, sc StrategyConfig, in manualOpenInputs) (*
 			res.errf("warning: --record-only does not arm a stop-loss trigger automatically — place the SL manually on the HL UI")
 		}
 	} else {
-		execResult, execStderr, execErr := d.execute(
-			script, sc.Symbol, openSide,
-			resolvedOrderSize,
-			effectiveSLPct, 0, 0, sc.MarginMode, sc.Leverage, false,
-			hlExecuteSnapshot{},
-		)
+		key, execResult, execStderr, execErr := d.executeWithIntent(sc, openSide, resolvedOrderSize, func(cloid string) (*HyperliquidExecuteResult, string, error) {
+			return d.execute(
+				script, sc.Symbol, openSide,
+				resolvedOrderSize,
+				effectiveSLPct, 0, 0, sc.MarginMode, sc.Leverage, false,
+				hlExecuteSnapshot{}, cloid,
+			)
+		})
+		intentKey = key
 		if execStderr != "" {
 			res.errf("HL execute stderr: %s", execStderr)
 		}
@@ -778,6 +802,7 @@ func manualOpenCore(d manualCoreDeps, sc StrategyConfig, in manualOpenInputs) (*
 		ATRMethod:                       resolveATRMethod(sc, cfg),
 		TPOIDs:                          tpOIDs,
 		RatchetFallbackNormalizePending: ratchetFallbackNormalizePending && stopLossOID > 0 && stopLossTriggerPx > 0,
+		Metadata:                        in.Metadata,
 		CreatedAt:                       time.Now().UTC(),
 	}
 	if err := d.stateDB.InsertPendingManualAction(action); err != nil {
@@ -801,6 +826,7 @@ func manualOpenCore(d manualCoreDeps, sc StrategyConfig, in manualOpenInputs) (*
 		return res, manualFailf("error queuing action: %v", err)
 	}
 
+	d.stateDB.dropIntent(intentKey, manualIntentLogger(sc))
 	res.queued = true
 	res.outf("Queued: %s position will appear in the dashboard after the next scheduler cycle.", strategyID)
 	return res, nil
@@ -858,7 +884,7 @@ func manualAddCore(d manualCoreDeps, sc StrategyConfig, in manualAddInputs) (*ma
 		return res, manualFailf("error: strategy has a pending circuit-breaker close — manual-add blocked")
 	}
 	if view.DailyLossHold {
-		return res, manualFailf("error: %s — manual-add blocked until UTC rollover (closes and SL edits are unaffected)", view.DailyLossNote)
+		return res, manualFailf("error: %s — manual-add blocked until the daily reset (closes and SL edits are unaffected)", view.DailyLossNote)
 	}
 	pos := view.Pos
 	if pos == nil {
@@ -935,7 +961,7 @@ func manualAddCore(d manualCoreDeps, sc StrategyConfig, in manualAddInputs) (*ma
 	}
 
 	var resolvedFillPrice, fillQty, fillFee float64
-	var exchangeOID string
+	var exchangeOID, intentKey string
 
 	if in.RecordOnly {
 		fillQty = in.Size
@@ -944,12 +970,15 @@ func manualAddCore(d manualCoreDeps, sc StrategyConfig, in manualAddInputs) (*ma
 		// Add order: same-side market order. No SL pct, no cancel OID, and NO
 		// margin-mode/leverage (HL rejects update_leverage on an open position);
 		// the post-add protection sync re-sizes SL + un-cleared TPs.
-		execResult, execStderr, execErr := d.execute(
-			sc.Script, sc.Symbol, addSide,
-			resolvedOrderSize,
-			0, 0, 0, "", 0, false,
-			hlExecuteSnapshot{},
-		)
+		key, execResult, execStderr, execErr := d.executeWithIntent(sc, addSide, resolvedOrderSize, func(cloid string) (*HyperliquidExecuteResult, string, error) {
+			return d.execute(
+				sc.Script, sc.Symbol, addSide,
+				resolvedOrderSize,
+				0, 0, 0, "", 0, false,
+				hlExecuteSnapshot{}, cloid,
+			)
+		})
+		intentKey = key
 		if execStderr != "" {
 			res.errf("HL execute stderr: %s", execStderr)
 		}
@@ -990,6 +1019,7 @@ func manualAddCore(d manualCoreDeps, sc StrategyConfig, in manualAddInputs) (*ma
 	if err := d.stateDB.InsertPendingManualAction(action); err != nil {
 		return res, manualFailf("error queuing action: %v", err)
 	}
+	d.stateDB.dropIntent(intentKey, manualIntentLogger(sc))
 	res.queued = true
 	res.outf("Queued: scale-in for %s will blend into the position after the next scheduler cycle.", strategyID)
 	return res, nil
@@ -1176,10 +1206,12 @@ func manualCloseCore(d manualCoreDeps, sc StrategyConfig, in manualCloseInputs)
 		extraCancelOIDs = cloneInt64s(pos.TPOIDs)
 	}
 
-	execResult, stderr, execErr := d.execute(
-		sc.Script, sc.Symbol, closeSide, closeQty,
-		0, cancelOID, 0, "", 0, closeFullPosition, hlExecuteSnapshot{}, extraCancelOIDs...,
-	)
+	intentKey, execResult, stderr, execErr := d.executeWithIntent(sc, closeSide, closeQty, func(cloid string) (*HyperliquidExecuteResult, string, error) {
+		return d.execute(
+			sc.Script, sc.Symbol, closeSide, closeQty,
+			0, cancelOID, 0, "", 0, closeFullPosition, hlExecuteSnapshot{}, cloid, extraCancelOIDs...,
+		)
+	})
 	if stderr != "" {
 		res.errf("HL close stderr: %s", stderr)
 	}
@@ -1235,6 +1267,7 @@ func manualCloseCore(d manualCoreDeps, sc StrategyConfig, in manualCloseInputs)
 		return res, manualFailf("error queuing close action: %v", err)
 	}
 
+	d.stateDB.dropIntent(intentKey, manualIntentLogger(sc))
 	res.queued = true
 	res.outf("Queued: close will be reflected in the dashboard after the next scheduler cycle.")
 	return res, nil
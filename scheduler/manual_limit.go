@@ -97,9 +97,9 @@ func parseHyperliquidLimitOpenOutput(stdout []byte, stderrStr string, runErr err
 	var result HyperliquidLimitOpenResult
 	if jsonErr := json.Unmarshal(stdout, &result); jsonErr != nil {
 		if runErr != nil {
-			return nil, stderrStr, fmt.Errorf("limit-open error: %w (stderr: %s; stdout: %s)", runErr, stderrStr, string(stdout))
+			return nil, stderrStr, fmt.Errorf("limit-open error: %w (stderr: %s; stdout: %s)", runErr, outputSnippet(stderrStr), outputSnippet(string(stdout)))
 		}
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, outputSnippet(string(stdout)))
 	}
 	// A non-empty Error / status=error is a structured failure the caller
 	// inspects; runErr (exit 1) accompanies it but the JSON is authoritative.
@@ -117,9 +117,9 @@ func parseHyperliquidLimitStatusOutput(stdout []byte, stderrStr string, runErr e
 	var result HyperliquidLimitStatusResult
 	if jsonErr := json.Unmarshal(stdout, &result); jsonErr != nil {
 		if runErr != nil {
-			return nil, stderrStr, fmt.Errorf("limit-status error: %w (stderr: %s; stdout: %s)", runErr, stderrStr, string(stdout))
+			return nil, stderrStr, fmt.Errorf("limit-status error: %w (stderr: %s; stdout: %s)", runErr, outputSnippet(stderrStr), outputSnippet(string(stdout)))
 		}
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -167,9 +167,9 @@ func parseHyperliquidCancelOrderOutput(stdout []byte, stderrStr string, runErr e
 	var result HyperliquidCancelOrderResult
 	if jsonErr := json.Unmarshal(stdout, &result); jsonErr != nil {
 		if runErr != nil {
-			return nil, stderrStr, fmt.Errorf("cancel-order error: %w (stderr: %s; stdout: %s)", runErr, stderrStr, string(stdout))
+			return nil, stderrStr, fmt.Errorf("cancel-order error: %w (stderr: %s; stdout: %s)", runErr, outputSnippet(stderrStr), outputSnippet(string(stdout)))
 		}
-		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, string(stdout))
+		return nil, stderrStr, fmt.Errorf("parse output: %w (stdout: %s)", jsonErr, outputSnippet(string(stdout)))
 	}
 	return &result, stderrStr, nil
 }
@@ -389,7 +389,7 @@ func TestManualOpenCoreRefusesQueuedLimitOrder(t *testing.T) {
 	deps.fetchMids = func([]string) (map[string]float64, error) {
 		return map[string]float64{sc.Symbol: 2000}, nil
 	}
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		t.Error("market execute must not be called while a resting limit exists")
 		return nil, "", errors.New("execute called")
 	}
@@ -536,7 +536,7 @@ func TestManualCloseCancelsPartialLimitRemainderBeforeFlatten(t *testing.T) {
 	)
 	deps := newCLIManualCoreDeps(cfg, db, nil)
 	execCalls := 0
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		execCalls++
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: 0.4, OID: 4242, Fee: 0.4}}}, "", nil
 	}
@@ -599,7 +599,7 @@ func TestManualCloseReconcilesStaleSnapshotAgainstAdoptedLimitFill(t *testing.T)
 	deps := newCLIManualCoreDeps(cfg, db, nil)
 	var gotCloseQty float64
 	var gotFullClose bool
-	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
 		gotCloseQty = size
 		gotFullClose = closeFull
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: size, OID: 4242, Fee: 0.4}}}, "", nil
@@ -669,7 +669,7 @@ func TestManualCloseAcceptsExplicitQtyMatchingReconciledSize(t *testing.T) {
 	cfg, sc, db := staleReconcileCloseHarness(t)
 	deps := newCLIManualCoreDeps(cfg, db, nil)
 	var gotCloseQty float64
-	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, _ bool, _ hlExecuteSnapshot, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, _ bool, _ hlExecuteSnapshot, _ string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
 		gotCloseQty = size
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: size, OID: 4242, Fee: 0.4}}}, "", nil
 	}
@@ -697,7 +697,7 @@ func TestManualClosePartialQtyBetweenStaleAndReconciledSize(t *testing.T) {
 	deps := newCLIManualCoreDeps(cfg, db, nil)
 	var gotCloseQty float64
 	var gotFullClose bool
-	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
 		gotCloseQty = size
 		gotFullClose = closeFull
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: size, OID: 4242, Fee: 0.4}}}, "", nil
@@ -724,7 +724,7 @@ func TestManualClosePartialQtyBetweenStaleAndReconciledSize(t *testing.T) {
 func TestManualCloseRejectsQtyExceedingReconciledSize(t *testing.T) {
 	cfg, sc, db := staleReconcileCloseHarness(t)
 	deps := newCLIManualCoreDeps(cfg, db, nil)
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		t.Error("execute must not run when --qty exceeds the reconciled position")
 		return nil, "", errors.New("execute called")
 	}
@@ -799,7 +799,7 @@ func TestManualCloseRereadsFreshPositionWhenRowDeletedBeforeClearResting(t *test
 	sc, deps, db := staleReadRowGoneCloseHarness(t)
 	var gotCloseQty float64
 	var gotFullClose bool
-	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, closeFull bool, _ hlExecuteSnapshot, _ string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
 		gotCloseQty = size
 		gotFullClose = closeFull
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: size, OID: 4242, Fee: 0.4}}}, "", nil
@@ -828,7 +828,7 @@ func TestManualCloseRereadsFreshPositionWhenRowDeletedBeforeClearResting(t *test
 func TestManualCloseExplicitQtyValidatedAgainstRereadWhenRowGone(t *testing.T) {
 	sc, deps, db := staleReadRowGoneCloseHarness(t)
 	var gotCloseQty float64
-	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, _ bool, _ hlExecuteSnapshot, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(_ string, _ string, _ string, size float64, _ float64, _ int64, _ float64, _ string, _ float64, _ bool, _ hlExecuteSnapshot, _ string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
 		gotCloseQty = size
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2010, TotalSz: size, OID: 4242, Fee: 0.4}}}, "", nil
 	}
@@ -862,7 +862,7 @@ func TestManualAddCancelsPartialLimitRemainderBeforeAveraging(t *testing.T) {
 		return map[string]float64{sc.Symbol: 2000}, nil
 	}
 	execCalls := 0
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		execCalls++
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 1995, TotalSz: 0.05, OID: 5252, Fee: 0.1}}}, "", nil
 	}
@@ -895,7 +895,7 @@ func TestManualCloseDefersWhenLimitCancelHasUnadoptedFill(t *testing.T) {
 		},
 	)
 	deps := newCLIManualCoreDeps(cfg, db, nil)
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		t.Error("execute must not run while a limit fill is unadopted")
 		return nil, "", errors.New("execute called")
 	}
@@ -929,7 +929,7 @@ func TestManualAddDefersWhenLimitCancelBookStateUnknown(t *testing.T) {
 	deps.fetchMids = func([]string) (map[string]float64, error) {
 		return map[string]float64{sc.Symbol: 2000}, nil
 	}
-	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+	deps.execute = func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 		t.Error("execute must not run while limit book state is unknown")
 		return nil, "", errors.New("execute called")
 	}
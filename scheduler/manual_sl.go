@@ -13,7 +13,7 @@ import (
 // (#1050 update-sl/cancel-sl) do not.
 func manualActionRecordsTrade(action string) bool {
 	switch action {
-	case "open", "close", "add":
+	case "open", "close", "add", "adjust-position":
 		return true
 	default:
 		return false
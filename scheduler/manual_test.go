@@ -1206,7 +1206,7 @@ func TestManualCoresGuardPositionDoubleFire(t *testing.T) {
 	firingDeps := func(fired *int) manualCoreDeps {
 		d := newCLIManualCoreDeps(cfg, db, nil)
 		d.fetchMids = func(coins []string) (map[string]float64, error) { return map[string]float64{"ETH": 2000}, nil }
-		d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+		d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 			if closeFullPosition {
 				t.Errorf("partial/shared-coin close must be sized (non-reduce-only), got closeFullPosition=true")
 			}
@@ -1218,7 +1218,7 @@ func TestManualCoresGuardPositionDoubleFire(t *testing.T) {
 	failLoudDeps := func() manualCoreDeps {
 		d := newCLIManualCoreDeps(cfg, db, nil)
 		d.fetchMids = func(coins []string) (map[string]float64, error) { return map[string]float64{"ETH": 2000}, nil }
-		d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+		d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 			t.Error("execute must not be called for a guarded action")
 			return nil, "", fmt.Errorf("stub")
 		}
@@ -1355,7 +1355,7 @@ func TestManualActionLockPreventsCrossProcessDoubleFire(t *testing.T) {
 	// A holds the lock across a blocked on-chain submit — its pending row is not
 	// inserted until the test releases it.
 	depsA := newCLIManualCoreDeps(cfg, db, nil)
-	depsA.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	depsA.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		atomic.AddInt32(&aFired, 1)
 		close(enteredSubmit)
 		<-releaseSubmit
@@ -1364,7 +1364,7 @@ func TestManualActionLockPreventsCrossProcessDoubleFire(t *testing.T) {
 
 	// B: reaching its venue seam at all is the double-fire the lock must prevent.
 	depsB := newCLIManualCoreDeps(cfg, db, nil)
-	depsB.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	depsB.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		atomic.AddInt32(&bFired, 1)
 		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2100, TotalSz: size, OID: 5252, Fee: 1.0}}}, "", nil
 	}
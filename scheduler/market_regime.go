@@ -0,0 +1,399 @@
+package main
+
+// market_regime.go — Go-side per-asset market regime (#4926).
+//
+// A lightweight trend + volatility classifier computed in Go from OHLCV the
+// scheduler caches per (platform, type, symbol). Candles are re-fetched once
+// per bar of market_regime.timeframe through the dashboard's fetch_candles.py
+// helper, so strategies sharing an asset share one fetch and one label. The
+// label ("trending_up/vol_high") is forwarded to check scripts as
+// --market-regime and shown next to the asset price in channel summaries.
+//
+// This is independent of the ADX regime store (#879): it never gates entries
+// and is not stamped onto positions. Strategies opt in by declaring a
+// market_regime parameter; it is also set on market_ctx for close evaluators.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Market regime defaults.
+const (
+	defaultMarketRegimeTimeframe      = "1h"
+	defaultMarketRegimeTrendPeriod    = 50
+	defaultMarketRegimeTrendThreshold = 1.0
+	defaultMarketRegimeVolWindow      = 20
+)
+
+// Market regime labels. Trend labels reuse the ADX regime vocabulary.
+const (
+	marketTrendUp   = "trending_up"
+	marketTrendDown = "trending_down"
+	marketRanging   = "ranging"
+	marketVolHigh   = "vol_high"
+	marketVolLow    = "vol_low"
+)
+
+// MarketRegimeConfig enables the Go-side market regime classifier.
+type MarketRegimeConfig struct {
+	Enabled           bool    `json:"enabled"`
+	Timeframe         string  `json:"timeframe,omitempty"`           // candle timeframe (default "1h")
+	TrendPeriod       int     `json:"trend_period,omitempty"`        // SMA length in bars (default 50)
+	TrendThresholdPct float64 `json:"trend_threshold_pct,omitempty"` // |close vs SMA| % needed to call a trend (default 1)
+	VolWindow         int     `json:"vol_window,omitempty"`          // realized-vol window in bars (default 20)
+}
+
+func (c *MarketRegimeConfig) timeframe() string {
+	if c.Timeframe == "" {
+		return defaultMarketRegimeTimeframe
+	}
+	return normalizeRegimeTimeframe(c.Timeframe)
+}
+
+func (c *MarketRegimeConfig) trendPeriod() int {
+	if c.TrendPeriod <= 0 {
+		return defaultMarketRegimeTrendPeriod
+	}
+	return c.TrendPeriod
+}
+
+func (c *MarketRegimeConfig) trendThresholdPct() float64 {
+	if c.TrendThresholdPct <= 0 {
+		return defaultMarketRegimeTrendThreshold
+	}
+	return c.TrendThresholdPct
+}
+
+func (c *MarketRegimeConfig) volWindow() int {
+	if c.VolWindow <= 0 {
+		return defaultMarketRegimeVolWindow
+	}
+	return c.VolWindow
+}
+
+// slopeLag is how many bars back the SMA is compared to for its direction.
+func (c *MarketRegimeConfig) slopeLag() int {
+	if lag := c.trendPeriod() / 10; lag > 1 {
+		return lag
+	}
+	return 1
+}
+
+// ohlcvLimit is the candle count fetched per asset: enough for the lagged
+// SMA and a few volatility windows of history for the median.
+func (c *MarketRegimeConfig) ohlcvLimit() int {
+	n := c.trendPeriod() + c.slopeLag()
+	if v := 6 * c.volWindow(); v > n {
+		n = v
+	}
+	return n + 5
+}
+
+// marketRegimeErrors validates the top-level market_regime block.
+func marketRegimeErrors(c *MarketRegimeConfig) []string {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	var errs []string
+	if c.Timeframe != "" {
+		if _, ok := diagTimeframeDuration(c.Timeframe); !ok || !validRegimeTimeframe(c.Timeframe) {
+			errs = append(errs, fmt.Sprintf("market_regime.timeframe %q is not a supported candle timeframe", c.Timeframe))
+		}
+	}
+	if c.TrendPeriod < 0 || c.TrendPeriod > 500 {
+		errs = append(errs, fmt.Sprintf("market_regime.trend_period must be in [0, 500] (0 = default %d), got %d", defaultMarketRegimeTrendPeriod, c.TrendPeriod))
+	}
+	if c.TrendThresholdPct < 0 || c.TrendThresholdPct > 50 {
+		errs = append(errs, fmt.Sprintf("market_regime.trend_threshold_pct must be in [0, 50] (0 = default %g), got %g", defaultMarketRegimeTrendThreshold, c.TrendThresholdPct))
+	}
+	if c.VolWindow < 0 || c.VolWindow > 200 || c.VolWindow == 1 {
+		errs = append(errs, fmt.Sprintf("market_regime.vol_window must be 0 (default %d) or in [2, 200], got %d", defaultMarketRegimeVolWindow, c.VolWindow))
+	}
+	return errs
+}
+
+// MarketRegime is one asset's classification.
+type MarketRegime struct {
+	Trend string
+	Vol   string
+}
+
+// Label renders the regime as "<trend>/<vol>".
+func (r MarketRegime) Label() string {
+	if r.Trend == "" {
+		return ""
+	}
+	return r.Trend + "/" + r.Vol
+}
+
+// classifyMarketRegime labels the latest bar. Trend: close more than
+// trend_threshold_pct above (below) its SMA while the SMA rises (falls)
+// versus slopeLag bars ago; otherwise ranging. Volatility: the latest
+// vol_window stdev of log returns against the median of that rolling stdev
+// over the fetched history. ok=false when there are too few candles.
+func classifyMarketRegime(candles []UICandle, c *MarketRegimeConfig) (MarketRegime, bool) {
+	p, lag, w := c.trendPeriod(), c.slopeLag(), c.volWindow()
+	n := len(candles)
+	if n < p+lag || n < 2*w+1 {
+		return MarketRegime{}, false
+	}
+	closes := make([]float64, n)
+	for i, cd := range candles {
+		if cd.Close <= 0 {
+			return MarketRegime{}, false
+		}
+		closes[i] = cd.Close
+	}
+	sma := meanFloat(closes[n-p:])
+	prev := meanFloat(closes[n-p-lag : n-lag])
+	devPct := (closes[n-1] - sma) / sma * 100
+	trend := marketRanging
+	switch thr := c.trendThresholdPct(); {
+	case devPct > thr && sma > prev:
+		trend = marketTrendUp
+	case devPct < -thr && sma < prev:
+		trend = marketTrendDown
+	}
+
+	returns := make([]float64, n-1)
+	for i := 1; i < n; i++ {
+		returns[i-1] = math.Log(closes[i] / closes[i-1])
+	}
+	rolling := make([]float64, 0, len(returns)-w+1)
+	for end := w; end <= len(returns); end++ {
+		rolling = append(rolling, stdevFloat(returns[end-w:end]))
+	}
+	current := rolling[len(rolling)-1]
+	sorted := append([]float64(nil), rolling...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	vol := marketVolLow
+	if current > median {
+		vol = marketVolHigh
+	}
+	return MarketRegime{Trend: trend, Vol: vol}, true
+}
+
+func meanFloat(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func stdevFloat(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := meanFloat(xs)
+	var sq float64
+	for _, x := range xs {
+		sq += (x - m) * (x - m)
+	}
+	return math.Sqrt(sq / float64(len(xs)-1))
+}
+
+// marketRegimeKey identifies one cached OHLCV series.
+type marketRegimeKey struct {
+	Platform string
+	Type     string
+	Symbol   string
+}
+
+func (k marketRegimeKey) String() string {
+	return k.Platform + "/" + k.Type + "/" + k.Symbol
+}
+
+// marketRegimeKeyFor returns sc's series key; ok=false for strategy types
+// the classifier does not serve (options, manual) or without a symbol.
+func marketRegimeKeyFor(sc StrategyConfig) (marketRegimeKey, bool) {
+	switch sc.Type {
+	case "spot", "perps", "futures":
+	default:
+		return marketRegimeKey{}, false
+	}
+	sym := strategyDisplaySymbol(sc)
+	if sym == "" {
+		return marketRegimeKey{}, false
+	}
+	return marketRegimeKey{Platform: sc.Platform, Type: sc.Type, Symbol: sym}, true
+}
+
+type marketRegimeEntry struct {
+	candles   []UICandle
+	fetchedAt time.Time
+	regime    MarketRegime
+}
+
+// MarketRegimeService caches per-asset OHLCV and the derived regime. Its own
+// mutex guards entries: the refresh goroutine writes, the dispatch loop and
+// summary formatting read.
+type MarketRegimeService struct {
+	mu         sync.RWMutex
+	entries    map[marketRegimeKey]*marketRegimeEntry
+	refreshing bool
+	fetch      UICandleFetcher
+}
+
+// globalMarketRegimes is the process-wide service (package-level like
+// globalRegimeStore so arg builders and summaries read it without plumbing).
+var globalMarketRegimes = &MarketRegimeService{fetch: FetchUICandles}
+
+// StartRefresh re-fetches, in the background, every due asset whose cached
+// candles predate the current bar, then reclassifies. The returned func
+// blocks until the refresh finishes. A disabled config clears the cache so
+// no label is forwarded. A refresh still running from an earlier cycle is
+// not doubled up.
+func (s *MarketRegimeService) StartRefresh(c *MarketRegimeConfig, due []StrategyConfig, now time.Time) func() {
+	s.mu.Lock()
+	if c == nil || !c.Enabled {
+		s.entries = nil
+		s.mu.Unlock()
+		return func() {}
+	}
+	if s.refreshing {
+		s.mu.Unlock()
+		return func() {}
+	}
+	if s.entries == nil {
+		s.entries = make(map[marketRegimeKey]*marketRegimeEntry)
+	}
+	tf, _ := diagTimeframeDuration(c.timeframe())
+	stale := make(map[marketRegimeKey]StrategyConfig)
+	for _, sc := range due {
+		key, ok := marketRegimeKeyFor(sc)
+		if !ok {
+			continue
+		}
+		if _, queued := stale[key]; queued {
+			continue
+		}
+		if e := s.entries[key]; e == nil || tf <= 0 || !now.Truncate(tf).Equal(e.fetchedAt.Truncate(tf)) {
+			fetchSC := sc
+			fetchSC.Timeframe = c.timeframe()
+			stale[key] = fetchSC
+		}
+	}
+	if len(stale) == 0 {
+		s.mu.Unlock()
+		return func() {}
+	}
+	s.refreshing = true
+	fetch := s.fetch
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		type result struct {
+			key     marketRegimeKey
+			candles []UICandle
+			err     error
+		}
+		results := make(chan result, len(stale))
+		var wg sync.WaitGroup
+		for key, sc := range stale {
+			wg.Add(1)
+			go func(key marketRegimeKey, sc StrategyConfig) {
+				defer wg.Done()
+				candles, _, err := fetch(UICandleRequest{Strategy: sc, Limit: c.ohlcvLimit()})
+				results <- result{key, candles, err}
+			}(key, sc)
+		}
+		wg.Wait()
+		close(results)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.refreshing = false
+		for r := range results {
+			if r.err != nil {
+				fmt.Printf("[WARN] market regime %s: candle fetch failed; keeping last label: %v\n", r.key, r.err)
+				continue
+			}
+			e := &marketRegimeEntry{candles: r.candles, fetchedAt: now}
+			if regime, ok := classifyMarketRegime(r.candles, c); ok {
+				e.regime = regime
+			} else {
+				fmt.Printf("[WARN] market regime %s: %d candles is too few to classify\n", r.key, len(r.candles))
+			}
+			s.entries[r.key] = e
+		}
+		if summary := s.summaryLocked(); summary != "" {
+			fmt.Printf("Market regime: %s\n", summary)
+		}
+	}()
+	return func() { <-done }
+}
+
+// summaryLocked renders "hyperliquid/perps/BTC=trending_up/vol_high; ..."
+// sorted by key. Caller holds s.mu.
+func (s *MarketRegimeService) summaryLocked() string {
+	keys := s.sortedKeysLocked()
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		label := s.entries[k].regime.Label()
+		if label == "" {
+			label = "-"
+		}
+		parts = append(parts, k.String()+"="+label)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (s *MarketRegimeService) sortedKeysLocked() []marketRegimeKey {
+	keys := make([]marketRegimeKey, 0, len(s.entries))
+	for k := range s.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// LabelForStrategy returns sc's asset label, or "" when unknown.
+func (s *MarketRegimeService) LabelForStrategy(sc StrategyConfig) string {
+	key, ok := marketRegimeKeyFor(sc)
+	if !ok {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if e := s.entries[key]; e != nil {
+		return e.regime.Label()
+	}
+	return ""
+}
+
+// LabelForAsset returns the first known label (sorted by key) for a base
+// asset such as "BTC", for summary price lines.
+func (s *MarketRegimeService) LabelForAsset(base string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, k := range s.sortedKeysLocked() {
+		if strings.TrimSuffix(strings.ToUpper(k.Symbol), "/USDT") != base {
+			continue
+		}
+		if label := s.entries[k].regime.Label(); label != "" {
+			return label
+		}
+	}
+	return ""
+}
+
+// appendMarketRegimeArg forwards sc's market regime as --market-regime=<label>.
+// Omitted when the service is disabled or the asset has no label yet.
+func appendMarketRegimeArg(args []string, sc StrategyConfig) []string {
+	if label := globalMarketRegimes.LabelForStrategy(sc); label != "" {
+		return append(args, "--market-regime="+label)
+	}
+	return args
+}
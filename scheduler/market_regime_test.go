@@ -0,0 +1,141 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// marketRegimeTestCandles builds n closes from f(i).
+func marketRegimeTestCandles(n int, f func(i int) float64) []UICandle {
+	out := make([]UICandle, n)
+	for i := range out {
+		out[i] = UICandle{Time: int64(i) * 3600, Close: f(i)}
+	}
+	return out
+}
+
+func TestClassifyMarketRegime(t *testing.T) {
+	c := &MarketRegimeConfig{Enabled: true}
+	wiggle := func(i int) float64 { return 0.002 * math.Sin(float64(i)) }
+
+	up := marketRegimeTestCandles(125, func(i int) float64 { return 100 * math.Exp(0.003*float64(i)+wiggle(i)) })
+	if r, ok := classifyMarketRegime(up, c); !ok || r.Trend != marketTrendUp {
+		t.Fatalf("uptrend = %+v, %v", r, ok)
+	}
+	down := marketRegimeTestCandles(125, func(i int) float64 { return 100 * math.Exp(-0.003*float64(i)+wiggle(i)) })
+	if r, ok := classifyMarketRegime(down, c); !ok || r.Trend != marketTrendDown {
+		t.Fatalf("downtrend = %+v, %v", r, ok)
+	}
+
+	// Flat, calm history, then the last bars swing hard: ranging + high vol.
+	spiky := marketRegimeTestCandles(125, func(i int) float64 {
+		if i >= 110 {
+			return 100 * (1 + 0.03*math.Sin(float64(i)*2))
+		}
+		return 100 * (1 + wiggle(i))
+	})
+	r, ok := classifyMarketRegime(spiky, c)
+	if !ok || r.Trend != marketRanging || r.Vol != marketVolHigh {
+		t.Fatalf("spiky = %+v, %v", r, ok)
+	}
+	if got := r.Label(); got != "ranging/vol_high" {
+		t.Fatalf("Label = %q", got)
+	}
+
+	calm := marketRegimeTestCandles(125, func(i int) float64 {
+		if i < 60 {
+			return 100 * (1 + 0.03*math.Sin(float64(i)*2))
+		}
+		return 100 * (1 + wiggle(i))
+	})
+	if r, ok := classifyMarketRegime(calm, c); !ok || r.Vol != marketVolLow {
+		t.Fatalf("calm = %+v, %v", r, ok)
+	}
+
+	if _, ok := classifyMarketRegime(up[:30], c); ok {
+		t.Fatal("too few candles should not classify")
+	}
+}
+
+func TestMarketRegimeErrors(t *testing.T) {
+	if errs := marketRegimeErrors(&MarketRegimeConfig{Enabled: true}); len(errs) != 0 {
+		t.Fatalf("defaults should validate: %v", errs)
+	}
+	errs := marketRegimeErrors(&MarketRegimeConfig{Enabled: true, Timeframe: "7x", TrendPeriod: -1, VolWindow: 1})
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"timeframe", "trend_period", "vol_window"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("errors %v missing %q", errs, want)
+		}
+	}
+	if errs := marketRegimeErrors(&MarketRegimeConfig{Timeframe: "7x"}); len(errs) != 0 {
+		t.Fatalf("disabled block should not validate: %v", errs)
+	}
+}
+
+func TestMarketRegimeServiceFetchesOncePerBar(t *testing.T) {
+	var fetches atomic.Int32
+	up := marketRegimeTestCandles(125, func(i int) float64 { return 100 * math.Exp(0.003*float64(i)) })
+	s := &MarketRegimeService{fetch: func(req UICandleRequest) ([]UICandle, string, error) {
+		fetches.Add(1)
+		if req.Strategy.Timeframe != "1h" || req.Limit < 100 {
+			t.Errorf("unexpected request %+v", req)
+		}
+		return up, "test", nil
+	}}
+	c := &MarketRegimeConfig{Enabled: true}
+	due := []StrategyConfig{
+		{ID: "a", Type: "perps", Platform: "hyperliquid", Args: []string{"sma", "BTC", "15m"}},
+		{ID: "b", Type: "perps", Platform: "hyperliquid", Args: []string{"rsi", "BTC", "4h"}},
+		{ID: "opt", Type: "options", Platform: "deribit", Args: []string{"vol", "BTC"}},
+	}
+	now := time.Date(2026, 5, 1, 10, 5, 0, 0, time.UTC)
+	s.StartRefresh(c, due, now)()
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("fetches = %d, want 1 shared fetch", got)
+	}
+	if got := s.LabelForStrategy(due[1]); got != "trending_up/vol_low" && got != "trending_up/vol_high" {
+		t.Fatalf("label = %q", got)
+	}
+	if got := s.LabelForStrategy(due[2]); got != "" {
+		t.Fatalf("options should have no label, got %q", got)
+	}
+	if got := s.LabelForAsset("BTC"); !strings.HasPrefix(got, "trending_up/") {
+		t.Fatalf("LabelForAsset = %q", got)
+	}
+
+	s.StartRefresh(c, due, now.Add(30*time.Minute))()
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("same bar refetched: fetches = %d", got)
+	}
+	s.StartRefresh(c, due, now.Add(time.Hour))()
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("new bar not refetched: fetches = %d", got)
+	}
+
+	s.StartRefresh(nil, due, now)()
+	if got := s.LabelForStrategy(due[0]); got != "" {
+		t.Fatalf("disabled service should clear labels, got %q", got)
+	}
+}
+
+func TestAppendMarketRegimeArg(t *testing.T) {
+	orig := globalMarketRegimes
+	t.Cleanup(func() { globalMarketRegimes = orig })
+	sc := StrategyConfig{ID: "a", Type: "spot", Platform: "binanceus", Args: []string{"sma", "BTC/USDT", "1h"}}
+	globalMarketRegimes = &MarketRegimeService{}
+	if got := appendMarketRegimeArg([]string{"x"}, sc); len(got) != 1 {
+		t.Fatalf("no label should leave args alone, got %v", got)
+	}
+	key, _ := marketRegimeKeyFor(sc)
+	globalMarketRegimes.entries = map[marketRegimeKey]*marketRegimeEntry{
+		key: {regime: MarketRegime{Trend: marketRanging, Vol: marketVolLow}},
+	}
+	got := appendMarketRegimeArg([]string{"x"}, sc)
+	if got[len(got)-1] != "--market-regime=ranging/vol_low" {
+		t.Fatalf("args = %v", got)
+	}
+}
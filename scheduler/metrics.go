@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// handleMetrics serves Prometheus text-format metrics (exposition format
+// 0.0.4). Same bearer-token rule as /status. Renders from the readState
+// snapshot (#4919) so neither a slow scraper nor a long cycle blocks the other.
+func (ss *StatusServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if !ss.requireAPIAuth(w, r) {
+		return
+	}
+	body := renderPrometheusMetrics(ss.readState()) + renderLatencyMetrics() + renderPythonPoolMetrics()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(body))
+}
+
+// renderPrometheusMetrics renders the scheduler's metrics. Caller holds at
+// least mu.RLock or passes a readState snapshot. Output ordering is deterministic (sorted label values).
+func renderPrometheusMetrics(state *AppState) string {
+	var b strings.Builder
+	writePromHeader(&b, "go_trader_cycle_count", "counter", "Scheduler cycles started since the state DB was created.")
+	fmt.Fprintf(&b, "go_trader_cycle_count %d\n", state.CycleCount)
+	if !state.LastCycle.IsZero() {
+		writePromHeader(&b, "go_trader_last_cycle_timestamp_seconds", "gauge", "Unix time the most recent cycle finished.")
+		fmt.Fprintf(&b, "go_trader_last_cycle_timestamp_seconds %d\n", state.LastCycle.Unix())
+	}
+
+	if len(state.CycleTimings) > 0 {
+		last := state.CycleTimings[len(state.CycleTimings)-1]
+		writePromHeader(&b, "go_trader_cycle_duration_seconds", "gauge", "Duration of the most recent completed cycle by phase.")
+		for _, p := range []struct {
+			phase string
+			ms    int64
+		}{
+			{"total", last.TotalMs},
+			{"price_fetch", last.PriceFetchMs},
+			{"subprocess", last.SubprocessMs},
+			{"marking", last.MarkingMs},
+			{"save", last.SaveMs},
+		} {
+			fmt.Fprintf(&b, "go_trader_cycle_duration_seconds{phase=%q} %s\n", p.phase, promSeconds(p.ms))
+		}
+		writePromHeader(&b, "go_trader_tick_interval_seconds", "gauge", "Tick interval in force for the most recent cycle.")
+		fmt.Fprintf(&b, "go_trader_tick_interval_seconds %d\n", last.TickSeconds)
+
+		sum := summarizeCycleTimings(state.CycleTimings)
+		writePromHeader(&b, "go_trader_cycle_window_samples", "gauge", "Cycles in the rolling timing window.")
+		fmt.Fprintf(&b, "go_trader_cycle_window_samples %d\n", sum.Samples)
+		writePromHeader(&b, "go_trader_cycle_window_slow_cycles", "gauge", "Cycles in the rolling window that exceeded the tick interval.")
+		fmt.Fprintf(&b, "go_trader_cycle_window_slow_cycles %d\n", sum.SlowCycles)
+		writePromHeader(&b, "go_trader_cycle_window_duration_seconds", "gauge", "Average and maximum total cycle duration over the rolling window.")
+		fmt.Fprintf(&b, "go_trader_cycle_window_duration_seconds{stat=\"avg\"} %s\n", promSeconds(sum.AvgTotalMs))
+		fmt.Fprintf(&b, "go_trader_cycle_window_duration_seconds{stat=\"max\"} %s\n", promSeconds(sum.MaxTotalMs))
+
+		if len(last.Strategies) > 0 {
+			ids := make([]string, 0, len(last.Strategies))
+			for id := range last.Strategies {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			writePromHeader(&b, "go_trader_strategy_duration_seconds", "gauge", "Per-strategy duration in the most recent completed cycle, by phase.")
+			for _, id := range ids {
+				t := last.Strategies[id]
+				label := promLabelValue(id)
+				fmt.Fprintf(&b, "go_trader_strategy_duration_seconds{strategy=\"%s\",phase=\"total\"} %s\n", label, promSeconds(t.TotalMs))
+				fmt.Fprintf(&b, "go_trader_strategy_duration_seconds{strategy=\"%s\",phase=\"subprocess\"} %s\n", label, promSeconds(t.SubprocessMs))
+				fmt.Fprintf(&b, "go_trader_strategy_duration_seconds{strategy=\"%s\",phase=\"marking\"} %s\n", label, promSeconds(t.MarkingMs))
+			}
+		}
+	}
+	return b.String()
+}
+
+func writePromHeader(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func promSeconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// promLabelValue escapes a label value per the exposition format
+// (backslash, double quote, newline).
+func promLabelValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return strings.ReplaceAll(v, "\n", `\n`)
+}
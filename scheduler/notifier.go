@@ -32,6 +32,7 @@ type notifierBackend struct {
 type MultiNotifier struct {
 	mu       sync.RWMutex
 	backends []notifierBackend
+	outbox   *notifyOutbox // #4920 async delivery for *Async sends; nil = synchronous
 }
 
 // NewMultiNotifier creates a MultiNotifier from backend descriptors.
@@ -378,3 +379,15 @@ func (m *MultiNotifier) DiscordBackend() *DiscordNotifier {
 	}
 	return nil
 }
+
+// telegramBackend returns the registered *TelegramNotifier, or nil.
+func (m *MultiNotifier) telegramBackend() *TelegramNotifier {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, b := range m.backends {
+		if t, ok := b.notifier.(*TelegramNotifier); ok {
+			return t
+		}
+	}
+	return nil
+}
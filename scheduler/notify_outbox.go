@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// notifyOutbox is the outbound notification queue (#4920): a single worker
+// drains sends in FIFO order so the cycle never waits on a slow Discord or
+// Telegram API call. Messages are fully formatted by the caller (under mu
+// when they read state) before they are enqueued; the worker touches no
+// AppState. Order across enqueues is preserved — one channel's summary pages
+// arrive in sequence.
+type notifyOutbox struct {
+	ch      chan func()
+	pending sync.WaitGroup
+}
+
+// notifyOutboxCap bounds queued sends. A full queue falls back to sending
+// inline on the caller (already outside mu), trading latency for never
+// dropping a message.
+const notifyOutboxCap = 256
+
+func newNotifyOutbox(capacity int) *notifyOutbox {
+	o := &notifyOutbox{ch: make(chan func(), capacity)}
+	go o.run()
+	return o
+}
+
+func (o *notifyOutbox) run() {
+	for send := range o.ch {
+		send()
+		o.pending.Done()
+	}
+}
+
+// enqueue queues send, running it inline when the outbox is nil (tests,
+// CLIs) or full.
+func (o *notifyOutbox) enqueue(send func()) {
+	if o == nil {
+		send()
+		return
+	}
+	o.pending.Add(1)
+	select {
+	case o.ch <- send:
+	default:
+		o.pending.Done()
+		fmt.Println("[WARN] notification outbox full; sending inline")
+		send()
+	}
+}
+
+// flush waits up to timeout for queued sends to finish. Returns false when
+// sends were still pending at the deadline.
+func (o *notifyOutbox) flush(timeout time.Duration) bool {
+	if o == nil {
+		return true
+	}
+	done := make(chan struct{})
+	go func() {
+		o.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// StartOutbox enables asynchronous delivery for the *Async send methods.
+// Without it (tests, one-shot CLIs) they send synchronously.
+func (m *MultiNotifier) StartOutbox() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.outbox == nil {
+		m.outbox = newNotifyOutbox(notifyOutboxCap)
+	}
+}
+
+func (m *MultiNotifier) currentOutbox() *notifyOutbox {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.outbox
+}
+
+// SendToChannelAsync is SendToChannel routed through the outbox. Call it
+// with the final message text and without holding the state mutex.
+func (m *MultiNotifier) SendToChannelAsync(platform, stratType, content string) {
+	m.currentOutbox().enqueue(func() { m.SendToChannel(platform, stratType, content) })
+}
+
+// FlushOutbox waits up to timeout for queued notifications (shutdown,
+// --once exit) so the last cycle's summaries are not lost.
+func (m *MultiNotifier) FlushOutbox(timeout time.Duration) {
+	if !m.currentOutbox().flush(timeout) {
+		fmt.Printf("[WARN] notification outbox not drained after %s; remaining messages dropped\n", timeout)
+	}
+}
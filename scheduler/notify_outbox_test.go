@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// blockingNotifier parks every SendMessage until release is closed.
+type blockingNotifier struct {
+	mockNotifier
+	release chan struct{}
+}
+
+func (b *blockingNotifier) SendMessage(channelID, content string) error {
+	<-b.release
+	return b.mockNotifier.SendMessage(channelID, content)
+}
+
+func TestSendToChannelAsyncDoesNotBlockAndKeepsOrder(t *testing.T) {
+	bn := &blockingNotifier{release: make(chan struct{})}
+	m := NewMultiNotifier(notifierBackend{notifier: bn, channels: map[string]string{"spot": "ch-spot"}})
+	m.StartOutbox()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		m.SendToChannelAsync("spot", "spot", fmt.Sprintf("page %d", i))
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("SendToChannelAsync blocked on a slow backend")
+	}
+	close(bn.release)
+	m.FlushOutbox(5 * time.Second)
+
+	bn.mu.Lock()
+	defer bn.mu.Unlock()
+	if len(bn.messages) != 5 {
+		t.Fatalf("delivered %d messages, want 5", len(bn.messages))
+	}
+	for i, msg := range bn.messages {
+		if want := fmt.Sprintf("page %d", i); msg.content != want || msg.channelID != "ch-spot" {
+			t.Errorf("message %d = %+v, want %q on ch-spot", i, msg, want)
+		}
+	}
+}
+
+func TestSendToChannelAsyncWithoutOutboxIsSynchronous(t *testing.T) {
+	mn := &mockNotifier{}
+	m := NewMultiNotifier(notifierBackend{notifier: mn, channels: map[string]string{"spot": "ch-spot"}})
+	m.SendToChannelAsync("spot", "spot", "hello")
+	if len(mn.messages) != 1 {
+		t.Fatalf("messages = %d, want 1 delivered inline", len(mn.messages))
+	}
+}
+
+func TestNotifyOutboxFullSendsInline(t *testing.T) {
+	o := &notifyOutbox{ch: make(chan func())} // unbuffered, no worker
+	ran := false
+	o.enqueue(func() { ran = true })
+	if !ran {
+		t.Fatal("full outbox must fall back to an inline send")
+	}
+	if !o.flush(time.Second) {
+		t.Fatal("inline fallback left a pending count behind")
+	}
+}
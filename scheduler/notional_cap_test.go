@@ -217,7 +217,7 @@ func TestManualOpenCoreRefusesNotionalHold(t *testing.T) {
 			return manualStateView{HasStrategy: true, NotionalHold: true,
 				NotionalNote: "portfolio notional $60000.00 exceeds cap $50000.00 — new opens blocked, exits continue"}, nil
 		},
-		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 			t.Error("execute must not be called while the notional cap is breached")
 			return nil, "", nil
 		},
@@ -240,7 +240,7 @@ func TestManualAddCoreRefusesNotionalHold(t *testing.T) {
 			return manualStateView{HasStrategy: true, Pos: pos, NotionalHold: true,
 				NotionalNote: "portfolio notional $60000.00 exceeds cap $50000.00 — new opens blocked, exits continue"}, nil
 		},
-		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, ...int64) (*HyperliquidExecuteResult, string, error) {
+		execute: func(string, string, string, float64, float64, int64, float64, string, float64, bool, hlExecuteSnapshot, string, ...int64) (*HyperliquidExecuteResult, string, error) {
 			t.Error("execute must not be called while the notional cap is breached")
 			return nil, "", nil
 		},
@@ -0,0 +1,255 @@
+package main
+
+// openapi: machine-readable schema of the status API (#4934).
+//
+// GET /openapi.json serves an OpenAPI 3.0 document whose response schemas are
+// reflected from the same Go structs the handlers encode (StatusResp,
+// StrategyDetail, RiskReport, ...), so the schema cannot drift from the wire
+// format. openAPISpecVersion is the contract version clients generate
+// against: bump the minor for additive changes and the major when a field is
+// removed or changes type. The daemon build Version is reported separately as
+// x-build-version.
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// openAPISpecVersion is the versioned status API contract (info.version).
+const openAPISpecVersion = "1.0.0"
+
+// apiParam is one documented query or path parameter.
+type apiParam struct {
+	Name, In, Type, Description string
+}
+
+// apiRoute is one documented read endpoint. Response is a zero value of the
+// encoded type; nil documents a free-form object.
+type apiRoute struct {
+	Path, Summary string
+	Params        []apiParam
+	Response      any
+	Public        bool // no bearer token required
+}
+
+// statusAPIRoutes lists the documented endpoints. Add an entry here when a
+// new read endpoint is registered in Start.
+var statusAPIRoutes = []apiRoute{
+	{Path: "/health", Summary: "Liveness and build version", Public: true, Response: struct {
+		Status  string `json:"status"`
+		Version string `json:"version"`
+		PID     int    `json:"pid"`
+		Reason  string `json:"reason,omitempty"`
+	}{}},
+	{Path: "/status", Summary: "Full scheduler state with live marks", Response: StatusResp{}},
+	{Path: "/history", Summary: "Paginated trade history from the state DB", Response: HistoryResp{}, Params: []apiParam{
+		{"strategy", "query", "string", "strategy ID filter"},
+		{"symbol", "query", "string", "symbol filter"},
+		{"since", "query", "string", "RFC3339 lower bound"},
+		{"until", "query", "string", "RFC3339 upper bound"},
+		{"limit", "query", "integer", "page size (default 50)"},
+		{"offset", "query", "integer", "page offset"},
+	}},
+	{Path: "/prices/history", Summary: "Recorded per-cycle prices for one symbol", Response: struct {
+		Symbol    string       `json:"symbol"`
+		From      time.Time    `json:"from"`
+		To        time.Time    `json:"to"`
+		Points    []PricePoint `json:"points"`
+		Truncated bool         `json:"truncated"`
+	}{}, Params: []apiParam{
+		{"symbol", "query", "string", "price key, e.g. BTC/USDT (required)"},
+		{"from", "query", "string", "RFC3339 or unix seconds (default 24h ago)"},
+		{"to", "query", "string", "RFC3339 or unix seconds (default now)"},
+		{"limit", "query", "integer", "max points"},
+	}},
+	{Path: "/signals", Summary: "Every evaluated signal with its outcome, newest first", Response: struct {
+		Signals []SignalRecord `json:"signals"`
+	}{}, Params: []apiParam{
+		{"strategy", "query", "string", "strategy ID filter"},
+		{"outcome", "query", "string", "executed, blocked, hold, no_trade or not_executed"},
+		{"limit", "query", "integer", "max records (default 100, max 1000)"},
+	}},
+	{Path: "/strategies/{id}", Summary: "One strategy's config, state, positions and activity", Response: StrategyDetail{}, Params: []apiParam{
+		{"id", "path", "string", "strategy ID"},
+		{"trades", "query", "integer", "recent trades to include (default 20)"},
+	}},
+	{Path: "/risk", Summary: "Portfolio and per-strategy risk usage against limits", Response: RiskReport{}},
+	{Path: "/api/attribution", Summary: "Realized PnL grouped by trade tag", Response: struct {
+		By       string           `json:"by"`
+		Strategy string           `json:"strategy"`
+		Days     int              `json:"days"`
+		Rows     []TagAttribution `json:"rows"`
+	}{}, Params: []apiParam{
+		{"by", "query", "string", "tag key: reason, regime, signal or source (default reason)"},
+		{"strategy", "query", "string", "strategy ID filter"},
+		{"days", "query", "integer", "lookback in days"},
+	}},
+	{Path: "/openapi.json", Summary: "This document", Public: true},
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  []byte
+)
+
+// buildOpenAPISpec assembles the OpenAPI document from statusAPIRoutes.
+func buildOpenAPISpec() map[string]any {
+	components := map[string]any{}
+	paths := map[string]any{}
+	for _, rt := range statusAPIRoutes {
+		schema := map[string]any{"type": "object"}
+		if rt.Response != nil {
+			schema = openAPISchema(reflect.TypeOf(rt.Response), components)
+		}
+		op := map[string]any{
+			"summary": rt.Summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+				},
+			},
+		}
+		if rt.Public {
+			op["security"] = []any{}
+		}
+		if len(rt.Params) > 0 {
+			params := make([]any, 0, len(rt.Params))
+			for _, p := range rt.Params {
+				params = append(params, map[string]any{
+					"name":        p.Name,
+					"in":          p.In,
+					"required":    p.In == "path",
+					"description": p.Description,
+					"schema":      map[string]any{"type": p.Type},
+				})
+			}
+			op["parameters"] = params
+		}
+		paths[rt.Path] = map[string]any{"get": op}
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":           "go-trader status API",
+			"version":         openAPISpecVersion,
+			"x-build-version": Version,
+		},
+		"paths":    paths,
+		"security": []any{map[string]any{"bearerAuth": []any{}}},
+		"components": map[string]any{
+			"schemas": components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "description": "STATUS_AUTH_TOKEN; only enforced when set"},
+			},
+		},
+	}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// openAPISchema returns the schema for t, registering named structs under
+// components and referencing them, so recursive types terminate.
+func openAPISchema(t reflect.Type, components map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		return map[string]any{} // custom encoding; shape not derivable
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": openAPISchema(t.Elem(), components)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": openAPISchema(t.Elem(), components)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return openAPIStructSchema(t, components)
+		}
+		if _, ok := components[t.Name()]; !ok {
+			components[t.Name()] = map[string]any{} // placeholder breaks cycles
+			components[t.Name()] = openAPIStructSchema(t, components)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+// openAPIStructSchema follows encoding/json field rules: json tag names,
+// "-" skipped, unexported skipped, embedded structs flattened, and
+// omitempty fields optional.
+func openAPIStructSchema(t reflect.Type, components map[string]any) map[string]any {
+	props := map[string]any{}
+	var required []string
+	var walk func(reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			ft := f.Type
+			if f.Anonymous && name == "" {
+				for ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = openAPISchema(ft, components)
+			if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+				required = append(required, name)
+			}
+		}
+	}
+	walk(t)
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (ss *StatusServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	openAPIOnce.Do(func() {
+		openAPIDoc, _ = json.Marshal(buildOpenAPISpec())
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDoc)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	ss := &StatusServer{}
+	rec := httptest.NewRecorder()
+	ss.handleOpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	var doc struct {
+		Info       struct{ Version string }
+		Paths      map[string]json.RawMessage
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage
+			}
+		}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Info.Version != openAPISpecVersion || len(doc.Paths) != len(statusAPIRoutes) {
+		t.Fatalf("info = %+v, %d paths", doc.Info, len(doc.Paths))
+	}
+	if _, ok := doc.Components.Schemas["StatusResp"].Properties["cycle_count"]; !ok {
+		t.Fatal("StatusResp.cycle_count missing")
+	}
+	// Embedded *Position fields are flattened like encoding/json does.
+	if _, ok := doc.Components.Schemas["StrategyDetailPosition"].Properties["avg_cost"]; !ok {
+		t.Fatalf("StrategyDetailPosition props = %v", doc.Components.Schemas["StrategyDetailPosition"].Properties)
+	}
+	if _, ok := doc.Components.Schemas["Trade"].Properties["timestamp"]; !ok {
+		t.Fatal("Trade.timestamp missing")
+	}
+
+	// Every $ref resolves.
+	body := rec.Body.String()
+	for _, part := range strings.Split(body, `"$ref":"#/components/schemas/`)[1:] {
+		name := part[:strings.IndexByte(part, '"')]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("dangling $ref %q", name)
+		}
+	}
+}
@@ -22,6 +22,12 @@ type OptionPosition struct {
 	CurrentValueUSD float64   `json:"current_value_usd"`
 	Greeks          OptGreeks `json:"greeks"`
 	OpenedAt        time.Time `json:"opened_at"`
+	HarvestPeakPct  float64   `json:"harvest_peak_pct,omitempty"` // best premium capture seen once the theta-harvest trail armed (#4969)
+	// HarvestOverride replaces individual theta_harvest thresholds for this
+	// position only, set from /go-trader-harvest or `go-trader harvest-override`.
+	HarvestOverride *ThetaHarvestOverride `json:"harvest_override,omitempty"`
+	// Metadata is free-form context, as on Position (#4971).
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // OptGreeks holds option Greeks.
@@ -371,11 +377,11 @@ func EncodeAllPositionsJSON(optPos map[string]*OptionPosition, spotPos map[strin
 }
 
 // CheckThetaHarvest evaluates open options positions for early exit.
+// A position's HarvestOverride (#4970) takes precedence over cfg field by
+// field, so an overridden position can be harvested even when the strategy's
+// block is off, or skipped when it is on.
 // Returns trade details for any positions that were closed.
-func CheckThetaHarvest(s *StrategyState, cfg *ThetaHarvestConfig, logger *StrategyLogger) (int, []string) {
-	if cfg == nil || !cfg.Enabled {
-		return 0, nil
-	}
+func CheckThetaHarvest(s *StrategyState, base *ThetaHarvestConfig, logger *StrategyLogger) (int, []string) {
 
 	trades := 0
 	var details []string
@@ -392,6 +398,10 @@ func CheckThetaHarvest(s *StrategyState, cfg *ThetaHarvestConfig, logger *Strate
 		if pos.Action != "sell" {
 			continue
 		}
+		cfg := effectiveThetaHarvest(base, pos.HarvestOverride)
+		if cfg == nil || !cfg.Enabled {
+			continue
+		}
 
 		entryPremium := pos.EntryPremiumUSD
 		if entryPremium <= 0 {
@@ -408,6 +418,21 @@ func CheckThetaHarvest(s *StrategyState, cfg *ThetaHarvestConfig, logger *Strate
 		profitUSD := entryPremium - currentCost
 		profitPct := (profitUSD / entryPremium) * 100
 
+		// Trailing mode (#4969): arm at the trigger, then close when the
+		// capture retraces from its best.
+		if cfg.TrailingTriggerPct > 0 && cfg.TrailingRetracePct > 0 {
+			if profitPct >= cfg.TrailingTriggerPct && profitPct > pos.HarvestPeakPct {
+				pos.HarvestPeakPct = profitPct
+			}
+			if pos.HarvestPeakPct > 0 && profitPct <= pos.HarvestPeakPct-cfg.TrailingRetracePct {
+				toClose = append(toClose, closeAction{
+					id:     id,
+					reason: fmt.Sprintf("📉 Theta harvest trail: capture %.0f%% retraced from %.0f%% peak ($%.2f of $%.2f premium)", profitPct, pos.HarvestPeakPct, profitUSD, entryPremium),
+				})
+				continue
+			}
+		}
+
 		// Check profit target (e.g. captured 60% of premium)
 		if cfg.ProfitTargetPct > 0 && profitPct >= cfg.ProfitTargetPct {
 			toClose = append(toClose, closeAction{
@@ -487,3 +512,73 @@ func CheckThetaHarvest(s *StrategyState, cfg *ThetaHarvestConfig, logger *Strate
 
 	return trades, details
 }
+
+// CheckLongOptionExits evaluates open bought options for early exit: a
+// take-profit on the value gained over the premium paid, a stop on the
+// premium lost, and a DTE floor so a long call/put is sold while it still
+// has time value instead of decaying to worthless. Returns trade details for
+// any positions that were closed.
+func CheckLongOptionExits(s *StrategyState, cfg *LongOptionExitConfig, logger *StrategyLogger) (int, []string) {
+	if cfg == nil || !cfg.Enabled {
+		return 0, nil
+	}
+
+	type closeAction struct {
+		id     string
+		reason string
+	}
+	var toClose []closeAction
+	for id, pos := range s.OptionPositions {
+		if pos.Action != "buy" || pos.EntryPremiumUSD <= 0 {
+			continue
+		}
+		gainPct := (pos.CurrentValueUSD - pos.EntryPremiumUSD) / pos.EntryPremiumUSD * 100
+		switch {
+		case cfg.ProfitTargetPct > 0 && gainPct >= cfg.ProfitTargetPct:
+			toClose = append(toClose, closeAction{id: id, reason: fmt.Sprintf("🎯 Long option take-profit: +%.0f%% on $%.2f premium", gainPct, pos.EntryPremiumUSD)})
+		case cfg.StopLossPct > 0 && -gainPct >= cfg.StopLossPct:
+			toClose = append(toClose, closeAction{id: id, reason: fmt.Sprintf("🛑 Long option stop: %.0f%% of $%.2f premium lost", -gainPct, pos.EntryPremiumUSD)})
+		case cfg.MinDTEClose > 0 && pos.DTE > 0 && pos.DTE <= cfg.MinDTEClose:
+			toClose = append(toClose, closeAction{id: id, reason: fmt.Sprintf("⏰ Long option DTE exit: %.1f days to expiry (min: %.0f)", pos.DTE, cfg.MinDTEClose)})
+		}
+	}
+
+	trades := 0
+	var details []string
+	for _, c := range toClose {
+		pos := s.OptionPositions[c.id]
+		value := pos.CurrentValueUSD
+		if value < 0 {
+			value = 0
+		}
+		pnl := value - pos.EntryPremiumUSD
+		s.Cash += value
+
+		now := time.Now().UTC()
+		trade := Trade{
+			Timestamp:   now,
+			StrategyID:  s.ID,
+			Symbol:      pos.ID,
+			PositionID:  ensureOptionTradeID(s.ID, pos),
+			Side:        optionCloseTradeSide(pos.Action),
+			Quantity:    pos.Quantity,
+			Price:       value,
+			Value:       value,
+			TradeType:   "options",
+			Details:     fmt.Sprintf("Long option exit close %s PnL=$%.2f", pos.ID, pnl),
+			IsClose:     true,
+			RealizedPnL: pnl,
+			PnLGross:    true, // no fee modeled on option closes: gross == net
+		}
+		trade.Regime = s.Regime
+		RecordTrade(s, trade)
+		RecordTradeResult(&s.RiskState, pnl)
+		recordClosedOptionPosition(s, pos, value, pnl, "long_option_exit", now)
+
+		logger.Info("%s | %s | PnL: $%.2f", c.reason, pos.ID, pnl)
+		details = append(details, fmt.Sprintf("[%s] CLOSE %s — %s (PnL: $%.2f)", s.ID, pos.ID, c.reason, pnl))
+		delete(s.OptionPositions, c.id)
+		trades++
+	}
+	return trades, details
+}
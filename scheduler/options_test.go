@@ -411,3 +411,99 @@ func TestCheckThetaHarvestSkipsBuyPositions(t *testing.T) {
 		t.Error("should not harvest buy positions")
 	}
 }
+
+func TestCheckLongOptionExits(t *testing.T) {
+	newState := func() *StrategyState {
+		return &StrategyState{
+			ID:   "test",
+			Cash: 5000,
+			OptionPositions: map[string]*OptionPosition{
+				"winner":  {ID: "winner", Action: "buy", EntryPremiumUSD: 100, CurrentValueUSD: 250, DTE: 20, Quantity: 1},
+				"loser":   {ID: "loser", Action: "buy", EntryPremiumUSD: 100, CurrentValueUSD: 40, DTE: 20, Quantity: 1},
+				"decay":   {ID: "decay", Action: "buy", EntryPremiumUSD: 100, CurrentValueUSD: 90, DTE: 2, Quantity: 1},
+				"holding": {ID: "holding", Action: "buy", EntryPremiumUSD: 100, CurrentValueUSD: 120, DTE: 20, Quantity: 1},
+				"sold":    {ID: "sold", Action: "sell", EntryPremiumUSD: 100, CurrentValueUSD: -10, DTE: 1, Quantity: 1},
+			},
+			Positions: make(map[string]*Position),
+		}
+	}
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+
+	s := newState()
+	if trades, _ := CheckLongOptionExits(s, &LongOptionExitConfig{Enabled: false, ProfitTargetPct: 100}, logger); trades != 0 {
+		t.Fatalf("disabled config closed %d positions", trades)
+	}
+
+	cfg := &LongOptionExitConfig{Enabled: true, ProfitTargetPct: 100, StopLossPct: 50, MinDTEClose: 3}
+	trades, details := CheckLongOptionExits(s, cfg, logger)
+	if trades != 3 || len(details) != 3 {
+		t.Fatalf("trades=%d details=%v, want winner, loser and decay closed", trades, details)
+	}
+	for _, id := range []string{"winner", "loser", "decay"} {
+		if _, open := s.OptionPositions[id]; open {
+			t.Errorf("%s should be closed", id)
+		}
+	}
+	for _, id := range []string{"holding", "sold"} {
+		if _, open := s.OptionPositions[id]; !open {
+			t.Errorf("%s should stay open", id)
+		}
+	}
+	if s.Cash != 5000+250+40+90 {
+		t.Errorf("cash = %v, want the three legs sold at current value", s.Cash)
+	}
+	for _, tr := range s.TradeHistory {
+		if tr.Tags[TradeTagReason] != "long_option_exit" {
+			t.Errorf("trade %s tagged %q", tr.Symbol, tr.Tags[TradeTagReason])
+		}
+	}
+}
+
+func TestCheckThetaHarvestTrailing(t *testing.T) {
+	s := &StrategyState{
+		ID:   "test",
+		Cash: 5000,
+		OptionPositions: map[string]*OptionPosition{
+			"pos1": {ID: "pos1", Action: "sell", EntryPremiumUSD: 100, CurrentValueUSD: -50, Quantity: 1},
+		},
+		Positions: make(map[string]*Position),
+	}
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	cfg := &ThetaHarvestConfig{Enabled: true, StopLossPct: 200, TrailingTriggerPct: 60, TrailingRetracePct: 10}
+	pos := s.OptionPositions["pos1"]
+
+	steps := []struct {
+		value     float64 // CurrentValueUSD (negative liability)
+		wantPeak  float64
+		wantClose bool
+	}{
+		{-50, 0, false},  // 50% captured: trail not armed
+		{-35, 65, false}, // armed at 65%
+		{-15, 85, false}, // steady decay raises the peak past a fixed 60% target
+		{-22, 85, false}, // 78%: within the 10-point retrace
+		{-26, 85, true},  // 74%: gave back 11 points
+	}
+	for i, st := range steps {
+		pos.CurrentValueUSD = st.value
+		trades, details := CheckThetaHarvest(s, cfg, logger)
+		if (trades == 1) != st.wantClose {
+			t.Fatalf("step %d: trades=%d, want close=%v", i, trades, st.wantClose)
+		}
+		if st.wantClose {
+			if !strings.Contains(details[0], "retraced from 85% peak") {
+				t.Errorf("detail = %q", details[0])
+			}
+			break
+		}
+		if pos.HarvestPeakPct != st.wantPeak {
+			t.Fatalf("step %d: peak = %v, want %v", i, pos.HarvestPeakPct, st.wantPeak)
+		}
+	}
+	if _, open := s.OptionPositions["pos1"]; open {
+		t.Error("position should be closed by the trail")
+	}
+}
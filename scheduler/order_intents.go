@@ -0,0 +1,543 @@
+package main
+
+// order_intents: crash-safe journal for live orders (#4916).
+//
+// Every live order gets an idempotency key and an order_intents row BEFORE
+// the executor script runs (Phase 3). On HL and OKX the key is also the
+// order's client order ID (cloid / clOrdId), so the venue can be asked what
+// became of it later. The row is settled with the exchange outcome as soon as
+// the script returns and deleted once Phase 4 has applied the fill to state
+// (the trade row itself is persisted eagerly by tradeRecorder). A row that is
+// still present at startup means the process died between the order and the
+// state mutation: reconcileOrderIntents looks unsettled HL/OKX orders up by
+// client order ID, checks the trades table for the fill's exchange order ID,
+// and reports every fill that never reached state to the owner. Those rows
+// stay in the journal (status "unapplied") and are re-reported at each
+// startup until the operator verifies the venue and clears them with
+// `go-trader order-intents --resolve <key>`.
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	orderIntentPending   = "pending"   // journaled, executor not yet returned
+	orderIntentFilled    = "filled"    // exchange acknowledged a fill
+	orderIntentUnknown   = "unknown"   // executor failed without a venue verdict (timeout, crash) — may have filled
+	orderIntentUnapplied = "unapplied" // found at startup with a fill that never reached state; kept until resolved
+)
+
+// OrderIntent is a row from the order_intents journal.
+type OrderIntent struct {
+	Key             string
+	StrategyID      string
+	Platform        string
+	Symbol          string
+	Side            string
+	Size            float64
+	Status          string
+	FillQty         float64
+	FillPrice       float64
+	ExchangeOrderID string
+	Error           string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// orderIntentJournal is the package-level journal (wired to the state DB at
+// startup). nil disables journaling — tests and CLI subcommands that place no
+// scheduler orders leave it unset.
+var orderIntentJournal *StateDB
+
+// newOrderIntentKey mints a unique idempotency key for one intended order:
+// 32 hex characters (16 bytes), which OKX accepts as a clOrdId as-is and HL
+// as a cloid with a 0x prefix (orderIntentClientID). The strategy is on the
+// row, so the key carries no other structure.
+func newOrderIntentKey(now time.Time) string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%032x", now.UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// orderIntentClientID is the client order ID sent to platform for key, or ""
+// when the venue has no client-ID lookup (or journaling is off).
+func orderIntentClientID(platform, key string) string {
+	if key == "" {
+		return ""
+	}
+	switch platform {
+	case "hyperliquid":
+		return "0x" + key
+	case "okx":
+		return key
+	}
+	return ""
+}
+
+// beginOrderIntent journals an intended live order and returns its key ("" when
+// journaling is disabled or the insert failed — the order still proceeds; a
+// journal write failure must not block trading).
+func beginOrderIntent(sc StrategyConfig, symbol, side string, size float64, logger *StrategyLogger) string {
+	return orderIntentJournal.beginIntent(sc, symbol, side, size, logger)
+}
+
+// beginIntent is beginOrderIntent against an explicit journal; the manual
+// cores use their own state DB handle (nil disables journaling).
+func (sdb *StateDB) beginIntent(sc StrategyConfig, symbol, side string, size float64, logger *StrategyLogger) string {
+	if sdb == nil {
+		return ""
+	}
+	now := time.Now().UTC()
+	intent := OrderIntent{
+		Key:        newOrderIntentKey(now),
+		StrategyID: sc.ID,
+		Platform:   sc.Platform,
+		Symbol:     symbol,
+		Side:       side,
+		Size:       size,
+		Status:     orderIntentPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := sdb.InsertOrderIntent(intent); err != nil {
+		logger.Warn("order intent journal insert failed for %s %s: %v", side, symbol, err)
+		return ""
+	}
+	return intent.Key
+}
+
+// orderIntentOutcome is implemented by each venue's execute result so the
+// journal can settle an intent without knowing the result shape. Receivers
+// must tolerate nil (an executor that failed before producing JSON).
+type orderIntentOutcome interface {
+	intentOutcome() (venueErr string, fillQty, fillPrice float64, exchangeOrderID string)
+}
+
+// settleOrderIntent records the executor outcome for key. execErr is the
+// process-level error (outcome unknown, row kept as "unknown") unless its
+// text is a confirmed venue rejection (liveExecRejected — e.g. the #4958
+// rate-limit retries); a venue rejection means nothing filled and drops the
+// row. Otherwise the row becomes "filled" with the fill details.
+func settleOrderIntent(key string, execErr error, outcome orderIntentOutcome, logger *StrategyLogger) {
+	orderIntentJournal.settleIntent(key, execErr, outcome, logger)
+}
+
+func (sdb *StateDB) settleIntent(key string, execErr error, outcome orderIntentOutcome, logger *StrategyLogger) {
+	if key == "" || sdb == nil {
+		return
+	}
+	venueErr, fillQty, fillPrice, oid := outcome.intentOutcome()
+	var err error
+	switch {
+	case execErr != nil && !liveExecRejected(execErr.Error()):
+		err = sdb.UpdateOrderIntent(key, orderIntentUnknown, 0, 0, "", execErr.Error())
+	case execErr != nil, venueErr != "":
+		err = sdb.DeleteOrderIntent(key)
+	default:
+		err = sdb.UpdateOrderIntent(key, orderIntentFilled, fillQty, fillPrice, oid, "")
+	}
+	if err != nil {
+		logger.Warn("order intent journal update failed for %s: %v", key, err)
+	}
+}
+
+// dropIntent deletes key once its fill is durably recorded outside the
+// journal (a queued pending manual action), so the startup reconcile does not
+// report it as unapplied.
+func (sdb *StateDB) dropIntent(key string, logger *StrategyLogger) {
+	if key == "" || sdb == nil {
+		return
+	}
+	if err := sdb.DeleteOrderIntent(key); err != nil {
+		logger.Warn("order intent journal delete failed for %s: %v", key, err)
+	}
+}
+
+func (r *HyperliquidExecuteResult) intentOutcome() (string, float64, float64, string) {
+	if r == nil {
+		return "", 0, 0, ""
+	}
+	if r.Execution == nil || r.Execution.Fill == nil {
+		return r.Error, 0, 0, ""
+	}
+	f := r.Execution.Fill
+	oid := ""
+	if f.OID != 0 {
+		oid = fmt.Sprintf("%d", f.OID)
+	}
+	return r.Error, f.TotalSz, f.AvgPx, oid
+}
+
+func (r *OKXExecuteResult) intentOutcome() (string, float64, float64, string) {
+	if r == nil {
+		return "", 0, 0, ""
+	}
+	if r.Execution == nil || r.Execution.Fill == nil {
+		return r.Error, 0, 0, ""
+	}
+	return r.Error, r.Execution.Fill.TotalSz, r.Execution.Fill.AvgPx, r.Execution.Fill.OID
+}
+
+func (r *RobinhoodExecuteResult) intentOutcome() (string, float64, float64, string) {
+	if r == nil {
+		return "", 0, 0, ""
+	}
+	if r.Execution == nil || r.Execution.Fill == nil {
+		return r.Error, 0, 0, ""
+	}
+	return r.Error, r.Execution.Fill.Quantity, r.Execution.Fill.AvgPx, r.Execution.Fill.OID
+}
+
+func (r *TopStepExecuteResult) intentOutcome() (string, float64, float64, string) {
+	if r == nil {
+		return "", 0, 0, ""
+	}
+	if r.Execution == nil || r.Execution.Fill == nil {
+		return r.Error, 0, 0, ""
+	}
+	return r.Error, float64(r.Execution.Fill.TotalContracts), r.Execution.Fill.AvgPx, r.Execution.Fill.OID
+}
+
+// ackOrderIntents deletes the filled intents for strategyID/symbol once Phase 4
+// has applied them to state. "unknown" rows are left for the startup
+// reconcile — their fill, if any, never reached state.
+func ackOrderIntents(strategyID, symbol string, logger *StrategyLogger) {
+	if orderIntentJournal == nil {
+		return
+	}
+	if err := orderIntentJournal.AckOrderIntents(strategyID, symbol); err != nil {
+		logger.Warn("order intent journal ack failed for %s: %v", symbol, err)
+	}
+}
+
+// VenueOrderStatus is what a venue reports for an order looked up by client
+// order ID (#4916). Status is the venue's own word ("filled", "closed",
+// "canceled", "open", ...) or "not_found" when it never saw the ID.
+type VenueOrderStatus struct {
+	Status     string  `json:"status"`
+	OID        string  `json:"oid,omitempty"`
+	FilledSize float64 `json:"filled_size,omitempty"`
+	AvgPx      float64 `json:"avg_px,omitempty"`
+}
+
+// RunHyperliquidOrderStatus runs check_hyperliquid.py --order-status for cloid.
+func RunHyperliquidOrderStatus(script, symbol, cloid string, sinceMs int64) (*VenueOrderStatus, error) {
+	args := []string{
+		"--order-status",
+		fmt.Sprintf("--symbol=%s", symbol),
+		fmt.Sprintf("--cloid=%s", cloid),
+		"--mode=live",
+	}
+	if sinceMs > 0 {
+		args = append(args, fmt.Sprintf("--since-ms=%d", sinceMs))
+	}
+	stdout, stderr, err := runPythonSideEffect(script, args)
+	return parseVenueOrderStatusOutput(stdout, string(stderr), err)
+}
+
+// RunOKXOrderStatus runs check_okx.py --order-status for clientOrderID.
+func RunOKXOrderStatus(script, symbol, clientOrderID, instType string) (*VenueOrderStatus, error) {
+	args := []string{
+		"--order-status",
+		fmt.Sprintf("--symbol=%s", symbol),
+		fmt.Sprintf("--client-order-id=%s", clientOrderID),
+		"--mode=live",
+		fmt.Sprintf("--inst-type=%s", instType),
+	}
+	stdout, stderr, err := runPythonSideEffect(script, args)
+	return parseVenueOrderStatusOutput(stdout, string(stderr), err)
+}
+
+func parseVenueOrderStatusOutput(stdout []byte, stderrStr string, runErr error) (*VenueOrderStatus, error) {
+	var out struct {
+		Order *VenueOrderStatus `json:"order"`
+		Error string            `json:"error"`
+	}
+	if err := json.Unmarshal(stdout, &out); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("order status error: %w (stderr: %s)", runErr, outputSnippet(stderrStr))
+		}
+		return nil, fmt.Errorf("parse order status output: %w (stdout: %s)", err, outputSnippet(string(stdout)))
+	}
+	if out.Error != "" {
+		return nil, fmt.Errorf("order status: %s", out.Error)
+	}
+	if out.Order == nil {
+		return nil, fmt.Errorf("order status: no order in output")
+	}
+	return out.Order, nil
+}
+
+// orderIntentLookupFn asks the venue what became of in by its client order
+// ID. Returns nil, nil for venues without a client-ID lookup. Package var so
+// tests can stub the subprocess.
+var orderIntentLookupFn = lookupOrderIntentOnVenue
+
+func lookupOrderIntentOnVenue(sc StrategyConfig, in OrderIntent) (*VenueOrderStatus, error) {
+	clientID := orderIntentClientID(in.Platform, in.Key)
+	if clientID == "" || sc.Script == "" {
+		return nil, nil
+	}
+	switch in.Platform {
+	case "hyperliquid":
+		return RunHyperliquidOrderStatus(sc.Script, in.Symbol, clientID, limitStatusSinceMs(in.CreatedAt))
+	case "okx":
+		return RunOKXOrderStatus(sc.Script, in.Symbol, clientID, okxInstType(sc.Args))
+	}
+	return nil, nil
+}
+
+// resolveOrderIntentOnVenue settles a pending/unknown intent from the venue's
+// record of its client order ID. Returns the updated intent and drop=true when
+// the venue shows nothing filled (never placed, or canceled/rejected empty).
+// A failed lookup or a fill of unknown size leaves the intent as it was.
+func resolveOrderIntentOnVenue(sdb *StateDB, sc StrategyConfig, in OrderIntent) (OrderIntent, bool, error) {
+	st, err := orderIntentLookupFn(sc, in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] order intent %s: venue lookup failed: %v\n", in.Key, err)
+		return in, false, nil
+	}
+	if st == nil {
+		return in, false, nil
+	}
+	switch {
+	case st.FilledSize > 0:
+		in.Status = orderIntentFilled
+		in.FillQty = st.FilledSize
+		in.FillPrice = st.AvgPx
+		in.ExchangeOrderID = st.OID
+		in.Error = ""
+		return in, false, sdb.UpdateOrderIntent(in.Key, in.Status, in.FillQty, in.FillPrice, in.ExchangeOrderID, "")
+	case st.Status != "open" && st.Status != "filled" && st.Status != "closed":
+		return in, true, nil
+	}
+	note := "venue reports " + st.Status
+	if in.Error != "" {
+		note = in.Error + "; " + note
+	}
+	in.Error = note
+	return in, false, nil
+}
+
+// reconcileOrderIntents runs once at startup, before the first cycle.
+// Unsettled HL/OKX intents are first looked up on the venue by client order
+// ID: those that never filled are dropped. Intents whose fill is already in
+// the trades table are dropped silently. Every other row is a live order
+// whose outcome never reached state: it is returned as an operator message
+// and kept (filled rows become "unapplied" so the Phase 4 ack cannot clear
+// them) until the operator resolves it.
+func reconcileOrderIntents(sdb *StateDB, cfg *Config) ([]string, error) {
+	intents, err := sdb.LoadOrderIntents()
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, in := range intents {
+		if in.Status == orderIntentPending || in.Status == orderIntentUnknown {
+			sc, _ := findStrategyConfig(cfg, in.StrategyID)
+			var drop bool
+			if in, drop, err = resolveOrderIntentOnVenue(sdb, sc, in); err != nil {
+				return msgs, err
+			}
+			if drop {
+				if err := sdb.DeleteOrderIntent(in.Key); err != nil {
+					return msgs, err
+				}
+				continue
+			}
+		}
+		recorded := false
+		if in.ExchangeOrderID != "" {
+			if recorded, err = sdb.TradeExistsForOrderID(in.StrategyID, in.ExchangeOrderID); err != nil {
+				return msgs, err
+			}
+		}
+		if recorded {
+			if err := sdb.DeleteOrderIntent(in.Key); err != nil {
+				return msgs, err
+			}
+			continue
+		}
+		if in.Status == orderIntentFilled {
+			in.Status = orderIntentUnapplied
+			if err := sdb.UpdateOrderIntent(in.Key, in.Status, in.FillQty, in.FillPrice, in.ExchangeOrderID, in.Error); err != nil {
+				return msgs, err
+			}
+		}
+		msgs = append(msgs, formatOrderIntentReconcile(in))
+	}
+	return msgs, nil
+}
+
+func formatOrderIntentReconcile(in OrderIntent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "unacknowledged live order [%s] %s %s %s size=%g (key %s, %s, placed %s)",
+		in.StrategyID, in.Platform, in.Side, in.Symbol, in.Size, in.Key, in.Status, in.CreatedAt.Format(time.RFC3339))
+	switch in.Status {
+	case orderIntentFilled, orderIntentUnapplied:
+		fmt.Fprintf(&b, ": filled %g @ $%g (oid %s) but never applied to state", in.FillQty, in.FillPrice, in.ExchangeOrderID)
+	case orderIntentUnknown:
+		fmt.Fprintf(&b, ": executor failed (%s) — may have filled", in.Error)
+	default:
+		b.WriteString(": process stopped before the executor returned — may have filled")
+		if in.Error != "" {
+			fmt.Fprintf(&b, " (%s)", in.Error)
+		}
+	}
+	fmt.Fprintf(&b, ". Verify the position on the venue (correct state with adjust-position if needed), then clear it with `go-trader order-intents --resolve %s`.", in.Key)
+	return b.String()
+}
+
+// runOrderIntents implements `go-trader order-intents [--resolve <key>]`:
+// list the journaled live orders, or drop one after the operator has
+// verified the venue and state agree.
+func runOrderIntents(args []string) int {
+	fs := flag.NewFlagSet("order-intents", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	resolve := fs.String("resolve", "", "Intent key to clear once the venue and state agree")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, "Usage: go-trader order-intents [--config <path>] [--resolve <key>]")
+		return 2
+	}
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+
+	intents, err := stateDB.LoadOrderIntents()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load order intents: %v\n", err)
+		return 1
+	}
+	if *resolve == "" {
+		if len(intents) == 0 {
+			fmt.Println("No journaled live orders.")
+			return 0
+		}
+		for _, in := range intents {
+			fmt.Printf("%s  %-9s [%s] %s %s %s size=%g fill=%g @ $%g oid=%s placed %s\n",
+				in.Key, in.Status, in.StrategyID, in.Platform, in.Side, in.Symbol, in.Size,
+				in.FillQty, in.FillPrice, in.ExchangeOrderID, in.CreatedAt.Format(time.RFC3339))
+		}
+		return 0
+	}
+	for _, in := range intents {
+		if in.Key != *resolve {
+			continue
+		}
+		if err := stateDB.DeleteOrderIntent(in.Key); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to clear order intent: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Cleared order intent %s ([%s] %s %s %s).\n", in.Key, in.StrategyID, in.Platform, in.Side, in.Symbol)
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "No order intent with key %q\n", *resolve)
+	return 1
+}
+
+// InsertOrderIntent journals one intended live order.
+func (sdb *StateDB) InsertOrderIntent(in OrderIntent) error {
+	if sdb == nil || sdb.db == nil {
+		return fmt.Errorf("state db unavailable")
+	}
+	_, err := sdb.db.Exec(`INSERT INTO order_intents
+		(intent_key, strategy_id, platform, symbol, side, size, status, fill_qty, fill_price, exchange_order_id, error, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		in.Key, in.StrategyID, in.Platform, in.Symbol, in.Side, in.Size, in.Status, in.FillQty, in.FillPrice,
+		in.ExchangeOrderID, in.Error, formatTime(in.CreatedAt), formatTime(in.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	// The row must be on disk before the order is sent, also when the state
+	// DB is encrypted and otherwise only persisted by SaveState.
+	return sdb.persistEncrypted()
+}
+
+// UpdateOrderIntent records an executor outcome on a journaled intent.
+func (sdb *StateDB) UpdateOrderIntent(key, status string, fillQty, fillPrice float64, exchangeOrderID, errMsg string) error {
+	if sdb == nil || sdb.db == nil {
+		return nil
+	}
+	_, err := sdb.db.Exec(`UPDATE order_intents SET status = ?, fill_qty = ?, fill_price = ?, exchange_order_id = ?, error = ?, updated_at = ? WHERE intent_key = ?`,
+		status, fillQty, fillPrice, exchangeOrderID, errMsg, formatTime(time.Now().UTC()), key)
+	if err != nil {
+		return err
+	}
+	return sdb.persistEncrypted()
+}
+
+// DeleteOrderIntent removes one intent.
+func (sdb *StateDB) DeleteOrderIntent(key string) error {
+	if sdb == nil || sdb.db == nil {
+		return nil
+	}
+	_, err := sdb.db.Exec("DELETE FROM order_intents WHERE intent_key = ?", key)
+	if err != nil {
+		return err
+	}
+	return sdb.persistEncrypted()
+}
+
+// AckOrderIntents deletes the filled intents for strategyID/symbol.
+func (sdb *StateDB) AckOrderIntents(strategyID, symbol string) error {
+	if sdb == nil || sdb.db == nil {
+		return nil
+	}
+	_, err := sdb.db.Exec("DELETE FROM order_intents WHERE strategy_id = ? AND symbol = ? AND status = ?", strategyID, symbol, orderIntentFilled)
+	if err != nil {
+		return err
+	}
+	return sdb.persistEncrypted()
+}
+
+// LoadOrderIntents returns every journaled intent, oldest first.
+func (sdb *StateDB) LoadOrderIntents() ([]OrderIntent, error) {
+	if sdb == nil || sdb.db == nil {
+		return nil, nil
+	}
+	rows, err := sdb.db.Query(`SELECT intent_key, strategy_id, platform, symbol, side, size, status, fill_qty, fill_price, exchange_order_id, error, created_at, updated_at FROM order_intents ORDER BY created_at, intent_key`)
+	if err != nil {
+		return nil, fmt.Errorf("load order intents: %w", err)
+	}
+	defer rows.Close()
+	var intents []OrderIntent
+	for rows.Next() {
+		var in OrderIntent
+		var createdStr, updatedStr string
+		if err := rows.Scan(&in.Key, &in.StrategyID, &in.Platform, &in.Symbol, &in.Side, &in.Size, &in.Status, &in.FillQty, &in.FillPrice, &in.ExchangeOrderID, &in.Error, &createdStr, &updatedStr); err != nil {
+			return nil, fmt.Errorf("scan order intent: %w", err)
+		}
+		in.CreatedAt = parseTime(createdStr)
+		in.UpdatedAt = parseTime(updatedStr)
+		intents = append(intents, in)
+	}
+	return intents, rows.Err()
+}
+
+// TradeExistsForOrderID reports whether strategyID has a persisted trade
+// stamped with exchangeOrderID.
+func (sdb *StateDB) TradeExistsForOrderID(strategyID, exchangeOrderID string) (bool, error) {
+	var n int
+	err := sdb.db.QueryRow("SELECT COUNT(*) FROM trades WHERE strategy_id = ? AND exchange_order_id = ?", strategyID, exchangeOrderID).Scan(&n)
+	return n > 0, err
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func useOrderIntentJournal(t *testing.T) *StateDB {
+	t.Helper()
+	sdb := openTestDB(t)
+	prev := orderIntentJournal
+	orderIntentJournal = sdb
+	t.Cleanup(func() { orderIntentJournal = prev })
+	return sdb
+}
+
+func TestOrderIntentLifecycle(t *testing.T) {
+	sdb := useOrderIntentJournal(t)
+	logger := &StrategyLogger{stratID: "okx-btc", writer: io.Discard}
+	sc := StrategyConfig{ID: "okx-btc", Platform: "okx"}
+
+	// Filled then applied: the ack drops the row.
+	key := beginOrderIntent(sc, "BTC-USDT", "buy", 0.5, logger)
+	settleOrderIntent(key, nil, &OKXExecuteResult{Execution: &OKXExecution{Fill: &OKXFill{AvgPx: 100, TotalSz: 0.5, OID: "42"}}}, logger)
+	intents, _ := sdb.LoadOrderIntents()
+	if len(intents) != 1 || intents[0].Status != orderIntentFilled || intents[0].ExchangeOrderID != "42" || intents[0].FillQty != 0.5 {
+		t.Fatalf("filled intent = %+v", intents)
+	}
+	ackOrderIntents(sc.ID, "BTC-USDT", logger)
+	if intents, _ = sdb.LoadOrderIntents(); len(intents) != 0 {
+		t.Fatalf("ack must drop filled intents, got %+v", intents)
+	}
+
+	// A venue rejection filled nothing: dropped immediately.
+	key = beginOrderIntent(sc, "BTC-USDT", "buy", 0.5, logger)
+	settleOrderIntent(key, nil, &OKXExecuteResult{Error: "insufficient margin"}, logger)
+	if intents, _ = sdb.LoadOrderIntents(); len(intents) != 0 {
+		t.Fatalf("rejected intent must be dropped, got %+v", intents)
+	}
+
+	// A rate-limit rejection surfaced as an executor error placed nothing:
+	// each #4958 retry attempt leaves no row behind.
+	key = beginOrderIntent(sc, "BTC-USDT", "buy", 0.5, logger)
+	settleOrderIntent(key, errors.New("execute error: exit status 1 (stderr: 429 Too Many Requests)"), (*OKXExecuteResult)(nil), logger)
+	if intents, _ = sdb.LoadOrderIntents(); len(intents) != 0 {
+		t.Fatalf("rate-limited intent must be dropped, got %+v", intents)
+	}
+
+	// A process failure has no verdict: kept as unknown, and the ack leaves it.
+	key = beginOrderIntent(sc, "BTC-USDT", "sell", 0.5, logger)
+	settleOrderIntent(key, errors.New("signal: killed"), (*OKXExecuteResult)(nil), logger)
+	ackOrderIntents(sc.ID, "BTC-USDT", logger)
+	if intents, _ = sdb.LoadOrderIntents(); len(intents) != 1 || intents[0].Status != orderIntentUnknown {
+		t.Fatalf("unknown intent = %+v", intents)
+	}
+}
+
+// With state encryption the DB lives in memory; a journaled intent must still
+// reach the sealed file before the order is sent, without waiting for SaveState.
+func TestOrderIntentPersistedUnderStateEncryption(t *testing.T) {
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+	sdb, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	defer sdb.Close()
+	prev := orderIntentJournal
+	orderIntentJournal = sdb
+	t.Cleanup(func() { orderIntentJournal = prev })
+
+	logger := &StrategyLogger{stratID: "hl-btc", writer: io.Discard}
+	key := beginOrderIntent(StrategyConfig{ID: "hl-btc", Platform: "hyperliquid"}, "BTC", "buy", 0.01, logger)
+	if key == "" {
+		t.Fatal("intent not journaled")
+	}
+
+	ro, err := openStateDBForRead(path)
+	if err != nil {
+		t.Fatalf("openStateDBForRead: %v", err)
+	}
+	defer ro.Close()
+	var n int
+	if err := ro.QueryRow("SELECT COUNT(*) FROM order_intents WHERE intent_key = ?", key).Scan(&n); err != nil || n != 1 {
+		t.Fatalf("intent rows on disk = %d, %v; want 1", n, err)
+	}
+}
+
+func TestOrderIntentClientID(t *testing.T) {
+	key := newOrderIntentKey(time.Now())
+	if len(key) != 32 || strings.Trim(key, "0123456789abcdef") != "" {
+		t.Fatalf("key %q is not 32 hex characters", key)
+	}
+	if got := orderIntentClientID("hyperliquid", key); got != "0x"+key {
+		t.Errorf("HL cloid = %q", got)
+	}
+	if got := orderIntentClientID("okx", key); got != key {
+		t.Errorf("OKX clOrdId = %q", got)
+	}
+	if got := orderIntentClientID("robinhood", key); got != "" {
+		t.Errorf("robinhood client ID = %q, want none", got)
+	}
+	if got := orderIntentClientID("okx", ""); got != "" {
+		t.Errorf("client ID without a journaled key = %q", got)
+	}
+}
+
+// Manual HL orders journal through the core's own state DB handle: a failed
+// execute keeps the intent (sent as the cloid) for the startup reconcile, and a
+// queued fill drops it.
+func TestManualOrdersJournalOrderIntents(t *testing.T) {
+	sdb := openTestDB(t)
+	sc := StrategyConfig{ID: "m", Type: "manual", Platform: "hyperliquid", Symbol: "ETH", Leverage: 3, Direction: "both"}
+	var sentCloid string
+	deps := manualCoreDeps{
+		cfg:     &Config{},
+		stateDB: sdb,
+		loadState: func(strategyID, symbol string) (manualStateView, error) {
+			return manualStateView{HasStrategy: true}, nil
+		},
+		execute: func(_, _, _ string, _, _ float64, _ int64, _ float64, _ string, _ float64, _ bool, _ hlExecuteSnapshot, cloid string, _ ...int64) (*HyperliquidExecuteResult, string, error) {
+			sentCloid = cloid
+			return nil, "", errors.New("signal: killed")
+		},
+		fetchMids: func([]string) (map[string]float64, error) {
+			return map[string]float64{"ETH": 2000}, nil
+		},
+	}
+	if _, err := manualOpenCore(deps, sc, manualOpenInputs{StrategyID: "m", Side: "long", Margin: 50}); err == nil {
+		t.Fatal("expected the execute failure to surface")
+	}
+	intents, err := sdb.LoadOrderIntents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intents) != 1 || intents[0].Status != orderIntentUnknown || intents[0].Side != "buy" {
+		t.Fatalf("intents = %+v, want one unknown buy", intents)
+	}
+	if sentCloid != "0x"+intents[0].Key {
+		t.Errorf("cloid = %q, want 0x+%s", sentCloid, intents[0].Key)
+	}
+
+	key, _, _, _ := deps.executeWithIntent(sc, "sell", 0.1, func(string) (*HyperliquidExecuteResult, string, error) {
+		return &HyperliquidExecuteResult{Execution: &HyperliquidExecution{Fill: &HyperliquidFill{TotalSz: 0.1, AvgPx: 2000}}}, "", nil
+	})
+	sdb.dropIntent(key, manualIntentLogger(sc))
+	if intents, _ = sdb.LoadOrderIntents(); len(intents) != 1 {
+		t.Fatalf("queued fill must drop its intent, got %+v", intents)
+	}
+}
+
+func TestReconcileOrderIntents(t *testing.T) {
+	sdb := openTestDB(t)
+	now := time.Now().UTC()
+	for _, in := range []OrderIntent{
+		{Key: "a", StrategyID: "rh-btc", Platform: "robinhood", Symbol: "BTC", Side: "buy", Size: 0.01, Status: orderIntentPending},
+		{Key: "b", StrategyID: "hl-btc", Platform: "hyperliquid", Symbol: "BTC", Side: "buy", Size: 0.01, Status: orderIntentFilled, FillQty: 0.01, FillPrice: 60000, ExchangeOrderID: "7"},
+		{Key: "c", StrategyID: "hl-eth", Platform: "hyperliquid", Symbol: "ETH", Side: "sell", Size: 1, Status: orderIntentFilled, FillQty: 1, FillPrice: 3000, ExchangeOrderID: "8"},
+		{Key: "d", StrategyID: "hl-btc", Platform: "hyperliquid", Symbol: "BTC", Side: "buy", Size: 0.01, Status: orderIntentPending},
+		{Key: "e", StrategyID: "okx-btc", Platform: "okx", Symbol: "BTC", Side: "buy", Size: 0.02, Status: orderIntentUnknown, Error: "signal: killed"},
+	} {
+		in.CreatedAt, in.UpdatedAt = now, now
+		if err := sdb.InsertOrderIntent(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The hl-btc fill made it into the trades table before the crash.
+	if err := sdb.InsertTrade("hl-btc", Trade{Timestamp: now, StrategyID: "hl-btc", Symbol: "BTC", Side: "buy", Quantity: 0.01, Price: 60000, ExchangeOrderID: "7"}); err != nil {
+		t.Fatal(err)
+	}
+	// HL never saw order d; OKX filled order e.
+	orig := orderIntentLookupFn
+	t.Cleanup(func() { orderIntentLookupFn = orig })
+	orderIntentLookupFn = func(_ StrategyConfig, in OrderIntent) (*VenueOrderStatus, error) {
+		switch in.Key {
+		case "d":
+			return &VenueOrderStatus{Status: "not_found"}, nil
+		case "e":
+			return &VenueOrderStatus{Status: "closed", OID: "9", FilledSize: 0.02, AvgPx: 61000}, nil
+		}
+		return nil, nil
+	}
+	cfg := &Config{}
+
+	msgs, err := reconcileOrderIntents(sdb, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("want 3 unacknowledged intents, got %q", msgs)
+	}
+	if !strings.Contains(msgs[0], "may have filled") ||
+		!strings.Contains(msgs[1], "filled 1 @ $3000 (oid 8) but never applied") ||
+		!strings.Contains(msgs[2], "filled 0.02 @ $61000 (oid 9) but never applied") ||
+		!strings.Contains(msgs[2], "order-intents --resolve e") {
+		t.Errorf("messages = %q", msgs)
+	}
+
+	// Unapplied fills survive the Phase 4 ack and the next startup.
+	ackOrderIntents("hl-eth", "ETH", &StrategyLogger{stratID: "hl-eth", writer: io.Discard})
+	intents, _ := sdb.LoadOrderIntents()
+	keys := make([]string, 0, len(intents))
+	for _, in := range intents {
+		keys = append(keys, in.Key+"="+in.Status)
+	}
+	if got := strings.Join(keys, ","); got != "a=pending,c=unapplied,e=unapplied" {
+		t.Fatalf("journal after reconcile = %s", got)
+	}
+	if again, _ := reconcileOrderIntents(sdb, cfg); len(again) != 3 {
+		t.Errorf("unresolved intents must be reported again, got %q", again)
+	}
+
+	if err := sdb.DeleteOrderIntent("c"); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := reconcileOrderIntents(sdb, cfg); len(again) != 2 {
+		t.Errorf("a resolved intent must not be reported, got %q", again)
+	}
+}
+
+func TestParseVenueOrderStatusOutput(t *testing.T) {
+	st, err := parseVenueOrderStatusOutput([]byte(`{"order":{"status":"filled","oid":"77","filled_size":0.5,"avg_px":100}}`), "", nil)
+	if err != nil || st.Status != "filled" || st.OID != "77" || st.FilledSize != 0.5 || st.AvgPx != 100 {
+		t.Fatalf("status = %+v, err = %v", st, err)
+	}
+	if _, err := parseVenueOrderStatusOutput([]byte(`{"error":"rate limited"}`), "", errors.New("exit status 1")); err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("venue error not surfaced: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+// orphan_reaper: kill strategy-script processes whose scheduler is gone
+// (#4980).
+//
+// Every Python subprocess is spawned with GO_TRADER_OWNER=<pid>:<starttime>,
+// the spawning scheduler's PID and its /proc start time (so a recycled PID
+// never looks like a live owner). If the scheduler crashes or is SIGKILLed,
+// its in-flight scripts — and anything they forked — outlive it, re-parented
+// to init. The reaper scans /proc at startup and every orphanReapInterval,
+// and SIGKILLs the process group of every marked process whose owner is not
+// running any more. Scripts owned by a live scheduler, including another
+// go-trader instance on the same host, are never touched. Zombies are
+// skipped: they hold no resources beyond a PID and init reaps them. Without
+// /proc (non-Linux) the reaper is a no-op.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	orphanOwnerEnv      = "GO_TRADER_OWNER"
+	orphanReapInterval  = 10 * time.Minute
+	orphanReaperProcDir = "/proc"
+)
+
+// orphanKill terminates a process group (pgid > 0) or a single process.
+// Package var so tests never signal real processes.
+var orphanKill = func(pid int, group bool) error {
+	if group {
+		return killProcessGroup(pid)
+	}
+	return killProcess(pid)
+}
+
+// procStat is the part of /proc/<pid>/stat the reaper needs.
+type procStat struct {
+	state     string
+	pgrp      int
+	startTime string
+}
+
+// readProcStat parses /proc/<pid>/stat. comm may contain spaces and
+// parentheses, so fields are counted from the last ')'.
+func readProcStat(procDir string, pid int) (procStat, bool) {
+	raw, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return procStat{}, false
+	}
+	s := string(raw)
+	i := strings.LastIndexByte(s, ')')
+	if i < 0 {
+		return procStat{}, false
+	}
+	// After comm: state(3) ppid(4) pgrp(5) … starttime(22).
+	fields := strings.Fields(s[i+1:])
+	if len(fields) < 20 {
+		return procStat{}, false
+	}
+	pgrp, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return procStat{}, false
+	}
+	return procStat{state: fields[0], pgrp: pgrp, startTime: fields[19]}, true
+}
+
+// selfOwnerMarker is this process's GO_TRADER_OWNER value.
+var selfOwnerMarker = func() string {
+	start := "0"
+	if st, ok := readProcStat(orphanReaperProcDir, os.Getpid()); ok {
+		start = st.startTime
+	}
+	return fmt.Sprintf("%d:%s", os.Getpid(), start)
+}()
+
+// scriptOwnerEnv is the environment overlay that marks a spawned script.
+func scriptOwnerEnv() map[string]string {
+	return map[string]string{orphanOwnerEnv: selfOwnerMarker}
+}
+
+// processOwnerMarker returns pid's GO_TRADER_OWNER value, "" when unmarked
+// or unreadable (another user's process).
+func processOwnerMarker(procDir string, pid int) string {
+	raw, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "environ"))
+	if err != nil {
+		return ""
+	}
+	prefix := orphanOwnerEnv + "="
+	for _, kv := range strings.Split(string(raw), "\x00") {
+		if strings.HasPrefix(kv, prefix) {
+			return kv[len(prefix):]
+		}
+	}
+	return ""
+}
+
+// ownerAlive reports whether marker names a process that is still running
+// with the same start time.
+func ownerAlive(procDir, marker string) bool {
+	pidStr, start, ok := strings.Cut(marker, ":")
+	if !ok {
+		return false
+	}
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || pid <= 0 {
+		return false
+	}
+	st, ok := readProcStat(procDir, pid)
+	return ok && (start == "0" || st.startTime == start)
+}
+
+// reapOrphanedScripts kills every marked process under procDir whose owner
+// is gone and returns the PIDs it signalled.
+func reapOrphanedScripts(procDir string) []int {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	self := os.Getpid()
+	killedGroups := make(map[int]bool)
+	var reaped []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		marker := processOwnerMarker(procDir, pid)
+		if marker == "" || marker == selfOwnerMarker || ownerAlive(procDir, marker) {
+			continue
+		}
+		st, ok := readProcStat(procDir, pid)
+		if !ok || st.state == "Z" || killedGroups[st.pgrp] {
+			continue
+		}
+		// Scripts are spawned with Setpgid, so the group leader's PID is
+		// the group; kill the whole group to take forked children along.
+		group := st.pgrp > 1 && st.pgrp != currentProcessGroup()
+		target := pid
+		if group {
+			target = st.pgrp
+			killedGroups[st.pgrp] = true
+		}
+		if err := orphanKill(target, group); err != nil {
+			fmt.Printf("[reaper] failed to kill orphaned script pid=%d (owner %s): %v\n", pid, marker, err)
+			continue
+		}
+		fmt.Printf("[reaper] killed orphaned script pid=%d pgid=%d (owner %s gone)\n", pid, st.pgrp, marker)
+		reaped = append(reaped, pid)
+	}
+	return reaped
+}
+
+// runOrphanReaper sweeps every interval until ctx is cancelled. The startup
+// sweep runs synchronously in main before this starts.
+func runOrphanReaper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			reapOrphanedScripts(orphanReaperProcDir)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func writeFakeProc(t *testing.T, dir string, pid int, comm, state string, pgrp int, start, owner string) {
+	t.Helper()
+	pdir := filepath.Join(dir, strconv.Itoa(pid))
+	if err := os.MkdirAll(pdir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stat := fmt.Sprintf("%d (%s) %s 1 %d %d 0 -1 4194304 0 0 0 0 0 0 0 0 20 0 1 0 %s 0 0\n", pid, comm, state, pgrp, pgrp, start)
+	if err := os.WriteFile(filepath.Join(pdir, "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	env := "PATH=/usr/bin\x00"
+	if owner != "" {
+		env += orphanOwnerEnv + "=" + owner + "\x00"
+	}
+	if err := os.WriteFile(filepath.Join(pdir, "environ"), []byte(env), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReapOrphanedScripts(t *testing.T) {
+	dir := t.TempDir()
+	writeFakeProc(t, dir, 200, "go-trader", "S", 200, "555", "")               // live scheduler
+	writeFakeProc(t, dir, 100, "python3 (check)", "S", 100, "900", "999999:1") // owner gone
+	writeFakeProc(t, dir, 105, "python3", "S", 100, "901", "999999:1")         // forked child, same group
+	writeFakeProc(t, dir, 101, "python3", "S", 101, "902", "200:555")          // owner alive
+	writeFakeProc(t, dir, 102, "python3", "S", 102, "903", "200:444")          // owner PID recycled
+	writeFakeProc(t, dir, 103, "python3", "Z", 103, "904", "999999:1")         // zombie
+	writeFakeProc(t, dir, 104, "python3", "S", 104, "905", "")                 // not ours
+
+	orig := orphanKill
+	defer func() { orphanKill = orig }()
+	var killed []int
+	orphanKill = func(pid int, group bool) error {
+		if !group {
+			t.Errorf("pid %d killed without its group", pid)
+		}
+		killed = append(killed, pid)
+		return nil
+	}
+
+	reaped := reapOrphanedScripts(dir)
+	sort.Ints(killed)
+	if fmt.Sprint(killed) != "[100 102]" || len(reaped) != 2 {
+		t.Fatalf("killed groups %v, reaped %v", killed, reaped)
+	}
+	if got := reapOrphanedScripts(filepath.Join(dir, "missing")); got != nil {
+		t.Errorf("missing proc dir reaped %v", got)
+	}
+	if env := scriptOwnerEnv(); env[orphanOwnerEnv] != selfOwnerMarker {
+		t.Errorf("owner env = %v", env)
+	}
+}
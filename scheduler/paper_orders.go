@@ -0,0 +1,215 @@
+package main
+
+// paper_orders: order simulation for paper generic spot entries.
+//
+// With a paper_limit_entries block, a fresh open (BUY from flat, or an
+// allow_short SELL from flat) no longer fills at the cycle price. It rests as
+// a limit order at the signal price (less offset_pct) and fills — at the limit
+// price, without slippage — only when a later cycle's bar range crosses it:
+// bar_low <= limit for a buy, bar_high >= limit for a sell. Check scripts emit
+// the evaluated candle's bar_high/bar_low; when they are missing the cycle
+// price stands in for both. An order that has not filled after expiry_cycles
+// later cycles is cancelled, as is one met by an opposite signal, a registry
+// close action or an already-open position.
+//
+// Only entries are simulated: closes, dca tranches and scale-in adds keep the
+// market-at-cycle-price path. Market fills flatter mean-reversion strategies,
+// which buy exactly the dips they then never get filled on in practice.
+
+import (
+	"fmt"
+	"time"
+)
+
+// paperOrderKindLimit is the PaperOrder.Kind of a resting limit entry.
+const paperOrderKindLimit = "limit"
+
+// PaperOrder is a simulated order resting on a paper strategy's book.
+type PaperOrder struct {
+	Kind         string    `json:"kind"`
+	Side         string    `json:"side"` // "buy" | "sell"
+	Price        float64   `json:"price"`
+	SizeFraction float64   `json:"size_fraction,omitempty"`
+	PlacedAt     time.Time `json:"placed_at"`
+	// CyclesLeft counts the later cycles this order may still be evaluated
+	// in; it is cancelled once an unfilled evaluation brings it to zero.
+	CyclesLeft int `json:"cycles_left"`
+}
+
+// expiryCycles returns the effective resting lifetime (0 = 1).
+func (c *PaperLimitEntriesConfig) expiryCycles() int {
+	if c.ExpiryCycles <= 0 {
+		return 1
+	}
+	return c.ExpiryCycles
+}
+
+// paperLimitEntriesLabel renders a paper_limit_entries block for the
+// hot-reload diff.
+func paperLimitEntriesLabel(c *PaperLimitEntriesConfig) string {
+	if c == nil {
+		return "off"
+	}
+	return fmt.Sprintf("offset %.2f%%, expiry %d cycle(s)", c.OffsetPct, c.expiryCycles())
+}
+
+// paperLimitPrice places the limit offset_pct better than the signal price.
+func paperLimitPrice(c *PaperLimitEntriesConfig, side string, price float64) float64 {
+	if side == "sell" {
+		return price * (1 + c.OffsetPct/100)
+	}
+	return price * (1 - c.OffsetPct/100)
+}
+
+// paperOrderCrossed reports whether the cycle's bar range reached the order's
+// limit. A non-positive bar high/low falls back to the cycle price.
+func paperOrderCrossed(o *PaperOrder, price, barHigh, barLow float64) bool {
+	if o.Side == "sell" {
+		if barHigh <= 0 {
+			barHigh = price
+		}
+		return barHigh >= o.Price
+	}
+	if barLow <= 0 {
+		barLow = price
+	}
+	return barLow <= o.Price
+}
+
+// routeSpotPaperLimitEntry runs the paper order simulator for one spot
+// result: it settles a resting order for the symbol (fill, expire or cancel)
+// and turns a fresh-open signal into a new resting order. handled=true means
+// the signal was consumed here and must not reach the market executors; a
+// fill is returned in exec.OpenTrade for the caller to record.
+func routeSpotPaperLimitEntry(sc StrategyConfig, s *StrategyState, result *SpotResult, price, sizeFraction float64, logger *StrategyLogger) (exec SignalExecutionResult, handled bool) {
+	symbol := result.Symbol
+	if o := s.PaperOrders[symbol]; o != nil {
+		_, open := s.Positions[symbol]
+		opposite := (o.Side == "buy" && result.Signal == -1) || (o.Side == "sell" && result.Signal == 1)
+		switch {
+		case sc.PaperLimitEntries == nil:
+			cancelSpotPaperOrder(s, symbol, "paper_limit_entries disabled", logger)
+		case open:
+			cancelSpotPaperOrder(s, symbol, "position already open", logger)
+		case opposite || result.CloseFraction > 0:
+			cancelSpotPaperOrder(s, symbol, "opposite signal", logger)
+		case paperOrderCrossed(o, price, result.BarHigh, result.BarLow):
+			delete(s.PaperOrders, symbol)
+			return fillSpotPaperLimitEntry(s, symbol, o, logger), true
+		default:
+			o.CyclesLeft--
+			if o.CyclesLeft <= 0 {
+				cancelSpotPaperOrder(s, symbol, "expired unfilled", logger)
+				break
+			}
+			logger.Info("Limit %s %s resting @ $%.2f (%d cycle(s) left)", sideSignalLabel(o.Side), symbol, o.Price, o.CyclesLeft)
+			return exec, true
+		}
+	}
+
+	cfg := sc.PaperLimitEntries
+	if cfg == nil || result.CloseFraction > 0 {
+		return exec, false
+	}
+	side := ""
+	if _, open := s.Positions[symbol]; !open && result.Signal == 1 {
+		side = "buy"
+	} else if sc.AllowShort && spotPaperShortOpens(s, result.Signal, symbol, result.CloseFraction) {
+		side = "sell"
+	}
+	if side == "" {
+		return exec, false
+	}
+	if s.PaperOrders == nil {
+		s.PaperOrders = make(map[string]*PaperOrder)
+	}
+	o := &PaperOrder{
+		Kind:         paperOrderKindLimit,
+		Side:         side,
+		Price:        symbolSpecFor(sc.Platform, symbol).RoundPrice(paperLimitPrice(cfg, side, price)),
+		SizeFraction: sizeFraction,
+		PlacedAt:     time.Now().UTC(),
+		CyclesLeft:   cfg.expiryCycles(),
+	}
+	s.PaperOrders[symbol] = o
+	logger.Info("Limit %s %s placed @ $%.2f (signal $%.2f, expires after %d cycle(s))", sideSignalLabel(side), symbol, o.Price, price, o.CyclesLeft)
+	return exec, true
+}
+
+func cancelSpotPaperOrder(s *StrategyState, symbol, why string, logger *StrategyLogger) {
+	if o := s.PaperOrders[symbol]; o != nil {
+		logger.Info("Limit %s %s @ $%.2f cancelled — %s", sideSignalLabel(o.Side), symbol, o.Price, why)
+	}
+	delete(s.PaperOrders, symbol)
+}
+
+func sideSignalLabel(side string) string {
+	if side == "sell" {
+		return "SELL"
+	}
+	return "BUY"
+}
+
+// fillSpotPaperLimitEntry opens the position at the order's limit price with
+// the cash budget (scaled by the order's size_fraction) available at fill
+// time. A buy opens a long; a sell opens an allow_short paper short with the
+// same collateral model as ExecuteSpotPaperShortDeferredOpen.
+func fillSpotPaperLimitEntry(s *StrategyState, symbol string, o *PaperOrder, logger *StrategyLogger) SignalExecutionResult {
+	var result SignalExecutionResult
+	budget := s.Cash
+	if o.SizeFraction > 0 && o.SizeFraction < 1 {
+		budget *= o.SizeFraction
+	}
+	if budget < 1 || o.Price <= 0 {
+		logger.Info("Limit %s %s crossed @ $%.2f but cash ($%.2f) is insufficient — cancelled", sideSignalLabel(o.Side), symbol, o.Price, s.Cash)
+		return result
+	}
+	qty, why := symbolSpecFor(s.Platform, symbol).openQty(budget/o.Price, o.Price)
+	if why != "" {
+		logger.Info("Limit %s %s crossed @ $%.2f but %s — cancelled", sideSignalLabel(o.Side), symbol, o.Price, why)
+		return result
+	}
+	notional := qty * o.Price
+	fee := CalculatePlatformSpotFee(s.Platform, notional)
+	totalDebit := notional + fee
+	s.Cash -= totalDebit
+	now := time.Now().UTC()
+	positionID := newTradePositionID(s.ID, symbol, now)
+	pos := &Position{
+		Symbol:          symbol,
+		TradePositionID: positionID,
+		Quantity:        qty,
+		InitialQuantity: qty,
+		AvgCost:         o.Price,
+		Side:            "long",
+		OwnerStrategyID: s.ID,
+		OpenedAt:        now,
+	}
+	details := fmt.Sprintf("Open long %.6f @ $%.2f (fee $%.2f) [paper limit fill]", qty, o.Price, fee)
+	if o.Side == "sell" {
+		pos.Side = "short"
+		pos.BorrowAccruedAt = now
+		details = fmt.Sprintf("Open short %.6f @ $%.2f (fee $%.2f) [paper limit fill, allow_short]", qty, o.Price, fee)
+	}
+	s.Positions[symbol] = pos
+	trade := Trade{
+		Timestamp:   now,
+		StrategyID:  s.ID,
+		Symbol:      symbol,
+		PositionID:  positionID,
+		Side:        o.Side,
+		Quantity:    qty,
+		Price:       o.Price,
+		Value:       totalDebit,
+		TradeType:   "spot",
+		Details:     details,
+		ExchangeFee: fee,
+		FeeSource:   FeeSourceModeled,
+		PnLGross:    true,
+	}
+	trade.Regime = s.Regime
+	result.OpenTrade = &trade
+	result.TradesExecuted = 1
+	logger.Info("Limit %s %s filled: %.6f @ $%.2f (fee $%.2f, rested since %s)", sideSignalLabel(o.Side), symbol, qty, o.Price, fee, o.PlacedAt.Format(time.RFC3339))
+	return result
+}
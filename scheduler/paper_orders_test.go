@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPaperLimitEntry_RestsFillsAndExpires(t *testing.T) {
+	prevRecorder := tradeRecorder
+	tradeRecorder = nil
+	t.Cleanup(func() { tradeRecorder = prevRecorder })
+
+	sc := StrategyConfig{ID: "mr-btc", Type: "spot", Platform: "binanceus", Capital: 1000,
+		PaperLimitEntries: &PaperLimitEntriesConfig{OffsetPct: 1, ExpiryCycles: 2}}
+	s := NewStrategyState(sc)
+	logger := silentStrategyLogger("mr-btc")
+	run := func(signal int, price, high, low float64) int {
+		res := &SpotResult{Symbol: "BTC/USDT", Signal: signal}
+		res.BarHigh, res.BarLow = high, low
+		trades, _ := executeSpotResult(sc, s, nil, res, "", price, nil, nil, logger)
+		return trades
+	}
+
+	// BUY from flat rests at 99 instead of filling at 100.
+	if trades := run(1, 100, 101, 99.5); trades != 0 || len(s.Positions) != 0 {
+		t.Fatalf("entry filled at market: trades=%d", trades)
+	}
+	o := s.PaperOrders["BTC/USDT"]
+	if o == nil || o.Side != "buy" || math.Abs(o.Price-99) > 1e-9 {
+		t.Fatalf("resting order = %+v", o)
+	}
+	// Next bar never trades down to 99: still resting, one cycle left.
+	if trades := run(0, 100.5, 101, 99.2); trades != 0 || s.PaperOrders["BTC/USDT"].CyclesLeft != 1 {
+		t.Fatalf("uncrossed bar: trades=%d order=%+v", trades, s.PaperOrders["BTC/USDT"])
+	}
+	// Bar low crosses 99: fills at the limit price, not the cycle price.
+	if trades := run(0, 100, 100.5, 98.7); trades != 1 {
+		t.Fatalf("crossed bar did not fill: trades=%d", trades)
+	}
+	pos := s.Positions["BTC/USDT"]
+	if pos == nil || pos.AvgCost != 99 || s.PaperOrders["BTC/USDT"] != nil {
+		t.Fatalf("fill: pos=%+v orders=%v", pos, s.PaperOrders)
+	}
+
+	// Close, then let a fresh entry expire unfilled.
+	if _, err := ExecuteSpotSignalWithFillFee(s, -1, "BTC/USDT", 100, 0, 0, "", 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	run(1, 100, 100, 100)
+	run(0, 100, 100, 100)
+	run(0, 100, 100, 100)
+	if s.PaperOrders["BTC/USDT"] != nil || s.Positions["BTC/USDT"] != nil {
+		t.Fatalf("order should have expired: %+v", s.PaperOrders["BTC/USDT"])
+	}
+
+	// An opposite signal cancels a resting entry.
+	run(1, 100, 100, 100)
+	run(-1, 100, 100, 100)
+	if s.PaperOrders["BTC/USDT"] != nil {
+		t.Error("SELL must cancel a resting BUY")
+	}
+}
+
+func TestPaperOrdersRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	state := NewAppState()
+	s := NewStrategyState(StrategyConfig{ID: "mr-btc", Type: "spot", Platform: "binanceus", Capital: 1000})
+	s.PaperOrders = map[string]*PaperOrder{"BTC/USDT": {Kind: paperOrderKindLimit, Side: "buy", Price: 99, CyclesLeft: 2}}
+	state.Strategies[s.ID] = s
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Strategies["mr-btc"].PaperOrders["BTC/USDT"]
+	if got == nil || got.Price != 99 || got.CyclesLeft != 2 || got.Side != "buy" {
+		t.Fatalf("loaded order = %+v", got)
+	}
+}
+
+func TestValidateConfig_PaperLimitEntries(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{
+				ID: "mr-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+				Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				PaperLimitEntries: &PaperLimitEntriesConfig{OffsetPct: 60}, DCA: &DCAConfig{TrancheUSD: 100},
+			},
+			{
+				ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Script: "shared_scripts/check_hyperliquid.py",
+				Args: []string{"sma_crossover", "BTC", "1h"}, Capital: 1000, MaxDrawdownPct: 10, PaperLimitEntries: &PaperLimitEntriesConfig{},
+			},
+		},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"paper_limit_entries.offset_pct must be in [0, 50)", "paper_limit_entries and dca", "paper_limit_entries is only supported for paper generic spot"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
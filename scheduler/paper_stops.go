@@ -0,0 +1,109 @@
+package main
+
+// paper_stops: stop / stop-limit order model for paper protective stops.
+//
+// Paper HL perps stops (the fixed ATR SL and the trailing SL) have no order
+// on an exchange; the scheduler books a synthetic close when the stop is
+// crossed. By default that check uses only the cycle's snapshot price, so a
+// wick through the trigger between cycles is missed. With a paper_stop_order
+// block the check uses the evaluated candle's bar_high/bar_low from the check
+// script:
+//
+//   - "stop" (stop-market): triggers when the bar reaches the trigger and
+//     fills at the trigger, or at the bar's best price when the whole bar
+//     gapped through it.
+//   - "stop_limit": triggers the same way but only fills at or better than
+//     trigger ∓ limit_offset_pct. A bar that gapped through the limit leaves
+//     the position open; a later cycle whose bar trades back to the limit
+//     fills it. This is evaluated statelessly every cycle, so no order state
+//     is persisted beyond the position's trigger.
+//
+// Missing bar fields fall back to the cycle price.
+
+import "fmt"
+
+const (
+	paperStopTypeStop      = "stop"
+	paperStopTypeStopLimit = "stop_limit"
+)
+
+// paperBar is the evaluated candle's range from a check result (0 = unknown).
+type paperBar struct {
+	High float64
+	Low  float64
+}
+
+// paperBarOf extracts the bar range emitted next to a check decision.
+func paperBarOf(f StrategyDecisionFields) paperBar {
+	return paperBar{High: f.BarHigh, Low: f.BarLow}
+}
+
+// stopType returns the effective order type ("" = stop).
+func (c *PaperStopOrderConfig) stopType() string {
+	if c.Type == "" {
+		return paperStopTypeStop
+	}
+	return c.Type
+}
+
+// paperStopOrderLabel renders a paper_stop_order block for the hot-reload diff.
+func paperStopOrderLabel(c *PaperStopOrderConfig) string {
+	if c == nil {
+		return "snapshot"
+	}
+	if c.stopType() == paperStopTypeStopLimit {
+		return fmt.Sprintf("stop_limit (offset %.2f%%)", c.LimitOffsetPct)
+	}
+	return paperStopTypeStop
+}
+
+// paperStopFill decides whether a paper protective stop at trigger fills
+// this cycle. fill reports the close and px its price; triggeredUnfilled is
+// true when a stop-limit triggered but the bar never traded at its limit.
+// A nil cfg keeps the legacy snapshot semantics: fill at the trigger when
+// the cycle price has crossed it.
+func paperStopFill(cfg *PaperStopOrderConfig, side string, trigger, mark float64, bar paperBar) (fill bool, px float64, triggeredUnfilled bool) {
+	if cfg == nil {
+		if trailingStopBreached(side, mark, trigger) {
+			return true, trigger, false
+		}
+		return false, 0, false
+	}
+	if trigger <= 0 || mark <= 0 {
+		return false, 0, false
+	}
+	high, low := bar.High, bar.Low
+	if high <= 0 {
+		high = mark
+	}
+	if low <= 0 {
+		low = mark
+	}
+	switch side {
+	case "long":
+		if low > trigger && mark > trigger {
+			return false, 0, false
+		}
+		px = trigger
+		if high < trigger {
+			px = high
+		}
+		if cfg.stopType() == paperStopTypeStopLimit && px < trigger*(1-cfg.LimitOffsetPct/100) {
+			return false, 0, true
+		}
+		return true, px, false
+	case "short":
+		if high < trigger && mark < trigger {
+			return false, 0, false
+		}
+		px = trigger
+		if low > trigger {
+			px = low
+		}
+		if cfg.stopType() == paperStopTypeStopLimit && px > trigger*(1+cfg.LimitOffsetPct/100) {
+			return false, 0, true
+		}
+		return true, px, false
+	}
+	return false, 0, false
+}
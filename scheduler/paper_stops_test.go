@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaperStopFill(t *testing.T) {
+	stop := &PaperStopOrderConfig{}
+	stopLimit := &PaperStopOrderConfig{Type: paperStopTypeStopLimit, LimitOffsetPct: 1}
+	cases := []struct {
+		name       string
+		cfg        *PaperStopOrderConfig
+		side       string
+		mark       float64
+		bar        paperBar
+		wantFill   bool
+		wantPx     float64
+		wantUnfill bool
+	}{
+		{"snapshot misses a wick", nil, "long", 105, paperBar{High: 106, Low: 98}, false, 0, false},
+		{"stop catches the wick at the trigger", stop, "long", 105, paperBar{High: 106, Low: 98}, true, 100, false},
+		{"stop gapped through fills at bar high", stop, "long", 95, paperBar{High: 97, Low: 94}, true, 97, false},
+		{"stop untouched", stop, "long", 105, paperBar{High: 106, Low: 101}, false, 0, false},
+		{"short stop catches the wick", stop, "short", 95, paperBar{High: 101, Low: 94}, true, 100, false},
+		{"stop-limit fills within the offset", stopLimit, "long", 99.5, paperBar{High: 99.5, Low: 98}, true, 99.5, false},
+		{"stop-limit gapped past the limit", stopLimit, "long", 95, paperBar{High: 97, Low: 94}, false, 0, true},
+		{"short stop-limit gapped past the limit", stopLimit, "short", 105, paperBar{High: 106, Low: 103}, false, 0, true},
+		{"missing bar falls back to the mark", stop, "long", 99, paperBar{}, true, 99, false},
+	}
+	for _, tc := range cases {
+		fill, px, unfilled := paperStopFill(tc.cfg, tc.side, 100, tc.mark, tc.bar)
+		if fill != tc.wantFill || px != tc.wantPx || unfilled != tc.wantUnfill {
+			t.Errorf("%s: got (%v, %v, %v), want (%v, %v, %v)", tc.name, fill, px, unfilled, tc.wantFill, tc.wantPx, tc.wantUnfill)
+		}
+	}
+}
+
+func TestRunHyperliquidFixedATRStopLossPaper_IntrabarStop(t *testing.T) {
+	mult := 1.5
+	sc := StrategyConfig{Platform: "hyperliquid", Type: "perps", StopLossATRMult: &mult, PaperStopOrder: &PaperStopOrderConfig{}}
+	pos := &Position{AvgCost: 2000, EntryATR: 40}
+	// Trigger 1940; the cycle closes at 1990 but the bar wicked to 1930.
+	_, breach, px := runHyperliquidFixedATRStopLossPaper(sc, "long", pos, 1990, 1940, paperBar{High: 2005, Low: 1930})
+	if !breach || px != 1940 {
+		t.Fatalf("breach=%v px=%v, want intrabar fill at 1940", breach, px)
+	}
+}
+
+func TestValidateConfig_PaperStopOrder(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{
+				ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Script: "shared_scripts/check_hyperliquid.py",
+				Args: []string{"sma_crossover", "BTC", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				PaperStopOrder: &PaperStopOrderConfig{Type: "trailing"},
+			},
+			{
+				ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+				Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				PaperStopOrder: &PaperStopOrderConfig{LimitOffsetPct: 1},
+			},
+		},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"paper_stop_order.type must be", "paper_stop_order is only supported for paper hyperliquid perps", "limit_offset_pct requires type \"stop_limit\""} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
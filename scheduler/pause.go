@@ -33,7 +33,8 @@ package main
 //   - a pure-close directional exit, mirroring perpsCloseActionSuppressesNewSL:
 //     sell on a long with shorts disallowed, or buy on a short with longs
 //     disallowed (spot is long-only — ExecuteSpotSignalWithFillFee's sell branch only
-//     ever closes a long — so spot sells always qualify).
+//     ever closes a long — so spot sells always qualify; allow_short paper
+//     shorts open only from flat, which posQty <= 0 already holds).
 //
 // posSide is the open position's side ("long"/"short"; spot positions are
 // "long"). allowsLong/allowsShort describe what the EXECUTOR can open for an
@@ -0,0 +1,195 @@
+package main
+
+// Per-platform aggregate risk limits (platforms.<name>.risk).
+//
+// PlatformConfig.Risk historically only defaulted per-strategy
+// max_drawdown_pct at load. It is now also enforced on the platform as a
+// whole: every cycle the scheduler values the platform's strategies together
+// (shared wallets deduped exactly like the portfolio kill switch, via
+// computeSubsetPortfolioValue) and compares
+//
+//   - max_drawdown_pct — drawdown of that value from the platform's own
+//     persisted high-water mark (PlatformRiskState.PeakValue), and
+//   - max_notional_usd — gross notional of the platform's open positions.
+//
+// A breach holds position-INCREASING actions for that platform's strategies
+// with the same semantics as the #1269 daily loss hold (pausedBlocksSignal
+// at the dispatch sites, pausedOptionsActions for options). Nothing is
+// force-closed — the portfolio kill switch stays the only flattening path.
+// The hold is unlatched: it is recomputed every cycle and clears once the
+// platform drawdown recovers under the limit or notional falls under the cap.
+// Only the peak (and the breach bookkeeping used for edge-triggered alerts)
+// is persisted, in the platform_risk table.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PlatformRiskState is the persisted per-platform risk state.
+type PlatformRiskState struct {
+	PeakValue          float64   `json:"peak_value"`
+	CurrentDrawdownPct float64   `json:"current_drawdown_pct"`
+	Breached           bool      `json:"breached,omitempty"`
+	BreachedAt         time.Time `json:"breached_at,omitempty"`
+	Reason             string    `json:"reason,omitempty"`
+}
+
+// PlatformRiskStatus is one platform's once-per-cycle evaluation.
+type PlatformRiskStatus struct {
+	Platform         string
+	Value            float64
+	Notional         float64
+	DrawdownPct      float64
+	DrawdownBreached bool
+	NotionalBreached bool
+	NewlyBreached    bool // entered the breached state this cycle (alert edge)
+	Reason           string
+}
+
+// Held reports whether the platform's position-increasing actions are held.
+func (st PlatformRiskStatus) Held() bool {
+	return st.DrawdownBreached || st.NotionalBreached
+}
+
+// platformRiskEnforced reports whether a platform override carries a limit
+// enforced at the platform level.
+func platformRiskEnforced(pc *PlatformConfig) bool {
+	return pc != nil && pc.Risk != nil && (pc.Risk.MaxDrawdownPct > 0 || pc.Risk.MaxNotionalUSD > 0)
+}
+
+// evaluatePlatformRisk values every platform with an enforced override,
+// ratchets its peak (unless freezePeak — shared-wallet fallback cycles, same
+// rule as the portfolio peak, #243), and returns the status per platform.
+// Mutates state.PlatformRisk; call under mu.Lock. Platforms whose override
+// was removed drop their persisted state.
+func evaluatePlatformRisk(cfg *Config, state *AppState, prices map[string]float64, walletBalances map[SharedWalletKey]float64, sharedWallets map[SharedWalletKey][]string, freezePeak bool, now time.Time) map[string]PlatformRiskStatus {
+	out := make(map[string]PlatformRiskStatus)
+	for name := range state.PlatformRisk {
+		if !platformRiskEnforced(cfg.Platforms[name]) {
+			delete(state.PlatformRisk, name)
+		}
+	}
+	names := make([]string, 0, len(cfg.Platforms))
+	for name, pc := range cfg.Platforms {
+		if platformRiskEnforced(pc) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		limits := cfg.Platforms[name].Risk
+		var subset []StrategyConfig
+		states := make(map[string]*StrategyState)
+		for _, sc := range cfg.Strategies {
+			if sc.Platform != name {
+				continue
+			}
+			subset = append(subset, sc)
+			if s, ok := state.Strategies[sc.ID]; ok {
+				states[sc.ID] = s
+			}
+		}
+		if len(subset) == 0 {
+			continue
+		}
+		if state.PlatformRisk == nil {
+			state.PlatformRisk = make(map[string]*PlatformRiskState)
+		}
+		prs := state.PlatformRisk[name]
+		if prs == nil {
+			prs = &PlatformRiskState{}
+			state.PlatformRisk[name] = prs
+		}
+
+		value, usedFallback := computeSubsetPortfolioValue(subset, state, prices, walletBalances, sharedWallets)
+		st := PlatformRiskStatus{Platform: name, Value: value, Notional: PortfolioNotional(states, prices)}
+		if value > prs.PeakValue && !(freezePeak || usedFallback) {
+			prs.PeakValue = value
+		}
+		if prs.PeakValue > 0 && value < prs.PeakValue {
+			st.DrawdownPct = (prs.PeakValue - value) / prs.PeakValue * 100
+		}
+		prs.CurrentDrawdownPct = st.DrawdownPct
+
+		var reasons []string
+		if limits.MaxDrawdownPct > 0 && st.DrawdownPct > limits.MaxDrawdownPct {
+			st.DrawdownBreached = true
+			reasons = append(reasons, fmt.Sprintf("platform %s drawdown %.1f%% exceeds limit %.1f%% (value=$%.2f, peak=$%.2f)",
+				name, st.DrawdownPct, limits.MaxDrawdownPct, value, prs.PeakValue))
+		}
+		if limits.MaxNotionalUSD > 0 && st.Notional > limits.MaxNotionalUSD {
+			st.NotionalBreached = true
+			reasons = append(reasons, fmt.Sprintf("platform %s notional $%.2f exceeds cap $%.2f", name, st.Notional, limits.MaxNotionalUSD))
+		}
+		st.Reason = strings.Join(reasons, "; ")
+
+		if st.Held() {
+			st.NewlyBreached = !prs.Breached
+			if !prs.Breached {
+				prs.BreachedAt = now.UTC()
+			}
+			prs.Breached = true
+			prs.Reason = st.Reason
+		} else {
+			prs.Breached = false
+			prs.BreachedAt = time.Time{}
+			prs.Reason = ""
+		}
+		out[name] = st
+	}
+	return out
+}
+
+// sortedPlatformRiskNames returns the platforms in statuses, sorted, so logs
+// and alerts come out in a stable order.
+func sortedPlatformRiskNames(statuses map[string]PlatformRiskStatus) []string {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// platformRiskHoldReason returns the hold detail for a strategy's platform,
+// or "" when the platform is not held this cycle.
+func platformRiskHoldReason(statuses map[string]PlatformRiskStatus, platform string) string {
+	if st, ok := statuses[platform]; ok && st.Held() {
+		return st.Reason
+	}
+	return ""
+}
+
+const platformRiskSummaryPrefix = "[config] Platform risk limits (entries held on breach): "
+
+// platformRiskStartupSummaryLine renders the [config] line listing enforced
+// platform limits, or "" when none are set.
+func platformRiskStartupSummaryLine(cfg *Config) string {
+	var parts []string
+	names := make([]string, 0, len(cfg.Platforms))
+	for name := range cfg.Platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pc := cfg.Platforms[name]
+		if !platformRiskEnforced(pc) {
+			continue
+		}
+		p := name + ":"
+		if pc.Risk.MaxDrawdownPct > 0 {
+			p += fmt.Sprintf(" dd<=%.1f%%", pc.Risk.MaxDrawdownPct)
+		}
+		if pc.Risk.MaxNotionalUSD > 0 {
+			p += fmt.Sprintf(" notional<=$%.0f", pc.Risk.MaxNotionalUSD)
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return platformRiskSummaryPrefix + strings.Join(parts, "; ")
+}
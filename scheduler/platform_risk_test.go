@@ -0,0 +1,132 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func platformRiskFixture() (*Config, *AppState) {
+	cfg := &Config{
+		Platforms: map[string]*PlatformConfig{
+			"hyperliquid": {Risk: &PortfolioRiskConfig{MaxDrawdownPct: 15}},
+			"binanceus":   {Risk: &PortfolioRiskConfig{MaxNotionalUSD: 500}},
+			"okx":         {},
+		},
+		Strategies: []StrategyConfig{
+			{ID: "hl-a", Type: "perps", Platform: "hyperliquid", Capital: 1000},
+			{ID: "hl-b", Type: "perps", Platform: "hyperliquid", Capital: 1000},
+			{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000},
+			{ID: "okx-a", Type: "spot", Platform: "okx", Capital: 1000},
+		},
+	}
+	state := NewAppState()
+	for _, sc := range cfg.Strategies {
+		state.Strategies[sc.ID] = NewStrategyState(sc)
+	}
+	return cfg, state
+}
+
+func TestEvaluatePlatformRisk_DrawdownHold(t *testing.T) {
+	cfg, state := platformRiskFixture()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	st := evaluatePlatformRisk(cfg, state, nil, nil, nil, false, now)
+	if _, ok := st["okx"]; ok {
+		t.Error("okx has no enforced limit and must not be evaluated")
+	}
+	if hl := st["hyperliquid"]; hl.Held() || hl.Value != 2000 || state.PlatformRisk["hyperliquid"].PeakValue != 2000 {
+		t.Fatalf("baseline: %+v peak=%+v", hl, state.PlatformRisk["hyperliquid"])
+	}
+
+	// 20% platform drawdown (hl-a loses 400) breaches the 15% limit.
+	state.Strategies["hl-a"].Cash = 600
+	st = evaluatePlatformRisk(cfg, state, nil, nil, nil, false, now)
+	hl := st["hyperliquid"]
+	if !hl.DrawdownBreached || !hl.NewlyBreached || hl.DrawdownPct != 20 {
+		t.Fatalf("breach: %+v", hl)
+	}
+	if why := platformRiskHoldReason(st, "hyperliquid"); !strings.Contains(why, "drawdown 20.0% exceeds limit 15.0%") {
+		t.Errorf("hold reason = %q", why)
+	}
+	if platformRiskHoldReason(st, "okx") != "" || platformRiskHoldReason(st, "binanceus") != "" {
+		t.Error("other platforms must not be held")
+	}
+	if st = evaluatePlatformRisk(cfg, state, nil, nil, nil, false, now); st["hyperliquid"].NewlyBreached {
+		t.Error("second breached cycle must not re-alert")
+	}
+
+	// Recovery under the limit clears the hold; the peak stays.
+	state.Strategies["hl-a"].Cash = 900
+	st = evaluatePlatformRisk(cfg, state, nil, nil, nil, false, now)
+	if st["hyperliquid"].Held() || state.PlatformRisk["hyperliquid"].Breached || state.PlatformRisk["hyperliquid"].PeakValue != 2000 {
+		t.Errorf("recovery: %+v state=%+v", st["hyperliquid"], state.PlatformRisk["hyperliquid"])
+	}
+
+	// Removing the override drops the persisted platform state.
+	delete(cfg.Platforms, "hyperliquid")
+	evaluatePlatformRisk(cfg, state, nil, nil, nil, false, now)
+	if _, ok := state.PlatformRisk["hyperliquid"]; ok {
+		t.Error("platform state kept after its limit was removed")
+	}
+}
+
+func TestEvaluatePlatformRisk_NotionalAndFrozenPeak(t *testing.T) {
+	cfg, state := platformRiskFixture()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	s := state.Strategies["sma-btc"]
+	s.Cash = 400
+	s.Positions["BTC/USDT"] = &Position{Symbol: "BTC/USDT", Quantity: 0.01, AvgCost: 60000, Side: "long"}
+	st := evaluatePlatformRisk(cfg, state, map[string]float64{"BTC/USDT": 60000}, nil, nil, false, now)
+	if b := st["binanceus"]; !b.NotionalBreached || b.Notional != 600 {
+		t.Fatalf("notional: %+v", b)
+	}
+
+	// A fallback cycle never raises the platform peak.
+	state.Strategies["hl-a"].Cash = 5000
+	evaluatePlatformRisk(cfg, state, nil, nil, nil, true, now)
+	if got := state.PlatformRisk["hyperliquid"].PeakValue; got != 2000 {
+		t.Errorf("frozen peak = %v, want 2000", got)
+	}
+}
+
+func TestPlatformRiskStateRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := &AppState{Strategies: make(map[string]*StrategyState), PlatformRisk: map[string]*PlatformRiskState{
+		"hyperliquid": {PeakValue: 2000, CurrentDrawdownPct: 20, Breached: true, BreachedAt: at, Reason: "platform hyperliquid drawdown"},
+	}}
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	got, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	prs := got.PlatformRisk["hyperliquid"]
+	if prs == nil || prs.PeakValue != 2000 || !prs.Breached || !prs.BreachedAt.Equal(at) || prs.Reason != "platform hyperliquid drawdown" {
+		t.Errorf("platform risk = %+v", prs)
+	}
+}
+
+func TestValidateConfig_PlatformRiskLimits(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Platforms: map[string]*PlatformConfig{
+			"hyperliquid": {Risk: &PortfolioRiskConfig{MaxDrawdownPct: 120, MaxNotionalUSD: -1}},
+		},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"platforms.hyperliquid.risk.max_drawdown_pct", "platforms.hyperliquid.risk.max_notional_usd"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -122,6 +122,14 @@ type Position struct {
 	// compares this stamp to the live resolution once per boot to catch that
 	// gap. "" = pre-#1277 position, never stamped (drift check skips it).
 	ATRMethodAtOpen string `json:"atr_method_at_open,omitempty"`
+	// Borrow accounting for allow_short paper spot shorts (spot_short.go).
+	// BorrowFeesUSD is the cumulative fee already debited from cash;
+	// BorrowAccruedAt is the accrual watermark (zero = accrue from OpenedAt).
+	BorrowFeesUSD   float64   `json:"borrow_fees_usd,omitempty"`
+	BorrowAccruedAt time.Time `json:"borrow_accrued_at,omitempty"`
+	// Metadata is free-form context attached by executors, /go-trader-note,
+	// or manual-open --meta (#4971). Persisted as positions.metadata_json.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // riskAnchorPrice returns the price geometry that on-chain SL/TP triggers are
@@ -582,6 +590,10 @@ type Trade struct {
 	PositionID      string    `json:"position_id"`
 	ExchangeOrderID string    `json:"exchange_order_id,omitempty"` // exchange-provided order ID (e.g. Hyperliquid oid)
 	ExchangeFee     float64   `json:"exchange_fee,omitempty"`      // fee charged by exchange (if available)
+	// SlippageCost is the modeled paper slippage on this fill in dollars
+	// (slippageCost; 0 for live fills). RecordTrade folds it and ExchangeFee
+	// into the strategy's TotalSlippage / TotalFees (#4977).
+	SlippageCost float64 `json:"slippage_cost,omitempty"`
 
 	// IsClose marks closing legs of a round-trip (close, stop-loss, circuit-breaker
 	// liquidation, theta harvest, wheel call-away). Used by lifetime-stats queries
@@ -625,6 +637,21 @@ type Trade struct {
 	TPOIDs            []int64 `json:"tp_oids,omitempty"`
 	Manual            bool    `json:"manual,omitempty"` // set when position was opened via manual-open CLI (#569)
 
+	// Tags classify the trade for PnL attribution (#4928): reason, regime,
+	// signal, source. Executors may preset any key; RecordTrade fills the
+	// rest via tagTrade. Persisted as trades.tags_json.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Metadata is free-form context (entry thesis, linked order IDs, hedge
+	// association) set by executors, Discord /go-trader-note, or manual
+	// tooling (#4971). Persisted as trades.metadata_json.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Indicators is the numeric snapshot of the check script's indicators
+	// for the signal that produced this trade (#4952). Persisted as
+	// trades.indicators_json; nil for trades not driven by a signal.
+	Indicators map[string]float64 `json:"indicators,omitempty"`
+
 	// SL arming method + TP tier snapshot at fill time (#669). StopLossATRMult
 	// is non-nil iff SL was ATR-armed (sc.StopLossATRMult>0 OR
 	// sc.TrailingStopATRMult>0); the value is the configured multiplier
@@ -1446,6 +1473,7 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 				Side:            "buy",
 				Quantity:        closeQty,
 				Price:           execPrice,
+				SlippageCost:    slippageCost("buy", closeQty, price, execPrice),
 				Value:           closeQty * execPrice,
 				TradeType:       "perps",
 				Details:         details,
@@ -1524,7 +1552,13 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 				return tradesExecuted, nil
 			}
 			budget := PerpsOpenNotionalSized(s.Cash, execPrice, sizing)
-			qty = budget / execPrice
+			spec := symbolSpecFor(s.Platform, symbol)
+			execPrice = spec.RoundPrice(execPrice)
+			var why string
+			if qty, why = spec.openQty(budget/execPrice, execPrice); why != "" {
+				logger.Info("Skipping open long %s perp: %s", symbol, why)
+				return tradesExecuted, nil
+			}
 		}
 		notional := qty * execPrice
 		useFillFee := flipCloseQty == 0
@@ -1565,6 +1599,7 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 			Side:            "buy",
 			Quantity:        qty,
 			Price:           execPrice,
+			SlippageCost:    slippageCost("buy", qty, price, execPrice),
 			Value:           notional,
 			TradeType:       "perps",
 			Details:         fmt.Sprintf("Open long %.6f @ $%.2f (%s, fee $%.2f)", qty, execPrice, leverageLabel, fee),
@@ -1659,6 +1694,7 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 				Side:            "sell",
 				Quantity:        closeQty,
 				Price:           execPrice,
+				SlippageCost:    slippageCost("sell", closeQty, price, execPrice),
 				Value:           closeQty * execPrice,
 				TradeType:       "perps",
 				Details:         details,
@@ -1726,7 +1762,13 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 				return tradesExecuted, nil
 			}
 			budget := PerpsOpenNotionalSized(s.Cash, execPrice, sizing)
-			qty = budget / execPrice
+			spec := symbolSpecFor(s.Platform, symbol)
+			execPrice = spec.RoundPrice(execPrice)
+			var why string
+			if qty, why = spec.openQty(budget/execPrice, execPrice); why != "" {
+				logger.Info("Skipping open short %s perp: %s", symbol, why)
+				return tradesExecuted, nil
+			}
 		}
 		notional := qty * execPrice
 		useFillFee := flipCloseQty == 0
@@ -1767,6 +1809,7 @@ func executePerpsSignalWithLeverage(s *StrategyState, signal int, symbol string,
 			Side:            "sell",
 			Quantity:        qty,
 			Price:           execPrice,
+			SlippageCost:    slippageCost("sell", qty, price, execPrice),
 			Value:           notional,
 			TradeType:       "perps",
 			Details:         fmt.Sprintf("Open short %.6f @ $%.2f (%s, fee $%.2f)", qty, execPrice, leverageLabel, fee),
@@ -1807,15 +1850,26 @@ func perpsSizingLabel(sizing PerpsSizing) string {
 // leg reduces pos.Quantity (paper) or uses fillQty (live) without deleting
 // the position. closeFraction == 0 preserves the legacy full-close semantics.
 func ExecuteSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, price float64, fillQty float64, fillFee float64, fillOID string, closeFraction float64, logger *StrategyLogger) (int, error) {
-	out, err := executeSpotSignalWithFillFee(s, signal, symbol, price, fillQty, fillFee, fillOID, closeFraction, logger, func(trade Trade) {
+	out, err := executeSpotSignalWithFillFee(s, signal, symbol, price, fillQty, fillFee, fillOID, closeFraction, 1, logger, func(trade Trade) {
 		RecordTrade(s, trade)
 	})
 	return out.TradesExecuted, err
 }
 
 func ExecuteSpotSignalWithFillFeeDeferredOpen(s *StrategyState, signal int, symbol string, price float64, fillQty float64, fillFee float64, fillOID string, closeFraction float64, logger *StrategyLogger) (SignalExecutionResult, error) {
+	return executeSpotSignalDeferredOpen(s, signal, symbol, price, fillQty, fillFee, fillOID, closeFraction, 1, logger)
+}
+
+// ExecuteSpotPaperSignalSizedDeferredOpen is the paper DeferredOpen executor
+// with the strategy's resolved size_fraction applied to a fresh open's cash
+// budget (size_fraction.go). Closes are unaffected.
+func ExecuteSpotPaperSignalSizedDeferredOpen(s *StrategyState, signal int, symbol string, price, sizeFraction, closeFraction float64, logger *StrategyLogger) (SignalExecutionResult, error) {
+	return executeSpotSignalDeferredOpen(s, signal, symbol, price, 0, 0, "", closeFraction, sizeFraction, logger)
+}
+
+func executeSpotSignalDeferredOpen(s *StrategyState, signal int, symbol string, price float64, fillQty float64, fillFee float64, fillOID string, closeFraction, sizeFraction float64, logger *StrategyLogger) (SignalExecutionResult, error) {
 	var result SignalExecutionResult
-	out, err := executeSpotSignalWithFillFee(s, signal, symbol, price, fillQty, fillFee, fillOID, closeFraction, logger, func(trade Trade) {
+	out, err := executeSpotSignalWithFillFee(s, signal, symbol, price, fillQty, fillFee, fillOID, closeFraction, sizeFraction, logger, func(trade Trade) {
 		t := trade
 		result.OpenTrade = &t
 	})
@@ -1834,7 +1888,7 @@ type spotSignalExecOutcome struct {
 	CashOverBudgetAlert   string
 }
 
-func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, price float64, fillQty float64, fillFee float64, fillOID string, closeFraction float64, logger *StrategyLogger, recordOpen func(Trade)) (spotSignalExecOutcome, error) {
+func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, price float64, fillQty float64, fillFee float64, fillOID string, closeFraction, sizeFraction float64, logger *StrategyLogger, recordOpen func(Trade)) (spotSignalExecOutcome, error) {
 	if signal == 0 {
 		return spotSignalExecOutcome{}, nil
 	}
@@ -1876,9 +1930,18 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 				fillMetadataUsed = true
 			}
 			totalCost := buyCost + fee
-			pnl := closeQty*pos.AvgCost - totalCost
+			// The open posted closeQty*AvgCost as collateral (PortfolioValue's
+			// short branch); the close returns it along with the P&L. Borrow
+			// (allow_short, spot_short.go) was already debited from cash as it
+			// accrued, so it only reduces the reported PnL here.
+			borrow := 0.0
+			if pos.BorrowFeesUSD > 0 && pos.Quantity > 0 {
+				borrow = pos.BorrowFeesUSD * closeQty / pos.Quantity
+				pos.BorrowFeesUSD -= borrow
+			}
+			pnl := closeQty*pos.AvgCost - totalCost - borrow
 			grossPnL := pnl + fee
-			s.Cash += closeQty*pos.AvgCost - totalCost
+			s.Cash += 2*closeQty*pos.AvgCost - totalCost
 			maybeClearCashReconcileRequired(s)
 			now := time.Now().UTC()
 			positionID := ensurePositionTradeID(s.ID, symbol, pos)
@@ -1886,6 +1949,9 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 			if partialClose {
 				details = fmt.Sprintf("Partial-close short %.6f, PnL: $%.2f (fee $%.2f)", closeQty, pnl, fee)
 			}
+			if borrow > 0 {
+				details += fmt.Sprintf(" (borrow $%.2f)", borrow)
+			}
 			trade := Trade{
 				Timestamp:       now,
 				StrategyID:      s.ID,
@@ -1894,6 +1960,7 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 				Side:            "buy",
 				Quantity:        closeQty,
 				Price:           execPrice,
+				SlippageCost:    slippageCost("buy", closeQty, price, execPrice),
 				Value:           totalCost,
 				TradeType:       "spot",
 				Details:         details,
@@ -1938,6 +2005,9 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 		// fills are booked, then CRITICAL-alerted and marked reconcile-required.
 		budget := s.Cash
 		liveBuy := fillQty > 0
+		if !liveBuy && sizeFraction > 0 && sizeFraction < 1 {
+			budget *= sizeFraction
+		}
 		if !liveBuy && budget < 1 {
 			logger.Info("Insufficient cash ($%.2f) to buy %s", s.Cash, symbol)
 			out.TradesExecuted = tradesExecuted
@@ -1953,7 +2023,14 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 				out.TradesExecuted = tradesExecuted
 				return out, nil
 			}
-			qty = budget / execPrice
+			spec := symbolSpecFor(s.Platform, symbol)
+			execPrice = spec.RoundPrice(execPrice)
+			var why string
+			if qty, why = spec.openQty(budget/execPrice, execPrice); why != "" {
+				logger.Info("Skipping buy %s: %s", symbol, why)
+				out.TradesExecuted = tradesExecuted
+				return out, nil
+			}
 		}
 		tradeCost := qty * execPrice
 		useFillMetadata := fillQty > 0 && !fillMetadataUsed
@@ -1995,6 +2072,7 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 			Side:            "buy",
 			Quantity:        qty,
 			Price:           execPrice,
+			SlippageCost:    slippageCost("buy", qty, price, execPrice),
 			Value:           totalDebit,
 			TradeType:       "spot",
 			Details:         details,
@@ -2050,6 +2128,7 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 				Side:            "sell",
 				Quantity:        closeQty,
 				Price:           execPrice,
+				SlippageCost:    slippageCost("sell", closeQty, price, execPrice),
 				Value:           netProceeds,
 				TradeType:       "spot",
 				Details:         details,
@@ -2076,6 +2155,8 @@ func executeSpotSignalWithFillFee(s *StrategyState, signal int, symbol string, p
 				logger.Info("SELL %s: %.6f @ $%.2f (fee $%.2f) | PnL: $%.2f", symbol, closeQty, execPrice, fee, pnl)
 			}
 			tradesExecuted++
+		} else if exists && pos.Side == "short" {
+			logger.Info("Already short %s (qty=%.6f), skipping sell", symbol, pos.Quantity)
 		} else {
 			logger.Info("No long position in %s to sell, skipping", symbol)
 		}
@@ -2169,6 +2250,7 @@ func executeFuturesSignalWithFillFee(s *StrategyState, signal int, symbol string
 				Side:            "buy",
 				Quantity:        float64(contracts),
 				Price:           execPrice,
+				SlippageCost:    slippageCost("buy", float64(contracts)*multiplier, price, execPrice),
 				Value:           float64(contracts) * multiplier * execPrice,
 				TradeType:       "futures",
 				Details:         details,
@@ -2259,6 +2341,7 @@ func executeFuturesSignalWithFillFee(s *StrategyState, signal int, symbol string
 			Side:            "buy",
 			Quantity:        float64(contracts),
 			Price:           execPrice,
+			SlippageCost:    slippageCost("buy", float64(contracts)*multiplier, price, execPrice),
 			Value:           float64(contracts) * marginPerContract,
 			TradeType:       "futures",
 			Details:         fmt.Sprintf("Open long %d contracts @ $%.2f (fee $%.2f)", contracts, execPrice, fee),
@@ -2320,6 +2403,7 @@ func executeFuturesSignalWithFillFee(s *StrategyState, signal int, symbol string
 				Side:            "sell",
 				Quantity:        float64(contracts),
 				Price:           execPrice,
+				SlippageCost:    slippageCost("sell", float64(contracts)*multiplier, price, execPrice),
 				Value:           float64(contracts) * multiplier * execPrice,
 				TradeType:       "futures",
 				Details:         details,
@@ -2409,6 +2493,7 @@ func executeFuturesSignalWithFillFee(s *StrategyState, signal int, symbol string
 				Side:            "sell",
 				Quantity:        float64(contracts),
 				Price:           execPrice,
+				SlippageCost:    slippageCost("sell", float64(contracts)*multiplier, price, execPrice),
 				Value:           float64(contracts) * marginPerContract,
 				TradeType:       "futures",
 				Details:         fmt.Sprintf("Open short %d contracts @ $%.2f (fee $%.2f)", contracts, execPrice, fee),
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// portfolioRiskStateVersion is the schema version stamped on the persisted
+// portfolio_risk row. LoadState refuses a row written by a newer scheduler
+// rather than silently dropping fields it does not know (a downgrade would
+// otherwise re-save a truncated high-water mark). Legacy rows (version 0,
+// pre-versioning) load unchanged and are stamped on the next save.
+const portfolioRiskStateVersion = 1
+
+// maxPortfolioRiskHistory caps the daily drawdown history (~13 months).
+const maxPortfolioRiskHistory = 400
+
+// Peak sources recorded in PortfolioRiskState.PeakSource. Only
+// peakSourceHighWater comes from observed value; the rest are rebaselines,
+// and a rebaseline that lowers the peak is written to the kill-switch log.
+const (
+	peakSourceInit            = "init"
+	peakSourceHighWater       = "high_water"
+	peakSourcePruneRebaseline = "prune_rebaseline"
+	peakSourceAutoReset       = "auto_reset"
+)
+
+// PortfolioRiskSample is one UTC day of portfolio value history: the highest
+// and last observed value and the deepest equity drawdown seen that day.
+type PortfolioRiskSample struct {
+	Date           string  `json:"date"` // YYYY-MM-DD (UTC)
+	HighValue      float64 `json:"high_value"`
+	LastValue      float64 `json:"last_value"`
+	MaxDrawdownPct float64 `json:"max_drawdown_pct"`
+}
+
+// rebaselinePortfolioPeak replaces PeakValue with value outside the normal
+// upward ratchet and records why. A rebaseline that lowers the peak is
+// logged as a "peak_rebaseline" kill-switch event so a restart or config
+// edit can never reset the high-water mark without an audit trail.
+func rebaselinePortfolioPeak(prs *PortfolioRiskState, value float64, source, details string, now time.Time) {
+	old := prs.PeakValue
+	prs.PeakValue = value
+	prs.PeakAt = now.UTC()
+	prs.PeakSource = source
+	if old > 0 && value < old {
+		dd := (old - value) / old * 100
+		addKillSwitchEvent(prs, "peak_rebaseline", "", dd, value, old,
+			fmt.Sprintf("%s: peak $%.2f -> $%.2f", details, old, value))
+	}
+}
+
+// recordPortfolioRiskSample folds one observation into the current UTC day's
+// history sample, appending a new day when the date rolls over.
+func recordPortfolioRiskSample(prs *PortfolioRiskState, value, drawdownPct float64, now time.Time) {
+	date := now.UTC().Format("2006-01-02")
+	if n := len(prs.History); n > 0 && prs.History[n-1].Date == date {
+		s := &prs.History[n-1]
+		if value > s.HighValue {
+			s.HighValue = value
+		}
+		s.LastValue = value
+		if drawdownPct > s.MaxDrawdownPct {
+			s.MaxDrawdownPct = drawdownPct
+		}
+		return
+	}
+	prs.History = append(prs.History, PortfolioRiskSample{Date: date, HighValue: value, LastValue: value, MaxDrawdownPct: drawdownPct})
+	if len(prs.History) > maxPortfolioRiskHistory {
+		prs.History = prs.History[len(prs.History)-maxPortfolioRiskHistory:]
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPortfolioRiskStateRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	peakAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &AppState{Strategies: make(map[string]*StrategyState)}
+	state.PortfolioRisk = PortfolioRiskState{
+		PeakValue:        12500,
+		PeakAt:           peakAt,
+		PeakSource:       peakSourceHighWater,
+		KillSwitchActive: true,
+		KillSwitchAt:     peakAt.Add(time.Hour),
+		KillSwitchReason: "portfolio drawdown 26.0% exceeds limit 25.0%",
+		History: []PortfolioRiskSample{
+			{Date: "2026-03-01", HighValue: 12500, LastValue: 12000, MaxDrawdownPct: 4},
+			{Date: "2026-03-02", HighValue: 11000, LastValue: 9250, MaxDrawdownPct: 26},
+		},
+	}
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	got, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	pr := got.PortfolioRisk
+	if pr.Version != portfolioRiskStateVersion || pr.PeakValue != 12500 || !pr.PeakAt.Equal(peakAt) || pr.PeakSource != peakSourceHighWater {
+		t.Errorf("peak metadata = %+v", pr)
+	}
+	if pr.KillSwitchReason != state.PortfolioRisk.KillSwitchReason {
+		t.Errorf("kill switch reason = %q", pr.KillSwitchReason)
+	}
+	if len(pr.History) != 2 || pr.History[1] != state.PortfolioRisk.History[1] {
+		t.Errorf("history = %+v", pr.History)
+	}
+}
+
+func TestLoadState_RejectsNewerPortfolioRiskVersion(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.SaveState(&AppState{Strategies: make(map[string]*StrategyState)}); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if _, err := db.db.Exec("UPDATE portfolio_risk SET version = ? WHERE id = 1", portfolioRiskStateVersion+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.LoadState(); err == nil || !strings.Contains(err.Error(), "newer than supported") {
+		t.Errorf("LoadState err = %v, want version rejection", err)
+	}
+}
+
+func TestRebaselinePortfolioPeakLogsDecrease(t *testing.T) {
+	now := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	var prs PortfolioRiskState
+	rebaselinePortfolioPeak(&prs, 5000, peakSourceInit, "initial peak", now)
+	if prs.PeakValue != 5000 || prs.PeakSource != peakSourceInit || len(prs.Events) != 0 {
+		t.Fatalf("init: %+v", prs)
+	}
+	rebaselinePortfolioPeak(&prs, 4000, peakSourcePruneRebaseline, "strategies pruned from config", now)
+	if len(prs.Events) != 1 || prs.Events[0].Type != "peak_rebaseline" || prs.Events[0].PeakValue != 5000 {
+		t.Fatalf("lowered peak not audited: %+v", prs.Events)
+	}
+	if prs.PeakValue != 4000 || prs.PeakSource != peakSourcePruneRebaseline {
+		t.Errorf("peak = %+v", prs)
+	}
+}
+
+func TestRecordPortfolioRiskSample(t *testing.T) {
+	var prs PortfolioRiskState
+	day := time.Date(2026, 5, 1, 1, 0, 0, 0, time.UTC)
+	recordPortfolioRiskSample(&prs, 1000, 0, day)
+	recordPortfolioRiskSample(&prs, 1100, 0, day.Add(time.Hour))
+	recordPortfolioRiskSample(&prs, 990, 10, day.Add(2*time.Hour))
+	recordPortfolioRiskSample(&prs, 1000, 9, day.Add(24*time.Hour))
+	if len(prs.History) != 2 {
+		t.Fatalf("history = %+v", prs.History)
+	}
+	if s := prs.History[0]; s.HighValue != 1100 || s.LastValue != 990 || s.MaxDrawdownPct != 10 {
+		t.Errorf("day 1 = %+v", s)
+	}
+	for i := 0; i < maxPortfolioRiskHistory+5; i++ {
+		recordPortfolioRiskSample(&prs, 1000, 0, day.Add(time.Duration(i+2)*24*time.Hour))
+	}
+	if len(prs.History) != maxPortfolioRiskHistory {
+		t.Errorf("history len = %d, want cap %d", len(prs.History), maxPortfolioRiskHistory)
+	}
+}
@@ -673,7 +673,7 @@ func TestRunOKXExecuteOrder_CashReconcileGate(t *testing.T) {
 	t.Cleanup(func() { okxExecuteFn = orig })
 
 	var calls []string
-	okxExecuteFn = func(script, symbol, side string, size float64, instType string) (*OKXExecuteResult, string, error) {
+	okxExecuteFn = func(script, symbol, side string, size float64, instType, clientOrderID string) (*OKXExecuteResult, string, error) {
 		calls = append(calls, side)
 		return &OKXExecuteResult{
 			Execution: &OKXExecution{Action: side, Symbol: symbol, Size: size, Fill: &OKXFill{AvgPx: 100, TotalSz: size}},
@@ -0,0 +1,168 @@
+package main
+
+// Historical portfolio VaR / CVaR.
+//
+// Daily portfolio returns come from the persisted portfolio_risk_history
+// samples (one LastValue per UTC day, see recordPortfolioRiskSample). Only
+// consecutive-day pairs count, so a daemon outage never turns into one
+// multi-day "daily" return. VaR at confidence c is the loss at the (1-c)
+// quantile of those returns; CVaR is the mean loss beyond it. Both are
+// reported as a percent of portfolio value and projected onto the current
+// value in USD.
+//
+// The estimate is always computed (and shown in /status and the channel
+// summaries) once enough history exists. portfolio_risk.max_var_pct turns it
+// into an entry gate: while the projected 1-day VaR exceeds the limit,
+// position-increasing actions are held with the #1269 daily loss semantics
+// (pausedBlocksSignal at the dispatch sites, pausedOptionsActions for
+// options). Nothing is force-closed and the gate is unlatched. Deposits and
+// withdrawals show up as returns — the estimate is a sizing guide, not a
+// reconciled performance figure.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	defaultVaRConfidencePct = 95
+	defaultVaRLookbackDays  = 90
+	// minVaRSamples is the fewest daily returns the estimate needs; below it
+	// VaR is reported as insufficient and the max_var_pct gate is inert.
+	minVaRSamples = 20
+)
+
+// PortfolioVaR is the once-per-cycle historical VaR estimate. Not persisted —
+// it is recomputed from the persisted daily history every cycle.
+type PortfolioVaR struct {
+	ConfidencePct  float64 `json:"confidence_pct"`
+	LookbackDays   int     `json:"lookback_days"`
+	Samples        int     `json:"samples"`
+	VaRPct         float64 `json:"var_pct"`
+	CVaRPct        float64 `json:"cvar_pct"`
+	VaRUSD         float64 `json:"var_usd"`
+	CVaRUSD        float64 `json:"cvar_usd"`
+	PortfolioValue float64 `json:"portfolio_value"`
+	LimitPct       float64 `json:"limit_pct,omitempty"` // max_var_pct (0 = informational only)
+	Breached       bool    `json:"breached,omitempty"`
+	Insufficient   bool    `json:"insufficient,omitempty"` // fewer than minVaRSamples returns
+}
+
+func varConfidencePct(pr *PortfolioRiskConfig) float64 {
+	if pr == nil || pr.VaRConfidencePct <= 0 {
+		return defaultVaRConfidencePct
+	}
+	return pr.VaRConfidencePct
+}
+
+func varLookbackDays(pr *PortfolioRiskConfig) int {
+	if pr == nil || pr.VaRLookbackDays <= 0 {
+		return defaultVaRLookbackDays
+	}
+	return pr.VaRLookbackDays
+}
+
+func maxVaRPct(pr *PortfolioRiskConfig) float64 {
+	if pr == nil {
+		return 0
+	}
+	return pr.MaxVaRPct
+}
+
+// portfolioDailyReturns returns the daily returns of the history samples dated
+// inside the lookback. Pairs whose dates are not exactly one day apart, or
+// whose previous value is not positive, are skipped.
+func portfolioDailyReturns(history []PortfolioRiskSample, lookbackDays int, now time.Time) []float64 {
+	cutoff := drawdownWindowCutoff(now, lookbackDays)
+	var out []float64
+	for i := 1; i < len(history); i++ {
+		prev, cur := history[i-1], history[i]
+		if cur.Date <= cutoff || prev.LastValue <= 0 {
+			continue
+		}
+		pd, err1 := time.Parse("2006-01-02", prev.Date)
+		cd, err2 := time.Parse("2006-01-02", cur.Date)
+		if err1 != nil || err2 != nil || cd.Sub(pd) != 24*time.Hour {
+			continue
+		}
+		out = append(out, cur.LastValue/prev.LastValue-1)
+	}
+	return out
+}
+
+// historicalVaR returns the historical VaR and CVaR (as positive loss
+// percents) of returns at confidencePct. A tail with no losses yields 0.
+func historicalVaR(returns []float64, confidencePct float64) (varPct, cvarPct float64) {
+	if len(returns) == 0 {
+		return 0, 0
+	}
+	sorted := append([]float64(nil), returns...)
+	sort.Float64s(sorted)
+	idx := int(math.Floor((1 - confidencePct/100) * float64(len(sorted))))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	varPct = math.Max(0, -sorted[idx]*100)
+	var sum float64
+	for _, r := range sorted[:idx+1] {
+		sum += r
+	}
+	cvarPct = math.Max(0, -sum/float64(idx+1)*100)
+	return varPct, cvarPct
+}
+
+// evaluatePortfolioVaR estimates the portfolio VaR from prs.History and
+// projects it onto portfolioValue. Pure read.
+func evaluatePortfolioVaR(pr *PortfolioRiskConfig, prs *PortfolioRiskState, portfolioValue float64, now time.Time) PortfolioVaR {
+	v := PortfolioVaR{
+		ConfidencePct:  varConfidencePct(pr),
+		LookbackDays:   varLookbackDays(pr),
+		PortfolioValue: portfolioValue,
+		LimitPct:       maxVaRPct(pr),
+	}
+	returns := portfolioDailyReturns(prs.History, v.LookbackDays, now)
+	v.Samples = len(returns)
+	if v.Samples < minVaRSamples {
+		v.Insufficient = true
+		return v
+	}
+	v.VaRPct, v.CVaRPct = historicalVaR(returns, v.ConfidencePct)
+	if portfolioValue > 0 {
+		v.VaRUSD = portfolioValue * v.VaRPct / 100
+		v.CVaRUSD = portfolioValue * v.CVaRPct / 100
+	}
+	v.Breached = v.LimitPct > 0 && v.VaRPct > v.LimitPct
+	return v
+}
+
+// portfolioVaRHoldDetail is the one-line operator explanation of a breached
+// max_var_pct gate, shared by the cycle log and the owner DM.
+func portfolioVaRHoldDetail(v PortfolioVaR) string {
+	return fmt.Sprintf("portfolio 1-day VaR(%.0f%%) %.2f%% ($%.2f) exceeds max_var_pct %.2f%%",
+		v.ConfidencePct, v.VaRPct, v.VaRUSD, v.LimitPct)
+}
+
+// portfolioVaRLine renders the VaR estimate for /status and the channel
+// summaries, or "" when no estimate has been computed yet.
+func portfolioVaRLine(v *PortfolioVaR) string {
+	if v == nil {
+		return ""
+	}
+	if v.Insufficient {
+		return fmt.Sprintf("VaR: collecting history (%d/%d daily returns)", v.Samples, minVaRSamples)
+	}
+	line := fmt.Sprintf("VaR(%.0f%%, 1d): %.2f%% ($%.2f) · CVaR %.2f%% ($%.2f) · %dd/%d samples",
+		v.ConfidencePct, v.VaRPct, v.VaRUSD, v.CVaRPct, v.CVaRUSD, v.LookbackDays, v.Samples)
+	if v.Breached {
+		line += fmt.Sprintf(" — 🛑 over max_var_pct %.2f%%, entries held", v.LimitPct)
+	} else if v.LimitPct > 0 {
+		line += fmt.Sprintf(" / limit %.2f%%", v.LimitPct)
+	}
+	return line
+}
+
+// portfolioVaRAlerted throttles the breach DM to the transition into the
+// breached state. Written only from the main trading loop.
+var portfolioVaRAlerted bool
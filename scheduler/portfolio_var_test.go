@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// varHistory builds consecutive daily samples ending at end whose returns
+// cycle through rets.
+func varHistory(end time.Time, days int, rets []float64) []PortfolioRiskSample {
+	out := make([]PortfolioRiskSample, 0, days+1)
+	v := 10000.0
+	start := end.AddDate(0, 0, -days)
+	for i := 0; i <= days; i++ {
+		if i > 0 {
+			v *= 1 + rets[(i-1)%len(rets)]
+		}
+		d := start.AddDate(0, 0, i).Format("2006-01-02")
+		out = append(out, PortfolioRiskSample{Date: d, HighValue: v, LastValue: v})
+	}
+	return out
+}
+
+func TestHistoricalVaR(t *testing.T) {
+	// 20 returns: -5%, -4%, then 18 gains. 95% VaR = 2nd worst (index 1), CVaR = mean of the two.
+	rets := []float64{-0.05, -0.04}
+	for i := 0; i < 18; i++ {
+		rets = append(rets, 0.01)
+	}
+	v, cv := historicalVaR(rets, 95)
+	if math.Abs(v-4) > 1e-9 || math.Abs(cv-4.5) > 1e-9 {
+		t.Errorf("95%%: var=%v cvar=%v, want 4 / 4.5", v, cv)
+	}
+	if v, _ := historicalVaR([]float64{0.01, 0.02}, 95); v != 0 {
+		t.Errorf("no losses must give 0 VaR, got %v", v)
+	}
+}
+
+func TestPortfolioDailyReturns_SkipsGaps(t *testing.T) {
+	now := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	h := []PortfolioRiskSample{
+		{Date: "2026-06-01", LastValue: 100},
+		{Date: "2026-06-02", LastValue: 110},
+		{Date: "2026-06-05", LastValue: 55}, // 3-day gap: skipped
+		{Date: "2026-06-06", LastValue: 66},
+	}
+	got := portfolioDailyReturns(h, 30, now)
+	if len(got) != 2 || math.Abs(got[0]-0.1) > 1e-9 || math.Abs(got[1]-0.2) > 1e-9 {
+		t.Errorf("returns = %v", got)
+	}
+}
+
+func TestEvaluatePortfolioVaR(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	prs := &PortfolioRiskState{History: varHistory(now, 10, []float64{-0.03, 0.01})}
+	if v := evaluatePortfolioVaR(nil, prs, 10000, now); !v.Insufficient || v.Breached {
+		t.Fatalf("10 samples must be insufficient: %+v", v)
+	}
+
+	prs.History = varHistory(now, 60, []float64{-0.03, 0.01, 0.01, 0.01})
+	v := evaluatePortfolioVaR(&PortfolioRiskConfig{MaxVaRPct: 2}, prs, 10000, now)
+	if v.Insufficient || v.Samples != 60 || math.Abs(v.VaRPct-3) > 1e-6 || math.Abs(v.VaRUSD-300) > 1e-3 {
+		t.Fatalf("estimate: %+v", v)
+	}
+	if !v.Breached || !strings.Contains(portfolioVaRHoldDetail(v), "exceeds max_var_pct 2.00%") {
+		t.Errorf("3%% VaR over a 2%% limit must breach: %+v", v)
+	}
+	if v := evaluatePortfolioVaR(&PortfolioRiskConfig{MaxVaRPct: 5}, prs, 10000, now); v.Breached {
+		t.Errorf("under the limit must not breach: %+v", v)
+	}
+}
+
+func TestValidateConfig_VaR(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		PortfolioRisk:   &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60, VaRConfidencePct: 40, VaRLookbackDays: 5, MaxVaRPct: -1},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"var_confidence_pct", "var_lookback_days", "max_var_pct"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+// position_adjust: register or correct a position in state (#4972).
+//
+// Positions opened by hand on the exchange, or quantities that drifted after
+// fees, used to need a hand-edited state DB. `go-trader adjust-position` and
+// the owner-DM /go-trader-position set a strategy's position for one symbol
+// to an explicit quantity, average cost and side:
+//   - no position yet → one is registered, opened now;
+//   - an existing position → its quantity and average cost are replaced;
+//   - quantity 0 → the position is removed.
+//
+// Every adjustment books one audit trade (Manual, Details "Manual position
+// adjustment: …", reason tag position_adjustment) for the quantity delta at
+// the new average cost. Cash and realized PnL are left alone: the adjustment
+// records what the venue already holds, it is not a fill. The CLI queues an
+// "adjust-position" pending manual action the scheduler drains next cycle;
+// the Discord command applies in-process after an explicit confirm.
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// positionAdjustment is the target state for one symbol.
+type positionAdjustment struct {
+	Symbol   string
+	Quantity float64 // 0 removes the position
+	AvgCost  float64
+	Side     string // "long" | "short"; "" keeps the existing side (long for a new position)
+	Origin   string // audit-trade label; "" means "Manual"
+}
+
+// validatePositionAdjustment checks adj against sc before anything is queued
+// or applied.
+func validatePositionAdjustment(sc StrategyConfig, adj positionAdjustment) error {
+	switch sc.Type {
+	case "spot", "perps", "futures", "manual":
+	default:
+		return fmt.Errorf("%s is type %q; position adjustments apply to spot, perps, futures and manual strategies", sc.ID, sc.Type)
+	}
+	if strings.TrimSpace(adj.Symbol) == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if adj.Quantity < 0 || math.IsNaN(adj.Quantity) || math.IsInf(adj.Quantity, 0) {
+		return fmt.Errorf("quantity must be >= 0, got %g", adj.Quantity)
+	}
+	if adj.Quantity > 0 && (adj.AvgCost <= 0 || math.IsNaN(adj.AvgCost) || math.IsInf(adj.AvgCost, 0)) {
+		return fmt.Errorf("avg cost must be > 0, got %g", adj.AvgCost)
+	}
+	switch adj.Side {
+	case "", "long":
+	case "short":
+		if sc.Type == "spot" && !sc.AllowShort {
+			return fmt.Errorf("%s is a spot strategy without allow_short; a short position can't be registered", sc.ID)
+		}
+	default:
+		return fmt.Errorf("side must be long or short, got %q", adj.Side)
+	}
+	return nil
+}
+
+// applyPositionAdjustment sets s's position for adj.Symbol and books the
+// audit trade. Returns a one-line summary. Caller holds mu.
+func applyPositionAdjustment(s *StrategyState, sc StrategyConfig, adj positionAdjustment, now time.Time) (string, error) {
+	if err := validatePositionAdjustment(sc, adj); err != nil {
+		return "", err
+	}
+	sym := adj.Symbol
+	cur := s.Positions[sym]
+	tradeType := sc.Type
+	if tradeType == "manual" {
+		tradeType = "perps"
+	}
+	trade := Trade{
+		Timestamp:  now,
+		StrategyID: s.ID,
+		Symbol:     sym,
+		TradeType:  tradeType,
+		Manual:     true,
+	}
+
+	var summary string
+	switch {
+	case adj.Quantity == 0:
+		if cur == nil {
+			return "", fmt.Errorf("%s has no %s position to remove", s.ID, sym)
+		}
+		trade.PositionID = ensurePositionTradeID(s.ID, sym, cur)
+		trade.Side = closeTradeSide(cur.Side)
+		trade.Quantity = cur.Quantity
+		trade.Price = cur.AvgCost
+		summary = fmt.Sprintf("removed %s %s %g @ $%.4f", sym, cur.Side, cur.Quantity, cur.AvgCost)
+		delete(s.Positions, sym)
+
+	case cur == nil:
+		if sc.Type == "futures" {
+			return "", fmt.Errorf("registering a new futures position needs the contract multiplier from a live check; adjust an existing position instead")
+		}
+		side := adj.Side
+		if side == "" {
+			side = "long"
+		}
+		cur = &Position{
+			Symbol:          sym,
+			Quantity:        adj.Quantity,
+			InitialQuantity: adj.Quantity,
+			AvgCost:         adj.AvgCost,
+			Side:            side,
+			OwnerStrategyID: s.ID,
+			OpenedAt:        now,
+			TradePositionID: newTradePositionID(s.ID, sym, now),
+		}
+		// Spot positions keep Multiplier 0 so PortfolioValue carries their
+		// notional and a later sell's proceeds replace it (and allow_short
+		// shorts accrue borrow); perps are valued on PnL at Multiplier 1.
+		if sc.Type != "spot" {
+			cur.Multiplier = 1
+			cur.Leverage = sc.Leverage
+		}
+		s.Positions[sym] = cur
+		trade.PositionID = cur.TradePositionID
+		trade.Side = openTradeSide(side)
+		trade.Quantity = adj.Quantity
+		trade.Price = adj.AvgCost
+		summary = fmt.Sprintf("registered %s %s %g @ $%.4f", sym, side, adj.Quantity, adj.AvgCost)
+
+	default:
+		if adj.Side != "" && adj.Side != cur.Side {
+			return "", fmt.Errorf("%s %s is %s; remove it (quantity 0) before registering a %s", s.ID, sym, cur.Side, adj.Side)
+		}
+		delta := adj.Quantity - cur.Quantity
+		trade.PositionID = ensurePositionTradeID(s.ID, sym, cur)
+		trade.Side = openTradeSide(cur.Side)
+		if delta < 0 {
+			trade.Side = closeTradeSide(cur.Side)
+		}
+		trade.Quantity = math.Abs(delta)
+		trade.Price = adj.AvgCost
+		summary = fmt.Sprintf("%s %s %g @ $%.4f → %g @ $%.4f", sym, cur.Side, cur.Quantity, cur.AvgCost, adj.Quantity, adj.AvgCost)
+		cur.Quantity = adj.Quantity
+		cur.AvgCost = adj.AvgCost
+		if cur.InitialQuantity < adj.Quantity {
+			cur.InitialQuantity = adj.Quantity
+		}
+	}
+
+	trade.Value = trade.Quantity * trade.Price
+	origin := adj.Origin
+	if origin == "" {
+		origin = "Manual"
+	}
+	trade.Details = origin + " position adjustment: " + summary + " (no cash or PnL booked)"
+	trade.Regime = s.Regime
+	RecordTrade(s, trade)
+	return summary, nil
+}
+
+// findStrategyConfig returns the strategy with id from cfg.
+func findStrategyConfig(cfg *Config, id string) (StrategyConfig, bool) {
+	for _, sc := range cfg.Strategies {
+		if sc.ID == id {
+			return sc, true
+		}
+	}
+	return StrategyConfig{}, false
+}
+
+// runAdjustPosition implements `go-trader adjust-position <strategy-id>
+// --qty N [--avg-cost P] [--side long|short] [--symbol S]`. It validates the
+// request and queues an adjust-position action the scheduler applies on its
+// next cycle.
+func runAdjustPosition(args []string) int {
+	fs := flag.NewFlagSet("adjust-position", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	symbol := fs.String("symbol", "", "Position symbol (defaults to the strategy's configured symbol)")
+	qty := fs.Float64("qty", 0, "Target quantity (required; 0 removes the position)")
+	avgCost := fs.Float64("avg-cost", 0, "Target average cost (required unless --qty 0)")
+	side := fs.String("side", "", "long or short (default: keep the existing side; long for a new position)")
+
+	args = reorderArgsForPositional(args, collectBoolFlagNames(fs))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	qtySet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "qty" {
+			qtySet = true
+		}
+	})
+	if fs.NArg() != 1 || !qtySet {
+		fmt.Fprintln(os.Stderr, "Usage: go-trader adjust-position <strategy-id> --qty N [--avg-cost P] [--side long|short] [--symbol S]")
+		return 2
+	}
+	strategyID := fs.Arg(0)
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	sc, ok := findStrategyConfig(cfg, strategyID)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Strategy %q not found in config%s\n", strategyID, includedFilesNote(cfg))
+		return 1
+	}
+	adj := positionAdjustment{Symbol: *symbol, Quantity: *qty, AvgCost: *avgCost, Side: strings.ToLower(strings.TrimSpace(*side))}
+	if adj.Symbol == "" {
+		adj.Symbol = strategyDisplaySymbol(sc)
+	}
+	if err := validatePositionAdjustment(sc, adj); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+
+	if err := stateDB.InsertPendingManualAction(PendingManualAction{
+		StrategyID: strategyID,
+		Action:     "adjust-position",
+		Symbol:     adj.Symbol,
+		Side:       adj.Side,
+		Quantity:   adj.Quantity,
+		FillPrice:  adj.AvgCost,
+		CreatedAt:  time.Now().UTC(),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to queue position adjustment: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Queued position adjustment for %s %s: qty %g @ $%g — applied on the scheduler's next cycle.\n", strategyID, adj.Symbol, adj.Quantity, adj.AvgCost)
+	return 0
+}
+
+// handlePositionAdjust serves /go-trader-position: validate, DM the change
+// for an explicit confirm, then apply under mu and save.
+func (d *DiscordNotifier) handlePositionAdjust(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	deferAck(s, i)
+	id := optionString(opts, "strategy", "")
+	symbol := optionString(opts, "symbol", "")
+	qty, qtyErr := strconv.ParseFloat(strings.TrimSpace(optionString(opts, "qty", "")), 64)
+	avgCost, _ := strconv.ParseFloat(strings.TrimSpace(optionString(opts, "avg_cost", "0")), 64)
+	if id == "" || symbol == "" || qtyErr != nil {
+		followupText(s, i, "usage: /go-trader-position <strategy> <symbol> <qty> [avg_cost] [side] — qty 0 removes the position")
+		return
+	}
+	if d.ss == nil || d.ss.state == nil || d.ss.mu == nil || d.cfg == nil {
+		followupText(s, i, "status server not ready")
+		return
+	}
+	sc, ok := findStrategyConfig(d.cfg, id)
+	if !ok {
+		followupText(s, i, fmt.Sprintf("unknown strategy %q", id))
+		return
+	}
+	adj := positionAdjustment{Symbol: symbol, Quantity: qty, AvgCost: avgCost, Side: strings.ToLower(optionString(opts, "side", ""))}
+	if err := validatePositionAdjustment(sc, adj); err != nil {
+		followupText(s, i, err.Error())
+		return
+	}
+
+	d.ss.mu.RLock()
+	current := "no position"
+	if ss := d.ss.state.Strategies[id]; ss != nil {
+		if pos := ss.Positions[symbol]; pos != nil {
+			current = fmt.Sprintf("%s %g @ $%.4f", pos.Side, pos.Quantity, pos.AvgCost)
+		}
+	}
+	d.ss.mu.RUnlock()
+	prompt := fmt.Sprintf("Adjust `%s` %s: %s → qty %g @ $%.4f %s\nAn audit trade is recorded; cash and PnL are unchanged.", id, symbol, current, adj.Quantity, adj.AvgCost, adj.Side)
+	if !d.confirmDestructive(interactionUserID(i), prompt) {
+		followupText(s, i, "Cancelled — no confirmation received.")
+		return
+	}
+
+	d.ss.mu.Lock()
+	var summary string
+	var err, saveErr error
+	if ss := d.ss.state.Strategies[id]; ss == nil {
+		err = fmt.Errorf("strategy state for %q not found", id)
+	} else {
+		summary, err = applyPositionAdjustment(ss, sc, adj, time.Now().UTC())
+	}
+	if err == nil && d.ss.stateDB != nil {
+		saveErr = SaveStateWithDB(d.ss.state, d.cfg, d.ss.stateDB)
+	}
+	d.ss.mu.Unlock()
+	d.ss.snapshot.expire()
+
+	if err != nil {
+		followupText(s, i, "position adjustment failed: "+err.Error())
+		return
+	}
+	msg := fmt.Sprintf("✏️ `%s` position adjusted: %s", id, summary)
+	if saveErr != nil {
+		msg += " WARNING: SaveState failed (" + saveErr.Error() + "); the change may not survive a restart before the next successful save."
+	}
+	fmt.Printf("[discord] /position %s: %s\n", id, summary)
+	followupText(s, i, msg)
+}
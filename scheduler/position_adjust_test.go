@@ -0,0 +1,170 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyPositionAdjustment(t *testing.T) {
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+
+	sc := StrategyConfig{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Leverage: 3}
+	s := &StrategyState{ID: "hl-btc", Cash: 1000, Positions: map[string]*Position{}}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Register a position opened by hand on the venue.
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "BTC", Quantity: 0.1, AvgCost: 60000}, now); err != nil {
+		t.Fatal(err)
+	}
+	pos := s.Positions["BTC"]
+	if pos == nil || pos.Side != "long" || pos.Quantity != 0.1 || pos.Leverage != 3 || !pos.OpenedAt.Equal(now) {
+		t.Fatalf("registered position = %+v", pos)
+	}
+
+	// Correct the amount after fees.
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "BTC", Quantity: 0.0995, AvgCost: 60030}, now); err != nil {
+		t.Fatal(err)
+	}
+	if pos.Quantity != 0.0995 || pos.AvgCost != 60030 {
+		t.Fatalf("adjusted position = %+v", pos)
+	}
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "BTC", Quantity: 0.1, AvgCost: 60000, Side: "short"}, now); err == nil {
+		t.Error("side flip accepted on an open position")
+	}
+
+	// Remove it.
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "BTC"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, open := s.Positions["BTC"]; open {
+		t.Fatal("quantity 0 should remove the position")
+	}
+
+	if s.Cash != 1000 {
+		t.Errorf("cash = %v, adjustments must not move cash", s.Cash)
+	}
+	if len(s.TradeHistory) != 3 {
+		t.Fatalf("want 3 audit trades, got %d", len(s.TradeHistory))
+	}
+	for _, tr := range s.TradeHistory {
+		if !tr.Manual || tr.IsClose || tr.RealizedPnL != 0 || tr.Tags[TradeTagReason] != "position_adjustment" {
+			t.Errorf("audit trade = %+v", tr)
+		}
+	}
+	if got := s.TradeHistory[1]; got.Side != "sell" || got.Quantity < 0.00049 || got.Quantity > 0.00051 {
+		t.Errorf("reduction audit trade side=%s qty=%v", got.Side, got.Quantity)
+	}
+}
+
+// A registered spot holding must be valued on notional like any other spot
+// position: selling it later swaps the position for cash without a jump in
+// PortfolioValue.
+func TestApplyPositionAdjustmentSpotRegisterThenSell(t *testing.T) {
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+
+	sc := StrategyConfig{ID: "spot-btc", Type: "spot", Platform: "binanceus"}
+	s := &StrategyState{ID: "spot-btc", Type: "spot", Platform: "binanceus", Cash: 1000, Positions: map[string]*Position{}}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "BTC", Quantity: 0.5, AvgCost: 100}, now); err != nil {
+		t.Fatal(err)
+	}
+	if m := s.Positions["BTC"].Multiplier; m != 0 {
+		t.Fatalf("spot position multiplier = %g, want 0", m)
+	}
+
+	prices := map[string]float64{"BTC": 120}
+	before := PortfolioValue(s, prices)
+	if before != 1060 {
+		t.Fatalf("value after registering = %v, want cash + notional 1060", before)
+	}
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("spot-btc")
+	if _, err := ExecuteSpotSignalWithFillFee(s, -1, "BTC", 120, 0, 0, "", 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	if _, open := s.Positions["BTC"]; open {
+		t.Fatal("sell should close the registered position")
+	}
+	// Only slippage and fees may separate the two values.
+	if after := PortfolioValue(s, prices); math.Abs(after-before) > 1 {
+		t.Errorf("value jumped across the sell: %v -> %v", before, after)
+	}
+}
+
+func TestApplyPositionAdjustmentSpotShortAccruesBorrow(t *testing.T) {
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+
+	sc := StrategyConfig{ID: "spot-eth", Type: "spot", Platform: "binanceus", AllowShort: true}
+	s := &StrategyState{ID: "spot-eth", Cash: 1000, Positions: map[string]*Position{}}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: "ETH", Quantity: 1, AvgCost: 2000, Side: "short"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if fee := accrueSpotShortBorrow(sc, s, "ETH", 2000, now.Add(24*time.Hour)); fee <= 0 {
+		t.Errorf("registered allow_short short accrued no borrow fee (%v)", fee)
+	}
+}
+
+func TestValidatePositionAdjustment(t *testing.T) {
+	spot := StrategyConfig{ID: "spot-btc", Type: "spot"}
+	cases := []struct {
+		sc   StrategyConfig
+		adj  positionAdjustment
+		want string
+	}{
+		{StrategyConfig{ID: "opts", Type: "options"}, positionAdjustment{Symbol: "BTC", Quantity: 1, AvgCost: 1}, "apply to spot"},
+		{spot, positionAdjustment{Symbol: "BTC/USDT", Quantity: -1, AvgCost: 1}, "quantity"},
+		{spot, positionAdjustment{Symbol: "BTC/USDT", Quantity: 1}, "avg cost"},
+		{spot, positionAdjustment{Symbol: "BTC/USDT", Quantity: 1, AvgCost: 1, Side: "short"}, "allow_short"},
+		{spot, positionAdjustment{Symbol: "BTC/USDT", Quantity: 0}, ""},
+	}
+	for _, c := range cases {
+		err := validatePositionAdjustment(c.sc, c.adj)
+		if c.want == "" {
+			if err != nil {
+				t.Errorf("%+v: unexpected error %v", c.adj, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%+v: err = %v, want %q", c.adj, err, c.want)
+		}
+	}
+}
+
+func TestDrainAdjustPosition(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+
+	state := &AppState{Strategies: map[string]*StrategyState{
+		"spot-eth": {ID: "spot-eth", Type: "spot", Cash: 500, Positions: map[string]*Position{}},
+	}}
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "spot-eth", Type: "spot", Args: []string{"sma", "ETH/USDT"}}}}
+	if err := db.InsertPendingManualAction(PendingManualAction{
+		StrategyID: "spot-eth", Action: "adjust-position", Symbol: "ETH/USDT",
+		Quantity: 2, FillPrice: 3000, CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	alerts := drainPendingManualActions(state, cfg, db)
+	if pos := state.Strategies["spot-eth"].Positions["ETH/USDT"]; pos == nil || pos.Quantity != 2 || pos.AvgCost != 3000 {
+		t.Fatalf("position = %+v", pos)
+	}
+	if len(alerts) != 1 || alerts[0].trades != 1 {
+		t.Errorf("alerts = %+v, want one audit trade alert", alerts)
+	}
+}
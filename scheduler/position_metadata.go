@@ -0,0 +1,168 @@
+package main
+
+// position_metadata: free-form notes on positions and trades (#4971).
+//
+// Position, OptionPosition and Trade carry a Metadata map[string]string for
+// context the structured fields don't cover: an entry thesis, linked order
+// IDs, the hedge a leg belongs to. Executors may set it directly; operators
+// set it with `manual-open --meta key=value` (copied onto the position and
+// its open trade) or /go-trader-note on an open position. It is persisted as
+// metadata_json on positions, option_positions and trades and returned by
+// GET /strategies/{id}.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxMetadataKeyLen and maxMetadataValueLen bound operator-supplied notes so
+// one entry can't bloat every state save.
+const (
+	maxMetadataKeyLen   = 64
+	maxMetadataValueLen = 500
+)
+
+// metadataFlag is a repeatable key=value flag.Value.
+type metadataFlag map[string]string
+
+func (m metadataFlag) String() string {
+	return formatMetadata(m)
+}
+
+func (m metadataFlag) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("metadata must be key=value, got %q", v)
+	}
+	key = strings.TrimSpace(key)
+	if err := validateMetadataEntry(key, value); err != nil {
+		return err
+	}
+	m[key] = value
+	return nil
+}
+
+// validateMetadataEntry checks one key/value pair.
+func validateMetadataEntry(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("metadata key must not be empty")
+	}
+	if len(key) > maxMetadataKeyLen {
+		return fmt.Errorf("metadata key %q is longer than %d characters", key, maxMetadataKeyLen)
+	}
+	if len(value) > maxMetadataValueLen {
+		return fmt.Errorf("metadata value for %q is longer than %d characters", key, maxMetadataValueLen)
+	}
+	return nil
+}
+
+// cloneMetadata returns a copy of m, or nil when m is empty.
+func cloneMetadata(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// withMetadataEntry returns m with key set to value; an empty value deletes
+// key. The result is nil when no entries remain.
+func withMetadataEntry(m map[string]string, key, value string) map[string]string {
+	out := cloneMetadata(m)
+	if value == "" {
+		delete(out, key)
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	}
+	if out == nil {
+		out = make(map[string]string, 1)
+	}
+	out[key] = value
+	return out
+}
+
+// formatMetadata renders m as "k=v, k=v" sorted by key.
+func formatMetadata(m map[string]string) string {
+	if len(m) == 0 {
+		return "none"
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+m[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// setPositionNote sets key=value on the metadata of s's position target: a
+// directional position by symbol, else an option position by ID. An empty
+// value removes key. Returns the resulting metadata rendered for a reply.
+// Caller holds mu.
+func setPositionNote(s *StrategyState, target, key, value string) (string, error) {
+	key = strings.TrimSpace(key)
+	if err := validateMetadataEntry(key, value); err != nil {
+		return "", err
+	}
+	if pos := s.Positions[target]; pos != nil {
+		pos.Metadata = withMetadataEntry(pos.Metadata, key, value)
+		return formatMetadata(pos.Metadata), nil
+	}
+	if opt := s.OptionPositions[target]; opt != nil {
+		opt.Metadata = withMetadataEntry(opt.Metadata, key, value)
+		return formatMetadata(opt.Metadata), nil
+	}
+	return "", fmt.Errorf("%s has no open position %q", s.ID, target)
+}
+
+// handleNote serves /go-trader-note. The change is saved immediately so a
+// restart before the next cycle keeps it.
+func (d *DiscordNotifier) handleNote(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	id := optionString(opts, "strategy", "")
+	target := optionString(opts, "position", "")
+	key := optionString(opts, "key", "")
+	if id == "" || target == "" || key == "" {
+		respondText(s, i, "usage: /go-trader-note <strategy> <position> <key> [value] — omit value to remove the key")
+		return
+	}
+	if d.ss == nil || d.ss.state == nil || d.ss.mu == nil {
+		respondText(s, i, "status server not ready")
+		return
+	}
+
+	d.ss.mu.Lock()
+	var summary string
+	var err, saveErr error
+	if ss := d.ss.state.Strategies[id]; ss == nil {
+		err = fmt.Errorf("unknown strategy %q", id)
+	} else {
+		summary, err = setPositionNote(ss, target, key, optionString(opts, "value", ""))
+	}
+	if err == nil && d.ss.stateDB != nil {
+		saveErr = SaveStateWithDB(d.ss.state, d.cfg, d.ss.stateDB)
+	}
+	d.ss.mu.Unlock()
+	d.ss.snapshot.expire()
+
+	if err != nil {
+		respondText(s, i, "note failed: "+err.Error())
+		return
+	}
+	msg := fmt.Sprintf("📝 `%s` %s metadata: %s", id, target, summary)
+	if saveErr != nil {
+		msg += " WARNING: SaveState failed (" + saveErr.Error() + "); the change may not survive a restart before the next successful save."
+	}
+	fmt.Printf("[discord] /note %s %s %s\n", id, target, key)
+	respondText(s, i, msg)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadataFlag(t *testing.T) {
+	m := metadataFlag{}
+	if err := m.Set("thesis=breakout=retest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set("hedge_for=hl-eth"); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.String(); got != "hedge_for=hl-eth, thesis=breakout=retest" {
+		t.Errorf("String = %q", got)
+	}
+	for _, bad := range []string{"novalue", "=x"} {
+		if err := m.Set(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestSetPositionNote(t *testing.T) {
+	s := &StrategyState{
+		ID:              "deribit-wheel-btc",
+		Positions:       map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 1}},
+		OptionPositions: map[string]*OptionPosition{"BTC-put-55000": {ID: "BTC-put-55000"}},
+	}
+	if got, err := setPositionNote(s, "BTC", "thesis", "range bottom"); err != nil || got != "thesis=range bottom" {
+		t.Fatalf("got=%q err=%v", got, err)
+	}
+	if _, err := setPositionNote(s, "BTC-put-55000", "hedge_for", "BTC"); err != nil {
+		t.Fatal(err)
+	}
+	if s.OptionPositions["BTC-put-55000"].Metadata["hedge_for"] != "BTC" {
+		t.Errorf("option metadata = %v", s.OptionPositions["BTC-put-55000"].Metadata)
+	}
+	if got, _ := setPositionNote(s, "BTC", "thesis", ""); got != "none" || s.Positions["BTC"].Metadata != nil {
+		t.Errorf("empty value should remove the key: %q %v", got, s.Positions["BTC"].Metadata)
+	}
+	if _, err := setPositionNote(s, "ETH", "thesis", "x"); err == nil {
+		t.Error("unknown position accepted")
+	}
+}
+
+func TestMetadataPersisted(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	state := &AppState{
+		Strategies: map[string]*StrategyState{
+			"opts": {
+				ID: "opts", Type: "options", Platform: "deribit", Cash: 1000,
+				Positions: map[string]*Position{
+					"BTC": {Symbol: "BTC", Quantity: 1, AvgCost: 60000, Side: "long", Multiplier: 1, OpenedAt: now, Metadata: map[string]string{"thesis": "wheel assignment"}},
+				},
+				OptionPositions: map[string]*OptionPosition{
+					"BTC-call-70000": {ID: "BTC-call-70000", Underlying: "BTC", OptionType: "call", Strike: 70000, Action: "sell", Quantity: 1, OpenedAt: now, Metadata: map[string]string{"hedge_for": "BTC"}},
+				},
+				TradeHistory: []Trade{{Timestamp: now, StrategyID: "opts", Symbol: "BTC", Side: "buy", Quantity: 1, Price: 60000, Value: 60000, TradeType: "spot", Metadata: map[string]string{"linked_order": "abc123"}}},
+			},
+		},
+	}
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	ls := loaded.Strategies["opts"]
+	if got := ls.Positions["BTC"].Metadata["thesis"]; got != "wheel assignment" {
+		t.Errorf("position metadata = %v", ls.Positions["BTC"].Metadata)
+	}
+	if got := ls.OptionPositions["BTC-call-70000"].Metadata["hedge_for"]; got != "BTC" {
+		t.Errorf("option metadata = %v", ls.OptionPositions["BTC-call-70000"].Metadata)
+	}
+	if len(ls.TradeHistory) != 1 || ls.TradeHistory[0].Metadata["linked_order"] != "abc123" {
+		t.Errorf("trade metadata = %+v", ls.TradeHistory)
+	}
+}
+
+func TestDrainManualOpenCopiesMetadata(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+
+	stratID := "hl-manual-eth-live"
+	state := &AppState{Strategies: map[string]*StrategyState{
+		stratID: {ID: stratID, Platform: "hyperliquid", Type: "manual", Positions: map[string]*Position{}, Cash: 10000},
+	}}
+	cfg := &Config{Strategies: []StrategyConfig{{ID: stratID, Type: "manual", Platform: "hyperliquid", Symbol: "ETH", Leverage: 10}}}
+
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+
+	if err := db.InsertPendingManualAction(PendingManualAction{
+		StrategyID: stratID, Action: "open", Symbol: "ETH", Side: "long",
+		Quantity: 0.5, FillPrice: 2000, Metadata: map[string]string{"thesis": "ETF flows"},
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	drainPendingManualActions(state, cfg, db)
+
+	ss := state.Strategies[stratID]
+	if pos := ss.Positions["ETH"]; pos == nil || pos.Metadata["thesis"] != "ETF flows" {
+		t.Fatalf("position metadata not copied: %+v", pos)
+	}
+	if n := len(ss.TradeHistory); n != 1 || ss.TradeHistory[0].Metadata["thesis"] != "ETF flows" {
+		t.Fatalf("open trade metadata not copied: %+v", ss.TradeHistory)
+	}
+}
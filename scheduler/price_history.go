@@ -0,0 +1,214 @@
+package main
+
+// price_history: per-cycle price snapshots on disk (#4929).
+//
+// Each cycle's price map is appended as one JSON line to
+// <db_file>.prices/YYYY-MM-DD.jsonl (UTC day). One file per day keeps
+// retention a matter of deleting old files and bounds how much a range query
+// reads. The store is history for charting, equity reconstruction and replay,
+// not state: writes are not fsync'd and a torn final line is skipped.
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultPriceHistoryDays is the retention when price_history_days is unset.
+const defaultPriceHistoryDays = 30
+
+// maxPriceHistoryDays bounds price_history_days.
+const maxPriceHistoryDays = 3650
+
+// maxPriceHistoryPoints caps one /prices/history response.
+const maxPriceHistoryPoints = 20000
+
+const priceHistoryDayLayout = "2006-01-02"
+
+// PriceHistoryRetentionDays returns cfg.PriceHistoryDays, defaulting to
+// defaultPriceHistoryDays when unset. 0 disables recording.
+func PriceHistoryRetentionDays(cfg *Config) int {
+	if cfg == nil || cfg.PriceHistoryDays == nil {
+		return defaultPriceHistoryDays
+	}
+	return *cfg.PriceHistoryDays
+}
+
+// priceSnapshot is one line of a day file.
+type priceSnapshot struct {
+	T int64              `json:"t"`
+	P map[string]float64 `json:"p"`
+}
+
+// PricePoint is one sample returned by Query.
+type PricePoint struct {
+	Time  int64   `json:"t"`
+	Price float64 `json:"p"`
+}
+
+// PriceHistoryStore appends snapshots and answers range queries.
+type PriceHistoryStore struct {
+	mu            sync.Mutex
+	dir           string
+	retentionDays int
+	lastPruneDay  string
+}
+
+// priceHistory is the package-level store, wired in main. nil disables
+// recording (tests, CLI subcommands, price_history_days=0).
+var priceHistory *PriceHistoryStore
+
+// priceHistoryDir returns the snapshot directory for a state DB file ("" when
+// the DB has no file to sit beside).
+func priceHistoryDir(dbFile string) string {
+	if dbFile == "" || dbFile == ":memory:" {
+		return ""
+	}
+	return dbFile + ".prices"
+}
+
+// NewPriceHistoryStore returns a store under dir, or nil when dir is empty or
+// retention is disabled.
+func NewPriceHistoryStore(dir string, retentionDays int) *PriceHistoryStore {
+	if dir == "" || retentionDays <= 0 {
+		return nil
+	}
+	return &PriceHistoryStore{dir: dir, retentionDays: retentionDays}
+}
+
+// Record appends the cycle's non-zero prices and, once per UTC day, deletes
+// day files older than the retention window.
+func (p *PriceHistoryStore) Record(prices map[string]float64, at time.Time) error {
+	if p == nil || len(prices) == 0 {
+		return nil
+	}
+	snap := priceSnapshot{T: at.Unix(), P: make(map[string]float64, len(prices))}
+	for sym, px := range prices {
+		if px > 0 {
+			snap.P[sym] = px
+		}
+	}
+	if len(snap.P) == 0 {
+		return nil
+	}
+	line, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("marshal price snapshot: %w", err)
+	}
+	day := at.UTC().Format(priceHistoryDayLayout)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := os.MkdirAll(p.dir, 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(p.dir, day+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if p.lastPruneDay != day {
+		p.lastPruneDay = day
+		return p.pruneLocked(at)
+	}
+	return nil
+}
+
+// pruneLocked removes day files older than retentionDays. Caller holds p.mu.
+func (p *PriceHistoryStore) pruneLocked(now time.Time) error {
+	return pruneDayFiles(p.dir, now.UTC().AddDate(0, 0, -p.retentionDays).Format(priceHistoryDayLayout))
+}
+
+// pruneDayFiles removes YYYY-MM-DD.jsonl files in dir dated before cutoff (#4953:
+// shared with the indicator log).
+func pruneDayFiles(dir, cutoff string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		day, ok := strings.CutSuffix(e.Name(), ".jsonl")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(priceHistoryDayLayout, day); err != nil {
+			continue
+		}
+		if day < cutoff {
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Query returns symbol's samples with from <= t <= to, oldest first, capped
+// at limit points. truncated reports whether the cap cut the range short.
+func (p *PriceHistoryStore) Query(symbol string, from, to time.Time, limit int) (points []PricePoint, truncated bool, err error) {
+	if p == nil {
+		return nil, false, nil
+	}
+	if limit <= 0 || limit > maxPriceHistoryPoints {
+		limit = maxPriceHistoryPoints
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fromDay := from.UTC().Truncate(24 * time.Hour)
+	for day := fromDay; !day.After(to.UTC()); day = day.AddDate(0, 0, 1) {
+		dayPoints, err := p.readDayLocked(day.Format(priceHistoryDayLayout), symbol, from.Unix(), to.Unix())
+		if err != nil {
+			return nil, false, err
+		}
+		for _, pt := range dayPoints {
+			if len(points) == limit {
+				return points, true, nil
+			}
+			points = append(points, pt)
+		}
+	}
+	return points, false, nil
+}
+
+func (p *PriceHistoryStore) readDayLocked(day, symbol string, from, to int64) ([]PricePoint, error) {
+	f, err := os.Open(filepath.Join(p.dir, day+".jsonl"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []PricePoint
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var snap priceSnapshot
+		if err := json.Unmarshal(sc.Bytes(), &snap); err != nil {
+			continue // torn line
+		}
+		if snap.T < from || snap.T > to {
+			continue
+		}
+		if px, ok := snap.P[symbol]; ok {
+			out = append(out, PricePoint{Time: snap.T, Price: px})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time < out[j].Time })
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPriceHistoryRecordQueryAndPrune(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state.db.prices")
+	p := NewPriceHistoryStore(dir, 2)
+	day0 := time.Date(2026, 5, 1, 23, 50, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		at := day0.Add(time.Duration(i) * 10 * time.Minute) // crosses midnight
+		if err := p.Record(map[string]float64{"BTC/USDT": 100 + float64(i), "ETH": 0}, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pts, truncated, err := p.Query("BTC/USDT", day0, day0.Add(time.Hour), 0)
+	if err != nil || truncated || len(pts) != 4 || pts[3].Price != 103 {
+		t.Fatalf("Query = %v, %v, %v", pts, truncated, err)
+	}
+	if pts, _, _ := p.Query("ETH", day0, day0.Add(time.Hour), 0); len(pts) != 0 {
+		t.Fatalf("zero prices should not be recorded: %v", pts)
+	}
+	if pts, truncated, _ := p.Query("BTC/USDT", day0, day0.Add(time.Hour), 2); len(pts) != 2 || !truncated {
+		t.Fatalf("limit: %v, truncated=%v", pts, truncated)
+	}
+
+	// A torn line is skipped.
+	f, err := os.OpenFile(filepath.Join(dir, "2026-05-02.jsonl"), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"t":1,"p":{"BTC`)
+	f.Close()
+	if pts, _, err := p.Query("BTC/USDT", day0, day0.Add(time.Hour), 0); err != nil || len(pts) != 4 {
+		t.Fatalf("torn line: %v, %v", pts, err)
+	}
+
+	if err := p.Record(map[string]float64{"BTC/USDT": 1}, day0.AddDate(0, 0, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2026-05-01.jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("expired day file not pruned: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2026-05-02.jsonl")); err != nil {
+		t.Fatalf("in-window day file pruned: %v", err)
+	}
+
+	if NewPriceHistoryStore(dir, 0) != nil || NewPriceHistoryStore("", 30) != nil {
+		t.Fatal("disabled store should be nil")
+	}
+}
+
+func TestHandlePriceHistory(t *testing.T) {
+	orig := priceHistory
+	t.Cleanup(func() { priceHistory = orig })
+	ss := &StatusServer{}
+
+	priceHistory = nil
+	rec := httptest.NewRecorder()
+	ss.handlePriceHistory(rec, httptest.NewRequest(http.MethodGet, "/prices/history?symbol=BTC", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("disabled status = %d", rec.Code)
+	}
+
+	priceHistory = NewPriceHistoryStore(filepath.Join(t.TempDir(), "p"), 30)
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	priceHistory.Record(map[string]float64{"BTC": 50000}, at)
+
+	rec = httptest.NewRecorder()
+	ss.handlePriceHistory(rec, httptest.NewRequest(http.MethodGet, "/prices/history?symbol=BTC&from=2026-05-01T00:00:00Z&to=1777680000", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Points []PricePoint `json:"points"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Points) != 1 || resp.Points[0].Time != at.Unix() || resp.Points[0].Price != 50000 {
+		t.Fatalf("points = %+v", resp.Points)
+	}
+
+	for _, q := range []string{"", "symbol=BTC&from=yesterday", "symbol=BTC&from=2026-05-02T00:00:00Z&to=2026-05-01T00:00:00Z"} {
+		rec = httptest.NewRecorder()
+		ss.handlePriceHistory(rec, httptest.NewRequest(http.MethodGet, "/prices/history?"+q, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q status = %d", q, rec.Code)
+		}
+	}
+}
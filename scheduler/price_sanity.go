@@ -0,0 +1,150 @@
+package main
+
+// price_sanity: cycle-over-cycle price sanity check (#4960).
+//
+// A fetched price that jumped more than max_move_pct from the strategy's
+// previous cycle almost always means a bad data point (a stray print, a
+// wrong-market quote) rather than a real move. The dispatch site feeds each
+// cycle's price into priceSanityTracker; a jump beyond the limit refuses the
+// cycle's signal — entries and exits alike, since either would trade on the
+// bad price — and alerts. The rejected price is not adopted as the reference,
+// so the next good print compares against the last sane one. If the next
+// cycle confirms the new level (within max_move_pct of the rejected price),
+// the move is accepted as real and trading resumes. All state is in-memory;
+// a restart starts every strategy fresh.
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const defaultPriceSanityMaxMovePct = 30.0
+
+// PriceSanityConfig is the top-level "price_sanity" block.
+type PriceSanityConfig struct {
+	Enabled       bool    `json:"enabled"`
+	MaxMovePct    float64 `json:"max_move_pct,omitempty"`   // largest believable cycle-over-cycle move, percent; 0/omitted → 30
+	WindowMinutes int     `json:"window_minutes,omitempty"` // only compare against a previous price at most this old; 0/omitted → always compare
+}
+
+func (c *PriceSanityConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *PriceSanityConfig) maxMovePct() float64 {
+	if c.MaxMovePct > 0 {
+		return c.MaxMovePct
+	}
+	return defaultPriceSanityMaxMovePct
+}
+
+// validatePriceSanityConfig checks the block. Nil is disabled.
+func validatePriceSanityConfig(c *PriceSanityConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	if c.MaxMovePct < 0 || c.MaxMovePct > 1000 {
+		errs = append(errs, fmt.Sprintf("price_sanity.max_move_pct must be in [0, 1000] (0 = default %g), got %g", defaultPriceSanityMaxMovePct, c.MaxMovePct))
+	}
+	if c.WindowMinutes < 0 {
+		errs = append(errs, fmt.Sprintf("price_sanity.window_minutes must be >= 0 (0 = always compare), got %d", c.WindowMinutes))
+	}
+	return errs
+}
+
+func clonePriceSanityConfig(c *PriceSanityConfig) *PriceSanityConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// formatPriceSanityConfig renders the block for reload change logs.
+func formatPriceSanityConfig(c *PriceSanityConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled(max_move_pct=%g, window_minutes=%d)", c.maxMovePct(), c.WindowMinutes)
+}
+
+// priceSanityEntry is one strategy's slot in priceSanityTracker.
+type priceSanityEntry struct {
+	lastPrice float64   // last accepted price
+	lastAt    time.Time // when lastPrice was observed
+	pending   float64   // last rejected price, awaiting confirmation
+}
+
+// priceSanityTracker tracks each strategy's last sane price.
+type priceSanityTracker struct {
+	mu      sync.Mutex
+	entries map[string]*priceSanityEntry
+}
+
+// Observe checks one cycle's price for strategyID against the previous
+// accepted price. It returns a non-empty reason when the price is rejected.
+// A non-positive price is ignored.
+func (t *priceSanityTracker) Observe(strategyID string, price float64, cfg *PriceSanityConfig, now time.Time) string {
+	if price <= 0 {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[string]*priceSanityEntry)
+	}
+	e := t.entries[strategyID]
+	if e == nil {
+		e = &priceSanityEntry{}
+		t.entries[strategyID] = e
+	}
+
+	limit := cfg.maxMovePct()
+	accept := func() string {
+		e.lastPrice, e.lastAt, e.pending = price, now, 0
+		return ""
+	}
+	if e.lastPrice <= 0 {
+		return accept()
+	}
+	if cfg.WindowMinutes > 0 && now.Sub(e.lastAt) > time.Duration(cfg.WindowMinutes)*time.Minute {
+		return accept()
+	}
+	move := math.Abs(price-e.lastPrice) / e.lastPrice * 100
+	if move <= limit {
+		return accept()
+	}
+	// A second print at the new level confirms a real move.
+	if e.pending > 0 && math.Abs(price-e.pending)/e.pending*100 <= limit {
+		return accept()
+	}
+	e.pending = price
+	return fmt.Sprintf("price $%g moved %.1f%% from previous $%g (limit %g%%)", price, move, e.lastPrice, limit)
+}
+
+// priceSanity is the package-level tracker; resets on restart.
+var priceSanity = &priceSanityTracker{}
+
+// observePriceSanity runs the per-cycle price sanity check for sc, alerts on
+// a rejection, and returns the reason the cycle's signal must be refused ("" =
+// sane). A nil or disabled block is a no-op.
+func observePriceSanity(cfg *PriceSanityConfig, sc StrategyConfig, price float64, notifier *MultiNotifier, logger *StrategyLogger) string {
+	if !cfg.enabled() {
+		return ""
+	}
+	reason := priceSanity.Observe(sc.ID, price, cfg, time.Now().UTC())
+	if reason == "" {
+		return ""
+	}
+	logger.Warn("Price sanity: %s", reason)
+	if notifier != nil && notifier.HasBackends() {
+		msg := fmt.Sprintf("**PRICE SANITY** [%s] %s %s: %s — trading refused this cycle; a confirming print next cycle accepts the move",
+			sc.ID, sc.Platform, extractAsset(sc), reason)
+		notifier.SendToAllChannels(msg)
+		notifier.SendOwnerDM(msg)
+	}
+	return reason
+}
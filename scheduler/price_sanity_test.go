@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPriceSanityTracker_RejectsJumpThenConfirms(t *testing.T) {
+	tr := &priceSanityTracker{}
+	cfg := &PriceSanityConfig{Enabled: true}
+	now := time.Unix(1700000000, 0).UTC()
+	if reason := tr.Observe("s", 100, cfg, now); reason != "" {
+		t.Fatalf("first price rejected: %q", reason)
+	}
+	if reason := tr.Observe("s", 125, cfg, now); reason != "" {
+		t.Fatalf("25%% move rejected: %q", reason)
+	}
+	reason := tr.Observe("s", 12.5, cfg, now)
+	if !strings.Contains(reason, "moved 90.0%") {
+		t.Fatalf("reason = %q, want a 90%% rejection", reason)
+	}
+	// The bad print is not the reference: a sane print passes.
+	if reason := tr.Observe("s", 126, cfg, now); reason != "" {
+		t.Fatalf("sane print after a bad one rejected: %q", reason)
+	}
+	// A real move: rejected once, then confirmed by the next print.
+	if reason := tr.Observe("s", 200, cfg, now); reason == "" {
+		t.Fatal("59% jump should be rejected on first sight")
+	}
+	if reason := tr.Observe("s", 202, cfg, now); reason != "" {
+		t.Fatalf("confirming print rejected: %q", reason)
+	}
+	if reason := tr.Observe("s", 205, cfg, now); reason != "" {
+		t.Fatalf("new level not adopted: %q", reason)
+	}
+}
+
+func TestPriceSanityTracker_Window(t *testing.T) {
+	tr := &priceSanityTracker{}
+	cfg := &PriceSanityConfig{Enabled: true, MaxMovePct: 10, WindowMinutes: 5}
+	now := time.Unix(1700000000, 0).UTC()
+	tr.Observe("s", 100, cfg, now)
+	if reason := tr.Observe("s", 120, cfg, now.Add(4*time.Minute)); reason == "" {
+		t.Fatal("20% in 4 minutes should be rejected with a 10% limit")
+	}
+	if reason := tr.Observe("s", 130, cfg, now.Add(10*time.Minute)); reason != "" {
+		t.Fatalf("previous price older than the window should not be compared: %q", reason)
+	}
+}
+
+func TestObservePriceSanity_Disabled(t *testing.T) {
+	sc := StrategyConfig{ID: "sanity-obs", Platform: "hyperliquid", Args: []string{"momentum", "BTC", "1h"}}
+	if why := observePriceSanity(nil, sc, 100, nil, nil); why != "" {
+		t.Fatalf("nil block returned %q", why)
+	}
+	cfg := &PriceSanityConfig{Enabled: true}
+	observePriceSanity(cfg, sc, 100, nil, silentStrategyLogger(sc.ID))
+	if why := observePriceSanity(cfg, sc, 1000, nil, silentStrategyLogger(sc.ID)); why == "" {
+		t.Fatal("10x jump should be refused")
+	}
+}
+
+func TestValidatePriceSanityConfig(t *testing.T) {
+	if errs := validatePriceSanityConfig(&PriceSanityConfig{Enabled: true}); len(errs) != 0 {
+		t.Fatalf("defaults rejected: %v", errs)
+	}
+	if errs := validatePriceSanityConfig(&PriceSanityConfig{MaxMovePct: -1, WindowMinutes: -1}); len(errs) != 2 {
+		t.Fatalf("errs = %v, want 2", errs)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+// price_validation: cross-source price validation (#4961).
+//
+// Valuation reads one rail per asset (BinanceUS spot via check_price.py, the
+// venue mark for perps) and execution reads the check script's own price, so
+// a single bad source could drive both. With price_validation enabled, each
+// cycle quotes every crypto spot/perps asset from up to three independent
+// sources — BinanceUS spot, the Hyperliquid mid, and the Deribit index (BTC
+// and ETH) — and builds a per-asset reference:
+//
+//   - sources within max_divergence_pct of their median agree; the reference
+//     is the median.
+//   - with three or more sources, an outlier is outvoted: the median stays
+//     the reference and any valuation price (prices["X/USDT"], prices["X"])
+//     beyond the limit is replaced by it.
+//   - with two disagreeing sources there is no majority and the asset is
+//     marked unresolved.
+//
+// One **PRICE DIVERGENCE** alert fires when an asset starts diverging and one
+// **PRICE DIVERGENCE CLEARED** when its sources agree again. At dispatch,
+// crossSourceHoldReason compares the check script's price against the
+// reference; with block_on_divergence a mismatch (or an unresolved asset)
+// refuses the cycle's signal. Sources that fail to quote are skipped; an
+// asset with fewer than two quotes is not validated. All state is in-memory.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultPriceValidationMaxDivergencePct = 2.0
+
+// PriceValidationConfig is the top-level "price_validation" block.
+type PriceValidationConfig struct {
+	Enabled           bool    `json:"enabled"`
+	MaxDivergencePct  float64 `json:"max_divergence_pct,omitempty"`  // sources (and the check price) further than this from the median disagree; 0/omitted → 2
+	BlockOnDivergence bool    `json:"block_on_divergence,omitempty"` // refuse signals whose check price disagrees with the reference, or whose asset is unresolved
+}
+
+func (c *PriceValidationConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *PriceValidationConfig) maxDivergencePct() float64 {
+	if c.MaxDivergencePct > 0 {
+		return c.MaxDivergencePct
+	}
+	return defaultPriceValidationMaxDivergencePct
+}
+
+// validatePriceValidationConfig checks the block. Nil is disabled.
+func validatePriceValidationConfig(c *PriceValidationConfig) []string {
+	if c == nil {
+		return nil
+	}
+	if c.MaxDivergencePct < 0 || c.MaxDivergencePct > 100 {
+		return []string{fmt.Sprintf("price_validation.max_divergence_pct must be in [0, 100] (0 = default %g), got %g", defaultPriceValidationMaxDivergencePct, c.MaxDivergencePct)}
+	}
+	return nil
+}
+
+func clonePriceValidationConfig(c *PriceValidationConfig) *PriceValidationConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// formatPriceValidationConfig renders the block for reload change logs.
+func formatPriceValidationConfig(c *PriceValidationConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled(max_divergence_pct=%g, block_on_divergence=%t)", c.maxDivergencePct(), c.BlockOnDivergence)
+}
+
+// priceValidationSources fetch the independent quotes. Package vars so
+// tests can stub the network.
+var (
+	priceValidationSpotFn  = FetchPrices
+	priceValidationHLFn    = fetchHyperliquidMids
+	priceValidationIndexFn = func(asset string) (float64, error) { return NewDeribitPricer().FetchSpotPrice(asset) }
+)
+
+// priceValidationIndexAssets are the assets with a Deribit index.
+var priceValidationIndexAssets = map[string]bool{"BTC": true, "ETH": true}
+
+// crossSourceReference is one asset's validated price for the cycle.
+type crossSourceReference struct {
+	Median     float64
+	Sources    map[string]float64
+	Divergent  bool // some source is further than the limit from the median
+	Unresolved bool // divergent with no majority (two sources)
+}
+
+// crossSourceStore holds the latest cycle's references and each asset's
+// divergence latch for alert edges.
+type crossSourceStore struct {
+	mu        sync.Mutex
+	refs      map[string]crossSourceReference
+	divergent map[string]bool
+}
+
+var crossSourcePrices = &crossSourceStore{}
+
+// reference returns asset's reference from the latest cycle.
+func (s *crossSourceStore) reference(asset string) (crossSourceReference, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ref, ok := s.refs[asset]
+	return ref, ok
+}
+
+// priceValidationAssets returns the sorted crypto assets traded by spot and
+// perps strategies.
+func priceValidationAssets(strategies []StrategyConfig) []string {
+	set := make(map[string]bool)
+	for _, sc := range strategies {
+		if sc.Type != "spot" && sc.Type != "perps" {
+			continue
+		}
+		if asset := extractAsset(sc); asset != "" && !strings.Contains(asset, "/") {
+			set[asset] = true
+		}
+	}
+	assets := make([]string, 0, len(set))
+	for a := range set {
+		assets = append(assets, a)
+	}
+	sort.Strings(assets)
+	return assets
+}
+
+// buildCrossSourceReference derives an asset's reference from its quotes.
+// ok=false when fewer than two sources quoted.
+func buildCrossSourceReference(sources map[string]float64, limitPct float64) (crossSourceReference, bool) {
+	vals := make([]float64, 0, len(sources))
+	for _, v := range sources {
+		if v > 0 {
+			vals = append(vals, v)
+		}
+	}
+	if len(vals) < 2 {
+		return crossSourceReference{}, false
+	}
+	sort.Float64s(vals)
+	median := vals[len(vals)/2]
+	if len(vals)%2 == 0 {
+		median = (vals[len(vals)/2-1] + vals[len(vals)/2]) / 2
+	}
+	ref := crossSourceReference{Median: median, Sources: sources}
+	for _, v := range vals {
+		if priceDivergencePct(v, median) > limitPct {
+			ref.Divergent = true
+		}
+	}
+	ref.Unresolved = ref.Divergent && len(vals) < 3
+	return ref, true
+}
+
+func priceDivergencePct(v, ref float64) float64 {
+	if ref <= 0 {
+		return 0
+	}
+	return math.Abs(v-ref) / ref * 100
+}
+
+func formatCrossSourceQuotes(sources map[string]float64) string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=$%g", name, sources[name]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runCrossSourcePriceValidation quotes every asset from the independent
+// sources, stores the cycle's references, replaces outvoted valuation prices
+// in prices with the median, and sends divergence alerts on state edges. A
+// nil or disabled block clears the stored references.
+func runCrossSourcePriceValidation(cfg *PriceValidationConfig, strategies []StrategyConfig, prices map[string]float64, notifier *MultiNotifier) {
+	if !cfg.enabled() {
+		crossSourcePrices.mu.Lock()
+		crossSourcePrices.refs = nil
+		crossSourcePrices.mu.Unlock()
+		return
+	}
+	assets := priceValidationAssets(strategies)
+	if len(assets) == 0 {
+		return
+	}
+	quotes := make(map[string]map[string]float64, len(assets))
+	for _, a := range assets {
+		quotes[a] = make(map[string]float64)
+	}
+
+	var missingSpot []string
+	for _, a := range assets {
+		if p := prices[a+"/USDT"]; p > 0 {
+			quotes[a]["binanceus"] = p
+		} else {
+			missingSpot = append(missingSpot, a+"/USDT")
+		}
+	}
+	if len(missingSpot) > 0 {
+		if spot, err := priceValidationSpotFn(missingSpot); err != nil {
+			fmt.Printf("[WARN] price_validation: BinanceUS quotes failed: %v\n", err)
+		} else {
+			for sym, p := range spot {
+				if q, ok := quotes[strings.TrimSuffix(sym, "/USDT")]; ok && p > 0 {
+					q["binanceus"] = p
+				}
+			}
+		}
+	}
+	if mids, err := priceValidationHLFn(assets); err != nil {
+		fmt.Printf("[WARN] price_validation: Hyperliquid mids failed: %v\n", err)
+	} else {
+		for coin, p := range mids {
+			if q, ok := quotes[coin]; ok && p > 0 {
+				q["hyperliquid"] = p
+			}
+		}
+	}
+	for _, a := range assets {
+		if !priceValidationIndexAssets[a] {
+			continue
+		}
+		if p, err := priceValidationIndexFn(a); err != nil {
+			fmt.Printf("[WARN] price_validation: Deribit index for %s failed: %v\n", a, err)
+		} else if p > 0 {
+			quotes[a]["deribit"] = p
+		}
+	}
+
+	limit := cfg.maxDivergencePct()
+	refs := make(map[string]crossSourceReference, len(assets))
+	var alerts []string
+	crossSourcePrices.mu.Lock()
+	if crossSourcePrices.divergent == nil {
+		crossSourcePrices.divergent = make(map[string]bool)
+	}
+	for _, a := range assets {
+		ref, ok := buildCrossSourceReference(quotes[a], limit)
+		if !ok {
+			continue
+		}
+		refs[a] = ref
+		if ref.Divergent && !ref.Unresolved {
+			for _, key := range []string{a + "/USDT", a} {
+				if p, ok := prices[key]; ok && priceDivergencePct(p, ref.Median) > limit {
+					fmt.Printf("[WARN] price_validation: %s=$%g outvoted — valuing at median $%g\n", key, p, ref.Median)
+					prices[key] = ref.Median
+				}
+			}
+		}
+		was := crossSourcePrices.divergent[a]
+		switch {
+		case ref.Divergent && !was:
+			verdict := fmt.Sprintf("using the median $%g", ref.Median)
+			if ref.Unresolved {
+				verdict = "no majority"
+				if cfg.BlockOnDivergence {
+					verdict += " — trading refused until the sources agree"
+				}
+			}
+			alerts = append(alerts, fmt.Sprintf("**PRICE DIVERGENCE** %s: sources disagree beyond %g%% (%s); %s", a, limit, formatCrossSourceQuotes(ref.Sources), verdict))
+		case !ref.Divergent && was:
+			alerts = append(alerts, fmt.Sprintf("**PRICE DIVERGENCE CLEARED** %s: sources agree again (%s)", a, formatCrossSourceQuotes(ref.Sources)))
+		}
+		crossSourcePrices.divergent[a] = ref.Divergent
+	}
+	crossSourcePrices.refs = refs
+	crossSourcePrices.mu.Unlock()
+
+	for _, msg := range alerts {
+		fmt.Printf("[WARN] %s\n", msg)
+		if notifier != nil && notifier.HasBackends() {
+			notifier.SendToAllChannels(msg)
+			notifier.SendOwnerDM(msg)
+		}
+	}
+}
+
+// crossSourceHoldReason compares a strategy's check-script price with its
+// asset's reference. It returns the reason to refuse the cycle's signal ("" =
+// agree, not validated, or block_on_divergence off). Mismatches are logged
+// either way.
+func crossSourceHoldReason(cfg *PriceValidationConfig, sc StrategyConfig, price float64, logger *StrategyLogger) string {
+	if !cfg.enabled() || price <= 0 {
+		return ""
+	}
+	ref, ok := crossSourcePrices.reference(extractAsset(sc))
+	if !ok {
+		return ""
+	}
+	var reason string
+	switch {
+	case ref.Unresolved:
+		reason = fmt.Sprintf("price sources disagree with no majority (%s)", formatCrossSourceQuotes(ref.Sources))
+	case priceDivergencePct(price, ref.Median) > cfg.maxDivergencePct():
+		reason = fmt.Sprintf("check price $%g is %.2f%% from the cross-source median $%g (limit %g%%)",
+			price, priceDivergencePct(price, ref.Median), ref.Median, cfg.maxDivergencePct())
+	default:
+		return ""
+	}
+	logger.Warn("Price validation: %s", reason)
+	if !cfg.BlockOnDivergence {
+		return ""
+	}
+	return reason
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBuildCrossSourceReference(t *testing.T) {
+	if _, ok := buildCrossSourceReference(map[string]float64{"binanceus": 100}, 2); ok {
+		t.Fatal("a single source must not be validated")
+	}
+	ref, _ := buildCrossSourceReference(map[string]float64{"binanceus": 100, "hyperliquid": 100.5, "deribit": 100.2}, 2)
+	if ref.Divergent || ref.Median != 100.2 {
+		t.Fatalf("agreeing sources: %+v", ref)
+	}
+	ref, _ = buildCrossSourceReference(map[string]float64{"binanceus": 80, "hyperliquid": 100.5, "deribit": 100.2}, 2)
+	if !ref.Divergent || ref.Unresolved || ref.Median != 100.2 {
+		t.Fatalf("outlier with a majority: %+v", ref)
+	}
+	ref, _ = buildCrossSourceReference(map[string]float64{"binanceus": 80, "hyperliquid": 100}, 2)
+	if !ref.Divergent || !ref.Unresolved {
+		t.Fatalf("two disagreeing sources: %+v", ref)
+	}
+}
+
+func stubPriceValidationSources(t *testing.T, spot, hl map[string]float64, index map[string]float64) {
+	t.Helper()
+	origSpot, origHL, origIndex := priceValidationSpotFn, priceValidationHLFn, priceValidationIndexFn
+	t.Cleanup(func() {
+		priceValidationSpotFn, priceValidationHLFn, priceValidationIndexFn = origSpot, origHL, origIndex
+		crossSourcePrices = &crossSourceStore{}
+	})
+	crossSourcePrices = &crossSourceStore{}
+	priceValidationSpotFn = func([]string) (map[string]float64, error) { return spot, nil }
+	priceValidationHLFn = func([]string) (map[string]float64, error) { return hl, nil }
+	priceValidationIndexFn = func(asset string) (float64, error) {
+		if p, ok := index[asset]; ok {
+			return p, nil
+		}
+		return 0, errors.New("no index")
+	}
+}
+
+func TestRunCrossSourcePriceValidation_ReplacesOutvotedPrice(t *testing.T) {
+	stubPriceValidationSources(t, nil, map[string]float64{"BTC": 60100}, map[string]float64{"BTC": 60000})
+	cfg := &PriceValidationConfig{Enabled: true, BlockOnDivergence: true}
+	strategies := []StrategyConfig{
+		{ID: "spot-btc", Type: "spot", Args: []string{"sma", "BTC/USDT"}},
+		{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Args: []string{"momentum", "BTC", "1h"}},
+	}
+	prices := map[string]float64{"BTC/USDT": 6000, "BTC": 60100}
+	runCrossSourcePriceValidation(cfg, strategies, prices, nil)
+	if prices["BTC/USDT"] != 60000 {
+		t.Fatalf("outvoted spot price = %v, want the median 60000", prices["BTC/USDT"])
+	}
+	if prices["BTC"] != 60100 {
+		t.Fatalf("agreeing perps mark changed to %v", prices["BTC"])
+	}
+	logger := silentStrategyLogger("spot-btc")
+	if why := crossSourceHoldReason(cfg, strategies[0], 6000, logger); !strings.Contains(why, "cross-source median") {
+		t.Fatalf("bad check price not refused: %q", why)
+	}
+	if why := crossSourceHoldReason(cfg, strategies[0], 60050, logger); why != "" {
+		t.Fatalf("good check price refused: %q", why)
+	}
+}
+
+func TestRunCrossSourcePriceValidation_Unresolved(t *testing.T) {
+	stubPriceValidationSources(t, map[string]float64{"SOL/USDT": 150}, map[string]float64{"SOL": 120}, nil)
+	sc := StrategyConfig{ID: "hl-sol", Type: "perps", Platform: "hyperliquid", Args: []string{"momentum", "SOL", "1h"}}
+	prices := map[string]float64{"SOL": 120}
+	cfg := &PriceValidationConfig{Enabled: true}
+	runCrossSourcePriceValidation(cfg, []StrategyConfig{sc}, prices, nil)
+	if prices["SOL"] != 120 {
+		t.Fatalf("no-majority price must not be replaced, got %v", prices["SOL"])
+	}
+	logger := silentStrategyLogger(sc.ID)
+	if why := crossSourceHoldReason(cfg, sc, 120, logger); why != "" {
+		t.Fatalf("alert-only block refused a signal: %q", why)
+	}
+	cfg.BlockOnDivergence = true
+	if why := crossSourceHoldReason(cfg, sc, 120, logger); !strings.Contains(why, "no majority") {
+		t.Fatalf("unresolved asset not refused: %q", why)
+	}
+}
+
+func TestValidatePriceValidationConfig(t *testing.T) {
+	if errs := validatePriceValidationConfig(&PriceValidationConfig{Enabled: true}); len(errs) != 0 {
+		t.Fatalf("defaults rejected: %v", errs)
+	}
+	if errs := validatePriceValidationConfig(&PriceValidationConfig{MaxDivergencePct: -1}); len(errs) != 1 {
+		t.Fatalf("errs = %v, want 1", errs)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTryLockFileContention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.lock")
+	a, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if err := tryLockFile(a); err != nil {
+		t.Fatalf("first lock: %v", err)
+	}
+	if err := tryLockFile(b); !errors.Is(err, errFileLocked) {
+		t.Fatalf("second lock = %v, want errFileLocked", err)
+	}
+	a.Close()
+	if err := tryLockFile(b); err != nil {
+		t.Fatalf("lock after release: %v", err)
+	}
+}
+
+func TestRaiseSelfSignalReachesNotifiedChannel(t *testing.T) {
+	ch := make(chan os.Signal, 1)
+	notifySelfSignal(ch, reloadSignal)
+	defer signal.Stop(ch)
+	if err := raiseSelfSignal(reloadSignal); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case sig := <-ch:
+		if sig != reloadSignal {
+			t.Errorf("got %v, want %v", sig, reloadSignal)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload signal not delivered")
+	}
+}
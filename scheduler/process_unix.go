@@ -0,0 +1,68 @@
+//go:build !windows
+
+package main
+
+// process_unix: POSIX process-group, signal and file-lock primitives
+// (#4982). process_windows.go carries the Windows counterparts; keep the two
+// in step.
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// pythonInterpreter is the virtualenv interpreter every script runs under.
+const pythonInterpreter = ".venv/bin/python3"
+
+var (
+	// reloadSignal asks the daemon to hot-reload its config.
+	reloadSignal os.Signal = syscall.SIGHUP
+	// credentialRotateSignal asks the daemon to rotate credentials.
+	credentialRotateSignal os.Signal = syscall.SIGUSR1
+)
+
+// setScriptProcessGroup starts cmd in its own process group so a timeout or
+// shutdown can kill the script together with anything it forked.
+func setScriptProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup SIGKILLs the process group led by pid.
+func killProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGKILL)
+}
+
+// killProcess SIGKILLs a single process.
+func killProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// currentProcessGroup is this process's group ID.
+func currentProcessGroup() int {
+	return syscall.Getpgrp()
+}
+
+// notifySelfSignal relays sig to ch, whether the OS or raiseSelfSignal sent it.
+func notifySelfSignal(ch chan<- os.Signal, sig os.Signal) {
+	signal.Notify(ch, sig)
+}
+
+// raiseSelfSignal sends sig to this process.
+func raiseSelfSignal(sig os.Signal) error {
+	return syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+}
+
+// tryLockFile takes an exclusive, non-blocking flock on f. It returns
+// errFileLocked when another descriptor holds it.
+func tryLockFile(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	// LOCK_NB returns EWOULDBLOCK (== EAGAIN on Linux) while another holder
+	// has it.
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errFileLocked
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+package main
+
+// process_windows: Windows counterparts of process_unix.go (#4982).
+//
+// Scripts start in a new process group and are killed as a tree with
+// taskkill /T, the closest Windows analogue of a POSIX group SIGKILL.
+// Windows delivers no SIGHUP/SIGUSR1, so config reload and credential
+// rotation only arrive in-process (the Discord/UI/API triggers, which call
+// raiseSelfSignal). The state-DB and manual-action locks use LockFileEx.
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// pythonInterpreter is the virtualenv interpreter every script runs under.
+const pythonInterpreter = `.venv\Scripts\python.exe`
+
+var (
+	// reloadSignal asks the daemon to hot-reload its config. Never sent by
+	// Windows itself.
+	reloadSignal os.Signal = syscall.SIGHUP
+	// credentialRotateSignal asks the daemon to rotate credentials. Windows
+	// has no SIGUSR1; this value only ever travels in-process.
+	credentialRotateSignal os.Signal = syscall.Signal(0x1e)
+)
+
+// setScriptProcessGroup starts cmd in a new process group, and makes context
+// cancellation kill the whole tree rather than just the interpreter, so the
+// children are still reachable through their parent when taskkill runs.
+func setScriptProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_PROCESS_GROUP}
+	cmd.Cancel = func() error {
+		if err := killProcessGroup(cmd.Process.Pid); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+}
+
+// killProcessGroup force-kills pid and its descendants.
+func killProcessGroup(pid int) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// killProcess force-kills a single process.
+func killProcess(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
+
+// currentProcessGroup has no Windows equivalent; 0 matches no script.
+func currentProcessGroup() int {
+	return 0
+}
+
+var (
+	selfSignalMu    sync.Mutex
+	selfSignalChans = make(map[os.Signal][]chan<- os.Signal)
+)
+
+// notifySelfSignal relays sig to ch when raiseSelfSignal sends it.
+func notifySelfSignal(ch chan<- os.Signal, sig os.Signal) {
+	selfSignalMu.Lock()
+	defer selfSignalMu.Unlock()
+	selfSignalChans[sig] = append(selfSignalChans[sig], ch)
+}
+
+// raiseSelfSignal delivers sig to every channel registered for it. Like a
+// real signal it never blocks: a full channel already has one pending.
+func raiseSelfSignal(sig os.Signal) error {
+	selfSignalMu.Lock()
+	defer selfSignalMu.Unlock()
+	chans := selfSignalChans[sig]
+	if len(chans) == 0 {
+		return errors.New("no handler registered for " + sig.String())
+	}
+	for _, ch := range chans {
+		select {
+		case ch <- sig:
+		default:
+		}
+	}
+	return nil
+}
+
+// tryLockFile takes an exclusive, non-blocking LockFileEx on f. It returns
+// errFileLocked when another handle holds it. The lock is released when f is
+// closed.
+func tryLockFile(f *os.File) error {
+	err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &windows.Overlapped{})
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errFileLocked
+	}
+	return err
+}
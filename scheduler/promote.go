@@ -0,0 +1,276 @@
+package main
+
+// promote: gated paper → live promotion from the CLI (#4974).
+//
+// `go-trader promote <strategy-id>` flips a paper strategy's --mode=paper arg
+// to --mode=live only when every check passes:
+//   - the strategy is in paper mode (has a --mode=paper arg to flip);
+//   - its paper track record spans at least --min-days since the first trade;
+//   - it has at least --min-trades closed positions;
+//   - its realized Sharpe (ComputeSharpeRatio, same as the leaderboard) is
+//     above --min-sharpe;
+//   - the platform's live credentials are present in the environment;
+//   - it is flat — a simulated position carried into live is a phantom
+//     (same rule as /go-trader-paper-to-live).
+//
+// The config is rewritten through flipStrategyToLive + writeValidatedConfigRoot,
+// the same validated write the Discord and dashboard paper→live paths use.
+// The CLI then queues a "promote" pending manual action; the scheduler drains
+// it and announces the promotion on the strategy's channel and the owner DM.
+// Live trading starts after the scheduler restarts (args do not hot-reload).
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// promotionCriteria are the thresholds `go-trader promote` enforces.
+type promotionCriteria struct {
+	MinDays   int
+	MinTrades int
+	MinSharpe float64
+}
+
+// promotionEvidence is the paper record read from the state DB.
+type promotionEvidence struct {
+	FirstTrade   time.Time
+	ClosedTrades int
+	Sharpe       float64
+}
+
+// promotionCheck is one line of the promote checklist.
+type promotionCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// promotionCredentialEnv lists the env vars a live strategy needs per
+// platform — the same vars ValidateConfig requires for --mode=live.
+var promotionCredentialEnv = map[string][]string{
+	"hyperliquid": {"HYPERLIQUID_SECRET_KEY", "HYPERLIQUID_ACCOUNT_ADDRESS"},
+	"okx":         {"OKX_API_KEY", "OKX_API_SECRET", "OKX_PASSPHRASE"},
+	"robinhood":   {"ROBINHOOD_USERNAME", "ROBINHOOD_PASSWORD", "ROBINHOOD_TOTP_SECRET"},
+	"topstep":     {"TOPSTEP_API_KEY", "TOPSTEP_API_SECRET", "TOPSTEP_ACCOUNT_ID"},
+}
+
+// hasPaperModeArg reports whether args carry the --mode=paper arg
+// flipStrategyToLive rewrites.
+func hasPaperModeArg(args []string) bool {
+	for _, a := range args {
+		if strings.TrimSpace(a) == "--mode=paper" {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluatePromotion runs every promote check; s may be nil for a strategy
+// with no persisted state.
+func evaluatePromotion(sc StrategyConfig, s *StrategyState, ev promotionEvidence, crit promotionCriteria, now time.Time, getenv func(string) string) []promotionCheck {
+	var checks []promotionCheck
+
+	paper := hasPaperModeArg(sc.Args)
+	modeDetail := "--mode=paper"
+	if !paper {
+		modeDetail = "no --mode=paper arg (already live, or the platform has no paper mode)"
+	}
+	checks = append(checks, promotionCheck{Name: "paper mode", OK: paper, Detail: modeDetail})
+
+	days := 0.0
+	if !ev.FirstTrade.IsZero() {
+		days = now.Sub(ev.FirstTrade).Hours() / 24
+	}
+	checks = append(checks, promotionCheck{
+		Name:   "track record",
+		OK:     !ev.FirstTrade.IsZero() && days >= float64(crit.MinDays),
+		Detail: fmt.Sprintf("%.1f days since first trade (need %d)", days, crit.MinDays),
+	})
+	checks = append(checks, promotionCheck{
+		Name:   "closed trades",
+		OK:     ev.ClosedTrades >= crit.MinTrades,
+		Detail: fmt.Sprintf("%d (need %d)", ev.ClosedTrades, crit.MinTrades),
+	})
+	checks = append(checks, promotionCheck{
+		Name:   "sharpe",
+		OK:     ev.Sharpe > crit.MinSharpe,
+		Detail: fmt.Sprintf("%s (need > %.2f)", fmtSharpe(ev.Sharpe), crit.MinSharpe),
+	})
+
+	if vars, ok := promotionCredentialEnv[sc.Platform]; !ok {
+		checks = append(checks, promotionCheck{Name: "credentials", Detail: fmt.Sprintf("no live credential list for platform %q; promote by hand", sc.Platform)})
+	} else {
+		var missing []string
+		for _, v := range vars {
+			if strings.TrimSpace(getenv(v)) == "" {
+				missing = append(missing, v)
+			}
+		}
+		detail := strings.Join(vars, ", ") + " set"
+		if len(missing) > 0 {
+			detail = "missing " + strings.Join(missing, ", ")
+		}
+		checks = append(checks, promotionCheck{Name: "credentials", OK: len(missing) == 0, Detail: detail})
+	}
+
+	var open []string
+	if s != nil {
+		for sym := range s.Positions {
+			open = append(open, sym)
+		}
+		for id := range s.OptionPositions {
+			open = append(open, id)
+		}
+	}
+	sort.Strings(open)
+	flatDetail := "no open positions"
+	if len(open) > 0 {
+		flatDetail = "open: " + strings.Join(open, ", ") + " — flatten first"
+	}
+	checks = append(checks, promotionCheck{Name: "flat", OK: len(open) == 0, Detail: flatDetail})
+	return checks
+}
+
+// promotionPassed reports whether every check passed.
+func promotionPassed(checks []promotionCheck) bool {
+	for _, c := range checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// formatPromotionChecks renders the checklist, one line per check.
+func formatPromotionChecks(id string, checks []promotionCheck) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Promotion checks for %s:\n", id)
+	for _, c := range checks {
+		mark := "✗"
+		if c.OK {
+			mark = "✓"
+		}
+		fmt.Fprintf(&sb, "  %s %-13s %s\n", mark, c.Name, c.Detail)
+	}
+	return sb.String()
+}
+
+// promotionAnnouncement is the Discord message for a drained "promote"
+// action. The paper record travels in the action's Metadata.
+func promotionAnnouncement(sc StrategyConfig, a PendingManualAction) string {
+	return fmt.Sprintf("🚀 **%s** promoted from PAPER to LIVE (paper record: %s days, %s closed trades, Sharpe %s). Real orders start after the scheduler restarts.",
+		sc.ID, a.Metadata["days"], a.Metadata["closed_trades"], a.Metadata["sharpe"])
+}
+
+// runPromote implements `go-trader promote <strategy-id> [--min-days N]
+// [--min-trades N] [--min-sharpe X] [--dry-run]`.
+func runPromote(args []string) int {
+	fs := flag.NewFlagSet("promote", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	minDays := fs.Int("min-days", 30, "Minimum days since the strategy's first paper trade")
+	minTrades := fs.Int("min-trades", 10, "Minimum closed paper positions")
+	minSharpe := fs.Float64("min-sharpe", 0, "Realized Sharpe must be above this")
+	dryRun := fs.Bool("dry-run", false, "Run the checks without changing the config")
+
+	args = reorderArgsForPositional(args, collectBoolFlagNames(fs))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go-trader promote <strategy-id> [--min-days N] [--min-trades N] [--min-sharpe X] [--dry-run]")
+		return 2
+	}
+	strategyID := fs.Arg(0)
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	sc, ok := findStrategyConfig(cfg, strategyID)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Strategy %q not found in config%s\n", strategyID, includedFilesNote(cfg))
+		return 1
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+	state, err := stateDB.LoadState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load state: %v\n", err)
+		return 1
+	}
+	s := state.Strategies[strategyID]
+
+	var ev promotionEvidence
+	if ev.FirstTrade, err = stateDB.EarliestTradeTimestamp([]string{strategyID}); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read trade history: %v\n", err)
+		return 1
+	}
+	closed, total, err := stateDB.QueryClosedPositions(strategyID, "", time.Time{}, time.Time{}, sharpeLookbackLimit, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read closed positions: %v\n", err)
+		return 1
+	}
+	ev.ClosedTrades = total
+	if s != nil {
+		ev.Sharpe = ComputeSharpeRatio(closed, EffectiveInitialCapital(sc, s), RiskFreeRateOrDefault(cfg))
+	}
+
+	now := time.Now().UTC()
+	checks := evaluatePromotion(sc, s, ev, promotionCriteria{MinDays: *minDays, MinTrades: *minTrades, MinSharpe: *minSharpe}, now, os.Getenv)
+	fmt.Print(formatPromotionChecks(strategyID, checks))
+	if !promotionPassed(checks) {
+		fmt.Fprintln(os.Stderr, "Promotion refused: fix the failing checks above.")
+		return 1
+	}
+	if *dryRun {
+		fmt.Println("Dry run: all checks passed; config unchanged.")
+		return 0
+	}
+
+	raw, err := os.ReadFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read config: %v\n", err)
+		return 1
+	}
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &root); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse config: %v\n", err)
+		return 1
+	}
+	_, after, err := flipStrategyToLive(root, strategyID)
+	if err == nil {
+		err = writeValidatedConfigRoot(*configPath, root)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write config: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Strategy %s switched to LIVE (args: %s).\n", strategyID, strings.Join(after, " "))
+
+	days := now.Sub(ev.FirstTrade).Hours() / 24
+	if err := stateDB.InsertPendingManualAction(PendingManualAction{
+		StrategyID: strategyID,
+		Action:     "promote",
+		Metadata: map[string]string{
+			"days":          strconv.FormatFloat(days, 'f', 1, 64),
+			"closed_trades": strconv.Itoa(ev.ClosedTrades),
+			"sharpe":        fmtSharpe(ev.Sharpe),
+		},
+		CreatedAt: now,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] config updated but the Discord announcement could not be queued: %v\n", err)
+	}
+	fmt.Println("Restart go-trader (sudo systemctl restart go-trader) to start live trading.")
+	return 0
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluatePromotion(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	sc := StrategyConfig{ID: "hl-sma-btc", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "BTC", "1h", "--mode=paper"}}
+	s := &StrategyState{ID: sc.ID, Positions: map[string]*Position{}}
+	ev := promotionEvidence{FirstTrade: now.AddDate(0, 0, -45), ClosedTrades: 24, Sharpe: 1.3}
+	crit := promotionCriteria{MinDays: 30, MinTrades: 10}
+	env := map[string]string{"HYPERLIQUID_SECRET_KEY": "k", "HYPERLIQUID_ACCOUNT_ADDRESS": "0xabc"}
+	getenv := func(k string) string { return env[k] }
+
+	if checks := evaluatePromotion(sc, s, ev, crit, now, getenv); !promotionPassed(checks) {
+		t.Fatalf("expected pass:\n%s", formatPromotionChecks(sc.ID, checks))
+	}
+
+	failing := func(checks []promotionCheck) []string {
+		var out []string
+		for _, c := range checks {
+			if !c.OK {
+				out = append(out, c.Name)
+			}
+		}
+		return out
+	}
+	short := ev
+	short.FirstTrade = now.AddDate(0, 0, -10)
+	short.ClosedTrades = 3
+	short.Sharpe = -0.4
+	if got := failing(evaluatePromotion(sc, s, short, crit, now, getenv)); strings.Join(got, ",") != "track record,closed trades,sharpe" {
+		t.Errorf("weak record failures = %v", got)
+	}
+
+	delete(env, "HYPERLIQUID_SECRET_KEY")
+	s.Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.1}
+	live := sc
+	live.Args = []string{"sma", "BTC", "1h", "--mode=live"}
+	checks := evaluatePromotion(live, s, ev, crit, now, getenv)
+	if got := failing(checks); strings.Join(got, ",") != "paper mode,credentials,flat" {
+		t.Errorf("failures = %v", got)
+	}
+	if out := formatPromotionChecks(sc.ID, checks); !strings.Contains(out, "missing HYPERLIQUID_SECRET_KEY") || !strings.Contains(out, "open: BTC") {
+		t.Errorf("checklist:\n%s", out)
+	}
+
+	// No paper record at all never passes, even with a zero day minimum.
+	if got := failing(evaluatePromotion(sc, nil, promotionEvidence{}, promotionCriteria{}, now, getenv)); len(got) == 0 || got[0] != "track record" {
+		t.Errorf("empty record failures = %v", got)
+	}
+}
+
+func TestDrainPromoteAnnounces(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+
+	sc := StrategyConfig{ID: "hl-sma-btc", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "BTC", "1h", "--mode=paper"}}
+	state := &AppState{Strategies: map[string]*StrategyState{sc.ID: {ID: sc.ID, Positions: map[string]*Position{}}}}
+	cfg := &Config{Strategies: []StrategyConfig{sc}}
+	if err := db.InsertPendingManualAction(PendingManualAction{
+		StrategyID: sc.ID, Action: "promote",
+		Metadata:  map[string]string{"days": "45.0", "closed_trades": "24", "sharpe": "+1.30"},
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := drainPendingManualActions(state, cfg, db)
+	if len(alerts) != 1 || alerts[0].trades != 0 || len(alerts[0].notices) != 1 {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+	if msg := alerts[0].notices[0]; !strings.Contains(msg, "hl-sma-btc") || !strings.Contains(msg, "45.0 days, 24 closed trades, Sharpe +1.30") {
+		t.Errorf("announcement = %q", msg)
+	}
+	if remaining, _ := db.LoadPendingManualActions(); len(remaining) != 0 {
+		t.Errorf("queue not drained: %d rows", len(remaining))
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+// pushgateway: Prometheus Pushgateway support for --once runs (#4939).
+//
+// A cron-driven `--once` run exits before anything can scrape /metrics, so
+// with a pushgateway block configured the run PUTs the same exposition
+// renderPrometheusMetrics serves, plus go_trader_cycle_success, to
+// <url>/metrics/job/<job>[/<label>/<value>...] right before exiting. PUT
+// replaces the whole group, so a metric that disappears from a run does not
+// linger from the previous one. Basic auth goes in the URL userinfo. The
+// long-running daemon ignores the block — scrape /metrics instead. A failed
+// push is logged and never changes the exit status.
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPushgatewayJob     = "go_trader"
+	defaultPushgatewayTimeout = 10 * time.Second
+)
+
+// PushgatewayConfig is the top-level "pushgateway" block.
+type PushgatewayConfig struct {
+	URL            string            `json:"url"`                       // Pushgateway base URL, e.g. http://localhost:9091
+	Job            string            `json:"job,omitempty"`             // job label; default "go_trader"
+	Grouping       map[string]string `json:"grouping,omitempty"`        // extra grouping labels, e.g. {"instance": "vps-1"}
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"` // 0/omitted → 10
+}
+
+var promLabelNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func (p *PushgatewayConfig) enabled() bool {
+	return p != nil && strings.TrimSpace(p.URL) != ""
+}
+
+func (p *PushgatewayConfig) job() string {
+	if j := strings.TrimSpace(p.Job); j != "" {
+		return j
+	}
+	return defaultPushgatewayJob
+}
+
+func (p *PushgatewayConfig) timeout() time.Duration {
+	if p.TimeoutSeconds > 0 {
+		return time.Duration(p.TimeoutSeconds) * time.Second
+	}
+	return defaultPushgatewayTimeout
+}
+
+// validatePushgatewayConfig checks the block. Nil/empty url is disabled.
+func validatePushgatewayConfig(p *PushgatewayConfig) []string {
+	if p == nil {
+		return nil
+	}
+	var errs []string
+	if !p.enabled() {
+		if p.Job != "" || len(p.Grouping) > 0 {
+			errs = append(errs, "pushgateway.job/grouping require pushgateway.url")
+		}
+		return errs
+	}
+	if err := validateHealthcheckURL(p.URL); err != nil {
+		errs = append(errs, fmt.Sprintf("pushgateway.url: %v", err))
+	}
+	for k, v := range p.Grouping {
+		if !promLabelNameRE.MatchString(k) || k == "job" {
+			errs = append(errs, fmt.Sprintf("pushgateway.grouping key %q must be a Prometheus label name other than \"job\"", k))
+		}
+		if v == "" {
+			errs = append(errs, fmt.Sprintf("pushgateway.grouping[%q] must not be empty", k))
+		}
+	}
+	if p.TimeoutSeconds < 0 {
+		errs = append(errs, fmt.Sprintf("pushgateway.timeout_seconds must be >= 0 (0 = default %s), got %d", defaultPushgatewayTimeout, p.TimeoutSeconds))
+	}
+	return errs
+}
+
+// pushgatewayURL builds the grouping-key URL. Label values are path-escaped;
+// grouping labels are sorted for a stable group identity.
+func pushgatewayURL(p *PushgatewayConfig) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(strings.TrimSpace(p.URL), "/"))
+	b.WriteString("/metrics/job/")
+	b.WriteString(url.PathEscape(p.job()))
+	keys := make([]string, 0, len(p.Grouping))
+	for k := range p.Grouping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString("/" + k + "/" + url.PathEscape(p.Grouping[k]))
+	}
+	return b.String()
+}
+
+// renderPushgatewayMetrics is the /metrics body plus the run outcome. Caller
+// holds at least mu.RLock.
+func renderPushgatewayMetrics(state *AppState, cycleFailure string) string {
+	var b strings.Builder
+	b.WriteString(renderPrometheusMetrics(state))
+	b.WriteString(renderLatencyMetrics())
+	b.WriteString(renderPythonPoolMetrics())
+	writePromHeader(&b, "go_trader_cycle_success", "gauge", "1 when the pushed --once cycle saved state cleanly, 0 otherwise.")
+	success := 1
+	if cycleFailure != "" {
+		success = 0
+	}
+	fmt.Fprintf(&b, "go_trader_cycle_success %d\n", success)
+	return b.String()
+}
+
+// pushMetrics PUTs body to the configured Pushgateway group.
+func pushMetrics(p *PushgatewayConfig, body string) error {
+	if !p.enabled() {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodPut, pushgatewayURL(p), bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	resp, err := (&http.Client{Timeout: p.timeout()}).Do(req)
+	if err != nil {
+		return fmt.Errorf("pushgateway: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pushgateway: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPushMetricsPutsGroupedExposition(t *testing.T) {
+	var gotMethod, gotPath, gotBody, gotUser string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.EscapedPath()
+		gotUser, _, _ = r.BasicAuth()
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := &PushgatewayConfig{
+		URL:      strings.Replace(srv.URL, "http://", "http://cron:secret@", 1) + "/",
+		Grouping: map[string]string{"instance": "vps 1", "env": "prod"},
+	}
+	state := &AppState{CycleCount: 7, LastCycle: time.Unix(1700000000, 0)}
+	if err := pushMetrics(p, renderPushgatewayMetrics(state, "")); err != nil {
+		t.Fatalf("pushMetrics: %v", err)
+	}
+	if gotMethod != http.MethodPut || gotPath != "/metrics/job/go_trader/env/prod/instance/vps%201" {
+		t.Errorf("request = %s %s", gotMethod, gotPath)
+	}
+	if gotUser != "cron" {
+		t.Errorf("basic auth user = %q, want cron", gotUser)
+	}
+	for _, want := range []string{"go_trader_cycle_count 7\n", "go_trader_cycle_success 1\n"} {
+		if !strings.Contains(gotBody, want) {
+			t.Errorf("body missing %q:\n%s", want, gotBody)
+		}
+	}
+	if body := renderPushgatewayMetrics(state, "state save failed"); !strings.Contains(body, "go_trader_cycle_success 0\n") {
+		t.Errorf("failed cycle should push success 0:\n%s", body)
+	}
+}
+
+func TestPushMetricsReportsHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metric", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	err := pushMetrics(&PushgatewayConfig{URL: srv.URL}, "x 1\n")
+	if err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+		t.Fatalf("err = %v, want HTTP 400", err)
+	}
+}
+
+func TestValidatePushgatewayConfig(t *testing.T) {
+	if errs := validatePushgatewayConfig(nil); errs != nil {
+		t.Errorf("nil: %v", errs)
+	}
+	if errs := validatePushgatewayConfig(&PushgatewayConfig{URL: "http://pg:9091", Job: "trader", TimeoutSeconds: 5}); errs != nil {
+		t.Errorf("valid: %v", errs)
+	}
+	errs := validatePushgatewayConfig(&PushgatewayConfig{URL: "ftp://pg", Grouping: map[string]string{"job": "x", "bad-key": "y", "ok": ""}, TimeoutSeconds: -1})
+	if len(errs) != 5 {
+		t.Errorf("want 5 errors, got %d: %v", len(errs), errs)
+	}
+	if errs := validatePushgatewayConfig(&PushgatewayConfig{Job: "orphan"}); len(errs) != 1 {
+		t.Errorf("job without url: %v", errs)
+	}
+}
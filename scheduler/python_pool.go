@@ -0,0 +1,216 @@
+package main
+
+// python_pool: configurable, optionally adaptive cap on concurrent Python
+// subprocesses (#4978).
+//
+// Every runPython* call takes a slot from pythonSemaphore. The cap is
+// python_concurrency.max (default 4). With adaptive on, the live limit steps
+// down by one (never below min) whenever a script times out or, when
+// max_load_per_cpu is set, the 1-minute load average per CPU is above it at
+// release time; after pythonRecoverAfter clean runs it steps back up by one,
+// never above max. Slots already handed out are never revoked: a lower limit
+// only holds new acquires until enough running scripts finish. /metrics
+// exposes the live limit, running subprocesses and queue depth.
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultPythonConcurrency = 4
+	maxPythonConcurrency     = 64
+	// pythonRecoverAfter is how many consecutive clean runs raise an
+	// adaptively lowered limit by one.
+	pythonRecoverAfter = 20
+)
+
+// PythonConcurrencyConfig is the top-level "python_concurrency" block.
+type PythonConcurrencyConfig struct {
+	Max           int     `json:"max,omitempty"`              // concurrent trading-path scripts; 0/omitted → 4
+	Adaptive      bool    `json:"adaptive,omitempty"`         // step the limit down on timeouts / high load, back up after clean runs
+	Min           int     `json:"min,omitempty"`              // adaptive floor; 0/omitted → 1
+	MaxLoadPerCPU float64 `json:"max_load_per_cpu,omitempty"` // adaptive: 1-minute load average per CPU above which the limit steps down; 0 → timeouts only
+}
+
+func (c *PythonConcurrencyConfig) max() int {
+	if c == nil || c.Max <= 0 {
+		return defaultPythonConcurrency
+	}
+	return c.Max
+}
+
+func (c *PythonConcurrencyConfig) min() int {
+	if c == nil || c.Min <= 0 {
+		return 1
+	}
+	return c.Min
+}
+
+func (c *PythonConcurrencyConfig) adaptive() bool {
+	return c != nil && c.Adaptive
+}
+
+// validatePythonConcurrencyConfig checks the block. Nil is the default cap.
+func validatePythonConcurrencyConfig(c *PythonConcurrencyConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	if c.Max < 0 || c.Max > maxPythonConcurrency {
+		errs = append(errs, fmt.Sprintf("python_concurrency.max must be in [0, %d] (0 = default %d), got %d", maxPythonConcurrency, defaultPythonConcurrency, c.Max))
+	}
+	if c.Min < 0 || c.Min > c.max() {
+		errs = append(errs, fmt.Sprintf("python_concurrency.min must be in [0, max=%d] (0 = 1), got %d", c.max(), c.Min))
+	}
+	if c.MaxLoadPerCPU < 0 {
+		errs = append(errs, fmt.Sprintf("python_concurrency.max_load_per_cpu must be >= 0 (0 = timeouts only), got %g", c.MaxLoadPerCPU))
+	}
+	if !c.Adaptive && (c.Min != 0 || c.MaxLoadPerCPU != 0) {
+		errs = append(errs, "python_concurrency.min and max_load_per_cpu require adaptive: true")
+	}
+	return errs
+}
+
+func clonePythonConcurrencyConfig(c *PythonConcurrencyConfig) *PythonConcurrencyConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// formatPythonConcurrencyConfig renders the block for reload change logs.
+func formatPythonConcurrencyConfig(c *PythonConcurrencyConfig) string {
+	if !c.adaptive() {
+		return fmt.Sprintf("max=%d", c.max())
+	}
+	return fmt.Sprintf("adaptive(min=%d, max=%d, max_load_per_cpu=%g)", c.min(), c.max(), c.MaxLoadPerCPU)
+}
+
+// pythonLimiter is a counting semaphore whose limit can move at runtime.
+type pythonLimiter struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	cfg  *PythonConcurrencyConfig
+
+	limit    int // current cap; == cfg.max() unless adaptive lowered it
+	inUse    int
+	waiting  int
+	okStreak int
+}
+
+func newPythonLimiter(cfg *PythonConcurrencyConfig) *pythonLimiter {
+	l := &pythonLimiter{cfg: cfg, limit: cfg.max()}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// pythonSemaphore limits concurrent Python subprocess executions.
+var pythonSemaphore = newPythonLimiter(nil)
+
+// pythonLoadPerCPU returns the 1-minute load average divided by the CPU
+// count, ok=false when unavailable (non-Linux). Package var so tests can
+// stub it.
+var pythonLoadPerCPU = func() (float64, bool) {
+	raw, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(raw))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	return load / float64(runtime.NumCPU()), true
+}
+
+// Configure applies a (new) python_concurrency block. A static limit is set
+// outright; an adaptive one keeps its current level clamped to [min, max].
+func (l *pythonLimiter) Configure(cfg *PythonConcurrencyConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = cfg
+	switch {
+	case !cfg.adaptive() || l.limit > cfg.max():
+		l.limit = cfg.max()
+	case l.limit < cfg.min():
+		l.limit = cfg.min()
+	}
+	l.okStreak = 0
+	l.cond.Broadcast()
+}
+
+// Acquire blocks until a slot is free.
+func (l *pythonLimiter) Acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waiting++
+	for l.inUse >= l.limit {
+		l.cond.Wait()
+	}
+	l.waiting--
+	l.inUse++
+}
+
+// Release returns a slot. err is the script's outcome; in adaptive mode a
+// timeout (or high load) lowers the limit and a clean run counts toward
+// raising it again.
+func (l *pythonLimiter) Release(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inUse--
+	if l.cfg.adaptive() {
+		var timeout *pythonScriptTimeoutError
+		overloaded := false
+		if l.cfg.MaxLoadPerCPU > 0 {
+			if load, ok := pythonLoadPerCPU(); ok && load > l.cfg.MaxLoadPerCPU {
+				overloaded = true
+			}
+		}
+		switch {
+		case errors.As(err, &timeout) || overloaded:
+			l.okStreak = 0
+			if l.limit > l.cfg.min() {
+				l.limit--
+				fmt.Printf("[python] concurrency lowered to %d (timeout=%t, overloaded=%t)\n", l.limit, timeout != nil, overloaded)
+			}
+		case l.limit < l.cfg.max():
+			l.okStreak++
+			if l.okStreak >= pythonRecoverAfter {
+				l.okStreak = 0
+				l.limit++
+				fmt.Printf("[python] concurrency raised to %d\n", l.limit)
+			}
+		}
+	}
+	l.cond.Broadcast()
+}
+
+// Snapshot returns the current limit, running subprocesses and queue depth.
+func (l *pythonLimiter) Snapshot() (limit, running, queued int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit, l.inUse, l.waiting
+}
+
+// renderPythonPoolMetrics renders the subprocess pool gauges.
+func renderPythonPoolMetrics() string {
+	limit, running, queued := pythonSemaphore.Snapshot()
+	var b strings.Builder
+	writePromHeader(&b, "go_trader_python_concurrency_limit", "gauge", "Current cap on concurrent trading-path Python subprocesses.")
+	fmt.Fprintf(&b, "go_trader_python_concurrency_limit %d\n", limit)
+	writePromHeader(&b, "go_trader_python_running", "gauge", "Python subprocesses currently holding a slot.")
+	fmt.Fprintf(&b, "go_trader_python_running %d\n", running)
+	writePromHeader(&b, "go_trader_python_queue_depth", "gauge", "Python subprocess calls waiting for a slot.")
+	fmt.Fprintf(&b, "go_trader_python_queue_depth %d\n", queued)
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPythonLimiterAdaptive(t *testing.T) {
+	origLoad := pythonLoadPerCPU
+	defer func() { pythonLoadPerCPU = origLoad }()
+	load := 0.5
+	pythonLoadPerCPU = func() (float64, bool) { return load, true }
+
+	l := newPythonLimiter(&PythonConcurrencyConfig{Max: 3, Adaptive: true, Min: 2, MaxLoadPerCPU: 2})
+	timeout := &pythonScriptTimeoutError{d: time.Second}
+	run := func(err error) {
+		l.Acquire()
+		l.Release(err)
+	}
+	run(timeout)
+	if limit, _, _ := l.Snapshot(); limit != 2 {
+		t.Fatalf("limit after timeout = %d, want 2", limit)
+	}
+	run(timeout)
+	if limit, _, _ := l.Snapshot(); limit != 2 {
+		t.Fatalf("limit went below min: %d", limit)
+	}
+	for i := 0; i < pythonRecoverAfter; i++ {
+		run(errors.New("script error")) // non-timeout failures count as clean
+	}
+	if limit, _, _ := l.Snapshot(); limit != 3 {
+		t.Fatalf("limit after recovery = %d, want 3", limit)
+	}
+	load = 4
+	run(nil)
+	if limit, _, _ := l.Snapshot(); limit != 2 {
+		t.Fatalf("limit under load = %d, want 2", limit)
+	}
+
+	// A static config ignores timeouts and resets the limit.
+	l.Configure(&PythonConcurrencyConfig{Max: 5})
+	run(timeout)
+	if limit, _, _ := l.Snapshot(); limit != 5 {
+		t.Fatalf("static limit = %d, want 5", limit)
+	}
+}
+
+func TestPythonLimiterQueue(t *testing.T) {
+	l := newPythonLimiter(&PythonConcurrencyConfig{Max: 1})
+	l.Acquire()
+	done := make(chan struct{})
+	go func() {
+		l.Acquire()
+		l.Release(nil)
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, running, queued := l.Snapshot(); running == 1 && queued == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("second acquire never queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// Raising the limit admits the waiter without a release.
+	l.Configure(&PythonConcurrencyConfig{Max: 2})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waiter not admitted after limit raise")
+	}
+	l.Release(nil)
+	if limit, running, queued := l.Snapshot(); limit != 2 || running != 0 || queued != 0 {
+		t.Errorf("snapshot = %d/%d/%d", limit, running, queued)
+	}
+}
+
+func TestValidatePythonConcurrencyConfig(t *testing.T) {
+	if errs := validatePythonConcurrencyConfig(&PythonConcurrencyConfig{Max: 8, Adaptive: true, Min: 2, MaxLoadPerCPU: 1.5}); len(errs) != 0 {
+		t.Errorf("valid block rejected: %v", errs)
+	}
+	errs := validatePythonConcurrencyConfig(&PythonConcurrencyConfig{Max: 100, Min: -1, MaxLoadPerCPU: 1})
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"python_concurrency.max", "python_concurrency.min", "require adaptive"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in %v", want, errs)
+		}
+	}
+	if !strings.Contains(renderPythonPoolMetrics(), "go_trader_python_queue_depth 0") {
+		t.Error("queue depth gauge missing")
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+// quiet_hours: hold routine channel summaries overnight (#4950).
+//
+// Inside the configured window, channel summaries for runs that executed no
+// trades (the routine cadence posts and HOLD-cycle posts) are held instead of
+// sent. Summaries that carry trades still post immediately, and alerts —
+// kill switch, circuit breaker, stale data, execution failures — never go
+// through this path, so they are unaffected. On each channel's first run
+// after the window closes its summary posts regardless of cadence, prefixed
+// with a catch-up line counting what was held. Held state is in-memory; a
+// restart during quiet hours drops the count, not any trade notification.
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // timezone names resolve on hosts without a system zoneinfo
+)
+
+// QuietHoursConfig is the top-level "quiet_hours" block.
+type QuietHoursConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`              // "HH:MM" local to Timezone; the window opens here
+	End      string `json:"end"`                // "HH:MM" local to Timezone; an end before start wraps past midnight
+	Timezone string `json:"timezone,omitempty"` // IANA name (e.g. "America/New_York"); empty → UTC
+}
+
+func (c *QuietHoursConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *QuietHoursConfig) location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC // validated at load
+	}
+	return loc
+}
+
+// active reports whether now falls inside the quiet window.
+func (c *QuietHoursConfig) active(now time.Time) bool {
+	if !c.enabled() {
+		return false
+	}
+	sh, sm, ok1 := ParseLeaderboardPostTime(c.Start)
+	eh, em, ok2 := ParseLeaderboardPostTime(c.End)
+	if !ok1 || !ok2 {
+		return false
+	}
+	local := now.In(c.location())
+	cur := local.Hour()*60 + local.Minute()
+	start, end := sh*60+sm, eh*60+em
+	if start < end {
+		return cur >= start && cur < end
+	}
+	return cur >= start || cur < end
+}
+
+// label renders the window for catch-up messages and reload logs.
+func (c *QuietHoursConfig) label() string {
+	tz := c.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	return fmt.Sprintf("%s–%s %s", c.Start, c.End, tz)
+}
+
+// validateQuietHoursConfig checks the block. Nil or disabled is valid.
+func validateQuietHoursConfig(c *QuietHoursConfig) []string {
+	if !c.enabled() {
+		return nil
+	}
+	var errs []string
+	sh, sm, okStart := ParseLeaderboardPostTime(c.Start)
+	if !okStart {
+		errs = append(errs, fmt.Sprintf("quiet_hours.start must be HH:MM, got %q", c.Start))
+	}
+	eh, em, okEnd := ParseLeaderboardPostTime(c.End)
+	if !okEnd {
+		errs = append(errs, fmt.Sprintf("quiet_hours.end must be HH:MM, got %q", c.End))
+	}
+	if okStart && okEnd && sh == eh && sm == em {
+		errs = append(errs, fmt.Sprintf("quiet_hours: start and end are both %q (empty window)", c.Start))
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			errs = append(errs, fmt.Sprintf("quiet_hours.timezone: unknown zone %q", c.Timezone))
+		}
+	}
+	return errs
+}
+
+func cloneQuietHoursConfig(c *QuietHoursConfig) *QuietHoursConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// formatQuietHoursConfig renders the block for reload change logs.
+func formatQuietHoursConfig(c *QuietHoursConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return "enabled(" + c.label() + ")"
+}
+
+// quietHoursHeld is one channel's held-summary tally.
+type quietHoursHeld struct {
+	count       int
+	first, last time.Time
+}
+
+// quietHoursBatcher counts summaries held per channel key until the
+// channel's first post after the window.
+type quietHoursBatcher struct {
+	mu   sync.Mutex
+	held map[string]*quietHoursHeld
+}
+
+// Hold records one held summary for chKey.
+func (b *quietHoursBatcher) Hold(chKey string, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.held == nil {
+		b.held = make(map[string]*quietHoursHeld)
+	}
+	h := b.held[chKey]
+	if h == nil {
+		h = &quietHoursHeld{first: now}
+		b.held[chKey] = h
+	}
+	h.count++
+	h.last = now
+}
+
+// Pending reports whether chKey has held summaries awaiting a catch-up.
+func (b *quietHoursBatcher) Pending(chKey string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.held[chKey] != nil
+}
+
+// Release clears chKey's tally and returns the catch-up line to prefix its
+// next summary, or "" when nothing was held.
+func (b *quietHoursBatcher) Release(chKey string, cfg *QuietHoursConfig) string {
+	b.mu.Lock()
+	h := b.held[chKey]
+	delete(b.held, chKey)
+	b.mu.Unlock()
+	if h == nil {
+		return ""
+	}
+	noun := "summaries"
+	if h.count == 1 {
+		noun = "summary"
+	}
+	window := ""
+	if cfg != nil {
+		window = " (" + cfg.label() + ")"
+	}
+	return fmt.Sprintf("🌅 **Quiet hours catch-up**%s — %d routine %s held between %s and %s UTC; trade posts went out as usual. Current summary follows.",
+		window, h.count, noun, h.first.Format("Jan 02 15:04"), h.last.Format("15:04"))
+}
+
+// quietHours is the package-level batcher; resets on restart.
+var quietHours = &quietHoursBatcher{}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursActive_WrapsMidnight(t *testing.T) {
+	cfg := &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"}
+	cases := []struct {
+		at   string
+		want bool
+	}{
+		{"2026-03-10T21:59:00Z", false},
+		{"2026-03-10T22:00:00Z", true},
+		{"2026-03-11T03:30:00Z", true},
+		{"2026-03-11T06:59:00Z", true},
+		{"2026-03-11T07:00:00Z", false},
+		{"2026-03-11T12:00:00Z", false},
+	}
+	for _, c := range cases {
+		now, _ := time.Parse(time.RFC3339, c.at)
+		if got := cfg.active(now); got != c.want {
+			t.Errorf("active(%s) = %v, want %v", c.at, got, c.want)
+		}
+	}
+}
+
+func TestQuietHoursActive_SameDayAndTimezone(t *testing.T) {
+	cfg := &QuietHoursConfig{Enabled: true, Start: "01:00", End: "05:00", Timezone: "America/New_York"}
+	// 06:00 UTC in March (EDT, UTC-4) is 02:00 local → inside.
+	if !cfg.active(time.Date(2026, 3, 20, 6, 0, 0, 0, time.UTC)) {
+		t.Error("06:00 UTC should be inside 01:00–05:00 America/New_York")
+	}
+	// 03:00 UTC is 23:00 local the previous day → outside.
+	if cfg.active(time.Date(2026, 3, 20, 3, 0, 0, 0, time.UTC)) {
+		t.Error("03:00 UTC should be outside 01:00–05:00 America/New_York")
+	}
+	var disabled *QuietHoursConfig
+	if disabled.active(time.Date(2026, 3, 20, 3, 0, 0, 0, time.UTC)) {
+		t.Error("nil config must never be active")
+	}
+}
+
+func TestValidateQuietHoursConfig(t *testing.T) {
+	if errs := validateQuietHoursConfig(&QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Timezone: "Europe/London"}); len(errs) != 0 {
+		t.Fatalf("valid config rejected: %v", errs)
+	}
+	if errs := validateQuietHoursConfig(&QuietHoursConfig{Start: "bogus"}); len(errs) != 0 {
+		t.Fatalf("disabled config should not be validated: %v", errs)
+	}
+	errs := validateQuietHoursConfig(&QuietHoursConfig{Enabled: true, Start: "25:00", End: "7", Timezone: "Mars/Olympus"})
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"quiet_hours.start", "quiet_hours.end", "unknown zone"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in %v", want, errs)
+		}
+	}
+	errs = validateQuietHoursConfig(&QuietHoursConfig{Enabled: true, Start: "7:00", End: "07:00"})
+	if len(errs) != 1 || !strings.Contains(errs[0], "empty window") {
+		t.Errorf("equal start/end: got %v, want one empty-window error", errs)
+	}
+}
+
+func TestQuietHoursBatcher_HoldAndRelease(t *testing.T) {
+	b := &quietHoursBatcher{}
+	cfg := &QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00"}
+	if b.Pending("spot") || b.Release("spot", cfg) != "" {
+		t.Fatal("empty batcher should have nothing pending")
+	}
+	b.Hold("spot", time.Date(2026, 3, 10, 22, 5, 0, 0, time.UTC))
+	b.Hold("spot", time.Date(2026, 3, 11, 6, 55, 0, 0, time.UTC))
+	b.Hold("perps", time.Date(2026, 3, 11, 1, 0, 0, 0, time.UTC))
+	if !b.Pending("spot") {
+		t.Fatal("spot should be pending after Hold")
+	}
+	line := b.Release("spot", cfg)
+	for _, want := range []string{"Quiet hours catch-up", "22:00–07:00 UTC", "2 routine summaries", "Mar 10 22:05", "06:55"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("catch-up line missing %q: %s", want, line)
+		}
+	}
+	if b.Pending("spot") {
+		t.Error("Release should clear the channel")
+	}
+	if !b.Pending("perps") {
+		t.Error("Release must not clear other channels")
+	}
+	if line := b.Release("perps", cfg); !strings.Contains(line, "1 routine summary held") {
+		t.Errorf("singular noun: %s", line)
+	}
+}
@@ -494,7 +494,7 @@ func regimeGateOutagePolicyNote(key regimeBundleKey, due []StrategyConfig, rc *R
 
 // startRegimeStorePopulation rebuilds the global store for this cycle: clear,
 // union due-strategy signatures, one subprocess per distinct signature
-// (parallel; pythonSemaphore caps concurrency at 4). It kicks the work off on
+// (parallel; pythonSemaphore caps concurrency). It kicks the work off on
 // a background goroutine and returns a wait func, so the main loop can run
 // the once-per-cycle portfolio risk / kill-switch phase CONCURRENTLY and a
 // regime hang can never delay risk management — call the wait func right
@@ -25,7 +25,8 @@ func markSchedulerStarted() {
 // collectPriceSymbols returns the list of BinanceUS-format symbols to fetch
 // for spot strategy valuation/notional. Only "spot" strategy types are
 // included — spot positions are stored and fetched under the same key
-// (e.g. "BTC/USDT"), so no aliasing is needed.
+// (e.g. "BTC/USDT"), so no aliasing is needed. Explicit per-strategy
+// benchmark pairs (#4927) ride along so alpha/beta has a price every cycle.
 //
 // Perps strategies are intentionally excluded: HL and OKX perps marks are
 // now sourced from the venues they live on via fetchHyperliquidMids and
@@ -36,6 +37,10 @@ func markSchedulerStarted() {
 func collectPriceSymbols(strategies []StrategyConfig) []string {
 	set := make(map[string]bool)
 	for _, sc := range strategies {
+		// #4927: a configured benchmark pair is priced on the same rail.
+		if sc.Benchmark != "" {
+			set[sc.Benchmark] = true
+		}
 		if sc.Type != "spot" {
 			continue
 		}
@@ -243,6 +248,17 @@ type PortfolioRiskState struct {
 	WarningEquityDeltaPct    float64           `json:"warning_equity_delta_pct,omitempty"`
 	WarningMarginDeltaPct    float64           `json:"warning_margin_delta_pct,omitempty"`
 	Events                   []KillSwitchEvent `json:"events,omitempty"`
+
+	// Versioned high-water-mark metadata (see portfolio_risk_state.go).
+	Version          int                   `json:"version,omitempty"`
+	PeakAt           time.Time             `json:"peak_at,omitempty"`
+	PeakSource       string                `json:"peak_source,omitempty"`
+	KillSwitchReason string                `json:"kill_switch_reason,omitempty"`
+	History          []PortfolioRiskSample `json:"history,omitempty"`
+
+	// VaR is this cycle's historical VaR estimate (portfolio_var.go).
+	// Recomputed every cycle from History; not persisted.
+	VaR *PortfolioVaR `json:"var,omitempty"`
 }
 
 // SharedWalletBalanceFetcher returns the real on-chain balance for a given
@@ -340,7 +356,10 @@ func ClearLatchedKillSwitchSharedWallet(state *AppState, strategies []StrategyCo
 	// Re-baseline peak to the verified on-chain total so CheckPortfolioRisk
 	// does not immediately re-latch on the first tick using the stale
 	// (potentially double-counted) peak.
+	state.PortfolioRisk.KillSwitchReason = ""
 	state.PortfolioRisk.PeakValue = totalBalance
+	state.PortfolioRisk.PeakAt = time.Now().UTC()
+	state.PortfolioRisk.PeakSource = peakSourceAutoReset
 	state.PortfolioRisk.CurrentDrawdownPct = 0
 	state.PortfolioRisk.CurrentMarginDrawdownPct = 0
 	addKillSwitchEvent(&state.PortfolioRisk, "auto_reset", "",
@@ -387,7 +406,10 @@ func AutoResetConfirmedFlatKillSwitch(prs *PortfolioRiskState, rebaselineValue f
 	prs.LastWarningMarginDDPct = 0
 	prs.WarningEquityDeltaPct = 0
 	prs.WarningMarginDeltaPct = 0
+	prs.KillSwitchReason = ""
 	prs.PeakValue = rebaselineValue
+	prs.PeakAt = time.Now().UTC()
+	prs.PeakSource = peakSourceAutoReset
 	prs.CurrentDrawdownPct = 0
 	prs.CurrentMarginDrawdownPct = 0
 	addKillSwitchEvent(prs, "auto_reset", "", 0, rebaselineValue, rebaselineValue, details)
@@ -417,6 +439,21 @@ func addKillSwitchEvent(prs *PortfolioRiskState, eventType, source string, drawd
 	}
 }
 
+// ManualResetKillSwitch clears a latched kill switch on an operator's
+// explicit request (owner DM reply, gRPC admin API) and logs a "reset" event.
+// Caller holds mu.Lock and persists state. Returns false when nothing was
+// latched.
+func ManualResetKillSwitch(prs *PortfolioRiskState, details string) bool {
+	if !prs.KillSwitchActive {
+		return false
+	}
+	prs.KillSwitchActive = false
+	prs.KillSwitchAt = time.Time{}
+	prs.KillSwitchReason = ""
+	addKillSwitchEvent(prs, "reset", "", prs.CurrentDrawdownPct, 0, prs.PeakValue, details)
+	return true
+}
+
 // AggregatePerpsMarginInputs sums unrealized loss and deployed margin across
 // every perps strategy in the portfolio. It returns the numerator and
 // denominator inputs of the drawdown ratio (not a ratio itself) — matches the
@@ -491,6 +528,8 @@ func CheckPortfolioRisk(prs *PortfolioRiskState, cfg *PortfolioRiskConfig, total
 	// Ratchet peak high-water mark upward only.
 	if totalValue > prs.PeakValue {
 		prs.PeakValue = totalValue
+		prs.PeakAt = time.Now().UTC()
+		prs.PeakSource = peakSourceHighWater
 	}
 
 	// Compute both drawdown signals independently. Each is persisted to its
@@ -542,6 +581,7 @@ func CheckPortfolioRisk(prs *PortfolioRiskState, cfg *PortfolioRiskConfig, total
 			r = fmt.Sprintf("portfolio drawdown %.1f%% exceeds limit %.1f%% (value=$%.2f, peak=$%.2f)",
 				equityDD, cfg.MaxDrawdownPct, totalValue, prs.PeakValue)
 		}
+		prs.KillSwitchReason = r
 		addKillSwitchEvent(prs, "triggered", source, dd, totalValue, prs.PeakValue, r)
 		return false, false, false, r
 	}
@@ -634,6 +674,7 @@ type RiskState struct {
 	CurrentDrawdownPct  float64   `json:"current_drawdown_pct"`
 	DailyPnL            float64   `json:"daily_pnl"`
 	DailyPnLDate        string    `json:"daily_pnl_date"`
+	DailyTrades         int       `json:"daily_trades"` // trades recorded on DailyPnLDate, for max_daily_trades
 	ConsecutiveLosses   int       `json:"consecutive_losses"`
 	CircuitBreaker      bool      `json:"circuit_breaker"`
 	CircuitBreakerUntil time.Time `json:"circuit_breaker_until"`
@@ -652,6 +693,10 @@ type RiskState struct {
 	// pairs according to its API; HL uses coin name + base-unit size, other
 	// venues will use their own identifier conventions (phases 2-4).
 	PendingCircuitCloses map[string]*PendingCircuitClose `json:"pending_circuit_closes,omitempty"`
+	// PeakHistory holds daily high values while drawdown_window_days is set,
+	// so PeakValue can age out of the lookback (see drawdown_window.go).
+	// Serialized to SQLite as risk_peak_history_json.
+	PeakHistory []DailyPeak `json:"peak_history,omitempty"`
 }
 
 // PlatformPendingCloseHyperliquid is the map key in RiskState.PendingCircuitCloses
@@ -1126,15 +1171,70 @@ func setOKXCircuitBreakerPending(sc *StrategyConfig, s *StrategyState, assist *P
 	})
 }
 
-// rolloverDailyPnL resets DailyPnL to zero whenever the UTC date has advanced
-// past DailyPnLDate. Calling this at both risk-check time and trade-record time
-// ensures the reset is applied regardless of which code path runs first after
-// midnight — fixing issue #27 where a skipped or late risk check could cause
-// trades to be counted against the wrong day.
+// dailyResetBoundary is when the per-day risk counters roll over (#4986):
+// portfolio_risk.daily_reset_hour in daily_reset_timezone.
+type dailyResetBoundary struct {
+	hour int
+	loc  *time.Location
+}
+
+// activeDailyReset is the boundary riskDay applies; nil means 00:00 UTC. Set
+// from config at daemon startup, on SIGHUP reload and by the manual CLIs, and
+// read from the UI/API goroutines, hence atomic.
+var activeDailyReset atomic.Pointer[dailyResetBoundary]
+
+// applyDailyResetFromConfig adopts cfg's daily reset hour/timezone. Like
+// applyAlertThrottleFromConfig, call it only for a config actually in use. An
+// unknown timezone (already rejected by validation) falls back to UTC.
+func applyDailyResetFromConfig(cfg *Config) {
+	b := &dailyResetBoundary{loc: time.UTC}
+	if cfg != nil && cfg.PortfolioRisk != nil {
+		b.hour = cfg.PortfolioRisk.DailyResetHour
+		if tz := cfg.PortfolioRisk.DailyResetTimezone; tz != "" {
+			if loc, err := time.LoadLocation(tz); err == nil {
+				b.loc = loc
+			}
+		}
+	}
+	activeDailyReset.Store(b)
+}
+
+// riskDay returns the day key (YYYY-MM-DD of the day's start) the daily risk
+// counters use at now. With a 17:00 reset, 16:59 still belongs to the day
+// that began at 17:00 yesterday. The wall-clock hour is compared directly so
+// DST shifts move the reset with the zone.
+func riskDay(now time.Time) string {
+	b := activeDailyReset.Load()
+	if b == nil {
+		return now.UTC().Format("2006-01-02")
+	}
+	local := now.In(b.loc)
+	if local.Hour() < b.hour {
+		local = local.AddDate(0, 0, -1)
+	}
+	return local.Format("2006-01-02")
+}
+
+// dailyResetLabel renders the active boundary for operator messages, e.g.
+// "00:00 UTC" or "17:00 America/New_York".
+func dailyResetLabel() string {
+	b := activeDailyReset.Load()
+	if b == nil {
+		return "00:00 UTC"
+	}
+	return fmt.Sprintf("%02d:00 %s", b.hour, b.loc.String())
+}
+
+// rolloverDailyPnL resets DailyPnL to zero whenever the risk day (riskDay) has
+// advanced past DailyPnLDate. Calling this at both risk-check time and
+// trade-record time ensures the reset is applied regardless of which code
+// path runs first after the reset — fixing issue #27 where a skipped or late
+// risk check could cause trades to be counted against the wrong day.
 func rolloverDailyPnL(r *RiskState) {
-	today := time.Now().UTC().Format("2006-01-02")
+	today := riskDay(time.Now())
 	if r.DailyPnLDate != today {
 		r.DailyPnL = 0
+		r.DailyTrades = 0
 		r.DailyPnLDate = today
 	}
 }
@@ -1447,10 +1547,12 @@ func CheckRisk(sc *StrategyConfig, s *StrategyState, portfolioValue float64, pri
 		circuitBreakerSuppressedWarned.Delete(s.ID)
 	}
 
-	// Update peak
-	if portfolioValue > r.PeakValue {
-		r.PeakValue = portfolioValue
+	// Update peak (all-time, or rolling over drawdown_window_days).
+	var windowDays int
+	if sc != nil {
+		windowDays = sc.DrawdownWindowDays
 	}
+	updateStrategyPeak(r, windowDays, portfolioValue, now)
 
 	// Check drawdown.
 	//
@@ -48,6 +48,15 @@ type PerpsSizing struct {
 	// RiskStopUnresolved carries the resolver's reason when RiskStopDistance
 	// is 0 in risk mode, for skip-reason logging.
 	RiskStopUnresolved string
+	// SizeFraction scales the open notional by the strategy's resolved
+	// size_fraction (size_fraction.go). 0 or 1 = full size.
+	SizeFraction float64
+}
+
+// withSizeFraction returns the bundle with the resolved size_fraction applied.
+func (s PerpsSizing) withSizeFraction(f float64) PerpsSizing {
+	s.SizeFraction = f
+	return s
 }
 
 // riskUnresolvedLabel returns the resolver failure reason, defaulting to a
@@ -124,10 +133,16 @@ func PerpsRiskBasedNotional(cash, price, riskPct, stopDistance, exchangeLeverage
 // turn that into a fail-closed refusal (fresh open) or a close-only degrade
 // (flip), never a silent notional fallback.
 func PerpsOpenNotionalSized(cash, price float64, sizing PerpsSizing) float64 {
+	var notional float64
 	if sizing.RiskPerTradePct > 0 {
-		return PerpsRiskBasedNotional(cash, price, sizing.RiskPerTradePct, sizing.RiskStopDistance, sizing.ExchangeLeverage)
+		notional = PerpsRiskBasedNotional(cash, price, sizing.RiskPerTradePct, sizing.RiskStopDistance, sizing.ExchangeLeverage)
+	} else {
+		notional = PerpsOpenNotional(cash, sizing.SizingLeverage, sizing.ExchangeLeverage, sizing.MarginPerTradeUSD)
+	}
+	if sizing.SizeFraction > 0 && sizing.SizeFraction < 1 {
+		notional *= sizing.SizeFraction
 	}
-	return PerpsOpenNotional(cash, sizing.SizingLeverage, sizing.ExchangeLeverage, sizing.MarginPerTradeUSD)
+	return notional
 }
 
 // riskStopOwner enumerates the stop owners risk-per-trade sizing can derive a
@@ -3366,3 +3366,61 @@ func TestCheckRisk_CircuitBreakerDisabled_ThrottleClearsWhenBreachClears(t *test
 		t.Fatal("throttle should clear once the breach clears")
 	}
 }
+
+func TestRiskDayResetHour(t *testing.T) {
+	defer activeDailyReset.Store(nil)
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	if got := riskDay(at("2026-03-09T23:30:00Z")); got != "2026-03-09" {
+		t.Errorf("default boundary = %s", got)
+	}
+
+	applyDailyResetFromConfig(&Config{PortfolioRisk: &PortfolioRiskConfig{DailyResetHour: 17, DailyResetTimezone: "America/New_York"}})
+	for _, tc := range []struct{ now, want string }{
+		{"2026-03-06T21:59:00Z", "2026-03-05"}, // 16:59 EST
+		{"2026-03-06T22:00:00Z", "2026-03-06"}, // 17:00 EST
+		{"2026-03-08T20:59:00Z", "2026-03-07"}, // 16:59 EDT, the DST switch day
+		{"2026-03-08T21:00:00Z", "2026-03-08"}, // 17:00 EDT
+		{"2026-03-09T03:00:00Z", "2026-03-08"}, // 23:00 EDT
+	} {
+		if got := riskDay(at(tc.now)); got != tc.want {
+			t.Errorf("riskDay(%s) = %s, want %s", tc.now, got, tc.want)
+		}
+	}
+	if got := dailyResetLabel(); got != "17:00 America/New_York" {
+		t.Errorf("label = %q", got)
+	}
+
+	applyDailyResetFromConfig(&Config{PortfolioRisk: &PortfolioRiskConfig{DailyResetHour: 8}})
+	if got := riskDay(at("2026-03-09T07:59:00Z")); got != "2026-03-08" {
+		t.Errorf("08:00 UTC boundary before = %s", got)
+	}
+	if got := riskDay(at("2026-03-09T08:00:00Z")); got != "2026-03-09" {
+		t.Errorf("08:00 UTC boundary after = %s", got)
+	}
+}
+
+func TestValidateConfig_DailyReset(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		PortfolioRisk:   &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60, DailyResetHour: 24, DailyResetTimezone: "Mars/Olympus"},
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"daily_reset_hour", "daily_reset_timezone"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -187,7 +187,8 @@ type scaleInSnapshot struct {
 // perpsScaleInDecision decides whether a same-direction perps signal should ADD
 // to the existing position (scale-in) rather than be skipped. It is pure and
 // gates on: opt-in (AllowScaleIn), direction-match (an add never flips), the
-// max-adds and max-added-notional caps, and the signed ATR spacing.
+// max-adds and max-added-notional caps, the signed ATR spacing, and the
+// min_gain_pct pyramiding gate.
 //
 // defaultOpenNotionalUSD is the strategy's standard open notional (the same
 // sizing a fresh open leg uses); it is the per-add notional unless
@@ -264,6 +265,23 @@ func perpsScaleInDecision(sc StrategyConfig, snap scaleInSnapshot, signal int, p
 		}
 	}
 
+	if cfg.MinGainPct > 0 {
+		lastAdd := snap.LastAddPrice
+		if lastAdd <= 0 {
+			lastAdd = snap.AvgCost
+		}
+		gainPct := 0.0
+		if lastAdd > 0 {
+			gainPct = (price - lastAdd) / lastAdd * 100
+			if snap.Side == "short" {
+				gainPct = -gainPct
+			}
+		}
+		if gainPct+1e-9 < cfg.MinGainPct {
+			return 0, false, fmt.Sprintf("scale-in min_gain_pct not reached (%.2f%% < %.2f%% since last entry)", gainPct, cfg.MinGainPct)
+		}
+	}
+
 	return addNotional / price, true, ""
 }
 
@@ -332,6 +350,73 @@ func applyPerpsScaleIn(s *StrategyState, sc StrategyConfig, symbol string, addPr
 	return 1, &trade
 }
 
+// applySpotScaleIn buys usd of symbol at execPrice into the open paper spot
+// long pos: cash pays notional + fee (spot is fully funded, unlike the perps
+// margin leg), the blend goes through applyScaleIn, and the scale_in trade
+// leg is recorded. label names the leg in Details ("Scale-in", "DCA tranche
+// 3"). Shared by the spot pyramiding path (allow_scale_in) and dca.
+func applySpotScaleIn(s *StrategyState, pos *Position, symbol string, usd, execPrice float64, label string, logger *StrategyLogger) {
+	qty := usd / execPrice
+	fee := CalculatePlatformSpotFee(s.Platform, usd)
+	totalDebit := usd + fee
+	s.Cash -= totalDebit
+	applyScaleIn(pos, qty, execPrice)
+	pos.ScaleInResizePending = false // paper spot: no on-chain protection to resize
+	trade := Trade{
+		Timestamp:   time.Now().UTC(),
+		StrategyID:  s.ID,
+		Symbol:      symbol,
+		PositionID:  ensurePositionTradeID(s.ID, symbol, pos),
+		Side:        "buy",
+		Quantity:    qty,
+		Price:       execPrice,
+		Value:       totalDebit,
+		TradeType:   scaleInTradeType,
+		Details:     fmt.Sprintf("%s: add %.6f @ $%.2f (new qty %.6f, avg $%.2f, fee $%.2f)", label, qty, execPrice, pos.Quantity, pos.AvgCost, fee),
+		ExchangeFee: fee,
+		FeeSource:   FeeSourceModeled,
+		PnLGross:    true,
+	}
+	trade.Regime = pos.Regime
+	trade.EntryATR = pos.EntryATR
+	RecordTrade(s, trade)
+	logger.Info("%s %s: +%.6f @ $%.2f (new qty %.6f, avg $%.2f, fee $%.2f)", label, symbol, qty, execPrice, pos.Quantity, pos.AvgCost, fee)
+}
+
+// spotScaleInUSD decides whether a BUY on an open paper spot long pyramids
+// (allow_scale_in) and returns the add's USD notional. The gate is the shared
+// perpsScaleInDecision; the default per-add notional is the full-size open a
+// fresh BUY would make (all cash), and every add is bounded by cash.
+func spotScaleInUSD(sc StrategyConfig, pos *Position, cash, price, sizeFraction float64) (float64, string) {
+	if pos == nil || pos.Side != "long" {
+		return 0, "not a same-direction add"
+	}
+	snap := scaleInSnapshot{
+		Side:             pos.Side,
+		Quantity:         pos.Quantity,
+		AvgCost:          pos.AvgCost,
+		EntryATR:         pos.EntryATR,
+		ScaleInCount:     pos.ScaleInCount,
+		AddedNotionalUSD: pos.AddedNotionalUSD,
+		LastAddPrice:     pos.LastAddPrice,
+	}
+	qty, ok, reason := perpsScaleInDecision(sc, snap, 1, price, cash)
+	if !ok {
+		return 0, reason
+	}
+	usd := qty * price
+	if sizeFraction > 0 && sizeFraction < 1 {
+		usd *= sizeFraction
+	}
+	if usd > cash {
+		usd = cash
+	}
+	if usd < 1 {
+		return 0, fmt.Sprintf("insufficient cash ($%.2f) for a scale-in add", cash)
+	}
+	return usd, ""
+}
+
 // scaleInProtectionForceReplace forces the HL protection sync to cancel+replace
 // the SL and any already-placed (un-cleared) TP tiers after a scale-in. The
 // trigger PRICES are frozen, but the SIZE grew, so the existing trigger orders
@@ -384,21 +469,34 @@ func runHyperliquidScaleInOrder(sc StrategyConfig, result *HyperliquidResult, ad
 		side = "sell"
 	}
 	logger.Info("Placing live scale-in %s %s size=%.6f", side, result.Symbol, addSize)
-	execResult, stderr, err := RunHyperliquidExecute(sc.Script, result.Symbol, side, addSize, 0, 0, 0, "", 0, false, walletSnapshot)
-	if stderr != "" {
-		logger.Info("execute stderr: %s", stderr)
+	attempt := func(size float64) (*HyperliquidExecuteResult, error) {
+		intentKey := beginOrderIntent(sc, result.Symbol, side, size, logger)
+		execResult, stderr, err := RunHyperliquidExecute(sc.Script, result.Symbol, side, size, 0, 0, 0, "", 0, false, walletSnapshot, orderIntentClientID(sc.Platform, intentKey))
+		settleOrderIntent(intentKey, err, execResult, logger)
+		if stderr != "" {
+			logger.Info("execute stderr: %s", stderr)
+		}
+		return execResult, err
 	}
+	// #4958: classified retry/resize, as for regular opens.
+	execResult, addSize, err := retryHyperliquidExecute(attempt, addSize, result.Price, liveSymbolSpecFor(sc.Platform, result.Symbol), true, logger)
 	if err != nil {
-		logger.Error("Live scale-in failed: %v", err)
-		notifyLiveExecFailure(notifier, sc, directionOpen, result.Symbol, err.Error())
+		msg := liveExecFailureMessage(err.Error())
+		logger.Error("Live scale-in failed: %s", msg)
+		notifyLiveExecFailure(notifier, sc, directionOpen, result.Symbol, msg)
 		return execResult, false
 	}
 	if execResult.Error != "" {
-		logger.Error("Live scale-in returned error: %s", execResult.Error)
-		notifyLiveExecFailure(notifier, sc, directionOpen, result.Symbol, execResult.Error)
+		msg := liveExecFailureMessage(execResult.Error)
+		logger.Error("Live scale-in returned error: %s", msg)
+		notifyLiveExecFailure(notifier, sc, directionOpen, result.Symbol, msg)
 		return execResult, false
 	}
 	clearLiveExecThrottle(sc, directionOpen, result.Symbol)
+	if !vetHyperliquidFill(sc, execResult, result.Symbol, side, addSize, false, notifier, logger) {
+		return execResult, false
+	}
+	execResult.SlippageBreached = checkLiveFillSlippage(sc, result.Symbol, side, result.Price, execResult.Execution.Fill.AvgPx, notifier, logger)
 	return execResult, true
 }
 
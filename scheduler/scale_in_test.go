@@ -534,3 +534,47 @@ func TestPerpsScaleInDecisionSpacingNeedsEntryATR(t *testing.T) {
 		t.Fatalf("spacing gate should reject when EntryATR is unavailable")
 	}
 }
+
+func TestPerpsScaleInDecision_MinGainPct(t *testing.T) {
+	sc := StrategyConfig{AllowScaleIn: true, ScaleIn: &ScaleInConfig{MinGainPct: 5, AddNotionalUSD: 500}}
+	snap := scaleInSnapshot{Side: "long", Quantity: 1, AvgCost: 100, LastAddPrice: 110}
+	if _, ok, reason := perpsScaleInDecision(sc, snap, 1, 114, 1000); ok || reason == "" {
+		t.Errorf("3.6%% since the last add must not pass a 5%% gate (reason %q)", reason)
+	}
+	if qty, ok, _ := perpsScaleInDecision(sc, snap, 1, 115.5, 1000); !ok || qty <= 0 {
+		t.Error("a 5% gain since the last add must pass")
+	}
+	short := scaleInSnapshot{Side: "short", Quantity: 1, AvgCost: 100}
+	if _, ok, _ := perpsScaleInDecision(sc, short, -1, 94, 1000); !ok {
+		t.Error("a 6% drop must count as a gain for a short")
+	}
+}
+
+func TestSpotScaleIn_PyramidsIntoWinners(t *testing.T) {
+	sc := StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000,
+		AllowScaleIn: true, ScaleIn: &ScaleInConfig{MaxAdds: 1, AddNotionalUSD: 200, MinGainPct: 10}}
+	s := NewStrategyState(sc)
+	s.Cash = 500
+	pos := &Position{Symbol: "BTC/USDT", Quantity: 5, InitialQuantity: 5, AvgCost: 100, Side: "long"}
+	s.Positions["BTC/USDT"] = pos
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("sma-btc")
+
+	if usd, why := spotScaleInUSD(sc, pos, s.Cash, 105, 1); usd != 0 || why == "" {
+		t.Fatalf("5%% gain must not add: %v %q", usd, why)
+	}
+	usd, _ := spotScaleInUSD(sc, pos, s.Cash, 110, 0.5)
+	if usd != 100 {
+		t.Fatalf("add usd = %v, want 200 * size_fraction 0.5", usd)
+	}
+	applySpotScaleIn(s, pos, "BTC/USDT", usd, 110, "Scale-in", logger)
+	if pos.ScaleInCount != 1 || pos.LastAddPrice != 110 || s.Cash >= 400 {
+		t.Errorf("after add: pos=%+v cash=%v", pos, s.Cash)
+	}
+	if got := s.TradeHistory[len(s.TradeHistory)-1]; got.TradeType != scaleInTradeType {
+		t.Errorf("trade type = %q", got.TradeType)
+	}
+	if usd, why := spotScaleInUSD(sc, pos, s.Cash, 200, 1); usd != 0 || why != "scale-in max_adds reached" {
+		t.Errorf("max_adds must stop further adds: %v %q", usd, why)
+	}
+}
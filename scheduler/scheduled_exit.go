@@ -0,0 +1,137 @@
+package main
+
+// scheduled_exit: time-based exits for spot positions (#4967).
+//
+// exit_at closes a spot strategy's positions at a fixed UTC slot — e.g.
+// Friday 20:00 to sit out weekend risk — whatever the strategy signals. It
+// runs in Go after the cycle's dispatch, so it fires even when the check
+// script fails. On the slot day, from the slot time on, every position
+// opened before the slot is closed at the cycle price through the paper
+// spot executor; positions opened after the slot (a re-entry) are left
+// alone, so the rule is restart-safe without persisted state. The closes
+// are recorded as scheduled-exit trades (Details "Scheduled exit: …",
+// reason tag scheduled_exit). Paper spot only: live OKX/Robinhood spot is
+// rejected at config load.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExitScheduleConfig is a strategy's "exit_at" block.
+type ExitScheduleConfig struct {
+	Weekday string `json:"weekday,omitempty"` // "monday".."sunday"; empty → every day
+	Time    string `json:"time"`              // "HH:MM" UTC
+}
+
+// slot returns the exit time on now's UTC day, and false when now's weekday
+// is not the configured one.
+func (c *ExitScheduleConfig) slot(now time.Time) (time.Time, bool) {
+	now = now.UTC()
+	if c.Weekday != "" {
+		if wd, _ := parseWeekday(c.Weekday); now.Weekday() != wd {
+			return time.Time{}, false
+		}
+	}
+	h, m, _ := ParseLeaderboardPostTime(c.Time)
+	return time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, time.UTC), true
+}
+
+// validateExitSchedule checks sc's exit_at. Nil is disabled.
+func validateExitSchedule(sc StrategyConfig, prefix string) []string {
+	c := sc.ExitAt
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	if sc.Type != "spot" {
+		errs = append(errs, fmt.Sprintf("%s: exit_at is only supported for spot strategies, got type %q", prefix, sc.Type))
+	} else if (sc.Platform == "okx" || sc.Platform == "robinhood") && isLiveArgs(sc.Args) {
+		errs = append(errs, fmt.Sprintf("%s: exit_at books paper closes and is not supported for live %s strategies", prefix, sc.Platform))
+	}
+	if c.Weekday != "" {
+		if _, ok := parseWeekday(c.Weekday); !ok {
+			errs = append(errs, fmt.Sprintf("%s: exit_at.weekday must be a day name (e.g. \"friday\"), got %q", prefix, c.Weekday))
+		}
+	}
+	if _, _, ok := ParseLeaderboardPostTime(c.Time); !ok {
+		errs = append(errs, fmt.Sprintf("%s: exit_at.time must be HH:MM (UTC), got %q", prefix, c.Time))
+	}
+	return errs
+}
+
+// formatExitSchedule renders exit_at for reload change logs.
+func formatExitSchedule(c *ExitScheduleConfig) string {
+	if c == nil {
+		return "disabled"
+	}
+	day := c.Weekday
+	if day == "" {
+		day = "daily"
+	}
+	return fmt.Sprintf("%s %s UTC", day, c.Time)
+}
+
+// scheduledExitSymbols returns the symbols of s's positions due for the
+// scheduled exit at now: the slot has passed today and the position was
+// opened before it. Sorted for deterministic booking.
+func scheduledExitSymbols(c *ExitScheduleConfig, s *StrategyState, now time.Time) []string {
+	if c == nil {
+		return nil
+	}
+	slot, ok := c.slot(now)
+	if !ok || now.Before(slot) {
+		return nil
+	}
+	var syms []string
+	for sym, pos := range s.Positions {
+		if pos == nil || pos.Quantity <= 0 || (!pos.OpenedAt.IsZero() && !pos.OpenedAt.Before(slot)) {
+			continue
+		}
+		syms = append(syms, sym)
+	}
+	sort.Strings(syms)
+	return syms
+}
+
+// runScheduledExit closes sc's positions due for its exit_at slot at the
+// cycle price. Returns the trades booked and a channel detail line.
+func runScheduledExit(sc StrategyConfig, s *StrategyState, prices map[string]float64, mu *sync.RWMutex, now time.Time, logger *StrategyLogger) (int, string) {
+	if sc.ExitAt == nil || sc.Type != "spot" {
+		return 0, ""
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	trades := 0
+	var closed []string
+	for _, sym := range scheduledExitSymbols(sc.ExitAt, s, now) {
+		price := prices[sym]
+		if price <= 0 {
+			logger.Warn("Scheduled exit: no price for %s — retrying next cycle", sym)
+			continue
+		}
+		signal := -1
+		if s.Positions[sym].Side == "short" {
+			signal = 1
+		}
+		unstage := stageTradeReason(s, "Scheduled exit")
+		exec, err := ExecuteSpotPaperSignalSizedDeferredOpen(s, signal, sym, price, 0, 1, logger)
+		unstage()
+		if err != nil {
+			logger.Error("Scheduled exit: close %s failed: %v", sym, err)
+			continue
+		}
+		if exec.TradesExecuted > 0 {
+			trades += exec.TradesExecuted
+			closed = append(closed, fmt.Sprintf("%s @ $%.2f", sym, price))
+			logger.Info("Scheduled exit (%s): closed %s @ $%.2f", formatExitSchedule(sc.ExitAt), sym, price)
+		}
+	}
+	if trades == 0 {
+		return 0, ""
+	}
+	return trades, fmt.Sprintf("[%s] ⏰ SCHEDULED EXIT (%s) %s", sc.ID, formatExitSchedule(sc.ExitAt), strings.Join(closed, ", "))
+}
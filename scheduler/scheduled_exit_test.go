@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduledExitSymbols(t *testing.T) {
+	// 2026-10-16 is a Friday.
+	slotDay := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	cfg := &ExitScheduleConfig{Weekday: "friday", Time: "20:00"}
+	s := &StrategyState{Positions: map[string]*Position{
+		"BTC/USDT": {Symbol: "BTC/USDT", Side: "long", Quantity: 0.1, OpenedAt: slotDay.Add(-48 * time.Hour)},
+		"ETH/USDT": {Symbol: "ETH/USDT", Side: "long", Quantity: 1, OpenedAt: slotDay.Add(21 * time.Hour)},
+	}}
+	if got := scheduledExitSymbols(cfg, s, slotDay.Add(19*time.Hour)); len(got) != 0 {
+		t.Fatalf("before the slot: %v", got)
+	}
+	if got := scheduledExitSymbols(cfg, s, slotDay.Add(-24*time.Hour+21*time.Hour)); len(got) != 0 {
+		t.Fatalf("wrong weekday: %v", got)
+	}
+	got := scheduledExitSymbols(cfg, s, slotDay.Add(22*time.Hour))
+	if len(got) != 1 || got[0] != "BTC/USDT" {
+		t.Fatalf("after the slot = %v, want only the position opened before it", got)
+	}
+	daily := &ExitScheduleConfig{Time: "20:00"}
+	if got := scheduledExitSymbols(daily, s, slotDay.Add(-24*time.Hour+21*time.Hour)); len(got) != 1 {
+		t.Fatalf("daily slot = %v", got)
+	}
+}
+
+func TestRunScheduledExit(t *testing.T) {
+	slot := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	sc := StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", ExitAt: &ExitScheduleConfig{Weekday: "friday", Time: "20:00"}}
+	s := NewStrategyState(StrategyConfig{ID: sc.ID, Type: "spot", Platform: "binanceus", Capital: 1000})
+	s.Cash = 0
+	s.Positions["BTC/USDT"] = &Position{Symbol: "BTC/USDT", Side: "long", Quantity: 0.01, AvgCost: 60000, OpenedAt: slot.Add(-time.Hour)}
+	var mu sync.RWMutex
+	n, detail := runScheduledExit(sc, s, map[string]float64{"BTC/USDT": 62000}, &mu, slot.Add(time.Minute), silentStrategyLogger(sc.ID))
+	if n != 1 || !strings.Contains(detail, "SCHEDULED EXIT (friday 20:00 UTC) BTC/USDT") {
+		t.Fatalf("n=%d detail=%q", n, detail)
+	}
+	if _, open := s.Positions["BTC/USDT"]; open {
+		t.Fatal("position still open after the scheduled exit")
+	}
+	last := s.TradeHistory[len(s.TradeHistory)-1]
+	if !strings.HasPrefix(last.Details, "Scheduled exit: ") || last.Tags[TradeTagReason] != "scheduled_exit" {
+		t.Errorf("trade not recorded as a scheduled exit: details=%q tags=%v", last.Details, last.Tags)
+	}
+	if s.pendingTradeReason != "" {
+		t.Error("trade reason left staged")
+	}
+}
+
+func TestValidateExitSchedule(t *testing.T) {
+	sc := StrategyConfig{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", ExitAt: &ExitScheduleConfig{Weekday: "fri", Time: "25:00"}}
+	if errs := validateExitSchedule(sc, "strategy[hl-btc]"); len(errs) != 3 {
+		t.Fatalf("errs = %v, want type, weekday and time errors", errs)
+	}
+	sc = StrategyConfig{ID: "okx-btc", Type: "spot", Platform: "okx", Args: []string{"sma", "BTC", "1h", "--mode=live"}, ExitAt: &ExitScheduleConfig{Time: "20:00"}}
+	if errs := validateExitSchedule(sc, "strategy[okx-btc]"); len(errs) != 1 || !strings.Contains(errs[0], "live okx") {
+		t.Fatalf("live spot errs = %v", errs)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+// script_env: per-strategy environment for check scripts (#4981).
+//
+// strategies[].env sets variables on the strategy's check-script subprocess
+// (exchange keys, proxy settings, feature flags), overriding the scheduler's
+// own. strategies[].env_passthrough, when non-empty, switches from
+// inheriting the scheduler's whole environment to an allowlist: only the
+// listed host variables (a trailing "*" matches a prefix) plus scriptBaseEnv
+// reach the script. The orphan-reaper marker (#4980) is always set. Values
+// are redacted from /go-trader-config and the dashboard strategy API. Only
+// the check script gets the recipe; order execution, close and fetch helpers
+// keep the scheduler environment.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// scriptBaseEnv is always passed through, even under an allowlist — the
+// interpreter and its libraries need them to start.
+var scriptBaseEnv = []string{"PATH", "HOME", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ScriptEnv is one subprocess's environment recipe. A nil *ScriptEnv inherits
+// the scheduler's environment.
+type ScriptEnv struct {
+	Vars        map[string]string // set on top of whatever is inherited
+	Passthrough []string          // empty = inherit everything; else an allowlist
+}
+
+// strategyScriptEnv returns sc's recipe, nil when it configures neither env
+// nor env_passthrough.
+func strategyScriptEnv(sc StrategyConfig) *ScriptEnv {
+	if len(sc.Env) == 0 && len(sc.EnvPassthrough) == 0 {
+		return nil
+	}
+	return &ScriptEnv{Vars: sc.Env, Passthrough: sc.EnvPassthrough}
+}
+
+// envAllowed reports whether name matches an allowlist entry.
+func envAllowed(name string, allow []string) bool {
+	for _, a := range allow {
+		if p, ok := strings.CutSuffix(a, "*"); ok {
+			if strings.HasPrefix(name, p) {
+				return true
+			}
+		} else if name == a {
+			return true
+		}
+	}
+	return false
+}
+
+// buildScriptEnvironment renders e into a cmd.Env slice. The owner marker
+// always wins.
+func buildScriptEnvironment(e *ScriptEnv) []string {
+	overlay := make(map[string]string)
+	if e != nil {
+		for k, v := range e.Vars {
+			overlay[k] = v
+		}
+	}
+	for k, v := range scriptOwnerEnv() {
+		overlay[k] = v
+	}
+	if e == nil || len(e.Passthrough) == 0 {
+		return processEnvironment(overlay)
+	}
+	allow := append(append([]string(nil), scriptBaseEnv...), e.Passthrough...)
+	var env []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, set := overlay[name]; set || !envAllowed(name, allow) {
+			continue
+		}
+		env = append(env, kv)
+	}
+	keys := make([]string, 0, len(overlay))
+	for k := range overlay {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+overlay[k])
+	}
+	return env
+}
+
+// validateStrategyScriptEnv checks sc's env and env_passthrough.
+func validateStrategyScriptEnv(prefix string, sc StrategyConfig) []string {
+	var errs []string
+	names := make([]string, 0, len(sc.Env))
+	for k := range sc.Env {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		switch {
+		case !envNameRe.MatchString(k):
+			errs = append(errs, fmt.Sprintf("%s: env key %q is not a valid variable name", prefix, k))
+		case k == orphanOwnerEnv:
+			errs = append(errs, fmt.Sprintf("%s: env may not set %s (reserved for the orphan reaper)", prefix, orphanOwnerEnv))
+		}
+	}
+	for _, p := range sc.EnvPassthrough {
+		if !envNameRe.MatchString(strings.TrimSuffix(p, "*")) {
+			errs = append(errs, fmt.Sprintf("%s: env_passthrough entry %q must be a variable name, optionally ending in *", prefix, p))
+		}
+	}
+	if (len(sc.Env) > 0 || len(sc.EnvPassthrough) > 0) && sc.Type == "manual" {
+		errs = append(errs, fmt.Sprintf("%s: env / env_passthrough are not supported for manual strategies (no check script)", prefix))
+	}
+	return errs
+}
+
+// redactStrategyEnv returns sc with every env value replaced by the
+// redaction placeholder, for API responses.
+func redactStrategyEnv(sc StrategyConfig) StrategyConfig {
+	if len(sc.Env) == 0 {
+		return sc
+	}
+	red := make(map[string]string, len(sc.Env))
+	for k := range sc.Env {
+		red[k] = configSecretReplacement
+	}
+	sc.Env = red
+	return sc
+}
+
+// redactStrategyEnvJSON blanks strategies[].env values in a raw config
+// document (redactConfigForDisplay). Names stay visible.
+func redactStrategyEnvJSON(root map[string]json.RawMessage) {
+	raw, ok := root["strategies"]
+	if !ok {
+		return
+	}
+	var strategies []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &strategies); err != nil {
+		return
+	}
+	changed := false
+	for _, st := range strategies {
+		var env map[string]json.RawMessage
+		if st == nil || json.Unmarshal(st["env"], &env) != nil || len(env) == 0 {
+			continue
+		}
+		placeholder, _ := json.Marshal(configSecretReplacement)
+		for k := range env {
+			env[k] = placeholder
+		}
+		if nb, err := json.Marshal(env); err == nil {
+			st["env"] = nb
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if nb, err := json.Marshal(strategies); err == nil {
+		root["strategies"] = nb
+	}
+}
+
+// formatScriptEnv renders an env/env_passthrough pair for reload change logs
+// without values.
+func formatScriptEnv(env map[string]string, passthrough []string) string {
+	names := make([]string, 0, len(env))
+	for k := range env {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	mode := "inherit"
+	if len(passthrough) > 0 {
+		mode = "allowlist[" + strings.Join(passthrough, ",") + "]"
+	}
+	return fmt.Sprintf("env[%s] %s", strings.Join(names, ","), mode)
+}
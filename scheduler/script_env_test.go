@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func envLookup(env []string, name string) (string, bool) {
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == name {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func TestBuildScriptEnvironment(t *testing.T) {
+	t.Setenv("GT_TEST_HOST", "host")
+	t.Setenv("GT_PROXY_HTTP", "http://proxy")
+	t.Setenv("GT_API_KEY", "scheduler-key")
+
+	// Inherit: host env plus overrides, marker always present.
+	env := buildScriptEnvironment(strategyScriptEnv(StrategyConfig{Env: map[string]string{"GT_API_KEY": "strategy-key"}}))
+	if v, _ := envLookup(env, "GT_TEST_HOST"); v != "host" {
+		t.Errorf("inherited GT_TEST_HOST = %q", v)
+	}
+	if v, _ := envLookup(env, "GT_API_KEY"); v != "strategy-key" {
+		t.Errorf("GT_API_KEY = %q, want override", v)
+	}
+	if v, _ := envLookup(env, orphanOwnerEnv); v != selfOwnerMarker {
+		t.Errorf("owner marker = %q", v)
+	}
+
+	// Allowlist: only listed / prefix-matched host vars and the baseline.
+	env = buildScriptEnvironment(&ScriptEnv{
+		Vars:        map[string]string{"FEATURE_X": "1"},
+		Passthrough: []string{"GT_PROXY_*"},
+	})
+	if _, ok := envLookup(env, "GT_TEST_HOST"); ok {
+		t.Error("GT_TEST_HOST leaked through allowlist")
+	}
+	if _, ok := envLookup(env, "GT_API_KEY"); ok {
+		t.Error("GT_API_KEY leaked through allowlist")
+	}
+	if v, _ := envLookup(env, "GT_PROXY_HTTP"); v != "http://proxy" {
+		t.Errorf("GT_PROXY_HTTP = %q", v)
+	}
+	if v, _ := envLookup(env, "FEATURE_X"); v != "1" {
+		t.Errorf("FEATURE_X = %q", v)
+	}
+	if _, ok := envLookup(env, orphanOwnerEnv); !ok {
+		t.Error("owner marker missing under allowlist")
+	}
+	if _, ok := envLookup(env, "PATH"); !ok {
+		t.Error("PATH missing under allowlist")
+	}
+
+	if strategyScriptEnv(StrategyConfig{}) != nil {
+		t.Error("strategy without env should inherit (nil)")
+	}
+}
+
+func TestValidateStrategyScriptEnv(t *testing.T) {
+	ok := StrategyConfig{Type: "spot", Env: map[string]string{"HTTPS_PROXY": "x"}, EnvPassthrough: []string{"AWS_*", "HOME"}}
+	if errs := validateStrategyScriptEnv("s", ok); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	bad := StrategyConfig{Type: "manual", Env: map[string]string{"1BAD": "x", orphanOwnerEnv: "y"}, EnvPassthrough: []string{"A*B"}}
+	errs := validateStrategyScriptEnv("s", bad)
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{`env key "1BAD"`, "may not set " + orphanOwnerEnv, `env_passthrough entry "A*B"`, "manual"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in:\n%s", want, joined)
+		}
+	}
+}
+
+func TestStrategyEnvRedaction(t *testing.T) {
+	raw := []byte(`{"strategies":[{"id":"a","env":{"API_KEY":"s3cret"}},{"id":"b"}]}`)
+	out, err := redactConfigForDisplay(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "s3cret") || !strings.Contains(out, "API_KEY") {
+		t.Errorf("config display not redacted:\n%s", out)
+	}
+
+	sc := StrategyConfig{ID: "a", Env: map[string]string{"API_KEY": "s3cret"}}
+	red := redactStrategyEnv(sc)
+	if red.Env["API_KEY"] != configSecretReplacement || sc.Env["API_KEY"] != "s3cret" {
+		t.Errorf("redactStrategyEnv: got %v, original %v", red.Env, sc.Env)
+	}
+
+	if got := formatScriptEnv(sc.Env, []string{"AWS_*"}); got != "env[API_KEY] allowlist[AWS_*]" {
+		t.Errorf("formatScriptEnv = %q", got)
+	}
+}
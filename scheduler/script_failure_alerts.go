@@ -1,9 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 )
@@ -17,6 +19,20 @@ import (
 // blip that clears on the next cycle.
 const scriptFailureAlertThreshold = 3
 
+// activeScriptFailureAlertThreshold is the threshold in force: the top-level
+// script_failure_alert_after (#4943) when set, else
+// scriptFailureAlertThreshold. Set at startup and on SIGHUP reload.
+var activeScriptFailureAlertThreshold = scriptFailureAlertThreshold
+
+// applyScriptFailureAlertThreshold adopts the configured threshold; n <= 0
+// restores the default.
+func applyScriptFailureAlertThreshold(n int) {
+	if n <= 0 {
+		n = scriptFailureAlertThreshold
+	}
+	activeScriptFailureAlertThreshold = n
+}
+
 // scriptFailureTransientAlertThreshold is the consecutive transient-only failure
 // count before operator alert (#1128). Higher than scriptFailureAlertThreshold
 // so brief 429 storms stay journald-only, while a sustained IP-level throttle
@@ -46,9 +62,14 @@ type scriptFailureMode string
 
 const (
 	// scriptFailureCrash is a hard crash: non-zero exit with no usable JSON
-	// (timeout, OOM, import/init crash, missing script). Surfaced as the
-	// run*Check "Script failed: %v" branch.
+	// (OOM, import/init crash, missing script). Surfaced as the run*Check
+	// "Script failed: %v" branch.
 	scriptFailureCrash scriptFailureMode = "crash"
+	// scriptFailureTimeout is a subprocess killed at its deadline (#4943).
+	scriptFailureTimeout scriptFailureMode = "timeout"
+	// scriptFailureParse is a clean exit whose stdout is not the expected
+	// JSON result (#4943).
+	scriptFailureParse scriptFailureMode = "parse"
 	// scriptFailureError is a soft error: the script emitted JSON with a
 	// non-empty result.Error. Surfaced as the run*Check
 	// "Script returned error: %s" branch.
@@ -57,12 +78,30 @@ const (
 
 // scriptFailureModeLabel renders a scriptFailureMode for operator messages.
 func scriptFailureModeLabel(mode scriptFailureMode) string {
-	if mode == scriptFailureCrash {
+	switch mode {
+	case scriptFailureCrash:
 		return "hard crash"
+	case scriptFailureTimeout:
+		return "timeout"
+	case scriptFailureParse:
+		return "unparseable output"
 	}
 	return "script error"
 }
 
+// scriptCrashMode classifies a Run*Check error (no usable result) as a
+// timeout, an output parse failure, or a hard crash.
+func scriptCrashMode(err error) scriptFailureMode {
+	var timeout *pythonScriptTimeoutError
+	switch {
+	case errors.As(err, &timeout):
+		return scriptFailureTimeout
+	case strings.HasPrefix(err.Error(), "parse output:"):
+		return scriptFailureParse
+	}
+	return scriptFailureCrash
+}
+
 // scriptFailureEntry is one slot in the in-memory per-strategy tracker.
 type scriptFailureEntry struct {
 	count          int
@@ -83,12 +122,12 @@ type ScriptFailureTracker struct {
 
 // Record increments the consecutive-failure count for strategyID and reports
 // whether this failure should fire an operator alert, along with the post-
-// increment count. Alerts fire when the streak first reaches
-// scriptFailureAlertThreshold, then re-throttle (every 10th failure or once an
+// increment count. Alerts fire when the streak first reaches the active
+// threshold (script_failure_alert_after, default 3), then re-throttle (every 10th failure or once an
 // hour) while the streak persists. A change in error signature after the
 // threshold re-alerts immediately so operators see a shifting failure mode.
 func (t *ScriptFailureTracker) Record(strategyID, errSig string, now time.Time) (bool, int) {
-	return recordScriptFailureAtThreshold(t, strategyID, errSig, now, scriptFailureAlertThreshold, 0)
+	return recordScriptFailureAtThreshold(t, strategyID, errSig, now, activeScriptFailureAlertThreshold, 0)
 }
 
 // Clear resets the failure streak for strategyID after a clean script run and
@@ -191,8 +230,8 @@ func recordScriptFailureAtThreshold(t *ScriptFailureTracker, strategyID, errSig
 }
 
 // notifyScriptFailure records a signal-script failure for sc and fires a
-// throttled operator alert once the consecutive-failure streak crosses
-// scriptFailureAlertThreshold. mode distinguishes a hard crash (no JSON) from a
+// throttled operator alert once the consecutive-failure streak crosses the
+// active threshold. mode distinguishes a hard crash (no JSON) from a
 // soft result.Error so the alert names the failure character. The failure is
 // always recorded — even with no notifier backends — so the count and recovery
 // state stay accurate; nil/empty notifier just suppresses the send.
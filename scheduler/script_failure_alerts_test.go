@@ -293,3 +293,43 @@ func TestScriptFailureTransientTracker_RecoveryResetsWallClock(t *testing.T) {
 		t.Fatalf("post-recovery streak must restart: notify=%v count=%d, want false/1", notify, count)
 	}
 }
+
+func TestScriptFailureTracker_ConfiguredThreshold(t *testing.T) {
+	applyScriptFailureAlertThreshold(5)
+	defer applyScriptFailureAlertThreshold(0)
+	tr := &ScriptFailureTracker{}
+	now := time.Unix(1700000000, 0).UTC()
+	for i := 1; i < 5; i++ {
+		if notify, _ := tr.Record("hl-x", "boom", now); notify {
+			t.Fatalf("failure %d: expected no alert below configured threshold 5", i)
+		}
+	}
+	if notify, count := tr.Record("hl-x", "boom", now); !notify || count != 5 {
+		t.Fatalf("5th failure: notify=%v count=%d, want alert at 5", notify, count)
+	}
+}
+
+func TestApplyScriptFailureAlertThreshold_NonPositiveResetsDefault(t *testing.T) {
+	applyScriptFailureAlertThreshold(7)
+	applyScriptFailureAlertThreshold(0)
+	if activeScriptFailureAlertThreshold != scriptFailureAlertThreshold {
+		t.Fatalf("threshold = %d, want default %d", activeScriptFailureAlertThreshold, scriptFailureAlertThreshold)
+	}
+}
+
+func TestScriptCrashMode(t *testing.T) {
+	cases := []struct {
+		err  error
+		want scriptFailureMode
+	}{
+		{&pythonScriptTimeoutError{d: 30 * time.Second}, scriptFailureTimeout},
+		{fmt.Errorf("run: %w", &pythonScriptTimeoutError{d: time.Minute}), scriptFailureTimeout},
+		{fmt.Errorf("parse output: invalid character 'T'"), scriptFailureParse},
+		{fmt.Errorf("exit status 1"), scriptFailureCrash},
+	}
+	for _, c := range cases {
+		if got := scriptCrashMode(c.err); got != c.want {
+			t.Errorf("scriptCrashMode(%q) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
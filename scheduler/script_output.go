@@ -0,0 +1,117 @@
+package main
+
+// script_output: bounded capture of Python subprocess output (#4979).
+//
+// A runaway script printing megabytes would otherwise be buffered whole and
+// then pasted into error strings, logs and DMs. Subprocess stdout and stderr
+// go through cappedOutput, which holds at most script_output_max_bytes per
+// stream (default 1 MiB): stdout keeps its head (the JSON result comes
+// first), stderr keeps its tail (the traceback comes last), and the dropped
+// byte count is marked in place. Error messages that quote output go through
+// outputSnippet, which is bounded far tighter regardless of the cap.
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultScriptOutputMaxBytes = 1 << 20
+	minScriptOutputMaxBytes     = 4 << 10
+	maxScriptOutputMaxBytes     = 64 << 20
+	// scriptErrorSnippetBytes bounds the output quoted in one error message.
+	scriptErrorSnippetBytes = 1024
+)
+
+// activeScriptOutputMaxBytes is the per-stream capture cap. Set at startup
+// and on SIGHUP reload.
+var activeScriptOutputMaxBytes = defaultScriptOutputMaxBytes
+
+// applyScriptOutputMaxBytes adopts the configured cap; n <= 0 restores the
+// default.
+func applyScriptOutputMaxBytes(n int) {
+	if n <= 0 {
+		n = defaultScriptOutputMaxBytes
+	}
+	activeScriptOutputMaxBytes = n
+}
+
+// validateScriptOutputMaxBytes checks script_output_max_bytes.
+func validateScriptOutputMaxBytes(n int) []string {
+	if n == 0 || (n >= minScriptOutputMaxBytes && n <= maxScriptOutputMaxBytes) {
+		return nil
+	}
+	return []string{fmt.Sprintf("script_output_max_bytes must be 0 (default %d) or in [%d, %d], got %d",
+		defaultScriptOutputMaxBytes, minScriptOutputMaxBytes, maxScriptOutputMaxBytes, n)}
+}
+
+// cappedOutput is an io.Writer that keeps at most max bytes: the first max
+// (head) or the last max (keepTail). Writes never fail, so the child never
+// sees a broken pipe.
+type cappedOutput struct {
+	max      int
+	keepTail bool
+	buf      []byte
+	dropped  int64
+}
+
+func newCappedOutput(max int, keepTail bool) *cappedOutput {
+	return &cappedOutput{max: max, keepTail: keepTail}
+}
+
+func (c *cappedOutput) Write(p []byte) (int, error) {
+	if !c.keepTail {
+		room := c.max - len(c.buf)
+		if room > len(p) {
+			room = len(p)
+		}
+		if room > 0 {
+			c.buf = append(c.buf, p[:room]...)
+		}
+		c.dropped += int64(len(p) - room)
+		return len(p), nil
+	}
+	c.buf = append(c.buf, p...)
+	// Compact lazily so a chatty stream costs amortized O(1) per byte while
+	// memory stays under 2×max.
+	if len(c.buf) > 2*c.max {
+		c.trimTail()
+	}
+	return len(p), nil
+}
+
+func (c *cappedOutput) trimTail() {
+	if over := len(c.buf) - c.max; over > 0 {
+		c.dropped += int64(over)
+		c.buf = append(c.buf[:0], c.buf[over:]...)
+	}
+}
+
+// Bytes returns the captured output with a truncation marker where bytes
+// were dropped.
+func (c *cappedOutput) Bytes() []byte {
+	if c.keepTail {
+		c.trimTail()
+	}
+	if c.dropped == 0 {
+		return c.buf
+	}
+	marker := fmt.Sprintf("[... %d bytes truncated ...]", c.dropped)
+	if c.keepTail {
+		return append([]byte(marker+"\n"), c.buf...)
+	}
+	return append(append([]byte(nil), c.buf...), "\n"+marker...)
+}
+
+// outputSnippet bounds script output quoted in an error message: trimmed,
+// and when longer than scriptErrorSnippetBytes, its head and tail around an
+// omission marker.
+func outputSnippet(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= scriptErrorSnippetBytes {
+		return s
+	}
+	half := scriptErrorSnippetBytes / 2
+	omitted := len(s) - 2*half
+	return strings.ToValidUTF8(s[:half], "") + fmt.Sprintf(" [... %d bytes omitted ...] ", omitted) + strings.ToValidUTF8(s[len(s)-half:], "")
+}
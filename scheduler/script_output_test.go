@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCappedOutput(t *testing.T) {
+	head := newCappedOutput(10, false)
+	head.Write([]byte(`{"signal":`))
+	head.Write([]byte(strings.Repeat("x", 100)))
+	if got := string(head.Bytes()); got != `{"signal":`+"\n[... 100 bytes truncated ...]" {
+		t.Errorf("head = %q", got)
+	}
+
+	tail := newCappedOutput(10, true)
+	for i := 0; i < 50; i++ {
+		tail.Write([]byte("noise\n"))
+	}
+	tail.Write([]byte("Traceback!"))
+	if got := string(tail.Bytes()); got != "[... 300 bytes truncated ...]\nTraceback!" {
+		t.Errorf("tail = %q", got)
+	}
+	if len(tail.buf) > 20 {
+		t.Errorf("tail buffer grew to %d", len(tail.buf))
+	}
+
+	small := newCappedOutput(10, true)
+	small.Write([]byte("ok"))
+	if got := string(small.Bytes()); got != "ok" {
+		t.Errorf("untruncated = %q", got)
+	}
+}
+
+func TestOutputSnippet(t *testing.T) {
+	if got := outputSnippet("  short \n"); got != "short" {
+		t.Errorf("short = %q", got)
+	}
+	long := "A" + strings.Repeat("x", 5000) + "Z"
+	got := outputSnippet(long)
+	if len(got) > scriptErrorSnippetBytes+64 || !strings.HasPrefix(got, "A") || !strings.HasSuffix(got, "Z") || !strings.Contains(got, "bytes omitted") {
+		t.Errorf("long snippet (%d bytes) = %.80q…", len(got), got)
+	}
+
+	if errs := validateScriptOutputMaxBytes(100); len(errs) != 1 {
+		t.Errorf("tiny cap accepted: %v", errs)
+	}
+	if errs := validateScriptOutputMaxBytes(0); len(errs) != 0 {
+		t.Errorf("default rejected: %v", errs)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+// sd_notify: systemd readiness and watchdog integration (#4983).
+//
+// Under a Type=notify unit systemd sets NOTIFY_SOCKET, and with WatchdogSec=
+// also WATCHDOG_USEC (and WATCHDOG_PID). The daemon sends READY=1 once
+// startup is done and the main loop is about to run, STOPPING=1 when it
+// starts draining, and a STATUS= line per cycle. sdWatchdog keeps systemd's
+// watchdog fed: every successfully completed cycle pings at once, and a
+// background ticker pings every half period while the loop is idle between
+// cycles or the current cycle is younger than the watchdog period. A cycle
+// stuck longer than that stops the pings, and systemd restarts the service
+// one period later. Outside systemd every call is a no-op.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	sdNotifySocketEnv = "NOTIFY_SOCKET"
+	sdWatchdogUsecEnv = "WATCHDOG_USEC"
+	sdWatchdogPIDEnv  = "WATCHDOG_PID"
+)
+
+// sdNotify sends one notification datagram to $NOTIFY_SOCKET. Package var so
+// tests can capture messages; a nil error without the variable set.
+var sdNotify = func(state string) error {
+	path := os.Getenv(sdNotifySocketEnv)
+	if path == "" {
+		return nil
+	}
+	// A leading '@' names a Linux abstract-namespace socket.
+	if path[0] == '@' {
+		path = "\x00" + path[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// sdNotifyLogged sends state and logs, rather than returns, a failure —
+// notifications are best-effort and never stop the daemon.
+func sdNotifyLogged(state string) {
+	if err := sdNotify(state); err != nil {
+		fmt.Printf("[WARN] systemd notify %q failed: %v\n", state, err)
+	}
+}
+
+// sdWatchdogPeriod returns the watchdog period systemd asked this process to
+// honour, 0 when the watchdog is off or meant for another PID.
+func sdWatchdogPeriod(getenv func(string) string, pid int) time.Duration {
+	usec, err := strconv.ParseInt(getenv(sdWatchdogUsecEnv), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if p := getenv(sdWatchdogPIDEnv); p != "" && p != strconv.Itoa(pid) {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// sdWatchdog tracks main-loop liveness. All methods are no-ops on nil.
+type sdWatchdog struct {
+	period time.Duration
+
+	mu         sync.Mutex
+	cycleStart time.Time // zero while idle between cycles
+	withheld   bool      // a ping was skipped for the current cycle (logged once)
+}
+
+// newSDWatchdog returns a watchdog for the current environment, nil when
+// systemd did not enable one.
+func newSDWatchdog() *sdWatchdog {
+	period := sdWatchdogPeriod(os.Getenv, os.Getpid())
+	if period <= 0 {
+		return nil
+	}
+	fmt.Printf("[systemd] watchdog enabled (period %s)\n", period)
+	return &sdWatchdog{period: period}
+}
+
+// CycleStarted marks the loop busy from now.
+func (w *sdWatchdog) CycleStarted(now time.Time) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.cycleStart = now
+	w.withheld = false
+	w.mu.Unlock()
+}
+
+// CycleFinished pings the watchdog straight away when the cycle succeeded
+// (failure == ""). The loop stays busy until Idle, so a hang in the
+// post-cycle reporting is still caught.
+func (w *sdWatchdog) CycleFinished(failure string) {
+	if w == nil || failure != "" {
+		return
+	}
+	sdNotifyLogged("WATCHDOG=1")
+}
+
+// Idle marks the loop waiting for the next due strategy.
+func (w *sdWatchdog) Idle() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.cycleStart = time.Time{}
+	w.mu.Unlock()
+}
+
+// alive reports whether a ping is deserved at now: the loop is idle, or the
+// current cycle has run for less than one watchdog period.
+func (w *sdWatchdog) alive(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cycleStart.IsZero() || now.Sub(w.cycleStart) < w.period {
+		return true
+	}
+	if !w.withheld {
+		w.withheld = true
+		fmt.Printf("[systemd] cycle running for %s (watchdog %s); withholding watchdog pings\n", now.Sub(w.cycleStart).Round(time.Second), w.period)
+	}
+	return false
+}
+
+// Run pings every half period while the loop is alive, until ctx ends.
+func (w *sdWatchdog) Run(ctx context.Context) {
+	if w == nil {
+		return
+	}
+	t := time.NewTicker(w.period / 2)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			if w.alive(now) {
+				sdNotifyLogged("WATCHDOG=1")
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSDNotifySendsDatagram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram unsupported: %v", err)
+	}
+	defer conn.Close()
+	t.Setenv(sdNotifySocketEnv, path)
+
+	if err := sdNotify("READY=1"); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "READY=1" {
+		t.Errorf("datagram = %q", got)
+	}
+
+	t.Setenv(sdNotifySocketEnv, "")
+	if err := sdNotify("READY=1"); err != nil {
+		t.Errorf("without NOTIFY_SOCKET: %v", err)
+	}
+}
+
+func TestSDWatchdogPeriod(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(k string) string { return env[k] }
+	if got := sdWatchdogPeriod(getenv, 42); got != 0 {
+		t.Errorf("unset = %v", got)
+	}
+	env[sdWatchdogUsecEnv] = "30000000"
+	if got := sdWatchdogPeriod(getenv, 42); got != 30*time.Second {
+		t.Errorf("period = %v", got)
+	}
+	env[sdWatchdogPIDEnv] = "42"
+	if got := sdWatchdogPeriod(getenv, 42); got != 30*time.Second {
+		t.Errorf("own pid = %v", got)
+	}
+	env[sdWatchdogPIDEnv] = "7"
+	if got := sdWatchdogPeriod(getenv, 42); got != 0 {
+		t.Errorf("other pid = %v", got)
+	}
+}
+
+func TestSDWatchdogLiveness(t *testing.T) {
+	var sent []string
+	old := sdNotify
+	sdNotify = func(s string) error { sent = append(sent, s); return nil }
+	defer func() { sdNotify = old }()
+
+	w := &sdWatchdog{period: time.Minute}
+	now := time.Now()
+	if !w.alive(now) {
+		t.Error("idle loop should be alive")
+	}
+	w.CycleStarted(now)
+	if !w.alive(now.Add(30 * time.Second)) {
+		t.Error("young cycle should be alive")
+	}
+	if w.alive(now.Add(2 * time.Minute)) {
+		t.Error("cycle older than the period should withhold pings")
+	}
+	w.CycleFinished("state save failed")
+	if len(sent) != 0 {
+		t.Errorf("failed cycle pinged: %v", sent)
+	}
+	w.CycleFinished("")
+	if len(sent) != 1 || sent[0] != "WATCHDOG=1" {
+		t.Errorf("successful cycle sent %v", sent)
+	}
+	w.Idle()
+	if !w.alive(now.Add(time.Hour)) {
+		t.Error("idle loop should be alive regardless of age")
+	}
+
+	var nilW *sdWatchdog
+	nilW.CycleStarted(now)
+	nilW.CycleFinished("")
+	nilW.Idle()
+	if len(sent) != 1 {
+		t.Errorf("nil watchdog sent %v", sent)
+	}
+}
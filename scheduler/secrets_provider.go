@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// secretsFetchTimeout bounds the startup secrets fetch. A provider that cannot
+// answer in this window fails startup rather than hanging the daemon.
+const secretsFetchTimeout = 20 * time.Second
+
+// secretsProvider fetches a flat map of env-var name → secret value from an
+// external store. Implementations must never log values.
+type secretsProvider interface {
+	Name() string
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// secretsProviders maps GO_TRADER_SECRETS_PROVIDER values to constructors.
+// Constructors read their own bootstrap env vars and fail on missing ones.
+var secretsProviders = map[string]func() (secretsProvider, error){
+	"vault": newVaultSecretsProvider,
+	"aws":   newAWSSecretsProvider,
+}
+
+// secretEnvNameRe matches names the provider may export. Values are applied
+// with os.Setenv so both Go and the Python check/execute subprocesses (which
+// inherit the environment) see them.
+var secretEnvNameRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// secretEnvReservedPrefixes are never overwritten from a secret store: process
+// plumbing (PATH, loader, interpreter) and the provider's own bootstrap vars.
+var secretEnvReservedPrefixes = []string{"PATH", "HOME", "USER", "SHELL", "LD_", "DYLD_", "PYTHON", "VIRTUAL_ENV", "GO_TRADER_SECRETS_", "VAULT_", "AWS_"}
+
+// loadSecretsFromProvider runs before LoadConfig on daemon startup. With
+// GO_TRADER_SECRETS_PROVIDER unset it is a no-op and raw env vars remain the
+// only source. Otherwise every fetched key is exported unless the same env
+// var is already set non-empty — an explicit env var on the box wins, so an
+// operator can override one key without editing the store.
+func loadSecretsFromProvider() error {
+	name, secrets, err := fetchProviderSecrets()
+	if err != nil || name == "" {
+		return err
+	}
+	applied, skipped, err := applySecretsToEnv(secrets)
+	if err != nil {
+		return fmt.Errorf("%s secrets provider: %w", name, err)
+	}
+	for _, k := range applied {
+		secretsProviderOwned[k] = true
+	}
+	fmt.Printf("[secrets] Loaded %d secret(s) from %s: %s\n", len(applied), name, strings.Join(applied, ", "))
+	if len(skipped) > 0 {
+		fmt.Printf("[secrets] Kept existing env for %s (env var set on the box overrides %s)\n", strings.Join(skipped, ", "), name)
+	}
+	return nil
+}
+
+// secretsProviderOwned records which env vars the provider exported at
+// startup. Credential rotation (credential_rotation.go) may overwrite these;
+// keys the box set explicitly keep precedence there too. Written only from
+// the main goroutine (startup, then rotation).
+var secretsProviderOwned = map[string]bool{}
+
+// fetchProviderSecrets resolves GO_TRADER_SECRETS_PROVIDER and fetches its
+// secrets. Returns an empty name (and no error) when no provider is set.
+func fetchProviderSecrets() (string, map[string]string, error) {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("GO_TRADER_SECRETS_PROVIDER")))
+	if name == "" {
+		return "", nil, nil
+	}
+	ctor, ok := secretsProviders[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown GO_TRADER_SECRETS_PROVIDER %q (supported: %s)", name, strings.Join(secretsProviderNames(), ", "))
+	}
+	p, err := ctor()
+	if err != nil {
+		return "", nil, fmt.Errorf("%s secrets provider: %w", name, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secretsFetchTimeout)
+	defer cancel()
+	secrets, err := p.Fetch(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s secrets provider: %w", p.Name(), err)
+	}
+	return p.Name(), secrets, nil
+}
+
+// applySecretsToEnv exports secrets, returning the applied and skipped
+// (already set) names sorted. Invalid or reserved names are an error so a
+// typo in the store surfaces at startup instead of as a missing credential.
+func applySecretsToEnv(secrets map[string]string) (applied, skipped []string, err error) {
+	names, err := validateSecretEnvNames(secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, k := range names {
+		if strings.TrimSpace(os.Getenv(k)) != "" {
+			skipped = append(skipped, k)
+			continue
+		}
+		if err := os.Setenv(k, secrets[k]); err != nil {
+			return nil, nil, fmt.Errorf("set %s: %w", k, err)
+		}
+		applied = append(applied, k)
+	}
+	return applied, skipped, nil
+}
+
+// validateSecretEnvNames returns the keys sorted, or an error naming the
+// first key that is not a valid, non-reserved env var name.
+func validateSecretEnvNames(secrets map[string]string) ([]string, error) {
+	names := make([]string, 0, len(secrets))
+	for k := range secrets {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		if !secretEnvNameRe.MatchString(k) {
+			return nil, fmt.Errorf("secret key %q is not a valid env var name (want UPPER_SNAKE_CASE)", k)
+		}
+		for _, prefix := range secretEnvReservedPrefixes {
+			if strings.HasPrefix(k, prefix) {
+				return nil, fmt.Errorf("secret key %q is reserved and cannot be set from a secrets provider", k)
+			}
+		}
+	}
+	return names, nil
+}
+
+func secretsProviderNames() []string {
+	names := make([]string, 0, len(secretsProviders))
+	for k := range secretsProviders {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ---------------------------------------------------------------------------
+// HashiCorp Vault (KV v1/v2 over the HTTP API)
+// ---------------------------------------------------------------------------
+
+type vaultSecretsProvider struct {
+	addr      string
+	token     string
+	namespace string
+	path      string
+	client    *http.Client
+}
+
+// newVaultSecretsProvider reads VAULT_ADDR / VAULT_TOKEN (the Vault CLI's own
+// names), optional VAULT_NAMESPACE, and GO_TRADER_VAULT_SECRET_PATH — the API
+// path below /v1/, e.g. "secret/data/go-trader" for a KV v2 mount.
+func newVaultSecretsProvider() (secretsProvider, error) {
+	p := &vaultSecretsProvider{
+		addr:      strings.TrimRight(strings.TrimSpace(os.Getenv("VAULT_ADDR")), "/"),
+		token:     strings.TrimSpace(os.Getenv("VAULT_TOKEN")),
+		namespace: strings.TrimSpace(os.Getenv("VAULT_NAMESPACE")),
+		path:      strings.Trim(strings.TrimSpace(os.Getenv("GO_TRADER_VAULT_SECRET_PATH")), "/"),
+		client:    &http.Client{Timeout: secretsFetchTimeout},
+	}
+	var missing []string
+	if p.addr == "" {
+		missing = append(missing, "VAULT_ADDR")
+	}
+	if p.token == "" {
+		missing = append(missing, "VAULT_TOKEN")
+	}
+	if p.path == "" {
+		missing = append(missing, "GO_TRADER_VAULT_SECRET_PATH")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	return p, nil
+}
+
+func (p *vaultSecretsProvider) Name() string { return "vault" }
+
+func (p *vaultSecretsProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p.path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p.path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Vault error bodies are {"errors": [...]} and carry no secret material.
+		return nil, fmt.Errorf("read %s: HTTP %d: %s", p.path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var envelope struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", p.path, err)
+	}
+	// KV v2 nests the secret under data.data alongside data.metadata; KV v1
+	// returns it directly under data.
+	fields := envelope.Data
+	if inner, ok := envelope.Data["data"]; ok {
+		if _, hasMeta := envelope.Data["metadata"]; hasMeta {
+			fields = nil
+			if err := json.Unmarshal(inner, &fields); err != nil {
+				return nil, fmt.Errorf("parse %s: data.data: %w", p.path, err)
+			}
+		}
+	}
+	return secretStringFields(fields, p.path)
+}
+
+// ---------------------------------------------------------------------------
+// AWS Secrets Manager (via the aws CLI)
+// ---------------------------------------------------------------------------
+
+// awsSecretsCommand runs the AWS CLI. Shelling out keeps the binary free of
+// the AWS SDK and inherits the CLI's full credential chain (instance role,
+// SSO, profiles). Swapped in tests.
+var awsSecretsCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "aws", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+type awsSecretsProvider struct {
+	secretID string
+}
+
+// newAWSSecretsProvider reads GO_TRADER_AWS_SECRET_ID (name or ARN). Region
+// and credentials come from the standard AWS_* env / profile chain.
+func newAWSSecretsProvider() (secretsProvider, error) {
+	id := strings.TrimSpace(os.Getenv("GO_TRADER_AWS_SECRET_ID"))
+	if id == "" {
+		return nil, fmt.Errorf("missing GO_TRADER_AWS_SECRET_ID")
+	}
+	return &awsSecretsProvider{secretID: id}, nil
+}
+
+func (p *awsSecretsProvider) Name() string { return "aws" }
+
+func (p *awsSecretsProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	out, err := awsSecretsCommand(ctx, "secretsmanager", "get-secret-value",
+		"--secret-id", p.secretID, "--query", "SecretString", "--output", "text")
+	if err != nil {
+		return nil, fmt.Errorf("get-secret-value %s: %w", p.secretID, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		// Never echo the payload — it is the secret.
+		return nil, fmt.Errorf("secret %s: SecretString must be a JSON object of ENV_VAR_NAME → value", p.secretID)
+	}
+	return secretStringFields(fields, p.secretID)
+}
+
+// secretStringFields converts a JSON object to string values. Non-string
+// values are rejected rather than stringified so a nested object put in the
+// wrong place fails loudly.
+func secretStringFields(fields map[string]json.RawMessage, source string) (map[string]string, error) {
+	out := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("%s: key %q must be a string", source, k)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
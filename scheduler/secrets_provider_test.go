@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadSecretsFromProviderDisabled(t *testing.T) {
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "")
+	if err := loadSecretsFromProvider(); err != nil {
+		t.Fatalf("unset provider should be a no-op, got %v", err)
+	}
+}
+
+func TestLoadSecretsFromProviderUnknown(t *testing.T) {
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "keychain")
+	err := loadSecretsFromProvider()
+	if err == nil || !strings.Contains(err.Error(), "aws, vault") {
+		t.Fatalf("err = %v, want supported list", err)
+	}
+}
+
+func TestVaultSecretsProviderKVv2(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/go-trader" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != "root-token" || r.Header.Get("X-Vault-Namespace") != "trading" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"DISCORD_BOT_TOKEN":"disc-from-vault","HYPERLIQUID_SECRET_KEY":"0xabc"},"metadata":{"version":3}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "vault")
+	t.Setenv("VAULT_ADDR", srv.URL+"/")
+	t.Setenv("VAULT_TOKEN", "root-token")
+	t.Setenv("VAULT_NAMESPACE", "trading")
+	t.Setenv("GO_TRADER_VAULT_SECRET_PATH", "/secret/data/go-trader")
+	t.Setenv("DISCORD_BOT_TOKEN", "explicit-on-box")
+	t.Setenv("HYPERLIQUID_SECRET_KEY", "")
+
+	if err := loadSecretsFromProvider(); err != nil {
+		t.Fatalf("loadSecretsFromProvider: %v", err)
+	}
+	if got := os.Getenv("HYPERLIQUID_SECRET_KEY"); got != "0xabc" {
+		t.Errorf("HYPERLIQUID_SECRET_KEY = %q, want value from vault", got)
+	}
+	if got := os.Getenv("DISCORD_BOT_TOKEN"); got != "explicit-on-box" {
+		t.Errorf("DISCORD_BOT_TOKEN = %q, explicit env var must win", got)
+	}
+}
+
+func TestVaultSecretsProviderKVv1AndErrors(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"data":{"OKX_API_KEY":"k1"}}`))
+		} else {
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+		}
+	}))
+	defer srv.Close()
+	p := &vaultSecretsProvider{addr: srv.URL, token: "t", path: "kv/go-trader", client: srv.Client()}
+
+	got, err := p.Fetch(context.Background())
+	if err != nil || got["OKX_API_KEY"] != "k1" {
+		t.Fatalf("Fetch = %v, %v; want KV v1 fields", got, err)
+	}
+	status = http.StatusForbidden
+	if _, err := p.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Fatalf("err = %v, want HTTP 403", err)
+	}
+}
+
+func TestVaultSecretsProviderMissingBootstrap(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("GO_TRADER_VAULT_SECRET_PATH", "secret/data/x")
+	_, err := newVaultSecretsProvider()
+	if err == nil || !strings.Contains(err.Error(), "VAULT_ADDR, VAULT_TOKEN") {
+		t.Fatalf("err = %v, want missing VAULT_ADDR, VAULT_TOKEN", err)
+	}
+}
+
+func TestAWSSecretsProvider(t *testing.T) {
+	orig := awsSecretsCommand
+	defer func() { awsSecretsCommand = orig }()
+	var gotArgs []string
+	awsSecretsCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{"TOPSTEP_API_KEY":"tk","TOPSTEP_API_SECRET":"ts"}` + "\n"), nil
+	}
+	t.Setenv("GO_TRADER_SECRETS_PROVIDER", "aws")
+	t.Setenv("GO_TRADER_AWS_SECRET_ID", "prod/go-trader")
+	t.Setenv("TOPSTEP_API_KEY", "")
+	t.Setenv("TOPSTEP_API_SECRET", "")
+
+	if err := loadSecretsFromProvider(); err != nil {
+		t.Fatalf("loadSecretsFromProvider: %v", err)
+	}
+	if os.Getenv("TOPSTEP_API_KEY") != "tk" || os.Getenv("TOPSTEP_API_SECRET") != "ts" {
+		t.Errorf("TopStep keys not exported from aws secret")
+	}
+	if strings.Join(gotArgs, " ") != "secretsmanager get-secret-value --secret-id prod/go-trader --query SecretString --output text" {
+		t.Errorf("aws args = %v", gotArgs)
+	}
+
+	awsSecretsCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("plain-string-secret"), nil
+	}
+	err := loadSecretsFromProvider()
+	if err == nil || strings.Contains(err.Error(), "plain-string-secret") {
+		t.Fatalf("err = %v, want JSON-object error that does not echo the payload", err)
+	}
+
+	awsSecretsCommand = func(ctx context.Context, args ...string) ([]byte, error) {
+		return nil, errors.New("exit status 255: AccessDeniedException")
+	}
+	if err := loadSecretsFromProvider(); err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("err = %v, want CLI failure surfaced", err)
+	}
+}
+
+func TestApplySecretsToEnvRejectsReservedAndInvalidNames(t *testing.T) {
+	for _, key := range []string{"PATH", "LD_PRELOAD", "VAULT_TOKEN", "discord_bot_token", "1KEY"} {
+		if _, _, err := applySecretsToEnv(map[string]string{key: "x"}); err == nil {
+			t.Errorf("key %q: expected rejection", key)
+		}
+	}
+}
@@ -16,7 +16,8 @@ import (
 type StatusServer struct {
 	state          *AppState
 	mu             *sync.RWMutex
-	statusToken    string   // if non-empty, /status requires Authorization: Bearer <token>
+	statusToken    string // if non-empty, /status requires Authorization: Bearer <token>; read via currentStatusToken
+	tokenMu        sync.RWMutex
 	priceSymbols   []string // BinanceUS spot symbols to always fetch prices for
 	futuresSymbols []string // CME futures contracts that need TopStep marks (#261)
 	hlPerpsCoins   []string // HL perps coins that need venue-native marks (#263)
@@ -26,6 +27,10 @@ type StatusServer struct {
 	candleCache    *UICandleCache
 	tuning         *tuningRunManager // #1339 persistent dedicated research lane
 
+	// snapshot is the last published copy-on-read AppState (#4919); readers
+	// go through readState instead of taking mu.
+	snapshot stateSnapshotHolder
+
 	// strategiesMu protects `strategies` independently of `mu`. SIGHUP holds
 	// the global state `mu.Lock()` across the reload (see config_reload.go);
 	// UpdateStrategies is invoked from that path, so reusing `mu` here would
@@ -42,6 +47,7 @@ type StatusServer struct {
 	// (SetConfigContext runs from the reload path which already holds mu).
 	intervalSeconds   int              // global check interval for leaderboard entries
 	userCloseDefaults CloseDefaultsMap // user_defaults.close for /api/closing-strategies override marking
+	cors              *CORSConfig      // #4932 cross-origin policy applied by corsHandler (cors.go)
 
 	// #1256 mutation surface. globalNotifyRatchet mirrors the top-level
 	// notify_ratchet_triggers (#1110) for GET /api/config/notifications
@@ -69,6 +75,12 @@ type StatusServer struct {
 	// trigger fired when a confirmed structural write asked for
 	// apply-via-restart (nil → restartSelf; injectable for tests).
 	restartFn func() error
+	// rotateCreds signals the main loop to re-read credentials
+	// (requestCredentialRotation in production; injectable for tests).
+	rotateCreds func() error
+	// runNow marks a strategy due on the next loop iteration (#4941 Discord
+	// /run); wired to strategyScheduler.RunNow via SetRunNow, nil until then.
+	runNow func(id string) bool
 
 	// Throttled logging for repeated mark-fetch failures on the /status
 	// rail. /status can be polled frequently (oncall dashboard, monitoring),
@@ -113,7 +125,59 @@ func NewStatusServer(state *AppState, mu *sync.RWMutex, statusToken string, stra
 		candleFetcher:  FetchUICandles,
 		candleCache:    NewUICandleCache(30 * time.Second),
 		reloadConfig:   requestSIGHUPReload,
+		rotateCreds:    requestCredentialRotation,
+	}
+}
+
+// currentStatusToken returns the bearer token in force. It can change at
+// runtime via credential rotation (SetStatusToken), so handlers never read
+// the field directly.
+func (ss *StatusServer) currentStatusToken() string {
+	ss.tokenMu.RLock()
+	defer ss.tokenMu.RUnlock()
+	return ss.statusToken
+}
+
+// SetStatusToken swaps the bearer token after a STATUS_AUTH_TOKEN rotation.
+func (ss *StatusServer) SetStatusToken(token string) {
+	ss.tokenMu.Lock()
+	defer ss.tokenMu.Unlock()
+	ss.statusToken = token
+}
+
+// SetRunNow wires the scheduler's off-schedule trigger (#4941).
+func (ss *StatusServer) SetRunNow(fn func(id string) bool) {
+	if ss == nil {
+		return
 	}
+	ss.strategiesMu.Lock()
+	defer ss.strategiesMu.Unlock()
+	ss.runNow = fn
+}
+
+// requestRunNow schedules strategy id for the next tick. Errors name an
+// unknown strategy or a daemon without a scheduler (--once, startup).
+func (ss *StatusServer) requestRunNow(id string) error {
+	ss.strategiesMu.RLock()
+	fn := ss.runNow
+	known := false
+	for _, sc := range ss.strategies {
+		if sc.ID == id {
+			known = true
+			break
+		}
+	}
+	ss.strategiesMu.RUnlock()
+	if !known {
+		return fmt.Errorf("unknown strategy %q", id)
+	}
+	if fn == nil {
+		return fmt.Errorf("scheduler not running")
+	}
+	if !fn(id) {
+		return fmt.Errorf("strategy %q is not scheduled (non-positive interval)", id)
+	}
+	return nil
 }
 
 // UpdateStrategies refreshes config-derived status metadata after a hot reload.
@@ -225,7 +289,13 @@ func (ss *StatusServer) Start(port int) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/status", ss.handleStatus)
 	mux.HandleFunc("/health", ss.handleHealth)
+	mux.HandleFunc("/metrics", ss.handleMetrics)
 	mux.HandleFunc("/history", ss.handleHistory)
+	mux.HandleFunc("/prices/history", ss.handlePriceHistory)
+	mux.HandleFunc("/strategies/", ss.handleStrategyDetail) // #4930 (ui_strategy_detail.go)
+	mux.HandleFunc("/risk", ss.handleRisk)                  // #4931 (ui_risk.go)
+	mux.HandleFunc("/openapi.json", ss.handleOpenAPI)       // #4934 (openapi.go)
+	mux.HandleFunc("/signals", ss.handleSignals)            // #4954 (signal_history.go)
 	mux.HandleFunc("/dashboard", ss.handleDashboard)
 	mux.HandleFunc("/dashboard/", ss.handleDashboard)
 	mux.HandleFunc("/tuning", ss.handleTuning)
@@ -241,6 +311,7 @@ func (ss *StatusServer) Start(port int) {
 	mux.HandleFunc("/api/leaderboard", ss.handleAPILeaderboard)
 	mux.HandleFunc("/api/diagnostics", ss.handleAPIDiagnostics)
 	mux.HandleFunc("/api/cashflow", ss.handleAPICashflow)
+	mux.HandleFunc("/api/attribution", ss.handleAPIAttribution) // #4928
 	mux.HandleFunc("/api/strategies/dead", ss.handleAPIDeadStrategies)
 	mux.HandleFunc("/api/closing-strategies", ss.handleAPIClosingStrategies)
 	mux.HandleFunc("/api/correlation", ss.handleAPICorrelation)
@@ -258,6 +329,8 @@ func (ss *StatusServer) Start(port int) {
 	// through the "/api/strategies/" prefix handler below.
 	mux.HandleFunc("/api/confirm", ss.handleAPIConfirm)
 	mux.HandleFunc("/api/config/add-strategy", ss.handleAPIAddStrategy)
+	// Zero-downtime credential rotation trigger (credential_rotation.go).
+	mux.HandleFunc("/api/credentials/rotate", ss.handleAPICredentialsRotate)
 	mux.HandleFunc("/api/strategies/", ss.handleAPIStrategy)
 
 	listener, boundPort, err := bindWithFallback(port, statusPortMaxAttempts)
@@ -276,9 +349,10 @@ func (ss *StatusServer) Start(port int) {
 		fmt.Printf("[server] WARNING: requested port %d was in use, bound to %d instead — another go-trader may already be running on %d; compare /health pid across ports\n", port, boundPort, port)
 	}
 	fmt.Printf("[server] Status endpoint at http://localhost:%d/status\n", boundPort)
+	fmt.Printf("[server] Metrics at http://localhost:%d/metrics\n", boundPort)
 	fmt.Printf("[server] Dashboard at http://localhost:%d/dashboard\n", boundPort)
 	fmt.Printf("[server] Tuning at http://localhost:%d/tuning\n", boundPort)
-	if ss.statusToken != "" {
+	if ss.currentStatusToken() != "" {
 		fmt.Printf("[server] Dashboard API requires the configured status token\n")
 	} else {
 		// #1229/#1256: mutations (incl. leverage/direction/stop-loss via the
@@ -287,7 +361,7 @@ func (ss *StatusServer) Start(port int) {
 		fmt.Printf("[server] NOTE: status_token unset — dashboard mutations are open to any local (loopback) client; set status_token if other users can reach this host\n")
 	}
 	go func() {
-		if err := http.Serve(listener, mux); err != nil {
+		if err := http.Serve(listener, ss.corsHandler(mux)); err != nil {
 			fmt.Printf("[server] HTTP server error: %v\n", err)
 		}
 	}()
@@ -312,9 +386,7 @@ func (ss *StatusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ss.mu.RLock()
-	lastCycle := ss.state.LastCycle
-	ss.mu.RUnlock()
+	lastCycle := ss.readLastCycle()
 
 	// `version` is the build-stamped Version (#682) so scripts/update.sh can
 	// confirm the post-restart process matches the just-built binary before
@@ -334,10 +406,56 @@ func (ss *StatusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// StratStatus is one strategy entry of the /status response.
+type StratStatus struct {
+	ID                             string                     `json:"id"`
+	Type                           string                     `json:"type"`
+	Cash                           float64                    `json:"cash"`
+	InitialCapital                 float64                    `json:"initial_capital"`
+	Positions                      map[string]*Position       `json:"positions"`
+	OptionPositions                map[string]*OptionPosition `json:"option_positions"`
+	TradeCount                     int                        `json:"trade_count"`
+	PortfolioValue                 float64                    `json:"portfolio_value"`
+	PnL                            float64                    `json:"pnl"`
+	PnLPct                         float64                    `json:"pnl_pct"`
+	RiskState                      RiskState                  `json:"risk_state"`
+	Regime                         string                     `json:"regime,omitempty"`
+	RegimeGateFailClosed           bool                       `json:"regime_gate_fail_closed,omitempty"`          // #1278: entry gate is actively failing closed — allowed_regimes configured, policy "closed", strategy flat, and the cycle store has no gate label; fresh opens are held
+	BaseDirection                  string                     `json:"base_direction,omitempty"`                   // #779: base direction from config (pre-policy resolution)
+	BaseInvertSignal               bool                       `json:"base_invert_signal,omitempty"`               // #779: base invert from config (pre-policy resolution)
+	EffectiveDirection             string                     `json:"effective_direction,omitempty"`              // #779: resolved direction for the active regime (policy override or base)
+	EffectiveInvertSignal          bool                       `json:"effective_invert_signal,omitempty"`          // #779: resolved invert for the active regime
+	RegimeDirectionalPolicy        bool                       `json:"regime_directional_policy,omitempty"`        // #779: true when strategy has a policy block configured
+	EffectivePolicyRegime          string                     `json:"effective_policy_regime,omitempty"`          // #779: regime key the resolver used (pos.Regime while open, current regime when flat); shown only when policy is configured
+	DirectionalCertificationStatus string                     `json:"directional_certification_status,omitempty"` // #1157: certified|expired|uncertified for the strategy's (asset,tf,classifier) cell
+	DirectionalCertificationCell   string                     `json:"directional_certification_cell,omitempty"`   // #1157: (asset,timeframe,classifier) certification key
+	RegimeDivergence               *RegimeDivergenceState     `json:"regime_divergence,omitempty"`                // #907: active window-divergence state; nil when none
+	RegimeProfile                  *RegimeProfileState        `json:"regime_profile,omitempty"`                   // #998: active regime-profile allocation switch state; nil when none
+	Paused                         bool                       `json:"paused,omitempty"`                           // #1150: strategy is paused — position-increasing signals held; closes and SL/TP management still run
+	Benchmark                      *BenchmarkStats            `json:"benchmark,omitempty"`                        // #4927: rolling alpha/beta vs the strategy's benchmark; nil until enough daily points exist
+	GrossPnL                       float64                    `json:"gross_pnl"`                                  // #4977: pnl plus total_fees and total_slippage
+	TotalFees                      float64                    `json:"total_fees"`                                 // #4977: cumulative fees booked
+	TotalSlippage                  float64                    `json:"total_slippage"`                             // #4977: cumulative modeled paper slippage (positive = cost)
+}
+
+// StatusResp is the /status response.
+type StatusResp struct {
+	CycleCount         int                           `json:"cycle_count"`
+	Prices             map[string]float64            `json:"prices"`
+	Strategies         map[string]StratStatus        `json:"strategies"`
+	PortfolioRisk      PortfolioRiskState            `json:"portfolio_risk"`
+	TotalValue         float64                       `json:"total_value"`
+	TotalNotional      float64                       `json:"total_notional"`
+	Correlation        *CorrelationSnapshot          `json:"correlation,omitempty"`
+	ReconciliationGaps map[string]*ReconciliationGap `json:"reconciliation_gaps,omitempty"`
+	CycleTimings       []CycleTiming                 `json:"cycle_timings,omitempty"`
+	CycleTimingSummary *CycleTimingSummary           `json:"cycle_timing_summary,omitempty"`
+}
+
 func (ss *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 	// #38: Optional bearer token auth for /status.
-	if ss.statusToken != "" {
-		if r.Header.Get("Authorization") != "Bearer "+ss.statusToken {
+	if token := ss.currentStatusToken(); token != "" {
+		if r.Header.Get("Authorization") != "Bearer "+token {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write([]byte(`{"error":"unauthorized"}`))
@@ -347,63 +465,30 @@ func (ss *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 
 	prices := ss.fetchLiveMarkPrices()
 
-	// Re-acquire read lock to build the response
-	ss.mu.RLock()
-	defer ss.mu.RUnlock()
-
-	type StratStatus struct {
-		ID                             string                     `json:"id"`
-		Type                           string                     `json:"type"`
-		Cash                           float64                    `json:"cash"`
-		InitialCapital                 float64                    `json:"initial_capital"`
-		Positions                      map[string]*Position       `json:"positions"`
-		OptionPositions                map[string]*OptionPosition `json:"option_positions"`
-		TradeCount                     int                        `json:"trade_count"`
-		PortfolioValue                 float64                    `json:"portfolio_value"`
-		PnL                            float64                    `json:"pnl"`
-		PnLPct                         float64                    `json:"pnl_pct"`
-		RiskState                      RiskState                  `json:"risk_state"`
-		Regime                         string                     `json:"regime,omitempty"`
-		RegimeGateFailClosed           bool                       `json:"regime_gate_fail_closed,omitempty"`          // #1278: entry gate is actively failing closed — allowed_regimes configured, policy "closed", strategy flat, and the cycle store has no gate label; fresh opens are held
-		BaseDirection                  string                     `json:"base_direction,omitempty"`                   // #779: base direction from config (pre-policy resolution)
-		BaseInvertSignal               bool                       `json:"base_invert_signal,omitempty"`               // #779: base invert from config (pre-policy resolution)
-		EffectiveDirection             string                     `json:"effective_direction,omitempty"`              // #779: resolved direction for the active regime (policy override or base)
-		EffectiveInvertSignal          bool                       `json:"effective_invert_signal,omitempty"`          // #779: resolved invert for the active regime
-		RegimeDirectionalPolicy        bool                       `json:"regime_directional_policy,omitempty"`        // #779: true when strategy has a policy block configured
-		EffectivePolicyRegime          string                     `json:"effective_policy_regime,omitempty"`          // #779: regime key the resolver used (pos.Regime while open, current regime when flat); shown only when policy is configured
-		DirectionalCertificationStatus string                     `json:"directional_certification_status,omitempty"` // #1157: certified|expired|uncertified for the strategy's (asset,tf,classifier) cell
-		DirectionalCertificationCell   string                     `json:"directional_certification_cell,omitempty"`   // #1157: (asset,timeframe,classifier) certification key
-		RegimeDivergence               *RegimeDivergenceState     `json:"regime_divergence,omitempty"`                // #907: active window-divergence state; nil when none
-		RegimeProfile                  *RegimeProfileState        `json:"regime_profile,omitempty"`                   // #998: active regime-profile allocation switch state; nil when none
-		Paused                         bool                       `json:"paused,omitempty"`                           // #1150: strategy is paused — position-increasing signals held; closes and SL/TP management still run
-	}
-
-	type StatusResp struct {
-		CycleCount         int                           `json:"cycle_count"`
-		Prices             map[string]float64            `json:"prices"`
-		Strategies         map[string]StratStatus        `json:"strategies"`
-		PortfolioRisk      PortfolioRiskState            `json:"portfolio_risk"`
-		TotalValue         float64                       `json:"total_value"`
-		TotalNotional      float64                       `json:"total_notional"`
-		Correlation        *CorrelationSnapshot          `json:"correlation,omitempty"`
-		ReconciliationGaps map[string]*ReconciliationGap `json:"reconciliation_gaps,omitempty"`
-	}
+	// #4919: build from the copy-on-read snapshot so a cycle holding mu
+	// never stalls /status.
+	state := ss.readState()
 
 	totalValue := 0.0
-	for _, s := range ss.state.Strategies {
+	for _, s := range state.Strategies {
 		totalValue += displayStrategyValue(s, prices)
 	}
-	totalNotional := PortfolioNotional(ss.state.Strategies, prices)
+	totalNotional := PortfolioNotional(state.Strategies, prices)
 
 	resp := StatusResp{
-		CycleCount:         ss.state.CycleCount,
+		CycleCount:         state.CycleCount,
 		Prices:             prices,
 		Strategies:         make(map[string]StratStatus),
-		PortfolioRisk:      ss.state.PortfolioRisk,
+		PortfolioRisk:      state.PortfolioRisk,
 		TotalValue:         totalValue,
 		TotalNotional:      totalNotional,
-		Correlation:        ss.state.CorrelationSnapshot,
-		ReconciliationGaps: ss.state.ReconciliationGaps,
+		Correlation:        state.CorrelationSnapshot,
+		ReconciliationGaps: state.ReconciliationGaps,
+		CycleTimings:       state.CycleTimings,
+	}
+	if len(state.CycleTimings) > 0 {
+		sum := summarizeCycleTimings(state.CycleTimings)
+		resp.CycleTimingSummary = &sum
 	}
 
 	// Build config lookup for EffectiveInitialCapital. strategies has its own
@@ -415,7 +500,7 @@ func (ss *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	ss.strategiesMu.RUnlock()
 
-	for id, s := range ss.state.Strategies {
+	for id, s := range state.Strategies {
 		pv := displayStrategyValue(s, prices)
 		sc := cfgByID[id]
 		initCap := EffectiveInitialCapital(sc, s)
@@ -429,6 +514,10 @@ func (ss *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 		// what the next signal will be evaluated under — pulled by replaying
 		// the resolver against the strategy's first open position (or flat).
 		dirView := directionalStatusForStrategy(sc, s, ss.regime, time.Now().UTC())
+		var bench *BenchmarkStats
+		if st, ok := benchmarkStats(s.Benchmark, benchmarkWindowDays); ok {
+			bench = &st
+		}
 
 		resp.Strategies[id] = StratStatus{
 			ID:                             s.ID,
@@ -455,6 +544,10 @@ func (ss *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 			RegimeDivergence:               s.RegimeDivergence,
 			RegimeProfile:                  s.RegimeProfile,
 			Paused:                         sc.Paused,
+			Benchmark:                      bench,
+			GrossPnL:                       pnl + s.TotalFees + s.TotalSlippage,
+			TotalFees:                      s.TotalFees,
+			TotalSlippage:                  s.TotalSlippage,
 		}
 	}
 
@@ -469,15 +562,13 @@ func (ss *StatusServer) fetchLiveMarkPrices() map[string]float64 {
 	for _, sym := range ss.priceSymbols {
 		symbolSet[sym] = true
 	}
-	ss.mu.RLock()
-	for _, s := range ss.state.Strategies {
+	for _, s := range ss.readState().Strategies {
 		for sym := range s.Positions {
 			if strings.Contains(sym, "/") {
 				symbolSet[sym] = true
 			}
 		}
 	}
-	ss.mu.RUnlock()
 
 	symbols := make([]string, 0, len(symbolSet))
 	for s := range symbolSet {
@@ -533,7 +624,7 @@ type directionalStatusView struct {
 // directionalStatusForStrategy replays the directional-policy resolver
 // (cert-gated per #1085/#1157) against the strategy's first open position, or
 // the live regime when flat. Read-only; caller supplies a consistent snapshot
-// of the strategy state (call under ss.mu.RLock, or on a cloned snapshot).
+// of the strategy state (call under ss.mu.RLock, or on a readState snapshot).
 func directionalStatusForStrategy(sc StrategyConfig, s *StrategyState, rc *RegimeConfig, now time.Time) directionalStatusView {
 	view := directionalStatusView{
 		BaseDirection:    EffectiveDirection(sc),
@@ -568,9 +659,17 @@ func directionalStatusForStrategy(sc StrategyConfig, s *StrategyState, rc *Regim
 	return view
 }
 
+// HistoryResp is the /history response.
+type HistoryResp struct {
+	Trades []Trade `json:"trades"`
+	Total  int     `json:"total"`
+	Limit  int     `json:"limit"`
+	Offset int     `json:"offset"`
+}
+
 func (ss *StatusServer) handleHistory(w http.ResponseWriter, r *http.Request) {
-	if ss.statusToken != "" {
-		if r.Header.Get("Authorization") != "Bearer "+ss.statusToken {
+	if token := ss.currentStatusToken(); token != "" {
+		if r.Header.Get("Authorization") != "Bearer "+token {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write([]byte(`{"error":"unauthorized"}`))
@@ -614,13 +713,6 @@ func (ss *StatusServer) handleHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type HistoryResp struct {
-		Trades []Trade `json:"trades"`
-		Total  int     `json:"total"`
-		Limit  int     `json:"limit"`
-		Offset int     `json:"offset"`
-	}
-
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(HistoryResp{
 		Trades: trades,
@@ -629,3 +721,75 @@ func (ss *StatusServer) handleHistory(w http.ResponseWriter, r *http.Request) {
 		Offset: offset,
 	})
 }
+
+// handlePriceHistory serves recorded per-cycle prices for one symbol (#4929):
+// ?symbol= (required, the price-map key, e.g. "BTC/USDT" or "ETH"), ?from= /
+// ?to= as RFC3339 or unix seconds (default: the last 24h), ?limit= (capped at
+// maxPriceHistoryPoints).
+func (ss *StatusServer) handlePriceHistory(w http.ResponseWriter, r *http.Request) {
+	if token := ss.currentStatusToken(); token != "" {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+	}
+	if priceHistory == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "price history not enabled")
+		return
+	}
+	q := r.URL.Query()
+	symbol := q.Get("symbol")
+	if symbol == "" {
+		writeJSONError(w, http.StatusBadRequest, "symbol is required")
+		return
+	}
+	now := time.Now().UTC()
+	from, to := now.Add(-24*time.Hour), now
+	for _, p := range []struct {
+		key string
+		dst *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		raw := q.Get(p.key)
+		if raw == "" {
+			continue
+		}
+		t, err := parsePriceHistoryTime(raw)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("%s: %v", p.key, err))
+			return
+		}
+		*p.dst = t
+	}
+	if to.Before(from) {
+		writeJSONError(w, http.StatusBadRequest, "to is before from")
+		return
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	points, truncated, err := priceHistory.Query(symbol, from, to, limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if points == nil {
+		points = []PricePoint{}
+	}
+	writeJSON(w, map[string]any{
+		"symbol":    symbol,
+		"from":      from.UTC().Format(time.RFC3339),
+		"to":        to.UTC().Format(time.RFC3339),
+		"points":    points,
+		"truncated": truncated,
+	})
+}
+
+// parsePriceHistoryTime accepts RFC3339 or unix seconds.
+func parsePriceHistoryTime(raw string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("want RFC3339 or unix seconds, got %q", raw)
+	}
+	return t, nil
+}
@@ -0,0 +1,319 @@
+package main
+
+// shadow: paper twin of a live strategy (#4975).
+//
+// A live strategy with "shadow": true keeps a parallel paper StrategyState
+// fed the same check-script signal at the same price the check evaluated at,
+// before any risk gate. The twin books through the ordinary paper executors
+// (modeled fees, no slippage, every signal filled) but never touches the
+// trades table, the trade ledger, the state WAL or trade diagnostics — its
+// trades live only in its own history, persisted per strategy in the
+// shadow_strategies table.
+//
+// The weekly digest compares the twin with the live strategy's booked trades
+// over the window: the PnL gap splits into fees (live fees vs modeled),
+// slippage (live fill price vs the twin's paper price for trades matched by
+// symbol, side and time) and the rest — missed fills (paper trades with no
+// live counterpart: gated signals, failed orders), live-only trades (exchange
+// stop-losses, manual closes) and sizing drift. The twin only follows
+// signals; it runs no stop-loss or take-profit management of its own.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// shadowMatchWindow is how far apart a live trade and a paper twin trade may
+// be and still count as the same fill.
+const shadowMatchWindow = 10 * time.Minute
+
+// shadowBook holds the paper twins of every shadowed live strategy.
+type shadowBook struct {
+	mu    sync.Mutex
+	db    *StateDB
+	twins map[string]*StrategyState
+}
+
+// shadowTwins is the package-level book, wired in main. nil disables shadow
+// mode (tests, CLI subcommands); every method is nil-safe.
+var shadowTwins *shadowBook
+
+// newShadowBook loads persisted twins from db. A nil db keeps twins in
+// memory only; a load failure is logged and the twins start fresh.
+func newShadowBook(db *StateDB) *shadowBook {
+	b := &shadowBook{db: db, twins: make(map[string]*StrategyState)}
+	if db == nil {
+		return b
+	}
+	twins, err := db.LoadShadowStates()
+	if err != nil {
+		fmt.Printf("[WARN] shadow: failed to load paper twins, starting fresh: %v\n", err)
+		return b
+	}
+	for id, s := range twins {
+		s.shadow = true
+		b.twins[id] = s
+	}
+	return b
+}
+
+// shadowSupported reports whether sc's platform/type has a paper executor
+// the twin can use.
+func shadowSupported(sc StrategyConfig) bool {
+	switch sc.Platform {
+	case "hyperliquid":
+		return sc.Type == "perps"
+	case "okx":
+		return sc.Type == "perps" || sc.Type == "spot"
+	case "robinhood":
+		return sc.Type == "spot"
+	}
+	return false
+}
+
+// Observe feeds one check result to sc's twin, creating the twin on first
+// use. No-op unless sc is a live shadowed strategy.
+func (b *shadowBook) Observe(sc StrategyConfig, symbol string, signal int, price float64, dec StrategyDecisionFields, indicators map[string]interface{}) {
+	if b == nil || !sc.Shadow || !isLiveArgs(sc.Args) || !shadowSupported(sc) || signal == 0 || price <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	twin := b.twins[sc.ID]
+	if twin == nil {
+		twin = NewStrategyState(sc)
+		twin.shadow = true
+		b.twins[sc.ID] = twin
+	}
+	logger := &StrategyLogger{stratID: sc.ID + "/shadow", writer: io.Discard}
+	var trades int
+	var err error
+	if sc.Type == "perps" {
+		sizing := PerpsSizingFor(sc, price, indicatorsATRValue(indicators)).withSizeFraction(resolveSizeFraction(sc, dec))
+		trades, err = ExecutePerpsSignalWithLeverage(twin, signal, symbol, price, sizing, 0, "", 0, EffectiveDirection(sc), dec.CloseFraction, logger)
+	} else {
+		trades, err = ExecuteSpotSignalWithFillFee(twin, signal, symbol, price, 0, 0, "", dec.CloseFraction, logger)
+	}
+	if err != nil {
+		fmt.Printf("[WARN] shadow %s: paper execute failed: %v\n", sc.ID, err)
+		return
+	}
+	if trades == 0 {
+		return
+	}
+	if len(twin.TradeHistory) > maxTradeHistory {
+		twin.TradeHistory = append([]Trade(nil), twin.TradeHistory[len(twin.TradeHistory)-maxTradeHistory:]...)
+	}
+	twin.ClosedPositions = nil
+	if b.db != nil {
+		if err := b.db.SaveShadowState(twin); err != nil {
+			fmt.Printf("[WARN] shadow %s: save failed: %v\n", sc.ID, err)
+		}
+	}
+}
+
+// Trades returns a copy of id's twin trades at or after since.
+func (b *shadowBook) Trades(id string, since time.Time) []Trade {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	twin := b.twins[id]
+	if twin == nil {
+		return nil
+	}
+	var out []Trade
+	for _, t := range twin.TradeHistory {
+		if !t.Timestamp.Before(since) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// LoadShadowStates returns every persisted twin keyed by strategy ID.
+func (sdb *StateDB) LoadShadowStates() (map[string]*StrategyState, error) {
+	rows, err := sdb.db.Query(`SELECT strategy_id, state_json FROM shadow_strategies`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make(map[string]*StrategyState)
+	for rows.Next() {
+		var id, raw string
+		if err := rows.Scan(&id, &raw); err != nil {
+			return nil, err
+		}
+		var s StrategyState
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return nil, fmt.Errorf("decode shadow state %s: %w", id, err)
+		}
+		if s.Positions == nil {
+			s.Positions = make(map[string]*Position)
+		}
+		if s.OptionPositions == nil {
+			s.OptionPositions = make(map[string]*OptionPosition)
+		}
+		out[id] = &s
+	}
+	return out, rows.Err()
+}
+
+// SaveShadowState upserts one twin.
+func (sdb *StateDB) SaveShadowState(s *StrategyState) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = sdb.db.Exec(`INSERT INTO shadow_strategies (strategy_id, state_json, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(strategy_id) DO UPDATE SET state_json = excluded.state_json, updated_at = excluded.updated_at`,
+		s.ID, string(raw), time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// shadowDivergence is one strategy's live-vs-paper comparison.
+type shadowDivergence struct {
+	strategyID  string
+	livePnL     float64 // realized, net of fees
+	paperPnL    float64
+	liveFees    float64
+	paperFees   float64
+	slippage    float64 // adverse live-vs-paper price cost on matched fills (positive = cost)
+	paperTrades int
+	liveTrades  int
+	missed      int // paper trades with no live counterpart
+	liveOnly    int // live trades with no paper counterpart
+}
+
+func (d shadowDivergence) gap() float64 { return d.livePnL - d.paperPnL }
+
+// shadowComparable reports whether a live trade came from the signal path:
+// funding rows, manual actions and position adjustments have no paper twin.
+func shadowComparable(t Trade) bool {
+	return t.TradeType != "funding" && !t.Manual
+}
+
+// compareShadow matches live and paper trades (same symbol and side, within
+// shadowMatchWindow, each used once, nearest first in time order) and sums
+// the divergence components.
+func compareShadow(id string, live, paper []Trade) shadowDivergence {
+	d := shadowDivergence{strategyID: id, paperTrades: len(paper)}
+	for _, t := range paper {
+		d.paperPnL += tradeLedgerDelta(t)
+		d.paperFees += t.ExchangeFee
+	}
+	var comparable []Trade
+	for _, t := range live {
+		if !shadowComparable(t) {
+			continue
+		}
+		comparable = append(comparable, t)
+		d.livePnL += tradeLedgerDelta(t)
+		d.liveFees += t.ExchangeFee
+	}
+	d.liveTrades = len(comparable)
+	sort.Slice(comparable, func(i, j int) bool { return comparable[i].Timestamp.Before(comparable[j].Timestamp) })
+
+	used := make([]bool, len(paper))
+	for _, lt := range comparable {
+		best := -1
+		var bestDist time.Duration
+		for i, pt := range paper {
+			if used[i] || pt.Symbol != lt.Symbol || pt.Side != lt.Side {
+				continue
+			}
+			dist := lt.Timestamp.Sub(pt.Timestamp)
+			if dist < 0 {
+				dist = -dist
+			}
+			if dist > shadowMatchWindow || (best >= 0 && dist >= bestDist) {
+				continue
+			}
+			best, bestDist = i, dist
+		}
+		if best < 0 {
+			d.liveOnly++
+			continue
+		}
+		used[best] = true
+		cost := (lt.Price - paper[best].Price) * lt.Quantity
+		if lt.Side == "sell" {
+			cost = -cost
+		}
+		d.slippage += cost
+	}
+	for _, u := range used {
+		if !u {
+			d.missed++
+		}
+	}
+	return d
+}
+
+// digestShadowDivergence is the paper-vs-live section: for each shadowed
+// live strategy, the realized PnL gap to its paper twin split into fees,
+// slippage and the unexplained rest, with missed and live-only fill counts.
+func digestShadowDivergence(in weeklyDigestInput) string {
+	if in.cfg == nil || in.shadow == nil {
+		return ""
+	}
+	since := in.now.Add(-weeklyDigestWindow)
+	var rows []shadowDivergence
+	for _, sc := range in.cfg.Strategies {
+		if !sc.Shadow || !isLiveArgs(sc.Args) {
+			continue
+		}
+		paper := in.shadow.Trades(sc.ID, since)
+		var live []Trade
+		if in.db != nil {
+			var err error
+			if live, _, err = in.db.QueryTradeHistory(sc.ID, "", since, in.now, 500, 0); err != nil {
+				fmt.Printf("[WARN] weekly digest: shadow trades for %s: %v\n", sc.ID, err)
+				continue
+			}
+		}
+		d := compareShadow(sc.ID, live, paper)
+		if d.paperTrades == 0 && d.liveTrades == 0 {
+			continue
+		}
+		rows = append(rows, d)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	// Largest shortfall first.
+	sort.Slice(rows, func(i, j int) bool {
+		if gi, gj := rows[i].gap(), rows[j].gap(); gi != gj {
+			return gi < gj
+		}
+		return rows[i].strategyID < rows[j].strategyID
+	})
+	return formatShadowDivergence(rows)
+}
+
+func formatShadowDivergence(rows []shadowDivergence) string {
+	var sb strings.Builder
+	sb.WriteString("👥 **Live vs paper twin (7d)**\n")
+	for i, d := range rows {
+		if i == weeklyDigestMaxRows {
+			fmt.Fprintf(&sb, "… +%d more\n", len(rows)-weeklyDigestMaxRows)
+			break
+		}
+		fees := d.paperFees - d.liveFees
+		slip := -d.slippage
+		other := d.gap() - fees - slip
+		if math.Abs(other) < 0.005 {
+			other = 0
+		}
+		fmt.Fprintf(&sb, "`%s` live $%+.2f vs paper $%+.2f (gap $%+.2f): fees $%+.2f · slippage $%+.2f · other $%+.2f · missed %d of %d paper fills · %d live-only\n",
+			d.strategyID, d.livePnL, d.paperPnL, d.gap(), fees, slip, other, d.missed, d.paperTrades, d.liveOnly)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShadowBookObserve(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+	origRecorder := tradeRecorder
+	tradeRecorder = func(id string, _ Trade) error {
+		t.Errorf("twin trade for %s reached the trades table", id)
+		return nil
+	}
+	defer func() { tradeRecorder = origRecorder }()
+
+	sc := StrategyConfig{ID: "hl-btc", Platform: "hyperliquid", Type: "perps", Capital: 1000, Leverage: 1, Shadow: true, Args: []string{"sma", "BTC", "1h", "--mode=live"}}
+	book := newShadowBook(db)
+	book.Observe(sc, "BTC", 1, 100, StrategyDecisionFields{}, nil)
+	book.Observe(sc, "BTC", 0, 105, StrategyDecisionFields{}, nil) // hold
+	book.Observe(sc, "BTC", -1, 110, StrategyDecisionFields{}, nil)
+
+	paper := book.Trades(sc.ID, time.Time{})
+	if len(paper) != 2 || paper[0].Side != "buy" || !paper[1].IsClose {
+		t.Fatalf("twin trades = %+v", paper)
+	}
+	if tradeLedgerDelta(paper[1]) <= 0 {
+		t.Errorf("winning paper round trip booked %v", tradeLedgerDelta(paper[1]))
+	}
+
+	// Paper strategies and non-shadowed live ones get no twin.
+	paperSC := sc
+	paperSC.ID, paperSC.Args = "hl-paper", []string{"sma", "BTC", "1h"}
+	book.Observe(paperSC, "BTC", 1, 100, StrategyDecisionFields{}, nil)
+	if got := book.Trades(paperSC.ID, time.Time{}); len(got) != 0 {
+		t.Errorf("paper strategy got a twin: %+v", got)
+	}
+
+	reloaded := newShadowBook(db)
+	if got := reloaded.Trades(sc.ID, time.Time{}); len(got) != 2 {
+		t.Fatalf("reloaded twin trades = %d, want 2", len(got))
+	}
+	if !reloaded.twins[sc.ID].shadow {
+		t.Error("reloaded twin not marked shadow")
+	}
+}
+
+func TestCompareShadow(t *testing.T) {
+	t0 := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	paper := []Trade{
+		{Timestamp: t0, Symbol: "BTC", Side: "buy", Quantity: 1, Price: 100, ExchangeFee: 0.05, PnLGross: true},
+		{Timestamp: t0.Add(time.Hour), Symbol: "BTC", Side: "sell", Quantity: 1, Price: 110, ExchangeFee: 0.05, IsClose: true, RealizedPnL: 10, PnLGross: true},
+	}
+	live := []Trade{
+		{Timestamp: t0.Add(5 * time.Second), Symbol: "BTC", Side: "buy", Quantity: 1, Price: 100.5, ExchangeFee: 0.1, PnLGross: true},
+		// Exchange stop-loss hours later: no paper counterpart.
+		{Timestamp: t0.Add(3 * time.Hour), Symbol: "BTC", Side: "sell", Quantity: 1, Price: 99, ExchangeFee: 0.1, IsClose: true, RealizedPnL: -1.5, PnLGross: true},
+		{Timestamp: t0.Add(4 * time.Hour), Symbol: "BTC", TradeType: "funding", RealizedPnL: -0.2, PnLGross: true},
+	}
+
+	d := compareShadow("hl-btc", live, paper)
+	if d.missed != 1 || d.liveOnly != 1 || d.liveTrades != 2 || d.paperTrades != 2 {
+		t.Fatalf("counts = %+v", d)
+	}
+	if d.slippage < 0.499 || d.slippage > 0.501 {
+		t.Errorf("slippage = %v, want 0.5", d.slippage)
+	}
+	out := formatShadowDivergence([]shadowDivergence{d})
+	for _, want := range []string{"`hl-btc` live $-1.70 vs paper $+9.90 (gap $-11.60)", "fees $-0.10", "slippage $-0.50", "missed 1 of 2 paper fills", "1 live-only"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package main
+
+// sheets_export: optional Google Sheets exporter (#4936).
+//
+// After each saved cycle, closed trades recorded since the last export are
+// appended to one tab and, once per UTC day, a portfolio equity row to
+// another. Auth is a Google service account (JSON key file): the exporter
+// signs an RS256 JWT, exchanges it for an access token, and calls the Sheets
+// v4 values:append endpoint — no SDK. Share the spreadsheet with the service
+// account's client_email as an editor.
+//
+// The trade cursor (last exported trades.rowid) and the last equity date live
+// in sheets_export_state, so a restart or a failed append resumes where it
+// stopped instead of duplicating or dropping rows. The first run for a
+// spreadsheet writes header rows and starts from the current end of the
+// ledger — history is not backfilled. Like the healthcheck ping, the export
+// runs off the main loop with at most one run in flight, and failures are
+// logged, never allowed to affect trading.
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultSheetsTradesTab   = "Trades"
+	defaultSheetsEquityTab   = "Equity"
+	sheetsAPIBase            = "https://sheets.googleapis.com/v4/spreadsheets"
+	sheetsScope              = "https://www.googleapis.com/auth/spreadsheets"
+	sheetsHTTPTimeout        = 30 * time.Second
+	sheetsMaxTradesPerExport = 500
+)
+
+var (
+	sheetsTradeHeader  = []any{"Closed At (UTC)", "Strategy", "Symbol", "Side", "Quantity", "Price", "Value", "Realized PnL", "Fee", "Net PnL", "Reason", "Details"}
+	sheetsEquityHeader = []any{"Date (UTC)", "Total Value", "Initial Capital", "PnL", "PnL %", "Drawdown %", "Open Positions", "Kill Switch"}
+)
+
+// GoogleSheetsConfig is the top-level "google_sheets" block.
+type GoogleSheetsConfig struct {
+	Enabled         bool   `json:"enabled"`
+	SpreadsheetID   string `json:"spreadsheet_id"`
+	CredentialsFile string `json:"credentials_file,omitempty"` // service-account JSON key; empty → GOOGLE_APPLICATION_CREDENTIALS
+	TradesTab       string `json:"trades_tab,omitempty"`       // default "Trades"
+	EquityTab       string `json:"equity_tab,omitempty"`       // default "Equity"
+}
+
+func (c *GoogleSheetsConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *GoogleSheetsConfig) credentialsFile() string {
+	if f := strings.TrimSpace(c.CredentialsFile); f != "" {
+		return f
+	}
+	return strings.TrimSpace(os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+}
+
+func (c *GoogleSheetsConfig) tradesTab() string {
+	if c.TradesTab != "" {
+		return c.TradesTab
+	}
+	return defaultSheetsTradesTab
+}
+
+func (c *GoogleSheetsConfig) equityTab() string {
+	if c.EquityTab != "" {
+		return c.EquityTab
+	}
+	return defaultSheetsEquityTab
+}
+
+// validateGoogleSheetsConfig checks the block. Disabled is always valid.
+func validateGoogleSheetsConfig(c *GoogleSheetsConfig) []string {
+	if !c.enabled() {
+		return nil
+	}
+	var errs []string
+	if strings.TrimSpace(c.SpreadsheetID) == "" {
+		errs = append(errs, "google_sheets.spreadsheet_id is required when enabled")
+	}
+	if c.credentialsFile() == "" {
+		errs = append(errs, "google_sheets.credentials_file (or GOOGLE_APPLICATION_CREDENTIALS) is required when enabled")
+	}
+	if c.TradesTab != "" && c.TradesTab == c.EquityTab {
+		errs = append(errs, "google_sheets.trades_tab and equity_tab must differ")
+	}
+	return errs
+}
+
+// SheetsEquityRow is the daily portfolio snapshot, built under mu by the
+// caller.
+type SheetsEquityRow struct {
+	At             time.Time
+	TotalValue     float64
+	InitialCapital float64
+	DrawdownPct    float64
+	OpenPositions  int
+	KillSwitch     bool
+}
+
+// buildSheetsEquityRow snapshots the portfolio for the equity tab. Caller
+// holds mu.
+func buildSheetsEquityRow(strategies []StrategyConfig, state *AppState, prices map[string]float64, now time.Time) SheetsEquityRow {
+	row := SheetsEquityRow{At: now, DrawdownPct: state.PortfolioRisk.CurrentDrawdownPct, KillSwitch: state.PortfolioRisk.KillSwitchActive}
+	for _, sc := range strategies {
+		s := state.Strategies[sc.ID]
+		if s == nil {
+			continue
+		}
+		row.TotalValue += displayStrategyValue(s, prices)
+		row.InitialCapital += EffectiveInitialCapital(sc, s)
+		row.OpenPositions += len(s.Positions) + len(s.OptionPositions)
+	}
+	return row
+}
+
+func (r SheetsEquityRow) values() []any {
+	pnl := r.TotalValue - r.InitialCapital
+	pnlPct := 0.0
+	if r.InitialCapital > 0 {
+		pnlPct = pnl / r.InitialCapital * 100
+	}
+	return []any{r.At.UTC().Format("2006-01-02"), round2(r.TotalValue), round2(r.InitialCapital), round2(pnl), round2(pnlPct), round2(r.DrawdownPct), r.OpenPositions, r.KillSwitch}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// sheetsTradeValues renders one closed trade row.
+func sheetsTradeValues(t Trade) []any {
+	return []any{
+		t.Timestamp.UTC().Format("2006-01-02 15:04:05"), t.StrategyID, t.Symbol, t.Side,
+		t.Quantity, t.Price, round2(t.Value), round2(t.RealizedPnL), round2(t.ExchangeFee), round2(tradeNetPnL(t)),
+		t.Tags[TradeTagReason], t.Details,
+	}
+}
+
+// sheetsExportCursor is the persisted export position for one spreadsheet.
+type sheetsExportCursor struct {
+	SpreadsheetID  string
+	LastTradeRowID int64
+	LastEquityDate string
+}
+
+// LoadSheetsExportCursor returns the cursor; ok=false when none is stored.
+func (sdb *StateDB) LoadSheetsExportCursor() (c sheetsExportCursor, ok bool, err error) {
+	if sdb == nil || sdb.db == nil {
+		return c, false, fmt.Errorf("state db unavailable")
+	}
+	err = sdb.db.QueryRow(`SELECT spreadsheet_id, last_trade_rowid, last_equity_date FROM sheets_export_state WHERE id = 1`).
+		Scan(&c.SpreadsheetID, &c.LastTradeRowID, &c.LastEquityDate)
+	if err == sql.ErrNoRows {
+		return c, false, nil
+	}
+	if err != nil {
+		return c, false, fmt.Errorf("load sheets export cursor: %w", err)
+	}
+	return c, true, nil
+}
+
+// SaveSheetsExportCursor upserts the cursor.
+func (sdb *StateDB) SaveSheetsExportCursor(c sheetsExportCursor) error {
+	if sdb == nil || sdb.db == nil {
+		return fmt.Errorf("state db unavailable")
+	}
+	_, err := sdb.db.Exec(`INSERT INTO sheets_export_state (id, spreadsheet_id, last_trade_rowid, last_equity_date) VALUES (1, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET spreadsheet_id = excluded.spreadsheet_id, last_trade_rowid = excluded.last_trade_rowid, last_equity_date = excluded.last_equity_date`,
+		c.SpreadsheetID, c.LastTradeRowID, c.LastEquityDate)
+	if err != nil {
+		return fmt.Errorf("save sheets export cursor: %w", err)
+	}
+	return nil
+}
+
+// MaxTradeRowID returns the newest trades.rowid (0 for an empty ledger).
+func (sdb *StateDB) MaxTradeRowID() (int64, error) {
+	var id sql.NullInt64
+	if err := sdb.db.QueryRow(`SELECT MAX(rowid) FROM trades`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("max trade rowid: %w", err)
+	}
+	return id.Int64, nil
+}
+
+// QueryClosedTradesAfter returns up to limit close legs with rowid > after,
+// oldest first, with their rowids.
+func (sdb *StateDB) QueryClosedTradesAfter(after int64, limit int) ([]Trade, []int64, error) {
+	rows, err := sdb.db.Query(`SELECT rowid, timestamp, strategy_id, symbol, side, quantity, price, value, details, exchange_fee, realized_pnl, COALESCE(pnl_gross, 0), COALESCE(tags_json, '')
+		FROM trades WHERE is_close = 1 AND rowid > ? ORDER BY rowid LIMIT ?`, after, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query closed trades: %w", err)
+	}
+	defer rows.Close()
+	var trades []Trade
+	var ids []int64
+	for rows.Next() {
+		var t Trade
+		var id int64
+		var ts, tagsJSON string
+		var pnlGross int
+		if err := rows.Scan(&id, &ts, &t.StrategyID, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &t.Value, &t.Details, &t.ExchangeFee, &t.RealizedPnL, &pnlGross, &tagsJSON); err != nil {
+			return nil, nil, fmt.Errorf("scan closed trade: %w", err)
+		}
+		t.Timestamp = parseTime(ts)
+		t.IsClose = true
+		t.PnLGross = pnlGross != 0
+		t.Tags = parseStringMapJSON(tagsJSON)
+		trades = append(trades, t)
+		ids = append(ids, id)
+	}
+	return trades, ids, rows.Err()
+}
+
+// sheetsExporter appends rows to Google Sheets. At most one export is in
+// flight; a run that finds the previous one still going is skipped (the
+// cursor picks its trades up next time).
+type sheetsExporter struct {
+	inflight atomic.Bool
+	client   *http.Client
+	apiBase  string
+
+	tokenMu     sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	tokenKey    string // credentials file the cached token belongs to
+}
+
+func newSheetsExporter() *sheetsExporter {
+	return &sheetsExporter{client: &http.Client{Timeout: sheetsHTTPTimeout}, apiBase: sheetsAPIBase}
+}
+
+// Export appends new closed trades and, on a new UTC day, the equity row.
+// Blocks for the HTTP round-trips — the main loop calls it via `go` except
+// on --once. Errors are logged, never returned.
+func (e *sheetsExporter) Export(c *GoogleSheetsConfig, sdb *StateDB, equity SheetsEquityRow) {
+	if !c.enabled() || sdb == nil {
+		return
+	}
+	if !e.inflight.CompareAndSwap(false, true) {
+		fmt.Println("[WARN] google_sheets: previous export still in flight, skipping this cycle")
+		return
+	}
+	defer e.inflight.Store(false)
+	if err := e.export(c, sdb, equity); err != nil {
+		fmt.Printf("[WARN] google_sheets: export failed: %v\n", err)
+	}
+}
+
+func (e *sheetsExporter) export(c *GoogleSheetsConfig, sdb *StateDB, equity SheetsEquityRow) error {
+	cur, ok, err := sdb.LoadSheetsExportCursor()
+	if err != nil {
+		return err
+	}
+	if !ok || cur.SpreadsheetID != c.SpreadsheetID {
+		// New spreadsheet: headers, then start from the current ledger end.
+		maxID, err := sdb.MaxTradeRowID()
+		if err != nil {
+			return err
+		}
+		if err := e.appendRows(c, c.tradesTab(), [][]any{sheetsTradeHeader}); err != nil {
+			return err
+		}
+		if err := e.appendRows(c, c.equityTab(), [][]any{sheetsEquityHeader}); err != nil {
+			return err
+		}
+		cur = sheetsExportCursor{SpreadsheetID: c.SpreadsheetID, LastTradeRowID: maxID}
+		if err := sdb.SaveSheetsExportCursor(cur); err != nil {
+			return err
+		}
+		fmt.Printf("[google_sheets] Exporting to spreadsheet %s from trade rowid %d\n", c.SpreadsheetID, maxID)
+	}
+
+	trades, ids, err := sdb.QueryClosedTradesAfter(cur.LastTradeRowID, sheetsMaxTradesPerExport)
+	if err != nil {
+		return err
+	}
+	if len(trades) > 0 {
+		rows := make([][]any, len(trades))
+		for i, t := range trades {
+			rows[i] = sheetsTradeValues(t)
+		}
+		if err := e.appendRows(c, c.tradesTab(), rows); err != nil {
+			return err
+		}
+		cur.LastTradeRowID = ids[len(ids)-1]
+		if err := sdb.SaveSheetsExportCursor(cur); err != nil {
+			return err
+		}
+	}
+
+	if day := equity.At.UTC().Format("2006-01-02"); !equity.At.IsZero() && day != cur.LastEquityDate {
+		if err := e.appendRows(c, c.equityTab(), [][]any{equity.values()}); err != nil {
+			return err
+		}
+		cur.LastEquityDate = day
+		if err := sdb.SaveSheetsExportCursor(cur); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendRows calls values:append on tab.
+func (e *sheetsExporter) appendRows(c *GoogleSheetsConfig, tab string, rows [][]any) error {
+	token, err := e.accessToken(c.credentialsFile())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]any{"values": rows})
+	if err != nil {
+		return err
+	}
+	rng := url.PathEscape("'" + strings.ReplaceAll(tab, "'", "''") + "'!A1")
+	endpoint := fmt.Sprintf("%s/%s/values/%s:append?valueInputOption=RAW&insertDataOption=INSERT_ROWS",
+		e.apiBase, url.PathEscape(c.SpreadsheetID), rng)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("append to %s: %w", tab, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("append to %s: HTTP %d: %s", tab, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return nil
+}
+
+// serviceAccountKey is the subset of a Google service-account JSON key used.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// accessToken returns a cached OAuth access token, refreshing it a minute
+// before expiry via the JWT-bearer grant.
+func (e *sheetsExporter) accessToken(credFile string) (string, error) {
+	e.tokenMu.Lock()
+	defer e.tokenMu.Unlock()
+	if e.token != "" && e.tokenKey == credFile && time.Now().Before(e.tokenExpiry.Add(-time.Minute)) {
+		return e.token, nil
+	}
+	raw, err := os.ReadFile(credFile)
+	if err != nil {
+		return "", fmt.Errorf("read credentials: %w", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", fmt.Errorf("parse credentials: %w", err)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	assertion, err := signServiceAccountJWT(key, time.Now())
+	if err != nil {
+		return "", err
+	}
+	resp, err := e.client.PostForm(key.TokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", fmt.Errorf("token exchange: %w", err)
+	}
+	defer resp.Body.Close()
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&tok); err != nil {
+		return "", fmt.Errorf("token exchange: HTTP %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode/100 != 2 || tok.AccessToken == "" {
+		return "", fmt.Errorf("token exchange: HTTP %d: %s", resp.StatusCode, tok.Error)
+	}
+	e.token, e.tokenKey = tok.AccessToken, credFile
+	e.tokenExpiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	return e.token, nil
+}
+
+// signServiceAccountJWT builds the RS256 assertion for the JWT-bearer grant.
+func signServiceAccountJWT(key serviceAccountKey, now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("credentials private_key is not PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if k, err1 := x509.ParsePKCS1PrivateKey(block.Bytes); err1 == nil {
+			parsed = k
+		} else {
+			return "", fmt.Errorf("parse private_key: %w", err)
+		}
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("credentials private_key is not RSA")
+	}
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   key.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	signing := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(signing))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", fmt.Errorf("sign jwt: %w", err)
+	}
+	return signing + "." + enc.EncodeToString(sig), nil
+}
+
+// formatGoogleSheetsConfig is the one-line reload description of c.
+func formatGoogleSheetsConfig(c *GoogleSheetsConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled(spreadsheet=%s, tabs=%s/%s)", c.SpreadsheetID, c.tradesTab(), c.equityTab())
+}
+
+func cloneGoogleSheetsConfig(c *GoogleSheetsConfig) *GoogleSheetsConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
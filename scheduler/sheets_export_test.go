@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type sheetsAppend struct {
+	Range  string
+	Values [][]any
+}
+
+// newSheetsTestServer fakes the token endpoint and values:append, and writes
+// a service-account key pointing at it.
+func newSheetsTestServer(t *testing.T) (*GoogleSheetsConfig, *sheetsExporter, func() []sheetsAppend, *int) {
+	t.Helper()
+	var mu sync.Mutex
+	var appends []sheetsAppend
+	tokenCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/token" {
+			tokenCalls++
+			if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || strings.Count(r.FormValue("assertion"), ".") != 2 {
+				http.Error(w, `{"error_description":"bad grant"}`, http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"access_token":"tok-1","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Values [][]any `json:"values"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		rng := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v4/spreadsheets/sheet-1/values/"), ":append")
+		appends = append(appends, sheetsAppend{Range: rng, Values: body.Values})
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	cred, _ := json.Marshal(serviceAccountKey{
+		ClientEmail: "exporter@example.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    srv.URL + "/token",
+	})
+	credFile := filepath.Join(t.TempDir(), "sa.json")
+	if err := os.WriteFile(credFile, cred, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	e := newSheetsExporter()
+	e.apiBase = srv.URL + "/v4/spreadsheets"
+	cfg := &GoogleSheetsConfig{Enabled: true, SpreadsheetID: "sheet-1", CredentialsFile: credFile}
+	return cfg, e, func() []sheetsAppend {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]sheetsAppend(nil), appends...)
+	}, &tokenCalls
+}
+
+func TestSheetsExportAppendsNewClosedTradesAndDailyEquity(t *testing.T) {
+	sdb := openTestDB(t)
+	cfg, e, appends, tokenCalls := newSheetsTestServer(t)
+	day1 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Pre-existing history is not backfilled.
+	if err := sdb.InsertTrade("s1", Trade{Timestamp: day1, StrategyID: "s1", Symbol: "BTC", Side: "sell", Quantity: 1, Price: 100, Value: 100, IsClose: true, RealizedPnL: 5}); err != nil {
+		t.Fatal(err)
+	}
+	e.Export(cfg, sdb, SheetsEquityRow{At: day1, TotalValue: 1100, InitialCapital: 1000})
+	got := appends()
+	if len(got) != 3 {
+		t.Fatalf("first export appended %d batches, want headers + equity (3): %+v", len(got), got)
+	}
+	if got[0].Range != "'Trades'!A1" || got[0].Values[0][0] != "Closed At (UTC)" {
+		t.Errorf("trades header = %+v", got[0])
+	}
+	if got[2].Range != "'Equity'!A1" || got[2].Values[0][0] != "2026-03-01" || got[2].Values[0][3] != 100.0 {
+		t.Errorf("equity row = %+v", got[2])
+	}
+
+	// One open (skipped) and one close since the cursor; same day → no equity.
+	sdb.InsertTrade("s1", Trade{Timestamp: day1, StrategyID: "s1", Symbol: "ETH", Side: "buy", Quantity: 2, Price: 10, Value: 20})
+	sdb.InsertTrade("s1", Trade{Timestamp: day1.Add(time.Hour), StrategyID: "s1", Symbol: "ETH", Side: "sell", Quantity: 2, Price: 12, Value: 24, IsClose: true, RealizedPnL: 4, Tags: map[string]string{TradeTagReason: "stop_loss"}})
+	e.Export(cfg, sdb, SheetsEquityRow{At: day1.Add(time.Hour), TotalValue: 1104, InitialCapital: 1000})
+	got = appends()
+	if len(got) != 4 {
+		t.Fatalf("second export: %d batches, want 4: %+v", len(got), got)
+	}
+	row := got[3].Values
+	if len(row) != 1 || row[0][2] != "ETH" || row[0][7] != 4.0 || row[0][10] != "stop_loss" {
+		t.Errorf("trade rows = %+v", row)
+	}
+
+	// Nothing new, next day → only the equity row.
+	e.Export(cfg, sdb, SheetsEquityRow{At: day1.Add(24 * time.Hour), TotalValue: 1110, InitialCapital: 1000})
+	got = appends()
+	if len(got) != 5 || got[4].Range != "'Equity'!A1" || got[4].Values[0][0] != "2026-03-02" {
+		t.Fatalf("third export: %+v", got)
+	}
+	if *tokenCalls != 1 {
+		t.Errorf("token exchanged %d times, want 1 (cached)", *tokenCalls)
+	}
+
+	// A restarted exporter resumes from the persisted cursor.
+	e2 := newSheetsExporter()
+	e2.apiBase = e.apiBase
+	e2.Export(cfg, sdb, SheetsEquityRow{At: day1.Add(25 * time.Hour)})
+	if n := len(appends()); n != 5 {
+		t.Errorf("restart re-exported rows: %d batches, want 5", n)
+	}
+}
+
+func TestValidateGoogleSheetsConfig(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	if errs := validateGoogleSheetsConfig(nil); errs != nil {
+		t.Errorf("nil: %v", errs)
+	}
+	if errs := validateGoogleSheetsConfig(&GoogleSheetsConfig{SpreadsheetID: ""}); errs != nil {
+		t.Errorf("disabled: %v", errs)
+	}
+	errs := validateGoogleSheetsConfig(&GoogleSheetsConfig{Enabled: true, TradesTab: "X", EquityTab: "X"})
+	if len(errs) != 3 {
+		t.Errorf("want 3 errors, got %v", errs)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/etc/sa.json")
+	if errs := validateGoogleSheetsConfig(&GoogleSheetsConfig{Enabled: true, SpreadsheetID: "abc"}); errs != nil {
+		t.Errorf("env credentials: %v", errs)
+	}
+}
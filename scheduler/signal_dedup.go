@@ -0,0 +1,128 @@
+package main
+
+// signal_dedup: duplicate signal suppression (#4965).
+//
+// The "already long" checks only look at the current position, so a signal
+// the strategy re-emits every cycle can re-open after a stop-loss or take-
+// profit closed the last entry, and options scripts that re-emit the same
+// open actions every run keep stacking legs. With signal_dedup_minutes set,
+// each strategy's last executed signal per symbol (and each executed option
+// open leg) is remembered; an identical position-increasing signal within
+// the window is suppressed. A different signal in between (e.g. the sell
+// that closed the position) resets the comparison, and exits are never
+// suppressed. All state is in-memory; a restart starts every strategy fresh.
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// signalDedupEntry is the last executed signal for one key.
+type signalDedupEntry struct {
+	fingerprint string
+	at          time.Time
+}
+
+// signalDedupTracker remembers executed signals by strategy/symbol key.
+type signalDedupTracker struct {
+	mu   sync.Mutex
+	last map[string]signalDedupEntry
+}
+
+// signalDedup is the package-level tracker; resets on restart.
+var signalDedup = &signalDedupTracker{}
+
+// Record stores fingerprint as key's last executed signal.
+func (t *signalDedupTracker) Record(key, fingerprint string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		t.last = make(map[string]signalDedupEntry)
+	}
+	t.last[key] = signalDedupEntry{fingerprint: fingerprint, at: now}
+}
+
+// Duplicate reports whether key's last executed signal is fingerprint and
+// was executed within window of now, returning its execution time.
+func (t *signalDedupTracker) Duplicate(key, fingerprint string, window time.Duration, now time.Time) (time.Time, bool) {
+	if window <= 0 {
+		return time.Time{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.last[key]
+	if !ok || e.fingerprint != fingerprint || now.Sub(e.at) > window {
+		return time.Time{}, false
+	}
+	return e.at, true
+}
+
+func signalDedupWindow(sc StrategyConfig) time.Duration {
+	return time.Duration(sc.SignalDedupMinutes) * time.Minute
+}
+
+func signalDedupFingerprint(signal int) string {
+	return fmt.Sprintf("signal=%d", signal)
+}
+
+func optionsDedupKey(sc StrategyConfig, underlying string, a OptionsAction) string {
+	return fmt.Sprintf("%s|%s|%s %s %g %s", sc.ID, underlying, a.Action, a.OptionType, a.Strike, a.Expiry)
+}
+
+// duplicateSignalReason returns why signal repeats sc's last executed signal
+// on symbol within signal_dedup_minutes ("" = not a duplicate, or disabled).
+// Callers only apply it to position-increasing signals.
+func duplicateSignalReason(sc StrategyConfig, symbol string, signal int) string {
+	if signal == 0 {
+		return ""
+	}
+	at, dup := signalDedup.Duplicate(sc.ID+"|"+symbol, signalDedupFingerprint(signal), signalDedupWindow(sc), time.Now().UTC())
+	if !dup {
+		return ""
+	}
+	return fmt.Sprintf("identical signal %d executed %s ago (signal_dedup_minutes %d)",
+		signal, time.Since(at).Round(time.Second), sc.SignalDedupMinutes)
+}
+
+// recordExecutedSignal remembers signal as sc's last executed signal on
+// symbol when it booked trades. Recorded even with dedup disabled so a
+// hot-reloaded window applies immediately.
+func recordExecutedSignal(sc StrategyConfig, symbol string, signal, trades int) {
+	if signal == 0 || trades <= 0 {
+		return
+	}
+	signalDedup.Record(sc.ID+"|"+symbol, signalDedupFingerprint(signal), time.Now().UTC())
+}
+
+// duplicateOptionsActions drops open actions identical (action, type,
+// strike, expiry) to an open leg sc executed within signal_dedup_minutes.
+// Close actions always pass.
+func duplicateOptionsActions(sc StrategyConfig, underlying string, actions []OptionsAction) (kept []OptionsAction, dropped int) {
+	window := signalDedupWindow(sc)
+	now := time.Now().UTC()
+	for _, a := range actions {
+		if a.Action != "close" {
+			if _, dup := signalDedup.Duplicate(optionsDedupKey(sc, underlying, a), "open", window, now); dup {
+				dropped++
+				continue
+			}
+		}
+		kept = append(kept, a)
+	}
+	return kept, dropped
+}
+
+// recordExecutedOptionsActions remembers the open actions of an options
+// result that booked trades.
+func recordExecutedOptionsActions(sc StrategyConfig, underlying string, actions []OptionsAction, trades int) {
+	if trades <= 0 {
+		return
+	}
+	now := time.Now().UTC()
+	for _, a := range actions {
+		if a.Action != "close" {
+			signalDedup.Record(optionsDedupKey(sc, underlying, a), "open", now)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignalDedupTracker(t *testing.T) {
+	tr := &signalDedupTracker{}
+	now := time.Unix(1700000000, 0).UTC()
+	window := 30 * time.Minute
+	if _, dup := tr.Duplicate("s|BTC", "signal=1", window, now); dup {
+		t.Fatal("nothing recorded yet")
+	}
+	tr.Record("s|BTC", "signal=1", now)
+	if _, dup := tr.Duplicate("s|BTC", "signal=1", window, now.Add(10*time.Minute)); !dup {
+		t.Error("identical signal within the window not flagged")
+	}
+	if _, dup := tr.Duplicate("s|BTC", "signal=1", window, now.Add(31*time.Minute)); dup {
+		t.Error("signal past the window flagged")
+	}
+	if _, dup := tr.Duplicate("s|BTC", "signal=1", 0, now); dup {
+		t.Error("disabled window flagged")
+	}
+	tr.Record("s|BTC", "signal=-1", now.Add(time.Minute))
+	if _, dup := tr.Duplicate("s|BTC", "signal=1", window, now.Add(2*time.Minute)); dup {
+		t.Error("a different signal in between must reset the comparison")
+	}
+}
+
+func TestDuplicateSignalReason(t *testing.T) {
+	orig := signalDedup
+	t.Cleanup(func() { signalDedup = orig })
+	signalDedup = &signalDedupTracker{}
+	sc := StrategyConfig{ID: "hl-btc", SignalDedupMinutes: 60}
+	recordExecutedSignal(sc, "BTC", 1, 0)
+	if why := duplicateSignalReason(sc, "BTC", 1); why != "" {
+		t.Fatalf("signal with no trades recorded: %q", why)
+	}
+	recordExecutedSignal(sc, "BTC", 1, 1)
+	if why := duplicateSignalReason(sc, "BTC", 1); !strings.Contains(why, "signal_dedup_minutes 60") {
+		t.Errorf("repeat not flagged: %q", why)
+	}
+	if why := duplicateSignalReason(sc, "ETH", 1); why != "" {
+		t.Errorf("other symbol flagged: %q", why)
+	}
+	sc.SignalDedupMinutes = 0
+	if why := duplicateSignalReason(sc, "BTC", 1); why != "" {
+		t.Errorf("disabled dedup flagged: %q", why)
+	}
+}
+
+func TestDuplicateOptionsActions(t *testing.T) {
+	orig := signalDedup
+	t.Cleanup(func() { signalDedup = orig })
+	signalDedup = &signalDedupTracker{}
+	sc := StrategyConfig{ID: "deribit-vol-btc", Type: "options", SignalDedupMinutes: 240}
+	put := OptionsAction{Action: "sell", OptionType: "put", Strike: 55000, Expiry: "2026-11-27"}
+	call := OptionsAction{Action: "sell", OptionType: "call", Strike: 70000, Expiry: "2026-11-27"}
+	recordExecutedOptionsActions(sc, "BTC", []OptionsAction{put}, 1)
+
+	closePut := put
+	closePut.Action = "close"
+	kept, dropped := duplicateOptionsActions(sc, "BTC", []OptionsAction{put, call, closePut})
+	if dropped != 1 || len(kept) != 2 || kept[0].OptionType != "call" || kept[1].Action != "close" {
+		t.Fatalf("kept=%+v dropped=%d, want the repeated put open dropped", kept, dropped)
+	}
+	sc.SignalDedupMinutes = 0
+	if _, dropped := duplicateOptionsActions(sc, "BTC", []OptionsAction{put}); dropped != 0 {
+		t.Error("disabled dedup dropped an action")
+	}
+}
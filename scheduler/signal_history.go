@@ -0,0 +1,281 @@
+package main
+
+// signal_history: every check-script signal, traded or not (#4954).
+//
+// Trades only record signals that filled; a HOLD, or a BUY that a risk gate
+// suppressed, used to leave nothing behind but a log line. Each successful
+// check now opens a pending record (Begin) with the script's raw signal; the
+// gates that zero it name themselves (Block); and the dispatcher closes it
+// (Finish) with the gated signal and the number of trades booked. Finished
+// records are appended to the state DB's signal_history table, pruned to
+// signal_history_days, and served newest-first at GET /signals.
+//
+// Outcomes: "executed" (trades booked), "blocked" (a gate held it; reason
+// names the first gate), "hold" (the script said 0), "no_trade" (a signal
+// reached the executor but booked nothing, e.g. already positioned) and
+// "not_executed" (never reached the executor — live order failure; closed
+// when the strategy's next check begins). Best-effort: a DB failure is
+// logged and never blocks the cycle.
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Signal outcomes.
+const (
+	SignalOutcomeExecuted    = "executed"
+	SignalOutcomeBlocked     = "blocked"
+	SignalOutcomeHold        = "hold"
+	SignalOutcomeNoTrade     = "no_trade"
+	SignalOutcomeNotExecuted = "not_executed"
+)
+
+// defaultSignalHistoryDays is the retention when signal_history_days is unset.
+const defaultSignalHistoryDays = 14
+
+const (
+	signalHistoryAPIDefaultLimit = 100
+	signalHistoryAPIMaxLimit     = 1000
+)
+
+// signalHistoryPruneInterval throttles the retention DELETE. Var so tests
+// can shrink it.
+var signalHistoryPruneInterval = time.Hour
+
+// SignalRecord is one evaluated signal.
+type SignalRecord struct {
+	ID         int64     `json:"id"`
+	Time       time.Time `json:"time"`
+	StrategyID string    `json:"strategy_id"`
+	Symbol     string    `json:"symbol"`
+	Signal     int       `json:"signal"`    // script output before gates
+	Effective  int       `json:"effective"` // signal handed to the executor
+	Outcome    string    `json:"outcome"`   // see SignalOutcome*
+	Reason     string    `json:"reason"`    // first gate that held it ("" unless blocked)
+	Price      float64   `json:"price"`     // price the check evaluated at
+	Trades     int       `json:"trades"`    // trades booked from this signal
+}
+
+// SignalHistoryRetentionDays returns cfg.SignalHistoryDays, defaulting to
+// defaultSignalHistoryDays when unset. 0 disables recording.
+func SignalHistoryRetentionDays(cfg *Config) int {
+	if cfg == nil || cfg.SignalHistoryDays == nil {
+		return defaultSignalHistoryDays
+	}
+	return *cfg.SignalHistoryDays
+}
+
+// signalHistoryRecorder tracks each strategy's in-flight signal and appends
+// finished ones to the state DB.
+type signalHistoryRecorder struct {
+	mu            sync.Mutex
+	db            *StateDB
+	retentionDays int
+	pending       map[string]*SignalRecord
+	lastPrune     time.Time
+	now           func() time.Time
+}
+
+// signalHistory is the package-level recorder, wired in main. nil disables
+// recording (tests, CLI subcommands, signal_history_days=0); every method is
+// nil-safe.
+var signalHistory *signalHistoryRecorder
+
+// newSignalHistoryRecorder returns a recorder writing to db, or nil when db
+// is nil or retention is disabled.
+func newSignalHistoryRecorder(db *StateDB, retentionDays int) *signalHistoryRecorder {
+	if db == nil || retentionDays <= 0 {
+		return nil
+	}
+	return &signalHistoryRecorder{db: db, retentionDays: retentionDays, pending: make(map[string]*SignalRecord), now: time.Now}
+}
+
+// Begin opens strategyID's record for this check. A record the previous
+// check never finished is flushed first as not_executed.
+func (r *signalHistoryRecorder) Begin(strategyID, symbol string, signal int, price float64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev := r.pending[strategyID]; prev != nil {
+		prev.Outcome = SignalOutcomeNotExecuted
+		prev.Effective = 0
+		r.appendLocked(prev)
+	}
+	r.pending[strategyID] = &SignalRecord{
+		Time:       r.now().UTC(),
+		StrategyID: strategyID,
+		Symbol:     symbol,
+		Signal:     signal,
+		Effective:  signal,
+		Price:      price,
+	}
+}
+
+// Block records the gate that held strategyID's signal. The first gate wins.
+func (r *signalHistoryRecorder) Block(strategyID, reason string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec := r.pending[strategyID]; rec != nil && rec.Reason == "" {
+		rec.Reason = reason
+	}
+}
+
+// Finish closes strategyID's record with the gated signal the executor saw
+// and the trades it booked.
+func (r *signalHistoryRecorder) Finish(strategyID string, effective, trades int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.pending[strategyID]
+	if rec == nil {
+		return
+	}
+	delete(r.pending, strategyID)
+	rec.Effective = effective
+	rec.Trades = trades
+	rec.Outcome = signalOutcome(rec)
+	r.appendLocked(rec)
+}
+
+// signalOutcome classifies a finished record.
+func signalOutcome(rec *SignalRecord) string {
+	switch {
+	case rec.Trades > 0:
+		return SignalOutcomeExecuted
+	case rec.Reason != "":
+		return SignalOutcomeBlocked
+	case rec.Signal == 0:
+		return SignalOutcomeHold
+	case rec.Effective == 0:
+		// Zeroed without a named gate (e.g. an ensemble member's vote).
+		rec.Reason = "suppressed"
+		return SignalOutcomeBlocked
+	default:
+		return SignalOutcomeNoTrade
+	}
+}
+
+// appendLocked inserts rec and prunes past retention at most once per
+// signalHistoryPruneInterval. Caller holds r.mu.
+func (r *signalHistoryRecorder) appendLocked(rec *SignalRecord) {
+	if err := r.db.InsertSignalRecord(rec); err != nil {
+		fmt.Printf("[WARN] signal history: insert for %s failed: %v\n", rec.StrategyID, err)
+		return
+	}
+	now := r.now().UTC()
+	if now.Sub(r.lastPrune) < signalHistoryPruneInterval {
+		return
+	}
+	r.lastPrune = now
+	cutoff := now.AddDate(0, 0, -r.retentionDays).Format(time.RFC3339)
+	if err := r.db.PruneSignalHistory(cutoff); err != nil {
+		fmt.Printf("[WARN] signal history: retention prune failed: %v\n", err)
+	}
+}
+
+// InsertSignalRecord appends one finished signal record.
+func (sdb *StateDB) InsertSignalRecord(rec *SignalRecord) error {
+	res, err := sdb.db.Exec(`INSERT INTO signal_history
+		(ts, strategy_id, symbol, signal, effective, outcome, reason, price, trades)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.Time.UTC().Format(time.RFC3339), rec.StrategyID, rec.Symbol, rec.Signal, rec.Effective,
+		rec.Outcome, rec.Reason, rec.Price, rec.Trades)
+	if err != nil {
+		return err
+	}
+	rec.ID, _ = res.LastInsertId()
+	return nil
+}
+
+// PruneSignalHistory deletes records older than cutoffTS (RFC3339 —
+// lexicographic order matches time order).
+func (sdb *StateDB) PruneSignalHistory(cutoffTS string) error {
+	_, err := sdb.db.Exec(`DELETE FROM signal_history WHERE ts < ?`, cutoffTS)
+	return err
+}
+
+// SignalRecords returns records newest first, optionally filtered by
+// strategy and outcome.
+func (sdb *StateDB) SignalRecords(strategyID, outcome string, limit int) ([]SignalRecord, error) {
+	query := `SELECT id, ts, strategy_id, symbol, signal, effective, outcome, reason, price, trades FROM signal_history WHERE 1=1`
+	var args []any
+	if strategyID != "" {
+		query += ` AND strategy_id = ?`
+		args = append(args, strategyID)
+	}
+	if outcome != "" {
+		query += ` AND outcome = ?`
+		args = append(args, outcome)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+	rows, err := sdb.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []SignalRecord
+	for rows.Next() {
+		var rec SignalRecord
+		var ts string
+		if err := rows.Scan(&rec.ID, &ts, &rec.StrategyID, &rec.Symbol, &rec.Signal, &rec.Effective,
+			&rec.Outcome, &rec.Reason, &rec.Price, &rec.Trades); err != nil {
+			return nil, err
+		}
+		rec.Time, _ = time.Parse(time.RFC3339, ts)
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+// handleSignals serves GET /signals?strategy=&outcome=&limit= (same
+// status_token auth as /history).
+func (ss *StatusServer) handleSignals(w http.ResponseWriter, r *http.Request) {
+	if token := ss.currentStatusToken(); token != "" {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+	}
+	if ss.stateDB == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "state DB not available")
+		return
+	}
+	q := r.URL.Query()
+	outcome := q.Get("outcome")
+	switch outcome {
+	case "", SignalOutcomeExecuted, SignalOutcomeBlocked, SignalOutcomeHold, SignalOutcomeNoTrade, SignalOutcomeNotExecuted:
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("unknown outcome %q", outcome))
+		return
+	}
+	limit := signalHistoryAPIDefaultLimit
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(n, signalHistoryAPIMaxLimit)
+	}
+	records, err := ss.stateDB.SignalRecords(q.Get("strategy"), outcome, limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if records == nil {
+		records = []SignalRecord{}
+	}
+	writeJSON(w, map[string]any{"signals": records})
+}
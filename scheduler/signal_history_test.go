@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSignalHistoryRecorder_Outcomes(t *testing.T) {
+	db := openTestDB(t)
+	r := newSignalHistoryRecorder(db, 14)
+	r.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+
+	r.Begin("a", "BTC", 0, 50000)
+	r.Finish("a", 0, 0)
+	r.Begin("b", "BTC", 1, 50000)
+	r.Block("b", "regime_gate")
+	r.Block("b", "paused") // later gates don't overwrite the first
+	r.Finish("b", 0, 0)
+	r.Begin("c", "ETH", -1, 3000)
+	r.Finish("c", -1, 1)
+	r.Begin("d", "ETH", 1, 3000)
+	r.Finish("d", 1, 0)
+	r.Begin("e", "SOL", 1, 150)
+	r.Finish("e", 0, 0)
+	r.Begin("f", "SOL", 1, 150) // live order failed: never finished
+	r.Begin("f", "SOL", 0, 151)
+
+	recs, err := db.SignalRecords("", "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]SignalRecord{}
+	for _, rec := range recs {
+		if _, seen := got[rec.StrategyID]; !seen {
+			got[rec.StrategyID] = rec
+		}
+	}
+	want := map[string][2]string{
+		"a": {SignalOutcomeHold, ""},
+		"b": {SignalOutcomeBlocked, "regime_gate"},
+		"c": {SignalOutcomeExecuted, ""},
+		"d": {SignalOutcomeNoTrade, ""},
+		"e": {SignalOutcomeBlocked, "suppressed"},
+		"f": {SignalOutcomeNotExecuted, ""},
+	}
+	for id, w := range want {
+		if rec := got[id]; rec.Outcome != w[0] || rec.Reason != w[1] {
+			t.Errorf("%s: outcome/reason = %q/%q, want %q/%q", id, rec.Outcome, rec.Reason, w[0], w[1])
+		}
+	}
+	if got["c"].Trades != 1 || got["c"].Signal != -1 || got["c"].Price != 3000 {
+		t.Errorf("c = %+v", got["c"])
+	}
+}
+
+func TestSignalHistoryRecorder_PrunesPastRetention(t *testing.T) {
+	db := openTestDB(t)
+	r := newSignalHistoryRecorder(db, 1)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	r.Begin("a", "BTC", 0, 1)
+	r.Finish("a", 0, 0)
+	now = now.Add(72 * time.Hour)
+	r.Begin("a", "BTC", 0, 1)
+	r.Finish("a", 0, 0)
+	recs, err := db.SignalRecords("a", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || !recs[0].Time.Equal(now) {
+		t.Errorf("after prune = %+v, want only the newest record", recs)
+	}
+}
+
+func TestSignalHistoryRecorder_NilSafe(t *testing.T) {
+	var r *signalHistoryRecorder
+	r.Begin("a", "BTC", 1, 1)
+	r.Block("a", "paused")
+	r.Finish("a", 0, 0)
+	if newSignalHistoryRecorder(nil, 14) != nil || newSignalHistoryRecorder(openTestDB(t), 0) != nil {
+		t.Error("nil DB or zero retention should disable recording")
+	}
+}
+
+func TestHandleSignals_FiltersAndValidates(t *testing.T) {
+	db := openTestDB(t)
+	r := newSignalHistoryRecorder(db, 14)
+	r.Begin("a", "BTC", 1, 50000)
+	r.Block("a", "var_limit")
+	r.Finish("a", 0, 0)
+	r.Begin("b", "ETH", 0, 3000)
+	r.Finish("b", 0, 0)
+
+	var mu sync.RWMutex
+	ss := NewStatusServer(&AppState{}, &mu, "", nil, db)
+	rec := httptest.NewRecorder()
+	ss.handleSignals(rec, httptest.NewRequest(http.MethodGet, "/signals?outcome=blocked", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var body struct {
+		Signals []SignalRecord `json:"signals"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Signals) != 1 || body.Signals[0].StrategyID != "a" || body.Signals[0].Reason != "var_limit" {
+		t.Errorf("signals = %+v", body.Signals)
+	}
+
+	for _, q := range []string{"outcome=bogus", "limit=0", "limit=x"} {
+		rec := httptest.NewRecorder()
+		ss.handleSignals(rec, httptest.NewRequest(http.MethodGet, "/signals?"+q, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", q, rec.Code)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
 )
 
 // stateDBLock holds an exclusive advisory lock on <DBFile>.lock for the
@@ -16,8 +15,9 @@ import (
 // from silently running against the same state DB / exchange account, which
 // would book duplicate trades and desync on-chain positions.
 //
-// The lock is a kernel flock() on an open file descriptor — never a
-// "write PID to a file, refuse if the file exists" scheme. The distinction is
+// The lock is a kernel flock() (LockFileEx on Windows, via tryLockFile) on an
+// open file descriptor — never a "write PID to a file, refuse if the file
+// exists" scheme. The distinction is
 // load-bearing for deploy safety: the OS auto-releases an fd lock when the
 // process dies (including SIGKILL/crash), so a hard-killed daemon leaves no
 // stale lock and the next start always succeeds. A pidfile-content check would
@@ -37,6 +37,10 @@ type stateDBLock struct {
 // never read back — the OS releases the lock on process exit.
 var heldStateDBLock *stateDBLock
 
+// errFileLocked is tryLockFile's "another holder has it" result
+// (process_unix.go / process_windows.go).
+var errFileLocked = errors.New("file is locked by another holder")
+
 // stateDBLockedError is returned by acquireStateDBLock when the lock is already
 // held by another live process. PID is the value the holder recorded in the
 // lock file (best-effort, for the operator message only — the lock decision is
@@ -66,17 +70,22 @@ func stateDBLockPath(dbPath string) string {
 // canonicalDBPath resolves dbPath to an absolute, symlink-free form so distinct
 // strings naming the same file map to the same lock path. Resolution is
 // best-effort and degrades to the most-resolved form available: if the DB file
-// (or a path component) doesn't exist yet, EvalSymlinks fails and we keep the
-// Abs form; if even Abs fails we keep the raw string. The guard must never
-// refuse to start merely because the path couldn't be canonicalized — the
-// fallback just reverts to the pre-hardening raw-string behavior.
+// doesn't exist yet (the daemon locks before OpenStateDB creates it, #4984),
+// its directory is resolved instead so the first and later starts agree; if
+// that fails too we keep the Abs form, and if even Abs fails the raw string.
+// The guard must never refuse to start merely because the path couldn't be
+// canonicalized — the fallback just reverts to the pre-hardening raw-string
+// behavior.
 func canonicalDBPath(dbPath string) string {
 	resolved := dbPath
 	if abs, err := filepath.Abs(resolved); err == nil {
 		resolved = abs
 	}
 	if eval, err := filepath.EvalSymlinks(resolved); err == nil {
-		resolved = eval
+		return eval
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(resolved)); err == nil {
+		return filepath.Join(dir, filepath.Base(resolved))
 	}
 	return resolved
 }
@@ -88,17 +97,21 @@ func canonicalDBPath(dbPath string) string {
 // create the lock file, unexpected flock errno) return a wrapped error.
 func acquireStateDBLock(dbPath string) (*stateDBLock, error) {
 	lockPath := stateDBLockPath(dbPath)
+	// The daemon locks before OpenStateDB has created the DB directory.
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("create lock dir: %w", err)
+	}
 	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
 	if err != nil {
 		return nil, fmt.Errorf("open lock file %s: %w", lockPath, err)
 	}
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
-		// LOCK_NB returns EWOULDBLOCK (== EAGAIN on Linux) when another process
-		// holds the lock. Read the holder's recorded PID for the message, then
-		// release our fd so we don't leak it on the exit path.
+	if err := tryLockFile(f); err != nil {
+		// errFileLocked when another process holds the lock. Read the holder's
+		// recorded PID for the message, then release our fd so we don't leak
+		// it on the exit path.
 		pid := readLockPID(f)
 		f.Close()
-		if errors.Is(err, syscall.EWOULDBLOCK) {
+		if errors.Is(err, errFileLocked) {
 			return nil, &stateDBLockedError{path: lockPath, pid: pid}
 		}
 		return nil, fmt.Errorf("flock %s: %w", lockPath, err)
@@ -156,3 +169,30 @@ func readLockPID(f *os.File) int {
 	}
 	return pid
 }
+
+// holdsStateDBLock reports whether this process already holds the singleton
+// lock for dbPath (taken by main before the state DB is opened), so
+// OpenStateDB must not try to take it again on a second descriptor.
+func holdsStateDBLock(dbPath string) bool {
+	return heldStateDBLock != nil && heldStateDBLock.f != nil && heldStateDBLock.path == stateDBLockPath(dbPath)
+}
+
+// refuseSecondScheduler reports a failed singleton lock on stderr and to the
+// owner DM, then exits with ExitSingletonLock. Called before the state DB is
+// opened, so the refused process has written nothing.
+func refuseSecondScheduler(cfg *Config, lockErr error) {
+	var msg string
+	var locked *stateDBLockedError
+	if errors.As(lockErr, &locked) {
+		msg = fmt.Sprintf("CRITICAL: %s — refusing to start so this process can't overwrite its state saves or double-trade against the same state DB. If no other go-trader is actually running, the lock auto-releases on exit; check `pgrep -af go-trader`.", locked.Error())
+	} else {
+		msg = fmt.Sprintf("CRITICAL: could not acquire state DB lock: %v — refusing to start.", lockErr)
+	}
+	fmt.Fprintln(os.Stderr, "[singleton] "+msg)
+	notifier, cleanup := buildNotifierFromConfig(cfg)
+	if notifier.HasOwner() {
+		notifier.SendOwnerDM("**Singleton guard** — " + msg)
+	}
+	cleanup()
+	os.Exit(ExitSingletonLock)
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"syscall"
 	"testing"
 )
 
@@ -100,8 +99,6 @@ func TestAcquireStateDBLock_DistinctPaths(t *testing.T) {
 func TestAcquireStateDBLock_SymlinkedPathContends(t *testing.T) {
 	dir := t.TempDir()
 	realDB := filepath.Join(dir, "state.db")
-	// The DB file must exist for EvalSymlinks to resolve the path (in production
-	// the state DB is opened before the lock is taken).
 	if err := os.WriteFile(realDB, nil, 0o644); err != nil {
 		t.Fatalf("create db file: %v", err)
 	}
@@ -132,6 +129,62 @@ func TestAcquireStateDBLock_SymlinkedPathContends(t *testing.T) {
 	}
 }
 
+func TestAcquireStateDBLock_BeforeDBExists(t *testing.T) {
+	// The scheduler locks before the state DB is opened (#4984), so on a first
+	// start neither the DB nor its directory exists yet. A symlinked directory
+	// must still collapse to the same lock as its target.
+	root := t.TempDir()
+	realDir := filepath.Join(root, "data")
+	linkDir := filepath.Join(root, "data-alias")
+	if err := os.Mkdir(realDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(realDir, linkDir); err != nil {
+		t.Skipf("symlinks unavailable on this platform: %v", err)
+	}
+
+	first, err := acquireStateDBLock(filepath.Join(realDir, "state.db"))
+	if err != nil {
+		t.Fatalf("acquire with missing DB failed: %v", err)
+	}
+	defer first.Release()
+	second, err := acquireStateDBLock(filepath.Join(linkDir, "state.db"))
+	if err == nil {
+		second.Release()
+		t.Fatal("missing DB reached through a symlinked dir got its own lock")
+	}
+
+	nested := filepath.Join(root, "fresh", "state.db")
+	lock, err := acquireStateDBLock(nested)
+	if err != nil {
+		t.Fatalf("acquire in missing directory failed: %v", err)
+	}
+	lock.Release()
+}
+
+func TestHoldsStateDBLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	old := heldStateDBLock
+	defer func() { heldStateDBLock = old }()
+
+	heldStateDBLock = nil
+	if holdsStateDBLock(dbPath) {
+		t.Error("reported held with no lock")
+	}
+	lock, err := acquireStateDBLock(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	heldStateDBLock = lock
+	if !holdsStateDBLock(dbPath) {
+		t.Error("held lock not reported")
+	}
+	if holdsStateDBLock(filepath.Join(t.TempDir(), "other.db")) {
+		t.Error("lock reported for a different DB")
+	}
+}
+
 func TestAcquireStateDBLock_StaleFdReleasesOnClose(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "state.db")
 	lockPath := stateDBLockPath(dbPath)
@@ -143,7 +196,7 @@ func TestAcquireStateDBLock_StaleFdReleasesOnClose(t *testing.T) {
 	if err != nil {
 		t.Fatalf("open lock file: %v", err)
 	}
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+	if err := tryLockFile(f); err != nil {
 		t.Fatalf("seed flock: %v", err)
 	}
 	f.Close() // kernel releases the flock here
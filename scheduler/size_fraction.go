@@ -0,0 +1,58 @@
+package main
+
+// size_fraction: strategy-expressed conviction sizing.
+//
+// Check scripts may emit "size_fraction" (or "confidence") in (0, 1] next to
+// the signal. When the strategy config opts in with a size_fraction block,
+// the open is scaled by that fraction, clamped to [min, max]; a missing or
+// non-positive value means full size (still capped at max). Without the
+// block the fields are ignored, so existing strategies size exactly as
+// before.
+//
+// Scaling is applied where each executor sizes a fresh open: the perps
+// notional (PerpsOpenNotionalSized, live and paper, HL and OKX) and the paper
+// generic spot cash budget (including the allow_short and dca paths).
+// Closes are never scaled.
+
+import "fmt"
+
+// resolveSizeFraction returns the fraction of a full-size open to use for
+// this result, or 1 when the strategy has not opted in.
+func resolveSizeFraction(sc StrategyConfig, f StrategyDecisionFields) float64 {
+	if sc.SizeFraction == nil {
+		return 1
+	}
+	lo, hi := sc.SizeFraction.bounds()
+	v := f.SizeFraction
+	if v <= 0 {
+		v = f.Confidence
+	}
+	if v <= 0 || v > 1 {
+		v = 1
+	}
+	if v < lo {
+		v = lo
+	}
+	if v > hi {
+		v = hi
+	}
+	return v
+}
+
+// bounds returns the effective [min, max] clamp (max defaults to 1).
+func (c *SizeFractionConfig) bounds() (float64, float64) {
+	hi := c.Max
+	if hi <= 0 {
+		hi = 1
+	}
+	return c.Min, hi
+}
+
+// sizeFractionLabel renders a size_fraction block for the hot-reload diff.
+func sizeFractionLabel(c *SizeFractionConfig) string {
+	if c == nil {
+		return "off"
+	}
+	lo, hi := c.bounds()
+	return fmt.Sprintf("[%.2f, %.2f]", lo, hi)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestResolveSizeFraction(t *testing.T) {
+	on := StrategyConfig{SizeFraction: &SizeFractionConfig{Min: 0.25, Max: 0.8}}
+	cases := []struct {
+		name string
+		sc   StrategyConfig
+		in   StrategyDecisionFields
+		want float64
+	}{
+		{"not opted in ignores the field", StrategyConfig{}, StrategyDecisionFields{SizeFraction: 0.3}, 1},
+		{"in range", on, StrategyDecisionFields{SizeFraction: 0.5}, 0.5},
+		{"floored", on, StrategyDecisionFields{SizeFraction: 0.1}, 0.25},
+		{"capped", on, StrategyDecisionFields{SizeFraction: 0.95}, 0.8},
+		{"missing means full size, capped", on, StrategyDecisionFields{}, 0.8},
+		{"confidence fallback", on, StrategyDecisionFields{Confidence: 0.4}, 0.4},
+		{"size_fraction wins", on, StrategyDecisionFields{SizeFraction: 0.6, Confidence: 0.3}, 0.6},
+		{"default max is 1", StrategyConfig{SizeFraction: &SizeFractionConfig{}}, StrategyDecisionFields{}, 1},
+	}
+	for _, tc := range cases {
+		if got := resolveSizeFraction(tc.sc, tc.in); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	var res SpotResult
+	if err := json.Unmarshal([]byte(`{"signal":1,"size_fraction":0.5,"confidence":0.7}`), &res); err != nil || res.SizeFraction != 0.5 || res.Confidence != 0.7 {
+		t.Errorf("decode: %+v, %v", res.StrategyDecisionFields, err)
+	}
+}
+
+func TestSizeFraction_ScalesOpens(t *testing.T) {
+	full := PerpsOpenNotionalSized(1000, 100, PerpsSizing{SizingLeverage: 2, ExchangeLeverage: 2})
+	half := PerpsOpenNotionalSized(1000, 100, PerpsSizing{SizingLeverage: 2, ExchangeLeverage: 2}.withSizeFraction(0.5))
+	if full != 2000 || half != 1000 {
+		t.Errorf("perps notional full=%v half=%v", full, half)
+	}
+
+	s := NewStrategyState(StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000})
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("sma-btc")
+	exec, err := ExecuteSpotPaperSignalSizedDeferredOpen(s, 1, "BTC/USDT", 100, 0.4, 0, logger)
+	if err != nil || exec.OpenTrade == nil {
+		t.Fatalf("open: %+v, %v", exec, err)
+	}
+	pos := s.Positions["BTC/USDT"]
+	if notional := pos.Quantity * pos.AvgCost; math.Abs(notional-400) > 1e-6 {
+		t.Errorf("spot open notional = %v, want 400", notional)
+	}
+	if math.Abs(s.Cash-(600-exec.OpenTrade.ExchangeFee)) > 1e-6 {
+		t.Errorf("cash = %v", s.Cash)
+	}
+}
+
+func TestValidateConfig_SizeFraction(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{
+				ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+				Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				SizeFraction: &SizeFractionConfig{Min: 0.9, Max: 0.5},
+			},
+			{
+				ID: "rh-btc", Type: "spot", Platform: "robinhood", Script: "shared_scripts/check_robinhood.py",
+				Args: []string{"sma_crossover", "BTC", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+				SizeFraction: &SizeFractionConfig{Max: 2},
+			},
+		},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"size_fraction.min (0.9) must be <= max (0.5)", "size_fraction is only supported for perps and generic spot", "size_fraction.max must be in [0, 1]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+// slippage_guard: max-slippage guard on live fills (#4959).
+//
+// Live fills through a fast market can land far from the signal price the
+// strategy (and the paper math) assumed. When a strategy sets
+// max_slippage_pct, every successful live execute compares the fill's avg
+// price with the cycle's signal price; an adverse deviation beyond the limit
+// alerts the channels and the owner. With flatten_on_slippage (HL perps
+// only), a fresh open that breached the limit is market-closed right after it
+// is booked, so the strategy never sits in an entry it would not have taken.
+// Favourable slippage is never flagged.
+
+import (
+	"fmt"
+	"sync"
+)
+
+// slippageFlattenCloseFn submits the reduce-only close that flattens a
+// slipped entry. Package var so tests can stub the Python close script.
+var slippageFlattenCloseFn = func(symbol string, partialSz *float64, cancelOIDs []int64) (*HyperliquidCloseResult, string, error) {
+	return RunHyperliquidClose(hyperliquidLiveCloseScript, symbol, partialSz, cancelOIDs)
+}
+
+// liveFillSlippagePct returns how much worse fillPx is than signalPx, in
+// percent of signalPx: positive when a buy paid more or a sell received
+// less, negative when the fill was better. 0 when either price is unknown.
+func liveFillSlippagePct(side string, signalPx, fillPx float64) float64 {
+	if signalPx <= 0 || fillPx <= 0 {
+		return 0
+	}
+	pct := (fillPx - signalPx) / signalPx * 100
+	if side == "sell" {
+		pct = -pct
+	}
+	return pct
+}
+
+// checkLiveFillSlippage alerts when a live fill breached sc.MaxSlippagePct.
+// Returns true on a breach; a strategy without the guard never breaches.
+func checkLiveFillSlippage(sc StrategyConfig, symbol, side string, signalPx, fillPx float64, notifier *MultiNotifier, logger *StrategyLogger) bool {
+	if sc.MaxSlippagePct == nil || *sc.MaxSlippagePct <= 0 {
+		return false
+	}
+	slip := liveFillSlippagePct(side, signalPx, fillPx)
+	if slip <= *sc.MaxSlippagePct {
+		return false
+	}
+	logger.Warn("Live %s %s filled at $%.4f vs signal $%.4f — slippage %.2f%% exceeds max_slippage_pct %.2f%%",
+		side, symbol, fillPx, signalPx, slip, *sc.MaxSlippagePct)
+	if notifier != nil && notifier.HasBackends() {
+		msg := formatSlippageAlert(sc, symbol, side, signalPx, fillPx, slip)
+		notifier.SendToAllChannels(msg)
+		notifier.SendOwnerDM(msg)
+	}
+	return true
+}
+
+func formatSlippageAlert(sc StrategyConfig, symbol, side string, signalPx, fillPx, slip float64) string {
+	return fmt.Sprintf("**LIVE SLIPPAGE** [%s] %s %s %s filled at $%.4f vs signal $%.4f: %.2f%% adverse (max %.2f%%).",
+		sc.ID, sc.Platform, side, symbol, fillPx, signalPx, slip, *sc.MaxSlippagePct)
+}
+
+// flattenSlippedHyperliquidEntry market-closes the position a slipped fresh
+// open just created and books the close. Caller must NOT hold mu. Returns the
+// number of trades booked and a detail line for the cycle log.
+func flattenSlippedHyperliquidEntry(sc StrategyConfig, s *StrategyState, symbol string, mu *sync.RWMutex, notifier *MultiNotifier, logger *StrategyLogger) (int, string) {
+	mu.RLock()
+	pos, ok := s.Positions[symbol]
+	var qty float64
+	var cancelOIDs []int64
+	if ok && pos != nil {
+		qty = pos.Quantity
+		cancelOIDs = appendUniquePositiveStopLossOID(cancelOIDs, pos.StopLossOID)
+		for _, oid := range pos.TPOIDs {
+			cancelOIDs = appendUniquePositiveStopLossOID(cancelOIDs, oid)
+		}
+	}
+	mu.RUnlock()
+	if qty <= 0 {
+		return 0, ""
+	}
+
+	logger.Warn("flatten_on_slippage: closing %.6f %s opened through the slippage limit", qty, symbol)
+	// Sized close (not sz=None) so a coin-sharing peer's exposure survives.
+	res, stderr, err := slippageFlattenCloseFn(symbol, &qty, cancelOIDs)
+	if stderr != "" {
+		logger.Info("slippage flatten stderr: %s", stderr)
+	}
+	if err != nil || res == nil || res.Close == nil || res.Close.Fill == nil || res.Close.Fill.TotalSz <= 0 {
+		reason := "no fill reported"
+		if err != nil {
+			reason = err.Error()
+		}
+		logger.Error("flatten_on_slippage close failed for %s: %s — position left open", symbol, reason)
+		if notifier != nil && notifier.HasBackends() {
+			msg := fmt.Sprintf("**SLIPPAGE FLATTEN FAILED** [%s] %s position is still OPEN: %s", sc.ID, symbol, reason)
+			notifier.SendToAllChannels(msg)
+			notifier.SendOwnerDM(msg)
+		}
+		return 0, ""
+	}
+
+	fill := res.Close.Fill
+	mu.Lock()
+	applyHyperliquidCircuitCloseFill(s, symbol, fill.TotalSz, fill.AvgPx, fill.Fee, 0, fill.OID, "slippage_guard")
+	if p, ok := s.Positions[symbol]; ok && p != nil {
+		// A short close fill leaves a remainder whose cancelled triggers must
+		// be forgotten so the next protection sync re-arms them.
+		slOID, tpOIDs := forceCloseCanceledProtectionSnapshot(p, hyperliquidSucceededCancelOIDs(res, cancelOIDs))
+		clearForceCloseCanceledProtectionOIDs(p, slOID, tpOIDs)
+	}
+	mu.Unlock()
+	detail := fmt.Sprintf("Flattened %.6f %s @ $%.4f after slippage breach", fill.TotalSz, symbol, fill.AvgPx)
+	logger.Info("%s", detail)
+	if notifier != nil && notifier.HasBackends() {
+		msg := fmt.Sprintf("**SLIPPAGE FLATTEN** [%s] %s: closed %.6f @ $%.4f", sc.ID, symbol, fill.TotalSz, fill.AvgPx)
+		notifier.SendToAllChannels(msg)
+		notifier.SendOwnerDM(msg)
+	}
+	return 1, detail
+}
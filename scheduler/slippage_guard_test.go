@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestLiveFillSlippagePct(t *testing.T) {
+	cases := []struct {
+		side         string
+		signal, fill float64
+		want         float64
+	}{
+		{"buy", 2000, 2020, 1},
+		{"buy", 2000, 1980, -1},
+		{"sell", 2000, 1980, 1},
+		{"sell", 2000, 2020, -1},
+		{"buy", 0, 2020, 0},
+	}
+	for _, c := range cases {
+		if got := liveFillSlippagePct(c.side, c.signal, c.fill); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s %v→%v = %v, want %v", c.side, c.signal, c.fill, got, c.want)
+		}
+	}
+}
+
+func TestCheckLiveFillSlippage(t *testing.T) {
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	max := 0.5
+	sc := StrategyConfig{ID: "hl-eth", Platform: "hyperliquid", MaxSlippagePct: &max}
+	if !checkLiveFillSlippage(sc, "ETH", "buy", 2000, 2012, nil, logger) {
+		t.Error("0.6% adverse should breach 0.5%")
+	}
+	if checkLiveFillSlippage(sc, "ETH", "buy", 2000, 2008, nil, logger) {
+		t.Error("0.4% adverse should pass")
+	}
+	if checkLiveFillSlippage(sc, "ETH", "sell", 2000, 2100, nil, logger) {
+		t.Error("favourable slippage must never breach")
+	}
+	sc.MaxSlippagePct = nil
+	if checkLiveFillSlippage(sc, "ETH", "buy", 2000, 3000, nil, logger) {
+		t.Error("no guard configured must never breach")
+	}
+}
+
+func TestFlattenSlippedHyperliquidEntry(t *testing.T) {
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("test")
+	defer logger.Close()
+	orig := slippageFlattenCloseFn
+	t.Cleanup(func() { slippageFlattenCloseFn = orig })
+	sc := StrategyConfig{ID: "hl-eth", Type: "perps", Platform: "hyperliquid"}
+	newState := func() *StrategyState {
+		return &StrategyState{
+			ID: "hl-eth", Type: "perps", Platform: "hyperliquid", Cash: 1000, InitialCapital: 1000,
+			Positions: map[string]*Position{
+				"ETH": {Symbol: "ETH", Side: "long", Quantity: 0.5, InitialQuantity: 0.5, AvgCost: 2020, Multiplier: 1, StopLossOID: 11, TPOIDs: []int64{12}, OwnerStrategyID: "hl-eth"},
+			},
+			OptionPositions: make(map[string]*OptionPosition),
+			RiskState:       RiskState{PeakValue: 1000},
+		}
+	}
+	var mu sync.RWMutex
+
+	var gotSz float64
+	var gotCancel []int64
+	slippageFlattenCloseFn = func(symbol string, partialSz *float64, cancelOIDs []int64) (*HyperliquidCloseResult, string, error) {
+		gotSz, gotCancel = *partialSz, cancelOIDs
+		return &HyperliquidCloseResult{Close: &HyperliquidClose{Symbol: symbol, Fill: &HyperliquidCloseFill{AvgPx: 2010, TotalSz: 0.5, OID: 99, Fee: 0.2}}}, "", nil
+	}
+	s := newState()
+	if n, _ := flattenSlippedHyperliquidEntry(sc, s, "ETH", &mu, nil, logger); n != 1 {
+		t.Fatalf("trades = %d, want 1", n)
+	}
+	if gotSz != 0.5 || len(gotCancel) != 2 {
+		t.Errorf("close request sz=%v cancel=%v", gotSz, gotCancel)
+	}
+	if _, open := s.Positions["ETH"]; open {
+		t.Error("position should be flat after the flatten")
+	}
+	last := s.TradeHistory[len(s.TradeHistory)-1]
+	if !last.IsClose || last.ExchangeOrderID != "99" || math.Abs(last.RealizedPnL-(-5)) > 1e-9 {
+		t.Errorf("close trade = %+v", last)
+	}
+
+	// Close failure: position stays open, nothing booked.
+	slippageFlattenCloseFn = func(string, *float64, []int64) (*HyperliquidCloseResult, string, error) {
+		return nil, "", errors.New("boom")
+	}
+	s = newState()
+	if n, _ := flattenSlippedHyperliquidEntry(sc, s, "ETH", &mu, nil, logger); n != 0 || s.Positions["ETH"] == nil || len(s.TradeHistory) != 0 {
+		t.Errorf("failed flatten must leave state untouched: n=%d pos=%+v trades=%d", n, s.Positions["ETH"], len(s.TradeHistory))
+	}
+}
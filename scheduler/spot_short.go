@@ -0,0 +1,130 @@
+package main
+
+// allow_short: opt-in paper short-selling for generic spot strategies.
+//
+// ExecuteSpotSignalWithFillFee is long-only on the open side — a SELL with no
+// position is a no-op. With allow_short set, executeSpotResult routes a SELL
+// from flat here instead, opening a paper short that the executor's existing
+// close-short branch unwinds on the next BUY (and flips long, matching the
+// buy-from-flat open).
+//
+// Accounting mirrors PortfolioValue's short branch (qty * (2*avg - price)):
+// the open debits qty*avg as collateral plus the fee, so the strategy's value
+// is unchanged at entry and the close credits the collateral back with the
+// P&L. Borrow is charged continuously on the short's mark notional at
+// short_borrow_apr_pct, debited from cash whenever the strategy runs its
+// spot check (accrueSpotShortBorrow), and folded into the realized PnL when
+// the short closes. Paper-only: validateConfig rejects live, okx and
+// robinhood strategies (no venue borrow, and those dispatch sites use their
+// own executors).
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultShortBorrowAPRPct is the borrow rate applied when
+// short_borrow_apr_pct is unset.
+const defaultShortBorrowAPRPct = 10.0
+
+// spotPaperShortOpens reports whether a spot signal should open an
+// allow_short paper short: a fresh SELL from flat (no position on symbol, not
+// a close action from the open/close registry).
+func spotPaperShortOpens(s *StrategyState, signal int, symbol string, closeFraction float64) bool {
+	if signal != -1 || closeFraction > 0 {
+		return false
+	}
+	_, exists := s.Positions[symbol]
+	return !exists
+}
+
+// spotShortBorrowFee is the borrow charge for holding qty short at price for
+// elapsed at aprPct percent per year.
+func spotShortBorrowFee(qty, price, aprPct float64, elapsed time.Duration) float64 {
+	if qty <= 0 || price <= 0 || aprPct <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return qty * price * aprPct / 100 * elapsed.Hours() / (365 * 24)
+}
+
+// accrueSpotShortBorrow debits the borrow fee on a paper spot short at symbol
+// since its last accrual and advances the watermark. Returns the fee charged.
+// Must be called under Lock.
+func accrueSpotShortBorrow(sc StrategyConfig, s *StrategyState, symbol string, price float64, now time.Time) float64 {
+	pos, ok := s.Positions[symbol]
+	if !ok || pos.Side != "short" || pos.Multiplier > 0 {
+		return 0
+	}
+	from := pos.BorrowAccruedAt
+	if from.IsZero() {
+		from = pos.OpenedAt
+	}
+	if from.IsZero() || !now.After(from) {
+		pos.BorrowAccruedAt = now
+		return 0
+	}
+	fee := spotShortBorrowFee(pos.Quantity, price, sc.ShortBorrowAPR(), now.Sub(from))
+	s.Cash -= fee
+	pos.BorrowFeesUSD += fee
+	pos.BorrowAccruedAt = now
+	return fee
+}
+
+// ExecuteSpotPaperShortDeferredOpen opens an allow_short paper short on
+// symbol, deploying cash (scaled by the resolved size_fraction) as
+// collateral. The open trade is returned in OpenTrade for the caller to
+// record (mirrors the DeferredOpen executors).
+func ExecuteSpotPaperShortDeferredOpen(s *StrategyState, symbol string, price, sizeFraction float64, logger *StrategyLogger) (SignalExecutionResult, error) {
+	var result SignalExecutionResult
+	budget := s.Cash
+	if sizeFraction > 0 && sizeFraction < 1 {
+		budget *= sizeFraction
+	}
+	if budget < 1 {
+		logger.Info("Insufficient cash ($%.2f) to short %s", s.Cash, symbol)
+		return result, nil
+	}
+	execPrice := ApplySlippage(price)
+	if execPrice <= 0 {
+		return result, nil
+	}
+	qty := budget / execPrice
+	collateral := qty * execPrice
+	fee := CalculatePlatformSpotFee(s.Platform, collateral)
+	totalDebit := collateral + fee
+	s.Cash -= totalDebit
+	now := time.Now().UTC()
+	positionID := newTradePositionID(s.ID, symbol, now)
+	s.Positions[symbol] = &Position{
+		Symbol:          symbol,
+		TradePositionID: positionID,
+		Quantity:        qty,
+		InitialQuantity: qty,
+		AvgCost:         execPrice,
+		Side:            "short",
+		OwnerStrategyID: s.ID,
+		OpenedAt:        now,
+		BorrowAccruedAt: now,
+	}
+	trade := Trade{
+		Timestamp:   now,
+		StrategyID:  s.ID,
+		Symbol:      symbol,
+		PositionID:  positionID,
+		Side:        "sell",
+		Quantity:    qty,
+		Price:       execPrice,
+		Value:       totalDebit,
+		TradeType:   "spot",
+		Details:     fmt.Sprintf("Open short %.6f @ $%.2f (fee $%.2f) [paper, allow_short]", qty, execPrice, fee),
+		ExchangeFee: fee,
+		FeeSource:   FeeSourceModeled,
+		PnLGross:    true,
+	}
+	trade.SlippageCost = slippageCost("sell", qty, price, execPrice)
+	trade.Regime = s.Regime
+	result.OpenTrade = &trade
+	result.TradesExecuted = 1
+	logger.Info("SELL %s: %.6f @ $%.2f (fee $%.2f, collateral $%.2f) [open short]", symbol, qty, execPrice, fee, collateral)
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpotPaperShort_OpenAccrueClose(t *testing.T) {
+	sc := StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000, AllowShort: true}
+	s := NewStrategyState(sc)
+	lm, _ := NewLogManager("")
+	logger, _ := lm.GetStrategyLogger("sma-btc")
+
+	if !spotPaperShortOpens(s, -1, "BTC/USDT", 0) || spotPaperShortOpens(s, 1, "BTC/USDT", 0) || spotPaperShortOpens(s, -1, "BTC/USDT", 1) {
+		t.Fatal("only a fresh SELL from flat opens a paper short")
+	}
+	exec, err := ExecuteSpotPaperShortDeferredOpen(s, "BTC/USDT", 100, 1, logger)
+	if err != nil || exec.TradesExecuted != 1 || exec.OpenTrade == nil || exec.OpenTrade.Side != "sell" {
+		t.Fatalf("open: %+v, %v", exec, err)
+	}
+	pos := s.Positions["BTC/USDT"]
+	if pos == nil || pos.Side != "short" {
+		t.Fatalf("position = %+v", pos)
+	}
+	// Collateral posted: value at the entry mark is capital less the fee.
+	prices := map[string]float64{"BTC/USDT": pos.AvgCost}
+	if pv := PortfolioValue(s, prices); math.Abs(pv-(1000-exec.OpenTrade.ExchangeFee)) > 1e-6 {
+		t.Errorf("value at entry = %v", pv)
+	}
+	if spotPaperShortOpens(s, -1, "BTC/USDT", 0) {
+		t.Error("a second SELL while short must not re-open")
+	}
+
+	// One year at the default 10% APR on ~$1000 notional.
+	now := time.Now().UTC()
+	pos.BorrowAccruedAt = now.Add(-365 * 24 * time.Hour)
+	cashBefore := s.Cash
+	fee := accrueSpotShortBorrow(sc, s, "BTC/USDT", 100, now)
+	if want := pos.Quantity * 100 * 0.10; math.Abs(fee-want) > 1e-6 || math.Abs(cashBefore-s.Cash-fee) > 1e-9 || pos.BorrowFeesUSD != fee {
+		t.Fatalf("borrow fee = %v, want %v (cash %v -> %v)", fee, want, cashBefore, s.Cash)
+	}
+	if again := accrueSpotShortBorrow(sc, s, "BTC/USDT", 100, now); again != 0 {
+		t.Errorf("re-accrual at the same watermark charged %v", again)
+	}
+
+	// BUY closes the short at 90; the collateral comes back with the P&L.
+	qty, avg := pos.Quantity, pos.AvgCost
+	if _, err := ExecuteSpotSignalWithFillFee(s, 1, "BTC/USDT", 90, 0, 0, "", 1, logger); err != nil {
+		t.Fatal(err)
+	}
+	if _, open := s.Positions["BTC/USDT"]; open {
+		t.Fatal("short should be closed")
+	}
+	closeTrade := s.TradeHistory[len(s.TradeHistory)-1]
+	want := 1000 - exec.OpenTrade.ExchangeFee - fee + qty*(avg-closeTrade.Price) - closeTrade.ExchangeFee
+	if math.Abs(s.Cash-want) > 1e-6 {
+		t.Errorf("cash after close = %v, want %v", s.Cash, want)
+	}
+	if !strings.Contains(closeTrade.Details, "borrow $") {
+		t.Errorf("close details missing borrow: %q", closeTrade.Details)
+	}
+	if math.Abs(closeTrade.RealizedPnL-(qty*(avg-closeTrade.Price)-fee)) > 1e-6 {
+		t.Errorf("realized PnL %v must be net of borrow", closeTrade.RealizedPnL)
+	}
+}
+
+func TestValidateConfig_AllowShort(t *testing.T) {
+	apr := -1.0
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{
+			{
+				ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+				Args: []string{"sma_crossover", "BTC/USDT", "1h", "--mode=live"}, Capital: 1000, MaxDrawdownPct: 10,
+				AllowShort: true, ShortBorrowAPRPct: &apr,
+			},
+			{
+				ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Script: "shared_scripts/check_hyperliquid.py",
+				Args: []string{"sma_crossover", "BTC", "1h"}, Capital: 1000, MaxDrawdownPct: 10, AllowShort: true,
+			},
+		},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"allow_short is paper-only", "short_borrow_apr_pct must be in [0, 1000]", "allow_short is only supported for spot"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+// stale_data: frozen price / stale candle alerting (#4944).
+//
+// Each check cycle the dispatch site feeds the strategy's cycle price and the
+// open time of the candle the check script evaluated (bar_time) into
+// staleDataTracker. A price that has not moved for price_cycles consecutive
+// cycles, or a latest candle older than max_candle_age_bars timeframes, marks
+// the strategy's symbol stale: one **STALE DATA** alert fires on entry into
+// the stale state and one **STALE DATA CLEARED** when fresh data returns.
+// With block_entries the dispatch site holds position-increasing signals
+// while stale, with the same pass-through semantics as pause: closes,
+// reductions and the Signal==0 manage path keep running. All state is
+// in-memory; a restart starts every strategy fresh.
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultStaleDataPriceCycles      = 5
+	defaultStaleDataMaxCandleAgeBars = 2.0
+)
+
+// StaleDataConfig is the top-level "stale_data" block.
+type StaleDataConfig struct {
+	Enabled          bool    `json:"enabled"`
+	PriceCycles      int     `json:"price_cycles,omitempty"`        // consecutive cycles with an unchanged price before alerting; 0/omitted → 5
+	MaxCandleAgeBars float64 `json:"max_candle_age_bars,omitempty"` // latest candle open older than this many timeframes is stale; 0/omitted → 2
+	BlockEntries     bool    `json:"block_entries,omitempty"`       // hold position-increasing signals while the symbol is stale
+}
+
+func (c *StaleDataConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *StaleDataConfig) priceCycles() int {
+	if c.PriceCycles > 0 {
+		return c.PriceCycles
+	}
+	return defaultStaleDataPriceCycles
+}
+
+func (c *StaleDataConfig) maxCandleAgeBars() float64 {
+	if c.MaxCandleAgeBars > 0 {
+		return c.MaxCandleAgeBars
+	}
+	return defaultStaleDataMaxCandleAgeBars
+}
+
+// validateStaleDataConfig checks the block. Nil is disabled.
+func validateStaleDataConfig(c *StaleDataConfig) []string {
+	if c == nil {
+		return nil
+	}
+	var errs []string
+	if c.PriceCycles < 0 {
+		errs = append(errs, fmt.Sprintf("stale_data.price_cycles must be >= 0 (0 = default %d), got %d", defaultStaleDataPriceCycles, c.PriceCycles))
+	}
+	if c.MaxCandleAgeBars < 0 {
+		errs = append(errs, fmt.Sprintf("stale_data.max_candle_age_bars must be >= 0 (0 = default %g), got %g", defaultStaleDataMaxCandleAgeBars, c.MaxCandleAgeBars))
+	}
+	return errs
+}
+
+func cloneStaleDataConfig(c *StaleDataConfig) *StaleDataConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// formatStaleDataConfig renders the block for reload change logs.
+func formatStaleDataConfig(c *StaleDataConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled(price_cycles=%d, max_candle_age_bars=%g, block_entries=%t)", c.priceCycles(), c.maxCandleAgeBars(), c.BlockEntries)
+}
+
+// staleDataEntry is one strategy's slot in staleDataTracker.
+type staleDataEntry struct {
+	lastPrice float64
+	unchanged int  // consecutive cycles the price matched lastPrice
+	stale     bool // an alert fired and fresh data has not returned since
+}
+
+// staleDataTracker tracks per-strategy price and candle freshness.
+type staleDataTracker struct {
+	mu      sync.Mutex
+	entries map[string]*staleDataEntry
+}
+
+// Observe records one cycle's price and evaluated-candle open time for
+// strategyID. It returns the stale reason ("" when fresh), whether this cycle
+// entered the stale state (alert), and whether it left it (recovered). A zero
+// price or barTime skips that half of the check; an unknown timeframe skips
+// the candle check.
+func (t *staleDataTracker) Observe(strategyID string, price float64, barTime time.Time, timeframe string, cfg *StaleDataConfig, now time.Time) (string, bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[string]*staleDataEntry)
+	}
+	e := t.entries[strategyID]
+	if e == nil {
+		e = &staleDataEntry{}
+		t.entries[strategyID] = e
+	}
+
+	var reasons []string
+	if price > 0 {
+		if price == e.lastPrice {
+			e.unchanged++
+		} else {
+			e.lastPrice = price
+			e.unchanged = 0
+		}
+		if e.unchanged >= cfg.priceCycles() {
+			reasons = append(reasons, fmt.Sprintf("price unchanged at $%g for %d cycles", price, e.unchanged))
+		}
+	}
+	if tf, ok := diagTimeframeDuration(timeframe); ok && !barTime.IsZero() {
+		limit := time.Duration(cfg.maxCandleAgeBars() * float64(tf))
+		if age := now.Sub(barTime); age > limit {
+			reasons = append(reasons, fmt.Sprintf("latest %s candle opened %s ago (limit %s)", timeframe, age.Truncate(time.Second), limit))
+		}
+	}
+
+	reason := strings.Join(reasons, "; ")
+	switch {
+	case reason != "" && !e.stale:
+		e.stale = true
+		return reason, true, false
+	case reason == "" && e.stale:
+		e.stale = false
+		return "", false, true
+	}
+	return reason, false, false
+}
+
+// staleData is the package-level tracker; resets on restart.
+var staleData = &staleDataTracker{}
+
+// parseBarTime parses the check script's bar_time (ISO-8601). Empty or
+// unparseable values return the zero time, which skips the candle check.
+func parseBarTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+// observeStaleData runs the per-cycle freshness check for sc, sends the
+// stale/cleared alerts, and returns the entry-hold reason — non-empty only
+// while stale with block_entries set. A nil or disabled block is a no-op.
+func observeStaleData(cfg *StaleDataConfig, sc StrategyConfig, price float64, barTime, timeframe string, notifier *MultiNotifier, logger *StrategyLogger) string {
+	if !cfg.enabled() {
+		return ""
+	}
+	reason, alert, recovered := staleData.Observe(sc.ID, price, parseBarTime(barTime), timeframe, cfg, time.Now().UTC())
+	symbol := extractAsset(sc)
+	if alert {
+		logger.Warn("Stale data: %s", reason)
+		if notifier != nil && notifier.HasBackends() {
+			msg := fmt.Sprintf("**STALE DATA** [%s] %s %s: %s", sc.ID, sc.Platform, symbol, reason)
+			if cfg.BlockEntries {
+				msg += " — new entries held until data refreshes"
+			}
+			notifier.SendToAllChannels(msg)
+			notifier.SendOwnerDM(msg)
+		}
+	}
+	if recovered {
+		logger.Info("Stale data cleared: price and candles refreshing again")
+		if notifier != nil && notifier.HasBackends() {
+			notifier.SendToAllChannels(fmt.Sprintf("**STALE DATA CLEARED** [%s] %s %s: price and candles refreshing again", sc.ID, sc.Platform, symbol))
+		}
+	}
+	if reason == "" || !cfg.BlockEntries {
+		return ""
+	}
+	return reason
+}
+
+// staleCandleReason reports a signal computed from stale candles (#4962).
+// The check script's bar_time is the open time of the newest candle it used;
+// that candle closes one timeframe later, and a healthy feed never leaves it
+// closed for more than another timeframe. Anything older means the script
+// saw an exchange outage or a cached candle set, so the executor rejects
+// the signal outright — independent of the stale_data block, which only
+// alerts and holds entries. "" when fresh, or when bar_time or the
+// timeframe is unknown.
+func staleCandleReason(barTime, timeframe string, now time.Time) string {
+	tf, ok := diagTimeframeDuration(timeframe)
+	bt := parseBarTime(barTime)
+	if !ok || bt.IsZero() {
+		return ""
+	}
+	closedAt := bt.Add(tf)
+	if age := now.Sub(closedAt); age > tf {
+		return fmt.Sprintf("newest %s candle closed %s ago (opened %s)", timeframe, age.Truncate(time.Second), bt.Format(time.RFC3339))
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStaleDataTracker_FrozenPriceAlertsOnceAndRecovers(t *testing.T) {
+	tr := &staleDataTracker{}
+	cfg := &StaleDataConfig{Enabled: true, PriceCycles: 3}
+	now := time.Unix(1700000000, 0).UTC()
+	for i := 0; i < 3; i++ {
+		if reason, alert, _ := tr.Observe("hl-btc", 100, time.Time{}, "1h", cfg, now); reason != "" || alert {
+			t.Fatalf("cycle %d: reason=%q alert=%v, want fresh", i, reason, alert)
+		}
+	}
+	reason, alert, _ := tr.Observe("hl-btc", 100, time.Time{}, "1h", cfg, now)
+	if !alert || !strings.Contains(reason, "price unchanged") {
+		t.Fatalf("reason=%q alert=%v, want price-unchanged alert after 3 unchanged cycles", reason, alert)
+	}
+	if reason, alert, _ = tr.Observe("hl-btc", 100, time.Time{}, "1h", cfg, now); reason == "" || alert {
+		t.Fatalf("reason=%q alert=%v, want still stale without re-alert", reason, alert)
+	}
+	reason, alert, recovered := tr.Observe("hl-btc", 101, time.Time{}, "1h", cfg, now)
+	if reason != "" || alert || !recovered {
+		t.Fatalf("reason=%q alert=%v recovered=%v, want recovery on price move", reason, alert, recovered)
+	}
+}
+
+func TestStaleDataTracker_OldCandle(t *testing.T) {
+	tr := &staleDataTracker{}
+	cfg := &StaleDataConfig{Enabled: true}
+	now := time.Date(2026, 1, 2, 12, 30, 0, 0, time.UTC)
+	if reason, _, _ := tr.Observe("s", 100, now.Add(-90*time.Minute), "1h", cfg, now); reason != "" {
+		t.Fatalf("90m-old 1h candle flagged stale: %q", reason)
+	}
+	reason, alert, _ := tr.Observe("s", 101, now.Add(-3*time.Hour), "1h", cfg, now)
+	if !alert || !strings.Contains(reason, "candle") {
+		t.Fatalf("reason=%q alert=%v, want candle alert past 2 timeframes", reason, alert)
+	}
+	if reason, _, _ := tr.Observe("s", 102, now.Add(-3*time.Hour), "bogus", cfg, now); reason != "" {
+		t.Fatalf("unknown timeframe should skip candle check, got %q", reason)
+	}
+}
+
+func TestObserveStaleData_DisabledAndBlockEntries(t *testing.T) {
+	sc := StrategyConfig{ID: "stale-obs", Platform: "hyperliquid", Args: []string{"momentum", "BTC", "1h"}}
+	old := time.Now().UTC().Add(-48 * time.Hour).Format(time.RFC3339)
+	if why := observeStaleData(nil, sc, 100, old, "1h", nil, nil); why != "" {
+		t.Fatalf("nil block returned %q", why)
+	}
+	if why := observeStaleData(&StaleDataConfig{Enabled: true}, sc, 100, old, "1h", nil, silentStrategyLogger(sc.ID)); why != "" {
+		t.Fatalf("alert-only block returned hold reason %q", why)
+	}
+	if why := observeStaleData(&StaleDataConfig{Enabled: true, BlockEntries: true}, sc, 100, old, "1h", nil, silentStrategyLogger(sc.ID)); why == "" {
+		t.Fatal("block_entries should return a hold reason while stale")
+	}
+}
+
+func TestParseBarTime(t *testing.T) {
+	got := parseBarTime("2026-01-02T03:00:00+00:00")
+	if want := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("parseBarTime = %v, want %v", got, want)
+	}
+	if !parseBarTime("").IsZero() || !parseBarTime("yesterday").IsZero() {
+		t.Fatal("empty/invalid bar_time should parse to zero")
+	}
+}
+
+func TestValidateStaleDataConfig(t *testing.T) {
+	if errs := validateStaleDataConfig(&StaleDataConfig{Enabled: true}); len(errs) != 0 {
+		t.Fatalf("defaults rejected: %v", errs)
+	}
+	if errs := validateStaleDataConfig(&StaleDataConfig{PriceCycles: -1, MaxCandleAgeBars: -1}); len(errs) != 2 {
+		t.Fatalf("errs = %v, want 2", errs)
+	}
+}
+
+func TestStaleCandleReason(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 30, 0, 0, time.UTC)
+	cases := []struct {
+		barTime, tf string
+		stale       bool
+	}{
+		{"2026-01-02T12:00:00Z", "1h", false}, // forming candle
+		{"2026-01-02T11:00:00Z", "1h", false}, // last closed candle
+		{"2026-01-02T10:00:00Z", "1h", true},  // closed 1h30m ago
+		{"2026-01-02T11:45:00Z", "15m", true}, // closed 30m ago
+		{"", "1h", false},
+		{"2026-01-01T00:00:00Z", "bogus", false},
+	}
+	for _, c := range cases {
+		if got := staleCandleReason(c.barTime, c.tf, now); (got != "") != c.stale {
+			t.Errorf("staleCandleReason(%q, %q) = %q, want stale=%v", c.barTime, c.tf, got, c.stale)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+// startup_sync: compare live venue accounts with persisted state at startup (#4973).
+//
+// A restarted scheduler otherwise trusts state.db even when positions were
+// opened, closed or resized on the exchange while it was down. With
+// startup_sync enabled, every live wallet (grouped by walletKeyFor; live HL
+// manual strategies count as perps) is fetched once before the first cycle
+// and each coin's signed state quantity is compared with the venue:
+//   - mode "report" (default) lists every discrepancy for the owner DM;
+//   - mode "adopt" also rewrites state to match the venue through
+//     applyPositionAdjustment, booking one "Startup sync" audit trade per
+//     change. Coins traded by more than one strategy on the wallet are
+//     only reported — ownership of the difference is ambiguous.
+//
+// Cash is compared only when the wallet is flat on both sides, since venue
+// equity includes unrealized PnL and margin while state cash does not. A
+// flat wallet owned by a single strategy adopts the venue balance in adopt
+// mode; the strategy's peak moves by the same delta so the correction does
+// not read as drawdown. TopStep positions are synced but its balance feed is
+// unverified (see #1106), so TopStep cash is never compared; Robinhood has
+// no balance fetcher.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StartupSyncConfig enables the startup venue comparison.
+type StartupSyncConfig struct {
+	Enabled bool   `json:"enabled"`
+	Mode    string `json:"mode,omitempty"` // "report" (default) | "adopt"
+}
+
+// startupSyncCashTolerance is the larger of $1 and 0.5% of the venue balance;
+// smaller cash differences are rounding and fee noise.
+const (
+	startupSyncCashToleranceUSD = 1.0
+	startupSyncCashTolerancePct = 0.005
+)
+
+// adopt reports whether discrepancies should be written into state.
+func (c *StartupSyncConfig) adopt() bool {
+	return c != nil && c.Enabled && c.Mode == "adopt"
+}
+
+// startupSyncErrors validates the startup_sync block.
+func startupSyncErrors(c *StartupSyncConfig) []string {
+	if c == nil {
+		return nil
+	}
+	switch c.Mode {
+	case "", "report", "adopt":
+		return nil
+	}
+	return []string{fmt.Sprintf("startup_sync.mode must be \"report\" or \"adopt\", got %q", c.Mode)}
+}
+
+// venuePosition is one open position on the venue. Size is signed
+// (positive = long, negative = short), mirroring HLPosition.
+type venuePosition struct {
+	Coin       string
+	Size       float64
+	EntryPrice float64
+}
+
+// venueSnapshot is a wallet's open positions plus, when the platform has a
+// trusted balance feed, its account balance.
+type venueSnapshot struct {
+	Balance    float64
+	HasBalance bool
+	Positions  []venuePosition
+}
+
+// venueSnapshotFetcher fetches one wallet. Injected so tests can stub.
+type venueSnapshotFetcher func(key SharedWalletKey) (*venueSnapshot, error)
+
+// defaultVenueSnapshotFetcher dispatches to the platform position fetchers
+// the kill switch already uses.
+func defaultVenueSnapshotFetcher(key SharedWalletKey) (*venueSnapshot, error) {
+	snap := &venueSnapshot{}
+	switch key.Platform {
+	case "hyperliquid":
+		bal, positions, err := fetchHyperliquidState(key.Account)
+		if err != nil {
+			return nil, err
+		}
+		snap.Balance, snap.HasBalance = bal, true
+		for _, p := range positions {
+			snap.Positions = append(snap.Positions, venuePosition{Coin: p.Coin, Size: p.Size, EntryPrice: p.EntryPrice})
+		}
+	case "okx":
+		positions, err := defaultOKXPositionsFetcher()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range positions {
+			snap.Positions = append(snap.Positions, venuePosition{Coin: p.Coin, Size: p.Size, EntryPrice: p.EntryPrice})
+		}
+		if bal, err := defaultSharedWalletBalance("okx"); err == nil {
+			snap.Balance, snap.HasBalance = bal, true
+		}
+	case "topstep":
+		positions, err := defaultTopStepPositionsFetcher()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range positions {
+			size := float64(p.Size)
+			if p.Side == "short" {
+				size = -math.Abs(size)
+			}
+			snap.Positions = append(snap.Positions, venuePosition{Coin: p.Coin, Size: size, EntryPrice: p.AvgPrice})
+		}
+	case "robinhood":
+		positions, err := defaultRobinhoodPositionsFetcher()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range positions {
+			snap.Positions = append(snap.Positions, venuePosition{Coin: p.Coin, Size: p.Size, EntryPrice: p.AvgPrice})
+		}
+	default:
+		return nil, fmt.Errorf("no position fetcher for platform %q", key.Platform)
+	}
+	return snap, nil
+}
+
+// startupSyncWalletKey is walletKeyFor, with live HL manual strategies
+// treated as perps: they trade the same account.
+func startupSyncWalletKey(sc StrategyConfig) (SharedWalletKey, bool) {
+	if sc.Platform == "hyperliquid" && sc.Type == "manual" {
+		sc.Type = "perps"
+	}
+	return walletKeyFor(sc)
+}
+
+// signedStateQty returns pos's quantity, negative for shorts.
+func signedStateQty(pos *Position) float64 {
+	if pos == nil {
+		return 0
+	}
+	if pos.Side == "short" {
+		return -pos.Quantity
+	}
+	return pos.Quantity
+}
+
+// sameQty reports whether two signed quantities match within float noise.
+func sameQty(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9+1e-6*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// syncLiveStateAtStartup compares every live wallet with state and, in
+// adopt mode, corrects state. Returns one line per finding for the owner
+// DM and whether state changed. Performs network I/O; call during
+// single-threaded startup, before markSchedulerStarted.
+func syncLiveStateAtStartup(state *AppState, cfg *Config, fetch venueSnapshotFetcher, now time.Time) ([]string, bool) {
+	if cfg.StartupSync == nil || !cfg.StartupSync.Enabled {
+		return nil, false
+	}
+	if fetch == nil {
+		fetch = defaultVenueSnapshotFetcher
+	}
+	adopt := cfg.StartupSync.adopt()
+
+	wallets := make(map[SharedWalletKey][]StrategyConfig)
+	for _, sc := range cfg.Strategies {
+		if key, ok := startupSyncWalletKey(sc); ok && state.Strategies[sc.ID] != nil {
+			wallets[key] = append(wallets[key], sc)
+		}
+	}
+	keys := make([]SharedWalletKey, 0, len(wallets))
+	for key := range wallets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Platform != keys[j].Platform {
+			return keys[i].Platform < keys[j].Platform
+		}
+		return keys[i].Account < keys[j].Account
+	})
+
+	var lines []string
+	changed := false
+	for _, key := range keys {
+		strategies := wallets[key]
+		snap, err := fetch(key)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("%s: venue fetch failed, state kept as loaded: %v", key.Platform, err))
+			continue
+		}
+		walletLines, walletChanged := syncWalletAtStartup(state, strategies, key.Platform, snap, adopt, now)
+		lines = append(lines, walletLines...)
+		changed = changed || walletChanged
+	}
+	return lines, changed
+}
+
+// syncWalletAtStartup handles one wallet for syncLiveStateAtStartup.
+func syncWalletAtStartup(state *AppState, strategies []StrategyConfig, platform string, snap *venueSnapshot, adopt bool, now time.Time) ([]string, bool) {
+	var lines []string
+	changed := false
+
+	// Which strategies trade or hold each coin.
+	owners := make(map[string][]StrategyConfig)
+	addOwner := func(coin string, sc StrategyConfig) {
+		for _, o := range owners[coin] {
+			if o.ID == sc.ID {
+				return
+			}
+		}
+		owners[coin] = append(owners[coin], sc)
+	}
+	for _, sc := range strategies {
+		if sym := strategyDisplaySymbol(sc); sym != "" {
+			addOwner(sym, sc)
+		}
+		for sym := range state.Strategies[sc.ID].Positions {
+			addOwner(sym, sc)
+		}
+	}
+	venue := make(map[string]venuePosition, len(snap.Positions))
+	for _, p := range snap.Positions {
+		venue[p.Coin] = p
+	}
+	coins := make([]string, 0, len(owners)+len(venue))
+	for coin := range owners {
+		coins = append(coins, coin)
+	}
+	for coin := range venue {
+		if _, ok := owners[coin]; !ok {
+			coins = append(coins, coin)
+		}
+	}
+	sort.Strings(coins)
+
+	for _, coin := range coins {
+		var stateQty float64
+		for _, sc := range owners[coin] {
+			stateQty += signedStateQty(state.Strategies[sc.ID].Positions[coin])
+		}
+		vp := venue[coin]
+		if sameQty(stateQty, vp.Size) {
+			continue
+		}
+		diff := fmt.Sprintf("%s %s: state %+g vs venue %+g", platform, coin, stateQty, vp.Size)
+		switch {
+		case len(owners[coin]) == 0:
+			lines = append(lines, diff+" — no live strategy trades this coin; left alone")
+		case len(owners[coin]) > 1:
+			ids := make([]string, 0, len(owners[coin]))
+			for _, sc := range owners[coin] {
+				ids = append(ids, sc.ID)
+			}
+			lines = append(lines, diff+" — shared by "+strings.Join(ids, ", ")+"; fix with adjust-position")
+		case !adopt:
+			lines = append(lines, diff+" ("+owners[coin][0].ID+")")
+		default:
+			sc := owners[coin][0]
+			summary, err := adoptVenuePosition(state.Strategies[sc.ID], sc, coin, vp, now)
+			if summary != "" {
+				changed = true
+			}
+			if err != nil {
+				lines = append(lines, diff+" — adopt failed for "+sc.ID+": "+err.Error())
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s — adopted into %s: %s", diff, sc.ID, summary))
+		}
+	}
+
+	if !snap.HasBalance {
+		return lines, changed
+	}
+	var stateCash float64
+	for _, sc := range strategies {
+		s := state.Strategies[sc.ID]
+		if len(s.Positions) > 0 {
+			return lines, changed
+		}
+		stateCash += s.Cash
+	}
+	if len(snap.Positions) > 0 {
+		return lines, changed
+	}
+	tolerance := math.Max(startupSyncCashToleranceUSD, math.Abs(snap.Balance)*startupSyncCashTolerancePct)
+	if math.Abs(snap.Balance-stateCash) <= tolerance {
+		return lines, changed
+	}
+	diff := fmt.Sprintf("%s cash: state $%.2f vs venue $%.2f", platform, stateCash, snap.Balance)
+	switch {
+	case len(strategies) > 1:
+		lines = append(lines, diff+" — wallet shared by several strategies; not adopted")
+	case !adopt:
+		lines = append(lines, diff+" ("+strategies[0].ID+")")
+	default:
+		s := state.Strategies[strategies[0].ID]
+		delta := snap.Balance - s.Cash
+		s.Cash = snap.Balance
+		if s.RiskState.PeakValue > 0 {
+			s.RiskState.PeakValue = math.Max(s.RiskState.PeakValue+delta, s.Cash)
+		}
+		changed = true
+		lines = append(lines, fmt.Sprintf("%s — adopted into %s", diff, s.ID))
+	}
+	return lines, changed
+}
+
+// adoptVenuePosition makes s's position in coin match vp. A side flip is a
+// removal followed by a fresh registration (two audit trades).
+func adoptVenuePosition(s *StrategyState, sc StrategyConfig, coin string, vp venuePosition, now time.Time) (string, error) {
+	side := "long"
+	if vp.Size < 0 {
+		side = "short"
+	}
+	var parts []string
+	if cur := s.Positions[coin]; cur != nil && (vp.Size == 0 || cur.Side != side) {
+		summary, err := applyPositionAdjustment(s, sc, positionAdjustment{Symbol: coin, Origin: "Startup sync"}, now)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, summary)
+	}
+	if vp.Size != 0 {
+		summary, err := applyPositionAdjustment(s, sc, positionAdjustment{
+			Symbol:   coin,
+			Quantity: math.Abs(vp.Size),
+			AvgCost:  vp.EntryPrice,
+			Side:     side,
+			Origin:   "Startup sync",
+		}, now)
+		if err != nil {
+			return strings.Join(parts, "; "), err
+		}
+		parts = append(parts, summary)
+	}
+	return strings.Join(parts, "; "), nil
+}
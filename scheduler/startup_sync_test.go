@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startupSyncFixture(mode string) (*AppState, *Config) {
+	cfg := &Config{
+		StartupSync: &StartupSyncConfig{Enabled: true, Mode: mode},
+		Strategies: []StrategyConfig{
+			{ID: "hl-btc", Platform: "hyperliquid", Type: "perps", Leverage: 2, Args: []string{"sma", "BTC", "1h", "--mode=live"}},
+			{ID: "hl-eth-a", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "ETH", "1h", "--mode=live"}},
+			{ID: "hl-eth-b", Platform: "hyperliquid", Type: "perps", Args: []string{"rsi", "ETH", "1h", "--mode=live"}},
+			{ID: "hl-sol", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "SOL", "1h", "--mode=live"}},
+			{ID: "hl-paper", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "DOGE", "1h"}},
+		},
+	}
+	state := &AppState{Strategies: map[string]*StrategyState{}}
+	for _, sc := range cfg.Strategies {
+		state.Strategies[sc.ID] = &StrategyState{ID: sc.ID, Cash: 1000, Positions: map[string]*Position{}}
+	}
+	state.Strategies["hl-btc"].Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.1, AvgCost: 60000, Side: "long"}
+	state.Strategies["hl-sol"].Positions["SOL"] = &Position{Symbol: "SOL", Quantity: 5, AvgCost: 150, Side: "long"}
+	return state, cfg
+}
+
+func startupSyncVenue(key SharedWalletKey) (*venueSnapshot, error) {
+	if key.Platform != "hyperliquid" {
+		return nil, fmt.Errorf("unexpected platform %s", key.Platform)
+	}
+	return &venueSnapshot{
+		Balance: 5000, HasBalance: true,
+		Positions: []venuePosition{
+			{Coin: "BTC", Size: 0.12, EntryPrice: 61000}, // resized while down
+			{Coin: "ETH", Size: -1, EntryPrice: 3000},    // shared coin
+			{Coin: "XRP", Size: 100, EntryPrice: 0.5},    // untracked
+			// SOL closed on the venue
+		},
+	}, nil
+}
+
+func TestSyncLiveStateAtStartupReport(t *testing.T) {
+	t.Setenv("HYPERLIQUID_ACCOUNT_ADDRESS", "0xabc")
+	state, cfg := startupSyncFixture("")
+
+	lines, changed := syncLiveStateAtStartup(state, cfg, startupSyncVenue, time.Now())
+	if changed {
+		t.Error("report mode changed state")
+	}
+	joined := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"BTC: state +0.1 vs venue +0.12 (hl-btc)",
+		"ETH: state +0 vs venue -1 — shared by hl-eth-a, hl-eth-b",
+		"SOL: state +5 vs venue +0 (hl-sol)",
+		"XRP: state +0 vs venue +100 — no live strategy trades this coin",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "DOGE") || strings.Contains(joined, "cash") {
+		t.Errorf("paper strategy or non-flat cash reported:\n%s", joined)
+	}
+	if state.Strategies["hl-btc"].Positions["BTC"].Quantity != 0.1 {
+		t.Error("report mode touched a position")
+	}
+}
+
+func TestSyncLiveStateAtStartupAdopt(t *testing.T) {
+	t.Setenv("HYPERLIQUID_ACCOUNT_ADDRESS", "0xabc")
+	origRecorder := tradeRecorder
+	tradeRecorder = func(_ string, _ Trade) error { return nil }
+	defer func() { tradeRecorder = origRecorder }()
+	state, cfg := startupSyncFixture("adopt")
+
+	if _, changed := syncLiveStateAtStartup(state, cfg, startupSyncVenue, time.Now()); !changed {
+		t.Fatal("adopt mode reported no change")
+	}
+	btc := state.Strategies["hl-btc"]
+	if pos := btc.Positions["BTC"]; pos.Quantity != 0.12 || pos.AvgCost != 61000 {
+		t.Errorf("BTC not adopted: %+v", pos)
+	}
+	if len(btc.TradeHistory) != 1 || !strings.HasPrefix(btc.TradeHistory[0].Details, "Startup sync position adjustment") {
+		t.Errorf("audit trade = %+v", btc.TradeHistory)
+	}
+	if btc.Cash != 1000 {
+		t.Errorf("position adoption moved cash: %v", btc.Cash)
+	}
+	if _, open := state.Strategies["hl-sol"].Positions["SOL"]; open {
+		t.Error("SOL closed on the venue but still open in state")
+	}
+	if len(state.Strategies["hl-eth-a"].Positions)+len(state.Strategies["hl-eth-b"].Positions) != 0 {
+		t.Error("shared coin adopted")
+	}
+}
+
+func TestSyncLiveStateAtStartupFlatCash(t *testing.T) {
+	t.Setenv("HYPERLIQUID_ACCOUNT_ADDRESS", "0xabc")
+	cfg := &Config{
+		StartupSync: &StartupSyncConfig{Enabled: true, Mode: "adopt"},
+		Strategies:  []StrategyConfig{{ID: "hl-btc", Platform: "hyperliquid", Type: "perps", Args: []string{"sma", "BTC", "1h", "--mode=live"}}},
+	}
+	s := &StrategyState{ID: "hl-btc", Cash: 1000, Positions: map[string]*Position{}, RiskState: RiskState{PeakValue: 1100}}
+	state := &AppState{Strategies: map[string]*StrategyState{"hl-btc": s}}
+	venue := func(SharedWalletKey) (*venueSnapshot, error) {
+		return &venueSnapshot{Balance: 940, HasBalance: true}, nil
+	}
+
+	lines, changed := syncLiveStateAtStartup(state, cfg, venue, time.Now())
+	if !changed || s.Cash != 940 || s.RiskState.PeakValue != 1040 {
+		t.Fatalf("changed=%v cash=%v peak=%v lines=%v", changed, s.Cash, s.RiskState.PeakValue, lines)
+	}
+
+	// Within tolerance: nothing to report.
+	s.Cash = 940.5
+	if lines, changed := syncLiveStateAtStartup(state, cfg, venue, time.Now()); changed || len(lines) != 0 {
+		t.Errorf("noise reported: changed=%v lines=%v", changed, lines)
+	}
+}
+
+func TestSyncLiveStateAtStartupFetchFailureKeepsState(t *testing.T) {
+	t.Setenv("HYPERLIQUID_ACCOUNT_ADDRESS", "0xabc")
+	state, cfg := startupSyncFixture("adopt")
+	failing := func(SharedWalletKey) (*venueSnapshot, error) { return nil, fmt.Errorf("timeout") }
+
+	lines, changed := syncLiveStateAtStartup(state, cfg, failing, time.Now())
+	if changed || len(lines) != 1 || !strings.Contains(lines[0], "state kept as loaded") {
+		t.Errorf("changed=%v lines=%v", changed, lines)
+	}
+	if errs := startupSyncErrors(&StartupSyncConfig{Enabled: true, Mode: "overwrite"}); len(errs) != 1 {
+		t.Errorf("invalid mode accepted: %v", errs)
+	}
+}
@@ -49,19 +49,51 @@ func RecordTrade(s *StrategyState, trade Trade) {
 			trade.PositionID = ensureOptionTradeID(s.ID, opt)
 		}
 	}
+	if trade.Indicators == nil && len(s.pendingIndicators) > 0 {
+		trade.Indicators = s.pendingIndicators
+	}
+	if s.pendingTradeReason != "" {
+		trade.Details = s.pendingTradeReason + ": " + trade.Details
+	}
+	tagTrade(&trade)
 	s.TradeHistory = append(s.TradeHistory, trade)
-	if tradeRecorder == nil {
+	rolloverDailyPnL(&s.RiskState)
+	s.RiskState.DailyTrades++
+	s.TotalFees += trade.ExchangeFee
+	s.TotalSlippage += trade.SlippageCost
+	if s.shadow {
 		return
 	}
-	if err := tradeRecorder(s.ID, trade); err != nil {
-		msg := fmt.Sprintf("immediate trade persist failed for %s: %v", s.ID, err)
+	if tradeRecorder != nil {
+		if err := tradeRecorder(s.ID, trade); err != nil {
+			msg := fmt.Sprintf("immediate trade persist failed for %s: %v", s.ID, err)
+			fmt.Fprintf(os.Stderr, "[state] WARN: %s\n", msg)
+			if tradePersistWarn != nil {
+				tradePersistWarn(msg)
+			}
+		} else {
+			s.TradeHistory[len(s.TradeHistory)-1].persisted = true
+		}
+	}
+	// #4938: best-effort append to the standalone trade ledger.
+	if err := tradeLedger.Append(s.ID, trade); err != nil {
+		fmt.Fprintf(os.Stderr, "[state] WARN: %v\n", err)
+	}
+	// #4917: the caller is still applying the position/cash side of this
+	// trade; the strategy is journaled by flushStateWAL once it is done.
+	stateWAL.MarkDirty(s)
+}
+
+// flushStateWAL journals every strategy that recorded a trade since the last
+// flush or save (#4917). Call under mu once their mutations are complete.
+func flushStateWAL() {
+	if err := stateWAL.Flush(); err != nil {
+		msg := fmt.Sprintf("state WAL flush failed: %v", err)
 		fmt.Fprintf(os.Stderr, "[state] WARN: %s\n", msg)
 		if tradePersistWarn != nil {
 			tradePersistWarn(msg)
 		}
-		return
 	}
-	s.TradeHistory[len(s.TradeHistory)-1].persisted = true
 }
 
 // ReconciliationGap tracks the drift between virtual per-strategy positions and
@@ -80,11 +112,14 @@ type ReconciliationGap struct {
 
 // AppState holds all persistent state across restarts.
 type AppState struct {
-	CycleCount          int                       `json:"cycle_count"`
-	LastCycle           time.Time                 `json:"last_cycle"`
-	Strategies          map[string]*StrategyState `json:"strategies"`
-	PortfolioRisk       PortfolioRiskState        `json:"portfolio_risk"`
-	CorrelationSnapshot *CorrelationSnapshot      `json:"correlation_snapshot,omitempty"`
+	CycleCount    int                       `json:"cycle_count"`
+	LastCycle     time.Time                 `json:"last_cycle"`
+	Strategies    map[string]*StrategyState `json:"strategies"`
+	PortfolioRisk PortfolioRiskState        `json:"portfolio_risk"`
+	// PlatformRisk is the per-platform aggregate risk state for platforms
+	// with an enforced platforms.<name>.risk limit (platform_risk.go).
+	PlatformRisk        map[string]*PlatformRiskState `json:"platform_risk,omitempty"`
+	CorrelationSnapshot *CorrelationSnapshot          `json:"correlation_snapshot,omitempty"`
 	// ReconciliationGaps is ephemeral — recomputed each sync cycle, not persisted to SQLite.
 	ReconciliationGaps      map[string]*ReconciliationGap `json:"reconciliation_gaps,omitempty"`
 	LastLeaderboardPostDate string                        `json:"last_leaderboard_post_date,omitempty"`
@@ -94,6 +129,17 @@ type AppState struct {
 	LastLeaderboardSummaries map[string]time.Time `json:"last_leaderboard_summaries,omitempty"`
 	// LastSummaryPost tracks the last regular summary post per notification channel key.
 	LastSummaryPost map[string]time.Time `json:"last_summary_post,omitempty"`
+	// LastWeeklyDigestDate is the UTC date (YYYY-MM-DD) of the last
+	// weekly_digest post (#4955). Persisted in app_state.last_weekly_digest_date.
+	LastWeeklyDigestDate string `json:"last_weekly_digest_date,omitempty"`
+	// MutedStrategies holds strategies whose trade alerts were muted at
+	// runtime via /go-trader-mute (#4951), keyed by strategy ID → mute time.
+	// Persisted as JSON in app_state.muted_strategies.
+	MutedStrategies map[string]time.Time `json:"muted_strategies,omitempty"`
+	// CycleTimings is the rolling per-cycle timing history (oldest first,
+	// capped at cycleTimingWindow). Persisted as JSON in app_state.cycle_timings
+	// so /status and /metrics keep their history across restarts.
+	CycleTimings []CycleTiming `json:"cycle_timings,omitempty"`
 }
 
 // StrategyState is the per-strategy persistent state.
@@ -148,6 +194,33 @@ type StrategyState struct {
 	// buys routinely end fee-negative (cash=-fee), and perps/futures can go
 	// negative from leveraged PnL.
 	CashReconcileRequired bool `json:"cash_reconcile_required,omitempty"`
+
+	// PaperOrders holds resting simulated orders keyed by symbol
+	// (paper_orders.go). Persisted as strategies.paper_orders_json.
+	PaperOrders map[string]*PaperOrder `json:"paper_orders,omitempty"`
+
+	// Benchmark is the daily value/benchmark series behind rolling
+	// alpha/beta (benchmark.go, #4927). Persisted as
+	// strategies.benchmark_json.
+	Benchmark *BenchmarkTrack `json:"benchmark,omitempty"`
+
+	// TotalFees and TotalSlippage are the cumulative fees and modeled
+	// slippage booked through RecordTrade (#4977), so gross PnL is net PnL
+	// plus both. Persisted as strategies.total_fees / total_slippage; they
+	// count from the upgrade that added them, not from the first trade.
+	TotalFees     float64 `json:"total_fees,omitempty"`
+	TotalSlippage float64 `json:"total_slippage,omitempty"`
+
+	// pendingIndicators is the indicator snapshot staged by the execute*
+	// dispatcher for the signal being booked (#4952); RecordTrade copies it
+	// onto trades that don't carry their own. In-memory only.
+	pendingIndicators map[string]float64
+	// pendingTradeReason prefixes the Details of trades booked while a
+	// Go-driven exit is staged (stageTradeReason). In-memory only.
+	pendingTradeReason string
+	// shadow marks a paper twin (shadow.go); RecordTrade keeps its trades
+	// in memory only. In-memory only.
+	shadow bool
 }
 
 func NewStrategyState(cfg StrategyConfig) *StrategyState {
@@ -398,7 +471,17 @@ func LoadStateWithDB(cfg *Config, sdb *StateDB) (*AppState, error) {
 	return NewAppState(), nil
 }
 
-// SaveStateWithDB saves state to SQLite.
+// SaveStateWithDB saves state to SQLite. A completed save truncates the state
+// WAL (#4917): every journaled mutation is now in the DB. A failed one
+// journals the strategies still dirty, so they survive a crash before the
+// next completed save.
 func SaveStateWithDB(state *AppState, cfg *Config, sdb *StateDB) error {
-	return sdb.SaveState(state)
+	if err := sdb.SaveState(state); err != nil {
+		flushStateWAL()
+		return err
+	}
+	if err := stateWAL.Truncate(); err != nil {
+		fmt.Fprintf(os.Stderr, "[state] WARN: state WAL truncate failed: %v\n", err)
+	}
+	return nil
 }
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateKeyEnvVar holds the optional at-rest encryption key for the state DB:
+// 32 bytes as 64 hex chars or standard base64. It can come from the box env
+// or from the secrets provider (secrets_provider.go), which exports before
+// the DB is opened. Unset keeps the historical plaintext SQLite file.
+const stateKeyEnvVar = "GO_TRADER_STATE_KEY"
+
+// encryptedStateMagic prefixes an encrypted state DB file. The layout is
+// magic | 12-byte GCM nonce | AES-256-GCM(serialized SQLite DB), with the
+// magic as additional authenticated data so a truncated or re-labelled file
+// fails to open instead of decrypting to garbage.
+var encryptedStateMagic = []byte("GOTRENC1")
+
+// sqliteFileHeader is the fixed 16-byte header of a plaintext SQLite file.
+var sqliteFileHeader = []byte("SQLite format 3\x00")
+
+// stateEncryptionKey returns the configured key, nil when encryption is off,
+// or an error when the env var is set but not a 32-byte key — a typo must not
+// silently fall back to plaintext.
+func stateEncryptionKey() ([]byte, error) {
+	raw := strings.TrimSpace(os.Getenv(stateKeyEnvVar))
+	if raw == "" {
+		return nil, nil
+	}
+	if key, err := hex.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%s must be a 32-byte key as 64 hex chars or base64 (e.g. `openssl rand -hex 32`)", stateKeyEnvVar)
+}
+
+// isEncryptedStateFile reports whether the file at path carries the
+// encrypted-state magic. A missing file is not encrypted.
+func isEncryptedStateFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close()
+	head := make([]byte, len(encryptedStateMagic))
+	n, _ := f.Read(head)
+	return n == len(head) && bytes.Equal(head, encryptedStateMagic), nil
+}
+
+func sealState(key, plain []byte) ([]byte, error) {
+	gcm, err := newStateGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	out := make([]byte, 0, len(encryptedStateMagic)+len(nonce)+len(plain)+gcm.Overhead())
+	out = append(out, encryptedStateMagic...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plain, encryptedStateMagic), nil
+}
+
+func openSealedState(key, sealed []byte) ([]byte, error) {
+	gcm, err := newStateGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < len(encryptedStateMagic)+gcm.NonceSize() || !bytes.Equal(sealed[:len(encryptedStateMagic)], encryptedStateMagic) {
+		return nil, errors.New("not an encrypted state file")
+	}
+	rest := sealed[len(encryptedStateMagic):]
+	plain, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], encryptedStateMagic)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt state DB (wrong %s?): %w", stateKeyEnvVar, err)
+	}
+	return plain, nil
+}
+
+func newStateGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPlaintextSQLite returns the bytes of a plaintext SQLite file with any
+// WAL content checkpointed in, so migrating to encryption never drops the
+// last un-checkpointed writes. Returns nil for a missing file.
+func readPlaintextSQLite(path string) ([]byte, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("checkpoint WAL: %w", err)
+	}
+	db.Close()
+	return os.ReadFile(path)
+}
+
+// loadStateImage returns the decrypted SQLite image at path (nil for a
+// missing file). A plaintext file is accepted so enabling encryption on an
+// existing deployment migrates in place on the first persist.
+func loadStateImage(path string, key []byte) (image []byte, wasPlaintext bool, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if bytes.HasPrefix(raw, encryptedStateMagic) {
+		image, err := openSealedState(key, raw)
+		return image, false, err
+	}
+	if len(raw) > 0 && !bytes.HasPrefix(raw, sqliteFileHeader) {
+		return nil, false, fmt.Errorf("%s is neither a SQLite DB nor an encrypted state file", path)
+	}
+	image, err = readPlaintextSQLite(path)
+	return image, len(image) > 0, err
+}
+
+// openInMemoryStateDB opens a single-connection in-memory SQLite DB seeded
+// with image (empty DB when image is nil). The pool is pinned to one
+// connection so every query sees the same in-memory database.
+func openInMemoryStateDB(image []byte) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	if len(image) == 0 {
+		return db, nil
+	}
+	// Bytes 18/19 are the file-format read/write versions; 2 means WAL, which
+	// a deserialized in-memory DB cannot use. Rewrite to rollback-journal (1).
+	image = append([]byte(nil), image...)
+	if len(image) > 19 && (image[18] == 2 || image[19] == 2) {
+		image[18], image[19] = 1, 1
+	}
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	defer conn.Close()
+	err = conn.Raw(func(dc interface{}) error {
+		d, ok := dc.(interface{ Deserialize([]byte) error })
+		if !ok {
+			return errors.New("sqlite driver does not support deserialize")
+		}
+		return d.Deserialize(image)
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("load decrypted state DB: %w", err)
+	}
+	return db, nil
+}
+
+// openStateDBForRead opens the state DB for read-only inspection
+// (agent-info, diagnostics). An encrypted file is decrypted into memory; a
+// plaintext one uses the read-only DSN so nothing is migrated or written.
+func openStateDBForRead(path string) (*sql.DB, error) {
+	encrypted, err := isEncryptedStateFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	}
+	key, err := stateEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%s is encrypted; set %s to read it", path, stateKeyEnvVar)
+	}
+	image, _, err := loadStateImage(path, key)
+	if err != nil {
+		return nil, err
+	}
+	return openInMemoryStateDB(image)
+}
+
+// openEncryptedStateDB backs a StateDB with an in-memory copy of the
+// encrypted file. The process takes the <DBFile>.lock singleton flock for the
+// handle's lifetime: each process persists its whole in-memory image, so two
+// concurrent writers would silently overwrite each other's rows. A scheduler
+// already holds it (#4984), so only CLI callers take it here.
+func openEncryptedStateDB(path string, key []byte) (*StateDB, error) {
+	var lock *stateDBLock
+	if !holdsStateDBLock(path) {
+		var err error
+		if lock, err = acquireStateDBLock(path); err != nil {
+			var locked *stateDBLockedError
+			if errors.As(err, &locked) {
+				return nil, fmt.Errorf("encrypted state DB is single-writer: %w (stop it, or use the status API)", locked)
+			}
+			return nil, err
+		}
+	}
+	image, wasPlaintext, err := loadStateImage(path, key)
+	if err != nil {
+		lock.Release()
+		return nil, err
+	}
+	db, err := openInMemoryStateDB(image)
+	if err != nil {
+		lock.Release()
+		return nil, err
+	}
+	if wasPlaintext {
+		fmt.Printf("[state] %s is set; %s will be encrypted on the next save\n", stateKeyEnvVar, path)
+	}
+	return &StateDB{db: db, encPath: path, encKey: key, encLock: lock}, nil
+}
+
+// persistEncrypted writes the in-memory DB to disk, sealed, via temp file +
+// fsync + rename so a crash mid-write leaves the previous image intact. No-op
+// for a plaintext StateDB, whose writes already hit the file.
+func (sdb *StateDB) persistEncrypted() error {
+	if sdb == nil || sdb.encKey == nil {
+		return nil
+	}
+	sdb.encMu.Lock()
+	defer sdb.encMu.Unlock()
+
+	var image []byte
+	conn, err := sdb.db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	err = conn.Raw(func(dc interface{}) error {
+		s, ok := dc.(interface{ Serialize() ([]byte, error) })
+		if !ok {
+			return errors.New("sqlite driver does not support serialize")
+		}
+		image, err = s.Serialize()
+		return err
+	})
+	conn.Close()
+	if err != nil {
+		return fmt.Errorf("persist encrypted state: serialize: %w", err)
+	}
+	sealed, err := sealState(sdb.encKey, image)
+	if err != nil {
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+
+	dir := filepath.Dir(sdb.encPath)
+	tmp, err := os.CreateTemp(dir, filepath.Base(sdb.encPath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	if err := os.Rename(tmpName, sdb.encPath); err != nil {
+		return fmt.Errorf("persist encrypted state: %w", err)
+	}
+	// Stale plaintext sidecars from before the migration would otherwise
+	// keep un-encrypted pages on disk.
+	os.Remove(sdb.encPath + "-wal")
+	os.Remove(sdb.encPath + "-shm")
+	return nil
+}
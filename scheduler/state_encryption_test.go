@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testStateKeyHex = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestStateEncryptionKeyParsing(t *testing.T) {
+	t.Setenv(stateKeyEnvVar, "")
+	if key, err := stateEncryptionKey(); err != nil || key != nil {
+		t.Fatalf("unset key = %v, %v; want nil, nil", key, err)
+	}
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	if key, err := stateEncryptionKey(); err != nil || len(key) != 32 {
+		t.Fatalf("hex key = %d bytes, %v", len(key), err)
+	}
+	t.Setenv(stateKeyEnvVar, "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	if key, err := stateEncryptionKey(); err != nil || len(key) != 32 {
+		t.Fatalf("base64 key = %d bytes, %v", len(key), err)
+	}
+	t.Setenv(stateKeyEnvVar, "hunter2")
+	if _, err := stateEncryptionKey(); err == nil {
+		t.Fatal("short key must be rejected, not fall back to plaintext")
+	}
+}
+
+func TestEncryptedStateDBRoundTrip(t *testing.T) {
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	state := makeTestState()
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(raw, encryptedStateMagic) {
+		t.Fatalf("state file not encrypted: header %q", raw[:16])
+	}
+	if bytes.Contains(raw, []byte("hl-momentum-btc")) {
+		t.Fatal("strategy ID leaked into encrypted file")
+	}
+
+	db, err = OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if loaded == nil || loaded.CycleCount != state.CycleCount || loaded.Strategies["hl-momentum-btc"] == nil {
+		t.Fatalf("round trip lost state: %+v", loaded)
+	}
+}
+
+func TestEncryptedStateDBMigratesPlaintext(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+	t.Setenv(stateKeyEnvVar, "")
+	plain, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB plaintext: %v", err)
+	}
+	if err := plain.SaveState(makeTestState()); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	plain.Close()
+
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB encrypted: %v", err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil || loaded == nil || loaded.CycleCount != 42 {
+		t.Fatalf("plaintext state not carried over: %+v, %v", loaded, err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if enc, _ := isEncryptedStateFile(path); !enc {
+		t.Fatal("plaintext DB not encrypted after close")
+	}
+	if _, err := os.Stat(path + "-wal"); !os.IsNotExist(err) {
+		t.Errorf("plaintext WAL sidecar left behind: %v", err)
+	}
+}
+
+func TestEncryptedStateDBRequiresCorrectKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	db.Close()
+
+	t.Setenv(stateKeyEnvVar, "")
+	if _, err := OpenStateDB(path); err == nil || !strings.Contains(err.Error(), stateKeyEnvVar) {
+		t.Fatalf("missing key err = %v, want hint naming %s", err, stateKeyEnvVar)
+	}
+	t.Setenv(stateKeyEnvVar, strings.Repeat("ff", 32))
+	if _, err := OpenStateDB(path); err == nil || !strings.Contains(err.Error(), "decrypt") {
+		t.Fatalf("wrong key err = %v, want decrypt failure", err)
+	}
+}
+
+func TestEncryptedStateDBSingleWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	defer db.Close()
+	if _, err := OpenStateDB(path); err == nil || !strings.Contains(err.Error(), "single-writer") {
+		t.Fatalf("second open err = %v, want single-writer refusal", err)
+	}
+}
+
+func TestEncryptedStateDBUsesSchedulerLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	lock, err := acquireStateDBLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := heldStateDBLock
+	heldStateDBLock = lock
+	defer func() { heldStateDBLock = old; lock.Release() }()
+
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB under the scheduler's own lock: %v", err)
+	}
+	db.Close()
+	if !holdsStateDBLock(path) {
+		t.Error("closing the DB released the scheduler's lock")
+	}
+}
+
+func TestOpenStateDBForReadDecrypts(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	if err := db.SaveState(makeTestState()); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	db.Close()
+
+	var ro *sql.DB
+	if ro, err = openStateDBForRead(path); err != nil {
+		t.Fatalf("openStateDBForRead: %v", err)
+	}
+	defer ro.Close()
+	var n int
+	if err := ro.QueryRow("SELECT COUNT(*) FROM strategies").Scan(&n); err != nil || n == 0 {
+		t.Fatalf("strategies count = %d, %v", n, err)
+	}
+}
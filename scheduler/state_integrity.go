@@ -0,0 +1,299 @@
+package main
+
+// state_integrity: state DB checksum, rotating backups and corruption
+// recovery (#4918).
+//
+// Every writer of the core state tables (SaveState, the initial_capital
+// override, the ledger backfills) restamps app_state.state_checksum — a
+// SHA-256 over the strategies' identity/cash/capital and every open position
+// and option position — inside its own transaction. At startup
+// openStateDBWithRecovery runs PRAGMA quick_check and recomputes the hash; a
+// mismatch means the file was truncated, partially written or edited under
+// us. A corrupt DB (or one SQLite/the decryptor refuses to open) is moved
+// aside as <db_file>.corrupt-<ts> and the newest backup that itself passes
+// both checks is restored, with a loud owner alert. Backups are taken hourly
+// after a successful cycle save (<db_file>.bak.1 newest … .bak.3 oldest).
+// A DB written before the checksum existed (empty column) is not verified
+// until its next save stamps it.
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	stateBackupCount    = 3
+	stateBackupInterval = time.Hour
+)
+
+// errStateCorrupt marks an integrity failure detected by this file's checks.
+var errStateCorrupt = errors.New("state DB integrity check failed")
+
+type stateChecksumQuerier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// computeStateChecksum hashes the core state rows in a canonical order.
+// Floats are rendered with strconv 'g'/-1, which round-trips SQLite REALs
+// exactly, so the hash is stable across save and load.
+func computeStateChecksum(q stateChecksumQuerier) (string, error) {
+	h := sha256.New()
+	for _, query := range []string{
+		"SELECT id, type, platform, cash, initial_capital FROM strategies ORDER BY id",
+		"SELECT strategy_id, symbol, side, quantity, avg_cost FROM positions ORDER BY strategy_id, symbol",
+		"SELECT strategy_id, id, quantity, strike FROM option_positions ORDER BY strategy_id, id",
+	} {
+		rows, err := q.Query(query)
+		if err != nil {
+			return "", fmt.Errorf("checksum query: %w", err)
+		}
+		cols, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return "", err
+		}
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		for rows.Next() {
+			if err := rows.Scan(ptrs...); err != nil {
+				rows.Close()
+				return "", fmt.Errorf("checksum scan: %w", err)
+			}
+			for _, v := range vals {
+				h.Write([]byte(checksumField(v)))
+				h.Write([]byte{0})
+			}
+			h.Write([]byte{'\n'})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0x1e})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func checksumField(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case []byte:
+		return string(x)
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// stampStateChecksum recomputes and stores the checksum inside tx. Callers
+// that write strategies/positions/option_positions must call it before Commit.
+func stampStateChecksum(tx *sql.Tx) error {
+	sum, err := computeStateChecksum(tx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE app_state SET state_checksum = ? WHERE id = 1", sum); err != nil {
+		return fmt.Errorf("stamp state checksum: %w", err)
+	}
+	return nil
+}
+
+// VerifyIntegrity runs SQLite's quick_check and compares the stored state
+// checksum (when present) against the rows on disk.
+func (sdb *StateDB) VerifyIntegrity() error {
+	var result string
+	if err := sdb.db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("%w: quick_check: %v", errStateCorrupt, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("%w: quick_check: %s", errStateCorrupt, result)
+	}
+	var stored string
+	err := sdb.db.QueryRow("SELECT state_checksum FROM app_state WHERE id = 1").Scan(&stored)
+	if err == sql.ErrNoRows || (err == nil && stored == "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: read state checksum: %v", errStateCorrupt, err)
+	}
+	sum, err := computeStateChecksum(sdb.db)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errStateCorrupt, err)
+	}
+	if sum != stored {
+		return fmt.Errorf("%w: state checksum mismatch (stored %.12s…, on disk %.12s…)", errStateCorrupt, stored, sum)
+	}
+	return nil
+}
+
+// isStateCorruption reports whether an open/verify error looks like a damaged
+// file rather than an operational problem (lock held, missing key, bad path).
+// A GCM failure is indistinguishable from a wrong key, which is why recovery
+// only restores a backup that itself opens with the same key.
+func isStateCorruption(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errStateCorrupt) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"malformed", "not a database", "disk image", "decrypt state db", "neither a sqlite db nor an encrypted state file", "load decrypted state db"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// stateBackupPath returns the n-th rotating backup (1 = newest).
+func stateBackupPath(dbFile string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", dbFile, n)
+}
+
+// openVerifiedStateDB opens path and verifies it, closing it on failure.
+func openVerifiedStateDB(path string) (*StateDB, error) {
+	sdb, err := OpenStateDB(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := sdb.VerifyIntegrity(); err != nil {
+		sdb.closeDB()
+		return nil, err
+	}
+	return sdb, nil
+}
+
+// verifyStateBackup checks a backup on a scratch copy so opening it (schema
+// migration, WAL sidecars) never mutates the backup itself.
+func verifyStateBackup(backup string) error {
+	if _, err := os.Stat(backup); err != nil {
+		return err
+	}
+	scratch := backup + ".verify"
+	defer func() {
+		os.Remove(scratch)
+		os.Remove(scratch + "-wal")
+		os.Remove(scratch + "-shm")
+	}()
+	if err := copyFileSync(backup, scratch); err != nil {
+		return err
+	}
+	sdb, err := openVerifiedStateDB(scratch)
+	if err != nil {
+		return err
+	}
+	sdb.closeDB()
+	return nil
+}
+
+// openStateDBWithRecovery opens the state DB, verifying its integrity. On
+// corruption it restores the newest valid backup and returns an operator
+// message describing the recovery; any other error is returned unchanged.
+func openStateDBWithRecovery(dbFile string) (*StateDB, string, error) {
+	sdb, err := openVerifiedStateDB(dbFile)
+	if err == nil {
+		return sdb, "", nil
+	}
+	if !isStateCorruption(err) {
+		return nil, "", err
+	}
+	corruptErr := err
+	for n := 1; n <= stateBackupCount; n++ {
+		backup := stateBackupPath(dbFile, n)
+		if verr := verifyStateBackup(backup); verr != nil {
+			if !os.IsNotExist(verr) {
+				fmt.Fprintf(os.Stderr, "[state] backup %s unusable: %v\n", backup, verr)
+			}
+			continue
+		}
+		backupTime := "unknown time"
+		if fi, statErr := os.Stat(backup); statErr == nil {
+			backupTime = fi.ModTime().UTC().Format(time.RFC3339)
+		}
+		aside := fmt.Sprintf("%s.corrupt-%s", dbFile, time.Now().UTC().Format("20060102T150405Z"))
+		if err := os.Rename(dbFile, aside); err != nil && !os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("move corrupt state DB aside: %w (original error: %v)", err, corruptErr)
+		}
+		for _, sidecar := range []string{"-wal", "-shm"} {
+			os.Rename(dbFile+sidecar, aside+sidecar)
+		}
+		if err := restoreStateBackup(backup, dbFile); err != nil {
+			return nil, "", fmt.Errorf("restore %s: %w (original error: %v)", backup, err, corruptErr)
+		}
+		sdb, err := openVerifiedStateDB(dbFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("open restored %s: %w (original error: %v)", backup, err, corruptErr)
+		}
+		msg := fmt.Sprintf("CRITICAL: state DB %s failed its integrity check (%v). Moved it aside to %s and restored backup %s (taken %s). Trades, positions and cash changed since that backup must be reconciled against the venues.",
+			dbFile, corruptErr, aside, backup, backupTime)
+		return sdb, msg, nil
+	}
+	return nil, "", fmt.Errorf("%w; no valid backup found (%s)", corruptErr, stateBackupPath(dbFile, 1))
+}
+
+var (
+	stateBackupMu     sync.Mutex
+	lastStateBackupAt time.Time
+)
+
+// maybeRotateStateBackups takes a fresh backup after a successful save when
+// the newest one is older than stateBackupInterval, shifting older backups
+// down and dropping the oldest. The first call after a restart dates the
+// newest backup by its mtime, so restarts don't rotate good backups out. The
+// new copy is verified before anything rotates. Failures are logged and never
+// fail the cycle.
+func maybeRotateStateBackups(sdb *StateDB, dbFile string, now time.Time) {
+	if dbFile == "" || dbFile == ":memory:" {
+		return
+	}
+	stateBackupMu.Lock()
+	defer stateBackupMu.Unlock()
+	if lastStateBackupAt.IsZero() {
+		if fi, err := os.Stat(stateBackupPath(dbFile, 1)); err == nil {
+			lastStateBackupAt = fi.ModTime()
+		}
+	}
+	if !lastStateBackupAt.IsZero() && now.Sub(lastStateBackupAt) < stateBackupInterval {
+		return
+	}
+	tmp := stateBackupPath(dbFile, 0) + ".tmp"
+	if err := sdb.BackupTo(tmp); err != nil {
+		os.Remove(tmp)
+		fmt.Fprintf(os.Stderr, "[state] WARN: state backup failed: %v\n", err)
+		return
+	}
+	if err := verifyStateBackup(tmp); err != nil {
+		os.Remove(tmp)
+		fmt.Fprintf(os.Stderr, "[state] WARN: new state backup failed verification, keeping the old ones: %v\n", err)
+		return
+	}
+	for n := stateBackupCount - 1; n >= 1; n-- {
+		if err := os.Rename(stateBackupPath(dbFile, n), stateBackupPath(dbFile, n+1)); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "[state] WARN: rotate state backup %d: %v\n", n, err)
+		}
+	}
+	if err := os.Rename(tmp, stateBackupPath(dbFile, 1)); err != nil {
+		fmt.Fprintf(os.Stderr, "[state] WARN: install state backup: %v\n", err)
+		return
+	}
+	lastStateBackupAt = now
+}
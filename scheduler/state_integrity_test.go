@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func saveIntegrityTestDB(t *testing.T, path string, cycle int) {
+	t.Helper()
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	state := makeTestState()
+	state.CycleCount = cycle
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestStateChecksumDetectsOutOfBandEdit(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+	saveIntegrityTestDB(t, path, 42)
+
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.VerifyIntegrity(); err != nil {
+		t.Fatalf("fresh save should verify: %v", err)
+	}
+	if _, err := db.db.Exec("UPDATE strategies SET cash = cash + 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.VerifyIntegrity(); !errors.Is(err, errStateCorrupt) {
+		t.Fatalf("VerifyIntegrity = %v, want errStateCorrupt", err)
+	}
+}
+
+func TestStateChecksumLegacyDBSkipped(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	path := filepath.Join(t.TempDir(), "state.db")
+	saveIntegrityTestDB(t, path, 42)
+
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.db.Exec("UPDATE app_state SET state_checksum = ''"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.db.Exec("UPDATE strategies SET cash = cash + 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.VerifyIntegrity(); err != nil {
+		t.Fatalf("legacy DB without checksum should verify: %v", err)
+	}
+}
+
+func TestOpenStateDBWithRecoveryRestoresNewestValidBackup(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.db")
+	saveIntegrityTestDB(t, path, 42)
+
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.BackupTo(stateBackupPath(path, 2)); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	// Newest backup is itself damaged; recovery must skip it.
+	if err := os.WriteFile(stateBackupPath(path, 1), []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Truncate the live DB mid-file.
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, fi.Size()/3); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(path + "-wal")
+	os.Remove(path + "-shm")
+
+	db, notice, err := openStateDBWithRecovery(path)
+	if err != nil {
+		t.Fatalf("openStateDBWithRecovery: %v", err)
+	}
+	defer db.Close()
+	if !strings.Contains(notice, "CRITICAL") || !strings.Contains(notice, stateBackupPath(path, 2)) {
+		t.Errorf("notice = %q, want CRITICAL naming .bak.2", notice)
+	}
+	loaded, err := db.LoadState()
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if loaded.CycleCount != 42 {
+		t.Errorf("CycleCount = %d, want 42 from backup", loaded.CycleCount)
+	}
+	aside, _ := filepath.Glob(path + ".corrupt-*")
+	if len(aside) == 0 {
+		t.Error("corrupt DB was not moved aside")
+	}
+}
+
+func TestOpenStateDBWithRecoveryNoBackupFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	if err := os.WriteFile(path, []byte("SQLite format 3\x00garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := openStateDBWithRecovery(path); err == nil || !strings.Contains(err.Error(), "no valid backup") {
+		t.Fatalf("err = %v, want no valid backup", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("corrupt DB must stay in place when nothing was restored: %v", err)
+	}
+}
+
+func TestMaybeRotateStateBackups(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	saved := lastStateBackupAt
+	lastStateBackupAt = time.Time{}
+	t.Cleanup(func() { lastStateBackupAt = saved })
+
+	path := filepath.Join(t.TempDir(), "state.db")
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.SaveState(makeTestState()); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	maybeRotateStateBackups(db, path, now)
+	maybeRotateStateBackups(db, path, now.Add(time.Minute))
+	if _, err := os.Stat(stateBackupPath(path, 2)); !os.IsNotExist(err) {
+		t.Fatal("backup rotated again inside the interval")
+	}
+	for i := 1; i <= stateBackupCount+1; i++ {
+		maybeRotateStateBackups(db, path, now.Add(time.Duration(i)*stateBackupInterval))
+	}
+	for n := 1; n <= stateBackupCount; n++ {
+		if err := verifyStateBackup(stateBackupPath(path, n)); err != nil {
+			t.Errorf("backup %d: %v", n, err)
+		}
+	}
+	if _, err := os.Stat(stateBackupPath(path, stateBackupCount+1)); !os.IsNotExist(err) {
+		t.Error("more than stateBackupCount backups kept")
+	}
+}
+
+// After a restart the interval runs from the newest backup's mtime, not from
+// process start.
+func TestMaybeRotateStateBackupsSeedsFromNewestBackup(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	saved := lastStateBackupAt
+	t.Cleanup(func() { lastStateBackupAt = saved })
+
+	path := filepath.Join(t.TempDir(), "state.db")
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.SaveState(makeTestState()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.BackupTo(stateBackupPath(path, 1)); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := os.Chtimes(stateBackupPath(path, 1), now, now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	lastStateBackupAt = time.Time{}
+	maybeRotateStateBackups(db, path, now)
+	if _, err := os.Stat(stateBackupPath(path, 2)); !os.IsNotExist(err) {
+		t.Fatal("restart rotated a backup taken inside the interval")
+	}
+
+	old := now.Add(-2 * stateBackupInterval)
+	if err := os.Chtimes(stateBackupPath(path, 1), old, old); err != nil {
+		t.Fatal(err)
+	}
+	lastStateBackupAt = time.Time{}
+	maybeRotateStateBackups(db, path, now)
+	if err := verifyStateBackup(stateBackupPath(path, 2)); err != nil {
+		t.Fatalf("stale backup not rotated: %v", err)
+	}
+}
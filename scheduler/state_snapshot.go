@@ -0,0 +1,208 @@
+package main
+
+// state_snapshot: copy-on-read AppState for HTTP/Discord readers (#4919).
+//
+// The scheduler's single sync.RWMutex serializes every AppState mutation, so
+// a cycle that holds mu.Lock across a slow venue call used to park /status,
+// /metrics, /health and the read-only slash commands behind it. Readers now
+// call ss.readState(): a copy published within stateSnapshotReuseWindow is
+// shared as-is, so a burst of dashboard polls costs one clone rather than one
+// each. Otherwise, when mu is free they clone a fresh copy under a brief RLock
+// and publish it; while a writer holds (or is waiting for) mu they are served
+// the last published copy instead of queueing. The main loop also publishes a
+// copy at the end of every cycle, so the fallback is at most one cycle stale.
+// Only before the first snapshot exists does a reader block. Operator
+// mutations outside the cycle (Discord /mute, /position, /note, ...) expire
+// the held copy so the next read sees them. /health reads
+// LastCycle directly (readLastCycle) and never clones.
+//
+// Each clone takes a sequence number while it still holds RLock, so a copy
+// taken before a write always numbers below one taken after it; publish only
+// replaces the held copy with a newer one, so a slow reader cannot roll the
+// snapshot back.
+//
+// The clone is deep for everything a reader can observe (strategies,
+// positions, risk state, trade history, timing history) so it is safe to use
+// without any lock; writers never see it.
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// stateSnapshotReuseWindow is how long a published snapshot is served
+// without re-cloning. Tests set it to 0 to force fresh reads.
+var stateSnapshotReuseWindow = time.Second
+
+// stateSnapshot is one published read-only copy of AppState.
+type stateSnapshot struct {
+	state *AppState
+	seq   uint64
+	at    time.Time
+}
+
+// stateSnapshotHolder stores the most recently published read snapshot.
+type stateSnapshotHolder struct {
+	p   atomic.Pointer[stateSnapshot]
+	seq atomic.Uint64
+}
+
+// capture clones state and stamps it with the next sequence number. Caller
+// holds at least mu.RLock so no write can land between the clone and the stamp.
+func (h *stateSnapshotHolder) capture(state *AppState) *stateSnapshot {
+	return &stateSnapshot{state: cloneAppStateForRead(state), seq: h.seq.Add(1), at: time.Now()}
+}
+
+// publish stores snap unless a newer snapshot is already held.
+func (h *stateSnapshotHolder) publish(snap *stateSnapshot) {
+	for {
+		cur := h.p.Load()
+		if cur != nil && cur.seq >= snap.seq {
+			return
+		}
+		if h.p.CompareAndSwap(cur, snap) {
+			return
+		}
+	}
+}
+
+// expire marks the held snapshot as too old to reuse, so the next readState
+// re-clones. The copy itself stays as the fallback while mu is held.
+func (h *stateSnapshotHolder) expire() {
+	for {
+		cur := h.p.Load()
+		if cur == nil || cur.at.IsZero() {
+			return
+		}
+		stale := *cur
+		stale.at = time.Time{}
+		if h.p.CompareAndSwap(cur, &stale) {
+			return
+		}
+	}
+}
+
+// readState returns a lock-free read-only AppState. Callers must not mutate it.
+func (ss *StatusServer) readState() *AppState {
+	cur := ss.snapshot.p.Load()
+	if cur != nil && time.Since(cur.at) < stateSnapshotReuseWindow {
+		return cur.state
+	}
+	if ss.mu.TryRLock() {
+		snap := ss.snapshot.capture(ss.state)
+		ss.mu.RUnlock()
+		ss.snapshot.publish(snap)
+		return snap.state
+	}
+	if cur != nil {
+		return cur.state
+	}
+	ss.mu.RLock()
+	snap := ss.snapshot.capture(ss.state)
+	ss.mu.RUnlock()
+	ss.snapshot.publish(snap)
+	return snap.state
+}
+
+// readLastCycle returns state.LastCycle without cloning: read directly when
+// mu is free, from the published snapshot while a writer holds it.
+func (ss *StatusServer) readLastCycle() time.Time {
+	if ss.mu.TryRLock() {
+		defer ss.mu.RUnlock()
+		return ss.state.LastCycle
+	}
+	if cur := ss.snapshot.p.Load(); cur != nil {
+		return cur.state.LastCycle
+	}
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.state.LastCycle
+}
+
+// publishStateSnapshot refreshes the fallback copy. Caller must NOT hold mu.
+func (ss *StatusServer) publishStateSnapshot() {
+	ss.mu.RLock()
+	snap := ss.snapshot.capture(ss.state)
+	ss.mu.RUnlock()
+	ss.snapshot.publish(snap)
+}
+
+// cloneAppStateForRead deep-copies state for lock-free readers. Caller holds
+// at least mu.RLock.
+func cloneAppStateForRead(state *AppState) *AppState {
+	if state == nil {
+		return &AppState{Strategies: map[string]*StrategyState{}}
+	}
+	out := *state
+	out.Strategies = make(map[string]*StrategyState, len(state.Strategies))
+	for id, s := range state.Strategies {
+		if s != nil {
+			out.Strategies[id] = cloneStrategyStateForRead(s)
+		}
+	}
+	out.PortfolioRisk.Events = append([]KillSwitchEvent(nil), state.PortfolioRisk.Events...)
+	out.PortfolioRisk.History = append([]PortfolioRiskSample(nil), state.PortfolioRisk.History...)
+	if v := state.PortfolioRisk.VaR; v != nil {
+		cp := *v
+		out.PortfolioRisk.VaR = &cp
+	}
+	if state.PlatformRisk != nil {
+		out.PlatformRisk = make(map[string]*PlatformRiskState, len(state.PlatformRisk))
+		for k, v := range state.PlatformRisk {
+			if v != nil {
+				cp := *v
+				out.PlatformRisk[k] = &cp
+			}
+		}
+	}
+	if state.ReconciliationGaps != nil {
+		out.ReconciliationGaps = make(map[string]*ReconciliationGap, len(state.ReconciliationGaps))
+		for k, v := range state.ReconciliationGaps {
+			if v != nil {
+				cp := *v
+				cp.Strategies = append([]string(nil), v.Strategies...)
+				out.ReconciliationGaps[k] = &cp
+			}
+		}
+	}
+	out.LastLeaderboardSummaries = cloneTimeMap(state.LastLeaderboardSummaries)
+	out.LastSummaryPost = cloneTimeMap(state.LastSummaryPost)
+	out.MutedStrategies = cloneTimeMap(state.MutedStrategies)
+	out.CycleTimings = append([]CycleTiming(nil), state.CycleTimings...)
+	return &out
+}
+
+func cloneStrategyStateForRead(s *StrategyState) *StrategyState {
+	cp := *s
+	cp.Positions = cloneUIPositions(s.Positions)
+	cp.OptionPositions = cloneUIOptionPositions(s.OptionPositions)
+	cp.TradeHistory = append([]Trade(nil), s.TradeHistory...)
+	cp.RiskState = cloneUIRiskState(s.RiskState)
+	cp.RegimeWindows = cloneStringMap(s.RegimeWindows)
+	if s.RegimeDivergence != nil {
+		d := *s.RegimeDivergence
+		cp.RegimeDivergence = &d
+	}
+	if s.RegimeProfile != nil {
+		p := *s.RegimeProfile
+		cp.RegimeProfile = &p
+	}
+	if s.PaperOrders != nil {
+		cp.PaperOrders = make(map[string]*PaperOrder, len(s.PaperOrders))
+		for k, v := range s.PaperOrders {
+			if v != nil {
+				o := *v
+				cp.PaperOrders[k] = &o
+			}
+		}
+	}
+	if s.Benchmark != nil {
+		b := *s.Benchmark
+		b.Points = append([]BenchmarkPoint(nil), s.Benchmark.Points...)
+		cp.Benchmark = &b
+	}
+	// Per-cycle flush buffers are writer-only.
+	cp.ClosedPositions = nil
+	cp.ClosedOptionPositions = nil
+	return &cp
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadStateServesSnapshotWhileWriterHoldsLock(t *testing.T) {
+	var mu sync.RWMutex
+	state := &AppState{
+		CycleCount: 1,
+		Strategies: map[string]*StrategyState{
+			"s1": {ID: "s1", Cash: 100, Positions: map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 1}}},
+		},
+	}
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+	ss.publishStateSnapshot()
+	ss.snapshot.expire()
+
+	mu.Lock()
+	state.CycleCount = 2
+	state.Strategies["s1"].Cash = 50
+	done := make(chan *AppState, 1)
+	go func() { done <- ss.readState() }()
+	var got *AppState
+	select {
+	case got = <-done:
+	case <-time.After(2 * time.Second):
+		mu.Unlock()
+		t.Fatal("readState blocked behind the write lock")
+	}
+	mu.Unlock()
+	if got.CycleCount != 1 || got.Strategies["s1"].Cash != 100 {
+		t.Fatalf("snapshot = cycle %d cash %g, want last published 1/100", got.CycleCount, got.Strategies["s1"].Cash)
+	}
+
+	ss.snapshot.expire()
+	fresh := ss.readState()
+	if fresh.CycleCount != 2 || fresh.Strategies["s1"].Cash != 50 {
+		t.Fatalf("fresh read = cycle %d cash %g, want 2/50", fresh.CycleCount, fresh.Strategies["s1"].Cash)
+	}
+}
+
+func TestReadStateReusesRecentSnapshot(t *testing.T) {
+	var mu sync.RWMutex
+	state := &AppState{CycleCount: 1, Strategies: map[string]*StrategyState{}}
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+
+	first := ss.readState()
+	mu.Lock()
+	state.CycleCount = 2
+	mu.Unlock()
+	if again := ss.readState(); again != first {
+		t.Fatal("read within the reuse window re-cloned state")
+	}
+
+	ss.snapshot.expire()
+	if fresh := ss.readState(); fresh == first || fresh.CycleCount != 2 {
+		t.Fatalf("read after expire = cycle %d, want a fresh clone at cycle 2", fresh.CycleCount)
+	}
+}
+
+func TestStateSnapshotPublishKeepsNewest(t *testing.T) {
+	var h stateSnapshotHolder
+	older := h.capture(&AppState{CycleCount: 1})
+	newer := h.capture(&AppState{CycleCount: 2})
+
+	h.publish(newer)
+	h.publish(older)
+	if got := h.p.Load().state.CycleCount; got != 2 {
+		t.Fatalf("held snapshot cycle = %d, want the newer 2", got)
+	}
+}
+
+func TestReadLastCycleDoesNotClone(t *testing.T) {
+	var mu sync.RWMutex
+	last := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := &AppState{LastCycle: last, Strategies: map[string]*StrategyState{}}
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+
+	if got := ss.readLastCycle(); !got.Equal(last) {
+		t.Fatalf("readLastCycle = %v, want %v", got, last)
+	}
+	if ss.snapshot.p.Load() != nil {
+		t.Fatal("readLastCycle published a cloned snapshot")
+	}
+
+	ss.publishStateSnapshot()
+	mu.Lock()
+	state.LastCycle = last.Add(time.Minute)
+	got := ss.readLastCycle()
+	mu.Unlock()
+	if !got.Equal(last) {
+		t.Fatalf("readLastCycle under write lock = %v, want snapshot value %v", got, last)
+	}
+}
+
+func TestCloneAppStateForReadIsIndependent(t *testing.T) {
+	state := &AppState{
+		Strategies: map[string]*StrategyState{
+			"s1": {
+				ID:           "s1",
+				Positions:    map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 1}},
+				TradeHistory: []Trade{{Symbol: "BTC"}},
+			},
+		},
+		CycleTimings: []CycleTiming{{}},
+	}
+	snap := cloneAppStateForRead(state)
+	state.Strategies["s1"].Positions["BTC"].Quantity = 3
+	state.Strategies["s1"].Positions["ETH"] = &Position{Symbol: "ETH"}
+	state.Strategies["s1"].TradeHistory[0].Symbol = "ETH"
+	state.Strategies["s2"] = &StrategyState{ID: "s2"}
+	state.CycleTimings[0].Slow = true
+
+	s1 := snap.Strategies["s1"]
+	if s1.Positions["BTC"].Quantity != 1 || len(s1.Positions) != 1 {
+		t.Errorf("positions leaked into snapshot: %+v", s1.Positions)
+	}
+	if s1.TradeHistory[0].Symbol != "BTC" {
+		t.Error("trade history shares backing array with live state")
+	}
+	if len(snap.Strategies) != 1 || snap.CycleTimings[0].Slow {
+		t.Error("strategies map or cycle timings shared with live state")
+	}
+}
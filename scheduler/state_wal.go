@@ -0,0 +1,268 @@
+package main
+
+// state_wal: redo journal for in-cycle state mutations (#4917).
+//
+// Positions, cash and risk state only reach SQLite at the cycle-end SaveState.
+// Trades are persisted eagerly (#289), but the position/cash mutation that
+// accompanies them is not, so a crash — or a run of failed saves — left the
+// DB behind what actually happened on the venue. RecordTrade marks its
+// strategy dirty; once the mutation around the trade is complete (after each
+// strategy's Phase 4, and whenever a save fails) Flush appends one fsync'd
+// line per dirty strategy to <db_file>.wal holding an image of its state
+// (trade history stripped down to the trades whose eager insert failed; the
+// in-cycle closed-position buffer included). Journaling at RecordTrade time
+// would capture a half-applied close — the position still open, the PnL not
+// yet booked — and replay would resurrect a position the venue no longer
+// holds. A successful SaveStateWithDB truncates the file, so any record
+// present at startup postdates the last completed save: replayStateWAL
+// restores each strategy from its newest image and the caller saves
+// immediately.
+//
+// Images make replay idempotent — a WAL replayed twice (e.g. the post-replay
+// save failed too) converges on the same state. A torn final line from a
+// crash mid-append is ignored. With state encryption on (#4885) every line
+// is sealed with the state key, like the DB file itself.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// stateWALRecord is one line of the redo journal.
+type stateWALRecord struct {
+	At                    time.Time              `json:"at"`
+	StrategyID            string                 `json:"strategy_id"`
+	UnpersistedTrades     []Trade                `json:"unpersisted_trades,omitempty"`
+	State                 *StrategyState         `json:"state"`
+	ClosedPositions       []ClosedPosition       `json:"closed_positions,omitempty"`
+	ClosedOptionPositions []ClosedOptionPosition `json:"closed_option_positions,omitempty"`
+}
+
+// stateWALSealedPrefix marks a line sealed with the state encryption key.
+var stateWALSealedPrefix = []byte("sealed:")
+
+// StateWAL is an append-only redo journal file.
+type StateWAL struct {
+	mu    sync.Mutex
+	path  string
+	key   []byte                    // state encryption key; nil writes plaintext lines
+	dirty map[string]*StrategyState // strategies with a trade since the last flush/save
+}
+
+// newStateWAL returns the journal at path, sealing lines with key when set.
+func newStateWAL(path string, key []byte) *StateWAL {
+	return &StateWAL{path: path, key: key, dirty: make(map[string]*StrategyState)}
+}
+
+// stateWAL is the package-level journal, wired in main after state load. nil
+// disables journaling (tests, CLI subcommands).
+var stateWAL *StateWAL
+
+// stateWALPath returns the journal path for a state DB file ("" when the DB
+// has no file to sit beside).
+func stateWALPath(dbFile string) string {
+	if dbFile == "" || dbFile == ":memory:" {
+		return ""
+	}
+	return dbFile + ".wal"
+}
+
+// MarkDirty notes that s recorded a trade whose mutation is still being
+// applied. Called from RecordTrade under the state lock; no I/O.
+func (w *StateWAL) MarkDirty(s *StrategyState) {
+	if w == nil || s == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.dirty == nil {
+		w.dirty = make(map[string]*StrategyState)
+	}
+	w.dirty[s.ID] = s
+}
+
+// Flush journals the current image of every dirty strategy with one fsync.
+// Call it under the state lock (read or write), at a point where no
+// strategy is mid-mutation.
+func (w *StateWAL) Flush() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.dirty) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	now := time.Now().UTC()
+	for _, s := range w.dirty {
+		line, err := w.encodeRecord(s, now)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	clear(w.dirty)
+	return nil
+}
+
+// encodeRecord renders one journal line for s, sealed when a key is set.
+func (w *StateWAL) encodeRecord(s *StrategyState, now time.Time) ([]byte, error) {
+	image := *s
+	image.TradeHistory = nil
+	rec := stateWALRecord{
+		At:                    now,
+		StrategyID:            s.ID,
+		State:                 &image,
+		ClosedPositions:       s.ClosedPositions,
+		ClosedOptionPositions: s.ClosedOptionPositions,
+	}
+	for _, t := range s.TradeHistory {
+		if !t.persisted {
+			rec.UnpersistedTrades = append(rec.UnpersistedTrades, t)
+		}
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal wal record: %w", err)
+	}
+	if w.key == nil {
+		return line, nil
+	}
+	sealed, err := sealState(w.key, line)
+	if err != nil {
+		return nil, fmt.Errorf("seal wal record: %w", err)
+	}
+	return append(append([]byte(nil), stateWALSealedPrefix...), base64.StdEncoding.EncodeToString(sealed)...), nil
+}
+
+// Truncate empties the journal after a completed save; everything dirty is
+// now in the DB.
+func (w *StateWAL) Truncate() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	clear(w.dirty)
+	if err := os.Truncate(w.path, 0); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readStateWAL returns every well-formed record in path, opening sealed lines
+// with key. A missing file is an empty journal; an unparsable line (torn
+// final append) is skipped. A sealed line without a key is an error: the
+// journal cannot be replayed, and must not be truncated unread.
+func readStateWAL(path string, key []byte) ([]stateWALRecord, int, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	var recs []stateWALRecord
+	skipped := 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if sealedB64, ok := bytes.CutPrefix(line, stateWALSealedPrefix); ok {
+			if key == nil {
+				return nil, 0, fmt.Errorf("%s is encrypted; set %s to replay it", path, stateKeyEnvVar)
+			}
+			sealed, err := base64.StdEncoding.DecodeString(string(sealedB64))
+			if err != nil {
+				skipped++
+				continue
+			}
+			if line, err = openSealedState(key, sealed); err != nil {
+				skipped++
+				continue
+			}
+		}
+		var rec stateWALRecord
+		if err := json.Unmarshal(line, &rec); err != nil || rec.State == nil || rec.StrategyID == "" {
+			skipped++
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs, skipped, sc.Err()
+}
+
+// replayStateWAL applies the journal at path to a freshly loaded state and
+// returns one operator line per restored strategy (nil when the journal is
+// empty, i.e. the last save completed).
+func replayStateWAL(state *AppState, path string, key []byte) ([]string, error) {
+	recs, skipped, err := readStateWAL(path, key)
+	if err != nil {
+		return nil, fmt.Errorf("read state wal: %w", err)
+	}
+	if len(recs) == 0 {
+		return nil, nil
+	}
+	latest := make(map[string]stateWALRecord)
+	var order []string
+	for _, rec := range recs {
+		if _, seen := latest[rec.StrategyID]; !seen {
+			order = append(order, rec.StrategyID)
+		}
+		latest[rec.StrategyID] = rec
+	}
+	var lines []string
+	for _, id := range order {
+		rec := latest[id]
+		restored := rec.State
+		if cur := state.Strategies[id]; cur != nil {
+			restored.TradeHistory = cur.TradeHistory
+			restored.RegimeDivergence = cur.RegimeDivergence
+		}
+		// Each image carries every trade still unpersisted when it was
+		// written, so the newest one alone is complete.
+		restored.TradeHistory = append(restored.TradeHistory, rec.UnpersistedTrades...)
+		restored.ClosedPositions = rec.ClosedPositions
+		restored.ClosedOptionPositions = rec.ClosedOptionPositions
+		if restored.Positions == nil {
+			restored.Positions = make(map[string]*Position)
+		}
+		if restored.OptionPositions == nil {
+			restored.OptionPositions = make(map[string]*OptionPosition)
+		}
+		state.Strategies[id] = restored
+		lines = append(lines, fmt.Sprintf("restored %s from the state WAL (unsaved mutation at %s, %d position(s), cash $%.2f, %d unpersisted trade(s))",
+			id, rec.At.Format(time.RFC3339), len(restored.Positions), restored.Cash, len(rec.UnpersistedTrades)))
+	}
+	if skipped > 0 {
+		lines = append(lines, fmt.Sprintf("skipped %d unreadable state WAL record(s) (torn write)", skipped))
+	}
+	return lines, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStateWALReplayRestoresUnsavedMutations(t *testing.T) {
+	prevRecorder, prevWAL := tradeRecorder, stateWAL
+	t.Cleanup(func() { tradeRecorder, stateWAL = prevRecorder, prevWAL })
+	sdb := openTestDB(t)
+	tradeRecorder = nil // eager insert "failed": the trade must be re-queued by replay
+	walPath := filepath.Join(t.TempDir(), "state.db.wal")
+	stateWAL = newStateWAL(walPath, nil)
+
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Capital: 1000}}}
+	state := NewAppState()
+	s := NewStrategyState(cfg.Strategies[0])
+	state.Strategies[s.ID] = s
+	if err := SaveStateWithDB(state, cfg, sdb); err != nil {
+		t.Fatal(err)
+	}
+
+	// In-cycle mutation that never reaches a completed save.
+	now := time.Now().UTC()
+	s.Cash = 990
+	s.Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.01, AvgCost: 60000, Side: "long", OpenedAt: now}
+	RecordTrade(s, Trade{Timestamp: now, Symbol: "BTC", Side: "buy", Quantity: 0.01, Price: 60000, TradeType: "perps"})
+	flushStateWAL()
+	f, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"at":"2026-01-01T00:00:00Z","strategy_id":"hl-b`) // torn final append
+	f.Close()
+
+	// Restart: the DB still holds the pre-trade state.
+	loaded, err := LoadStateWithDB(cfg, sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Strategies["hl-btc"]; got.Cash != 1000 || len(got.Positions) != 0 {
+		t.Fatalf("precondition: DB should lag the mutation, got cash=%g positions=%d", got.Cash, len(got.Positions))
+	}
+	lines, err := replayStateWAL(loaded, walPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || !strings.Contains(lines[0], "restored hl-btc") || !strings.Contains(lines[1], "torn write") {
+		t.Fatalf("replay lines = %q", lines)
+	}
+	got := loaded.Strategies["hl-btc"]
+	if got.Cash != 990 || got.Positions["BTC"] == nil || got.Positions["BTC"].Quantity != 0.01 {
+		t.Fatalf("replayed state: cash=%g positions=%+v", got.Cash, got.Positions)
+	}
+
+	// Saving the replayed state persists the re-queued trade and empties the WAL.
+	if err := SaveStateWithDB(loaded, cfg, sdb); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(walPath); err != nil || fi.Size() != 0 {
+		t.Fatalf("WAL not truncated after save: %v size=%v", err, fi)
+	}
+	trades, err := sdb.RecentTradesForStrategy("hl-btc", 10)
+	if err != nil || len(trades) != 1 {
+		t.Fatalf("re-queued trade not persisted: %d trades, err=%v", len(trades), err)
+	}
+	if lines, _ := replayStateWAL(loaded, walPath, nil); lines != nil {
+		t.Errorf("empty WAL must replay nothing, got %q", lines)
+	}
+}
+
+// A close books the trade first and removes the position afterwards; the
+// journaled image must be the post-close one, or replay would resurrect a
+// position the venue no longer holds.
+func TestStateWALReplayAfterPerpsClose(t *testing.T) {
+	prevRecorder, prevWAL := tradeRecorder, stateWAL
+	t.Cleanup(func() { tradeRecorder, stateWAL = prevRecorder, prevWAL })
+	sdb := openTestDB(t)
+	tradeRecorder = nil
+	walPath := filepath.Join(t.TempDir(), "state.db.wal")
+	stateWAL = newStateWAL(walPath, nil)
+
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Capital: 1000}}}
+	state := NewAppState()
+	s := NewStrategyState(cfg.Strategies[0])
+	s.Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.01, AvgCost: 60000, Side: "long", OpenedAt: time.Now().UTC(), Multiplier: 1}
+	state.Strategies[s.ID] = s
+	if err := SaveStateWithDB(state, cfg, sdb); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bookPerpsCloseWithFillFee(s, "BTC", 61000, 0, false, "", "signal", "close", "test", nil) {
+		t.Fatal("close not booked")
+	}
+	flushStateWAL()
+	wantCash := s.Cash
+
+	loaded, err := LoadStateWithDB(cfg, sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replayStateWAL(loaded, walPath, nil); err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Strategies["hl-btc"]
+	if len(got.Positions) != 0 {
+		t.Fatalf("replay resurrected closed position: %+v", got.Positions["BTC"])
+	}
+	if got.Cash != wantCash || len(got.ClosedPositions) != 1 {
+		t.Errorf("replayed cash=%g closed=%d, want cash=%g closed=1", got.Cash, len(got.ClosedPositions), wantCash)
+	}
+	if n := len(got.TradeHistory); n != 1 || !got.TradeHistory[n-1].IsClose {
+		t.Errorf("close trade not re-queued: %+v", got.TradeHistory)
+	}
+}
+
+func TestStateWALSealedWithStateKey(t *testing.T) {
+	key, _ := hex.DecodeString(testStateKeyHex)
+	walPath := filepath.Join(t.TempDir(), "state.db.wal")
+	w := newStateWAL(walPath, key)
+	s := NewStrategyState(StrategyConfig{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Capital: 1000})
+	s.Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.01, AvgCost: 60000, Side: "long"}
+	w.MarkDirty(s)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(walPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(raw, stateWALSealedPrefix) || bytes.Contains(raw, []byte("hl-btc")) {
+		t.Fatalf("WAL line not sealed: %q", raw)
+	}
+
+	if _, err := replayStateWAL(NewAppState(), walPath, nil); err == nil {
+		t.Error("replaying a sealed WAL without the key must fail, not skip it")
+	}
+	state := NewAppState()
+	lines, err := replayStateWAL(state, walPath, key)
+	if err != nil || len(lines) != 1 {
+		t.Fatalf("replay with key: lines=%q err=%v", lines, err)
+	}
+	if p := state.Strategies["hl-btc"].Positions["BTC"]; p == nil || p.Quantity != 0.01 {
+		t.Errorf("sealed replay lost the position: %+v", p)
+	}
+}
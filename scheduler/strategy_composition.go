@@ -18,6 +18,21 @@ type StrategyDecisionFields struct {
 	CloseFraction   float64        `json:"close_fraction"`
 	CloseStrategy   string         `json:"close_strategy,omitempty"`
 	Regime          *RegimePayload `json:"regime,omitempty"`
+	// SizeFraction / Confidence let a strategy express conviction: the
+	// fraction (0, 1] of a full-size open it wants. Only honored when the
+	// strategy config opts in via size_fraction (size_fraction.go);
+	// SizeFraction wins when both are emitted.
+	SizeFraction float64 `json:"size_fraction,omitempty"`
+	Confidence   float64 `json:"confidence,omitempty"`
+	// BarHigh / BarLow are the high and low of the latest candle the check
+	// script evaluated, used by the paper order simulator to decide whether a
+	// resting order was crossed (paper_orders.go). 0 = not emitted.
+	BarHigh float64 `json:"bar_high,omitempty"`
+	BarLow  float64 `json:"bar_low,omitempty"`
+	// BarTime is the ISO-8601 open time of that candle, used by the
+	// stale-data check and the stale-candle signal rejection (#4962,
+	// stale_data.go). "" = not emitted.
+	BarTime string `json:"bar_time,omitempty"`
 }
 
 // PositionCtx is the optional state snapshot threaded into close evaluators
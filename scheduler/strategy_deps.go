@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// strategyDependencyErrors validates strategies[].depends_on (#4923): every
+// referenced ID must exist, a strategy may not depend on itself, and the
+// dependency graph must be acyclic.
+func strategyDependencyErrors(strategies []StrategyConfig) []string {
+	known := make(map[string]bool, len(strategies))
+	for _, sc := range strategies {
+		known[sc.ID] = true
+	}
+	var errs []string
+	for _, sc := range strategies {
+		seen := make(map[string]bool, len(sc.DependsOn))
+		for _, dep := range sc.DependsOn {
+			switch {
+			case dep == sc.ID:
+				errs = append(errs, fmt.Sprintf("strategy[%s]: depends_on cannot reference itself", sc.ID))
+			case !known[dep]:
+				errs = append(errs, fmt.Sprintf("strategy[%s]: depends_on references unknown strategy %q", sc.ID, dep))
+			case seen[dep]:
+				errs = append(errs, fmt.Sprintf("strategy[%s]: depends_on lists %q more than once", sc.ID, dep))
+			}
+			seen[dep] = true
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if cycle := strategyDependencyCycle(strategies); len(cycle) > 0 {
+		errs = append(errs, fmt.Sprintf("strategies: depends_on cycle %s", strings.Join(cycle, " -> ")))
+	}
+	return errs
+}
+
+// strategyDependencyCycle returns one dependency cycle (first node repeated
+// at the end), or nil when the graph is acyclic.
+func strategyDependencyCycle(strategies []StrategyConfig) []string {
+	deps := make(map[string][]string, len(strategies))
+	ids := make([]string, 0, len(strategies))
+	for _, sc := range strategies {
+		deps[sc.ID] = sc.DependsOn
+		ids = append(ids, sc.ID)
+	}
+	sort.Strings(ids)
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	mark := make(map[string]int, len(ids))
+	var stack []string
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		mark[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range deps[id] {
+			switch mark[dep] {
+			case visiting:
+				for i, s := range stack {
+					if s == dep {
+						return append(append([]string(nil), stack[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		mark[id] = done
+		return nil
+	}
+	for _, id := range ids {
+		if mark[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// orderByDependencies returns the due strategies reordered so each runs after
+// every due strategy it depends_on; otherwise config order is kept.
+// Dependencies that are not due this cycle impose no constraint. Input is
+// validated acyclic at load; should a cycle slip through, the remaining
+// strategies are appended in config order rather than dropped.
+func orderByDependencies(due []StrategyConfig) []StrategyConfig {
+	hasDeps := false
+	for _, sc := range due {
+		if len(sc.DependsOn) > 0 {
+			hasDeps = true
+			break
+		}
+	}
+	if !hasDeps {
+		return due
+	}
+	inDue := make(map[string]bool, len(due))
+	for _, sc := range due {
+		inDue[sc.ID] = true
+	}
+	placed := make(map[string]bool, len(due))
+	out := make([]StrategyConfig, 0, len(due))
+	for len(out) < len(due) {
+		progressed := false
+		for _, sc := range due {
+			if placed[sc.ID] {
+				continue
+			}
+			ready := true
+			for _, dep := range sc.DependsOn {
+				if inDue[dep] && !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				out = append(out, sc)
+				placed[sc.ID] = true
+				progressed = true
+				break // restart the scan so earlier config entries keep priority
+			}
+		}
+		if !progressed {
+			for _, sc := range due {
+				if !placed[sc.ID] {
+					out = append(out, sc)
+					placed[sc.ID] = true
+				}
+			}
+		}
+	}
+	return out
+}
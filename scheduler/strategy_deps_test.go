@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func strategyIDs(scs []StrategyConfig) string {
+	ids := make([]string, len(scs))
+	for i, sc := range scs {
+		ids[i] = sc.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestOrderByDependenciesRunsHedgerAfterDirectional(t *testing.T) {
+	due := []StrategyConfig{
+		{ID: "hedge", DependsOn: []string{"dir-btc", "dir-eth"}},
+		{ID: "dir-btc"},
+		{ID: "other"},
+		{ID: "dir-eth"},
+	}
+	if got := strategyIDs(orderByDependencies(due)); got != "dir-btc,other,dir-eth,hedge" {
+		t.Fatalf("order = %s", got)
+	}
+}
+
+func TestOrderByDependenciesIgnoresDependencyNotDue(t *testing.T) {
+	due := []StrategyConfig{
+		{ID: "hedge", DependsOn: []string{"dir-btc"}},
+		{ID: "other"},
+	}
+	if got := strategyIDs(orderByDependencies(due)); got != "hedge,other" {
+		t.Fatalf("order = %s, want config order when the dependency is not due", got)
+	}
+}
+
+func TestOrderByDependenciesChain(t *testing.T) {
+	due := []StrategyConfig{
+		{ID: "c", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+		{ID: "a"},
+	}
+	if got := strategyIDs(orderByDependencies(due)); got != "a,b,c" {
+		t.Fatalf("order = %s", got)
+	}
+}
+
+func TestStrategyDependencyErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		strategies []StrategyConfig
+		want       string
+	}{
+		{"valid", []StrategyConfig{{ID: "a"}, {ID: "b", DependsOn: []string{"a"}}}, ""},
+		{"unknown", []StrategyConfig{{ID: "a", DependsOn: []string{"zz"}}}, `unknown strategy "zz"`},
+		{"self", []StrategyConfig{{ID: "a", DependsOn: []string{"a"}}}, "cannot reference itself"},
+		{"duplicate", []StrategyConfig{{ID: "a"}, {ID: "b", DependsOn: []string{"a", "a"}}}, "more than once"},
+		{"cycle", []StrategyConfig{
+			{ID: "a", DependsOn: []string{"c"}},
+			{ID: "b", DependsOn: []string{"a"}},
+			{ID: "c", DependsOn: []string{"b"}},
+		}, "depends_on cycle a -> c -> b -> a"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := strategyDependencyErrors(tc.strategies)
+			if tc.want == "" {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 || !strings.Contains(strings.Join(errs, "\n"), tc.want) {
+				t.Fatalf("errors = %v, want %q", errs, tc.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,5 @@
 package main
 
-import "time"
-
 const (
 	strategyDrawdownFastIntervalSeconds = 90
 	defaultDrawdownWarnThresholdPct     = 80
@@ -58,10 +56,8 @@ func effectiveStrategyIntervalSeconds(sc StrategyConfig, s *StrategyState, globa
 }
 
 // effectiveStrategyIntervals computes the effective per-strategy check
-// interval for every strategy in one pass. Callers that need the same
-// intervals for both due-detection and the scheduler delay calculation should
-// compute this map once per cycle (under mu.RLock) and pass it to both
-// strategyIsDue and nextStrategyCheckDelay, instead of recomputing per call.
+// interval for every strategy in one pass. The main loop computes it under
+// mu.RLock and hands it to strategyScheduler.Sync (#4921).
 func effectiveStrategyIntervals(strategies []StrategyConfig, states map[string]*StrategyState, globalIntervalSeconds int, warnThresholdPct float64) map[string]int {
 	out := make(map[string]int, len(strategies))
 	for _, sc := range strategies {
@@ -69,60 +65,3 @@ func effectiveStrategyIntervals(strategies []StrategyConfig, states map[string]*
 	}
 	return out
 }
-
-// nextStrategyCheckDelay returns the time until the next strategy is due, or
-// 0 if any strategy is due now (including a first-run strategy with no
-// lastRun entry), or -1 if no strategy is a delay candidate at all (all
-// skipped by zero-capital or non-positive interval). The -1 sentinel lets
-// schedulerDelay distinguish "fire ASAP" from "nothing scheduled — fall back."
-//
-// `intervals` must be the precomputed map from effectiveStrategyIntervals.
-func nextStrategyCheckDelay(strategies []StrategyConfig, intervals map[string]int, lastRun map[string]time.Time, now time.Time) time.Duration {
-	var minDelay time.Duration
-	hasCandidate := false
-	for _, sc := range strategies {
-		if shouldSkipZeroCapital(sc) {
-			continue
-		}
-		interval := intervals[sc.ID]
-		if interval <= 0 {
-			continue
-		}
-		last, ok := lastRun[sc.ID]
-		if !ok {
-			return 0
-		}
-		delay := last.Add(time.Duration(interval) * time.Second).Sub(now)
-		if delay <= 0 {
-			return 0
-		}
-		if !hasCandidate || delay < minDelay {
-			minDelay = delay
-			hasCandidate = true
-		}
-	}
-	if !hasCandidate {
-		return -1
-	}
-	return minDelay
-}
-
-// schedulerDelay turns the next-due time into a sleep duration: a positive
-// next-due wins; "due now" sleeps 1s to yield; "no candidates" falls back to
-// fallbackSeconds (then globalIntervalSeconds, then 60s).
-func schedulerDelay(strategies []StrategyConfig, intervals map[string]int, lastRun map[string]time.Time, globalIntervalSeconds int, now time.Time, fallbackSeconds int) time.Duration {
-	delay := nextStrategyCheckDelay(strategies, intervals, lastRun, now)
-	if delay > 0 {
-		return delay
-	}
-	if delay == 0 {
-		return time.Second
-	}
-	if fallbackSeconds <= 0 {
-		fallbackSeconds = globalIntervalSeconds
-	}
-	if fallbackSeconds <= 0 {
-		fallbackSeconds = 60
-	}
-	return time.Duration(fallbackSeconds) * time.Second
-}
@@ -1,9 +1,6 @@
 package main
 
-import (
-	"testing"
-	"time"
-)
+import "testing"
 
 func TestEffectiveStrategyIntervalSeconds_DrawdownWarningUsesFastInterval(t *testing.T) {
 	sc := StrategyConfig{ID: "s1", IntervalSeconds: 3600}
@@ -108,87 +105,3 @@ func TestEffectiveStrategyIntervalSeconds_OverMaxDrawdownStillFast(t *testing.T)
 		t.Errorf("effective interval = %d, want fast %d when drawdown exceeds max but CB not yet set", got, strategyDrawdownFastIntervalSeconds)
 	}
 }
-
-func TestNextStrategyCheckDelay_UsesWarningInterval(t *testing.T) {
-	now := time.Date(2026, 4, 23, 12, 0, 0, 0, time.UTC)
-	strategies := []StrategyConfig{
-		{ID: "warning", IntervalSeconds: 3600, Capital: 1000},
-		{ID: "normal", IntervalSeconds: 3600, Capital: 1000},
-	}
-	states := map[string]*StrategyState{
-		"warning": {
-			RiskState: RiskState{MaxDrawdownPct: 10, CurrentDrawdownPct: 8.5},
-		},
-		"normal": {
-			RiskState: RiskState{MaxDrawdownPct: 10, CurrentDrawdownPct: 1.0},
-		},
-	}
-	lastRun := map[string]time.Time{
-		"warning": now.Add(-30 * time.Second),
-		"normal":  now.Add(-30 * time.Second),
-	}
-
-	intervals := effectiveStrategyIntervals(strategies, states, 600, 80)
-	got := nextStrategyCheckDelay(strategies, intervals, lastRun, now)
-	if got != time.Minute {
-		t.Errorf("next delay = %s, want 1m", got)
-	}
-}
-
-// First-run path: a strategy with no lastRun entry must short-circuit to 0
-// so schedulerDelay yields immediately (1s) and the dueStrategies loop picks
-// it up next cycle.
-func TestNextStrategyCheckDelay_FirstRunReturnsZero(t *testing.T) {
-	now := time.Date(2026, 4, 23, 12, 0, 0, 0, time.UTC)
-	strategies := []StrategyConfig{
-		{ID: "fresh", IntervalSeconds: 3600, Capital: 1000},
-	}
-	intervals := effectiveStrategyIntervals(strategies, nil, 600, 80)
-
-	got := nextStrategyCheckDelay(strategies, intervals, map[string]time.Time{}, now)
-	if got != 0 {
-		t.Errorf("first-run delay = %s, want 0", got)
-	}
-
-	// schedulerDelay should turn that into a 1s yield.
-	sd := schedulerDelay(strategies, intervals, map[string]time.Time{}, 600, now, 60)
-	if sd != time.Second {
-		t.Errorf("schedulerDelay first-run = %s, want 1s", sd)
-	}
-}
-
-// No-candidate path: every strategy is skipped (zero capital with capital_pct
-// set, or non-positive interval). nextStrategyCheckDelay must return -1 so
-// schedulerDelay falls back to the configured fallback interval rather than
-// busy-looping.
-func TestNextStrategyCheckDelay_NoCandidatesReturnsNegative(t *testing.T) {
-	now := time.Date(2026, 4, 23, 12, 0, 0, 0, time.UTC)
-	strategies := []StrategyConfig{
-		// shouldSkipZeroCapital: capital_pct set (0-1) + capital == 0
-		{ID: "skipped-zero-cap", IntervalSeconds: 3600, CapitalPct: 0.5, Capital: 0},
-	}
-	intervals := effectiveStrategyIntervals(strategies, nil, 600, 80)
-
-	got := nextStrategyCheckDelay(strategies, intervals, map[string]time.Time{}, now)
-	if got != -1 {
-		t.Errorf("no-candidates delay = %s, want -1", got)
-	}
-
-	// schedulerDelay should fall back to the configured fallbackSeconds.
-	sd := schedulerDelay(strategies, intervals, map[string]time.Time{}, 600, now, 120)
-	if sd != 120*time.Second {
-		t.Errorf("schedulerDelay no-candidates = %s, want 120s fallback", sd)
-	}
-
-	// fallbackSeconds <= 0 → use globalIntervalSeconds.
-	sd = schedulerDelay(strategies, intervals, map[string]time.Time{}, 600, now, 0)
-	if sd != 600*time.Second {
-		t.Errorf("schedulerDelay fallback->global = %s, want 600s", sd)
-	}
-
-	// Both <= 0 → 60s ultimate fallback.
-	sd = schedulerDelay(strategies, intervals, map[string]time.Time{}, 0, now, 0)
-	if sd != 60*time.Second {
-		t.Errorf("schedulerDelay ultimate fallback = %s, want 60s", sd)
-	}
-}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Per-strategy exposure caps (max_notional_usd / max_positions /
+// max_daily_trades).
+//
+// Evaluated once per strategy per cycle, in the Phase 1 read of the strategy
+// state, and enforced at the dispatch sites next to the portfolio notional
+// cap with the same pausedBlocksSignal hold semantics: position-increasing
+// signals are held, closes/reductions and the Signal==0 manage path keep
+// running, nothing is force-closed.
+//
+//   - max_notional_usd: once the strategy's gross notional (PortfolioNotional
+//     over its own positions) reaches the cap, every position-increasing
+//     signal is held — fresh opens, adds, and flips.
+//   - max_positions: once the strategy holds that many open positions (spot/
+//     perps/futures positions plus option legs), only FRESH opens are held;
+//     an add to an existing position doesn't change the count. Options drop
+//     open actions (each opens a new leg).
+//   - max_daily_trades: once the strategy has recorded that many trades
+//     today (RiskState.DailyTrades, reset at the daily-PnL rollover, #4986),
+//     every position-increasing signal is held until the rollover — a cheap
+//     brake on a misbehaving script spamming signals.
+
+// strategyLimitStatus is one strategy's cap evaluation for the cycle.
+type strategyLimitStatus struct {
+	NotionalReason  string // non-empty when max_notional_usd is reached
+	PositionsReason string // non-empty when max_positions is reached
+	TradesReason    string // non-empty when max_daily_trades is reached
+}
+
+// evaluateStrategyLimits checks sc's per-strategy caps against its current
+// positions. Pure read; call under mu.RLock.
+func evaluateStrategyLimits(sc *StrategyConfig, s *StrategyState, prices map[string]float64) strategyLimitStatus {
+	var st strategyLimitStatus
+	if sc == nil || s == nil {
+		return st
+	}
+	if sc.MaxNotionalUSD > 0 {
+		notional := PortfolioNotional(map[string]*StrategyState{s.ID: s}, prices)
+		if notional >= sc.MaxNotionalUSD {
+			st.NotionalReason = fmt.Sprintf("strategy notional $%.2f at max_notional_usd $%.2f", notional, sc.MaxNotionalUSD)
+		}
+	}
+	if sc.MaxPositions > 0 {
+		n := len(s.Positions) + len(s.OptionPositions)
+		if n >= sc.MaxPositions {
+			st.PositionsReason = fmt.Sprintf("%d open position(s) at max_positions %d", n, sc.MaxPositions)
+		}
+	}
+	if sc.MaxDailyTrades > 0 {
+		// Pure read: a count from a previous risk day is already stale.
+		n := 0
+		if s.RiskState.DailyPnLDate == riskDay(time.Now()) {
+			n = s.RiskState.DailyTrades
+		}
+		if n >= sc.MaxDailyTrades {
+			st.TradesReason = fmt.Sprintf("%d trade(s) today at max_daily_trades %d", n, sc.MaxDailyTrades)
+		}
+	}
+	return st
+}
+
+// holdReason returns why a position-increasing signal must be held given the
+// strategy's current quantity in the traded symbol (posQty <= 0 means the
+// signal would open a new position), or "" when it may proceed.
+func (st strategyLimitStatus) holdReason(posQty float64) string {
+	if st.NotionalReason != "" {
+		return st.NotionalReason
+	}
+	if st.TradesReason != "" {
+		return st.TradesReason
+	}
+	if st.PositionsReason != "" && posQty <= 0 {
+		return st.PositionsReason
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateStrategyLimits(t *testing.T) {
+	sc := StrategyConfig{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000}
+	s := NewStrategyState(sc)
+	s.Positions["BTC/USDT"] = &Position{Symbol: "BTC/USDT", Quantity: 0.01, AvgCost: 60000, Side: "long"}
+	prices := map[string]float64{"BTC/USDT": 60000}
+
+	if st := evaluateStrategyLimits(&sc, s, prices); st.holdReason(0) != "" {
+		t.Fatalf("no caps configured must never hold: %+v", st)
+	}
+
+	sc.MaxNotionalUSD = 500
+	st := evaluateStrategyLimits(&sc, s, prices)
+	if why := st.holdReason(0.01); !strings.Contains(why, "max_notional_usd $500.00") {
+		t.Errorf("notional cap must hold adds too: %q", why)
+	}
+
+	sc.MaxNotionalUSD = 1000
+	sc.MaxPositions = 1
+	st = evaluateStrategyLimits(&sc, s, prices)
+	if st.NotionalReason != "" {
+		t.Errorf("notional $600 under $1000 cap held: %+v", st)
+	}
+	if why := st.holdReason(0); !strings.Contains(why, "at max_positions 1") {
+		t.Errorf("fresh open at max_positions not held: %q", why)
+	}
+	if why := st.holdReason(0.01); why != "" {
+		t.Errorf("add to an existing position must pass the count cap: %q", why)
+	}
+
+	sc.MaxPositions = 0
+	sc.MaxDailyTrades = 2
+	RecordTrade(s, Trade{Symbol: "BTC/USDT", Side: "buy", Quantity: 0.005, Price: 60000})
+	if st := evaluateStrategyLimits(&sc, s, prices); st.TradesReason != "" {
+		t.Errorf("1 trade under max_daily_trades 2 held: %+v", st)
+	}
+	RecordTrade(s, Trade{Symbol: "BTC/USDT", Side: "buy", Quantity: 0.005, Price: 60000})
+	if why := evaluateStrategyLimits(&sc, s, prices).holdReason(0.01); !strings.Contains(why, "2 trade(s) today at max_daily_trades 2") {
+		t.Errorf("daily trade cap not held: %q", why)
+	}
+	s.RiskState.DailyPnLDate = "2000-01-01"
+	if st := evaluateStrategyLimits(&sc, s, prices); st.TradesReason != "" {
+		t.Errorf("previous day's count must not hold: %+v", st)
+	}
+	rolloverDailyPnL(&s.RiskState)
+	if s.RiskState.DailyTrades != 0 {
+		t.Errorf("rollover left DailyTrades = %d", s.RiskState.DailyTrades)
+	}
+}
+
+func TestValidateConfig_StrategyCaps(t *testing.T) {
+	cfg := &Config{
+		IntervalSeconds: 60,
+		Strategies: []StrategyConfig{{
+			ID: "sma-btc", Type: "spot", Platform: "binanceus", Script: "shared_scripts/check_strategy.py",
+			Args: []string{"sma_crossover", "BTC/USDT", "1h"}, Capital: 1000, MaxDrawdownPct: 10,
+			MaxNotionalUSD: -5, MaxPositions: -1, MaxDailyTrades: -1,
+		}},
+	}
+	err := validateConfig(cfg, false)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"max_notional_usd must be >= 0", "max_positions must be >= 0", "max_daily_trades must be >= 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
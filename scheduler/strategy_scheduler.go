@@ -0,0 +1,233 @@
+package main
+
+// strategy_scheduler: per-strategy cadence (#4921).
+//
+// Each enabled strategy owns a lightweight goroutine driven by its own timer,
+// anchored to the strategy's first run: a 90s strategy is due at t0+90s,
+// t0+180s, … and a 7m strategy at t0+420s, … independently of each other, of
+// the top-level interval_seconds and of how long the previous cycle took.
+// There is no global tick, GCD or 60s floor any more.
+//
+// The goroutines only mark a strategy due and wake the main loop. Execution
+// stays on the main loop, which takes the due set, fetches prices once (the
+// shared price service) and runs the due strategies through the portfolio
+// kill switch and per-strategy risk gate exactly as before, so risk checks
+// and the save phase still see one consistent AppState.
+//
+// A strategy stays due until the cycle that started after it became due
+// reports it ran (MarkRan); one skipped by the kill switch is retried on the
+// next loop iteration. Ticks that land while a strategy is already due
+// coalesce — a slow cycle never builds a backlog of runs.
+
+import (
+	"sync"
+	"time"
+)
+
+// strategySchedulerRetryDelay paces the loop while strategies the last
+// cycle did not run are still due (kill switch, drain).
+const strategySchedulerRetryDelay = time.Second
+
+type strategyScheduler struct {
+	mu      sync.Mutex
+	entries map[string]*strategyTicker
+	wake    chan struct{} // cap 1; signalled when any strategy becomes due
+}
+
+type strategyTicker struct {
+	interval time.Duration
+	stop     chan struct{}
+	dueAt    time.Time // zero = not due
+	lastRan  time.Time
+}
+
+func newStrategyScheduler() *strategyScheduler {
+	return &strategyScheduler{
+		entries: make(map[string]*strategyTicker),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Sync reconciles the tickers with the current strategy list and effective
+// intervals (seconds, from effectiveStrategyIntervals). New strategies are
+// due immediately; a changed interval (hot reload, drawdown fast cadence)
+// re-anchors at lastRan+interval so the new cadence applies at once; removed
+// strategies are stopped.
+func (s *strategyScheduler) Sync(strategies []StrategyConfig, intervals map[string]int, now time.Time) {
+	want := make(map[string]time.Duration, len(strategies))
+	for _, sc := range strategies {
+		if iv := intervals[sc.ID]; iv > 0 {
+			want[sc.ID] = time.Duration(iv) * time.Second
+		}
+	}
+	s.syncDurations(want, now)
+}
+
+func (s *strategyScheduler) syncDurations(want map[string]time.Duration, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.entries {
+		if _, ok := want[id]; !ok {
+			close(t.stop)
+			delete(s.entries, id)
+		}
+	}
+	for id, interval := range want {
+		old := s.entries[id]
+		if old != nil && old.interval == interval {
+			continue
+		}
+		t := &strategyTicker{interval: interval, stop: make(chan struct{})}
+		first := interval
+		if old == nil {
+			t.dueAt = now
+			s.signal()
+		} else {
+			close(old.stop)
+			t.dueAt = old.dueAt
+			t.lastRan = old.lastRan
+			if !old.lastRan.IsZero() {
+				first = old.lastRan.Add(interval).Sub(now)
+			}
+			if first <= 0 && t.dueAt.IsZero() {
+				t.dueAt = now
+				s.signal()
+			}
+			if first <= 0 {
+				first = interval
+			}
+		}
+		s.entries[id] = t
+		go s.run(id, t, now.Add(first))
+	}
+}
+
+// run marks the strategy due at each anchored tick until stopped.
+func (s *strategyScheduler) run(id string, t *strategyTicker, next time.Time) {
+	timer := time.NewTimer(time.Until(next))
+	defer timer.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case fired := <-timer.C:
+			s.markDue(id, t, fired)
+			for !next.After(fired) {
+				next = next.Add(t.interval)
+			}
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+func (s *strategyScheduler) markDue(id string, t *strategyTicker, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries[id] != t {
+		return // replaced or removed while the timer fired
+	}
+	if t.dueAt.IsZero() {
+		t.dueAt = at
+	}
+	s.signal()
+}
+
+// signal wakes the main loop without blocking. Caller holds s.mu.
+func (s *strategyScheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// DueIDs returns the strategies currently due.
+func (s *strategyScheduler) DueIDs() map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]bool)
+	for id, t := range s.entries {
+		if !t.dueAt.IsZero() {
+			out[id] = true
+		}
+	}
+	return out
+}
+
+// RunNow marks the strategy due at now regardless of its interval (#4941,
+// Discord /run) and wakes the loop. The ticker keeps its anchor, so the
+// regular cadence is unchanged. Returns false when the strategy is not
+// scheduled (unknown ID or non-positive interval). dueAt moves to now even
+// when already due, so a cycle in flight that picked the strategy up earlier
+// does not consume the request — MarkRan keeps it pending for the next one.
+func (s *strategyScheduler) RunNow(id string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.entries[id]
+	if t == nil {
+		return false
+	}
+	t.dueAt = now
+	s.signal()
+	return true
+}
+
+// HasDue reports whether any strategy is still waiting to run.
+func (s *strategyScheduler) HasDue() bool {
+	return len(s.DueIDs()) > 0
+}
+
+// MarkRan records that the cycle started at cycleStart ran (or deliberately
+// skipped) the strategy. A tick that fired after cycleStart stays pending so
+// a run longer than the interval is followed by another run, not dropped.
+func (s *strategyScheduler) MarkRan(id string, cycleStart time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.entries[id]
+	if t == nil {
+		return
+	}
+	t.lastRan = time.Now()
+	if !t.dueAt.IsZero() && !t.dueAt.After(cycleStart) {
+		t.dueAt = time.Time{}
+	}
+}
+
+// Wake is signalled whenever a strategy becomes due.
+func (s *strategyScheduler) Wake() <-chan struct{} {
+	return s.wake
+}
+
+// waitChannel returns a channel that fires when the loop should run again:
+// after strategySchedulerRetryDelay while strategies are still due, otherwise
+// on the next Wake. The returned stop func releases the retry timer.
+func (s *strategyScheduler) waitChannel() (<-chan struct{}, func()) {
+	if !s.HasDue() {
+		return s.wake, func() {}
+	}
+	ch := make(chan struct{})
+	timer := time.AfterFunc(strategySchedulerRetryDelay, func() { close(ch) })
+	return ch, func() { timer.Stop() }
+}
+
+// shortestStrategyInterval is the tightest effective interval (seconds)
+// among strategies, the budget a cycle must finish within for none of them
+// to miss a beat. 0 when none has a positive interval.
+func shortestStrategyInterval(strategies []StrategyConfig, intervals map[string]int) int {
+	shortest := 0
+	for _, sc := range strategies {
+		if iv := intervals[sc.ID]; iv > 0 && (shortest == 0 || iv < shortest) {
+			shortest = iv
+		}
+	}
+	return shortest
+}
+
+// Stop halts every ticker goroutine.
+func (s *strategyScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.entries {
+		close(t.stop)
+		delete(s.entries, id)
+	}
+}
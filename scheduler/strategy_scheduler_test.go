@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func waitForDue(t *testing.T, s *strategyScheduler, id string, within time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(within)
+	for time.Now().Before(deadline) {
+		if s.DueIDs()[id] {
+			return
+		}
+		select {
+		case <-s.Wake():
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	t.Fatalf("%s not due within %s", id, within)
+}
+
+func TestStrategySchedulerNewStrategyDueImmediately(t *testing.T) {
+	s := newStrategyScheduler()
+	defer s.Stop()
+	now := time.Now()
+	s.Sync([]StrategyConfig{{ID: "a"}, {ID: "b"}, {ID: "off"}}, map[string]int{"a": 90, "b": 420}, now)
+	due := s.DueIDs()
+	if !due["a"] || !due["b"] || due["off"] {
+		t.Fatalf("due = %v, want a and b only (non-positive interval never scheduled)", due)
+	}
+	s.MarkRan("a", now)
+	s.MarkRan("b", now)
+	if s.HasDue() {
+		t.Fatalf("still due after MarkRan: %v", s.DueIDs())
+	}
+}
+
+func TestStrategySchedulerIndependentCadences(t *testing.T) {
+	s := newStrategyScheduler()
+	defer s.Stop()
+	start := time.Now()
+	s.syncDurations(map[string]time.Duration{"fast": 40 * time.Millisecond, "slow": time.Hour}, start)
+	s.MarkRan("fast", start)
+	s.MarkRan("slow", start)
+
+	waitForDue(t, s, "fast", 2*time.Second)
+	if s.DueIDs()["slow"] {
+		t.Fatal("slow strategy became due on the fast strategy's tick")
+	}
+}
+
+func TestStrategySchedulerTickDuringRunStaysDue(t *testing.T) {
+	s := newStrategyScheduler()
+	defer s.Stop()
+	start := time.Now()
+	s.syncDurations(map[string]time.Duration{"a": 30 * time.Millisecond}, start)
+	s.MarkRan("a", start)
+
+	// The run is slower than the interval: a tick lands after cycleStart.
+	cycleStart := time.Now()
+	waitForDue(t, s, "a", 2*time.Second)
+	s.MarkRan("a", cycleStart)
+	if !s.DueIDs()["a"] {
+		t.Fatal("tick that fired mid-run was dropped by MarkRan")
+	}
+}
+
+func TestStrategySchedulerIntervalChangeReanchors(t *testing.T) {
+	s := newStrategyScheduler()
+	defer s.Stop()
+	now := time.Now()
+	s.syncDurations(map[string]time.Duration{"a": time.Hour}, now)
+	s.MarkRan("a", now)
+
+	// Drawdown fast cadence kicks in long after the last run: due at once.
+	s.mu.Lock()
+	s.entries["a"].lastRan = now.Add(-2 * time.Minute)
+	s.mu.Unlock()
+	s.syncDurations(map[string]time.Duration{"a": 90 * time.Second}, now)
+	if !s.DueIDs()["a"] {
+		t.Fatal("interval shortened below time since last run should be due immediately")
+	}
+
+	s.syncDurations(map[string]time.Duration{}, now)
+	if len(s.DueIDs()) != 0 {
+		t.Fatal("removed strategy still scheduled")
+	}
+}
+
+func TestShortestStrategyInterval(t *testing.T) {
+	due := []StrategyConfig{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	if got := shortestStrategyInterval(due, map[string]int{"a": 420, "b": 90, "c": 0}); got != 90 {
+		t.Fatalf("shortest = %d, want 90", got)
+	}
+	if got := shortestStrategyInterval(nil, nil); got != 0 {
+		t.Fatalf("shortest(empty) = %d, want 0", got)
+	}
+}
+
+func TestStrategySchedulerRunNow(t *testing.T) {
+	s := newStrategyScheduler()
+	defer s.Stop()
+	start := time.Now()
+	s.syncDurations(map[string]time.Duration{"a": time.Hour}, start)
+	s.MarkRan("a", start)
+	select {
+	case <-s.Wake(): // drain the new-strategy signal
+	default:
+	}
+	if s.HasDue() {
+		t.Fatal("due before RunNow")
+	}
+	if s.RunNow("missing", start) {
+		t.Fatal("RunNow on an unscheduled strategy should report false")
+	}
+	if !s.RunNow("a", start.Add(time.Millisecond)) {
+		t.Fatal("RunNow(a) = false")
+	}
+	select {
+	case <-s.Wake():
+	default:
+		t.Fatal("RunNow did not wake the loop")
+	}
+	// A cycle that started before the request does not consume it.
+	s.MarkRan("a", start)
+	if !s.DueIDs()["a"] {
+		t.Fatal("run-now request dropped by a cycle that started before it")
+	}
+	s.MarkRan("a", start.Add(time.Second))
+	if s.HasDue() {
+		t.Fatal("still due after the run-now cycle")
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// `go-trader stress` — read-only scenario revaluation of the current book.
+//
+// Every open position is revalued under a uniform spot shock (and, for
+// options, an implied-vol bump): spot/futures/perps through the same
+// PortfolioValue / perpsMarginDrawdownInputs math the risk checks use, option
+// legs through Black-Scholes (bsPrice) as a model delta applied on top of the
+// persisted mark. The report lists the projected loss per strategy and which
+// protections would trip at those marks — strategy circuit breakers, perps
+// margin wipe-outs, platforms.<name>.risk limits and the portfolio kill switch
+// (evaluated with CheckPortfolioRisk on a copy of the persisted state).
+// Nothing is written: the config is loaded as a snapshot and the state DB is
+// opened mode=ro. Shared wallets are summed per strategy (no exchange
+// balances are fetched), like the summary fallback.
+
+// stressBaseVol is the implied vol assumed for option revaluation — the same
+// default the IBKR Black-Scholes pricer uses for crypto options.
+const stressBaseVol = 0.80
+
+// stressScenario is one uniform shock, in percent (-20 = spot down 20%).
+type stressScenario struct {
+	SpotShockPct float64
+	VolShockPct  float64 // relative bump of stressBaseVol (+50 = 80% → 120%)
+}
+
+func (sc stressScenario) label() string {
+	l := fmt.Sprintf("spot %+.1f%%", sc.SpotShockPct)
+	if sc.VolShockPct != 0 {
+		l += fmt.Sprintf(", vol %+.1f%%", sc.VolShockPct)
+	}
+	return l
+}
+
+// stressStrategyResult is one strategy's revaluation under a scenario.
+type stressStrategyResult struct {
+	ID             string
+	Platform       string
+	BaseValue      float64
+	StressedValue  float64
+	DrawdownPct    float64 // CheckRisk-equivalent drawdown at stressed marks
+	LimitPct       float64 // the strategy's max_drawdown_pct
+	CircuitBreaker bool    // drawdown would exceed max_drawdown_pct with the breaker enabled
+	MarginWipeout  bool    // perps unrealized loss >= deployed margin
+}
+
+// stressResult is the whole-book outcome of one scenario.
+type stressResult struct {
+	Scenario         stressScenario
+	BaseValue        float64
+	StressedValue    float64
+	EquityDDPct      float64
+	MarginDDPct      float64
+	KillSwitch       bool
+	KillSwitchWarn   bool
+	KillSwitchReason string
+	Strategies       []stressStrategyResult
+	Platforms        []string // platforms.<name>.risk breaches
+	Notes            []string
+}
+
+// parseStressShocks parses a comma-separated list of percentages such as
+// "-20%,-10,+5%".
+func parseStressShocks(s string) ([]float64, error) {
+	var out []float64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(part), "%"))
+		if part == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shock %q: %w", part, err)
+		}
+		if v <= -100 {
+			return nil, fmt.Errorf("shock %g%% would take prices to zero or below", v)
+		}
+		out = append(out, v)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no shock given")
+	}
+	return out, nil
+}
+
+// stressPrices returns the shocked mark for every priced symbol and every
+// open position (positions without a live price shock their AvgCost, the
+// same fallback PortfolioValue applies).
+func stressPrices(state *AppState, prices map[string]float64, shockPct float64) map[string]float64 {
+	f := 1 + shockPct/100
+	out := make(map[string]float64, len(prices))
+	for sym, p := range prices {
+		out[sym] = p * f
+	}
+	for _, s := range state.Strategies {
+		for sym, pos := range s.Positions {
+			if _, ok := prices[sym]; !ok && pos.AvgCost > 0 {
+				out[sym] = pos.AvgCost * f
+			}
+		}
+	}
+	return out
+}
+
+// stressOptionDelta is the model change in USD value of one option leg when
+// spot moves from spot to spot*(1+shock) and vol from stressBaseVol to the
+// bumped vol. Expired legs use intrinsic value.
+func stressOptionDelta(opt *OptionPosition, spot float64, sc stressScenario, now time.Time) float64 {
+	shocked := spot * (1 + sc.SpotShockPct/100)
+	vol := stressBaseVol * (1 + sc.VolShockPct/100)
+	T := 0.0
+	if exp, err := time.Parse("2006-01-02", opt.Expiry); err == nil {
+		T = exp.UTC().Sub(now.UTC()).Hours() / 24 / 365
+	}
+	typ := strings.ToLower(opt.OptionType)
+	price := func(s, sigma float64) float64 {
+		if T <= 0 || sigma <= 0 {
+			if typ == "call" {
+				return math.Max(0, s-opt.Strike)
+			}
+			return math.Max(0, opt.Strike-s)
+		}
+		p, _, _, _, _ := bsPrice(s, opt.Strike, T, 0.05, sigma, typ)
+		return p
+	}
+	sign := 1.0
+	if opt.Action == "sell" {
+		sign = -1
+	}
+	return sign * opt.Quantity * (price(shocked, vol) - price(spot, stressBaseVol))
+}
+
+// runStressScenario revalues the book under sc. Pure read of cfg/state.
+func runStressScenario(cfg *Config, state *AppState, prices map[string]float64, sc stressScenario, now time.Time) stressResult {
+	res := stressResult{Scenario: sc}
+	shocked := stressPrices(state, prices, sc.SpotShockPct)
+	stressedByID := make(map[string]float64)
+
+	for _, scfg := range cfg.Strategies {
+		s := state.Strategies[scfg.ID]
+		if s == nil {
+			continue
+		}
+		base := PortfolioValue(s, prices)
+		stressed := PortfolioValue(s, shocked)
+		for _, opt := range s.OptionPositions {
+			spot := findSpotPrice(strings.ToUpper(opt.Underlying), prices)
+			if spot <= 0 {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s/%s: no spot price for %s — option leg held at its mark", scfg.ID, opt.ID, opt.Underlying))
+				continue
+			}
+			stressed += stressOptionDelta(opt, spot, sc, now)
+		}
+		res.BaseValue += base
+		res.StressedValue += stressed
+		stressedByID[scfg.ID] = stressed
+
+		r := stressStrategyResult{ID: scfg.ID, Platform: scfg.Platform, BaseValue: base, StressedValue: stressed, LimitPct: s.RiskState.MaxDrawdownPct}
+		if scfg.Type != "manual" {
+			peak := math.Max(s.RiskState.PeakValue, base)
+			loss, denom := peak-stressed, peak
+			if scfg.Type == "perps" {
+				if pnlLoss, margin := perpsMarginDrawdownInputs(s, scfg.Leverage, shocked); margin > 0 {
+					loss, denom = pnlLoss, margin
+					r.MarginWipeout = pnlLoss >= margin
+				}
+			}
+			if loss > 0 && denom > 0 {
+				r.DrawdownPct = loss / denom * 100
+			}
+			r.CircuitBreaker = scfg.CircuitBreakerEnabled() && r.LimitPct > 0 && r.DrawdownPct > r.LimitPct
+		}
+		res.Strategies = append(res.Strategies, r)
+	}
+	sort.Slice(res.Strategies, func(i, j int) bool {
+		return res.Strategies[i].StressedValue-res.Strategies[i].BaseValue < res.Strategies[j].StressedValue-res.Strategies[j].BaseValue
+	})
+
+	// Platform limits against the persisted platform peaks.
+	names := make([]string, 0, len(cfg.Platforms))
+	for name, pc := range cfg.Platforms {
+		if platformRiskEnforced(pc) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		limits := cfg.Platforms[name].Risk
+		var base, stressed float64
+		states := make(map[string]*StrategyState)
+		for _, scfg := range cfg.Strategies {
+			if s := state.Strategies[scfg.ID]; s != nil && scfg.Platform == name {
+				base += PortfolioValue(s, prices)
+				stressed += stressedByID[scfg.ID]
+				states[scfg.ID] = s
+			}
+		}
+		peak := base
+		if prs := state.PlatformRisk[name]; prs != nil && prs.PeakValue > peak {
+			peak = prs.PeakValue
+		}
+		if limits.MaxDrawdownPct > 0 && peak > 0 {
+			if dd := (peak - stressed) / peak * 100; dd > limits.MaxDrawdownPct {
+				res.Platforms = append(res.Platforms, fmt.Sprintf("%s drawdown %.1f%% > %.1f%%", name, dd, limits.MaxDrawdownPct))
+			}
+		}
+		if limits.MaxNotionalUSD > 0 {
+			if n := PortfolioNotional(states, shocked); n > limits.MaxNotionalUSD {
+				res.Platforms = append(res.Platforms, fmt.Sprintf("%s notional $%.2f > $%.2f", name, n, limits.MaxNotionalUSD))
+			}
+		}
+	}
+
+	// Portfolio kill switch on a copy of the persisted state.
+	if cfg.PortfolioRisk != nil {
+		prs := state.PortfolioRisk
+		prs.Events = nil
+		prs.History = nil
+		if res.BaseValue > prs.PeakValue {
+			prs.PeakValue = res.BaseValue
+		}
+		perpsLoss, perpsMargin := AggregatePerpsMarginInputs(state.Strategies, cfg.Strategies, shocked)
+		allowed, _, warning, reason := CheckPortfolioRisk(&prs, cfg.PortfolioRisk, res.StressedValue, PortfolioNotional(state.Strategies, shocked), perpsLoss, perpsMargin)
+		res.EquityDDPct = prs.CurrentDrawdownPct
+		res.MarginDDPct = prs.CurrentMarginDrawdownPct
+		res.KillSwitch = !allowed
+		res.KillSwitchWarn = warning
+		if !allowed {
+			res.KillSwitchReason = reason
+		}
+	}
+	return res
+}
+
+// formatStressReport renders the scenarios for the terminal and owner DM.
+func formatStressReport(results []stressResult, top int) string {
+	var sb strings.Builder
+	for i, res := range results {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		pnl := res.StressedValue - res.BaseValue
+		pct := 0.0
+		if res.BaseValue > 0 {
+			pct = pnl / res.BaseValue * 100
+		}
+		sb.WriteString(fmt.Sprintf("**Stress: %s**\n", res.Scenario.label()))
+		sb.WriteString(fmt.Sprintf("Portfolio: $%.2f → $%.2f (%s, %s)\n", res.BaseValue, res.StressedValue, fmtSignedDollar(pnl), fmtSignedPct(pct)))
+		sb.WriteString(fmt.Sprintf("Drawdown: equity %.1f%%, perps margin %.1f%%\n", res.EquityDDPct, res.MarginDDPct))
+		switch {
+		case res.KillSwitch:
+			sb.WriteString("🛑 Portfolio kill switch WOULD TRIP: " + res.KillSwitchReason + "\n")
+		case res.KillSwitchWarn:
+			sb.WriteString("⚠️ Portfolio kill switch warning band\n")
+		default:
+			sb.WriteString("✅ Portfolio kill switch holds\n")
+		}
+		for _, p := range res.Platforms {
+			sb.WriteString("🛑 Platform limit: " + p + "\n")
+		}
+		var tripped []string
+		for _, r := range res.Strategies {
+			switch {
+			case r.MarginWipeout:
+				tripped = append(tripped, fmt.Sprintf("%s (margin wiped out, %.0f%%)", r.ID, r.DrawdownPct))
+			case r.CircuitBreaker:
+				tripped = append(tripped, fmt.Sprintf("%s (%.1f%% > %.1f%%)", r.ID, r.DrawdownPct, r.LimitPct))
+			}
+		}
+		if len(tripped) > 0 {
+			sb.WriteString(fmt.Sprintf("🛑 Strategy circuit breakers (%d): %s\n", len(tripped), strings.Join(tripped, ", ")))
+		}
+		shown := 0
+		for _, r := range res.Strategies {
+			d := r.StressedValue - r.BaseValue
+			if d >= 0 || shown >= top {
+				break
+			}
+			if shown == 0 {
+				sb.WriteString("Largest losses:\n")
+			}
+			sb.WriteString(fmt.Sprintf("  %s: %s (dd %.1f%%)\n", r.ID, fmtSignedDollar(d), r.DrawdownPct))
+			shown++
+		}
+		for _, n := range res.Notes {
+			sb.WriteString("note: " + n + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func runStress(args []string) int {
+	fs := flag.NewFlagSet("stress", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	shock := fs.String("shock", "-20%", "Spot shock(s), comma-separated (e.g. -20%,-10%,+10%)")
+	volShock := fs.String("vol-shock", "0%", "Relative implied-vol bump for option legs (e.g. +50%)")
+	top := fs.Int("top", 5, "Largest per-strategy losses to list per scenario")
+	post := fs.Bool("post", false, "Also send the report to the owner DM (Discord/Telegram)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "stress: unexpected arguments: %v\n", fs.Args())
+		return 2
+	}
+	shocks, err := parseStressShocks(*shock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stress: --shock: %v\n", err)
+		return 2
+	}
+	vols, err := parseStressShocks(*volShock)
+	if err != nil || len(vols) != 1 {
+		fmt.Fprintf(os.Stderr, "stress: --vol-shock must be a single percentage\n")
+		return 2
+	}
+
+	cfg, err := loadConfigSnapshot(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stress: %v\n", err)
+		return 1
+	}
+	if _, err := os.Stat(cfg.DBFile); err != nil {
+		fmt.Fprintf(os.Stderr, "stress: state DB %s not found: %v\n", cfg.DBFile, err)
+		return 1
+	}
+	db, err := openStateDBForRead(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stress: open %s: %v\n", cfg.DBFile, err)
+		return 1
+	}
+	sdb := &StateDB{db: db}
+	defer sdb.Close()
+	state, err := sdb.LoadState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stress: load state: %v\n", err)
+		return 1
+	}
+	if state == nil {
+		state = NewAppState()
+	}
+
+	prices := fetchPricesForSummary(cfg)
+	now := time.Now().UTC()
+	results := make([]stressResult, 0, len(shocks))
+	for _, s := range shocks {
+		results = append(results, runStressScenario(cfg, state, prices, stressScenario{SpotShockPct: s, VolShockPct: vols[0]}, now))
+	}
+	report := formatStressReport(results, *top)
+	fmt.Print(report)
+
+	if *post {
+		notifier, closeNotifier := buildNotifierFromConfig(cfg)
+		defer closeNotifier()
+		if !notifier.HasBackends() {
+			fmt.Fprintln(os.Stderr, "stress: --post: no Discord/Telegram backend configured")
+			return 1
+		}
+		notifier.SendOwnerDM(report)
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStressShocks(t *testing.T) {
+	got, err := parseStressShocks("-20%, -10 ,+5%")
+	if err != nil || len(got) != 3 || got[0] != -20 || got[1] != -10 || got[2] != 5 {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	for _, bad := range []string{"", "abc", "-100%"} {
+		if _, err := parseStressShocks(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestRunStressScenario(t *testing.T) {
+	cfg := &Config{
+		PortfolioRisk: &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60},
+		Platforms:     map[string]*PlatformConfig{"hyperliquid": {Risk: &PortfolioRiskConfig{MaxDrawdownPct: 10}}},
+		Strategies: []StrategyConfig{
+			{ID: "sma-btc", Type: "spot", Platform: "binanceus", Capital: 1000, MaxDrawdownPct: 50},
+			{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Capital: 1000, Leverage: 5, MaxDrawdownPct: 50},
+		},
+	}
+	state := NewAppState()
+	for _, sc := range cfg.Strategies {
+		s := NewStrategyState(sc)
+		s.RiskState.MaxDrawdownPct = sc.MaxDrawdownPct
+		state.Strategies[sc.ID] = s
+	}
+	spot := state.Strategies["sma-btc"]
+	spot.Cash = 0
+	spot.Positions["BTC/USDT"] = &Position{Symbol: "BTC/USDT", Quantity: 0.01, AvgCost: 100000, Side: "long"}
+	perps := state.Strategies["hl-btc"]
+	perps.Positions["BTC"] = &Position{Symbol: "BTC", Quantity: 0.05, AvgCost: 100000, Side: "long", Multiplier: 1}
+	prices := map[string]float64{"BTC/USDT": 100000, "BTC": 100000}
+
+	res := runStressScenario(cfg, state, prices, stressScenario{SpotShockPct: -20}, time.Now())
+	// Spot: 1000 → 800. Perps: 5000 notional long loses its full 1000 margin (cash 1000 → 0).
+	if res.BaseValue != 2000 || res.StressedValue != 800 {
+		t.Fatalf("values: base=%v stressed=%v", res.BaseValue, res.StressedValue)
+	}
+	if !res.KillSwitch || !strings.Contains(res.KillSwitchReason, "drawdown") {
+		t.Errorf("kill switch should trip: %+v", res)
+	}
+	if len(res.Platforms) != 1 || !strings.HasPrefix(res.Platforms[0], "hyperliquid drawdown") {
+		t.Errorf("platform breaches = %v", res.Platforms)
+	}
+	if first := res.Strategies[0]; first.ID != "hl-btc" || !first.MarginWipeout || !first.CircuitBreaker {
+		t.Errorf("perps result = %+v", first)
+	}
+	if spotRes := res.Strategies[1]; spotRes.CircuitBreaker || spotRes.DrawdownPct != 20 {
+		t.Errorf("spot result = %+v", spotRes)
+	}
+	// The persisted state is untouched.
+	if state.PortfolioRisk.KillSwitchActive || state.PortfolioRisk.PeakValue != 0 {
+		t.Errorf("stress mutated portfolio risk state: %+v", state.PortfolioRisk)
+	}
+	if out := formatStressReport([]stressResult{res}, 5); !strings.Contains(out, "kill switch WOULD TRIP") || !strings.Contains(out, "hl-btc (margin wiped out") {
+		t.Errorf("report:\n%s", out)
+	}
+}
+
+func TestStressOptionDelta(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	put := &OptionPosition{OptionType: "put", Strike: 90000, Expiry: "2026-07-01", Action: "buy", Quantity: 1}
+	if d := stressOptionDelta(put, 100000, stressScenario{SpotShockPct: -20}, now); d <= 0 {
+		t.Errorf("long put must gain on a spot drop, got %v", d)
+	}
+	put.Action = "sell"
+	if d := stressOptionDelta(put, 100000, stressScenario{SpotShockPct: -20}, now); d >= 0 {
+		t.Errorf("short put must lose on a spot drop, got %v", d)
+	}
+	call := &OptionPosition{OptionType: "call", Strike: 100000, Expiry: "2026-07-01", Action: "buy", Quantity: 1}
+	if d := stressOptionDelta(call, 100000, stressScenario{VolShockPct: 50}, now); d <= 0 {
+		t.Errorf("long call must gain on a vol bump, got %v", d)
+	}
+}
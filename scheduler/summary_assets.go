@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// summary_assets: per-underlying PnL breakdown in channel summaries (#4948).
+//
+// Per-bot table rows hide whether the book is net long or net profitable on a
+// given coin when several strategies trade it. assetBreakdown folds the
+// channel's bots by underlying: PnL is the sum of each bot's value minus
+// initial capital (realized trade history plus open marks), and net exposure
+// is the signed mark notional of the open positions. The line renders only
+// when the summary spans more than one underlying; per-asset summaries
+// already show a single coin.
+
+// assetAggregate is one underlying's row in the breakdown.
+type assetAggregate struct {
+	asset       string
+	pnl         float64
+	bots        int
+	netNotional float64 // long positive, short negative, at current marks
+}
+
+// assetBreakdown groups bots by underlying, ordered BTC/ETH/SOL/BNB first
+// (assetSortKey). Options legs carry no spot notional and count only toward
+// PnL.
+func assetBreakdown(strategies []StrategyConfig, bots []botInfo, state *AppState, prices map[string]float64) []assetAggregate {
+	byAsset := make(map[string]*assetAggregate)
+	get := func(asset string) *assetAggregate {
+		a := byAsset[asset]
+		if a == nil {
+			a = &assetAggregate{asset: asset}
+			byAsset[asset] = a
+		}
+		return a
+	}
+	for _, b := range bots {
+		if b.asset == "" {
+			continue
+		}
+		a := get(b.asset)
+		a.pnl += b.pnl
+		a.bots++
+	}
+	for _, sc := range strategies {
+		asset := extractAsset(sc)
+		ss := state.Strategies[sc.ID]
+		if asset == "" || ss == nil || byAsset[asset] == nil {
+			continue
+		}
+		for sym, pos := range ss.Positions {
+			price := prices[sym]
+			if price == 0 {
+				price = pos.AvgCost
+			}
+			notional := pos.Quantity * price
+			if pos.Multiplier > 0 {
+				notional *= pos.Multiplier
+			}
+			if pos.Side == "short" {
+				notional = -notional
+			}
+			byAsset[asset].netNotional += notional
+		}
+	}
+	out := make([]assetAggregate, 0, len(byAsset))
+	for _, a := range byAsset {
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return assetSortKey(out[i].asset) < assetSortKey(out[j].asset)
+	})
+	return out
+}
+
+// fmtSignedUSD renders v as "+$1,234" / "-$1,234".
+func fmtSignedUSD(v float64) string {
+	if v < 0 {
+		return "-$" + fmtComma(-v)
+	}
+	return "+$" + fmtComma(v)
+}
+
+// formatAssetBreakdown renders the "By asset" summary line, or "" when the
+// bots cover fewer than two underlyings.
+func formatAssetBreakdown(aggs []assetAggregate) string {
+	if len(aggs) < 2 {
+		return ""
+	}
+	parts := make([]string, 0, len(aggs))
+	for _, a := range aggs {
+		noun := "bots"
+		if a.bots == 1 {
+			noun = "bot"
+		}
+		exposure := "flat"
+		switch {
+		case a.netNotional >= 0.5:
+			exposure = "net long $" + fmtComma(a.netNotional)
+		case a.netNotional <= -0.5:
+			exposure = "net short $" + fmtComma(-a.netNotional)
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s across %d %s, %s", a.asset, fmtSignedUSD(a.pnl), a.bots, noun, exposure))
+	}
+	return "🪙 By asset: " + strings.Join(parts, " | ") + "\n"
+}
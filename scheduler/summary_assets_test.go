@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssetBreakdown_AggregatesPerUnderlying(t *testing.T) {
+	strats := []StrategyConfig{
+		{ID: "hl-a-btc", Type: "perps", Args: []string{"sma", "BTC", "1h"}},
+		{ID: "hl-b-btc", Type: "perps", Args: []string{"rsi", "BTC", "1h"}},
+		{ID: "hl-a-eth", Type: "perps", Args: []string{"sma", "ETH", "1h"}},
+	}
+	state := &AppState{Strategies: map[string]*StrategyState{
+		"hl-a-btc": {Positions: map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 0.1, AvgCost: 40000, Side: "long", Multiplier: 1}}},
+		"hl-b-btc": {Positions: map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 0.04, AvgCost: 52000, Side: "short", Multiplier: 1}}},
+		"hl-a-eth": {},
+	}}
+	bots := []botInfo{
+		{id: "hl-a-btc", asset: "BTC", pnl: 1000},
+		{id: "hl-b-btc", asset: "BTC", pnl: -50},
+		{id: "hl-a-eth", asset: "ETH", pnl: -40},
+	}
+	prices := map[string]float64{"BTC": 50000}
+
+	aggs := assetBreakdown(strats, bots, state, prices)
+	if len(aggs) != 2 || aggs[0].asset != "BTC" || aggs[1].asset != "ETH" {
+		t.Fatalf("aggs = %+v, want BTC then ETH", aggs)
+	}
+	if aggs[0].pnl != 950 || aggs[0].bots != 2 {
+		t.Errorf("BTC pnl/bots = %v/%d, want 950/2", aggs[0].pnl, aggs[0].bots)
+	}
+	if aggs[0].netNotional != 3000 {
+		t.Errorf("BTC net notional = %v, want 3000 (5000 long - 2000 short)", aggs[0].netNotional)
+	}
+
+	line := formatAssetBreakdown(aggs)
+	want := "🪙 By asset: BTC: +$950 across 2 bots, net long $3,000 | ETH: -$40 across 1 bot, flat\n"
+	if line != want {
+		t.Errorf("line = %q, want %q", line, want)
+	}
+}
+
+func TestFormatAssetBreakdown_SingleAssetOmitted(t *testing.T) {
+	if got := formatAssetBreakdown([]assetAggregate{{asset: "BTC", pnl: 10, bots: 3}}); got != "" {
+		t.Errorf("single asset should render nothing, got %q", got)
+	}
+}
+
+func TestFormatCategorySummary_AssetBreakdownSection(t *testing.T) {
+	strats := []StrategyConfig{
+		{ID: "hl-a-btc", Type: "perps", Args: []string{"sma", "BTC", "1h"}, Capital: 1000},
+		{ID: "hl-a-eth", Type: "perps", Args: []string{"sma", "ETH", "1h"}, Capital: 1000},
+	}
+	state := &AppState{Strategies: map[string]*StrategyState{
+		"hl-a-btc": {Cash: 1100},
+		"hl-a-eth": {Cash: 950},
+	}}
+	prices := map[string]float64{"BTC/USDT": 50000, "ETH/USDT": 3000}
+
+	msg := strings.Join(FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, nil), "\n")
+	if !strings.Contains(msg, "By asset: BTC: +$100 across 1 bot, flat | ETH: -$50 across 1 bot, flat") {
+		t.Errorf("missing asset breakdown, got:\n%s", msg)
+	}
+
+	layout := &SummaryLayoutConfig{Sections: []string{"table"}}
+	msg = strings.Join(FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, layout), "\n")
+	if strings.Contains(msg, "By asset") {
+		t.Errorf("summary_layout without assets should omit the breakdown, got:\n%s", msg)
+	}
+}
@@ -21,6 +21,8 @@ func TestParseSummaryFrequency(t *testing.T) {
 		{"case-insensitive alias", "Every", 0, false},
 		{"hourly alias", "hourly", time.Hour, false},
 		{"daily alias", "daily", 24 * time.Hour, false},
+		{"on_trade_only alias", "on_trade_only", summaryOnTradeOnly, false},
+		{"on_trade alias", "On_Trade", summaryOnTradeOnly, false},
 		{"go duration minutes", "30m", 30 * time.Minute, false},
 		{"go duration hours", "2h", 2 * time.Hour, false},
 		{"go duration combined", "1h30m", 90 * time.Minute, false},
@@ -134,6 +136,21 @@ func TestShouldPostSummary_EveryAliasOverridesSpotDefault(t *testing.T) {
 	}
 }
 
+func TestShouldPostSummary_OnTradeOnlyPostsOnlyWithTrades(t *testing.T) {
+	now := time.Date(2026, 4, 28, 12, 0, 0, 0, time.UTC)
+
+	// Never posted, continuous channel, a day since the last post — still
+	// silent without trades.
+	for _, lastPost := range []time.Time{{}, now.Add(-24 * time.Hour)} {
+		if ShouldPostSummary("on_trade_only", true, false, lastPost, now) {
+			t.Errorf("on_trade_only posted without trades (lastPost=%v)", lastPost)
+		}
+	}
+	if !ShouldPostSummary("on_trade_only", false, true, now, now) {
+		t.Error("on_trade_only should post when the run executed trades")
+	}
+}
+
 func TestShouldPostSummary_HourlyAliasThrottlesContinuousByWallClock(t *testing.T) {
 	now := time.Date(2026, 4, 28, 12, 0, 0, 0, time.UTC)
 
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// summary_layout: per-channel summary content (#4947).
+//
+// summary_layout is keyed like summary_frequency (channel keys, plus "*" as
+// the fallback for channels without their own entry). Each entry can drop
+// sections from FormatCategorySummary, replace the strategy table with a
+// chosen column list, and change the row order, so a channel with many
+// strategies fits Discord's 2000-char limit without spilling into
+// continuation messages. A channel with no layout renders exactly as before.
+
+// summaryLayoutFallbackKey is the summary_layout key applied to channels
+// that have no entry of their own.
+const summaryLayoutFallbackKey = "*"
+
+// SummaryLayouts is the top-level "summary_layout" map: channel key → entry.
+type SummaryLayouts map[string]*SummaryLayoutConfig
+
+// SummaryLayoutConfig is one summary_layout entry.
+type SummaryLayoutConfig struct {
+	Sections []string `json:"sections,omitempty"` // subset of summarySections; omitted → all
+	Columns  []string `json:"columns,omitempty"`  // strategy-table columns in display order; omitted → the standard table
+	SortBy   string   `json:"sort_by,omitempty"`  // id (default), pnl, pnl_pct, value
+}
+
+// summarySections are the optional blocks of a category summary. The title
+// and the "Positions: N open" count always render.
+var summarySections = []string{
+	"risk",      // circuit-breaker / trading-active status and the portfolio VaR line
+	"prices",    // inline price (and regime) line
+	"stats",     // cycle / initial-capital line and book Sharpe
+	"table",     // strategy table
+	"assets",    // per-underlying PnL / net exposure line (#4948)
+	"positions", // per-position detail bullets
+	"trades",    // this cycle's trade lines
+}
+
+var summarySortKeys = []string{"id", "pnl", "pnl_pct", "value"}
+
+// summaryTotals are the TOTAL-row inputs for a layout table.
+type summaryTotals struct {
+	value, pnl, pnlPct, fees float64
+	closed, wins, losses     int
+	sharedWallet             bool
+}
+
+// summaryColumn is one selectable strategy-table column.
+type summaryColumn struct {
+	name   string
+	header string
+	width  int
+	cell   func(b botInfo) string
+	total  func(t summaryTotals) string
+}
+
+func blankSummaryTotal(summaryTotals) string { return "" }
+
+var summaryColumns = []summaryColumn{
+	{"value", "Value", 6, func(b botInfo) string { return fmtComma(b.value) }, func(t summaryTotals) string { return fmtComma(t.value) }},
+	{"pnl", "PnL", 6, func(b botInfo) string { return fmtPnl(b.pnl) }, func(t summaryTotals) string { return fmtPnl(t.pnl) }},
+	{"pnl_pct", "PnL%", 7, func(b botInfo) string { return fmtPnlPct(b.pnlPct) }, func(t summaryTotals) string { return fmtPnlPct(t.pnlPct) }},
+	{"dd", "DD", 4, func(b botInfo) string { return fmtDrawdownPct(b.maxDrawdownPct) }, blankSummaryTotal},
+	{"wallet", "Wallet%", 7, func(b botInfo) string {
+		if b.walletPct > 0 {
+			return fmt.Sprintf("%.1f%%", b.walletPct)
+		}
+		return ""
+	}, func(t summaryTotals) string {
+		if t.sharedWallet {
+			return "100.0%"
+		}
+		return ""
+	}},
+	{"tf", "Tf", 4, func(b botInfo) string { return b.timeframe }, blankSummaryTotal},
+	{"int", "Int", 4, func(b botInfo) string { return b.interval }, blankSummaryTotal},
+	{"trades", "#T", 4, func(b botInfo) string { return fmt.Sprintf("%d", b.closedTrades) }, func(t summaryTotals) string { return fmt.Sprintf("%d", t.closed) }},
+	{"wl", "W/L", 5, func(b botInfo) string { return fmtWinLossRatio(b.winningTrades, b.losingTrades) }, func(t summaryTotals) string { return fmtWinLossRatio(t.wins, t.losses) }},
+	{"fees", "Fees", 6, func(b botInfo) string { return fmtComma2(botFees(b)) }, func(t summaryTotals) string { return fmtComma2(t.fees) }},
+	{"alpha", "Alpha", 6, func(b botInfo) string {
+		if b.benchmark == nil {
+			return ""
+		}
+		return fmt.Sprintf("%+.0f%%", b.benchmark.Alpha)
+	}, blankSummaryTotal},
+	{"beta", "Beta", 5, func(b botInfo) string {
+		if b.benchmark == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.2f", b.benchmark.Beta)
+	}, blankSummaryTotal},
+}
+
+func summaryColumnByName(name string) (summaryColumn, bool) {
+	for _, c := range summaryColumns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return summaryColumn{}, false
+}
+
+func summaryColumnNames() []string {
+	names := make([]string, len(summaryColumns))
+	for i, c := range summaryColumns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// botFees sums the exchange fees in the strategy's in-memory trade history.
+func botFees(b botInfo) float64 {
+	total := 0.0
+	for _, t := range b.tradeHistory {
+		total += t.ExchangeFee
+	}
+	return total
+}
+
+// resolveSummaryLayout returns the layout for channelKey, falling back to
+// the "*" entry. Nil means the standard summary.
+func resolveSummaryLayout(layouts SummaryLayouts, channelKey string) *SummaryLayoutConfig {
+	if l, ok := layouts[channelKey]; ok {
+		return l
+	}
+	return layouts[summaryLayoutFallbackKey]
+}
+
+// showSection reports whether the layout renders the named section.
+func (l *SummaryLayoutConfig) showSection(name string) bool {
+	return l == nil || len(l.Sections) == 0 || slices.Contains(l.Sections, name)
+}
+
+func (l *SummaryLayoutConfig) sortBy() string {
+	if l == nil || l.SortBy == "" {
+		return "id"
+	}
+	return l.SortBy
+}
+
+// sortSummaryBots orders table rows. id is A→Z (#354); the others are
+// descending with id as the tiebreak so the order is stable across cycles.
+func sortSummaryBots(bots []botInfo, sortBy string) {
+	key := func(b botInfo) float64 {
+		switch sortBy {
+		case "pnl":
+			return b.pnl
+		case "pnl_pct":
+			return b.pnlPct
+		case "value":
+			return b.value
+		}
+		return 0
+	}
+	sort.SliceStable(bots, func(i, j int) bool {
+		if sortBy != "id" {
+			if ki, kj := key(bots[i]), key(bots[j]); ki != kj {
+				return ki > kj
+			}
+		}
+		return bots[i].id < bots[j].id
+	})
+}
+
+// validateSummaryLayouts checks every summary_layout entry.
+func validateSummaryLayouts(layouts SummaryLayouts) []string {
+	var errs []string
+	keys := make([]string, 0, len(layouts))
+	for k := range layouts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		l := layouts[k]
+		if strings.TrimSpace(k) == "" {
+			errs = append(errs, "summary_layout: empty key")
+			continue
+		}
+		if l == nil {
+			continue
+		}
+		for _, s := range l.Sections {
+			if !slices.Contains(summarySections, s) {
+				errs = append(errs, fmt.Sprintf("summary_layout[%q].sections: unknown section %q (valid: %s)", k, s, strings.Join(summarySections, ", ")))
+			}
+		}
+		seen := make(map[string]bool)
+		for _, c := range l.Columns {
+			if _, ok := summaryColumnByName(c); !ok {
+				errs = append(errs, fmt.Sprintf("summary_layout[%q].columns: unknown column %q (valid: %s)", k, c, strings.Join(summaryColumnNames(), ", ")))
+			} else if seen[c] {
+				errs = append(errs, fmt.Sprintf("summary_layout[%q].columns: duplicate column %q", k, c))
+			}
+			seen[c] = true
+		}
+		if l.SortBy != "" && !slices.Contains(summarySortKeys, l.SortBy) {
+			errs = append(errs, fmt.Sprintf("summary_layout[%q].sort_by: unknown key %q (valid: %s)", k, l.SortBy, strings.Join(summarySortKeys, ", ")))
+		}
+	}
+	return errs
+}
+
+func cloneSummaryLayouts(layouts SummaryLayouts) SummaryLayouts {
+	if layouts == nil {
+		return nil
+	}
+	out := make(SummaryLayouts, len(layouts))
+	for k, l := range layouts {
+		if l == nil {
+			out[k] = nil
+			continue
+		}
+		out[k] = &SummaryLayoutConfig{
+			Sections: append([]string(nil), l.Sections...),
+			Columns:  append([]string(nil), l.Columns...),
+			SortBy:   l.SortBy,
+		}
+	}
+	return out
+}
+
+// formatSummaryLayouts renders summary_layout for reload change logs.
+func formatSummaryLayouts(layouts SummaryLayouts) string {
+	if len(layouts) == 0 {
+		return "{}"
+	}
+	keys := make([]string, 0, len(layouts))
+	for k := range layouts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		l := layouts[k]
+		if l == nil {
+			parts = append(parts, k+":default")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s:sections=%v,columns=%v,sort=%s", k, l.Sections, l.Columns, l.sortBy()))
+	}
+	return "{" + strings.Join(parts, "; ") + "}"
+}
+
+// writeSummaryLayoutTableChunks is writeCatTableChunks for a layout with an
+// explicit column list: catTableMaxRows rows per code block, TOTAL row in
+// the last block.
+func writeSummaryLayoutTableChunks(bots []botInfo, columns []string, totals summaryTotals) []string {
+	if len(bots) == 0 {
+		return nil
+	}
+	cols := make([]summaryColumn, 0, len(columns))
+	for _, name := range columns {
+		if c, ok := summaryColumnByName(name); ok {
+			cols = append(cols, c)
+		}
+	}
+	for _, bot := range bots {
+		totals.closed += bot.closedTrades
+		totals.wins += bot.winningTrades
+		totals.losses += bot.losingTrades
+		totals.fees += botFees(bot)
+	}
+	row := func(label string, cell func(c summaryColumn) string) string {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%-*s", catTableStrategyWidth, label))
+		for _, c := range cols {
+			sb.WriteString(fmt.Sprintf(" %*s", c.width, cell(c)))
+		}
+		return strings.TrimRight(sb.String(), " ")
+	}
+	header := row("Strategy", func(c summaryColumn) string { return c.header })
+	sep := strings.Repeat("-", len(header))
+	var chunks []string
+	for start := 0; start < len(bots); start += catTableMaxRows {
+		end := start + catTableMaxRows
+		if end > len(bots) {
+			end = len(bots)
+		}
+		var sb strings.Builder
+		sb.WriteString("\n```\n")
+		sb.WriteString(header + "\n")
+		sb.WriteString(sep + "\n")
+		for _, bot := range bots[start:end] {
+			b := bot
+			sb.WriteString(row(summaryStrategyLabel(b.id), func(c summaryColumn) string { return c.cell(b) }) + "\n")
+		}
+		if end == len(bots) {
+			sb.WriteString(sep + "\n")
+			sb.WriteString(row("TOTAL", func(c summaryColumn) string { return c.total(totals) }) + "\n")
+		}
+		sb.WriteString("```\n")
+		chunks = append(chunks, sb.String())
+	}
+	return chunks
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func summaryLayoutFixture() ([]StrategyConfig, *AppState, map[string]float64) {
+	strats := []StrategyConfig{
+		{ID: "hl-a-btc", Type: "perps", Args: []string{"sma", "BTC", "1h"}, Capital: 1000},
+		{ID: "hl-b-btc", Type: "perps", Args: []string{"rsi", "BTC", "1h"}, Capital: 1000},
+	}
+	state := &AppState{
+		Strategies: map[string]*StrategyState{
+			"hl-a-btc": {Cash: 900, TradeHistory: []Trade{{ExchangeFee: 1.25}, {ExchangeFee: 0.75}}},
+			"hl-b-btc": {Cash: 1200},
+		},
+	}
+	prices := map[string]float64{"BTC/USDT": 50000}
+	return strats, state, prices
+}
+
+func TestResolveSummaryLayout(t *testing.T) {
+	spot := &SummaryLayoutConfig{SortBy: "pnl"}
+	star := &SummaryLayoutConfig{SortBy: "value"}
+	layouts := SummaryLayouts{"spot": spot, "*": star}
+	if got := resolveSummaryLayout(layouts, "spot"); got != spot {
+		t.Errorf("spot: got %+v, want channel entry", got)
+	}
+	if got := resolveSummaryLayout(layouts, "hyperliquid"); got != star {
+		t.Errorf("hyperliquid: got %+v, want fallback", got)
+	}
+	if got := resolveSummaryLayout(nil, "spot"); got != nil {
+		t.Errorf("nil layouts: got %+v, want nil", got)
+	}
+}
+
+func TestValidateSummaryLayouts(t *testing.T) {
+	ok := SummaryLayouts{"*": {Sections: []string{"table", "trades"}, Columns: []string{"pnl", "fees"}, SortBy: "pnl_pct"}}
+	if errs := validateSummaryLayouts(ok); len(errs) != 0 {
+		t.Fatalf("valid layout rejected: %v", errs)
+	}
+	bad := SummaryLayouts{
+		"":     {},
+		"spot": {Sections: []string{"chart"}, Columns: []string{"pnl", "pnl", "gamma"}, SortBy: "name"},
+	}
+	errs := validateSummaryLayouts(bad)
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"empty key", `unknown section "chart"`, `duplicate column "pnl"`, `unknown column "gamma"`, `unknown key "name"`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in errors:\n%s", want, joined)
+		}
+	}
+}
+
+func TestFormatCategorySummary_NilLayoutUnchanged(t *testing.T) {
+	strats, state, prices := summaryLayoutFixture()
+	base := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, nil)
+	empty := FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, &SummaryLayoutConfig{})
+	if strings.Join(base, "\n") != strings.Join(empty, "\n") {
+		t.Errorf("empty layout changed output:\n%s\n---\n%s", strings.Join(base, "\n"), strings.Join(empty, "\n"))
+	}
+}
+
+func TestFormatCategorySummary_LayoutSections(t *testing.T) {
+	strats, state, prices := summaryLayoutFixture()
+	layout := &SummaryLayoutConfig{Sections: []string{"table"}}
+	msg := strings.Join(FormatCategorySummary(1, 0, 2, 1, -1, prices, []string{"BUY BTC"}, strats, state, "hyperliquid", "", 600, 1.5, nil, nil, layout), "\n")
+	for _, gone := range []string{"Trading active", "BTC: $", "Cycle #", "Book Sharpe", "**Trades:**"} {
+		if strings.Contains(msg, gone) {
+			t.Errorf("section %q should be omitted, got:\n%s", gone, msg)
+		}
+	}
+	if !strings.Contains(msg, "TOTAL") {
+		t.Errorf("table should render, got:\n%s", msg)
+	}
+
+	layout = &SummaryLayoutConfig{Sections: []string{"risk", "trades"}}
+	msg = strings.Join(FormatCategorySummary(1, 0, 2, 1, -1, prices, []string{"BUY BTC"}, strats, state, "hyperliquid", "", 600, 0, nil, nil, layout), "\n")
+	if strings.Contains(msg, "TOTAL") {
+		t.Errorf("table should be omitted, got:\n%s", msg)
+	}
+	if !strings.Contains(msg, "Trading active") || !strings.Contains(msg, "• BUY BTC") {
+		t.Errorf("risk and trades sections should render, got:\n%s", msg)
+	}
+}
+
+func TestFormatCategorySummary_LayoutColumnsAndSort(t *testing.T) {
+	strats, state, prices := summaryLayoutFixture()
+	layout := &SummaryLayoutConfig{Columns: []string{"pnl", "fees"}, SortBy: "pnl"}
+	msg := strings.Join(FormatCategorySummary(1, 0, 2, 0, -1, prices, nil, strats, state, "hyperliquid", "", 600, 0, nil, nil, layout), "\n")
+
+	var header string
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "Strategy") {
+			header = line
+			break
+		}
+	}
+	if fields := strings.Fields(header); strings.Join(fields, ",") != "Strategy,PnL,Fees" {
+		t.Fatalf("header = %q, want Strategy PnL Fees", header)
+	}
+	a, b := strings.Index(msg, "hl-a-btc"), strings.Index(msg, "hl-b-btc")
+	if a < 0 || b < 0 || b > a {
+		t.Errorf("sort_by=pnl should list hl-b-btc (+200) before hl-a-btc (-100), got:\n%s", msg)
+	}
+	if !strings.Contains(msg, "2.00") {
+		t.Errorf("fees column should show hl-a-btc's 2.00 in fees, got:\n%s", msg)
+	}
+}
+
+func TestSortSummaryBots_TiebreakByID(t *testing.T) {
+	bots := []botInfo{{id: "c", pnl: 5}, {id: "a", pnl: 5}, {id: "b", pnl: 9}}
+	sortSummaryBots(bots, "pnl")
+	var ids []string
+	for _, b := range bots {
+		ids = append(ids, b.id)
+	}
+	if got := strings.Join(ids, ","); got != "b,a,c" {
+		t.Errorf("order = %s, want b,a,c", got)
+	}
+}
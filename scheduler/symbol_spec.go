@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SymbolSpec is per-symbol exchange metadata under
+// platforms.<name>.symbols.<symbol>. Computed open sizes are floored to
+// LotSize and paper fill prices rounded to TickSize so paper books match what
+// the venue would accept, and live Hyperliquid orders never carry raw floats
+// like size=0.0123456789. An open whose rounded notional is below
+// MinNotionalUSD is skipped. Zero fields are not enforced.
+type SymbolSpec struct {
+	TickSize       float64 `json:"tick_size,omitempty"`
+	LotSize        float64 `json:"lot_size,omitempty"`
+	MinNotionalUSD float64 `json:"min_notional_usd,omitempty"`
+}
+
+// RoundQty floors qty to a whole number of lots.
+func (sp SymbolSpec) RoundQty(qty float64) float64 {
+	return floorToStep(qty, sp.LotSize)
+}
+
+// RoundPrice rounds px to the nearest tick.
+func (sp SymbolSpec) RoundPrice(px float64) float64 {
+	if sp.TickSize <= 0 || px <= 0 {
+		return px
+	}
+	return cleanStepValue(math.Round(px/sp.TickSize)*sp.TickSize, sp.TickSize)
+}
+
+// openQty rounds a computed open quantity at price and enforces the minimum
+// notional. Returns 0 with the skip reason when nothing valid remains.
+func (sp SymbolSpec) openQty(qty, price float64) (float64, string) {
+	rounded := sp.RoundQty(qty)
+	if rounded <= 0 {
+		return 0, fmt.Sprintf("size %.8g rounds to zero at lot size %g", qty, sp.LotSize)
+	}
+	if sp.MinNotionalUSD > 0 && rounded*price < sp.MinNotionalUSD {
+		return 0, fmt.Sprintf("notional $%.2f is below the $%.2f minimum", rounded*price, sp.MinNotionalUSD)
+	}
+	return rounded, ""
+}
+
+// floorToStep floors v to a multiple of step (step <= 0 returns v). A 1e-9
+// step tolerance keeps values that are a lot multiple up to float noise
+// (0.3/0.1 = 2.9999999999999996) from losing a whole lot.
+func floorToStep(v, step float64) float64 {
+	if step <= 0 || v <= 0 {
+		return v
+	}
+	return cleanStepValue(math.Floor(v/step+1e-9)*step, step)
+}
+
+// cleanStepValue trims float noise from a step multiple by formatting it to
+// the step's decimal places.
+func cleanStepValue(v, step float64) float64 {
+	decimals := 0
+	if s := strconv.FormatFloat(step, 'f', -1, 64); strings.Contains(s, ".") {
+		decimals = len(s) - strings.Index(s, ".") - 1
+	}
+	out, err := strconv.ParseFloat(strconv.FormatFloat(v, 'f', decimals, 64), 64)
+	if err != nil {
+		return v
+	}
+	return out
+}
+
+func symbolSpecErrors(platform string, specs map[string]*SymbolSpec) []string {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []string
+	for _, name := range names {
+		sp := specs[name]
+		if sp == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("platforms.%s.symbols[%s]", platform, name)
+		if sp.TickSize < 0 {
+			errs = append(errs, fmt.Sprintf("%s.tick_size must be >= 0, got %g", prefix, sp.TickSize))
+		}
+		if sp.LotSize < 0 {
+			errs = append(errs, fmt.Sprintf("%s.lot_size must be >= 0, got %g", prefix, sp.LotSize))
+		}
+		if sp.MinNotionalUSD < 0 {
+			errs = append(errs, fmt.Sprintf("%s.min_notional_usd must be >= 0, got %g", prefix, sp.MinNotionalUSD))
+		}
+	}
+	return errs
+}
+
+// symbolSpecsLabel renders every platform's symbol metadata for the
+// hot-reload diff.
+func symbolSpecsLabel(platforms map[string]*PlatformConfig) string {
+	var parts []string
+	for platform, pc := range platforms {
+		if pc == nil {
+			continue
+		}
+		for symbol, sp := range pc.Symbols {
+			if sp != nil {
+				parts = append(parts, fmt.Sprintf("%s/%s(tick=%g lot=%g min=$%g)", platform, symbol, sp.TickSize, sp.LotSize, sp.MinNotionalUSD))
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// --- package-level store (loaded at startup + SIGHUP) -----------------------
+
+var (
+	symbolSpecMu         sync.RWMutex
+	symbolSpecStore      map[string]SymbolSpec // key: platform + "\x00" + symbol
+	platformMinNotionals map[string]float64
+)
+
+// setSymbolSpecs installs the platforms' symbol metadata and live minimum
+// order notionals for the executors.
+func setSymbolSpecs(platforms map[string]*PlatformConfig) {
+	next := make(map[string]SymbolSpec)
+	nextMins := make(map[string]float64)
+	for platform, pc := range platforms {
+		if pc == nil {
+			continue
+		}
+		if pc.MinOrderNotionalUSD > 0 {
+			nextMins[platform] = pc.MinOrderNotionalUSD
+		}
+		for symbol, sp := range pc.Symbols {
+			if sp != nil {
+				next[platform+"\x00"+symbol] = *sp
+			}
+		}
+	}
+	symbolSpecMu.Lock()
+	symbolSpecStore = next
+	platformMinNotionals = nextMins
+	symbolSpecMu.Unlock()
+}
+
+// symbolSpecFor returns the configured metadata for symbol on platform (the
+// zero spec, which enforces nothing, when none is configured).
+func symbolSpecFor(platform, symbol string) SymbolSpec {
+	symbolSpecMu.RLock()
+	defer symbolSpecMu.RUnlock()
+	return symbolSpecStore[platform+"\x00"+symbol]
+}
+
+// liveSymbolSpecFor is symbolSpecFor for live orders: a symbol without its own
+// min_notional_usd inherits the platform's min_order_notional_usd, so a
+// nearly-exhausted wallet never burns an API call on a guaranteed rejection.
+func liveSymbolSpecFor(platform, symbol string) SymbolSpec {
+	sp := symbolSpecFor(platform, symbol)
+	if sp.MinNotionalUSD <= 0 {
+		symbolSpecMu.RLock()
+		sp.MinNotionalUSD = platformMinNotionals[platform]
+		symbolSpecMu.RUnlock()
+	}
+	return sp
+}
+
+// platformMinNotionalsLabel renders every platform's min_order_notional_usd
+// for the hot-reload diff.
+func platformMinNotionalsLabel(platforms map[string]*PlatformConfig) string {
+	var parts []string
+	for platform, pc := range platforms {
+		if pc != nil && pc.MinOrderNotionalUSD > 0 {
+			parts = append(parts, fmt.Sprintf("%s=$%g", platform, pc.MinOrderNotionalUSD))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSymbolSpecRounding(t *testing.T) {
+	sp := SymbolSpec{TickSize: 0.5, LotSize: 0.001, MinNotionalUSD: 10}
+	for _, tc := range []struct{ in, want float64 }{
+		{0.0123456789, 0.012},
+		{0.3, 0.3},
+		{0.0009, 0},
+	} {
+		if got := sp.RoundQty(tc.in); got != tc.want {
+			t.Errorf("RoundQty(%g) = %g, want %g", tc.in, got, tc.want)
+		}
+	}
+	if got := (SymbolSpec{LotSize: 0.1}).RoundQty(0.3); got != 0.3 {
+		t.Errorf("RoundQty must tolerate float noise: got %g", got)
+	}
+	if got := sp.RoundPrice(100.26); got != 100.5 {
+		t.Errorf("RoundPrice(100.26) = %g, want 100.5", got)
+	}
+	if got := (SymbolSpec{}).RoundQty(0.0123456789); got != 0.0123456789 {
+		t.Errorf("zero spec must not round: got %g", got)
+	}
+	if qty, why := sp.openQty(0.05, 100); qty != 0 || !strings.Contains(why, "below the $10.00 minimum") {
+		t.Errorf("openQty below min notional = %g, %q", qty, why)
+	}
+	if qty, why := sp.openQty(0.2345, 100); qty != 0.234 || why != "" {
+		t.Errorf("openQty = %g, %q", qty, why)
+	}
+}
+
+func TestPaperOpensUseSymbolSpec(t *testing.T) {
+	setSymbolSpecs(map[string]*PlatformConfig{
+		"hyperliquid": {Symbols: map[string]*SymbolSpec{"BTC": {TickSize: 1, LotSize: 0.001}}},
+		"binanceus":   {Symbols: map[string]*SymbolSpec{"ETH/USDT": {LotSize: 0.01, MinNotionalUSD: 5000}}},
+	})
+	t.Cleanup(func() { setSymbolSpecs(nil) })
+	logger := silentStrategyLogger("spec")
+
+	perps := NewStrategyState(StrategyConfig{ID: "hl-btc", Type: "perps", Platform: "hyperliquid", Capital: 1000})
+	if _, err := ExecutePerpsSignalWithLeverage(perps, 1, "BTC", 30000, PerpsSizing{SizingLeverage: 1, ExchangeLeverage: 1}, 0, "", 0, DirectionLong, 0, logger); err != nil {
+		t.Fatal(err)
+	}
+	pos := perps.Positions["BTC"]
+	if pos == nil || pos.Quantity != 0.033 || pos.AvgCost != float64(int64(pos.AvgCost)) {
+		t.Fatalf("perps open not rounded: %+v", pos)
+	}
+
+	spot := NewStrategyState(StrategyConfig{ID: "spot-eth", Type: "spot", Platform: "binanceus", Capital: 1000})
+	if trades, err := ExecuteSpotSignalWithFillFee(spot, 1, "ETH/USDT", 2000, 0, 0, "", 0, logger); err != nil || trades != 0 {
+		t.Fatalf("open below min notional: trades=%d err=%v", trades, err)
+	}
+	if spot.Cash != 1000 || len(spot.Positions) != 0 {
+		t.Errorf("skipped open must leave state untouched: cash=%g positions=%v", spot.Cash, spot.Positions)
+	}
+}
+
+func TestValidateConfig_SymbolSpecs(t *testing.T) {
+	errs := symbolSpecErrors("hyperliquid", map[string]*SymbolSpec{"BTC": {TickSize: -1, LotSize: -0.1, MinNotionalUSD: -5}})
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"platforms.hyperliquid.symbols[BTC].tick_size", "lot_size must be >= 0", "min_notional_usd must be >= 0"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("errors %q missing %q", joined, want)
+		}
+	}
+}
+
+func TestLiveMinOrderNotionalSkipsOpens(t *testing.T) {
+	setSymbolSpecs(map[string]*PlatformConfig{
+		"okx": {MinOrderNotionalUSD: 10, Symbols: map[string]*SymbolSpec{"ETH-USDT": {MinNotionalUSD: 1}}},
+	})
+	t.Cleanup(func() { setSymbolSpecs(nil) })
+	if got := liveSymbolSpecFor("okx", "BTC-USDT").MinNotionalUSD; got != 10 {
+		t.Errorf("platform default min = %g, want 10", got)
+	}
+	if got := liveSymbolSpecFor("okx", "ETH-USDT").MinNotionalUSD; got != 1 {
+		t.Errorf("symbol min must override the platform default, got %g", got)
+	}
+	if got := symbolSpecFor("okx", "BTC-USDT").MinNotionalUSD; got != 0 {
+		t.Errorf("paper spec must not inherit the live minimum, got %g", got)
+	}
+
+	orig := okxExecuteFn
+	t.Cleanup(func() { okxExecuteFn = orig })
+	var calls []string
+	okxExecuteFn = func(script, symbol, side string, size float64, instType, clientOrderID string) (*OKXExecuteResult, string, error) {
+		calls = append(calls, side)
+		return &OKXExecuteResult{Execution: &OKXExecution{Action: side, Symbol: symbol, Size: size, Fill: &OKXFill{AvgPx: 100, TotalSz: size}}}, "", nil
+	}
+	logger := &StrategyLogger{stratID: "okx-btc", writer: io.Discard}
+	sc := StrategyConfig{ID: "okx-btc", Type: "spot", Platform: "okx", Script: "check_okx.py"}
+
+	if er, ok := runOKXExecuteOrder(sc, &OKXResult{Symbol: "BTC-USDT", Signal: 1, Price: 100}, 100, 3, false, 0, "", 0, nil, logger); ok || er != nil || len(calls) != 0 {
+		t.Fatalf("$3 buy must be skipped before the executor: ok=%v calls=%v", ok, calls)
+	}
+	// Closes are never held back by the minimum.
+	if _, ok := runOKXExecuteOrder(sc, &OKXResult{Symbol: "BTC-USDT", Signal: -1, Price: 100}, 100, 0, false, 0.01, "long", 100, nil, logger); !ok || len(calls) != 1 {
+		t.Fatalf("dust close must proceed: ok=%v calls=%v", ok, calls)
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+// tax_export: Koinly / CoinTracker CSV presets for `go-trader export` (#4937).
+//
+// Both tools import a "universal" sent/received/fee layout, so every ledger
+// row is first mapped to a taxTxn and then rendered per preset:
+//
+//   - spot (and wheel assignment) legs are asset swaps: a buy sends quote and
+//     receives base, a sell the reverse, with exchange_fee in the quote
+//     currency;
+//   - perps / futures / options are cash-settled derivatives: only the close
+//     leg is a taxable event, exported as a realized gain (received USD) or
+//     loss (sent USD). Option premiums paid or received are inside that close
+//     PnL (bought: exit value − premium paid; sold: premium kept − buy-back),
+//     so an option still open at year end is reported in the year it closes
+//     or expires. Open legs only emit their fee, as a cost;
+//   - funding rows are income when received and a margin cost when paid.
+//
+// Derivative gains use the gross PnL with the fee in the fee column, so the
+// tool's net equals tradeNetPnL under both the #954 gross and legacy
+// conventions.
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// taxTxnKind classifies a taxTxn for label/tag mapping.
+type taxTxnKind int
+
+const (
+	taxTxnTrade        taxTxnKind = iota // spot swap
+	taxTxnRealizedGain                   // derivative close, PnL >= 0
+	taxTxnRealizedLoss                   // derivative close, PnL < 0
+	taxTxnFee                            // standalone fee (derivative open)
+	taxTxnFundingIn                      // funding received
+	taxTxnFundingOut                     // funding paid
+)
+
+// taxTxn is one tool-neutral transaction row.
+type taxTxn struct {
+	Time        time.Time
+	SentAmount  float64
+	SentCur     string
+	RecvAmount  float64
+	RecvCur     string
+	FeeAmount   float64
+	FeeCur      string
+	Kind        taxTxnKind
+	Description string
+	Ref         string
+}
+
+// taxExportPreset renders taxTxns for one import tool.
+type taxExportPreset struct {
+	Name   string
+	Header []string
+	Row    func(taxTxn) []string
+}
+
+var taxExportPresets = map[string]taxExportPreset{
+	"koinly": {
+		Name:   "Koinly",
+		Header: []string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"},
+		Row:    koinlyRow,
+	},
+	"cointracker": {
+		Name:   "CoinTracker",
+		Header: []string{"Date", "Received Quantity", "Received Currency", "Sent Quantity", "Sent Currency", "Fee Amount", "Fee Currency", "Tag"},
+		Row:    coinTrackerRow,
+	},
+}
+
+// koinlyLabels maps kinds to Koinly labels. Koinly treats a "realized gain"
+// withdrawal as a loss.
+var koinlyLabels = map[taxTxnKind]string{
+	taxTxnRealizedGain: "realized gain",
+	taxTxnRealizedLoss: "realized gain",
+	taxTxnFee:          "cost",
+	taxTxnFundingIn:    "realized gain",
+	taxTxnFundingOut:   "margin fee",
+}
+
+// coinTrackerTags maps kinds to CoinTracker tags.
+var coinTrackerTags = map[taxTxnKind]string{
+	taxTxnRealizedGain: "income",
+	taxTxnRealizedLoss: "lost",
+	taxTxnFee:          "lost",
+	taxTxnFundingIn:    "income",
+	taxTxnFundingOut:   "lost",
+}
+
+func koinlyRow(t taxTxn) []string {
+	return []string{
+		t.Time.UTC().Format("2006-01-02 15:04:05") + " UTC",
+		formatTaxAmount(t.SentAmount), taxCur(t.SentAmount, t.SentCur),
+		formatTaxAmount(t.RecvAmount), taxCur(t.RecvAmount, t.RecvCur),
+		formatTaxAmount(t.FeeAmount), taxCur(t.FeeAmount, t.FeeCur),
+		"", "",
+		koinlyLabels[t.Kind], t.Description, t.Ref,
+	}
+}
+
+func coinTrackerRow(t taxTxn) []string {
+	return []string{
+		t.Time.UTC().Format("01/02/2006 15:04:05"),
+		formatTaxAmount(t.RecvAmount), taxCur(t.RecvAmount, t.RecvCur),
+		formatTaxAmount(t.SentAmount), taxCur(t.SentAmount, t.SentCur),
+		formatTaxAmount(t.FeeAmount), taxCur(t.FeeAmount, t.FeeCur),
+		coinTrackerTags[t.Kind],
+	}
+}
+
+// formatTaxAmount leaves zero amounts blank, as both importers expect.
+func formatTaxAmount(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return formatTradingViewFloat(v)
+}
+
+func taxCur(amount float64, cur string) string {
+	if amount == 0 {
+		return ""
+	}
+	return cur
+}
+
+type taxExportOptions struct {
+	tradingViewExportOptions
+	Year int // 0 = all years
+}
+
+func runTaxExport(preset string, args []string) int {
+	p := taxExportPresets[preset]
+	fs := flag.NewFlagSet("export "+preset, flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	outputPath := fs.String("output", "", "Output CSV path")
+	all := fs.Bool("all", false, "Export all configured strategies with trade data")
+	year := fs.Int("year", 0, "Only export trades in this UTC calendar year (default: all)")
+	var strategyIDs repeatedStringFlag
+	fs.Var(&strategyIDs, "strategy", "Strategy ID to export; may be specified multiple times")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return 2
+	}
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+
+	n, err := exportTaxCSVFile(stateDB, cfg, p, taxExportOptions{
+		tradingViewExportOptions: tradingViewExportOptions{
+			All:         *all,
+			StrategyIDs: []string(strategyIDs),
+			OutputPath:  *outputPath,
+		},
+		Year: *year,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s export failed: %v\n", p.Name, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d %s transaction rows to %s\n", n, p.Name, *outputPath)
+	return 0
+}
+
+func exportTaxCSVFile(stateDB *StateDB, cfg *Config, p taxExportPreset, opts taxExportOptions) (int, error) {
+	if strings.TrimSpace(opts.OutputPath) == "" {
+		return 0, fmt.Errorf("--output is required")
+	}
+	if opts.Year != 0 && (opts.Year < 2000 || opts.Year > 9999) {
+		return 0, fmt.Errorf("--year %d is out of range", opts.Year)
+	}
+	strategies, err := selectTradingViewExportStrategies(cfg, opts.tradingViewExportOptions)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]string, 0, len(strategies))
+	for _, sc := range strategies {
+		ids = append(ids, sc.ID)
+	}
+	trades, err := stateDB.QueryTaxExportTrades(ids)
+	if err != nil {
+		return 0, err
+	}
+	if opts.Year != 0 {
+		kept := trades[:0]
+		for _, t := range trades {
+			if t.Timestamp.UTC().Year() == opts.Year {
+				kept = append(kept, t)
+			}
+		}
+		trades = kept
+	}
+	txns, err := buildTaxTxns(strategies, trades)
+	if err != nil {
+		return 0, err
+	}
+	if len(txns) == 0 {
+		return 0, fmt.Errorf("no taxable trade data found for selected strategies")
+	}
+	if _, err := os.Stat(opts.OutputPath); err == nil {
+		fmt.Fprintf(os.Stderr, "[WARN] overwriting existing %s CSV: %s\n", p.Name, opts.OutputPath)
+	} else if !os.IsNotExist(err) {
+		return 0, fmt.Errorf("check output CSV: %w", err)
+	}
+	f, err := os.Create(opts.OutputPath)
+	if err != nil {
+		return 0, fmt.Errorf("create output CSV: %w", err)
+	}
+	defer f.Close()
+	if err := writeTaxCSV(f, p, txns); err != nil {
+		return 0, err
+	}
+	return len(txns), nil
+}
+
+// buildTaxTxns maps ledger rows (oldest first) to taxTxns. Rows for
+// strategies not in the selection are skipped.
+func buildTaxTxns(strategies []StrategyConfig, trades []Trade) ([]taxTxn, error) {
+	byID := make(map[string]StrategyConfig, len(strategies))
+	for _, sc := range strategies {
+		byID[sc.ID] = sc
+	}
+	var out []taxTxn
+	for _, t := range trades {
+		sc, ok := byID[t.StrategyID]
+		if !ok {
+			continue
+		}
+		txn, ok, err := taxTxnForTrade(sc, t)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, txn)
+		}
+	}
+	return out, nil
+}
+
+// taxTxnForTrade maps one ledger row; ok=false when the row carries nothing
+// taxable (a fee-free derivative open).
+func taxTxnForTrade(sc StrategyConfig, t Trade) (taxTxn, bool, error) {
+	tradeType := strings.ToLower(strings.TrimSpace(t.TradeType))
+	if tradeType == "" {
+		tradeType = strings.ToLower(strings.TrimSpace(sc.Type))
+	}
+	txn := taxTxn{
+		Time:        t.Timestamp,
+		Description: strings.TrimSpace(fmt.Sprintf("%s %s %s", t.StrategyID, t.Symbol, t.Details)),
+		Ref:         t.ExchangeOrderID,
+	}
+
+	switch tradeType {
+	case TradeTypeFunding:
+		amt := tradeNetPnL(t)
+		if amt == 0 {
+			return txn, false, nil
+		}
+		if amt > 0 {
+			txn.Kind, txn.RecvAmount, txn.RecvCur = taxTxnFundingIn, amt, "USD"
+		} else {
+			txn.Kind, txn.SentAmount, txn.SentCur = taxTxnFundingOut, -amt, "USD"
+		}
+		return txn, true, nil
+
+	case "spot", "assignment":
+		side, err := tradingViewSide(sc, t)
+		if err != nil {
+			return txn, false, err
+		}
+		if t.Quantity <= 0 || t.Price <= 0 {
+			return txn, false, fmt.Errorf("strategy %s trade %s at %s has non-positive quantity/price", t.StrategyID, tradingViewTradeRef(t), formatTime(t.Timestamp))
+		}
+		base, quote, ok := splitCryptoPair(t.Symbol)
+		if !ok {
+			base, quote = normalizeTradingViewTicker(t.Symbol), "USD"
+		}
+		notional := t.Quantity * t.Price
+		txn.Kind = taxTxnTrade
+		if side == "buy" {
+			txn.SentAmount, txn.SentCur, txn.RecvAmount, txn.RecvCur = notional, quote, t.Quantity, base
+		} else {
+			txn.SentAmount, txn.SentCur, txn.RecvAmount, txn.RecvCur = t.Quantity, base, notional, quote
+		}
+		txn.FeeAmount, txn.FeeCur = t.ExchangeFee, quote
+		return txn, true, nil
+	}
+
+	// Cash-settled derivative (perps, futures, options).
+	if !t.IsClose {
+		if t.ExchangeFee <= 0 {
+			return txn, false, nil
+		}
+		txn.Kind, txn.SentAmount, txn.SentCur = taxTxnFee, t.ExchangeFee, "USD"
+		return txn, true, nil
+	}
+	gross := tradeNetPnL(t) + t.ExchangeFee
+	if gross >= 0 {
+		txn.Kind, txn.RecvAmount, txn.RecvCur = taxTxnRealizedGain, gross, "USD"
+	} else {
+		txn.Kind, txn.SentAmount, txn.SentCur = taxTxnRealizedLoss, math.Abs(gross), "USD"
+	}
+	txn.FeeAmount, txn.FeeCur = t.ExchangeFee, "USD"
+	return txn, true, nil
+}
+
+func writeTaxCSV(w io.Writer, p taxExportPreset, txns []taxTxn) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(p.Header); err != nil {
+		return fmt.Errorf("write CSV header: %w", err)
+	}
+	for _, t := range txns {
+		if err := cw.Write(p.Row(t)); err != nil {
+			return fmt.Errorf("write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush CSV: %w", err)
+	}
+	return nil
+}
+
+// QueryTaxExportTrades returns every ledger row for strategyIDs, oldest
+// first, with the PnL-convention columns the tax presets need.
+func (sdb *StateDB) QueryTaxExportTrades(strategyIDs []string) ([]Trade, error) {
+	if sdb == nil || sdb.db == nil {
+		return nil, fmt.Errorf("state db unavailable")
+	}
+	if len(strategyIDs) == 0 {
+		return nil, fmt.Errorf("at least one strategy id is required")
+	}
+	placeholders := make([]string, len(strategyIDs))
+	args := make([]interface{}, 0, len(strategyIDs))
+	for i, id := range strategyIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	query := fmt.Sprintf(`SELECT timestamp, strategy_id, symbol, side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, pnl_gross
+		FROM trades
+		WHERE strategy_id IN (%s)
+		ORDER BY timestamp ASC, strategy_id ASC, symbol ASC, rowid ASC`, strings.Join(placeholders, ","))
+	rows, err := sdb.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tax export trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []Trade
+	for rows.Next() {
+		var t Trade
+		var tsStr string
+		var isClose, pnlGross int
+		if err := rows.Scan(&tsStr, &t.StrategyID, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &t.Value, &t.TradeType, &t.Details, &t.ExchangeOrderID, &t.ExchangeFee, &isClose, &t.RealizedPnL, &pnlGross); err != nil {
+			return nil, fmt.Errorf("scan tax export trade: %w", err)
+		}
+		t.Timestamp = parseTime(tsStr)
+		t.IsClose = isClose != 0
+		t.PnLGross = pnlGross != 0
+		trades = append(trades, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tax export trades: %w", err)
+	}
+	return trades, nil
+}
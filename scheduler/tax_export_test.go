@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaxTxnsMapSpotDerivativesOptionsAndFunding(t *testing.T) {
+	ts := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+	strategies := []StrategyConfig{
+		{ID: "okx-btc", Platform: "okx", Type: "spot"},
+		{ID: "hl-eth", Platform: "hyperliquid", Type: "perps"},
+		{ID: "deribit-opt", Platform: "deribit", Type: "options"},
+	}
+	trades := []Trade{
+		{Timestamp: ts, StrategyID: "okx-btc", Symbol: "BTC/USDT", Side: "buy", Quantity: 0.5, Price: 60000, TradeType: "spot", ExchangeFee: 3},
+		{Timestamp: ts, StrategyID: "okx-btc", Symbol: "BTC/USDT", Side: "sell", Quantity: 0.5, Price: 62000, TradeType: "spot", IsClose: true},
+		// Perps open with a fee, then a gross-convention losing close.
+		{Timestamp: ts, StrategyID: "hl-eth", Symbol: "ETH", Side: "buy", Quantity: 1, Price: 3000, TradeType: "perps", ExchangeFee: 1.2, PnLGross: true},
+		{Timestamp: ts, StrategyID: "hl-eth", Symbol: "ETH", Side: "sell", Quantity: 1, Price: 2950, TradeType: "perps", ExchangeFee: 1.1, IsClose: true, RealizedPnL: -50, PnLGross: true},
+		{Timestamp: ts, StrategyID: "hl-eth", Symbol: "ETH", Side: "funding", TradeType: TradeTypeFunding, RealizedPnL: -0.4, PnLGross: true},
+		// Option: fee-free open is not taxable; the close carries the premium PnL.
+		{Timestamp: ts, StrategyID: "deribit-opt", Symbol: "BTC-call-70000-2026-03-27", Side: "sell", Quantity: 1, Price: 500, TradeType: "options"},
+		{Timestamp: ts, StrategyID: "deribit-opt", Symbol: "BTC-call-sell-70000-2026-03-27", Side: "buy", Quantity: 1, Price: 120, TradeType: "options", IsClose: true, RealizedPnL: 380, PnLGross: true},
+		{Timestamp: ts, StrategyID: "other", Symbol: "SOL", Side: "buy", Quantity: 1, Price: 1},
+	}
+	txns, err := buildTaxTxns(strategies, trades)
+	if err != nil {
+		t.Fatalf("buildTaxTxns: %v", err)
+	}
+	if len(txns) != 6 {
+		t.Fatalf("got %d txns, want 6: %+v", len(txns), txns)
+	}
+
+	var buf bytes.Buffer
+	if err := writeTaxCSV(&buf, taxExportPresets["koinly"], txns); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash",
+		"2026-02-03 04:05:06 UTC,30000,USDT,0.5,BTC,3,USDT,,,,okx-btc BTC/USDT,",
+		"2026-02-03 04:05:06 UTC,0.5,BTC,31000,USDT,,,,,,okx-btc BTC/USDT,",
+		"2026-02-03 04:05:06 UTC,1.2,USD,,,,,,,cost,hl-eth ETH,",
+		"2026-02-03 04:05:06 UTC,50,USD,,,1.1,USD,,,realized gain,hl-eth ETH,",
+		"2026-02-03 04:05:06 UTC,0.4,USD,,,,,,,margin fee,hl-eth ETH,",
+		"2026-02-03 04:05:06 UTC,,,380,USD,,,,,realized gain,deribit-opt BTC-call-sell-70000-2026-03-27,",
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(want, "\n") {
+		t.Errorf("koinly CSV:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+
+	buf.Reset()
+	if err := writeTaxCSV(&buf, taxExportPresets["cointracker"], txns[:4]); err != nil {
+		t.Fatal(err)
+	}
+	wantCT := []string{
+		"Date,Received Quantity,Received Currency,Sent Quantity,Sent Currency,Fee Amount,Fee Currency,Tag",
+		"02/03/2026 04:05:06,0.5,BTC,30000,USDT,3,USDT,",
+		"02/03/2026 04:05:06,31000,USDT,0.5,BTC,,,",
+		"02/03/2026 04:05:06,,,1.2,USD,,,lost",
+		"02/03/2026 04:05:06,,,50,USD,1.1,USD,lost",
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(wantCT, "\n") {
+		t.Errorf("cointracker CSV:\n%s\nwant:\n%s", got, strings.Join(wantCT, "\n"))
+	}
+}
+
+func TestTaxTxnLegacyNetCloseKeepsNetTotal(t *testing.T) {
+	// Legacy row: realized_pnl is already net of the stamped fee.
+	sc := StrategyConfig{ID: "hl-btc", Type: "perps"}
+	txn, ok, err := taxTxnForTrade(sc, Trade{StrategyID: "hl-btc", Symbol: "BTC", Side: "sell", IsClose: true, RealizedPnL: 98, ExchangeFee: 2, TradeType: "perps"})
+	if err != nil || !ok {
+		t.Fatalf("ok=%v err=%v", ok, err)
+	}
+	if txn.RecvAmount-txn.FeeAmount != 98 || txn.Kind != taxTxnRealizedGain {
+		t.Errorf("txn = %+v, want gross 100 with fee 2", txn)
+	}
+}
+
+func TestTaxExportYearFilterAndOutput(t *testing.T) {
+	sdb := openTestDB(t)
+	cfg := &Config{Strategies: []StrategyConfig{{ID: "hl-btc", Platform: "hyperliquid", Type: "perps"}}}
+	for _, y := range []int{2025, 2026} {
+		if err := sdb.InsertTrade("hl-btc", Trade{Timestamp: time.Date(y, 6, 1, 0, 0, 0, 0, time.UTC), StrategyID: "hl-btc", Symbol: "BTC", Side: "sell", Quantity: 1, Price: 1, TradeType: "perps", IsClose: true, RealizedPnL: float64(y - 2020), PnLGross: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := t.TempDir() + "/koinly.csv"
+	n, err := exportTaxCSVFile(sdb, cfg, taxExportPresets["koinly"], taxExportOptions{
+		tradingViewExportOptions: tradingViewExportOptions{All: true, OutputPath: out},
+		Year:                     2026,
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("n=%d err=%v, want 1 row for 2026", n, err)
+	}
+	if _, err := exportTaxCSVFile(sdb, cfg, taxExportPresets["koinly"], taxExportOptions{
+		tradingViewExportOptions: tradingViewExportOptions{All: true, OutputPath: out},
+		Year:                     2024,
+	}); err == nil || !strings.Contains(err.Error(), "no taxable trade data") {
+		t.Errorf("empty year: err = %v", err)
+	}
+}
@@ -23,6 +23,7 @@ type TelegramNotifier struct {
 	lastUpdate  int64  // offset for getUpdates polling
 	mu          sync.Mutex
 	closed      bool
+	tokenMu     sync.RWMutex // guards botToken against RotateToken
 }
 
 // NewTelegramNotifier creates a new Telegram bot notifier.
@@ -85,7 +86,14 @@ type telegramCBData struct {
 
 // apiCall makes a POST request to the Telegram Bot API.
 func (t *TelegramNotifier) apiCall(method string, payload interface{}) (*telegramResponse, error) {
-	url := t.baseURL + t.botToken + "/" + method
+	t.tokenMu.RLock()
+	token := t.botToken
+	t.tokenMu.RUnlock()
+	return t.apiCallWithToken(token, method, payload)
+}
+
+func (t *TelegramNotifier) apiCallWithToken(token, method string, payload interface{}) (*telegramResponse, error) {
+	url := t.baseURL + token + "/" + method
 
 	var body io.Reader
 	if payload != nil {
@@ -107,7 +115,7 @@ func (t *TelegramNotifier) apiCall(method string, payload interface{}) (*telegra
 	resp, err := t.client.Do(req)
 	if err != nil {
 		// Redact bot token from error to prevent leaking in logs.
-		safeMsg := strings.ReplaceAll(err.Error(), t.botToken, "[REDACTED]")
+		safeMsg := strings.ReplaceAll(err.Error(), token, "[REDACTED]")
 		return nil, fmt.Errorf("telegram %s: %s", method, safeMsg)
 	}
 	defer resp.Body.Close()
@@ -227,6 +235,28 @@ func (t *TelegramNotifier) getUpdates(timeoutSec int) ([]telegramUpdate, error)
 	return updates, nil
 }
 
+// RotateToken swaps in a new bot token after verifying it with getMe, so a
+// bad token leaves the current one in use. The getUpdates offset resets:
+// update IDs are per-bot, and Telegram never re-delivers updates the old
+// offset already confirmed, so 0 is safe for the same bot and required for a
+// different one.
+func (t *TelegramNotifier) RotateToken(token string) error {
+	resp, err := t.apiCallWithToken(token, "getMe", nil)
+	if err != nil {
+		return fmt.Errorf("telegram getMe failed: %w", err)
+	}
+	if !resp.OK {
+		return fmt.Errorf("telegram getMe: %s", resp.Description)
+	}
+	t.tokenMu.Lock()
+	t.botToken = token
+	t.tokenMu.Unlock()
+	t.mu.Lock()
+	t.lastUpdate = 0
+	t.mu.Unlock()
+	return nil
+}
+
 // Close marks the notifier as closed and stops any pending polling.
 func (t *TelegramNotifier) Close() {
 	t.mu.Lock()
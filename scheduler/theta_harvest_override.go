@@ -0,0 +1,313 @@
+package main
+
+// theta_harvest_override: per-position theta harvest thresholds (#4970).
+//
+// theta_harvest is configured per strategy, but operators sometimes want one
+// leg handled differently ("let this one ride to 80%", "don't stop this one
+// out"). A ThetaHarvestOverride on the OptionPosition replaces individual
+// fields of the strategy's block for that position only; unset fields fall
+// through to the config. The override is persisted with the position
+// (option_positions.harvest_override_json) and disappears when it closes.
+//
+// Two ways to set one:
+//   - /go-trader-harvest <strategy> <position> <field> <value> (owner DM)
+//     applies in-process under mu and saves immediately, like /go-trader-mute.
+//   - `go-trader harvest-override` queues a "harvest-override" row in
+//     pending_manual_actions; the scheduler applies it at the top of the next
+//     cycle (the CLI never writes strategy state directly).
+//
+// Field "clear" removes the override.
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ThetaHarvestOverride holds per-position replacements for ThetaHarvestConfig
+// fields. Nil fields inherit the strategy's value.
+type ThetaHarvestOverride struct {
+	Enabled            *bool    `json:"enabled,omitempty"`
+	ProfitTargetPct    *float64 `json:"profit_target_pct,omitempty"`
+	StopLossPct        *float64 `json:"stop_loss_pct,omitempty"`
+	MinDTEClose        *float64 `json:"min_dte_close,omitempty"`
+	TrailingTriggerPct *float64 `json:"trailing_trigger_pct,omitempty"`
+	TrailingRetracePct *float64 `json:"trailing_retrace_pct,omitempty"`
+}
+
+// thetaHarvestOverrideFields are the fields an override accepts, in help
+// order. "clear" (not listed) removes the override.
+var thetaHarvestOverrideFields = []string{
+	"enabled",
+	"profit_target_pct",
+	"stop_loss_pct",
+	"min_dte_close",
+	"trailing_trigger_pct",
+	"trailing_retrace_pct",
+}
+
+// effectiveThetaHarvest returns base with o's fields applied. base may be nil
+// (no strategy block), in which case unset fields are zero.
+func effectiveThetaHarvest(base *ThetaHarvestConfig, o *ThetaHarvestOverride) *ThetaHarvestConfig {
+	if o == nil {
+		return base
+	}
+	var c ThetaHarvestConfig
+	if base != nil {
+		c = *base
+	}
+	if o.Enabled != nil {
+		c.Enabled = *o.Enabled
+	}
+	if o.ProfitTargetPct != nil {
+		c.ProfitTargetPct = *o.ProfitTargetPct
+	}
+	if o.StopLossPct != nil {
+		c.StopLossPct = *o.StopLossPct
+	}
+	if o.MinDTEClose != nil {
+		c.MinDTEClose = *o.MinDTEClose
+	}
+	if o.TrailingTriggerPct != nil {
+		c.TrailingTriggerPct = *o.TrailingTriggerPct
+	}
+	if o.TrailingRetracePct != nil {
+		c.TrailingRetracePct = *o.TrailingRetracePct
+	}
+	return &c
+}
+
+// withThetaHarvestOverride returns a copy of o with field set to the parsed
+// value. Field "clear" returns nil.
+func withThetaHarvestOverride(o *ThetaHarvestOverride, field, value string) (*ThetaHarvestOverride, error) {
+	field = strings.TrimSpace(field)
+	v := strings.TrimSpace(value)
+	if field == "clear" {
+		return nil, nil
+	}
+	var next ThetaHarvestOverride
+	if o != nil {
+		next = *o
+	}
+	if field == "enabled" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("enabled must be true or false")
+		}
+		next.Enabled = &b
+		return &next, nil
+	}
+	var dst **float64
+	switch field {
+	case "profit_target_pct":
+		dst = &next.ProfitTargetPct
+	case "stop_loss_pct":
+		dst = &next.StopLossPct
+	case "min_dte_close":
+		dst = &next.MinDTEClose
+	case "trailing_trigger_pct":
+		dst = &next.TrailingTriggerPct
+	case "trailing_retrace_pct":
+		dst = &next.TrailingRetracePct
+	default:
+		return nil, fmt.Errorf("unsupported field %q (supported: %s, clear)", field, strings.Join(thetaHarvestOverrideFields, ", "))
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number, got %q", field, v)
+	}
+	if f < 0 {
+		return nil, fmt.Errorf("%s must be >= 0, got %g", field, f)
+	}
+	if field == "trailing_trigger_pct" && f > 100 {
+		return nil, fmt.Errorf("trailing_trigger_pct must be <= 100, got %g", f)
+	}
+	*dst = &f
+	return &next, nil
+}
+
+// formatThetaHarvestOverride renders o as "field=value ..." in help order.
+func formatThetaHarvestOverride(o *ThetaHarvestOverride) string {
+	if o == nil {
+		return "none"
+	}
+	var parts []string
+	if o.Enabled != nil {
+		parts = append(parts, fmt.Sprintf("enabled=%t", *o.Enabled))
+	}
+	for _, f := range []struct {
+		name string
+		v    *float64
+	}{
+		{"profit_target_pct", o.ProfitTargetPct},
+		{"stop_loss_pct", o.StopLossPct},
+		{"min_dte_close", o.MinDTEClose},
+		{"trailing_trigger_pct", o.TrailingTriggerPct},
+		{"trailing_retrace_pct", o.TrailingRetracePct},
+	} {
+		if f.v != nil {
+			parts = append(parts, fmt.Sprintf("%s=%g", f.name, *f.v))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+// setOptionHarvestOverride applies field=value to the override of s's option
+// position positionID and returns the resulting override rendered for a
+// reply. Only sold legs are harvested. Caller holds mu.
+func setOptionHarvestOverride(s *StrategyState, positionID, field, value string) (string, error) {
+	pos := s.OptionPositions[positionID]
+	if pos == nil {
+		return "", fmt.Errorf("%s has no open option position %q", s.ID, positionID)
+	}
+	if pos.Action != "sell" {
+		return "", fmt.Errorf("%s is a bought leg; theta harvest only manages sold options", positionID)
+	}
+	next, err := withThetaHarvestOverride(pos.HarvestOverride, field, value)
+	if err != nil {
+		return "", err
+	}
+	pos.HarvestOverride = next
+	return formatThetaHarvestOverride(next), nil
+}
+
+func marshalHarvestOverrideJSON(o *ThetaHarvestOverride) string {
+	if o == nil {
+		return ""
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func parseHarvestOverrideJSON(s string) *ThetaHarvestOverride {
+	if s == "" {
+		return nil
+	}
+	var o ThetaHarvestOverride
+	if err := json.Unmarshal([]byte(s), &o); err != nil {
+		return nil
+	}
+	return &o
+}
+
+// handleHarvestOverride serves /go-trader-harvest. The change is saved
+// immediately so a restart before the next cycle keeps it.
+func (d *DiscordNotifier) handleHarvestOverride(s *discordgo.Session, i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	id := optionString(opts, "strategy", "")
+	positionID := optionString(opts, "position", "")
+	field := optionString(opts, "field", "")
+	if id == "" || positionID == "" || field == "" {
+		respondText(s, i, "usage: /go-trader-harvest <strategy> <position> <field> <value> — fields: "+strings.Join(thetaHarvestOverrideFields, ", ")+", clear")
+		return
+	}
+	if d.ss == nil || d.ss.state == nil || d.ss.mu == nil {
+		respondText(s, i, "status server not ready")
+		return
+	}
+
+	d.ss.mu.Lock()
+	var summary string
+	var err, saveErr error
+	if ss := d.ss.state.Strategies[id]; ss == nil {
+		err = fmt.Errorf("unknown strategy %q", id)
+	} else {
+		summary, err = setOptionHarvestOverride(ss, positionID, field, optionString(opts, "value", ""))
+	}
+	if err == nil && d.ss.stateDB != nil {
+		saveErr = SaveStateWithDB(d.ss.state, d.cfg, d.ss.stateDB)
+	}
+	d.ss.mu.Unlock()
+	d.ss.snapshot.expire()
+
+	if err != nil {
+		respondText(s, i, "harvest override failed: "+err.Error())
+		return
+	}
+	msg := fmt.Sprintf("🎛️ `%s` %s theta harvest override: %s", id, positionID, summary)
+	if saveErr != nil {
+		msg += " WARNING: SaveState failed (" + saveErr.Error() + "); the change may not survive a restart before the next successful save."
+	}
+	fmt.Printf("[discord] /harvest %s %s %s: %s\n", id, positionID, field, summary)
+	respondText(s, i, msg)
+}
+
+// runHarvestOverride implements `go-trader harvest-override <strategy-id>
+// <position-id> <field> <value>`. It validates the request and queues a
+// harvest-override action the scheduler applies on its next cycle.
+func runHarvestOverride(args []string) int {
+	fs := flag.NewFlagSet("harvest-override", flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+
+	args = reorderArgsForPositional(args, collectBoolFlagNames(fs))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	usage := "Usage: go-trader harvest-override <strategy-id> <position-id> <field> <value> (fields: " + strings.Join(thetaHarvestOverrideFields, ", ") + "; or <field> clear)"
+	if fs.NArg() < 3 || fs.NArg() > 4 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	strategyID, positionID, field := fs.Arg(0), fs.Arg(1), fs.Arg(2)
+	value := fs.Arg(3)
+	if field != "clear" && fs.NArg() != 4 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	if _, err := withThetaHarvestOverride(nil, field, value); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	var sc *StrategyConfig
+	for idx := range cfg.Strategies {
+		if cfg.Strategies[idx].ID == strategyID {
+			sc = &cfg.Strategies[idx]
+			break
+		}
+	}
+	if sc == nil {
+		fmt.Fprintf(os.Stderr, "Strategy %q not found in config\n", strategyID)
+		return 1
+	}
+	if sc.Type != "options" {
+		fmt.Fprintf(os.Stderr, "Strategy %q is type %q; theta harvest overrides apply to options strategies only\n", strategyID, sc.Type)
+		return 1
+	}
+	stateDB, err := OpenStateDB(cfg.DBFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+		return 1
+	}
+	defer stateDB.Close()
+
+	if err := stateDB.InsertPendingManualAction(PendingManualAction{
+		StrategyID:    strategyID,
+		Action:        "harvest-override",
+		Symbol:        positionID,
+		OverrideField: field,
+		OverrideValue: value,
+		CreatedAt:     time.Now().UTC(),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to queue harvest override: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Queued theta harvest override for %s %s: %s %s — applied on the scheduler's next cycle.\n", strategyID, positionID, field, value)
+	return 0
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithThetaHarvestOverride(t *testing.T) {
+	o, err := withThetaHarvestOverride(nil, "profit_target_pct", "80")
+	if err != nil || o.ProfitTargetPct == nil || *o.ProfitTargetPct != 80 {
+		t.Fatalf("o=%+v err=%v", o, err)
+	}
+	o, err = withThetaHarvestOverride(o, "enabled", "false")
+	if err != nil || o.Enabled == nil || *o.Enabled || *o.ProfitTargetPct != 80 {
+		t.Fatalf("second field must keep the first: o=%+v err=%v", o, err)
+	}
+	if got := formatThetaHarvestOverride(o); got != "enabled=false profit_target_pct=80" {
+		t.Errorf("format = %q", got)
+	}
+	for _, bad := range [][2]string{{"profit_target_pct", "-1"}, {"trailing_trigger_pct", "120"}, {"enabled", "maybe"}, {"capital", "5"}} {
+		if _, err := withThetaHarvestOverride(nil, bad[0], bad[1]); err == nil {
+			t.Errorf("%s=%s accepted", bad[0], bad[1])
+		}
+	}
+	if o, err := withThetaHarvestOverride(o, "clear", ""); err != nil || o != nil {
+		t.Errorf("clear: o=%+v err=%v", o, err)
+	}
+}
+
+func TestCheckThetaHarvestPositionOverride(t *testing.T) {
+	newState := func() *StrategyState {
+		return &StrategyState{
+			ID:   "test",
+			Cash: 5000,
+			OptionPositions: map[string]*OptionPosition{
+				// 65% of premium captured
+				"ride": {ID: "ride", Action: "sell", EntryPremiumUSD: 100, CurrentValueUSD: -35, Quantity: 1},
+				"take": {ID: "take", Action: "sell", EntryPremiumUSD: 100, CurrentValueUSD: -35, Quantity: 1},
+			},
+			Positions: make(map[string]*Position),
+		}
+	}
+	logger := silentStrategyLogger("test")
+	cfg := &ThetaHarvestConfig{Enabled: true, ProfitTargetPct: 60}
+
+	s := newState()
+	if _, err := setOptionHarvestOverride(s, "ride", "profit_target_pct", "80"); err != nil {
+		t.Fatal(err)
+	}
+	trades, _ := CheckThetaHarvest(s, cfg, logger)
+	if trades != 1 || s.OptionPositions["ride"] == nil || s.OptionPositions["take"] != nil {
+		t.Fatalf("trades=%d open=%v, want only the un-overridden leg closed", trades, s.OptionPositions)
+	}
+
+	// An override can enable harvesting for one leg of a strategy without a block.
+	s = newState()
+	if _, err := setOptionHarvestOverride(s, "take", "enabled", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := setOptionHarvestOverride(s, "take", "profit_target_pct", "50"); err != nil {
+		t.Fatal(err)
+	}
+	if trades, _ := CheckThetaHarvest(s, nil, logger); trades != 1 || s.OptionPositions["take"] != nil {
+		t.Fatalf("trades=%d, want the enabled leg closed", trades)
+	}
+
+	if _, err := setOptionHarvestOverride(s, "missing", "enabled", "true"); err == nil {
+		t.Error("unknown position accepted")
+	}
+	s.OptionPositions["long"] = &OptionPosition{ID: "long", Action: "buy"}
+	if _, err := setOptionHarvestOverride(s, "long", "enabled", "true"); err == nil || !strings.Contains(err.Error(), "bought leg") {
+		t.Errorf("bought leg accepted: %v", err)
+	}
+}
+
+func TestHarvestOverrideQueuedAndPersisted(t *testing.T) {
+	db, err := OpenStateDB(":memory:")
+	if err != nil {
+		t.Fatalf("open state db: %v", err)
+	}
+	defer db.Close()
+
+	stratID := "deribit-wheel-btc"
+	state := &AppState{
+		Strategies: map[string]*StrategyState{
+			stratID: {
+				ID: stratID, Type: "options", Platform: "deribit", Cash: 10000,
+				Positions: map[string]*Position{},
+				OptionPositions: map[string]*OptionPosition{
+					"BTC-put-55000": {ID: "BTC-put-55000", Underlying: "BTC", OptionType: "put", Strike: 55000, Action: "sell", Quantity: 1, EntryPremiumUSD: 100},
+				},
+			},
+		},
+	}
+	cfg := &Config{Strategies: []StrategyConfig{{ID: stratID, Type: "options", Platform: "deribit"}}}
+
+	if err := db.InsertPendingManualAction(PendingManualAction{
+		StrategyID: stratID, Action: "harvest-override", Symbol: "BTC-put-55000",
+		OverrideField: "stop_loss_pct", OverrideValue: "300", CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if alerts := drainPendingManualActions(state, cfg, db); len(alerts) != 0 {
+		t.Errorf("override recorded %d trade alerts", len(alerts))
+	}
+	pos := state.Strategies[stratID].OptionPositions["BTC-put-55000"]
+	if pos.HarvestOverride == nil || pos.HarvestOverride.StopLossPct == nil || *pos.HarvestOverride.StopLossPct != 300 {
+		t.Fatalf("override not applied: %+v", pos.HarvestOverride)
+	}
+	if remaining, _ := db.LoadPendingManualActions(); len(remaining) != 0 {
+		t.Errorf("queue not drained: %d rows", len(remaining))
+	}
+
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	got := loaded.Strategies[stratID].OptionPositions["BTC-put-55000"].HarvestOverride
+	if formatThetaHarvestOverride(got) != "stop_loss_pct=300" {
+		t.Errorf("reloaded override = %s", formatThetaHarvestOverride(got))
+	}
+}
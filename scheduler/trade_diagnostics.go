@@ -86,7 +86,7 @@ type TradeDiagnosticsRow struct {
 // local SQLite write (same cost class as the InsertTrade that already runs on
 // every close path); the OHLCV fetch never happens here.
 func captureTradeDiagnostics(s *StrategyState, pos *Position, closePrice, realizedPnL float64, reason string, closedAt time.Time) {
-	if tradeDiagnosticsRecorder == nil || s == nil || pos == nil {
+	if tradeDiagnosticsRecorder == nil || s == nil || pos == nil || s.shadow {
 		return
 	}
 	row := TradeDiagnosticsRow{
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// #4952: indicator snapshot on trades. Check scripts return an Indicators map
+// with each signal; the execute* dispatchers stage a numeric copy on the
+// StrategyState before booking, RecordTrade stamps it onto every trade the
+// signal produces, and it is persisted as trades.indicators_json so entries
+// can be audited later. The trade detail line carries a compact rendering.
+
+// tradeIndicatorDetailMax caps how many indicators the detail line shows; the
+// full snapshot is still persisted.
+const tradeIndicatorDetailMax = 6
+
+// tradeIndicatorSnapshot keeps the finite numeric scalars from a check
+// script's Indicators map. Strings, nested objects and NaN/Inf are dropped.
+// Returns nil when nothing numeric remains.
+func tradeIndicatorSnapshot(indicators map[string]interface{}) map[string]float64 {
+	var out map[string]float64
+	for k := range indicators {
+		v, ok := indicatorFloat(indicators, k)
+		if !ok || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]float64, len(indicators))
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// stageTradeIndicators sets the snapshot RecordTrade stamps onto s's next
+// trades and returns a func that clears it. Dispatchers defer the clear so a
+// snapshot never leaks into a later, unrelated booking (e.g. a stop-loss
+// fill).
+func stageTradeIndicators(s *StrategyState, indicators map[string]interface{}) func() {
+	s.pendingIndicators = tradeIndicatorSnapshot(indicators)
+	return func() { s.pendingIndicators = nil }
+}
+
+// formatTradeIndicators renders a snapshot as " | k=v k=v", keys sorted, at
+// most tradeIndicatorDetailMax entries. Empty string when there is nothing to
+// show.
+func formatTradeIndicators(indicators map[string]float64) string {
+	if len(indicators) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(indicators))
+	for k := range indicators {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	more := 0
+	if len(keys) > tradeIndicatorDetailMax {
+		more = len(keys) - tradeIndicatorDetailMax
+		keys = keys[:tradeIndicatorDetailMax]
+	}
+	parts := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%.4g", k, indicators[k]))
+	}
+	if more > 0 {
+		parts = append(parts, fmt.Sprintf("+%d", more))
+	}
+	return " | " + strings.Join(parts, " ")
+}
+
+func marshalIndicatorsJSON(indicators map[string]float64) string {
+	if len(indicators) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(indicators)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+func parseIndicatorsJSON(raw string) map[string]float64 {
+	if raw == "" {
+		return nil
+	}
+	var out map[string]float64
+	if err := json.Unmarshal([]byte(raw), &out); err != nil || len(out) == 0 {
+		return nil
+	}
+	return out
+}
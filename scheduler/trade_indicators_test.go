@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTradeIndicatorSnapshot_KeepsFiniteNumbers(t *testing.T) {
+	got := tradeIndicatorSnapshot(map[string]interface{}{
+		"rsi":    28.4,
+		"atr":    json.Number("512"),
+		"period": 14,
+		"trend":  "up",
+		"bands":  map[string]interface{}{"upper": 1.0},
+		"nan":    math.NaN(),
+	})
+	if len(got) != 3 || got["rsi"] != 28.4 || got["atr"] != 512 || got["period"] != 14 {
+		t.Errorf("snapshot = %v, want rsi/atr/period only", got)
+	}
+	if tradeIndicatorSnapshot(map[string]interface{}{"trend": "up"}) != nil {
+		t.Error("no numeric indicators should yield nil")
+	}
+}
+
+func TestFormatTradeIndicators(t *testing.T) {
+	if got := formatTradeIndicators(nil); got != "" {
+		t.Errorf("nil = %q, want empty", got)
+	}
+	if got := formatTradeIndicators(map[string]float64{"rsi": 28.4321, "atr": 512.5}); got != " | atr=512.5 rsi=28.43" {
+		t.Errorf("got %q", got)
+	}
+	many := map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8}
+	if got := formatTradeIndicators(many); got != " | a=1 b=2 c=3 d=4 e=5 f=6 +2" {
+		t.Errorf("capped = %q", got)
+	}
+}
+
+func TestRecordTrade_StampsStagedIndicators(t *testing.T) {
+	s := &StrategyState{ID: "hl-a-btc", Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	unstage := stageTradeIndicators(s, map[string]interface{}{"rsi": 28.4})
+	RecordTrade(s, Trade{Symbol: "BTC", Side: "buy", Quantity: 1, Price: 50000})
+	RecordTrade(s, Trade{Symbol: "BTC", Side: "sell", Quantity: 1, Price: 50000, Indicators: map[string]float64{"own": 1}})
+	unstage()
+	RecordTrade(s, Trade{Symbol: "BTC", Side: "sell", Quantity: 1, Price: 49000})
+
+	if got := s.TradeHistory[0].Indicators["rsi"]; got != 28.4 {
+		t.Errorf("staged snapshot not stamped: %v", s.TradeHistory[0].Indicators)
+	}
+	if _, ok := s.TradeHistory[1].Indicators["rsi"]; ok {
+		t.Error("a trade's own Indicators must win over the staged snapshot")
+	}
+	if s.TradeHistory[2].Indicators != nil {
+		t.Errorf("cleared snapshot leaked: %v", s.TradeHistory[2].Indicators)
+	}
+}
+
+func TestTradeIndicators_PersistAcrossReload(t *testing.T) {
+	db := openTestDB(t)
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &AppState{
+		CycleCount: 1,
+		Strategies: map[string]*StrategyState{
+			"hl-a-btc": {ID: "hl-a-btc", Type: "perps", Cash: 1000, InitialCapital: 1000,
+				Positions: make(map[string]*Position), OptionPositions: make(map[string]*OptionPosition),
+				TradeHistory: []Trade{{Timestamp: ts, StrategyID: "hl-a-btc", Symbol: "BTC", Side: "buy", Quantity: 0.01, Price: 50000, Value: 500,
+					TradeType: "perps", Indicators: map[string]float64{"rsi": 28.4, "atr": 512}}}},
+		},
+	}
+	if err := db.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := db.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	trades := loaded.Strategies["hl-a-btc"].TradeHistory
+	if len(trades) != 1 || trades[0].Indicators["rsi"] != 28.4 || trades[0].Indicators["atr"] != 512 {
+		t.Errorf("indicators after reload = %+v", trades)
+	}
+}
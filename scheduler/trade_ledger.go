@@ -0,0 +1,469 @@
+package main
+
+// trade_ledger: standalone, queryable trade ledger (#4938).
+//
+// The in-state TradeHistory is capped at maxTradeHistory per strategy, and the
+// state DB's trades table is shaped for the daemon (text timestamps, two PnL
+// conventions, rows rewritten by backfills). The ledger is a separate SQLite
+// file — <db_file>.ledger.db unless trade_ledger.path is set — that every
+// RecordTrade also appends to, with one flat row per trade: integer
+// timestamps, UTC month, and the trade's net PnL and ledger delta already
+// resolved through tradeNetPnL / tradeLedgerDelta, indexed on strategy,
+// symbol and time. It is never pruned.
+//
+// Rows are keyed by (timestamp, strategy, symbol, side, qty, price, position),
+// so the startup sync from the state DB — which picks up history from before
+// the ledger existed, rows written outside RecordTrade, and fee/PnL fixes
+// from `backfill trade-ledger` — can overlap the live stream without
+// duplicating. Appends are best-effort: a ledger failure is logged and never
+// blocks trading.
+//
+// The file is plain SQLite even when GO_TRADER_STATE_KEY encrypts the state
+// DB, so with the key set the ledger stays off unless
+// trade_ledger.allow_plaintext opts in. Query it with `go-trader ledger pnl`
+// or any SQLite client.
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// TradeLedgerConfig is the top-level "trade_ledger" block.
+type TradeLedgerConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path,omitempty"` // default <db_file>.ledger.db
+	// AllowPlaintext keeps the (unencrypted) ledger on while
+	// GO_TRADER_STATE_KEY encrypts the state DB.
+	AllowPlaintext bool `json:"allow_plaintext,omitempty"`
+}
+
+const tradeLedgerDDL = `
+CREATE TABLE IF NOT EXISTS ledger_trades (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_key TEXT NOT NULL UNIQUE,
+    ts_ms INTEGER NOT NULL,
+    timestamp TEXT NOT NULL,
+    month TEXT NOT NULL,
+    strategy_id TEXT NOT NULL,
+    symbol TEXT NOT NULL,
+    position_id TEXT NOT NULL DEFAULT '',
+    side TEXT NOT NULL,
+    trade_type TEXT NOT NULL DEFAULT '',
+    quantity REAL NOT NULL,
+    price REAL NOT NULL,
+    value REAL NOT NULL,
+    exchange_fee REAL NOT NULL DEFAULT 0,
+    is_close INTEGER NOT NULL DEFAULT 0,
+    net_pnl REAL NOT NULL DEFAULT 0,
+    ledger_delta REAL NOT NULL DEFAULT 0,
+    exchange_order_id TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    tags_json TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_strategy_ts ON ledger_trades(strategy_id, ts_ms);
+CREATE INDEX IF NOT EXISTS idx_ledger_symbol_ts ON ledger_trades(symbol, ts_ms);
+CREATE INDEX IF NOT EXISTS idx_ledger_ts ON ledger_trades(ts_ms);
+CREATE INDEX IF NOT EXISTS idx_ledger_month ON ledger_trades(month);
+`
+
+// TradeLedger is the standalone ledger database.
+type TradeLedger struct {
+	mu   sync.Mutex
+	db   *sql.DB
+	path string
+}
+
+// tradeLedger is the package-level ledger, wired in main when trade_ledger is
+// enabled. nil disables streaming (tests, CLI subcommands).
+var tradeLedger *TradeLedger
+
+// tradeLedgerPath resolves the ledger file for cfg ("" when the state DB has
+// no file to sit beside and no path is configured).
+func tradeLedgerPath(cfg *Config) string {
+	if cfg.TradeLedger != nil && strings.TrimSpace(cfg.TradeLedger.Path) != "" {
+		return strings.TrimSpace(cfg.TradeLedger.Path)
+	}
+	if cfg.DBFile == "" || cfg.DBFile == ":memory:" {
+		return ""
+	}
+	return cfg.DBFile + ".ledger.db"
+}
+
+// tradeLedgerErrors validates the trade_ledger block.
+func tradeLedgerErrors(c *TradeLedgerConfig, dbFile string) []string {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	path := strings.TrimSpace(c.Path)
+	if path == "" && (dbFile == "" || dbFile == ":memory:") {
+		return []string{"trade_ledger.path is required when db_file is not a file"}
+	}
+	if path != "" && filepath.Clean(path) == filepath.Clean(dbFile) {
+		return []string{"trade_ledger.path must differ from db_file"}
+	}
+	return nil
+}
+
+// tradeLedgerPlaintextErr refuses the ledger when the state DB is encrypted
+// and trade_ledger.allow_plaintext is not set: the ledger would hold the
+// trade history in cleartext next to the sealed state.
+func tradeLedgerPlaintextErr(c *TradeLedgerConfig) error {
+	if strings.TrimSpace(os.Getenv(stateKeyEnvVar)) == "" || (c != nil && c.AllowPlaintext) {
+		return nil
+	}
+	return fmt.Errorf("%s encrypts the state DB but the trade ledger is plain SQLite; set trade_ledger.allow_plaintext to keep it", stateKeyEnvVar)
+}
+
+// OpenTradeLedger opens (or creates) the ledger at path.
+func OpenTradeLedger(path string) (*TradeLedger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create ledger dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open ledger: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	for _, stmt := range []string{"PRAGMA journal_mode=WAL", "PRAGMA busy_timeout=5000", tradeLedgerDDL} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("init ledger: %w", err)
+		}
+	}
+	return &TradeLedger{db: db, path: path}, nil
+}
+
+// Close releases the ledger handle (nil-safe).
+func (l *TradeLedger) Close() error {
+	if l == nil || l.db == nil {
+		return nil
+	}
+	return l.db.Close()
+}
+
+// tradeLedgerKey identifies a trade across the live stream and the state-DB
+// sync.
+func tradeLedgerKey(strategyID string, t Trade) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%g|%g|%s", formatTime(t.Timestamp), strategyID, t.Symbol, t.Side, t.Quantity, t.Price, t.PositionID)
+}
+
+const tradeLedgerInsertSQL = `INSERT INTO ledger_trades
+	(trade_key, ts_ms, timestamp, month, strategy_id, symbol, position_id, side, trade_type, quantity, price, value, exchange_fee, is_close, net_pnl, ledger_delta, exchange_order_id, details, tags_json)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+// tradeLedgerUpsertSQL is the sync variant: the state DB is authoritative for
+// the derived columns a backfill may have corrected.
+const tradeLedgerUpsertSQL = tradeLedgerInsertSQL + `
+	ON CONFLICT(trade_key) DO UPDATE SET exchange_fee = excluded.exchange_fee, is_close = excluded.is_close,
+		net_pnl = excluded.net_pnl, ledger_delta = excluded.ledger_delta, tags_json = excluded.tags_json`
+
+func tradeLedgerArgs(strategyID string, t Trade) []any {
+	ts := t.Timestamp.UTC()
+	netPnL := 0.0
+	if t.IsClose || t.TradeType == TradeTypeFunding {
+		netPnL = tradeNetPnL(t)
+	}
+	return []any{
+		tradeLedgerKey(strategyID, t), ts.UnixMilli(), formatTime(ts), ts.Format("2006-01"),
+		strategyID, t.Symbol, t.PositionID, t.Side, t.TradeType, t.Quantity, t.Price, t.Value,
+		t.ExchangeFee, boolToInt(t.IsClose), netPnL, tradeLedgerDelta(t), t.ExchangeOrderID, t.Details, marshalStringMapJSON(t.Tags),
+	}
+}
+
+// Append records one trade (nil-safe). Duplicates are ignored.
+func (l *TradeLedger) Append(strategyID string, t Trade) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.db.Exec(tradeLedgerInsertSQL+" ON CONFLICT(trade_key) DO NOTHING", tradeLedgerArgs(strategyID, t)...); err != nil {
+		return fmt.Errorf("trade ledger append: %w", err)
+	}
+	return nil
+}
+
+// SyncFromStateDB upserts every state-DB trade row into the ledger in one
+// transaction, returning how many were new.
+func (l *TradeLedger) SyncFromStateDB(sdb *StateDB) (int, error) {
+	if sdb == nil || sdb.db == nil {
+		return 0, fmt.Errorf("state db unavailable")
+	}
+	rows, err := sdb.db.Query(`SELECT strategy_id, timestamp, symbol, COALESCE(position_id, ''), side, quantity, price, value, trade_type, details, exchange_order_id, exchange_fee, is_close, realized_pnl, COALESCE(pnl_gross, 0), COALESCE(tags_json, '')
+		FROM trades ORDER BY rowid`)
+	if err != nil {
+		return 0, fmt.Errorf("query state trades: %w", err)
+	}
+	type keyed struct {
+		id string
+		t  Trade
+	}
+	var all []keyed
+	for rows.Next() {
+		var k keyed
+		var ts, tagsJSON string
+		var isClose, pnlGross int
+		if err := rows.Scan(&k.id, &ts, &k.t.Symbol, &k.t.PositionID, &k.t.Side, &k.t.Quantity, &k.t.Price, &k.t.Value, &k.t.TradeType, &k.t.Details, &k.t.ExchangeOrderID, &k.t.ExchangeFee, &isClose, &k.t.RealizedPnL, &pnlGross, &tagsJSON); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan state trade: %w", err)
+		}
+		k.t.Timestamp = parseTime(ts)
+		k.t.IsClose = isClose != 0
+		k.t.PnLGross = pnlGross != 0
+		k.t.Tags = parseStringMapJSON(tagsJSON)
+		all = append(all, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate state trades: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tx, err := l.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var before int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM ledger_trades`).Scan(&before); err != nil {
+		return 0, err
+	}
+	stmt, err := tx.Prepare(tradeLedgerUpsertSQL)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, k := range all {
+		if _, err := stmt.Exec(tradeLedgerArgs(k.id, k.t)...); err != nil {
+			return 0, fmt.Errorf("trade ledger sync: %w", err)
+		}
+	}
+	var after int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM ledger_trades`).Scan(&after); err != nil {
+		return 0, err
+	}
+	return after - before, tx.Commit()
+}
+
+// LedgerFilter narrows an aggregate query. Zero values match everything.
+type LedgerFilter struct {
+	StrategyID string
+	Symbol     string
+	Since      time.Time
+	Until      time.Time
+}
+
+// LedgerAggregate is one group of an aggregate query.
+type LedgerAggregate struct {
+	Key         string  `json:"key"`
+	Trades      int     `json:"trades"`
+	Closes      int     `json:"closes"`
+	Wins        int     `json:"wins"`
+	Volume      float64 `json:"volume"`
+	Fees        float64 `json:"fees"`
+	NetPnL      float64 `json:"net_pnl"`      // closed-trade net PnL + funding
+	LedgerDelta float64 `json:"ledger_delta"` // NetPnL minus open-leg fees (the cash effect)
+}
+
+// ledgerGroupColumns are the supported --by groupings.
+var ledgerGroupColumns = map[string]string{
+	"month":    "month",
+	"symbol":   "symbol",
+	"strategy": "strategy_id",
+}
+
+// Aggregate groups the ledger by "month", "symbol" or "strategy".
+func (l *TradeLedger) Aggregate(by string, f LedgerFilter) ([]LedgerAggregate, error) {
+	col, ok := ledgerGroupColumns[by]
+	if !ok {
+		return nil, fmt.Errorf("unknown grouping %q (want month, symbol or strategy)", by)
+	}
+	var where []string
+	var args []any
+	if f.StrategyID != "" {
+		where, args = append(where, "strategy_id = ?"), append(args, f.StrategyID)
+	}
+	if f.Symbol != "" {
+		where, args = append(where, "symbol = ?"), append(args, f.Symbol)
+	}
+	if !f.Since.IsZero() {
+		where, args = append(where, "ts_ms >= ?"), append(args, f.Since.UnixMilli())
+	}
+	if !f.Until.IsZero() {
+		where, args = append(where, "ts_ms < ?"), append(args, f.Until.UnixMilli())
+	}
+	query := fmt.Sprintf(`SELECT %s, COUNT(*), SUM(is_close), SUM(CASE WHEN is_close = 1 AND net_pnl > 0 THEN 1 ELSE 0 END),
+		SUM(ABS(value)), SUM(exchange_fee), SUM(net_pnl), SUM(ledger_delta)
+		FROM ledger_trades`, col)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" GROUP BY %s ORDER BY %s", col, col)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ledger aggregate: %w", err)
+	}
+	defer rows.Close()
+	var out []LedgerAggregate
+	for rows.Next() {
+		var a LedgerAggregate
+		if err := rows.Scan(&a.Key, &a.Trades, &a.Closes, &a.Wins, &a.Volume, &a.Fees, &a.NetPnL, &a.LedgerDelta); err != nil {
+			return nil, fmt.Errorf("scan ledger aggregate: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// runLedger implements `go-trader ledger <sync|pnl>`.
+func runLedger(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: go-trader ledger sync [--config <path>]")
+		fmt.Fprintln(os.Stderr, "       go-trader ledger pnl [--config <path>] [--by month|symbol|strategy] [--strategy <id>] [--symbol <sym>] [--since YYYY-MM-DD] [--until YYYY-MM-DD] [--json]")
+		return 2
+	}
+	sub := args[0]
+	fs := flag.NewFlagSet("ledger "+sub, flag.ContinueOnError)
+	configPath := fs.String("config", "scheduler/config.json", "Path to config file")
+	by := fs.String("by", "month", "Group by month, symbol or strategy (pnl)")
+	strategyID := fs.String("strategy", "", "Only this strategy (pnl)")
+	symbol := fs.String("symbol", "", "Only this symbol (pnl)")
+	since := fs.String("since", "", "Inclusive UTC start date YYYY-MM-DD or RFC3339 (pnl)")
+	until := fs.String("until", "", "Exclusive UTC end date YYYY-MM-DD or RFC3339 (pnl)")
+	asJSON := fs.Bool("json", false, "Emit JSON instead of a table (pnl)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "ledger: unexpected arguments: %v\n", fs.Args())
+		return 2
+	}
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		return 1
+	}
+	path := tradeLedgerPath(cfg)
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "ledger: no ledger path (set trade_ledger.path)")
+		return 1
+	}
+
+	switch sub {
+	case "sync":
+		if err := tradeLedgerPlaintextErr(cfg.TradeLedger); err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", err)
+			return 1
+		}
+		stateDB, err := OpenStateDB(cfg.DBFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open state DB: %v\n", err)
+			return 1
+		}
+		defer stateDB.Close()
+		ledger, err := OpenTradeLedger(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", err)
+			return 1
+		}
+		defer ledger.Close()
+		n, err := ledger.SyncFromStateDB(stateDB)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "Synced %d new trades into %s\n", n, path)
+		return 0
+	case "pnl":
+		if _, err := os.Stat(path); err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %s not found; enable trade_ledger or run `go-trader ledger sync` first\n", path)
+			return 1
+		}
+		f := LedgerFilter{StrategyID: *strategyID, Symbol: *symbol}
+		if f.Since, err = parseLedgerTime(*since); err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: --since: %v\n", err)
+			return 2
+		}
+		if f.Until, err = parseLedgerTime(*until); err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: --until: %v\n", err)
+			return 2
+		}
+		ledger, err := OpenTradeLedger(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", err)
+			return 1
+		}
+		defer ledger.Close()
+		rows, err := ledger.Aggregate(*by, f)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ledger: %v\n", err)
+			return 2
+		}
+		if *asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if rows == nil {
+				rows = []LedgerAggregate{}
+			}
+			if err := enc.Encode(rows); err != nil {
+				return 1
+			}
+			return 0
+		}
+		writeLedgerTable(os.Stdout, *by, rows)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown ledger command %q (want sync or pnl)\n", sub)
+		return 2
+	}
+}
+
+// parseLedgerTime accepts "", YYYY-MM-DD (UTC midnight) or RFC3339.
+func parseLedgerTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func writeLedgerTable(w io.Writer, by string, rows []LedgerAggregate) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tTRADES\tCLOSES\tWIN%%\tVOLUME\tFEES\tNET PNL\t\n", strings.ToUpper(by))
+	var total LedgerAggregate
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%.2f\t%.2f\t%.2f\t\n", r.Key, r.Trades, r.Closes, ledgerWinRate(r), r.Volume, r.Fees, r.NetPnL)
+		total.Trades += r.Trades
+		total.Closes += r.Closes
+		total.Wins += r.Wins
+		total.Volume += r.Volume
+		total.Fees += r.Fees
+		total.NetPnL += r.NetPnL
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%s\t%.2f\t%.2f\t%.2f\t\n", total.Trades, total.Closes, ledgerWinRate(total), total.Volume, total.Fees, total.NetPnL)
+	tw.Flush()
+}
+
+func ledgerWinRate(a LedgerAggregate) string {
+	if a.Closes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(a.Wins)/float64(a.Closes)*100)
+}
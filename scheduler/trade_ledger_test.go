@@ -0,0 +1,89 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTradeLedgerStreamSyncAndAggregate(t *testing.T) {
+	sdb := openTestDB(t)
+	ledger, err := OpenTradeLedger(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ledger.Close() })
+
+	jan := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC)
+	// Older history lives only in the state DB.
+	old := Trade{Timestamp: jan, StrategyID: "hl-btc", Symbol: "BTC", Side: "sell", Quantity: 1, Price: 100, Value: 100, IsClose: true, RealizedPnL: 10, ExchangeFee: 1, PnLGross: true}
+	if err := sdb.InsertTrade("hl-btc", old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Live stream via RecordTrade (also persisted to the state DB).
+	prevRec, prevLedger := tradeRecorder, tradeLedger
+	tradeRecorder, tradeLedger = sdb.InsertTrade, ledger
+	t.Cleanup(func() { tradeRecorder, tradeLedger = prevRec, prevLedger })
+	s := &StrategyState{ID: "hl-eth", Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	RecordTrade(s, Trade{Timestamp: feb, Symbol: "ETH", Side: "buy", Quantity: 2, Price: 50, Value: 100, ExchangeFee: 0.5, PnLGross: true})
+	RecordTrade(s, Trade{Timestamp: feb.Add(time.Hour), Symbol: "ETH", Side: "sell", Quantity: 2, Price: 45, Value: 90, IsClose: true, RealizedPnL: -10, ExchangeFee: 0.5, PnLGross: true})
+
+	// Sync adds only the pre-existing row; the streamed ones are not duplicated.
+	n, err := ledger.SyncFromStateDB(sdb)
+	if err != nil || n != 1 {
+		t.Fatalf("sync added %d (err %v), want 1", n, err)
+	}
+	if n, _ := ledger.SyncFromStateDB(sdb); n != 0 {
+		t.Errorf("second sync added %d, want 0", n)
+	}
+
+	months, err := ledger.Aggregate("month", LedgerFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(months) != 2 || months[0].Key != "2026-01" || months[0].NetPnL != 9 || months[0].Wins != 1 {
+		t.Fatalf("months = %+v", months)
+	}
+	if feb := months[1]; feb.Trades != 2 || feb.Closes != 1 || feb.NetPnL != -10.5 || feb.LedgerDelta != -11 || feb.Fees != 1 {
+		t.Errorf("feb = %+v, want net -10.5, ledger delta -11", feb)
+	}
+
+	bySymbol, err := ledger.Aggregate("symbol", LedgerFilter{Since: feb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bySymbol) != 1 || bySymbol[0].Key != "ETH" {
+		t.Errorf("by symbol since feb = %+v", bySymbol)
+	}
+	if _, err := ledger.Aggregate("weekday", LedgerFilter{}); err == nil {
+		t.Error("unknown grouping accepted")
+	}
+}
+
+func TestTradeLedgerErrors(t *testing.T) {
+	if errs := tradeLedgerErrors(&TradeLedgerConfig{Enabled: true}, "state.db"); errs != nil {
+		t.Errorf("default path: %v", errs)
+	}
+	if errs := tradeLedgerErrors(&TradeLedgerConfig{Enabled: true, Path: "./state.db"}, "state.db"); len(errs) != 1 {
+		t.Errorf("same file as db_file: %v", errs)
+	}
+	if errs := tradeLedgerErrors(&TradeLedgerConfig{Enabled: true}, ":memory:"); len(errs) != 1 {
+		t.Errorf("in-memory db without path: %v", errs)
+	}
+}
+
+func TestTradeLedgerPlaintextErr(t *testing.T) {
+	t.Setenv(stateKeyEnvVar, "")
+	if err := tradeLedgerPlaintextErr(&TradeLedgerConfig{Enabled: true}); err != nil {
+		t.Errorf("no state key: %v", err)
+	}
+	t.Setenv(stateKeyEnvVar, testStateKeyHex)
+	if err := tradeLedgerPlaintextErr(&TradeLedgerConfig{Enabled: true}); err == nil {
+		t.Error("encrypted state DB must refuse a plaintext ledger without allow_plaintext")
+	}
+	if err := tradeLedgerPlaintextErr(&TradeLedgerConfig{Enabled: true, AllowPlaintext: true}); err != nil {
+		t.Errorf("allow_plaintext: %v", err)
+	}
+}
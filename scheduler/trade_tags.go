@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// #4928: trade tags + PnL attribution. Every trade carries a small
+// key→value tag set stamped at RecordTrade time, persisted as
+// trades.tags_json, so realized PnL can be grouped by why a trade happened
+// (e.g. theta-harvest exits vs signal exits) rather than only by strategy.
+
+// Trade tag keys.
+const (
+	TradeTagReason = "reason" // see tradeReasonTag
+	TradeTagRegime = "regime" // Trade.Regime at booking
+	TradeTagSignal = "signal" // strategy script name, or "ensemble:<id>" for an ensemble executor
+	TradeTagSource = "source" // "manual" or "auto"
+)
+
+// tradeTagKeys is the set /api/attribution accepts for by=.
+var tradeTagKeys = []string{TradeTagReason, TradeTagRegime, TradeTagSignal, TradeTagSource}
+
+// tradeTagger maps strategy IDs to their signal tag. Configured from the
+// live config at startup and on SIGHUP; CLI subcommands leave it empty and
+// their trades simply carry no signal tag.
+type tradeTagger struct {
+	mu      sync.RWMutex
+	signals map[string]string
+}
+
+var globalTradeTagger = &tradeTagger{}
+
+// Configure rebuilds the strategy → signal map from cfg.
+func (t *tradeTagger) Configure(cfg *Config) {
+	signals := make(map[string]string, len(cfg.Strategies))
+	for _, sc := range cfg.Strategies {
+		if sc.Type == "manual" {
+			signals[sc.ID] = "manual"
+		} else if len(sc.Args) > 0 {
+			signals[sc.ID] = sc.Args[0]
+		}
+	}
+	for _, e := range cfg.Ensembles {
+		signals[e.Executor] = "ensemble:" + e.ID
+	}
+	t.mu.Lock()
+	t.signals = signals
+	t.mu.Unlock()
+}
+
+func (t *tradeTagger) signalFor(strategyID string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.signals[strategyID]
+}
+
+// tagTrade fills any tag the executor did not set explicitly. Called from
+// RecordTrade so every booking path is covered.
+func tagTrade(trade *Trade) {
+	if trade.Tags == nil {
+		trade.Tags = make(map[string]string, len(tradeTagKeys))
+	}
+	setDefault := func(k, v string) {
+		if v == "" {
+			return
+		}
+		if _, ok := trade.Tags[k]; !ok {
+			trade.Tags[k] = v
+		}
+	}
+	setDefault(TradeTagReason, tradeReasonTag(*trade))
+	setDefault(TradeTagRegime, trade.Regime)
+	setDefault(TradeTagSignal, globalTradeTagger.signalFor(trade.StrategyID))
+	source := "auto"
+	if trade.Manual || strings.HasPrefix(strings.ToLower(trade.Details), "manual ") {
+		source = "manual"
+	}
+	setDefault(TradeTagSource, source)
+}
+
+// stageTradeReason prefixes the Details of every trade s books until the
+// returned func is called with reason (e.g. "Scheduled exit"), so trades
+// from a Go-driven exit read — and tag — as that exit rather than a signal.
+func stageTradeReason(s *StrategyState, reason string) func() {
+	s.pendingTradeReason = reason
+	return func() { s.pendingTradeReason = "" }
+}
+
+// tradeReasonTag classifies a trade from its type and Details text, the
+// same text the close DM's tradeAlertCloseSource reads. Order matters:
+// "paper trailing sl close" must hit trailing before plain SL.
+func tradeReasonTag(t Trade) string {
+	if t.TradeType == TradeTypeFunding {
+		return "funding"
+	}
+	d := strings.ToLower(t.Details)
+	switch {
+	case strings.Contains(d, "position adjustment"):
+		return "position_adjustment"
+	case strings.Contains(d, "scheduled exit"):
+		return "scheduled_exit"
+	case strings.Contains(d, "theta harvest"):
+		return "theta_harvest"
+	case strings.Contains(d, "long option exit"):
+		return "long_option_exit"
+	case strings.Contains(d, "wheel"):
+		return "wheel"
+	case strings.Contains(d, "circuit breaker"):
+		return "circuit_breaker"
+	case strings.Contains(d, "kill switch"):
+		return "kill_switch"
+	case strings.Contains(d, "trailing sl"):
+		return "trailing_stop"
+	case strings.Contains(d, "sl close"), strings.Contains(d, "stop loss"):
+		return "stop_loss"
+	case strings.HasPrefix(d, "tp") && strings.Contains(d, "fill close"):
+		return "take_profit"
+	case strings.Contains(d, "external"):
+		return "external"
+	case strings.Contains(d, "regime/direction flip"):
+		return "regime_flip"
+	case strings.Contains(d, "scale-in"), strings.Contains(d, "limit add"):
+		return "scale_in"
+	case t.IsClose:
+		return "signal_exit"
+	}
+	return "signal_entry"
+}
+
+// TagAttribution is one row of the PnL attribution report: every close leg
+// (plus funding rows) whose tag `by` equals Value.
+type TagAttribution struct {
+	Value  string  `json:"value"`
+	Trades int     `json:"trades"`
+	Wins   int     `json:"wins"`
+	Losses int     `json:"losses"`
+	NetPnL float64 `json:"net_pnl"`
+	Share  float64 `json:"share_pct"` // NetPnL / Σ|NetPnL| across rows, signed
+}
+
+// attributePnLByTag groups realized PnL by one tag key. Only close legs and
+// funding rows carry realized PnL; untagged (pre-#4928) rows group under
+// "untagged". Sorted by NetPnL descending.
+func attributePnLByTag(trades []Trade, by string) []TagAttribution {
+	groups := make(map[string]*TagAttribution)
+	for _, t := range trades {
+		if !t.IsClose && t.TradeType != TradeTypeFunding {
+			continue
+		}
+		v := t.Tags[by]
+		if v == "" {
+			v = "untagged"
+		}
+		g := groups[v]
+		if g == nil {
+			g = &TagAttribution{Value: v}
+			groups[v] = g
+		}
+		pnl := tradeNetPnL(t)
+		g.Trades++
+		g.NetPnL += pnl
+		if t.IsClose {
+			if pnl > 0 {
+				g.Wins++
+			} else if pnl < 0 {
+				g.Losses++
+			}
+		}
+	}
+	var gross float64
+	for _, g := range groups {
+		if g.NetPnL < 0 {
+			gross -= g.NetPnL
+		} else {
+			gross += g.NetPnL
+		}
+	}
+	out := make([]TagAttribution, 0, len(groups))
+	for _, g := range groups {
+		if gross > 0 {
+			g.Share = g.NetPnL / gross * 100
+		}
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].NetPnL != out[j].NetPnL {
+			return out[i].NetPnL > out[j].NetPnL
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
+
+// QueryTaggedPnLTrades returns the realized-PnL rows (close legs and
+// funding) since a cutoff, optionally for one strategy, with just the
+// columns attribution needs.
+func (sdb *StateDB) QueryTaggedPnLTrades(strategyID string, since time.Time) ([]Trade, error) {
+	if sdb == nil || sdb.db == nil {
+		return nil, fmt.Errorf("state db unavailable")
+	}
+	query := `SELECT strategy_id, trade_type, is_close, realized_pnl, exchange_fee, COALESCE(pnl_gross, 0), COALESCE(tags_json, '')
+		FROM trades WHERE (is_close = 1 OR trade_type = ?) AND timestamp >= ?`
+	args := []any{TradeTypeFunding, formatTime(since)}
+	if strategyID != "" {
+		query += " AND strategy_id = ?"
+		args = append(args, strategyID)
+	}
+	rows, err := sdb.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tagged trades: %w", err)
+	}
+	defer rows.Close()
+	var out []Trade
+	for rows.Next() {
+		var t Trade
+		var isClose, pnlGross int
+		var tagsJSON string
+		if err := rows.Scan(&t.StrategyID, &t.TradeType, &isClose, &t.RealizedPnL, &t.ExchangeFee, &pnlGross, &tagsJSON); err != nil {
+			return nil, fmt.Errorf("scan tagged trade: %w", err)
+		}
+		t.IsClose = isClose != 0
+		t.PnLGross = pnlGross != 0
+		t.Tags = parseStringMapJSON(tagsJSON)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
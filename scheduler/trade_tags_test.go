@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTradeReasonTag(t *testing.T) {
+	cases := []struct {
+		trade Trade
+		want  string
+	}{
+		{Trade{Details: "Open long 0.1 @ $100 (fee $0.10)"}, "signal_entry"},
+		{Trade{Details: "Close long, PnL: $5.00 (fee $0.10)", IsClose: true}, "signal_exit"},
+		{Trade{Details: "Theta harvest close BTC-P PnL=$12.00", IsClose: true}, "theta_harvest"},
+		{Trade{Details: "Paper trailing SL close, PnL: $-3.00", IsClose: true}, "trailing_stop"},
+		{Trade{Details: "Stop loss close, PnL: $-3.00", IsClose: true}, "stop_loss"},
+		{Trade{Details: "TP1 fill close 0.5, PnL: $4.00", IsClose: true}, "take_profit"},
+		{Trade{Details: "Circuit breaker force-close", IsClose: true}, "circuit_breaker"},
+		{Trade{Details: "Scale-in BTC 0.1 @ $100 (add #1)"}, "scale_in"},
+		{Trade{TradeType: TradeTypeFunding, Details: "Funding payment"}, "funding"},
+	}
+	for _, tc := range cases {
+		if got := tradeReasonTag(tc.trade); got != tc.want {
+			t.Errorf("tradeReasonTag(%q) = %q, want %q", tc.trade.Details, got, tc.want)
+		}
+	}
+}
+
+func TestRecordTradeStampsTags(t *testing.T) {
+	orig := globalTradeTagger
+	t.Cleanup(func() { globalTradeTagger = orig })
+	globalTradeTagger = &tradeTagger{}
+	globalTradeTagger.Configure(&Config{
+		Strategies: []StrategyConfig{
+			{ID: "a", Type: "perps", Args: []string{"sma", "BTC", "1h"}},
+			{ID: "b", Type: "perps", Args: []string{"rsi", "BTC", "1h"}},
+		},
+		Ensembles: []EnsembleConfig{{ID: "btc", Executor: "b", Members: []string{"a"}}},
+	})
+
+	s := &StrategyState{ID: "a", Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{}}
+	RecordTrade(s, Trade{Symbol: "BTC", Details: "Close long, PnL: $1", IsClose: true, Regime: "trending"})
+	got := s.TradeHistory[0].Tags
+	want := map[string]string{TradeTagReason: "signal_exit", TradeTagRegime: "trending", TradeTagSignal: "sma", TradeTagSource: "auto"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("tags = %v, want %v", got, want)
+		}
+	}
+
+	s.ID = "b"
+	RecordTrade(s, Trade{Symbol: "BTC", Details: "manual open long BTC @ $1", Tags: map[string]string{TradeTagReason: "breakout"}})
+	got = s.TradeHistory[1].Tags
+	if got[TradeTagReason] != "breakout" || got[TradeTagSource] != "manual" || got[TradeTagSignal] != "ensemble:btc" {
+		t.Fatalf("preset/manual/ensemble tags = %v", got)
+	}
+	if _, ok := got[TradeTagRegime]; ok {
+		t.Fatalf("empty regime should not be tagged: %v", got)
+	}
+}
+
+func TestAttributePnLByTag(t *testing.T) {
+	trades := []Trade{
+		{IsClose: true, RealizedPnL: 30, Tags: map[string]string{TradeTagReason: "theta_harvest"}},
+		{IsClose: true, RealizedPnL: 20, Tags: map[string]string{TradeTagReason: "theta_harvest"}},
+		{IsClose: true, RealizedPnL: -10, ExchangeFee: 2, PnLGross: true, Tags: map[string]string{TradeTagReason: "signal_exit"}},
+		{IsClose: false, ExchangeFee: 5, PnLGross: true, Tags: map[string]string{TradeTagReason: "signal_entry"}},
+		{TradeType: TradeTypeFunding, RealizedPnL: -3},
+	}
+	rows := attributePnLByTag(trades, TradeTagReason)
+	if len(rows) != 3 {
+		t.Fatalf("rows = %+v, want theta_harvest, untagged, signal_exit", rows)
+	}
+	if rows[0].Value != "theta_harvest" || rows[0].NetPnL != 50 || rows[0].Wins != 2 || rows[0].Trades != 2 {
+		t.Fatalf("theta row = %+v", rows[0])
+	}
+	if rows[1].Value != "untagged" || rows[1].NetPnL != -3 || rows[1].Wins+rows[1].Losses != 0 {
+		t.Fatalf("funding row = %+v", rows[1])
+	}
+	if rows[2].Value != "signal_exit" || rows[2].NetPnL != -12 || rows[2].Losses != 1 {
+		t.Fatalf("signal_exit row = %+v", rows[2])
+	}
+	if math.Abs(rows[0].Share-50.0/65*100) > 1e-9 {
+		t.Fatalf("share = %v", rows[0].Share)
+	}
+}
+
+func TestTradeTagsPersistAndAttributionEndpoint(t *testing.T) {
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	now := time.Now().UTC()
+	if err := db.InsertTrade("a", Trade{Timestamp: now, Symbol: "BTC", Side: "sell", IsClose: true, RealizedPnL: 7, Tags: map[string]string{TradeTagReason: "stop_loss"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.InsertTrade("a", Trade{Timestamp: now.AddDate(0, 0, -60), Symbol: "BTC", Side: "sell", IsClose: true, RealizedPnL: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	ss := &StatusServer{stateDB: db}
+	rec := httptest.NewRecorder()
+	ss.handleAPIAttribution(rec, httptest.NewRequest(http.MethodGet, "/api/attribution?strategy=a", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		By   string           `json:"by"`
+		Rows []TagAttribution `json:"rows"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.By != TradeTagReason || len(resp.Rows) != 1 || resp.Rows[0].Value != "stop_loss" || resp.Rows[0].NetPnL != 7 {
+		t.Fatalf("resp = %+v (the 60-day-old row is outside the default window)", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	ss.handleAPIAttribution(rec, httptest.NewRequest(http.MethodGet, "/api/attribution?by=mood", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown tag key status = %d", rec.Code)
+	}
+}
@@ -40,11 +40,14 @@ type tradingViewExportOptions struct {
 func runExport(args []string) int {
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "Usage: go-trader export tradingview [--config scheduler/config.json] (--all | --strategy <id>...) --output <file>")
+		fmt.Fprintln(os.Stderr, "       go-trader export koinly|cointracker [--config scheduler/config.json] (--all | --strategy <id>...) [--year YYYY] --output <file>")
 		return 2
 	}
 	switch args[0] {
 	case "tradingview":
 		return runTradingViewExport(args[1:])
+	case "koinly", "cointracker":
+		return runTaxExport(args[0], args[1:])
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown export target %q\n", args[0])
 		return 2
@@ -123,7 +123,7 @@ func FetchUICandles(req UICandleRequest) ([]UICandle, string, error) {
 
 	stdout, stderr, err := runPythonReadOnly("shared_scripts/fetch_candles.py", args)
 	if err != nil {
-		return nil, "", fmt.Errorf("fetch_candles: %w (stderr: %s)", err, strings.TrimSpace(string(stderr)))
+		return nil, "", fmt.Errorf("fetch_candles: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 	}
 	var resp struct {
 		Candles []UICandle `json:"candles"`
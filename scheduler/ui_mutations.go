@@ -85,25 +85,52 @@ func (ss *StatusServer) triggerConfigReload() string {
 // guarded patch path as the tuner (applyStrategyConfigPatch on configWriteMu)
 // and then signals the hot-reload. Returns the apply message.
 func (ss *StatusServer) applyUIStrategyOverrides(w http.ResponseWriter, id string, overrides map[string]json.RawMessage) (string, bool) {
+	msg, err := ss.applyStrategyOverrides(id, overrides)
+	if err != nil {
+		writeUIActionError(w, err)
+		return "", false
+	}
+	return msg, true
+}
+
+// applyStrategyOverrides is the response-writer-free core of
+// applyUIStrategyOverrides, shared with the gRPC admin API. Errors are
+// *uiActionError.
+func (ss *StatusServer) applyStrategyOverrides(id string, overrides map[string]json.RawMessage) (string, error) {
 	sc, ok := ss.strategyConfig(id)
 	if !ok {
-		writeJSONError(w, http.StatusNotFound, "strategy not found")
-		return "", false
+		return "", &uiActionError{status: http.StatusNotFound, msg: "strategy not found"}
 	}
 	merged, err := mergeStrategyTunerOverrides(sc, overrides)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, err.Error())
-		return "", false
+		return "", &uiActionError{status: http.StatusBadRequest, msg: err.Error()}
 	}
 	hasOpen := ss.strategyHasOpenPosition(id)
 	ss.configWriteMu.Lock()
 	_, err = applyStrategyConfigPatch(ss.configPath, id, merged, overrides, hasOpen)
 	ss.configWriteMu.Unlock()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, err.Error())
-		return "", false
+		return "", &uiActionError{status: http.StatusInternalServerError, msg: err.Error()}
 	}
-	return ss.triggerConfigReload(), true
+	return ss.triggerConfigReload(), nil
+}
+
+// setStrategyPaused pauses or resumes a strategy and returns the operator
+// message. Shared by the dashboard endpoint and the gRPC admin API.
+func (ss *StatusServer) setStrategyPaused(id string, paused bool) (string, error) {
+	if strings.TrimSpace(ss.configPath) == "" {
+		return "", &uiActionError{status: http.StatusServiceUnavailable, msg: "config path not configured"}
+	}
+	raw, _ := json.Marshal(paused)
+	msg, err := ss.applyStrategyOverrides(id, map[string]json.RawMessage{"paused": raw})
+	if err != nil {
+		return "", err
+	}
+	verb := "resumed"
+	if paused {
+		verb = "paused"
+	}
+	return fmt.Sprintf("Strategy %s %s. %s", id, verb, msg), nil
 }
 
 // handleAPIStrategyPause handles POST /api/strategies/{id}/pause with body
@@ -128,15 +155,12 @@ func (ss *StatusServer) handleAPIStrategyPause(w http.ResponseWriter, r *http.Re
 		writeJSONError(w, http.StatusBadRequest, "paused must be true or false")
 		return
 	}
-	msg, ok := ss.applyUIStrategyOverrides(w, id, map[string]json.RawMessage{"paused": raw})
-	if !ok {
+	msg, err := ss.setStrategyPaused(id, paused)
+	if err != nil {
+		writeUIActionError(w, err)
 		return
 	}
-	verb := "resumed"
-	if paused {
-		verb = "paused"
-	}
-	writeJSON(w, uiMutationResponse{OK: true, Message: fmt.Sprintf("Strategy %s %s. %s", id, verb, msg)})
+	writeJSON(w, uiMutationResponse{OK: true, Message: msg})
 }
 
 // handleAPIStrategyNotifications handles POST
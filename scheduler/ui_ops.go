@@ -1,13 +1,15 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 )
 
 // ui_ops.go — #1231 read-only operator API endpoints (Phase 2 of the #1229
-// dashboard-parity plan). Six GET routes give the dashboard read parity with
+// dashboard-parity plan). GET routes give the dashboard read parity with
 // every Discord read-only command and the diagnostics CLI:
 //
 //	/api/leaderboard        — per-strategy PnL ranking (Discord `leaderboard`)
@@ -16,6 +18,7 @@ import (
 //	/api/strategies/dead    — Discord `dead-strategies`
 //	/api/closing-strategies — #1203 close-evaluator registry dump
 //	/api/correlation        — Discord `correlation` / the /status snapshot
+//	/api/attribution        — #4928 realized PnL grouped by trade tag
 //
 // Locking contract: every handler is read-only, drain-aware
 // (rejectIfDraining) and token-guarded (requireAPIAuth). SQLite reads run
@@ -224,6 +227,57 @@ func (ss *StatusServer) handleAPICashflow(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// handleAPIAttribution attributes realized PnL across one trade tag (#4928):
+// ?by=reason|regime|signal|source (default reason), optional ?strategy= and
+// ?days= (default 30; 0 = all history). Same DB error contract as
+// /api/diagnostics.
+func (ss *StatusServer) handleAPIAttribution(w http.ResponseWriter, r *http.Request) {
+	if ss.rejectIfDraining(w) {
+		return
+	}
+	if !ss.requireAPIAuth(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if ss.stateDB == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "database not available")
+		return
+	}
+
+	q := r.URL.Query()
+	by := q.Get("by")
+	if by == "" {
+		by = TradeTagReason
+	}
+	if !slices.Contains(tradeTagKeys, by) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("by must be one of %v", tradeTagKeys))
+		return
+	}
+	days := 30
+	if v, err := strconv.Atoi(q.Get("days")); err == nil && v >= 0 {
+		days = v
+	}
+	var since time.Time
+	if days > 0 {
+		since = time.Now().UTC().AddDate(0, 0, -days)
+	}
+	strategyID := q.Get("strategy")
+	trades, err := ss.stateDB.QueryTaggedPnLTrades(strategyID, since)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, map[string]any{
+		"by":       by,
+		"strategy": strategyID,
+		"days":     days,
+		"rows":     attributePnLByTag(trades, by),
+	})
+}
+
 // handleAPIDeadStrategies lists strategies that have never opened a position
 // (lifetime is_close=0 count == 0) — same predicate as the Discord
 // `dead-strategies` command. Lifetime stats come from SQLite before the state
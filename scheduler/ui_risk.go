@@ -0,0 +1,171 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ui_risk.go — #4931 GET /risk: the portfolio kill-switch state, per-strategy
+// circuit breakers, and the limits each is measured against, with usage
+// percentages, so monitoring can alert while a breaker is still approaching
+// rather than after it fires.
+
+// RiskLimits are the active portfolio_risk limits. Zero means "not set".
+type RiskLimits struct {
+	MaxDrawdownPct              float64 `json:"max_drawdown_pct"`
+	WarnDrawdownPct             float64 `json:"warn_drawdown_pct"` // MaxDrawdownPct × warn_threshold_pct / 100
+	MaxNotionalUSD              float64 `json:"max_notional_usd,omitempty"`
+	DailyMaxLossUSD             float64 `json:"daily_max_loss_usd,omitempty"`
+	DailyMaxLossPct             float64 `json:"daily_max_loss_pct,omitempty"`
+	MaxSameDirectionNotionalUSD float64 `json:"max_same_direction_notional_usd,omitempty"`
+	MaxAssetConcentrationPct    float64 `json:"max_asset_concentration_pct,omitempty"`
+}
+
+// PortfolioRiskReport is the portfolio half of the /risk response.
+type PortfolioRiskReport struct {
+	TotalValue               float64       `json:"total_value"`
+	PeakValue                float64       `json:"peak_value"`
+	CurrentDrawdownPct       float64       `json:"current_drawdown_pct"`
+	CurrentMarginDrawdownPct float64       `json:"current_margin_drawdown_pct,omitempty"`
+	DrawdownUsagePct         float64       `json:"drawdown_usage_pct"` // worse of the two drawdowns as a % of max_drawdown_pct
+	InWarningBand            bool          `json:"in_warning_band"`
+	KillSwitchActive         bool          `json:"kill_switch_active"`
+	KillSwitchAt             time.Time     `json:"kill_switch_at,omitempty"`
+	KillSwitchReason         string        `json:"kill_switch_reason,omitempty"`
+	TotalNotional            float64       `json:"total_notional"`
+	NotionalUsagePct         float64       `json:"notional_usage_pct,omitempty"` // of max_notional_usd; omitted when no cap
+	DailyPnL                 float64       `json:"daily_pnl"`
+	DailyLossThresholdUSD    float64       `json:"daily_loss_threshold_usd,omitempty"`
+	DailyLossUsagePct        float64       `json:"daily_loss_usage_pct,omitempty"`
+	DailyLossTripped         bool          `json:"daily_loss_tripped,omitempty"`
+	VaR                      *PortfolioVaR `json:"var,omitempty"`
+}
+
+// StrategyRiskReport is one strategy's circuit-breaker view.
+type StrategyRiskReport struct {
+	ID                       string    `json:"id"`
+	CircuitBreaker           bool      `json:"circuit_breaker"`
+	CircuitBreakerUntil      time.Time `json:"circuit_breaker_until,omitempty"`
+	RemainingCooldownSeconds int64     `json:"remaining_cooldown_seconds,omitempty"`
+	CurrentDrawdownPct       float64   `json:"current_drawdown_pct"`
+	MaxDrawdownPct           float64   `json:"max_drawdown_pct"`
+	DrawdownUsagePct         float64   `json:"drawdown_usage_pct"`
+	ConsecutiveLosses        int       `json:"consecutive_losses"`
+	LossStreakThreshold      int       `json:"loss_streak_threshold"`
+	DailyPnL                 float64   `json:"daily_pnl"`
+	PendingCircuitClose      bool      `json:"pending_circuit_close,omitempty"`
+}
+
+// RiskReport is the /risk response.
+type RiskReport struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Limits      RiskLimits           `json:"limits"`
+	Portfolio   PortfolioRiskReport  `json:"portfolio"`
+	Strategies  []StrategyRiskReport `json:"strategies"`
+}
+
+// riskUsagePct returns value as a percentage of limit, 0 when no limit.
+func riskUsagePct(value, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return value / limit * 100
+}
+
+// buildRiskReport assembles the /risk response. Pure read of state; prices
+// feed notional and total value exactly as /status does.
+func buildRiskReport(state *AppState, strategies []StrategyConfig, pr *PortfolioRiskConfig, prices map[string]float64, now time.Time) RiskReport {
+	limits := RiskLimits{
+		MaxDrawdownPct:              portfolioRiskMaxDrawdown(pr),
+		WarnDrawdownPct:             portfolioRiskMaxDrawdown(pr) * portfolioRiskWarnThreshold(pr) / 100,
+		MaxNotionalUSD:              portfolioRiskMaxNotional(pr),
+		DailyMaxLossUSD:             portfolioRiskDailyMaxLossUSD(pr),
+		DailyMaxLossPct:             portfolioRiskDailyMaxLossPct(pr),
+		MaxSameDirectionNotionalUSD: portfolioRiskMaxSameDirectionNotional(pr),
+		MaxAssetConcentrationPct:    portfolioRiskMaxAssetConcentration(pr),
+	}
+
+	prs := state.PortfolioRisk
+	worstDD := max(prs.CurrentDrawdownPct, prs.CurrentMarginDrawdownPct)
+	port := PortfolioRiskReport{
+		PeakValue:                prs.PeakValue,
+		CurrentDrawdownPct:       prs.CurrentDrawdownPct,
+		CurrentMarginDrawdownPct: prs.CurrentMarginDrawdownPct,
+		DrawdownUsagePct:         riskUsagePct(worstDD, limits.MaxDrawdownPct),
+		InWarningBand:            !prs.KillSwitchActive && limits.WarnDrawdownPct > 0 && worstDD >= limits.WarnDrawdownPct,
+		KillSwitchActive:         prs.KillSwitchActive,
+		KillSwitchAt:             prs.KillSwitchAt,
+		KillSwitchReason:         prs.KillSwitchReason,
+		TotalNotional:            PortfolioNotional(state.Strategies, prices),
+		VaR:                      prs.VaR,
+	}
+	for _, s := range state.Strategies {
+		port.TotalValue += displayStrategyValue(s, prices)
+	}
+	port.NotionalUsagePct = riskUsagePct(port.TotalNotional, limits.MaxNotionalUSD)
+	dl := evaluateDailyLossLimit(pr, state.Strategies, now)
+	port.DailyPnL = dl.DailyPnL
+	port.DailyLossThresholdUSD = dl.ThresholdUSD
+	port.DailyLossUsagePct = riskUsagePct(dl.LossUSD, dl.ThresholdUSD)
+	port.DailyLossTripped = dl.Tripped
+
+	today := riskDay(now)
+	rows := make([]StrategyRiskReport, 0, len(strategies))
+	for i := range strategies {
+		sc := &strategies[i]
+		s := state.Strategies[sc.ID]
+		if s == nil {
+			continue
+		}
+		rs := s.RiskState
+		row := StrategyRiskReport{
+			ID:                  sc.ID,
+			CircuitBreaker:      rs.CircuitBreaker,
+			CurrentDrawdownPct:  rs.CurrentDrawdownPct,
+			MaxDrawdownPct:      sc.MaxDrawdownPct,
+			DrawdownUsagePct:    riskUsagePct(rs.CurrentDrawdownPct, sc.MaxDrawdownPct),
+			ConsecutiveLosses:   rs.ConsecutiveLosses,
+			LossStreakThreshold: sc.CircuitBreakerLossStreakThreshold(),
+			PendingCircuitClose: len(rs.PendingCircuitCloses) > 0,
+		}
+		if rs.DailyPnLDate == today {
+			row.DailyPnL = rs.DailyPnL
+		}
+		if rs.CircuitBreaker {
+			row.CircuitBreakerUntil = rs.CircuitBreakerUntil
+			if remaining := rs.CircuitBreakerUntil.Sub(now); remaining > 0 {
+				row.RemainingCooldownSeconds = int64(remaining.Seconds())
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+
+	return RiskReport{GeneratedAt: now, Limits: limits, Portfolio: port, Strategies: rows}
+}
+
+func (ss *StatusServer) handleRisk(w http.ResponseWriter, r *http.Request) {
+	if ss.rejectIfDraining(w) {
+		return
+	}
+	if !ss.requireAPIAuth(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	prices := ss.fetchLiveMarkPrices()
+	state := ss.readState()
+
+	ss.strategiesMu.RLock()
+	strategies := append([]StrategyConfig(nil), ss.strategies...)
+	var pr *PortfolioRiskConfig
+	if ss.uiCfg != nil {
+		pr = clonePortfolioRiskConfig(ss.uiCfg.PortfolioRisk)
+	}
+	ss.strategiesMu.RUnlock()
+
+	writeJSON(w, buildRiskReport(state, strategies, pr, prices, time.Now().UTC()))
+}
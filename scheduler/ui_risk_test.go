@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuildRiskReport(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	state := NewAppState()
+	state.PortfolioRisk = PortfolioRiskState{PeakValue: 2000, CurrentDrawdownPct: 16}
+	state.Strategies["a"] = &StrategyState{
+		ID: "a", Cash: 1000, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{},
+		RiskState: RiskState{CircuitBreaker: true, CircuitBreakerUntil: now.Add(90 * time.Second), CurrentDrawdownPct: 5, ConsecutiveLosses: 3, DailyPnL: -50, DailyPnLDate: "2026-05-01"},
+	}
+	state.Strategies["b"] = &StrategyState{
+		ID: "b", Cash: 500, Positions: map[string]*Position{}, OptionPositions: map[string]*OptionPosition{},
+		RiskState: RiskState{DailyPnL: -999, DailyPnLDate: "2026-04-30"},
+	}
+	strategies := []StrategyConfig{{ID: "b", MaxDrawdownPct: 10}, {ID: "a", MaxDrawdownPct: 20}, {ID: "gone"}}
+	pr := &PortfolioRiskConfig{MaxDrawdownPct: 25, WarnThresholdPct: 60, DailyMaxLossUSD: 200}
+
+	rep := buildRiskReport(state, strategies, pr, nil, now)
+	if rep.Limits.WarnDrawdownPct != 15 || rep.Portfolio.DrawdownUsagePct != 64 || !rep.Portfolio.InWarningBand {
+		t.Fatalf("portfolio = %+v, limits = %+v", rep.Portfolio, rep.Limits)
+	}
+	if rep.Portfolio.TotalValue != 1500 || rep.Portfolio.DailyPnL != -50 || math.Abs(rep.Portfolio.DailyLossUsagePct-25) > 1e-9 {
+		t.Fatalf("portfolio = %+v (stale-day DailyPnL must not count)", rep.Portfolio)
+	}
+	if len(rep.Strategies) != 2 || rep.Strategies[0].ID != "a" {
+		t.Fatalf("strategies = %+v", rep.Strategies)
+	}
+	a := rep.Strategies[0]
+	if !a.CircuitBreaker || a.RemainingCooldownSeconds != 90 || a.DrawdownUsagePct != 25 || a.LossStreakThreshold != DefaultCBLossStreakThreshold {
+		t.Fatalf("a = %+v", a)
+	}
+	if b := rep.Strategies[1]; b.CircuitBreaker || b.RemainingCooldownSeconds != 0 || b.DailyPnL != 0 {
+		t.Fatalf("b = %+v", b)
+	}
+}
+
+func TestHandleRisk(t *testing.T) {
+	state := NewAppState()
+	state.PortfolioRisk = PortfolioRiskState{PeakValue: 1000, KillSwitchActive: true, KillSwitchReason: "drawdown"}
+	var mu sync.RWMutex
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+
+	rec := httptest.NewRecorder()
+	ss.handleRisk(rec, httptest.NewRequest(http.MethodGet, "/risk", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp RiskReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Portfolio.KillSwitchActive || resp.Portfolio.KillSwitchReason != "drawdown" || resp.Portfolio.InWarningBand {
+		t.Fatalf("resp = %+v", resp.Portfolio)
+	}
+
+	rec = httptest.NewRecorder()
+	ss.handleRisk(rec, httptest.NewRequest(http.MethodPost, "/risk", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("POST status = %d", rec.Code)
+	}
+}
@@ -118,10 +118,11 @@ func (ss *StatusServer) rejectIfDraining(w http.ResponseWriter) bool {
 }
 
 func (ss *StatusServer) requireAPIAuth(w http.ResponseWriter, r *http.Request) bool {
-	if ss.statusToken == "" {
+	token := ss.currentStatusToken()
+	if token == "" {
 		return true
 	}
-	if r.Header.Get("Authorization") == "Bearer "+ss.statusToken {
+	if r.Header.Get("Authorization") == "Bearer "+token {
 		return true
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -467,16 +468,11 @@ func (ss *StatusServer) uiStrategyOverview(id string) (UIStrategyOverview, Lifet
 		return UIStrategyOverview{}, LifetimeTradeStats{}, false
 	}
 
-	ss.mu.RLock()
-	strat := ss.state.Strategies[id]
-	var snapshot StrategyState
-	if strat != nil {
-		snapshot = *strat
-	}
-	ss.mu.RUnlock()
+	strat := ss.readState().Strategies[id]
 	if strat == nil {
 		return UIStrategyOverview{}, LifetimeTradeStats{}, false
 	}
+	snapshot := *strat
 
 	prices := ss.fetchLiveMarkPrices()
 	pv := displayStrategyValue(&snapshot, prices)
@@ -533,21 +529,12 @@ func (ss *StatusServer) handleAPIStrategyStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	ss.mu.RLock()
-	strat := ss.state.Strategies[id]
-	var snapshot StrategyState
-	if strat != nil {
-		snapshot = *strat
-		snapshot.Positions = cloneUIPositions(strat.Positions)
-		snapshot.OptionPositions = cloneUIOptionPositions(strat.OptionPositions)
-		snapshot.TradeHistory = append([]Trade(nil), strat.TradeHistory...)
-		snapshot.RiskState = cloneUIRiskState(strat.RiskState)
-	}
-	ss.mu.RUnlock()
+	strat := ss.readState().Strategies[id]
 	if strat == nil {
 		writeJSONError(w, http.StatusNotFound, "strategy state not found")
 		return
 	}
+	snapshot := *strat
 
 	resp := UIStrategyStatus{
 		ID:                    overview.ID,
@@ -594,19 +581,12 @@ func (ss *StatusServer) handleAPIStrategyEquity(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	ss.mu.RLock()
-	strat := ss.state.Strategies[id]
-	var snapshot StrategyState
-	if strat != nil {
-		snapshot = *strat
-		snapshot.Positions = cloneUIPositions(strat.Positions)
-		snapshot.OptionPositions = cloneUIOptionPositions(strat.OptionPositions)
-	}
-	ss.mu.RUnlock()
+	strat := ss.readState().Strategies[id]
 	if strat == nil {
 		writeJSONError(w, http.StatusNotFound, "strategy state not found")
 		return
 	}
+	snapshot := *strat
 
 	initCap := EffectiveInitialCapital(sc, &snapshot)
 	// Cost-basis terminal point only — avoids N× external mark fetches when
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ui_strategy_detail.go — #4930 GET /strategies/{id}: everything about one
+// strategy in a single response (config, state, positions with live marks,
+// recent trades, risk state, last script output and error), so a dashboard
+// polling one bot does not have to pull the whole /status dump.
+
+// defaultStrategyDetailTrades is how many recent trades /strategies/{id}
+// returns when ?trades= is absent.
+const defaultStrategyDetailTrades = 20
+
+// StrategyDetailPosition is an open position plus its live mark.
+type StrategyDetailPosition struct {
+	*Position
+	Mark          float64 `json:"mark,omitempty"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+}
+
+// StrategyDetail is the /strategies/{id} response.
+type StrategyDetail struct {
+	ID              string                     `json:"id"`
+	Config          StrategyConfig             `json:"config"`
+	Cash            float64                    `json:"cash"`
+	InitialCapital  float64                    `json:"initial_capital"`
+	PortfolioValue  float64                    `json:"portfolio_value"`
+	PnL             float64                    `json:"pnl"`
+	PnLPct          float64                    `json:"pnl_pct"`
+	Regime          string                     `json:"regime,omitempty"`
+	Positions       []StrategyDetailPosition   `json:"positions"`
+	OptionPositions map[string]*OptionPosition `json:"option_positions"`
+	RecentTrades    []Trade                    `json:"recent_trades"`
+	RiskState       RiskState                  `json:"risk_state"`
+	Benchmark       *BenchmarkStats            `json:"benchmark,omitempty"`
+	Activity        StrategyActivity           `json:"activity"`
+}
+
+func (ss *StatusServer) handleStrategyDetail(w http.ResponseWriter, r *http.Request) {
+	if ss.rejectIfDraining(w) {
+		return
+	}
+	if !ss.requireAPIAuth(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	rest := strings.TrimPrefix(r.URL.Path, "/strategies/")
+	id, err := url.PathUnescape(strings.TrimSuffix(rest, "/"))
+	if err != nil || id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	sc, ok := ss.strategyConfig(id)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "strategy not found")
+		return
+	}
+	nTrades := defaultStrategyDetailTrades
+	if v, err := strconv.Atoi(r.URL.Query().Get("trades")); err == nil && v >= 0 {
+		nTrades = min(v, maxTradeHistory)
+	}
+
+	// Marks are fetched before reading the snapshot, mirroring /status.
+	prices := ss.fetchLiveMarkPrices()
+	s := ss.readState().Strategies[id]
+	if s == nil {
+		writeJSONError(w, http.StatusNotFound, "strategy state not found")
+		return
+	}
+
+	pv := displayStrategyValue(s, prices)
+	initCap := EffectiveInitialCapital(sc, s)
+	resp := StrategyDetail{
+		ID:              id,
+		Config:          redactStrategyEnv(sc),
+		Cash:            s.Cash,
+		InitialCapital:  initCap,
+		PortfolioValue:  pv,
+		PnL:             pv - initCap,
+		Regime:          strategyDisplayRegimeLabel(s, sc, ss.regime),
+		Positions:       make([]StrategyDetailPosition, 0, len(s.Positions)),
+		OptionPositions: s.OptionPositions,
+		RiskState:       s.RiskState,
+		Activity:        strategyActivity.Get(id),
+	}
+	if initCap > 0 {
+		resp.PnLPct = resp.PnL / initCap * 100
+	}
+	for _, pos := range s.Positions {
+		dp := StrategyDetailPosition{Position: pos}
+		if mark := prices[pos.Symbol]; mark > 0 {
+			dp.Mark = mark
+			dp.UnrealizedPnL = positionUnrealizedPnL(pos, mark)
+		}
+		resp.Positions = append(resp.Positions, dp)
+	}
+	sort.Slice(resp.Positions, func(i, j int) bool { return resp.Positions[i].Symbol < resp.Positions[j].Symbol })
+	trades := s.TradeHistory
+	if len(trades) > nTrades {
+		trades = trades[len(trades)-nTrades:]
+	}
+	resp.RecentTrades = append([]Trade{}, trades...)
+	if st, ok := benchmarkStats(s.Benchmark, benchmarkWindowDays); ok {
+		resp.Benchmark = &st
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHandleStrategyDetail(t *testing.T) {
+	state := NewAppState()
+	s := &StrategyState{
+		ID: "hl-sma-btc", Type: "perps", Platform: "hyperliquid", Cash: 900, InitialCapital: 1000,
+		Positions:       map[string]*Position{"BTC": {Symbol: "BTC", Quantity: 0.01, AvgCost: 10000, Side: "long"}},
+		OptionPositions: map[string]*OptionPosition{},
+	}
+	for i := 0; i < 30; i++ {
+		s.TradeHistory = append(s.TradeHistory, Trade{Timestamp: time.Unix(int64(i), 0), Symbol: "BTC", Details: "t"})
+	}
+	state.Strategies[s.ID] = s
+	var mu sync.RWMutex
+	ss := NewStatusServer(state, &mu, "", nil, nil)
+	ss.strategies = []StrategyConfig{{ID: s.ID, Type: "perps", Platform: "hyperliquid", Args: []string{"sma", "BTC", "1h"}, Capital: 1000}}
+
+	orig := strategyActivity
+	t.Cleanup(func() { strategyActivity = orig })
+	strategyActivity = &strategyActivityLog{}
+	logger := &StrategyLogger{stratID: s.ID, writer: io.Discard}
+	logger.Output("Signal: BUY | BTC @ $10000.00")
+	logger.Error("Script failed: %s", "boom")
+
+	rec := httptest.NewRecorder()
+	ss.handleStrategyDetail(rec, httptest.NewRequest(http.MethodGet, "/strategies/hl-sma-btc?trades=5", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp StrategyDetail
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Config.ID != s.ID || resp.Cash != 900 || len(resp.Positions) != 1 || resp.Positions[0].Symbol != "BTC" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if len(resp.RecentTrades) != 5 || resp.RecentTrades[4].Timestamp.Unix() != 29 {
+		t.Fatalf("recent trades = %+v", resp.RecentTrades)
+	}
+	if resp.Activity.LastOutput != "Signal: BUY | BTC @ $10000.00" || resp.Activity.LastError != "Script failed: boom" || resp.Activity.LastErrorAt.IsZero() {
+		t.Fatalf("activity = %+v", resp.Activity)
+	}
+
+	rec = httptest.NewRecorder()
+	ss.handleStrategyDetail(rec, httptest.NewRequest(http.MethodGet, "/strategies/nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown strategy status = %d", rec.Code)
+	}
+}
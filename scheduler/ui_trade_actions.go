@@ -208,14 +208,42 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 		}
 	}
 
+	res, err := ss.runTradeAction(id, action, params)
+	if err != nil {
+		writeUIActionError(w, err)
+		return
+	}
+	writeJSON(w, uiTradeActionResponse{OK: true, Queued: res.queued, Message: res.uiMessage()})
+}
+
+// uiActionError is a refused or failed UI/admin action carrying the HTTP
+// status the dashboard reports; the gRPC admin API maps it to a code.
+type uiActionError struct {
+	status int
+	msg    string
+}
+
+func (e *uiActionError) Error() string { return e.msg }
+
+// writeUIActionError writes err as a JSON error response.
+func writeUIActionError(w http.ResponseWriter, err error) {
+	if ae, ok := err.(*uiActionError); ok {
+		writeJSONError(w, ae.status, ae.msg)
+		return
+	}
+	writeJSONError(w, http.StatusInternalServerError, err.Error())
+}
+
+// runTradeAction runs one already-authorized trade action through its
+// manual core. Shared by the dashboard endpoint (after the confirm nonce is
+// consumed) and the gRPC admin API; errors are *uiActionError.
+func (ss *StatusServer) runTradeAction(id, action string, params map[string]json.RawMessage) (*manualCoreResult, error) {
 	cfg := ss.uiTradeConfig()
 	if cfg == nil {
-		writeJSONError(w, http.StatusServiceUnavailable, "config not available")
-		return
+		return nil, &uiActionError{status: http.StatusServiceUnavailable, msg: "config not available"}
 	}
 	if ss.stateDB == nil {
-		writeJSONError(w, http.StatusServiceUnavailable, "state db not available")
-		return
+		return nil, &uiActionError{status: http.StatusServiceUnavailable, msg: "state db not available"}
 	}
 	deps := ss.daemonManualCoreDeps(cfg)
 	if ss.tradeDepsHook != nil {
@@ -231,8 +259,7 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 		sc, lookupErr = lookupManualStrategy(cfg, id)
 	}
 	if lookupErr != nil {
-		writeJSONError(w, http.StatusBadRequest, lookupErr.Error())
-		return
+		return nil, &uiActionError{status: http.StatusBadRequest, msg: lookupErr.Error()}
 	}
 
 	// Serialize trade-action submits: the double-fire guard below is a
@@ -278,16 +305,13 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 	// most one un-drained open/add/close per strategy+symbol.
 	if action == "open" || action == "add" || action == "close" || action == "force-close" {
 		if pending, perr := pendingManualActionExists(ss.stateDB, id, guardSym, "open", "add", "close"); perr != nil {
-			writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("could not check pending actions: %v", perr))
-			return
+			return nil, &uiActionError{status: http.StatusInternalServerError, msg: fmt.Sprintf("could not check pending actions: %v", perr)}
 		} else if pending {
-			writeJSONError(w, http.StatusConflict, "a position-changing action (open/add/close) for this strategy is already submitted and awaiting the scheduler's next cycle — refresh after it applies before retrying")
-			return
+			return nil, &uiActionError{status: http.StatusConflict, msg: "a position-changing action (open/add/close) for this strategy is already submitted and awaiting the scheduler's next cycle — refresh after it applies before retrying"}
 		}
 		if action == "open" {
 			if view, verr := deps.loadState(id, sc.Symbol); verr == nil && view.Pos != nil {
-				writeJSONError(w, http.StatusConflict, fmt.Sprintf("strategy already holds an open %s position — use add or close instead", sc.Symbol))
-				return
+				return nil, &uiActionError{status: http.StatusConflict, msg: fmt.Sprintf("strategy already holds an open %s position — use add or close instead", sc.Symbol)}
 			}
 		}
 	}
@@ -308,8 +332,7 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 			SLPct:      p.num("sl_pct"),
 		}
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = manualOpenCore(deps, sc, in)
 	case "add":
@@ -320,41 +343,35 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 			Margin:     p.num("margin"),
 		}
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = manualAddCore(deps, sc, in)
 	case "close":
 		qty := p.num("qty")
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = manualCloseCore(deps, sc, manualCloseInputs{StrategyID: id, Qty: qty})
 	case "force-close":
 		qty := p.num("qty")
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = forceCloseCore(deps, sc, sym, forceCloseInputs{StrategyID: id, Qty: qty})
 	case "update-sl":
 		in := manualSLInputs{StrategyID: id, Symbol: p.str("symbol"), Trigger: p.num("trigger")}
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = manualUpdateSLCore(deps, sc, in)
 	case "cancel-sl":
 		in := manualSLInputs{StrategyID: id, Symbol: p.str("symbol")}
 		if p.err != nil {
-			writeJSONError(w, http.StatusBadRequest, p.err.Error())
-			return
+			return nil, &uiActionError{status: http.StatusBadRequest, msg: p.err.Error()}
 		}
 		res, coreErr = manualCancelSLCore(deps, sc, in)
 	default:
-		http.NotFound(w, r)
-		return
+		return nil, &uiActionError{status: http.StatusNotFound, msg: "unknown trade action " + action}
 	}
 
 	if coreErr != nil {
@@ -364,8 +381,7 @@ func (ss *StatusServer) handleAPIStrategyTradeAction(w http.ResponseWriter, r *h
 				msg = ctx + "\n" + msg
 			}
 		}
-		writeJSONError(w, uiTradeActionHTTPStatus(coreErr), msg)
-		return
+		return nil, &uiActionError{status: uiTradeActionHTTPStatus(coreErr), msg: msg}
 	}
-	writeJSON(w, uiTradeActionResponse{OK: true, Queued: res.queued, Message: res.uiMessage()})
+	return res, nil
 }
@@ -106,7 +106,7 @@ func confirmNonceFor(t *testing.T, ss *StatusServer, action, id, params string)
 // nil fails loudly if hit.
 type tradeStubs struct {
 	updateSL    func(script, symbol, side string, size, triggerPx float64, cancelOID int64) (*HyperliquidStopLossUpdateResult, string, error)
-	execute     func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error)
+	execute     func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error)
 	closer      HyperliquidLiveCloser
 	cancelOrder func(script, symbol string, oid int64) (*HyperliquidCancelOrderResult, string, error)
 }
@@ -139,7 +139,7 @@ func stubTradeDeps(t *testing.T, ss *StatusServer) *tradeStubs {
 		if stubs.execute != nil {
 			d.execute = stubs.execute
 		} else {
-			d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+			d.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 				t.Error("execute must not be called")
 				return nil, "", fmt.Errorf("stub")
 			}
@@ -423,7 +423,7 @@ func TestUITradeActionsKillSwitchAndCBGates(t *testing.T) {
 func TestUICloseQueuesFromStubbedFill(t *testing.T) {
 	ss, db, _ := newTradeActionTestServer(t)
 	stubs := stubTradeDeps(t, ss)
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		if side != "sell" || size != 0.4 {
 			t.Errorf("close exec side=%s size=%.4f, want sell 0.4", side, size)
 		}
@@ -495,7 +495,7 @@ func TestUIOpenGuardsDoubleFire(t *testing.T) {
 	if err := db.DeletePendingManualActionsThrough(rows[len(rows)-1].ID); err != nil {
 		t.Fatalf("delete pending: %v", err)
 	}
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		if side != "buy" {
 			t.Errorf("open exec side = %s, want buy", side)
 		}
@@ -535,7 +535,7 @@ func TestUIOpenGuardsDoubleFire(t *testing.T) {
 func TestUIAddQueuesAndGuardsPending(t *testing.T) {
 	ss, db, _ := newTradeActionTestServer(t)
 	stubs := stubTradeDeps(t, ss)
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		return &HyperliquidExecuteResult{
 			Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2050, TotalSz: 0.05, OID: 556, Fee: 0.4}},
 		}, "", nil
@@ -556,7 +556,7 @@ func TestUIAddQueuesAndGuardsPending(t *testing.T) {
 	}
 
 	// Retry while the add is still queued -> 409, no second venue call.
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		t.Error("execute must not be called for a guarded add retry")
 		return nil, "", fmt.Errorf("stub")
 	}
@@ -660,7 +660,7 @@ func TestUICloseGuardsDoubleFire(t *testing.T) {
 	if err := db.DeletePendingManualActionsThrough(rows[len(rows)-1].ID); err != nil {
 		t.Fatalf("delete: %v", err)
 	}
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		return &HyperliquidExecuteResult{
 			Execution: &HyperliquidExecution{Fill: &HyperliquidFill{AvgPx: 2100, TotalSz: 0.4, OID: 4243, Fee: 1.5}},
 		}, "", nil
@@ -685,7 +685,7 @@ func TestUITradeActionsConcurrentOpensSerialized(t *testing.T) {
 	delete(ss.state.Strategies["hl-manual-eth"].Positions, "ETH")
 
 	var execCalls int32
-	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
+	stubs.execute = func(script, symbol, side string, size, stopLossPct float64, cancelOID int64, prevPosQty float64, marginMode string, leverage float64, closeFullPosition bool, snapshot hlExecuteSnapshot, cloid string, extraCancelOIDs ...int64) (*HyperliquidExecuteResult, string, error) {
 		atomic.AddInt32(&execCalls, 1)
 		time.Sleep(20 * time.Millisecond) // widen the race window
 		return &HyperliquidExecuteResult{
@@ -85,6 +85,7 @@ func (ss *StatusServer) SetConfigContext(configPath string, cfg *Config) {
 	ss.intervalSeconds = cfg.IntervalSeconds
 	ss.userCloseDefaults = cfg.userDefaultsClose()
 	ss.globalNotifyRatchet = cfg.NotifyRatchetTriggers
+	ss.cors = cloneCORSConfig(cfg.CORS)
 	ss.strategiesMu.Unlock()
 }
 
@@ -363,7 +364,7 @@ func loadStrategyTunerSchema(sc StrategyConfig) (map[string]interface{}, string,
 	}
 	if parseErr := json.Unmarshal(stdout, &resp); parseErr != nil {
 		if err != nil {
-			return nil, "", fmt.Errorf("strategy_tuner_schema: %w (stderr: %s)", err, strings.TrimSpace(string(stderr)))
+			return nil, "", fmt.Errorf("strategy_tuner_schema: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 		}
 		return nil, "", fmt.Errorf("parse strategy schema: %w", parseErr)
 	}
@@ -371,7 +372,7 @@ func loadStrategyTunerSchema(sc StrategyConfig) (map[string]interface{}, string,
 		return nil, "", fmt.Errorf("%s", resp.Error)
 	}
 	if err != nil {
-		return nil, "", fmt.Errorf("strategy_tuner_schema: %w (stderr: %s)", err, strings.TrimSpace(string(stderr)))
+		return nil, "", fmt.Errorf("strategy_tuner_schema: %w (stderr: %s)", err, outputSnippet(string(stderr)))
 	}
 	if resp.DefaultParams == nil {
 		resp.DefaultParams = map[string]interface{}{}
@@ -769,7 +770,7 @@ func runStrategySimulate(candles []UICandle, configs map[string]map[string]inter
 	}
 	if err := json.Unmarshal(stdout, &resp); err != nil {
 		if runErr != nil {
-			return nil, fmt.Errorf("simulate_strategy: %w (stderr: %s)", runErr, strings.TrimSpace(string(stderr)))
+			return nil, fmt.Errorf("simulate_strategy: %w (stderr: %s)", runErr, outputSnippet(string(stderr)))
 		}
 		return nil, fmt.Errorf("parse simulate response: %w", err)
 	}
@@ -777,7 +778,7 @@ func runStrategySimulate(candles []UICandle, configs map[string]map[string]inter
 		return nil, fmt.Errorf("%s", resp.Error)
 	}
 	if runErr != nil {
-		return nil, fmt.Errorf("simulate_strategy: %w (stderr: %s)", runErr, strings.TrimSpace(string(stderr)))
+		return nil, fmt.Errorf("simulate_strategy: %w (stderr: %s)", runErr, outputSnippet(string(stderr)))
 	}
 	if resp.Markers == nil {
 		resp.Markers = map[string][]UITradeMarker{}
@@ -800,6 +801,9 @@ func applyStrategyConfigPatch(configPath, strategyID string, merged StrategyConf
 	}
 	rawStrategies, ok := root["strategies"]
 	if !ok {
+		if _, inc := root[configIncludeKey]; inc {
+			return false, configStrategyNotFound(root, strategyID)
+		}
 		return false, fmt.Errorf("config has no strategies array")
 	}
 	var strategies []json.RawMessage
@@ -833,7 +837,7 @@ func applyStrategyConfigPatch(configPath, strategyID string, merged StrategyConf
 		break
 	}
 	if !found {
-		return false, fmt.Errorf("strategy %q not found in config", strategyID)
+		return false, configStrategyNotFound(root, strategyID)
 	}
 	newStrategies, err := json.Marshal(strategies)
 	if err != nil {
@@ -604,7 +604,7 @@ func readTuningJSON(path string, dst any) error {
 }
 
 var tuningProcessSpawner = func(ctx context.Context, script string, args []string, env map[string]string) ([]byte, []byte, error) {
-	return spawnPythonProcessWithEnv(ctx, script, args, nil, 0, env)
+	return spawnPythonProcessWithEnv(ctx, script, args, nil, 0, &ScriptEnv{Vars: env})
 }
 
 func runTuningProcess(ctx context.Context, job tuningRunJob) error {
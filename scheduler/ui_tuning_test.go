@@ -197,12 +197,13 @@ func TestRunTuningProcessBypassesTradingSemaphoreAndSetsCacheEnv(t *testing.T) {
 		return nil, nil, nil
 	}
 
-	for i := 0; i < cap(pythonSemaphore); i++ {
-		pythonSemaphore <- struct{}{}
+	limit, _, _ := pythonSemaphore.Snapshot()
+	for i := 0; i < limit; i++ {
+		pythonSemaphore.Acquire()
 	}
 	defer func() {
-		for i := 0; i < cap(pythonSemaphore); i++ {
-			<-pythonSemaphore
+		for i := 0; i < limit; i++ {
+			pythonSemaphore.Release(nil)
 		}
 	}()
 	done := make(chan error, 1)
@@ -318,3 +318,17 @@ func TestUpdateShellAllDedupesCanonicalAliases1055(t *testing.T) {
 		t.Errorf("expected a single canonicalized entry from both sources\n%s", text)
 	}
 }
+
+func TestUpdateShellRecordsBuildChecksumForSwap(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(updateShellScriptPath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	write := strings.Index(text, "> ./go-trader.new.sha256")
+	swap := strings.Index(text, "mv -f ./go-trader.new.sha256 ./go-trader.sha256")
+	if write < 0 || swap < 0 || write > swap {
+		t.Fatal("update.sh must write go-trader.new.sha256 at build and move it to go-trader.sha256 with the swap")
+	}
+}
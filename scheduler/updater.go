@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -13,12 +14,17 @@ import (
 
 const defaultGoTraderSystemdUnit = "go-trader"
 
-// checkForUpdates uses git fetch to check for upstream changes. If new commits are found
+// checkForUpdates uses git fetch to check for upstream changes (or, with
+// update_channel "releases", GitHub Releases — see updater_releases.go). If new commits are found
 // (and the remote hash differs from lastNotifiedHash), it sends a Discord channel notification
 // and, if OwnerID is configured, a DM offering to auto-upgrade.
 // Best-effort: errors are logged but never block startup or the main loop.
 // Returns true if updates are available.
 func checkForUpdates(cfg *Config, notifier *MultiNotifier, lastNotifiedHash *string, mu *sync.RWMutex, state *AppState, stateDB *StateDB) bool {
+	if releasesUpdateChannel(cfg) {
+		return checkForReleaseUpdates(cfg, notifier, lastNotifiedHash, mu, state, stateDB)
+	}
+
 	// Must be a git repo.
 	if err := gitCheck(); err != nil {
 		fmt.Printf("[update] Not a git repo or git unavailable: %v\n", err)
@@ -67,13 +73,16 @@ func checkForUpdates(cfg *Config, notifier *MultiNotifier, lastNotifiedHash *str
 	// DM the owner offering auto-upgrade (non-blocking goroutine).
 	if notifier != nil && notifier.HasOwner() {
 		go func() {
-			dmMsg := fmt.Sprintf("**Update available**: `%s` → `%s`\nWould you like me to upgrade automatically? (yes/no)\n_This will: git pull, rebuild, and restart._",
-				localHash[:8], remoteHash[:8])
+			dmMsg := fmt.Sprintf("**Update available**: `%s` → `%s`\nWould you like me to upgrade automatically? (yes/no)\n_This will: git pull, rebuild, and restart._%s",
+				localHash[:8], remoteHash[:8], upgradeGateNote(cfg))
 			resp, err := notifier.AskOwnerDM(dmMsg, 30*time.Minute)
 			if err != nil || strings.ToLower(strings.TrimSpace(resp)) != "yes" {
 				notifier.SendOwnerDM("Upgrade skipped.")
 				return
 			}
+			if !awaitUpgradeSlot(cfg, notifier, mu, state) {
+				return
+			}
 			applyUpgrade(notifier, mu, state, cfg, stateDB)
 		}()
 	}
@@ -90,6 +99,7 @@ func checkForUpdates(cfg *Config, notifier *MultiNotifier, lastNotifiedHash *str
 // function retains control of the state-save + restartSelf() ordering.
 func applyUpgrade(notifier *MultiNotifier, mu *sync.RWMutex, state *AppState, cfg *Config, stateDB *StateDB) {
 	notifier.SendOwnerDM("Starting upgrade...")
+	prePullSHA, _ := gitRevParse("HEAD")
 
 	// 5min covers a cold uv sync + go build on a slow VPS; killing mid-build
 	// leaves the worktree at the new SHA but no rebuilt binary, which the
@@ -102,13 +112,33 @@ func applyUpgrade(notifier *MultiNotifier, mu *sync.RWMutex, state *AppState, cf
 		notifier.SendOwnerDM(fmt.Sprintf("**update.sh failed**:\n```\n%s\n```\n%v", tailForDM(string(out), 1500), err))
 		return
 	}
-	notifier.SendOwnerDM(fmt.Sprintf("update.sh OK:\n```\n%s\n```\nSaving state and restarting...", tailForDM(string(out), 1500)))
+
+	// Refuse to restart into a binary that isn't the one update.sh built and
+	// probed (its build-time checksum sidecar — an integrity check, not a
+	// signature); revert the swap so an unplanned restart doesn't pick it up
+	// either.
+	binary, err := filepath.Abs("go-trader")
+	if err == nil {
+		err = checkBuiltUpgradeIntegrity(binary)
+	}
+	if err != nil {
+		notes := revertBinarySwap(binary, prePullSHA)
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade refused**: new binary failed its integrity check (%v) — not restarting.\n%s", err, strings.Join(notes, "\n")))
+		return
+	}
+	notifier.SendOwnerDM(fmt.Sprintf("update.sh OK (build checksum matches):\n```\n%s\n```\nSaving state and restarting...", tailForDM(string(out), 1500)))
 
 	// Step 3: save state safely
 	mu.Lock()
 	if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
 		fmt.Printf("[upgrade] Failed to save state: %v\n", err)
 	}
+	// Snapshot state and leave the marker the next process uses to roll back
+	// a build that won't stay up (upgrade_guard.go).
+	if err := prepareUpgradeRollback(cfg, stateDB, binary, prePullSHA); err != nil {
+		fmt.Printf("[upgrade] Rollback safety net unavailable: %v\n", err)
+		notifier.SendOwnerDM(fmt.Sprintf("Warning: automatic rollback unavailable for this upgrade (%v) — restarting anyway.", err))
+	}
 	mu.Unlock()
 
 	// Step 4: close notifier connections
@@ -146,6 +176,9 @@ func restartSelf() error {
 	if err != nil {
 		return fmt.Errorf("get executable: %w", err)
 	}
+	// After an upgrade swap the running inode was renamed to <binary>.prev,
+	// which is what /proc/self/exe now reports; exec the swapped-in binary.
+	exe = strings.TrimSuffix(exe, ".prev")
 	return syscall.Exec(exe, os.Args, os.Environ())
 }
 
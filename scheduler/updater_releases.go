@@ -0,0 +1,490 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Update channels selectable via config update_channel. "git" (or empty) is
+// the historical fetch + scripts/update.sh path; "releases" polls GitHub
+// Releases and swaps in the prebuilt binary, for deployments that have
+// neither a git checkout nor a Go toolchain.
+const (
+	updateChannelGit      = "git"
+	updateChannelReleases = "releases"
+)
+
+const defaultUpdateRepo = "richkuo/go-trader"
+
+// githubAPIBase is a var so tests can point the releases checker at an
+// httptest server.
+var githubAPIBase = "https://api.github.com"
+
+// maxReleaseAssetBytes caps a binary download so a wrong asset (or a hostile
+// redirect) can't fill the disk.
+const maxReleaseAssetBytes = 512 << 20
+
+var updateRepoRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+type githubRelease struct {
+	TagName string               `json:"tag_name"`
+	HTMLURL string               `json:"html_url"`
+	Assets  []githubReleaseAsset `json:"assets"`
+}
+
+type githubReleaseAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+func releasesUpdateChannel(cfg *Config) bool {
+	return cfg.UpdateChannel == updateChannelReleases
+}
+
+func resolvedUpdateRepo(cfg *Config) string {
+	if r := strings.TrimSpace(cfg.UpdateRepo); r != "" {
+		return r
+	}
+	return defaultUpdateRepo
+}
+
+// validateUpdateChannel checks update_channel / update_repo / update_signing_key.
+func validateUpdateChannel(cfg *Config) []string {
+	var errs []string
+	switch cfg.UpdateChannel {
+	case "", updateChannelGit, updateChannelReleases:
+	default:
+		errs = append(errs, fmt.Sprintf("update_channel must be %q or %q, got %q", updateChannelGit, updateChannelReleases, cfg.UpdateChannel))
+	}
+	if r := strings.TrimSpace(cfg.UpdateRepo); r != "" && !updateRepoRe.MatchString(r) {
+		errs = append(errs, fmt.Sprintf("update_repo must be a GitHub \"owner/name\", got %q", cfg.UpdateRepo))
+	}
+	if strings.TrimSpace(cfg.UpdateSigningKey) != "" {
+		if _, err := parseUpdateSigningKey(cfg.UpdateSigningKey); err != nil {
+			errs = append(errs, err.Error())
+		} else if !releasesUpdateChannel(cfg) {
+			// update.sh builds locally; there is no publisher signature to check.
+			errs = append(errs, fmt.Sprintf("update_signing_key only applies to update_channel %q", updateChannelReleases))
+		}
+	}
+	return errs
+}
+
+// releaseAssetName is the asset a release must carry for this platform:
+// the bare binary built with -ldflags "-X main.Version=<tag>".
+func releaseAssetName(goos, goarch string) string {
+	return fmt.Sprintf("go-trader_%s_%s", goos, goarch)
+}
+
+func (r *githubRelease) asset(name string) *githubReleaseAsset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// parseReleaseVersion parses "v1.2.3" (also "1.2.3", and git-describe forms
+// like "v1.2.3-4-gabcdef" by their base). ok=false for "dev" and anything
+// else that can't be ordered.
+func parseReleaseVersion(s string) (v [3]int, ok bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// releaseIsNewer reports whether tag orders after current. An unparseable
+// current version (a "dev" build) is never auto-upgraded: there is no way to
+// tell whether the release is older than what's running.
+func releaseIsNewer(tag, current string) (bool, error) {
+	tv, ok := parseReleaseVersion(tag)
+	if !ok {
+		return false, fmt.Errorf("release tag %q is not a version", tag)
+	}
+	cv, ok := parseReleaseVersion(current)
+	if !ok {
+		return false, fmt.Errorf("running version %q is not a release version", current)
+	}
+	for i := range tv {
+		if tv[i] != cv[i] {
+			return tv[i] > cv[i], nil
+		}
+	}
+	return false, nil
+}
+
+func githubRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Optional: lifts the 60 req/h unauthenticated limit and allows private repos.
+	if tok := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); tok != "" {
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	return req, nil
+}
+
+// fetchLatestRelease returns the repo's latest published (non-draft,
+// non-prerelease) release.
+func fetchLatestRelease(repo string) (*githubRelease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	req, err := githubRequest(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimRight(githubAPIBase, "/"), repo))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("GET releases/latest: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var rel githubRelease
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("decode release: %w", err)
+	}
+	if rel.TagName == "" {
+		return nil, fmt.Errorf("release has no tag_name")
+	}
+	return &rel, nil
+}
+
+// checkForReleaseUpdates is the update_channel=releases counterpart of
+// checkForUpdates: same notify-once + owner-DM contract, with the release tag
+// standing in for the remote hash.
+func checkForReleaseUpdates(cfg *Config, notifier *MultiNotifier, lastNotifiedTag *string, mu *sync.RWMutex, state *AppState, stateDB *StateDB) bool {
+	repo := resolvedUpdateRepo(cfg)
+	rel, err := fetchLatestRelease(repo)
+	if err != nil {
+		fmt.Printf("[update] GitHub release check for %s failed: %v\n", repo, err)
+		return false
+	}
+	newer, err := releaseIsNewer(rel.TagName, Version)
+	if err != nil {
+		fmt.Printf("[update] Cannot compare releases: %v\n", err)
+		return false
+	}
+	if !newer {
+		fmt.Println("[update] Already up to date")
+		return false
+	}
+
+	if lastNotifiedTag != nil && *lastNotifiedTag == rel.TagName {
+		fmt.Printf("[update] Release %s available, already notified\n", rel.TagName)
+		return true
+	}
+
+	assetName := releaseAssetName(runtime.GOOS, runtime.GOARCH)
+	missing := missingReleaseAssets(rel, assetName, cfg.UpdateSigningKey != "")
+	fmt.Printf("[update] Release available: %s → %s\n", Version, rel.TagName)
+
+	if notifier != nil && notifier.HasBackends() {
+		notifier.SendToAllChannels(formatReleaseUpdateMessage(Version, rel, missing))
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("[update] Release %s is missing %s; auto-upgrade unavailable\n", rel.TagName, strings.Join(missing, ", "))
+	} else if notifier != nil && notifier.HasOwner() {
+		go func() {
+			dmMsg := fmt.Sprintf("**Release available**: `%s` → `%s`\nWould you like me to upgrade automatically? (yes/no)\n_This will: download %s, verify it against the published checksums, swap it in, and restart._%s",
+				Version, rel.TagName, assetName, upgradeGateNote(cfg))
+			resp, err := notifier.AskOwnerDM(dmMsg, 30*time.Minute)
+			if err != nil || strings.ToLower(strings.TrimSpace(resp)) != "yes" {
+				notifier.SendOwnerDM("Upgrade skipped.")
+				return
+			}
+			if !awaitUpgradeSlot(cfg, notifier, mu, state) {
+				return
+			}
+			applyReleaseUpgrade(notifier, mu, state, cfg, stateDB, rel, assetName)
+		}()
+	}
+
+	if lastNotifiedTag != nil {
+		*lastNotifiedTag = rel.TagName
+	}
+	return true
+}
+
+// applyReleaseUpgrade downloads the release binary next to the running one,
+// verifies the staged copy (published checksum, optional signature, version,
+// probe), swaps it in (running binary → .prev, the same layout update.sh
+// leaves), then saves state and restarts with the upgrade_guard.go rollback
+// marker in place. Any verification failure leaves the running binary alone.
+func applyReleaseUpgrade(notifier *MultiNotifier, mu *sync.RWMutex, state *AppState, cfg *Config, stateDB *StateDB, rel *githubRelease, assetName string) {
+	notifier.SendOwnerDM(fmt.Sprintf("Starting upgrade to %s...", rel.TagName))
+
+	binary, err := runningBinaryPath()
+	if err != nil {
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade failed**: locate running binary: %v", err))
+		return
+	}
+	checksums, err := verifiedReleaseChecksums(cfg, rel)
+	if err != nil {
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade refused**: %v — still on %s.", err, Version))
+		return
+	}
+	asset := rel.asset(assetName)
+	if asset == nil {
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade failed**: release %s has no %s asset", rel.TagName, assetName))
+		return
+	}
+	staged := binary + ".new"
+	if err := downloadReleaseAsset(asset, staged); err != nil {
+		os.Remove(staged)
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade failed**: download %s: %v", asset.Name, err))
+		return
+	}
+	if err := verifyArtifactChecksum(staged, checksums, asset.Name); err != nil {
+		os.Remove(staged)
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade refused**: %v — still on %s.", err, Version))
+		return
+	}
+	out, err := verifyStagedBinary(staged, rel.TagName)
+	if err != nil {
+		os.Remove(staged)
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade failed**: staged %s did not verify — still on %s:\n```\n%s\n```\n%v", rel.TagName, Version, tailForDM(out, 1200), err))
+		return
+	}
+	if err := swapInBinary(staged, binary); err != nil {
+		os.Remove(staged)
+		notifier.SendOwnerDM(fmt.Sprintf("**Upgrade failed**: swap binary: %v", err))
+		return
+	}
+	notifier.SendOwnerDM(fmt.Sprintf("%s verified and installed:\n```\n%s\n```\nSaving state and restarting...", rel.TagName, tailForDM(out, 1200)))
+
+	mu.Lock()
+	if err := SaveStateWithDB(state, cfg, stateDB); err != nil {
+		fmt.Printf("[upgrade] Failed to save state: %v\n", err)
+	}
+	if err := prepareUpgradeRollback(cfg, stateDB, binary, ""); err != nil {
+		fmt.Printf("[upgrade] Rollback safety net unavailable: %v\n", err)
+		notifier.SendOwnerDM(fmt.Sprintf("Warning: automatic rollback unavailable for this upgrade (%v) — restarting anyway.", err))
+	}
+	mu.Unlock()
+
+	notifier.Close()
+	if err := restartSelf(); err != nil {
+		fmt.Printf("[upgrade] Restart failed: %v\n", err)
+	}
+}
+
+// runningBinaryPath resolves the executable through symlinks so the swap
+// replaces the real file, not a link in $PATH.
+func runningBinaryPath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+// missingReleaseAssets lists the assets an automatic upgrade needs that rel
+// doesn't carry: the platform binary, checksums.txt, and (when
+// update_signing_key is set) its signature.
+func missingReleaseAssets(rel *githubRelease, assetName string, wantSignature bool) []string {
+	var missing []string
+	for _, name := range []string{assetName, releaseChecksumsAsset} {
+		if rel.asset(name) == nil {
+			missing = append(missing, name)
+		}
+	}
+	if wantSignature && rel.asset(releaseSignatureAsset) == nil {
+		missing = append(missing, releaseSignatureAsset)
+	}
+	return missing
+}
+
+// verifiedReleaseChecksums downloads checksums.txt and, when
+// update_signing_key is configured, verifies its detached signature. Without
+// a key the checksum only guards against a corrupted or swapped download
+// URL, not a compromised release.
+func verifiedReleaseChecksums(cfg *Config, rel *githubRelease) ([]byte, error) {
+	sumsAsset := rel.asset(releaseChecksumsAsset)
+	if sumsAsset == nil {
+		return nil, fmt.Errorf("release %s publishes no %s", rel.TagName, releaseChecksumsAsset)
+	}
+	checksums, err := downloadReleaseBytes(sumsAsset, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", releaseChecksumsAsset, err)
+	}
+	pub, err := parseUpdateSigningKey(cfg.UpdateSigningKey)
+	if err != nil || pub == nil {
+		return checksums, err
+	}
+	sigAsset := rel.asset(releaseSignatureAsset)
+	if sigAsset == nil {
+		return nil, fmt.Errorf("release %s publishes no %s and update_signing_key is set", rel.TagName, releaseSignatureAsset)
+	}
+	sig, err := downloadReleaseBytes(sigAsset, 4096)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", releaseSignatureAsset, err)
+	}
+	if err := verifyChecksumSignature(pub, checksums, sig); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// downloadReleaseBytes fetches a small asset (checksums, signature) into
+// memory, refusing anything over max bytes.
+func downloadReleaseBytes(asset *githubReleaseAsset, max int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := githubRequest(ctx, asset.BrowserDownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("larger than %d bytes", max)
+	}
+	return data, nil
+}
+
+// downloadReleaseAsset streams the asset to dst (0755), rejecting a size
+// that disagrees with the release metadata.
+func downloadReleaseAsset(asset *githubReleaseAsset, dst string) error {
+	if asset.Size <= 0 || asset.Size > maxReleaseAssetBytes {
+		return fmt.Errorf("asset size %d outside (0, %d]", asset.Size, int64(maxReleaseAssetBytes))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	req, err := githubRequest(ctx, asset.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(resp.Body, asset.Size+1))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if n != asset.Size {
+		return fmt.Errorf("downloaded %d bytes, release lists %d", n, asset.Size)
+	}
+	return nil
+}
+
+// verifyStagedBinary checks the staged binary runs on this host, reports the
+// expected version, and passes the same check-script probe update.sh runs
+// before its swap. Returns the combined output for the owner DM.
+func verifyStagedBinary(staged, wantVersion string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, staged, "version").CombinedOutput()
+	got := strings.TrimSpace(string(out))
+	if err != nil {
+		return got, fmt.Errorf("run %s version: %w", filepath.Base(staged), err)
+	}
+	if got != wantVersion {
+		return got, fmt.Errorf("staged binary reports version %q, release tag is %q", got, wantVersion)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	args := []string{"probe"}
+	if f := flag.Lookup("config"); f != nil {
+		args = append(args, "-config", f.Value.String())
+	}
+	probeOut, err := exec.CommandContext(ctx, staged, args...).CombinedOutput()
+	if err != nil {
+		return string(probeOut), fmt.Errorf("probe: %w", err)
+	}
+	return string(probeOut), nil
+}
+
+// swapInBinary moves binary → binary.prev and staged → binary. If the second
+// rename fails the first is undone so the service still has a binary to start.
+func swapInBinary(staged, binary string) error {
+	prev := binary + ".prev"
+	if err := os.Rename(binary, prev); err != nil {
+		return err
+	}
+	if err := os.Rename(staged, binary); err != nil {
+		if rerr := os.Rename(prev, binary); rerr != nil {
+			return fmt.Errorf("%w (restoring previous binary also failed: %v)", err, rerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// formatReleaseUpdateMessage builds the channel notification for a newer
+// release; missing lists assets that block the automatic upgrade.
+func formatReleaseUpdateMessage(current string, rel *githubRelease, missing []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**New Release: %s**\n", rel.TagName)
+	fmt.Fprintf(&sb, "`%s` → `%s`\n", current, rel.TagName)
+	if rel.HTMLURL != "" {
+		sb.WriteString(rel.HTMLURL + "\n")
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&sb, "Release is missing `%s` — upgrade manually.", strings.Join(missing, "`, `"))
+	} else {
+		sb.WriteString("The owner can approve the upgrade by DM.")
+	}
+	return sb.String()
+}
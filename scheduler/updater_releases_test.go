@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReleaseIsNewer(t *testing.T) {
+	cases := []struct {
+		tag, current string
+		want         bool
+		wantErr      bool
+	}{
+		{"v1.4.0", "v1.3.9", true, false},
+		{"v1.4.0", "v1.4.0", false, false},
+		{"v1.4.0", "v1.10.0", false, false},
+		{"v2.0", "v1.9.9-3-gabcdef0", true, false},
+		{"v1.4.0", "dev", false, true},
+		{"nightly", "v1.0.0", false, true},
+	}
+	for _, c := range cases {
+		got, err := releaseIsNewer(c.tag, c.current)
+		if (err != nil) != c.wantErr || got != c.want {
+			t.Errorf("releaseIsNewer(%q, %q) = %v, %v; want %v, err=%v", c.tag, c.current, got, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestValidateUpdateChannel(t *testing.T) {
+	if errs := validateUpdateChannel(&Config{}); len(errs) != 0 {
+		t.Errorf("empty channel errs = %v", errs)
+	}
+	if errs := validateUpdateChannel(&Config{UpdateChannel: "releases", UpdateRepo: "me/go-trader-fork"}); len(errs) != 0 {
+		t.Errorf("releases channel errs = %v", errs)
+	}
+	if errs := validateUpdateChannel(&Config{UpdateChannel: "nightly", UpdateRepo: "https://github.com/x/y"}); len(errs) != 2 {
+		t.Errorf("bad channel+repo errs = %v, want 2", errs)
+	}
+	key := strings.Repeat("11", 32)
+	if errs := validateUpdateChannel(&Config{UpdateSigningKey: key}); len(errs) != 1 || !strings.Contains(errs[0], "only applies") {
+		t.Errorf("signing key on git channel errs = %v", errs)
+	}
+	if errs := validateUpdateChannel(&Config{UpdateChannel: "releases", UpdateSigningKey: key}); len(errs) != 0 {
+		t.Errorf("signing key on releases channel errs = %v", errs)
+	}
+}
+
+func TestFetchLatestRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/me/go-trader/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"tag_name":"v9.9.9","html_url":"https://example.test/r","assets":[{"name":"go-trader_linux_amd64","browser_download_url":"https://example.test/a","size":42}]}`))
+	}))
+	defer srv.Close()
+	prev := githubAPIBase
+	githubAPIBase = srv.URL
+	t.Cleanup(func() { githubAPIBase = prev })
+
+	rel, err := fetchLatestRelease("me/go-trader")
+	if err != nil {
+		t.Fatalf("fetchLatestRelease: %v", err)
+	}
+	if rel.TagName != "v9.9.9" || rel.asset("go-trader_linux_amd64") == nil || rel.asset("go-trader_darwin_arm64") != nil {
+		t.Fatalf("release = %+v", rel)
+	}
+	if _, err := fetchLatestRelease("me/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("missing repo err = %v, want HTTP 404", err)
+	}
+}
+
+// fakeReleaseBinary is a shell script standing in for a downloaded build:
+// "version" prints the given version, "probe" succeeds.
+func fakeReleaseBinary(version string) string {
+	return "#!/bin/sh\nif [ \"$1\" = version ]; then echo " + version + "; exit 0; fi\necho probe: OK\n"
+}
+
+func TestDownloadVerifyAndSwapReleaseBinary(t *testing.T) {
+	body := fakeReleaseBinary("v2.0.0")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "go-trader")
+	if err := os.WriteFile(binary, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	staged := binary + ".new"
+	asset := &githubReleaseAsset{Name: "go-trader_linux_amd64", BrowserDownloadURL: srv.URL, Size: int64(len(body))}
+	if err := downloadReleaseAsset(asset, staged); err != nil {
+		t.Fatalf("downloadReleaseAsset: %v", err)
+	}
+	if out, err := verifyStagedBinary(staged, "v2.0.0"); err != nil {
+		t.Fatalf("verifyStagedBinary: %v\n%s", err, out)
+	}
+	if _, err := verifyStagedBinary(staged, "v2.0.1"); err == nil || !strings.Contains(err.Error(), "reports version") {
+		t.Fatalf("version mismatch err = %v", err)
+	}
+	if err := swapInBinary(staged, binary); err != nil {
+		t.Fatalf("swapInBinary: %v", err)
+	}
+	if got, _ := os.ReadFile(binary + ".prev"); string(got) != "old" {
+		t.Errorf(".prev = %q, want the old binary", got)
+	}
+	if got, _ := os.ReadFile(binary); string(got) != body {
+		t.Error("new binary not swapped in")
+	}
+}
+
+func TestDownloadReleaseAssetRejectsSizeMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("truncated"))
+	}))
+	defer srv.Close()
+	dst := filepath.Join(t.TempDir(), "go-trader.new")
+	asset := &githubReleaseAsset{BrowserDownloadURL: srv.URL, Size: 1000}
+	if err := downloadReleaseAsset(asset, dst); err == nil || !strings.Contains(err.Error(), "release lists 1000") {
+		t.Fatalf("err = %v, want size mismatch", err)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// upgradeMarkerPath records an in-flight in-process upgrade (applyUpgrade →
+// restartSelf). Relative to the working directory like the default db_file,
+// and inside scheduler/ because that is writable under the shipped unit's
+// ProtectSystem=strict.
+const upgradeMarkerPath = "scheduler/upgrade-pending.json"
+
+// upgradeHealthyAfter is how long the upgraded process must stay up before the
+// upgrade is confirmed and the marker removed. A start that dies sooner counts
+// as failed.
+const upgradeHealthyAfter = 2 * time.Minute
+
+// upgradeMaxFailedStarts is the number of starts of the new binary that may
+// die inside upgradeHealthyAfter before the next start rolls back. With the
+// shipped RestartSec=10 a crash-looping build is reverted within ~30s.
+const upgradeMaxFailedStarts = 2
+
+// upgradeMarker is written by the outgoing process just before it restarts
+// into a freshly swapped binary, and read at startup by the next one. Paths
+// are absolute so a rollback doesn't depend on loading config, which may be
+// exactly what the new build fails at.
+type upgradeMarker struct {
+	FromVersion  string    `json:"from_version"`
+	PrePullSHA   string    `json:"pre_pull_sha,omitempty"`
+	Binary       string    `json:"binary"`
+	PrevBinary   string    `json:"prev_binary"`
+	DBFile       string    `json:"db_file"`
+	StateBackup  string    `json:"state_backup,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Starts       int       `json:"starts"`
+	StateTouched bool      `json:"state_touched,omitempty"`
+
+	RolledBack     bool     `json:"rolled_back,omitempty"`
+	RollbackReason string   `json:"rollback_reason,omitempty"`
+	RollbackOutput string   `json:"rollback_output,omitempty"`
+	RollbackNotes  []string `json:"rollback_notes,omitempty"`
+}
+
+// upgradeMarkerMu serializes marker read-modify-write between the main
+// goroutine (state-touched stamp) and the healthy-confirmation timer.
+// upgradeStateTouchedDone short-circuits markUpgradeStateTouched after its
+// first call so the per-cycle hook doesn't re-read the file forever.
+var (
+	upgradeMarkerMu         sync.Mutex
+	upgradeStateTouchedDone bool
+)
+
+func readUpgradeMarker(path string) (*upgradeMarker, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var m upgradeMarker
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+func writeUpgradeMarker(path string, m *upgradeMarker) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// prepareUpgradeRollback runs in the outgoing process after the binary was
+// swapped (update.sh, or the releases channel) and state was saved: it
+// snapshots the state DB and writes the marker the next process checks. An
+// empty prePullSHA (releases channel) skips the git reset on rollback. Any
+// error means the restart proceeds without a rollback safety net, so the
+// caller DMs it.
+func prepareUpgradeRollback(cfg *Config, stateDB *StateDB, binary, prePullSHA string) error {
+	prev := binary + ".prev"
+	if _, err := os.Stat(prev); err != nil {
+		return fmt.Errorf("no previous binary to roll back to: %w", err)
+	}
+	dbFile, err := filepath.Abs(cfg.DBFile)
+	if err != nil {
+		return err
+	}
+	backup := dbFile + ".pre-upgrade"
+	if err := stateDB.BackupTo(backup); err != nil {
+		return fmt.Errorf("back up state DB: %w", err)
+	}
+	m := &upgradeMarker{
+		FromVersion: Version,
+		PrePullSHA:  prePullSHA,
+		Binary:      binary,
+		PrevBinary:  prev,
+		DBFile:      dbFile,
+		StateBackup: backup,
+		CreatedAt:   time.Now().UTC(),
+	}
+	upgradeMarkerMu.Lock()
+	defer upgradeMarkerMu.Unlock()
+	return writeUpgradeMarker(upgradeMarkerPath, m)
+}
+
+// upgradeGuardOnStartup runs first thing on the daemon path. It returns an
+// owner-DM notice to replay once the notifier exists ("" when none):
+//   - no marker: nothing to do.
+//   - marker says rolled back: this is the restored binary — report the
+//     failure and clear the marker.
+//   - otherwise this is the new binary: count the start, and roll back (then
+//     exec the previous binary, never returning) once too many starts died
+//     inside upgradeHealthyAfter.
+func upgradeGuardOnStartup() string {
+	upgradeMarkerMu.Lock()
+	m, err := readUpgradeMarker(upgradeMarkerPath)
+	if err != nil {
+		upgradeMarkerMu.Unlock()
+		fmt.Fprintf(os.Stderr, "[upgrade] could not read %s: %v — upgrade rollback guard disabled\n", upgradeMarkerPath, err)
+		return ""
+	}
+	if m == nil {
+		upgradeMarkerMu.Unlock()
+		return ""
+	}
+	if m.RolledBack {
+		os.Remove(upgradeMarkerPath)
+		os.Remove(upgradeHealthPath)
+		upgradeMarkerMu.Unlock()
+		return formatUpgradeRollbackNotice(m)
+	}
+	if m.Starts >= upgradeMaxFailedStarts {
+		reason := fmt.Sprintf("upgraded binary failed to stay up %s on %d start(s)", upgradeHealthyAfter, m.Starts)
+		upgradeMarkerMu.Unlock()
+		rollbackUpgrade(m, reason)
+		return "" // unreachable unless the exec failed; rollbackUpgrade logged it
+	}
+	m.Starts++
+	if err := writeUpgradeMarker(upgradeMarkerPath, m); err != nil {
+		fmt.Fprintf(os.Stderr, "[upgrade] could not update %s: %v\n", upgradeMarkerPath, err)
+	}
+	upgradeMarkerMu.Unlock()
+	superviseUpgradeHealth(upgradeHealthDeadline)
+	fmt.Printf("[upgrade] upgraded from %s; start %d — confirming after a passing self-check and %s uptime\n", m.FromVersion, m.Starts, upgradeHealthyAfter)
+	return ""
+}
+
+// rollbackPendingUpgrade rolls back immediately when this process is an
+// unconfirmed upgrade — for startup failures that exit with a status systemd
+// won't restart (RestartPreventExitStatus), so no later start would count it.
+// Returns (and the caller exits as before) when nothing is pending.
+func rollbackPendingUpgrade(reason string) {
+	upgradeMarkerMu.Lock()
+	m, err := readUpgradeMarker(upgradeMarkerPath)
+	upgradeMarkerMu.Unlock()
+	if err != nil || m == nil || m.RolledBack {
+		return
+	}
+	rollbackUpgrade(m, reason)
+}
+
+// upgradePending reports whether this process is an unconfirmed upgrade.
+func upgradePending() bool {
+	upgradeMarkerMu.Lock()
+	defer upgradeMarkerMu.Unlock()
+	m, err := readUpgradeMarker(upgradeMarkerPath)
+	return err == nil && m != nil && !m.RolledBack
+}
+
+// markUpgradeStateTouched records that the new binary has started trading, so
+// a later rollback keeps its state instead of restoring the pre-upgrade
+// snapshot (which would drop fills it already booked). Called before each
+// cycle; only the first call touches the file.
+func markUpgradeStateTouched() {
+	upgradeMarkerMu.Lock()
+	defer upgradeMarkerMu.Unlock()
+	if upgradeStateTouchedDone {
+		return
+	}
+	upgradeStateTouchedDone = true
+	m, err := readUpgradeMarker(upgradeMarkerPath)
+	if err != nil || m == nil || m.RolledBack || m.StateTouched {
+		return
+	}
+	m.StateTouched = true
+	if err := writeUpgradeMarker(upgradeMarkerPath, m); err != nil {
+		fmt.Fprintf(os.Stderr, "[upgrade] could not update %s: %v\n", upgradeMarkerPath, err)
+	}
+}
+
+// confirmUpgradeAfter clears the marker (and health report) once the process
+// has survived upgradeHealthyAfter with a passing self-check, and tells the
+// owner. Without a health report by then it leaves the decision to
+// superviseUpgradeHealth. No-op without a pending marker.
+func confirmUpgradeAfter(delay time.Duration, notifier *MultiNotifier) {
+	if !upgradePending() {
+		return
+	}
+	time.AfterFunc(delay, func() {
+		if !upgradeHealthReported() {
+			fmt.Printf("[upgrade] %s uptime reached but no passing self-check yet — not confirming\n", delay)
+			return
+		}
+		upgradeMarkerMu.Lock()
+		m, err := readUpgradeMarker(upgradeMarkerPath)
+		if err != nil || m == nil || m.RolledBack {
+			upgradeMarkerMu.Unlock()
+			return
+		}
+		os.Remove(upgradeMarkerPath)
+		os.Remove(upgradeHealthPath)
+		upgradeMarkerMu.Unlock()
+		msg := fmt.Sprintf("Upgrade confirmed: %s → %s passed its self-check and stayed healthy for %s.", m.FromVersion, Version, delay)
+		fmt.Println("[upgrade] " + msg)
+		if notifier != nil && notifier.HasOwner() {
+			notifier.SendOwnerDM(msg)
+		}
+	})
+}
+
+// rollbackUpgrade restores the previous binary (and, if the new one never
+// traded, the pre-upgrade state snapshot), resets the tree to the pre-pull
+// SHA, marks the marker rolled back, and execs the previous binary. Returns
+// only if the exec itself failed.
+func rollbackUpgrade(m *upgradeMarker, reason string) {
+	fmt.Fprintf(os.Stderr, "[upgrade] ROLLBACK: %s\n", reason)
+	m.RolledBack = true
+	m.RollbackReason = reason
+	m.RollbackOutput = recentServiceOutput()
+
+	if err := os.Rename(m.PrevBinary, m.Binary); err != nil {
+		m.RollbackNotes = append(m.RollbackNotes, fmt.Sprintf("binary restore failed: %v — still on the new build", err))
+		fmt.Fprintf(os.Stderr, "[upgrade] rollback: restore %s: %v\n", m.PrevBinary, err)
+		upgradeMarkerMu.Lock()
+		writeUpgradeMarker(upgradeMarkerPath, m)
+		upgradeMarkerMu.Unlock()
+		return
+	}
+	m.RollbackNotes = append(m.RollbackNotes, "previous binary restored")
+
+	switch {
+	case m.StateTouched:
+		m.RollbackNotes = append(m.RollbackNotes, "state DB kept (the new build had started trading; the snapshot would drop its fills)")
+	case m.StateBackup == "":
+		m.RollbackNotes = append(m.RollbackNotes, "no state snapshot recorded")
+	default:
+		if err := restoreStateBackup(m.StateBackup, m.DBFile); err != nil {
+			m.RollbackNotes = append(m.RollbackNotes, fmt.Sprintf("state restore failed: %v", err))
+		} else {
+			m.RollbackNotes = append(m.RollbackNotes, "pre-upgrade state DB restored")
+		}
+	}
+
+	if m.PrePullSHA != "" {
+		if out, err := runRollbackCmd(60*time.Second, "git", "reset", "--hard", m.PrePullSHA); err != nil {
+			m.RollbackNotes = append(m.RollbackNotes, fmt.Sprintf("git reset to %.8s failed: %v %s", m.PrePullSHA, err, tailForDM(out, 200)))
+		} else if out, err := runRollbackCmd(180*time.Second, "uv", "sync"); err != nil {
+			m.RollbackNotes = append(m.RollbackNotes, fmt.Sprintf("tree reset to %.8s but uv sync failed: %v %s", m.PrePullSHA, err, tailForDM(out, 200)))
+		} else {
+			m.RollbackNotes = append(m.RollbackNotes, fmt.Sprintf("tree reset to %.8s", m.PrePullSHA))
+		}
+	}
+
+	upgradeMarkerMu.Lock()
+	if err := writeUpgradeMarker(upgradeMarkerPath, m); err != nil {
+		fmt.Fprintf(os.Stderr, "[upgrade] rollback: could not record report: %v\n", err)
+	}
+	upgradeMarkerMu.Unlock()
+
+	fmt.Fprintf(os.Stderr, "[upgrade] rollback: exec %s\n", m.Binary)
+	if err := syscall.Exec(m.Binary, os.Args, os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "[upgrade] rollback: exec previous binary: %v\n", err)
+	}
+}
+
+// restoreStateBackup copies the snapshot over the live DB and drops the WAL
+// sidecars so SQLite doesn't replay the new build's frames onto it.
+func restoreStateBackup(backup, dbFile string) error {
+	tmp := dbFile + ".restore"
+	if err := copyFileSync(backup, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	os.Remove(dbFile + "-wal")
+	os.Remove(dbFile + "-shm")
+	return os.Rename(tmp, dbFile)
+}
+
+// copyFileSync copies src to dst (0600) and fsyncs dst.
+func copyFileSync(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func runRollbackCmd(timeout time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	return string(out), err
+}
+
+// recentServiceOutput best-effort fetches the failed starts' output from the
+// journal (stdout/stderr of the unit). Empty off systemd.
+func recentServiceOutput() string {
+	out, err := runRollbackCmd(10*time.Second, "journalctl", "-u", updateSystemdUnitName(), "-n", "40", "--no-pager", "-o", "cat")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func formatUpgradeRollbackNotice(m *upgradeMarker) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Upgrade rolled back** — %s. Running %s again.", m.RollbackReason, Version)
+	for _, n := range m.RollbackNotes {
+		b.WriteString("\n• " + n)
+	}
+	if m.RollbackOutput != "" {
+		fmt.Fprintf(&b, "\nFailure output:\n```\n%s\n```", tailForDM(m.RollbackOutput, 1200))
+	} else {
+		b.WriteString("\nNo captured output — check the service log.")
+	}
+	return b.String()
+}
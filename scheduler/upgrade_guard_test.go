@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func chdirUpgradeSandbox(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "scheduler"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	upgradeStateTouchedDone = false
+	t.Cleanup(func() { upgradeStateTouchedDone = false })
+	return dir
+}
+
+func TestUpgradeGuardNoMarker(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	if got := upgradeGuardOnStartup(); got != "" {
+		t.Fatalf("notice = %q, want none without a marker", got)
+	}
+	if upgradePending() {
+		t.Fatal("upgradePending without a marker")
+	}
+}
+
+func TestUpgradeGuardCountsStarts(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	if err := writeUpgradeMarker(upgradeMarkerPath, &upgradeMarker{FromVersion: "v1.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	for want := 1; want <= upgradeMaxFailedStarts; want++ {
+		if got := upgradeGuardOnStartup(); got != "" {
+			t.Fatalf("start %d notice = %q", want, got)
+		}
+		m, err := readUpgradeMarker(upgradeMarkerPath)
+		if err != nil || m == nil {
+			t.Fatalf("marker after start %d: %v, %v", want, m, err)
+		}
+		if m.Starts != want {
+			t.Fatalf("Starts = %d, want %d", m.Starts, want)
+		}
+	}
+	if !upgradePending() {
+		t.Fatal("upgrade should still be pending before confirmation")
+	}
+}
+
+func TestUpgradeGuardReportsRollback(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	m := &upgradeMarker{
+		FromVersion:    "v1.0.0",
+		RolledBack:     true,
+		RollbackReason: "upgraded binary failed to stay up 2m0s on 2 start(s)",
+		RollbackNotes:  []string{"previous binary restored", "pre-upgrade state DB restored"},
+		RollbackOutput: "panic: boom",
+	}
+	if err := writeUpgradeMarker(upgradeMarkerPath, m); err != nil {
+		t.Fatal(err)
+	}
+	got := upgradeGuardOnStartup()
+	for _, want := range []string{"Upgrade rolled back", "failed to stay up", "pre-upgrade state DB restored", "panic: boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notice missing %q:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(upgradeMarkerPath); !os.IsNotExist(err) {
+		t.Error("rolled-back marker should be cleared after reporting")
+	}
+}
+
+func TestMarkUpgradeStateTouchedOnce(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	if err := writeUpgradeMarker(upgradeMarkerPath, &upgradeMarker{FromVersion: "v1.0.0", Starts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	markUpgradeStateTouched()
+	m, _ := readUpgradeMarker(upgradeMarkerPath)
+	if m == nil || !m.StateTouched {
+		t.Fatalf("StateTouched not recorded: %+v", m)
+	}
+}
+
+func TestRollbackUpgradeMissingPrevBinaryDoesNotExec(t *testing.T) {
+	dir := chdirUpgradeSandbox(t)
+	m := &upgradeMarker{
+		FromVersion: "v1.0.0",
+		Binary:      filepath.Join(dir, "go-trader"),
+		PrevBinary:  filepath.Join(dir, "go-trader.prev"),
+		Starts:      upgradeMaxFailedStarts,
+	}
+	rollbackUpgrade(m, "test")
+	got, err := readUpgradeMarker(upgradeMarkerPath)
+	if err != nil || got == nil {
+		t.Fatalf("marker not recorded: %v", err)
+	}
+	if !got.RolledBack || len(got.RollbackNotes) == 0 || !strings.Contains(got.RollbackNotes[0], "binary restore failed") {
+		t.Errorf("marker = %+v, want rolled back with restore-failed note", got)
+	}
+}
+
+func TestStateDBBackupAndRestore(t *testing.T) {
+	resetInitialCapitalGuardDedup(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.db")
+	db, err := OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("OpenStateDB: %v", err)
+	}
+	state := makeTestState()
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	backup := path + ".pre-upgrade"
+	if err := db.BackupTo(backup); err != nil {
+		t.Fatalf("BackupTo: %v", err)
+	}
+	state.CycleCount = 99
+	if err := db.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	db.Close()
+
+	if err := restoreStateBackup(backup, path); err != nil {
+		t.Fatalf("restoreStateBackup: %v", err)
+	}
+	db, err = OpenStateDB(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+	loaded, err := db.LoadState()
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if loaded.CycleCount != 42 {
+		t.Errorf("CycleCount = %d, want pre-upgrade 42", loaded.CycleCount)
+	}
+}
+
+func TestValidateUpgradeHealthWritesReport(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	resetInitialCapitalGuardDedup(t)
+	prev := upgradeDryCycleFn
+	upgradeDryCycleFn = func(*Config) (int, error) { return 2, nil }
+	t.Cleanup(func() { upgradeDryCycleFn = prev })
+
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.SaveState(makeTestState()); err != nil {
+		t.Fatal(err)
+	}
+
+	validateUpgradeHealth(&Config{}, db)
+	if upgradeHealthReported() {
+		t.Fatal("no pending upgrade: self-check must not run")
+	}
+
+	if err := writeUpgradeMarker(upgradeMarkerPath, &upgradeMarker{FromVersion: "v1.0.0", Starts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	validateUpgradeHealth(&Config{}, db)
+	if !upgradeHealthReported() {
+		t.Fatal("passing self-check did not write a health report")
+	}
+	r, _ := readUpgradeHealth(upgradeHealthPath)
+	joined := strings.Join(r.Checks, "\n")
+	for _, want := range []string{"config: loaded", "state: loaded", "dry cycle: 2 check script(s)"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("checks missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestUpgradeSelfCheckFailureRollsBack(t *testing.T) {
+	dir := chdirUpgradeSandbox(t)
+	resetInitialCapitalGuardDedup(t)
+	prev := upgradeDryCycleFn
+	upgradeDryCycleFn = func(*Config) (int, error) { return 0, fmt.Errorf("check_hyperliquid.py: ImportError") }
+	t.Cleanup(func() { upgradeDryCycleFn = prev })
+
+	db, err := OpenStateDB(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// PrevBinary is missing, so the rollback records its attempt without exec.
+	m := &upgradeMarker{FromVersion: "v1.0.0", Binary: filepath.Join(dir, "go-trader"), PrevBinary: filepath.Join(dir, "go-trader.prev"), Starts: 1}
+	if err := writeUpgradeMarker(upgradeMarkerPath, m); err != nil {
+		t.Fatal(err)
+	}
+	validateUpgradeHealth(&Config{}, db)
+	if upgradeHealthReported() {
+		t.Error("failed self-check wrote a health report")
+	}
+	got, _ := readUpgradeMarker(upgradeMarkerPath)
+	if got == nil || !got.RolledBack || !strings.Contains(got.RollbackReason, "self-check failed") {
+		t.Fatalf("marker = %+v, want rollback for the failed self-check", got)
+	}
+}
+
+func TestUpgradeHealthReportTiedToPID(t *testing.T) {
+	chdirUpgradeSandbox(t)
+	if err := writeUpgradeHealth(upgradeHealthPath, &upgradeHealthReport{Version: "v2", PID: os.Getpid() + 1}); err != nil {
+		t.Fatal(err)
+	}
+	if upgradeHealthReported() {
+		t.Error("a report from another process must not count")
+	}
+}
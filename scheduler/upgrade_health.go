@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// upgradeHealthPath is the success report the upgraded process writes once
+// its post-upgrade self-check passes. The supervisor (superviseUpgradeHealth)
+// and the uptime confirmation both require it; it sits next to
+// upgradeMarkerPath for the same ProtectSystem=strict reason.
+const upgradeHealthPath = "scheduler/upgrade-health.json"
+
+// upgradeHealthDeadline bounds how long an upgraded process may take from
+// start to a passing self-check before the supervisor reverts it. Generous:
+// the dry cycle spawns one check script per distinct script.
+const upgradeHealthDeadline = 10 * time.Minute
+
+// upgradeHealthReport is the content of upgradeHealthPath. PID ties the
+// report to the process that produced it so a stale file left by an earlier
+// start can't vouch for a later, hung one.
+type upgradeHealthReport struct {
+	Version   string    `json:"version"`
+	PID       int       `json:"pid"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []string  `json:"checks"`
+}
+
+// upgradeDryCycleFn is swapped in tests; production runs runUpgradeDryCycle.
+var upgradeDryCycleFn = runUpgradeDryCycle
+
+func readUpgradeHealth(path string) (*upgradeHealthReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var r upgradeHealthReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+func writeUpgradeHealth(path string, r *upgradeHealthReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// upgradeHealthReported reports whether this process has written a passing
+// health report.
+func upgradeHealthReported() bool {
+	r, err := readUpgradeHealth(upgradeHealthPath)
+	return err == nil && r != nil && r.PID == os.Getpid()
+}
+
+// runUpgradeSelfCheck is the post-upgrade self-check: the config loaded
+// (the caller only gets here after LoadConfig validated it), the state DB
+// re-reads cleanly, and one dry cycle evaluates signals without trading.
+// Returns one line per passed check.
+func runUpgradeSelfCheck(cfg *Config, stateDB *StateDB) ([]string, error) {
+	checks := []string{fmt.Sprintf("config: loaded, %d strategies", len(cfg.Strategies))}
+
+	loaded, err := stateDB.LoadState()
+	if err != nil {
+		return checks, fmt.Errorf("state: %w", err)
+	}
+	if loaded == nil {
+		checks = append(checks, "state: empty DB (fresh start)")
+	} else {
+		checks = append(checks, fmt.Sprintf("state: loaded %d strategies at cycle %d", len(loaded.Strategies), loaded.CycleCount))
+	}
+
+	n, err := upgradeDryCycleFn(cfg)
+	if err != nil {
+		return checks, fmt.Errorf("dry cycle: %w", err)
+	}
+	checks = append(checks, fmt.Sprintf("dry cycle: %d check script(s) returned a signal", n))
+	return checks, nil
+}
+
+// runUpgradeDryCycle evaluates one signal per distinct check script with the
+// first strategy's configured args — the read-only half of a cycle; nothing
+// is executed or booked. Options (positions on stdin) and manual strategies
+// are covered by the startup probe instead.
+func runUpgradeDryCycle(cfg *Config) (int, error) {
+	seen := make(map[string]bool)
+	n := 0
+	for _, sc := range cfg.Strategies {
+		if sc.Script == "" || sc.Type == "options" || sc.Type == "manual" || seen[sc.Script] {
+			continue
+		}
+		seen[sc.Script] = true
+		stdout, stderr, err := runPythonReadOnly(sc.Script, sc.Args)
+		if err != nil {
+			return n, fmt.Errorf("%s (%s): %v %s", sc.ID, sc.Script, err, tailForDM(string(stderr), 300))
+		}
+		var out struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(stdout, &out); err != nil {
+			return n, fmt.Errorf("%s (%s): unparseable output: %v", sc.ID, sc.Script, err)
+		}
+		if strings.TrimSpace(out.Error) != "" {
+			return n, fmt.Errorf("%s (%s): %s", sc.ID, sc.Script, out.Error)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// validateUpgradeHealth runs the self-check when this process is an
+// unconfirmed upgrade, before its first cycle. Pass → write the health
+// report. Fail → roll back now (execs the previous binary, which DMs the
+// report); returns only if there was nothing to roll back to.
+func validateUpgradeHealth(cfg *Config, stateDB *StateDB) {
+	if !upgradePending() {
+		return
+	}
+	checks, err := runUpgradeSelfCheck(cfg, stateDB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[upgrade] post-upgrade self-check FAILED: %v\n", err)
+		rollbackPendingUpgrade(fmt.Sprintf("post-upgrade self-check failed: %v", err))
+		return
+	}
+	r := &upgradeHealthReport{Version: Version, PID: os.Getpid(), CheckedAt: time.Now().UTC(), Checks: checks}
+	if err := writeUpgradeHealth(upgradeHealthPath, r); err != nil {
+		// Roll back now rather than let the supervisor fire mid-cycle later.
+		rollbackPendingUpgrade(fmt.Sprintf("could not record post-upgrade health report: %v", err))
+		return
+	}
+	fmt.Printf("[upgrade] post-upgrade self-check passed: %s\n", strings.Join(checks, "; "))
+}
+
+// superviseUpgradeHealth is the supervisor half: started the moment an
+// upgraded process counts its start, it reverts the upgrade if no health
+// report from this process appears within deadline — covering a build that
+// stays up (so never trips the failed-start count) but hangs in startup or
+// never passes its self-check.
+func superviseUpgradeHealth(deadline time.Duration) {
+	time.AfterFunc(deadline, func() {
+		if upgradeHealthReported() {
+			return
+		}
+		upgradeMarkerMu.Lock()
+		m, err := readUpgradeMarker(upgradeMarkerPath)
+		upgradeMarkerMu.Unlock()
+		if err != nil || m == nil || m.RolledBack {
+			return
+		}
+		rollbackUpgrade(m, fmt.Sprintf("no post-upgrade health report within %s (self-check never passed)", deadline))
+	})
+}
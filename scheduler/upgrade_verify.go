@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Release assets carrying the published SHA-256 sums (sha256sum format) and,
+// optionally, a detached Ed25519 signature over that file.
+const (
+	releaseChecksumsAsset = "checksums.txt"
+	releaseSignatureAsset = "checksums.txt.sig"
+)
+
+// builtChecksumSuffix names the sidecar scripts/update.sh writes next to the
+// binary it builds (go-trader.sha256), so applyUpgrade can confirm the file
+// it is about to restart into is byte-for-byte the one that passed probe.
+// The sidecar is written on the same host, so it is an integrity check
+// against a torn or swapped file, not an authenticity check.
+const builtChecksumSuffix = ".sha256"
+
+// parseUpdateSigningKey decodes update_signing_key: a base64 (std or
+// URL-safe) or hex Ed25519 public key. Empty → nil, nil (signatures not
+// required).
+func parseUpdateSigningKey(s string) (ed25519.PublicKey, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var raw []byte
+	if b, err := hex.DecodeString(s); err == nil {
+		raw = b
+	} else if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		raw = b
+	} else if b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "=")); err == nil {
+		raw = b
+	} else {
+		return nil, fmt.Errorf("update_signing_key is neither hex nor base64")
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("update_signing_key must decode to %d bytes (Ed25519 public key), got %d", ed25519.PublicKeySize, len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// verifyChecksumSignature checks sig (raw 64 bytes or base64 text) over the
+// checksums file.
+func verifyChecksumSignature(pub ed25519.PublicKey, checksums, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("signature is neither raw Ed25519 nor base64: %w", err)
+		}
+		sig = decoded
+	}
+	if len(sig) != ed25519.SignatureSize || !ed25519.Verify(pub, checksums, sig) {
+		return fmt.Errorf("%s signature does not verify against update_signing_key", releaseChecksumsAsset)
+	}
+	return nil
+}
+
+// checksumFor returns the hex SHA-256 listed for name in a sha256sum-format
+// file ("<hex>  <name>" or binary-mode "<hex> *<name>").
+func checksumFor(checksums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(checksums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := strings.ToLower(fields[0])
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return "", fmt.Errorf("malformed checksum for %s: %q", name, fields[0])
+		}
+		return sum, nil
+	}
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyArtifactChecksum hashes path and compares it with the entry for name
+// in checksums.
+func verifyArtifactChecksum(path string, checksums []byte, name string) error {
+	want, err := checksumFor(checksums, name)
+	if err != nil {
+		return err
+	}
+	got, err := sha256File(path)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("sha256 mismatch for %s: got %s, published %s", name, got, want)
+	}
+	return nil
+}
+
+// checkBuiltUpgradeIntegrity checks the binary update.sh swapped in against the
+// checksum sidecar it recorded at build time. A missing sidecar is a refusal,
+// not a pass: the artifact can't be tied to the build that passed probe.
+// Anyone who can rewrite the binary can rewrite the sidecar, so this proves
+// integrity only; the git channel's provenance is the fetched commit.
+func checkBuiltUpgradeIntegrity(binary string) error {
+	sums, err := os.ReadFile(binary + builtChecksumSuffix)
+	if err != nil {
+		return fmt.Errorf("read build checksum: %w", err)
+	}
+	return verifyArtifactChecksum(binary, sums, filepath.Base(binary))
+}
+
+// revertBinarySwap undoes update.sh's swap after a refused verification so
+// the next (unplanned) restart doesn't pick up the unverified binary: restore
+// .prev, and reset the tree to the pre-pull SHA. Returns one note per step
+// for the owner DM.
+func revertBinarySwap(binary, prePullSHA string) []string {
+	var notes []string
+	if err := os.Rename(binary+".prev", binary); err != nil {
+		notes = append(notes, fmt.Sprintf("restore %s.prev failed: %v — the unverified binary is still in place, do not restart", filepath.Base(binary), err))
+	} else {
+		notes = append(notes, "previous binary restored")
+	}
+	if prePullSHA == "" {
+		return notes
+	}
+	if out, err := runRollbackCmd(60*time.Second, "git", "reset", "--hard", prePullSHA); err != nil {
+		notes = append(notes, fmt.Sprintf("git reset to %.8s failed: %v %s", prePullSHA, err, tailForDM(out, 200)))
+	} else if out, err := runRollbackCmd(180*time.Second, "uv", "sync"); err != nil {
+		notes = append(notes, fmt.Sprintf("tree reset to %.8s but uv sync failed: %v %s", prePullSHA, err, tailForDM(out, 200)))
+	} else {
+		notes = append(notes, fmt.Sprintf("tree reset to %.8s", prePullSHA))
+	}
+	return notes
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecksumFor(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	sums := []byte(fmt.Sprintf("%s  go-trader_linux_amd64\n%s *go-trader_darwin_arm64\n", sum, strings.Repeat("cd", 32)))
+	if got, err := checksumFor(sums, "go-trader_linux_amd64"); err != nil || got != sum {
+		t.Errorf("linux = %q, %v", got, err)
+	}
+	if got, err := checksumFor(sums, "go-trader_darwin_arm64"); err != nil || got != strings.Repeat("cd", 32) {
+		t.Errorf("binary-mode entry = %q, %v", got, err)
+	}
+	if _, err := checksumFor(sums, "go-trader_windows_amd64"); err == nil {
+		t.Error("unlisted asset must not verify")
+	}
+}
+
+func TestCheckBuiltUpgradeIntegrity(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "go-trader")
+	if err := os.WriteFile(binary, []byte("built"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBuiltUpgradeIntegrity(binary); err == nil {
+		t.Fatal("missing sidecar must refuse")
+	}
+	sum := sha256.Sum256([]byte("built"))
+	sidecar := fmt.Sprintf("%s  go-trader\n", hex.EncodeToString(sum[:]))
+	if err := os.WriteFile(binary+builtChecksumSuffix, []byte(sidecar), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBuiltUpgradeIntegrity(binary); err != nil {
+		t.Fatalf("matching sidecar: %v", err)
+	}
+	if err := os.WriteFile(binary, []byte("modified"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBuiltUpgradeIntegrity(binary); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Fatalf("modified binary err = %v", err)
+	}
+}
+
+func TestRevertBinarySwapRestoresPrev(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "go-trader")
+	os.WriteFile(binary, []byte("new"), 0755)
+	os.WriteFile(binary+".prev", []byte("old"), 0755)
+	notes := revertBinarySwap(binary, "")
+	if got, _ := os.ReadFile(binary); string(got) != "old" {
+		t.Errorf("binary = %q, want previous restored", got)
+	}
+	if len(notes) != 1 || notes[0] != "previous binary restored" {
+		t.Errorf("notes = %v", notes)
+	}
+}
+
+func TestParseUpdateSigningKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, enc := range []string{hex.EncodeToString(pub), base64.StdEncoding.EncodeToString(pub), base64.RawURLEncoding.EncodeToString(pub)} {
+		got, err := parseUpdateSigningKey(enc)
+		if err != nil || !got.Equal(pub) {
+			t.Errorf("parse %q = %v, %v", enc, got, err)
+		}
+	}
+	if _, err := parseUpdateSigningKey("abcd"); err == nil {
+		t.Error("short key must be rejected")
+	}
+	if k, err := parseUpdateSigningKey(""); k != nil || err != nil {
+		t.Errorf("empty key = %v, %v; want nil, nil", k, err)
+	}
+}
+
+// releaseFixture serves a release whose assets are the given name→body map.
+func releaseFixture(t *testing.T, files map[string][]byte) *githubRelease {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	rel := &githubRelease{TagName: "v2.0.0"}
+	for name, body := range files {
+		rel.Assets = append(rel.Assets, githubReleaseAsset{Name: name, BrowserDownloadURL: srv.URL + "/" + name, Size: int64(len(body))})
+	}
+	return rel
+}
+
+func TestVerifiedReleaseChecksumsSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sums := []byte(strings.Repeat("ab", 32) + "  go-trader_linux_amd64\n")
+	cfg := &Config{UpdateChannel: "releases", UpdateSigningKey: hex.EncodeToString(pub)}
+
+	signed := releaseFixture(t, map[string][]byte{
+		releaseChecksumsAsset: sums,
+		releaseSignatureAsset: []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums))),
+	})
+	if got, err := verifiedReleaseChecksums(cfg, signed); err != nil || string(got) != string(sums) {
+		t.Fatalf("signed release = %q, %v", got, err)
+	}
+
+	forged := releaseFixture(t, map[string][]byte{
+		releaseChecksumsAsset: []byte(strings.Repeat("00", 32) + "  go-trader_linux_amd64\n"),
+		releaseSignatureAsset: ed25519.Sign(priv, sums),
+	})
+	if _, err := verifiedReleaseChecksums(cfg, forged); err == nil || !strings.Contains(err.Error(), "does not verify") {
+		t.Fatalf("forged checksums err = %v", err)
+	}
+
+	unsigned := releaseFixture(t, map[string][]byte{releaseChecksumsAsset: sums})
+	if _, err := verifiedReleaseChecksums(cfg, unsigned); err == nil || !strings.Contains(err.Error(), releaseSignatureAsset) {
+		t.Fatalf("unsigned release with key err = %v", err)
+	}
+	if _, err := verifiedReleaseChecksums(&Config{UpdateChannel: "releases"}, unsigned); err != nil {
+		t.Fatalf("unsigned release without key: %v", err)
+	}
+	if _, err := verifiedReleaseChecksums(cfg, releaseFixture(t, map[string][]byte{})); err == nil {
+		t.Fatal("release without checksums must be refused")
+	}
+}
+
+func TestMissingReleaseAssets(t *testing.T) {
+	rel := &githubRelease{Assets: []githubReleaseAsset{{Name: "go-trader_linux_amd64"}}}
+	got := missingReleaseAssets(rel, "go-trader_linux_amd64", true)
+	if strings.Join(got, ",") != "checksums.txt,checksums.txt.sig" {
+		t.Errorf("missing = %v", got)
+	}
+}
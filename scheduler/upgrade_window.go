@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// upgradeGatePoll is how often a deferred upgrade re-checks for open live
+// positions while it waits inside the window.
+var upgradeGatePoll = time.Minute
+
+// upgradeWindow is a parsed auto_update_window: an optional weekday set and a
+// UTC HH:MM-HH:MM span. An end before the start wraps past midnight; the
+// weekday names the day the window opens.
+type upgradeWindow struct {
+	days     map[time.Weekday]bool // nil = every day
+	startMin int
+	endMin   int
+	raw      string
+}
+
+var upgradeWindowDays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseUpgradeWindow parses "[days] HH:MM-HH:MM [UTC]", e.g.
+// "sat 02:00-04:00 UTC", "sat,sun 23:00-01:00", "daily 03:00-05:00" or just
+// "03:00-05:00". Empty → nil, nil (no window: upgrades apply once approved).
+func parseUpgradeWindow(s string) (*upgradeWindow, error) {
+	fields := strings.Fields(strings.ToLower(strings.TrimSpace(s)))
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if fields[len(fields)-1] == "utc" {
+		fields = fields[:len(fields)-1]
+	}
+	w := &upgradeWindow{raw: strings.TrimSpace(s)}
+	switch len(fields) {
+	case 1:
+	case 2:
+		if fields[0] != "daily" {
+			w.days = make(map[time.Weekday]bool)
+			for _, name := range strings.Split(fields[0], ",") {
+				d, ok := upgradeWindowDays[name]
+				if !ok {
+					return nil, fmt.Errorf("auto_update_window: unknown day %q (use mon..sun or daily)", name)
+				}
+				w.days[d] = true
+			}
+		}
+		fields = fields[1:]
+	default:
+		return nil, fmt.Errorf("auto_update_window: want \"[days] HH:MM-HH:MM [UTC]\", got %q", s)
+	}
+	span := strings.SplitN(fields[0], "-", 2)
+	if len(span) != 2 {
+		return nil, fmt.Errorf("auto_update_window: want HH:MM-HH:MM, got %q", fields[0])
+	}
+	sh, sm, ok1 := ParseLeaderboardPostTime(span[0])
+	eh, em, ok2 := ParseLeaderboardPostTime(span[1])
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("auto_update_window: want HH:MM-HH:MM (UTC), got %q", fields[0])
+	}
+	w.startMin, w.endMin = sh*60+sm, eh*60+em
+	if w.startMin == w.endMin {
+		return nil, fmt.Errorf("auto_update_window: %q is an empty window", fields[0])
+	}
+	return w, nil
+}
+
+func validateAutoUpdateWindow(s string) []string {
+	if _, err := parseUpgradeWindow(s); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+func (w *upgradeWindow) String() string { return w.raw }
+
+func (w *upgradeWindow) dayAllowed(d time.Weekday) bool {
+	return w.days == nil || w.days[d]
+}
+
+// contains reports whether t falls inside the window.
+func (w *upgradeWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	m := t.Hour()*60 + t.Minute()
+	if w.startMin < w.endMin {
+		return w.dayAllowed(t.Weekday()) && m >= w.startMin && m < w.endMin
+	}
+	return (w.dayAllowed(t.Weekday()) && m >= w.startMin) ||
+		(w.dayAllowed(t.AddDate(0, 0, -1).Weekday()) && m < w.endMin)
+}
+
+// nextOpen returns t when inside the window, else the next time it opens.
+func (w *upgradeWindow) nextOpen(t time.Time) time.Time {
+	t = t.UTC()
+	if w.contains(t) {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	for d := 0; d <= 7; d++ {
+		day := midnight.AddDate(0, 0, d)
+		open := day.Add(time.Duration(w.startMin) * time.Minute)
+		if open.After(t) && w.dayAllowed(day.Weekday()) {
+			return open
+		}
+	}
+	return t // unreachable: every parsed window opens at least weekly
+}
+
+// closesAt returns when the occurrence containing t ends. Only meaningful
+// when contains(t).
+func (w *upgradeWindow) closesAt(t time.Time) time.Time {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	end := midnight.Add(time.Duration(w.endMin) * time.Minute)
+	if w.startMin > w.endMin && t.Hour()*60+t.Minute() >= w.startMin {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// openLivePositions lists "<strategy> <symbol> <side>" for every non-flat
+// position (and option leg) held by a live strategy. Caller holds mu.
+func openLivePositions(cfg *Config, state *AppState) []string {
+	var out []string
+	for _, sc := range cfg.Strategies {
+		if !isLiveArgs(sc.Args) {
+			continue
+		}
+		ss := state.Strategies[sc.ID]
+		if ss == nil {
+			continue
+		}
+		for sym, pos := range ss.Positions {
+			if pos != nil && pos.Quantity != 0 {
+				out = append(out, fmt.Sprintf("%s %s %s", sc.ID, sym, pos.Side))
+			}
+		}
+		for key, op := range ss.OptionPositions {
+			if op != nil && op.Quantity != 0 {
+				out = append(out, fmt.Sprintf("%s %s (option)", sc.ID, key))
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// upgradeGateNote is appended to the upgrade-offer DM so the owner knows
+// when a "yes" will actually restart the daemon.
+func upgradeGateNote(cfg *Config) string {
+	if strings.TrimSpace(cfg.AutoUpdateWindow) != "" {
+		return fmt.Sprintf("\n_Applied in the maintenance window (%s) once live positions are flat._", strings.TrimSpace(cfg.AutoUpdateWindow))
+	}
+	return "\n_Applied once live positions are flat (or you override)._"
+}
+
+// awaitUpgradeSlot blocks an owner-approved upgrade until it may restart the
+// daemon: inside auto_update_window (when set) and with no open live
+// positions. Each time the gate finds live positions it asks the owner once —
+// `override` restarts anyway, `cancel` drops the upgrade, anything else (or
+// no reply) keeps polling until flat, or until the window closes and the next
+// one opens. Returns false when the upgrade was cancelled.
+func awaitUpgradeSlot(cfg *Config, notifier *MultiNotifier, mu *sync.RWMutex, state *AppState) bool {
+	window, _ := parseUpgradeWindow(cfg.AutoUpdateWindow) // validated at load
+	for {
+		if window != nil {
+			if open := window.nextOpen(time.Now()); time.Until(open) > 0 {
+				notifier.SendOwnerDM(fmt.Sprintf("Upgrade approved — waiting for the maintenance window (%s), opens %s.", window, open.Format("Mon 2006-01-02 15:04 UTC")))
+				time.Sleep(time.Until(open))
+			}
+		}
+
+		mu.RLock()
+		open := openLivePositions(cfg, state)
+		mu.RUnlock()
+		if len(open) == 0 {
+			return true
+		}
+
+		ask := fmt.Sprintf("Upgrade is ready but %d live position(s) are open:\n```\n%s\n```\nReply `override` to restart now, `cancel` to drop this upgrade; otherwise I'll apply it once flat",
+			len(open), tailForDM(strings.Join(open, "\n"), 1200))
+		deadline := time.Time{}
+		if window != nil {
+			deadline = window.closesAt(time.Now())
+			ask += fmt.Sprintf(" (before %s, else the next window)", deadline.Format("15:04 UTC"))
+		}
+		resp, err := notifier.AskOwnerDM(ask+".", 30*time.Minute)
+		switch strings.ToLower(strings.TrimSpace(resp)) {
+		case "override":
+			if err == nil {
+				notifier.SendOwnerDM("Override — upgrading with live positions open.")
+				return true
+			}
+		case "cancel":
+			if err == nil {
+				notifier.SendOwnerDM("Upgrade cancelled.")
+				return false
+			}
+		}
+
+		for {
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				break // window over: wait for the next one and ask again
+			}
+			mu.RLock()
+			open = openLivePositions(cfg, state)
+			mu.RUnlock()
+			if len(open) == 0 {
+				notifier.SendOwnerDM("Live positions are flat — applying the upgrade.")
+				return true
+			}
+			time.Sleep(upgradeGatePoll)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseUpgradeWindow(t *testing.T) {
+	for _, ok := range []string{"sat 02:00-04:00 UTC", "sat,sun 23:00-01:00", "daily 03:00-05:00", "03:00-05:00", ""} {
+		if _, err := parseUpgradeWindow(ok); err != nil {
+			t.Errorf("parseUpgradeWindow(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"someday 02:00-04:00", "sat 02:00", "sat 25:00-26:00", "sat 02:00-02:00", "sat 02:00-04:00 PST extra"} {
+		if _, err := parseUpgradeWindow(bad); err == nil {
+			t.Errorf("parseUpgradeWindow(%q) accepted", bad)
+		}
+	}
+}
+
+func TestUpgradeWindowContainsAndNextOpen(t *testing.T) {
+	w, err := parseUpgradeWindow("sat 02:00-04:00 UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-10-17 is a Saturday.
+	inside := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+	if !w.contains(inside) || !w.nextOpen(inside).Equal(inside) {
+		t.Errorf("03:00 Sat should be inside")
+	}
+	if got := w.closesAt(inside); !got.Equal(time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("closesAt = %v", got)
+	}
+	after := time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC)
+	if w.contains(after) {
+		t.Error("window end is exclusive")
+	}
+	if got := w.nextOpen(after); !got.Equal(time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("nextOpen after close = %v, want next Saturday 02:00", got)
+	}
+}
+
+func TestUpgradeWindowWrapsMidnight(t *testing.T) {
+	w, err := parseUpgradeWindow("fri 23:00-01:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fri 2026-10-16 23:30 and Sat 00:30 are inside; Sat 23:30 is not.
+	fri := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+	sat := time.Date(2026, 10, 17, 0, 30, 0, 0, time.UTC)
+	if !w.contains(fri) || !w.contains(sat) {
+		t.Error("wrapped window should cover both sides of midnight")
+	}
+	if w.contains(time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC)) {
+		t.Error("Saturday 23:30 is outside a Friday-only window")
+	}
+	if got := w.closesAt(fri); !got.Equal(time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("closesAt(fri) = %v", got)
+	}
+	if got := w.closesAt(sat); !got.Equal(time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("closesAt(sat) = %v", got)
+	}
+}
+
+func TestOpenLivePositions(t *testing.T) {
+	cfg := &Config{Strategies: []StrategyConfig{
+		{ID: "hl-live", Args: []string{"--mode=live"}},
+		{ID: "hl-paper", Args: []string{"--mode=paper"}},
+	}}
+	state := &AppState{Strategies: map[string]*StrategyState{
+		"hl-live": {Positions: map[string]*Position{
+			"BTC": {Quantity: 0.1, Side: "long"},
+			"ETH": {Quantity: 0, Side: "long"},
+		}},
+		"hl-paper": {Positions: map[string]*Position{"SOL": {Quantity: 5, Side: "short"}}},
+	}}
+	got := openLivePositions(cfg, state)
+	if strings.Join(got, ";") != "hl-live BTC long" {
+		t.Errorf("openLivePositions = %v, want only the live non-flat BTC leg", got)
+	}
+}
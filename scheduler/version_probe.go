@@ -1,14 +1,12 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -45,6 +43,9 @@ var probeArgv = []string{
 	// stays a faithful mirror without it (the script-level parser is shared,
 	// so this probe covers the flag for every mode of the same script).
 	"--atr-method=simple",
+	// #4926: new Go forwards the Go-side market regime when market_regime is
+	// enabled; probe it so a stale Python that rejects the flag fails startup.
+	"--market-regime=trending_up/vol_high",
 	"--probe-only",
 }
 
@@ -59,6 +60,7 @@ var probeCompositeArgv = []string{
 	"--regime-atr-window", "",
 	"--regime-payload-json", `{"macro":{"regime":"trending_up_clean","score":0.5,"classifier":"composite","metrics":{"adx":30.0}}}`,
 	"--atr-method=simple",
+	"--market-regime=trending_up/vol_high",
 	"--probe-only",
 }
 
@@ -86,6 +88,7 @@ var executeProbeArgv = []string{
 	"--mode=paper",
 	"--margin-mode=cross", "--leverage=1",
 	"--account-leverage=1", "--account-margin-mode=cross",
+	"--cloid=0x00000000000000000000000000000000",
 	"--probe-only",
 }
 
@@ -102,6 +105,22 @@ var limitOpenProbeArgv = []string{
 	"--probe-only",
 }
 
+// hlOrderStatusProbeArgv / okxExecuteProbeArgv / okxOrderStatusProbeArgv
+// cover the #4916 client-order-ID flags so a stale Python rejects them at
+// startup rather than on the first live order or the startup reconcile.
+var hlOrderStatusProbeArgv = []string{
+	"--order-status", "--symbol=BTC", "--cloid=0x00000000000000000000000000000000", "--probe-only",
+}
+
+var okxExecuteProbeArgv = []string{
+	"--execute", "--symbol=BTC", "--side=buy", "--size=0", "--mode=paper", "--inst-type=swap",
+	"--client-order-id=00000000000000000000000000000000", "--probe-only",
+}
+
+var okxOrderStatusProbeArgv = []string{
+	"--order-status", "--symbol=BTC", "--client-order-id=00000000000000000000000000000000", "--inst-type=swap", "--probe-only",
+}
+
 var limitStatusProbeArgv = []string{
 	"--limit-status", "--symbol=BTC", "--oids-json=[1]", "--probe-only",
 }
@@ -184,6 +203,17 @@ func probeCheckScripts(cfg *Config) error {
 			if err := probeOneCheckScriptFn(script, cancelOrderProbeArgv); err != nil {
 				return err
 			}
+			if err := probeOneCheckScriptFn(script, hlOrderStatusProbeArgv); err != nil {
+				return err
+			}
+		}
+		if filepath.Base(script) == "check_okx.py" {
+			if err := probeOneCheckScriptFn(script, okxExecuteProbeArgv); err != nil {
+				return err
+			}
+			if err := probeOneCheckScriptFn(script, okxOrderStatusProbeArgv); err != nil {
+				return err
+			}
 		}
 	}
 	// #1137: probe the LLM entry-analysis pipeline only when a strategy opts
@@ -232,22 +262,24 @@ func probeOneCheckScript(script string, argv []string) error {
 	defer cancel()
 
 	cmdArgs := append([]string{script}, argv...)
-	cmd := exec.CommandContext(ctx, ".venv/bin/python3", cmdArgs...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd := exec.CommandContext(ctx, pythonInterpreter, cmdArgs...)
+	setScriptProcessGroup(cmd)
+	cmd.Env = processEnvironment(scriptOwnerEnv())
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	stdout := newCappedOutput(activeScriptOutputMaxBytes, false)
+	stderr := newCappedOutput(activeScriptOutputMaxBytes, true)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
 	err := cmd.Run()
 	if ctx.Err() == context.DeadlineExceeded {
 		if cmd.Process != nil {
-			syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			killProcessGroup(cmd.Process.Pid)
 		}
 		return fmt.Errorf("%s: probe timed out after %s", script, probeTimeout)
 	}
 	if err != nil {
-		return formatProbeFailure(script, err, stderr.String(), stdout.String())
+		return formatProbeFailure(script, err, string(stderr.Bytes()), string(stdout.Bytes()))
 	}
 	return nil
 }
@@ -136,7 +136,8 @@ func TestProbeIgnoresEmptyScripts(t *testing.T) {
 }
 
 // TestProbeRunsExtraArgvForHL verifies the extra --fetch-atr (#689) and
-// --execute (PR #769) probes are dispatched only for check_hyperliquid.py.
+// --execute (PR #769) probes are dispatched only for check_hyperliquid.py,
+// and the #4916 execute/order-status probes for check_okx.py.
 // Stubs probeOneCheckScriptFn to record argv shapes per script without
 // requiring a real .venv.
 func TestProbeRunsExtraArgvForHL(t *testing.T) {
@@ -157,6 +158,8 @@ func TestProbeRunsExtraArgvForHL(t *testing.T) {
 				mode = "limit-status"
 			case "--cancel-order":
 				mode = "cancel-order"
+			case "--order-status":
+				mode = "order-status"
 			}
 			if mode != "signal" {
 				break
@@ -168,6 +171,7 @@ func TestProbeRunsExtraArgvForHL(t *testing.T) {
 	cfg := &Config{
 		Strategies: []StrategyConfig{
 			{Script: "shared_scripts/check_hyperliquid.py"},
+			{Script: "shared_scripts/check_okx.py"},
 			{Script: "shared_scripts/check_strategy.py"},
 		},
 	}
@@ -175,7 +179,7 @@ func TestProbeRunsExtraArgvForHL(t *testing.T) {
 		t.Fatalf("probe failed: %v", err)
 	}
 	hl := calls["shared_scripts/check_hyperliquid.py"]
-	wantHL := []string{"signal", "signal", "fetch-atr", "execute", "limit-open", "limit-status", "cancel-order"}
+	wantHL := []string{"signal", "signal", "fetch-atr", "execute", "limit-open", "limit-status", "cancel-order", "order-status"}
 	if len(hl) != len(wantHL) {
 		t.Errorf("HL should be probed %v, got %v", wantHL, hl)
 	} else {
@@ -185,6 +189,10 @@ func TestProbeRunsExtraArgvForHL(t *testing.T) {
 			}
 		}
 	}
+	okx := calls["shared_scripts/check_okx.py"]
+	if want := []string{"signal", "signal", "execute", "order-status"}; strings.Join(okx, ",") != strings.Join(want, ",") {
+		t.Errorf("OKX should be probed %v, got %v", want, okx)
+	}
 	spot := calls["shared_scripts/check_strategy.py"]
 	if len(spot) != 2 || spot[0] != "signal" || spot[1] != "signal" {
 		t.Errorf("non-HL should be probed signal(adx)+signal(composite), got %v", spot)
@@ -0,0 +1,302 @@
+package main
+
+// weekly_digest: once-a-week operator report (#4955).
+//
+// When enabled, the first cycle at or after weekday+time (UTC) posts one
+// message built from weeklyDigestSections to weekly_digest.channel (or the
+// leaderboard route when unset), then stamps AppState.LastWeeklyDigestDate
+// so a restart doesn't repost. A week whose slot passes while the daemon is
+// down is skipped, not caught up. Sections return "" when they have nothing
+// to say; a digest with no sections is not posted.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// weeklyDigestWindow is the lookback every section reports on.
+const weeklyDigestWindow = 7 * 24 * time.Hour
+
+// weeklyDigestMaxRows caps per-strategy rows in one section.
+const weeklyDigestMaxRows = 15
+
+// WeeklyDigestConfig is the top-level "weekly_digest" block.
+type WeeklyDigestConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Weekday   string `json:"weekday,omitempty"`    // "monday".."sunday"; empty → monday
+	Time      string `json:"time,omitempty"`       // "HH:MM" UTC; empty → 00:00
+	Channel   string `json:"channel,omitempty"`    // channel ID; empty → leaderboard channel / broadcast
+	AgingDays int    `json:"aging_days,omitempty"` // aging section lists positions held longer than this; 0/omitted → 7
+	// Compare lists strategy ID pairs that each get an A/B report (compare.go).
+	Compare [][]string `json:"compare,omitempty"`
+}
+
+func (c *WeeklyDigestConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *WeeklyDigestConfig) weekday() time.Weekday {
+	wd, _ := parseWeekday(c.Weekday)
+	return wd
+}
+
+func (c *WeeklyDigestConfig) minuteOfDay() int {
+	if c.Time == "" {
+		return 0
+	}
+	h, m, _ := ParseLeaderboardPostTime(c.Time)
+	return h*60 + m
+}
+
+// due reports whether the digest should post at now given the last post date.
+func (c *WeeklyDigestConfig) due(now time.Time, lastDate string) bool {
+	if !c.enabled() {
+		return false
+	}
+	now = now.UTC()
+	return now.Weekday() == c.weekday() &&
+		now.Hour()*60+now.Minute() >= c.minuteOfDay() &&
+		lastDate != now.Format("2006-01-02")
+}
+
+// parseWeekday maps a lowercase English day name to time.Weekday; empty is
+// Monday.
+func parseWeekday(s string) (time.Weekday, bool) {
+	if s == "" {
+		return time.Monday, true
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, true
+		}
+	}
+	return time.Monday, false
+}
+
+// validateWeeklyDigestConfig checks the block. Nil or disabled is valid.
+func validateWeeklyDigestConfig(c *WeeklyDigestConfig) []string {
+	if !c.enabled() {
+		return nil
+	}
+	var errs []string
+	if _, ok := parseWeekday(c.Weekday); !ok {
+		errs = append(errs, fmt.Sprintf("weekly_digest.weekday must be a day name (e.g. \"monday\"), got %q", c.Weekday))
+	}
+	if c.Time != "" {
+		if _, _, ok := ParseLeaderboardPostTime(c.Time); !ok {
+			errs = append(errs, fmt.Sprintf("weekly_digest.time must be HH:MM (UTC), got %q", c.Time))
+		}
+	}
+	if c.AgingDays < 0 {
+		errs = append(errs, fmt.Sprintf("weekly_digest.aging_days must be >= 0 (0 = default %d), got %d", defaultDigestAgingDays, c.AgingDays))
+	}
+	return errs
+}
+
+func cloneWeeklyDigestConfig(c *WeeklyDigestConfig) *WeeklyDigestConfig {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	if c.Compare != nil {
+		cp.Compare = make([][]string, len(c.Compare))
+		for i, pair := range c.Compare {
+			cp.Compare[i] = append([]string(nil), pair...)
+		}
+	}
+	return &cp
+}
+
+// formatWeeklyDigestConfig renders the block for reload change logs.
+func formatWeeklyDigestConfig(c *WeeklyDigestConfig) string {
+	if !c.enabled() {
+		return "disabled"
+	}
+	at := c.Time
+	if at == "" {
+		at = "00:00"
+	}
+	return fmt.Sprintf("enabled(%s %s UTC)", c.weekday(), at)
+}
+
+// weeklyDigestInput is what a section may read. Sections run outside mu;
+// state is a snapshot.
+type weeklyDigestInput struct {
+	cfg    *Config
+	state  *AppState
+	db     *StateDB
+	shadow *shadowBook
+	prices map[string]float64
+	now    time.Time
+}
+
+// weeklyDigestSections are rendered in order.
+var weeklyDigestSections = []func(in weeklyDigestInput) string{
+	digestSignalConversion,
+	digestPositionAging,
+	digestShadowDivergence,
+	digestABComparisons,
+	digestFeeImpact,
+}
+
+// buildWeeklyDigest renders the digest, or "" when every section is empty.
+func buildWeeklyDigest(in weeklyDigestInput) string {
+	var parts []string
+	for _, section := range weeklyDigestSections {
+		if s := section(in); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	header := fmt.Sprintf("📬 **Weekly digest** — %s to %s UTC",
+		in.now.Add(-weeklyDigestWindow).UTC().Format("Jan 02"), in.now.UTC().Format("Jan 02"))
+	return header + "\n\n" + strings.Join(parts, "\n\n")
+}
+
+// postWeeklyDigest sends msg to cfg.Channel, or the leaderboard route when
+// no channel is set.
+func postWeeklyDigest(cfg *WeeklyDigestConfig, msg string, notifier *MultiNotifier) error {
+	if cfg.Channel != "" {
+		return notifier.SendMessage(cfg.Channel, msg)
+	}
+	notifier.PostLeaderboardBroadcast(msg)
+	return nil
+}
+
+// signalConversion is one strategy's signal outcomes over the digest window.
+type signalConversion struct {
+	strategyID   string
+	signals      int // every non-hold record
+	executed     int
+	skipped      int // no_trade: reached the executor, nothing to do (e.g. already long)
+	blocked      int
+	failed       int // not_executed: live order failure
+	blockReasons map[string]int
+}
+
+func (c signalConversion) rate() float64 {
+	if c.signals == 0 {
+		return 0
+	}
+	return float64(c.executed) / float64(c.signals)
+}
+
+// SignalConversion tallies non-hold signal_history records since since, per
+// strategy.
+func (sdb *StateDB) SignalConversion(since time.Time) ([]signalConversion, error) {
+	rows, err := sdb.db.Query(`SELECT strategy_id, outcome, reason, COUNT(*) FROM signal_history
+		WHERE ts >= ? AND outcome != ? GROUP BY strategy_id, outcome, reason`,
+		since.UTC().Format(time.RFC3339), SignalOutcomeHold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	byID := make(map[string]*signalConversion)
+	for rows.Next() {
+		var id, outcome, reason string
+		var n int
+		if err := rows.Scan(&id, &outcome, &reason, &n); err != nil {
+			return nil, err
+		}
+		c := byID[id]
+		if c == nil {
+			c = &signalConversion{strategyID: id, blockReasons: make(map[string]int)}
+			byID[id] = c
+		}
+		c.signals += n
+		switch outcome {
+		case SignalOutcomeExecuted:
+			c.executed += n
+		case SignalOutcomeNoTrade:
+			c.skipped += n
+		case SignalOutcomeBlocked:
+			c.blocked += n
+			c.blockReasons[reason] += n
+		case SignalOutcomeNotExecuted:
+			c.failed += n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]signalConversion, 0, len(byID))
+	for _, c := range byID {
+		out = append(out, *c)
+	}
+	// Worst converters first: they are what the section is for.
+	sort.Slice(out, func(i, j int) bool {
+		if ri, rj := out[i].rate(), out[j].rate(); ri != rj {
+			return ri < rj
+		}
+		return out[i].strategyID < out[j].strategyID
+	})
+	return out, nil
+}
+
+// digestSignalConversion is the signal-to-execution section: per strategy,
+// how many signals executed, were skipped, risk-blocked or failed, with
+// strategies that never executed called out.
+func digestSignalConversion(in weeklyDigestInput) string {
+	if in.db == nil || SignalHistoryRetentionDays(in.cfg) == 0 {
+		return ""
+	}
+	convs, err := in.db.SignalConversion(in.now.Add(-weeklyDigestWindow))
+	if err != nil {
+		fmt.Printf("[WARN] weekly digest: signal conversion query failed: %v\n", err)
+		return ""
+	}
+	if len(convs) == 0 {
+		return ""
+	}
+	return formatSignalConversion(convs)
+}
+
+func formatSignalConversion(convs []signalConversion) string {
+	var sb strings.Builder
+	sb.WriteString("🎯 **Signal → execution (7d)**\n")
+	var never []string
+	for i, c := range convs {
+		if c.executed == 0 && c.signals > 0 {
+			never = append(never, fmt.Sprintf("`%s` (%d)", c.strategyID, c.signals))
+		}
+		if i == weeklyDigestMaxRows {
+			fmt.Fprintf(&sb, "… +%d more\n", len(convs)-weeklyDigestMaxRows)
+			continue
+		}
+		if i > weeklyDigestMaxRows {
+			continue
+		}
+		fmt.Fprintf(&sb, "`%s` %d signals: %d executed (%.0f%%) · %d skipped · %d blocked%s · %d failed\n",
+			c.strategyID, c.signals, c.executed, c.rate()*100, c.skipped, c.blocked, formatBlockReasons(c.blockReasons), c.failed)
+	}
+	if len(never) > 0 {
+		fmt.Fprintf(&sb, "⚠️ Never executed: %s", strings.Join(never, ", "))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// formatBlockReasons renders " (paused 3, var_limit 1)", most frequent first.
+func formatBlockReasons(reasons map[string]int) string {
+	if len(reasons) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(reasons))
+	for k := range reasons {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if reasons[keys[i]] != reasons[keys[j]] {
+			return reasons[keys[i]] > reasons[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s %d", k, reasons[k])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
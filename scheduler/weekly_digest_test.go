@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeeklyDigestConfig_Due(t *testing.T) {
+	cfg := &WeeklyDigestConfig{Enabled: true, Weekday: "Monday", Time: "09:00"}
+	mon := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC) // a Monday
+	if !cfg.due(mon, "") {
+		t.Error("Monday 09:00 should be due")
+	}
+	if cfg.due(mon.Add(-time.Minute), "") {
+		t.Error("before the configured time should not be due")
+	}
+	if cfg.due(mon, "2026-03-16") {
+		t.Error("already posted today should not be due")
+	}
+	if cfg.due(mon.AddDate(0, 0, 1), "") {
+		t.Error("Tuesday should not be due")
+	}
+	var disabled *WeeklyDigestConfig
+	if disabled.due(mon, "") {
+		t.Error("nil config must never be due")
+	}
+}
+
+func TestValidateWeeklyDigestConfig(t *testing.T) {
+	if errs := validateWeeklyDigestConfig(&WeeklyDigestConfig{Enabled: true}); len(errs) != 0 {
+		t.Fatalf("defaults rejected: %v", errs)
+	}
+	errs := validateWeeklyDigestConfig(&WeeklyDigestConfig{Enabled: true, Weekday: "funday", Time: "9am"})
+	joined := strings.Join(errs, "\n")
+	for _, want := range []string{"weekly_digest.weekday", "weekly_digest.time"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in %v", want, errs)
+		}
+	}
+}
+
+func TestDigestSignalConversion(t *testing.T) {
+	db := openTestDB(t)
+	r := newSignalHistoryRecorder(db, 14)
+	now := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now.Add(-time.Hour) }
+	record := func(id string, signal, effective, trades int, reason string) {
+		r.Begin(id, "BTC", signal, 1)
+		if reason != "" {
+			r.Block(id, reason)
+		}
+		r.Finish(id, effective, trades)
+	}
+	record("good", 1, 1, 1, "")
+	record("good", -1, -1, 1, "")
+	record("good", 0, 0, 0, "") // holds are not signals
+	record("stuck", 1, 1, 0, "")
+	record("stuck", 1, 0, 0, "paused")
+	record("stuck", 1, 0, 0, "paused")
+	record("stuck", 1, 0, 0, "var_limit")
+	r.Begin("stuck", "BTC", 1, 1)
+	r.Begin("stuck", "BTC", 0, 1) // previous never finished → failed
+	r.Finish("stuck", 0, 0)
+
+	msg := digestSignalConversion(weeklyDigestInput{cfg: &Config{}, db: db, now: now})
+	for _, want := range []string{
+		"Signal → execution (7d)",
+		"`stuck` 5 signals: 0 executed (0%) · 1 skipped · 3 blocked (paused 2, var_limit 1) · 1 failed",
+		"`good` 2 signals: 2 executed (100%) · 0 skipped · 0 blocked · 0 failed",
+		"Never executed: `stuck` (5)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("missing %q in:\n%s", want, msg)
+		}
+	}
+	if strings.Index(msg, "`stuck`") > strings.Index(msg, "`good`") {
+		t.Error("worst converter should be listed first")
+	}
+
+	full := buildWeeklyDigest(weeklyDigestInput{cfg: &Config{}, db: db, now: now})
+	if !strings.HasPrefix(full, "📬 **Weekly digest** — Mar 09 to Mar 16 UTC") {
+		t.Errorf("digest header: %q", full)
+	}
+	if got := buildWeeklyDigest(weeklyDigestInput{cfg: &Config{}, now: now}); got != "" {
+		t.Errorf("digest with no content should be empty, got %q", got)
+	}
+}
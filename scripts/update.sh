@@ -424,6 +424,8 @@ run_rsync_from() {
         --exclude='go-trader'
         --exclude='go-trader.new'
         --exclude='go-trader.prev'
+        --exclude='go-trader.sha256'
+        --exclude='go-trader.new.sha256'
         --exclude='go-trader.pid'
         --exclude='go-trader-signal.log'
     )
@@ -868,11 +870,19 @@ if [[ ! -x ./go-trader.new ]]; then
     fail "go-trader.new is not executable"
 fi
 echo "[update] built ${ver}: $(stat -c '%s' ./go-trader.new 2>/dev/null || stat -f '%z' ./go-trader.new) bytes"
+# Build-time checksum, labelled with the post-swap name. applyUpgrade refuses
+# to restart into a go-trader that doesn't match it.
+if command -v sha256sum >/dev/null 2>&1; then
+    new_sum=$(sha256sum ./go-trader.new | awk '{print $1}')
+else
+    new_sum=$(shasum -a 256 ./go-trader.new | awk '{print $1}')
+fi
+printf '%s  go-trader\n' "$new_sum" > ./go-trader.new.sha256
 end_phase
 
 begin_phase probe
 if ! ./go-trader.new probe; then
-    rm -f ./go-trader.new
+    rm -f ./go-trader.new ./go-trader.new.sha256
     fail "go-trader.new probe rejected the freshly synced Python — refusing to swap"
 fi
 end_phase
@@ -883,6 +893,7 @@ if [[ -e ./go-trader ]]; then
     mv -f ./go-trader ./go-trader.prev
 fi
 mv -f ./go-trader.new ./go-trader
+mv -f ./go-trader.new.sha256 ./go-trader.sha256
 end_phase
 
 # #1051: refresh the auto-generated agent capability doc from the freshly
@@ -99,7 +99,7 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
                      close_strategies=None,
                      position_side="", position_ctx=None,
                      regime_enabled=False, regime_windows_spec=None, ohlcv_limit=200, regime_atr_window="",
-                     regime_payload_json=None,
+                     regime_payload_json=None, market_regime="",
                      close_params_by_name=None,
                      atr_method="simple",
                      mark_price=0.0):
@@ -192,6 +192,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             injected_payload_json=regime_payload_json,
         )
         strategy_params["regime"] = strategy_regime
+        if market_regime:
+            strategy_params["market_regime"] = market_regime
         if strategy_params_override:
             merged = {**strategy_params_override, **strategy_params}
             strategy_params = merged
@@ -206,6 +208,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             # this is empty (e.g. regime detection disabled mid-position).
             if live_regime:
                 market_ctx["regime"] = live_regime
+            if market_regime:
+                market_ctx["market_regime"] = market_regime
             evaluation = evaluate_open_close(
                 apply_strategy,
                 get_strategy,
@@ -301,6 +305,26 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             "platform": "hyperliquid",
             "timestamp": datetime.now(timezone.utc).isoformat(),
         }
+        # Conviction sizing: a strategy may emit a size_fraction or confidence
+        # column; the scheduler only applies it when the strategy config opts in.
+        for key in ("size_fraction", "confidence"):
+            if key in indicators:
+                output[key] = indicators[key]
+        # Evaluated candle's range so paper stop orders can trigger intrabar.
+        for key in ("high", "low"):
+            try:
+                fval = float(last.get(key))
+            except (TypeError, ValueError):
+                continue
+            if math.isfinite(fval):
+                output["bar_" + key] = round(fval, 6)
+        # Evaluated candle's open time so the scheduler can flag a frozen
+        # candle feed (stale_data).
+        bar_ts = result_df.index[-1] if len(result_df.index) else None
+        if hasattr(bar_ts, "isoformat"):
+            if getattr(bar_ts, "tzinfo", None) is None:
+                bar_ts = bar_ts.replace(tzinfo=timezone.utc)
+            output["bar_time"] = bar_ts.isoformat()
         if decision:
             output.update(decision)
         print(json.dumps(output, cls=SafeEncoder))
@@ -813,7 +837,7 @@ def run_sync_protection(
         sys.exit(1)
 
 
-def run_execute(symbol, side, size, mode, stop_loss_pct=0.0, cancel_oid=0, prev_pos_qty=0.0, margin_mode="", leverage=0, close_full_position=False, account_leverage=0, account_margin_mode=""):
+def run_execute(symbol, side, size, mode, stop_loss_pct=0.0, cancel_oid=0, prev_pos_qty=0.0, margin_mode="", leverage=0, close_full_position=False, account_leverage=0, account_margin_mode="", cloid=""):
     """Place a live market order on Hyperliquid, optionally wrapping it with
     a stop-loss trigger (open) or cancelling a stale SL trigger (close).
 
@@ -935,9 +959,20 @@ def run_execute(symbol, side, size, mode, stop_loss_pct=0.0, cancel_oid=0, prev_
         if close_full_position:
             # Final-tier TP close (#592): close the entire on-chain residual
             # without specifying a size so rounding drift never leaves dust.
-            result = adapter.market_close(symbol, sz=None)
+            result = adapter.market_close(symbol, sz=None, cloid=cloid)
         else:
-            result = adapter.market_open(symbol, is_buy, size)
+            result = adapter.market_open(symbol, is_buy, size, cloid=cloid)
+
+        # A venue rejection (e.g. "Insufficient margin to place order.") comes
+        # back as a status error rather than an exception. Raise it so the
+        # scheduler sees the reason and can classify it (#4958) instead of
+        # reading an empty fill.
+        if isinstance(result, dict):
+            if result.get("status") == "err":
+                raise RuntimeError(f"order rejected: {result.get('response')}")
+            kind, payload = _classify_sl_response(result)
+            if kind == "error":
+                raise RuntimeError(f"order rejected: {payload}")
 
         # Extract fill info from SDK response structure:
         # {"status": "ok", "response": {"type": "order", "data": {"statuses": [...]}}}
@@ -945,6 +980,10 @@ def run_execute(symbol, side, size, mode, stop_loss_pct=0.0, cancel_oid=0, prev_
         try:
             statuses = result.get("response", {}).get("data", {}).get("statuses", [])
             if statuses:
+                # A "resting" status means (part of) the order is on the book
+                # rather than filled; the scheduler tracks it and books later
+                # fills (#4956).
+                resting = statuses[0].get("resting")
                 filled = statuses[0].get("filled", {})
                 fill = {
                     "avg_px": float(filled.get("avgPx", 0) or 0),
@@ -959,6 +998,8 @@ def run_execute(symbol, side, size, mode, stop_loss_pct=0.0, cancel_oid=0, prev_
                 fee = filled.get("fee")
                 if fee is not None:
                     fill["fee"] = float(fee)
+                if isinstance(resting, dict) and resting.get("oid") is not None:
+                    fill["resting_oid"] = int(resting["oid"])
         except Exception:
             pass
 
@@ -1343,6 +1384,49 @@ def run_limit_open(symbol, side, size, limit_px, mode, tif="Alo",
         sys.exit(1)
 
 
+def run_order_status(symbol, cloid, mode, since_ms=0):
+    """Report what became of an order placed with client order ID ``cloid`` (#4916).
+
+    Emits ``{"order": {status, oid, filled_size, avg_px}}`` where ``status`` is
+    HL's order status ("filled", "open", "canceled", ...) or "not_found" when
+    HL never saw the ID. The fill fields sum the on-chain fills for the OID so
+    a canceled order's partial fill is still reported. Any lookup failure is a
+    top-level ``error`` — the scheduler then keeps the intent unresolved.
+    """
+    if mode != "live":
+        print(json.dumps({"error": "--order-status requires --mode=live"}, cls=SafeEncoder))
+        sys.exit(1)
+    try:
+        from adapter import HyperliquidExchangeAdapter
+        adapter = HyperliquidExchangeAdapter()
+
+        if since_ms <= 0:
+            since_ms = int(time.time() * 1000) - 7 * 24 * 60 * 60 * 1000
+
+        order = adapter.query_order_by_cloid(cloid)
+        oid = int(order.get("oid", 0) or 0)
+        entry = {"status": order.get("status", "")}
+        if oid > 0:
+            entry["oid"] = str(oid)
+            summary = adapter.fills_summary_by_oid(oid, since_ms)
+            entry["filled_size"] = float(summary.get("filled_size", 0) or 0)
+            entry["avg_px"] = float(summary.get("avg_px", 0) or 0)
+        print(json.dumps({
+            "platform": "hyperliquid",
+            "symbol": symbol,
+            "timestamp": datetime.now(timezone.utc).isoformat(),
+            "order": entry,
+        }, cls=SafeEncoder))
+    except Exception as e:
+        traceback.print_exc(file=sys.stderr)
+        print(json.dumps({
+            "platform": "hyperliquid",
+            "timestamp": datetime.now(timezone.utc).isoformat(),
+            "error": str(e),
+        }, cls=SafeEncoder))
+        sys.exit(1)
+
+
 def run_limit_status(symbol, oids, mode, since_ms=0):
     """Report resting/fill status for one or more limit-order OIDs (#883).
 
@@ -1586,6 +1670,8 @@ def main():
                             help="on-chain leverage observed in Go's clearinghouseState snapshot; when paired with --account-margin-mode lets Python skip the duplicate get_position_leverage /info call (#768)")
         parser.add_argument("--account-margin-mode", default="",
                             help="on-chain margin mode observed in Go's clearinghouseState snapshot; see --account-leverage (#768)")
+        parser.add_argument("--cloid", default="",
+                            help="client order ID (0x + 32 hex) from the scheduler's order-intent journal, for crash recovery lookups (#4916)")
         parser.add_argument("--probe-only", action="store_true",
                             help="Startup compatibility probe (PR #769): validate execute-mode argv shape — including --account-leverage / --account-margin-mode — and exit 0 without trading.")
         args = parser.parse_args()
@@ -1600,7 +1686,25 @@ def main():
                     margin_mode=args.margin_mode, leverage=args.leverage,
                     close_full_position=args.close_full_position,
                     account_leverage=args.account_leverage,
-                    account_margin_mode=args.account_margin_mode)
+                    account_margin_mode=args.account_margin_mode,
+                    cloid=args.cloid)
+    elif "--order-status" in sys.argv:
+        # Client-order-ID lookup for the startup order-intent reconcile:
+        #   --order-status --symbol=BTC --cloid=0x... [--since-ms=N] [--mode=live] (#4916)
+        import argparse
+        parser = argparse.ArgumentParser()
+        parser.add_argument("--order-status", action="store_true")
+        parser.add_argument("--symbol", required=True)
+        parser.add_argument("--cloid", required=True)
+        parser.add_argument("--since-ms", type=int, default=0,
+                            help="userFills lookback floor in epoch ms; 0 = default 7-day window")
+        parser.add_argument("--mode", default="live")
+        parser.add_argument("--probe-only", action="store_true",
+                            help="Startup compatibility probe (#4916): validate argv shape and exit 0.")
+        args = parser.parse_args()
+        if args.probe_only:
+            sys.exit(0)
+        run_order_status(args.symbol, args.cloid, args.mode, since_ms=args.since_ms)
     elif "--limit-open" in sys.argv:
         # Resting limit-order open: --limit-open --symbol=BTC --side=buy
         #   --size=0.01 --limit-price=58000 [--tif=Alo] [--mode=live] (#883)
@@ -1693,6 +1797,8 @@ def main():
         # #879: precomputed global-store regime payload; presence (even empty)
         # disables inline regime computation.
         parser.add_argument("--regime-payload-json", default=None)
+        # #4926: Go-side per-asset market regime ("trending_up/vol_high").
+        parser.add_argument("--market-regime", default="")
         # #1277: ATR smoothing method for the standard_atr surface (EntryATR
         # stamping + market_ctx["atr"]). Forwarded by Go from the resolved
         # atr_method config; "simple" is the frozen legacy default.
@@ -1735,6 +1841,7 @@ def main():
             ohlcv_limit=args.ohlcv_limit,
             regime_atr_window=args.regime_atr_window,
             regime_payload_json=args.regime_payload_json,
+            market_regime=args.market_regime,
             close_params_by_name=close_params_by_name,
             atr_method=args.atr_method,
             mark_price=args.mark_price,
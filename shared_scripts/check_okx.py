@@ -91,7 +91,7 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
                      open_strategy=None, close_strategies=None,
                      position_side="", position_ctx=None,
                      regime_enabled=False, regime_windows_spec=None, ohlcv_limit=200, regime_atr_window="",
-                     regime_payload_json=None,
+                     regime_payload_json=None, market_regime="",
                      close_params_by_name=None,
                      atr_method="simple"):
     """Run strategy signal check using OKX OHLCV data."""
@@ -170,6 +170,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             injected_payload_json=regime_payload_json,
         )
         strategy_params["regime"] = strategy_regime
+        if market_regime:
+            strategy_params["market_regime"] = market_regime
         if strategy_params_override:
             merged = {**strategy_params_override, **strategy_params}
             strategy_params = merged
@@ -182,6 +184,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             # #733: live regime label for tiered_tp_atr_live_regime evaluator.
             if live_regime:
                 market_ctx["regime"] = live_regime
+            if market_regime:
+                market_ctx["market_regime"] = market_regime
             evaluation = evaluate_open_close(
                 apply_strategy,
                 get_strategy,
@@ -275,6 +279,18 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             "platform": "okx",
             "timestamp": datetime.now(timezone.utc).isoformat(),
         }
+        # Conviction sizing: a strategy may emit a size_fraction or confidence
+        # column; the scheduler only applies it when the strategy config opts in.
+        for key in ("size_fraction", "confidence"):
+            if key in indicators:
+                output[key] = indicators[key]
+        # Evaluated candle's open time so the scheduler can flag a frozen
+        # candle feed (stale_data).
+        bar_ts = result_df.index[-1] if len(result_df.index) else None
+        if hasattr(bar_ts, "isoformat"):
+            if getattr(bar_ts, "tzinfo", None) is None:
+                bar_ts = bar_ts.replace(tzinfo=timezone.utc)
+            output["bar_time"] = bar_ts.isoformat()
         if decision:
             output.update(decision)
         print(json.dumps(output))
@@ -297,8 +313,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
         sys.exit(1)
 
 
-def run_execute(symbol, side, size, mode, inst_type="swap"):
-    """Place a live market order on OKX."""
+def run_execute(symbol, side, size, mode, inst_type="swap", client_order_id=""):
+    """Place a live market order on OKX. ``client_order_id`` is sent as clOrdId (#4916)."""
     if mode != "live":
         print(json.dumps({"error": "--execute requires --mode=live"}))
         sys.exit(1)
@@ -308,7 +324,7 @@ def run_execute(symbol, side, size, mode, inst_type="swap"):
         adapter = OKXExchangeAdapter()
 
         is_buy = side.lower() == "buy"
-        result = adapter.market_open(symbol, is_buy, size, inst_type=inst_type)
+        result = adapter.market_open(symbol, is_buy, size, inst_type=inst_type, client_order_id=client_order_id)
 
         # Extract fill info from ccxt response structure
         fill = {}
@@ -348,6 +364,45 @@ def run_execute(symbol, side, size, mode, inst_type="swap"):
         sys.exit(1)
 
 
+def run_order_status(symbol, client_order_id, mode, inst_type="swap"):
+    """Report what became of the order placed with ``client_order_id`` (#4916).
+
+    Emits ``{"order": {status, oid, filled_size, avg_px}}`` where ``status`` is
+    the ccxt order status ("closed", "open", "canceled") or "not_found". Any
+    lookup failure is a top-level ``error`` — the scheduler then keeps the
+    intent unresolved.
+    """
+    if mode != "live":
+        print(json.dumps({"error": "--order-status requires --mode=live"}))
+        sys.exit(1)
+
+    try:
+        from adapter import OKXExchangeAdapter
+        adapter = OKXExchangeAdapter()
+
+        order = adapter.fetch_order_by_client_id(symbol, client_order_id, inst_type=inst_type)
+        entry = {"status": order.get("status", "")}
+        if order.get("oid"):
+            entry["oid"] = order["oid"]
+            entry["filled_size"] = float(order.get("filled", 0) or 0)
+            entry["avg_px"] = float(order.get("average", 0) or 0)
+        print(json.dumps({
+            "platform": "okx",
+            "symbol": symbol,
+            "timestamp": datetime.now(timezone.utc).isoformat(),
+            "order": entry,
+        }))
+
+    except Exception as e:
+        traceback.print_exc(file=sys.stderr)
+        print(json.dumps({
+            "platform": "okx",
+            "timestamp": datetime.now(timezone.utc).isoformat(),
+            "error": str(e),
+        }))
+        sys.exit(1)
+
+
 def main():
     if "--execute" in sys.argv:
         # Execute mode: --execute --symbol=BTC --side=buy|sell --size=0.01 [--mode=live] [--inst-type=spot|swap]
@@ -359,8 +414,30 @@ def main():
         parser.add_argument("--size", type=float, required=True)
         parser.add_argument("--mode", default="live")
         parser.add_argument("--inst-type", default="swap", choices=["spot", "swap"])
+        parser.add_argument("--client-order-id", default="",
+                            help="sent as clOrdId; the scheduler's order-intent key for crash recovery lookups (#4916)")
+        parser.add_argument("--probe-only", action="store_true",
+                            help="Startup compatibility probe (#4916): validate execute-mode argv shape and exit 0 without trading.")
+        args = parser.parse_args()
+        if args.probe_only:
+            sys.exit(0)
+        run_execute(args.symbol, args.side, args.size, args.mode, args.inst_type, client_order_id=args.client_order_id)
+    elif "--order-status" in sys.argv:
+        # Client-order-ID lookup for the startup order-intent reconcile:
+        #   --order-status --symbol=BTC --client-order-id=<key> [--inst-type=spot|swap] [--mode=live] (#4916)
+        import argparse
+        parser = argparse.ArgumentParser()
+        parser.add_argument("--order-status", action="store_true")
+        parser.add_argument("--symbol", required=True)
+        parser.add_argument("--client-order-id", required=True)
+        parser.add_argument("--mode", default="live")
+        parser.add_argument("--inst-type", default="swap", choices=["spot", "swap"])
+        parser.add_argument("--probe-only", action="store_true",
+                            help="Startup compatibility probe (#4916): validate argv shape and exit 0.")
         args = parser.parse_args()
-        run_execute(args.symbol, args.side, args.size, args.mode, args.inst_type)
+        if args.probe_only:
+            sys.exit(0)
+        run_order_status(args.symbol, args.client_order_id, args.mode, inst_type=args.inst_type)
     else:
         # Signal check mode: <strategy> <symbol> <timeframe> [--mode=paper|live] [--htf-filter] [--inst-type=spot|swap]
         import argparse
@@ -377,6 +454,8 @@ def main():
         # #879: precomputed global-store regime payload; presence (even empty)
         # disables inline regime computation.
         parser.add_argument("--regime-payload-json", default=None)
+        # #4926: Go-side per-asset market regime ("trending_up/vol_high").
+        parser.add_argument("--market-regime", default="")
         # #1277: ATR smoothing method for the standard_atr surface (EntryATR
         # stamping + market_ctx["atr"]). Forwarded by Go from the resolved
         # atr_method config; "simple" is the frozen legacy default.
@@ -417,6 +496,7 @@ def main():
             ohlcv_limit=args.ohlcv_limit,
             regime_atr_window=args.regime_atr_window,
             regime_payload_json=args.regime_payload_json,
+            market_regime=args.market_regime,
             close_params_by_name=close_params_by_name,
             atr_method=args.atr_method,
         )
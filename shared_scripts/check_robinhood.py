@@ -119,7 +119,7 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
                      close_strategies=None,
                      position_side="", position_ctx=None,
                      regime_enabled=False, regime_windows_spec=None, ohlcv_limit=200, regime_atr_window="",
-                     regime_payload_json=None,
+                     regime_payload_json=None, market_regime="",
                      close_params_by_name=None,
                      atr_method="simple"):
     """Run strategy signal check using yfinance OHLCV data."""
@@ -181,6 +181,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
         )
         strategy_params = (strategy_params or {})
         strategy_params["regime"] = strategy_regime
+        if market_regime:
+            strategy_params["market_regime"] = market_regime
         decision = None
         if open_close_enabled:
             market_ctx = {"mark_price": float(df["close"].iloc[-1])}
@@ -190,6 +192,8 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             # #733: live regime label for tiered_tp_atr_live_regime evaluator.
             if live_regime:
                 market_ctx["regime"] = live_regime
+            if market_regime:
+                market_ctx["market_regime"] = market_regime
             evaluation = evaluate_open_close(
                 apply_strategy,
                 get_strategy,
@@ -277,6 +281,13 @@ def run_signal_check(strategy_name, symbol, timeframe, mode, htf_filter_enabled=
             "platform": "robinhood",
             "timestamp": datetime.now(timezone.utc).isoformat(),
         }
+        # Evaluated candle's open time so the scheduler can flag a frozen
+        # candle feed (stale_data).
+        bar_ts = result_df.index[-1] if len(result_df.index) else None
+        if hasattr(bar_ts, "isoformat"):
+            if getattr(bar_ts, "tzinfo", None) is None:
+                bar_ts = bar_ts.replace(tzinfo=timezone.utc)
+            output["bar_time"] = bar_ts.isoformat()
         if decision:
             output.update(decision)
         print(json.dumps(output))
@@ -387,6 +398,8 @@ def main():
         # #879: precomputed global-store regime payload; presence (even empty)
         # disables inline regime computation.
         parser.add_argument("--regime-payload-json", default=None)
+        # #4926: Go-side per-asset market regime ("trending_up/vol_high").
+        parser.add_argument("--market-regime", default="")
         # #1277: ATR smoothing method for the standard_atr surface (EntryATR
         # stamping + market_ctx["atr"]). Forwarded by Go from the resolved
         # atr_method config; "simple" is the frozen legacy default.
@@ -426,6 +439,7 @@ def main():
             ohlcv_limit=args.ohlcv_limit,
             regime_atr_window=args.regime_atr_window,
             regime_payload_json=args.regime_payload_json,
+            market_regime=args.market_regime,
             close_params_by_name=close_params_by_name,
             atr_method=args.atr_method,
         )
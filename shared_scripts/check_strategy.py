@@ -81,6 +81,8 @@ def main():
     # #879: precomputed global-store regime payload; presence (even empty)
     # disables inline regime computation. None when the flag is absent.
     regime_payload_json = _arg_value("--regime-payload-json")
+    # #4926: Go-side per-asset market regime ("trending_up/vol_high").
+    market_regime = (_arg_value("--market-regime", "") or "").strip()
     # #1277: ATR smoothing method for the standard_atr surface (EntryATR
     # stamping + market_ctx["atr"]). Forwarded by Go from the resolved
     # atr_method config; "simple" is the frozen legacy default. Fails loud on
@@ -128,7 +130,7 @@ def main():
             "--position-regime",
             "--regime-windows-spec-json", "--ohlcv-limit",
             "--regime-atr-window", "--regime-directional-window",
-            "--regime-payload-json", "--atr-method",
+            "--regime-payload-json", "--atr-method", "--market-regime",
         ):
             skip_next = True
             continue
@@ -232,6 +234,8 @@ def main():
         )
         strategy_params = (strategy_params or {})
         strategy_params["regime"] = strategy_regime
+        if market_regime:
+            strategy_params["market_regime"] = market_regime
 
         decision = None
         if open_close_enabled:
@@ -242,6 +246,8 @@ def main():
             # #733: live regime label for tiered_tp_atr_live_regime evaluator.
             if live_regime:
                 market_ctx["regime"] = live_regime
+            if market_regime:
+                market_ctx["market_regime"] = market_regime
             evaluation = evaluate_open_close(
                 apply_strategy,
                 get_strategy,
@@ -318,6 +324,27 @@ def main():
             "regime": stdout_regime,
             "timestamp": datetime.now(timezone.utc).isoformat()
         }
+        # Conviction sizing: a strategy may emit a size_fraction or confidence
+        # column; the scheduler only applies it when the strategy config opts in.
+        for key in ("size_fraction", "confidence"):
+            if key in indicators:
+                output[key] = indicators[key]
+        # Evaluated candle's range for the paper order simulator (resting
+        # limit entries fill only when a later bar crosses them).
+        for key in ("high", "low"):
+            try:
+                fval = float(last.get(key))
+            except (TypeError, ValueError):
+                continue
+            if math.isfinite(fval):
+                output["bar_" + key] = round(fval, 2)
+        # Evaluated candle's open time so the scheduler can flag a frozen
+        # candle feed (stale_data).
+        bar_ts = result_df.index[-1] if len(result_df.index) else None
+        if hasattr(bar_ts, "isoformat"):
+            if getattr(bar_ts, "tzinfo", None) is None:
+                bar_ts = bar_ts.replace(tzinfo=timezone.utc)
+            output["bar_time"] = bar_ts.isoformat()
         if decision:
             output.update(decision)
         print(json.dumps(output))
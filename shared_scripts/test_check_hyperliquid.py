@@ -223,6 +223,18 @@ class TestFillExtraction:
         result = self._run_execute_with_mock_response(sdk_response)
         assert result["execution"]["fill"] == {}
 
+    def test_status_error_is_raised_as_rejection(self):
+        """#4958: a per-order status error (e.g. insufficient margin) must fail
+        the execute with the venue's reason, not emit an empty fill."""
+        sdk_response = {
+            "status": "ok",
+            "response": {"type": "order", "data": {"statuses": [
+                {"error": "Insufficient margin to place order. asset=0"},
+            ]}},
+        }
+        with pytest.raises(SystemExit):
+            self._run_execute_with_mock_response(sdk_response)
+
 
 class TestMarginMode:
     """#486: run_execute calls update_leverage with isolated/cross before placing